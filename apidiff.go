@@ -0,0 +1,197 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"go.uber.org/thriftrw/internal/apidiff"
+	"go.uber.org/thriftrw/version"
+
+	"github.com/jessevdk/go-flags"
+)
+
+// Status code with which "thriftrw apidiff" exits if it found breaking
+// changes. Other failures exit with status 1.
+const apidiffBreakingExitCode = 2
+
+// Import path prefix for code generated by "thriftrw apidiff". The code is
+// never compiled so this only needs to be consistent between versions.
+const apidiffPackagePrefix = "thriftrw.apidiff"
+
+type apidiffOptions struct {
+	Manifest      string `long:"manifest" short:"m" value-name:"FILE" description:"Compare against the API recorded in this manifest."`
+	WriteManifest bool   `long:"write-manifest" short:"w" description:"Record the API of the generated code to the --manifest file instead of comparing against it."`
+	OldThriftRW   string `long:"old-thriftrw" value-name:"PATH" description:"Compare against the code generated by this thriftrw executable."`
+
+	ThriftRoot string `long:"thrift-root" value-name:"DIR" description:"Directory whose descendants contain all Thrift files. By default, this is the deepest common ancestor directory of the Thrift files."`
+	NoRecurse  bool   `long:"no-recurse" description:"Don't compare code generated for included Thrift files."`
+}
+
+// doAPIDiff implements the "thriftrw apidiff" command.
+func doAPIDiff(args []string) error {
+	var opts apidiffOptions
+
+	parser := flags.NewParser(&opts, flags.Default)
+	parser.Name = "thriftrw apidiff"
+	parser.Usage = "[OPTIONS] FILE\n\n" +
+		"Reports changes to the Go API of the code generated for FILE by this version of\n" +
+		"thriftrw, compared to the API recorded in a manifest or the code generated by\n" +
+		"another thriftrw executable. Exits with status 2 if breaking changes were found."
+
+	files, err := parser.ParseArgs(args)
+	if err != nil {
+		return nil // message already printed by go-flags
+	}
+
+	if len(files) != 1 {
+		var buffer bytes.Buffer
+		parser.WriteHelp(&buffer)
+		return errors.New(buffer.String())
+	}
+
+	return diffAPI(files[0], &opts, os.Stdout)
+}
+
+// diffAPI generates code for the given Thrift file and compares its API
+// against the API specified in the options, writing the changes to the given
+// writer.
+func diffAPI(file string, opts *apidiffOptions, out io.Writer) error {
+	if (opts.Manifest == "") == (opts.OldThriftRW == "") {
+		return errors.New("Exactly one of --manifest and --old-thriftrw must be provided")
+	}
+	if opts.WriteManifest && opts.Manifest == "" {
+		return errors.New("--write-manifest requires --manifest")
+	}
+
+	dir, err := ioutil.TempDir("", "thriftrw-apidiff")
+	if err != nil {
+		return fmt.Errorf("Could not create a temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	newDir := filepath.Join(dir, "new")
+	err = generateFile(file, genOptions{
+		OutputDirectory: newDir,
+		PackagePrefix:   apidiffPackagePrefix,
+		ThriftRoot:      opts.ThriftRoot,
+		NoRecurse:       opts.NoRecurse,
+	})
+	if err != nil {
+		return err
+	}
+
+	newAPI, err := apidiff.Load(newDir)
+	if err != nil {
+		return fmt.Errorf("Could not load the API of the generated code: %v", err)
+	}
+
+	if opts.WriteManifest {
+		return writeAPIManifest(opts.Manifest, &apidiff.Manifest{
+			Version: version.Version,
+			API:     newAPI,
+		})
+	}
+
+	var oldAPI apidiff.API
+	if opts.Manifest != "" {
+		oldAPI, err = readAPIManifest(opts.Manifest)
+	} else {
+		oldAPI, err = generateOldAPI(file, opts, filepath.Join(dir, "old"))
+	}
+	if err != nil {
+		return err
+	}
+
+	var breaking int
+	for _, c := range apidiff.Compare(oldAPI, newAPI) {
+		if c.Breaking() {
+			breaking++
+		}
+		if _, err := fmt.Fprintln(out, c); err != nil {
+			return err
+		}
+	}
+
+	if breaking > 0 {
+		return exitError{
+			Code:    apidiffBreakingExitCode,
+			Message: fmt.Sprintf("Found %d breaking API changes", breaking),
+		}
+	}
+	return nil
+}
+
+func readAPIManifest(path string) (apidiff.API, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Could not open manifest %q: %v", path, err)
+	}
+	defer f.Close()
+
+	m, err := apidiff.ReadManifest(f)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read manifest %q: %v", path, err)
+	}
+	return m.API, nil
+}
+
+func writeAPIManifest(path string, m *apidiff.Manifest) error {
+	var buff bytes.Buffer
+	if err := apidiff.WriteManifest(&buff, m); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, buff.Bytes(), 0644); err != nil {
+		return fmt.Errorf("Could not write manifest %q: %v", path, err)
+	}
+	return nil
+}
+
+// generateOldAPI generates code for the given Thrift file with the
+// executable specified in the options and returns its API.
+func generateOldAPI(file string, opts *apidiffOptions, dir string) (apidiff.API, error) {
+	args := []string{"--out", dir, "--pkg-prefix", apidiffPackagePrefix}
+	if opts.ThriftRoot != "" {
+		args = append(args, "--thrift-root", opts.ThriftRoot)
+	}
+	if opts.NoRecurse {
+		args = append(args, "--no-recurse")
+	}
+	args = append(args, file)
+
+	cmd := exec.Command(opts.OldThriftRW, args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("Failed to generate code with %q: %v\n%s", opts.OldThriftRW, err, output)
+	}
+
+	api, err := apidiff.Load(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Could not load the API of the code generated by %q: %v", opts.OldThriftRW, err)
+	}
+	return api, nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffAPIManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-apidiff-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "service.thrift")
+	manifest := filepath.Join(dir, "api.json")
+
+	writeThrift := func(src string) {
+		require.NoError(t, ioutil.WriteFile(file, []byte(src), 0644))
+	}
+
+	writeThrift("struct User {\n  1: required string name\n  2: optional string email\n}\n")
+
+	var out bytes.Buffer
+	require.NoError(t, diffAPI(file, &apidiffOptions{
+		Manifest:      manifest,
+		WriteManifest: true,
+	}, &out))
+	assert.Empty(t, out.String())
+
+	t.Run("unchanged", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, diffAPI(file, &apidiffOptions{Manifest: manifest}, &out))
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("added", func(t *testing.T) {
+		writeThrift("struct User {\n  1: required string name\n  2: optional string email\n  3: optional i32 age\n}\n")
+
+		var out bytes.Buffer
+		require.NoError(t, diffAPI(file, &apidiffOptions{Manifest: manifest}, &out))
+		assert.Contains(t, out.String(), "added service.User.Age: *int32\n")
+		assert.Contains(t, out.String(), "added service.User.GetAge: (*User) func() int32\n")
+		assert.NotContains(t, out.String(), "removed")
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		writeThrift("struct User {\n  1: required string name\n}\n")
+
+		var out bytes.Buffer
+		err := diffAPI(file, &apidiffOptions{Manifest: manifest}, &out)
+		if assert.Error(t, err) {
+			exitErr, ok := err.(exitError)
+			require.True(t, ok, "expected an exitError, got %T", err)
+			assert.Equal(t, apidiffBreakingExitCode, exitErr.Code)
+		}
+		assert.Contains(t, out.String(), "removed service.User.Email: *string\n")
+		assert.Contains(t, out.String(), "removed service.User.GetEmail: (*User) func() string\n")
+	})
+}
+
+func TestDiffAPIOptionErrors(t *testing.T) {
+	tests := []struct {
+		desc string
+		give apidiffOptions
+		want string
+	}{
+		{
+			desc: "neither",
+			want: "Exactly one of --manifest and --old-thriftrw must be provided",
+		},
+		{
+			desc: "both",
+			give: apidiffOptions{Manifest: "api.json", OldThriftRW: "thriftrw"},
+			want: "Exactly one of --manifest and --old-thriftrw must be provided",
+		},
+		{
+			desc: "write without manifest",
+			give: apidiffOptions{OldThriftRW: "thriftrw", WriteManifest: true},
+			want: "--write-manifest requires --manifest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := diffAPI("foo.thrift", &tt.give, ioutil.Discard)
+			if assert.Error(t, err) {
+				assert.Equal(t, tt.want, err.Error())
+			}
+		})
+	}
+}
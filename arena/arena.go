@@ -0,0 +1,216 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package arena provides batch allocation for values decoded by code
+// generated with the --allocator option.
+//
+// Code generated with --allocator has a FromWireArena method on every struct
+// in addition to FromWire. FromWireArena allocates the structs and optional
+// primitive fields of the decoded object graph from the given Arena rather
+// than allocating each of them separately on the heap.
+//
+// 	var a arena.Arena
+// 	var req myservice.Request
+// 	if err := req.FromWireArena(value, &a); err != nil {
+// 		return err
+// 	}
+//
+// Values are allocated in slabs which hold many values of the same type. The
+// values remain valid for as long as they are referenced; a slab is collected
+// once nothing refers to any of its values, so an object graph decoded into
+// an Arena is collected in a few large pieces rather than value by value.
+//
+// Slices and maps are still allocated with make.
+package arena
+
+import "go.uber.org/thriftrw/uuid"
+
+// DefaultSlabSize is the number of values of each type allocated together
+// if an Arena does not specify a SlabSize.
+const DefaultSlabSize = 64
+
+// Arena allocates values in slabs.
+//
+// The zero value is ready to use. A nil Arena is valid and allocates every
+// value separately on the heap. An Arena is not safe for concurrent use.
+type Arena struct {
+	// SlabSize is the number of values of each type allocated together.
+	// Defaults to DefaultSlabSize.
+	SlabSize int
+
+	bools    []bool
+	int8s    []int8
+	int16s   []int16
+	int32s   []int32
+	int64s   []int64
+	float64s []float64
+	strings  []string
+	uuids    []uuid.UUID
+
+	slabs map[*SlabKey]interface{}
+}
+
+// SlabCapacity returns the number of values that should be allocated
+// together when a new slab is needed.
+func (a *Arena) SlabCapacity() int {
+	if a == nil || a.SlabSize <= 0 {
+		return DefaultSlabSize
+	}
+	return a.SlabSize
+}
+
+// Reset releases the Arena's references to its slabs. Values allocated
+// before the call remain valid; values allocated after it go into new
+// slabs.
+func (a *Arena) Reset() {
+	*a = Arena{SlabSize: a.SlabSize}
+}
+
+// Bool allocates a bool with the given value.
+func (a *Arena) Bool(x bool) *bool {
+	if a == nil {
+		return &x
+	}
+	if len(a.bools) == cap(a.bools) {
+		a.bools = make([]bool, 0, a.SlabCapacity())
+	}
+	a.bools = append(a.bools, x)
+	return &a.bools[len(a.bools)-1]
+}
+
+// Int8 allocates an int8 with the given value.
+func (a *Arena) Int8(x int8) *int8 {
+	if a == nil {
+		return &x
+	}
+	if len(a.int8s) == cap(a.int8s) {
+		a.int8s = make([]int8, 0, a.SlabCapacity())
+	}
+	a.int8s = append(a.int8s, x)
+	return &a.int8s[len(a.int8s)-1]
+}
+
+// Int16 allocates an int16 with the given value.
+func (a *Arena) Int16(x int16) *int16 {
+	if a == nil {
+		return &x
+	}
+	if len(a.int16s) == cap(a.int16s) {
+		a.int16s = make([]int16, 0, a.SlabCapacity())
+	}
+	a.int16s = append(a.int16s, x)
+	return &a.int16s[len(a.int16s)-1]
+}
+
+// Int32 allocates an int32 with the given value.
+func (a *Arena) Int32(x int32) *int32 {
+	if a == nil {
+		return &x
+	}
+	if len(a.int32s) == cap(a.int32s) {
+		a.int32s = make([]int32, 0, a.SlabCapacity())
+	}
+	a.int32s = append(a.int32s, x)
+	return &a.int32s[len(a.int32s)-1]
+}
+
+// Int64 allocates an int64 with the given value.
+func (a *Arena) Int64(x int64) *int64 {
+	if a == nil {
+		return &x
+	}
+	if len(a.int64s) == cap(a.int64s) {
+		a.int64s = make([]int64, 0, a.SlabCapacity())
+	}
+	a.int64s = append(a.int64s, x)
+	return &a.int64s[len(a.int64s)-1]
+}
+
+// Float64 allocates a float64 with the given value.
+func (a *Arena) Float64(x float64) *float64 {
+	if a == nil {
+		return &x
+	}
+	if len(a.float64s) == cap(a.float64s) {
+		a.float64s = make([]float64, 0, a.SlabCapacity())
+	}
+	a.float64s = append(a.float64s, x)
+	return &a.float64s[len(a.float64s)-1]
+}
+
+// String allocates a string with the given value.
+func (a *Arena) String(x string) *string {
+	if a == nil {
+		return &x
+	}
+	if len(a.strings) == cap(a.strings) {
+		a.strings = make([]string, 0, a.SlabCapacity())
+	}
+	a.strings = append(a.strings, x)
+	return &a.strings[len(a.strings)-1]
+}
+
+// UUID allocates a uuid.UUID with the given value.
+func (a *Arena) UUID(x uuid.UUID) *uuid.UUID {
+	if a == nil {
+		return &x
+	}
+	if len(a.uuids) == cap(a.uuids) {
+		a.uuids = make([]uuid.UUID, 0, a.SlabCapacity())
+	}
+	a.uuids = append(a.uuids, x)
+	return &a.uuids[len(a.uuids)-1]
+}
+
+// SlabKey identifies the slab of values of a single type in an Arena.
+//
+// Generated code declares a SlabKey for every struct type it allocates from
+// an Arena and stores a pointer to a slice of that type under it.
+type SlabKey struct {
+	name string
+}
+
+// NewSlabKey builds a new SlabKey. The name is used for debugging only;
+// keys are compared by identity.
+func NewSlabKey(name string) *SlabKey {
+	return &SlabKey{name: name}
+}
+
+// String returns the name of the SlabKey.
+func (k *SlabKey) String() string {
+	return k.name
+}
+
+// Slab returns the slab stored under the given key or nil if the Arena does
+// not have one.
+func (a *Arena) Slab(key *SlabKey) interface{} {
+	if a == nil {
+		return nil
+	}
+	return a.slabs[key]
+}
+
+// SetSlab stores a slab under the given key.
+func (a *Arena) SetSlab(key *SlabKey, slab interface{}) {
+	if a.slabs == nil {
+		a.slabs = make(map[*SlabKey]interface{})
+	}
+	a.slabs[key] = slab
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package arena
+
+import (
+	"testing"
+
+	"go.uber.org/thriftrw/uuid"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNilArena(t *testing.T) {
+	var a *Arena
+	assert.Equal(t, true, *a.Bool(true))
+	assert.Equal(t, int8(1), *a.Int8(1))
+	assert.Equal(t, int16(2), *a.Int16(2))
+	assert.Equal(t, int32(3), *a.Int32(3))
+	assert.Equal(t, int64(4), *a.Int64(4))
+	assert.Equal(t, 5.0, *a.Float64(5))
+	assert.Equal(t, "six", *a.String("six"))
+	assert.Equal(t, uuid.UUID{7}, *a.UUID(uuid.UUID{7}))
+	assert.Equal(t, DefaultSlabSize, a.SlabCapacity())
+	assert.Nil(t, a.Slab(NewSlabKey("foo")))
+}
+
+func TestArenaSlabs(t *testing.T) {
+	a := Arena{SlabSize: 2}
+
+	x := a.Int32(1)
+	y := a.Int32(2)
+	z := a.Int32(3)
+	assert.Equal(t, []int32{1, 2, 3}, []int32{*x, *y, *z})
+
+	// The first two values share a slab. The third value starts a new one
+	// without affecting the values allocated before it.
+	assert.Equal(t, 2, cap(a.int32s))
+	assert.Equal(t, 1, len(a.int32s))
+	*y = 42
+	assert.Equal(t, int32(1), *x)
+	assert.Equal(t, int32(3), *z)
+}
+
+func TestArenaReset(t *testing.T) {
+	a := Arena{SlabSize: 4}
+	s := a.String("hello")
+	key := NewSlabKey("foo")
+	a.SetSlab(key, new([]int))
+
+	a.Reset()
+	assert.Equal(t, "hello", *s)
+	assert.Nil(t, a.Slab(key))
+	assert.Equal(t, 4, a.SlabCapacity())
+	assert.Empty(t, a.strings)
+}
+
+func TestSlabKey(t *testing.T) {
+	var a Arena
+	k1 := NewSlabKey("foo")
+	k2 := NewSlabKey("foo")
+	assert.Equal(t, "foo", k1.String())
+
+	a.SetSlab(k1, "bar")
+	assert.Equal(t, "bar", a.Slab(k1))
+	assert.Nil(t, a.Slab(k2), "keys must be compared by identity")
+}
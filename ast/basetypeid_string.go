@@ -24,9 +24,9 @@ package ast
 
 import "fmt"
 
-const _BaseTypeID_name = "BoolTypeIDI8TypeIDI16TypeIDI32TypeIDI64TypeIDDoubleTypeIDStringTypeIDBinaryTypeID"
+const _BaseTypeID_name = "BoolTypeIDI8TypeIDI16TypeIDI32TypeIDI64TypeIDDoubleTypeIDStringTypeIDBinaryTypeIDUUIDTypeID"
 
-var _BaseTypeID_index = [...]uint8{0, 10, 18, 27, 36, 45, 57, 69, 81}
+var _BaseTypeID_index = [...]uint8{0, 10, 18, 27, 36, 45, 57, 69, 81, 91}
 
 func (i BaseTypeID) String() string {
 	i -= 1
@@ -44,6 +44,10 @@ type Constant struct {
 	Type  Type
 	Value ConstantValue
 	Line  int
+
+	// Doc comment preceding the constant with the comment markers removed,
+	// or an empty string if there wasn't one.
+	Doc string
 }
 
 func (*Constant) node()       {}
@@ -70,6 +74,10 @@ type Typedef struct {
 	Type        Type
 	Annotations []*Annotation
 	Line        int
+
+	// Doc comment preceding the typedef with the comment markers removed,
+	// or an empty string if there wasn't one.
+	Doc string
 }
 
 // Definition implementation for Typedef.
@@ -104,6 +112,10 @@ type Enum struct {
 	Items       []*EnumItem
 	Annotations []*Annotation
 	Line        int
+
+	// Doc comment preceding the enum with the comment markers removed,
+	// or an empty string if there wasn't one.
+	Doc string
 }
 
 func (*Enum) node()       {}
@@ -133,6 +145,10 @@ type EnumItem struct {
 	Value       *int
 	Annotations []*Annotation
 	Line        int
+
+	// Doc comment preceding the item with the comment markers removed,
+	// or an empty string if there wasn't one.
+	Doc string
 }
 
 func (*EnumItem) node() {}
@@ -145,6 +161,35 @@ func (i *EnumItem) visitChildren(ss nodeStack, v visitor) {
 	}
 }
 
+// Senum is a deprecated set of string values.
+//
+// 	senum Color { "red", "green" }
+//
+// The values are not enforced on the wire. Senums are treated as typedefs of
+// string.
+type Senum struct {
+	Name        string
+	Values      []string
+	Annotations []*Annotation
+	Line        int
+}
+
+func (*Senum) node()       {}
+func (*Senum) definition() {}
+
+func (e *Senum) lineNumber() int { return e.Line }
+
+func (e *Senum) visitChildren(ss nodeStack, v visitor) {
+	for _, ann := range e.Annotations {
+		v.visit(ss, ann)
+	}
+}
+
+// Info for Senum.
+func (e *Senum) Info() DefinitionInfo {
+	return DefinitionInfo{Name: e.Name, Line: e.Line}
+}
+
 // StructureType specifies whether a struct-like type is a struct, union, or
 // exception.
 type StructureType int
@@ -182,6 +227,16 @@ type Struct struct {
 	Fields      []*Field
 	Annotations []*Annotation
 	Line        int
+
+	// Doc comment preceding the struct with the comment markers removed,
+	// or an empty string if there wasn't one.
+	Doc string
+
+	// Whether the struct was declared with the deprecated xsd_all keyword.
+	// This has no effect.
+	//
+	// 	struct User xsd_all { ... }
+	XSDAll bool
 }
 
 func (*Struct) node()       {}
@@ -217,6 +272,10 @@ type Service struct {
 	Parent      *ServiceReference
 	Annotations []*Annotation
 	Line        int
+
+	// Doc comment preceding the service with the comment markers removed,
+	// or an empty string if there wasn't one.
+	Doc string
 }
 
 func (*Service) node()       {}
@@ -252,6 +311,10 @@ type Function struct {
 	OneWay      bool
 	Annotations []*Annotation
 	Line        int
+
+	// Doc comment preceding the function with the comment markers removed,
+	// or an empty string if there wasn't one.
+	Doc string
 }
 
 func (*Function) node() {}
@@ -297,6 +360,19 @@ type Field struct {
 	Default      ConstantValue
 	Annotations  []*Annotation
 	Line         int
+
+	// Doc comment preceding the field with the comment markers removed,
+	// or an empty string if there wasn't one.
+	Doc string
+
+	// Deprecated XSD attributes of the field. These have no effect.
+	//
+	// 	1: optional string name xsd_optional xsd_nillable xsd_attrs {
+	// 		1: string lang
+	// 	}
+	XSDOptional   bool
+	XSDNillable   bool
+	XSDAttributes []*Field
 }
 
 func (*Field) node() {}
@@ -306,6 +382,9 @@ func (n *Field) lineNumber() int { return n.Line }
 func (n *Field) visitChildren(ss nodeStack, v visitor) {
 	v.visit(ss, n.Type)
 	v.visit(ss, n.Default)
+	for _, attr := range n.XSDAttributes {
+		v.visit(ss, attr)
+	}
 	for _, ann := range n.Annotations {
 		v.visit(ss, ann)
 	}
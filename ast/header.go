@@ -40,6 +40,10 @@ type Header interface {
 // thriftrw's custom Include-As syntax may be used to change the name under
 // which the file is imported.
 //
+// 	include "shared.thrift" as t
+//
+// The older form of the syntax is also supported.
+//
 // 	include t "shared.thrift"
 type Include struct {
 	Path string
@@ -63,10 +67,25 @@ func (i *Include) Info() HeaderInfo {
 // generated code in certain languages.
 //
 // 	namespace py foo.bar
+//
+// The deprecated language-specific forms of the statement are also
+// supported.
+//
+// 	php_namespace foo
+//
+// Annotations on namespace statements apply to the whole Thrift file.
+//
+// 	namespace go foo (go.package = "foo")
 type Namespace struct {
-	Scope string
-	Name  string
-	Line  int
+	Scope       string
+	Name        string
+	Annotations []*Annotation
+	Line        int
+
+	// Deprecated keyword with which the namespace was declared, if any. For
+	// example, this is "php_namespace" for the statement above and empty
+	// for namespaces declared with "namespace".
+	Keyword string
 }
 
 func (*Namespace) node()   {}
@@ -74,7 +93,11 @@ func (*Namespace) header() {}
 
 func (n *Namespace) lineNumber() int { return n.Line }
 
-func (*Namespace) visitChildren(nodeStack, visitor) {}
+func (n *Namespace) visitChildren(ss nodeStack, v visitor) {
+	for _, ann := range n.Annotations {
+		v.visit(ss, ann)
+	}
+}
 
 // Info for Namespace.
 func (n *Namespace) Info() HeaderInfo {
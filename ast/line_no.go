@@ -52,6 +52,7 @@ var _ nodeWithLine = (*Include)(nil)
 var _ nodeWithLine = ListType{}
 var _ nodeWithLine = MapType{}
 var _ nodeWithLine = (*Namespace)(nil)
+var _ nodeWithLine = (*Senum)(nil)
 var _ nodeWithLine = (*Service)(nil)
 var _ nodeWithLine = SetType{}
 var _ nodeWithLine = (*Struct)(nil)
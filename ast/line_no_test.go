@@ -63,6 +63,7 @@ func TestLineNumber(t *testing.T) {
 		{give: ListType{Line: 18}, want: 18},
 		{give: SetType{Line: 19}, want: 19},
 		{give: TypeReference{Line: 20}, want: 20},
+		{give: &Senum{Line: 21}, want: 21},
 	}
 
 	for _, tt := range tests {
@@ -54,6 +54,7 @@ var _ Node = ListType{}
 var _ Node = MapType{}
 var _ Node = (*Namespace)(nil)
 var _ Node = (*Program)(nil)
+var _ Node = (*Senum)(nil)
 var _ Node = (*Service)(nil)
 var _ Node = SetType{}
 var _ Node = (*Struct)(nil)
@@ -24,6 +24,10 @@ package ast
 type Program struct {
 	Headers     []Header
 	Definitions []Definition
+
+	// Comments in the file, in the order in which they appear. This is
+	// populated only if the parser was asked to retain comments.
+	Comments []*Comment
 }
 
 func (*Program) node() {}
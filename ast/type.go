@@ -45,14 +45,17 @@ const (
 	DoubleTypeID                       // double
 	StringTypeID                       // string
 	BinaryTypeID                       // binary
+	UUIDTypeID                         // uuid
 )
 
 // BaseType is a reference to a Thrift base type.
 //
-// 	bool, byte, i16, i32, i64, double, string, binary
+// 	bool, byte, i16, i32, i64, double, string, binary, uuid
 //
 // All references to base types in the document may be followed by type
-// annotations.
+// annotations, except for uuid. uuid is not a reserved word: it may still be
+// used as the name of a field or definition, but references to types named
+// uuid always refer to the base type.
 //
 // 	bool (go.type = "int")
 type BaseType struct {
@@ -95,6 +98,8 @@ func (bt BaseType) String() string {
 		name = "string"
 	case BinaryTypeID:
 		name = "binary"
+	case UUIDTypeID:
+		name = "uuid"
 	default:
 		panic(fmt.Sprintf("unknown base type %v", bt.ID))
 	}
@@ -0,0 +1,179 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// +build apache
+
+package bench
+
+import (
+	"fmt"
+	"testing"
+
+	"go.uber.org/thriftrw/bench/apache/models"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+// apacheType is implemented by structs generated by the Apache Thrift Go
+// generator.
+type apacheType interface {
+	Read(thrift.TProtocol) error
+	Write(thrift.TProtocol) error
+}
+
+type apacheCase struct {
+	name  string
+	value apacheType
+	empty func() apacheType
+}
+
+func apacheCases() []apacheCase {
+	return []apacheCase{
+		{
+			name:  "Node",
+			value: newApacheNode(nodeDepth, nodeFanout),
+			empty: func() apacheType { return models.NewNode() },
+		},
+		{
+			name:  "Batch",
+			value: newApacheBatch(batchSize),
+			empty: func() apacheType { return models.NewBatch() },
+		},
+		{
+			name:  "Sparse",
+			value: newApacheSparse(),
+			empty: func() apacheType { return models.NewSparse() },
+		},
+	}
+}
+
+func newApacheNode(depth, fanout int) *models.Node {
+	value := int64(depth)
+	n := &models.Node{
+		Name:  fmt.Sprintf("node-%d", depth),
+		Value: &value,
+	}
+	if depth > 1 {
+		n.Children = make([]*models.Node, fanout)
+		for i := range n.Children {
+			n.Children[i] = newApacheNode(depth-1, fanout)
+		}
+	}
+	return n
+}
+
+func newApacheBatch(size int) *models.Batch {
+	b := &models.Batch{
+		Ids:    make([]int64, size),
+		Names:  make([]string, size),
+		Points: make([]*models.Point, size),
+		Counts: make(map[string]int64, size/10),
+	}
+	for i := 0; i < size; i++ {
+		b.Ids[i] = int64(i)
+		b.Names[i] = fmt.Sprintf("name-%d", i)
+		b.Points[i] = &models.Point{X: float64(i), Y: float64(-i)}
+		if i%10 == 0 {
+			b.Counts[b.Names[i]] = int64(i)
+		}
+	}
+	return b
+}
+
+func newApacheSparse() *models.Sparse {
+	flag := true
+	medium := int32(42)
+	text := "hello"
+	return &models.Sparse{
+		Flag1:   &flag,
+		Medium1: &medium,
+		Text1:   &text,
+		Point1:  &models.Point{X: 1, Y: 2},
+	}
+}
+
+func apacheEncode(v apacheType) ([]byte, error) {
+	buff := thrift.NewTMemoryBuffer()
+	if err := v.Write(thrift.NewTBinaryProtocolTransport(buff)); err != nil {
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
+
+func apacheDecode(bs []byte, v apacheType) error {
+	buff := thrift.NewTMemoryBuffer()
+	if _, err := buff.Write(bs); err != nil {
+		return err
+	}
+	return v.Read(thrift.NewTBinaryProtocolTransport(buff))
+}
+
+func TestApacheCompatible(t *testing.T) {
+	// Both generators must produce the same bytes for the same data so that
+	// the benchmarks compare equivalent work.
+	rw := thriftRWCases()
+	for i, tt := range apacheCases() {
+		want, err := encode(rw[i].value)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := apacheEncode(tt.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := decode(got, rw[i].empty()); err != nil {
+			t.Errorf("%v: ThriftRW could not decode Apache output: %v", tt.name, err)
+		}
+		if len(got) != len(want) {
+			t.Errorf("%v: encoded sizes differ: ThriftRW %d, Apache %d", tt.name, len(want), len(got))
+		}
+	}
+}
+
+func BenchmarkApache(b *testing.B) {
+	for _, tt := range apacheCases() {
+		bs, err := apacheEncode(tt.value)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(tt.name+"/Encode", func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(bs)))
+			for i := 0; i < b.N; i++ {
+				if _, err := apacheEncode(tt.value); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(tt.name+"/Decode", func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(bs)))
+			for i := 0; i < b.N; i++ {
+				if err := apacheDecode(bs, tt.empty()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bench
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"go.uber.org/thriftrw/bench/models"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/ptr"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Sizes of the benchmarked values. These are shared with the benchmarks for
+// Apache-generated code so that both encode the same data.
+const (
+	nodeDepth  = 5
+	nodeFanout = 4
+	batchSize  = 1000
+)
+
+type thriftType interface {
+	ToWire() (wire.Value, error)
+	FromWire(wire.Value) error
+}
+
+// thriftRWCase is a value used in the benchmarks along with a constructor
+// for empty values of the same type.
+type thriftRWCase struct {
+	name  string
+	value thriftType
+	empty func() thriftType
+}
+
+func thriftRWCases() []thriftRWCase {
+	return []thriftRWCase{
+		{
+			name:  "Node",
+			value: newNode(nodeDepth, nodeFanout),
+			empty: func() thriftType { return new(models.Node) },
+		},
+		{
+			name:  "Batch",
+			value: newBatch(batchSize),
+			empty: func() thriftType { return new(models.Batch) },
+		},
+		{
+			name:  "Sparse",
+			value: newSparse(),
+			empty: func() thriftType { return new(models.Sparse) },
+		},
+	}
+}
+
+// newNode builds a tree of the given depth in which every node except the
+// leaves has the given number of children.
+func newNode(depth, fanout int) *models.Node {
+	n := &models.Node{
+		Name:  fmt.Sprintf("node-%d", depth),
+		Value: ptr.Int64(int64(depth)),
+	}
+	if depth > 1 {
+		n.Children = make([]*models.Node, fanout)
+		for i := range n.Children {
+			n.Children[i] = newNode(depth-1, fanout)
+		}
+	}
+	return n
+}
+
+func newBatch(size int) *models.Batch {
+	b := &models.Batch{
+		Ids:    make([]int64, size),
+		Names:  make([]string, size),
+		Points: make([]*models.Point, size),
+		Counts: make(map[string]int64, size/10),
+	}
+	for i := 0; i < size; i++ {
+		b.Ids[i] = int64(i)
+		b.Names[i] = fmt.Sprintf("name-%d", i)
+		b.Points[i] = &models.Point{X: float64(i), Y: float64(-i)}
+		if i%10 == 0 {
+			b.Counts[b.Names[i]] = int64(i)
+		}
+	}
+	return b
+}
+
+// newSparse builds a Sparse with only a few of its fields set.
+func newSparse() *models.Sparse {
+	return &models.Sparse{
+		Flag1:   ptr.Bool(true),
+		Medium1: ptr.Int32(42),
+		Text1:   ptr.String("hello"),
+		Point1:  &models.Point{X: 1, Y: 2},
+	}
+}
+
+func encode(v thriftType) ([]byte, error) {
+	w, err := v.ToWire()
+	if err != nil {
+		return nil, err
+	}
+
+	var buff bytes.Buffer
+	err = protocol.Binary.Encode(w, &buff)
+	return buff.Bytes(), err
+}
+
+func decode(bs []byte, v thriftType) error {
+	w, err := protocol.Binary.Decode(bytes.NewReader(bs), wire.TStruct)
+	if err != nil {
+		return err
+	}
+	return v.FromWire(w)
+}
+
+func TestThriftRWRoundTrip(t *testing.T) {
+	for _, tt := range thriftRWCases() {
+		bs, err := encode(tt.value)
+		require.NoError(t, err, tt.name)
+
+		got := tt.empty()
+		require.NoError(t, decode(bs, got), tt.name)
+		assert.Equal(t, tt.value, got, tt.name)
+	}
+}
+
+func BenchmarkThriftRW(b *testing.B) {
+	for _, tt := range thriftRWCases() {
+		bs, err := encode(tt.value)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(tt.name+"/Encode", func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(bs)))
+			for i := 0; i < b.N; i++ {
+				if _, err := encode(tt.value); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(tt.name+"/Decode", func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(bs)))
+			for i := 0; i < b.N; i++ {
+				if err := decode(bs, tt.empty()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
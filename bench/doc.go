@@ -0,0 +1,37 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package bench contains benchmarks for code generated by ThriftRW.
+//
+// The benchmarks encode and decode the types defined in thrift/models.thrift
+// with the Binary protocol.
+//
+// 	go test -bench . ./bench
+//
+// The same types may be compared against code generated by the Apache Thrift
+// Go generator. This requires the Apache Thrift compiler and Go library.
+//
+// 	make -C bench apache
+// 	go test -tags apache -bench . ./bench
+//
+// Benchmarks for both generators are named after the generator followed by
+// the type and the operation (for example, ThriftRW/Node/Decode and
+// Apache/Node/Decode) so that their results may be compared directly.
+package bench
@@ -0,0 +1,10 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+package models
+
+import "go.uber.org/thriftrw/thriftreflect"
+
+var ThriftModule = &thriftreflect.ThriftModule{Name: "models", Package: "go.uber.org/thriftrw/bench/models", FilePath: "models.thrift", SHA1: "337e1a9bbcea3684caa198ad152726dfef13f1f4", Raw: rawIDL}
+
+const rawIDL = "// Representative types used to benchmark generated code. The same file is\n// compiled with the Apache Thrift Go generator for comparison, so it sticks\n// to features supported by both generators.\n\n/** Deeply nested values. */\nstruct Node {\n    1: required string name\n    2: optional i64 value\n    3: optional list<Node> children\n}\n\n/** Large lists of primitives and structs. */\nstruct Point {\n    1: required double x\n    2: required double y\n}\n\nstruct Batch {\n    1: required list<i64> ids\n    2: required list<string> names\n    3: required list<Point> points\n    4: optional map<string, i64> counts\n}\n\n/** Many optional fields, most of which are unset. */\nstruct Sparse {\n    1: optional bool flag1\n    2: optional bool flag2\n    3: optional i16 small1\n    4: optional i16 small2\n    5: optional i32 medium1\n    6: optional i32 medium2\n    7: optional i64 large1\n    8: optional i64 large2\n    9: optional double ratio1\n    10: optional double ratio2\n    11: optional string text1\n    12: optional string text2\n    13: optional string text3\n    14: optional string text4\n    15: optional binary blob1\n    16: optional binary blob2\n    17: optional Point point1\n    18: optional Point point2\n    19: optional list<i32> numbers\n    20: optional set<string> tags\n}\n"
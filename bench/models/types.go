@@ -0,0 +1,1592 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"go.uber.org/thriftrw/wire"
+	"strings"
+)
+
+type Batch struct {
+	Ids    []int64          `json:"ids"`
+	Names  []string         `json:"names"`
+	Points []*Point         `json:"points"`
+	Counts map[string]int64 `json:"counts"`
+}
+
+type _List_I64_ValueList []int64
+
+func (v _List_I64_ValueList) ForEach(f func(wire.Value) error) error {
+	for _, x := range v {
+		w, err := wire.NewValueI64(x), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_I64_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_I64_ValueList) ValueType() wire.Type {
+	return wire.TI64
+}
+
+func (_List_I64_ValueList) Close() {
+}
+
+type _List_String_ValueList []string
+
+func (v _List_String_ValueList) ForEach(f func(wire.Value) error) error {
+	for _, x := range v {
+		w, err := wire.NewValueString(x), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_String_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_String_ValueList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_List_String_ValueList) Close() {
+}
+
+type _List_Point_ValueList []*Point
+
+func (v _List_Point_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_Point_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_Point_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_Point_ValueList) Close() {
+}
+
+type _Map_String_I64_MapItemList map[string]int64
+
+func (m _Map_String_I64_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
+		vw, err := wire.NewValueI64(v), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_String_I64_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_String_I64_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_I64_MapItemList) ValueType() wire.Type {
+	return wire.TI64
+}
+
+func (_Map_String_I64_MapItemList) Close() {
+}
+
+func (v *Batch) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Ids == nil {
+		return w, wire.NilRequiredFieldError{Struct: "Batch", Field: "Ids", ID: 1}
+	}
+	w, err = wire.NewValueList(_List_I64_ValueList(v.Ids)), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.Names == nil {
+		return w, wire.NilRequiredFieldError{Struct: "Batch", Field: "Names", ID: 2}
+	}
+	w, err = wire.NewValueList(_List_String_ValueList(v.Names)), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 2, Value: w}
+	i++
+	if v.Points == nil {
+		return w, wire.NilRequiredFieldError{Struct: "Batch", Field: "Points", ID: 3}
+	}
+	w, err = wire.NewValueList(_List_Point_ValueList(v.Points)), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 3, Value: w}
+	i++
+	if v.Counts != nil {
+		w, err = wire.NewValueMap(_Map_String_I64_MapItemList(v.Counts)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _List_I64_Read(l wire.ValueList) ([]int64, error) {
+	if l.ValueType() != wire.TI64 {
+		return nil, nil
+	}
+	o := make([]int64, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := x.GetI64(), error(nil)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _List_String_Read(l wire.ValueList) ([]string, error) {
+	if l.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+	o := make([]string, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := x.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _Point_Read(w wire.Value) (*Point, error) {
+	var v Point
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_Point_Read(l wire.ValueList) ([]*Point, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+	o := make([]*Point, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _Point_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _Map_String_I64_Read(m wire.MapItemList) (map[string]int64, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+	if m.ValueType() != wire.TI64 {
+		return nil, nil
+	}
+	o := make(map[string]int64, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		v, err := x.Value.GetI64(), error(nil)
+		if err != nil {
+			return err
+		}
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+func (v *Batch) FromWire(w wire.Value) error {
+	var err error
+	idsIsSet := false
+	namesIsSet := false
+	pointsIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TList {
+				v.Ids, err = _List_I64_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("Batch", "Ids", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				idsIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TList {
+				v.Names, err = _List_String_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("Batch", "Names", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				namesIsSet = true
+			}
+		case 3:
+			if field.Value.Type() == wire.TList {
+				v.Points, err = _List_Point_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("Batch", "Points", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				pointsIsSet = true
+			}
+		case 4:
+			if field.Value.Type() == wire.TMap {
+				v.Counts, err = _Map_String_I64_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("Batch", "Counts", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	if !idsIsSet {
+		wire.ObserveDecodeError("Batch", "Ids", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Batch", Field: "Ids", ID: 1}
+	}
+	if !namesIsSet {
+		wire.ObserveDecodeError("Batch", "Names", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Batch", Field: "Names", ID: 2}
+	}
+	if !pointsIsSet {
+		wire.ObserveDecodeError("Batch", "Points", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Batch", Field: "Points", ID: 3}
+	}
+	return nil
+}
+
+func (v *Batch) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [4]string
+	i := 0
+	fields[i] = fmt.Sprintf("Ids: %v", v.Ids)
+	i++
+	fields[i] = fmt.Sprintf("Names: %v", v.Names)
+	i++
+	fields[i] = fmt.Sprintf("Points: %v", v.Points)
+	i++
+	if v.Counts != nil {
+		fields[i] = fmt.Sprintf("Counts: %v", v.Counts)
+		i++
+	}
+	return fmt.Sprintf("Batch{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_I64_Equals(lhs, rhs []int64) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func _List_String_Equals(lhs, rhs []string) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func _List_Point_Equals(lhs, rhs []*Point) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func _Map_String_I64_Equals(lhs, rhs map[string]int64) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *Batch) Equals(rhs *Batch) bool {
+	if !_List_I64_Equals(v.Ids, rhs.Ids) {
+		return false
+	}
+	if !_List_String_Equals(v.Names, rhs.Names) {
+		return false
+	}
+	if !_List_Point_Equals(v.Points, rhs.Points) {
+		return false
+	}
+	if !((v.Counts == nil && rhs.Counts == nil) || (v.Counts != nil && rhs.Counts != nil && _Map_String_I64_Equals(v.Counts, rhs.Counts))) {
+		return false
+	}
+	return true
+}
+
+func (v *Batch) GetIds() (o []int64) {
+	if v != nil {
+		o = v.Ids
+	}
+	return
+}
+
+func (v *Batch) GetNames() (o []string) {
+	if v != nil {
+		o = v.Names
+	}
+	return
+}
+
+func (v *Batch) GetPoints() (o []*Point) {
+	if v != nil {
+		o = v.Points
+	}
+	return
+}
+
+func (v *Batch) GetCounts() (o map[string]int64) {
+	if v != nil && v.Counts != nil {
+		return v.Counts
+	}
+	return
+}
+
+// Deeply nested values.
+type Node struct {
+	Name     string  `json:"name"`
+	Value    *int64  `json:"value,omitempty"`
+	Children []*Node `json:"children"`
+}
+
+type _List_Node_ValueList []*Node
+
+func (v _List_Node_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_Node_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_Node_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_Node_ValueList) Close() {
+}
+
+func (v *Node) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueString(v.Name), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.Value != nil {
+		w, err = wire.NewValueI64(*(v.Value)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.Children != nil {
+		w, err = wire.NewValueList(_List_Node_ValueList(v.Children)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Node_Read(w wire.Value) (*Node, error) {
+	var v Node
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_Node_Read(l wire.ValueList) ([]*Node, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+	o := make([]*Node, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _Node_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func (v *Node) FromWire(w wire.Value) error {
+	var err error
+	nameIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.Name, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("Node", "Name", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				nameIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Value = &x
+				if err != nil {
+					wire.ObserveDecodeError("Node", "Value", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 3:
+			if field.Value.Type() == wire.TList {
+				v.Children, err = _List_Node_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("Node", "Children", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	if !nameIsSet {
+		wire.ObserveDecodeError("Node", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Node", Field: "Name", ID: 1}
+	}
+	return nil
+}
+
+func (v *Node) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [3]string
+	i := 0
+	fields[i] = fmt.Sprintf("Name: %v", v.Name)
+	i++
+	if v.Value != nil {
+		fields[i] = fmt.Sprintf("Value: %v", *(v.Value))
+		i++
+	}
+	if v.Children != nil {
+		fields[i] = fmt.Sprintf("Children: %v", v.Children)
+		i++
+	}
+	return fmt.Sprintf("Node{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _I64_EqualsPtr(lhs, rhs *int64) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _List_Node_Equals(lhs, rhs []*Node) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *Node) Equals(rhs *Node) bool {
+	if !(v.Name == rhs.Name) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Value, rhs.Value) {
+		return false
+	}
+	if !((v.Children == nil && rhs.Children == nil) || (v.Children != nil && rhs.Children != nil && _List_Node_Equals(v.Children, rhs.Children))) {
+		return false
+	}
+	return true
+}
+
+func (v *Node) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
+}
+
+func (v *Node) GetValue() (o int64) {
+	if v != nil && v.Value != nil {
+		return *v.Value
+	}
+	return
+}
+
+func (v *Node) GetChildren() (o []*Node) {
+	if v != nil && v.Children != nil {
+		return v.Children
+	}
+	return
+}
+
+// Large lists of primitives and structs.
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+func (v *Point) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueDouble(v.X), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	w, err = wire.NewValueDouble(v.Y), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 2, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *Point) FromWire(w wire.Value) error {
+	var err error
+	xIsSet := false
+	yIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TDouble {
+				v.X, err = field.Value.GetDouble(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("Point", "X", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				xIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TDouble {
+				v.Y, err = field.Value.GetDouble(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("Point", "Y", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				yIsSet = true
+			}
+		}
+	}
+	if !xIsSet {
+		wire.ObserveDecodeError("Point", "X", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Point", Field: "X", ID: 1}
+	}
+	if !yIsSet {
+		wire.ObserveDecodeError("Point", "Y", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Point", Field: "Y", ID: 2}
+	}
+	return nil
+}
+
+func (v *Point) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	fields[i] = fmt.Sprintf("X: %v", v.X)
+	i++
+	fields[i] = fmt.Sprintf("Y: %v", v.Y)
+	i++
+	return fmt.Sprintf("Point{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *Point) Equals(rhs *Point) bool {
+	if !(v.X == rhs.X) {
+		return false
+	}
+	if !(v.Y == rhs.Y) {
+		return false
+	}
+	return true
+}
+
+func (v *Point) GetX() (o float64) {
+	if v != nil {
+		o = v.X
+	}
+	return
+}
+
+func (v *Point) GetY() (o float64) {
+	if v != nil {
+		o = v.Y
+	}
+	return
+}
+
+// Many optional fields, most of which are unset.
+type Sparse struct {
+	Flag1   *bool               `json:"flag1,omitempty"`
+	Flag2   *bool               `json:"flag2,omitempty"`
+	Small1  *int16              `json:"small1,omitempty"`
+	Small2  *int16              `json:"small2,omitempty"`
+	Medium1 *int32              `json:"medium1,omitempty"`
+	Medium2 *int32              `json:"medium2,omitempty"`
+	Large1  *int64              `json:"large1,omitempty"`
+	Large2  *int64              `json:"large2,omitempty"`
+	Ratio1  *float64            `json:"ratio1,omitempty"`
+	Ratio2  *float64            `json:"ratio2,omitempty"`
+	Text1   *string             `json:"text1,omitempty"`
+	Text2   *string             `json:"text2,omitempty"`
+	Text3   *string             `json:"text3,omitempty"`
+	Text4   *string             `json:"text4,omitempty"`
+	Blob1   []byte              `json:"blob1"`
+	Blob2   []byte              `json:"blob2"`
+	Point1  *Point              `json:"point1,omitempty"`
+	Point2  *Point              `json:"point2,omitempty"`
+	Numbers []int32             `json:"numbers"`
+	Tags    map[string]struct{} `json:"tags"`
+}
+
+type _List_I32_ValueList []int32
+
+func (v _List_I32_ValueList) ForEach(f func(wire.Value) error) error {
+	for _, x := range v {
+		w, err := wire.NewValueI32(x), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_I32_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_I32_ValueList) ValueType() wire.Type {
+	return wire.TI32
+}
+
+func (_List_I32_ValueList) Close() {
+}
+
+type _Set_String_ValueList map[string]struct{}
+
+func (v _Set_String_ValueList) ForEach(f func(wire.Value) error) error {
+	for x := range v {
+		w, err := wire.NewValueString(x), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _Set_String_ValueList) Size() int {
+	return len(v)
+}
+
+func (_Set_String_ValueList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Set_String_ValueList) Close() {
+}
+
+func (v *Sparse) ToWire() (wire.Value, error) {
+	var (
+		fields [20]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Flag1 != nil {
+		w, err = wire.NewValueBool(*(v.Flag1)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.Flag2 != nil {
+		w, err = wire.NewValueBool(*(v.Flag2)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.Small1 != nil {
+		w, err = wire.NewValueI16(*(v.Small1)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.Small2 != nil {
+		w, err = wire.NewValueI16(*(v.Small2)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.Medium1 != nil {
+		w, err = wire.NewValueI32(*(v.Medium1)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+	if v.Medium2 != nil {
+		w, err = wire.NewValueI32(*(v.Medium2)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 6, Value: w}
+		i++
+	}
+	if v.Large1 != nil {
+		w, err = wire.NewValueI64(*(v.Large1)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 7, Value: w}
+		i++
+	}
+	if v.Large2 != nil {
+		w, err = wire.NewValueI64(*(v.Large2)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 8, Value: w}
+		i++
+	}
+	if v.Ratio1 != nil {
+		w, err = wire.NewValueDouble(*(v.Ratio1)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 9, Value: w}
+		i++
+	}
+	if v.Ratio2 != nil {
+		w, err = wire.NewValueDouble(*(v.Ratio2)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Text1 != nil {
+		w, err = wire.NewValueString(*(v.Text1)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 11, Value: w}
+		i++
+	}
+	if v.Text2 != nil {
+		w, err = wire.NewValueString(*(v.Text2)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 12, Value: w}
+		i++
+	}
+	if v.Text3 != nil {
+		w, err = wire.NewValueString(*(v.Text3)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 13, Value: w}
+		i++
+	}
+	if v.Text4 != nil {
+		w, err = wire.NewValueString(*(v.Text4)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 14, Value: w}
+		i++
+	}
+	if v.Blob1 != nil {
+		w, err = wire.NewValueBinary(v.Blob1), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 15, Value: w}
+		i++
+	}
+	if v.Blob2 != nil {
+		w, err = wire.NewValueBinary(v.Blob2), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 16, Value: w}
+		i++
+	}
+	if v.Point1 != nil {
+		w, err = v.Point1.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 17, Value: w}
+		i++
+	}
+	if v.Point2 != nil {
+		w, err = v.Point2.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 18, Value: w}
+		i++
+	}
+	if v.Numbers != nil {
+		w, err = wire.NewValueList(_List_I32_ValueList(v.Numbers)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 19, Value: w}
+		i++
+	}
+	if v.Tags != nil {
+		w, err = wire.NewValueSet(_Set_String_ValueList(v.Tags)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _List_I32_Read(l wire.ValueList) ([]int32, error) {
+	if l.ValueType() != wire.TI32 {
+		return nil, nil
+	}
+	o := make([]int32, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := x.GetI32(), error(nil)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _Set_String_Read(s wire.ValueList) (map[string]struct{}, error) {
+	if s.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+	o := make(map[string]struct{}, s.Size())
+	err := s.ForEach(func(x wire.Value) error {
+		i, err := x.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		o[i] = struct{}{}
+		return nil
+	})
+	s.Close()
+	return o, err
+}
+
+func (v *Sparse) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Flag1 = &x
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Flag1", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 2:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Flag2 = &x
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Flag2", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 3:
+			if field.Value.Type() == wire.TI16 {
+				var x int16
+				x, err = field.Value.GetI16(), error(nil)
+				v.Small1 = &x
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Small1", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 4:
+			if field.Value.Type() == wire.TI16 {
+				var x int16
+				x, err = field.Value.GetI16(), error(nil)
+				v.Small2 = &x
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Small2", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 5:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Medium1 = &x
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Medium1", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 6:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Medium2 = &x
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Medium2", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 7:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Large1 = &x
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Large1", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 8:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Large2 = &x
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Large2", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 9:
+			if field.Value.Type() == wire.TDouble {
+				var x float64
+				x, err = field.Value.GetDouble(), error(nil)
+				v.Ratio1 = &x
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Ratio1", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 10:
+			if field.Value.Type() == wire.TDouble {
+				var x float64
+				x, err = field.Value.GetDouble(), error(nil)
+				v.Ratio2 = &x
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Ratio2", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 11:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Text1 = &x
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Text1", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 12:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Text2 = &x
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Text2", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 13:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Text3 = &x
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Text3", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 14:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Text4 = &x
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Text4", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 15:
+			if field.Value.Type() == wire.TBinary {
+				v.Blob1, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Blob1", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 16:
+			if field.Value.Type() == wire.TBinary {
+				v.Blob2, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Blob2", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 17:
+			if field.Value.Type() == wire.TStruct {
+				v.Point1, err = _Point_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Point1", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 18:
+			if field.Value.Type() == wire.TStruct {
+				v.Point2, err = _Point_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Point2", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 19:
+			if field.Value.Type() == wire.TList {
+				v.Numbers, err = _List_I32_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Numbers", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 20:
+			if field.Value.Type() == wire.TSet {
+				v.Tags, err = _Set_String_Read(field.Value.GetSet())
+				if err != nil {
+					wire.ObserveDecodeError("Sparse", "Tags", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *Sparse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [20]string
+	i := 0
+	if v.Flag1 != nil {
+		fields[i] = fmt.Sprintf("Flag1: %v", *(v.Flag1))
+		i++
+	}
+	if v.Flag2 != nil {
+		fields[i] = fmt.Sprintf("Flag2: %v", *(v.Flag2))
+		i++
+	}
+	if v.Small1 != nil {
+		fields[i] = fmt.Sprintf("Small1: %v", *(v.Small1))
+		i++
+	}
+	if v.Small2 != nil {
+		fields[i] = fmt.Sprintf("Small2: %v", *(v.Small2))
+		i++
+	}
+	if v.Medium1 != nil {
+		fields[i] = fmt.Sprintf("Medium1: %v", *(v.Medium1))
+		i++
+	}
+	if v.Medium2 != nil {
+		fields[i] = fmt.Sprintf("Medium2: %v", *(v.Medium2))
+		i++
+	}
+	if v.Large1 != nil {
+		fields[i] = fmt.Sprintf("Large1: %v", *(v.Large1))
+		i++
+	}
+	if v.Large2 != nil {
+		fields[i] = fmt.Sprintf("Large2: %v", *(v.Large2))
+		i++
+	}
+	if v.Ratio1 != nil {
+		fields[i] = fmt.Sprintf("Ratio1: %v", *(v.Ratio1))
+		i++
+	}
+	if v.Ratio2 != nil {
+		fields[i] = fmt.Sprintf("Ratio2: %v", *(v.Ratio2))
+		i++
+	}
+	if v.Text1 != nil {
+		fields[i] = fmt.Sprintf("Text1: %v", *(v.Text1))
+		i++
+	}
+	if v.Text2 != nil {
+		fields[i] = fmt.Sprintf("Text2: %v", *(v.Text2))
+		i++
+	}
+	if v.Text3 != nil {
+		fields[i] = fmt.Sprintf("Text3: %v", *(v.Text3))
+		i++
+	}
+	if v.Text4 != nil {
+		fields[i] = fmt.Sprintf("Text4: %v", *(v.Text4))
+		i++
+	}
+	if v.Blob1 != nil {
+		fields[i] = fmt.Sprintf("Blob1: %v", v.Blob1)
+		i++
+	}
+	if v.Blob2 != nil {
+		fields[i] = fmt.Sprintf("Blob2: %v", v.Blob2)
+		i++
+	}
+	if v.Point1 != nil {
+		fields[i] = fmt.Sprintf("Point1: %v", v.Point1)
+		i++
+	}
+	if v.Point2 != nil {
+		fields[i] = fmt.Sprintf("Point2: %v", v.Point2)
+		i++
+	}
+	if v.Numbers != nil {
+		fields[i] = fmt.Sprintf("Numbers: %v", v.Numbers)
+		i++
+	}
+	if v.Tags != nil {
+		fields[i] = fmt.Sprintf("Tags: %v", v.Tags)
+		i++
+	}
+	return fmt.Sprintf("Sparse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Bool_EqualsPtr(lhs, rhs *bool) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _I16_EqualsPtr(lhs, rhs *int16) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _I32_EqualsPtr(lhs, rhs *int32) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _Double_EqualsPtr(lhs, rhs *float64) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _String_EqualsPtr(lhs, rhs *string) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _List_I32_Equals(lhs, rhs []int32) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func _Set_String_Equals(lhs, rhs map[string]struct{}) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for x := range rhs {
+		if _, ok := lhs[x]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *Sparse) Equals(rhs *Sparse) bool {
+	if !_Bool_EqualsPtr(v.Flag1, rhs.Flag1) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.Flag2, rhs.Flag2) {
+		return false
+	}
+	if !_I16_EqualsPtr(v.Small1, rhs.Small1) {
+		return false
+	}
+	if !_I16_EqualsPtr(v.Small2, rhs.Small2) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.Medium1, rhs.Medium1) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.Medium2, rhs.Medium2) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Large1, rhs.Large1) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Large2, rhs.Large2) {
+		return false
+	}
+	if !_Double_EqualsPtr(v.Ratio1, rhs.Ratio1) {
+		return false
+	}
+	if !_Double_EqualsPtr(v.Ratio2, rhs.Ratio2) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Text1, rhs.Text1) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Text2, rhs.Text2) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Text3, rhs.Text3) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Text4, rhs.Text4) {
+		return false
+	}
+	if !((v.Blob1 == nil && rhs.Blob1 == nil) || (v.Blob1 != nil && rhs.Blob1 != nil && bytes.Equal(v.Blob1, rhs.Blob1))) {
+		return false
+	}
+	if !((v.Blob2 == nil && rhs.Blob2 == nil) || (v.Blob2 != nil && rhs.Blob2 != nil && bytes.Equal(v.Blob2, rhs.Blob2))) {
+		return false
+	}
+	if !((v.Point1 == nil && rhs.Point1 == nil) || (v.Point1 != nil && rhs.Point1 != nil && v.Point1.Equals(rhs.Point1))) {
+		return false
+	}
+	if !((v.Point2 == nil && rhs.Point2 == nil) || (v.Point2 != nil && rhs.Point2 != nil && v.Point2.Equals(rhs.Point2))) {
+		return false
+	}
+	if !((v.Numbers == nil && rhs.Numbers == nil) || (v.Numbers != nil && rhs.Numbers != nil && _List_I32_Equals(v.Numbers, rhs.Numbers))) {
+		return false
+	}
+	if !((v.Tags == nil && rhs.Tags == nil) || (v.Tags != nil && rhs.Tags != nil && _Set_String_Equals(v.Tags, rhs.Tags))) {
+		return false
+	}
+	return true
+}
+
+func (v *Sparse) GetFlag1() (o bool) {
+	if v != nil && v.Flag1 != nil {
+		return *v.Flag1
+	}
+	return
+}
+
+func (v *Sparse) GetFlag2() (o bool) {
+	if v != nil && v.Flag2 != nil {
+		return *v.Flag2
+	}
+	return
+}
+
+func (v *Sparse) GetSmall1() (o int16) {
+	if v != nil && v.Small1 != nil {
+		return *v.Small1
+	}
+	return
+}
+
+func (v *Sparse) GetSmall2() (o int16) {
+	if v != nil && v.Small2 != nil {
+		return *v.Small2
+	}
+	return
+}
+
+func (v *Sparse) GetMedium1() (o int32) {
+	if v != nil && v.Medium1 != nil {
+		return *v.Medium1
+	}
+	return
+}
+
+func (v *Sparse) GetMedium2() (o int32) {
+	if v != nil && v.Medium2 != nil {
+		return *v.Medium2
+	}
+	return
+}
+
+func (v *Sparse) GetLarge1() (o int64) {
+	if v != nil && v.Large1 != nil {
+		return *v.Large1
+	}
+	return
+}
+
+func (v *Sparse) GetLarge2() (o int64) {
+	if v != nil && v.Large2 != nil {
+		return *v.Large2
+	}
+	return
+}
+
+func (v *Sparse) GetRatio1() (o float64) {
+	if v != nil && v.Ratio1 != nil {
+		return *v.Ratio1
+	}
+	return
+}
+
+func (v *Sparse) GetRatio2() (o float64) {
+	if v != nil && v.Ratio2 != nil {
+		return *v.Ratio2
+	}
+	return
+}
+
+func (v *Sparse) GetText1() (o string) {
+	if v != nil && v.Text1 != nil {
+		return *v.Text1
+	}
+	return
+}
+
+func (v *Sparse) GetText2() (o string) {
+	if v != nil && v.Text2 != nil {
+		return *v.Text2
+	}
+	return
+}
+
+func (v *Sparse) GetText3() (o string) {
+	if v != nil && v.Text3 != nil {
+		return *v.Text3
+	}
+	return
+}
+
+func (v *Sparse) GetText4() (o string) {
+	if v != nil && v.Text4 != nil {
+		return *v.Text4
+	}
+	return
+}
+
+func (v *Sparse) GetBlob1() (o []byte) {
+	if v != nil && v.Blob1 != nil {
+		return v.Blob1
+	}
+	return
+}
+
+func (v *Sparse) GetBlob2() (o []byte) {
+	if v != nil && v.Blob2 != nil {
+		return v.Blob2
+	}
+	return
+}
+
+func (v *Sparse) GetPoint1() (o *Point) {
+	if v != nil && v.Point1 != nil {
+		return v.Point1
+	}
+	return
+}
+
+func (v *Sparse) GetPoint2() (o *Point) {
+	if v != nil && v.Point2 != nil {
+		return v.Point2
+	}
+	return
+}
+
+func (v *Sparse) GetNumbers() (o []int32) {
+	if v != nil && v.Numbers != nil {
+		return v.Numbers
+	}
+	return
+}
+
+func (v *Sparse) GetTags() (o map[string]struct{}) {
+	if v != nil && v.Tags != nil {
+		return v.Tags
+	}
+	return
+}
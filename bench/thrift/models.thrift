@@ -0,0 +1,47 @@
+// Representative types used to benchmark generated code. The same file is
+// compiled with the Apache Thrift Go generator for comparison, so it sticks
+// to features supported by both generators.
+
+/** Deeply nested values. */
+struct Node {
+    1: required string name
+    2: optional i64 value
+    3: optional list<Node> children
+}
+
+/** Large lists of primitives and structs. */
+struct Point {
+    1: required double x
+    2: required double y
+}
+
+struct Batch {
+    1: required list<i64> ids
+    2: required list<string> names
+    3: required list<Point> points
+    4: optional map<string, i64> counts
+}
+
+/** Many optional fields, most of which are unset. */
+struct Sparse {
+    1: optional bool flag1
+    2: optional bool flag2
+    3: optional i16 small1
+    4: optional i16 small2
+    5: optional i32 medium1
+    6: optional i32 medium2
+    7: optional i64 large1
+    8: optional i64 large2
+    9: optional double ratio1
+    10: optional double ratio2
+    11: optional string text1
+    12: optional string text2
+    13: optional string text3
+    14: optional string text4
+    15: optional binary blob1
+    16: optional binary blob2
+    17: optional Point point1
+    18: optional Point point2
+    19: optional list<i32> numbers
+    20: optional set<string> tags
+}
@@ -0,0 +1,297 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/envelope"
+	intenvelope "go.uber.org/thriftrw/internal/envelope"
+	"go.uber.org/thriftrw/internal/frame"
+	"go.uber.org/thriftrw/internal/shell"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/thriftreflect/reflection"
+
+	"github.com/jessevdk/go-flags"
+)
+
+type callOptions struct {
+	Multiplexed bool          `long:"multiplexed" description:"Address the request to the service by name, as expected by servers which serve multiple services."`
+	IDLDir      string        `long:"idl-dir" value-name:"DIR" description:"Write the Thrift files retrieved from the server to DIR."`
+	Timeout     time.Duration `long:"timeout" value-name:"DURATION" default:"10s" description:"Give up if the server does not respond within this duration."`
+}
+
+// doCall implements the "thriftrw call" command.
+func doCall(args []string) error {
+	var opts callOptions
+
+	parser := flags.NewParser(&opts, flags.Default)
+	parser.Name = "thriftrw call"
+	parser.Usage = "[OPTIONS] ADDRESS [SERVICE::METHOD [ARGS]]\n\n" +
+		"Discovers the Thrift files of the server at ADDRESS with the Reflection\n" +
+		"service of go.uber.org/thriftrw/thriftreflect/reflection. Requests are\n" +
+		"framed and encoded with the Binary protocol.\n\n" +
+		"Without a method, the modules, services, and types of the server are\n" +
+		"listed. With a method, it is called with ARGS, a JSON object keyed by\n" +
+		"the names of its arguments, and its result is printed as JSON. Values\n" +
+		"are written as in \"thriftrw shell\"."
+
+	rest, err := parser.ParseArgs(args)
+	if err != nil {
+		return nil // message already printed by go-flags
+	}
+
+	if len(rest) < 1 || len(rest) > 3 {
+		var buffer bytes.Buffer
+		parser.WriteHelp(&buffer)
+		return errors.New(buffer.String())
+	}
+
+	return runCall(os.Stdout, &opts, rest[0], rest[1:]...)
+}
+
+// runCall connects to the server at the given address and either lists its
+// modules or calls the method named in args, writing the output to w.
+func runCall(w io.Writer, opts *callOptions, addr string, args ...string) error {
+	conn, err := net.DialTimeout("tcp", addr, opts.Timeout)
+	if err != nil {
+		return fmt.Errorf("could not connect to %v: %v", addr, err)
+	}
+	defer conn.Close()
+	if opts.Timeout > 0 {
+		if err := conn.SetDeadline(time.Now().Add(opts.Timeout)); err != nil {
+			return err
+		}
+	}
+
+	transport := frame.NewClient(conn, conn)
+	refl := reflection.NewMultiplexedClient(protocol.Binary, transport)
+
+	if len(args) == 0 {
+		if opts.IDLDir != "" {
+			if _, err := fetchIDLs(refl, opts.IDLDir); err != nil {
+				return err
+			}
+		}
+		return listModules(w, refl)
+	}
+
+	service, method, err := parseCallMethod(args[0])
+	if err != nil {
+		return err
+	}
+	argsJSON := "{}"
+	if len(args) > 1 {
+		argsJSON = args[1]
+	}
+
+	dir := opts.IDLDir
+	if dir == "" {
+		dir, err = ioutil.TempDir("", "thriftrw-call")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+	}
+
+	files, err := fetchIDLs(refl, dir)
+	if err != nil {
+		return err
+	}
+
+	fn, err := findFunction(dir, files, service, method)
+	if err != nil {
+		return err
+	}
+	if fn.OneWay {
+		return fmt.Errorf("cannot call %v::%v: oneway functions are not supported", service, method)
+	}
+
+	argsSpec := &compile.StructSpec{
+		Name:   fmt.Sprintf("%v_%v_Args", service, method),
+		Type:   ast.StructType,
+		Fields: compile.FieldGroup(fn.ArgsSpec),
+	}
+	body, err := shell.EncodeJSON(argsSpec, argsJSON)
+	if err != nil {
+		return fmt.Errorf("invalid arguments for %v::%v: %v", service, method, err)
+	}
+
+	name := method
+	if opts.Multiplexed {
+		name = envelope.MultiplexedName(service, method)
+	}
+	res, err := intenvelope.NewClient(protocol.Binary, transport).Send(name, body)
+	if err != nil {
+		return fmt.Errorf("call to %v::%v failed: %v", service, method, err)
+	}
+
+	out, err := shell.DecodeJSON(resultStruct(service, fn), res)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", out)
+	return err
+}
+
+// parseCallMethod splits a method reference in the form SERVICE::METHOD.
+func parseCallMethod(s string) (service, method string, err error) {
+	i := strings.Index(s, "::")
+	if i <= 0 || i+2 >= len(s) {
+		return "", "", fmt.Errorf("invalid method %q: expected SERVICE::METHOD", s)
+	}
+	return s[:i], s[i+2:], nil
+}
+
+// listModules writes the modules known to the server, along with their
+// services and types, to w.
+func listModules(w io.Writer, refl reflection.Reflection) error {
+	modules, err := refl.ListModules()
+	if err != nil {
+		return fmt.Errorf("could not list modules: %v", err)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].FilePath < modules[j].FilePath
+	})
+
+	var buff bytes.Buffer
+	for _, m := range modules {
+		fmt.Fprintf(&buff, "%v (%v)\n", m.FilePath, m.ImportPath)
+		if m.Types == nil && m.Services == nil {
+			fmt.Fprintf(&buff, "  generated without --reflection; use --idl-dir to retrieve the IDL\n")
+			continue
+		}
+
+		for _, s := range m.Services {
+			if s.Parent != nil {
+				fmt.Fprintf(&buff, "  service %v extends %v\n", s.Name, *s.Parent)
+			} else {
+				fmt.Fprintf(&buff, "  service %v\n", s.Name)
+			}
+			for _, method := range s.Methods {
+				if method.OneWay {
+					fmt.Fprintf(&buff, "    %v (oneway)\n", method.Name)
+				} else {
+					fmt.Fprintf(&buff, "    %v\n", method.Name)
+				}
+			}
+		}
+		for _, t := range m.Types {
+			fmt.Fprintf(&buff, "  %v %v\n", t.Kind, t.Name)
+		}
+	}
+
+	_, err = w.Write(buff.Bytes())
+	return err
+}
+
+// fetchIDLs writes the Thrift files known to the server under dir and
+// returns their paths relative to it, sorted.
+func fetchIDLs(refl reflection.Reflection, dir string) ([]string, error) {
+	idls, err := reflection.FetchIDLs(refl)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve Thrift files: %v", err)
+	}
+
+	files := make([]string, 0, len(idls))
+	for path, idl := range idls {
+		// The paths come from the server so they must not escape dir.
+		if filepath.IsAbs(path) || strings.Contains(path, "..") {
+			return nil, fmt.Errorf("server returned invalid Thrift file path %q", path)
+		}
+
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(path, []byte(idl), 0644); err != nil {
+			return nil, err
+		}
+		files = append(files, path)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// findFunction compiles the given Thrift files to find the function with the
+// given name of the given service or the services it extends.
+func findFunction(dir string, files []string, service, method string) (*compile.FunctionSpec, error) {
+	var (
+		spec  *compile.ServiceSpec
+		found string
+	)
+	for _, file := range files {
+		m, err := compile.Compile(file)
+		if err != nil {
+			return nil, fmt.Errorf("could not compile %q retrieved from the server: %v", file, err)
+		}
+		s, ok := m.Services[service]
+		if !ok {
+			continue
+		}
+		if spec != nil {
+			return nil, fmt.Errorf("service %q is defined in both %q and %q", service, found, file)
+		}
+		spec, found = s, file
+	}
+	if spec == nil {
+		return nil, fmt.Errorf("unknown service %q: run without a method to list services", service)
+	}
+
+	for s := spec; s != nil; s = s.Parent {
+		if fn, ok := s.Functions[method]; ok {
+			return fn, nil
+		}
+	}
+	return nil, fmt.Errorf("service %q does not have a method %q", service, method)
+}
+
+// resultStruct returns a struct with the fields of the result of the given
+// function: its return value as "success" and its exceptions.
+func resultStruct(service string, fn *compile.FunctionSpec) *compile.StructSpec {
+	var fields compile.FieldGroup
+	if fn.ResultSpec.ReturnType != nil {
+		fields = append(fields, &compile.FieldSpec{
+			ID:   0,
+			Name: "success",
+			Type: fn.ResultSpec.ReturnType,
+		})
+	}
+	fields = append(fields, fn.ResultSpec.Exceptions...)
+
+	return &compile.StructSpec{
+		Name:   fmt.Sprintf("%v_%v_Result", service, fn.Name),
+		Type:   ast.StructType,
+		Fields: fields,
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/thriftrw/envelope"
+	intenvelope "go.uber.org/thriftrw/internal/envelope"
+	"go.uber.org/thriftrw/internal/frame"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/thriftreflect"
+	"go.uber.org/thriftrw/thriftreflect/reflection"
+	"go.uber.org/thriftrw/thriftreflect/thriftreflecttest"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	_callBaseIDL = `
+service Base {
+    void ping()
+}
+`
+	_callEchoIDL = `
+include "../base.thrift"
+
+exception Failure {
+    1: required string message
+}
+
+service Echo extends base.Base {
+    string echo(1: required string msg) throws (1: Failure failure)
+    oneway void fire()
+}
+`
+)
+
+func init() {
+	base := &thriftreflect.ThriftModule{
+		Name:     "base",
+		Package:  "example.com/calltest/base",
+		FilePath: "calltest/base.thrift",
+		SHA1:     "1",
+		Raw:      _callBaseIDL,
+	}
+	thriftreflect.Register(&thriftreflect.ThriftModule{
+		Name:     "echo",
+		Package:  "example.com/calltest/echo",
+		FilePath: "calltest/echo/echo.thrift",
+		SHA1:     "2",
+		Raw:      _callEchoIDL,
+		Includes: []*thriftreflect.ThriftModule{base},
+		Descriptor: &thriftreflect.Descriptor{
+			Types: []thriftreflect.TypeDescriptor{
+				{Name: "Failure", GoName: "Failure", Kind: "exception"},
+			},
+			Services: []thriftreflect.ServiceDescriptor{
+				{
+					Name:   "Echo",
+					Parent: "base.Base",
+					Methods: []thriftreflect.MethodDescriptor{
+						{Name: "echo"},
+						{Name: "fire", OneWay: true},
+					},
+				},
+			},
+		},
+	})
+}
+
+// echoService implements the Echo service of _callEchoIDL by hand.
+func echoService(ctx context.Context, method string, body wire.Value) (wire.Value, error) {
+	var fields []wire.Field
+	switch method {
+	case "ping":
+	case "echo":
+		var msg string
+		for _, f := range body.GetStruct().Fields {
+			if f.ID == 1 {
+				msg = f.Value.GetString()
+			}
+		}
+
+		if msg == "fail" {
+			failure := wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+				{ID: 1, Value: wire.NewValueString("great sadness")},
+			}})
+			fields = append(fields, wire.Field{ID: 1, Value: failure})
+		} else {
+			fields = append(fields, wire.Field{ID: 0, Value: wire.NewValueString(msg)})
+		}
+	default:
+		return wire.Value{}, intenvelope.ErrUnknownMethod(method)
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields}), nil
+}
+
+type muxHandler struct{ *envelope.Mux }
+
+func (h muxHandler) Handle(name string, body wire.Value) (wire.Value, error) {
+	return h.Mux.Handle(context.Background(), name, body)
+}
+
+// serveCallTest serves the Reflection and Echo services on every connection
+// accepted by the listener. Echo is served with and without multiplexing.
+func serveCallTest(l net.Listener) {
+	mux := envelope.NewMux()
+	mux.Register(reflection.ServiceName,
+		reflection.NewReflectionHandler(reflection.NewService()).HandleContext)
+	mux.Register("Echo", echoService)
+	mux.RegisterDefault(echoService)
+	server := intenvelope.NewServer(protocol.Binary, muxHandler{mux})
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go frame.NewServer(conn, conn).Serve(server)
+	}
+}
+
+func TestCall(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	go serveCallTest(l)
+
+	addr := l.Addr().String()
+	call := func(opts callOptions, args ...string) (string, error) {
+		var out bytes.Buffer
+		err := runCall(&out, &opts, addr, args...)
+		return out.String(), err
+	}
+
+	t.Run("list", func(t *testing.T) {
+		out, err := call(callOptions{})
+		require.NoError(t, err)
+		assert.Contains(t, out,
+			"calltest/base.thrift (example.com/calltest/base)\n"+
+				"  generated without --reflection; use --idl-dir to retrieve the IDL\n"+
+				"calltest/echo/echo.thrift (example.com/calltest/echo)\n"+
+				"  service Echo extends base.Base\n"+
+				"    echo\n"+
+				"    fire (oneway)\n"+
+				"  exception Failure\n")
+	})
+
+	t.Run("idl dir", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "thriftrw-call-test")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		_, err = call(callOptions{IDLDir: dir})
+		require.NoError(t, err)
+
+		got, err := ioutil.ReadFile(filepath.Join(dir, "calltest", "echo", "echo.thrift"))
+		require.NoError(t, err)
+		assert.Equal(t, _callEchoIDL, string(got))
+
+		got, err = ioutil.ReadFile(filepath.Join(dir, "calltest", "base.thrift"))
+		require.NoError(t, err)
+		assert.Equal(t, _callBaseIDL, string(got))
+	})
+
+	tests := []struct {
+		desc    string
+		opts    callOptions
+		args    []string
+		want    string
+		wantErr string
+	}{
+		{
+			desc: "success",
+			args: []string{"Echo::echo", `{"msg": "hello"}`},
+			want: `{"success":"hello"}` + "\n",
+		},
+		{
+			desc: "multiplexed",
+			opts: callOptions{Multiplexed: true},
+			args: []string{"Echo::echo", `{"msg": "hello"}`},
+			want: `{"success":"hello"}` + "\n",
+		},
+		{
+			desc: "exception",
+			args: []string{"Echo::echo", `{"msg": "fail"}`},
+			want: `{"failure":{"message":"great sadness"}}` + "\n",
+		},
+		{
+			desc: "inherited void method without arguments",
+			args: []string{"Echo::ping"},
+			want: "{}\n",
+		},
+		{
+			desc:    "missing argument",
+			args:    []string{"Echo::echo", `{}`},
+			wantErr: `invalid arguments for Echo::echo: missing required field "msg"`,
+		},
+		{
+			desc:    "invalid JSON",
+			args:    []string{"Echo::echo", `{"msg": `},
+			wantErr: "invalid arguments for Echo::echo: invalid JSON",
+		},
+		{
+			desc:    "oneway",
+			args:    []string{"Echo::fire"},
+			wantErr: "cannot call Echo::fire: oneway functions are not supported",
+		},
+		{
+			desc:    "unknown service",
+			args:    []string{"Foo::echo"},
+			wantErr: `unknown service "Foo"`,
+		},
+		{
+			desc:    "unknown method",
+			args:    []string{"Echo::shout"},
+			wantErr: `service "Echo" does not have a method "shout"`,
+		},
+		{
+			desc:    "invalid method",
+			args:    []string{"echo"},
+			wantErr: `invalid method "echo": expected SERVICE::METHOD`,
+		},
+	}
+
+	for _, tt := range tests {
+		out, err := call(tt.opts, tt.args...)
+		if tt.wantErr != "" {
+			if assert.Error(t, err, tt.desc) {
+				assert.Contains(t, err.Error(), tt.wantErr, tt.desc)
+			}
+			continue
+		}
+		if assert.NoError(t, err, tt.desc) {
+			assert.Equal(t, tt.want, out, tt.desc)
+		}
+	}
+}
+
+func TestCallCannotConnect(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	err = runCall(&bytes.Buffer{}, &callOptions{}, addr)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "could not connect to 127.0.0.1:")
+	}
+}
+
+func TestDoCallUsage(t *testing.T) {
+	err := doCall(nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "thriftrw call [OPTIONS] ADDRESS [SERVICE::METHOD [ARGS]]")
+	}
+}
+
+func TestCallRejectsPathsOutsideDir(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	refl := thriftreflecttest.NewMockReflection(mockCtrl)
+	refl.EXPECT().ListModules().Return([]*reflection.ModuleInfo{
+		{ImportPath: "example.com/evil", FilePath: "../evil.thrift"},
+	}, nil)
+	refl.EXPECT().GetIDL("example.com/evil").Return("struct Evil {}", nil)
+
+	dir, err := ioutil.TempDir("", "thriftrw-call-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = fetchIDLs(refl, filepath.Join(dir, "idl"))
+	assert.EqualError(t, err, `server returned invalid Thrift file path "../evil.thrift"`)
+
+	_, err = os.Stat(filepath.Join(dir, "evil.thrift"))
+	assert.True(t, os.IsNotExist(err), "file must not be written outside the directory")
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/internal/compat"
+
+	"github.com/jessevdk/go-flags"
+)
+
+// Status code with which "thriftrw compat" exits if it found breaking
+// changes. Other failures exit with status 1.
+const compatBreakingExitCode = 2
+
+// doCompat implements the "thriftrw compat" command.
+func doCompat(args []string) error {
+	parser := flags.NewParser(nil, flags.Default)
+	parser.Name = "thriftrw compat"
+	parser.Usage = "OLD NEW\n\n" +
+		"Reports changes to the Thrift file NEW which break wire compatibility with OLD.\n" +
+		"Exits with status 2 if breaking changes were found."
+
+	files, err := parser.ParseArgs(args)
+	if err != nil {
+		return nil // message already printed by go-flags
+	}
+
+	if len(files) != 2 {
+		var buffer bytes.Buffer
+		parser.WriteHelp(&buffer)
+		return errors.New(buffer.String())
+	}
+
+	return checkCompat(files[0], files[1], os.Stdout)
+}
+
+// checkCompat compares the given versions of a Thrift file and writes the
+// breaking changes to the given writer.
+func checkCompat(oldFile, newFile string, out io.Writer) error {
+	oldModule, err := compile.Compile(oldFile)
+	if err != nil {
+		return fmt.Errorf("Failed to compile %q: %+v", oldFile, err)
+	}
+
+	newModule, err := compile.Compile(newFile)
+	if err != nil {
+		return fmt.Errorf("Failed to compile %q: %+v", newFile, err)
+	}
+
+	breaks := compat.Check(oldModule, newModule)
+	for _, b := range breaks {
+		if _, err := fmt.Fprintln(out, b); err != nil {
+			return err
+		}
+	}
+
+	if len(breaks) > 0 {
+		return exitError{
+			Code:    compatBreakingExitCode,
+			Message: fmt.Sprintf("Found %d breaking changes", len(breaks)),
+		}
+	}
+	return nil
+}
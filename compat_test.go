@@ -0,0 +1,62 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCompat(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-compat-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeFile := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+		return path
+	}
+
+	oldFile := writeFile("old.thrift", "struct Foo { 1: required string bar }")
+	compatibleFile := writeFile("compatible.thrift", "struct Foo { 1: required string bar, 2: optional i32 baz }")
+	breakingFile := writeFile("breaking.thrift", "struct Foo { 1: required i64 bar }")
+
+	var out bytes.Buffer
+	assert.NoError(t, checkCompat(oldFile, compatibleFile, &out))
+	assert.Empty(t, out.String())
+
+	out.Reset()
+	err = checkCompat(oldFile, breakingFile, &out)
+	assert.Equal(t, exitError{Code: 2, Message: "Found 1 breaking changes"}, err)
+	assert.Equal(t, "struct Foo: field 1 (bar) changed type from string to i64\n", out.String())
+
+	err = checkCompat(oldFile, filepath.Join(dir, "missing.thrift"), &out)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Failed to compile")
+	}
+}
@@ -46,5 +46,9 @@ func compileAnnotations(astAnnotations []*ast.Annotation) (Annotations, error) {
 	if len(annotations) == 0 {
 		return nil, nil
 	}
+
+	if err := validateDocAnnotations(annotations); err != nil {
+		return nil, err
+	}
 	return annotations, nil
 }
@@ -21,7 +21,10 @@
 package compile
 
 import (
+	"errors"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"go.uber.org/thriftrw/ast"
 	"go.uber.org/thriftrw/idl"
@@ -34,6 +37,9 @@ func Compile(path string, opts ...Option) (*Module, error) {
 	for _, opt := range opts {
 		opt(&c)
 	}
+	if c.pedantic {
+		c.questionable = new([]Warning)
+	}
 
 	m, err := c.load(path)
 	if err != nil {
@@ -49,7 +55,20 @@ func Compile(path string, opts ...Option) (*Module, error) {
 		}
 		return nil
 	})
-	return m, err
+	if err != nil {
+		return m, err
+	}
+
+	if c.strictUnused {
+		if unused := FindUnused(m); len(unused) > 0 {
+			return m, unusedError{Unused: unused}
+		}
+	}
+
+	if c.pedantic && len(*c.questionable) > 0 {
+		return m, pedanticError{Warnings: *c.questionable}
+	}
+	return m, nil
 }
 
 // compiler is responsible for compiling Thrift files.
@@ -58,14 +77,30 @@ type compiler struct {
 	fs FS
 	// nonStrict will compile Thrift files that do not pass strict validation.
 	nonStrict bool
+	// If non-nil, warn is called with warnings about the Thrift files.
+	warn func(Warning)
+	// strictUnused fails compilation if there are unused includes or
+	// definitions.
+	strictUnused bool
+	// pedantic fails compilation if there are questionable definitions.
+	pedantic bool
+	// If pedantic is set, questionable definitions are recorded here
+	// instead of being reported as warnings.
+	questionable *[]Warning
+	// interner deduplicates strings across all parsed files.
+	interner *idl.Interner
+	// includePaths are the directories searched, in order, for included
+	// files which are not found relative to the file including them.
+	includePaths []string
 	// Map from file path to Module representing that file.
 	Modules map[string]*Module
 }
 
 func newCompiler() compiler {
 	return compiler{
-		fs:      realFS{},
-		Modules: make(map[string]*Module),
+		fs:       realFS{},
+		Modules:  make(map[string]*Module),
+		interner: new(idl.Interner),
 	}
 }
 
@@ -132,7 +167,14 @@ func (c compiler) load(p string) (*Module, error) {
 		return nil, fileReadError{Path: p, Reason: err}
 	}
 
-	prog, err := idl.Parse(s)
+	cfg := idl.Config{Interner: c.interner}
+	if c.warn != nil {
+		cfg.Warn = func(w idl.Warning) {
+			c.warn(Warning{Path: p, Line: w.Line, Message: w.Message})
+		}
+	}
+
+	prog, err := cfg.Parse(s)
 	if err != nil {
 		return nil, parseError{Path: p, Reason: err}
 	}
@@ -193,6 +235,20 @@ func (c compiler) gather(m *Module, prog *ast.Program) error {
 		m.Includes[include.Name] = include
 	}
 
+	var annotations []*ast.Annotation
+	for _, h := range prog.Headers {
+		if ns, ok := h.(*ast.Namespace); ok {
+			m.Namespaces = append(m.Namespaces, &Namespace{Scope: ns.Scope, Name: ns.Name})
+			annotations = append(annotations, ns.Annotations...)
+		}
+	}
+
+	var err error
+	m.Annotations, err = compileAnnotations(annotations)
+	if err != nil {
+		return compileError{Target: "namespace", Reason: err}
+	}
+
 	for _, d := range prog.Definitions {
 		if err := thriftNS.claim(d.Info().Name, d.Info().Line); err != nil {
 			return definitionError{Definition: d, Reason: err}
@@ -211,6 +267,12 @@ func (c compiler) gather(m *Module, prog *ast.Program) error {
 				return definitionError{Definition: d, Reason: err}
 			}
 			m.Types[typedef.ThriftName()] = typedef
+		case *ast.Senum:
+			typedef, err := compileTypedef(m.ThriftPath, senumTypedef(definition))
+			if err != nil {
+				return definitionError{Definition: d, Reason: err}
+			}
+			m.Types[typedef.ThriftName()] = typedef
 		case *ast.Enum:
 			enum, err := compileEnum(m.ThriftPath, definition)
 			if err != nil {
@@ -226,6 +288,7 @@ func (c compiler) gather(m *Module, prog *ast.Program) error {
 			if err != nil {
 				return definitionError{Definition: d, Reason: err}
 			}
+			c.reportQuestionable(checkStruct(m.ThriftPath, definition))
 			m.Types[s.ThriftName()] = s
 		case *ast.Service:
 			service, err := compileService(m.ThriftPath, definition)
@@ -239,23 +302,74 @@ func (c compiler) gather(m *Module, prog *ast.Program) error {
 	return nil
 }
 
+// reportQuestionable records the given warnings about questionable
+// definitions if the compiler is pedantic, and reports them as warnings
+// otherwise.
+func (c compiler) reportQuestionable(warnings []Warning) {
+	if c.pedantic {
+		*c.questionable = append(*c.questionable, warnings...)
+		return
+	}
+
+	if c.warn != nil {
+		for _, w := range warnings {
+			c.warn(w)
+		}
+	}
+}
+
 // include loads the file specified by the given include in the given Module.
 //
-// The path to the file is relative to the ThriftPath of the given module.
+// Relative paths are resolved against the directory containing the given
+// module first, followed by each of the include paths in order. The included
+// module is exposed under the name specified with the
+// include-as syntax, defaulting to the name of the file.
 func (c compiler) include(m *Module, include *ast.Include) (*IncludedModule, error) {
-	if len(include.Name) > 0 {
-		// TODO(abg): Add support for include-as flag somewhere.
+	name := include.Name
+	if len(name) == 0 {
+		name = fileBaseName(include.Path)
+	} else if strings.Contains(name, ".") {
 		return nil, includeError{
 			Include: include,
-			Reason:  includeAsDisabledError{},
+			Reason:  errors.New("include names may not contain '.'"),
 		}
 	}
 
-	ipath := filepath.Join(filepath.Dir(m.ThriftPath), include.Path)
-	incM, err := c.load(ipath)
-	if err != nil {
+	var searched []string
+	for _, ipath := range c.includeCandidates(m, include.Path) {
+		incM, err := c.load(ipath)
+		if err == nil {
+			return &IncludedModule{Name: name, Module: incM}, nil
+		}
+
+		// Move on to the next candidate only if this one doesn't exist.
+		// Other failures, including those of files it includes, are
+		// reported as-is.
+		if e, ok := err.(fileReadError); ok && os.IsNotExist(e.Reason) {
+			searched = append(searched, e.Path)
+			continue
+		}
 		return nil, includeError{Include: include, Reason: err}
 	}
 
-	return &IncludedModule{Name: fileBaseName(include.Path), Module: incM}, nil
+	return nil, includeError{
+		Include: include,
+		Reason:  includeNotFoundError{Searched: searched},
+	}
+}
+
+// includeCandidates returns the paths at which the file included by the
+// given module with the given path may be found, in the order in which they
+// should be tried.
+func (c compiler) includeCandidates(m *Module, p string) []string {
+	if filepath.IsAbs(p) {
+		return []string{p}
+	}
+
+	candidates := make([]string, 0, len(c.includePaths)+1)
+	candidates = append(candidates, filepath.Join(filepath.Dir(m.ThriftPath), p))
+	for _, dir := range c.includePaths {
+		candidates = append(candidates, filepath.Join(dir, p))
+	}
+	return candidates
 }
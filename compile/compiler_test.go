@@ -21,11 +21,13 @@
 package compile
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/thriftrw/idl"
 	"go.uber.org/thriftrw/wire"
 )
 
@@ -54,6 +56,60 @@ func TestFilesystem(t *testing.T) {
 	assert.Equal(t, wire.TStruct, sType.TypeCode(), "Type mismatch")
 }
 
+func TestMapFS(t *testing.T) {
+	fs := MapFS{
+		"main.thrift": `
+			include "./shared/shared.thrift"
+
+			struct S {
+				1: optional shared.UUID uuid;
+			}
+		`,
+		"/shared/shared.thrift": `
+			typedef string UUID;
+		`,
+	}
+
+	module, err := Compile("main.thrift", Filesystem(fs))
+	require.NoError(t, err, "Compile failed")
+	assert.Equal(t, "/main.thrift", module.ThriftPath)
+	assert.Contains(t, module.Includes, "shared")
+
+	_, err = Compile("missing.thrift", Filesystem(fs))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "/missing.thrift")
+	}
+}
+
+func TestCompileConcurrent(t *testing.T) {
+	fs := MapFS{
+		"main.thrift": `
+			include "./shared.thrift"
+
+			const shared.UUID defaultID = "foo"
+
+			struct S {
+				1: optional shared.UUID uuid = defaultID
+			}
+		`,
+		"shared.thrift": `typedef string UUID`,
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			module, err := Compile("main.thrift", Filesystem(fs))
+			if assert.NoError(t, err, "Compile failed") {
+				_, err = module.LookupType("S")
+				assert.NoError(t, err, "Lookup S failed")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 func TestCompile(t *testing.T) {
 	module, err := Compile("../gen/testdata/thrift/services.thrift")
 	require.NoError(t, err, "Compile failed")
@@ -86,3 +142,354 @@ func TestCompileNonStrict(t *testing.T) {
 	require.NoError(t, err, "Failed to find UUID field in struct")
 	assert.False(t, uuidField.Required, "Unspecified requiredness should be treated as optional")
 }
+
+func TestCompileIncludeAs(t *testing.T) {
+	files := map[string]string{
+		"/some/prefix/main.thrift": `
+			include "./a/shared.thrift" as sa
+			include "./b/shared.thrift" as sb
+
+			struct S {
+				1: optional sa.UUID a;
+				2: optional sb.UUID b;
+			}
+		`,
+		"/some/prefix/a/shared.thrift": `
+			typedef string UUID;
+		`,
+		"/some/prefix/b/shared.thrift": `
+			typedef binary UUID;
+		`,
+	}
+
+	fs := dummyFS{"/some/prefix/", files}
+
+	module, err := Compile("main.thrift", Filesystem(fs))
+	require.NoError(t, err, "Compile failed")
+
+	require.Len(t, module.Includes, 2)
+	assert.Equal(t, "sa", module.Includes["sa"].Name)
+	assert.Equal(t, "/some/prefix/a/shared.thrift", module.Includes["sa"].Module.ThriftPath)
+	assert.Equal(t, "sb", module.Includes["sb"].Name)
+	assert.Equal(t, "/some/prefix/b/shared.thrift", module.Includes["sb"].Module.ThriftPath)
+
+	sType, err := module.LookupType("S")
+	require.NoError(t, err, "Lookup S failed")
+
+	fields := sType.(*StructSpec).Fields
+	a, err := fields.FindByName("a")
+	require.NoError(t, err)
+	assert.Equal(t, wire.TBinary, a.Type.TypeCode())
+	assert.Equal(t, "/some/prefix/a/shared.thrift", a.Type.ThriftFile())
+
+	b, err := fields.FindByName("b")
+	require.NoError(t, err)
+	assert.Equal(t, "/some/prefix/b/shared.thrift", b.Type.ThriftFile())
+}
+
+func TestCompileIncludeAsFailure(t *testing.T) {
+	tests := []struct {
+		desc      string
+		main      string
+		wantError string
+	}{
+		{
+			desc: "conflicting names",
+			main: `
+				include "./a/shared.thrift"
+				include "./b/shared.thrift"
+			`,
+			wantError: `cannot include "./b/shared.thrift" as "" on line 3`,
+		},
+		{
+			desc: "conflicting aliases",
+			main: `
+				include "./a/shared.thrift" as s
+				include "./b/shared.thrift" as s
+			`,
+			wantError: `cannot include "./b/shared.thrift" as "s" on line 3`,
+		},
+		{
+			desc: "alias conflicts with definition",
+			main: `
+				include "./a/shared.thrift" as S
+				struct S {}
+			`,
+			wantError: `"S"`,
+		},
+		{
+			desc:      "qualified alias",
+			main:      `include "./a/shared.thrift" as foo.bar`,
+			wantError: "include names may not contain '.'",
+		},
+	}
+
+	for _, tt := range tests {
+		fs := dummyFS{"/some/prefix/", map[string]string{
+			"/some/prefix/main.thrift":     tt.main,
+			"/some/prefix/a/shared.thrift": `typedef string UUID`,
+			"/some/prefix/b/shared.thrift": `typedef string UUID`,
+		}}
+
+		_, err := Compile("main.thrift", Filesystem(fs))
+		if assert.Error(t, err, tt.desc) {
+			assert.Contains(t, err.Error(), tt.wantError, tt.desc)
+		}
+	}
+}
+
+func TestCompileIncludePaths(t *testing.T) {
+	fs := MapFS{
+		"/idl/main.thrift":            `include "shared.thrift"`,
+		"/idl/local.thrift":           `include "local/shared.thrift"`,
+		"/idl/local/shared.thrift":    `typedef string UUID`,
+		"/idl/absolute.thrift":        `include "/third/shared.thrift"`,
+		"/idl/nested.thrift":          `include "nested/outer.thrift"`,
+		"/first/nested/outer.thrift":  `include "inner.thrift"`,
+		"/first/nested/inner.thrift":  `typedef string UUID`,
+		"/first/shared.thrift":        `typedef string UUID`,
+		"/second/shared.thrift":       `typedef string UUID`,
+		"/second/local/shared.thrift": `typedef string UUID`,
+		"/third/shared.thrift":        `typedef string UUID`,
+	}
+
+	tests := []struct {
+		desc         string
+		file         string
+		includePaths []string
+
+		// Path of the module included by file, or the error message.
+		want      string
+		wantError string
+	}{
+		{
+			desc:      "no include paths",
+			file:      "/idl/main.thrift",
+			wantError: `cannot include "shared.thrift" as "" on line 1: not found, searched: /idl/shared.thrift`,
+		},
+		{
+			desc:         "first include path",
+			file:         "/idl/main.thrift",
+			includePaths: []string{"/first", "/second"},
+			want:         "/first/shared.thrift",
+		},
+		{
+			desc:         "later include path",
+			file:         "/idl/main.thrift",
+			includePaths: []string{"/third/missing", "/second"},
+			want:         "/second/shared.thrift",
+		},
+		{
+			desc:         "relative include path",
+			file:         "/idl/main.thrift",
+			includePaths: []string{"second"},
+			want:         "/second/shared.thrift",
+		},
+		{
+			desc:         "relative to including file first",
+			file:         "/idl/local.thrift",
+			includePaths: []string{"/second"},
+			want:         "/idl/local/shared.thrift",
+		},
+		{
+			desc:         "absolute include",
+			file:         "/idl/absolute.thrift",
+			includePaths: []string{"/first"},
+			want:         "/third/shared.thrift",
+		},
+		{
+			desc:         "relative to included file",
+			file:         "/idl/nested.thrift",
+			includePaths: []string{"/first"},
+			want:         "/first/nested/outer.thrift",
+		},
+		{
+			desc:         "not found",
+			file:         "/idl/main.thrift",
+			includePaths: []string{"/missing", "/third/missing"},
+			wantError: `cannot include "shared.thrift" as "" on line 1: not found, searched: ` +
+				"/idl/shared.thrift, /missing/shared.thrift, /third/missing/shared.thrift",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			m, err := Compile(tt.file, Filesystem(fs), IncludePaths(tt.includePaths...))
+			if tt.wantError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, m.Includes, 1)
+			for _, inc := range m.Includes {
+				assert.Equal(t, tt.want, inc.Module.ThriftPath)
+			}
+		})
+	}
+}
+
+func TestCompileFileAnnotations(t *testing.T) {
+	fs := dummyFS{"/some/prefix/", map[string]string{
+		"/some/prefix/main.thrift": `
+			namespace go foo (go.package = "fooapi")
+			namespace java com.example.foo (java.final = "true")
+		`,
+	}}
+
+	module, err := Compile("main.thrift", Filesystem(fs))
+	require.NoError(t, err, "Compile failed")
+	assert.Equal(t, Annotations{
+		"go.package": "fooapi",
+		"java.final": "true",
+	}, module.Annotations)
+}
+
+func TestCompileNamespaces(t *testing.T) {
+	fs := dummyFS{"/some/prefix/", map[string]string{
+		"/some/prefix/main.thrift": `
+			namespace * example.foo
+			namespace java com.example.foo
+			py_module example.foo.py
+			namespace java com.example.foo2
+		`,
+	}}
+
+	module, err := Compile("main.thrift", Filesystem(fs))
+	require.NoError(t, err, "Compile failed")
+	assert.Equal(t, []*Namespace{
+		{Scope: "*", Name: "example.foo"},
+		{Scope: "java", Name: "com.example.foo"},
+		{Scope: "py", Name: "example.foo.py"},
+		{Scope: "java", Name: "com.example.foo2"},
+	}, module.Namespaces)
+
+	tests := []struct {
+		scope  string
+		want   string
+		wantOK bool
+	}{
+		{scope: "java", want: "com.example.foo2", wantOK: true},
+		{scope: "py", want: "example.foo.py", wantOK: true},
+		{scope: "go", want: "example.foo", wantOK: true},
+		{scope: "*", want: "example.foo", wantOK: true},
+	}
+	for _, tt := range tests {
+		got, ok := module.LookupNamespace(tt.scope)
+		assert.Equal(t, tt.wantOK, ok, tt.scope)
+		assert.Equal(t, tt.want, got, tt.scope)
+	}
+
+	_, ok := (&Module{}).LookupNamespace("go")
+	assert.False(t, ok, "modules without namespaces must not have any")
+}
+
+func TestCompileFileAnnotationsConflict(t *testing.T) {
+	fs := dummyFS{"/some/prefix/", map[string]string{
+		"/some/prefix/main.thrift": `
+			namespace go foo (go.package = "fooapi")
+			namespace * foo (go.package = "barapi")
+		`,
+	}}
+
+	_, err := Compile("main.thrift", Filesystem(fs))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `cannot compile "namespace"`)
+		assert.Contains(t, err.Error(), "go.package")
+	}
+}
+
+func TestCompileDocs(t *testing.T) {
+	fs := dummyFS{"/some/prefix/", map[string]string{
+		"/some/prefix/main.thrift": `
+			/** A constant. */
+			const i32 answer = 42
+
+			/** An enum. */
+			enum Color {
+				/** An item. */
+				Red
+			}
+
+			/** A struct. */
+			struct Point {
+				/** A field. */
+				1: required double x
+			}
+
+			/** A service. */
+			service Points {
+				/** A function. */
+				Point getPoint(
+					/** An argument. */
+					1: string name
+				)
+			}
+		`,
+	}}
+
+	module, err := Compile("main.thrift", Filesystem(fs))
+	require.NoError(t, err, "Compile failed")
+
+	assert.Equal(t, "A constant.", module.Constants["answer"].Doc)
+
+	color := module.Types["Color"].(*EnumSpec)
+	assert.Equal(t, "An enum.", color.Doc)
+	assert.Equal(t, "An item.", color.Items[0].Doc)
+
+	point := module.Types["Point"].(*StructSpec)
+	assert.Equal(t, "A struct.", point.Doc)
+	assert.Equal(t, "A field.", point.Fields[0].Doc)
+
+	svc := module.Services["Points"]
+	assert.Equal(t, "A service.", svc.Doc)
+	assert.Equal(t, "A function.", svc.Functions["getPoint"].Doc)
+	assert.Equal(t, "An argument.", svc.Functions["getPoint"].ArgsSpec[0].Doc)
+}
+
+func TestCompileDeprecated(t *testing.T) {
+	files := map[string]string{
+		"/some/prefix/main.thrift": `
+			php_namespace Foo
+			senum Status { "a", "b" }
+		`,
+	}
+
+	fs := dummyFS{"/some/prefix/", files}
+
+	var warnings []Warning
+	module, err := Compile("main.thrift", Filesystem(fs), Warnings(func(w Warning) {
+		warnings = append(warnings, w)
+	}))
+	require.NoError(t, err, "Compile failed")
+
+	status, err := module.LookupType("Status")
+	require.NoError(t, err, "Lookup Status failed")
+	if assert.IsType(t, &TypedefSpec{}, status) {
+		assert.Equal(t, &StringSpec{}, status.(*TypedefSpec).Target)
+	}
+
+	if assert.Len(t, warnings, 2) {
+		assert.Equal(t, "/some/prefix/main.thrift", warnings[0].Path)
+		assert.Equal(t, 2, warnings[0].Line)
+		assert.Equal(t, 3, warnings[1].Line)
+	}
+}
+
+func TestCompileInterner(t *testing.T) {
+	fs := MapFS{
+		"a.thrift": `struct User { 1: optional string name }`,
+		"b.thrift": `struct User { 1: optional string name }`,
+	}
+
+	var interner idl.Interner
+	a, err := Compile("a.thrift", Filesystem(fs), Interner(&interner))
+	require.NoError(t, err)
+	b, err := Compile("b.thrift", Filesystem(fs), Interner(&interner))
+	require.NoError(t, err)
+
+	assert.Contains(t, a.Types, "User")
+	assert.Contains(t, b.Types, "User")
+	assert.Equal(t, 2, interner.Len(), "User and name must be shared")
+}
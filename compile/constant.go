@@ -34,6 +34,7 @@ type Constant struct {
 	File  string
 	Type  TypeSpec
 	Value ConstantValue
+	Doc   string
 }
 
 // compileConstant builds a Constant from the given AST constant.
@@ -48,6 +49,7 @@ func compileConstant(file string, src *ast.Constant) (*Constant, error) {
 		File:  file,
 		Type:  typ,
 		Value: compileConstantValue(src.Value),
+		Doc:   src.Doc,
 	}, nil
 }
 
@@ -68,6 +70,21 @@ func (c *Constant) Link(scope Scope) (err error) {
 	return nil
 }
 
+// ThriftName is the name of this constant as it appears in the Thrift file.
+func (c *Constant) ThriftName() string {
+	return c.Name
+}
+
+// ThriftAnnotations returns nil. Constants cannot be annotated in Thrift.
+func (c *Constant) ThriftAnnotations() Annotations {
+	return nil
+}
+
+// ThriftFile is the Thrift file in which this constant was defined.
+func (c *Constant) ThriftFile() string {
+	return c.File
+}
+
 func (c *Constant) String() string {
 	return fmt.Sprintf("Constant(%s %s)", c.Type.ThriftName(), c.Name)
 }
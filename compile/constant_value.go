@@ -23,8 +23,10 @@ package compile
 import (
 	"errors"
 	"fmt"
+	"sort"
 
 	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/uuid"
 )
 
 // ConstantValue represents a compiled constant value or a reference to one.
@@ -127,10 +129,17 @@ func (c ConstantInt) Link(scope Scope, t TypeSpec) (ConstantValue, error) {
 // Link for ConstantString.
 func (c ConstantString) Link(scope Scope, t TypeSpec) (ConstantValue, error) {
 	// TODO(abg): Are binary literals a thing?
-	if _, ok := RootTypeSpec(t).(*StringSpec); !ok {
+	switch RootTypeSpec(t).(type) {
+	case *StringSpec:
+		return c, nil
+	case *UUIDSpec:
+		if _, err := uuid.Parse(string(c)); err != nil {
+			return nil, constantValueCastError{Value: c, Type: t, Reason: err}
+		}
+		return c, nil
+	default:
 		return nil, constantValueCastError{Value: c, Type: t}
 	}
-	return c, nil
 }
 
 // Link for ConstantDouble.
@@ -199,6 +208,26 @@ func (c *ConstantStruct) Link(scope Scope, t TypeSpec) (ConstantValue, error) {
 		c.Fields[field.Name] = f
 	}
 
+	known := make(map[string]struct{}, len(s.Fields))
+	for _, field := range s.Fields {
+		known[field.Name] = struct{}{}
+	}
+
+	var unknown []string
+	for name := range c.Fields {
+		if _, ok := known[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, constantValueCastError{
+			Value:  c,
+			Type:   t,
+			Reason: fmt.Errorf("%q is not a field of %q", unknown[0], s.Name),
+		}
+	}
+
 	return c, nil
 }
 
@@ -240,6 +240,18 @@ func TestCastConstants(t *testing.T) {
 			give: ConstantString("foo"),
 			want: ConstantString("foo"),
 		},
+		{
+			desc: "ConstantString: uuid",
+			typ:  &UUIDSpec{},
+			give: ConstantString("123e4567-e89b-12d3-a456-426614174000"),
+			want: ConstantString("123e4567-e89b-12d3-a456-426614174000"),
+		},
+		{
+			desc:      "ConstantString: uuid (failure)",
+			typ:       &UUIDSpec{},
+			give:      ConstantString("foo"),
+			wantError: `cannot cast foo to "uuid"`,
+		},
 		{
 			desc: "ConstantDouble",
 			typ:  &DoubleSpec{},
@@ -295,6 +307,18 @@ func TestCastConstants(t *testing.T) {
 			},
 			wantError: `failed to cast field "someRequiredField": cannot cast foo to "i32"`,
 		},
+		{
+			desc: "ConstantStruct: unknown field",
+			typ:  someStruct,
+			give: &ConstantStruct{
+				Fields: map[string]ConstantValue{
+					"someRequiredField": ConstantInt(100),
+					"someUnknownField":  ConstantInt(1),
+					"anotherField":      ConstantInt(2),
+				},
+			},
+			wantError: `"anotherField" is not a field of "SomeStruct"`,
+		},
 		{
 			desc: "ConstantMap",
 			typ:  &MapSpec{KeySpec: &StringSpec{}, ValueSpec: &I32Spec{}},
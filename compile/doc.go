@@ -23,4 +23,34 @@
 //
 // Files are parsed using the Compile method, which will return a Module that
 // contains the types and services defined in the Thrift file.
+//
+// The compiled model does not depend on code generation and may be consumed
+// directly by other tools such as documentation generators, schema
+// registries, or gateways. Fully linked modules are returned: references to
+// types, constants and services, including those in included files, are
+// resolved to their definitions.
+//
+// 	module, err := compile.Compile("service.thrift")
+// 	if err != nil {
+// 		return err
+// 	}
+//
+// 	for name, service := range module.Services {
+// 		// ...
+// 	}
+//
+// Types, constants, services, functions, fields and enum items all
+// implement NamedEntity, which provides access to their names and
+// annotations. Use Walk to visit all entities defined in a module in a
+// deterministic order, and Module.Walk to visit all modules in a module tree.
+//
+// 	compile.Walk(compile.VisitorFunc(func(w compile.Walker, e compile.NamedEntity) {
+// 		if doc, ok := e.ThriftAnnotations()["doc"]; ok {
+// 			fmt.Println(e.ThriftName(), doc)
+// 		}
+// 	}), module)
+//
+// Compile keeps no package-level state and may be called concurrently. The
+// returned Module is not modified afterwards and may be read from multiple
+// goroutines.
 package compile
@@ -0,0 +1,119 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compile
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	docExampleAnnotation = "doc.example"
+	docFormatAnnotation  = "doc.format"
+)
+
+// docFormats is the set of values accepted by the doc.format annotation.
+// These are the formats defined by JSON Schema and OpenAPI.
+var docFormats = map[string]struct{}{
+	"binary":                {},
+	"byte":                  {},
+	"date":                  {},
+	"date-time":             {},
+	"double":                {},
+	"duration":              {},
+	"email":                 {},
+	"float":                 {},
+	"hostname":              {},
+	"idn-email":             {},
+	"idn-hostname":          {},
+	"int32":                 {},
+	"int64":                 {},
+	"ipv4":                  {},
+	"ipv6":                  {},
+	"iri":                   {},
+	"iri-reference":         {},
+	"json-pointer":          {},
+	"password":              {},
+	"regex":                 {},
+	"relative-json-pointer": {},
+	"time":                  {},
+	"uri":                   {},
+	"uri-reference":         {},
+	"uri-template":          {},
+	"uuid":                  {},
+}
+
+// Doc contains documentation hints for a Thrift entity. These are specified
+// with annotations and are intended for tools which export documentation or
+// schemas from compiled Thrift files.
+//
+// 	struct User {
+// 		1: required string email (doc.format = "email", doc.example = "jane@example.com")
+// 	}
+type Doc struct {
+	// Example value for the entity, specified with doc.example.
+	Example string
+
+	// Format of the entity's values, specified with doc.format. This is one
+	// of the formats defined by JSON Schema or OpenAPI, e.g. "email" or
+	// "date-time".
+	Format string
+}
+
+// LookupDoc returns the documentation hints specified with annotations on
+// the given entity.
+func LookupDoc(e NamedEntity) Doc {
+	annotations := e.ThriftAnnotations()
+	return Doc{
+		Example: annotations[docExampleAnnotation],
+		Format:  annotations[docFormatAnnotation],
+	}
+}
+
+// validateDocAnnotations verifies that the doc annotations in the given map,
+// if any, are valid.
+func validateDocAnnotations(annotations Annotations) error {
+	format, ok := annotations[docFormatAnnotation]
+	if !ok {
+		return nil
+	}
+
+	if _, ok := docFormats[format]; !ok {
+		return invalidDocFormatError{Format: format}
+	}
+	return nil
+}
+
+type invalidDocFormatError struct {
+	Format string
+}
+
+func (e invalidDocFormatError) Error() string {
+	formats := make([]string, 0, len(docFormats))
+	for f := range docFormats {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+
+	return fmt.Sprintf("unknown %v %q: must be one of %v",
+		docFormatAnnotation, e.Format, strings.Join(formats, ", "))
+}
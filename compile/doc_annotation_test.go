@@ -0,0 +1,110 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupDoc(t *testing.T) {
+	fs := dummyFS{"/", map[string]string{
+		"/main.thrift": `
+			typedef string UUID (doc.format = "uuid")
+
+			enum Role {
+				Admin (doc.example = "the owner")
+				Member
+			}
+
+			struct User {
+				1: required UUID id (doc.example = "123e4567-e89b-12d3-a456-426655440000")
+				2: required string email (
+					doc.format = "email",
+					doc.example = "jane@example.com",
+				)
+				3: optional Role role
+			} (doc.example = '{"email": "jane@example.com"}')
+		`,
+	}}
+
+	module, err := Compile("main.thrift", Filesystem(fs))
+	require.NoError(t, err)
+
+	user := module.Types["User"].(*StructSpec)
+	role := module.Types["Role"].(*EnumSpec)
+
+	tests := []struct {
+		desc   string
+		entity NamedEntity
+		want   Doc
+	}{
+		{
+			desc:   "typedef",
+			entity: module.Types["UUID"],
+			want:   Doc{Format: "uuid"},
+		},
+		{
+			desc:   "enum item",
+			entity: &role.Items[0],
+			want:   Doc{Example: "the owner"},
+		},
+		{
+			desc:   "struct",
+			entity: user,
+			want:   Doc{Example: `{"email": "jane@example.com"}`},
+		},
+		{
+			desc:   "field with example",
+			entity: user.Fields[0],
+			want:   Doc{Example: "123e4567-e89b-12d3-a456-426655440000"},
+		},
+		{
+			desc:   "field with format and example",
+			entity: user.Fields[1],
+			want:   Doc{Format: "email", Example: "jane@example.com"},
+		},
+		{
+			desc:   "no annotations",
+			entity: user.Fields[2],
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, LookupDoc(tt.entity), tt.desc)
+	}
+}
+
+func TestInvalidDocFormat(t *testing.T) {
+	fs := dummyFS{"/", map[string]string{
+		"/main.thrift": `
+			struct User {
+				1: required string email (doc.format = "e-mail")
+			}
+		`,
+	}}
+
+	_, err := Compile("main.thrift", Filesystem(fs))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown doc.format "e-mail": must be one of binary, byte,`)
+}
@@ -60,10 +60,10 @@ func TestCompileEnumSuccess(t *testing.T) {
 				Name: "Role",
 				File: "test.thrift",
 				Items: []EnumItem{
-					{"Disabled", 0, nil},
-					{"User", 1, nil},
-					{"Moderator", 2, nil},
-					{"Admin", 3, nil},
+					{"Disabled", 0, nil, ""},
+					{"User", 1, nil, ""},
+					{"Moderator", 2, nil, ""},
+					{"Admin", 3, nil, ""},
 				},
 			},
 		},
@@ -74,8 +74,8 @@ func TestCompileEnumSuccess(t *testing.T) {
 				Name: "CommentStatus",
 				File: "test.thrift",
 				Items: []EnumItem{
-					{"Visible", 12345, nil},
-					{"Hidden", 54321, nil},
+					{"Visible", 12345, nil, ""},
+					{"Hidden", 54321, nil, ""},
 				},
 			},
 		},
@@ -86,11 +86,11 @@ func TestCompileEnumSuccess(t *testing.T) {
 				Name: "foo",
 				File: "test.thrift",
 				Items: []EnumItem{
-					{"A", 0, nil},
-					{"B", 1, nil},
-					{"C", 10, nil},
-					{"D", 11, nil},
-					{"E", 12, nil},
+					{"A", 0, nil, ""},
+					{"B", 1, nil, ""},
+					{"C", 10, nil, ""},
+					{"D", 11, nil, ""},
+					{"E", 12, nil, ""},
 				},
 			},
 		},
@@ -101,11 +101,11 @@ func TestCompileEnumSuccess(t *testing.T) {
 				Name: "bar",
 				File: "test.thrift",
 				Items: []EnumItem{
-					{"A", 0, nil},
-					{"B", 0, nil},
-					{"C", 1, nil},
-					{"D", 0, nil},
-					{"E", 1, nil},
+					{"A", 0, nil, ""},
+					{"B", 0, nil, ""},
+					{"C", 1, nil, ""},
+					{"D", 0, nil, ""},
+					{"E", 1, nil, ""},
 				},
 			},
 		},
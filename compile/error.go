@@ -56,14 +56,6 @@ func (e fileCompileError) Error() string {
 	return fmt.Sprintf("could not compile file %q: %v", e.Path, e.Reason)
 }
 
-// includeAsDisabledError is raised when the user attempts to use the include-as
-// syntax without explicitly enabling it.
-type includeAsDisabledError struct{}
-
-func (e includeAsDisabledError) Error() string {
-	return "include-as syntax is currently disabled"
-}
-
 // includeError is raised when there is an error including another Thrift
 // file.
 type includeError struct {
@@ -78,6 +70,16 @@ func (e includeError) Error() string {
 	)
 }
 
+// includeNotFoundError is raised when an included file could not be found
+// at any of the paths searched for it.
+type includeNotFoundError struct {
+	Searched []string
+}
+
+func (e includeNotFoundError) Error() string {
+	return fmt.Sprintf("not found, searched: %v", strings.Join(e.Searched, ", "))
+}
+
 // definitionError is raised when there was an error compiling a definition
 // from the Thrift file.
 type definitionError struct {
@@ -0,0 +1,248 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compile
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"go.uber.org/thriftrw/uuid"
+	"go.uber.org/thriftrw/wire"
+)
+
+// EvaluateConst evaluates a constant value of the given type into its
+// wire representation.
+//
+// References to other constants and enum items are resolved, and values are
+// converted to the given type following the same rules as the compiler: for
+// example, integers are accepted for doubles, and 0 and 1 for booleans.
+// Omitted fields of struct constants are filled with their default values.
+//
+// The value and type must belong to a compiled Module. Evaluating a named
+// constant looks like,
+//
+// 	v, err := compile.EvaluateConst(c.Value, c.Type)
+//
+// Default values of struct fields may be evaluated the same way with
+// FieldSpec.Default and FieldSpec.Type.
+func EvaluateConst(v ConstantValue, t TypeSpec) (wire.Value, error) {
+	rt := RootTypeSpec(t)
+	switch v := v.(type) {
+	case ConstReference:
+		w, err := EvaluateConst(v.Target.Value, t)
+		if err != nil {
+			return wire.Value{}, constantCastError{Name: v.Target.Name, Reason: err}
+		}
+		return w, nil
+
+	case EnumItemReference:
+		if rt != v.Enum {
+			return wire.Value{}, constantValueCastError{Value: v, Type: t}
+		}
+		return wire.NewValueI32(v.Item.Value), nil
+
+	case ConstantBool:
+		switch rt.(type) {
+		case *BoolSpec:
+			return wire.NewValueBool(bool(v)), nil
+		}
+
+	case ConstantInt:
+		return evaluateConstantInt(v, t)
+
+	case ConstantDouble:
+		switch rt.(type) {
+		case *DoubleSpec:
+			return wire.NewValueDouble(float64(v)), nil
+		}
+
+	case ConstantString:
+		switch rt.(type) {
+		case *StringSpec, *BinarySpec:
+			return wire.NewValueBinary([]byte(v)), nil
+		case *UUIDSpec:
+			u, err := uuid.Parse(string(v))
+			if err != nil {
+				return wire.Value{}, constantValueCastError{Value: v, Type: t, Reason: err}
+			}
+			return u.ToWire()
+		}
+
+	case *ConstantStruct:
+		if s, ok := rt.(*StructSpec); ok {
+			return evaluateConstantStruct(v, s, t)
+		}
+
+	case ConstantMap:
+		if m, ok := rt.(*MapSpec); ok {
+			return evaluateConstantMap(v, m)
+		}
+
+	case ConstantList:
+		switch spec := rt.(type) {
+		case *ListSpec:
+			items, err := evaluateConstantValues(v, spec.ValueSpec)
+			if err != nil {
+				return wire.Value{}, err
+			}
+			return wire.NewValueList(
+				wire.ValueListFromSlice(spec.ValueSpec.TypeCode(), items)), nil
+		case *SetSpec:
+			return EvaluateConst(ConstantSet(v), t)
+		}
+
+	case ConstantSet:
+		if s, ok := rt.(*SetSpec); ok {
+			items, err := evaluateConstantValues(v, s.ValueSpec)
+			if err != nil {
+				return wire.Value{}, err
+			}
+			return wire.NewValueSet(
+				wire.ValueListFromSlice(s.ValueSpec.TypeCode(), items)), nil
+		}
+
+	case constantReference:
+		return wire.Value{}, constantValueCastError{
+			Value:  v,
+			Type:   t,
+			Reason: fmt.Errorf("reference to %q was not resolved", v.Name),
+		}
+	}
+
+	return wire.Value{}, constantValueCastError{Value: v, Type: t}
+}
+
+func evaluateConstantInt(v ConstantInt, t TypeSpec) (wire.Value, error) {
+	i := int64(v)
+	switch spec := RootTypeSpec(t).(type) {
+	case *I8Spec:
+		if i >= math.MinInt8 && i <= math.MaxInt8 {
+			return wire.NewValueI8(int8(i)), nil
+		}
+	case *I16Spec:
+		if i >= math.MinInt16 && i <= math.MaxInt16 {
+			return wire.NewValueI16(int16(i)), nil
+		}
+	case *I32Spec:
+		if i >= math.MinInt32 && i <= math.MaxInt32 {
+			return wire.NewValueI32(int32(i)), nil
+		}
+	case *I64Spec:
+		return wire.NewValueI64(i), nil
+	case *DoubleSpec:
+		return wire.NewValueDouble(float64(i)), nil
+	case *BoolSpec:
+		if i == 0 || i == 1 {
+			return wire.NewValueBool(i == 1), nil
+		}
+		return wire.Value{}, constantValueCastError{
+			Value:  v,
+			Type:   t,
+			Reason: errors.New("the value must be 0 or 1"),
+		}
+	case *EnumSpec:
+		for _, item := range spec.Items {
+			if int64(item.Value) == i {
+				return wire.NewValueI32(item.Value), nil
+			}
+		}
+		return wire.Value{}, constantValueCastError{
+			Value: v,
+			Type:  t,
+			Reason: fmt.Errorf(
+				"%v is not a valid value for enum %q", i, spec.ThriftName()),
+		}
+	default:
+		return wire.Value{}, constantValueCastError{Value: v, Type: t}
+	}
+
+	return wire.Value{}, constantValueCastError{
+		Value:  v,
+		Type:   t,
+		Reason: errors.New("the value is out of range"),
+	}
+}
+
+func evaluateConstantStruct(v *ConstantStruct, s *StructSpec, t TypeSpec) (wire.Value, error) {
+	fields := make([]wire.Field, 0, len(s.Fields))
+	for _, f := range s.Fields {
+		fv, ok := v.Fields[f.Name]
+		if !ok {
+			fv = f.Default
+		}
+		if fv == nil {
+			if f.Required {
+				return wire.Value{}, constantValueCastError{
+					Value:  v,
+					Type:   t,
+					Reason: fmt.Errorf("%q is a required field", f.Name),
+				}
+			}
+			continue
+		}
+
+		w, err := EvaluateConst(fv, f.Type)
+		if err != nil {
+			return wire.Value{}, constantValueCastError{
+				Value: v,
+				Type:  t,
+				Reason: constantStructFieldCastError{
+					FieldName: f.Name,
+					Reason:    err,
+				},
+			}
+		}
+		fields = append(fields, wire.Field{ID: f.ID, Value: w})
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields}), nil
+}
+
+func evaluateConstantMap(v ConstantMap, m *MapSpec) (wire.Value, error) {
+	items := make([]wire.MapItem, len(v))
+	for i, pair := range v {
+		key, err := EvaluateConst(pair.Key, m.KeySpec)
+		if err != nil {
+			return wire.Value{}, err
+		}
+
+		value, err := EvaluateConst(pair.Value, m.ValueSpec)
+		if err != nil {
+			return wire.Value{}, err
+		}
+
+		items[i] = wire.MapItem{Key: key, Value: value}
+	}
+	return wire.NewValueMap(wire.MapItemListFromSlice(
+		m.KeySpec.TypeCode(), m.ValueSpec.TypeCode(), items)), nil
+}
+
+func evaluateConstantValues(vs []ConstantValue, t TypeSpec) ([]wire.Value, error) {
+	items := make([]wire.Value, len(vs))
+	for i, v := range vs {
+		w, err := EvaluateConst(v, t)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = w
+	}
+	return items, nil
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compile
+
+import (
+	"testing"
+
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateConst(t *testing.T) {
+	fs := dummyFS{"/", map[string]string{
+		"/shared.thrift": `
+			typedef i64 Timestamp
+			const Timestamp Epoch = 0
+		`,
+		"/main.thrift": `
+			include "./shared.thrift"
+
+			enum Color { Red, Green = 5 }
+
+			struct Point {
+				1: required double x
+				2: required double y = 1
+				3: optional string label
+			}
+
+			const i8 Byte = 42
+			const i16 Short = -1
+			const i32 Int = 1
+			const double Double = 3
+			const bool Yes = 1
+			const bool No = false
+			const string Hello = "hello"
+			const Color Favorite = Color.Green
+			const Color FromInt = 0
+			const shared.Timestamp Start = shared.Epoch
+			const i64 IntRef = Int
+
+			const list<i32> Ints = [1, Int]
+			const set<string> Strings = ["a", Hello]
+			const map<Color, list<double>> Colors = {Color.Red: [1], 5: [2.5]}
+			const Point Origin = {"x": 0}
+			const list<Point> Points = [Origin, {"x": 1, "y": 2, "label": "one"}]
+		`,
+	}}
+
+	module, err := Compile("main.thrift", Filesystem(fs))
+	require.NoError(t, err)
+
+	origin := wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+		{ID: 1, Value: wire.NewValueDouble(0)},
+		{ID: 2, Value: wire.NewValueDouble(1)},
+	}})
+
+	tests := []struct {
+		name string
+		want wire.Value
+	}{
+		{"Byte", wire.NewValueI8(42)},
+		{"Short", wire.NewValueI16(-1)},
+		{"Int", wire.NewValueI32(1)},
+		{"Double", wire.NewValueDouble(3)},
+		{"Yes", wire.NewValueBool(true)},
+		{"No", wire.NewValueBool(false)},
+		{"Hello", wire.NewValueBinary([]byte("hello"))},
+		{"Favorite", wire.NewValueI32(5)},
+		{"FromInt", wire.NewValueI32(0)},
+		{"Start", wire.NewValueI64(0)},
+		{"IntRef", wire.NewValueI64(1)},
+		{
+			"Ints",
+			wire.NewValueList(wire.ValueListFromSlice(wire.TI32, []wire.Value{
+				wire.NewValueI32(1),
+				wire.NewValueI32(1),
+			})),
+		},
+		{
+			"Strings",
+			wire.NewValueSet(wire.ValueListFromSlice(wire.TBinary, []wire.Value{
+				wire.NewValueBinary([]byte("a")),
+				wire.NewValueBinary([]byte("hello")),
+			})),
+		},
+		{
+			"Colors",
+			wire.NewValueMap(wire.MapItemListFromSlice(wire.TI32, wire.TList, []wire.MapItem{
+				{
+					Key: wire.NewValueI32(0),
+					Value: wire.NewValueList(wire.ValueListFromSlice(wire.TDouble, []wire.Value{
+						wire.NewValueDouble(1),
+					})),
+				},
+				{
+					Key: wire.NewValueI32(5),
+					Value: wire.NewValueList(wire.ValueListFromSlice(wire.TDouble, []wire.Value{
+						wire.NewValueDouble(2.5),
+					})),
+				},
+			})),
+		},
+		{"Origin", origin},
+		{
+			"Points",
+			wire.NewValueList(wire.ValueListFromSlice(wire.TStruct, []wire.Value{
+				origin,
+				wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+					{ID: 1, Value: wire.NewValueDouble(1)},
+					{ID: 2, Value: wire.NewValueDouble(2)},
+					{ID: 3, Value: wire.NewValueBinary([]byte("one"))},
+				}}),
+			})),
+		},
+	}
+
+	for _, tt := range tests {
+		c, err := module.LookupConstant(tt.name)
+		require.NoError(t, err, tt.name)
+
+		got, err := EvaluateConst(c.Value, c.Type)
+		if assert.NoError(t, err, tt.name) {
+			assert.True(t, wire.ValuesAreEqual(tt.want, got),
+				"%v: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	point, err := module.LookupType("Point")
+	require.NoError(t, err)
+	y := point.(*StructSpec).Fields[1]
+	got, err := EvaluateConst(y.Default, y.Type)
+	require.NoError(t, err)
+	assert.True(t, wire.ValuesAreEqual(wire.NewValueDouble(1), got))
+}
+
+func TestEvaluateConstFailure(t *testing.T) {
+	tests := []struct {
+		desc     string
+		value    ConstantValue
+		typ      TypeSpec
+		messages []string
+	}{
+		{
+			desc:     "type mismatch",
+			value:    ConstantString("foo"),
+			typ:      &I32Spec{},
+			messages: []string{`cannot cast foo to "i32"`},
+		},
+		{
+			desc:     "i8 out of range",
+			value:    ConstantInt(128),
+			typ:      &I8Spec{},
+			messages: []string{`cannot cast 128 to "byte"`, "out of range"},
+		},
+		{
+			desc:     "i32 out of range",
+			value:    ConstantInt(-1 << 40),
+			typ:      &I32Spec{},
+			messages: []string{"out of range"},
+		},
+		{
+			desc:     "bool",
+			value:    ConstantInt(2),
+			typ:      &BoolSpec{},
+			messages: []string{"the value must be 0 or 1"},
+		},
+		{
+			desc:     "list item",
+			value:    ConstantList{ConstantInt(1), ConstantBool(true)},
+			typ:      &ListSpec{ValueSpec: &I64Spec{}},
+			messages: []string{`cannot cast true to "i64"`},
+		},
+		{
+			desc:     "unresolved reference",
+			value:    constantReference{Name: "foo"},
+			typ:      &I32Spec{},
+			messages: []string{`reference to "foo" was not resolved`},
+		},
+		{
+			desc: "missing required field",
+			value: &ConstantStruct{Fields: map[string]ConstantValue{
+				"y": ConstantInt(1),
+			}},
+			typ: &StructSpec{Name: "Point", Fields: FieldGroup{
+				{ID: 1, Name: "x", Type: &DoubleSpec{}, Required: true},
+				{ID: 2, Name: "y", Type: &DoubleSpec{}, Required: true},
+			}},
+			messages: []string{`"x" is a required field`},
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := EvaluateConst(tt.value, tt.typ)
+		if assert.Error(t, err, tt.desc) {
+			for _, msg := range tt.messages {
+				assert.Contains(t, err.Error(), msg, tt.desc)
+			}
+		}
+	}
+}
@@ -97,6 +97,7 @@ type FieldSpec struct {
 	Required    bool
 	Default     ConstantValue
 	Annotations Annotations
+	Doc         string
 }
 
 // compileField compiles the given Field source into a FieldSpec.
@@ -139,6 +140,7 @@ func compileField(src *ast.Field, options fieldOptions) (*FieldSpec, error) {
 		Required:    required,
 		Default:     compileConstantValue(src.Default),
 		Annotations: annotations,
+		Doc:         src.Doc,
 	}, nil
 }
 
@@ -41,9 +41,59 @@ type Module struct {
 	Types     map[string]TypeSpec
 	Services  map[string]*ServiceSpec
 
+	// Annotations which apply to the whole Thrift file. These are the
+	// annotations specified on the namespace statements of the file.
+	//
+	// 	namespace go users (go.package = "userapi")
+	Annotations Annotations
+
+	// Namespaces declared in the Thrift file in the order in which they
+	// were declared.
+	//
+	// 	namespace java com.example.users
+	// 	namespace py example.users
+	Namespaces []*Namespace
+
 	Raw []byte // The raw IDL input.
 }
 
+// Namespace is a namespace declared in a Thrift file for the code generated
+// for a specific language.
+type Namespace struct {
+	// Language for which the namespace applies. This is "*" for namespaces
+	// which apply to all languages.
+	Scope string
+
+	// Namespace for the generated code. For example, the Java package or the
+	// Python module.
+	Name string
+}
+
+// LookupNamespace returns the namespace declared in the Thrift file for the
+// given scope. Namespaces declared with the "*" scope apply to all scopes
+// that do not have their own namespace. If multiple namespaces were
+// declared for the same scope, the last one wins.
+//
+// Returns false if the Thrift file did not declare a namespace for the scope.
+func (m *Module) LookupNamespace(scope string) (string, bool) {
+	var (
+		name, wildcard string
+		found, hasAny  bool
+	)
+	for _, ns := range m.Namespaces {
+		switch ns.Scope {
+		case scope:
+			name, found = ns.Name, true
+		case "*":
+			wildcard, hasAny = ns.Name, true
+		}
+	}
+	if found {
+		return name, true
+	}
+	return wildcard, hasAny
+}
+
 // GetName for Module
 func (m *Module) GetName() string {
 	return m.Name
@@ -21,8 +21,13 @@
 package compile
 
 import (
+	"fmt"
 	"io/ioutil"
+	"os"
+	"path"
 	"path/filepath"
+
+	"go.uber.org/thriftrw/idl"
 )
 
 // Option represents a compiler option.
@@ -48,6 +53,39 @@ func (realFS) Abs(p string) (string, error) {
 	return filepath.Abs(p)
 }
 
+// MapFS is an in-memory FS which maps paths of Thrift files to their
+// contents. Paths are slash-separated and relative paths are resolved against
+// the root directory, "/".
+//
+// MapFS allows Thrift files to be compiled in environments without a
+// filesystem, such as a browser.
+//
+// 	m, err := compile.Compile("api.thrift", compile.Filesystem(compile.MapFS{
+// 		"api.thrift":    `include "shared.thrift" ...`,
+// 		"shared.thrift": `...`,
+// 	}))
+type MapFS map[string]string
+
+// Read returns the contents of the given file.
+func (fs MapFS) Read(filename string) ([]byte, error) {
+	want := mapFSAbs(filename)
+	for name, contents := range fs {
+		if mapFSAbs(name) == want {
+			return []byte(contents), nil
+		}
+	}
+	return nil, &os.PathError{Op: "open", Path: filename, Err: os.ErrNotExist}
+}
+
+// Abs resolves the given path against the root directory.
+func (MapFS) Abs(p string) (string, error) {
+	return mapFSAbs(p), nil
+}
+
+func mapFSAbs(p string) string {
+	return path.Clean("/" + filepath.ToSlash(p))
+}
+
 // Filesystem controls how the Thrift compiler accesses the filesystem.
 func Filesystem(fs FS) Option {
 	return func(c *compiler) {
@@ -62,3 +100,77 @@ func NonStrict() Option {
 		c.nonStrict = true
 	}
 }
+
+// StrictUnused fails compilation if any included file is never referenced
+// by the file that includes it, or if types or constants declared in
+// included files are never referenced. See FindUnused.
+func StrictUnused() Option {
+	return func(c *compiler) {
+		c.strictUnused = true
+	}
+}
+
+// Pedantic fails compilation if any definitions are likely to behave unlike
+// what their author intended, like required fields with default values. These
+// are otherwise reported as warnings.
+func Pedantic() Option {
+	return func(c *compiler) {
+		c.pedantic = true
+	}
+}
+
+// Interner shares the given Interner between all Thrift files parsed by
+// the compiler. Tools which compile many Thrift files into separate modules
+// may use the same Interner for all of them to store common names only
+// once.
+//
+// By default, strings are shared between the files of a single Compile
+// call.
+func Interner(i *idl.Interner) Option {
+	return func(c *compiler) {
+		c.interner = i
+	}
+}
+
+// IncludePaths adds directories in which included Thrift files are
+// searched for, like the -I option of the Apache Thrift compiler.
+//
+// Relative paths in include statements are first resolved against the
+// directory of the file including them, followed by each of these
+// directories in order. The first file that exists is used. Relative
+// directories are resolved by the FS, usually against the working
+// directory.
+//
+// The FS must report files which do not exist with errors for which
+// os.IsNotExist returns true for the search to move past them.
+func IncludePaths(dirs ...string) Option {
+	return func(c *compiler) {
+		c.includePaths = append(c.includePaths, dirs...)
+	}
+}
+
+// Warning is a problem found in a Thrift file which does not prevent it
+// from being compiled, such as the use of deprecated syntax.
+type Warning struct {
+	Path    string
+	Line    int
+	Message string
+
+	// Semantic is true if the warning is about a definition that is likely
+	// to behave unlike what its author intended rather than about
+	// deprecated syntax. With the Pedantic option, these fail compilation
+	// instead.
+	Semantic bool
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%v:%d: %v", w.Path, w.Line, w.Message)
+}
+
+// Warnings reports uses of deprecated syntax and questionable definitions in
+// the compiled Thrift files to the given function rather than ignoring them.
+func Warnings(f func(Warning)) Option {
+	return func(c *compiler) {
+		c.warn = f
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compile
+
+import (
+	"bytes"
+	"fmt"
+
+	"go.uber.org/thriftrw/ast"
+)
+
+// checkStruct looks for definitions in the given struct, union, or exception
+// which compile but are likely to behave unlike what their author intended.
+//
+// Unions may not have required fields or default values at all; those are
+// rejected by compileStruct.
+func checkStruct(path string, s *ast.Struct) []Warning {
+	var warnings []Warning
+	warnf := func(line int, msg string, args ...interface{}) {
+		warnings = append(warnings, Warning{
+			Path:     path,
+			Line:     line,
+			Message:  fmt.Sprintf(msg, args...),
+			Semantic: true,
+		})
+	}
+
+	if s.Type == ast.UnionType && len(s.Fields) == 0 {
+		warnf(s.Line, "union %q has no fields so no value of it is valid", s.Name)
+	}
+
+	for _, f := range s.Fields {
+		if f.Default == nil {
+			continue
+		}
+
+		if f.Requiredness == ast.Required {
+			warnf(f.Line,
+				"field %q of %q is required but has a default value so it will be treated as optional",
+				f.Name, s.Name)
+		} else if s.Type == ast.ExceptionType {
+			warnf(f.Line,
+				"field %q of exception %q has a default value so clients cannot tell "+
+					"whether it was set by the server",
+				f.Name, s.Name)
+		}
+	}
+
+	return warnings
+}
+
+// pedanticError is returned by Compile with the Pedantic option if the
+// Thrift files have questionable definitions.
+type pedanticError struct {
+	Warnings []Warning
+}
+
+func (e pedanticError) Error() string {
+	var buff bytes.Buffer
+	buff.WriteString("found questionable definitions:")
+	for _, w := range e.Warnings {
+		buff.WriteString("\n  ")
+		buff.WriteString(w.String())
+	}
+	return buff.String()
+}
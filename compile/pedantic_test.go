@@ -0,0 +1,87 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileQuestionable(t *testing.T) {
+	fs := MapFS{
+		"main.thrift": `
+			union Empty {}
+
+			struct User {
+				1: required string name = "anonymous"
+				2: optional string email = ""
+			}
+
+			exception NotFound {
+				1: optional string message = "not found"
+				2: required i32 code
+			}
+		`,
+	}
+
+	var warnings []Warning
+	_, err := Compile("main.thrift", Filesystem(fs), Warnings(func(w Warning) {
+		warnings = append(warnings, w)
+	}))
+	require.NoError(t, err, "questionable definitions must not fail without Pedantic")
+
+	var messages []string
+	for _, w := range warnings {
+		assert.True(t, w.Semantic, "warning %v must be semantic", w)
+		messages = append(messages, w.String())
+	}
+	assert.Equal(t, []string{
+		`/main.thrift:2: union "Empty" has no fields so no value of it is valid`,
+		`/main.thrift:5: field "name" of "User" is required but has a default value so it will be treated as optional`,
+		`/main.thrift:10: field "message" of exception "NotFound" has a default value so clients cannot tell whether it was set by the server`,
+	}, messages)
+
+	warnings = nil
+	_, err = Compile("main.thrift", Filesystem(fs), Pedantic(), Warnings(func(w Warning) {
+		warnings = append(warnings, w)
+	}))
+	require.Error(t, err)
+	assert.Empty(t, warnings, "questionable definitions must not be reported as warnings with Pedantic")
+	assert.Contains(t, err.Error(), "found questionable definitions:")
+	for _, msg := range messages {
+		assert.Contains(t, err.Error(), msg)
+	}
+}
+
+func TestCompilePedanticClean(t *testing.T) {
+	fs := MapFS{
+		"main.thrift": `
+			union Value { 1: string s; 2: i64 i }
+			struct User { 1: required string name; 2: optional string email = "" }
+			exception NotFound { 1: optional string message }
+		`,
+	}
+
+	_, err := Compile("main.thrift", Filesystem(fs), Pedantic())
+	assert.NoError(t, err)
+}
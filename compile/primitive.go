@@ -75,6 +75,14 @@ type (
 
 		Annotations Annotations
 	}
+
+	// UUIDSpec is the uuid type. UUIDs are represented on the wire as
+	// 16-byte binary values.
+	UUIDSpec struct {
+		nativeThriftType
+
+		Annotations Annotations
+	}
 )
 
 func (*BoolSpec) TypeCode() wire.Type   { return wire.TBool }
@@ -85,6 +93,7 @@ func (*I64Spec) TypeCode() wire.Type    { return wire.TI64 }
 func (*DoubleSpec) TypeCode() wire.Type { return wire.TDouble }
 func (*StringSpec) TypeCode() wire.Type { return wire.TBinary }
 func (*BinarySpec) TypeCode() wire.Type { return wire.TBinary }
+func (*UUIDSpec) TypeCode() wire.Type   { return wire.TBinary }
 
 func (*BoolSpec) ThriftName() string   { return "bool" }
 func (*I8Spec) ThriftName() string     { return "byte" }
@@ -94,6 +103,7 @@ func (*I64Spec) ThriftName() string    { return "i64" }
 func (*DoubleSpec) ThriftName() string { return "double" }
 func (*StringSpec) ThriftName() string { return "string" }
 func (*BinarySpec) ThriftName() string { return "binary" }
+func (*UUIDSpec) ThriftName() string   { return "uuid" }
 
 func (t *BoolSpec) Link(Scope) (TypeSpec, error)   { return t, nil }
 func (t *I8Spec) Link(Scope) (TypeSpec, error)     { return t, nil }
@@ -103,6 +113,7 @@ func (t *I64Spec) Link(Scope) (TypeSpec, error)    { return t, nil }
 func (t *DoubleSpec) Link(Scope) (TypeSpec, error) { return t, nil }
 func (t *StringSpec) Link(Scope) (TypeSpec, error) { return t, nil }
 func (t *BinarySpec) Link(Scope) (TypeSpec, error) { return t, nil }
+func (t *UUIDSpec) Link(Scope) (TypeSpec, error)   { return t, nil }
 
 func (*BoolSpec) ForEachTypeReference(func(TypeSpec) error) error   { return nil }
 func (*I8Spec) ForEachTypeReference(func(TypeSpec) error) error     { return nil }
@@ -112,6 +123,7 @@ func (*I64Spec) ForEachTypeReference(func(TypeSpec) error) error    { return nil
 func (*DoubleSpec) ForEachTypeReference(func(TypeSpec) error) error { return nil }
 func (*StringSpec) ForEachTypeReference(func(TypeSpec) error) error { return nil }
 func (*BinarySpec) ForEachTypeReference(func(TypeSpec) error) error { return nil }
+func (*UUIDSpec) ForEachTypeReference(func(TypeSpec) error) error   { return nil }
 
 func (t *BoolSpec) ThriftAnnotations() Annotations   { return t.Annotations }
 func (t *I8Spec) ThriftAnnotations() Annotations     { return t.Annotations }
@@ -121,6 +133,7 @@ func (t *I64Spec) ThriftAnnotations() Annotations    { return t.Annotations }
 func (t *DoubleSpec) ThriftAnnotations() Annotations { return t.Annotations }
 func (t *StringSpec) ThriftAnnotations() Annotations { return t.Annotations }
 func (t *BinarySpec) ThriftAnnotations() Annotations { return t.Annotations }
+func (t *UUIDSpec) ThriftAnnotations() Annotations   { return t.Annotations }
 
 // compileBaseType compiles a base type reference in the AST to a primitive
 // TypeSpec.
@@ -147,6 +160,8 @@ func compileBaseType(t ast.BaseType) (TypeSpec, error) {
 		return &StringSpec{Annotations: annots}, nil
 	case ast.BinaryTypeID:
 		return &BinarySpec{Annotations: annots}, nil
+	case ast.UUIDTypeID:
+		return &UUIDSpec{Annotations: annots}, nil
 	default:
 		panic(fmt.Sprintf("unknown base type %v", t))
 	}
@@ -75,6 +75,11 @@ func TestCompileBaseType(t *testing.T) {
 			give: ast.BaseType{ID: ast.BinaryTypeID},
 			want: &BinarySpec{},
 		},
+		{
+			desc: "uuid",
+			give: ast.BaseType{ID: ast.UUIDTypeID},
+			want: &UUIDSpec{},
+		},
 
 		// With annotations (success)
 		{
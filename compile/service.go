@@ -34,6 +34,7 @@ type ServiceSpec struct {
 	Parent      *ServiceSpec
 	Functions   map[string]*FunctionSpec
 	Annotations Annotations
+	Doc         string
 
 	parentSrc *ast.ServiceReference
 }
@@ -77,6 +78,7 @@ func compileService(file string, src *ast.Service) (*ServiceSpec, error) {
 		File:        file,
 		Functions:   functions,
 		Annotations: annotations,
+		Doc:         src.Doc,
 		parentSrc:   src.Parent,
 	}, nil
 }
@@ -157,6 +159,16 @@ func (s *ServiceSpec) ThriftFile() string {
 	return s.File
 }
 
+// ThriftName is the name of this service as it appears in the Thrift file.
+func (s *ServiceSpec) ThriftName() string {
+	return s.Name
+}
+
+// ThriftAnnotations returns the annotations declared on this service.
+func (s *ServiceSpec) ThriftAnnotations() Annotations {
+	return s.Annotations
+}
+
 // FunctionSpec is a single function inside a Service.
 type FunctionSpec struct {
 	linkOnce
@@ -166,6 +178,7 @@ type FunctionSpec struct {
 	ResultSpec  *ResultSpec // nil if OneWay is true
 	OneWay      bool
 	Annotations Annotations
+	Doc         string
 }
 
 func compileFunction(src *ast.Function) (*FunctionSpec, error) {
@@ -210,6 +223,7 @@ func compileFunction(src *ast.Function) (*FunctionSpec, error) {
 		ResultSpec:  result,
 		Annotations: annotations,
 		OneWay:      src.OneWay,
+		Doc:         src.Doc,
 	}, nil
 }
 
@@ -237,6 +251,16 @@ func (f *FunctionSpec) MethodName() string {
 	return f.Name
 }
 
+// ThriftName is the name of this function as it appears in the Thrift file.
+func (f *FunctionSpec) ThriftName() string {
+	return f.Name
+}
+
+// ThriftAnnotations returns the annotations declared on this function.
+func (f *FunctionSpec) ThriftAnnotations() Annotations {
+	return f.Annotations
+}
+
 // CallType returns the envelope type that is used when making enveloped
 // requests for this function.
 func (f *FunctionSpec) CallType() wire.EnvelopeType {
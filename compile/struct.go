@@ -34,6 +34,7 @@ type StructSpec struct {
 	Type        ast.StructureType
 	Fields      FieldGroup
 	Annotations Annotations
+	Doc         string
 }
 
 // compileStruct compiles a struct AST into a StructSpec.
@@ -69,6 +70,7 @@ func compileStruct(file string, src *ast.Struct, requiredness fieldRequiredness)
 		Type:        src.Type,
 		Fields:      fields,
 		Annotations: annotations,
+		Doc:         src.Doc,
 	}, nil
 }
 
@@ -187,6 +187,7 @@ func TestRootTypeSpec(t *testing.T) {
 		{desc: "DoubleSpec", give: &DoubleSpec{}},
 		{desc: "StringSpec", give: &StringSpec{}},
 		{desc: "BinarySpec", give: &BinarySpec{}},
+		{desc: "UUIDSpec", give: &UUIDSpec{}},
 
 		// Containers
 		{
@@ -33,6 +33,7 @@ type TypedefSpec struct {
 	File        string
 	Target      TypeSpec
 	Annotations Annotations
+	Doc         string
 
 	root TypeSpec
 }
@@ -58,9 +59,21 @@ func compileTypedef(file string, src *ast.Typedef) (*TypedefSpec, error) {
 		File:        file,
 		Target:      typ,
 		Annotations: annotations,
+		Doc:         src.Doc,
 	}, nil
 }
 
+// senumTypedef returns the typedef of string used in place of the given
+// senum. The set of values of senums is not enforced.
+func senumTypedef(src *ast.Senum) *ast.Typedef {
+	return &ast.Typedef{
+		Name:        src.Name,
+		Type:        ast.BaseType{ID: ast.StringTypeID, Line: src.Line},
+		Annotations: src.Annotations,
+		Line:        src.Line,
+	}
+}
+
 // TypeCode gets the wire type for the typedef.
 func (t *TypedefSpec) TypeCode() wire.Type {
 	return t.Target.TypeCode()
@@ -0,0 +1,274 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compile
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/idl"
+)
+
+// UnusedKind is the kind of item reported by FindUnused.
+type UnusedKind int
+
+// Kinds of unused items.
+const (
+	UnusedInclude UnusedKind = iota + 1
+	UnusedType
+	UnusedConstant
+)
+
+func (k UnusedKind) String() string {
+	switch k {
+	case UnusedInclude:
+		return "include"
+	case UnusedType:
+		return "type"
+	case UnusedConstant:
+		return "constant"
+	default:
+		return fmt.Sprintf("UnusedKind(%d)", int(k))
+	}
+}
+
+// Unused is an include, type, or constant which is never referenced.
+type Unused struct {
+	// Path to the Thrift file which declares the item.
+	Path string
+	Kind UnusedKind
+	// Name of the include, type, or constant.
+	Name string
+}
+
+func (u Unused) String() string {
+	return fmt.Sprintf("%v: %v %q is never used", u.Path, u.Kind, u.Name)
+}
+
+// FindUnused reports includes which are never referenced by the file that
+// includes them, and types and constants declared in included files which
+// are not referenced anywhere in the module graph.
+//
+// Definitions of the root module are its API and are not reported, but the
+// references they make are counted. A definition which refers only to
+// itself is considered unused.
+//
+// References are read from the IDL of each module so the Module must have
+// been produced by Compile. Results are sorted by path, kind, and name.
+func FindUnused(m *Module) []Unused {
+	f := unusedFinder{
+		used:     make(map[NamedEntity]struct{}),
+		includes: make(map[string]map[string]struct{}),
+	}
+
+	var modules []*Module
+	_ = m.Walk(func(m *Module) error {
+		modules = append(modules, m)
+		f.visitModule(m)
+		return nil
+	})
+
+	var unused []Unused
+	for _, mod := range modules {
+		for name := range mod.Includes {
+			if _, ok := f.includes[mod.ThriftPath][name]; !ok {
+				unused = append(unused, Unused{Path: mod.ThriftPath, Kind: UnusedInclude, Name: name})
+			}
+		}
+
+		if mod == m {
+			continue
+		}
+
+		for name, t := range mod.Types {
+			if _, ok := f.used[t]; !ok {
+				unused = append(unused, Unused{Path: mod.ThriftPath, Kind: UnusedType, Name: name})
+			}
+		}
+		for name, c := range mod.Constants {
+			if _, ok := f.used[c]; !ok {
+				unused = append(unused, Unused{Path: mod.ThriftPath, Kind: UnusedConstant, Name: name})
+			}
+		}
+	}
+
+	sort.Sort(unusedByPosition(unused))
+	return unused
+}
+
+// unusedFinder records the definitions and includes referenced by each
+// module.
+type unusedFinder struct {
+	// Referenced types and constants.
+	used map[NamedEntity]struct{}
+
+	// Names of the includes referenced by each module, keyed by the Thrift
+	// path of the module.
+	includes map[string]map[string]struct{}
+}
+
+func (f unusedFinder) visitModule(m *Module) {
+	// Linking resolves references to constants into copies of their values
+	// so the references are read from the AST instead.
+	var prog *ast.Program
+	if m.Raw != nil {
+		prog, _ = idl.Parse(m.Raw)
+	}
+	if prog == nil {
+		// Not compiled from IDL. Assume that everything is used.
+		for name := range m.Includes {
+			f.useInclude(m, name)
+		}
+		return
+	}
+
+	ast.Walk(ast.VisitorFunc(func(w ast.Walker, n ast.Node) {
+		// The top-level definition containing this node. Its ancestors
+		// end with the definition followed by the Program.
+		var owner string
+		if as := w.Ancestors(); len(as) >= 2 {
+			if d, ok := as[len(as)-2].(ast.Definition); ok {
+				owner = d.Info().Name
+			}
+		}
+
+		switch n := n.(type) {
+		case ast.TypeReference:
+			f.useType(m, owner, n.Name)
+		case ast.ConstantReference:
+			f.useConstant(m, owner, n.Name)
+		case *ast.Service:
+			if n.Parent != nil {
+				f.useService(m, n.Parent.Name)
+			}
+		}
+	}), prog)
+}
+
+// useType records a reference to the named type from the given definition
+// of the module.
+func (f unusedFinder) useType(m *Module, owner, name string) {
+	if t, ok := m.Types[name]; ok {
+		f.useLocal(owner, name, t)
+		return
+	}
+
+	mname, iname := splitInclude(name)
+	if inc, ok := m.Includes[mname]; ok {
+		f.useInclude(m, mname)
+		if t, ok := inc.Module.Types[iname]; ok {
+			f.used[t] = struct{}{}
+		}
+	}
+}
+
+// useConstant records a reference to the named constant or enum item from
+// the given definition of the module.
+func (f unusedFinder) useConstant(m *Module, owner, name string) {
+	if c, ok := m.Constants[name]; ok {
+		f.useLocal(owner, name, c)
+		return
+	}
+
+	// Enum.Item
+	mname, iname := splitInclude(name)
+	if t, ok := m.Types[mname]; ok {
+		f.useLocal(owner, mname, t)
+		return
+	}
+
+	inc, ok := m.Includes[mname]
+	if !ok {
+		return
+	}
+	f.useInclude(m, mname)
+
+	if c, ok := inc.Module.Constants[iname]; ok {
+		f.used[c] = struct{}{}
+		return
+	}
+
+	// include.Enum.Item
+	ename, _ := splitInclude(iname)
+	if t, ok := inc.Module.Types[ename]; ok {
+		f.used[t] = struct{}{}
+	}
+}
+
+// useService records a reference to the named service.
+func (f unusedFinder) useService(m *Module, name string) {
+	if _, ok := m.Services[name]; ok {
+		return
+	}
+	mname, _ := splitInclude(name)
+	if _, ok := m.Includes[mname]; ok {
+		f.useInclude(m, mname)
+	}
+}
+
+func (f unusedFinder) useLocal(owner, name string, e NamedEntity) {
+	if owner != name {
+		f.used[e] = struct{}{}
+	}
+}
+
+func (f unusedFinder) useInclude(m *Module, name string) {
+	names, ok := f.includes[m.ThriftPath]
+	if !ok {
+		names = make(map[string]struct{})
+		f.includes[m.ThriftPath] = names
+	}
+	names[name] = struct{}{}
+}
+
+type unusedByPosition []Unused
+
+func (us unusedByPosition) Len() int      { return len(us) }
+func (us unusedByPosition) Swap(i, j int) { us[i], us[j] = us[j], us[i] }
+
+func (us unusedByPosition) Less(i, j int) bool {
+	l, r := us[i], us[j]
+	if l.Path != r.Path {
+		return l.Path < r.Path
+	}
+	if l.Kind != r.Kind {
+		return l.Kind < r.Kind
+	}
+	return l.Name < r.Name
+}
+
+// unusedError is returned by Compile with the StrictUnused option if the
+// module graph has unused items.
+type unusedError struct {
+	Unused []Unused
+}
+
+func (e unusedError) Error() string {
+	var buff bytes.Buffer
+	buff.WriteString("found unused includes or definitions:")
+	for _, u := range e.Unused {
+		buff.WriteString("\n  ")
+		buff.WriteString(u.String())
+	}
+	return buff.String()
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindUnused(t *testing.T) {
+	tests := []struct {
+		desc  string
+		files MapFS
+		want  []Unused
+	}{
+		{
+			desc: "no includes",
+			files: MapFS{
+				"main.thrift": `
+					struct Foo {}
+					const i32 x = 1
+				`,
+			},
+		},
+		{
+			desc: "unused include",
+			files: MapFS{
+				"main.thrift":   `include "./shared.thrift"`,
+				"shared.thrift": `typedef string UUID`,
+			},
+			want: []Unused{
+				{Path: "/main.thrift", Kind: UnusedInclude, Name: "shared"},
+				{Path: "/shared.thrift", Kind: UnusedType, Name: "UUID"},
+			},
+		},
+		{
+			desc: "struct field",
+			files: MapFS{
+				"main.thrift": `
+					include "./shared.thrift"
+					struct Foo { 1: optional list<shared.UUID> ids }
+				`,
+				"shared.thrift": `
+					typedef string UUID
+					typedef i64 Timestamp
+				`,
+			},
+			want: []Unused{
+				{Path: "/shared.thrift", Kind: UnusedType, Name: "Timestamp"},
+			},
+		},
+		{
+			desc: "typedef target and constants",
+			files: MapFS{
+				"main.thrift": `
+					include "./shared.thrift"
+					typedef shared.Role MyRole
+					const i32 max = shared.limit
+					struct Foo { 1: optional MyRole role = shared.Role.ADMIN }
+				`,
+				"shared.thrift": `
+					enum Role { ADMIN, USER }
+					const i32 limit = 10
+					const i32 unusedLimit = 20
+				`,
+			},
+			want: []Unused{
+				{Path: "/shared.thrift", Kind: UnusedConstant, Name: "unusedLimit"},
+			},
+		},
+		{
+			desc: "constant in included file referencing another",
+			files: MapFS{
+				"main.thrift": `
+					include "./a.thrift"
+					const list<i32> xs = [a.x]
+				`,
+				"a.thrift": `
+					include "./b.thrift"
+					const i32 x = b.y
+				`,
+				"b.thrift": `const i32 y = 1`,
+			},
+		},
+		{
+			desc: "services",
+			files: MapFS{
+				"main.thrift": `
+					include "./base.thrift"
+					include "./errors.thrift"
+					service Foo extends base.Base {
+						void hello() throws (1: errors.Oops oops)
+					}
+				`,
+				"base.thrift":   `service Base {}`,
+				"errors.thrift": `exception Oops {}`,
+			},
+		},
+		{
+			desc: "self-referencing type",
+			files: MapFS{
+				"main.thrift": `
+					include "./shared.thrift"
+					struct Foo { 1: optional shared.Node node }
+				`,
+				"shared.thrift": `
+					struct Node { 1: optional Node child }
+					struct Orphan { 1: optional Orphan child }
+				`,
+			},
+			want: []Unused{
+				{Path: "/shared.thrift", Kind: UnusedType, Name: "Orphan"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		m, err := Compile("main.thrift", Filesystem(tt.files))
+		require.NoError(t, err, tt.desc)
+		assert.Equal(t, tt.want, FindUnused(m), tt.desc)
+	}
+}
+
+func TestCompileStrictUnused(t *testing.T) {
+	fs := MapFS{
+		"main.thrift": `
+			include "./a.thrift"
+			include "./b.thrift"
+			struct Foo { 1: optional a.UUID id }
+		`,
+		"a.thrift": `
+			typedef string UUID
+			const i32 limit = 1
+		`,
+		"b.thrift": `struct Bar {}`,
+	}
+
+	_, err := Compile("main.thrift", Filesystem(fs))
+	require.NoError(t, err, "unused items must not fail without StrictUnused")
+
+	_, err = Compile("main.thrift", Filesystem(fs), StrictUnused())
+	assert.EqualError(t, err, `found unused includes or definitions:
+  /a.thrift: constant "limit" is never used
+  /b.thrift: type "Bar" is never used
+  /main.thrift: include "b" is never used`)
+}
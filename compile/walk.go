@@ -0,0 +1,195 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compile
+
+import "sort"
+
+// Walker provides access to information about the state of the compiled
+// module walker.
+type Walker interface {
+	// Module returns the module whose definitions are being walked.
+	Module() *Module
+
+	// Ancestors returns a copy of a slice containing all the ancestor
+	// entities of the current entity. The first entity in the slice is the
+	// immediate parent of the current entity, the entity after that its
+	// parent, and so on. Top-level definitions of the module have no
+	// ancestors.
+	Ancestors() []NamedEntity
+
+	// Parent returns the parent entity of the current entity or nil if this
+	// is a top-level definition.
+	Parent() NamedEntity
+}
+
+// Visitor walks a compiled module. The Visit function is called on each
+// entity defined in the module. If the function returns a non-nil visitor
+// for any entity, that visitor is called on the children of that entity.
+type Visitor interface {
+	Visit(w Walker, e NamedEntity) Visitor
+}
+
+// VisitorFunc is a Visitor which visits all the entities of the module.
+type VisitorFunc func(Walker, NamedEntity)
+
+// Visit the given entity and its descendants.
+func (f VisitorFunc) Visit(w Walker, e NamedEntity) Visitor {
+	f(w, e)
+	return f
+}
+
+// MultiVisitor merges the given visitors into a single Visitor.
+func MultiVisitor(visitors ...Visitor) Visitor {
+	return multiVisitor(visitors)
+}
+
+type multiVisitor []Visitor
+
+func (vs multiVisitor) Visit(w Walker, e NamedEntity) Visitor {
+	newVS := make(multiVisitor, 0, len(vs))
+	for _, v := range vs {
+		if v := v.Visit(w, e); v != nil {
+			newVS = append(newVS, v)
+		}
+	}
+	return newVS
+}
+
+// Walk walks the definitions of the given module depth-first with the given
+// visitor. The visitor's Visit function should return a non-nil visitor if
+// it wants to visit the children of the entity it was called with.
+//
+// Top-level definitions are visited in the following order, sorted by name
+// within each group: constants, types, and services. The children of an
+// entity are,
+//
+// 	*StructSpec   Fields in the order they were declared
+// 	*EnumSpec     Items in the order they were declared
+// 	*ServiceSpec  Functions sorted by name
+// 	*FunctionSpec Arguments followed by exceptions, in declaration order
+//
+// All other entities have no children. References to other types are not
+// followed, and included modules are not visited. Use Module.Walk to visit
+// every module in a module tree.
+func Walk(v Visitor, m *Module) {
+	w := walker{module: m}
+	var names []string
+
+	names = names[:0]
+	for name := range m.Constants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		w.visit(v, m.Constants[name])
+	}
+
+	names = names[:0]
+	for name := range m.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		w.visit(v, m.Types[name])
+	}
+
+	names = names[:0]
+	for name := range m.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		w.visit(v, m.Services[name])
+	}
+}
+
+// walker tracks the stack of entities visited in the order they were
+// visited.
+type walker struct {
+	module *Module
+	stack  []NamedEntity
+}
+
+func (w walker) Module() *Module {
+	return w.module
+}
+
+func (w walker) Parent() NamedEntity {
+	if len(w.stack) == 0 {
+		return nil
+	}
+	return w.stack[len(w.stack)-1]
+}
+
+func (w walker) Ancestors() []NamedEntity {
+	if len(w.stack) == 0 {
+		return nil
+	}
+
+	ancestors := make([]NamedEntity, len(w.stack))
+	for i, e := range w.stack {
+		ancestors[len(w.stack)-1-i] = e
+	}
+	return ancestors
+}
+
+func (w walker) visit(v Visitor, e NamedEntity) {
+	if e == nil {
+		return
+	}
+
+	v = v.Visit(w, e)
+	if v == nil {
+		return
+	}
+
+	// Note that walker is passed by value so appending to the stack does not
+	// affect siblings of this entity.
+	w.stack = append(w.stack, e)
+	switch e := e.(type) {
+	case *StructSpec:
+		w.visitFields(v, e.Fields)
+	case *EnumSpec:
+		for i := range e.Items {
+			w.visit(v, &e.Items[i])
+		}
+	case *ServiceSpec:
+		names := make([]string, 0, len(e.Functions))
+		for name := range e.Functions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			w.visit(v, e.Functions[name])
+		}
+	case *FunctionSpec:
+		w.visitFields(v, FieldGroup(e.ArgsSpec))
+		if e.ResultSpec != nil {
+			w.visitFields(v, e.ResultSpec.Exceptions)
+		}
+	}
+}
+
+func (w walker) visitFields(v Visitor, fields FieldGroup) {
+	for _, f := range fields {
+		w.visit(v, f)
+	}
+}
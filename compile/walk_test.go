@@ -0,0 +1,169 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compile
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalk(t *testing.T) {
+	fs := dummyFS{"/", map[string]string{
+		"/shared.thrift": `
+			struct Shared { 1: optional string value }
+		`,
+		"/main.thrift": `
+			include "./shared.thrift"
+
+			const i32 Version = 1
+			const string Name = "main"
+
+			enum Color { Red, Green }
+
+			typedef shared.Shared Alias
+
+			exception NotFound { 1: required string key } (http.status = "404")
+
+			struct Item {
+				2: required string key
+				1: optional Color color
+			}
+
+			service Base {
+				void ping()
+			}
+
+			service Store extends Base {
+				Item get(1: string key) throws (1: NotFound notFound)
+				oneway void forget(1: string key)
+			} (version = "2")
+		`,
+	}}
+
+	module, err := Compile("main.thrift", Filesystem(fs))
+	require.NoError(t, err)
+
+	var visited []string
+	Walk(VisitorFunc(func(w Walker, e NamedEntity) {
+		assert.Equal(t, module, w.Module())
+
+		names := make([]string, 0, len(w.Ancestors())+1)
+		for _, a := range w.Ancestors() {
+			names = append([]string{a.ThriftName()}, names...)
+		}
+		names = append(names, e.ThriftName())
+		visited = append(visited, strings.Join(names, "."))
+
+		if w.Parent() == nil {
+			assert.Empty(t, w.Ancestors())
+		} else {
+			assert.Equal(t, w.Parent(), w.Ancestors()[0])
+		}
+	}), module)
+
+	assert.Equal(t, []string{
+		// Constants
+		"Name",
+		"Version",
+
+		// Types
+		"Alias",
+		"Color",
+		"Color.Red",
+		"Color.Green",
+		"Item",
+		"Item.key",
+		"Item.color",
+		"NotFound",
+		"NotFound.key",
+
+		// Services
+		"Base",
+		"Base.ping",
+		"Store",
+		"Store.forget",
+		"Store.forget.key",
+		"Store.get",
+		"Store.get.key",
+		"Store.get.notFound",
+	}, visited)
+}
+
+func TestWalkSkipChildren(t *testing.T) {
+	fs := dummyFS{"/", map[string]string{
+		"/main.thrift": `
+			struct Foo { 1: optional string bar }
+			service Svc { void baz(1: Foo foo) }
+		`,
+	}}
+
+	module, err := Compile("main.thrift", Filesystem(fs))
+	require.NoError(t, err)
+
+	var visited []string
+	var v Visitor
+	v = visitorFunc(func(w Walker, e NamedEntity) Visitor {
+		visited = append(visited, e.ThriftName())
+		if _, ok := e.(*ServiceSpec); ok {
+			return nil
+		}
+		return v
+	})
+	Walk(v, module)
+
+	assert.Equal(t, []string{"Foo", "bar", "Svc"}, visited)
+}
+
+func TestWalkMultiVisitor(t *testing.T) {
+	fs := dummyFS{"/", map[string]string{
+		"/main.thrift": `
+			service Svc { void foo() } (a = "b")
+		`,
+	}}
+
+	module, err := Compile("main.thrift", Filesystem(fs))
+	require.NoError(t, err)
+
+	var types, annotations []string
+	Walk(MultiVisitor(
+		VisitorFunc(func(w Walker, e NamedEntity) {
+			types = append(types, fmt.Sprintf("%T", e))
+		}),
+		VisitorFunc(func(w Walker, e NamedEntity) {
+			for k, v := range e.ThriftAnnotations() {
+				annotations = append(annotations, k+"="+v)
+			}
+		}),
+	), module)
+
+	assert.Equal(t, []string{"*compile.ServiceSpec", "*compile.FunctionSpec"}, types)
+	assert.Equal(t, []string{"a=b"}, annotations)
+}
+
+type visitorFunc func(Walker, NamedEntity) Visitor
+
+func (f visitorFunc) Visit(w Walker, e NamedEntity) Visitor {
+	return f(w, e)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"strconv"
+
+	"go.uber.org/thriftrw/internal/crosstest"
+
+	"github.com/jessevdk/go-flags"
+)
+
+// Exit code of "thriftrw crosstest client" if any of the tests failed.
+const crossTestFailureExitCode = 1
+
+type crossTestOptions struct {
+	Host      string `long:"host" value-name:"HOST" default:"localhost" description:"Host of the server under test. Used only by the client."`
+	Port      int    `long:"port" value-name:"PORT" default:"9090" description:"Port on which the server listens or to which the client connects."`
+	Protocol  string `long:"protocol" value-name:"PROTOCOL" default:"binary" description:"Protocol used to encode requests. Only binary is supported."`
+	Transport string `long:"transport" value-name:"TRANSPORT" default:"framed" description:"Transport over which requests are sent. Only framed is supported."`
+}
+
+// doCrossTest implements the "thriftrw crosstest" command.
+func doCrossTest(args []string) error {
+	var opts crossTestOptions
+
+	parser := flags.NewParser(&opts, flags.Default)
+	parser.Name = "thriftrw crosstest"
+	parser.Usage = "[OPTIONS] server|client\n\n" +
+		"Runs the server or the client of the Apache Thrift cross-implementation\n" +
+		"test suite. The server implements the ThriftTest service and the client\n" +
+		"calls every function of that service, verifying the responses."
+
+	rest, err := parser.ParseArgs(args)
+	if err != nil {
+		return nil // message already printed by go-flags
+	}
+
+	if len(rest) != 1 || (rest[0] != "server" && rest[0] != "client") {
+		var buffer bytes.Buffer
+		parser.WriteHelp(&buffer)
+		return errors.New(buffer.String())
+	}
+
+	if opts.Protocol != "binary" {
+		return fmt.Errorf("unsupported protocol %q: only binary is supported", opts.Protocol)
+	}
+	if opts.Transport != "framed" {
+		return fmt.Errorf("unsupported transport %q: only framed is supported", opts.Transport)
+	}
+
+	if rest[0] == "server" {
+		return crossTestServer(&opts)
+	}
+	return crossTestClient(os.Stdout, &opts)
+}
+
+// crossTestServer serves the ThriftTest service on the configured port.
+func crossTestServer(opts *crossTestOptions) error {
+	l, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(opts.Port)))
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	log.Printf("Serving ThriftTest on %v", l.Addr())
+	return crosstest.Serve(l)
+}
+
+// crossTestClient runs the test suite against the configured server and
+// writes the results to w.
+func crossTestClient(w io.Writer, opts *crossTestOptions) error {
+	addr := net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port))
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("could not connect to %v: %v", addr, err)
+	}
+	defer conn.Close()
+
+	if err := crosstest.Run(w, crosstest.NewClient(conn)); err != nil {
+		return exitError{
+			Code:    crossTestFailureExitCode,
+			Message: err.Error(),
+		}
+	}
+	return nil
+}
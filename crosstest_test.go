@@ -0,0 +1,86 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"go.uber.org/thriftrw/internal/crosstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrossTestClient(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	go crosstest.Serve(l)
+
+	var out bytes.Buffer
+	err = crossTestClient(&out, &crossTestOptions{
+		Host: "127.0.0.1",
+		Port: l.Addr().(*net.TCPAddr).Port,
+	})
+	require.NoError(t, err, "output:\n%s", out.String())
+	assert.Contains(t, out.String(), "ok   testInsanity\n")
+}
+
+func TestCrossTestClientCannotConnect(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	require.NoError(t, l.Close())
+
+	err = crossTestClient(&bytes.Buffer{}, &crossTestOptions{Host: "127.0.0.1", Port: port})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "could not connect to 127.0.0.1:")
+	}
+}
+
+func TestDoCrossTestUnsupported(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{
+			args:    []string{"--protocol=compact", "client"},
+			wantErr: `unsupported protocol "compact": only binary is supported`,
+		},
+		{
+			args:    []string{"--transport=buffered", "server"},
+			wantErr: `unsupported transport "buffered": only framed is supported`,
+		},
+		{
+			args:    []string{"proxy"},
+			wantErr: "server|client",
+		},
+	}
+
+	for _, tt := range tests {
+		err := doCrossTest(tt.args)
+		if assert.Error(t, err, "%v", tt.args) {
+			assert.Contains(t, err.Error(), tt.wantErr, "%v", tt.args)
+		}
+	}
+}
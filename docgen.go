@@ -0,0 +1,133 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/generate"
+	"go.uber.org/thriftrw/internal/docgen"
+
+	"github.com/jessevdk/go-flags"
+)
+
+type docOptions struct {
+	OutputDirectory string `long:"out" short:"o" value-name:"DIR" required:"true" description:"Directory to which the documentation will be written."`
+	Format          string `long:"format" value-name:"FORMAT" default:"markdown" choice:"markdown" choice:"html" description:"Format of the generated documentation."`
+	ThriftRoot      string `long:"thrift-root" value-name:"DIR" description:"Directory whose descendants contain all Thrift files. The structure of the generated pages mirrors the paths to the Thrift files relative to this directory. By default, this is the deepest common ancestor directory of the Thrift files."`
+	NoRecurse       bool   `long:"no-recurse" description:"Don't generate documentation for included Thrift files."`
+}
+
+// doDoc implements the "thriftrw doc" command.
+func doDoc(args []string) error {
+	var opts docOptions
+
+	parser := flags.NewParser(&opts, flags.Default)
+	parser.Name = "thriftrw doc"
+	parser.Usage = "[OPTIONS] FILE\n\n" +
+		"Generates Markdown or HTML documentation for FILE and the files it includes\n" +
+		"from the doc comments (/** ... */) on their definitions."
+
+	files, err := parser.ParseArgs(args)
+	if err != nil {
+		return nil // message already printed by go-flags
+	}
+
+	if len(files) != 1 {
+		var buffer bytes.Buffer
+		parser.WriteHelp(&buffer)
+		return errors.New(buffer.String())
+	}
+
+	return writeDocs(files[0], &opts)
+}
+
+// writeDocs generates documentation for the given Thrift file and writes it
+// to the output directory.
+func writeDocs(file string, opts *docOptions) error {
+	format, err := docgen.ParseFormat(opts.Format)
+	if err != nil {
+		return err
+	}
+
+	module, err := compile.Compile(file)
+	if err != nil {
+		return fmt.Errorf("Failed to compile %q: %+v", file, err)
+	}
+
+	thriftRoot, err := resolveThriftRoot(file, module, opts.ThriftRoot)
+	if err != nil {
+		return err
+	}
+
+	pages, err := docgen.Generate(module, &docgen.Options{
+		Format:     format,
+		ThriftRoot: thriftRoot,
+		NoRecurse:  opts.NoRecurse,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to generate documentation: %v", err)
+	}
+
+	for relPath, contents := range pages {
+		path := filepath.Join(opts.OutputDirectory, relPath)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("Could not create directory %q: %v", filepath.Dir(path), err)
+		}
+		if err := ioutil.WriteFile(path, contents, 0644); err != nil {
+			return fmt.Errorf("Could not write %q: %v", path, err)
+		}
+	}
+	return nil
+}
+
+// resolveThriftRoot returns the absolute path to the Thrift root of the given
+// module. If thriftRoot was not specified by the user, the deepest common
+// ancestor directory of all Thrift files is used.
+func resolveThriftRoot(file string, module *compile.Module, thriftRoot string) (string, error) {
+	if thriftRoot == "" {
+		root, err := generate.FindThriftRoot(module)
+		if err != nil {
+			return "", fmt.Errorf(
+				"Could not find a common parent directory for %q and the Thrift files "+
+					"imported by it.\nUse the --thrift-root option to provide this path.\n\t%v",
+				file, err)
+		}
+		return root, nil
+	}
+
+	root, err := filepath.Abs(thriftRoot)
+	if err != nil {
+		return "", fmt.Errorf("Unable to resolve absolute path for %q: %v", thriftRoot, err)
+	}
+	if err := generate.VerifyThriftRoot(module, root); err != nil {
+		return "", fmt.Errorf(
+			"An included Thrift file is not contained in the %q directory tree: %v",
+			root, err)
+	}
+	return root, nil
+}
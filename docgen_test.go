@@ -0,0 +1,81 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteDocs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-doc-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "idl", "service.thrift")
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+	require.NoError(t, ioutil.WriteFile(file, []byte(
+		"/** A user. */\nstruct User {\n  1: required string name\n}\n"), 0644))
+
+	outDir := filepath.Join(dir, "docs")
+	require.NoError(t, writeDocs(file, &docOptions{
+		OutputDirectory: outDir,
+		Format:          "html",
+	}))
+
+	contents, err := ioutil.ReadFile(filepath.Join(outDir, "service.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), `<h3 id="User">User (struct)</h3>`)
+	assert.Contains(t, string(contents), "<p>A user.</p>")
+}
+
+func TestWriteDocsThriftRoot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-doc-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "idl", "service.thrift")
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+	require.NoError(t, ioutil.WriteFile(file, []byte("struct User {}\n"), 0644))
+
+	outDir := filepath.Join(dir, "docs")
+	require.NoError(t, writeDocs(file, &docOptions{
+		OutputDirectory: outDir,
+		Format:          "markdown",
+		ThriftRoot:      dir,
+	}))
+	_, err = os.Stat(filepath.Join(outDir, "idl", "service.md"))
+	assert.NoError(t, err)
+
+	err = writeDocs(file, &docOptions{
+		OutputDirectory: outDir,
+		Format:          "markdown",
+		ThriftRoot:      filepath.Join(dir, "elsewhere"),
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "is not contained in the")
+	}
+}
@@ -21,6 +21,7 @@
 package envelope
 
 import (
+	"context"
 	"fmt"
 	"io"
 
@@ -37,39 +38,126 @@ type Enveloper interface {
 	ToWire() (wire.Value, error)
 }
 
-// Write writes an Envelope to the given writer.
-func Write(p protocol.Protocol, w io.Writer, seqID int32, e Enveloper) error {
+// Unenveloper is the interface implemented by a type that can be read from
+// an envelope. Args and Result types generated for Thrift services
+// implement both Enveloper and Unenveloper.
+type Unenveloper interface {
+	MethodName() string
+	EnvelopeType() wire.EnvelopeType
+	FromWire(wire.Value) error
+}
+
+// Envelope is a Thrift request or response along with the metadata needed to
+// route it.
+//
+// Envelope has the same shape as wire.Envelope and may be converted to and
+// from it.
+type Envelope struct {
+	Name  string
+	Type  wire.EnvelopeType
+	SeqID int32
+	Value wire.Value
+}
+
+// New builds an Envelope holding the given request or response.
+//
+// 	env, err := envelope.New(seqID, kv.KeyValue_GetValue_Helper.Args(&key))
+func New(seqID int32, e Enveloper) (Envelope, error) {
 	body, err := e.ToWire()
 	if err != nil {
-		return err
+		return Envelope{}, err
 	}
-	return p.EncodeEnveloped(wire.Envelope{
-		SeqID: seqID,
+	return Envelope{
 		Name:  e.MethodName(),
 		Type:  e.EnvelopeType(),
+		SeqID: seqID,
 		Value: body,
-	}, w)
+	}, nil
 }
 
-// ReadReply reads enveloped responses from the given reader.
-func ReadReply(p protocol.Protocol, r io.ReaderAt) (_ wire.Value, seqID int32, _ error) {
-	envelope, err := p.DecodeEnveloped(r)
-	if err != nil {
-		return wire.Value{}, 0, err
+// Decode reads an Envelope from the given reader using the given protocol.
+func Decode(p protocol.Protocol, r io.ReaderAt) (Envelope, error) {
+	e, err := p.DecodeEnveloped(r)
+	return Envelope(e), err
+}
+
+// DecodeContext is like Decode but stops decoding and returns ctx.Err() once
+// the given context is done.
+func DecodeContext(ctx context.Context, p protocol.Protocol, r io.ReaderAt) (Envelope, error) {
+	e, err := protocol.DecodeEnvelopedContext(ctx, p, r)
+	return Envelope(e), err
+}
+
+// Encode writes the Envelope to the given writer using the given protocol.
+func (e Envelope) Encode(p protocol.Protocol, w io.Writer) error {
+	return p.EncodeEnveloped(wire.Envelope(e), w)
+}
+
+// Reply returns the body of a reply Envelope.
+//
+// If the Envelope holds an exception, the TApplicationException is decoded
+// and returned as the error. An error is also returned if the Envelope is
+// neither a reply nor an exception. The body is returned in all cases.
+func (e Envelope) Reply() (wire.Value, error) {
+	switch e.Type {
+	case wire.Reply:
+		return e.Value, nil
+	case wire.Exception:
+		return e.Value, decodeException(e.Value)
+	default:
+		return e.Value, fmt.Errorf("unknown envelope type for reply, got %v", e.Type)
 	}
+}
 
-	switch {
-	case envelope.Type == wire.Reply:
-		return envelope.Value, envelope.SeqID, nil
-	case envelope.Type != wire.Exception:
-		return envelope.Value, envelope.SeqID, fmt.Errorf("unknown envelope type for reply, got %v", envelope.Type)
+// Unwrap decodes the body of the Envelope into the given request or response.
+//
+// 	var res kv.KeyValue_GetValue_Result
+// 	if err := env.Unwrap(&res); err != nil {
+// 		return nil, err
+// 	}
+// 	return kv.KeyValue_GetValue_Helper.UnwrapResponse(&res)
+//
+// If the Envelope holds an exception in place of the expected response, the
+// TApplicationException is decoded and returned as the error.
+func (e Envelope) Unwrap(v Unenveloper) error {
+	want := v.EnvelopeType()
+	if e.Type == wire.Exception && want == wire.Reply {
+		return decodeException(e.Value)
+	}
+	if e.Type != want {
+		return fmt.Errorf(
+			"unexpected envelope type for %q: expected %v, got %v",
+			v.MethodName(), want, e.Type)
 	}
+	return v.FromWire(e.Value)
+}
 
-	// Decode the exception payload.
+// decodeException decodes a TApplicationException from the given Value. If
+// decoding fails, the failure is returned instead.
+func decodeException(v wire.Value) error {
 	ex := &exception.TApplicationException{}
-	if err := ex.FromWire(envelope.Value); err != nil {
-		return envelope.Value, envelope.SeqID, fmt.Errorf("failed to decode exception: %v", err)
+	if err := ex.FromWire(v); err != nil {
+		return fmt.Errorf("failed to decode exception: %v", err)
+	}
+	return ex
+}
+
+// Write writes an Envelope to the given writer.
+func Write(p protocol.Protocol, w io.Writer, seqID int32, e Enveloper) error {
+	env, err := New(seqID, e)
+	if err != nil {
+		return err
+	}
+	return env.Encode(p, w)
+}
+
+// ReadReply reads enveloped responses from the given reader.
+func ReadReply(p protocol.Protocol, r io.ReaderAt) (_ wire.Value, seqID int32, _ error) {
+	envelope, err := Decode(p, r)
+	if err != nil {
+		return wire.Value{}, 0, err
 	}
 
-	return envelope.Value, envelope.SeqID, ex
+	body, err := envelope.Reply()
+	return body, envelope.SeqID, err
 }
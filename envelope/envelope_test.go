@@ -22,6 +22,7 @@ package envelope_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"testing"
 
@@ -30,10 +31,12 @@ import (
 	. "go.uber.org/thriftrw/envelope"
 
 	tv "go.uber.org/thriftrw/gen/testdata/services"
+	"go.uber.org/thriftrw/gen/testdata/unions"
 	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/wire"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type failToWire struct {
@@ -200,3 +203,97 @@ func TestReadReply(t *testing.T) {
 		assert.Equal(t, tt.wantSeqID, seqID, "%v: seqID mismatch", tt.desc)
 	}
 }
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	req := tv.KeyValue_GetValue_Helper.Args((*tv.Key)(stringp("foo")))
+
+	env, err := New(42, req)
+	require.NoError(t, err)
+	assert.Equal(t, "getValue", env.Name)
+	assert.Equal(t, wire.Call, env.Type)
+	assert.Equal(t, int32(42), env.SeqID)
+
+	var buf bytes.Buffer
+	require.NoError(t, env.Encode(protocol.Binary, &buf))
+
+	decoded, err := Decode(protocol.Binary, bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, env.Name, decoded.Name)
+	assert.Equal(t, env.Type, decoded.Type)
+	assert.Equal(t, env.SeqID, decoded.SeqID)
+
+	decodedCtx, err := DecodeContext(context.Background(), protocol.Binary, bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, decoded.Name, decodedCtx.Name)
+	assert.Equal(t, decoded.SeqID, decodedCtx.SeqID)
+
+	var got tv.KeyValue_GetValue_Args
+	require.NoError(t, decoded.Unwrap(&got))
+	assert.True(t, req.Equals(&got), "request mismatch: %v != %v", req, &got)
+}
+
+func TestNewError(t *testing.T) {
+	_, err := New(1, failToWire{})
+	assert.Error(t, err)
+}
+
+func TestEnvelopeUnwrap(t *testing.T) {
+	success, err := tv.KeyValue_GetValue_Helper.WrapResponse(
+		&unions.ArbitraryValue{StringValue: stringp("bar")}, nil)
+	require.NoError(t, err)
+	successEnv, err := New(1, success)
+	require.NoError(t, err)
+
+	tests := []struct {
+		desc    string
+		env     Envelope
+		into    Unenveloper
+		wantErr string
+	}{
+		{
+			desc: "reply",
+			env:  successEnv,
+			into: &tv.KeyValue_GetValue_Result{},
+		},
+		{
+			desc: "exception",
+			env: Envelope{
+				Name:  "getValue",
+				Type:  wire.Exception,
+				SeqID: 1,
+				Value: wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+					{ID: 1, Value: wire.NewValueString("great sadness")},
+				}}),
+			},
+			into:    &tv.KeyValue_GetValue_Result{},
+			wantErr: "TApplicationException{Message: great sadness}",
+		},
+		{
+			desc:    "reply for a call",
+			env:     successEnv,
+			into:    &tv.KeyValue_GetValue_Args{},
+			wantErr: `unexpected envelope type for "getValue": expected Call, got Reply`,
+		},
+		{
+			desc: "exception for a call",
+			env: Envelope{
+				Name:  "getValue",
+				Type:  wire.Exception,
+				Value: wire.NewValueStruct(wire.Struct{}),
+			},
+			into:    &tv.KeyValue_GetValue_Args{},
+			wantErr: `unexpected envelope type for "getValue": expected Call, got Exception`,
+		},
+	}
+
+	for _, tt := range tests {
+		err := tt.env.Unwrap(tt.into)
+		if tt.wantErr == "" {
+			assert.NoError(t, err, tt.desc)
+			continue
+		}
+		if assert.Error(t, err, tt.desc) {
+			assert.Contains(t, err.Error(), tt.wantErr, tt.desc)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package envelope
+
+import (
+	"context"
+
+	"go.uber.org/thriftrw/wire"
+)
+
+// HandlerFunc handles the body of a request to the given method and returns
+// the body of the response.
+type HandlerFunc func(ctx context.Context, method string, body wire.Value) (wire.Value, error)
+
+// Middleware is invoked around the handling of a request. Implementations
+// call next to continue handling the request, or return without calling it
+// to reject the request.
+//
+// Middleware may be used to add logging, metrics, or authorization to every
+// function of a service without wrapping each handler by hand.
+//
+// 	func logging(ctx context.Context, method string, body wire.Value, next envelope.HandlerFunc) (wire.Value, error) {
+// 		res, err := next(ctx, method, body)
+// 		log.Printf("%v: %v", method, err)
+// 		return res, err
+// 	}
+type Middleware func(ctx context.Context, method string, body wire.Value, next HandlerFunc) (wire.Value, error)
+
+// ApplyMiddleware returns a HandlerFunc which calls the given middleware
+// around h. The first middleware is the outermost: it is called first and
+// returns last.
+func ApplyMiddleware(h HandlerFunc, middleware ...Middleware) HandlerFunc {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = applyMiddleware(h, middleware[i])
+	}
+	return h
+}
+
+func applyMiddleware(next HandlerFunc, m Middleware) HandlerFunc {
+	return func(ctx context.Context, method string, body wire.Value) (wire.Value, error) {
+		return m(ctx, method, body, next)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package envelope
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyMiddleware(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(ctx context.Context, method string, body wire.Value, next HandlerFunc) (wire.Value, error) {
+			calls = append(calls, name+" before "+method)
+			res, err := next(ctx, method, body)
+			calls = append(calls, name+" after "+method)
+			return res, err
+		}
+	}
+
+	handler := func(ctx context.Context, method string, body wire.Value) (wire.Value, error) {
+		calls = append(calls, "handler")
+		return wire.NewValueI32(body.GetI32() + 1), nil
+	}
+
+	res, err := ApplyMiddleware(handler, record("first"), record("second"))(
+		context.Background(), "foo", wire.NewValueI32(41))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(42), res.GetI32())
+	assert.Equal(t, []string{
+		"first before foo",
+		"second before foo",
+		"handler",
+		"second after foo",
+		"first after foo",
+	}, calls)
+
+	calls = nil
+	res, err = ApplyMiddleware(handler)(context.Background(), "foo", wire.NewValueI32(1))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), res.GetI32())
+	assert.Equal(t, []string{"handler"}, calls)
+}
+
+func TestMiddlewareReject(t *testing.T) {
+	rejected := errors.New("permission denied")
+	reject := func(context.Context, string, wire.Value, HandlerFunc) (wire.Value, error) {
+		return wire.Value{}, rejected
+	}
+
+	handler := func(context.Context, string, wire.Value) (wire.Value, error) {
+		t.Fatal("handler must not be called")
+		return wire.Value{}, nil
+	}
+
+	_, err := ApplyMiddleware(handler, reject)(context.Background(), "foo", wire.Value{})
+	assert.Equal(t, rejected, err)
+}
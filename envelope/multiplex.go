@@ -0,0 +1,149 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package envelope
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/thriftrw/wire"
+)
+
+// MultiplexSeparator separates the service name from the method name in the
+// names of multiplexed envelopes.
+//
+// This matches the format used by Apache Thrift's TMultiplexedProtocol and
+// TMultiplexedProcessor, which allows a single connection to carry requests
+// for different services.
+const MultiplexSeparator = ":"
+
+// MultiplexedName returns the name of the envelope for a request to the given
+// method of the given service.
+//
+// 	MultiplexedName("KeyValue", "getValue") // "KeyValue:getValue"
+func MultiplexedName(service, method string) string {
+	return service + MultiplexSeparator + method
+}
+
+// SplitMultiplexedName splits the name of a multiplexed envelope into the
+// service and method names. The service name is empty if the name was not
+// multiplexed.
+//
+// 	SplitMultiplexedName("KeyValue:getValue") // "KeyValue", "getValue"
+// 	SplitMultiplexedName("getValue")          // "", "getValue"
+func SplitMultiplexedName(name string) (service, method string) {
+	if i := strings.Index(name, MultiplexSeparator); i >= 0 {
+		return name[:i], name[i+len(MultiplexSeparator):]
+	}
+	return "", name
+}
+
+// TrimServiceName removes the given service name from the given envelope name
+// if the envelope was multiplexed for that service. Other names are returned
+// as-is.
+//
+// Generated handlers use this to accept requests from both multiplexing and
+// non-multiplexing clients.
+func TrimServiceName(service, name string) string {
+	return strings.TrimPrefix(name, service+MultiplexSeparator)
+}
+
+// Multiplexed wraps the given request so that its envelope is addressed to
+// the given service.
+//
+// 	env, err := envelope.New(seqID, envelope.Multiplexed(
+// 		"KeyValue", kv.KeyValue_GetValue_Helper.Args(&key)))
+func Multiplexed(service string, e Enveloper) Enveloper {
+	return multiplexed{Enveloper: e, service: service}
+}
+
+type multiplexed struct {
+	Enveloper
+
+	service string
+}
+
+func (m multiplexed) MethodName() string {
+	return MultiplexedName(m.service, m.Enveloper.MethodName())
+}
+
+// UnknownServiceError is returned by Mux if a request is addressed to a
+// service that was not registered with it.
+type UnknownServiceError struct {
+	// Name of the envelope that could not be dispatched.
+	Name string
+}
+
+func (e UnknownServiceError) Error() string {
+	service, _ := SplitMultiplexedName(e.Name)
+	if service == "" {
+		return fmt.Sprintf("unknown method %q: request is not addressed to a service", e.Name)
+	}
+	return fmt.Sprintf("unknown method %q: unknown service %q", e.Name, service)
+}
+
+// Mux dispatches multiplexed requests to the handlers of different services
+// based on the service name in the envelope.
+//
+// 	mux := envelope.NewMux()
+// 	mux.Register("KeyValue", kv.NewKeyValueHandler(impl).HandleContext)
+// 	mux.Register("Reflection", reflection.NewReflectionHandler(reflection.NewService()).HandleContext)
+//
+// Handlers receive the method name with the service name removed. Mux may
+// be registered with another Mux or wrapped with middleware as a HandlerFunc.
+//
+// Handlers may not be registered concurrently with calls to Handle.
+type Mux struct {
+	services map[string]HandlerFunc
+	fallback HandlerFunc
+}
+
+// NewMux builds a new Mux with no services.
+func NewMux() *Mux {
+	return &Mux{services: make(map[string]HandlerFunc)}
+}
+
+// Register registers the handler for requests to the given service.
+func (m *Mux) Register(service string, h HandlerFunc) {
+	m.services[service] = h
+}
+
+// RegisterDefault registers the handler for requests that are not addressed
+// to a service. This allows clients that don't support multiplexing to call
+// the given handler.
+func (m *Mux) RegisterDefault(h HandlerFunc) {
+	m.fallback = h
+}
+
+// Handle dispatches the given request to the handler of the service it was
+// addressed to. UnknownServiceError is returned if there is no such handler.
+func (m *Mux) Handle(ctx context.Context, name string, body wire.Value) (wire.Value, error) {
+	service, method := SplitMultiplexedName(name)
+	h := m.fallback
+	if service != "" {
+		h = m.services[service]
+	}
+	if h == nil {
+		return wire.Value{}, UnknownServiceError{Name: name}
+	}
+	return h(ctx, method, body)
+}
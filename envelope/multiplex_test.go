@@ -0,0 +1,123 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package envelope_test
+
+import (
+	"context"
+	"testing"
+
+	. "go.uber.org/thriftrw/envelope"
+
+	tv "go.uber.org/thriftrw/gen/testdata/services"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitMultiplexedName(t *testing.T) {
+	tests := []struct {
+		give        string
+		wantService string
+		wantMethod  string
+	}{
+		{give: "getValue", wantMethod: "getValue"},
+		{give: "KeyValue:getValue", wantService: "KeyValue", wantMethod: "getValue"},
+		{give: ":getValue", wantMethod: "getValue"},
+		{give: "KeyValue:", wantService: "KeyValue"},
+	}
+
+	for _, tt := range tests {
+		service, method := SplitMultiplexedName(tt.give)
+		assert.Equal(t, tt.wantService, service, tt.give)
+		assert.Equal(t, tt.wantMethod, method, tt.give)
+	}
+
+	assert.Equal(t, "KeyValue:getValue", MultiplexedName("KeyValue", "getValue"))
+}
+
+func TestTrimServiceName(t *testing.T) {
+	assert.Equal(t, "getValue", TrimServiceName("KeyValue", "KeyValue:getValue"))
+	assert.Equal(t, "getValue", TrimServiceName("KeyValue", "getValue"))
+	assert.Equal(t, "Other:getValue", TrimServiceName("KeyValue", "Other:getValue"))
+}
+
+func TestMultiplexed(t *testing.T) {
+	args := tv.KeyValue_GetValue_Helper.Args((*tv.Key)(stringp("foo")))
+
+	env, err := New(42, Multiplexed("KeyValue", args))
+	require.NoError(t, err)
+	assert.Equal(t, "KeyValue:getValue", env.Name)
+	assert.Equal(t, wire.Call, env.Type)
+
+	want, err := New(42, args)
+	require.NoError(t, err)
+	assert.Equal(t, want.Value, env.Value)
+}
+
+func TestMux(t *testing.T) {
+	handler := func(service string) HandlerFunc {
+		return func(ctx context.Context, method string, body wire.Value) (wire.Value, error) {
+			return wire.NewValueBinary([]byte(service + "." + method)), nil
+		}
+	}
+
+	mux := NewMux()
+	mux.Register("KeyValue", handler("KeyValue"))
+	mux.Register("Meta", handler("Meta"))
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr string
+	}{
+		{name: "KeyValue:getValue", want: "KeyValue.getValue"},
+		{name: "Meta:health", want: "Meta.health"},
+		{
+			name:    "Other:health",
+			wantErr: `unknown method "Other:health": unknown service "Other"`,
+		},
+		{
+			name:    "health",
+			wantErr: `unknown method "health": request is not addressed to a service`,
+		},
+	}
+
+	for _, tt := range tests {
+		res, err := mux.Handle(context.Background(), tt.name, wire.NewValueStruct(wire.Struct{}))
+		if tt.wantErr != "" {
+			assert.Equal(t, UnknownServiceError{Name: tt.name}, err, tt.name)
+			if assert.Error(t, err, tt.name) {
+				assert.Equal(t, tt.wantErr, err.Error(), tt.name)
+			}
+			continue
+		}
+		if assert.NoError(t, err, tt.name) {
+			assert.Equal(t, tt.want, res.GetString(), tt.name)
+		}
+	}
+
+	mux.RegisterDefault(handler("default"))
+	res, err := mux.Handle(context.Background(), "health", wire.NewValueStruct(wire.Struct{}))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "default.health", res.GetString())
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/internal/fixtures"
+
+	"github.com/jessevdk/go-flags"
+)
+
+type fixturesOptions struct {
+	OutputDirectory string `long:"out" short:"o" value-name:"DIR" required:"true" description:"Directory to which the fixtures will be written."`
+	Languages       string `long:"lang" value-name:"LANGS" default:"go,java,py" description:"Comma-separated list of languages whose identifiers are included in the fixture descriptions."`
+}
+
+// doFixtures implements the "thriftrw fixtures" command.
+func doFixtures(args []string) error {
+	var opts fixturesOptions
+
+	parser := flags.NewParser(&opts, flags.Default)
+	parser.Name = "thriftrw fixtures"
+	parser.Usage = "[OPTIONS] FILE\n\n" +
+		"Writes Binary protocol encodings of sample values for the types defined in FILE\n" +
+		"and the files it includes. Each $module/$type.bin is accompanied by a\n" +
+		"$module/$type.json describing the encoded value."
+
+	files, err := parser.ParseArgs(args)
+	if err != nil {
+		return nil // message already printed by go-flags
+	}
+
+	if len(files) != 1 {
+		var buffer bytes.Buffer
+		parser.WriteHelp(&buffer)
+		return errors.New(buffer.String())
+	}
+
+	return writeFixtures(files[0], &opts)
+}
+
+// writeFixtures builds fixtures for the given Thrift file and writes them to
+// the output directory.
+func writeFixtures(file string, opts *fixturesOptions) error {
+	var langs []string
+	for _, lang := range strings.Split(opts.Languages, ",") {
+		if lang = strings.TrimSpace(lang); lang != "" {
+			langs = append(langs, lang)
+		}
+	}
+
+	module, err := compile.Compile(file)
+	if err != nil {
+		return fmt.Errorf("Failed to compile %q: %+v", file, err)
+	}
+
+	fs, err := fixtures.Build(module, langs)
+	if err != nil {
+		return fmt.Errorf("Failed to build fixtures for %q: %v", file, err)
+	}
+
+	for _, f := range fs {
+		desc, err := json.MarshalIndent(f.Description, "", "  ")
+		if err != nil {
+			return fmt.Errorf("Could not encode description of %q: %v", f.Name, err)
+		}
+
+		path := filepath.Join(opts.OutputDirectory, filepath.FromSlash(strings.Replace(f.Name, ".", "/", 1)))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("Could not create directory for %q: %v", f.Name, err)
+		}
+		if err := ioutil.WriteFile(path+".bin", f.Encoded, 0644); err != nil {
+			return fmt.Errorf("Could not write fixture for %q: %v", f.Name, err)
+		}
+		if err := ioutil.WriteFile(path+".json", append(desc, '\n'), 0644); err != nil {
+			return fmt.Errorf("Could not write description of %q: %v", f.Name, err)
+		}
+	}
+	return nil
+}
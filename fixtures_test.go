@@ -0,0 +1,70 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFixtures(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-fixtures-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "point.thrift")
+	require.NoError(t, ioutil.WriteFile(file, []byte("struct Point { 1: required i32 x }"), 0644))
+
+	outputDir := filepath.Join(dir, "out")
+	require.NoError(t, writeFixtures(file, &fixturesOptions{
+		OutputDirectory: outputDir,
+		Languages:       "go, java",
+	}))
+
+	encoded, err := ioutil.ReadFile(filepath.Join(outputDir, "point", "Point.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{
+		0x08, 0x00, 0x01, 0x00, 0x00, 0x27, 0x10, // 1: i32 10000
+		0x00, // stop
+	}, encoded)
+
+	contents, err := ioutil.ReadFile(filepath.Join(outputDir, "point", "Point.json"))
+	require.NoError(t, err)
+
+	var desc struct {
+		Type        string
+		Identifiers map[string]interface{}
+	}
+	require.NoError(t, json.Unmarshal(contents, &desc))
+	assert.Equal(t, "point.Point", desc.Type)
+	assert.Len(t, desc.Identifiers, 2)
+
+	err = writeFixtures(file, &fixturesOptions{OutputDirectory: outputDir, Languages: "cobol"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `unsupported language "cobol"`)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"go.uber.org/thriftrw/internal/thriftfmt"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/pmezard/go-difflib/difflib"
+	"go.uber.org/multierr"
+)
+
+type fmtOptions struct {
+	Write bool `long:"write" short:"w" description:"Write the result back to the source file instead of printing it."`
+	Diff  bool `long:"diff" short:"d" description:"Print a diff of the changes instead of the formatted file."`
+
+	// Whether deprecated syntax should be rewritten as well.
+	modernize bool
+}
+
+// doFmt implements the "thriftrw fmt" command.
+func doFmt(args []string) error {
+	return runFormatter("thriftrw fmt", &fmtOptions{}, args)
+}
+
+// doModernize implements the "thriftrw modernize" command.
+func doModernize(args []string) error {
+	return runFormatter("thriftrw modernize", &fmtOptions{modernize: true}, args)
+}
+
+func runFormatter(name string, opts *fmtOptions, args []string) error {
+	parser := flags.NewParser(opts, flags.Default)
+	parser.Name = name
+	parser.Usage = "[OPTIONS] FILE..."
+
+	files, err := parser.ParseArgs(args)
+	if err != nil {
+		return nil // message already printed by go-flags
+	}
+
+	if len(files) == 0 {
+		var buffer bytes.Buffer
+		parser.WriteHelp(&buffer)
+		return errors.New(buffer.String())
+	}
+
+	for _, file := range files {
+		err = multierr.Append(err, formatFile(file, opts, os.Stdout))
+	}
+	return err
+}
+
+// formatFile formats the given Thrift file and writes the result according to
+// the given options.
+func formatFile(file string, opts *fmtOptions, out io.Writer) error {
+	src, err := ioutil.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("Could not read %q: %v", file, err)
+	}
+
+	format := thriftfmt.Format
+	if opts.modernize {
+		format = thriftfmt.Modernize
+	}
+
+	formatted, err := format(src)
+	if err != nil {
+		return fmt.Errorf("Failed to format %q: %v", file, err)
+	}
+
+	if opts.Diff {
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(src)),
+			B:        difflib.SplitLines(string(formatted)),
+			FromFile: file + ".orig",
+			ToFile:   file,
+			Context:  3,
+		})
+		if err != nil {
+			return fmt.Errorf("Could not diff %q: %v", file, err)
+		}
+		if _, err := io.WriteString(out, diff); err != nil {
+			return err
+		}
+	}
+
+	if !opts.Write {
+		if !opts.Diff {
+			_, err = out.Write(formatted)
+		}
+		return err
+	}
+
+	if bytes.Equal(src, formatted) {
+		return nil
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return fmt.Errorf("Could not stat file %q: %v", file, err)
+	}
+	if err := ioutil.WriteFile(file, formatted, info.Mode()); err != nil {
+		return fmt.Errorf("Could not write %q: %v", file, err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatFile(t *testing.T) {
+	const (
+		src  = "struct Foo {\n  1: string bar\n}\n"
+		want = "struct Foo {\n    1: string bar\n}\n"
+	)
+
+	dir, err := ioutil.TempDir("", "thriftrw-fmt-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "foo.thrift")
+	require.NoError(t, ioutil.WriteFile(file, []byte(src), 0644))
+
+	readFile := func() string {
+		contents, err := ioutil.ReadFile(file)
+		require.NoError(t, err)
+		return string(contents)
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, formatFile(file, &fmtOptions{}, &out))
+	assert.Equal(t, want, out.String())
+	assert.Equal(t, src, readFile(), "file must not be modified without -w")
+
+	out.Reset()
+	require.NoError(t, formatFile(file, &fmtOptions{Diff: true}, &out))
+	assert.Contains(t, out.String(), "-  1: string bar\n+    1: string bar\n")
+	assert.Equal(t, src, readFile(), "file must not be modified without -w")
+
+	out.Reset()
+	require.NoError(t, formatFile(file, &fmtOptions{Write: true}, &out))
+	assert.Empty(t, out.String())
+	assert.Equal(t, want, readFile())
+
+	require.NoError(t, ioutil.WriteFile(file, []byte("struct Foo {"), 0644))
+	err = formatFile(file, &fmtOptions{}, &out)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Failed to format")
+	}
+
+	err = formatFile(filepath.Join(dir, "missing.thrift"), &fmtOptions{}, &out)
+	assert.Error(t, err)
+}
+
+func TestModernizeFile(t *testing.T) {
+	const (
+		src  = "php_namespace Foo\nstruct Foo xsd_all {\n  1: string bar xsd_optional\n}\n"
+		want = "namespace php Foo\n\nstruct Foo {\n    1: string bar\n}\n"
+	)
+
+	dir, err := ioutil.TempDir("", "thriftrw-modernize-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "foo.thrift")
+	require.NoError(t, ioutil.WriteFile(file, []byte(src), 0644))
+
+	var out bytes.Buffer
+	require.NoError(t, formatFile(file, &fmtOptions{}, &out))
+	assert.Contains(t, out.String(), "php_namespace Foo\n", "fmt must retain deprecated syntax")
+
+	out.Reset()
+	require.NoError(t, formatFile(file, &fmtOptions{Write: true, modernize: true}, &out))
+	contents, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(contents))
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+// arenaVarName is the name of the *arena.Arena parameter of FromWireArena
+// methods and of readers generated with arena allocation. It is reserved in
+// generated packages when arena allocation is enabled.
+const arenaVarName = "_arena"
+
+// arenaAllocator is implemented by Generators which may generate code that
+// allocates decoded values from an arena.Arena.
+type arenaAllocator interface {
+	arenaAllocation() bool
+}
+
+// useArenaAllocation returns true if FromWire should be generated to
+// allocate values from a caller-provided arena.Arena.
+func useArenaAllocation(g Generator) bool {
+	if o, ok := g.(arenaAllocator); ok {
+		return o.arenaAllocation()
+	}
+	return false
+}
+
+// arenaAllocFuncName returns the name of the function which allocates values
+// of the given struct from an arena.
+func arenaAllocFuncName(g Generator, spec compile.TypeSpec) string {
+	return fmt.Sprintf("_%s_New", g.MangleType(spec))
+}
+
+// Allocator generates a function to allocate a struct of the given
+// type from an arena.Arena.
+//
+// 	func $name(a *arena.Arena) *$structType {
+// 		...
+// 	}
+//
+// And returns its name. The function allocates on the heap if the arena is
+// nil.
+func (s *structGenerator) Allocator(g Generator, spec *compile.StructSpec) (string, error) {
+	name := arenaAllocFuncName(g, spec)
+	err := g.EnsureDeclared(
+		`
+		<$arena := import "go.uber.org/thriftrw/arena">
+		<$structType := typeName .Spec>
+
+		var <.Key> = <$arena>.NewSlabKey("<$structType>")
+
+		<$a := newVar "a">
+		<$s := newVar "s">
+		func <.Name>(<$a> *<$arena>.Arena) *<$structType> {
+			if <$a> == nil {
+				return new(<$structType>)
+			}
+
+			<$s>, ok := <$a>.Slab(<.Key>).(*[]<$structType>)
+			if !ok {
+				<$s> = new([]<$structType>)
+				<$a>.SetSlab(<.Key>, <$s>)
+			}
+			if len(*<$s>) == cap(*<$s>) {
+				*<$s> = make([]<$structType>, 0, <$a>.SlabCapacity())
+			}
+			*<$s> = (*<$s>)[:len(*<$s>)+1]
+			return &(*<$s>)[len(*<$s>)-1]
+		}
+		`,
+		struct {
+			Name string
+			Key  string
+			Spec *compile.StructSpec
+		}{Name: name, Key: fmt.Sprintf("_%s_SlabKey", g.MangleType(spec)), Spec: spec},
+	)
+
+	return name, wrapGenerateError(spec.ThriftName(), err)
+}
+
+// arenaPrimitive returns the name of the arena.Arena method which allocates
+// values of the given primitive type and the Go type it accepts.
+func arenaPrimitive(g Generator, spec compile.TypeSpec) (method, goType string) {
+	switch compile.RootTypeSpec(spec).(type) {
+	case *compile.BoolSpec:
+		return "Bool", "bool"
+	case *compile.I8Spec:
+		return "Int8", "int8"
+	case *compile.I16Spec:
+		return "Int16", "int16"
+	case *compile.I32Spec, *compile.EnumSpec:
+		return "Int32", "int32"
+	case *compile.I64Spec:
+		return "Int64", "int64"
+	case *compile.DoubleSpec:
+		return "Float64", "float64"
+	case *compile.StringSpec:
+		return "String", "string"
+	case *compile.UUIDSpec:
+		return "UUID", g.Import("go.uber.org/thriftrw/uuid") + ".UUID"
+	default:
+		panic(fmt.Sprintf("%v is not a primitive type", spec))
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"reflect"
+	"testing"
+
+	"go.uber.org/thriftrw/arena"
+	ta "go.uber.org/thriftrw/gen/testdata/features/arena/records"
+	tp "go.uber.org/thriftrw/gen/testdata/features/plain/records"
+	"go.uber.org/thriftrw/ptr"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// arenaType is a type generated with --allocator.
+type arenaType interface {
+	thriftType
+
+	FromWireArena(wire.Value, *arena.Arena) error
+}
+
+func TestArenaAllocationRoundTrip(t *testing.T) {
+	tests := []struct {
+		desc  string
+		give  thriftType
+		arena arenaType
+		plain thriftType
+	}{
+		{
+			desc: "optional primitives",
+			give: &tp.User{
+				Name:   "alice",
+				Age:    ptr.Int32(30),
+				Avatar: []byte{1, 2, 3},
+				Active: ptr.Bool(false),
+				Score:  ptr.Float64(1.5),
+				Home:   &tp.Point{X: 1, Y: 2},
+			},
+			arena: &ta.User{},
+			plain: &tp.User{},
+		},
+		{
+			desc:  "nested structs",
+			give:  newerUser(),
+			arena: &ta.UserV2{},
+			plain: &tp.UserV2{},
+		},
+		{
+			desc: "containers",
+			give: &tp.Shapes{
+				Points: []*tp.Point{{X: 1}, {Y: 2}},
+				ByName: map[string]*tp.Point{"origin": {}},
+				Counts: []struct {
+					Key   *tp.Point
+					Value int32
+				}{{Key: &tp.Point{X: 1}, Value: 2}},
+			},
+			arena: &ta.Shapes{},
+			plain: &tp.Shapes{},
+		},
+		{
+			desc:  "union",
+			give:  &tp.Shape{Point: &tp.Point{X: 1}},
+			arena: &ta.Shape{},
+			plain: &tp.Shape{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			w, err := tt.give.ToWire()
+			require.NoError(t, err)
+
+			for _, a := range []*arena.Arena{nil, {}, {SlabSize: 1}} {
+				require.NoError(t, tt.arena.FromWireArena(w, a), "SlabSize: %v", a.SlabCapacity())
+				require.NoError(t, transcode(t, tt.arena, tt.plain))
+				assert.Equal(t, tt.give, tt.plain, "SlabSize: %v", a.SlabCapacity())
+			}
+		})
+	}
+}
+
+func TestArenaAllocationSharesSlabs(t *testing.T) {
+	points := make([]*tp.Point, 10)
+	for i := range points {
+		points[i] = &tp.Point{X: int32(i)}
+	}
+	w, err := (&tp.UserV2{Name: "alice", History: points, Age: ptr.Int32(1)}).ToWire()
+	require.NoError(t, err)
+
+	pointSize := reflect.TypeOf(ta.Point{}).Size()
+	adjacent := func(history []*ta.Point) bool {
+		for i := 1; i < len(history); i++ {
+			if reflect.ValueOf(history[i]).Pointer()-reflect.ValueOf(history[i-1]).Pointer() != pointSize {
+				return false
+			}
+		}
+		return true
+	}
+
+	var a arena.Arena
+	var u1, u2 ta.UserV2
+	require.NoError(t, u1.FromWireArena(w, &a))
+	require.NoError(t, u2.FromWireArena(w, &a))
+	assert.True(t, adjacent(u1.History), "structs must be allocated from one slab")
+	assert.Equal(t, reflect.ValueOf(u1.Age).Pointer()+4, reflect.ValueOf(u2.Age).Pointer(),
+		"optional primitives must be allocated from one slab")
+
+	var u3 ta.UserV2
+	require.NoError(t, u3.FromWire(w))
+	assert.Equal(t, u1.History, u3.History)
+
+	// Values decoded into an Arena stay valid after it is reset.
+	a.Reset()
+	var u4 ta.UserV2
+	require.NoError(t, u4.FromWireArena(w, &a))
+	assert.Equal(t, u3.History, u1.History)
+	assert.Equal(t, u3.History, u4.History)
+	*u4.Age = 42
+	assert.Equal(t, int32(1), *u1.Age)
+}
+
+func TestArenaAllocationAllocs(t *testing.T) {
+	points := make([]*tp.Point, 100)
+	for i := range points {
+		points[i] = &tp.Point{X: int32(i)}
+	}
+	w, err := (&tp.Shapes{Points: points}).ToWire()
+	require.NoError(t, err)
+
+	heap := testing.AllocsPerRun(10, func() {
+		var s ta.Shapes
+		require.NoError(t, s.FromWire(w))
+	})
+	slabs := testing.AllocsPerRun(10, func() {
+		a := arena.Arena{SlabSize: len(points)}
+		var s ta.Shapes
+		require.NoError(t, s.FromWireArena(w, &a))
+	})
+	// Decoding the list still allocates, but the structs in it share a slab
+	// instead of being allocated one by one. Allow for the allocations of
+	// the Arena itself.
+	assert.True(t, heap-slabs >= float64(len(points))*0.9,
+		"expected about %v fewer than %v allocations, got %v", len(points), heap, slabs)
+}
+
+func TestArenaAllocationInvalid(t *testing.T) {
+	// Home is missing its required y field.
+	w := wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+		{ID: 1, Value: wire.NewValueString("alice")},
+		{ID: 8, Value: wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+			{ID: 1, Value: wire.NewValueI32(1)},
+		}})},
+	}})
+
+	var want tp.User
+	wantErr := want.FromWire(w)
+	require.Error(t, wantErr)
+
+	var a arena.Arena
+	var got ta.User
+	err := got.FromWireArena(w, &a)
+	require.Error(t, err)
+	assert.Equal(t, wantErr.Error(), err.Error())
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/compile"
+)
+
+const goBuilderAnnotation = "go.builder"
+
+// builderGenerator is implemented by Generators which may generate builders
+// for large structs.
+type builderGenerator interface {
+	builderThreshold() int
+}
+
+// builderThreshold returns the number of fields above which structs get
+// builders, or zero if builders are generated only for annotated structs.
+func builderThreshold(g Generator) int {
+	if o, ok := g.(builderGenerator); ok {
+		return o.builderThreshold()
+	}
+	return 0
+}
+
+// useBuilder returns true if a builder should be generated for the given
+// struct. Structs which have more fields than the builder threshold get
+// builders unless they are annotated with (go.builder = "false"). Smaller
+// structs may ask for one with (go.builder = "true").
+//
+// 	struct CreateUserRequest {
+// 		...
+// 	} (go.builder = "true")
+//
+// Unions never get builders.
+func useBuilder(g Generator, spec *compile.StructSpec) (bool, error) {
+	if spec.Type == ast.UnionType {
+		if _, ok := spec.Annotations[goBuilderAnnotation]; ok {
+			return false, fmt.Errorf(
+				"invalid go.builder annotation: %q is a union", spec.Name)
+		}
+		return false, nil
+	}
+
+	switch value, ok := spec.Annotations[goBuilderAnnotation]; {
+	case !ok:
+	case value == "true":
+		return true, nil
+	case value == "false":
+		return false, nil
+	default:
+		return false, fmt.Errorf(
+			"invalid go.builder annotation %q on %q: expected true or false", value, spec.Name)
+	}
+
+	n := builderThreshold(g)
+	return n > 0 && len(spec.Fields) > n, nil
+}
+
+// builderTypeName returns the name of the builder for the given struct.
+func builderTypeName(name string) string {
+	return name + "Builder"
+}
+
+// Builder generates a builder for the field group if requested for the
+// given struct.
+//
+// 	type UserBuilder struct{ ... }
+//
+// 	func NewUserBuilder() *UserBuilder
+// 	func (b *UserBuilder) Name(x string) *UserBuilder
+// 	func (b *UserBuilder) Email(x string) *UserBuilder
+// 	func (b *UserBuilder) Build() (*User, error)
+//
+// Build fails if any required field was not set or was set to nil, or if the
+// struct has a Validate method and the built value doesn't pass it.
+func (f fieldGroupGenerator) Builder(g Generator, spec *compile.StructSpec) error {
+	ok, err := useBuilder(g, spec)
+	if err != nil || !ok {
+		return err
+	}
+
+	for _, field := range f.Fields {
+		name, err := goNames(g).goName(field)
+		if err != nil {
+			return err
+		}
+		if name == "Build" {
+			return fmt.Errorf(
+				"cannot generate a builder for %q: field %q conflicts with its Build method",
+				spec.Name, field.Name)
+		}
+	}
+
+	return g.DeclareFromTemplate(
+		`
+		<$wire := import "go.uber.org/thriftrw/wire">
+		<$builder := builderTypeName .Name>
+		<$b := newVar "b">
+		<$x := newVar "x">
+
+		// <$builder> builds <.Name> values one field at a time.
+		<if .Fields>
+			//
+			// 	v, err := New<$builder>().<goName (index .Fields 0)>(...).Build()
+		<end>
+		type <$builder> struct {
+			v <.Name>
+			<range .Fields>
+				<if .Required>
+					has<goName .> bool
+				<end>
+			<end>
+		}
+
+		// New<$builder> returns a builder for a new <.Name> with no fields
+		// set.
+		func New<$builder>() *<$builder> {
+			return &<$builder>{}
+		}
+
+		<$name := .Name>
+		<range .Fields>
+			<$fname := goName .>
+			// <$fname> sets <$fname> on the <$name> being built.
+			func (<$b> *<$builder>) <$fname>(<$x> <typeReference .Type>) *<$builder> {
+				<if .Required>
+					<$b>.v.<$fname> = <$x>
+					<$b>.has<$fname> = true
+				<else if isValueField .>
+					<$b>.v.Set<$fname>(<$x>)
+				<else>
+					<$b>.v.<$fname> = <addressOf .Type><$x>
+				<end>
+				return <$b>
+			}
+		<end>
+
+		<$v := newVar "v">
+		// Build returns the <.Name> built so far. It returns an error if any
+		// of its required fields was not set or was set to nil<if .Validate>,
+		// or if the value fails validation<end>.
+		//
+		// The builder may be reused after calling Build; later changes don't
+		// affect the returned value.
+		func (<$b> *<$builder>) Build() (*<.Name>, error) {
+			<range .Fields>
+				<if .Required>
+					if !<$b>.has<goName .><if not (isPrimitiveType .Type)> || <$b>.v.<goName .> == nil<end> {
+						return nil, <$wire>.NilRequiredFieldError{
+							Struct: "<$name>",
+							Field:  "<goName .>",
+							ID:     <.ID>,
+						}
+					}
+				<end>
+			<end>
+			<$v> := <$b>.v
+			<if .Validate>
+				if err := <$v>.Validate(); err != nil {
+					return nil, err
+				}
+			<end>
+			return &<$v>, nil
+		}
+		`,
+		struct {
+			Name     string
+			Fields   compile.FieldGroup
+			Validate bool
+		}{Name: f.Name, Fields: f.Fields, Validate: hasValidation(g, spec)},
+		TemplateFunc("builderTypeName", builderTypeName),
+		TemplateFunc("addressOf", func(t compile.TypeSpec) string {
+			// Optional primitives are stored as pointers.
+			if isPrimitiveType(t) {
+				return "&"
+			}
+			return ""
+		}),
+	)
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+	ts "go.uber.org/thriftrw/gen/testdata/structs"
+	"go.uber.org/thriftrw/ptr"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilders(t *testing.T) {
+	location := &ts.Point{X: 1, Y: 2}
+	manager := &ts.BuiltUser{Name: "bob", Location: location}
+
+	b := ts.NewBuiltUserBuilder()
+	got, err := b.
+		Name("alice").
+		Email("alice@example.com").
+		Tags([]string{"admin"}).
+		Age(0).
+		Manager(manager).
+		Location(location).
+		Active(false).
+		Build()
+	require.NoError(t, err)
+
+	want := &ts.BuiltUser{
+		Name:     "alice",
+		Email:    ptr.String("alice@example.com"),
+		Tags:     []string{"admin"},
+		Manager:  manager,
+		Location: location,
+		Active:   ptr.Bool(false),
+	}
+	want.SetAge(0)
+	assert.True(t, want.Equals(got), "expected %v, got %v", want, got)
+	assert.True(t, got.IsSetAge(), "value fields set to zero must be set")
+
+	// Later changes to the builder don't affect values that were built.
+	again, err := b.Name("carol").Email("carol@example.com").Build()
+	require.NoError(t, err)
+	assert.Equal(t, "alice", got.Name)
+	assert.Equal(t, "alice@example.com", got.GetEmail())
+	assert.Equal(t, "carol", again.Name)
+
+	// Built values can be serialized.
+	w, err := got.ToWire()
+	require.NoError(t, err)
+	var decoded ts.BuiltUser
+	require.NoError(t, decoded.FromWire(w))
+	assert.True(t, got.Equals(&decoded))
+}
+
+func TestBuildersRequiredFields(t *testing.T) {
+	tests := []struct {
+		desc string
+		give *ts.BuiltUserBuilder
+		want error
+	}{
+		{
+			desc: "nothing set",
+			give: ts.NewBuiltUserBuilder(),
+			want: wire.NilRequiredFieldError{Struct: "BuiltUser", Field: "Name", ID: 1},
+		},
+		{
+			desc: "missing struct",
+			give: ts.NewBuiltUserBuilder().Name("alice").Email("alice@example.com"),
+			want: wire.NilRequiredFieldError{Struct: "BuiltUser", Field: "Location", ID: 6},
+		},
+		{
+			desc: "nil struct",
+			give: ts.NewBuiltUserBuilder().Name("alice").Location(nil),
+			want: wire.NilRequiredFieldError{Struct: "BuiltUser", Field: "Location", ID: 6},
+		},
+		{
+			desc: "missing primitive",
+			give: ts.NewBuiltUserBuilder().Location(&ts.Point{}),
+			want: wire.NilRequiredFieldError{Struct: "BuiltUser", Field: "Name", ID: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.give.Build()
+		assert.Nil(t, got, tt.desc)
+		assert.Equal(t, tt.want, err, tt.desc)
+	}
+}
+
+func TestBuildersValidate(t *testing.T) {
+	// Empty names are set but fail validation.
+	_, err := ts.NewBuiltUserBuilder().Name("").Location(&ts.Point{}).Build()
+	assert.Equal(t, wire.InvalidFieldError{
+		Struct: "BuiltUser",
+		Field:  "Name",
+		ID:     1,
+		Reason: "length must be at least 1",
+	}, err)
+
+	_, err = ts.NewBuiltUserBuilder().
+		Name("alice").
+		Location(&ts.Point{}).
+		Manager(&ts.BuiltUser{Location: &ts.Point{}}).
+		Build()
+	assert.Error(t, err, "nested structs must be validated")
+}
+
+func TestBuilderSelection(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-builder-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	thriftFile := filepath.Join(dir, "main.thrift")
+	require.NoError(t, ioutil.WriteFile(thriftFile, []byte(`
+		struct Small {
+			1: optional i32 a
+		}
+
+		struct Annotated {
+			1: optional i32 a
+		} (go.builder = "true")
+
+		struct Large {
+			1: optional i32 a
+			2: optional i32 b
+			3: optional i32 c
+			4: optional i32 d
+		}
+
+		struct OptedOut {
+			1: optional i32 a
+			2: optional i32 b
+			3: optional i32 c
+			4: optional i32 d
+		} (go.builder = "false")
+	`), 0644))
+
+	module, err := compile.Compile(thriftFile)
+	require.NoError(t, err)
+
+	tests := []struct {
+		desc      string
+		threshold int
+		want      []string
+	}{
+		{desc: "annotated only", want: []string{"Annotated"}},
+		{desc: "threshold", threshold: 3, want: []string{"Annotated", "Large"}},
+		{desc: "at the threshold", threshold: 4, want: []string{"Annotated"}},
+	}
+
+	for _, tt := range tests {
+		outputDir := filepath.Join(dir, "out")
+		require.NoError(t, Generate(module, &Options{
+			OutputDir:        outputDir,
+			PackagePrefix:    "example.com/out",
+			ThriftRoot:       dir,
+			NoVersionCheck:   true,
+			NoEmbedIDL:       true,
+			BuilderThreshold: tt.threshold,
+		}), tt.desc)
+
+		contents, err := ioutil.ReadFile(filepath.Join(outputDir, "main", "types.go"))
+		require.NoError(t, err, tt.desc)
+
+		var got []string
+		for _, name := range []string{"Small", "Annotated", "Large", "OptedOut"} {
+			if strings.Contains(string(contents), "type "+name+"Builder struct") {
+				got = append(got, name)
+			}
+		}
+		assert.Equal(t, tt.want, got, tt.desc)
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		give    string
+		wantErr string
+	}{
+		{
+			desc:    "invalid annotation",
+			give:    `struct Foo { 1: optional string bar } (go.builder = "yes")`,
+			wantErr: `invalid go.builder annotation "yes" on "Foo": expected true or false`,
+		},
+		{
+			desc:    "union",
+			give:    `union Foo { 1: string bar } (go.builder = "true")`,
+			wantErr: `invalid go.builder annotation: "Foo" is a union`,
+		},
+		{
+			desc:    "conflicting field",
+			give:    `struct Foo { 1: optional string build } (go.builder = "true")`,
+			wantErr: `cannot generate a builder for "Foo": field "build" conflicts with its Build method`,
+		},
+	}
+
+	for _, tt := range tests {
+		dir, err := ioutil.TempDir("", "thriftrw-builder-test")
+		require.NoError(t, err, tt.desc)
+		defer os.RemoveAll(dir)
+
+		thriftFile := filepath.Join(dir, "main.thrift")
+		require.NoError(t, ioutil.WriteFile(thriftFile, []byte(tt.give), 0644), tt.desc)
+
+		module, err := compile.Compile(thriftFile)
+		require.NoError(t, err, tt.desc)
+
+		err = Generate(module, &Options{
+			OutputDir:      filepath.Join(dir, "out"),
+			PackagePrefix:  "example.com/out",
+			ThriftRoot:     dir,
+			NoVersionCheck: true,
+			NoEmbedIDL:     true,
+		})
+		if assert.Error(t, err, tt.desc) {
+			assert.Contains(t, err.Error(), tt.wantErr, tt.desc)
+		}
+	}
+}
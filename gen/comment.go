@@ -0,0 +1,210 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// docMarker precedes doc comments rendered by the doc template function so
+// that they may be told apart from other comments in templates, which are not
+// copied into the generated code.
+const docMarker = "//thriftrw:doc"
+
+// docComment renders documentation from the Thrift file as a comment. Placed
+// on the line right above a declaration, field, or enum item in a template,
+// it becomes the doc comment of that node in the generated code.
+//
+// 	<doc .Spec.Doc>
+// 	type <.Name> struct {
+//
+// Nothing is rendered if the documentation is empty.
+func docComment(doc string) string {
+	if doc == "" {
+		return ""
+	}
+
+	lines := []string{docMarker}
+	for _, l := range strings.Split(doc, "\n") {
+		if l = strings.TrimRight(l, " \t\r"); l == "" {
+			lines = append(lines, "//")
+		} else {
+			lines = append(lines, "// "+l)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// commentFields returns pointers to the doc comment and line comment of the
+// given node. False is returned if the node cannot have a doc comment.
+func commentFields(n ast.Node) (doc, comment **ast.CommentGroup, ok bool) {
+	switch n := n.(type) {
+	case *ast.GenDecl:
+		return &n.Doc, nil, true
+	case *ast.FuncDecl:
+		return &n.Doc, nil, true
+	case *ast.TypeSpec:
+		return &n.Doc, &n.Comment, true
+	case *ast.ValueSpec:
+		return &n.Doc, &n.Comment, true
+	case *ast.ImportSpec:
+		return &n.Doc, &n.Comment, true
+	case *ast.Field:
+		return &n.Doc, &n.Comment, true
+	default:
+		return nil, nil, false
+	}
+}
+
+// detachDocs removes all comments from the given declaration and returns the
+// doc comments rendered by docComment, in the order ast.Inspect visits the
+// nodes that may have doc comments. Nil is returned if there were no such doc
+// comments.
+func detachDocs(decl ast.Decl) []string {
+	var (
+		docs  []string
+		found bool
+	)
+	ast.Inspect(decl, func(n ast.Node) bool {
+		doc, comment, ok := commentFields(n)
+		if !ok {
+			return true
+		}
+
+		text := renderedDoc(*doc)
+		found = found || text != ""
+		docs = append(docs, text)
+
+		*doc = nil
+		if comment != nil {
+			*comment = nil
+		}
+		return true
+	})
+
+	if !found {
+		return nil
+	}
+	return docs
+}
+
+// renderedDoc returns the lines of the given comment group following
+// docMarker.
+func renderedDoc(g *ast.CommentGroup) string {
+	if g == nil {
+		return ""
+	}
+
+	for i, c := range g.List {
+		if c.Text != docMarker {
+			continue
+		}
+
+		lines := make([]string, 0, len(g.List)-i-1)
+		for _, c := range g.List[i+1:] {
+			lines = append(lines, c.Text)
+		}
+		return strings.Join(lines, "\n")
+	}
+	return ""
+}
+
+// insertDocs adds doc comments previously removed by detachDocs to the
+// printed form of the same declaration.
+//
+// Generated declarations are printed without position information, so the
+// comments cannot be printed alongside them. Instead, the printed code is
+// parsed again to find the lines on which the documented nodes start, the
+// comments are inserted above these lines, and the result is formatted.
+func insertDocs(src []byte, docs []string) ([]byte, error) {
+	const header = "package thriftrw\n\n"
+
+	trailingNewline := bytes.HasSuffix(src, []byte{'\n'})
+	src = append([]byte(header), src...)
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "thriftrw.go", src, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(string(src), "\n")
+
+	// Line number to the doc comment that goes above it.
+	comments := make(map[int]string)
+	i := 0
+	for _, decl := range f.Decls {
+		ast.Inspect(decl, func(n ast.Node) bool {
+			if _, _, ok := commentFields(n); !ok || i >= len(docs) {
+				return true
+			}
+
+			doc := docs[i]
+			i++
+			if doc == "" {
+				return true
+			}
+
+			// Documented nodes start on their own lines, unless they
+			// share it with their parent declaration.
+			pos := fset.Position(n.Pos())
+			line := lines[pos.Line-1]
+			indent := len(line) - len(strings.TrimLeft(line, " \t"))
+			if pos.Column-1 != indent {
+				return true
+			}
+			if _, ok := comments[pos.Line]; !ok {
+				comments[pos.Line] = doc
+			}
+			return true
+		})
+	}
+
+	var buff bytes.Buffer
+	for i, line := range lines {
+		if doc, ok := comments[i+1]; ok {
+			indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			for _, c := range strings.Split(doc, "\n") {
+				buff.WriteString(indent)
+				buff.WriteString(c)
+				buff.WriteByte('\n')
+			}
+		}
+		buff.WriteString(line)
+		if i < len(lines)-1 {
+			buff.WriteByte('\n')
+		}
+	}
+
+	out, err := format.Source(buff.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	out = bytes.TrimPrefix(out, []byte(header))
+	if !trailingNewline {
+		out = bytes.TrimSuffix(out, []byte{'\n'})
+	}
+	return out, nil
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocComment(t *testing.T) {
+	tests := []struct {
+		give string
+		want string
+	}{
+		{give: "", want: ""},
+		{give: "Foo.", want: "//thriftrw:doc\n// Foo."},
+		{
+			give: "Foo.\n\n  Bar: baz  ",
+			want: "//thriftrw:doc\n// Foo.\n//\n//   Bar: baz",
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, docComment(tt.give), "%q", tt.give)
+	}
+}
+
+func TestGenerateDocComments(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-doc-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	thriftFile := filepath.Join(dir, "users.thrift")
+	require.NoError(t, ioutil.WriteFile(thriftFile, []byte(`
+		/**
+		 * A user of the system.
+		 *
+		 * Users are identified by ID.
+		 */
+		struct User {
+			// ID of the user.
+			1: required string id
+			2: optional string name
+			/** Free-form notes. */
+			3: optional string notes
+		}
+
+		/** Whether a user may log in. */
+		enum Status {
+			/** The user may log in. */
+			ENABLED
+			DISABLED
+		}
+
+		// Unique identifier for a user.
+		typedef string UserID
+
+		// Maximum length of a name.
+		const i32 maxNameLength = 64
+
+		service Users {
+			// Gets the user with the given ID.
+			User getUser(
+				// ID of the user to get.
+				1: string id
+			)
+		}
+	`), 0644))
+
+	module, err := compile.Compile(thriftFile)
+	require.NoError(t, err)
+
+	outputDir := filepath.Join(dir, "out")
+	require.NoError(t, Generate(module, &Options{
+		OutputDir:     outputDir,
+		PackagePrefix: "example.com/out",
+		ThriftRoot:    dir,
+	}))
+
+	read := func(name string) string {
+		contents, err := ioutil.ReadFile(filepath.Join(outputDir, "users", name))
+		require.NoError(t, err, name)
+		return string(contents)
+	}
+
+	types := read("types.go")
+	for _, want := range []string{
+		"// A user of the system.\n//\n// Users are identified by ID.\ntype User struct {\n",
+		"\t// ID of the user.\n\tID   string  `json:\"id\"`\n" +
+			"\tName *string `json:\"name,omitempty\"`\n" +
+			"\t// Free-form notes.\n\tNotes *string `json:\"notes,omitempty\"`\n}",
+		"// Whether a user may log in.\ntype Status int32\n",
+		"\t// The user may log in.\n\tStatusEnabled  Status = 0\n",
+		"// Unique identifier for a user.\ntype UserID string\n",
+	} {
+		assert.Contains(t, types, want)
+	}
+	assert.NotContains(t, types, docMarker)
+
+	assert.Contains(t, read("constants.go"),
+		"// Maximum length of a name.\nconst MaxNameLength int32 = 64\n")
+
+	assert.Contains(t, read("users_getuser.go"),
+		"// Gets the user with the given ID.\ntype Users_GetUser_Args struct {\n"+
+			"\t// ID of the user to get.\n\tID *string `json:\"id,omitempty\"`\n}")
+}
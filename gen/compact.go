@@ -0,0 +1,67 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+// compactGenerator is implemented by Generators which may generate compact
+// code for decoding structs.
+type compactGenerator interface {
+	compactCodegen() bool
+}
+
+// useCompactCodegen returns true if structs should decode their fields with
+// a wire.FieldTable instead of code unrolled for every field.
+func useCompactCodegen(g Generator) bool {
+	if o, ok := g.(compactGenerator); ok {
+		return o.compactCodegen()
+	}
+	return false
+}
+
+// fieldTableName returns the name of the variable holding the
+// wire.FieldTable for this field group.
+func (f fieldGroupGenerator) fieldTableName() string {
+	return "_" + f.Name + "_fieldTable"
+}
+
+// FieldTable generates the wire.FieldTable with which compact FromWire
+// methods decode the fields of the field group.
+//
+// 	var _User_fieldTable = wire.NewFieldTable("User",
+// 		wire.FieldInfo{ID: 1, Type: wire.TBinary, Name: "Name", Required: true},
+// 		wire.FieldInfo{ID: 2, Type: wire.TI32, Name: "Age"},
+// 	)
+//
+// Required fields with default values are not marked as required because
+// their defaults are filled in if they are absent.
+func (f fieldGroupGenerator) FieldTable(g Generator) error {
+	return g.DeclareFromTemplate(
+		`
+		<$wire := import "go.uber.org/thriftrw/wire">
+		var <.TableName> = <$wire>.NewFieldTable("<.Group.Name>",<range .Group.Fields>
+			<$wire>.FieldInfo{ID: <.ID>, Type: <typeCode .Type>, Name: "<goName .>"<if and .Required (not .Default)>, Required: true<end>},<end>
+		)
+		`,
+		struct {
+			TableName string
+			Group     fieldGroupGenerator
+		}{TableName: f.fieldTableName(), Group: f},
+	)
+}
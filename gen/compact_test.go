@@ -0,0 +1,244 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	tc "go.uber.org/thriftrw/gen/testdata/features/compact/records"
+	tp "go.uber.org/thriftrw/gen/testdata/features/plain/records"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/ptr"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// transcode encodes from with the Binary protocol and decodes the result
+// into to.
+func transcode(t *testing.T, from, to thriftType) error {
+	w, err := from.ToWire()
+	require.NoError(t, err, "failed to serialize %v", from)
+
+	var buff bytes.Buffer
+	require.NoError(t, protocol.Binary.Encode(w, &buff), "failed to encode %v", from)
+
+	w, err = protocol.Binary.Decode(bytes.NewReader(buff.Bytes()), w.Type())
+	require.NoError(t, err, "failed to decode %v", from)
+	return to.FromWire(w)
+}
+
+func TestCompactCodegenUsesFieldTables(t *testing.T) {
+	contents, err := ioutil.ReadFile("testdata/features/compact/records/types.go")
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), "var _User_fieldTable = wire.NewFieldTable(")
+
+	contents, err = ioutil.ReadFile("testdata/features/plain/records/types.go")
+	require.NoError(t, err)
+	assert.NotContains(t, string(contents), "wire.NewFieldTable(")
+}
+
+func TestCompactCodegenRoundTrip(t *testing.T) {
+	blue := tp.ColorBlue
+	tests := []struct {
+		desc    string
+		give    thriftType
+		compact thriftType
+		plain   thriftType
+	}{
+		{
+			desc: "required fields only",
+			give: &tp.User{Name: "alice"},
+			// Defaults are applied to missing fields.
+			compact: &tc.User{},
+			plain:   &tp.User{},
+		},
+		{
+			desc: "all fields",
+			give: &tp.User{
+				Name:   "alice",
+				Age:    ptr.Int32(30),
+				Avatar: []byte{1, 2, 3},
+				Color:  &blue,
+				Tags:   []string{"admin", "ops"},
+				Places: map[string]*tp.Point{"home": {X: 1, Y: 2}, "work": {X: 3}},
+				Ids:    map[int64]struct{}{1: {}, 2: {}},
+				Home:   &tp.Point{X: 1, Y: 2},
+				Active: ptr.Bool(false),
+				Score:  ptr.Float64(1.5),
+			},
+			compact: &tc.User{},
+			plain:   &tp.User{},
+		},
+		{
+			desc:    "nested structs",
+			give:    newerUser(),
+			compact: &tc.UserV2{},
+			plain:   &tp.UserV2{},
+		},
+		{
+			desc: "containers",
+			give: &tp.Shapes{
+				Points: []*tp.Point{{X: 1}, {Y: 2}},
+				Names:  map[string]struct{}{"a": {}},
+				Blobs:  [][]byte{{1}, {2, 3}},
+				ByName: map[string]*tp.Point{"origin": {}},
+				Counts: []struct {
+					Key   *tp.Point
+					Value int32
+				}{{Key: &tp.Point{X: 1}, Value: 2}},
+				Grid: [][]int32{{1, 2}, {}, {3}},
+			},
+			compact: &tc.Shapes{},
+			plain:   &tp.Shapes{},
+		},
+		{
+			desc:    "union",
+			give:    &tp.Shape{Polygon: []*tp.Point{{X: 1}, {X: 2}}},
+			compact: &tc.Shape{},
+			plain:   &tp.Shape{},
+		},
+		{
+			desc:    "exception",
+			give:    &tp.NotFound{Key: "foo"},
+			compact: &tc.NotFound{},
+			plain:   &tp.NotFound{},
+		},
+		{
+			desc:    "empty",
+			give:    &tp.Empty{},
+			compact: &tc.Empty{},
+			plain:   &tp.Empty{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			require.NoError(t, transcode(t, tt.give, tt.compact), "decode with compact codegen failed")
+			require.NoError(t, transcode(t, tt.compact, tt.plain), "encode with compact codegen failed")
+
+			want := tt.give
+			if u, ok := want.(*tp.User); ok && u.Active == nil {
+				u.Active = ptr.Bool(true)
+			}
+			assert.Equal(t, want, tt.plain)
+		})
+	}
+}
+
+func TestCompactCodegenInvalid(t *testing.T) {
+	point := func(fields ...wire.Field) wire.Value {
+		return wire.NewValueStruct(wire.Struct{Fields: fields})
+	}
+	name := wire.Field{ID: 1, Value: wire.NewValueString("alice")}
+
+	tests := []struct {
+		desc    string
+		give    wire.Value
+		compact thriftType
+		plain   thriftType
+		wantErr bool
+	}{
+		{
+			desc:    "missing required field",
+			give:    point(wire.Field{ID: 2, Value: wire.NewValueI32(30)}),
+			compact: &tc.User{},
+			plain:   &tp.User{},
+			wantErr: true,
+		},
+		{
+			desc:    "missing required field of nested struct",
+			give:    point(name, wire.Field{ID: 8, Value: point(wire.Field{ID: 1, Value: wire.NewValueI32(1)})}),
+			compact: &tc.User{},
+			plain:   &tp.User{},
+			wantErr: true,
+		},
+		{
+			desc:    "missing required field of exception",
+			give:    point(),
+			compact: &tc.NotFound{},
+			plain:   &tp.NotFound{},
+			wantErr: true,
+		},
+		{
+			desc: "mismatched type is skipped",
+			give: point(
+				name,
+				wire.Field{ID: 2, Value: wire.NewValueString("30")},
+				wire.Field{ID: 9, Value: wire.NewValueI32(1)},
+			),
+			compact: &tc.User{},
+			plain:   &tp.User{},
+		},
+		{
+			desc:    "unknown field is skipped",
+			give:    point(name, wire.Field{ID: 100, Value: wire.NewValueI32(1)}),
+			compact: &tc.User{},
+			plain:   &tp.User{},
+		},
+		{
+			desc:    "duplicate field",
+			give:    point(name, wire.Field{ID: 1, Value: wire.NewValueString("bob")}),
+			compact: &tc.User{},
+			plain:   &tp.User{},
+		},
+		{
+			desc:    "empty union",
+			give:    point(),
+			compact: &tc.Shape{},
+			plain:   &tp.Shape{},
+			wantErr: true,
+		},
+		{
+			desc: "union with multiple fields",
+			give: point(
+				wire.Field{ID: 1, Value: point()},
+				wire.Field{ID: 2, Value: wire.NewValueList(wire.ValueListFromSlice(wire.TStruct, nil))},
+			),
+			compact: &tc.Shape{},
+			plain:   &tp.Shape{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			compactErr := tt.compact.FromWire(tt.give)
+			plainErr := tt.plain.FromWire(tt.give)
+			if tt.wantErr {
+				require.Error(t, plainErr)
+				require.Error(t, compactErr)
+				assert.Equal(t, plainErr.Error(), compactErr.Error())
+				return
+			}
+
+			require.NoError(t, plainErr)
+			require.NoError(t, compactErr)
+
+			var got tp.User
+			require.NoError(t, transcode(t, tt.compact, &got))
+			assert.Equal(t, tt.plain, &got)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Compile and Generate may be called concurrently. Run with -race to catch
+// shared state.
+func TestConcurrentCompileAndGenerate(t *testing.T) {
+	thriftRoot, err := filepath.Abs("testdata/thrift")
+	require.NoError(t, err)
+
+	thriftFiles, err := filepath.Glob(filepath.Join(thriftRoot, "*.thrift"))
+	require.NoError(t, err)
+	require.NotEmpty(t, thriftFiles)
+
+	outputDir, err := ioutil.TempDir("", "thriftrw-concurrency-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(outputDir)
+
+	// Modules compiled up front are shared by all goroutines generating
+	// code for them.
+	shared := make(map[string]*compile.Module, len(thriftFiles))
+	for _, f := range thriftFiles {
+		m, err := compile.Compile(f)
+		require.NoError(t, err, "failed to compile %q", f)
+		shared[f] = m
+	}
+
+	const workers = 4
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		hashes = make(map[string][]string) // thrift file -> output hashes
+	)
+	for i := 0; i < workers; i++ {
+		for _, f := range thriftFiles {
+			wg.Add(1)
+			go func(i int, f string) {
+				defer wg.Done()
+
+				// Alternate between sharing the compiled module and
+				// compiling it again.
+				m := shared[f]
+				if i%2 == 1 {
+					var err error
+					m, err = compile.Compile(f)
+					if !assert.NoError(t, err, "failed to compile %q", f) {
+						return
+					}
+				}
+
+				dir := filepath.Join(outputDir, fmt.Sprintf("%v-%d", filepath.Base(f), i))
+				err := Generate(m, &Options{
+					OutputDir:     dir,
+					PackagePrefix: "go.uber.org/thriftrw/gen/testdata",
+					ThriftRoot:    thriftRoot,
+					NoRecurse:     true,
+					Jobs:          2,
+				})
+				if !assert.NoError(t, err, "failed to generate %q", f) {
+					return
+				}
+
+				h, err := dirhash(dir)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				mu.Lock()
+				hashes[f] = append(hashes[f], h)
+				mu.Unlock()
+			}(i, f)
+		}
+	}
+	wg.Wait()
+
+	for _, f := range thriftFiles {
+		got := hashes[f]
+		if assert.Len(t, got, workers, "missing output for %q", f) {
+			for _, h := range got[1:] {
+				assert.Equal(t, got[0], h, "output for %q must not depend on concurrent calls", f)
+			}
+		}
+	}
+}
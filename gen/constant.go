@@ -23,18 +23,75 @@ package gen
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/uuid"
 )
 
+// constantAccessorer is implemented by Generators which may expose
+// constants that cannot be Go constants through accessor functions.
+type constantAccessorer interface {
+	constantAccessors() bool
+}
+
+// useConstantAccessors returns true if constants that cannot be Go constants
+// should be exposed through accessor functions rather than package-level
+// variables.
+func useConstantAccessors(g Generator) bool {
+	if o, ok := g.(constantAccessorer); ok {
+		return o.constantAccessors()
+	}
+	return false
+}
+
 // Constant generates code for `const` expressions in Thrift files.
+//
+// Constants of struct, container, and binary types cannot be Go constants.
+// These are generated as package-level variables by default. If constant
+// accessors are enabled, they are generated as functions which build a new
+// copy of the value on every call so that callers cannot modify a value that
+// is shared with the rest of the program.
 func Constant(g Generator, c *compile.Constant) error {
-	err := g.DeclareFromTemplate(
-		`<if canBeConstant .Type>const<else>var<end> <constantName .Name> <typeReference .Type> = <constantValue .Value .Type>`,
-		c,
-		TemplateFunc("constantValue", ConstantValue),
+	value, err := ConstantValue(g, c.Value, c.Type)
+	if err != nil {
+		return wrapGenerateError(c.Name, err)
+	}
+
+	name, err := g.LookupConstantName(c)
+	if err != nil {
+		return wrapGenerateError(c.Name, err)
+	}
+
+	err = g.DeclareFromTemplate(
+		`
+		<if canBeConstant .Constant.Type>
+			<doc .Constant.Doc>
+			const <.Name> <typeReference .Constant.Type> = <.Value>
+		<else if .Accessor>
+			<doc .Constant.Doc>
+			func <.Name>() <typeReference .Constant.Type> {
+				return <.Value>
+			}
+		<else>
+			<doc .Constant.Doc>
+			var <.Name> <typeReference .Constant.Type> = <.Value>
+		<end>
+		`,
+		struct {
+			Constant *compile.Constant
+			Name     string
+			Value    string
+			Accessor bool
+		}{
+			Constant: c,
+			Name:     name,
+			// The value is placed after a return statement which must not
+			// be followed by a newline.
+			Value:    strings.TrimSpace(value),
+			Accessor: useConstantAccessors(g),
+		},
 		TemplateFunc("canBeConstant", canBeConstant),
-		TemplateFunc("constantName", constantName),
 	)
 	return wrapGenerateError(c.Name, err)
 }
@@ -44,6 +101,11 @@ func Constant(g Generator, c *compile.Constant) error {
 //
 // The constant must already have been linked to the given type.
 func ConstantValue(g Generator, c compile.ConstantValue, t compile.TypeSpec) (string, error) {
+	if _, ok := lookupSubstitution(g, t); ok {
+		return "", fmt.Errorf(
+			"constant values of substituted type %q are not supported", t.ThriftName())
+	}
+
 	switch v := c.(type) {
 	case compile.ConstantBool:
 		return constantBool(g, v, t)
@@ -58,6 +120,9 @@ func ConstantValue(g Generator, c compile.ConstantValue, t compile.TypeSpec) (st
 	case compile.ConstantSet:
 		return constantSet(g, v, t)
 	case compile.ConstantString:
+		if _, ok := compile.RootTypeSpec(t).(*compile.UUIDSpec); ok {
+			return constantUUID(g, v, t)
+		}
 		return strconv.Quote(string(v)), nil
 	case *compile.ConstantStruct:
 		return constantStruct(g, v, t)
@@ -82,6 +147,26 @@ func castConstant(g Generator, t compile.TypeSpec, s string) (string, error) {
 	return s, nil
 }
 
+// constantUUID generates an array literal for the UUID in the given string
+// constant. The string was validated by the compiler.
+func constantUUID(g Generator, v compile.ConstantString, t compile.TypeSpec) (string, error) {
+	u, err := uuid.Parse(string(v))
+	if err != nil {
+		return "", err
+	}
+
+	name, err := typeName(g, t)
+	if err != nil {
+		return "", err
+	}
+
+	bytes := make([]string, len(u))
+	for i, b := range u {
+		bytes[i] = fmt.Sprintf("%#02x", b)
+	}
+	return fmt.Sprintf("%s{%s}", name, strings.Join(bytes, ", ")), nil
+}
+
 func constantBool(g Generator, v compile.ConstantBool, t compile.TypeSpec) (_ string, err error) {
 	s := "false"
 	if v {
@@ -181,23 +266,45 @@ func constantSet(g Generator, v compile.ConstantSet, t compile.TypeSpec) (string
 
 func constantStruct(g Generator, v *compile.ConstantStruct, t compile.TypeSpec) (string, error) {
 	fields := compile.RootTypeSpec(t).(*compile.StructSpec).Fields
+
+	// Value fields must be assigned with their setters to be marked as set
+	// even if the constant holds the zero value.
+	var hasValueFields bool
+	for name := range v.Fields {
+		if f, err := fields.FindByName(name); err == nil && isValueField(f) {
+			hasValueFields = true
+		}
+	}
+
 	return g.TextTemplate(
 		`
 		<$fields := .Fields>
-		&<typeName .Spec>{
+		<$v := newVar "v">
+		<if .HasValueFields>func() *<typeName .Spec> {
+			<$v> := <end>&<typeName .Spec>{
 			<range $name, $value := .Value.Fields>
 				<$field := $fields.FindByName $name>
-				<if and (not $field.Required) (isPrimitiveType $field.Type)>
+				<if isValueField $field>
+				<else if and (not $field.Required) (isPrimitiveType $field.Type)>
 					<goName $field>: <constantValuePtr $value $field.Type>,
 				<else>
 					<goName $field>: <constantValue $value $field.Type>,
 				<end>
 			<end>
-		}`, struct {
-			Spec   compile.TypeSpec
-			Fields compile.FieldGroup
-			Value  *compile.ConstantStruct
-		}{Spec: t, Fields: fields, Value: v},
+		}<if .HasValueFields>
+			<range $name, $value := .Value.Fields>
+				<$field := $fields.FindByName $name>
+				<if isValueField $field>
+					<$v>.Set<goName $field>(<constantValue $value $field.Type>)
+				<end>
+			<end>
+			return <$v>
+		}()<end>`, struct {
+			Spec           compile.TypeSpec
+			Fields         compile.FieldGroup
+			Value          *compile.ConstantStruct
+			HasValueFields bool
+		}{Spec: t, Fields: fields, Value: v, HasValueFields: hasValueFields},
 		TemplateFunc("constantValue", ConstantValue),
 		TemplateFunc("constantValuePtr", ConstantValuePtr),
 	)
@@ -205,7 +312,7 @@ func constantStruct(g Generator, v *compile.ConstantStruct, t compile.TypeSpec)
 
 func enumItemReference(g Generator, v compile.EnumItemReference, t compile.TypeSpec) (_ string, err error) {
 	s, err := g.TextTemplate(`<enumItemName (typeName .Enum) .Item>`,
-		v, TemplateFunc("enumItemName", enumItemName))
+		v, TemplateFunc("enumItemName", goNames(g).enumItemName))
 	if err != nil {
 		return "", err
 	}
@@ -220,38 +327,56 @@ func enumItemReference(g Generator, v compile.EnumItemReference, t compile.TypeS
 func ConstantValuePtr(g Generator, c compile.ConstantValue, t compile.TypeSpec) (string, error) {
 	var ptrFunc string
 
-	switch compile.RootTypeSpec(t).(type) {
-	case *compile.BoolSpec:
-		ptrFunc = fmt.Sprintf("%v.Bool", g.Import("go.uber.org/thriftrw/ptr"))
-	case *compile.I8Spec:
-		ptrFunc = fmt.Sprintf("%v.Int8", g.Import("go.uber.org/thriftrw/ptr"))
-	case *compile.I16Spec:
-		ptrFunc = fmt.Sprintf("%v.Int16", g.Import("go.uber.org/thriftrw/ptr"))
-	case *compile.I32Spec:
-		ptrFunc = fmt.Sprintf("%v.Int32", g.Import("go.uber.org/thriftrw/ptr"))
-	case *compile.I64Spec:
-		ptrFunc = fmt.Sprintf("%v.Int64", g.Import("go.uber.org/thriftrw/ptr"))
-	case *compile.DoubleSpec:
-		ptrFunc = fmt.Sprintf("%v.Float64", g.Import("go.uber.org/thriftrw/ptr"))
-	case *compile.StringSpec:
-		ptrFunc = fmt.Sprintf("%v.String", g.Import("go.uber.org/thriftrw/ptr"))
-	case *compile.EnumSpec:
-		ptrFunc = fmt.Sprintf("_%s_ptr", g.MangleType(t))
-		err := g.EnsureDeclared(
-			`func <.Name>(v <typeReference .Spec>) *<typeReference .Spec> {
-				return &v
-			}`, struct {
-				Spec compile.TypeSpec
-				Name string
-			}{Spec: t, Name: ptrFunc})
+	if _, isTypedef := t.(*compile.TypedefSpec); isTypedef && isPrimitiveType(t) {
+		// The ptr package only accepts the built-in types so typedefs of
+		// primitives need their own helper.
+		var err error
+		ptrFunc, err = declarePtrFunc(g, t)
 		if err != nil {
 			return "", err
 		}
-	default:
-		return ConstantValue(g, c, t) // not a primitive
+	} else {
+		switch compile.RootTypeSpec(t).(type) {
+		case *compile.BoolSpec:
+			ptrFunc = fmt.Sprintf("%v.Bool", g.Import("go.uber.org/thriftrw/ptr"))
+		case *compile.I8Spec:
+			ptrFunc = fmt.Sprintf("%v.Int8", g.Import("go.uber.org/thriftrw/ptr"))
+		case *compile.I16Spec:
+			ptrFunc = fmt.Sprintf("%v.Int16", g.Import("go.uber.org/thriftrw/ptr"))
+		case *compile.I32Spec:
+			ptrFunc = fmt.Sprintf("%v.Int32", g.Import("go.uber.org/thriftrw/ptr"))
+		case *compile.I64Spec:
+			ptrFunc = fmt.Sprintf("%v.Int64", g.Import("go.uber.org/thriftrw/ptr"))
+		case *compile.DoubleSpec:
+			ptrFunc = fmt.Sprintf("%v.Float64", g.Import("go.uber.org/thriftrw/ptr"))
+		case *compile.StringSpec:
+			ptrFunc = fmt.Sprintf("%v.String", g.Import("go.uber.org/thriftrw/ptr"))
+		case *compile.EnumSpec, *compile.UUIDSpec:
+			var err error
+			ptrFunc, err = declarePtrFunc(g, t)
+			if err != nil {
+				return "", err
+			}
+		default:
+			return ConstantValue(g, c, t) // not a primitive
+		}
 	}
 
 	s, err := ConstantValue(g, c, t)
 	s = fmt.Sprintf("%v(%v)", ptrFunc, s)
 	return s, err
 }
+
+// declarePtrFunc declares a function which returns a pointer to a copy of a
+// value of type $t and returns its name.
+func declarePtrFunc(g Generator, t compile.TypeSpec) (string, error) {
+	name := fmt.Sprintf("_%s_ptr", g.MangleType(t))
+	err := g.EnsureDeclared(
+		`func <.Name>(v <typeReference .Spec>) *<typeReference .Spec> {
+			return &v
+		}`, struct {
+			Spec compile.TypeSpec
+			Name string
+		}{Spec: t, Name: name})
+	return name, err
+}
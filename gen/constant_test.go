@@ -21,8 +21,13 @@
 package gen
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"go.uber.org/thriftrw/compile"
+
 	tk "go.uber.org/thriftrw/gen/testdata/constants"
 	tc "go.uber.org/thriftrw/gen/testdata/containers"
 	te "go.uber.org/thriftrw/gen/testdata/enums"
@@ -31,6 +36,7 @@ import (
 	ts "go.uber.org/thriftrw/gen/testdata/structs"
 	td "go.uber.org/thriftrw/gen/testdata/typedefs"
 	tu "go.uber.org/thriftrw/gen/testdata/unions"
+	"go.uber.org/thriftrw/uuid"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -288,6 +294,16 @@ func TestConstants(t *testing.T) {
 			tk.MyEnum,
 			td.MyEnum(te.EnumWithValuesY),
 		},
+		{
+			"nilUUID",
+			tk.NilUUID,
+			uuid.UUID{},
+		},
+		{
+			"requestID",
+			tk.RequestID,
+			td.RequestID(uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")),
+		},
 	}
 
 	for _, tt := range tests {
@@ -315,3 +331,69 @@ func TestConstantsMutation(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, g.Edges[0].StartPoint.X, originalX)
 }
+
+func TestConstantAccessors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-constant-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	thriftFile := filepath.Join(dir, "main.thrift")
+	require.NoError(t, ioutil.WriteFile(thriftFile, []byte(`
+		struct Point {
+			1: required double x
+			2: required double y
+		}
+
+		const i32 Version = 1
+		const Point Origin = {"x": 0, "y": 0}
+		const list<Point> Corners = [Origin, {"x": 1, "y": 1}]
+		const map<string, i32> Versions = {"current": Version}
+	`), 0644))
+
+	module, err := compile.Compile(thriftFile)
+	require.NoError(t, err)
+
+	tests := []struct {
+		desc      string
+		accessors bool
+		want      []string
+	}{
+		{
+			desc: "variables",
+			want: []string{
+				"const Version int32 = 1",
+				"var Origin *Point = &Point{",
+				"var Corners []*Point = []*Point{",
+				"var Versions map[string]int32 = map[string]int32{",
+			},
+		},
+		{
+			desc:      "accessors",
+			accessors: true,
+			want: []string{
+				"const Version int32 = 1",
+				"func Origin() *Point {\n\treturn &Point{",
+				"func Corners() []*Point {\n\treturn []*Point{",
+				"func Versions() map[string]int32 {\n\treturn map[string]int32{",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		outputDir := filepath.Join(dir, "out")
+		require.NoError(t, Generate(module, &Options{
+			OutputDir:         outputDir,
+			PackagePrefix:     "example.com/out",
+			ThriftRoot:        dir,
+			NoVersionCheck:    true,
+			NoEmbedIDL:        true,
+			ConstantAccessors: tt.accessors,
+		}), tt.desc)
+
+		contents, err := ioutil.ReadFile(filepath.Join(outputDir, "main", "constants.go"))
+		require.NoError(t, err, tt.desc)
+		for _, want := range tt.want {
+			assert.Contains(t, string(contents), want, tt.desc)
+		}
+	}
+}
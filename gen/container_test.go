@@ -21,11 +21,13 @@
 package gen
 
 import (
+	"bytes"
 	"testing"
 
 	tc "go.uber.org/thriftrw/gen/testdata/containers"
 	te "go.uber.org/thriftrw/gen/testdata/enums"
 	ts "go.uber.org/thriftrw/gen/testdata/structs"
+	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/wire"
 
 	"github.com/stretchr/testify/assert"
@@ -1337,3 +1339,69 @@ func TestListOfBinaryReadNil(t *testing.T) {
 	require.NoError(t, wire.EvaluateValue(got))
 	assert.True(t, wire.ValuesAreEqual(value, got))
 }
+
+func TestUnhashableMapKeysAreUnique(t *testing.T) {
+	listOfI32 := func(xs ...int32) wire.Value {
+		var values []wire.Value
+		for _, x := range xs {
+			values = append(values, wire.NewValueI32(x))
+		}
+		return wire.NewValueList(wire.ValueListFromSlice(wire.TI32, values))
+	}
+	setOfI64 := func(xs ...int64) wire.Value {
+		var values []wire.Value
+		for _, x := range xs {
+			values = append(values, wire.NewValueI64(x))
+		}
+		return wire.NewValueSet(wire.ValueListFromSlice(wire.TI64, values))
+	}
+
+	t.Run("decode", func(t *testing.T) {
+		give := wire.NewValueStruct(wire.Struct{Fields: []wire.Field{{
+			ID: 8,
+			Value: wire.NewValueMap(wire.MapItemListFromSlice(wire.TList, wire.TSet, []wire.MapItem{
+				{Key: listOfI32(1, 2), Value: setOfI64(1)},
+				{Key: listOfI32(3), Value: setOfI64(2)},
+				{Key: listOfI32(1, 2), Value: setOfI64(3)},
+			})),
+		}}})
+
+		// Go through the Binary protocol so that the keys are decoded
+		// lazily.
+		var buff bytes.Buffer
+		require.NoError(t, protocol.Binary.Encode(give, &buff))
+		w, err := protocol.Binary.Decode(bytes.NewReader(buff.Bytes()), wire.TStruct)
+		require.NoError(t, err)
+
+		var got tc.ContainersOfContainers
+		require.NoError(t, got.FromWire(w))
+		assert.Equal(t, []struct {
+			Key   []int32
+			Value map[int64]struct{}
+		}{
+			{Key: []int32{1, 2}, Value: map[int64]struct{}{3: {}}},
+			{Key: []int32{3}, Value: map[int64]struct{}{2: {}}},
+		}, got.MapOfListToSet)
+	})
+
+	t.Run("encode", func(t *testing.T) {
+		give := tc.ContainersOfContainers{
+			MapOfListToSet: []struct {
+				Key   []int32
+				Value map[int64]struct{}
+			}{
+				{Key: []int32{1, 2}, Value: map[int64]struct{}{1: {}}},
+				{Key: []int32{1, 2}, Value: map[int64]struct{}{2: {}}},
+			},
+		}
+
+		w, err := give.ToWire()
+		require.NoError(t, err)
+
+		var buff bytes.Buffer
+		err = protocol.Binary.Encode(w, &buff)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "invalid map key: duplicate key [1 2]")
+		}
+	})
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+const goConvertFromAnnotation = "go.convert_from"
+
+// Ways in which a field is converted.
+const (
+	convertCopy  = "copy"  // assigned as-is
+	convertRef   = "ref"   // required primitive to optional
+	convertDeref = "deref" // optional primitive to required
+)
+
+// convertedField is a field of the target struct of a conversion.
+type convertedField struct {
+	Name string // Go name of the target field
+
+	// Go name of the source field and how it is converted, if the field
+	// could be mapped.
+	From string
+	Kind string
+
+	// Go type of the target field.
+	Type string
+}
+
+// conversions generates conversion functions for all structs in the module
+// annotated with go.convert_from.
+//
+// 	struct UserV2 {
+// 		...
+// 	} (go.convert_from = "UserV1")
+//
+// Generates,
+//
+// 	func ConvertUserV1ToUserV2(v *UserV1) *UserV2
+//
+// Fields are matched by field ID. Target fields which have no counterpart in
+// the source struct, or whose types differ, cannot be mapped automatically.
+// If there are any, the conversion function accepts a struct of functions to
+// fill them in.
+//
+// 	type UserV1ToUserV2_Unmapped struct {
+// 		Tags func(*UserV1) []string
+// 	}
+//
+// 	func ConvertUserV1ToUserV2(v *UserV1, u UserV1ToUserV2_Unmapped) *UserV2
+//
+// This means that call sites stop compiling when a schema change introduces
+// a field that must be converted by hand.
+func conversions(g Generator, m *compile.Module) error {
+	for _, name := range sortStringKeys(m.Types) {
+		to, ok := m.Types[name].(*compile.StructSpec)
+		if !ok {
+			continue
+		}
+
+		value, ok := to.Annotations[goConvertFromAnnotation]
+		if !ok {
+			continue
+		}
+
+		for _, fromName := range strings.Split(value, ",") {
+			fromName = strings.TrimSpace(fromName)
+			if fromName == "" {
+				continue
+			}
+
+			from, ok := m.Types[fromName].(*compile.StructSpec)
+			if !ok {
+				return wrapGenerateError(to.ThriftName(), fmt.Errorf(
+					"invalid go.convert_from annotation: %q is not a struct defined in the same file",
+					fromName))
+			}
+
+			if err := conversion(g, from, to); err != nil {
+				return wrapGenerateError(to.ThriftName(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// fieldGoType returns the Go type of the struct field generated for the
+// given field.
+func fieldGoType(g Generator, f *compile.FieldSpec) (string, error) {
+	if f.Required || isValueField(f) {
+		return typeReference(g, f.Type)
+	}
+	return typeReferencePtr(g, f.Type)
+}
+
+// conversion generates a function to convert the struct from into the
+// struct to.
+func conversion(g Generator, from, to *compile.StructSpec) error {
+	fromName, err := goNames(g).goName(from)
+	if err != nil {
+		return err
+	}
+
+	toName, err := goNames(g).goName(to)
+	if err != nil {
+		return err
+	}
+
+	fromFields := make(map[int16]*compile.FieldSpec, len(from.Fields))
+	for _, f := range from.Fields {
+		fromFields[f.ID] = f
+	}
+
+	var (
+		fields   []convertedField
+		unmapped bool
+	)
+	for _, t := range to.Fields {
+		cf := convertedField{}
+		if cf.Name, err = goNames(g).goName(t); err != nil {
+			return err
+		}
+		if cf.Type, err = fieldGoType(g, t); err != nil {
+			return err
+		}
+
+		if f, ok := fromFields[t.ID]; ok {
+			kind, err := conversionKind(g, f, t)
+			if err != nil {
+				return err
+			}
+			if kind != "" {
+				if cf.From, err = goNames(g).goName(f); err != nil {
+					return err
+				}
+				cf.Kind = kind
+			}
+		}
+
+		unmapped = unmapped || cf.Kind == ""
+		fields = append(fields, cf)
+	}
+
+	return g.DeclareFromTemplate(
+		`
+		<$funcName := printf "Convert%sTo%s" .From .To>
+		<$unmapped := printf "%sTo%s_Unmapped" .From .To>
+		<if .Unmapped>
+			type <$unmapped> struct {
+				<range .Fields>
+					<if not .Kind>
+						<.Name> func(*<$.From>) <.Type>
+					<end>
+				<end>
+			}
+		<end>
+
+		<$v := newVar "v">
+		<$u := newVar "u">
+		<$o := newVar "o">
+		func <$funcName>(<$v> *<.From><if .Unmapped>, <$u> <$unmapped><end>) *<.To> {
+			if <$v> == nil {
+				return nil
+			}
+
+			var <$o> <.To>
+			<range .Fields>
+				<if eq .Kind "copy">
+					<$o>.<.Name> = <$v>.<.From>
+				<else if eq .Kind "ref">
+					<$x := newVar "x">
+					<$x> := <$v>.<.From>
+					<$o>.<.Name> = &<$x>
+				<else if eq .Kind "deref">
+					if <$v>.<.From> != nil {
+						<$o>.<.Name> = *<$v>.<.From>
+					}
+				<else>
+					if <$u>.<.Name> != nil {
+						<$o>.<.Name> = <$u>.<.Name>(<$v>)
+					}
+				<end>
+			<end>
+			return &<$o>
+		}
+		`,
+		struct {
+			From     string
+			To       string
+			Fields   []convertedField
+			Unmapped bool
+		}{From: fromName, To: toName, Fields: fields, Unmapped: unmapped},
+	)
+}
+
+// conversionKind returns how the field from is converted into the field to,
+// or an empty string if it cannot be converted automatically.
+func conversionKind(g Generator, from, to *compile.FieldSpec) (string, error) {
+	fromType, err := typeReference(g, from.Type)
+	if err != nil {
+		return "", err
+	}
+	toType, err := typeReference(g, to.Type)
+	if err != nil {
+		return "", err
+	}
+	if fromType != toType {
+		return "", nil
+	}
+
+	// Whether value fields are set cannot be copied across packages, so
+	// they must be converted with the provided functions.
+	if isValueField(from) || isValueField(to) {
+		return "", nil
+	}
+
+	switch {
+	case from.Required == to.Required || !isPrimitiveType(to.Type):
+		return convertCopy, nil
+	case from.Required:
+		return convertRef, nil
+	default:
+		return convertDeref, nil
+	}
+}
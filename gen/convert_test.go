@@ -0,0 +1,138 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+	ts "go.uber.org/thriftrw/gen/testdata/structs"
+	"go.uber.org/thriftrw/ptr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertStructs(t *testing.T) {
+	v1 := &ts.UserV1{
+		Name:      "foo",
+		Age:       ptr.Int32(42),
+		Email:     ptr.String("foo@example.com"),
+		ID:        1234,
+		Nicknames: []string{"bar"},
+		Active:    true,
+	}
+
+	v2 := ts.ConvertUserV1ToUserV2(v1, ts.UserV1ToUserV2_Unmapped{
+		Email: func(v *ts.UserV1) []byte {
+			return []byte(v.GetEmail())
+		},
+	})
+	assert.Equal(t, &ts.UserV2{
+		Name:      "foo",
+		Age:       42,
+		Email:     []byte("foo@example.com"),
+		ID:        ptr.Int64(1234),
+		Nicknames: []string{"bar"},
+		Active:    ptr.Bool(true),
+	}, v2)
+
+	v1.ID = 5678
+	assert.Equal(t, int64(1234), *v2.ID, "converted values must not alias the source")
+
+	assert.Equal(t, &ts.UserV3{
+		Name:      "foo",
+		Nicknames: []string{"bar"},
+	}, ts.ConvertUserV2ToUserV3(v2))
+	assert.Equal(t, &ts.UserV3{
+		Name:      "foo",
+		Nicknames: []string{"bar"},
+	}, ts.ConvertUserV1ToUserV3(v1))
+
+	assert.Nil(t, ts.ConvertUserV1ToUserV3(nil))
+}
+
+func TestConvertStructsUnmappedDefaults(t *testing.T) {
+	v2 := ts.ConvertUserV1ToUserV2(&ts.UserV1{Name: "foo"}, ts.UserV1ToUserV2_Unmapped{})
+	assert.Equal(t, &ts.UserV2{
+		Name:   "foo",
+		ID:     ptr.Int64(0),
+		Active: ptr.Bool(false),
+	}, v2)
+}
+
+func TestConvertFromErrors(t *testing.T) {
+	tests := []struct {
+		desc      string
+		thrift    string
+		wantError string
+	}{
+		{
+			desc:      "unknown struct",
+			thrift:    `struct Foo {} (go.convert_from = "Bar")`,
+			wantError: `invalid go.convert_from annotation: "Bar" is not a struct defined in the same file`,
+		},
+		{
+			desc: "not a struct",
+			thrift: `
+				typedef string Bar
+				struct Foo {} (go.convert_from = "Bar")
+			`,
+			wantError: `invalid go.convert_from annotation: "Bar" is not a struct defined in the same file`,
+		},
+		{
+			desc: "name conflict",
+			thrift: `
+				struct Bar {}
+				struct Foo {} (go.convert_from = "Bar")
+				struct ConvertBarToFoo {}
+			`,
+			wantError: `ConvertBarToFoo`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "thriftrw-convert-test")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			path := filepath.Join(dir, "main.thrift")
+			require.NoError(t, ioutil.WriteFile(path, []byte(tt.thrift), 0644))
+
+			module, err := compile.Compile(path)
+			require.NoError(t, err)
+
+			err = Generate(module, &Options{
+				OutputDir:      filepath.Join(dir, "out"),
+				PackagePrefix:  "example.com/out",
+				ThriftRoot:     dir,
+				NoVersionCheck: true,
+			})
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+// findIncludeCycles looks for cycles in the include graph of the given
+// module.
+//
+// The compiler allows Thrift files to include each other so that types may
+// reference each other across files. Each Thrift file gets its own Go
+// package, however, and Go does not allow import cycles. Self-referential
+// and mutually recursive types are supported only if they are defined in the
+// same Thrift file.
+func findIncludeCycles(m *compile.Module) error {
+	return includeCycleFinder{
+		visiting: make(map[string]struct{}),
+		done:     make(map[string]struct{}),
+	}.Visit(m)
+}
+
+type includeCycleFinder struct {
+	// Chain of modules leading to the current module.
+	chain []*compile.Module
+
+	// Modules in chain.
+	visiting map[string]struct{}
+
+	// Modules whose includes were already explored without finding a cycle.
+	done map[string]struct{}
+}
+
+func (f includeCycleFinder) Visit(m *compile.Module) error {
+	if _, ok := f.done[m.ThriftPath]; ok {
+		return nil
+	}
+
+	f.chain = append(f.chain, m)
+	if _, ok := f.visiting[m.ThriftPath]; ok {
+		return includeCycleError{Modules: f.chain}
+	}
+
+	f.visiting[m.ThriftPath] = struct{}{}
+	for _, name := range sortStringKeys(m.Includes) {
+		if err := f.Visit(m.Includes[name].Module); err != nil {
+			return err
+		}
+	}
+	delete(f.visiting, m.ThriftPath)
+
+	f.done[m.ThriftPath] = struct{}{}
+	return nil
+}
+
+type includeCycleError struct {
+	// Modules in the cycle. The first and last module are the same.
+	Modules []*compile.Module
+}
+
+func (e includeCycleError) Error() string {
+	// Outputs:
+	//
+	// 	found an include cycle:
+	// 	    a.thrift
+	// 	 -> b.thrift
+	// 	 -> a.thrift
+	// 	Go does not allow import cycles. Types which reference each other
+	// 	must be defined in the same Thrift file.
+
+	// Don't report the modules that led up to the cycle.
+	last := e.Modules[len(e.Modules)-1]
+	modules := e.Modules
+	for i, m := range modules {
+		if m.ThriftPath == last.ThriftPath {
+			modules = modules[i:]
+			break
+		}
+	}
+
+	lines := make([]string, 0, len(modules)+2)
+	lines = append(lines, "found an include cycle:")
+	for i, m := range modules {
+		line := " "
+		if i == 0 {
+			line += "   "
+		} else {
+			line += "-> "
+		}
+		lines = append(lines, line+filepath.Base(m.ThriftPath))
+	}
+	lines = append(lines, "Go does not allow import cycles. "+
+		"Types which reference each other must be defined in the same Thrift file.")
+	return strings.Join(lines, "\n")
+}
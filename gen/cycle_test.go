@@ -0,0 +1,76 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindIncludeCycles(t *testing.T) {
+	module := func(name string) *compile.Module {
+		return &compile.Module{
+			Name:       name,
+			ThriftPath: "/" + name + ".thrift",
+			Includes:   make(map[string]*compile.IncludedModule),
+		}
+	}
+	include := func(m, target *compile.Module) {
+		m.Includes[target.Name] = &compile.IncludedModule{Name: target.Name, Module: target}
+	}
+
+	t.Run("no cycle", func(t *testing.T) {
+		a, b, c := module("a"), module("b"), module("c")
+		include(a, b)
+		include(a, c)
+		include(b, c)
+		assert.NoError(t, findIncludeCycles(a))
+	})
+
+	t.Run("self include", func(t *testing.T) {
+		a := module("a")
+		include(a, a)
+
+		err := findIncludeCycles(a)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "found an include cycle:\n    a.thrift\n -> a.thrift\n")
+		}
+	})
+
+	t.Run("indirect cycle", func(t *testing.T) {
+		root, a, b, c := module("root"), module("a"), module("b"), module("c")
+		include(root, a)
+		include(a, b)
+		include(b, c)
+		include(c, a)
+
+		err := findIncludeCycles(root)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(),
+				"found an include cycle:\n    a.thrift\n -> b.thrift\n -> c.thrift\n -> a.thrift\n")
+			assert.Contains(t, err.Error(), "Go does not allow import cycles")
+			assert.NotContains(t, err.Error(), "root.thrift")
+		}
+	})
+}
@@ -21,6 +21,10 @@
 // Package gen generates Go code based on a compiled Thrift module
 // specification.
 //
+// Generate keeps no package-level state and may be called concurrently,
+// including for the same compiled Module. A plugin Handle shared between
+// concurrent calls must itself be safe for concurrent use.
+//
 // NOTE: All APIs in this packages should be considered UNSTABLE and subject
 // to change WITHOUT a major version bump.
 package gen
@@ -23,13 +23,19 @@ package gen
 import (
 	"crypto/sha1"
 	"encoding/hex"
+	"fmt"
 	"sort"
 
+	"go.uber.org/thriftrw/ast"
 	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/thriftreflect"
 )
 
 // embedIDL generate Go code with a full copy of the IDL embeded.
-func embedIDL(g Generator, i thriftPackageImporter, m *compile.Module) error {
+//
+// If reflection is true, the ThriftModule also gets a Descriptor of the
+// module's contents and is registered with thriftreflect on init.
+func embedIDL(g Generator, i thriftPackageImporter, m *compile.Module, reflection bool) error {
 	pkg, err := i.Package(m.ThriftPath)
 	if err != nil {
 		return wrapGenerateError("idl embedding", err)
@@ -39,6 +45,10 @@ func embedIDL(g Generator, i thriftPackageImporter, m *compile.Module) error {
 		return wrapGenerateError("idl embedding", err)
 	}
 
+	// Modules flattened into the same package are told apart by the
+	// prefixes of their names.
+	prefix := i.NamePrefixes[m.ThriftPath]
+
 	hash := sha1.Sum(m.Raw)
 	var includes []string
 	for _, v := range m.Includes {
@@ -46,43 +56,183 @@ func embedIDL(g Generator, i thriftPackageImporter, m *compile.Module) error {
 		if err != nil {
 			return wrapGenerateError("idl embedding", err)
 		}
-		includes = append(includes, g.Import(importPath))
+		if importPath == pkg {
+			includes = append(includes, i.NamePrefixes[v.Module.ThriftPath]+"ThriftModule")
+		} else {
+			includes = append(includes, g.Import(importPath)+".ThriftModule")
+		}
 	}
 
 	sort.Strings(includes)
 
+	var descriptor *thriftreflect.Descriptor
+	if reflection {
+		descriptor, err = buildDescriptor(goNames(g), m)
+		if err != nil {
+			return wrapGenerateError("idl embedding", err)
+		}
+	}
+
+	doc := "ThriftModule represents the IDL file used to generate this package."
+	if prefix != "" {
+		doc = fmt.Sprintf("%vThriftModule represents the IDL file %v flattened into this package.", prefix, packageRelPath)
+	}
+
 	data := struct {
-		Name     string
-		Package  string
-		FilePath string
-		SHA1     string
-		Includes []string
-		Raw      []byte
+		Doc       string
+		Flattened bool
+		VarName   string
+		RawName   string
+		Name      string
+		Package   string
+		FilePath  string
+		SHA1      string
+		Includes  []string
+		Raw       []byte
+
+		Descriptor *thriftreflect.Descriptor
 	}{
-		Name:     m.Name,
-		Package:  pkg,
-		FilePath: packageRelPath,
-		SHA1:     hex.EncodeToString(hash[:]),
-		Includes: includes,
-		Raw:      m.Raw,
+		Doc:       doc,
+		Flattened: i.FlattenInto != "",
+		VarName:   prefix + "ThriftModule",
+		RawName:   "raw" + prefix + "IDL",
+		Name:      m.Name,
+		Package:   pkg,
+		FilePath:  packageRelPath,
+		SHA1:      hex.EncodeToString(hash[:]),
+		Includes:  includes,
+		Raw:       m.Raw,
+
+		Descriptor: descriptor,
 	}
 	err = g.DeclareFromTemplate(`
 		<$idl := import "go.uber.org/thriftrw/thriftreflect">
 
-		// ThriftModule represents the IDL file used to generate this package.
-		var ThriftModule = &<$idl>.ThriftModule {
+		// <.Doc>
+		var <.VarName> = &<$idl>.ThriftModule {
 			Name: "<.Name>",
 			Package: "<.Package>",
 			FilePath: <printf "%q" .FilePath>,
 			SHA1: "<.SHA1>",
-			<if .Includes>
+			<if and .Includes (not .Flattened)>
 				Includes: []*<$idl>.ThriftModule {<range .Includes>
-						<.>.ThriftModule, <end>
+						<.>, <end>
 					},
 			<end>
-			Raw: rawIDL,
+			Raw: <.RawName>,
+			<with .Descriptor>
+				Descriptor: &<$idl>.Descriptor{
+					<if .Types>
+						Types: []<$idl>.TypeDescriptor{<range .Types>
+							{Name: "<.Name>", GoName: "<.GoName>", Kind: "<.Kind>"},<end>
+						},
+					<end>
+					<if .Services>
+						Services: []<$idl>.ServiceDescriptor{<range .Services>
+							{
+								Name: "<.Name>",
+								<if .Parent>Parent: "<.Parent>",<end>
+								<if .Methods>
+									Methods: []<$idl>.MethodDescriptor{<range .Methods>
+										{Name: "<.Name>"<if .OneWay>, OneWay: true<end>},<end>
+									},
+								<end>
+							},<end>
+						},
+					<end>
+				},
+			<end>
 		}
-		const rawIDL = <printf "%q" .Raw>
+		const <.RawName> = <printf "%q" .Raw>
+
+		<if and .Includes .Flattened>
+			func init() {
+				// Included modules are flattened into this package and may
+				// include this module in turn, so they can only be referenced
+				// after they are initialized.
+				<.VarName>.Includes = []*<$idl>.ThriftModule {<range .Includes>
+						<.>, <end>
+					}
+			}
+		<end>
+
+		<if .Descriptor>
+			func init() {
+				<$idl>.Register(<.VarName>)
+			}
+		<end>
 		`, data)
 	return wrapGenerateError("idl embedding", err)
 }
+
+// buildDescriptor builds a description of the types and services defined in
+// the given module. Go names of types are determined with the given
+// nameMapper.
+func buildDescriptor(names nameMapper, m *compile.Module) (*thriftreflect.Descriptor, error) {
+	var d thriftreflect.Descriptor
+	for _, name := range sortStringKeys(m.Types) {
+		spec := m.Types[name]
+
+		var kind string
+		switch s := spec.(type) {
+		case *compile.EnumSpec:
+			kind = "enum"
+		case *compile.TypedefSpec:
+			kind = "typedef"
+		case *compile.StructSpec:
+			switch s.Type {
+			case ast.UnionType:
+				kind = "union"
+			case ast.ExceptionType:
+				kind = "exception"
+			default:
+				kind = "struct"
+			}
+		default:
+			continue
+		}
+
+		goName, err := names.goName(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		d.Types = append(d.Types, thriftreflect.TypeDescriptor{
+			Name:   name,
+			GoName: goName,
+			Kind:   kind,
+		})
+	}
+
+	for _, name := range sortStringKeys(m.Services) {
+		spec := m.Services[name]
+		sd := thriftreflect.ServiceDescriptor{Name: name}
+		if spec.Parent != nil {
+			sd.Parent = parentServiceName(m, spec.Parent)
+		}
+		for _, fname := range sortStringKeys(spec.Functions) {
+			f := spec.Functions[fname]
+			sd.Methods = append(sd.Methods, thriftreflect.MethodDescriptor{
+				Name:   f.MethodName(),
+				OneWay: f.OneWay,
+			})
+		}
+		d.Services = append(d.Services, sd)
+	}
+
+	return &d, nil
+}
+
+// parentServiceName returns the name with which the given module refers to
+// the given parent service.
+func parentServiceName(m *compile.Module, parent *compile.ServiceSpec) string {
+	if parent.File == m.ThriftPath {
+		return parent.Name
+	}
+	for _, name := range sortStringKeys(m.Includes) {
+		if m.Includes[name].Module.ThriftPath == parent.File {
+			return name + "." + parent.Name
+		}
+	}
+	return parent.Name
+}
@@ -25,10 +25,13 @@ import (
 	"encoding/hex"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/thriftrw/compile"
 	te "go.uber.org/thriftrw/gen/testdata/enums"
 	ts "go.uber.org/thriftrw/gen/testdata/structs"
 	"go.uber.org/thriftrw/thriftreflect"
@@ -80,3 +83,95 @@ func TestIDLEmbeddingInclude(t *testing.T) {
 		assert.Equal(t, te.ThriftModule, tm.Includes[0])
 	}
 }
+
+func TestBuildDescriptor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-reflection-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "base.thrift"), []byte(`
+		service BaseService { void health() }
+	`), 0644))
+	mainFile := filepath.Join(dir, "main.thrift")
+	require.NoError(t, ioutil.WriteFile(mainFile, []byte(`
+		include "./base.thrift"
+
+		enum Color { RED }
+		typedef string Name
+		struct Point { 1: required i32 x } (go.name = "GoPoint")
+		union Shape { 1: Point point }
+		exception Oops {}
+
+		service Local { void ping() }
+		service Remote extends base.BaseService {
+			oneway void fire()
+			Point get(1: Name name) throws (1: Oops oops)
+		}
+		service Nested extends Local {}
+	`), 0644))
+
+	module, err := compile.Compile(mainFile)
+	require.NoError(t, err)
+
+	d, err := buildDescriptor(nameMapper{}, module)
+	require.NoError(t, err)
+	assert.Equal(t, &thriftreflect.Descriptor{
+		Types: []thriftreflect.TypeDescriptor{
+			{Name: "Color", GoName: "Color", Kind: "enum"},
+			{Name: "Name", GoName: "Name", Kind: "typedef"},
+			{Name: "Oops", GoName: "Oops", Kind: "exception"},
+			{Name: "Point", GoName: "GoPoint", Kind: "struct"},
+			{Name: "Shape", GoName: "Shape", Kind: "union"},
+		},
+		Services: []thriftreflect.ServiceDescriptor{
+			{
+				Name:    "Local",
+				Methods: []thriftreflect.MethodDescriptor{{Name: "ping"}},
+			},
+			{
+				Name:   "Nested",
+				Parent: "Local",
+			},
+			{
+				Name:   "Remote",
+				Parent: "base.BaseService",
+				Methods: []thriftreflect.MethodDescriptor{
+					{Name: "fire", OneWay: true},
+					{Name: "get"},
+				},
+			},
+		},
+	}, d)
+
+	outputDir := filepath.Join(dir, "out")
+	require.NoError(t, Generate(module, &Options{
+		OutputDir:      outputDir,
+		PackagePrefix:  "example.com/out",
+		ThriftRoot:     dir,
+		NoVersionCheck: true,
+		Reflection:     true,
+	}))
+
+	for _, name := range []string{"main/idl.go", "base/idl.go"} {
+		contents, err := ioutil.ReadFile(filepath.Join(outputDir, name))
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), "Descriptor: &thriftreflect.Descriptor{", name)
+		assert.Contains(t, string(contents), "thriftreflect.Register(ThriftModule)", name)
+	}
+}
+
+func TestReflectionRequiresEmbeddedIDL(t *testing.T) {
+	module, err := compile.Compile("testdata/thrift/services.thrift")
+	require.NoError(t, err)
+
+	err = Generate(module, &Options{
+		OutputDir:     testdata(t),
+		PackagePrefix: "example.com/out",
+		ThriftRoot:    testdata(t),
+		NoEmbedIDL:    true,
+		Reflection:    true,
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Reflection requires embedded IDLs")
+	}
+}
@@ -21,11 +21,48 @@
 package gen
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 
 	"go.uber.org/thriftrw/compile"
 )
 
+// Annotation on enums overriding whether unrecognized values are rejected
+// when decoding.
+//
+// 	enum Status {
+// 		...
+// 	} (go.strict = "true")
+const strictEnumAnnotation = "go.strict"
+
+// strictEnumer is implemented by Generators which may generate enums that
+// reject unrecognized values.
+type strictEnumer interface {
+	strictEnums() bool
+}
+
+// isStrictEnum returns true if the FromWire method of the given enum should
+// return an UnknownEnumError for unrecognized values rather than preserving
+// them. The go.strict annotation takes precedence over the generator
+// default.
+func isStrictEnum(g Generator, spec *compile.EnumSpec) (bool, error) {
+	if s, ok := spec.Annotations[strictEnumAnnotation]; ok {
+		strict, err := strconv.ParseBool(s)
+		if err != nil {
+			return false, fmt.Errorf(
+				"invalid %v annotation %q: must be true or false",
+				strictEnumAnnotation, s)
+		}
+		return strict, nil
+	}
+
+	if o, ok := g.(strictEnumer); ok {
+		return o.strictEnums(), nil
+	}
+	return false, nil
+}
+
 // enumGenerator generates code to serialize and deserialize enums.
 type enumGenerator struct{}
 
@@ -55,8 +92,12 @@ func (e *enumGenerator) Reader(g Generator, spec *compile.EnumSpec) (string, err
 func enum(g Generator, spec *compile.EnumSpec) error {
 	items := enumUniqueItems(spec.Items)
 
-	// TODO(abg) define an error type in the library for unrecognized enums.
-	err := g.DeclareFromTemplate(
+	strict, err := isStrictEnum(g, spec)
+	if err != nil {
+		return wrapGenerateError(spec.Name, err)
+	}
+
+	err = g.DeclareFromTemplate(
 		`
 		<$bytes := import "bytes">
 		<$fmt := import "fmt">
@@ -67,11 +108,13 @@ func enum(g Generator, spec *compile.EnumSpec) error {
 		<$wire := import "go.uber.org/thriftrw/wire">
 
 		<$enumName := goName .Spec>
+		<doc .Spec.Doc>
 		type <$enumName> int32
 
 		<if .Spec.Items>
 			const (
 			<range .Spec.Items>
+				<doc .Doc>
 				<enumItemName $enumName .> <$enumName> = <.Value>
 			<end>
 			)
@@ -91,18 +134,49 @@ func enum(g Generator, spec *compile.EnumSpec) error {
 					return nil
 			<end>
 				default:
-					return <$fmt>.Errorf("unknown enum value %q for %q", value, "<$enumName>")
+					<$val := newVar "val">
+					<$val>, err := <$strconv>.ParseInt(string(value), 10, 32)
+					if err != nil {
+						return <$fmt>.Errorf("unknown enum value %q for %q: %v", value, "<$enumName>", err)
+					}
+					*<$v> = <$enumName>(<$val>)
+					return nil
 			}
 		}
 
+		func (<$v> <$enumName>) MarshalText() ([]byte, error) {
+			<if len .Spec.Items>
+				switch int32(<$v>) {
+				<range .UniqueItems>
+					case <.Value>:
+						return []byte("<.Name>"), nil
+				<end>
+				}
+			<end>
+			return []byte(<$strconv>.FormatInt(int64(<$v>), 10)), nil
+		}
+
 		func (<$v> <$enumName>) ToWire() (<$wire>.Value, error) {
 			return <$wire>.NewValueI32(int32(<$v>)), nil
 		}
 
 		<$w := newVar "w">
 		func (<$v> *<$enumName>) FromWire(<$w> <$wire>.Value) error {
-			*<$v> = (<$enumName>)(<$w>.GetI32());
-			return nil
+			<if .Strict>
+				<$x := newVar "x">
+				<$x> := <$w>.GetI32()
+				<if .UniqueItems>
+					switch <$x> {
+					case <range $i, $item := .UniqueItems><if $i>, <end><$item.Value><end>:
+						*<$v> = (<$enumName>)(<$x>)
+						return nil
+					}
+				<end>
+				return <$wire>.UnknownEnumError{Enum: "<$enumName>", Value: <$x>}
+			<else>
+				*<$v> = (<$enumName>)(<$w>.GetI32());
+				return nil
+			<end>
 		}
 
 		func (<$v> <$enumName>) String() string {
@@ -172,11 +246,13 @@ func enum(g Generator, spec *compile.EnumSpec) error {
 		struct {
 			Spec        *compile.EnumSpec
 			UniqueItems []compile.EnumItem
+			Strict      bool
 		}{
 			Spec:        spec,
 			UniqueItems: items,
+			Strict:      strict,
 		},
-		TemplateFunc("enumItemName", enumItemName),
+		TemplateFunc("enumItemName", goNames(g).enumItemName),
 	)
 
 	return wrapGenerateError(spec.Name, err)
@@ -184,13 +260,13 @@ func enum(g Generator, spec *compile.EnumSpec) error {
 
 // enumItemName returns the Go name that should be used for an enum item with
 // the given (potentially import qualified) enumName and EnumItem.
-func enumItemName(enumName string, spec *compile.EnumItem) (string, error) {
+func (m nameMapper) enumItemName(enumName string, spec *compile.EnumItem) (string, error) {
 	name, err := goNameAnnotation(spec)
 	if err != nil {
 		return "", err
 	}
 	if name == "" {
-		name = pascalCase(false, /* all caps */
+		name = m.pascalCase(false, /* all caps */
 			strings.Split(spec.ThriftName(), "_")...)
 	}
 	return enumName + name, err
@@ -23,12 +23,17 @@ package gen
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"go.uber.org/thriftrw/compile"
 	te "go.uber.org/thriftrw/gen/testdata/enums"
 	"go.uber.org/thriftrw/wire"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValueOfEnumDefault(t *testing.T) {
@@ -328,3 +333,93 @@ func TestUnmarshalTextReturnsErrorOnInvalidValue(t *testing.T) {
 	err := v.UnmarshalText([]byte("blah"))
 	assert.Error(t, err)
 }
+
+func TestEnumFromWirePreservesUnknownValues(t *testing.T) {
+	var v te.EnumDefault
+	assert.NoError(t, v.FromWire(wire.NewValueI32(42)))
+	assert.Equal(t, te.EnumDefault(42), v)
+}
+
+func TestStrictEnumFromWire(t *testing.T) {
+	var v te.StrictEnum
+	if assert.NoError(t, v.FromWire(wire.NewValueI32(2))) {
+		assert.Equal(t, te.StrictEnumInactive, v)
+	}
+
+	v = te.StrictEnumActive
+	err := v.FromWire(wire.NewValueI32(42))
+	assert.Equal(t, wire.UnknownEnumError{Enum: "StrictEnum", Value: 42}, err)
+	assert.EqualError(t, err, "unknown value 42 for enum StrictEnum")
+	assert.Equal(t, te.StrictEnumActive, v, "value must not change on failure")
+}
+
+func TestStrictEnums(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-enum-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	thriftFile := filepath.Join(dir, "main.thrift")
+	require.NoError(t, ioutil.WriteFile(thriftFile, []byte(`
+		enum Default { A, B }
+		enum Strict { A, B } (go.strict = "true")
+		enum Lenient { A, B } (go.strict = "false")
+	`), 0644))
+
+	module, err := compile.Compile(thriftFile)
+	require.NoError(t, err)
+
+	tests := []struct {
+		desc       string
+		strict     bool
+		wantStrict []string
+	}{
+		{
+			desc:       "default",
+			wantStrict: []string{"Strict"},
+		},
+		{
+			desc:       "strict",
+			strict:     true,
+			wantStrict: []string{"Default", "Strict"},
+		},
+	}
+
+	for _, tt := range tests {
+		outputDir := filepath.Join(dir, "out")
+		require.NoError(t, Generate(module, &Options{
+			OutputDir:      outputDir,
+			PackagePrefix:  "example.com/out",
+			ThriftRoot:     dir,
+			NoVersionCheck: true,
+			NoEmbedIDL:     true,
+			StrictEnums:    tt.strict,
+		}), tt.desc)
+
+		contents, err := ioutil.ReadFile(filepath.Join(outputDir, "main", "types.go"))
+		require.NoError(t, err, tt.desc)
+
+		strict := make(map[string]bool)
+		for _, name := range tt.wantStrict {
+			strict[name] = true
+		}
+		for _, name := range []string{"Default", "Strict", "Lenient"} {
+			want := fmt.Sprintf(`wire.UnknownEnumError{Enum: %q`, name)
+			if strict[name] {
+				assert.Contains(t, string(contents), want, "%v: %v", tt.desc, name)
+			} else {
+				assert.NotContains(t, string(contents), want, "%v: %v", tt.desc, name)
+			}
+		}
+	}
+}
+
+func TestStrictEnumAnnotationInvalid(t *testing.T) {
+	spec := &compile.EnumSpec{
+		Name:        "Foo",
+		Annotations: compile.Annotations{"go.strict": "yes"},
+	}
+	_, err := isStrictEnum(nil, spec)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `invalid go.strict annotation "yes": must be true or false`)
+	}
+}
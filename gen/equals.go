@@ -32,11 +32,18 @@ type equalsGenerator struct {
 	mapG  mapGenerator
 	setG  setGenerator
 	listG listGenerator
+
+	substitutionG substitutionGenerator
 }
 
 // Equals generates a string comparing rhs to the given lhs.
 // Equals generates an expression of type bool.
 func (e *equalsGenerator) Equals(g Generator, spec compile.TypeSpec, lhs, rhs string) (string, error) {
+	if sub, ok := lookupSubstitution(g, spec); ok {
+		equals, err := e.substitutionG.Equals(g, spec, sub)
+		return fmt.Sprintf("%s(%s, %s)", equals, lhs, rhs), err
+	}
+
 	if isPrimitiveType(spec) {
 		if _, isEnum := spec.(*compile.EnumSpec); !isEnum {
 			return fmt.Sprintf("(%s == %s)", lhs, rhs), nil
@@ -40,18 +40,29 @@ type fieldGroupGenerator struct {
 	Name   string
 	Fields compile.FieldGroup
 
+	// Doc comment for the generated struct.
+	Doc string
+
 	// If this field group represents a union of values, exactly one field
 	// must be set for it to be valid.
 	IsUnion         bool
 	AllowEmptyUnion bool
 
-	// This field group represents a Thrift exception.
-	IsException bool
+	// An Error method will be generated for this field group. This is true
+	// for exceptions and structs annotated with go.implements = "error".
+	HasErrorMethod bool
+
+	// Names of other methods that will be generated for this field group.
+	// Fields may not use these names.
+	ExtraMethods []string
 }
 
 func (f fieldGroupGenerator) checkReservedIdentifier(name string) error {
 	_, match := reservedIdentifiers[name]
-	match = match || (f.IsException && name == "Error")
+	match = match || (f.HasErrorMethod && name == "Error")
+	for _, m := range f.ExtraMethods {
+		match = match || name == m
+	}
 	if match {
 		return fmt.Errorf("%q is a reserved ThriftRW identifier", name)
 	}
@@ -59,6 +70,10 @@ func (f fieldGroupGenerator) checkReservedIdentifier(name string) error {
 }
 
 func (f fieldGroupGenerator) Generate(g Generator) error {
+	if err := f.checkValueFields(g); err != nil {
+		return err
+	}
+
 	if err := f.DefineStruct(g); err != nil {
 		return err
 	}
@@ -79,35 +94,77 @@ func (f fieldGroupGenerator) Generate(g Generator) error {
 		return err
 	}
 
+	if err := f.JSON(g); err != nil {
+		return err
+	}
+
+	if err := f.Getters(g); err != nil {
+		return err
+	}
+
+	if err := f.ValueFields(g); err != nil {
+		return err
+	}
+
+	if err := f.Presence(g); err != nil {
+		return err
+	}
+
+	if err := f.Hash(g); err != nil {
+		return err
+	}
+
+	if err := f.Sanitize(g); err != nil {
+		return err
+	}
+
+	if err := f.Validate(g); err != nil {
+		return err
+	}
+
+	if err := f.Headers(g); err != nil {
+		return err
+	}
+
+	if err := f.Streams(g); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func (f fieldGroupGenerator) DefineStruct(g Generator) error {
 	return g.DeclareFromTemplate(
-		`type <.Name> struct {
+		`<doc .Doc>
+		type <.Name> struct {
 			<range .Fields>
-				<if .Required>
+				<if or .Required (isValueField .)>
+					<doc .Doc>
 					<declFieldName .> <typeReference .Type> <tag .>
 				<else>
+					<doc .Doc>
 					<declFieldName .> <typeReferencePtr .Type> <tag .>
 				<end>
 			<end>
+
+			<range .Fields>
+				<if isValueField .>
+					<valueFieldFlag .> bool
+				<end>
+			<end>
+
+			<if keepUnknown>
+				<$wire := import "go.uber.org/thriftrw/wire">
+				<unknownFields> []<$wire>.Field
+			<end>
 		}`,
 		f,
 		TemplateFunc("tag", func(f *compile.FieldSpec) string {
-			// We want to add omitempty if the field is an optional struct or
-			// primitive to redure "null" noise. We won't add omitempty for
-			// optional collections because omitempty doesn't differentiate
-			// between nil and empty collections.
-
-			if (isStructType(f.Type) || isPrimitiveType(f.Type)) && !f.Required {
-				return fmt.Sprintf("`json:\"%s,omitempty\"`", f.Name)
-			}
-
-			return fmt.Sprintf("`json:%q`", f.Name)
-			// TODO(abg): Take go.tag and js.name annotations into account
+			return jsonTag(g, f)
+		}),
+		TemplateFunc("declFieldName", func(fs *compile.FieldSpec) (string, error) {
+			return f.declFieldName(g, fs)
 		}),
-		TemplateFunc("declFieldName", f.declFieldName),
 	)
 }
 
@@ -115,8 +172,8 @@ func (f fieldGroupGenerator) DefineStruct(g Generator) error {
 // It replicates goName but also register all field names in the
 // fieldGroupGenerator namespace, enforcing single field definition when
 // generating Go code. TL;DR: will fail during generation, before compilation.
-func (f *fieldGroupGenerator) declFieldName(fs *compile.FieldSpec) (string, error) {
-	name, fromAnnotation, err := goNameForNamedEntity(fs)
+func (f *fieldGroupGenerator) declFieldName(g Generator, fs *compile.FieldSpec) (string, error) {
+	name, fromAnnotation, err := goNames(g).goNameForNamedEntity(fs)
 	if err != nil {
 		return "", err
 	}
@@ -170,8 +227,11 @@ func (f fieldGroupGenerator) ToWire(g Generator) error {
 						if <$f> == nil {
 							// TODO: Include names of all missing fields in
 							// the error message.
-							return <$wVal>, <import "errors">.New(
-								"field <$fname> of <$structName> is required")
+							return <$wVal>, <$wire>.NilRequiredFieldError{
+								Struct: "<$structName>",
+								Field:  "<$fname>",
+								ID:     <.ID>,
+							}
 						}
 					<end>
 						<$wVal>, err = <toWire .Type $f>
@@ -186,15 +246,27 @@ func (f fieldGroupGenerator) ToWire(g Generator) error {
 						}
 						<$i>++
 				<else>
-					<if .Default>
+					<if and .Default (isValueField .)>
+						if !<valueFieldIsSet $v .> {
+							<$f> = <constantValue .Default .Type>
+							<$v>.<valueFieldFlag .> = true
+						}
+						{
+					<else if .Default>
 						if <$f> == nil {
 							<$f> = <constantValuePtr .Default .Type>
 						}
 						{
+					<else if isValueField .>
+						if <valueFieldIsSet $v .> {
 					<else>
 						if <$f> != nil {
 					<end>
-							<$wVal>, err = <toWirePtr .Type $f>
+							<if isValueField .>
+								<$wVal>, err = <toWire .Type $f>
+							<else>
+								<$wVal>, err = <toWirePtr .Type $f>
+							<end>
 							if err != nil {
 								// TODO: Nest the error inside a "failed to
 								// serialize field X of struct Y" error.
@@ -224,71 +296,153 @@ func (f fieldGroupGenerator) ToWire(g Generator) error {
 				<end>
 			<end>
 
-			return <$wire>.NewValueStruct(
-				<$wire>.Struct{Fields: <$fields>[:<$i>]},
-			), nil
+			<if keepUnknown>
+				return <$wire>.NewValueStruct(
+					<$wire>.Struct{Fields: append(<$fields>[:<$i>], <$v>.<unknownFields>...)},
+				), nil
+			<else>
+				return <$wire>.NewValueStruct(
+					<$wire>.Struct{Fields: <$fields>[:<$i>]},
+				), nil
+			<end>
 		}
-		`, f, TemplateFunc("constantValuePtr", ConstantValuePtr))
+		`, f,
+		TemplateFunc("constantValue", ConstantValue),
+		TemplateFunc("constantValuePtr", ConstantValuePtr))
 }
 
 func (f fieldGroupGenerator) FromWire(g Generator) error {
+	compact := useCompactCodegen(g) && len(f.Fields) > 0
+	if compact {
+		if err := f.FieldTable(g); err != nil {
+			return err
+		}
+	}
+
 	return g.DeclareFromTemplate(
 		`
 		<$wire := import "go.uber.org/thriftrw/wire">
 
 		<$v := newVar "v">
 		<$w := newVar "w">
-		func (<$v> *<.Name>) FromWire(<$w> <$wire>.Value) error {
+		<if useArena>
+			<$arena := import "go.uber.org/thriftrw/arena">
+			func (<$v> *<.Name>) FromWire(<$w> <$wire>.Value) error {
+				return <$v>.FromWireArena(<$w>, nil)
+			}
+
+			// FromWireArena is the same as FromWire except it allocates
+			// decoded values from the given arena.
+			func (<$v> *<.Name>) FromWireArena(<$w> <$wire>.Value, <arenaVar> *<$arena>.Arena) error {
+		<else>
+			func (<$v> *<.Name>) FromWire(<$w> <$wire>.Value) error {
+		<end>
 			<if len .Fields>
 				var err error
 			<end>
 			<$f := newVar "field">
+			<$structName := .Name>
 
 			<$isSet := newNamespace>
-			<range .Fields>
-				<if .Required>
-					<$isSet.NewName (printf "%sIsSet" .Name)> := false
+			<if compact>
+				<$i := newVar "i">
+				<if keepUnknown>
+					<$v>.<unknownFields>, err = <fieldTable>.DecodeUnknown(<$w>, func(<$i> int, <$f> <$wire>.Value) (err error) {
+				<else>
+					err = <fieldTable>.Decode(<$w>, func(<$i> int, <$f> <$wire>.Value) (err error) {
 				<end>
-			<end>
-
-			for _, <$f> := range <$w>.GetStruct().Fields {
-				switch <$f>.ID {
-				<range .Fields>
-				case <.ID>:
-					if <$f>.Value.Type() == <typeCode .Type> {
+					switch <$i> {
+					<range $index, $field := .Fields>
+					<with $field>
+					case <$index>:
 						<$lhs := printf "%s.%s" $v (goName .)>
-						<$value := printf "%s.Value" $f>
-						<if .Required>
-							<$lhs>, err = <fromWire .Type $value>
+						<if or .Required (isValueField .)>
+							<$lhs>, err = <fromWire .Type $f>
 						<else>
-							<fromWirePtr .Type $lhs $value>
+							<fromWirePtr .Type $lhs $f>
+						<end>
+						<if isValueField .>
+							<$v>.<valueFieldFlag .> = true
 						<end>
+					<end>
+					<end>
+					}
+					return err
+				})
+				if err != nil {
+					return err
+				}
+			<else>
+				<range .Fields>
+					<if .Required>
+						<$isSet.NewName (printf "%sIsSet" .Name)> := false
+					<end>
+				<end>
+
+				<if keepUnknown>
+					<$v>.<unknownFields> = nil
+				<end>
+				for _, <$f> := range <$w>.GetStruct().Fields {
+					switch <$f>.ID {
+					<range .Fields>
+					case <.ID>:
+						if <$f>.Value.Type() == <typeCode .Type> {
+							<$lhs := printf "%s.%s" $v (goName .)>
+							<$value := printf "%s.Value" $f>
+							<if or .Required (isValueField .)>
+								<$lhs>, err = <fromWire .Type $value>
+							<else>
+								<fromWirePtr .Type $lhs $value>
+							<end>
+							if err != nil {
+								<$wire>.ObserveDecodeError("<$structName>", "<goName .>", <$wire>.DecodeErrorInvalidValue)
+								return err
+								// TODO: Nest the error inside a "failed to read
+								// field X of struct Y" error.
+							}
+							<if .Required>
+								<$isSet.Rotate (printf "%sIsSet" .Name)> = true
+							<end>
+							<if isValueField .>
+								<$v>.<valueFieldFlag .> = true
+							<end>
+						}
+					<end>
+					<if keepUnknown>
+					default:
+						<$unknown := newVar "unknown">
+						<$unknown>, err := <$wire>.Detach(<$f>.Value)
 						if err != nil {
 							return err
-							// TODO: Nest the error inside a "failed to read
-							// field X of struct Y" error.
 						}
-						<if .Required>
-							<$isSet.Rotate (printf "%sIsSet" .Name)> = true
-						<end>
+						<$v>.<unknownFields> = append(<$v>.<unknownFields>,
+							<$wire>.Field{ID: <$f>.ID, Value: <$unknown>})
+					<end>
 					}
-				<end>
 				}
-			}
+			<end>
 
-			<$structName := .Name>
 			<range .Fields>
 				<$fname := goName .>
 				<$f := printf "%s.%s" $v $fname>
-				<if .Default>
+				<if and .Default (isValueField .)>
+					if !<valueFieldIsSet $v .> {
+						<$f> = <constantValue .Default .Type>
+						<$v>.<valueFieldFlag .> = true
+					}
+				<else if .Default>
 					if <$f> == nil {
 						<$f> = <constantValuePtr .Default .Type>
 					}
 				<else>
-					<if .Required>
+					<if and .Required (not compact)>
 						if !<$isSet.Rotate (printf "%sIsSet" .Name)> {
-							return <import "errors">.New(
-								"field <$fname> of <$structName> is required")
+							<$wire>.ObserveDecodeError("<$structName>", "<$fname>", <$wire>.DecodeErrorMissingRequiredField)
+							return <$wire>.MissingRequiredFieldError{
+								Struct: "<$structName>",
+								Field:  "<$fname>",
+								ID:     <.ID>,
+							}
 						}
 						// TODO: Include names of all missing fields in the
 						// error message.
@@ -305,11 +459,13 @@ func (f fieldGroupGenerator) FromWire(g Generator) error {
 				<end>
 				<if .AllowEmptyUnion>
 					if <$count> > 1 {
+						<$wire>.ObserveDecodeError("<.Name>", "", <$wire>.DecodeErrorInvalidUnion)
 						return <$fmt>.Errorf(
 							"<.Name> should have at most one field: got %v fields", <$count>)
 					}
 				<else>
 					if <$count> != 1 {
+						<$wire>.ObserveDecodeError("<.Name>", "", <$wire>.DecodeErrorInvalidUnion)
 						return <$fmt>.Errorf(
 							"<.Name> should have exactly one field: got %v fields", <$count>)
 					}
@@ -317,7 +473,11 @@ func (f fieldGroupGenerator) FromWire(g Generator) error {
 			<end>
 			return nil
 		}
-		`, f, TemplateFunc("constantValuePtr", ConstantValuePtr))
+		`, f,
+		TemplateFunc("constantValue", ConstantValue),
+		TemplateFunc("constantValuePtr", ConstantValuePtr),
+		TemplateFunc("compact", func() bool { return compact }),
+		TemplateFunc("fieldTable", func() string { return f.fieldTableName() }))
 }
 
 func (f fieldGroupGenerator) String(g Generator) error {
@@ -341,7 +501,12 @@ func (f fieldGroupGenerator) String(g Generator) error {
 				<$fname := goName .>
 				<$f := printf "%s.%s" $v $fname>
 
-				<if not .Required>
+				<if isValueField .>
+					if <valueFieldIsSet $v .> {
+						<$fields>[<$i>] = <$fmt>.Sprintf("<$fname>: %v", <$f>)
+						<$i>++
+					}
+				<else if not .Required>
 					if <$f> != nil {
 						<if isPrimitiveType .Type>
 							<$fields>[<$i>] = <$fmt>.Sprintf("<$fname>: %v", *(<$f>))
@@ -377,13 +542,88 @@ func (f fieldGroupGenerator) Equals(g Generator) error {
 					if !<equals .Type $lhsField $rhsField> {
 						return false
 					}
+				<else if isValueField .>
+					if <valueFieldIsSet $v .> != <valueFieldIsSet $rhs .> || !<equals .Type $lhsField $rhsField> {
+						return false
+					}
 				<else>
 					if !<equalsPtr .Type $lhsField $rhsField> {
 						return false
 					}
 				<end>
 			<end>
+			<if keepUnknown>
+				<$wire := import "go.uber.org/thriftrw/wire">
+				if !<$wire>.StructsAreEqual(
+					<$wire>.Struct{Fields: <$v>.<unknownFields>},
+					<$wire>.Struct{Fields: <$rhs>.<unknownFields>},
+				) {
+					return false
+				}
+			<end>
 			return true
 		}
 		`, f)
 }
+
+// Getters generates a GetX method for each field X of the struct. Getters
+// return the value of the field if it is set, its default value if it is
+// unset and has one, and the zero value of the field type otherwise. They are
+// safe to call on nil receivers.
+//
+// Getters are not generated for fields whose getter name conflicts with the
+// name of another field.
+func (f fieldGroupGenerator) Getters(g Generator) error {
+	for _, field := range f.Fields {
+		name, err := goNames(g).goName(field)
+		if err != nil {
+			return err
+		}
+		if err := f.Reserve("Get" + name); err != nil {
+			continue
+		}
+
+		err = g.DeclareFromTemplate(
+			`
+			<$fname := goName .Field>
+			<$v := newVar "v">
+			<$o := newVar "o">
+			func (<$v> *<.Name>) Get<$fname>() (<$o> <typeReference .Field.Type>) {
+				<if .Field.Required>
+					if <$v> != nil {
+						<$o> = <$v>.<$fname>
+					}
+				<else if isValueField .Field>
+					if <$v> != nil && <valueFieldIsSet $v .Field> {
+						return <$v>.<$fname>
+					}
+					<if .Field.Default>
+						<$o> = <constantValue .Field.Default .Field.Type>
+					<end>
+				<else>
+					if <$v> != nil && <$v>.<$fname> != nil {
+						<if isPrimitiveType .Field.Type>
+							return *<$v>.<$fname>
+						<else>
+							return <$v>.<$fname>
+						<end>
+					}
+					<if .Field.Default>
+						<$o> = <constantValue .Field.Default .Field.Type>
+					<end>
+				<end>
+				return
+			}
+			`,
+			struct {
+				Name  string
+				Field *compile.FieldSpec
+			}{Name: f.Name, Field: field},
+			TemplateFunc("constantValue", ConstantValue),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+	"path"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+// typeFilter decides which of the types defined in the Thrift files get
+// generated.
+//
+// Patterns are matched using path.Match against both, the name of the type
+// and its name qualified with the module name ("$module.$type"). A type is
+// generated if it matches any of the Include patterns (or no Include
+// patterns were specified) and none of the Exclude patterns.
+type typeFilter struct {
+	Include []string
+	Exclude []string
+}
+
+func (f typeFilter) empty() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+func (f typeFilter) validate() error {
+	for _, patterns := range [][]string{f.Include, f.Exclude} {
+		for _, p := range patterns {
+			if _, err := path.Match(p, ""); err != nil {
+				return fmt.Errorf("invalid type filter %q: %v", p, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Keep returns true if the type with the given name, defined in the given
+// module, should be generated.
+func (f typeFilter) Keep(module, name string) bool {
+	if len(f.Include) > 0 && !matchTypeName(f.Include, module, name) {
+		return false
+	}
+	return !matchTypeName(f.Exclude, module, name)
+}
+
+func matchTypeName(patterns []string, module, name string) bool {
+	qualified := module + "." + name
+	for _, p := range patterns {
+		// Patterns were validated beforehand.
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+		if ok, _ := path.Match(p, qualified); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// filterTypes returns a copy of the module tree rooted at the given module
+// with types rejected by the filter removed.
+//
+// An error is returned if a type, constant, or service that is still being
+// generated references a type that was removed.
+func filterTypes(m *compile.Module, f typeFilter) (*compile.Module, error) {
+	if f.empty() {
+		return m, nil
+	}
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+
+	// Removed types and their qualified names.
+	removed := make(map[compile.TypeSpec]string)
+	copies := make(map[string]*compile.Module)
+	err := m.Walk(func(m *compile.Module) error {
+		c := *m
+		c.Types = make(map[string]compile.TypeSpec, len(m.Types))
+		for name, spec := range m.Types {
+			if f.Keep(m.Name, name) {
+				c.Types[name] = spec
+			} else {
+				removed[spec] = m.Name + "." + name
+			}
+		}
+		copies[m.ThriftPath] = &c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, thriftPath := range sortStringKeys(copies) {
+		c := copies[thriftPath]
+		if err := checkFilteredReferences(c, removed); err != nil {
+			return nil, err
+		}
+
+		includes := make(map[string]*compile.IncludedModule, len(c.Includes))
+		for name, inc := range c.Includes {
+			includes[name] = &compile.IncludedModule{
+				Name:   inc.Name,
+				Module: copies[inc.Module.ThriftPath],
+			}
+		}
+		c.Includes = includes
+	}
+
+	return copies[m.ThriftPath], nil
+}
+
+// checkFilteredReferences verifies that nothing left in the given module
+// references a removed type.
+func checkFilteredReferences(m *compile.Module, removed map[compile.TypeSpec]string) error {
+	for _, name := range sortStringKeys(m.Types) {
+		spec := m.Types[name]
+		if err := spec.ForEachTypeReference(findRemovedType(removed)); err != nil {
+			return typeFilterError{Module: m.Name, Name: name, Reason: err}
+		}
+	}
+
+	for _, name := range sortStringKeys(m.Constants) {
+		if err := findRemovedType(removed)(m.Constants[name].Type); err != nil {
+			return typeFilterError{Module: m.Name, Name: name, Reason: err}
+		}
+	}
+
+	for _, serviceName := range sortStringKeys(m.Services) {
+		service := m.Services[serviceName]
+		for _, functionName := range sortStringKeys(service.Functions) {
+			function := service.Functions[functionName]
+
+			check := findRemovedType(removed)
+			err := compile.FieldGroup(function.ArgsSpec).ForEachTypeReference(check)
+			if err == nil && function.ResultSpec != nil {
+				if function.ResultSpec.ReturnType != nil {
+					err = check(function.ResultSpec.ReturnType)
+				}
+				if err == nil {
+					err = function.ResultSpec.Exceptions.ForEachTypeReference(check)
+				}
+			}
+			if err != nil {
+				return typeFilterError{
+					Module: m.Name,
+					Name:   serviceName + "." + functionName,
+					Reason: err,
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// findRemovedType returns a function which fails if the given type or the
+// element types of the given container type were removed.
+func findRemovedType(removed map[compile.TypeSpec]string) func(compile.TypeSpec) error {
+	var check func(compile.TypeSpec) error
+	check = func(spec compile.TypeSpec) error {
+		if name, ok := removed[spec]; ok {
+			return fmt.Errorf("references excluded type %q", name)
+		}
+
+		switch spec.(type) {
+		case *compile.MapSpec, *compile.ListSpec, *compile.SetSpec:
+			return spec.ForEachTypeReference(check)
+		default:
+			return nil
+		}
+	}
+	return check
+}
+
+type typeFilterError struct {
+	Module string
+	Name   string
+	Reason error
+}
+
+func (e typeFilterError) Error() string {
+	return fmt.Sprintf("%s.%s %v", e.Module, e.Name, e.Reason)
+}
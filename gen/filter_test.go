@@ -0,0 +1,232 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeFilterKeep(t *testing.T) {
+	tests := []struct {
+		desc   string
+		filter typeFilter
+		module string
+		name   string
+		want   bool
+	}{
+		{
+			desc: "no patterns",
+			name: "User",
+			want: true,
+		},
+		{
+			desc:   "excluded by name",
+			filter: typeFilter{Exclude: []string{"*_internal*"}},
+			module: "shared",
+			name:   "User_internal",
+		},
+		{
+			desc:   "excluded by qualified name",
+			filter: typeFilter{Exclude: []string{"shared.*"}},
+			module: "shared",
+			name:   "User",
+		},
+		{
+			desc:   "not excluded",
+			filter: typeFilter{Exclude: []string{"shared.*"}},
+			module: "main",
+			name:   "User",
+			want:   true,
+		},
+		{
+			desc:   "included",
+			filter: typeFilter{Include: []string{"User*"}},
+			module: "main",
+			name:   "UserID",
+			want:   true,
+		},
+		{
+			desc:   "not included",
+			filter: typeFilter{Include: []string{"User*"}},
+			module: "main",
+			name:   "Account",
+		},
+		{
+			desc: "included and excluded",
+			filter: typeFilter{
+				Include: []string{"User*"},
+				Exclude: []string{"*Internal"},
+			},
+			module: "main",
+			name:   "UserInternal",
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.filter.Keep(tt.module, tt.name), tt.desc)
+	}
+}
+
+func TestFilterTypes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-filter-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for path, contents := range map[string]string{
+		"shared/shared.thrift": `
+			typedef string UUID
+			struct Audit_internal { 1: optional string actor }
+		`,
+		"main.thrift": `
+			include "./shared/shared.thrift"
+
+			struct User { 1: required shared.UUID id }
+			struct UserDebug_internal { 1: optional list<shared.Audit_internal> audits }
+			enum Status { ENABLED }
+
+			const Status DefaultStatus = Status.ENABLED
+
+			service Users {
+				User getUser(1: shared.UUID id)
+			}
+		`,
+	} {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+
+	module, err := compile.Compile(filepath.Join(dir, "main.thrift"))
+	require.NoError(t, err)
+
+	typeNames := func(m *compile.Module) []string {
+		var names []string
+		for name := range m.Types {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	t.Run("no filter", func(t *testing.T) {
+		got, err := filterTypes(module, typeFilter{})
+		require.NoError(t, err)
+		assert.True(t, got == module, "module must not be copied without filters")
+	})
+
+	t.Run("exclude", func(t *testing.T) {
+		got, err := filterTypes(module, typeFilter{Exclude: []string{"*_internal*"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Status", "User"}, typeNames(got))
+		assert.Equal(t, []string{"UUID"}, typeNames(got.Includes["shared"].Module))
+
+		// The original module is left untouched.
+		assert.Len(t, module.Types, 3)
+		assert.Len(t, module.Includes["shared"].Module.Types, 2)
+	})
+
+	t.Run("include", func(t *testing.T) {
+		got, err := filterTypes(module, typeFilter{Include: []string{"User", "Status", "shared.UUID"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Status", "User"}, typeNames(got))
+		assert.Equal(t, []string{"UUID"}, typeNames(got.Includes["shared"].Module))
+	})
+
+	tests := []struct {
+		desc    string
+		filter  typeFilter
+		wantErr string
+	}{
+		{
+			desc:    "bad pattern",
+			filter:  typeFilter{Exclude: []string{"User["}},
+			wantErr: `invalid type filter "User["`,
+		},
+		{
+			desc:    "referenced by a container",
+			filter:  typeFilter{Exclude: []string{"Audit_internal"}},
+			wantErr: `main.UserDebug_internal references excluded type "shared.Audit_internal"`,
+		},
+		{
+			desc:    "referenced by a constant",
+			filter:  typeFilter{Exclude: []string{"Status"}},
+			wantErr: `main.DefaultStatus references excluded type "main.Status"`,
+		},
+		{
+			desc:    "referenced by a service",
+			filter:  typeFilter{Exclude: []string{"main.User"}},
+			wantErr: `main.Users.getUser references excluded type "main.User"`,
+		},
+		{
+			desc:    "referenced by an included type",
+			filter:  typeFilter{Include: []string{"User*"}},
+			wantErr: `main.User references excluded type "shared.UUID"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := filterTypes(module, tt.filter)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateTypeFilters(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-filter-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	thriftFile := filepath.Join(dir, "main.thrift")
+	require.NoError(t, ioutil.WriteFile(thriftFile, []byte(`
+		struct User { 1: required string name }
+		struct User_internal { 1: required string secret }
+	`), 0644))
+
+	module, err := compile.Compile(thriftFile)
+	require.NoError(t, err)
+
+	outputDir := filepath.Join(dir, "out")
+	require.NoError(t, Generate(module, &Options{
+		OutputDir:      outputDir,
+		PackagePrefix:  "example.com/out",
+		ThriftRoot:     dir,
+		NoVersionCheck: true,
+		NoEmbedIDL:     true,
+		ExcludeTypes:   []string{"*_internal*"},
+	}))
+
+	contents, err := ioutil.ReadFile(filepath.Join(outputDir, "main", "types.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), "type User struct")
+	assert.NotContains(t, string(contents), "User_internal")
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+// flattenNames prepares the given module and all modules it includes to be
+// generated into a single Go package. It returns the prefixes added to the
+// names of the included modules, keyed by their Thrift file paths.
+//
+// Types and constants of included modules are prefixed with the names of
+// their modules so that they do not collide with each other: the struct
+// User of shared.thrift is generated as SharedUser. Types get the prefixed
+// names through go.name annotations so that all references to them use the
+// new names.
+//
+// Prefixes and the original Go names of types are determined with the given
+// nameMapper.
+func flattenNames(root *compile.Module, names nameMapper) (map[string]string, error) {
+	prefixes := make(map[string]string)
+	err := root.Walk(func(m *compile.Module) error {
+		if m == root {
+			return nil
+		}
+
+		if !isGoIdentifier(m.Name) {
+			return generateError{
+				Name:   m.ThriftPath,
+				Reason: fmt.Errorf("cannot flatten module %q: its name is not a valid Go identifier", m.Name),
+			}
+		}
+		prefix := names.goCase(m.Name)
+		prefixes[m.ThriftPath] = prefix
+
+		for _, name := range sortStringKeys(m.Types) {
+			spec := m.Types[name]
+			goName, err := names.goName(spec)
+			if err != nil {
+				return generateError{Name: m.ThriftPath, Reason: err}
+			}
+			if err := setGoName(spec, prefix+goName); err != nil {
+				return generateError{Name: m.ThriftPath, Reason: err}
+			}
+		}
+		return nil
+	})
+	return prefixes, err
+}
+
+// setGoName changes the Go name of the given type with a go.name
+// annotation.
+func setGoName(spec compile.TypeSpec, name string) error {
+	var annotations *compile.Annotations
+	switch s := spec.(type) {
+	case *compile.StructSpec:
+		annotations = &s.Annotations
+	case *compile.EnumSpec:
+		annotations = &s.Annotations
+	case *compile.TypedefSpec:
+		annotations = &s.Annotations
+	default:
+		return fmt.Errorf("cannot rename %q: unexpected type %T", spec.ThriftName(), spec)
+	}
+
+	if *annotations == nil {
+		*annotations = make(compile.Annotations)
+	}
+	(*annotations)["go.name"] = name
+	return nil
+}
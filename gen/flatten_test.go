@@ -0,0 +1,183 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlatten(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-flatten-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"main.thrift": `
+			include "./shared.thrift"
+			include "./other.thrift"
+
+			struct User {
+				1: required shared.User user
+				2: optional other.User other
+				3: optional list<string> tags
+			}
+
+			const i32 Max = shared.MaxUsers
+
+			service Users extends shared.Base {
+				other.Status status(1: shared.UUID id)
+			}
+		`,
+		// Included modules may include each other because they end up in
+		// the same package.
+		"shared.thrift": `
+			include "./other.thrift"
+
+			struct User {
+				1: required string name
+				2: optional list<string> tags
+				3: optional other.Status status
+			}
+
+			const i32 MaxUsers = 10
+
+			typedef string UUID
+
+			service Base {
+				User get(1: UUID id)
+			}
+		`,
+		"other.thrift": `
+			include "./shared.thrift"
+
+			enum Status { ACTIVE, INACTIVE }
+
+			struct User {
+				1: optional shared.User shared
+			}
+		`,
+	}
+	for name, contents := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644))
+	}
+
+	module, err := compile.Compile(filepath.Join(dir, "main.thrift"))
+	require.NoError(t, err)
+
+	outputDir := filepath.Join(dir, "out")
+	require.NoError(t, Generate(module, &Options{
+		OutputDir:     outputDir,
+		PackagePrefix: "example.com/out",
+		ThriftRoot:    dir,
+		Flatten:       true,
+	}))
+
+	var generated []string
+	err = filepath.Walk(outputDir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			path, err = filepath.Rel(outputDir, path)
+			generated = append(generated, path)
+		}
+		return err
+	})
+	require.NoError(t, err)
+	sort.Strings(generated)
+	assert.Equal(t, []string{
+		"main/constants.go",
+		"main/idl.go",
+		"main/other_idl.go",
+		"main/other_types.go",
+		"main/shared_base_get.go",
+		"main/shared_constants.go",
+		"main/shared_idl.go",
+		"main/shared_types.go",
+		"main/types.go",
+		"main/users_status.go",
+		"main/versioncheck.go",
+	}, generated)
+
+	read := func(name string) string {
+		contents, err := ioutil.ReadFile(filepath.Join(outputDir, "main", name))
+		require.NoError(t, err)
+		return string(contents)
+	}
+
+	types := read("types.go")
+	assert.Contains(t, types, "type User struct {")
+	assert.Contains(t, types, "User  *SharedUser")
+	assert.Contains(t, types, "Other *OtherUser")
+	assert.NotContains(t, types, "example.com/out", "must not import other packages")
+
+	sharedTypes := read("shared_types.go")
+	assert.Contains(t, sharedTypes, "type SharedUser struct {")
+	assert.Contains(t, sharedTypes, "type SharedUUID string")
+	assert.Contains(t, sharedTypes, "Status *OtherStatus")
+	assert.NotContains(t, sharedTypes, "func _List_String_Equals(",
+		"helpers must be declared only once")
+	assert.Contains(t, types, "func _List_String_Equals(")
+
+	otherTypes := read("other_types.go")
+	assert.Contains(t, otherTypes, "type OtherStatus int32")
+	assert.Contains(t, otherTypes, "OtherStatusActive")
+	assert.Contains(t, otherTypes, "type OtherUser struct {")
+
+	assert.Contains(t, read("shared_constants.go"), "const SharedMaxUsers int32 = 10")
+	assert.Contains(t, read("shared_base_get.go"), "type Base_Get_Args struct {")
+	assert.Contains(t, read("users_status.go"), "func Users_Status_WithID(v SharedUUID)")
+
+	idl := read("idl.go")
+	assert.Contains(t, idl, "var ThriftModule = ")
+	assert.Contains(t, idl, "ThriftModule.Includes = []*thriftreflect.ThriftModule{OtherThriftModule, SharedThriftModule}")
+	assert.Contains(t, read("shared_idl.go"), "var SharedThriftModule = ")
+}
+
+func TestFlattenInvalidModuleName(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-flatten-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.thrift"),
+		[]byte(`include "./foo-bar.thrift"`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "foo-bar.thrift"),
+		[]byte(`struct Foo {}`), 0644))
+
+	module, err := compile.Compile(filepath.Join(dir, "main.thrift"))
+	require.NoError(t, err)
+
+	err = Generate(module, &Options{
+		OutputDir:     filepath.Join(dir, "out"),
+		PackagePrefix: "example.com/out",
+		ThriftRoot:    dir,
+		Flatten:       true,
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `cannot flatten module "foo-bar"`)
+	}
+}
@@ -24,8 +24,6 @@ import (
 	"bytes"
 	"fmt"
 	"go/token"
-	"io/ioutil"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -74,53 +72,317 @@ type Options struct {
 
 	// Do not embed IDLs in generated code
 	NoEmbedIDL bool
+
+	// Reflection adds a descriptor of the types and services defined in each
+	// Thrift file to the embedded IDL and registers it with thriftreflect
+	// when the generated package is initialized. This may not be used with
+	// NoEmbedIDL.
+	Reflection bool
+
+	// TypeSubstitutions replaces references to Thrift types with existing Go
+	// types. Keys are in the form "$module.$type".
+	//
+	// See TypeSubstitution for more information.
+	TypeSubstitutions map[string]TypeSubstitution
+
+	// TypeConverters specifies the conversion functions for Go types named
+	// in go.type annotations. Keys are fully qualified Go types.
+	//
+	// See TypeConverter for more information.
+	TypeConverters map[string]TypeConverter
+
+	// Header is a text/template whose output is prepended to every
+	// generated Go file. It should consist of Go comments.
+	//
+	// See HeaderData for the variables available to the template.
+	Header string
+
+	// IncludeTypes and ExcludeTypes are glob patterns which select the
+	// types that get generated. Patterns match against the name of the type
+	// or its name qualified with the module name ("$module.$type").
+	//
+	// If IncludeTypes is non-empty, only types matching one of its patterns
+	// are generated. Types matching any of the ExcludeTypes patterns are
+	// never generated. Generation fails if a generated type, constant, or
+	// service references a type that was filtered out.
+	//
+	// Note that the embedded IDL still contains the full Thrift file. Use
+	// NoEmbedIDL to omit it.
+	IncludeTypes []string
+	ExcludeTypes []string
+
+	// OnlyTypes and OnlyServices generate only the types or only the
+	// services of the Thrift files. IncludeSymbols are glob patterns which
+	// select the types, constants, and services that get generated, matched
+	// like IncludeTypes.
+	//
+	// Unlike IncludeTypes, everything that a selected definition references
+	// is generated as well, so generation never fails because of a missing
+	// type. Constants are generated with OnlyTypes or OnlyServices only if
+	// they are referenced. These may be used to generate only the parts of a
+	// large shared Thrift file that are used.
+	OnlyTypes      bool
+	OnlyServices   bool
+	IncludeSymbols []string
+
+	// JSONInt64AsString encodes i64 fields as JSON strings rather than
+	// numbers. JavaScript cannot represent all 64-bit integers as numbers.
+	JSONInt64AsString bool
+
+	// ConstantAccessors generates constants of struct, container, and
+	// binary types as functions which return a new copy of the value on
+	// every call, rather than as package-level variables which may be
+	// modified by any user of the package.
+	ConstantAccessors bool
+
+	// PackageName overrides the name of the Go package generated for the
+	// root Thrift file. Packages for other Thrift files may specify their
+	// names with a go.package annotation on a namespace statement.
+	//
+	// 	namespace go users (go.package = "userapi")
+	//
+	// By default, packages are named after the Thrift files that define
+	// them.
+	PackageName string
+
+	// Allocator generates a FromWireArena method on every struct and
+	// typedef which decodes values like FromWire but allocates structs and
+	// optional primitive fields from the given arena.Arena.
+	//
+	// All Thrift files whose types reference each other must be generated
+	// with this option.
+	Allocator bool
+
+	// StrictEnums generates enums whose FromWire methods return a
+	// wire.UnknownEnumError for values that are not defined in the enum. By
+	// default, unrecognized values are preserved so that peers may add new
+	// items without breaking older readers.
+	//
+	// Individual enums may override this with the go.strict annotation.
+	//
+	// 	enum Status {
+	// 		...
+	// 	} (go.strict = "false")
+	StrictEnums bool
+
+	// PresenceMethods generates Has, Clear, and Set methods for every
+	// optional field, similar to the presence API of protobuf.
+	//
+	// 	func (v *User) HasEmail() bool
+	// 	func (v *User) ClearEmail()
+	// 	func (v *User) SetEmail(x string)
+	//
+	// Setting a field of a union clears its other fields.
+	PresenceMethods bool
+
+	// HashMethods generates a Hash method for every struct which returns a
+	// stable 64-bit hash of its value. Equal values have the same hash.
+	//
+	// 	func (v *User) Hash() (uint64, error)
+	//
+	// Fields annotated with go.hash = "false" do not contribute to the hash.
+	// Code generation fails if a struct has a field named Hash.
+	HashMethods bool
+
+	// CompactCodegen generates FromWire methods which decode structs with a
+	// table describing their fields and a loop shared by all structs,
+	// instead of code unrolled for every field. This shrinks the generated
+	// code significantly for Thrift files with many structs, at a small
+	// cost in decoding performance.
+	CompactCodegen bool
+
+	// KeepUnknownFields generates structs which retain the fields they do
+	// not recognize when decoded with FromWire and include them again in
+	// ToWire. This lets intermediaries which were built against an older
+	// version of a Thrift file forward values without losing newer fields.
+	// Retained fields are compared by Equals.
+	KeepUnknownFields bool
+
+	// BuilderThreshold generates builders for structs and exceptions with
+	// more than this many fields. Builders set fields with chained calls
+	// and fail to build values whose required fields were not set.
+	//
+	// 	req, err := NewCreateUserRequestBuilder().Name("alice").Build()
+	//
+	// Structs may opt in or out with the go.builder annotation. If this is
+	// zero, only structs annotated with go.builder = "true" get builders.
+	BuilderThreshold int
+
+	// Flatten generates code for the root Thrift file and all Thrift files
+	// it includes into a single Go package rather than a package for each
+	// file. Types and constants of included files are prefixed with the
+	// names of their files to avoid collisions: the struct User of
+	// shared.thrift is generated as SharedUser. Include cycles are allowed.
+	//
+	// Services keep their names and must be unique across the Thrift
+	// files. This may not be used with NoRecurse or Reflection.
+	Flatten bool
+
+	// Initialisms are words which are written in all caps when they appear
+	// in the names of generated identifiers, in addition to common
+	// initialisms like ID, HTTP, and URL.
+	//
+	// 	Initialisms: []string{"SKU"}  // sku_id becomes SKUID
+	//
+	// All Thrift files whose types reference each other must be generated
+	// with the same Initialisms and PreserveNames.
+	Initialisms []string
+
+	// PreserveNames generates identifiers which keep the words of Thrift
+	// names unchanged rather than converting them to PascalCase. Only the
+	// first letter of each name is capitalized so that it is exported:
+	// user_id becomes User_id. Names with underscores may then conflict with
+	// those of generated helpers like Color_Values. Such conflicts are
+	// reported as errors.
+	PreserveNames bool
+
+	// Jobs is the maximum number of Thrift files for which code is
+	// generated concurrently. Defaults to the number of CPUs.
+	Jobs int
+
+	// GoVersion is the oldest version of Go, for example "1.23", that the
+	// generated code must build with. Code which requires newer versions of
+	// Go is generated only if it is supported by this version:
+	//
+	// 	- Go 1.18 converts lists, sets, and maps with the generic helpers
+	// 	  of the thriftlist, thriftset, and thriftmap packages, which
+	// 	  makes the generated code much smaller
+	// 	- Go 1.23 adds All methods that return iterators over the items of
+	// 	  typedefs of sets and maps
+	//
+	// By default, the generated code builds with all versions of Go
+	// supported by ThriftRW.
+	GoVersion string
 }
 
 // Generate generates code based on the given options.
 func Generate(m *compile.Module, o *Options) error {
-	if !filepath.IsAbs(o.ThriftRoot) {
-		return fmt.Errorf(
-			"ThriftRoot must be an absolute path: %q is not absolute",
-			o.ThriftRoot)
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	m, err := filterTypes(m, typeFilter{
+		Include: o.IncludeTypes,
+		Exclude: o.ExcludeTypes,
+	})
+	if err != nil {
+		return err
+	}
+
+	m, err = pruneModule(m, symbolFilter{
+		OnlyTypes:    o.OnlyTypes,
+		OnlyServices: o.OnlyServices,
+		Include:      o.IncludeSymbols,
+	})
+	if err != nil {
+		return err
 	}
 
-	if !filepath.IsAbs(o.OutputDir) {
-		return fmt.Errorf(
-			"OutputDir must be an absolute path: %q is not absolute",
-			o.OutputDir)
+	// Include cycles are allowed between modules generated into the same
+	// package.
+	if !o.Flatten {
+		if err := findIncludeCycles(m); err != nil {
+			return generateError{Name: m.ThriftPath, Reason: err}
+		}
+	}
+
+	packageNames, err := resolvePackageNames(m, o.PackageName)
+	if err != nil {
+		return err
 	}
 
 	importer := thriftPackageImporter{
 		ImportPrefix: o.PackagePrefix,
 		ThriftRoot:   o.ThriftRoot,
+		PackageNames: packageNames,
+	}
+	names := newNameMapper(o.Initialisms, o.PreserveNames)
+	if o.Flatten {
+		prefixes, err := flattenNames(m, names)
+		if err != nil {
+			return err
+		}
+		importer.FlattenInto = m.ThriftPath
+		importer.NamePrefixes = prefixes
 	}
 
-	// Mapping of filenames relative to OutputDir to their contents.
-	files := make(map[string][]byte)
-	genBuilder := newGenerateServiceBuilder(importer)
+	subs, err := resolveTypeSubstitutions(m, o.TypeSubstitutions, o.TypeConverters)
+	if err != nil {
+		return err
+	}
+
+	header, err := newFileHeader(o.Header)
+	if err != nil {
+		return err
+	}
 
-	generate := func(m *compile.Module) error {
-		moduleFiles, err := generateModule(m, importer, genBuilder, o)
+	// Note that we generate code for only those modules that we need to
+	// generate code for. If the user used --no-recurse, we're not going to
+	// generate code for included modules.
+	modules := []*compile.Module{m}
+	if !o.NoRecurse {
+		modules, err = sortedModules(m)
 		if err != nil {
-			return generateError{Name: m.ThriftPath, Reason: err}
+			return err
 		}
-		if err := mergeFiles(files, moduleFiles); err != nil {
-			return generateError{Name: m.ThriftPath, Reason: err}
+	}
+
+	withHeader := func(m *compile.Module, moduleFiles map[string][]byte, err error) (map[string][]byte, error) {
+		if err != nil {
+			return nil, err
 		}
-		return nil
+		if err := prependHeader(header, moduleFiles, importer, m); err != nil {
+			return nil, err
+		}
+		return moduleFiles, nil
 	}
 
-	// Note that we call generate directly on only those modules that we need
-	// to generate code for. If the user used --no-recurse, we're not going to
-	// generate code for included modules.
-	if o.NoRecurse {
-		if err := generate(m); err != nil {
+	var results []moduleResult
+	if o.Flatten {
+		// Modules flattened into the same package share a generator so
+		// that the helpers they have in common are declared only once.
+		g, err := newModuleGenerator(m, importer, subs, o)
+		if err != nil {
 			return err
 		}
+		results = generateSequential(modules, func(m *compile.Module) (map[string][]byte, error) {
+			moduleFiles, err := generateModuleFiles(g, m, importer, o)
+			return withHeader(m, moduleFiles, err)
+		})
 	} else {
-		if err := m.Walk(generate); err != nil {
-			return err
+		results = generateParallel(modules, o.Jobs, func(m *compile.Module) (map[string][]byte, error) {
+			moduleFiles, err := generateModule(m, importer, subs, o)
+			return withHeader(m, moduleFiles, err)
+		})
+	}
+
+	// Mapping of filenames relative to OutputDir to their contents.
+	files := make(map[string][]byte)
+	genBuilder := newGenerateServiceBuilder(importer)
+	genBuilder.names = names
+
+	// The request references included modules even with NoRecurse.
+	if err := m.Walk(genBuilder.AddNamespaces); err != nil {
+		return err
+	}
+
+	// Results are merged in the order of the modules so that the reported
+	// errors and the plugin request do not depend on scheduling.
+	var errs []error
+	for i, m := range modules {
+		r := results[i]
+		if r.Err == nil {
+			r.Err = mergeFiles(files, r.Files)
+		}
+		if r.Err == nil {
+			r.Err = addRootServices(genBuilder, m)
 		}
+		if r.Err != nil {
+			errs = append(errs, generateError{Name: m.ThriftPath, Reason: r.Err})
+		}
+	}
+	if err := multierr.Combine(errs...); err != nil {
+		return err
 	}
 
 	plug := o.Plugin
@@ -134,25 +396,17 @@ func Generate(m *compile.Module, o *Options) error {
 			return err
 		}
 
-		if err := mergeFiles(files, res.Files); err != nil {
+		// Files generated by plugins are attributed to the root Thrift file.
+		if err := prependHeader(header, res.Files, importer, m); err != nil {
 			return err
 		}
-	}
-
-	for relPath, contents := range files {
-		fullPath := filepath.Join(o.OutputDir, relPath)
-		directory := filepath.Dir(fullPath)
-
-		if err := os.MkdirAll(directory, 0755); err != nil {
-			return fmt.Errorf("could not create directory %q: %v", directory, err)
-		}
 
-		if err := ioutil.WriteFile(fullPath, contents, 0644); err != nil {
-			return fmt.Errorf("failed to write %q: %v", fullPath, err)
+		if err := mergeFiles(files, res.Files); err != nil {
+			return err
 		}
 	}
 
-	return nil
+	return writeFiles(o.OutputDir, files)
 }
 
 // TODO(abg): Make some sort of public interface out of the Importer
@@ -160,11 +414,28 @@ func Generate(m *compile.Module, o *Options) error {
 type thriftPackageImporter struct {
 	ImportPrefix string
 	ThriftRoot   string
+
+	// PackageNames maps Thrift file paths to the names of their Go
+	// packages. Files not listed here use the base name of their package
+	// directory.
+	PackageNames map[string]string
+
+	// FlattenInto is the path of the Thrift file into whose package code
+	// for all Thrift files is generated. If empty, each Thrift file gets
+	// its own package.
+	FlattenInto string
+
+	// NamePrefixes maps Thrift file paths to the prefixes added to the Go
+	// names of their constants.
+	NamePrefixes map[string]string
 }
 
 // RelativePackage returns the import path for the top-level package of the
 // given Thrift file relative to the ImportPrefix.
 func (i thriftPackageImporter) RelativePackage(file string) (string, error) {
+	if i.FlattenInto != "" {
+		file = i.FlattenInto
+	}
 	return filepath.Rel(i.ThriftRoot, strings.TrimSuffix(file, ".thrift"))
 }
 
@@ -172,6 +443,23 @@ func (i thriftPackageImporter) RelativeThriftFilePath(file string) (string, erro
 	return filepath.Rel(i.ThriftRoot, file)
 }
 
+// PackageName returns the name of the top-level Go package generated for the
+// given Thrift file.
+func (i thriftPackageImporter) PackageName(file string) (string, error) {
+	if i.FlattenInto != "" {
+		file = i.FlattenInto
+	}
+	if name, ok := i.PackageNames[file]; ok {
+		return name, nil
+	}
+
+	pkg, err := i.RelativePackage(file)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Base(pkg), nil
+}
+
 // Package returns the import path for the top-level package of the given Thrift
 // file.
 func (i thriftPackageImporter) Package(file string) (string, error) {
@@ -182,6 +470,20 @@ func (i thriftPackageImporter) Package(file string) (string, error) {
 	return filepath.Join(i.ImportPrefix, pkg), nil
 }
 
+// prependHeader adds the given header to the Go files generated for the
+// given module.
+func prependHeader(h *fileHeader, files map[string][]byte, i thriftPackageImporter, m *compile.Module) error {
+	if h == nil {
+		return nil
+	}
+
+	thriftFile, err := i.RelativeThriftFilePath(m.ThriftPath)
+	if err != nil {
+		return err
+	}
+	return h.Prepend(files, thriftFile)
+}
+
 func mergeFiles(dest, src map[string][]byte) error {
 	var errors []error
 	for path, contents := range src {
@@ -196,19 +498,21 @@ func mergeFiles(dest, src map[string][]byte) error {
 
 // generateModule returns a mapping from filename to file contents of files that
 // should be generated relative to o.OutputDir.
-func generateModule(m *compile.Module, i thriftPackageImporter, builder *generateServiceBuilder, o *Options) (map[string][]byte, error) {
-	// packageRelPath is the path relative to outputDir into which we'll be
-	// writing the package for this Thrift file. For $thriftRoot/foo/bar.thrift,
-	// packageRelPath is foo/bar, and packageDir is $outputDir/foo/bar. All
-	// files for bar.thrift will be written to the $outputDir/foo/bar/ tree. The
-	// package will be importable via $importPrefix/foo/bar.
-	packageRelPath, err := i.RelativePackage(m.ThriftPath)
+func generateModule(m *compile.Module, i thriftPackageImporter, subs typeSubstitutions, o *Options) (map[string][]byte, error) {
+	g, err := newModuleGenerator(m, i, subs, o)
 	if err != nil {
 		return nil, err
 	}
+	return generateModuleFiles(g, m, i, o)
+}
 
-	// TODO(abg): Prefer top-level package name from `namespace go` directive.
-	packageName := filepath.Base(packageRelPath)
+// newModuleGenerator builds a generator for the Go package of the given
+// module.
+func newModuleGenerator(m *compile.Module, i thriftPackageImporter, subs typeSubstitutions, o *Options) (*generator, error) {
+	packageName, err := i.PackageName(m.ThriftPath)
+	if err != nil {
+		return nil, err
+	}
 
 	// importPath is the full import path for the top-level package generated
 	// for this Thrift file.
@@ -217,15 +521,60 @@ func generateModule(m *compile.Module, i thriftPackageImporter, builder *generat
 		return nil, err
 	}
 
+	g := newGenerator(i, importPath, packageName, subs)
+	g.json = jsonOptions{Int64AsString: o.JSONInt64AsString}
+	g.constAccessors = o.ConstantAccessors
+	g.strict = o.StrictEnums
+	g.presence = o.PresenceMethods
+	g.hash = o.HashMethods
+	g.compact = o.CompactCodegen
+	g.unknown = o.KeepUnknownFields
+	g.builders = o.BuilderThreshold
+	g.names = newNameMapper(o.Initialisms, o.PreserveNames)
+	minor, _ := parseGoVersion(o.GoVersion)
+	g.iter = minor >= iteratorGoMinorVersion
+	g.generic = minor >= genericsGoMinorVersion
+	if o.Allocator {
+		g.arena = true
+		if err := g.Reserve(arenaVarName); err != nil {
+			return nil, err
+		}
+	}
+	if err := g.useIncludeNames(m); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// generateModuleFiles generates code for the given module with the given
+// generator. It returns a mapping from filename to file contents of files
+// that should be generated relative to o.OutputDir.
+func generateModuleFiles(g *generator, m *compile.Module, i thriftPackageImporter, o *Options) (map[string][]byte, error) {
+	// packageRelPath is the path relative to outputDir into which we'll be
+	// writing the package for this Thrift file. For $thriftRoot/foo/bar.thrift,
+	// packageRelPath is foo/bar, and packageDir is $outputDir/foo/bar. All
+	// files for bar.thrift will be written to the $outputDir/foo/bar/ tree. The
+	// package will be importable via $importPrefix/foo/bar.
+	packageRelPath, err := i.RelativePackage(m.ThriftPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Files for modules flattened into the package of another module are
+	// named after their modules.
+	var filePrefix string
+	if i.NamePrefixes[m.ThriftPath] != "" {
+		filePrefix = strings.ToLower(m.Name) + "_"
+	}
+
 	// Mapping of file names relative to packageRelPath to their contents.
 	// Note that we need to return a mapping relative to o.OutputDir so we
 	// will prepend $packageRelPath/ to all these paths.
 	files := make(map[string][]byte)
 
-	g := NewGenerator(i, importPath, packageName)
-
-	if !o.NoVersionCheck {
-		if err := Version(g, importPath); err != nil {
+	// A package needs only one version check.
+	if !o.NoVersionCheck && filePrefix == "" {
+		if err := Version(g, g.ImportPath); err != nil {
 			return nil, err
 		}
 
@@ -252,7 +601,7 @@ func generateModule(m *compile.Module, i thriftPackageImporter, builder *generat
 
 		// TODO(abg): Verify no file collisions
 		if !o.NoConstants {
-			files["constants.go"] = buff.Bytes()
+			files[filePrefix+"constants.go"] = buff.Bytes()
 		}
 	}
 
@@ -263,6 +612,10 @@ func generateModule(m *compile.Module, i thriftPackageImporter, builder *generat
 			}
 		}
 
+		if err := conversions(g, m); err != nil {
+			return nil, err
+		}
+
 		buff := new(bytes.Buffer)
 		if err := g.Write(buff, token.NewFileSet()); err != nil {
 			return nil, fmt.Errorf(
@@ -271,12 +624,12 @@ func generateModule(m *compile.Module, i thriftPackageImporter, builder *generat
 
 		// TODO(abg): Verify no file collisions
 		if !o.NoTypes {
-			files["types.go"] = buff.Bytes()
+			files[filePrefix+"types.go"] = buff.Bytes()
 		}
 	}
 
 	if !o.NoEmbedIDL {
-		if err := embedIDL(g, i, m); err != nil {
+		if err := embedIDL(g, i, m, o.Reflection); err != nil {
 			return nil, err
 		}
 
@@ -286,7 +639,7 @@ func generateModule(m *compile.Module, i thriftPackageImporter, builder *generat
 				"could not generate idl.go for %q: %v", m.ThriftPath, err)
 		}
 
-		files["idl.go"] = buff.Bytes()
+		files[filePrefix+"idl.go"] = buff.Bytes()
 	}
 
 	// Services must be generated last because names of user-defined types take
@@ -294,18 +647,6 @@ func generateModule(m *compile.Module, i thriftPackageImporter, builder *generat
 	if len(m.Services) > 0 {
 		for _, serviceName := range sortStringKeys(m.Services) {
 			service := m.Services[serviceName]
-
-			// generateModule gets called only for those modules for which we
-			// need to generate code. With --no-recurse, generateModule is
-			// called only on the root file specified by the user and not its
-			// included modules. Only services defined in these files are
-			// considered root services; plugins will generate code only for
-			// root services, even though they have information about the
-			// whole service tree.
-			if _, err := builder.AddRootService(service); err != nil {
-				return nil, err
-			}
-
 			serviceFiles, err := Service(g, service)
 			if err != nil {
 				return nil, fmt.Errorf(
@@ -315,7 +656,7 @@ func generateModule(m *compile.Module, i thriftPackageImporter, builder *generat
 
 			if !o.NoServiceHelpers {
 				for name, buff := range serviceFiles {
-					files[name] = buff.Bytes()
+					files[filePrefix+name] = buff.Bytes()
 				}
 			}
 		}
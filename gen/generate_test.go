@@ -21,6 +21,7 @@
 package gen
 
 import (
+	"bytes"
 	"errors"
 	"io/ioutil"
 	"os"
@@ -289,3 +290,306 @@ func TestThriftPackageImporter(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateIncludeAs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-include-as-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for path, contents := range map[string]string{
+		"a/shared.thrift": `struct Thing { 1: required string name }`,
+		"b/shared.thrift": `struct Thing { 1: required i64 id }`,
+		"main.thrift": `
+			include "./a/shared.thrift" as sa
+			include "./b/shared.thrift" as sb
+
+			struct Both {
+				1: required sa.Thing a
+				2: required sb.Thing b
+			}
+		`,
+	} {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+
+	module, err := compile.Compile(filepath.Join(dir, "main.thrift"))
+	require.NoError(t, err)
+
+	outputDir := filepath.Join(dir, "out")
+	require.NoError(t, Generate(module, &Options{
+		OutputDir:      outputDir,
+		PackagePrefix:  "example.com/out",
+		ThriftRoot:     dir,
+		NoVersionCheck: true,
+	}))
+
+	contents, err := ioutil.ReadFile(filepath.Join(outputDir, "main", "types.go"))
+	require.NoError(t, err)
+	types := string(contents)
+	assert.Contains(t, types, `sa "example.com/out/a/shared"`)
+	assert.Contains(t, types, `sb "example.com/out/b/shared"`)
+	assert.Contains(t, types, "A *sa.Thing")
+	assert.Contains(t, types, "B *sb.Thing")
+}
+
+func TestGeneratePackageName(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-package-name-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for path, contents := range map[string]string{
+		"shared/common.thrift": `
+			namespace go common (go.package = "commonapi")
+			struct Thing { 1: required string name }
+		`,
+		"main.thrift": `
+			include "./shared/common.thrift"
+
+			struct Wrapper {
+				1: required common.Thing thing
+			}
+		`,
+	} {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+
+	module, err := compile.Compile(filepath.Join(dir, "main.thrift"))
+	require.NoError(t, err)
+
+	outputDir := filepath.Join(dir, "out")
+	require.NoError(t, Generate(module, &Options{
+		OutputDir:      outputDir,
+		PackagePrefix:  "example.com/out",
+		ThriftRoot:     dir,
+		NoVersionCheck: true,
+		PackageName:    "mainapi",
+	}))
+
+	contents, err := ioutil.ReadFile(filepath.Join(outputDir, "shared", "common", "types.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), "package commonapi")
+
+	contents, err = ioutil.ReadFile(filepath.Join(outputDir, "main", "types.go"))
+	require.NoError(t, err)
+	types := string(contents)
+	assert.Contains(t, types, "package mainapi")
+	assert.Contains(t, types, `commonapi "example.com/out/shared/common"`)
+	assert.Contains(t, types, "Thing *commonapi.Thing")
+}
+
+func TestGenerateKeywordNames(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-keyword-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for path, contents := range map[string]string{
+		"type.thrift": `
+			struct Foo { 1: required string name }
+
+			senum Color { "red", "green" }
+		`,
+		"string.thrift": `
+			struct Bar { 1: required string value }
+		`,
+		"main.thrift": `
+			include "./type.thrift"
+			include "./string.thrift"
+
+			struct Wrapper {
+				1: required type.Foo foo
+				2: optional type.Color color
+				3: optional string.Bar bar
+			}
+
+			service Service {
+				void call(1: type.Foo type, 2: i32 len, 3: string func)
+			}
+		`,
+	} {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+
+	module, err := compile.Compile(filepath.Join(dir, "main.thrift"))
+	require.NoError(t, err)
+
+	outputDir := filepath.Join(dir, "out")
+	require.NoError(t, Generate(module, &Options{
+		OutputDir:      outputDir,
+		PackagePrefix:  "example.com/out",
+		ThriftRoot:     dir,
+		NoVersionCheck: true,
+	}))
+
+	contents, err := ioutil.ReadFile(filepath.Join(outputDir, "type", "types.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), "package type_")
+	assert.Contains(t, string(contents), "type Color string", "senums are typedefs of string")
+
+	contents, err = ioutil.ReadFile(filepath.Join(outputDir, "main", "types.go"))
+	require.NoError(t, err)
+	types := string(contents)
+	assert.Contains(t, types, `type_ "example.com/out/type"`)
+	assert.Contains(t, types, `string_ "example.com/out/string"`)
+	assert.Contains(t, types, "Foo   *type_.Foo")
+	assert.Contains(t, types, "Bar   *string_.Bar")
+
+	contents, err = ioutil.ReadFile(filepath.Join(outputDir, "string", "types.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), "package string_")
+	assert.Contains(t, string(contents), "Value string")
+
+	contents, err = ioutil.ReadFile(filepath.Join(outputDir, "main", "service_call.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), "func(type2 *type_.Foo, len2 *int32, func2 *string)")
+}
+
+func TestGenerateNames(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-names-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	thriftFile := filepath.Join(dir, "main.thrift")
+	require.NoError(t, ioutil.WriteFile(thriftFile, []byte(`
+		struct sku_info {
+			1: required string sku_id
+		}
+
+		enum sku_kind { basic_sku }
+
+		const i32 max_sku_count = 10
+
+		service sku_service {
+			sku_info get_sku(1: string sku_id)
+		}
+	`), 0644))
+
+	module, err := compile.Compile(thriftFile)
+	require.NoError(t, err)
+
+	tests := []struct {
+		desc        string
+		initialisms []string
+		preserve    bool
+		want        []string
+	}{
+		{
+			desc: "default",
+			want: []string{
+				"type SkuInfo struct {",
+				"SkuID string",
+				"SkuKindBasicSku SkuKind = 0",
+				"const MaxSkuCount int32 = 10",
+				"type SkuService_GetSku_Args struct {",
+				"func SkuService_GetSku_WithSkuID(",
+			},
+		},
+		{
+			desc:        "initialisms",
+			initialisms: []string{"SKU"},
+			want: []string{
+				"type SKUInfo struct {",
+				"SKUID string",
+				"SKUKindBasicSKU SKUKind = 0",
+				"const MaxSKUCount int32 = 10",
+				"type SKUService_GetSKU_Args struct {",
+				"func SKUService_GetSKU_WithSKUID(",
+			},
+		},
+		{
+			desc:     "preserve",
+			preserve: true,
+			want: []string{
+				"type Sku_info struct {",
+				"Sku_id string",
+				"Sku_kindBasic_sku Sku_kind = 0",
+				"const Max_sku_count int32 = 10",
+				"type Sku_service_Get_sku_Args struct {",
+				"func Sku_service_Get_sku_WithSku_id(",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		outputDir := filepath.Join(dir, "out")
+		require.NoError(t, os.RemoveAll(outputDir), tt.desc)
+		require.NoError(t, Generate(module, &Options{
+			OutputDir:      outputDir,
+			PackagePrefix:  "example.com/out",
+			ThriftRoot:     dir,
+			NoVersionCheck: true,
+			NoEmbedIDL:     true,
+			Initialisms:    tt.initialisms,
+			PreserveNames:  tt.preserve,
+		}), tt.desc)
+
+		var code bytes.Buffer
+		files, err := ioutil.ReadDir(filepath.Join(outputDir, "main"))
+		require.NoError(t, err, tt.desc)
+		for _, f := range files {
+			contents, err := ioutil.ReadFile(filepath.Join(outputDir, "main", f.Name()))
+			require.NoError(t, err, tt.desc)
+			code.Write(contents)
+		}
+
+		for _, want := range tt.want {
+			assert.Contains(t, code.String(), want, tt.desc)
+		}
+	}
+}
+
+func TestGenerateInvalidPackageName(t *testing.T) {
+	tests := []struct {
+		desc        string
+		thrift      string
+		packageName string
+		wantError   string
+	}{
+		{
+			desc:        "keyword option",
+			thrift:      `struct Foo {}`,
+			packageName: "func",
+			wantError:   `invalid Go package name "func"`,
+		},
+		{
+			desc:      "annotation with dash",
+			thrift:    `namespace go foo (go.package = "foo-api")`,
+			wantError: `invalid Go package name "foo-api"`,
+		},
+		{
+			desc:      "annotation starting with digit",
+			thrift:    `namespace * foo (go.package = "2foo")`,
+			wantError: `invalid Go package name "2foo"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "thriftrw-package-name-test")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			path := filepath.Join(dir, "main.thrift")
+			require.NoError(t, ioutil.WriteFile(path, []byte(tt.thrift), 0644))
+
+			module, err := compile.Compile(path)
+			require.NoError(t, err)
+
+			err = Generate(module, &Options{
+				OutputDir:      filepath.Join(dir, "out"),
+				PackagePrefix:  "example.com/out",
+				ThriftRoot:     dir,
+				NoVersionCheck: true,
+				PackageName:    tt.packageName,
+			})
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantError)
+			}
+		})
+	}
+}
@@ -29,11 +29,13 @@ import (
 	"go/token"
 	"go/types"
 	"io"
+	"path/filepath"
 	"reflect"
 	"text/template"
 
 	"go.uber.org/thriftrw/compile"
 	"go.uber.org/thriftrw/internal/curry"
+	"go.uber.org/thriftrw/internal/goast"
 	"go.uber.org/thriftrw/version"
 )
 
@@ -126,14 +128,33 @@ type generator struct {
 	w              WireGenerator
 	e              equalsGenerator
 	decls          []ast.Decl
+	docs           map[ast.Decl][]string // see detachDocs
 	thriftImporter thriftPackageImporter
 	mangler        *mangler
+	substitutions  typeSubstitutions
+	importNames    map[string]string
+	json           jsonOptions
+	constAccessors bool
+	arena          bool
+	strict         bool
+	iter           bool
+	generic        bool
+	presence       bool
+	hash           bool
+	compact        bool
+	unknown        bool
+	builders       int
+	names          nameMapper
 
 	// TODO use something to group related decls together
 }
 
 // NewGenerator sets up a new generator for Go code.
 func NewGenerator(timport thriftPackageImporter, importPath string, packageName string) Generator {
+	return newGenerator(timport, importPath, packageName, nil)
+}
+
+func newGenerator(timport thriftPackageImporter, importPath string, packageName string, subs typeSubstitutions) *generator {
 	// TODO(abg): Determine package name from `namespace go` directive.
 	namespace := NewNamespace()
 	return &generator{
@@ -143,13 +164,113 @@ func NewGenerator(timport thriftPackageImporter, importPath string, packageName
 		importer:       newImporter(namespace.Child()),
 		mangler:        newMangler(),
 		thriftImporter: timport,
+		substitutions:  subs,
+	}
+}
+
+// useIncludeNames makes the generated code refer to packages for modules
+// included with the include-as syntax by the names given to them in the
+// Thrift file. Packages for other included modules are referred to by their
+// package names.
+func (g *generator) useIncludeNames(m *compile.Module) error {
+	names := make(map[string]string)
+	for _, name := range sortStringKeys(m.Includes) {
+		inc := m.Includes[name]
+		importPath, err := g.thriftImporter.Package(inc.Module.ThriftPath)
+		if err != nil {
+			return err
+		}
+
+		if inc.Name != inc.Module.Name {
+			names[importPath] = inc.Name
+			continue
+		}
+
+		pkgName, err := g.thriftImporter.PackageName(inc.Module.ThriftPath)
+		if err != nil {
+			return err
+		}
+		if pkgName != filepath.Base(importPath) {
+			names[importPath] = pkgName
+		}
 	}
+
+	g.importNames = names
+	g.importer.names = names
+	return nil
+}
+
+func (g *generator) jsonOptions() jsonOptions {
+	return g.json
+}
+
+func (g *generator) constantAccessors() bool {
+	return g.constAccessors
+}
+
+func (g *generator) arenaAllocation() bool {
+	return g.arena
+}
+
+func (g *generator) strictEnums() bool {
+	return g.strict
+}
+
+func (g *generator) iterators() bool {
+	return g.iter
+}
+
+func (g *generator) generics() bool {
+	return g.generic
+}
+
+func (g *generator) presenceMethods() bool {
+	return g.presence
+}
+
+func (g *generator) hashMethods() bool {
+	return g.hash
+}
+
+func (g *generator) compactCodegen() bool {
+	return g.compact
+}
+
+func (g *generator) keepUnknownFields() bool {
+	return g.unknown
+}
+
+func (g *generator) builderThreshold() int {
+	return g.builders
+}
+
+func (g *generator) nameMapper() nameMapper {
+	return g.names
 }
 
 func (g *generator) MangleType(t compile.TypeSpec) string {
 	return g.mangler.MangleType(t)
 }
 
+// substitution returns the substitution for the given type unless the type
+// is defined in the package being generated. Substitutions declared with
+// go.type on typedefs apply everywhere.
+func (g *generator) substitution(t compile.TypeSpec) (TypeSubstitution, bool) {
+	sub, ok := g.substitutions[t]
+	if !ok {
+		return TypeSubstitution{}, false
+	}
+	if sub.target != nil {
+		return sub, true
+	}
+
+	importPath, err := g.thriftImporter.Package(t.ThriftFile())
+	if err != nil || importPath == g.ImportPath {
+		return TypeSubstitution{}, false
+	}
+	return sub, true
+}
+
 func (g *generator) LookupTypeName(t compile.TypeSpec) (string, error) {
 	if t.ThriftFile() == "" {
 		return "", fmt.Errorf(
@@ -161,7 +282,7 @@ func (g *generator) LookupTypeName(t compile.TypeSpec) (string, error) {
 		return "", err
 	}
 
-	name, err := goName(t)
+	name, err := g.names.goName(t)
 	if err != nil {
 		return "", err
 	}
@@ -178,7 +299,7 @@ func (g *generator) LookupConstantName(c *compile.Constant) (string, error) {
 		return "", err
 	}
 
-	name := constantName(c.Name)
+	name := g.thriftImporter.NamePrefixes[c.File] + g.names.constantName(c.Name)
 	if importPath != g.ImportPath {
 		pkg := g.Import(importPath)
 		name = pkg + "." + name
@@ -189,12 +310,20 @@ func (g *generator) LookupConstantName(c *compile.Constant) (string, error) {
 // TextTemplate renders the given template with the given template context.
 func (g *generator) TextTemplate(s string, data interface{}, opts ...TemplateOption) (string, error) {
 	templateFuncs := template.FuncMap{
-		"goCase":           goCase,
-		"goName":           goName,
+		"doc":              docComment,
+		"goCase":           g.names.goCase,
+		"goName":           g.names.goName,
 		"import":           g.Import,
 		"isHashable":       isHashable,
 		"isPrimitiveType":  isPrimitiveType,
 		"isStructType":     isStructType,
+		"isValueField":     isValueField,
+		"valueFieldFlag":   valueFieldFlag,
+		"valueFieldIsSet":  curryGenerator(valueFieldIsSet, g),
+		"useArena":         curryGenerator(useArenaAllocation, g),
+		"arenaVar":         func() string { return arenaVarName },
+		"keepUnknown":      curryGenerator(useKeepUnknownFields, g),
+		"unknownFields":    func() string { return unknownFieldsName },
 		"newNamespace":     g.Namespace.Child,
 		"newVar":           g.Namespace.Child().NewName,
 		"typeName":         curryGenerator(typeName, g),
@@ -271,6 +400,10 @@ func (g *generator) recordGenDeclNames(d *ast.GenDecl) (conflict bool, err error
 	case token.VAR:
 		for _, spec := range d.Specs {
 			for _, name := range spec.(*ast.ValueSpec).Names {
+				if name.Name == "_" {
+					// Blank identifiers never conflict.
+					continue
+				}
 				if err := g.Reserve(name.Name); err != nil {
 					return true, fmt.Errorf(
 						"could not declare var %q: %v", name.Name, err,
@@ -303,6 +436,17 @@ func (g *generator) recordGenDeclNames(d *ast.GenDecl) (conflict bool, err error
 //
 // The following functions are available to templates:
 //
+// arenaVar(): Returns the name of the *arena.Arena parameter of functions
+// generated with arena allocation.
+//
+// doc(str): Renders documentation from the Thrift file as a comment. This
+// must be placed on the line right above the declaration, field, or constant
+// it documents. Other comments in templates are not copied into the
+// generated code.
+//
+// 	<doc .Spec.Doc>
+// 	type <.Name> struct {
+//
 // fromWire(TypeSpec, v): Returns an expression of type (T, error) where T is
 // the type represented by TypeSpec, read from the given Value v.
 //
@@ -358,6 +502,26 @@ func (g *generator) recordGenDeclNames(d *ast.GenDecl) (conflict bool, err error
 //
 // 	<typeReferencePtr $someType>
 //
+// isValueField(FieldSpec): Returns true if the given optional field is stored
+// as a value rather than a pointer because of a go.pointer annotation.
+//
+// valueFieldFlag(FieldSpec): Returns the name of the unexported struct field
+// recording that the given value field was explicitly set.
+//
+// valueFieldIsSet(v, FieldSpec): Returns an expression of type bool that
+// checks whether the given value field of the struct v is set.
+//
+// 	<if valueFieldIsSet $v $field>
+//
+// useArena(): Returns true if decoded values should be allocated from an
+// arena.Arena.
+//
+// keepUnknown(): Returns true if structs should retain the fields they do
+// not recognize when decoding.
+//
+// unknownFields(): Returns the name of the struct field holding the
+// unrecognized fields of a struct if keepUnknown is true.
+//
 // equals(TypeSpec, lhs, rhs): Returns an expression of type bool that
 // compares lhs and rhs of given TypeSpec for equality.
 //
@@ -373,9 +537,13 @@ func (g *generator) declare(ignoreConflicts bool, s string, data interface{}, op
 		return err
 	}
 
-	f, err := parser.ParseFile(token.NewFileSet(), "thriftrw.go", bs, 0)
+	f, err := parser.ParseFile(token.NewFileSet(), "thriftrw.go", bs, parser.ParseComments)
 	if err != nil {
-		return fmt.Errorf("could not parse generated code: %v:\n%s", err, bs)
+		snippet := string(bs)
+		if pos, ok := goast.ErrorPosition(err); ok {
+			snippet = goast.Snippet(bs, pos.Line, 3)
+		}
+		return fmt.Errorf("could not parse generated code: %v:\n%s", err, snippet)
 	}
 
 	for _, decl := range f.Decls {
@@ -409,6 +577,12 @@ func (g *generator) declare(ignoreConflicts bool, s string, data interface{}, op
 		default:
 			// No special behavior. Move along.
 		}
+		if docs := detachDocs(decl); docs != nil {
+			if g.docs == nil {
+				g.docs = make(map[ast.Decl][]string)
+			}
+			g.docs[decl] = docs
+		}
 		g.appendDecl(decl)
 	}
 
@@ -454,7 +628,7 @@ func (g *generator) Write(w io.Writer, fs *token.FileSet) error {
 			return err
 		}
 
-		if err := cfg.Fprint(w, fs, decl); err != nil {
+		if err := g.writeDecl(w, cfg, fs, decl); err != nil {
 			return err
 		}
 
@@ -464,7 +638,9 @@ func (g *generator) Write(w io.Writer, fs *token.FileSet) error {
 	}
 
 	g.decls = nil
+	g.docs = nil
 	g.importer = newImporter(g.Namespace.Child())
+	g.importer.names = g.importNames
 
 	// init can appear multiple times in the same package across different
 	// files
@@ -472,6 +648,26 @@ func (g *generator) Write(w io.Writer, fs *token.FileSet) error {
 	return nil
 }
 
+// writeDecl prints the given declaration along with its doc comments.
+func (g *generator) writeDecl(w io.Writer, cfg printer.Config, fs *token.FileSet, decl ast.Decl) error {
+	docs, ok := g.docs[decl]
+	if !ok {
+		return cfg.Fprint(w, fs, decl)
+	}
+
+	var buff bytes.Buffer
+	if err := cfg.Fprint(&buff, fs, decl); err != nil {
+		return err
+	}
+
+	out, err := insertDocs(buff.Bytes(), docs)
+	if err != nil {
+		return fmt.Errorf("could not add doc comments: %v", err)
+	}
+	_, err = w.Write(out)
+	return err
+}
+
 // appendDecl appends a new declaration to the generator.
 func (g *generator) appendDecl(decl ast.Decl) {
 	g.decls = append(g.decls, decl)
@@ -0,0 +1,42 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+// genericsGoMinorVersion is the minor version of the first release of Go 1
+// with type parameters.
+const genericsGoMinorVersion = 18
+
+// genericsGenerator is implemented by Generators which may generate code
+// that uses type parameters.
+type genericsGenerator interface {
+	generics() bool
+}
+
+// useGenerics returns true if lists, sets, and maps should be converted to
+// and from their wire representations with the generic helpers of the
+// thriftlist, thriftset, and thriftmap packages instead of generating the
+// conversion code for every container type.
+func useGenerics(g Generator) bool {
+	if o, ok := g.(genericsGenerator); ok {
+		return o.generics()
+	}
+	return false
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package gen
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	tg "go.uber.org/thriftrw/gen/testdata/features/generics/records"
+	tp "go.uber.org/thriftrw/gen/testdata/features/plain/records"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/ptr"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeError returns the error encountered while encoding x with the Binary
+// protocol, if any. Containers are encoded lazily so errors about their
+// items surface from Encode rather than ToWire.
+func encodeError(x thriftType) error {
+	w, err := x.ToWire()
+	if err != nil {
+		return err
+	}
+	return protocol.Binary.Encode(w, new(bytes.Buffer))
+}
+
+func TestGenericContainersUseHelpers(t *testing.T) {
+	contents, err := ioutil.ReadFile("testdata/features/generics/records/types.go")
+	require.NoError(t, err)
+	for _, pkg := range []string{"thriftlist", "thriftset", "thriftmap"} {
+		assert.Contains(t, string(contents), `"go.uber.org/thriftrw/`+pkg+`"`)
+	}
+}
+
+func TestGenericContainersRoundTrip(t *testing.T) {
+	tests := []struct {
+		desc     string
+		give     thriftType
+		generics thriftType
+		plain    thriftType
+	}{
+		{
+			desc: "struct containers",
+			give: &tp.Shapes{
+				Points: []*tp.Point{{X: 1}, {Y: 2}},
+				Names:  map[string]struct{}{"a": {}, "b": {}},
+				Blobs:  [][]byte{{1}, {}, {2, 3}},
+				ByName: map[string]*tp.Point{"origin": {}, "one": {X: 1, Y: 1}},
+				Counts: []struct {
+					Key   *tp.Point
+					Value int32
+				}{{Key: &tp.Point{X: 1}, Value: 2}, {Key: &tp.Point{Y: 1}, Value: 3}},
+				Grid: [][]int32{{1, 2}, {}, {3}},
+			},
+			generics: &tg.Shapes{},
+			plain:    &tp.Shapes{},
+		},
+		{
+			desc:     "empty containers",
+			give:     &tp.Shapes{Points: []*tp.Point{}, Names: map[string]struct{}{}, Grid: [][]int32{}},
+			generics: &tg.Shapes{},
+			plain:    &tp.Shapes{},
+		},
+		{
+			desc: "primitive containers",
+			give: &tp.User{
+				Name:   "alice",
+				Tags:   []string{"a", "b"},
+				Ids:    map[int64]struct{}{1: {}, 2: {}},
+				Places: map[string]*tp.Point{"home": {X: 1}},
+				Active: ptr.Bool(true),
+			},
+			generics: &tg.User{},
+			plain:    &tp.User{},
+		},
+		{
+			desc:     "nested containers",
+			give:     newerUser(),
+			generics: &tg.UserV2{},
+			plain:    &tp.UserV2{},
+		},
+		{
+			desc:     "typedef of set",
+			give:     &tp.Tags{"a": {}, "b": {}},
+			generics: new(tg.Tags),
+			plain:    new(tp.Tags),
+		},
+		{
+			desc:     "typedef of set of structs",
+			give:     &tp.Points{{X: 1}, {X: 2}},
+			generics: new(tg.Points),
+			plain:    new(tp.Points),
+		},
+		{
+			desc: "typedef of map with struct keys",
+			give: &tp.Labels{
+				{Key: &tp.Point{X: 1}, Value: "one"},
+				{Key: &tp.Point{X: 2}, Value: "two"},
+			},
+			generics: new(tg.Labels),
+			plain:    new(tp.Labels),
+		},
+		{
+			desc:     "typedef of list",
+			give:     &tp.Names{"a", "a", "b"},
+			generics: new(tg.Names),
+			plain:    new(tp.Names),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			require.NoError(t, transcode(t, tt.give, tt.generics), "decode with generic containers failed")
+			require.NoError(t, transcode(t, tt.generics, tt.plain), "encode with generic containers failed")
+			assert.Equal(t, tt.give, tt.plain)
+		})
+	}
+}
+
+func TestGenericContainersNil(t *testing.T) {
+	tests := []struct {
+		desc     string
+		plain    thriftType
+		generics thriftType
+	}{
+		{
+			desc:     "list item",
+			plain:    &tp.Shapes{Points: []*tp.Point{{}, nil}},
+			generics: &tg.Shapes{Points: []*tg.Point{{}, nil}},
+		},
+		{
+			desc:     "set item",
+			plain:    &tp.Points{nil},
+			generics: &tg.Points{nil},
+		},
+		{
+			desc:     "map key",
+			plain:    &tp.Labels{{Key: nil, Value: "foo"}},
+			generics: &tg.Labels{{Key: nil, Value: "foo"}},
+		},
+		{
+			desc:     "map value",
+			plain:    &tp.Shapes{ByName: map[string]*tp.Point{"foo": nil}},
+			generics: &tg.Shapes{ByName: map[string]*tg.Point{"foo": nil}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			want := encodeError(tt.plain)
+			require.Error(t, want)
+
+			err := encodeError(tt.generics)
+			require.Error(t, err)
+			assert.Equal(t, want.Error(), err.Error())
+		})
+	}
+}
+
+func TestGenericContainersInvalid(t *testing.T) {
+	shapes := func(fields ...wire.Field) wire.Value {
+		return wire.NewValueStruct(wire.Struct{Fields: fields})
+	}
+	list := func(typ wire.Type, items ...wire.Value) wire.Value {
+		return wire.NewValueList(wire.ValueListFromSlice(typ, items))
+	}
+
+	tests := []struct {
+		desc string
+		give wire.Value
+	}{
+		{
+			desc: "mismatched list item type",
+			give: shapes(wire.Field{ID: 1, Value: list(wire.TI32, wire.NewValueI32(1))}),
+		},
+		{
+			desc: "mismatched set item type",
+			give: shapes(wire.Field{ID: 2, Value: wire.NewValueSet(
+				wire.ValueListFromSlice(wire.TI32, []wire.Value{wire.NewValueI32(1)}),
+			)}),
+		},
+		{
+			desc: "mismatched map key type",
+			give: shapes(wire.Field{ID: 4, Value: wire.NewValueMap(wire.MapItemListFromSlice(
+				wire.TI32, wire.TStruct,
+				[]wire.MapItem{{Key: wire.NewValueI32(1), Value: shapes()}},
+			))}),
+		},
+		{
+			desc: "invalid list item",
+			give: shapes(wire.Field{ID: 1, Value: list(wire.TStruct, shapes())}),
+		},
+		{
+			desc: "invalid map key",
+			give: shapes(wire.Field{ID: 5, Value: wire.NewValueMap(wire.MapItemListFromSlice(
+				wire.TStruct, wire.TI32,
+				[]wire.MapItem{{Key: shapes(), Value: wire.NewValueI32(1)}},
+			))}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var want tp.Shapes
+			wantErr := want.FromWire(tt.give)
+
+			var got tg.Shapes
+			err := got.FromWire(tt.give)
+			if wantErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, wantErr.Error(), err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			var roundTrip tp.Shapes
+			require.NoError(t, transcode(t, &got, &roundTrip))
+			assert.Equal(t, want, roundTrip)
+		})
+	}
+}
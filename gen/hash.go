@@ -0,0 +1,117 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import "fmt"
+
+const goHashAnnotation = "go.hash"
+
+// hashGenerator is implemented by Generators which may generate Hash
+// methods for structs.
+type hashGenerator interface {
+	hashMethods() bool
+}
+
+// useHashMethods returns true if Hash methods should be generated for
+// structs.
+func useHashMethods(g Generator) bool {
+	if o, ok := g.(hashGenerator); ok {
+		return o.hashMethods()
+	}
+	return false
+}
+
+// hashExcludedFields returns the IDs of the fields of the field group which
+// are annotated with go.hash = "false".
+//
+// 	3: optional i64 lastSeen (go.hash = "false")
+func (f fieldGroupGenerator) hashExcludedFields() ([]int16, error) {
+	var ids []int16
+	for _, field := range f.Fields {
+		value, ok := field.Annotations[goHashAnnotation]
+		if !ok {
+			continue
+		}
+
+		switch value {
+		case "true":
+		case "false":
+			ids = append(ids, field.ID)
+		default:
+			return nil, fmt.Errorf(
+				"invalid go.hash annotation %q on field %q: expected true or false", value, field.Name)
+		}
+	}
+	return ids, nil
+}
+
+// Hash generates a Hash method for the field group if hash methods were
+// requested.
+//
+// 	func (v *User) Hash() (uint64, error)
+//
+// The hash is derived from the wire representation of the struct, leaving
+// out fields annotated with go.hash = "false". An error is returned if the
+// name of the method conflicts with a field.
+func (f fieldGroupGenerator) Hash(g Generator) error {
+	excluded, err := f.hashExcludedFields()
+	if err != nil {
+		return err
+	}
+
+	if !useHashMethods(g) {
+		return nil
+	}
+
+	if err := f.Reserve("Hash"); err != nil {
+		return fmt.Errorf(
+			"cannot generate Hash for %q: the name is already used by the struct; "+
+				"rename the field with the go.name annotation", f.Name)
+	}
+
+	return g.DeclareFromTemplate(
+		`
+		<$wire := import "go.uber.org/thriftrw/wire">
+		<$v := newVar "v">
+		<$w := newVar "w">
+		// Hash returns a 64-bit hash of this <.Name>.
+		//
+		// Values which are equal have the same hash, so the hash may be used to
+		// deduplicate values or to store them in hash-based containers. Hash
+		// returns 0 if the <.Name> is nil and an error if it cannot be
+		// serialized.
+		func (<$v> *<.Name>) Hash() (uint64, error) {
+			if <$v> == nil {
+				return 0, nil
+			}
+
+			<$w>, err := <$v>.ToWire()
+			if err != nil {
+				return 0, err
+			}
+			return <$wire>.HashStruct(<$w>.GetStruct()<range .Excluded>, <.><end>), nil
+		}
+		`,
+		struct {
+			Name     string
+			Excluded []int16
+		}{Name: f.Name, Excluded: excluded})
+}
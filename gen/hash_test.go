@@ -0,0 +1,182 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+	tp "go.uber.org/thriftrw/gen/testdata/features/plain/records"
+	tk "go.uber.org/thriftrw/gen/testdata/features/unknown/records"
+	tkc "go.uber.org/thriftrw/gen/testdata/features/unknowncompact/records"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/ptr"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hashable is implemented by types generated with --hash-methods.
+type hashable interface {
+	thriftType
+
+	Hash() (uint64, error)
+}
+
+func hashOf(t *testing.T, x hashable) uint64 {
+	h, err := x.Hash()
+	require.NoError(t, err, "failed to hash %v", x)
+	return h
+}
+
+func TestHashMethods(t *testing.T) {
+	t.Run("sets in different order", func(t *testing.T) {
+		left := &tk.User{Name: "alice", Ids: map[int64]struct{}{1: {}, 2: {}, 3: {}}}
+		right := &tk.User{Name: "alice", Ids: map[int64]struct{}{3: {}, 1: {}, 2: {}}}
+		assert.True(t, left.Equals(right))
+		assert.Equal(t, hashOf(t, left), hashOf(t, right))
+	})
+
+	t.Run("different fields", func(t *testing.T) {
+		left := &tk.User{Name: "alice", Age: ptr.Int32(30)}
+		right := &tk.User{Name: "alice", Age: ptr.Int32(31)}
+		assert.False(t, left.Equals(right))
+		assert.NotEqual(t, hashOf(t, left), hashOf(t, right))
+	})
+
+	t.Run("excluded field", func(t *testing.T) {
+		left := &tk.Session{ID: "a", LastSeen: ptr.Int64(1)}
+		right := &tk.Session{ID: "a", LastSeen: ptr.Int64(2)}
+		assert.Equal(t, hashOf(t, left), hashOf(t, right))
+
+		right.ID = "b"
+		assert.NotEqual(t, hashOf(t, left), hashOf(t, right))
+	})
+
+	t.Run("compact", func(t *testing.T) {
+		left := &tk.User{Name: "alice", Tags: []string{"a", "b"}}
+		right := &tkc.User{Name: "alice", Tags: []string{"a", "b"}}
+		assert.Equal(t, hashOf(t, left), hashOf(t, right))
+	})
+}
+
+func TestHashMethodsUnknownFields(t *testing.T) {
+	decodeUser := func(v2 *tp.UserV2) *tk.User {
+		w, err := protocol.Binary.Decode(bytes.NewReader(encodeBinary(t, v2)), wire.TStruct)
+		require.NoError(t, err)
+
+		var u tk.User
+		require.NoError(t, u.FromWire(w))
+		return &u
+	}
+
+	// Values which are Equals must have the same hash, including the fields
+	// they do not know about.
+	left, right := decodeUser(newerUser()), decodeUser(newerUser())
+	assert.True(t, left.Equals(right))
+	assert.Equal(t, hashOf(t, left), hashOf(t, right))
+
+	other := newerUser()
+	other.Nickname = ptr.String("ally")
+	right = decodeUser(other)
+	assert.False(t, left.Equals(right), "values with different unknown fields are not equal")
+	assert.NotEqual(t, hashOf(t, left), hashOf(t, right))
+
+	right = decodeUser(&tp.UserV2{Name: "alice"})
+	right.Age = left.Age
+	right.Avatar = left.Avatar
+	right.Tags = left.Tags
+	right.Places = left.Places
+	right.Ids = left.Ids
+	assert.False(t, left.Equals(right), "values without the unknown fields are not equal")
+	assert.NotEqual(t, hashOf(t, left), hashOf(t, right))
+}
+
+func TestHashMethodsInvalid(t *testing.T) {
+	var nilUser *tk.User
+	assert.Equal(t, uint64(0), hashOf(t, nilUser))
+
+	_, err := (&tk.Shape{}).Hash()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Shape should have exactly one field: got 0 fields")
+	}
+}
+
+func TestHashMethodsConflict(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-hash-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	thriftFile := filepath.Join(dir, "main.thrift")
+	require.NoError(t, ioutil.WriteFile(thriftFile, []byte(`
+		struct Digest {
+			1: optional binary hash
+		}
+	`), 0644))
+
+	module, err := compile.Compile(thriftFile)
+	require.NoError(t, err)
+
+	opts := Options{
+		OutputDir:     filepath.Join(dir, "out"),
+		PackagePrefix: "example.com/out",
+		ThriftRoot:    dir,
+	}
+	require.NoError(t, Generate(module, &opts), "hash methods are not generated by default")
+
+	opts.HashMethods = true
+	err = Generate(module, &opts)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(),
+			`cannot generate Hash for "Digest": the name is already used by the struct`)
+	}
+}
+
+func TestHashAnnotationInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-hash-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	thriftFile := filepath.Join(dir, "main.thrift")
+	require.NoError(t, ioutil.WriteFile(thriftFile, []byte(`
+		struct User {
+			1: optional i64 lastSeen (go.hash = "no")
+		}
+	`), 0644))
+
+	module, err := compile.Compile(thriftFile)
+	require.NoError(t, err)
+
+	err = Generate(module, &Options{
+		OutputDir:     filepath.Join(dir, "out"),
+		PackagePrefix: "example.com/out",
+		ThriftRoot:    dir,
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(),
+			`invalid go.hash annotation "no" on field "lastSeen": expected true or false`)
+	}
+}
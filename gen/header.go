@@ -0,0 +1,113 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"go.uber.org/thriftrw/version"
+)
+
+// HeaderData is the data available to the template specified in
+// Options.Header.
+//
+// 	// Copyright (c) {{.Year}} Example, Inc.
+// 	// Generated from {{.ThriftFile}} by thriftrw v{{.Version}}.
+type HeaderData struct {
+	// Year in which the file was generated.
+	Year int
+
+	// Path to the Thrift file from which the file was generated, relative to
+	// the ThriftRoot.
+	ThriftFile string
+
+	// Version of ThriftRW that generated the file.
+	Version string
+}
+
+// fileHeader renders the header prepended to generated Go files.
+type fileHeader struct {
+	tmpl *template.Template
+	year int
+}
+
+func newFileHeader(s string) (*fileHeader, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	tmpl, err := template.New("header").Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid header template: %v", err)
+	}
+
+	// Render the template once so that references to unknown fields are
+	// reported before any code is generated.
+	h := &fileHeader{tmpl: tmpl, year: time.Now().Year()}
+	if _, err := h.Render(""); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Render renders the header for files generated from the given Thrift file.
+//
+// The returned header is always followed by a blank line so that it does not
+// become the package documentation.
+func (h *fileHeader) Render(thriftFile string) ([]byte, error) {
+	var buff bytes.Buffer
+	err := h.tmpl.Execute(&buff, HeaderData{
+		Year:       h.year,
+		ThriftFile: filepath.ToSlash(thriftFile),
+		Version:    version.Version,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not render header: %v", err)
+	}
+
+	header := strings.TrimRight(buff.String(), "\n")
+	if header == "" {
+		return nil, nil
+	}
+	return []byte(header + "\n\n"), nil
+}
+
+// Prepend adds the header for the given Thrift file to all Go files in the
+// given mapping of file names to their contents.
+func (h *fileHeader) Prepend(files map[string][]byte, thriftFile string) error {
+	header, err := h.Render(thriftFile)
+	if err != nil {
+		return err
+	}
+
+	for path, contents := range files {
+		if filepath.Ext(path) != ".go" {
+			continue
+		}
+		files[path] = append(append([]byte(nil), header...), contents...)
+	}
+	return nil
+}
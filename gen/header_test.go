@@ -0,0 +1,137 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/version"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileHeader(t *testing.T) {
+	tests := []struct {
+		desc       string
+		give       string
+		thriftFile string
+		want       string
+		wantErr    string
+	}{
+		{
+			desc: "empty",
+			give: "",
+		},
+		{
+			desc: "blank",
+			give: "\n\n",
+			want: "",
+		},
+		{
+			desc:       "variables",
+			give:       "// (c) {{.Year}}\n// {{.ThriftFile}} v{{.Version}}\n",
+			thriftFile: "foo/bar.thrift",
+			want: fmt.Sprintf("// (c) %d\n// foo/bar.thrift v%s\n\n",
+				time.Now().Year(), version.Version),
+		},
+		{
+			desc:    "parse error",
+			give:    "// {{.Year",
+			wantErr: "invalid header template",
+		},
+		{
+			desc:    "unknown variable",
+			give:    "// {{.Author}}",
+			wantErr: "could not render header",
+		},
+	}
+
+	for _, tt := range tests {
+		h, err := newFileHeader(tt.give)
+		if tt.wantErr != "" {
+			if assert.Error(t, err, tt.desc) {
+				assert.Contains(t, err.Error(), tt.wantErr, tt.desc)
+			}
+			continue
+		}
+		require.NoError(t, err, tt.desc)
+		if tt.give == "" {
+			assert.Nil(t, h, tt.desc)
+			continue
+		}
+
+		got, err := h.Render(tt.thriftFile)
+		require.NoError(t, err, tt.desc)
+		assert.Equal(t, tt.want, string(got), tt.desc)
+	}
+}
+
+func TestGenerateHeader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-header-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for path, contents := range map[string]string{
+		"shared/shared.thrift": `typedef string UUID`,
+		"main.thrift": `
+			include "./shared/shared.thrift"
+
+			struct User { 1: required shared.UUID id }
+		`,
+	} {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+
+	module, err := compile.Compile(filepath.Join(dir, "main.thrift"))
+	require.NoError(t, err)
+
+	outputDir := filepath.Join(dir, "out")
+	require.NoError(t, Generate(module, &Options{
+		OutputDir:     outputDir,
+		PackagePrefix: "example.com/out",
+		ThriftRoot:    dir,
+		Header:        "// Source: {{.ThriftFile}}\n",
+	}))
+
+	for file, thriftFile := range map[string]string{
+		"main/types.go":                 "main.thrift",
+		"main/versioncheck.go":          "main.thrift",
+		"shared/shared/types.go":        "shared/shared.thrift",
+		"shared/shared/versioncheck.go": "shared/shared.thrift",
+	} {
+		contents, err := ioutil.ReadFile(filepath.Join(outputDir, file))
+		require.NoError(t, err, file)
+
+		want := "// Source: " + thriftFile + "\n\n// Code generated by thriftrw"
+		assert.True(t, strings.HasPrefix(string(contents), want),
+			"%v must start with %q:\n%s", file, want, contents)
+	}
+}
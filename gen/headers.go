@@ -0,0 +1,401 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+const (
+	_headersAnnotation       = "headers"
+	_headersAnnotationPrefix = "headers."
+)
+
+// headerTypes lists the types accepted by typed header accessors.
+var headerTypes = map[string]struct{}{
+	"string": {},
+	"binary": {},
+	"bool":   {},
+	"i32":    {},
+	"i64":    {},
+	"double": {},
+}
+
+// headerAccessor is a typed accessor for a single key of a headers field.
+type headerAccessor struct {
+	Name string // Go name of the accessor, without the Get or Set prefix
+	Key  string // Key in the headers map
+	Type string // One of headerTypes
+}
+
+// headersField is a field annotated with (headers = "true").
+type headersField struct {
+	Name   string // Go name of the field
+	Type   string // Go type of the field
+	Binary bool   // Whether the values of the map are binary
+
+	// Go type of the values of the map
+	ValueType string
+
+	Accessors []headerAccessor
+}
+
+// headersAnnotation parses the headers annotations of the given field. It
+// returns nil if the field is not a headers field.
+//
+// 	1: optional map<string, binary> headers (
+// 		headers = "true",
+// 		headers.Deadline = "x-deadline:i64",
+// 	)
+//
+// Typed accessors are specified by headers.$name annotations whose values
+// are $key:$type, where the type defaults to that of the map's values.
+func headersAnnotation(f *compile.FieldSpec) (*headersField, error) {
+	value, ok := f.Annotations[_headersAnnotation]
+	if ok && value != "true" && value != "false" {
+		return nil, fmt.Errorf(
+			"invalid headers annotation %q on field %q: expected true or false", value, f.Name)
+	}
+
+	enabled := value == "true"
+	var accessors []headerAccessor
+	for _, key := range sortStringKeys(f.Annotations) {
+		if !strings.HasPrefix(key, _headersAnnotationPrefix) {
+			continue
+		}
+		if !enabled {
+			return nil, fmt.Errorf(
+				"invalid %v annotation: field %q is not annotated with "+
+					`(headers = "true")`, key, f.Name)
+		}
+
+		a, err := parseHeaderAccessor(strings.TrimPrefix(key, _headersAnnotationPrefix), f.Annotations[key])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v annotation on field %q: %v", key, f.Name, err)
+		}
+		accessors = append(accessors, a)
+	}
+
+	if !enabled {
+		return nil, nil
+	}
+
+	mapSpec, ok := compile.RootTypeSpec(f.Type).(*compile.MapSpec)
+	if !ok {
+		return nil, headersTypeError{Field: f.Name}
+	}
+	if _, ok := mapSpec.KeySpec.(*compile.StringSpec); !ok {
+		return nil, headersTypeError{Field: f.Name}
+	}
+
+	var binary bool
+	switch mapSpec.ValueSpec.(type) {
+	case *compile.StringSpec:
+	case *compile.BinarySpec:
+		binary = true
+	default:
+		return nil, headersTypeError{Field: f.Name}
+	}
+
+	for i, a := range accessors {
+		if a.Type != "" {
+			continue
+		}
+		if binary {
+			accessors[i].Type = "binary"
+		} else {
+			accessors[i].Type = "string"
+		}
+	}
+
+	valueType := "string"
+	if binary {
+		valueType = "[]byte"
+	}
+	return &headersField{Binary: binary, ValueType: valueType, Accessors: accessors}, nil
+}
+
+// parseHeaderAccessor parses the name and $key:$type value of a typed
+// header accessor.
+func parseHeaderAccessor(name, value string) (headerAccessor, error) {
+	c, _ := utf8.DecodeRuneInString(name)
+	if !unicode.IsLetter(c) || !unicode.IsUpper(c) || strings.IndexFunc(name, invalidIdentifierRune) >= 0 {
+		return headerAccessor{}, fmt.Errorf("%q is not a Go style public identifier", name)
+	}
+
+	a := headerAccessor{Name: name, Key: value}
+	if i := strings.LastIndex(value, ":"); i >= 0 {
+		a.Key, a.Type = value[:i], value[i+1:]
+		if _, ok := headerTypes[a.Type]; !ok {
+			return a, fmt.Errorf(
+				"unknown type %q: expected string, binary, bool, i32, i64, or double", a.Type)
+		}
+	}
+	if a.Key == "" {
+		return a, fmt.Errorf("header key for %q is empty", name)
+	}
+	return a, nil
+}
+
+func invalidIdentifierRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+type headersTypeError struct {
+	Field string
+}
+
+func (e headersTypeError) Error() string {
+	return fmt.Sprintf(
+		"invalid headers annotation: field %q must be a map<string, binary> or a map<string, string>",
+		e.Field)
+}
+
+// Headers generates accessors for the headers field of the field group, if
+// any.
+//
+// 	func (v *Request) GetHeader(key string) ([]byte, bool)
+// 	func (v *Request) SetHeader(key string, value []byte)
+// 	func (v *Request) DeleteHeader(key string)
+//
+// Typed accessors are generated for headers.$name annotations.
+//
+// 	func (v *Request) GetDeadline() (int64, bool, error)
+// 	func (v *Request) SetDeadline(x int64)
+func (f fieldGroupGenerator) Headers(g Generator) error {
+	var (
+		headers     *headersField
+		headersSpec *compile.FieldSpec
+	)
+	for _, field := range f.Fields {
+		h, err := headersAnnotation(field)
+		if err != nil {
+			return err
+		}
+		if h == nil {
+			continue
+		}
+		if headers != nil {
+			return fmt.Errorf(
+				"fields %q and %q are both annotated with headers: "+
+					"only one headers field is allowed per struct", headersSpec.Name, field.Name)
+		}
+
+		if _, ok := lookupSubstitution(g, field.Type); ok {
+			return fmt.Errorf(
+				"invalid headers annotation: field %q has a substituted type", field.Name)
+		}
+
+		h.Name, err = goNames(g).goName(field)
+		if err != nil {
+			return err
+		}
+		h.Type, err = typeReference(g, field.Type)
+		if err != nil {
+			return err
+		}
+		headers, headersSpec = h, field
+	}
+
+	if headers == nil {
+		return nil
+	}
+
+	methods := []string{"GetHeader", "SetHeader", "DeleteHeader"}
+	for _, a := range headers.Accessors {
+		methods = append(methods, "Get"+a.Name, "Set"+a.Name)
+	}
+	sort.Strings(methods)
+	for _, m := range methods {
+		if err := f.Reserve(m); err != nil {
+			return fmt.Errorf(
+				"cannot generate header accessor %q for field %q: "+
+					"the name is already used by the struct", m, headers.Name)
+		}
+	}
+
+	return g.DeclareFromTemplate(
+		`
+		<$v := newVar "v">
+		<$key := newVar "key">
+		<$value := newVar "value">
+		<$ok := newVar "ok">
+		<$x := newVar "x">
+		<$err := newVar "err">
+		<$valueType := .Headers.ValueType>
+		func (<$v> *<.Name>) GetHeader(<$key> string) (<$value> <$valueType>, <$ok> bool) {
+			if <$v> != nil {
+				<$value>, <$ok> = <$v>.<.Headers.Name>[<$key>]
+			}
+			return
+		}
+
+		func (<$v> *<.Name>) SetHeader(<$key> string, <$value> <$valueType>) {
+			if <$v>.<.Headers.Name> == nil {
+				<$v>.<.Headers.Name> = make(<.Headers.Type>)
+			}
+			<$v>.<.Headers.Name>[<$key>] = <$value>
+		}
+
+		func (<$v> *<.Name>) DeleteHeader(<$key> string) {
+			delete(<$v>.<.Headers.Name>, <$key>)
+		}
+
+		<range .Headers.Accessors>
+			<if isParsedHeader .>
+				func (<$v> *<$.Name>) Get<.Name>() (<headerGoType .>, bool, error) {
+					<$value>, <$ok> := <$v>.GetHeader(<printf "%q" .Key>)
+					if !<$ok> {
+						return <headerZero .>, false, nil
+					}
+					<$x>, <$err> := <parseHeader . $value>
+					return <castHeader . $x>, true, <$err>
+				}
+			<else>
+				func (<$v> *<$.Name>) Get<.Name>() (<headerGoType .>, bool) {
+					<$value>, <$ok> := <$v>.GetHeader(<printf "%q" .Key>)
+					return <convertHeader . $value>, <$ok>
+				}
+			<end>
+
+			func (<$v> *<$.Name>) Set<.Name>(<$x> <headerGoType .>) {
+				<$v>.SetHeader(<printf "%q" .Key>, <formatHeader . $x>)
+			}
+		<end>
+		`,
+		struct {
+			Name    string
+			Headers *headersField
+		}{Name: f.Name, Headers: headers},
+		TemplateFunc("isParsedHeader", func(a headerAccessor) bool {
+			return a.Type != "string" && a.Type != "binary"
+		}),
+		TemplateFunc("headerGoType", headerGoType),
+		TemplateFunc("headerZero", func(a headerAccessor) string {
+			if a.Type == "bool" {
+				return "false"
+			}
+			return "0"
+		}),
+		TemplateFunc("parseHeader", func(a headerAccessor, value string) string {
+			if headers.Binary {
+				value = fmt.Sprintf("string(%s)", value)
+			}
+			return parseHeaderExpr(g.Import("strconv"), a, value)
+		}),
+		TemplateFunc("castHeader", func(a headerAccessor, x string) string {
+			if a.Type == "i32" {
+				return fmt.Sprintf("int32(%s)", x)
+			}
+			return x
+		}),
+		TemplateFunc("convertHeader", func(a headerAccessor, value string) string {
+			return convertHeaderExpr(headers.Binary, a, value)
+		}),
+		TemplateFunc("formatHeader", func(a headerAccessor, x string) string {
+			return formatHeaderExpr(g.Import("strconv"), headers.Binary, a, x)
+		}),
+	)
+}
+
+// headerGoType returns the Go type used by the given typed accessor.
+func headerGoType(a headerAccessor) string {
+	switch a.Type {
+	case "binary":
+		return "[]byte"
+	case "bool":
+		return "bool"
+	case "i32":
+		return "int32"
+	case "i64":
+		return "int64"
+	case "double":
+		return "float64"
+	default:
+		return "string"
+	}
+}
+
+// parseHeaderExpr returns an expression which parses the string value of a
+// header for an accessor, returning the value and an error. i32 values are
+// parsed into an int64 which must be converted.
+func parseHeaderExpr(strconv string, a headerAccessor, value string) string {
+	switch a.Type {
+	case "bool":
+		return fmt.Sprintf("%s.ParseBool(%s)", strconv, value)
+	case "i32":
+		return fmt.Sprintf("%s.ParseInt(%s, 10, 32)", strconv, value)
+	case "i64":
+		return fmt.Sprintf("%s.ParseInt(%s, 10, 64)", strconv, value)
+	case "double":
+		return fmt.Sprintf("%s.ParseFloat(%s, 64)", strconv, value)
+	default:
+		panic(fmt.Sprintf("header type %q is not parsed", a.Type))
+	}
+}
+
+// convertHeaderExpr converts the value of a header to a string or binary
+// accessor's type.
+func convertHeaderExpr(binary bool, a headerAccessor, value string) string {
+	switch {
+	case binary && a.Type == "string":
+		return fmt.Sprintf("string(%s)", value)
+	case !binary && a.Type == "binary":
+		return fmt.Sprintf("[]byte(%s)", value)
+	default:
+		return value
+	}
+}
+
+// formatHeaderExpr returns an expression which formats x, a value of an
+// accessor's type, into the value type of the headers map.
+func formatHeaderExpr(strconv string, binary bool, a headerAccessor, x string) string {
+	var s string
+	switch a.Type {
+	case "string", "binary":
+		if binary == (a.Type == "binary") {
+			return x
+		}
+		if binary {
+			return fmt.Sprintf("[]byte(%s)", x)
+		}
+		return fmt.Sprintf("string(%s)", x)
+	case "bool":
+		s = fmt.Sprintf("%s.FormatBool(%s)", strconv, x)
+	case "i32":
+		s = fmt.Sprintf("%s.FormatInt(int64(%s), 10)", strconv, x)
+	case "i64":
+		s = fmt.Sprintf("%s.FormatInt(%s, 10)", strconv, x)
+	case "double":
+		s = fmt.Sprintf("%s.FormatFloat(%s, 'g', -1, 64)", strconv, x)
+	}
+
+	if binary {
+		return fmt.Sprintf("[]byte(%s)", s)
+	}
+	return s
+}
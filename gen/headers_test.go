@@ -0,0 +1,289 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+	ts "go.uber.org/thriftrw/gen/testdata/structs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadersAnnotation(t *testing.T) {
+	binaryMap := &compile.MapSpec{KeySpec: &compile.StringSpec{}, ValueSpec: &compile.BinarySpec{}}
+	stringMap := &compile.MapSpec{KeySpec: &compile.StringSpec{}, ValueSpec: &compile.StringSpec{}}
+
+	tests := []struct {
+		desc        string
+		typ         compile.TypeSpec
+		annotations compile.Annotations
+		want        *headersField
+		wantError   string
+	}{
+		{desc: "not annotated", typ: binaryMap},
+		{
+			desc:        "disabled",
+			typ:         binaryMap,
+			annotations: compile.Annotations{"headers": "false"},
+		},
+		{
+			desc:        "binary",
+			typ:         binaryMap,
+			annotations: compile.Annotations{"headers": "true"},
+			want:        &headersField{Binary: true, ValueType: "[]byte"},
+		},
+		{
+			desc: "typed accessors",
+			typ:  stringMap,
+			annotations: compile.Annotations{
+				"headers":          "true",
+				"headers.Deadline": "x-deadline:i64",
+				"headers.Caller":   "x-caller",
+				"headers.Port":     "host:port:i32",
+			},
+			want: &headersField{
+				ValueType: "string",
+				Accessors: []headerAccessor{
+					{Name: "Caller", Key: "x-caller", Type: "string"},
+					{Name: "Deadline", Key: "x-deadline", Type: "i64"},
+					{Name: "Port", Key: "host:port", Type: "i32"},
+				},
+			},
+		},
+		{
+			desc:        "invalid value",
+			typ:         binaryMap,
+			annotations: compile.Annotations{"headers": "yes"},
+			wantError:   `invalid headers annotation "yes" on field "foo": expected true or false`,
+		},
+		{
+			desc:        "accessor without headers",
+			typ:         binaryMap,
+			annotations: compile.Annotations{"headers.Deadline": "x-deadline"},
+			wantError:   `invalid headers.Deadline annotation: field "foo" is not annotated with (headers = "true")`,
+		},
+		{
+			desc:        "unknown type",
+			typ:         binaryMap,
+			annotations: compile.Annotations{"headers": "true", "headers.Deadline": "x-deadline:duration"},
+			wantError:   `invalid headers.Deadline annotation on field "foo": unknown type "duration"`,
+		},
+		{
+			desc:        "lowercase accessor",
+			typ:         binaryMap,
+			annotations: compile.Annotations{"headers": "true", "headers.deadline": "x-deadline"},
+			wantError:   `"deadline" is not a Go style public identifier`,
+		},
+		{
+			desc:        "empty key",
+			typ:         binaryMap,
+			annotations: compile.Annotations{"headers": "true", "headers.Deadline": ":i64"},
+			wantError:   `header key for "Deadline" is empty`,
+		},
+		{
+			desc:        "not a map",
+			typ:         &compile.StringSpec{},
+			annotations: compile.Annotations{"headers": "true"},
+			wantError:   `field "foo" must be a map<string, binary> or a map<string, string>`,
+		},
+		{
+			desc: "non-string keys",
+			typ: &compile.MapSpec{
+				KeySpec:   &compile.I32Spec{},
+				ValueSpec: &compile.StringSpec{},
+			},
+			annotations: compile.Annotations{"headers": "true"},
+			wantError:   `field "foo" must be a map<string, binary> or a map<string, string>`,
+		},
+		{
+			desc: "non-string values",
+			typ: &compile.MapSpec{
+				KeySpec:   &compile.StringSpec{},
+				ValueSpec: &compile.I64Spec{},
+			},
+			annotations: compile.Annotations{"headers": "true"},
+			wantError:   `field "foo" must be a map<string, binary> or a map<string, string>`,
+		},
+	}
+
+	for _, tt := range tests {
+		spec := &compile.FieldSpec{
+			Name:        "foo",
+			Type:        tt.typ,
+			Annotations: tt.annotations,
+		}
+
+		got, err := headersAnnotation(spec)
+		if tt.wantError != "" {
+			if assert.Error(t, err, tt.desc) {
+				assert.Contains(t, err.Error(), tt.wantError, tt.desc)
+			}
+			continue
+		}
+
+		if assert.NoError(t, err, tt.desc) {
+			assert.Equal(t, tt.want, got, tt.desc)
+		}
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	var req ts.Request
+	_, ok := req.GetHeader("x-caller")
+	assert.False(t, ok)
+
+	req.SetHeader("x-foo", []byte("bar"))
+	foo, ok := req.GetHeader("x-foo")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("bar"), foo)
+
+	req.DeleteHeader("x-foo")
+	_, ok = req.GetHeader("x-foo")
+	assert.False(t, ok)
+
+	req.SetCaller("foo")
+	req.SetDeadline(1234)
+	req.SetTraced(true)
+	req.SetToken([]byte{0x01, 0x02})
+	assert.Equal(t, map[string][]byte{
+		"x-caller":   []byte("foo"),
+		"x-deadline": []byte("1234"),
+		"x-traced":   []byte("true"),
+		"x-token":    {0x01, 0x02},
+	}, req.Headers)
+
+	caller, ok := req.GetCaller()
+	assert.True(t, ok)
+	assert.Equal(t, "foo", caller)
+
+	deadline, ok, err := req.GetDeadline()
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1234), deadline)
+
+	traced, ok, err := req.GetTraced()
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, traced)
+
+	req.SetHeader("x-deadline", []byte("soon"))
+	_, ok, err = req.GetDeadline()
+	assert.True(t, ok)
+	assert.Error(t, err)
+
+	var nilReq *ts.Request
+	_, ok, err = nilReq.GetDeadline()
+	assert.False(t, ok)
+	assert.NoError(t, err)
+}
+
+func TestTextHeaders(t *testing.T) {
+	var h ts.TextHeaders
+	h.SetPriority(-3)
+	h.SetWeight(0.5)
+	h.SetRaw([]byte("hello"))
+	assert.Equal(t, map[string]string{
+		"priority": "-3",
+		"weight":   "0.5",
+		"raw":      "hello",
+	}, h.Values)
+
+	priority, ok, err := h.GetPriority()
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int32(-3), priority)
+
+	weight, ok, err := h.GetWeight()
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 0.5, weight)
+
+	raw, ok := h.GetRaw()
+	assert.True(t, ok)
+	assert.Equal(t, []byte("hello"), raw)
+
+	h.SetHeader("priority", "99999999999")
+	_, _, err = h.GetPriority()
+	assert.Error(t, err, "i32 headers must not overflow")
+}
+
+func TestHeadersGenerateErrors(t *testing.T) {
+	tests := []struct {
+		desc      string
+		thrift    string
+		wantError string
+	}{
+		{
+			desc: "multiple headers fields",
+			thrift: `struct Foo {
+				1: optional map<string, string> a (headers = "true")
+				2: optional map<string, string> b (headers = "true")
+			}`,
+			wantError: `fields "a" and "b" are both annotated with headers`,
+		},
+		{
+			desc: "conflicts with getter",
+			thrift: `struct Foo {
+				1: optional map<string, string> headers (headers = "true")
+				2: optional string header
+			}`,
+			wantError: `cannot generate header accessor "GetHeader" for field "Headers"`,
+		},
+		{
+			desc: "conflicts with field",
+			thrift: `struct Foo {
+				1: optional map<string, string> headers (headers = "true", headers.Caller = "caller")
+				2: optional string SetCaller
+			}`,
+			wantError: `cannot generate header accessor "SetCaller" for field "Headers"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "thriftrw-headers-test")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			path := filepath.Join(dir, "main.thrift")
+			require.NoError(t, ioutil.WriteFile(path, []byte(tt.thrift), 0644))
+
+			module, err := compile.Compile(path)
+			require.NoError(t, err)
+
+			err = Generate(module, &Options{
+				OutputDir:      filepath.Join(dir, "out"),
+				PackagePrefix:  "example.com/out",
+				ThriftRoot:     dir,
+				NoVersionCheck: true,
+			})
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantError)
+			}
+		})
+	}
+}
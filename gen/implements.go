@@ -0,0 +1,99 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+// goImplementsAnnotation returns the interfaces listed in the
+// "go.implements" annotation of the given entity, if any.
+//
+// Interfaces are separated by semicolons and must be fully qualified with
+// their import path, except for the builtin "error" interface.
+//
+// 	struct Foo {
+// 		...
+// 	} (go.implements = "fmt.Stringer;example.com/events.Event")
+func goImplementsAnnotation(e compile.NamedEntity) ([]string, error) {
+	value, ok := e.ThriftAnnotations()["go.implements"]
+	if !ok {
+		return nil, nil
+	}
+
+	var ifaces []string
+	for _, iface := range strings.Split(value, ";") {
+		iface = strings.TrimSpace(iface)
+		if iface == "" {
+			continue
+		}
+
+		if iface != "error" {
+			if _, _, err := splitQualifiedName(iface); err != nil {
+				return nil, fmt.Errorf("invalid go.implements annotation: %v", err)
+			}
+		}
+		ifaces = append(ifaces, iface)
+	}
+	return ifaces, nil
+}
+
+// implementsError returns true if the given list of interfaces includes the
+// builtin error interface.
+func implementsError(ifaces []string) bool {
+	for _, iface := range ifaces {
+		if iface == "error" {
+			return true
+		}
+	}
+	return false
+}
+
+// assertImplements generates compile-time assertions that the type with the
+// given name implements the given interfaces.
+//
+// 	var _ fmt.Stringer = (*Foo)(nil)
+func assertImplements(g Generator, name string, ifaces []string) error {
+	for _, iface := range ifaces {
+		if iface != "error" {
+			var err error
+			iface, err = importQualified(g, iface)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := g.DeclareFromTemplate(
+			`var _ <.Interface> = (*<.Name>)(nil)`,
+			struct {
+				Name      string
+				Interface string
+			}{Name: name, Interface: iface},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+	ts "go.uber.org/thriftrw/gen/testdata/structs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoImplementsAnnotation(t *testing.T) {
+	tests := []struct {
+		desc      string
+		give      string
+		want      []string
+		wantError string
+	}{
+		{desc: "single", give: "error", want: []string{"error"}},
+		{
+			desc: "multiple",
+			give: "fmt.Stringer; example.com/events.Event;",
+			want: []string{"fmt.Stringer", "example.com/events.Event"},
+		},
+		{desc: "empty", give: " ; "},
+		{
+			desc:      "unqualified",
+			give:      "fmt.Stringer;Event",
+			wantError: `invalid go.implements annotation: "Event" is not a fully qualified Go identifier`,
+		},
+	}
+
+	for _, tt := range tests {
+		spec := &compile.StructSpec{
+			Name:        "Foo",
+			Annotations: compile.Annotations{"go.implements": tt.give},
+		}
+
+		got, err := goImplementsAnnotation(spec)
+		if tt.wantError != "" {
+			if assert.Error(t, err, tt.desc) {
+				assert.Contains(t, err.Error(), tt.wantError, tt.desc)
+			}
+			continue
+		}
+
+		if assert.NoError(t, err, tt.desc) {
+			assert.Equal(t, tt.want, got, tt.desc)
+		}
+	}
+}
+
+func TestGoImplementsError(t *testing.T) {
+	var err error = &ts.Failure{Reason: "great sadness"}
+	assert.Equal(t, "Failure{Reason: great sadness}", err.Error())
+}
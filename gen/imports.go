@@ -33,6 +33,10 @@ import (
 type importer struct {
 	ns      Namespace
 	imports map[string]*ast.ImportSpec
+
+	// Preferred names for specific import paths. Packages not listed here
+	// are imported under the base names of their import paths.
+	names map[string]string
 }
 
 // newImporter builds a new importer.
@@ -75,7 +79,11 @@ func (i importer) Import(path string) string {
 	// Find a name, preferring the base name
 	// TODO what if the package name is not the base name?
 	baseName := filepath.Base(path)
-	name := i.ns.NewName(sanitizeImportName(baseName))
+	preferred := baseName
+	if n, ok := i.names[path]; ok {
+		preferred = n
+	}
+	name := i.ns.NewName(sanitizeImportName(preferred))
 	astImport := &ast.ImportSpec{Path: stringLiteral(path)}
 	if name != baseName {
 		astImport.Name = ast.NewIdent(name)
@@ -81,3 +81,22 @@ func TestImport(t *testing.T) {
 		}
 	}
 }
+
+func TestImportPreferredNames(t *testing.T) {
+	imp := newImporter(NewNamespace())
+	imp.names = map[string]string{
+		"example.com/a/shared": "sa",
+		"example.com/b/shared": "sb",
+		"example.com/c/shared": "sa",
+	}
+
+	assert.Equal(t, "sa", imp.Import("example.com/a/shared"))
+	assert.Equal(t, "sb", imp.Import("example.com/b/shared"))
+	assert.Equal(t, "sa2", imp.Import("example.com/c/shared"))
+	assert.Equal(t, "shared", imp.Import("example.com/d/shared"))
+	assert.Equal(t, "sa", imp.Import("example.com/a/shared"))
+
+	if i := imp.imports["example.com/a/shared"]; assert.NotNil(t, i.Name) {
+		assert.Equal(t, "sa", i.Name.Name)
+	}
+}
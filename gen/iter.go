@@ -0,0 +1,145 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+// iteratorGoMinorVersion is the minor version of the first release of Go 1
+// with the iter package.
+const iteratorGoMinorVersion = 23
+
+// parseGoVersion parses a Go version like "1.23", "1.23.1", or "go1.23" and
+// returns its minor version. An empty string parses to 0.
+func parseGoVersion(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(s, "go"), ".")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] != "1" {
+		return 0, fmt.Errorf("invalid Go version %q: expected a version like 1.23", s)
+	}
+	for _, p := range parts[1:] {
+		if _, err := strconv.ParseUint(p, 10, 16); err != nil {
+			return 0, fmt.Errorf("invalid Go version %q: expected a version like 1.23", s)
+		}
+	}
+
+	minor, _ := strconv.Atoi(parts[1])
+	return minor, nil
+}
+
+// iteratorGenerator is implemented by Generators which may generate
+// iterators for range-over-func loops.
+type iteratorGenerator interface {
+	iterators() bool
+}
+
+// useIterators returns true if the generated code may use the iter package.
+func useIterators(g Generator) bool {
+	if o, ok := g.(iteratorGenerator); ok {
+		return o.iterators()
+	}
+	return false
+}
+
+// typedefIterator generates an All method for typedefs of sets and maps
+// which iterates over their items without exposing how they are represented
+// in Go.
+//
+// 	func (v $name) All() iter.Seq[$item] {
+// 		...
+// 	}
+//
+// Maps get an iter.Seq2 of their keys and values instead.
+func typedefIterator(g Generator, spec *compile.TypedefSpec, name string) error {
+	switch compile.RootTypeSpec(spec).(type) {
+	case *compile.SetSpec, *compile.MapSpec:
+	default:
+		return nil
+	}
+
+	return g.DeclareFromTemplate(
+		`
+		<$iter := import "iter">
+		<$v := newVar "v">
+		<$yield := newVar "yield">
+		<$x := newVar "x">
+		<with .Root>
+		<if isSet .>
+			<$item := typeReference .ValueSpec>
+
+			// All returns an iterator over the items of this set.
+			func (<$v> <$.Name>) All() <$iter>.Seq[<$item>] {
+				return func(<$yield> func(<$item>) bool) {
+					<if isHashable .ValueSpec>
+						for <$x> := range <$v> {
+					<else>
+						for _, <$x> := range <$v> {
+					<end>
+						if !<$yield>(<$x>) {
+							return
+						}
+					}
+				}
+			}
+		<else>
+			<$key := typeReference .KeySpec>
+			<$value := typeReference .ValueSpec>
+			<$k := newVar "k">
+
+			// All returns an iterator over the keys and values of this map.
+			func (<$v> <$.Name>) All() <$iter>.Seq2[<$key>, <$value>] {
+				return func(<$yield> func(<$key>, <$value>) bool) {
+					<if isHashable .KeySpec>
+						for <$k>, <$x> := range <$v> {
+							if !<$yield>(<$k>, <$x>) {
+								return
+							}
+						}
+					<else>
+						for _, <$x> := range <$v> {
+							if !<$yield>(<$x>.Key, <$x>.Value) {
+								return
+							}
+						}
+					<end>
+				}
+			}
+		<end>
+		<end>
+		`,
+		struct {
+			Name string
+			Root compile.TypeSpec
+		}{Name: name, Root: compile.RootTypeSpec(spec)},
+		TemplateFunc("isSet", func(s compile.TypeSpec) bool {
+			_, ok := s.(*compile.SetSpec)
+			return ok
+		}),
+	)
+}
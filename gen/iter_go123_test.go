@@ -0,0 +1,129 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.23
+// +build go1.23
+
+package gen
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	tg "go.uber.org/thriftrw/gen/testdata/features/generics/records"
+	ti "go.uber.org/thriftrw/gen/testdata/features/iterators/records"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedefIterators(t *testing.T) {
+	t.Run("set", func(t *testing.T) {
+		var got []string
+		for x := range (ti.Tags{"a": {}, "b": {}, "c": {}}).All() {
+			got = append(got, x)
+		}
+		sort.Strings(got)
+		assert.Equal(t, []string{"a", "b", "c"}, got)
+	})
+
+	t.Run("set of structs", func(t *testing.T) {
+		points := ti.Points{{X: 1}, {X: 2}}
+		var got []*ti.Point
+		for x := range points.All() {
+			got = append(got, x)
+		}
+		assert.Equal(t, []*ti.Point(points), got)
+	})
+
+	t.Run("typedef of typedef", func(t *testing.T) {
+		var got []*ti.Point
+		for x := range (ti.Outline{{X: 1}, {X: 2}}).All() {
+			got = append(got, x)
+		}
+		assert.Equal(t, []*ti.Point{{X: 1}, {X: 2}}, got)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		got := make(map[string]int32)
+		for k, v := range (ti.Counts{"a": 1, "b": 2}).All() {
+			got[k] = v
+		}
+		assert.Equal(t, map[string]int32{"a": 1, "b": 2}, got)
+	})
+
+	t.Run("map with struct keys", func(t *testing.T) {
+		labels := ti.Labels{
+			{Key: &ti.Point{X: 1}, Value: "one"},
+			{Key: &ti.Point{X: 2}, Value: "two"},
+		}
+		var keys []*ti.Point
+		var values []string
+		for k, v := range labels.All() {
+			keys = append(keys, k)
+			values = append(values, v)
+		}
+		assert.Equal(t, []*ti.Point{labels[0].Key, labels[1].Key}, keys)
+		assert.Equal(t, []string{"one", "two"}, values)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		for range ti.Tags(nil).All() {
+			t.Fatal("nil sets must be empty")
+		}
+		for range ti.Labels(nil).All() {
+			t.Fatal("nil maps must be empty")
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		var n int
+		for range (ti.Points{{X: 1}, {X: 2}, {X: 3}}).All() {
+			n++
+			break
+		}
+		assert.Equal(t, 1, n)
+
+		n = 0
+		for range (ti.Counts{"a": 1, "b": 2, "c": 3}).All() {
+			n++
+			if n == 2 {
+				break
+			}
+		}
+		assert.Equal(t, 2, n)
+	})
+}
+
+func TestTypedefIteratorsNotGenerated(t *testing.T) {
+	tests := []struct {
+		desc string
+		give interface{}
+	}{
+		{desc: "lists are already ranged over", give: ti.Names{}},
+		{desc: "Go 1.18 is too old", give: tg.Tags{}},
+		{desc: "Go 1.18 is too old", give: tg.Counts{}},
+	}
+
+	for _, tt := range tests {
+		_, ok := reflect.TypeOf(tt.give).MethodByName("All")
+		assert.False(t, ok, "%v: %T must not have an All method", tt.desc, tt.give)
+	}
+}
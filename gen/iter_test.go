@@ -0,0 +1,94 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+	tp "go.uber.org/thriftrw/gen/testdata/features/plain/records"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGoVersion(t *testing.T) {
+	tests := []struct {
+		give      string
+		want      int
+		wantError bool
+	}{
+		{give: "", want: 0},
+		{give: "1.7", want: 7},
+		{give: "1.23", want: 23},
+		{give: "go1.23", want: 23},
+		{give: "1.23.4", want: 23},
+		{give: "1", wantError: true},
+		{give: "2.0", wantError: true},
+		{give: "1.x", wantError: true},
+		{give: "1.23.4.5", wantError: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseGoVersion(tt.give)
+		if tt.wantError {
+			if assert.Error(t, err, tt.give) {
+				assert.Contains(t, err.Error(), "expected a version like 1.23", tt.give)
+			}
+			continue
+		}
+		if assert.NoError(t, err, tt.give) {
+			assert.Equal(t, tt.want, got, tt.give)
+		}
+	}
+}
+
+func TestTypedefIteratorsOmitted(t *testing.T) {
+	for _, give := range []interface{}{tp.Tags{}, tp.Points{}, tp.Counts{}, tp.Labels{}} {
+		_, ok := reflect.TypeOf(give).MethodByName("All")
+		assert.False(t, ok, "%T must not have an All method without a Go version", give)
+	}
+}
+
+func TestTypedefIteratorsInvalidGoVersion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-iter-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	thriftFile := filepath.Join(dir, "main.thrift")
+	require.NoError(t, ioutil.WriteFile(thriftFile, []byte(`typedef set<string> Tags`), 0644))
+
+	module, err := compile.Compile(thriftFile)
+	require.NoError(t, err)
+
+	err = Generate(module, &Options{
+		OutputDir:  filepath.Join(dir, "out"),
+		ThriftRoot: dir,
+		GoVersion:  "latest",
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `invalid Go version "latest"`)
+	}
+}
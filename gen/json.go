@@ -0,0 +1,286 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+// jsonOptions controls the JSON representation of generated types.
+type jsonOptions struct {
+	// Int64AsString encodes i64 fields as JSON strings. JavaScript clients
+	// are unable to represent all 64-bit integers as numbers.
+	Int64AsString bool
+}
+
+type jsonOptioner interface {
+	jsonOptions() jsonOptions
+}
+
+// lookupJSONOptions returns the JSON options of the given Generator.
+func lookupJSONOptions(g Generator) jsonOptions {
+	if o, ok := g.(jsonOptioner); ok {
+		return o.jsonOptions()
+	}
+	return jsonOptions{}
+}
+
+// jsonTag returns the struct tag for the given field.
+func jsonTag(g Generator, f *compile.FieldSpec) string {
+	opts := ""
+	if isJSONStringInt64(g, f.Type) {
+		opts += ",string"
+	}
+
+	// We want to add omitempty if the field is an optional struct or
+	// primitive to redure "null" noise. We won't add omitempty for optional
+	// collections because omitempty doesn't differentiate between nil and
+	// empty collections.
+	if (isStructType(f.Type) || isPrimitiveType(f.Type)) && !f.Required {
+		opts += ",omitempty"
+	}
+
+	return fmt.Sprintf("`json:\"%s%s\"`", f.Name, opts)
+	// TODO(abg): Take go.tag and js.name annotations into account
+}
+
+func isJSONStringInt64(g Generator, spec compile.TypeSpec) bool {
+	if !lookupJSONOptions(g).Int64AsString {
+		return false
+	}
+	if _, ok := lookupSubstitution(g, spec); ok {
+		return false
+	}
+	_, ok := compile.RootTypeSpec(spec).(*compile.I64Spec)
+	return ok
+}
+
+// jsonMapKeyFields returns fields of the given field group holding maps
+// whose keys encoding/json does not support. encoding/json represents map
+// keys as strings only for string, integer, and encoding.TextMarshaler
+// types. Enums implement encoding.TextMarshaler.
+func jsonMapKeyFields(g Generator, fields compile.FieldGroup) []*compile.FieldSpec {
+	var result []*compile.FieldSpec
+	for _, f := range fields {
+		if _, ok := lookupSubstitution(g, f.Type); ok {
+			continue
+		}
+		m, ok := compile.RootTypeSpec(f.Type).(*compile.MapSpec)
+		if !ok || jsonMapKeyKind(m) == "" {
+			continue
+		}
+		result = append(result, f)
+	}
+	return result
+}
+
+// jsonMapKeyKind returns "bool" or "double" for maps keyed by these types,
+// and an empty string for all other maps.
+func jsonMapKeyKind(spec compile.TypeSpec) string {
+	m, ok := compile.RootTypeSpec(spec).(*compile.MapSpec)
+	if !ok {
+		return ""
+	}
+
+	switch compile.RootTypeSpec(m.KeySpec).(type) {
+	case *compile.BoolSpec:
+		return "bool"
+	case *compile.DoubleSpec:
+		return "double"
+	default:
+		return ""
+	}
+}
+
+// JSON generates MarshalJSON and UnmarshalJSON methods for field groups
+// which cannot rely on the default behavior of encoding/json: unions, which
+// must have exactly one field set, structs with maps keyed by bools or
+// doubles, whose keys are encoded as JSON strings, and structs with value
+// fields, which are written if they are set, even to zero values, and are
+// marked as set when they are read.
+func (f fieldGroupGenerator) JSON(g Generator) error {
+	mapFields := jsonMapKeyFields(g, f.Fields)
+	var valueFields []*compile.FieldSpec
+	for _, field := range f.Fields {
+		if isValueField(field) {
+			valueFields = append(valueFields, field)
+		}
+	}
+	if !(f.IsUnion && len(f.Fields) > 0) && len(mapFields) == 0 && len(valueFields) == 0 {
+		return nil
+	}
+
+	return g.DeclareFromTemplate(
+		`
+		<$json := import "encoding/json">
+
+		<$v := newVar "v">
+		<$plain := newVar "plain">
+		<$x := newVar "x">
+		<$k := newVar "k">
+		<$i := newVar "i">
+		<$count := newVar "count">
+		<$zero := newVar "zero">
+
+		func (<$v> *<.Name>) MarshalJSON() ([]byte, error) {
+			<if and .IsUnion (len .Fields)>
+				<$count> := 0
+				<range .Fields>
+					if <$v>.<goName .> != nil { <$count>++ }
+				<end>
+				<if .AllowEmptyUnion>
+					if <$count> > 1 {
+						return nil, <import "fmt">.Errorf(
+							"<.Name> should have at most one field: got %v fields", <$count>)
+					}
+				<else>
+					if <$count> != 1 {
+						return nil, <import "fmt">.Errorf(
+							"<.Name> should have exactly one field: got %v fields", <$count>)
+					}
+				<end>
+			<end>
+
+			type <$plain> <.Name>
+			<if or .MapFields .ValueFields>
+				<$x> := struct {
+					*<$plain>
+					<range .MapFields>
+						<goName .> map[string]<typeReference (mapSpec .Type).ValueSpec> <jsonTag .>
+					<end>
+					<range .ValueFields>
+						<goName .> *<typeReference .Type> <jsonTag .>
+					<end>
+				}{<$plain>: (*<$plain>)(<$v>)}
+				<range .ValueFields>
+					if <valueFieldIsSet $v .> {
+						<$x>.<goName .> = &<$v>.<goName .>
+					}
+				<end>
+				<range .MapFields>
+					<$fname := goName .>
+					if <$v>.<$fname> != nil {
+						<$x>.<$fname> = make(map[string]<typeReference (mapSpec .Type).ValueSpec>, len(<$v>.<$fname>))
+						for <$k>, <$i> := range <$v>.<$fname> {
+							<if eq (jsonMapKeyKind .Type) "bool">
+								<$x>.<$fname>[<import "strconv">.FormatBool(bool(<$k>))] = <$i>
+							<else>
+								<$x>.<$fname>[<import "strconv">.FormatFloat(float64(<$k>), 'g', -1, 64)] = <$i>
+							<end>
+						}
+					}
+				<end>
+				return <$json>.Marshal(<$x>)
+			<else>
+				return <$json>.Marshal((*<$plain>)(<$v>))
+			<end>
+		}
+
+		<$text := newVar "text">
+		func (<$v> *<.Name>) UnmarshalJSON(<$text> []byte) error {
+			type <$plain> <.Name>
+			<if or .MapFields .ValueFields>
+				<$x> := struct {
+					*<$plain>
+					<range .MapFields>
+						<goName .> map[string]<typeReference (mapSpec .Type).ValueSpec> <jsonTag .>
+					<end>
+					<range .ValueFields>
+						<goName .> *<typeReference .Type> <jsonTag .>
+					<end>
+				}{<$plain>: (*<$plain>)(<$v>)}
+				if err := <$json>.Unmarshal(<$text>, &<$x>); err != nil {
+					return err
+				}
+				<range .ValueFields>
+					<$fname := goName .>
+					if <$x>.<$fname> != nil {
+						<$v>.<$fname> = *<$x>.<$fname>
+						<$v>.<valueFieldFlag .> = true
+					} else {
+						var <$zero> <typeReference .Type>
+						<$v>.<$fname> = <$zero>
+						<$v>.<valueFieldFlag .> = false
+					}
+				<end>
+				<$key := newVar "key">
+				<range .MapFields>
+					<$fname := goName .>
+					<$v>.<$fname> = nil
+					if <$x>.<$fname> != nil {
+						<$v>.<$fname> = make(<typeReference .Type>, len(<$x>.<$fname>))
+						for <$k>, <$i> := range <$x>.<$fname> {
+							<if eq (jsonMapKeyKind .Type) "bool">
+								<$key>, err := <import "strconv">.ParseBool(<$k>)
+							<else>
+								<$key>, err := <import "strconv">.ParseFloat(<$k>, 64)
+							<end>
+							if err != nil {
+								return <import "fmt">.Errorf(
+									"invalid key %q in field <$fname> of <$.Name>: %v", <$k>, err)
+							}
+							<$v>.<$fname>[<typeReference (mapSpec .Type).KeySpec>(<$key>)] = <$i>
+						}
+					}
+				<end>
+			<else>
+				if err := <$json>.Unmarshal(<$text>, (*<$plain>)(<$v>)); err != nil {
+					return err
+				}
+			<end>
+
+			<if and .IsUnion (len .Fields)>
+				<$count> := 0
+				<range .Fields>
+					if <$v>.<goName .> != nil { <$count>++ }
+				<end>
+				<if .AllowEmptyUnion>
+					if <$count> > 1 {
+						return <import "fmt">.Errorf(
+							"<.Name> should have at most one field: got %v fields", <$count>)
+					}
+				<else>
+					if <$count> != 1 {
+						return <import "fmt">.Errorf(
+							"<.Name> should have exactly one field: got %v fields", <$count>)
+					}
+				<end>
+			<end>
+			return nil
+		}
+		`,
+		struct {
+			fieldGroupGenerator
+
+			MapFields   []*compile.FieldSpec
+			ValueFields []*compile.FieldSpec
+		}{fieldGroupGenerator: f, MapFields: mapFields, ValueFields: valueFields},
+		TemplateFunc("jsonTag", func(f *compile.FieldSpec) string {
+			return jsonTag(g, f)
+		}),
+		TemplateFunc("jsonMapKeyKind", jsonMapKeyKind),
+		TemplateFunc("mapSpec", func(spec compile.TypeSpec) *compile.MapSpec {
+			return compile.RootTypeSpec(spec).(*compile.MapSpec)
+		}),
+	)
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+	tc "go.uber.org/thriftrw/gen/testdata/containers"
+	te "go.uber.org/thriftrw/gen/testdata/enums"
+	tu "go.uber.org/thriftrw/gen/testdata/unions"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONMapKeys(t *testing.T) {
+	tests := []struct {
+		desc string
+		v    interface{}
+		j    string
+	}{
+		{
+			desc: "bool and double keys",
+			v: &tc.MapsWithPrimitiveKeys{
+				BoolToString: map[bool]string{true: "yes", false: "no"},
+				DoubleToInt:  map[float64]int64{1.5: 1, -2: 2},
+			},
+			j: `{"boolToString":{"false":"no","true":"yes"},"doubleToInt":{"-2":2,"1.5":1}}`,
+		},
+		{
+			desc: "nil maps",
+			v:    &tc.MapsWithPrimitiveKeys{},
+			j:    `{"boolToString":null,"doubleToInt":null}`,
+		},
+		{
+			desc: "enum keys",
+			v: &tc.EnumContainers{
+				MapOfEnums: map[te.EnumWithDuplicateValues]int32{
+					te.EnumWithDuplicateValuesQ:    1,
+					te.EnumWithDuplicateValues(42): 2,
+				},
+			},
+			j: `{"listOfEnums":null,"setOfEnums":null,"mapOfEnums":{"42":2,"Q":1}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			encoded, err := json.Marshal(tt.v)
+			require.NoError(t, err, "failed to JSON encode %v", tt.v)
+			assert.Equal(t, tt.j, string(encoded))
+
+			v := reflect.New(reflect.TypeOf(tt.v).Elem()).Interface()
+			require.NoError(t, json.Unmarshal([]byte(tt.j), v), "failed to decode %q", tt.j)
+			assert.Equal(t, tt.v, v)
+		})
+	}
+}
+
+func TestJSONMapKeysInvalid(t *testing.T) {
+	var v tc.MapsWithPrimitiveKeys
+	err := json.Unmarshal([]byte(`{"boolToString":{"maybe":"x"}}`), &v)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(),
+			`invalid key "maybe" in field BoolToString of MapsWithPrimitiveKeys`)
+	}
+}
+
+func TestJSONUnionFieldCount(t *testing.T) {
+	_, err := json.Marshal(&tu.ArbitraryValue{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "ArbitraryValue should have exactly one field: got 0 fields")
+	}
+
+	_, err = json.Marshal(&tu.ArbitraryValue{BoolValue: boolp(true), StringValue: stringp("foo")})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "ArbitraryValue should have exactly one field: got 2 fields")
+	}
+
+	tests := []struct {
+		j       string
+		wantErr string
+	}{
+		{`{}`, "ArbitraryValue should have exactly one field: got 0 fields"},
+		{`{"boolValue":true,"int64Value":42}`, "ArbitraryValue should have exactly one field: got 2 fields"},
+		{`{"boolValue":`, "unexpected end of JSON input"},
+	}
+
+	for _, tt := range tests {
+		var v tu.ArbitraryValue
+		err := json.Unmarshal([]byte(tt.j), &v)
+		if assert.Error(t, err, "expected failure decoding %q", tt.j) {
+			assert.Contains(t, err.Error(), tt.wantErr)
+		}
+	}
+
+	var v tu.ArbitraryValue
+	require.NoError(t, json.Unmarshal([]byte(`{"int64Value":42}`), &v))
+	assert.Equal(t, tu.ArbitraryValue{Int64Value: int64p(42)}, v)
+}
+
+func TestEnumText(t *testing.T) {
+	tests := []struct {
+		e    te.EnumWithValues
+		text string
+	}{
+		{te.EnumWithValuesX, "X"},
+		{te.EnumWithValuesZ, "Z"},
+		{te.EnumWithValues(-42), "-42"},
+	}
+
+	for _, tt := range tests {
+		text, err := tt.e.MarshalText()
+		require.NoError(t, err)
+		assert.Equal(t, tt.text, string(text))
+
+		var e te.EnumWithValues
+		require.NoError(t, e.UnmarshalText(text))
+		assert.Equal(t, tt.e, e)
+	}
+}
+
+func TestJSONInt64AsString(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-json-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	thriftFile := filepath.Join(dir, "main.thrift")
+	require.NoError(t, ioutil.WriteFile(thriftFile, []byte(`
+		typedef i64 Timestamp
+
+		struct Event {
+			1: required i64 id
+			2: optional Timestamp at
+			3: optional i32 count
+			4: optional list<i64> values
+		}
+	`), 0644))
+
+	module, err := compile.Compile(thriftFile)
+	require.NoError(t, err)
+
+	outputDir := filepath.Join(dir, "out")
+	require.NoError(t, Generate(module, &Options{
+		OutputDir:         outputDir,
+		PackagePrefix:     "example.com/out",
+		ThriftRoot:        dir,
+		NoVersionCheck:    true,
+		NoEmbedIDL:        true,
+		JSONInt64AsString: true,
+	}))
+
+	contents, err := ioutil.ReadFile(filepath.Join(outputDir, "main", "types.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), "`json:\"id,string\"`")
+	assert.Contains(t, string(contents), "`json:\"at,string,omitempty\"`")
+	assert.Contains(t, string(contents), "`json:\"count,omitempty\"`")
+	assert.Contains(t, string(contents), "`json:\"values\"`")
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+// maxRequestBytesAnnotation returns the limit specified by the
+// "max_request_bytes" annotation of the given function, or 0 if the function
+// does not have one.
+//
+// 	void upload(1: binary data) (max_request_bytes = "1048576")
+func maxRequestBytesAnnotation(f *compile.FunctionSpec) (int64, error) {
+	value, ok := f.Annotations["max_request_bytes"]
+	if !ok {
+		return 0, nil
+	}
+
+	limit, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || limit <= 0 {
+		return 0, fmt.Errorf(
+			"invalid max_request_bytes annotation %q: must be a positive integer", value)
+	}
+	return limit, nil
+}
+
+// functionDecodeArgs generates an expression that provides the DecodeArgs
+// function for the given Thrift function.
+func functionDecodeArgs(g Generator, s *compile.ServiceSpec, f *compile.FunctionSpec) (string, error) {
+	limit, err := maxRequestBytesAnnotation(f)
+	if err != nil {
+		return "", err
+	}
+
+	return g.TextTemplate(
+		`
+		<$f := .Function>
+		<$prefix := namePrefix .Service $f>
+		<$protocol := import "go.uber.org/thriftrw/protocol">
+		<$wire := import "go.uber.org/thriftrw/wire">
+
+		func(p <$protocol>.Protocol, body []byte) (*<$prefix>Args, error) {
+			<if .Limit>
+				if int64(len(body)) > <.Limit> {
+					return nil, <$protocol>.SizeLimitError{
+						Method: "<$f.MethodName>",
+						Size:   int64(len(body)),
+						Limit:  <.Limit>,
+					}
+				}
+			<end>
+
+			w, err := p.Decode(<import "bytes">.NewReader(body), <$wire>.TStruct)
+			if err != nil {
+				return nil, err
+			}
+
+			var args <$prefix>Args
+			if err := args.FromWire(w); err != nil {
+				return nil, err
+			}
+			return &args, nil
+		}
+		`,
+		struct {
+			Service  *compile.ServiceSpec
+			Function *compile.FunctionSpec
+			Limit    int64
+		}{
+			Service:  s,
+			Function: f,
+			Limit:    limit,
+		},
+		TemplateFunc("namePrefix", curryGenerator(functionNamePrefix, g)))
+}
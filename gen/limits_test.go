@@ -0,0 +1,117 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+	tv "go.uber.org/thriftrw/gen/testdata/services"
+	tu "go.uber.org/thriftrw/gen/testdata/unions"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/ptr"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxRequestBytesAnnotation(t *testing.T) {
+	tests := []struct {
+		desc      string
+		give      *string
+		want      int64
+		wantError string
+	}{
+		{desc: "absent"},
+		{desc: "valid", give: ptr.String("1048576"), want: 1048576},
+		{
+			desc:      "not a number",
+			give:      ptr.String("1MB"),
+			wantError: `invalid max_request_bytes annotation "1MB": must be a positive integer`,
+		},
+		{
+			desc:      "zero",
+			give:      ptr.String("0"),
+			wantError: `invalid max_request_bytes annotation "0": must be a positive integer`,
+		},
+	}
+
+	for _, tt := range tests {
+		spec := &compile.FunctionSpec{Name: "foo", Annotations: compile.Annotations{}}
+		if tt.give != nil {
+			spec.Annotations["max_request_bytes"] = *tt.give
+		}
+
+		got, err := maxRequestBytesAnnotation(spec)
+		if tt.wantError != "" {
+			if assert.Error(t, err, tt.desc) {
+				assert.Contains(t, err.Error(), tt.wantError, tt.desc)
+			}
+			continue
+		}
+
+		if assert.NoError(t, err, tt.desc) {
+			assert.Equal(t, tt.want, got, tt.desc)
+		}
+	}
+}
+
+func TestDecodeArgs(t *testing.T) {
+	encode := func(args interface {
+		ToWire() (wire.Value, error)
+	}) []byte {
+		v, err := args.ToWire()
+		require.NoError(t, err)
+
+		var buff bytes.Buffer
+		require.NoError(t, protocol.Binary.Encode(v, &buff))
+		return buff.Bytes()
+	}
+
+	small := tv.KeyValue_SetValueV2_Helper.Args("foo", &tu.ArbitraryValue{BoolValue: ptr.Bool(true)})
+	got, err := tv.KeyValue_SetValueV2_Helper.DecodeArgs(protocol.Binary, encode(small))
+	require.NoError(t, err)
+	assert.Equal(t, small, got)
+
+	large := tv.KeyValue_SetValueV2_Helper.Args("foo",
+		&tu.ArbitraryValue{StringValue: ptr.String(strings.Repeat("a", 1048576))})
+	body := encode(large)
+	_, err = tv.KeyValue_SetValueV2_Helper.DecodeArgs(protocol.Binary, body)
+	assert.Equal(t, protocol.SizeLimitError{
+		Method: "setValueV2",
+		Size:   int64(len(body)),
+		Limit:  1048576,
+	}, err)
+
+	// Functions without limits accept requests of any size.
+	unlimited := tv.KeyValue_SetValue_Helper.Args(
+		(*tv.Key)(ptr.String("foo")),
+		&tu.ArbitraryValue{StringValue: ptr.String(strings.Repeat("a", 1048576))})
+	got2, err := tv.KeyValue_SetValue_Helper.DecodeArgs(protocol.Binary, encode(unlimited))
+	require.NoError(t, err)
+	assert.Equal(t, unlimited, got2)
+
+	_, err = tv.KeyValue_SetValue_Helper.DecodeArgs(protocol.Binary, []byte{0xff})
+	assert.Error(t, err, "invalid payloads must fail")
+}
@@ -39,6 +39,10 @@ type listGenerator struct{}
 // And $valueListName is returned. This may be used where a ValueList of the
 // given type is expected.
 func (l *listGenerator) ValueList(g Generator, spec *compile.ListSpec) (string, error) {
+	if useGenerics(g) {
+		return l.genericValueList(g, spec)
+	}
+
 	name := valueListName(g, spec)
 	err := g.EnsureDeclared(
 		`
@@ -100,6 +104,10 @@ func (l *listGenerator) ValueList(g Generator, spec *compile.ListSpec) (string,
 //
 // And returns its name.
 func (l *listGenerator) Reader(g Generator, spec *compile.ListSpec) (string, error) {
+	if useGenerics(g) {
+		return l.genericReader(g, spec)
+	}
+
 	name := readerFuncName(g, spec)
 	err := g.EnsureDeclared(
 		`
@@ -110,7 +118,7 @@ func (l *listGenerator) Reader(g Generator, spec *compile.ListSpec) (string, err
 			<$i := newVar "i">
 			<$o := newVar "o">
 			<$x := newVar "x">
-			func <.Name>(<$l> <$wire>.ValueList) (<$listType>, error) {
+			func <.Name>(<$l> <$wire>.ValueList<if useArena>, <arenaVar> *<import "go.uber.org/thriftrw/arena">.Arena<end>) (<$listType>, error) {
 				if <$l>.ValueType() != <typeCode .Spec.ValueSpec> {
 					return nil, nil
 				}
@@ -137,6 +145,70 @@ func (l *listGenerator) Reader(g Generator, spec *compile.ListSpec) (string, err
 	return name, wrapGenerateError(spec.ThriftName(), err)
 }
 
+// genericValueList generates a function which builds a ValueList for the
+// given list with thriftlist.Encode.
+//
+// 	func $valueListName(v $listType) wire.ValueList {
+// 		return thriftlist.Encode(v, $valueType, func(x $valueType) (wire.Value, error) { ... })
+// 	}
+//
+// And returns its name. Callers use it exactly like the type generated by
+// ValueList.
+func (l *listGenerator) genericValueList(g Generator, spec *compile.ListSpec) (string, error) {
+	name := valueListName(g, spec)
+	err := g.EnsureDeclared(
+		`
+			<$wire := import "go.uber.org/thriftrw/wire">
+			<$thriftlist := import "go.uber.org/thriftrw/thriftlist">
+
+			<$v := newVar "v">
+			<$x := newVar "x">
+			func <.Name>(<$v> <typeReference .Spec>) <$wire>.ValueList {
+				return <$thriftlist>.Encode(<$v>, <typeCode .Spec.ValueSpec>, func(<$x> <typeReference .Spec.ValueSpec>) (<$wire>.Value, error) {
+					<if not (isPrimitiveType .Spec.ValueSpec)>
+						if <$x> == nil {
+							return <$wire>.Value{}, <$thriftlist>.ErrNil
+						}
+					<end>
+					return <toWire .Spec.ValueSpec $x>
+				})
+			}
+		`,
+		struct {
+			Name string
+			Spec *compile.ListSpec
+		}{Name: name, Spec: spec},
+	)
+
+	return name, wrapGenerateError(spec.ThriftName(), err)
+}
+
+// genericReader generates a function to read a list of the given type from
+// a wire.List with thriftlist.Decode.
+func (l *listGenerator) genericReader(g Generator, spec *compile.ListSpec) (string, error) {
+	name := readerFuncName(g, spec)
+	err := g.EnsureDeclared(
+		`
+			<$wire := import "go.uber.org/thriftrw/wire">
+			<$thriftlist := import "go.uber.org/thriftrw/thriftlist">
+
+			<$l := newVar "l">
+			<$x := newVar "x">
+			func <.Name>(<$l> <$wire>.ValueList<if useArena>, <arenaVar> *<import "go.uber.org/thriftrw/arena">.Arena<end>) (<typeReference .Spec>, error) {
+				return <$thriftlist>.Decode(<$l>, <typeCode .Spec.ValueSpec>, func(<$x> <$wire>.Value) (<typeReference .Spec.ValueSpec>, error) {
+					return <fromWire .Spec.ValueSpec $x>
+				})
+			}
+		`,
+		struct {
+			Name string
+			Spec *compile.ListSpec
+		}{Name: name, Spec: spec},
+	)
+
+	return name, wrapGenerateError(spec.ThriftName(), err)
+}
+
 // Equals generates a function to compare lists of the given type
 //
 // 	func $name(lhs, rhs $listType) bool {
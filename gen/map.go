@@ -47,6 +47,10 @@ type mapGenerator struct{}
 // And $mapItemListName is returned. This may be used where a MapItemList of the
 // given type is expected.
 func (m *mapGenerator) ItemList(g Generator, spec *compile.MapSpec) (string, error) {
+	if useGenerics(g) {
+		return m.genericItemList(g, spec)
+	}
+
 	name := mapItemListName(g, spec)
 	err := g.EnsureDeclared(
 		`
@@ -60,10 +64,12 @@ func (m *mapGenerator) ItemList(g Generator, spec *compile.MapSpec) (string, err
 			<$i := newVar "i">
 			<$kw := newVar "kw">
 			<$vw := newVar "vw">
+			<$keys := newVar "keys">
 			func (<$m> <.Name>) ForEach(<$f> func(<$wire>.MapItem) error) error {
 				<if isHashable .Spec.KeySpec>
 					for <$k>, <$v> := range <$m> {
 				<else>
+					<$keys> := <$wire>.NewKeySet(len(<$m>))
 					for _, <$i> := range <$m> {
 						<$k> := <$i>.Key
 						<$v> := <$i>.Value
@@ -86,6 +92,14 @@ func (m *mapGenerator) ItemList(g Generator, spec *compile.MapSpec) (string, err
 							return err
 						}
 
+						<if not (isHashable .Spec.KeySpec)>
+							if _, dup, err := <$keys>.Add(<$kw>); err != nil {
+								return err
+							} else if dup {
+								return <import "fmt">.Errorf("invalid map key: duplicate key %v", <$k>)
+							}
+						<end>
+
 						<$vw>, err := <toWire .Spec.ValueSpec $v>
 						if err != nil {
 							// TODO(abg): nested error "invalid [%v]: %v"
@@ -123,6 +137,10 @@ func (m *mapGenerator) ItemList(g Generator, spec *compile.MapSpec) (string, err
 }
 
 func (m *mapGenerator) Reader(g Generator, spec *compile.MapSpec) (string, error) {
+	if useGenerics(g) {
+		return m.genericReader(g, spec)
+	}
+
 	name := readerFuncName(g, spec)
 	err := g.EnsureDeclared(
 		`
@@ -134,7 +152,9 @@ func (m *mapGenerator) Reader(g Generator, spec *compile.MapSpec) (string, error
 			<$x := newVar "x">
 			<$k := newVar "k">
 			<$v := newVar "v">
-			func <.Name>(<$m> <$wire>.MapItemList) (<$mapType>, error) {
+			<$i := newVar "i">
+			<$keys := newVar "keys">
+			func <.Name>(<$m> <$wire>.MapItemList<if useArena>, <arenaVar> *<import "go.uber.org/thriftrw/arena">.Arena<end>) (<$mapType>, error) {
 				if <$m>.KeyType() != <typeCode .Spec.KeySpec> {
 					return nil, nil
 				}
@@ -147,8 +167,18 @@ func (m *mapGenerator) Reader(g Generator, spec *compile.MapSpec) (string, error
 					<$o> := make(<$mapType>, <$m>.Size())
 				<else>
 					<$o> := make(<$mapType>, 0, <$m>.Size())
+					<$keys> := <$wire>.NewKeySet(<$m>.Size())
 				<end>
 				err := <$m>.ForEach(func(<$x> <$wire>.MapItem) error {
+					<if not (isHashable .Spec.KeySpec)>
+						// The key is recorded before it is decoded because
+						// decoding it closes the lists it contains.
+						<$i>, dup, err := <$keys>.Add(<$x>.Key)
+						if err != nil {
+							return err
+						}
+					<end>
+
 					<$k>, err := <fromWire .Spec.KeySpec (printf "%s.Key" $x)>
 					if err != nil {
 						return err
@@ -162,6 +192,12 @@ func (m *mapGenerator) Reader(g Generator, spec *compile.MapSpec) (string, error
 					<if isHashable .Spec.KeySpec>
 						<$o>[<$k>] = <$v>
 					<else>
+						// Later values replace earlier ones for duplicate
+						// keys, as they would in a Go map.
+						if dup {
+							<$o>[<$i>].Value = <$v>
+							return nil
+						}
 						<$o> = append(<$o>, struct {
 							Key <typeReference .Spec.KeySpec>
 							Value <typeReference .Spec.ValueSpec>
@@ -182,6 +218,78 @@ func (m *mapGenerator) Reader(g Generator, spec *compile.MapSpec) (string, error
 	return name, wrapGenerateError(spec.ThriftName(), err)
 }
 
+// genericItemList generates a function which builds a MapItemList for the
+// given map with thriftmap.Encode, or thriftmap.EncodeSlice if its keys are
+// not hashable, and returns its name.
+func (m *mapGenerator) genericItemList(g Generator, spec *compile.MapSpec) (string, error) {
+	name := mapItemListName(g, spec)
+	err := g.EnsureDeclared(
+		`
+			<$wire := import "go.uber.org/thriftrw/wire">
+			<$thriftmap := import "go.uber.org/thriftrw/thriftmap">
+
+			<$m := newVar "m">
+			<$k := newVar "k">
+			<$v := newVar "v">
+			func <.Name>(<$m> <typeReference .Spec>) <$wire>.MapItemList {
+				return <$thriftmap>.Encode<if not (isHashable .Spec.KeySpec)>Slice<end>(<$m>, <typeCode .Spec.KeySpec>, <typeCode .Spec.ValueSpec>,
+					func(<$k> <typeReference .Spec.KeySpec>) (<$wire>.Value, error) {
+						<if not (isPrimitiveType .Spec.KeySpec)>
+							if <$k> == nil {
+								return <$wire>.Value{}, <$thriftmap>.ErrNil
+							}
+						<end>
+						return <toWire .Spec.KeySpec $k>
+					},
+					func(<$v> <typeReference .Spec.ValueSpec>) (<$wire>.Value, error) {
+						<if not (isPrimitiveType .Spec.ValueSpec)>
+							if <$v> == nil {
+								return <$wire>.Value{}, <$thriftmap>.ErrNil
+							}
+						<end>
+						return <toWire .Spec.ValueSpec $v>
+					})
+			}
+		`,
+		struct {
+			Name string
+			Spec *compile.MapSpec
+		}{Name: name, Spec: spec},
+	)
+
+	return name, wrapGenerateError(spec.ThriftName(), err)
+}
+
+// genericReader generates a function to read a map of the given type from
+// a wire.Map with thriftmap.Decode or thriftmap.DecodeSlice.
+func (m *mapGenerator) genericReader(g Generator, spec *compile.MapSpec) (string, error) {
+	name := readerFuncName(g, spec)
+	err := g.EnsureDeclared(
+		`
+			<$wire := import "go.uber.org/thriftrw/wire">
+			<$thriftmap := import "go.uber.org/thriftrw/thriftmap">
+
+			<$m := newVar "m">
+			<$x := newVar "x">
+			func <.Name>(<$m> <$wire>.MapItemList<if useArena>, <arenaVar> *<import "go.uber.org/thriftrw/arena">.Arena<end>) (<typeReference .Spec>, error) {
+				return <$thriftmap>.Decode<if not (isHashable .Spec.KeySpec)>Slice<end>(<$m>, <typeCode .Spec.KeySpec>, <typeCode .Spec.ValueSpec>,
+					func(<$x> <$wire>.Value) (<typeReference .Spec.KeySpec>, error) {
+						return <fromWire .Spec.KeySpec $x>
+					},
+					func(<$x> <$wire>.Value) (<typeReference .Spec.ValueSpec>, error) {
+						return <fromWire .Spec.ValueSpec $x>
+					})
+			}
+		`,
+		struct {
+			Name string
+			Spec *compile.MapSpec
+		}{Name: name, Spec: spec},
+	)
+
+	return name, wrapGenerateError(spec.ThriftName(), err)
+}
+
 // Equals generates a function to compare maps of the given type
 //
 // 	func $name(lhs, rhs $mapType) bool {
@@ -0,0 +1,165 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+// normalizations maps the names accepted by the "normalize" annotation to
+// the functions in the strings package that implement them.
+var normalizations = map[string]string{
+	"trim":  "TrimSpace",
+	"lower": "ToLower",
+	"upper": "ToUpper",
+}
+
+// normalizeAnnotation returns the normalizations listed in the "normalize"
+// annotation of the given field, if any, in the order they should be
+// applied.
+//
+// 	1: required string email (normalize = "trim,lower")
+func normalizeAnnotation(f *compile.FieldSpec) ([]string, error) {
+	value, ok := f.Annotations["normalize"]
+	if !ok {
+		return nil, nil
+	}
+
+	if _, ok := compile.RootTypeSpec(f.Type).(*compile.StringSpec); !ok {
+		return nil, fmt.Errorf(
+			"invalid normalize annotation %q: field %q is not a string", value, f.Name)
+	}
+
+	var ops []string
+	for _, op := range strings.Split(value, ",") {
+		op = strings.TrimSpace(op)
+		if op == "" {
+			continue
+		}
+		if _, ok := normalizations[op]; !ok {
+			return nil, fmt.Errorf(
+				"invalid normalize annotation %q: unknown normalization %q "+
+					"(expected trim, lower, or upper)", value, op)
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+// sanitizedField is a field normalized by a Sanitize method.
+type sanitizedField struct {
+	Name     string // Go name of the field
+	Field    *compile.FieldSpec
+	Required bool
+
+	// Type is the Go type of the field if it must be converted to and from
+	// string, which is the case for typedefs.
+	Type string
+
+	Ops []string
+}
+
+// Sanitize generates a Sanitize method for the field group if any of its
+// fields have a normalize annotation.
+//
+// 	func (v *User) Sanitize() {
+// 		v.Name = strings.TrimSpace(v.Name)
+// 		...
+// 	}
+func (f fieldGroupGenerator) Sanitize(g Generator) error {
+	var fields []sanitizedField
+	for _, field := range f.Fields {
+		ops, err := normalizeAnnotation(field)
+		if err != nil {
+			return err
+		}
+		if len(ops) == 0 {
+			continue
+		}
+
+		if _, ok := lookupSubstitution(g, field.Type); ok {
+			return fmt.Errorf(
+				"invalid normalize annotation: field %q has a substituted type", field.Name)
+		}
+
+		name, err := goNames(g).goName(field)
+		if err != nil {
+			return err
+		}
+
+		sf := sanitizedField{Name: name, Field: field, Required: field.Required, Ops: ops}
+		if _, ok := field.Type.(*compile.StringSpec); !ok {
+			sf.Type, err = typeReference(g, field.Type)
+			if err != nil {
+				return err
+			}
+		}
+		fields = append(fields, sf)
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return g.DeclareFromTemplate(
+		`
+		<$v := newVar "v">
+		<$x := newVar "x">
+		func (<$v> *<.Name>) Sanitize() {
+			<range .Fields>
+				<if .Required>
+					<$v>.<.Name> = <normalize . (printf "%v.%v" $v .Name)>
+				<else if isValueField .Field>
+					if <valueFieldIsSet $v .Field> {
+						<$v>.<.Name> = <normalize . (printf "%v.%v" $v .Name)>
+						<$v>.<valueFieldFlag .Field> = true
+					}
+				<else>
+					if <$v>.<.Name> != nil {
+						<$x> := <normalize . (printf "*%v.%v" $v .Name)>
+						<$v>.<.Name> = &<$x>
+					}
+				<end>
+			<end>
+		}
+		`,
+		struct {
+			Name   string
+			Fields []sanitizedField
+		}{Name: f.Name, Fields: fields},
+		TemplateFunc("normalize", func(f sanitizedField, expr string) string {
+			pkg := g.Import("strings")
+			if f.Type != "" {
+				expr = fmt.Sprintf("string(%s)", expr)
+			}
+			for _, op := range f.Ops {
+				expr = fmt.Sprintf("%s.%s(%s)", pkg, normalizations[op], expr)
+			}
+			if f.Type != "" {
+				expr = fmt.Sprintf("(%s)(%s)", f.Type, expr)
+			}
+			return expr
+		}),
+	)
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+	ts "go.uber.org/thriftrw/gen/testdata/structs"
+	td "go.uber.org/thriftrw/gen/testdata/typedefs"
+	"go.uber.org/thriftrw/ptr"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeAnnotation(t *testing.T) {
+	tests := []struct {
+		desc      string
+		give      string
+		typ       compile.TypeSpec
+		want      []string
+		wantError string
+	}{
+		{desc: "single", give: "trim", want: []string{"trim"}},
+		{desc: "multiple", give: "trim, lower,", want: []string{"trim", "lower"}},
+		{desc: "empty", give: " , "},
+		{
+			desc:      "unknown",
+			give:      "trim,title",
+			wantError: `invalid normalize annotation "trim,title": unknown normalization "title"`,
+		},
+		{
+			desc:      "not a string",
+			give:      "trim",
+			typ:       &compile.I32Spec{},
+			wantError: `invalid normalize annotation "trim": field "foo" is not a string`,
+		},
+	}
+
+	for _, tt := range tests {
+		typ := tt.typ
+		if typ == nil {
+			typ = &compile.StringSpec{}
+		}
+		spec := &compile.FieldSpec{
+			Name:        "foo",
+			Type:        typ,
+			Annotations: compile.Annotations{"normalize": tt.give},
+		}
+
+		got, err := normalizeAnnotation(spec)
+		if tt.wantError != "" {
+			if assert.Error(t, err, tt.desc) {
+				assert.Contains(t, err.Error(), tt.wantError, tt.desc)
+			}
+			continue
+		}
+
+		if assert.NoError(t, err, tt.desc) {
+			assert.Equal(t, tt.want, got, tt.desc)
+		}
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	user := ts.NormalizedUser{
+		Name:        "  Jane Doe ",
+		Email:       ptr.String(" Jane@Example.COM"),
+		CountryCode: ptr.String("us "),
+		Bio:         ptr.String("  unchanged  "),
+	}
+	email := user.Email
+
+	user.Sanitize()
+	assert.Equal(t, ts.NormalizedUser{
+		Name:        "Jane Doe",
+		Email:       ptr.String("jane@example.com"),
+		CountryCode: ptr.String("US"),
+		Bio:         ptr.String("  unchanged  "),
+	}, user)
+	assert.Equal(t, " Jane@Example.COM", *email, "original value must not be modified")
+
+	var empty ts.NormalizedUser
+	empty.Sanitize()
+	assert.Equal(t, ts.NormalizedUser{}, empty)
+
+	toState := td.State(" Done")
+	transition := td.NormalizedTransition{FromState: "ACTIVE ", ToState: &toState}
+	transition.Sanitize()
+	assert.Equal(t, td.State("active"), transition.FromState)
+	assert.Equal(t, td.State("done"), *transition.ToState)
+}
@@ -0,0 +1,280 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+// optionsFile is the JSON representation of Options read by ReadOptions.
+// Related options are grouped together.
+type optionsFile struct {
+	Output   outputOptions   `json:"output"`
+	Types    typesOptions    `json:"types"`
+	Symbols  symbolsOptions  `json:"symbols"`
+	Features featuresOptions `json:"features"`
+	Build    buildOptions    `json:"build"`
+}
+
+type outputOptions struct {
+	Dir           string   `json:"dir"`
+	PackagePrefix string   `json:"packagePrefix"`
+	PackageName   string   `json:"packageName"`
+	ThriftRoot    string   `json:"thriftRoot"`
+	NoRecurse     bool     `json:"noRecurse"`
+	Flatten       bool     `json:"flatten"`
+	Header        string   `json:"header"`
+	Initialisms   []string `json:"initialisms"`
+	PreserveNames bool     `json:"preserveNames"`
+}
+
+type typesOptions struct {
+	Include       []string                    `json:"include"`
+	Exclude       []string                    `json:"exclude"`
+	Substitutions map[string]TypeSubstitution `json:"substitutions"`
+	Converters    map[string]TypeConverter    `json:"converters"`
+}
+
+type symbolsOptions struct {
+	Include      []string `json:"include"`
+	OnlyTypes    bool     `json:"onlyTypes"`
+	OnlyServices bool     `json:"onlyServices"`
+}
+
+type featuresOptions struct {
+	NoTypes           bool `json:"noTypes"`
+	NoConstants       bool `json:"noConstants"`
+	NoServiceHelpers  bool `json:"noServiceHelpers"`
+	NoEmbedIDL        bool `json:"noEmbedIDL"`
+	NoVersionCheck    bool `json:"noVersionCheck"`
+	Reflection        bool `json:"reflection"`
+	JSONInt64AsString bool `json:"jsonInt64AsString"`
+	ConstantAccessors bool `json:"constantAccessors"`
+	Allocator         bool `json:"allocator"`
+	StrictEnums       bool `json:"strictEnums"`
+	PresenceMethods   bool `json:"presenceMethods"`
+	HashMethods       bool `json:"hashMethods"`
+	CompactCodegen    bool `json:"compactCodegen"`
+	KeepUnknownFields bool `json:"keepUnknownFields"`
+	BuilderThreshold  int  `json:"builderThreshold"`
+}
+
+type buildOptions struct {
+	Jobs      int    `json:"jobs"`
+	GoVersion string `json:"goVersion"`
+}
+
+// ReadOptions reads generator options from a JSON object. Options are
+// organized into groups.
+//
+// 	{
+// 		"output": {
+// 			"dir": "gen-go",
+// 			"packagePrefix": "example.com/myservice/gen-go",
+// 			"packageName": "",
+// 			"thriftRoot": "idl",
+// 			"noRecurse": false,
+// 			"flatten": false,
+// 			"header": "// Code owned by the myservice team.",
+// 			"initialisms": ["SKU"],
+// 			"preserveNames": false
+// 		},
+// 		"types": {
+// 			"include": ["users.*"],
+// 			"exclude": ["users.Internal*"],
+// 			"substitutions": {"users.UUID": {"type": "example.com/uuid.UUID", ...}},
+// 			"converters": {"time.Time": {"toThrift": ..., "fromThrift": ...}}
+// 		},
+// 		"symbols": {
+// 			"include": ["users.User*"],
+// 			"onlyTypes": false,
+// 			"onlyServices": false
+// 		},
+// 		"features": {
+// 			"noTypes": false,
+// 			"noConstants": false,
+// 			"noServiceHelpers": false,
+// 			"noEmbedIDL": false,
+// 			"noVersionCheck": false,
+// 			"reflection": false,
+// 			"jsonInt64AsString": false,
+// 			"constantAccessors": false,
+// 			"allocator": false,
+// 			"strictEnums": false,
+// 			"presenceMethods": false,
+// 			"hashMethods": false,
+// 			"compactCodegen": false,
+// 			"keepUnknownFields": false,
+// 			"builderThreshold": 0
+// 		},
+// 		"build": {
+// 			"jobs": 4,
+// 			"goVersion": "1.23"
+// 		}
+// 	}
+//
+// All options are optional. Options which are not recognized are not
+// rejected so that configuration written for newer versions of ThriftRW
+// may be used with older versions. If warn is non-nil, it is called with
+// the name of every unrecognized option, for example, "output.foo".
+//
+// Paths are returned as-is. Callers should resolve relative paths and call
+// Validate on the returned Options before using them.
+func ReadOptions(r io.Reader, warn func(option string)) (*Options, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("could not decode options: %v", err)
+	}
+
+	var f optionsFile
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return nil, fmt.Errorf("could not decode options: %v", err)
+	}
+
+	if warn != nil {
+		warnUnknownOptions("", raw, reflect.TypeOf(f), warn)
+	}
+
+	return &Options{
+		OutputDir:         f.Output.Dir,
+		PackagePrefix:     f.Output.PackagePrefix,
+		PackageName:       f.Output.PackageName,
+		ThriftRoot:        f.Output.ThriftRoot,
+		NoRecurse:         f.Output.NoRecurse,
+		Flatten:           f.Output.Flatten,
+		Header:            f.Output.Header,
+		Initialisms:       f.Output.Initialisms,
+		PreserveNames:     f.Output.PreserveNames,
+		IncludeTypes:      f.Types.Include,
+		ExcludeTypes:      f.Types.Exclude,
+		TypeSubstitutions: f.Types.Substitutions,
+		TypeConverters:    f.Types.Converters,
+		IncludeSymbols:    f.Symbols.Include,
+		OnlyTypes:         f.Symbols.OnlyTypes,
+		OnlyServices:      f.Symbols.OnlyServices,
+		NoTypes:           f.Features.NoTypes,
+		NoConstants:       f.Features.NoConstants,
+		NoServiceHelpers:  f.Features.NoServiceHelpers,
+		NoEmbedIDL:        f.Features.NoEmbedIDL,
+		NoVersionCheck:    f.Features.NoVersionCheck,
+		Reflection:        f.Features.Reflection,
+		JSONInt64AsString: f.Features.JSONInt64AsString,
+		ConstantAccessors: f.Features.ConstantAccessors,
+		Allocator:         f.Features.Allocator,
+		StrictEnums:       f.Features.StrictEnums,
+		PresenceMethods:   f.Features.PresenceMethods,
+		HashMethods:       f.Features.HashMethods,
+		CompactCodegen:    f.Features.CompactCodegen,
+		KeepUnknownFields: f.Features.KeepUnknownFields,
+		BuilderThreshold:  f.Features.BuilderThreshold,
+		Jobs:              f.Build.Jobs,
+		GoVersion:         f.Build.GoVersion,
+	}, nil
+}
+
+// warnUnknownOptions calls warn with the name of every key of the given
+// JSON object that does not have a matching field in the struct type t.
+// Objects for fields which are themselves structs are checked recursively.
+func warnUnknownOptions(prefix string, raw json.RawMessage, t reflect.Type, warn func(string)) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return // not an object; type errors were already reported
+	}
+
+	for _, key := range sortStringKeys(obj) {
+		field, ok := findJSONField(t, key)
+		if !ok {
+			warn(prefix + key)
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			warnUnknownOptions(prefix+key+".", obj[key], field.Type, warn)
+		}
+	}
+}
+
+// findJSONField finds the field of the struct type t which encoding/json
+// decodes the given key into.
+func findJSONField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// Validate verifies that these Options may be used to generate code.
+//
+// All problems with the options are reported.
+func (o *Options) Validate() error {
+	var errs []error
+
+	if !filepath.IsAbs(o.ThriftRoot) {
+		errs = append(errs, fmt.Errorf(
+			"ThriftRoot must be an absolute path: %q is not absolute",
+			o.ThriftRoot))
+	}
+
+	if !filepath.IsAbs(o.OutputDir) {
+		errs = append(errs, fmt.Errorf(
+			"OutputDir must be an absolute path: %q is not absolute",
+			o.OutputDir))
+	}
+
+	if o.Reflection && o.NoEmbedIDL {
+		errs = append(errs, errors.New("Reflection requires embedded IDLs: NoEmbedIDL must not be set"))
+	}
+
+	if o.Flatten && o.NoRecurse {
+		errs = append(errs, errors.New("Flatten generates code for all included Thrift files: NoRecurse must not be set"))
+	}
+
+	if o.Flatten && o.Reflection {
+		errs = append(errs, errors.New("Reflection requires a package for each Thrift file: Flatten must not be set"))
+	}
+
+	if o.OnlyTypes && o.OnlyServices {
+		errs = append(errs, errors.New("OnlyTypes and OnlyServices must not be set together"))
+	}
+
+	if o.BuilderThreshold < 0 {
+		errs = append(errs, fmt.Errorf(
+			"BuilderThreshold must not be negative: got %d", o.BuilderThreshold))
+	}
+
+	if _, err := parseGoVersion(o.GoVersion); err != nil {
+		errs = append(errs, err)
+	}
+
+	return multierr.Combine(errs...)
+}
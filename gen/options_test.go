@@ -0,0 +1,257 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOptions(t *testing.T) {
+	give := `{
+		"output": {
+			"dir": "gen-go",
+			"packagePrefix": "example.com/gen-go",
+			"packageName": "userapi",
+			"thriftRoot": "idl",
+			"noRecurse": true,
+			"flatten": true,
+			"header": "// Hello",
+			"initialisms": ["SKU"],
+			"preserveNames": true
+		},
+		"types": {
+			"include": ["users.*"],
+			"exclude": ["users.Internal*"],
+			"substitutions": {
+				"users.UUID": {
+					"type": "example.com/uuid.UUID",
+					"toThrift": "example.com/uuid.ToString",
+					"fromThrift": "example.com/uuid.FromString"
+				}
+			},
+			"converters": {
+				"time.Time": {
+					"toThrift": "example.com/conv.TimeToUnixNano",
+					"fromThrift": "example.com/conv.TimeFromUnixNano"
+				}
+			}
+		},
+		"symbols": {"include": ["users.Get*"], "onlyServices": true},
+		"features": {
+			"noTypes": true,
+			"noConstants": true,
+			"noServiceHelpers": true,
+			"noEmbedIDL": true,
+			"noVersionCheck": true,
+			"reflection": true,
+			"jsonInt64AsString": true,
+			"constantAccessors": true,
+			"allocator": true,
+			"strictEnums": true,
+			"presenceMethods": true,
+			"hashMethods": true,
+			"compactCodegen": true,
+			"keepUnknownFields": true,
+			"builderThreshold": 30
+		},
+		"build": {"jobs": 4, "goVersion": "1.23"}
+	}`
+
+	var warnings []string
+	opts, err := ReadOptions(strings.NewReader(give), func(option string) {
+		warnings = append(warnings, option)
+	})
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	assert.Equal(t, &Options{
+		OutputDir:     "gen-go",
+		PackagePrefix: "example.com/gen-go",
+		PackageName:   "userapi",
+		ThriftRoot:    "idl",
+		NoRecurse:     true,
+		Flatten:       true,
+		Header:        "// Hello",
+		Initialisms:   []string{"SKU"},
+		PreserveNames: true,
+		IncludeTypes:  []string{"users.*"},
+		ExcludeTypes:  []string{"users.Internal*"},
+		TypeSubstitutions: map[string]TypeSubstitution{
+			"users.UUID": {
+				Type:       "example.com/uuid.UUID",
+				ToThrift:   "example.com/uuid.ToString",
+				FromThrift: "example.com/uuid.FromString",
+			},
+		},
+		TypeConverters: map[string]TypeConverter{
+			"time.Time": {
+				ToThrift:   "example.com/conv.TimeToUnixNano",
+				FromThrift: "example.com/conv.TimeFromUnixNano",
+			},
+		},
+		IncludeSymbols:    []string{"users.Get*"},
+		OnlyServices:      true,
+		NoTypes:           true,
+		NoConstants:       true,
+		NoServiceHelpers:  true,
+		NoEmbedIDL:        true,
+		NoVersionCheck:    true,
+		Reflection:        true,
+		JSONInt64AsString: true,
+		ConstantAccessors: true,
+		Allocator:         true,
+		StrictEnums:       true,
+		PresenceMethods:   true,
+		HashMethods:       true,
+		CompactCodegen:    true,
+		KeepUnknownFields: true,
+		BuilderThreshold:  30,
+		Jobs:              4,
+		GoVersion:         "1.23",
+	}, opts)
+}
+
+func TestReadOptionsUnknown(t *testing.T) {
+	give := `{
+		"output": {"dir": "out", "layout": "flat"},
+		"features": {"Reflection": true, "streaming": true},
+		"validation": {"enabled": true}
+	}`
+
+	var warnings []string
+	opts, err := ReadOptions(strings.NewReader(give), func(option string) {
+		warnings = append(warnings, option)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"features.streaming", "output.layout", "validation"}, warnings)
+	assert.Equal(t, "out", opts.OutputDir)
+	assert.True(t, opts.Reflection, "keys must be case-insensitive")
+
+	_, err = ReadOptions(strings.NewReader(give), nil)
+	assert.NoError(t, err, "warnings must be optional")
+}
+
+func TestReadOptionsErrors(t *testing.T) {
+	tests := []struct {
+		desc string
+		give string
+	}{
+		{desc: "invalid JSON", give: `{"output": `},
+		{desc: "not an object", give: `["output"]`},
+		{desc: "group is not an object", give: `{"output": "gen-go"}`},
+		{desc: "wrong type", give: `{"build": {"jobs": "4"}}`},
+	}
+
+	for _, tt := range tests {
+		_, err := ReadOptions(strings.NewReader(tt.give), nil)
+		if assert.Error(t, err, tt.desc) {
+			assert.Contains(t, err.Error(), "could not decode options", tt.desc)
+		}
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		give    Options
+		wantErr []string
+	}{
+		{
+			desc: "valid",
+			give: Options{OutputDir: "/out", ThriftRoot: "/idl", GoVersion: "1.23"},
+		},
+		{
+			desc: "relative paths",
+			give: Options{OutputDir: "out", ThriftRoot: "idl"},
+			wantErr: []string{
+				`ThriftRoot must be an absolute path: "idl" is not absolute`,
+				`OutputDir must be an absolute path: "out" is not absolute`,
+			},
+		},
+		{
+			desc: "reflection without IDL",
+			give: Options{
+				OutputDir:  "/out",
+				ThriftRoot: "/idl",
+				Reflection: true,
+				NoEmbedIDL: true,
+			},
+			wantErr: []string{"Reflection requires embedded IDLs"},
+		},
+		{
+			desc: "flatten with NoRecurse and Reflection",
+			give: Options{
+				OutputDir:  "/out",
+				ThriftRoot: "/idl",
+				Flatten:    true,
+				NoRecurse:  true,
+				Reflection: true,
+			},
+			wantErr: []string{
+				"NoRecurse must not be set",
+				"Flatten must not be set",
+			},
+		},
+		{
+			desc: "negative builder threshold",
+			give: Options{
+				OutputDir:        "/out",
+				ThriftRoot:       "/idl",
+				BuilderThreshold: -1,
+			},
+			wantErr: []string{"BuilderThreshold must not be negative: got -1"},
+		},
+		{
+			desc: "only types and only services",
+			give: Options{
+				OutputDir:    "/out",
+				ThriftRoot:   "/idl",
+				OnlyTypes:    true,
+				OnlyServices: true,
+			},
+			wantErr: []string{"OnlyTypes and OnlyServices must not be set together"},
+		},
+		{
+			desc:    "invalid Go version",
+			give:    Options{OutputDir: "/out", ThriftRoot: "/idl", GoVersion: "go1"},
+			wantErr: []string{"go1"},
+		},
+	}
+
+	for _, tt := range tests {
+		err := tt.give.Validate()
+		if len(tt.wantErr) == 0 {
+			assert.NoError(t, err, tt.desc)
+			continue
+		}
+
+		if assert.Error(t, err, tt.desc) {
+			for _, msg := range tt.wantErr {
+				assert.Contains(t, err.Error(), msg, tt.desc)
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/internal/goast"
+)
+
+// goPackageAnnotation is the annotation used on namespace statements to
+// specify the name of the Go package generated for a Thrift file.
+//
+// 	namespace go users (go.package = "userapi")
+const goPackageAnnotation = "go.package"
+
+// resolvePackageNames determines the Go package names for the given module
+// and all modules included by it. The result maps Thrift file paths to
+// package names. Modules which use the default package name, the base name
+// of the package directory, are omitted.
+//
+// Default package names which are Go keywords or predeclared identifiers are
+// escaped with a trailing underscore: the packages for type.thrift and
+// string.thrift are named type_ and string_. The escaped name is reported to
+// plugins as the PackageName of the module.
+//
+// rootName, if non-empty, overrides the package name of the given module.
+func resolvePackageNames(m *compile.Module, rootName string) (map[string]string, error) {
+	names := make(map[string]string)
+	err := m.Walk(func(m *compile.Module) error {
+		if name, ok := m.Annotations[goPackageAnnotation]; ok {
+			if err := validatePackageName(name); err != nil {
+				return generateError{Name: m.ThriftPath, Reason: err}
+			}
+			names[m.ThriftPath] = name
+			return nil
+		}
+
+		name := strings.TrimSuffix(filepath.Base(m.ThriftPath), ".thrift")
+		if goast.IsReservedKeyword(name) {
+			names[m.ThriftPath] = name + "_"
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if rootName != "" {
+		if err := validatePackageName(rootName); err != nil {
+			return nil, err
+		}
+		names[m.ThriftPath] = rootName
+	}
+	return names, nil
+}
+
+// validatePackageName verifies that the given string may be used as the
+// name of a Go package.
+func validatePackageName(name string) error {
+	if !isGoIdentifier(name) || token.Lookup(name).IsKeyword() {
+		return invalidPackageNameError{Name: name}
+	}
+	return nil
+}
+
+// isGoIdentifier returns true if the given string is a valid Go identifier.
+func isGoIdentifier(s string) bool {
+	for i, c := range s {
+		if c == '_' || unicode.IsLetter(c) || (i > 0 && unicode.IsDigit(c)) {
+			continue
+		}
+		return false
+	}
+	return len(s) > 0
+}
+
+type invalidPackageNameError struct {
+	Name string
+}
+
+func (e invalidPackageNameError) Error() string {
+	return fmt.Sprintf("invalid Go package name %q: "+
+		"package names must be valid Go identifiers", e.Name)
+}
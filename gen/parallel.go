@@ -0,0 +1,131 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+// moduleResult is the result of generating code for a single module.
+type moduleResult struct {
+	Files map[string][]byte
+	Err   error
+}
+
+// generateParallel calls generate for each of the given modules with at most
+// jobs calls running at the same time. If jobs is not positive, it defaults
+// to the number of CPUs.
+//
+// The results are returned in the same order as the modules.
+func generateParallel(
+	modules []*compile.Module,
+	jobs int,
+	generate func(*compile.Module) (map[string][]byte, error),
+) []moduleResult {
+	if jobs <= 0 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+
+	results := make([]moduleResult, len(modules))
+	sem := make(chan struct{}, jobs)
+
+	var wg sync.WaitGroup
+	for i, m := range modules {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, m *compile.Module) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			files, err := generate(m)
+			results[i] = moduleResult{Files: files, Err: err}
+		}(i, m)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// generateSequential calls generate for each of the given modules in order.
+// It stops at the first module that fails; later modules get no results.
+func generateSequential(
+	modules []*compile.Module,
+	generate func(*compile.Module) (map[string][]byte, error),
+) []moduleResult {
+	results := make([]moduleResult, len(modules))
+	for i, m := range modules {
+		files, err := generate(m)
+		results[i] = moduleResult{Files: files, Err: err}
+		if err != nil {
+			break
+		}
+	}
+	return results
+}
+
+// sortedModules returns the given module and all modules it includes,
+// directly or transitively, sorted by the paths of their Thrift files.
+func sortedModules(m *compile.Module) ([]*compile.Module, error) {
+	var modules []*compile.Module
+	err := m.Walk(func(m *compile.Module) error {
+		modules = append(modules, m)
+		return nil
+	})
+	sort.Sort(byThriftPath(modules))
+	return modules, err
+}
+
+type byThriftPath []*compile.Module
+
+func (ms byThriftPath) Len() int {
+	return len(ms)
+}
+
+func (ms byThriftPath) Less(i, j int) bool {
+	return ms[i].ThriftPath < ms[j].ThriftPath
+}
+
+func (ms byThriftPath) Swap(i, j int) {
+	ms[i], ms[j] = ms[j], ms[i]
+}
+
+// addRootServices adds the services defined in the given module to the
+// plugin request as root services.
+//
+// This is called only for those modules for which we need to generate code.
+// With --no-recurse, it is called only on the root file specified by the
+// user and not its included modules. Plugins will generate code only for
+// root services, even though they have information about the whole service
+// tree.
+func addRootServices(b *generateServiceBuilder, m *compile.Module) error {
+	for _, name := range sortStringKeys(m.Services) {
+		if _, err := b.AddRootService(m.Services[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
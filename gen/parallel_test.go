@@ -0,0 +1,186 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateParallel(t *testing.T) {
+	modules := make([]*compile.Module, 20)
+	for i := range modules {
+		modules[i] = &compile.Module{Name: fmt.Sprint(i)}
+	}
+
+	var (
+		mu         sync.Mutex
+		running    int
+		maxRunning int
+	)
+	results := generateParallel(modules, 3, func(m *compile.Module) (map[string][]byte, error) {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+
+		if m.Name == "7" {
+			return nil, fmt.Errorf("failed %v", m.Name)
+		}
+		return map[string][]byte{m.Name: nil}, nil
+	})
+
+	assert.True(t, maxRunning <= 3, "at most 3 modules must be generated at a time: got %v", maxRunning)
+	require.Len(t, results, len(modules))
+	for i, r := range results {
+		if i == 7 {
+			assert.EqualError(t, r.Err, "failed 7")
+			continue
+		}
+		assert.NoError(t, r.Err)
+		assert.Contains(t, r.Files, fmt.Sprint(i), "results must be in module order")
+	}
+}
+
+func TestGenerateJobs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-parallel-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	mainThrift := ""
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("mod%d", i)
+		mainThrift += fmt.Sprintf("include %q\n", "./"+name+".thrift")
+		require.NoError(t, ioutil.WriteFile(
+			filepath.Join(dir, name+".thrift"),
+			[]byte(fmt.Sprintf(`
+				struct Item%d {
+					1: required string name
+					2: optional list<i32> values
+				}
+				service Service%d {
+					Item%d get(1: string name)
+				}
+			`, i, i, i)), 0644))
+	}
+	mainPath := filepath.Join(dir, "main.thrift")
+	require.NoError(t, ioutil.WriteFile(mainPath, []byte(mainThrift), 0644))
+
+	module, err := compile.Compile(mainPath)
+	require.NoError(t, err)
+
+	generate := func(jobs int) map[string]string {
+		outputDir := filepath.Join(dir, fmt.Sprintf("out%d", jobs))
+		require.NoError(t, Generate(module, &Options{
+			OutputDir:      outputDir,
+			PackagePrefix:  "example.com/out",
+			ThriftRoot:     dir,
+			NoVersionCheck: true,
+			Jobs:           jobs,
+		}))
+
+		files := make(map[string]string)
+		err := filepath.Walk(outputDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			contents, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(outputDir, path)
+			files[rel] = string(contents)
+			return err
+		})
+		require.NoError(t, err)
+		return files
+	}
+
+	sequential := generate(1)
+	assert.Contains(t, sequential, filepath.Join("mod9", "types.go"))
+	assert.Equal(t, sequential, generate(8),
+		"parallel generation must produce the same output")
+}
+
+func TestGenerateJobsErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-parallel-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Fields foo_bar and fooBar both map to the Go name FooBar.
+	conflict := `struct Foo {
+		1: optional string foo_bar
+		2: optional string fooBar
+	}`
+	files := map[string]string{
+		"main.thrift": `include "./b.thrift"
+			include "./a.thrift"
+			include "./ok.thrift"`,
+		"a.thrift":  conflict,
+		"b.thrift":  conflict,
+		"ok.thrift": "struct Bar {}",
+	}
+	for name, contents := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644))
+	}
+
+	module, err := compile.Compile(filepath.Join(dir, "main.thrift"))
+	require.NoError(t, err)
+
+	for _, jobs := range []int{1, 4} {
+		err = Generate(module, &Options{
+			OutputDir:      filepath.Join(dir, "out"),
+			PackagePrefix:  "example.com/out",
+			ThriftRoot:     dir,
+			NoVersionCheck: true,
+			Jobs:           jobs,
+		})
+		require.Error(t, err)
+
+		// Errors for all failing files are reported in the order of their
+		// paths.
+		msg := err.Error()
+		a := filepath.Join(dir, "a.thrift")
+		b := filepath.Join(dir, "b.thrift")
+		if assert.Contains(t, msg, a) && assert.Contains(t, msg, b) {
+			assert.True(t, strings.Index(msg, a) < strings.Index(msg, b), "errors must be sorted: %v", msg)
+		}
+		assert.NotContains(t, msg, "ok.thrift")
+	}
+}
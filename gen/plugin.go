@@ -46,6 +46,12 @@ type generateServiceBuilder struct {
 
 	// To ensure there are no duplicates
 	rootServices map[api.ServiceID]struct{}
+
+	// ThriftFile -> Scope -> Namespace
+	namespaces map[string]map[string]string
+
+	// Converts Thrift names into the names of the generated Go identifiers.
+	names nameMapper
 }
 
 func newGenerateServiceBuilder(i thriftPackageImporter) *generateServiceBuilder {
@@ -61,9 +67,26 @@ func newGenerateServiceBuilder(i thriftPackageImporter) *generateServiceBuilder
 		moduleIDs:     make(map[string]api.ModuleID),
 		serviceIDs:    make(map[string]map[serviceName]api.ServiceID),
 		rootServices:  make(map[api.ServiceID]struct{}),
+		namespaces:    make(map[string]map[string]string),
 	}
 }
 
+// AddNamespaces records the namespaces declared in the Thrift file of the
+// given module so that they are included in the request if the module is
+// referenced by it.
+func (g *generateServiceBuilder) AddNamespaces(m *compile.Module) error {
+	if len(m.Namespaces) == 0 {
+		return nil
+	}
+
+	namespaces := make(map[string]string, len(m.Namespaces))
+	for _, ns := range m.Namespaces {
+		namespaces[ns.Scope] = ns.Name
+	}
+	g.namespaces[m.ThriftPath] = namespaces
+	return nil
+}
+
 func (g *generateServiceBuilder) Build() *api.GenerateServiceRequest {
 	return &g.GenerateServiceRequest
 }
@@ -103,9 +126,16 @@ func (g *generateServiceBuilder) addModule(thriftPath string) (api.ModuleID, err
 		return 0, err
 	}
 
+	name, err := g.importer.PackageName(thriftPath)
+	if err != nil {
+		return 0, err
+	}
+
 	g.Modules[id] = &api.Module{
-		ImportPath: importPath,
-		Directory:  dir,
+		ImportPath:  importPath,
+		Directory:   dir,
+		Namespaces:  g.namespaces[thriftPath],
+		PackageName: &name,
 	}
 	return id, nil
 }
@@ -137,21 +167,32 @@ func (g *generateServiceBuilder) addService(spec *compile.ServiceSpec) (api.Serv
 		return 0, err
 	}
 
+	importPath := g.Modules[moduleID].ImportPath
 	functions := make([]*api.Function, 0, len(spec.Functions))
 	for _, functionName := range sortStringKeys(spec.Functions) {
-		function, err := g.buildFunction(spec.Functions[functionName])
+		functionSpec := spec.Functions[functionName]
+		function, err := g.buildFunction(functionSpec)
 		if err != nil {
 			return 0, err
 		}
+
+		// Names of the wrapper structs generated for the function. These
+		// match functionNamePrefix.
+		prefix := fmt.Sprintf("%s_%s_", g.names.goCase(spec.Name), g.names.goCase(functionSpec.Name))
+		function.ArgsType = &api.TypeReference{Name: prefix + "Args", ImportPath: importPath}
+		if functionSpec.ResultSpec != nil {
+			function.ResultType = &api.TypeReference{Name: prefix + "Result", ImportPath: importPath}
+		}
 		functions = append(functions, function)
 	}
 
 	g.Services[serviceID] = &api.Service{
-		ThriftName: spec.Name,
-		Name:       goCase(spec.Name),
-		ParentID:   parentID,
-		Functions:  functions,
-		ModuleID:   moduleID,
+		ThriftName:  spec.Name,
+		Name:        g.names.goCase(spec.Name),
+		ParentID:    parentID,
+		Functions:   functions,
+		ModuleID:    moduleID,
+		Annotations: buildAnnotations(spec.Annotations),
 	}
 	return serviceID, nil
 }
@@ -163,9 +204,10 @@ func (g *generateServiceBuilder) buildFunction(spec *compile.FunctionSpec) (*api
 	}
 
 	function := &api.Function{
-		Name:       goCase(spec.Name),
-		ThriftName: spec.Name,
-		Arguments:  args,
+		Name:        g.names.goCase(spec.Name),
+		ThriftName:  spec.Name,
+		Arguments:   args,
+		Annotations: buildAnnotations(spec.Annotations),
 	}
 	if spec.OneWay {
 		function.OneWay = ptr.Bool(spec.OneWay)
@@ -199,18 +241,34 @@ func (g *generateServiceBuilder) buildFieldGroup(fs compile.FieldGroup) ([]*api.
 			return nil, err
 		}
 
-		name, err := goName(f)
+		name, err := g.names.goName(f)
 		if err != nil {
 			return nil, err
 		}
 		args = append(args, &api.Argument{
-			Name: name,
-			Type: t,
+			Name:        name,
+			Type:        t,
+			FieldID:     ptr.Int16(f.ID),
+			ThriftName:  ptr.String(f.Name),
+			Annotations: buildAnnotations(f.Annotations),
 		})
 	}
 	return args, nil
 }
 
+// buildAnnotations returns a copy of the given annotations for a request, or
+// nil if there are none so that they are omitted from it.
+func buildAnnotations(as compile.Annotations) map[string]string {
+	if len(as) == 0 {
+		return nil
+	}
+	annotations := make(map[string]string, len(as))
+	for k, v := range as {
+		annotations[k] = v
+	}
+	return annotations
+}
+
 func (g *generateServiceBuilder) buildType(spec compile.TypeSpec, required bool) (*api.Type, error) {
 	simpleType := func(t api.SimpleType) *api.SimpleType { return &t }
 
@@ -232,12 +290,19 @@ func (g *generateServiceBuilder) buildType(spec compile.TypeSpec, required bool)
 		t = &api.Type{SimpleType: simpleType(api.SimpleTypeFloat64)}
 	case *compile.StringSpec:
 		t = &api.Type{SimpleType: simpleType(api.SimpleTypeString)}
+	case *compile.UUIDSpec:
+		t = &api.Type{
+			ReferenceType: &api.TypeReference{
+				Name:       "UUID",
+				ImportPath: "go.uber.org/thriftrw/uuid",
+			},
+		}
 	case *compile.EnumSpec:
 		importPath, err := g.importer.Package(s.ThriftFile())
 		if err != nil {
 			return nil, err
 		}
-		name, err := goName(s)
+		name, err := g.names.goName(s)
 		if err != nil {
 			return nil, err
 		}
@@ -306,7 +371,7 @@ func (g *generateServiceBuilder) buildType(spec compile.TypeSpec, required bool)
 			return nil, err
 		}
 
-		name, err := goName(s)
+		name, err := g.names.goName(s)
 		if err != nil {
 			return nil, err
 		}
@@ -326,7 +391,7 @@ func (g *generateServiceBuilder) buildType(spec compile.TypeSpec, required bool)
 			return nil, err
 		}
 
-		name, err := goName(s)
+		name, err := g.names.goName(s)
 		if err != nil {
 			return nil, err
 		}
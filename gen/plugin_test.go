@@ -9,6 +9,7 @@ import (
 	"go.uber.org/thriftrw/ptr"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAddRootService(t *testing.T) {
@@ -35,8 +36,9 @@ func TestAddRootService(t *testing.T) {
 				},
 				Modules: map[api.ModuleID]*api.Module{
 					1: {
-						ImportPath: "go.uber.org/thriftrw/gen/testdata/empty",
-						Directory:  "empty",
+						ImportPath:  "go.uber.org/thriftrw/gen/testdata/empty",
+						Directory:   "empty",
+						PackageName: ptr.String("empty"),
 					},
 				},
 			},
@@ -59,8 +61,9 @@ func TestAddRootService(t *testing.T) {
 				},
 				Modules: map[api.ModuleID]*api.Module{
 					1: {
-						ImportPath: "go.uber.org/thriftrw/gen/testdata/service",
-						Directory:  "service",
+						ImportPath:  "go.uber.org/thriftrw/gen/testdata/service",
+						Directory:   "service",
+						PackageName: ptr.String("service"),
 					},
 				},
 			},
@@ -94,12 +97,87 @@ func TestAddRootService(t *testing.T) {
 				},
 				Modules: map[api.ModuleID]*api.Module{
 					1: {
-						ImportPath: "go.uber.org/thriftrw/gen/testdata/common/abstract",
-						Directory:  "common/abstract",
+						ImportPath:  "go.uber.org/thriftrw/gen/testdata/common/abstract",
+						Directory:   "common/abstract",
+						PackageName: ptr.String("abstract"),
 					},
 					2: {
-						ImportPath: "go.uber.org/thriftrw/gen/testdata/kv",
-						Directory:  "kv",
+						ImportPath:  "go.uber.org/thriftrw/gen/testdata/kv",
+						Directory:   "kv",
+						PackageName: ptr.String("kv"),
+					},
+				},
+			},
+		},
+		{
+			desc: "service with functions and annotations",
+			spec: &compile.ServiceSpec{
+				Name:        "KeyValue",
+				File:        "idl/kv.thrift",
+				Annotations: compile.Annotations{"rpc.service": "kv"},
+				Functions: map[string]*compile.FunctionSpec{
+					"getValue": {
+						Name: "getValue",
+						ArgsSpec: compile.ArgsSpec{
+							{ID: 1, Name: "key", Type: &compile.StringSpec{}},
+						},
+						ResultSpec: &compile.ResultSpec{ReturnType: &compile.StringSpec{}},
+					},
+					"forget": {
+						Name:     "forget",
+						OneWay:   true,
+						ArgsSpec: compile.ArgsSpec{},
+					},
+				},
+			},
+			want: &api.GenerateServiceRequest{
+				RootServices: []api.ServiceID{1},
+				Services: map[api.ServiceID]*api.Service{
+					1: {
+						Name:       "KeyValue",
+						ThriftName: "KeyValue",
+						Functions: []*api.Function{
+							{
+								Name:       "Forget",
+								ThriftName: "forget",
+								Arguments:  []*api.Argument{},
+								OneWay:     ptr.Bool(true),
+								ArgsType: &api.TypeReference{
+									Name:       "KeyValue_Forget_Args",
+									ImportPath: "go.uber.org/thriftrw/gen/testdata/kv",
+								},
+							},
+							{
+								Name:       "GetValue",
+								ThriftName: "getValue",
+								Arguments: []*api.Argument{
+									{
+										Name:       "Key",
+										Type:       &api.Type{PointerType: &api.Type{SimpleType: simpleType(api.SimpleTypeString)}},
+										FieldID:    ptr.Int16(1),
+										ThriftName: ptr.String("key"),
+									},
+								},
+								ReturnType: &api.Type{SimpleType: simpleType(api.SimpleTypeString)},
+								ArgsType: &api.TypeReference{
+									Name:       "KeyValue_GetValue_Args",
+									ImportPath: "go.uber.org/thriftrw/gen/testdata/kv",
+								},
+								ResultType: &api.TypeReference{
+									Name:       "KeyValue_GetValue_Result",
+									ImportPath: "go.uber.org/thriftrw/gen/testdata/kv",
+								},
+							},
+						},
+						ModuleID:    1,
+						Annotations: map[string]string{"rpc.service": "kv"},
+					},
+				},
+				Modules: map[api.ModuleID]*api.Module{
+					1: {
+						ImportPath:  "go.uber.org/thriftrw/gen/testdata/kv",
+						Directory:   "kv",
+						PackageName: ptr.String("kv"),
 					},
 				},
 			},
@@ -125,6 +203,78 @@ func TestAddRootService(t *testing.T) {
 	}
 }
 
+func TestAddNamespaces(t *testing.T) {
+	importer := thriftPackageImporter{
+		ImportPrefix: "go.uber.org/thriftrw/gen/testdata",
+		ThriftRoot:   "idl",
+	}
+
+	g := newGenerateServiceBuilder(importer)
+	require.NoError(t, g.AddNamespaces(&compile.Module{
+		ThriftPath: "idl/kv.thrift",
+		Namespaces: []*compile.Namespace{
+			{Scope: "java", Name: "com.example.kv"},
+			{Scope: "*", Name: "example.kv"},
+			{Scope: "java", Name: "com.example.keyvalue"},
+		},
+	}))
+	require.NoError(t, g.AddNamespaces(&compile.Module{ThriftPath: "idl/empty.thrift"}))
+
+	spec := &compile.ServiceSpec{Name: "KeyValue", File: "idl/kv.thrift"}
+	require.NoError(t, spec.Link(compile.EmptyScope("foo")))
+	_, err := g.AddRootService(spec)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[api.ModuleID]*api.Module{
+		1: {
+			ImportPath:  "go.uber.org/thriftrw/gen/testdata/kv",
+			Directory:   "kv",
+			PackageName: ptr.String("kv"),
+			Namespaces: map[string]string{
+				"java": "com.example.keyvalue",
+				"*":    "example.kv",
+			},
+		},
+	}, g.Build().Modules)
+}
+
+func TestAddModulePackageName(t *testing.T) {
+	tests := []struct {
+		desc       string
+		thriftPath string
+		annotation string
+		want       string
+	}{
+		{desc: "default", thriftPath: "idl/kv.thrift", want: "kv"},
+		{desc: "keyword", thriftPath: "idl/type.thrift", want: "type_"},
+		{desc: "predeclared", thriftPath: "idl/string.thrift", want: "string_"},
+		{
+			desc:       "annotation",
+			thriftPath: "idl/kv.thrift",
+			annotation: "keyvalue",
+			want:       "keyvalue",
+		},
+	}
+
+	for _, tt := range tests {
+		m := &compile.Module{ThriftPath: tt.thriftPath}
+		if tt.annotation != "" {
+			m.Annotations = map[string]string{goPackageAnnotation: tt.annotation}
+		}
+		names, err := resolvePackageNames(m, "")
+		require.NoError(t, err, tt.desc)
+
+		g := newGenerateServiceBuilder(thriftPackageImporter{
+			ImportPrefix: "go.uber.org/thriftrw/gen/testdata",
+			ThriftRoot:   "idl",
+			PackageNames: names,
+		})
+		id, err := g.addModule(tt.thriftPath)
+		require.NoError(t, err, tt.desc)
+		assert.Equal(t, ptr.String(tt.want), g.Build().Modules[id].PackageName, tt.desc)
+	}
+}
+
 func TestBuildFunction(t *testing.T) {
 	tests := []struct {
 		desc string
@@ -169,8 +319,10 @@ func TestBuildFunction(t *testing.T) {
 				ThriftName: "getValue",
 				Arguments: []*api.Argument{
 					{
-						Name: "Key",
-						Type: &api.Type{PointerType: &api.Type{SimpleType: simpleType(api.SimpleTypeString)}},
+						Name:       "Key",
+						Type:       &api.Type{PointerType: &api.Type{SimpleType: simpleType(api.SimpleTypeString)}},
+						FieldID:    ptr.Int16(1),
+						ThriftName: ptr.String("key"),
 					},
 				},
 				ReturnType: &api.Type{SliceType: &api.Type{SimpleType: simpleType(api.SimpleTypeByte)}},
@@ -185,6 +337,8 @@ func TestBuildFunction(t *testing.T) {
 								},
 							},
 						},
+						FieldID:    ptr.Int16(1),
+						ThriftName: ptr.String("doesNotExist"),
 					},
 				},
 			},
@@ -212,12 +366,16 @@ func TestBuildFunction(t *testing.T) {
 				ThriftName: "setValue",
 				Arguments: []*api.Argument{
 					{
-						Name: "Key",
-						Type: &api.Type{PointerType: &api.Type{SimpleType: simpleType(api.SimpleTypeString)}},
+						Name:       "Key",
+						Type:       &api.Type{PointerType: &api.Type{SimpleType: simpleType(api.SimpleTypeString)}},
+						FieldID:    ptr.Int16(1),
+						ThriftName: ptr.String("key"),
 					},
 					{
-						Name: "Value",
-						Type: &api.Type{SliceType: &api.Type{SimpleType: simpleType(api.SimpleTypeByte)}},
+						Name:       "Value",
+						Type:       &api.Type{SliceType: &api.Type{SimpleType: simpleType(api.SimpleTypeByte)}},
+						FieldID:    ptr.Int16(2),
+						ThriftName: ptr.String("value"),
 					},
 				},
 			},
@@ -225,24 +383,30 @@ func TestBuildFunction(t *testing.T) {
 		{
 			desc: "oneway",
 			spec: &compile.FunctionSpec{
-				Name:   "clearCache",
-				OneWay: true,
+				Name:        "clearCache",
+				OneWay:      true,
+				Annotations: compile.Annotations{"rpc.timeout": "100ms"},
 				ArgsSpec: compile.ArgsSpec{
 					{
-						ID:   1,
-						Name: "delayMS",
-						Type: &compile.I64Spec{},
+						ID:          1,
+						Name:        "delayMS",
+						Type:        &compile.I64Spec{},
+						Annotations: compile.Annotations{"validate": "positive"},
 					},
 				},
 			},
 			want: &api.Function{
-				Name:       "ClearCache",
-				ThriftName: "clearCache",
-				OneWay:     ptr.Bool(true),
+				Name:        "ClearCache",
+				ThriftName:  "clearCache",
+				OneWay:      ptr.Bool(true),
+				Annotations: map[string]string{"rpc.timeout": "100ms"},
 				Arguments: []*api.Argument{
 					{
-						Name: "DelayMS",
-						Type: &api.Type{PointerType: &api.Type{SimpleType: simpleType(api.SimpleTypeInt64)}},
+						Name:        "DelayMS",
+						Type:        &api.Type{PointerType: &api.Type{SimpleType: simpleType(api.SimpleTypeInt64)}},
+						FieldID:     ptr.Int16(1),
+						ThriftName:  ptr.String("delayMS"),
+						Annotations: map[string]string{"validate": "positive"},
 					},
 				},
 			},
@@ -0,0 +1,125 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import "go.uber.org/thriftrw/compile"
+
+// presenceGenerator is implemented by Generators which may generate
+// presence methods for optional fields.
+type presenceGenerator interface {
+	presenceMethods() bool
+}
+
+// usePresenceMethods returns true if Has, Clear, and Set methods should be
+// generated for optional fields.
+func usePresenceMethods(g Generator) bool {
+	if o, ok := g.(presenceGenerator); ok {
+		return o.presenceMethods()
+	}
+	return false
+}
+
+// Presence generates methods to check, clear, and set each optional field of
+// the field group if presence methods were requested.
+//
+// 	func (v *User) HasEmail() bool
+// 	func (v *User) ClearEmail()
+// 	func (v *User) SetEmail(x string)
+//
+// Setting a field of a union clears its other fields. Methods whose names
+// conflict with fields or other methods are not generated. For fields stored
+// as values, only Has is generated here because ValueFields already
+// generates SetX and ClearX.
+func (f fieldGroupGenerator) Presence(g Generator) error {
+	if !usePresenceMethods(g) {
+		return nil
+	}
+
+	for _, field := range f.Fields {
+		if field.Required {
+			continue
+		}
+
+		name, err := goNames(g).goName(field)
+		if err != nil {
+			return err
+		}
+
+		var methods struct{ Has, Clear, Set bool }
+		methods.Has = f.Reserve("Has"+name) == nil
+		methods.Clear = f.Reserve("Clear"+name) == nil
+		methods.Set = f.Reserve("Set"+name) == nil
+
+		err = g.DeclareFromTemplate(
+			`
+			<$fname := goName .Field>
+			<$v := newVar "v">
+			<$x := newVar "x">
+			<if .Methods.Has>
+				// Has<$fname> returns true if <$fname> is set on this <.Name>.
+				func (<$v> *<.Name>) Has<$fname>() bool {
+					<if isValueField .Field>
+						return <$v> != nil && <valueFieldIsSet $v .Field>
+					<else>
+						return <$v> != nil && <$v>.<$fname> != nil
+					<end>
+				}
+			<end>
+
+			<if .Methods.Clear>
+				// Clear<$fname> unsets <$fname> on this <.Name>.
+				func (<$v> *<.Name>) Clear<$fname>() {
+					<$v>.<$fname> = nil
+				}
+			<end>
+
+			<if .Methods.Set>
+				// Set<$fname> sets <$fname> on this <.Name> to the given value.
+				func (<$v> *<.Name>) Set<$fname>(<$x> <typeReference .Field.Type>) {
+					<$value := printf "%v%v" (addressOf .Field.Type) $x>
+					<if .IsUnion>
+						*<$v> = <.Name>{<$fname>: <$value>}
+					<else>
+						<$v>.<$fname> = <$value>
+					<end>
+				}
+			<end>
+			`,
+			struct {
+				Name    string
+				Field   *compile.FieldSpec
+				IsUnion bool
+				Methods struct{ Has, Clear, Set bool }
+			}{Name: f.Name, Field: field, IsUnion: f.IsUnion, Methods: methods},
+			TemplateFunc("addressOf", func(t compile.TypeSpec) string {
+				// Optional primitives are stored as pointers.
+				if isPrimitiveType(t) {
+					return "&"
+				}
+				return ""
+			}),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
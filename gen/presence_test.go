@@ -0,0 +1,172 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"reflect"
+	"testing"
+
+	tp "go.uber.org/thriftrw/gen/testdata/features/plain/records"
+	tpr "go.uber.org/thriftrw/gen/testdata/features/presence/records"
+	"go.uber.org/thriftrw/ptr"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fieldIDs returns the IDs of the fields in the wire representation of x.
+func fieldIDs(t *testing.T, x thriftType) []int16 {
+	w, err := x.ToWire()
+	require.NoError(t, err, "failed to serialize %v", x)
+
+	ids := []int16{}
+	for _, f := range w.GetStruct().Fields {
+		ids = append(ids, f.ID)
+	}
+	return ids
+}
+
+func TestPresenceMethods(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		var u *tpr.User
+		assert.False(t, u.HasAge())
+		assert.False(t, u.HasTags())
+		assert.False(t, u.HasHome())
+	})
+
+	t.Run("scalar", func(t *testing.T) {
+		u := tpr.User{Name: "alice"}
+		assert.False(t, u.HasAge())
+
+		u.SetAge(0)
+		assert.True(t, u.HasAge(), "zero values are present")
+		assert.Equal(t, ptr.Int32(0), u.Age)
+		// The default of Active is always written.
+		assert.Equal(t, []int16{1, 2, 9}, fieldIDs(t, &u))
+
+		u.ClearAge()
+		assert.False(t, u.HasAge())
+		assert.Nil(t, u.Age)
+		assert.Equal(t, []int16{1, 9}, fieldIDs(t, &u))
+	})
+
+	t.Run("containers", func(t *testing.T) {
+		u := tpr.User{Name: "alice"}
+		u.SetTags(nil)
+		assert.False(t, u.HasTags(), "nil containers are absent")
+
+		u.SetTags([]string{})
+		assert.True(t, u.HasTags(), "empty containers are present")
+		assert.Equal(t, []int16{1, 5, 9}, fieldIDs(t, &u))
+
+		u.SetPlaces(map[string]*tpr.Point{"home": {X: 1}})
+		assert.True(t, u.HasPlaces())
+
+		u.ClearTags()
+		u.ClearPlaces()
+		assert.False(t, u.HasTags())
+		assert.False(t, u.HasPlaces())
+		assert.Equal(t, []int16{1, 9}, fieldIDs(t, &u))
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		u := tpr.User{Name: "alice"}
+		home := &tpr.Point{X: 1, Y: 2}
+		u.SetHome(home)
+		assert.True(t, u.HasHome())
+		assert.True(t, u.Home == home, "structs are not copied")
+
+		u.ClearHome()
+		assert.False(t, u.HasHome())
+	})
+
+	t.Run("default", func(t *testing.T) {
+		// Active defaults to true when it is absent on the wire.
+		var u tpr.User
+		require.NoError(t, u.FromWire(wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+			{ID: 1, Value: wire.NewValueString("alice")},
+		}})))
+		assert.True(t, u.HasActive())
+		assert.True(t, u.GetActive())
+
+		u.ClearActive()
+		assert.False(t, u.HasActive())
+		assert.True(t, u.GetActive(), "getters return the default")
+
+		u.SetActive(false)
+		assert.True(t, u.HasActive())
+		assert.False(t, u.GetActive())
+	})
+
+	t.Run("union", func(t *testing.T) {
+		var s tpr.Shape
+		s.SetPoint(&tpr.Point{X: 1})
+		assert.True(t, s.HasPoint())
+		assert.False(t, s.HasPolygon())
+
+		s.SetPolygon([]*tpr.Point{{X: 1}, {X: 2}})
+		assert.True(t, s.HasPolygon())
+		assert.False(t, s.HasPoint(), "setting a field clears the others")
+		assert.Equal(t, []int16{2}, fieldIDs(t, &s))
+
+		s.ClearPolygon()
+		assert.False(t, s.HasPolygon())
+		_, err := s.ToWire()
+		assert.Error(t, err, "unions require a field")
+	})
+}
+
+func TestPresenceMethodsOmitted(t *testing.T) {
+	tests := []struct {
+		desc    string
+		give    interface{}
+		method  string
+		present bool
+	}{
+		{desc: "required field", give: &tpr.User{}, method: "HasName"},
+		{desc: "required field", give: &tpr.User{}, method: "ClearName"},
+		{desc: "required field", give: &tpr.User{}, method: "SetName"},
+		{
+			desc:   "conflicts with the hasEmail field",
+			give:   &tpr.Profile{},
+			method: "HasEmail",
+		},
+		{
+			desc:    "no conflict",
+			give:    &tpr.Profile{},
+			method:  "SetEmail",
+			present: true,
+		},
+		{
+			desc:    "no conflict",
+			give:    &tpr.Profile{},
+			method:  "HasHasEmail",
+			present: true,
+		},
+		{desc: "option disabled", give: &tp.User{}, method: "HasAge"},
+	}
+
+	for _, tt := range tests {
+		_, ok := reflect.TypeOf(tt.give).MethodByName(tt.method)
+		assert.Equal(t, tt.present, ok, "%v: %T.%v", tt.desc, tt.give, tt.method)
+	}
+}
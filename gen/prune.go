@@ -0,0 +1,256 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+	"path"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+// symbolFilter decides which of the types, constants, and services defined
+// in the Thrift files get generated.
+//
+// Everything that a selected definition references, directly or through
+// other definitions, is generated as well.
+type symbolFilter struct {
+	// Select only types or only services. Constants are generated only if
+	// they are referenced by a selected definition.
+	OnlyTypes    bool
+	OnlyServices bool
+
+	// Patterns matched using path.Match against both, the name of a
+	// definition and its name qualified with the module name
+	// ("$module.$name"). If non-empty, only definitions matching one of
+	// these are selected.
+	Include []string
+}
+
+func (f symbolFilter) empty() bool {
+	return !f.OnlyTypes && !f.OnlyServices && len(f.Include) == 0
+}
+
+func (f symbolFilter) validate() error {
+	for _, p := range f.Include {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid symbol filter %q: %v", p, err)
+		}
+	}
+	return nil
+}
+
+func (f symbolFilter) selectType(module, name string) bool {
+	return !f.OnlyServices && f.match(module, name)
+}
+
+func (f symbolFilter) selectConstant(module, name string) bool {
+	return !f.OnlyTypes && !f.OnlyServices && f.match(module, name)
+}
+
+func (f symbolFilter) selectService(module, name string) bool {
+	return !f.OnlyTypes && f.match(module, name)
+}
+
+func (f symbolFilter) match(module, name string) bool {
+	return len(f.Include) == 0 || matchTypeName(f.Include, module, name)
+}
+
+// pruneModule returns a copy of the module tree rooted at the given module
+// with only the definitions selected by the filter and the definitions they
+// depend on.
+func pruneModule(m *compile.Module, f symbolFilter) (*compile.Module, error) {
+	if f.empty() {
+		return m, nil
+	}
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+
+	p := pruner{
+		named:     make(map[compile.TypeSpec]struct{}),
+		types:     make(map[compile.TypeSpec]struct{}),
+		constants: make(map[*compile.Constant]struct{}),
+		services:  make(map[*compile.ServiceSpec]struct{}),
+	}
+
+	var modules []*compile.Module
+	err := m.Walk(func(m *compile.Module) error {
+		modules = append(modules, m)
+		for _, spec := range m.Types {
+			p.named[spec] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range modules {
+		for _, name := range sortStringKeys(m.Types) {
+			if f.selectType(m.Name, name) {
+				p.useType(m.Types[name])
+			}
+		}
+		for _, name := range sortStringKeys(m.Constants) {
+			if f.selectConstant(m.Name, name) {
+				p.useConstant(m.Constants[name])
+			}
+		}
+		for _, name := range sortStringKeys(m.Services) {
+			if f.selectService(m.Name, name) {
+				p.useService(m.Services[name])
+			}
+		}
+	}
+
+	copies := make(map[string]*compile.Module, len(modules))
+	for _, m := range modules {
+		c := *m
+		c.Types = make(map[string]compile.TypeSpec)
+		for name, spec := range m.Types {
+			if _, ok := p.types[spec]; ok {
+				c.Types[name] = spec
+			}
+		}
+		c.Constants = make(map[string]*compile.Constant)
+		for name, constant := range m.Constants {
+			if _, ok := p.constants[constant]; ok {
+				c.Constants[name] = constant
+			}
+		}
+		c.Services = make(map[string]*compile.ServiceSpec)
+		for name, service := range m.Services {
+			if _, ok := p.services[service]; ok {
+				c.Services[name] = service
+			}
+		}
+		copies[m.ThriftPath] = &c
+	}
+
+	for _, c := range copies {
+		includes := make(map[string]*compile.IncludedModule, len(c.Includes))
+		for name, inc := range c.Includes {
+			includes[name] = &compile.IncludedModule{
+				Name:   inc.Name,
+				Module: copies[inc.Module.ThriftPath],
+			}
+		}
+		c.Includes = includes
+	}
+
+	return copies[m.ThriftPath], nil
+}
+
+// pruner records the definitions that are used by the selected definitions.
+type pruner struct {
+	// Types declared in the Thrift files, as opposed to containers and
+	// primitives.
+	named map[compile.TypeSpec]struct{}
+
+	// Definitions that will be generated.
+	types     map[compile.TypeSpec]struct{}
+	constants map[*compile.Constant]struct{}
+	services  map[*compile.ServiceSpec]struct{}
+}
+
+// useType records the given type and the types it references. It always
+// returns nil so that it may be passed to ForEachTypeReference.
+func (p *pruner) useType(spec compile.TypeSpec) error {
+	if _, named := p.named[spec]; named {
+		if _, ok := p.types[spec]; ok {
+			return nil
+		}
+		p.types[spec] = struct{}{}
+	}
+
+	if s, ok := spec.(*compile.StructSpec); ok {
+		p.useFields(s.Fields)
+		return nil
+	}
+	return spec.ForEachTypeReference(p.useType)
+}
+
+func (p *pruner) useFields(fields compile.FieldGroup) {
+	for _, f := range fields {
+		p.useType(f.Type)
+		if f.Default != nil {
+			p.useValue(f.Default)
+		}
+	}
+}
+
+func (p *pruner) useConstant(c *compile.Constant) {
+	if _, ok := p.constants[c]; ok {
+		return
+	}
+	p.constants[c] = struct{}{}
+	p.useType(c.Type)
+	p.useValue(c.Value)
+}
+
+// useValue records the constants and enums referenced by the given constant
+// value.
+func (p *pruner) useValue(v compile.ConstantValue) {
+	switch v := v.(type) {
+	case compile.ConstReference:
+		p.useConstant(v.Target)
+	case compile.EnumItemReference:
+		p.useType(v.Enum)
+	case compile.ConstantList:
+		for _, item := range v {
+			p.useValue(item)
+		}
+	case compile.ConstantSet:
+		for _, item := range v {
+			p.useValue(item)
+		}
+	case compile.ConstantMap:
+		for _, pair := range v {
+			p.useValue(pair.Key)
+			p.useValue(pair.Value)
+		}
+	case *compile.ConstantStruct:
+		for _, field := range v.Fields {
+			p.useValue(field)
+		}
+	}
+}
+
+func (p *pruner) useService(s *compile.ServiceSpec) {
+	if _, ok := p.services[s]; ok {
+		return
+	}
+	p.services[s] = struct{}{}
+
+	if s.Parent != nil {
+		p.useService(s.Parent)
+	}
+	for _, f := range s.Functions {
+		p.useFields(compile.FieldGroup(f.ArgsSpec))
+		if f.ResultSpec != nil {
+			if f.ResultSpec.ReturnType != nil {
+				p.useType(f.ResultSpec.ReturnType)
+			}
+			p.useFields(f.ResultSpec.Exceptions)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneModule(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-prune-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for path, contents := range map[string]string{
+		"shared/shared.thrift": `
+			typedef string UUID
+			enum Role { USER, ADMIN }
+			struct Audit { 1: optional string actor }
+			exception NotFound { 1: optional string message }
+
+			const Role DefaultRole = Role.USER
+
+			service Base {
+				void health()
+			}
+		`,
+		"main.thrift": `
+			include "./shared/shared.thrift"
+
+			struct User {
+				1: required shared.UUID id
+				2: optional shared.Role role = shared.DefaultRole
+			}
+			struct Unused { 1: optional list<shared.Audit> audits }
+
+			const string Version = "1"
+
+			service Users extends shared.Base {
+				User getUser(1: string id) throws (1: shared.NotFound notFound)
+			}
+			service Admins {
+				void audit(1: shared.Audit audit)
+			}
+		`,
+	} {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+
+	module, err := compile.Compile(filepath.Join(dir, "main.thrift"))
+	require.NoError(t, err)
+
+	type definitions struct {
+		Types, Constants, Services []string
+	}
+
+	namesOf := func(m *compile.Module) definitions {
+		var d definitions
+		for name := range m.Types {
+			d.Types = append(d.Types, name)
+		}
+		for name := range m.Constants {
+			d.Constants = append(d.Constants, name)
+		}
+		for name := range m.Services {
+			d.Services = append(d.Services, name)
+		}
+		sort.Strings(d.Types)
+		sort.Strings(d.Constants)
+		sort.Strings(d.Services)
+		return d
+	}
+
+	t.Run("no filter", func(t *testing.T) {
+		got, err := pruneModule(module, symbolFilter{})
+		require.NoError(t, err)
+		assert.True(t, got == module, "module must not be copied without filters")
+	})
+
+	tests := []struct {
+		desc       string
+		filter     symbolFilter
+		wantMain   definitions
+		wantShared definitions
+	}{
+		{
+			desc:   "only types",
+			filter: symbolFilter{OnlyTypes: true},
+			wantMain: definitions{
+				Types: []string{"Unused", "User"},
+			},
+			wantShared: definitions{
+				Types:     []string{"Audit", "NotFound", "Role", "UUID"},
+				Constants: []string{"DefaultRole"},
+			},
+		},
+		{
+			desc:   "only services",
+			filter: symbolFilter{OnlyServices: true},
+			wantMain: definitions{
+				Types:    []string{"User"},
+				Services: []string{"Admins", "Users"},
+			},
+			wantShared: definitions{
+				Types:     []string{"Audit", "NotFound", "Role", "UUID"},
+				Constants: []string{"DefaultRole"},
+				Services:  []string{"Base"},
+			},
+		},
+		{
+			desc:   "include service",
+			filter: symbolFilter{Include: []string{"main.Users"}},
+			wantMain: definitions{
+				Types:    []string{"User"},
+				Services: []string{"Users"},
+			},
+			wantShared: definitions{
+				Types:     []string{"NotFound", "Role", "UUID"},
+				Constants: []string{"DefaultRole"},
+				Services:  []string{"Base"},
+			},
+		},
+		{
+			desc:   "include pattern",
+			filter: symbolFilter{Include: []string{"Ver*", "Unused"}},
+			wantMain: definitions{
+				Types:     []string{"Unused"},
+				Constants: []string{"Version"},
+			},
+			wantShared: definitions{
+				Types: []string{"Audit"},
+			},
+		},
+		{
+			desc:   "include with only types",
+			filter: symbolFilter{OnlyTypes: true, Include: []string{"Ver*", "Users", "shared.Role"}},
+			wantShared: definitions{
+				Types: []string{"Role"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := pruneModule(module, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMain, namesOf(got), "main.thrift")
+			assert.Equal(t, tt.wantShared, namesOf(got.Includes["shared"].Module), "shared.thrift")
+
+			// The original module is left untouched.
+			assert.Len(t, module.Types, 2)
+			assert.Len(t, module.Services, 2)
+			assert.Len(t, module.Includes["shared"].Module.Types, 4)
+		})
+	}
+
+	t.Run("bad pattern", func(t *testing.T) {
+		_, err := pruneModule(module, symbolFilter{Include: []string{"User["}})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), `invalid symbol filter "User["`)
+		}
+	})
+}
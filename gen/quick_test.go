@@ -21,16 +21,26 @@
 package gen
 
 import (
+	"bytes"
 	"math/rand"
 	"reflect"
+	"strings"
 	"testing"
 	"testing/quick"
 	"time"
 
 	tc "go.uber.org/thriftrw/gen/testdata/containers"
+	te "go.uber.org/thriftrw/gen/testdata/enums"
+	tx "go.uber.org/thriftrw/gen/testdata/exceptions"
+	tv "go.uber.org/thriftrw/gen/testdata/services"
 	ts "go.uber.org/thriftrw/gen/testdata/structs"
+	td "go.uber.org/thriftrw/gen/testdata/typedefs"
+	tu "go.uber.org/thriftrw/gen/testdata/unions"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestQuickRoundTrip(t *testing.T) {
@@ -65,6 +75,9 @@ func TestQuickRoundTrip(t *testing.T) {
 			if result[1].Interface() != nil {
 				continue // invalid value generated
 			}
+			if wire.EvaluateValue(result[0].Interface().(wire.Value)) != nil {
+				continue // invalid value generated, like duplicate map keys
+			}
 
 			i++ // increment i only if we found a valid sample
 
@@ -80,3 +93,181 @@ func TestQuickRoundTrip(t *testing.T) {
 		}
 	}
 }
+
+// thriftValueGenerator generates random values for types generated by
+// thriftrw that are always valid: required fields are set, unions have
+// exactly one field set, and recursive types are bounded in depth.
+type thriftValueGenerator struct {
+	rand *rand.Rand
+
+	// Types which are unions.
+	unions map[reflect.Type]struct{}
+}
+
+// maxQuickDepth is the depth beyond which optional fields are left unset
+// and collections are empty.
+const maxQuickDepth = 3
+
+func (g *thriftValueGenerator) Value(t reflect.Type) reflect.Value {
+	return g.value(t, 0)
+}
+
+func (g *thriftValueGenerator) value(t reflect.Type, depth int) reflect.Value {
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Ptr:
+		v.Set(g.value(t.Elem(), depth).Addr())
+	case reflect.Struct:
+		g.fill(v, depth+1)
+	case reflect.Slice:
+		n := g.length(depth)
+		v.Set(reflect.MakeSlice(t, 0, n))
+		for i := 0; i < n; i++ {
+			item := g.value(t.Elem(), depth+1)
+			if isMapItem(t.Elem()) && hasKey(v, item.Field(0)) {
+				continue // maps represented as slices must not repeat keys
+			}
+			v.Set(reflect.Append(v, item))
+		}
+	case reflect.Map:
+		v.Set(reflect.MakeMap(t))
+		for i := g.length(depth); i > 0; i-- {
+			v.SetMapIndex(g.value(t.Key(), depth+1), g.value(t.Elem(), depth+1))
+		}
+	case reflect.String:
+		v.SetString(g.string())
+	default:
+		// Scalars have no constraints.
+		scalar, ok := quick.Value(t, g.rand)
+		if !ok {
+			panic("cannot generate values of type " + t.String())
+		}
+		v.Set(scalar)
+	}
+	return v
+}
+
+// isMapItem returns true if t is the type of the items of maps whose keys are
+// not hashable: struct{Key K; Value V}.
+func isMapItem(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.Name() == "" && t.NumField() == 2 &&
+		t.Field(0).Name == "Key" && t.Field(1).Name == "Value"
+}
+
+// hasKey returns true if the given slice of map items has an item with the
+// given key.
+func hasKey(items reflect.Value, key reflect.Value) bool {
+	for i := 0; i < items.Len(); i++ {
+		if reflect.DeepEqual(items.Index(i).Field(0).Interface(), key.Interface()) {
+			return true
+		}
+	}
+	return false
+}
+
+// fill sets the fields of the given struct.
+func (g *thriftValueGenerator) fill(v reflect.Value, depth int) {
+	t := v.Type()
+	if _, isUnion := g.unions[t]; isUnion {
+		if t.NumField() > 0 {
+			i := g.rand.Intn(t.NumField())
+			v.Field(i).Set(g.value(t.Field(i).Type, depth))
+		}
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+
+		optional := strings.HasSuffix(f.Tag.Get("json"), ",omitempty")
+		nillable := f.Type.Kind() == reflect.Ptr ||
+			f.Type.Kind() == reflect.Slice ||
+			f.Type.Kind() == reflect.Map
+		if optional && nillable && (depth > maxQuickDepth || g.rand.Intn(2) == 0) {
+			continue
+		}
+		v.Field(i).Set(g.value(f.Type, depth))
+	}
+}
+
+func (g *thriftValueGenerator) length(depth int) int {
+	if depth > maxQuickDepth {
+		return 0
+	}
+	return g.rand.Intn(4)
+}
+
+func (g *thriftValueGenerator) string() string {
+	b := make([]rune, g.rand.Intn(8))
+	for i := range b {
+		b[i] = rune('a' + g.rand.Intn(26))
+	}
+	return string(b)
+}
+
+func TestQuickRoundTripGenerated(t *testing.T) {
+	unions := []reflect.Type{
+		reflect.TypeOf(tu.ArbitraryValue{}),
+		reflect.TypeOf(tu.Document{}),
+		reflect.TypeOf(tv.KeyValue_GetValue_Result{}),
+		reflect.TypeOf(tv.KeyValue_DeleteValue_Result{}),
+		reflect.TypeOf(tv.KeyValue_GetManyValues_Result{}),
+	}
+
+	tests := append([]reflect.Type{
+		reflect.TypeOf(tc.PrimitiveContainers{}),
+		reflect.TypeOf(tc.PrimitiveContainersRequired{}),
+		reflect.TypeOf(tc.EnumContainers{}),
+		reflect.TypeOf(tc.ContainersOfContainers{}),
+		reflect.TypeOf(tc.MapOfBinaryAndString{}),
+		reflect.TypeOf(te.StructWithOptionalEnum{}),
+		reflect.TypeOf(tx.DoesNotExistException{}),
+		reflect.TypeOf(ts.PrimitiveRequiredStruct{}),
+		reflect.TypeOf(ts.PrimitiveOptionalStruct{}),
+		reflect.TypeOf(ts.Frame{}),
+		reflect.TypeOf(ts.Edge{}),
+		reflect.TypeOf(ts.Graph{}),
+		reflect.TypeOf(ts.Tree{}),
+		reflect.TypeOf(ts.User{}),
+		reflect.TypeOf(td.Event{}),
+		reflect.TypeOf(td.Transition{}),
+		reflect.TypeOf(td.I128{}),
+		reflect.TypeOf(tv.KeyValue_SetValue_Args{}),
+		reflect.TypeOf(tv.KeyValue_GetManyValues_Args{}),
+	}, unions...)
+
+	seed := time.Now().UnixNano()
+	gen := thriftValueGenerator{
+		rand:   rand.New(rand.NewSource(seed)),
+		unions: make(map[reflect.Type]struct{}),
+	}
+	for _, u := range unions {
+		gen.unions[u] = struct{}{}
+	}
+
+	const attempts = 200
+	for _, tt := range tests {
+		for i := 0; i < attempts; i++ {
+			give := gen.Value(reflect.PtrTo(tt)).Interface()
+
+			v, err := give.(thriftType).ToWire()
+			require.NoError(t, err, "%v: generated invalid value %v (seed %d)", tt, give, seed)
+
+			// Go through the binary protocol to catch encoding issues as
+			// well.
+			var buff bytes.Buffer
+			require.NoError(t, protocol.Binary.Encode(v, &buff), "%v (seed %d)", tt, seed)
+			v, err = protocol.Binary.Decode(bytes.NewReader(buff.Bytes()), wire.TStruct)
+			require.NoError(t, err, "%v (seed %d)", tt, seed)
+
+			got := reflect.New(tt).Interface().(thriftType)
+			require.NoError(t, got.FromWire(v), "%v (seed %d)", tt, seed)
+			if !assert.Equal(t, give, got, "%v did not round trip (seed %d)", tt, seed) {
+				break
+			}
+		}
+	}
+}
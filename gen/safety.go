@@ -0,0 +1,133 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+// Annotation on exceptions specifying whether the request that raised them
+// may be retried safely.
+//
+// 	exception NotFound {
+// 		...
+// 	} (safety = "safe")
+const safetyAnnotation = "safety"
+
+// exceptionSafety is the value of the safety annotation of an exception.
+type exceptionSafety string
+
+// Valid values for the safety annotation.
+const (
+	safetyUnspecified exceptionSafety = ""
+	safetySafe        exceptionSafety = "safe"
+	safetyUnsafe      exceptionSafety = "unsafe"
+)
+
+// Methods returns the names of the methods that will be generated for this
+// safety.
+func (s exceptionSafety) Methods() []string {
+	if s == safetyUnspecified {
+		return nil
+	}
+	return []string{"Safe"}
+}
+
+// exceptionSafetyAnnotation parses the safety annotation of the given struct.
+// hasErrorMethod specifies whether the struct will be generated as an error.
+func exceptionSafetyAnnotation(spec *compile.StructSpec, hasErrorMethod bool) (exceptionSafety, error) {
+	s, ok := spec.ThriftAnnotations()[safetyAnnotation]
+	if !ok {
+		return safetyUnspecified, nil
+	}
+
+	safety := exceptionSafety(s)
+	if safety != safetySafe && safety != safetyUnsafe {
+		return safetyUnspecified, fmt.Errorf(
+			"invalid %v annotation %q: must be %q or %q",
+			safetyAnnotation, s, safetySafe, safetyUnsafe)
+	}
+
+	if !hasErrorMethod {
+		return safetyUnspecified, fmt.Errorf(
+			"%v annotation is supported on exceptions only", safetyAnnotation)
+	}
+
+	return safety, nil
+}
+
+// safetyMethods generates the Safe method for the type with the given name.
+func safetyMethods(g Generator, name string, safety exceptionSafety) error {
+	return g.DeclareFromTemplate(
+		`
+		<$v := newVar "v">
+		func (<$v> *<.Name>) Safe() bool {
+			return <.Safe>
+		}
+		`,
+		struct {
+			Name string
+			Safe bool
+		}{Name: name, Safe: safety == safetySafe},
+	)
+}
+
+// functionIsSafeException generates an expression that provides the
+// IsSafeException function for the given Thrift function.
+func functionIsSafeException(g Generator, f *compile.FunctionSpec) (string, error) {
+	var safe []*compile.FieldSpec
+	for _, e := range f.ResultSpec.Exceptions {
+		spec, ok := e.Type.(*compile.StructSpec)
+		if !ok {
+			continue
+		}
+
+		safety, err := exceptionSafetyAnnotation(spec, true)
+		if err != nil {
+			return "", err
+		}
+		if safety == safetySafe {
+			safe = append(safe, e)
+		}
+	}
+
+	return g.TextTemplate(
+		`
+		<if .>
+			func(err error) bool {
+				switch err.(type) {
+				<range .>
+					case <typeReferencePtr .Type>:
+						return true
+				<end>
+				default:
+					return false
+				}
+			}
+		<else>
+			func(error) bool {
+				return false
+			}
+		<end>
+		`, safe)
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/compile"
+	tx "go.uber.org/thriftrw/gen/testdata/exceptions"
+	tv "go.uber.org/thriftrw/gen/testdata/services"
+	"go.uber.org/thriftrw/retry"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExceptionSafetyAnnotation(t *testing.T) {
+	tests := []struct {
+		desc        string
+		annotations compile.Annotations
+		notError    bool
+		want        exceptionSafety
+		wantMethods []string
+		wantError   string
+	}{
+		{desc: "none"},
+		{
+			desc:        "safe",
+			annotations: compile.Annotations{"safety": "safe"},
+			want:        safetySafe,
+			wantMethods: []string{"Safe"},
+		},
+		{
+			desc:        "unsafe",
+			annotations: compile.Annotations{"safety": "unsafe"},
+			want:        safetyUnsafe,
+			wantMethods: []string{"Safe"},
+		},
+		{
+			desc:        "invalid",
+			annotations: compile.Annotations{"safety": "transient"},
+			wantError:   `invalid safety annotation "transient": must be "safe" or "unsafe"`,
+		},
+		{
+			desc:        "not an error",
+			annotations: compile.Annotations{"safety": "safe"},
+			notError:    true,
+			wantError:   "safety annotation is supported on exceptions only",
+		},
+	}
+
+	for _, tt := range tests {
+		spec := &compile.StructSpec{
+			Name:        "Foo",
+			Type:        ast.ExceptionType,
+			Annotations: tt.annotations,
+		}
+		got, err := exceptionSafetyAnnotation(spec, !tt.notError)
+		if tt.wantError != "" {
+			if assert.Error(t, err, tt.desc) {
+				assert.Contains(t, err.Error(), tt.wantError, tt.desc)
+			}
+			continue
+		}
+		if assert.NoError(t, err, tt.desc) {
+			assert.Equal(t, tt.want, got, tt.desc)
+			assert.Equal(t, tt.wantMethods, got.Methods(), tt.desc)
+		}
+	}
+}
+
+func TestSafetyMethods(t *testing.T) {
+	safe, ok := retry.Safety(&tx.DoesNotExistException{Key: "foo"})
+	assert.True(t, ok)
+	assert.True(t, safe)
+
+	safe, ok = retry.Safety(&tv.InternalError{})
+	assert.True(t, ok)
+	assert.False(t, safe)
+
+	_, ok = retry.Safety(&tx.EmptyException{})
+	assert.False(t, ok)
+}
+
+func TestIsSafeException(t *testing.T) {
+	tests := []struct {
+		desc            string
+		isSafeException func(error) bool
+		err             error
+		want            bool
+	}{
+		{
+			desc:            "safe exception",
+			isSafeException: tv.KeyValue_DeleteValue_Helper.IsSafeException,
+			err:             &tx.DoesNotExistException{},
+			want:            true,
+		},
+		{
+			desc:            "unsafe exception",
+			isSafeException: tv.KeyValue_DeleteValue_Helper.IsSafeException,
+			err:             &tv.InternalError{},
+		},
+		{
+			desc:            "unknown error",
+			isSafeException: tv.KeyValue_DeleteValue_Helper.IsSafeException,
+			err:             errors.New("great sadness"),
+		},
+		{
+			desc:            "undeclared safe exception",
+			isSafeException: tv.KeyValue_Size_Helper.IsSafeException,
+			err:             &tx.DoesNotExistException{},
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.isSafeException(tt.err), tt.desc)
+	}
+}
+
+func TestSafetyMethodsReserved(t *testing.T) {
+	fg := fieldGroupGenerator{
+		Name:           "NotFound",
+		HasErrorMethod: true,
+		ExtraMethods:   safetySafe.Methods(),
+	}
+
+	err := fg.checkReservedIdentifier("Safe")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `"Safe" is a reserved ThriftRW identifier`)
+	}
+}
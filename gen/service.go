@@ -60,10 +60,19 @@ func Service(g Generator, s *compile.ServiceSpec) (map[string]*bytes.Buffer, err
 
 // ServiceFunction generates code for the given function of the given service.
 func ServiceFunction(g Generator, s *compile.ServiceSpec, f *compile.FunctionSpec) error {
+	// Arguments are passed to and returned from helpers as pointers.
+	for _, arg := range f.ArgsSpec {
+		if _, ok := arg.Annotations[goPointerAnnotation]; ok {
+			return wrapGenerateError(fmt.Sprintf("%s.%s", s.Name, f.Name), fmt.Errorf(
+				"invalid go.pointer annotation: argument %q must be a pointer", arg.Name))
+		}
+	}
+
 	argsGen := fieldGroupGenerator{
 		Namespace: NewNamespace(),
-		Name:      functionNamePrefix(s, f) + "Args",
+		Name:      functionNamePrefix(g, s, f) + "Args",
 		Fields:    compile.FieldGroup(f.ArgsSpec),
+		Doc:       f.Doc,
 	}
 	if err := argsGen.Generate(g); err != nil {
 		return wrapGenerateError(fmt.Sprintf("%s.%s", s.Name, f.Name), err)
@@ -76,6 +85,10 @@ func ServiceFunction(g Generator, s *compile.ServiceSpec, f *compile.FunctionSpe
 		return wrapGenerateError(fmt.Sprintf("%s.%s", s.Name, f.Name), err)
 	}
 
+	if err := functionArgsOptions(g, s, f); err != nil {
+		return wrapGenerateError(fmt.Sprintf("%s.%s", s.Name, f.Name), err)
+	}
+
 	if f.ResultSpec == nil {
 		return nil
 	}
@@ -92,7 +105,7 @@ func ServiceFunction(g Generator, s *compile.ServiceSpec, f *compile.FunctionSpe
 
 	resultGen := fieldGroupGenerator{
 		Namespace:       NewNamespace(),
-		Name:            functionNamePrefix(s, f) + "Result",
+		Name:            functionNamePrefix(g, s, f) + "Result",
 		Fields:          resultFields,
 		IsUnion:         true,
 		AllowEmptyUnion: f.ResultSpec.ReturnType == nil,
@@ -103,6 +116,9 @@ func ServiceFunction(g Generator, s *compile.ServiceSpec, f *compile.FunctionSpe
 	if err := functionResponseEnveloper(g, s, f); err != nil {
 		return wrapGenerateError(fmt.Sprintf("%s.%s", s.Name, f.Name), err)
 	}
+	if err := functionResultConstructors(g, s, f); err != nil {
+		return wrapGenerateError(fmt.Sprintf("%s.%s", s.Name, f.Name), err)
+	}
 
 	// TODO(abg): If we receive unknown exceptions over the wire, we need to
 	// throw a generic error.
@@ -132,8 +148,10 @@ func functionHelper(g Generator, s *compile.ServiceSpec, f *compile.FunctionSpec
 
 		var <$prefix>Helper = struct{
 			Args func(<params $f>) *<$prefix>Args
+			DecodeArgs func(<import "go.uber.org/thriftrw/protocol">.Protocol, []byte) (*<$prefix>Args, error)
 			<if not $f.OneWay>
 				IsException func(error) bool
+				IsSafeException func(error) bool
 				<if $f.ResultSpec.ReturnType>
 					WrapResponse func(
 						<typeReference $f.ResultSpec.ReturnType>,
@@ -149,8 +167,10 @@ func functionHelper(g Generator, s *compile.ServiceSpec, f *compile.FunctionSpec
 
 		func init() {
 			<$prefix>Helper.Args = <newArgs .Service $f>
+			<$prefix>Helper.DecodeArgs = <decodeArgs .Service $f>
 			<if not $f.OneWay>
 				<$prefix>Helper.IsException = <isException $f>
+				<$prefix>Helper.IsSafeException = <isSafeException $f>
 				<$prefix>Helper.WrapResponse = <wrapResponse .Service $f>
 				<$prefix>Helper.UnwrapResponse = <unwrapResponse .Service $f>
 			<end>
@@ -165,13 +185,90 @@ func functionHelper(g Generator, s *compile.ServiceSpec, f *compile.FunctionSpec
 		},
 		TemplateFunc("params", functionParams),
 		TemplateFunc("isException", functionIsException),
+		TemplateFunc("isSafeException", functionIsSafeException),
 		TemplateFunc("newArgs", functionNewArgs),
+		TemplateFunc("decodeArgs", functionDecodeArgs),
 		TemplateFunc("wrapResponse", functionWrapResponse),
 		TemplateFunc("unwrapResponse", functionUnwrapResponse),
-		TemplateFunc("namePrefix", functionNamePrefix),
+		TemplateFunc("namePrefix", curryGenerator(functionNamePrefix, g)),
 	)
 }
 
+// functionArgsOptions generates functional options for the optional
+// arguments of the given function, along with a constructor which accepts the
+// required arguments followed by options.
+//
+// 	args := KeyValue_SetValue_NewArgs(KeyValue_SetValue_WithKey("foo"))
+//
+// Nothing is generated for functions which don't have optional arguments.
+func functionArgsOptions(g Generator, s *compile.ServiceSpec, f *compile.FunctionSpec) error {
+	hasOptional := false
+	for _, arg := range f.ArgsSpec {
+		if !arg.Required {
+			hasOptional = true
+			break
+		}
+	}
+	if !hasOptional {
+		return nil
+	}
+
+	return g.DeclareFromTemplate(
+		`
+		<$f := .Function>
+		<$prefix := namePrefix .Service $f>
+
+		type <$prefix>ArgsOption func(*<$prefix>Args)
+
+		<$v := newVar "v">
+		<$args := newVar "args">
+		<range $f.ArgsSpec>
+			<if not .Required>
+				func <$prefix>With<goCase .Name>(<$v> <typeReference .Type>) <$prefix>ArgsOption {
+					return func(<$args> *<$prefix>Args) {
+						<if isPrimitiveType .Type>
+							<$args>.<goCase .Name> = &<$v>
+						<else>
+							<$args>.<goCase .Name> = <$v>
+						<end>
+					}
+				}
+			<end>
+		<end>
+
+		<$params := newNamespace>
+		func <$prefix>NewArgs(
+			<range $f.ArgsSpec>
+				<if .Required>
+					<$params.NewName .Name> <typeReference .Type>,
+				<end>
+			<end>
+			<$params.NewName "opts"> ...<$prefix>ArgsOption,
+		) *<$prefix>Args {
+			<$args := $params.NewName "args">
+			<$args> := &<$prefix>Args{
+			<range $f.ArgsSpec>
+				<if .Required>
+					<goCase .Name>: <$params.Rotate .Name>,
+				<end>
+			<end>
+			}
+			for _, opt := range <$params.Rotate "opts"> {
+				opt(<$args>)
+			}
+			return <$args>
+		}
+		`,
+		struct {
+			Service  *compile.ServiceSpec
+			Function *compile.FunctionSpec
+		}{
+			Service:  s,
+			Function: f,
+		},
+		TemplateFunc("namePrefix", curryGenerator(functionNamePrefix, g)))
+}
+
 // functionIsException generates an expression that provides the IsException
 // function for the given Thrift function.
 func functionIsException(g Generator, f *compile.FunctionSpec) (string, error) {
@@ -225,7 +322,7 @@ func functionNewArgs(g Generator, s *compile.ServiceSpec, f *compile.FunctionSpe
 			Service:  s,
 			Function: f,
 		},
-		TemplateFunc("namePrefix", functionNamePrefix))
+		TemplateFunc("namePrefix", curryGenerator(functionNamePrefix, g)))
 }
 
 // functionWrapResponse generates an expression that provides the WrapResponse
@@ -274,7 +371,7 @@ func functionWrapResponse(g Generator, s *compile.ServiceSpec, f *compile.Functi
 			Service:  s,
 			Function: f,
 		},
-		TemplateFunc("namePrefix", functionNamePrefix))
+		TemplateFunc("namePrefix", curryGenerator(functionNamePrefix, g)))
 }
 
 // functionUnwrapResponse generates an expression that provides the
@@ -326,7 +423,68 @@ func functionUnwrapResponse(g Generator, s *compile.ServiceSpec, f *compile.Func
 			Service:  s,
 			Function: f,
 		},
-		TemplateFunc("namePrefix", functionNamePrefix))
+		TemplateFunc("namePrefix", curryGenerator(functionNamePrefix, g)))
+}
+
+// functionResultConstructors generates constructors for the result of the
+// given function from its return value or from an error returned by the
+// handler.
+//
+// 	result := KeyValue_GetValue_NewResultSuccess(value)
+// 	result, err := KeyValue_GetValue_NewResultError(&KeyDoesNotExist{})
+//
+// NewResultError fails if the error is not one of the exceptions declared by
+// the function.
+func functionResultConstructors(g Generator, s *compile.ServiceSpec, f *compile.FunctionSpec) error {
+	return g.DeclareFromTemplate(
+		`
+		<$f := .Function>
+		<$prefix := namePrefix .Service $f>
+
+		<$success := newVar "success">
+		<if $f.ResultSpec.ReturnType>
+			func <$prefix>NewResultSuccess(<$success> <typeReference $f.ResultSpec.ReturnType>) *<$prefix>Result {
+				<if isPrimitiveType $f.ResultSpec.ReturnType>
+					return &<$prefix>Result{Success: &<$success>}
+				<else>
+					return &<$prefix>Result{Success: <$success>}
+				<end>
+			}
+		<else>
+			func <$prefix>NewResultSuccess() *<$prefix>Result {
+				return &<$prefix>Result{}
+			}
+		<end>
+
+		<$errors := import "errors">
+		<$err := newVar "err">
+		func <$prefix>NewResultError(<$err> error) (*<$prefix>Result, error) {
+			if <$err> == nil {
+				return nil, <$errors>.New("<$prefix>NewResultError received a nil error")
+			}
+			<if $f.ResultSpec.Exceptions>
+				<$e := newVar "e">
+				switch <$e> := <$err>.(type) {
+					<range $f.ResultSpec.Exceptions>
+					case <typeReferencePtr .Type>:
+						if <$e> == nil {
+							return nil, <$errors>.New(
+								"<$prefix>NewResultError received non-nil error type with nil value for <$prefix>Result.<goCase .Name>")
+						}
+						return &<$prefix>Result{<goCase .Name>: <$e>}, nil
+					<end>
+				}
+			<end>
+			return nil, <$err>
+		}
+		`, struct {
+			Service  *compile.ServiceSpec
+			Function *compile.FunctionSpec
+		}{
+			Service:  s,
+			Function: f,
+		},
+		TemplateFunc("namePrefix", curryGenerator(functionNamePrefix, g)))
 }
 
 func functionArgsEnveloper(g Generator, s *compile.ServiceSpec, f *compile.FunctionSpec) error {
@@ -353,7 +511,7 @@ func functionArgsEnveloper(g Generator, s *compile.ServiceSpec, f *compile.Funct
 			Service:  s,
 			Function: f,
 		},
-		TemplateFunc("namePrefix", functionNamePrefix))
+		TemplateFunc("namePrefix", curryGenerator(functionNamePrefix, g)))
 
 }
 
@@ -380,10 +538,11 @@ func functionResponseEnveloper(g Generator, s *compile.ServiceSpec, f *compile.F
 			Service:  s,
 			Function: f,
 		},
-		TemplateFunc("namePrefix", functionNamePrefix))
+		TemplateFunc("namePrefix", curryGenerator(functionNamePrefix, g)))
 
 }
 
-func functionNamePrefix(s *compile.ServiceSpec, f *compile.FunctionSpec) string {
-	return fmt.Sprintf("%s_%s_", goCase(s.Name), goCase(f.Name))
+func functionNamePrefix(g Generator, s *compile.ServiceSpec, f *compile.FunctionSpec) string {
+	names := goNames(g)
+	return fmt.Sprintf("%s_%s_", names.goCase(s.Name), names.goCase(f.Name))
 }
@@ -238,6 +238,66 @@ func TestServiceArgs(t *testing.T) {
 	}
 }
 
+func TestServiceArgsOptions(t *testing.T) {
+	tests := []struct {
+		desc   string
+		input  interface{}
+		output interface{}
+	}{
+		{
+			desc:   "no options",
+			input:  tv.KeyValue_SetValue_NewArgs(),
+			output: &tv.KeyValue_SetValue_Args{},
+		},
+		{
+			desc: "all optional",
+			input: tv.KeyValue_SetValue_NewArgs(
+				tv.KeyValue_SetValue_WithKey("foo"),
+				tv.KeyValue_SetValue_WithValue(&tu.ArbitraryValue{BoolValue: boolp(true)}),
+			),
+			output: &tv.KeyValue_SetValue_Args{
+				Key:   (*tv.Key)(stringp("foo")),
+				Value: &tu.ArbitraryValue{BoolValue: boolp(true)},
+			},
+		},
+		{
+			desc:   "reserved keyword",
+			input:  tv.KeyValue_GetManyValues_NewArgs(tv.KeyValue_GetManyValues_WithRange([]tv.Key{"a", "b"})),
+			output: &tv.KeyValue_GetManyValues_Args{Range: []tv.Key{"a", "b"}},
+		},
+		{
+			desc:   "required only",
+			input:  tv.Cache_ClearMatching_NewArgs("foo"),
+			output: &tv.Cache_ClearMatching_Args{Prefix: "foo"},
+		},
+		{
+			desc: "required and optional",
+			input: tv.Cache_ClearMatching_NewArgs(
+				"foo",
+				tv.Cache_ClearMatching_WithExclude([]tv.Key{"foobar"}),
+				tv.Cache_ClearMatching_WithDurationMS(42),
+			),
+			output: &tv.Cache_ClearMatching_Args{
+				Prefix:     "foo",
+				DurationMS: ptr.Int64(42),
+				Exclude:    []tv.Key{"foobar"},
+			},
+		},
+		{
+			desc: "later options win",
+			input: tv.Cache_ClearAfter_NewArgs(
+				tv.Cache_ClearAfter_WithDurationMS(1),
+				tv.Cache_ClearAfter_WithDurationMS(2),
+			),
+			output: &tv.Cache_ClearAfter_Args{DurationMS: ptr.Int64(2)},
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.output, tt.input, tt.desc)
+	}
+}
+
 func TestServiceIsException(t *testing.T) {
 	tests := []struct {
 		isException func(error) bool
@@ -403,6 +463,83 @@ func TestWrapResponseTypedNilError(t *testing.T) {
 	}
 }
 
+func TestNewResultSuccess(t *testing.T) {
+	assert.Equal(t, &tv.KeyValue_SetValue_Result{}, tv.KeyValue_SetValue_NewResultSuccess())
+	assert.Equal(t, &tv.KeyValue_DeleteValue_Result{}, tv.KeyValue_DeleteValue_NewResultSuccess())
+	assert.Equal(t, &tv.KeyValue_Size_Result{Success: int64p(42)}, tv.KeyValue_Size_NewResultSuccess(42))
+	assert.Equal(t,
+		&tv.KeyValue_GetValue_Result{Success: &tu.ArbitraryValue{BoolValue: boolp(true)}},
+		tv.KeyValue_GetValue_NewResultSuccess(&tu.ArbitraryValue{BoolValue: boolp(true)}))
+}
+
+func TestNewResultError(t *testing.T) {
+	tests := []struct {
+		desc           string
+		run            func() (interface{}, error)
+		expectedResult interface{}
+		expectedError  string
+	}{
+		{
+			desc: "getValue exception",
+			run: func() (interface{}, error) {
+				return tv.KeyValue_GetValue_NewResultError(&tx.DoesNotExistException{Key: "foo"})
+			},
+			expectedResult: &tv.KeyValue_GetValue_Result{
+				DoesNotExist: &tx.DoesNotExistException{Key: "foo"},
+			},
+		},
+		{
+			desc: "deleteValue second exception",
+			run: func() (interface{}, error) {
+				return tv.KeyValue_DeleteValue_NewResultError(&tv.InternalError{})
+			},
+			expectedResult: &tv.KeyValue_DeleteValue_Result{
+				InternalError: &tv.InternalError{},
+			},
+		},
+		{
+			desc: "undeclared error",
+			run: func() (interface{}, error) {
+				return tv.KeyValue_DeleteValue_NewResultError(errors.New("foo"))
+			},
+			expectedError: "foo",
+		},
+		{
+			desc: "no exceptions",
+			run: func() (interface{}, error) {
+				return tv.KeyValue_Size_NewResultError(errors.New("foo"))
+			},
+			expectedError: "foo",
+		},
+		{
+			desc: "nil error",
+			run: func() (interface{}, error) {
+				return tv.KeyValue_SetValue_NewResultError(nil)
+			},
+			expectedError: "KeyValue_SetValue_NewResultError received a nil error",
+		},
+		{
+			desc: "typed nil exception",
+			run: func() (interface{}, error) {
+				return tv.KeyValue_GetValue_NewResultError((*tx.DoesNotExistException)(nil))
+			},
+			expectedError: "received non-nil error type with nil value for KeyValue_GetValue_Result.DoesNotExist",
+		},
+	}
+
+	for _, tt := range tests {
+		result, err := tt.run()
+		if tt.expectedError != "" {
+			if assert.Error(t, err, tt.desc) {
+				assert.Contains(t, err.Error(), tt.expectedError, tt.desc)
+			}
+		} else {
+			assert.NoError(t, err, tt.desc)
+			assert.Equal(t, tt.expectedResult, result, tt.desc)
+		}
+	}
+}
+
 func TestUnwrapResponse(t *testing.T) {
 	tests := []struct {
 		desc           string
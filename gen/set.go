@@ -39,6 +39,10 @@ type setGenerator struct{}
 // And $valueListName is returned. This may be used where a ValueList of the
 // given type is expected.
 func (s *setGenerator) ValueList(g Generator, spec *compile.SetSpec) (string, error) {
+	if useGenerics(g) {
+		return s.genericValueList(g, spec)
+	}
+
 	name := valueListName(g, spec)
 	err := g.EnsureDeclared(
 		`
@@ -94,6 +98,10 @@ func (s *setGenerator) ValueList(g Generator, spec *compile.SetSpec) (string, er
 }
 
 func (s *setGenerator) Reader(g Generator, spec *compile.SetSpec) (string, error) {
+	if useGenerics(g) {
+		return s.genericReader(g, spec)
+	}
+
 	name := readerFuncName(g, spec)
 	err := g.EnsureDeclared(
 		`
@@ -104,7 +112,7 @@ func (s *setGenerator) Reader(g Generator, spec *compile.SetSpec) (string, error
 			<$i := newVar "i">
 			<$o := newVar "o">
 			<$x := newVar "x">
-			func <.Name>(<$s> <$wire>.ValueList) (<$setType>, error) {
+			func <.Name>(<$s> <$wire>.ValueList<if useArena>, <arenaVar> *<import "go.uber.org/thriftrw/arena">.Arena<end>) (<$setType>, error) {
 				if <$s>.ValueType() != <typeCode .Spec.ValueSpec> {
 					return nil, nil
 				}
@@ -139,6 +147,64 @@ func (s *setGenerator) Reader(g Generator, spec *compile.SetSpec) (string, error
 	return name, wrapGenerateError(spec.ThriftName(), err)
 }
 
+// genericValueList generates a function which builds a ValueList for the
+// given set with thriftset.Encode, or thriftset.EncodeSlice if its items
+// are not hashable, and returns its name.
+func (s *setGenerator) genericValueList(g Generator, spec *compile.SetSpec) (string, error) {
+	name := valueListName(g, spec)
+	err := g.EnsureDeclared(
+		`
+			<$wire := import "go.uber.org/thriftrw/wire">
+			<$thriftset := import "go.uber.org/thriftrw/thriftset">
+
+			<$v := newVar "v">
+			<$x := newVar "x">
+			func <.Name>(<$v> <typeReference .Spec>) <$wire>.ValueList {
+				return <$thriftset>.Encode<if not (isHashable .Spec.ValueSpec)>Slice<end>(<$v>, <typeCode .Spec.ValueSpec>, func(<$x> <typeReference .Spec.ValueSpec>) (<$wire>.Value, error) {
+					<if not (isPrimitiveType .Spec.ValueSpec)>
+						if <$x> == nil {
+							return <$wire>.Value{}, <$thriftset>.ErrNil
+						}
+					<end>
+					return <toWire .Spec.ValueSpec $x>
+				})
+			}
+		`,
+		struct {
+			Name string
+			Spec *compile.SetSpec
+		}{Name: name, Spec: spec},
+	)
+
+	return name, wrapGenerateError(spec.ThriftName(), err)
+}
+
+// genericReader generates a function to read a set of the given type from
+// a wire.Set with thriftset.Decode or thriftset.DecodeSlice.
+func (s *setGenerator) genericReader(g Generator, spec *compile.SetSpec) (string, error) {
+	name := readerFuncName(g, spec)
+	err := g.EnsureDeclared(
+		`
+			<$wire := import "go.uber.org/thriftrw/wire">
+			<$thriftset := import "go.uber.org/thriftrw/thriftset">
+
+			<$s := newVar "s">
+			<$x := newVar "x">
+			func <.Name>(<$s> <$wire>.ValueList<if useArena>, <arenaVar> *<import "go.uber.org/thriftrw/arena">.Arena<end>) (<typeReference .Spec>, error) {
+				return <$thriftset>.Decode<if not (isHashable .Spec.ValueSpec)>Slice<end>(<$s>, <typeCode .Spec.ValueSpec>, func(<$x> <$wire>.Value) (<typeReference .Spec.ValueSpec>, error) {
+					return <fromWire .Spec.ValueSpec $x>
+				})
+			}
+		`,
+		struct {
+			Name string
+			Spec *compile.SetSpec
+		}{Name: name, Spec: spec},
+	)
+
+	return name, wrapGenerateError(spec.ThriftName(), err)
+}
+
 // Equals generates a function to compare sets of the given type
 //
 // func $name(lhs, rhs $setType) bool {
@@ -0,0 +1,158 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/statuscode"
+)
+
+// Annotations on exceptions specifying the status codes that gateways
+// should use to report them.
+//
+// 	exception NotFound {
+// 		...
+// 	} (http.status = "404", grpc.code = "NOT_FOUND")
+//
+// rpc.code specifies the error code with which RPC transports report the
+// exception. YARPC and gRPC share the same set of codes so this also serves
+// as the gRPC status code if grpc.code is not specified.
+//
+// 	exception InvalidUser {
+// 		...
+// 	} (rpc.code = "INVALID_ARGUMENT")
+const (
+	httpStatusAnnotation = "http.status"
+	grpcCodeAnnotation   = "grpc.code"
+	rpcCodeAnnotation    = "rpc.code"
+)
+
+// statusCodes holds the status codes specified for an exception.
+type statusCodes struct {
+	HTTPStatus int    // zero if unspecified
+	GRPCCode   string // empty if unspecified
+	RPCCode    string // empty if unspecified
+}
+
+// Methods returns the names of the methods that will be generated for these
+// status codes.
+func (c statusCodes) Methods() []string {
+	var methods []string
+	if c.HTTPStatus != 0 {
+		methods = append(methods, "HTTPStatus")
+	}
+	if c.GRPCCode != "" {
+		methods = append(methods, "GRPCCode")
+	}
+	if c.RPCCode != "" {
+		methods = append(methods, "RPCCode")
+	}
+	return methods
+}
+
+// statusCodeAnnotations parses the http.status, grpc.code, and rpc.code
+// annotations of the given struct. hasErrorMethod specifies whether the struct will be
+// generated as an error.
+func statusCodeAnnotations(spec *compile.StructSpec, hasErrorMethod bool) (statusCodes, error) {
+	var codes statusCodes
+	annotations := spec.ThriftAnnotations()
+
+	if s, ok := annotations[httpStatusAnnotation]; ok {
+		status, err := strconv.Atoi(s)
+		if err != nil || status < 100 || status > 599 {
+			return codes, fmt.Errorf(
+				"invalid %v annotation %q: must be an HTTP status code between 100 and 599",
+				httpStatusAnnotation, s)
+		}
+		codes.HTTPStatus = status
+	}
+
+	if code, ok := annotations[grpcCodeAnnotation]; ok {
+		if _, ok := statuscode.GRPCCodeValue(code); !ok || code == "OK" {
+			return codes, fmt.Errorf(
+				"invalid %v annotation %q: must be the name of a gRPC status code other than OK",
+				grpcCodeAnnotation, code)
+		}
+		codes.GRPCCode = code
+	}
+
+	if code, ok := annotations[rpcCodeAnnotation]; ok {
+		if _, ok := statuscode.GRPCCodeValue(code); !ok || code == "OK" {
+			return codes, fmt.Errorf(
+				"invalid %v annotation %q: must be the name of an RPC error code other than OK",
+				rpcCodeAnnotation, code)
+		}
+		if codes.GRPCCode != "" && codes.GRPCCode != code {
+			return codes, fmt.Errorf(
+				"%v annotation %q conflicts with %v annotation %q: "+
+					"specify only %v if the codes are the same",
+				rpcCodeAnnotation, code, grpcCodeAnnotation, codes.GRPCCode, rpcCodeAnnotation)
+		}
+		codes.RPCCode = code
+	}
+
+	if !hasErrorMethod && (codes.HTTPStatus != 0 || codes.GRPCCode != "") {
+		return codes, fmt.Errorf(
+			"%v and %v annotations are supported on exceptions only",
+			httpStatusAnnotation, grpcCodeAnnotation)
+	}
+
+	if !hasErrorMethod && codes.RPCCode != "" {
+		return codes, fmt.Errorf(
+			"%v annotations are supported on exceptions only", rpcCodeAnnotation)
+	}
+
+	return codes, nil
+}
+
+// statusCodeMethods generates the HTTPStatus, GRPCCode, and RPCCode methods
+// for the type with the given name.
+func statusCodeMethods(g Generator, name string, codes statusCodes) error {
+	return g.DeclareFromTemplate(
+		`
+		<$v := newVar "v">
+		<if .Codes.HTTPStatus>
+			func (<$v> *<.Name>) HTTPStatus() int {
+				return <.Codes.HTTPStatus>
+			}
+		<end>
+
+		<if .Codes.GRPCCode>
+			func (<$v> *<.Name>) GRPCCode() string {
+				return "<.Codes.GRPCCode>"
+			}
+		<end>
+
+		<if .Codes.RPCCode>
+			func (<$v> *<.Name>) RPCCode() string {
+				return "<.Codes.RPCCode>"
+			}
+		<end>
+		`,
+		struct {
+			Name  string
+			Codes statusCodes
+		}{Name: name, Codes: codes},
+	)
+}
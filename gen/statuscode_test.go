@@ -0,0 +1,194 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"testing"
+
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/compile"
+	tx "go.uber.org/thriftrw/gen/testdata/exceptions"
+	"go.uber.org/thriftrw/statuscode"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusCodeAnnotations(t *testing.T) {
+	tests := []struct {
+		desc        string
+		annotations compile.Annotations
+		notError    bool
+		want        statusCodes
+		wantMethods []string
+		wantError   string
+	}{
+		{desc: "none"},
+		{
+			desc:        "http",
+			annotations: compile.Annotations{"http.status": "404"},
+			want:        statusCodes{HTTPStatus: 404},
+			wantMethods: []string{"HTTPStatus"},
+		},
+		{
+			desc:        "grpc",
+			annotations: compile.Annotations{"grpc.code": "NOT_FOUND"},
+			want:        statusCodes{GRPCCode: "NOT_FOUND"},
+			wantMethods: []string{"GRPCCode"},
+		},
+		{
+			desc: "both",
+			annotations: compile.Annotations{
+				"http.status": "503",
+				"grpc.code":   "UNAVAILABLE",
+			},
+			want:        statusCodes{HTTPStatus: 503, GRPCCode: "UNAVAILABLE"},
+			wantMethods: []string{"HTTPStatus", "GRPCCode"},
+		},
+		{
+			desc:        "rpc",
+			annotations: compile.Annotations{"rpc.code": "INVALID_ARGUMENT"},
+			want:        statusCodes{RPCCode: "INVALID_ARGUMENT"},
+			wantMethods: []string{"RPCCode"},
+		},
+		{
+			desc: "grpc and rpc",
+			annotations: compile.Annotations{
+				"grpc.code": "NOT_FOUND",
+				"rpc.code":  "NOT_FOUND",
+			},
+			want:        statusCodes{GRPCCode: "NOT_FOUND", RPCCode: "NOT_FOUND"},
+			wantMethods: []string{"GRPCCode", "RPCCode"},
+		},
+		{
+			desc:        "http not a number",
+			annotations: compile.Annotations{"http.status": "NotFound"},
+			wantError:   `invalid http.status annotation "NotFound"`,
+		},
+		{
+			desc:        "http out of range",
+			annotations: compile.Annotations{"http.status": "42"},
+			wantError:   `invalid http.status annotation "42"`,
+		},
+		{
+			desc:        "grpc unknown code",
+			annotations: compile.Annotations{"grpc.code": "not_found"},
+			wantError:   `invalid grpc.code annotation "not_found"`,
+		},
+		{
+			desc:        "grpc OK",
+			annotations: compile.Annotations{"grpc.code": "OK"},
+			wantError:   `invalid grpc.code annotation "OK"`,
+		},
+		{
+			desc:        "rpc unknown code",
+			annotations: compile.Annotations{"rpc.code": "invalid-argument"},
+			wantError:   `invalid rpc.code annotation "invalid-argument"`,
+		},
+		{
+			desc:        "rpc OK",
+			annotations: compile.Annotations{"rpc.code": "OK"},
+			wantError:   `invalid rpc.code annotation "OK"`,
+		},
+		{
+			desc: "grpc and rpc conflict",
+			annotations: compile.Annotations{
+				"grpc.code": "NOT_FOUND",
+				"rpc.code":  "INVALID_ARGUMENT",
+			},
+			wantError: `rpc.code annotation "INVALID_ARGUMENT" conflicts with grpc.code annotation "NOT_FOUND"`,
+		},
+		{
+			desc:        "rpc not an error",
+			annotations: compile.Annotations{"rpc.code": "INTERNAL"},
+			notError:    true,
+			wantError:   "rpc.code annotations are supported on exceptions only",
+		},
+		{
+			desc:        "not an error",
+			annotations: compile.Annotations{"http.status": "404"},
+			notError:    true,
+			wantError:   "http.status and grpc.code annotations are supported on exceptions only",
+		},
+	}
+
+	for _, tt := range tests {
+		spec := &compile.StructSpec{
+			Name:        "Foo",
+			Type:        ast.ExceptionType,
+			Annotations: tt.annotations,
+		}
+		got, err := statusCodeAnnotations(spec, !tt.notError)
+		if tt.wantError != "" {
+			if assert.Error(t, err, tt.desc) {
+				assert.Contains(t, err.Error(), tt.wantError, tt.desc)
+			}
+			continue
+		}
+		if assert.NoError(t, err, tt.desc) {
+			assert.Equal(t, tt.want, got, tt.desc)
+			assert.Equal(t, tt.wantMethods, got.Methods(), tt.desc)
+		}
+	}
+}
+
+func TestStatusCodeMethods(t *testing.T) {
+	var err error = &tx.DoesNotExistException{Key: "foo"}
+
+	status, ok := statuscode.HTTPStatus(err)
+	if assert.True(t, ok) {
+		assert.Equal(t, 404, status)
+	}
+
+	code, ok := statuscode.GRPCCode(err)
+	if assert.True(t, ok) {
+		assert.Equal(t, "NOT_FOUND", code)
+	}
+
+	err = &tx.InvalidArgumentException{}
+	code, ok = statuscode.RPCCode(err)
+	if assert.True(t, ok) {
+		assert.Equal(t, "INVALID_ARGUMENT", code)
+	}
+	_, ok = statuscode.HTTPStatus(err)
+	assert.False(t, ok)
+
+	err = &tx.EmptyException{}
+	_, ok = statuscode.RPCCode(err)
+	assert.False(t, ok)
+	_, ok = statuscode.HTTPStatus(err)
+	assert.False(t, ok)
+	_, ok = statuscode.GRPCCode(err)
+	assert.False(t, ok)
+}
+
+func TestStatusCodeMethodsReserved(t *testing.T) {
+	fg := fieldGroupGenerator{
+		Name:           "NotFound",
+		HasErrorMethod: true,
+		ExtraMethods:   statusCodes{HTTPStatus: 404}.Methods(),
+	}
+
+	err := fg.checkReservedIdentifier("HTTPStatus")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `"HTTPStatus" is a reserved ThriftRW identifier`)
+	}
+	assert.NoError(t, fg.checkReservedIdentifier("GRPCCode"))
+}
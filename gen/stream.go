@@ -0,0 +1,235 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+const goStreamAnnotation = "go.stream"
+
+// streamAnnotation returns true if the given field is a list, set, or map
+// annotated with (go.stream = "true").
+//
+// 	1: required list<Point> points (go.stream = "true")
+func streamAnnotation(f *compile.FieldSpec) (bool, error) {
+	value, ok := f.Annotations[goStreamAnnotation]
+	if !ok || value == "false" {
+		return false, nil
+	}
+	if value != "true" {
+		return false, fmt.Errorf(
+			"invalid go.stream annotation %q on field %q: expected true or false", value, f.Name)
+	}
+
+	switch compile.RootTypeSpec(f.Type).(type) {
+	case *compile.ListSpec, *compile.SetSpec, *compile.MapSpec:
+		return true, nil
+	default:
+		return false, fmt.Errorf(
+			"invalid go.stream annotation: field %q is not a list, set, or map", f.Name)
+	}
+}
+
+// streamedField is a field whose elements may be handed to a callback
+// instead of being collected.
+type streamedField struct {
+	Field *compile.FieldSpec
+	Name  string // Go name of the field
+
+	// Kind is one of "list", "set", or "map". Spec is the root type of the
+	// field of that kind.
+	Kind string
+	Spec compile.TypeSpec
+}
+
+// streamsTypeName returns the name of the struct holding the element
+// callbacks for the given struct.
+func streamsTypeName(name string) string {
+	return name + "_Streams"
+}
+
+// Streams generates a FromWireStreams method for the field group if any of
+// its fields have a go.stream annotation.
+//
+// 	type Batch_Streams struct {
+// 		Points func(*Point) error
+// 	}
+//
+// 	func (v *Batch) FromWireStreams(w wire.Value, s Batch_Streams) error
+//
+// FromWireStreams decodes the struct like FromWire except that the elements
+// of streamed fields with non-nil callbacks are passed to the callbacks one
+// at a time. Because the Binary protocol reads collections lazily, this
+// allows processing collections larger than the available memory if the
+// value was decoded from a file. Streamed fields are left empty.
+//
+// With the allocator option, a FromWireStreamsArena method is generated as
+// well.
+func (f fieldGroupGenerator) Streams(g Generator) error {
+	var fields []streamedField
+	for _, field := range f.Fields {
+		ok, err := streamAnnotation(field)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		if _, ok := lookupSubstitution(g, field.Type); ok {
+			return fmt.Errorf(
+				"invalid go.stream annotation: field %q has a substituted type", field.Name)
+		}
+
+		name, err := goNames(g).goName(field)
+		if err != nil {
+			return err
+		}
+
+		sf := streamedField{Field: field, Name: name, Spec: compile.RootTypeSpec(field.Type)}
+		switch sf.Spec.(type) {
+		case *compile.ListSpec:
+			sf.Kind = "list"
+		case *compile.SetSpec:
+			sf.Kind = "set"
+		case *compile.MapSpec:
+			sf.Kind = "map"
+		}
+		fields = append(fields, sf)
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	methods := []string{"FromWireStreams"}
+	if useArenaAllocation(g) {
+		methods = append(methods, "FromWireStreamsArena")
+	}
+	for _, m := range methods {
+		if err := f.Reserve(m); err != nil {
+			return fmt.Errorf(
+				"cannot generate %v for %q: the name is already used by the struct", m, f.Name)
+		}
+	}
+
+	return g.DeclareFromTemplate(
+		`
+		<$wire := import "go.uber.org/thriftrw/wire">
+		<$streams := streamsTypeName .Name>
+
+		type <$streams> struct {
+			<range .Fields>
+				<if eq .Kind "map">
+					<.Name> func(<typeReference .Spec.KeySpec>, <typeReference .Spec.ValueSpec>) error
+				<else>
+					<.Name> func(<typeReference .Spec.ValueSpec>) error
+				<end>
+			<end>
+		}
+
+		<$v := newVar "v">
+		<$w := newVar "w">
+		<$s := newVar "s">
+		<$f := newVar "field">
+		<$fields := newVar "fields">
+		<$l := newVar "l">
+		<$m := newVar "m">
+		<$x := newVar "x">
+		<$k := newVar "k">
+		<$i := newVar "i">
+
+		<if useArena>
+			<$arena := import "go.uber.org/thriftrw/arena">
+			func (<$v> *<.Name>) FromWireStreams(<$w> <$wire>.Value, <$s> <$streams>) error {
+				return <$v>.FromWireStreamsArena(<$w>, <$s>, nil)
+			}
+
+			func (<$v> *<.Name>) FromWireStreamsArena(<$w> <$wire>.Value, <$s> <$streams>, <arenaVar> *<$arena>.Arena) error {
+		<else>
+			func (<$v> *<.Name>) FromWireStreams(<$w> <$wire>.Value, <$s> <$streams>) error {
+		<end>
+			<$fields> := make([]<$wire>.Field, 0, len(<$w>.GetStruct().Fields))
+			for _, <$f> := range <$w>.GetStruct().Fields {
+				switch <$f>.ID {
+				<range .Fields>
+				case <.Field.ID>:
+					if <$s>.<.Name> != nil && <$f>.Value.Type() == <typeCode .Spec> {
+						<if eq .Kind "map">
+							<$l> := <$f>.Value.GetMap()
+							if <$l>.KeyType() == <typeCode .Spec.KeySpec> && <$l>.ValueType() == <typeCode .Spec.ValueSpec> {
+								err := <$l>.ForEach(func(<$x> <$wire>.MapItem) error {
+									<$k>, err := <fromWire .Spec.KeySpec (printf "%s.Key" $x)>
+									if err != nil {
+										return err
+									}
+									<$m>, err := <fromWire .Spec.ValueSpec (printf "%s.Value" $x)>
+									if err != nil {
+										return err
+									}
+									return <$s>.<.Name>(<$k>, <$m>)
+								})
+								<$l>.Close()
+								if err != nil {
+									return err
+								}
+							}
+							<$f>.Value = <$wire>.NewValueMap(<$wire>.MapItemListFromSlice(<$l>.KeyType(), <$l>.ValueType(), nil))
+						<else>
+							<$l> := <$f>.Value.Get<if eq .Kind "set">Set<else>List<end>()
+							if <$l>.ValueType() == <typeCode .Spec.ValueSpec> {
+								err := <$l>.ForEach(func(<$x> <$wire>.Value) error {
+									<$i>, err := <fromWire .Spec.ValueSpec $x>
+									if err != nil {
+										return err
+									}
+									return <$s>.<.Name>(<$i>)
+								})
+								<$l>.Close()
+								if err != nil {
+									return err
+								}
+							}
+							<$f>.Value = <$wire>.NewValue<if eq .Kind "set">Set<else>List<end>(<$wire>.ValueListFromSlice(<$l>.ValueType(), nil))
+						<end>
+					}
+				<end>
+				}
+				<$fields> = append(<$fields>, <$f>)
+			}
+			<$value := printf "%s.NewValueStruct(%s.Struct{Fields: %s})" $wire $wire $fields>
+			<if useArena>
+				return <$v>.FromWireArena(<$value>, <arenaVar>)
+			<else>
+				return <$v>.FromWire(<$value>)
+			<end>
+		}
+		`,
+		struct {
+			Name   string
+			Fields []streamedField
+		}{Name: f.Name, Fields: fields},
+		TemplateFunc("streamsTypeName", streamsTypeName),
+	)
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+	ts "go.uber.org/thriftrw/gen/testdata/structs"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/ptr"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamAnnotation(t *testing.T) {
+	tests := []struct {
+		desc      string
+		give      string
+		typ       compile.TypeSpec
+		want      bool
+		wantError string
+	}{
+		{desc: "list", give: "true", typ: &compile.ListSpec{ValueSpec: &compile.I32Spec{}}, want: true},
+		{desc: "set", give: "true", typ: &compile.SetSpec{ValueSpec: &compile.I32Spec{}}, want: true},
+		{
+			desc: "map",
+			give: "true",
+			typ:  &compile.MapSpec{KeySpec: &compile.StringSpec{}, ValueSpec: &compile.I32Spec{}},
+			want: true,
+		},
+		{desc: "disabled", give: "false", typ: &compile.ListSpec{ValueSpec: &compile.I32Spec{}}},
+		{
+			desc:      "invalid value",
+			give:      "yes",
+			typ:       &compile.ListSpec{ValueSpec: &compile.I32Spec{}},
+			wantError: `invalid go.stream annotation "yes" on field "foo": expected true or false`,
+		},
+		{
+			desc:      "not a collection",
+			give:      "true",
+			typ:       &compile.StringSpec{},
+			wantError: `invalid go.stream annotation: field "foo" is not a list, set, or map`,
+		},
+	}
+
+	for _, tt := range tests {
+		spec := &compile.FieldSpec{
+			Name:        "foo",
+			Type:        tt.typ,
+			Annotations: compile.Annotations{"go.stream": tt.give},
+		}
+
+		got, err := streamAnnotation(spec)
+		if tt.wantError != "" {
+			if assert.Error(t, err, tt.desc) {
+				assert.Contains(t, err.Error(), tt.wantError, tt.desc)
+			}
+			continue
+		}
+
+		if assert.NoError(t, err, tt.desc) {
+			assert.Equal(t, tt.want, got, tt.desc)
+		}
+	}
+}
+
+// encodePointStream serializes the given value and returns a lazily decoded
+// wire.Value for it.
+func encodePointStream(t *testing.T, v *ts.PointStream) wire.Value {
+	w, err := v.ToWire()
+	require.NoError(t, err)
+
+	var buff bytes.Buffer
+	require.NoError(t, protocol.Binary.Encode(w, &buff))
+
+	w, err = protocol.Binary.Decode(bytes.NewReader(buff.Bytes()), wire.TStruct)
+	require.NoError(t, err)
+	return w
+}
+
+func TestFromWireStreams(t *testing.T) {
+	give := &ts.PointStream{
+		Points: []*ts.Point{{X: 1, Y: 2}, {X: 3, Y: 4}},
+		Counts: map[string]int32{"a": 1, "b": 2},
+		Tags:   map[string]struct{}{"foo": {}, "bar": {}},
+		Name:   ptr.String("hello"),
+	}
+
+	var (
+		points []*ts.Point
+		counts = make(map[string]int32)
+		tags   []string
+	)
+
+	var got ts.PointStream
+	require.NoError(t, got.FromWireStreams(encodePointStream(t, give), ts.PointStream_Streams{
+		Points: func(p *ts.Point) error {
+			points = append(points, p)
+			return nil
+		},
+		Counts: func(k string, v int32) error {
+			counts[k] = v
+			return nil
+		},
+		Tags: func(tag string) error {
+			tags = append(tags, tag)
+			return nil
+		},
+	}))
+
+	assert.Equal(t, give.Points, points)
+	assert.Equal(t, give.Counts, counts)
+	sort.Strings(tags)
+	assert.Equal(t, []string{"bar", "foo"}, tags)
+
+	assert.Equal(t, "hello", got.GetName(), "other fields must be decoded")
+	assert.Empty(t, got.Points, "streamed fields must be left empty")
+	assert.Empty(t, got.Counts, "streamed fields must be left empty")
+	assert.Empty(t, got.Tags, "streamed fields must be left empty")
+}
+
+func TestFromWireStreamsWithoutCallbacks(t *testing.T) {
+	give := &ts.PointStream{
+		Points: []*ts.Point{{X: 1, Y: 2}},
+		Counts: map[string]int32{"a": 1},
+	}
+
+	var got ts.PointStream
+	require.NoError(t, got.FromWireStreams(encodePointStream(t, give), ts.PointStream_Streams{}))
+	assert.True(t, give.Equals(&got), "fields without callbacks must be decoded normally")
+}
+
+func TestFromWireStreamsErrors(t *testing.T) {
+	give := &ts.PointStream{Points: []*ts.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}
+
+	var calls int
+	var got ts.PointStream
+	err := got.FromWireStreams(encodePointStream(t, give), ts.PointStream_Streams{
+		Points: func(*ts.Point) error {
+			calls++
+			return errors.New("great sadness")
+		},
+	})
+	assert.EqualError(t, err, "great sadness")
+	assert.Equal(t, 1, calls, "decoding must stop after the first error")
+
+	// Required streamed fields are still required.
+	err = got.FromWireStreams(wire.NewValueStruct(wire.Struct{}), ts.PointStream_Streams{
+		Points: func(*ts.Point) error { return nil },
+	})
+	assert.Error(t, err)
+}
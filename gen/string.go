@@ -40,12 +40,52 @@ func isAllCaps(s string) bool {
 	return true
 }
 
+// nameMapper converts Thrift names into Go identifiers.
+//
+// The zero value follows the default conventions: names are converted to
+// PascalCase and common initialisms like ID and HTTP are written in all
+// caps.
+type nameMapper struct {
+	// Initialisms are written in all caps in addition to the common
+	// initialisms.
+	Initialisms map[string]struct{}
+
+	// Preserve keeps the words of Thrift names unchanged. Only their first
+	// letters are capitalized.
+	Preserve bool
+}
+
+// newNameMapper builds a nameMapper which writes the given initialisms in
+// all caps, or preserves the Thrift names if preserve is true.
+func newNameMapper(initialisms []string, preserve bool) nameMapper {
+	m := nameMapper{Preserve: preserve}
+	if len(initialisms) > 0 {
+		m.Initialisms = make(map[string]struct{}, len(initialisms))
+		for _, s := range initialisms {
+			m.Initialisms[strings.ToUpper(s)] = struct{}{}
+		}
+	}
+	return m
+}
+
+func (m nameMapper) isInitialism(s string) bool {
+	if commonInitialisms[s] {
+		return true
+	}
+	_, ok := m.Initialisms[s]
+	return ok
+}
+
 // pascalCase combines the given words using PascalCase.
 //
 // If allowAllCaps is true, when an all-caps word that is not a known
 // abbreviation is encountered, it is left unchanged. Otherwise, it is
 // Titlecased.
-func pascalCase(allowAllCaps bool, words ...string) string {
+func (m nameMapper) pascalCase(allowAllCaps bool, words ...string) string {
+	if m.Preserve {
+		return capitalize(strings.Join(words, "_"))
+	}
+
 	for i, chunk := range words {
 		if len(chunk) == 0 {
 			// foo__bar
@@ -54,7 +94,7 @@ func pascalCase(allowAllCaps bool, words ...string) string {
 
 		// known initalism
 		init := strings.ToUpper(chunk)
-		if _, ok := commonInitialisms[init]; ok {
+		if m.isInitialism(init) {
 			words[i] = init
 			continue
 		}
@@ -69,29 +109,80 @@ func pascalCase(allowAllCaps bool, words ...string) string {
 
 		// Just another word, but could already be camelCased somehow, so just
 		// change the first letter.
-		head, headIndex := utf8.DecodeRuneInString(chunk)
-		words[i] = string(unicode.ToUpper(head)) + string(chunk[headIndex:])
+		words[i] = capitalize(chunk)
 	}
 
 	return strings.Join(words, "")
 }
 
-func constantName(s string) string {
-	return pascalCase(false /* all caps */, strings.Split(s, "_")...)
+// capitalize changes the first letter of the given string to upper case.
+func capitalize(s string) string {
+	head, headIndex := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(head)) + string(s[headIndex:])
+}
+
+func (m nameMapper) constantName(s string) string {
+	return m.pascalCase(false /* all caps */, strings.Split(s, "_")...)
 }
 
 // goCase converts strings into PascalCase.
-func goCase(s string) string {
+func (m nameMapper) goCase(s string) string {
 	if len(s) == 0 {
 		panic(fmt.Sprintf("%q is not a valid identifier", s))
 	}
 
 	words := strings.Split(s, "_")
-	return pascalCase(len(words) == 1 /* all caps */, words...)
+	return m.pascalCase(len(words) == 1 /* all caps */, words...)
 	// goCase allows all caps only if the string is a single all caps word.
 	// That is, "FOO" is allowed but "FOO_BAR" is changed to "FooBar".
 }
 
+func (m nameMapper) goNameForNamedEntity(e compile.NamedEntity) (name string, fromAnnotation bool, err error) {
+	fromAnnotation = true
+	name, err = goNameAnnotation(e)
+	if err == nil && name == "" {
+		name = m.goCase(e.ThriftName())
+		fromAnnotation = false
+	}
+	return name, fromAnnotation, err
+}
+
+func (m nameMapper) goName(e compile.NamedEntity) (string, error) {
+	name, _, err := m.goNameForNamedEntity(e)
+	return name, err
+}
+
+// nameMapperGenerator is implemented by Generators which may use
+// non-default conventions for the names of generated identifiers.
+type nameMapperGenerator interface {
+	nameMapper() nameMapper
+}
+
+// goNames returns the nameMapper which converts Thrift names into Go
+// identifiers for the given Generator.
+func goNames(g Generator) nameMapper {
+	if o, ok := g.(nameMapperGenerator); ok {
+		return o.nameMapper()
+	}
+	return nameMapper{}
+}
+
+// pascalCase, constantName, goCase, and goName follow the default
+// conventions for names. They are used for unexported identifiers and where
+// no Generator is available.
+
+func pascalCase(allowAllCaps bool, words ...string) string {
+	return nameMapper{}.pascalCase(allowAllCaps, words...)
+}
+
+func constantName(s string) string {
+	return nameMapper{}.constantName(s)
+}
+
+func goCase(s string) string {
+	return nameMapper{}.goCase(s)
+}
+
 // goNameAnnotation returns ("", nil) if there is no "go.name" annotation.
 func goNameAnnotation(e compile.NamedEntity) (string, error) {
 	name, ok := e.ThriftAnnotations()["go.name"]
@@ -118,19 +209,14 @@ func goNameAnnotation(e compile.NamedEntity) (string, error) {
 	return name, nil
 }
 
-func goNameForNamedEntity(e compile.NamedEntity) (name string, fromAnnotation bool, err error) {
-	fromAnnotation = true
-	name, err = goNameAnnotation(e)
-	if err == nil && name == "" {
-		name = goCase(e.ThriftName())
-		fromAnnotation = false
-	}
-	return name, fromAnnotation, err
+func goName(e compile.NamedEntity) (string, error) {
+	return nameMapper{}.goName(e)
 }
 
-func goName(e compile.NamedEntity) (string, error) {
-	name, _, err := goNameForNamedEntity(e)
-	return name, err
+// GoName returns the name of the Go identifier generated for the given
+// Thrift entity, taking go.name annotations into account.
+func GoName(e compile.NamedEntity) (string, error) {
+	return goName(e)
 }
 
 // This set is taken from https://github.com/golang/lint/blob/master/lint.go#L692
@@ -115,3 +115,55 @@ func TestConstantName(t *testing.T) {
 		assert.Equal(t, tt.want, constantName(tt.give))
 	}
 }
+
+func TestNameMapper(t *testing.T) {
+	tests := []struct {
+		desc        string
+		initialisms []string
+		preserve    bool
+		give        string
+		want        string
+		wantConst   string
+	}{
+		{
+			desc:      "default",
+			give:      "sku_id",
+			want:      "SkuID",
+			wantConst: "SkuID",
+		},
+		{
+			desc:        "initialisms",
+			initialisms: []string{"sku", "URN"},
+			give:        "sku_urn",
+			want:        "SKUURN",
+			wantConst:   "SKUURN",
+		},
+		{
+			desc:        "common initialisms are kept",
+			initialisms: []string{"SKU"},
+			give:        "http_sku",
+			want:        "HTTPSKU",
+			wantConst:   "HTTPSKU",
+		},
+		{
+			desc:      "preserve",
+			preserve:  true,
+			give:      "user_id",
+			want:      "User_id",
+			wantConst: "User_id",
+		},
+		{
+			desc:      "preserve all caps",
+			preserve:  true,
+			give:      "MAX_USERS",
+			want:      "MAX_USERS",
+			wantConst: "MAX_USERS",
+		},
+	}
+
+	for _, tt := range tests {
+		m := newNameMapper(tt.initialisms, tt.preserve)
+		assert.Equal(t, tt.want, m.goCase(tt.give), tt.desc)
+		assert.Equal(t, tt.wantConst, m.constantName(tt.give), tt.desc)
+	}
+}
@@ -29,6 +29,15 @@ import (
 type structGenerator struct{}
 
 func (s *structGenerator) Reader(g Generator, spec *compile.StructSpec) (string, error) {
+	var alloc string
+	if useArenaAllocation(g) {
+		var err error
+		alloc, err = s.Allocator(g, spec)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	name := readerFuncName(g, spec)
 	err := g.EnsureDeclared(
 		`
@@ -36,40 +45,73 @@ func (s *structGenerator) Reader(g Generator, spec *compile.StructSpec) (string,
 
 		<$v := newVar "v">
 		<$w := newVar "w">
-		func <.Name>(<$w> <$wire>.Value) (<typeReference .Spec>, error) {
-			var <$v> <typeName .Spec>
-			err := <$v>.FromWire(<$w>)
-			return &<$v>, err
-		}
+		<if .Alloc>
+			<$arena := import "go.uber.org/thriftrw/arena">
+			func <.Name>(<$w> <$wire>.Value, <.Arena> *<$arena>.Arena) (<typeReference .Spec>, error) {
+				<$v> := <.Alloc>(<.Arena>)
+				err := <$v>.FromWireArena(<$w>, <.Arena>)
+				return <$v>, err
+			}
+		<else>
+			func <.Name>(<$w> <$wire>.Value) (<typeReference .Spec>, error) {
+				var <$v> <typeName .Spec>
+				err := <$v>.FromWire(<$w>)
+				return &<$v>, err
+			}
+		<end>
 		`,
 		struct {
-			Name string
-			Spec *compile.StructSpec
-		}{Name: name, Spec: spec},
+			Name  string
+			Spec  *compile.StructSpec
+			Alloc string
+			Arena string
+		}{Name: name, Spec: spec, Alloc: alloc, Arena: arenaVarName},
 	)
 
 	return name, wrapGenerateError(spec.ThriftName(), err)
 }
 
 func structure(g Generator, spec *compile.StructSpec) error {
-	name, err := goName(spec)
+	name, err := goNames(g).goName(spec)
 	if err != nil {
 		return err
 	}
 
+	ifaces, err := goImplementsAnnotation(spec)
+	if err != nil {
+		return wrapGenerateError(spec.ThriftName(), err)
+	}
+	hasErrorMethod := spec.Type == ast.ExceptionType || implementsError(ifaces)
+
+	codes, err := statusCodeAnnotations(spec, hasErrorMethod)
+	if err != nil {
+		return wrapGenerateError(spec.ThriftName(), err)
+	}
+
+	safety, err := exceptionSafetyAnnotation(spec, hasErrorMethod)
+	if err != nil {
+		return wrapGenerateError(spec.ThriftName(), err)
+	}
+
 	fg := fieldGroupGenerator{
-		Namespace:   NewNamespace(),
-		Name:        name,
-		Fields:      spec.Fields,
-		IsUnion:     spec.Type == ast.UnionType,
-		IsException: spec.Type == ast.ExceptionType,
+		Namespace:      NewNamespace(),
+		Name:           name,
+		Fields:         spec.Fields,
+		Doc:            spec.Doc,
+		IsUnion:        spec.Type == ast.UnionType,
+		HasErrorMethod: hasErrorMethod,
+		ExtraMethods:   append(codes.Methods(), safety.Methods()...),
 	}
 
 	if err := fg.Generate(g); err != nil {
 		return wrapGenerateError(spec.ThriftName(), err)
 	}
 
-	if spec.Type == ast.ExceptionType {
+	if err := fg.Builder(g, spec); err != nil {
+		return wrapGenerateError(spec.ThriftName(), err)
+	}
+
+	if hasErrorMethod {
 		err := g.DeclareFromTemplate(
 			`
 			<$v := newVar "v">
@@ -82,6 +124,22 @@ func structure(g Generator, spec *compile.StructSpec) error {
 		}
 	}
 
+	if len(codes.Methods()) > 0 {
+		if err := statusCodeMethods(g, name, codes); err != nil {
+			return wrapGenerateError(spec.ThriftName(), err)
+		}
+	}
+
+	if safety != safetyUnspecified {
+		if err := safetyMethods(g, name, safety); err != nil {
+			return wrapGenerateError(spec.ThriftName(), err)
+		}
+	}
+
+	if err := assertImplements(g, name, ifaces); err != nil {
+		return wrapGenerateError(spec.ThriftName(), err)
+	}
+
 	return nil
 	// TODO(abg): For all struct types, handle the case where fields are named
 	// ToWire or FromWire.
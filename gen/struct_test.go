@@ -248,6 +248,44 @@ func TestStructRoundTripAndString(t *testing.T) {
 			}}),
 			"Node{Value: 1, Tail: Node{Value: 2}}",
 		},
+		{
+			"Tree: self-referential through containers",
+			&ts.Tree{
+				Value: "root",
+				Left:  &ts.Tree{Value: "left"},
+				Children: []*ts.Tree{
+					{Value: "child", Right: &ts.Tree{Value: "right"}},
+				},
+				Named: map[string]*ts.Tree{"foo": {Value: "foo"}},
+			},
+			wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+				{ID: 1, Value: wire.NewValueString("root")},
+				{ID: 2, Value: wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+					{ID: 1, Value: wire.NewValueString("left")},
+				}})},
+				{ID: 4, Value: wire.NewValueList(
+					wire.ValueListFromSlice(wire.TStruct, []wire.Value{
+						wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+							{ID: 1, Value: wire.NewValueString("child")},
+							{ID: 3, Value: wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+								{ID: 1, Value: wire.NewValueString("right")},
+							}})},
+						}}),
+					}),
+				)},
+				{ID: 5, Value: wire.NewValueMap(
+					wire.MapItemListFromSlice(wire.TBinary, wire.TStruct, []wire.MapItem{
+						{
+							Key: wire.NewValueString("foo"),
+							Value: wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+								{ID: 1, Value: wire.NewValueString("foo")},
+							}}),
+						},
+					}),
+				)},
+			}}),
+			"Tree{Value: root, Left: Tree{Value: left}, Children: [Tree{Value: child, Right: Tree{Value: right}}], Named: map[foo:Tree{Value: foo}]}",
+		},
 		{
 			"Document: PDF",
 			&tu.Document{Pdf: []byte{1, 2, 3}},
@@ -496,6 +534,93 @@ func TestPrimitiveRequiredMissingFields(t *testing.T) {
 	}
 }
 
+func TestRequiredFieldErrors(t *testing.T) {
+	t.Run("FromWire", func(t *testing.T) {
+		var o ts.ContactInfo
+		err := o.FromWire(wire.NewValueStruct(wire.Struct{}))
+		assert.Equal(t, wire.MissingRequiredFieldError{
+			Struct: "ContactInfo",
+			Field:  "EmailAddress",
+			ID:     1,
+		}, err)
+	})
+
+	t.Run("ToWire", func(t *testing.T) {
+		_, err := (&ts.Edge{StartPoint: &ts.Point{X: 1, Y: 2}}).ToWire()
+		assert.Equal(t, wire.NilRequiredFieldError{
+			Struct: "Edge",
+			Field:  "EndPoint",
+			ID:     2,
+		}, err)
+	})
+
+	t.Run("messages", func(t *testing.T) {
+		missing := wire.MissingRequiredFieldError{Struct: "Edge", Field: "EndPoint", ID: 2}
+		unset := wire.NilRequiredFieldError{Struct: "Edge", Field: "EndPoint", ID: 2}
+		assert.Equal(t, "field EndPoint of Edge is required", missing.Error())
+		assert.Equal(t, "field EndPoint of Edge is required but was not set", unset.Error())
+		assert.NotEqual(t, missing.Error(), unset.Error(),
+			"errors while encoding must be distinguishable from errors while decoding")
+	})
+}
+
+func TestErrorObserver(t *testing.T) {
+	type observation struct {
+		TypeName, Field string
+		Kind            wire.DecodeErrorKind
+	}
+
+	tests := []struct {
+		desc string
+		give wire.Value
+		into interface {
+			FromWire(wire.Value) error
+		}
+		want []observation
+	}{
+		{
+			desc: "missing required field",
+			give: wire.NewValueStruct(wire.Struct{}),
+			into: &ts.ContactInfo{},
+			want: []observation{
+				{"ContactInfo", "EmailAddress", wire.DecodeErrorMissingRequiredField},
+			},
+		},
+		{
+			desc: "invalid nested struct",
+			give: wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+				{ID: 1, Value: wire.NewValueStruct(wire.Struct{})},
+			}}),
+			into: &ts.Frame{},
+			want: []observation{
+				{"Point", "X", wire.DecodeErrorMissingRequiredField},
+				{"Frame", "TopLeft", wire.DecodeErrorInvalidValue},
+			},
+		},
+		{
+			desc: "empty union",
+			give: wire.NewValueStruct(wire.Struct{}),
+			into: &tu.Document{},
+			want: []observation{
+				{"Document", "", wire.DecodeErrorInvalidUnion},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var got []observation
+			restore := wire.SetErrorObserver(func(typeName, field string, kind wire.DecodeErrorKind) {
+				got = append(got, observation{typeName, field, kind})
+			})
+			defer restore()
+
+			assert.Error(t, tt.into.FromWire(tt.give))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestStructStringWithNil(t *testing.T) {
 	var f *ts.Frame
 	assert.Equal(t, "<nil>", f.String())
@@ -1527,3 +1652,54 @@ func TestStructValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestStructGetters(t *testing.T) {
+	t.Run("nil receiver", func(t *testing.T) {
+		var s *ts.PrimitiveOptionalStruct
+		assert.False(t, s.GetBoolField())
+		assert.Equal(t, int32(0), s.GetInt32Field())
+		assert.Equal(t, "", s.GetStringField())
+		assert.Nil(t, s.GetBinaryField())
+
+		var e *ts.Edge
+		assert.Nil(t, e.GetStartPoint())
+
+		var u *ts.User
+		assert.Equal(t, "", u.GetName())
+	})
+
+	t.Run("set", func(t *testing.T) {
+		s := &ts.PrimitiveOptionalStruct{
+			BoolField:   boolp(true),
+			Int32Field:  int32p(42),
+			StringField: stringp("foo"),
+			BinaryField: []byte("bar"),
+		}
+		assert.True(t, s.GetBoolField())
+		assert.Equal(t, int32(42), s.GetInt32Field())
+		assert.Equal(t, "foo", s.GetStringField())
+		assert.Equal(t, []byte("bar"), s.GetBinaryField())
+		assert.Equal(t, int64(0), s.GetInt64Field())
+
+		u := &ts.User{Name: "foo", Contact: &ts.ContactInfo{EmailAddress: "foo@example.com"}}
+		assert.Equal(t, "foo", u.GetName())
+		assert.Equal(t, "foo@example.com", u.GetContact().GetEmailAddress())
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		var s ts.DefaultsStruct
+		assert.Equal(t, int32(100), s.GetRequiredPrimitive())
+		assert.Equal(t, int32(200), s.GetOptionalPrimitive())
+		assert.Equal(t, te.EnumDefaultBar, s.GetRequiredEnum())
+		assert.Equal(t, []string{"hello", "world"}, s.GetRequiredList())
+
+		s.OptionalPrimitive = int32p(1)
+		assert.Equal(t, int32(1), s.GetOptionalPrimitive())
+	})
+
+	t.Run("union", func(t *testing.T) {
+		d := &tu.Document{PlainText: stringp("hello")}
+		assert.Equal(t, "hello", d.GetPlainText())
+		assert.Nil(t, d.GetPdf())
+	})
+}
@@ -0,0 +1,558 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+// TypeSubstitution replaces references to a user-defined Thrift type with an
+// existing Go type.
+//
+// All Go identifiers are fully qualified with their import path. For example,
+//
+// 	TypeSubstitution{
+// 		Type:       "github.com/google/uuid.UUID",
+// 		ToThrift:   "example.com/conv.UUIDToThrift",
+// 		FromThrift: "example.com/conv.UUIDFromThrift",
+// 	}
+//
+// Given a reference R to the Thrift-generated type and a reference G to the
+// substituted Go type, the conversion functions must have the signatures,
+//
+// 	func(G) (R, error)  // ToThrift
+// 	func(R) (G, error)  // FromThrift
+//
+// References follow the same rules as references to the Thrift type: structs
+// are referenced through pointers, and optional fields of primitive types
+// (including enums and typedefs of primitives) are pointers to those types.
+// If the Thrift type is hashable, the Go type must be comparable. If the
+// Thrift type is a reference type (binary or a collection), the Go type must
+// be nillable.
+//
+// References to the type from within the Thrift file that defines it are not
+// substituted because the conversion functions will usually need to import
+// the package generated for that file.
+//
+// Substitutions may also be declared in the Thrift file with a go.type
+// annotation. See TypeConverter for more information.
+type TypeSubstitution struct {
+	Type       string `json:"type"`
+	ToThrift   string `json:"toThrift"`
+	FromThrift string `json:"fromThrift"`
+
+	// Target of the typedef if this substitution was declared with a go.type
+	// annotation on a typedef. The conversion functions for such
+	// substitutions operate on the target type rather than the typedef.
+	target compile.TypeSpec
+}
+
+// TypeConverter specifies the functions used to convert between a Go type
+// named in a go.type annotation and its Thrift representation.
+//
+// 	typedef i64 Timestamp (go.type = "time.Time")
+//
+// Converters are registered by Go type rather than by Thrift type so that the
+// same converter may be used for all typedefs annotated with that type.
+//
+// 	map[string]TypeConverter{
+// 		"time.Time": {
+// 			ToThrift:   "example.com/conv.TimeToUnixNano",
+// 			FromThrift: "example.com/conv.TimeFromUnixNano",
+// 		},
+// 	}
+//
+// For typedefs, the conversion functions operate on the typedef's target
+// type. Given a reference T to the target type and a reference G to the Go
+// type, the conversion functions must have the signatures,
+//
+// 	func(G) (T, error)  // ToThrift
+// 	func(T) (G, error)  // FromThrift
+//
+// So the converters above would be func(time.Time) (int64, error) and
+// func(int64) (time.Time, error). Because the conversion functions don't
+// need the generated package, references to the typedef are substituted
+// everywhere, including the Thrift file that defines it.
+//
+// go.type annotations on structs behave exactly like TypeSubstitutions.
+type TypeConverter struct {
+	ToThrift   string `json:"toThrift"`
+	FromThrift string `json:"fromThrift"`
+}
+
+// ReadTypeSubstitutions reads a JSON object mapping Thrift types to their
+// substitutions. Thrift types are referenced the same way they would be
+// referenced from an including Thrift file: "$module.$type" where $module is
+// the name of the Thrift file without the ".thrift" extension.
+//
+// 	{
+// 		"shared.UUID": {
+// 			"type": "github.com/google/uuid.UUID",
+// 			"toThrift": "example.com/conv.UUIDToThrift",
+// 			"fromThrift": "example.com/conv.UUIDFromThrift"
+// 		}
+// 	}
+func ReadTypeSubstitutions(r io.Reader) (map[string]TypeSubstitution, error) {
+	var subs map[string]TypeSubstitution
+	if err := json.NewDecoder(r).Decode(&subs); err != nil {
+		return nil, fmt.Errorf("could not decode type substitutions: %v", err)
+	}
+	return subs, nil
+}
+
+// ReadTypeConverters reads a JSON object mapping Go types named in go.type
+// annotations to their converters.
+//
+// 	{
+// 		"time.Time": {
+// 			"toThrift": "example.com/conv.TimeToUnixNano",
+// 			"fromThrift": "example.com/conv.TimeFromUnixNano"
+// 		}
+// 	}
+func ReadTypeConverters(r io.Reader) (map[string]TypeConverter, error) {
+	var convs map[string]TypeConverter
+	if err := json.NewDecoder(r).Decode(&convs); err != nil {
+		return nil, fmt.Errorf("could not decode type converters: %v", err)
+	}
+	return convs, nil
+}
+
+// goTypeAnnotation is the annotation used to substitute a Thrift type with
+// an existing Go type.
+const goTypeAnnotation = "go.type"
+
+// typeSubstitutions maps user-defined types to their Go substitutions.
+type typeSubstitutions map[compile.TypeSpec]TypeSubstitution
+
+// resolveTypeSubstitutions resolves the keys of the given substitution map to
+// the types defined in the given module or any module included by it.
+//
+// Types with go.type annotations are substituted using the given converters.
+// Explicit substitutions take precedence over annotations.
+func resolveTypeSubstitutions(m *compile.Module, subs map[string]TypeSubstitution, convs map[string]TypeConverter) (typeSubstitutions, error) {
+	types := make(map[string]compile.TypeSpec)
+	err := m.Walk(func(m *compile.Module) error {
+		for name, spec := range m.Types {
+			types[m.Name+"."+name] = spec
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make(typeSubstitutions)
+	for _, name := range sortStringKeys(types) {
+		spec := types[name]
+		goType, ok := spec.ThriftAnnotations()[goTypeAnnotation]
+		if !ok {
+			continue
+		}
+		if _, ok := subs[name]; ok {
+			continue
+		}
+
+		sub, err := annotatedSubstitution(spec, goType, convs)
+		if err != nil {
+			return nil, substitutionError{Name: name, Reason: err}
+		}
+		resolved[spec] = sub
+	}
+
+	for _, name := range sortStringKeys(subs) {
+		sub := subs[name]
+		spec, ok := types[name]
+		if !ok {
+			return nil, substitutionError{
+				Name:   name,
+				Reason: errors.New("unknown type"),
+			}
+		}
+
+		if err := validateSubstitution(sub); err != nil {
+			return nil, substitutionError{Name: name, Reason: err}
+		}
+		resolved[spec] = sub
+	}
+
+	for _, name := range sortStringKeys(types) {
+		sub, ok := resolved[types[name]]
+		if !ok || sub.target == nil {
+			continue
+		}
+		if t := findSubstitutedType(sub.target, resolved); t != nil {
+			return nil, substitutionError{
+				Name: name,
+				Reason: fmt.Errorf(
+					"typedefs with %v annotations may not reference substituted type %q",
+					goTypeAnnotation, t.ThriftName()),
+			}
+		}
+	}
+
+	if len(resolved) == 0 {
+		return nil, nil
+	}
+	return resolved, nil
+}
+
+// annotatedSubstitution builds the substitution for a type annotated with
+// go.type.
+func annotatedSubstitution(spec compile.TypeSpec, goType string, convs map[string]TypeConverter) (TypeSubstitution, error) {
+	sub := TypeSubstitution{Type: goType}
+	switch s := spec.(type) {
+	case *compile.TypedefSpec:
+		sub.target = s.Target
+	case *compile.StructSpec:
+		// Structs are substituted the same way as with TypeSubstitutions.
+	default:
+		return sub, fmt.Errorf(
+			"%v annotation is supported on typedefs and structs only", goTypeAnnotation)
+	}
+
+	if _, _, err := splitQualifiedName(goType); err != nil {
+		return sub, fmt.Errorf("invalid %v annotation: %v", goTypeAnnotation, err)
+	}
+
+	conv, ok := convs[goType]
+	if !ok {
+		return sub, fmt.Errorf("no type converter registered for %q", goType)
+	}
+	sub.ToThrift = conv.ToThrift
+	sub.FromThrift = conv.FromThrift
+	if err := validateSubstitution(sub); err != nil {
+		return sub, fmt.Errorf("invalid type converter for %q: %v", goType, err)
+	}
+	return sub, nil
+}
+
+// findSubstitutedType returns the first type referenced by the given type
+// that has a substitution, or nil if there isn't one.
+//
+// The conversion functions for go.type annotations on typedefs operate on
+// the typedef's target which must be convertible to the typedef.
+func findSubstitutedType(spec compile.TypeSpec, subs typeSubstitutions) compile.TypeSpec {
+	if _, ok := subs[spec]; ok {
+		return spec
+	}
+
+	switch s := spec.(type) {
+	case *compile.MapSpec:
+		if t := findSubstitutedType(s.KeySpec, subs); t != nil {
+			return t
+		}
+		return findSubstitutedType(s.ValueSpec, subs)
+	case *compile.ListSpec:
+		return findSubstitutedType(s.ValueSpec, subs)
+	case *compile.SetSpec:
+		return findSubstitutedType(s.ValueSpec, subs)
+	default:
+		return nil
+	}
+}
+
+// validateSubstitution verifies that all identifiers referenced by the given
+// substitution are fully qualified.
+func validateSubstitution(sub TypeSubstitution) error {
+	for _, id := range []struct{ Field, Value string }{
+		{"type", sub.Type},
+		{"toThrift", sub.ToThrift},
+		{"fromThrift", sub.FromThrift},
+	} {
+		if _, _, err := splitQualifiedName(id.Value); err != nil {
+			return fmt.Errorf("invalid %q: %v", id.Field, err)
+		}
+	}
+	return nil
+}
+
+// splitQualifiedName splits "github.com/foo/bar.Baz" into "github.com/foo/bar"
+// and "Baz".
+func splitQualifiedName(s string) (importPath, name string, err error) {
+	i := strings.LastIndexByte(s, '.')
+	if i <= 0 || i < strings.LastIndexByte(s, '/') || i == len(s)-1 {
+		return "", "", fmt.Errorf(
+			"%q is not a fully qualified Go identifier (e.g. example.com/foo.Bar)", s)
+	}
+	return s[:i], s[i+1:], nil
+}
+
+// substitutor is implemented by Generators which support type substitution.
+type substitutor interface {
+	substitution(compile.TypeSpec) (TypeSubstitution, bool)
+}
+
+// lookupSubstitution returns the substitution for the given type if the
+// Generator has one for it.
+func lookupSubstitution(g Generator, spec compile.TypeSpec) (TypeSubstitution, bool) {
+	if s, ok := g.(substitutor); ok {
+		return s.substitution(spec)
+	}
+	return TypeSubstitution{}, false
+}
+
+// importQualified imports the package of the given fully qualified Go
+// identifier and returns a reference to the identifier.
+func importQualified(g Generator, s string) (string, error) {
+	importPath, name, err := splitQualifiedName(s)
+	if err != nil {
+		return "", err
+	}
+	return g.Import(importPath) + "." + name, nil
+}
+
+// substitutionGenerator generates helpers to convert between substituted Go
+// types and their Value representations.
+type substitutionGenerator struct {
+	// Names of helpers that have already been declared in this package.
+	//
+	// We track these ourselves rather than relying on EnsureDeclared because
+	// rendering the helpers imports the packages referenced by the
+	// substitution, which would go unused in files other than the one which
+	// declared the helper.
+	declared map[string]struct{}
+}
+
+func (s *substitutionGenerator) isDeclared(name string) bool {
+	_, ok := s.declared[name]
+	return ok
+}
+
+func (s *substitutionGenerator) markDeclared(name string) {
+	if s.declared == nil {
+		s.declared = make(map[string]struct{})
+	}
+	s.declared[name] = struct{}{}
+}
+
+// substitutionTemplateData is the template context for substitution helpers.
+type substitutionTemplateData struct {
+	Name    string
+	Spec    compile.TypeSpec
+	Convert string // conversion function
+
+	// ThriftType is the name of the Thrift-generated type. This is set only
+	// for readers and for substitutions declared with go.type.
+	ThriftType string
+
+	// Target is the typedef target for substitutions declared with go.type.
+	// Values are converted between the target and the typedef.
+	Target compile.TypeSpec
+
+	// ThriftRef is a reference to the Thrift-generated type. This is set
+	// only if Target is set.
+	ThriftRef string
+}
+
+// newSubstitutionTemplateData builds the template context for a helper of
+// the given substitution.
+func newSubstitutionTemplateData(g Generator, name string, spec compile.TypeSpec, sub TypeSubstitution, convert string) (substitutionTemplateData, error) {
+	data := substitutionTemplateData{
+		Name:    name,
+		Spec:    spec,
+		Convert: convert,
+		Target:  sub.target,
+	}
+
+	thriftType, err := g.LookupTypeName(spec)
+	if err != nil {
+		return data, err
+	}
+	data.ThriftType = thriftType
+	if sub.target != nil {
+		data.ThriftRef = thriftType
+		if isStructType(spec) {
+			data.ThriftRef = "*" + thriftType
+		}
+	}
+	return data, nil
+}
+
+// ToWire generates a function which converts the substituted type to the
+// Thrift type and returns its Value representation.
+//
+// 	func $name(v $goType) (wire.Value, error) {
+// 		...
+// 	}
+//
+// And returns its name.
+func (s *substitutionGenerator) ToWire(g Generator, spec compile.TypeSpec, sub TypeSubstitution) (string, error) {
+	name := fmt.Sprintf("_%s_SubstitutedToWire", g.MangleType(spec))
+	if s.isDeclared(name) {
+		return name, nil
+	}
+
+	convert, err := importQualified(g, sub.ToThrift)
+	if err != nil {
+		return "", wrapGenerateError(spec.ThriftName(), err)
+	}
+	data, err := newSubstitutionTemplateData(g, name, spec, sub, convert)
+	if err != nil {
+		return "", wrapGenerateError(spec.ThriftName(), err)
+	}
+
+	err = g.EnsureDeclared(
+		`
+			<$wire := import "go.uber.org/thriftrw/wire">
+
+			<$v := newVar "v">
+			<$x := newVar "x">
+			func <.Name>(<$v> <typeReference .Spec>) (<$wire>.Value, error) {
+				<$x>, err := <.Convert>(<$v>)
+				if err != nil {
+					return <$wire>.Value{}, err
+				}
+				<if .Target>
+					return (<.ThriftRef>)(<$x>).ToWire()
+				<else>
+					return <$x>.ToWire()
+				<end>
+			}
+		`, data)
+	if err != nil {
+		return "", wrapGenerateError(spec.ThriftName(), err)
+	}
+
+	s.markDeclared(name)
+	return name, nil
+}
+
+// Reader generates a function to read the substituted type from a Value.
+//
+// 	func $name(w wire.Value) ($goType, error) {
+// 		...
+// 	}
+//
+// And returns its name.
+func (s *substitutionGenerator) Reader(g Generator, spec compile.TypeSpec, sub TypeSubstitution) (string, error) {
+	name := fmt.Sprintf("_%s_SubstitutedRead", g.MangleType(spec))
+	if s.isDeclared(name) {
+		return name, nil
+	}
+
+	convert, err := importQualified(g, sub.FromThrift)
+	if err != nil {
+		return "", wrapGenerateError(spec.ThriftName(), err)
+	}
+	data, err := newSubstitutionTemplateData(g, name, spec, sub, convert)
+	if err != nil {
+		return "", wrapGenerateError(spec.ThriftName(), err)
+	}
+
+	err = g.EnsureDeclared(
+		`
+			<$wire := import "go.uber.org/thriftrw/wire">
+
+			<$w := newVar "w">
+			<$x := newVar "x">
+			<$o := newVar "o">
+			func <.Name>(<$w> <$wire>.Value) (<typeReference .Spec>, error) {
+				var <$x> <.ThriftType>
+				if err := <$x>.FromWire(<$w>); err != nil {
+					var <$o> <typeReference .Spec>
+					return <$o>, err
+				}
+				<if and .Target (isStructType .Spec)>
+					return <.Convert>((<typeReference .Target>)(&<$x>))
+				<else if .Target>
+					return <.Convert>((<typeReference .Target>)(<$x>))
+				<else if isStructType .Spec>
+					return <.Convert>(&<$x>)
+				<else>
+					return <.Convert>(<$x>)
+				<end>
+			}
+		`, data)
+	if err != nil {
+		return "", wrapGenerateError(spec.ThriftName(), err)
+	}
+
+	s.markDeclared(name)
+	return name, nil
+}
+
+// Equals generates a function to compare values of the substituted type by
+// comparing their Thrift representations.
+//
+// 	func $name(lhs, rhs $goType) bool {
+// 		...
+// 	}
+//
+// And returns its name.
+func (s *substitutionGenerator) Equals(g Generator, spec compile.TypeSpec, sub TypeSubstitution) (string, error) {
+	name := fmt.Sprintf("_%s_SubstitutedEquals", g.MangleType(spec))
+	if s.isDeclared(name) {
+		return name, nil
+	}
+
+	convert, err := importQualified(g, sub.ToThrift)
+	if err != nil {
+		return "", wrapGenerateError(spec.ThriftName(), err)
+	}
+	data, err := newSubstitutionTemplateData(g, name, spec, sub, convert)
+	if err != nil {
+		return "", wrapGenerateError(spec.ThriftName(), err)
+	}
+
+	err = g.EnsureDeclared(
+		`
+			<$lhs := newVar "lhs">
+			<$rhs := newVar "rhs">
+			<$l := newVar "l">
+			<$r := newVar "r">
+			func <.Name>(<$lhs>, <$rhs> <typeReference .Spec>) bool {
+				<$l>, err := <.Convert>(<$lhs>)
+				if err != nil {
+					return false
+				}
+				<$r>, err := <.Convert>(<$rhs>)
+				if err != nil {
+					return false
+				}
+				<if .Target>
+					return (<.ThriftRef>)(<$l>).Equals((<.ThriftRef>)(<$r>))
+				<else>
+					return <$l>.Equals(<$r>)
+				<end>
+			}
+		`, data)
+	if err != nil {
+		return "", wrapGenerateError(spec.ThriftName(), err)
+	}
+
+	s.markDeclared(name)
+	return name, nil
+}
+
+type substitutionError struct {
+	Name   string
+	Reason error
+}
+
+func (e substitutionError) Error() string {
+	return fmt.Sprintf("invalid type substitution for %q: %v", e.Name, e.Reason)
+}
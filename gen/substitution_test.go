@@ -0,0 +1,399 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTypeSubstitutions(t *testing.T) {
+	subs, err := ReadTypeSubstitutions(strings.NewReader(`{
+		"shared.UUID": {
+			"type": "github.com/google/uuid.UUID",
+			"toThrift": "example.com/conv.UUIDToThrift",
+			"fromThrift": "example.com/conv.UUIDFromThrift"
+		}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]TypeSubstitution{
+		"shared.UUID": {
+			Type:       "github.com/google/uuid.UUID",
+			ToThrift:   "example.com/conv.UUIDToThrift",
+			FromThrift: "example.com/conv.UUIDFromThrift",
+		},
+	}, subs)
+
+	_, err = ReadTypeSubstitutions(strings.NewReader(`[]`))
+	assert.Error(t, err)
+}
+
+func TestSplitQualifiedName(t *testing.T) {
+	tests := []struct {
+		give     string
+		wantPath string
+		wantName string
+		wantErr  bool
+	}{
+		{give: "github.com/google/uuid.UUID", wantPath: "github.com/google/uuid", wantName: "UUID"},
+		{give: "time.Time", wantPath: "time", wantName: "Time"},
+		{give: "gopkg.in/foo.v1", wantPath: "gopkg.in/foo", wantName: "v1"},
+		{give: "UUID", wantErr: true},
+		{give: ".UUID", wantErr: true},
+		{give: "time.", wantErr: true},
+		{give: "gopkg.in/foo", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		path, name, err := splitQualifiedName(tt.give)
+		if tt.wantErr {
+			assert.Error(t, err, tt.give)
+			continue
+		}
+		if assert.NoError(t, err, tt.give) {
+			assert.Equal(t, tt.wantPath, path, tt.give)
+			assert.Equal(t, tt.wantName, name, tt.give)
+		}
+	}
+}
+
+func TestTypeSubstitution(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-substitution-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeFile := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+		return path
+	}
+
+	writeFile("shared.thrift", `
+		typedef string UUID
+		struct Point { 1: required i32 x }
+		struct Wrapper { 1: required UUID id }
+	`)
+	mainFile := writeFile("main.thrift", `
+		include "./shared.thrift"
+
+		struct Foo {
+			1: required shared.UUID id
+			2: optional shared.UUID maybeID
+			3: optional list<shared.UUID> ids
+			4: optional shared.Point point
+		}
+
+		service Bar {
+			shared.UUID baz(1: shared.UUID id)
+		}
+	`)
+
+	module, err := compile.Compile(mainFile)
+	require.NoError(t, err)
+
+	subs := map[string]TypeSubstitution{
+		"shared.UUID": {
+			Type:       "github.com/google/uuid.UUID",
+			ToThrift:   "example.com/conv.UUIDToThrift",
+			FromThrift: "example.com/conv.UUIDFromThrift",
+		},
+		"shared.Point": {
+			Type:       "image.Point",
+			ToThrift:   "example.com/conv.PointToThrift",
+			FromThrift: "example.com/conv.PointFromThrift",
+		},
+	}
+
+	outputDir := filepath.Join(dir, "out")
+	require.NoError(t, Generate(module, &Options{
+		OutputDir:         outputDir,
+		PackagePrefix:     "example.com/out",
+		ThriftRoot:        dir,
+		NoVersionCheck:    true,
+		NoEmbedIDL:        true,
+		TypeSubstitutions: subs,
+	}))
+
+	readFile := func(name string) string {
+		contents, err := ioutil.ReadFile(filepath.Join(outputDir, name))
+		require.NoError(t, err)
+		return string(contents)
+	}
+
+	types := readFile("main/types.go")
+	for _, want := range []string{
+		`"github.com/google/uuid"`,
+		"ID      uuid.UUID",
+		"MaybeID *uuid.UUID",
+		"Ids     []uuid.UUID",
+		"Point   *image.Point",
+		"conv.UUIDToThrift(v)",
+		"conv.UUIDFromThrift(x)",
+		"conv.PointFromThrift(&x)",
+		"func _UUID_SubstitutedEquals(",
+	} {
+		assert.Contains(t, types, want)
+	}
+	assert.NotContains(t, types, "shared.UUID(")
+
+	service := readFile("main/bar_baz.go")
+	assert.Contains(t, service, "Success *uuid.UUID")
+	assert.Contains(t, service, "ID *uuid.UUID")
+
+	// References from within the defining module are left alone.
+	shared := readFile("shared/types.go")
+	assert.Contains(t, shared, "ID UUID")
+	assert.NotContains(t, shared, "uuid")
+}
+
+func TestReadTypeConverters(t *testing.T) {
+	convs, err := ReadTypeConverters(strings.NewReader(`{
+		"time.Time": {
+			"toThrift": "example.com/conv.TimeToThrift",
+			"fromThrift": "example.com/conv.TimeFromThrift"
+		}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]TypeConverter{
+		"time.Time": {
+			ToThrift:   "example.com/conv.TimeToThrift",
+			FromThrift: "example.com/conv.TimeFromThrift",
+		},
+	}, convs)
+
+	_, err = ReadTypeConverters(strings.NewReader(`[]`))
+	assert.Error(t, err)
+}
+
+func TestGoTypeAnnotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-go-type-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeFile := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+		return path
+	}
+
+	writeFile("shared.thrift", `
+		typedef i64 Timestamp (go.type = "time.Time")
+		struct Point { 1: required i32 x } (go.type = "image.Point")
+
+		struct Event {
+			1: required Timestamp time
+			2: optional Timestamp maybeTime
+			3: optional Point point
+		}
+	`)
+	mainFile := writeFile("main.thrift", `
+		include "./shared.thrift"
+
+		struct Foo {
+			1: optional list<shared.Timestamp> times
+			2: optional shared.Point point
+		}
+	`)
+
+	module, err := compile.Compile(mainFile)
+	require.NoError(t, err)
+
+	outputDir := filepath.Join(dir, "out")
+	require.NoError(t, Generate(module, &Options{
+		OutputDir:      outputDir,
+		PackagePrefix:  "example.com/out",
+		ThriftRoot:     dir,
+		NoVersionCheck: true,
+		NoEmbedIDL:     true,
+		TypeConverters: map[string]TypeConverter{
+			"time.Time": {
+				ToThrift:   "example.com/conv.TimeToThrift",
+				FromThrift: "example.com/conv.TimeFromThrift",
+			},
+			"image.Point": {
+				ToThrift:   "example.com/conv.PointToThrift",
+				FromThrift: "example.com/conv.PointFromThrift",
+			},
+		},
+	}))
+
+	readFile := func(name string) string {
+		contents, err := ioutil.ReadFile(filepath.Join(outputDir, name))
+		require.NoError(t, err)
+		return string(contents)
+	}
+
+	// Typedefs are substituted within the defining module and converted
+	// through their target type.
+	shared := readFile("shared/types.go")
+	for _, want := range []string{
+		"type Timestamp int64",
+		"Time      time.Time",
+		"MaybeTime *time.Time",
+		"Point     *Point",
+		"return (Timestamp)(x).ToWire()",
+		"conv.TimeFromThrift((int64)(x))",
+	} {
+		assert.Contains(t, shared, want)
+	}
+	assert.NotContains(t, shared, "image")
+
+	main := readFile("main/types.go")
+	for _, want := range []string{
+		"Times []time.Time",
+		"Point *image.Point",
+		"conv.PointFromThrift(&x)",
+	} {
+		assert.Contains(t, main, want)
+	}
+}
+
+func TestGoTypeAnnotationErrors(t *testing.T) {
+	tests := []struct {
+		desc      string
+		thrift    string
+		wantError string
+	}{
+		{
+			desc:      "no converter",
+			thrift:    `typedef string UUID (go.type = "example.com/foo.UUID")`,
+			wantError: `invalid type substitution for "errors.UUID": no type converter registered for "example.com/foo.UUID"`,
+		},
+		{
+			desc:      "unqualified type",
+			thrift:    `typedef string UUID (go.type = "UUID")`,
+			wantError: `invalid type substitution for "errors.UUID": invalid go.type annotation`,
+		},
+		{
+			desc:      "enum",
+			thrift:    `enum Color { Red } (go.type = "time.Time")`,
+			wantError: `invalid type substitution for "errors.Color": go.type annotation is supported on typedefs and structs only`,
+		},
+		{
+			desc: "substituted target",
+			thrift: `
+				typedef string UUID (go.type = "time.Time")
+				typedef list<UUID> UUIDs (go.type = "time.Time")
+			`,
+			wantError: `invalid type substitution for "errors.UUIDs": typedefs with go.type annotations may not reference substituted type "UUID"`,
+		},
+		{
+			desc:      "invalid converter",
+			thrift:    `typedef i64 Timestamp (go.type = "time.Duration")`,
+			wantError: `invalid type converter for "time.Duration": invalid "toThrift"`,
+		},
+	}
+
+	for _, tt := range tests {
+		dir, err := ioutil.TempDir("", "thriftrw-go-type-test")
+		require.NoError(t, err, tt.desc)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "errors.thrift")
+		require.NoError(t, ioutil.WriteFile(path, []byte(tt.thrift), 0644), tt.desc)
+		module, err := compile.Compile(path)
+		require.NoError(t, err, tt.desc)
+
+		err = Generate(module, &Options{
+			OutputDir:     filepath.Join(dir, "out"),
+			PackagePrefix: "example.com/out",
+			ThriftRoot:    dir,
+			TypeConverters: map[string]TypeConverter{
+				"time.Time": {
+					ToThrift:   "example.com/conv.TimeToThrift",
+					FromThrift: "example.com/conv.TimeFromThrift",
+				},
+				"time.Duration": {FromThrift: "example.com/conv.DurationFromThrift"},
+			},
+		})
+		if assert.Error(t, err, tt.desc) {
+			assert.Contains(t, err.Error(), tt.wantError, tt.desc)
+		}
+	}
+}
+
+func TestTypeSubstitutionErrors(t *testing.T) {
+	module, err := compile.Compile("testdata/thrift/typedefs.thrift")
+	require.NoError(t, err)
+
+	tests := []struct {
+		desc      string
+		subs      map[string]TypeSubstitution
+		wantError string
+	}{
+		{
+			desc: "unknown type",
+			subs: map[string]TypeSubstitution{
+				"typedefs.DoesNotExist": {
+					Type:       "example.com/foo.Bar",
+					ToThrift:   "example.com/foo.ToThrift",
+					FromThrift: "example.com/foo.FromThrift",
+				},
+			},
+			wantError: `invalid type substitution for "typedefs.DoesNotExist": unknown type`,
+		},
+		{
+			desc: "unqualified type",
+			subs: map[string]TypeSubstitution{
+				"typedefs.UUID": {
+					Type:       "Bar",
+					ToThrift:   "example.com/foo.ToThrift",
+					FromThrift: "example.com/foo.FromThrift",
+				},
+			},
+			wantError: `invalid type substitution for "typedefs.UUID": invalid "type"`,
+		},
+		{
+			desc: "missing conversion",
+			subs: map[string]TypeSubstitution{
+				"typedefs.UUID": {
+					Type:     "example.com/foo.Bar",
+					ToThrift: "example.com/foo.ToThrift",
+				},
+			},
+			wantError: `invalid type substitution for "typedefs.UUID": invalid "fromThrift"`,
+		},
+	}
+
+	for _, tt := range tests {
+		outputDir, err := ioutil.TempDir("", "thriftrw-substitution-test")
+		require.NoError(t, err, tt.desc)
+		defer os.RemoveAll(outputDir)
+
+		err = Generate(module, &Options{
+			OutputDir:         outputDir,
+			PackagePrefix:     "example.com/out",
+			ThriftRoot:        testdata(t),
+			TypeSubstitutions: tt.subs,
+		})
+		if assert.Error(t, err, tt.desc) {
+			assert.Contains(t, err.Error(), tt.wantError, tt.desc)
+		}
+	}
+}
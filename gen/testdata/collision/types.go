@@ -6,7 +6,6 @@ package collision
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"go.uber.org/thriftrw/wire"
 	"math"
@@ -68,8 +67,29 @@ func (v *MyEnum) UnmarshalText(value []byte) error {
 		*v = MyEnumFooBar2
 		return nil
 	default:
-		return fmt.Errorf("unknown enum value %q for %q", value, "MyEnum")
+		val, err := strconv.ParseInt(string(value), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", value, "MyEnum", err)
+		}
+		*v = MyEnum(val)
+		return nil
+	}
+}
+
+func (v MyEnum) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 123:
+		return []byte("X"), nil
+	case 456:
+		return []byte("Y"), nil
+	case 789:
+		return []byte("Z"), nil
+	case 790:
+		return []byte("FooBar"), nil
+	case 791:
+		return []byte("foo_bar"), nil
 	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
 
 func (v MyEnum) ToWire() (wire.Value, error) {
@@ -341,6 +361,7 @@ func (v *PrimitiveContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.A, err = _List_String_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("PrimitiveContainers", "A", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -348,6 +369,7 @@ func (v *PrimitiveContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TSet {
 				v.B, err = _Set_String_Read(field.Value.GetSet())
 				if err != nil {
+					wire.ObserveDecodeError("PrimitiveContainers", "B", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -355,6 +377,7 @@ func (v *PrimitiveContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TMap {
 				v.C, err = _Map_String_String_Read(field.Value.GetMap())
 				if err != nil {
+					wire.ObserveDecodeError("PrimitiveContainers", "C", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -438,6 +461,27 @@ func (v *PrimitiveContainers) Equals(rhs *PrimitiveContainers) bool {
 	return true
 }
 
+func (v *PrimitiveContainers) GetA() (o []string) {
+	if v != nil && v.A != nil {
+		return v.A
+	}
+	return
+}
+
+func (v *PrimitiveContainers) GetB() (o map[string]struct{}) {
+	if v != nil && v.B != nil {
+		return v.B
+	}
+	return
+}
+
+func (v *PrimitiveContainers) GetC() (o map[string]string) {
+	if v != nil && v.C != nil {
+		return v.C
+	}
+	return
+}
+
 type StructCollision struct {
 	CollisionField  bool   `json:"collisionField"`
 	CollisionField2 string `json:"collision_field"`
@@ -475,6 +519,7 @@ func (v *StructCollision) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBool {
 				v.CollisionField, err = field.Value.GetBool(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("StructCollision", "CollisionField", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				collisionFieldIsSet = true
@@ -483,6 +528,7 @@ func (v *StructCollision) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.CollisionField2, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("StructCollision", "CollisionField2", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				collision_fieldIsSet = true
@@ -490,10 +536,12 @@ func (v *StructCollision) FromWire(w wire.Value) error {
 		}
 	}
 	if !collisionFieldIsSet {
-		return errors.New("field CollisionField of StructCollision is required")
+		wire.ObserveDecodeError("StructCollision", "CollisionField", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "StructCollision", Field: "CollisionField", ID: 1}
 	}
 	if !collision_fieldIsSet {
-		return errors.New("field CollisionField2 of StructCollision is required")
+		wire.ObserveDecodeError("StructCollision", "CollisionField2", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "StructCollision", Field: "CollisionField2", ID: 2}
 	}
 	return nil
 }
@@ -521,6 +569,20 @@ func (v *StructCollision) Equals(rhs *StructCollision) bool {
 	return true
 }
 
+func (v *StructCollision) GetCollisionField() (o bool) {
+	if v != nil {
+		o = v.CollisionField
+	}
+	return
+}
+
+func (v *StructCollision) GetCollisionField2() (o string) {
+	if v != nil {
+		o = v.CollisionField2
+	}
+	return
+}
+
 type UnionCollision struct {
 	CollisionField  *bool   `json:"collisionField,omitempty"`
 	CollisionField2 *string `json:"collision_field,omitempty"`
@@ -565,6 +627,7 @@ func (v *UnionCollision) FromWire(w wire.Value) error {
 				x, err = field.Value.GetBool(), error(nil)
 				v.CollisionField = &x
 				if err != nil {
+					wire.ObserveDecodeError("UnionCollision", "CollisionField", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -574,6 +637,7 @@ func (v *UnionCollision) FromWire(w wire.Value) error {
 				x, err = field.Value.GetString(), error(nil)
 				v.CollisionField2 = &x
 				if err != nil {
+					wire.ObserveDecodeError("UnionCollision", "CollisionField2", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -587,6 +651,7 @@ func (v *UnionCollision) FromWire(w wire.Value) error {
 		count++
 	}
 	if count != 1 {
+		wire.ObserveDecodeError("UnionCollision", "", wire.DecodeErrorInvalidUnion)
 		return fmt.Errorf("UnionCollision should have exactly one field: got %v fields", count)
 	}
 	return nil
@@ -637,6 +702,53 @@ func (v *UnionCollision) Equals(rhs *UnionCollision) bool {
 	return true
 }
 
+func (v *UnionCollision) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.CollisionField != nil {
+		count++
+	}
+	if v.CollisionField2 != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("UnionCollision should have exactly one field: got %v fields", count)
+	}
+	type plain UnionCollision
+	return json.Marshal((*plain)(v))
+}
+
+func (v *UnionCollision) UnmarshalJSON(text []byte) error {
+	type plain UnionCollision
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.CollisionField != nil {
+		count++
+	}
+	if v.CollisionField2 != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("UnionCollision should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *UnionCollision) GetCollisionField() (o bool) {
+	if v != nil && v.CollisionField != nil {
+		return *v.CollisionField
+	}
+	return
+}
+
+func (v *UnionCollision) GetCollisionField2() (o string) {
+	if v != nil && v.CollisionField2 != nil {
+		return *v.CollisionField2
+	}
+	return
+}
+
 type WithDefault struct {
 	Pouet *StructCollision2 `json:"pouet,omitempty"`
 }
@@ -676,6 +788,7 @@ func (v *WithDefault) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.Pouet, err = _StructCollision_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("WithDefault", "Pouet", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -707,6 +820,14 @@ func (v *WithDefault) Equals(rhs *WithDefault) bool {
 	return true
 }
 
+func (v *WithDefault) GetPouet() (o *StructCollision2) {
+	if v != nil && v.Pouet != nil {
+		return v.Pouet
+	}
+	o = &StructCollision2{CollisionField: false, CollisionField2: "false indeed"}
+	return
+}
+
 type LittlePotatoe2 float64
 
 func (v LittlePotatoe2) ToWire() (wire.Value, error) {
@@ -753,8 +874,25 @@ func (v *MyEnum2) UnmarshalText(value []byte) error {
 		*v = MyEnum2Z
 		return nil
 	default:
-		return fmt.Errorf("unknown enum value %q for %q", value, "MyEnum2")
+		val, err := strconv.ParseInt(string(value), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", value, "MyEnum2", err)
+		}
+		*v = MyEnum2(val)
+		return nil
+	}
+}
+
+func (v MyEnum2) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 12:
+		return []byte("X"), nil
+	case 34:
+		return []byte("Y"), nil
+	case 56:
+		return []byte("Z"), nil
 	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
 
 func (v MyEnum2) ToWire() (wire.Value, error) {
@@ -860,6 +998,7 @@ func (v *StructCollision2) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBool {
 				v.CollisionField, err = field.Value.GetBool(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("StructCollision2", "CollisionField", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				collisionFieldIsSet = true
@@ -868,6 +1007,7 @@ func (v *StructCollision2) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.CollisionField2, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("StructCollision2", "CollisionField2", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				collision_fieldIsSet = true
@@ -875,10 +1015,12 @@ func (v *StructCollision2) FromWire(w wire.Value) error {
 		}
 	}
 	if !collisionFieldIsSet {
-		return errors.New("field CollisionField of StructCollision2 is required")
+		wire.ObserveDecodeError("StructCollision2", "CollisionField", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "StructCollision2", Field: "CollisionField", ID: 1}
 	}
 	if !collision_fieldIsSet {
-		return errors.New("field CollisionField2 of StructCollision2 is required")
+		wire.ObserveDecodeError("StructCollision2", "CollisionField2", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "StructCollision2", Field: "CollisionField2", ID: 2}
 	}
 	return nil
 }
@@ -906,6 +1048,20 @@ func (v *StructCollision2) Equals(rhs *StructCollision2) bool {
 	return true
 }
 
+func (v *StructCollision2) GetCollisionField() (o bool) {
+	if v != nil {
+		o = v.CollisionField
+	}
+	return
+}
+
+func (v *StructCollision2) GetCollisionField2() (o string) {
+	if v != nil {
+		o = v.CollisionField2
+	}
+	return
+}
+
 type UnionCollision2 struct {
 	CollisionField  *bool   `json:"collisionField,omitempty"`
 	CollisionField2 *string `json:"collision_field,omitempty"`
@@ -950,6 +1106,7 @@ func (v *UnionCollision2) FromWire(w wire.Value) error {
 				x, err = field.Value.GetBool(), error(nil)
 				v.CollisionField = &x
 				if err != nil {
+					wire.ObserveDecodeError("UnionCollision2", "CollisionField", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -959,6 +1116,7 @@ func (v *UnionCollision2) FromWire(w wire.Value) error {
 				x, err = field.Value.GetString(), error(nil)
 				v.CollisionField2 = &x
 				if err != nil {
+					wire.ObserveDecodeError("UnionCollision2", "CollisionField2", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -972,6 +1130,7 @@ func (v *UnionCollision2) FromWire(w wire.Value) error {
 		count++
 	}
 	if count != 1 {
+		wire.ObserveDecodeError("UnionCollision2", "", wire.DecodeErrorInvalidUnion)
 		return fmt.Errorf("UnionCollision2 should have exactly one field: got %v fields", count)
 	}
 	return nil
@@ -1003,3 +1162,50 @@ func (v *UnionCollision2) Equals(rhs *UnionCollision2) bool {
 	}
 	return true
 }
+
+func (v *UnionCollision2) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.CollisionField != nil {
+		count++
+	}
+	if v.CollisionField2 != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("UnionCollision2 should have exactly one field: got %v fields", count)
+	}
+	type plain UnionCollision2
+	return json.Marshal((*plain)(v))
+}
+
+func (v *UnionCollision2) UnmarshalJSON(text []byte) error {
+	type plain UnionCollision2
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.CollisionField != nil {
+		count++
+	}
+	if v.CollisionField2 != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("UnionCollision2 should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *UnionCollision2) GetCollisionField() (o bool) {
+	if v != nil && v.CollisionField != nil {
+		return *v.CollisionField
+	}
+	return
+}
+
+func (v *UnionCollision2) GetCollisionField2() (o string) {
+	if v != nil && v.CollisionField2 != nil {
+		return *v.CollisionField2
+	}
+	return
+}
@@ -11,6 +11,7 @@ import (
 	"go.uber.org/thriftrw/gen/testdata/typedefs"
 	"go.uber.org/thriftrw/gen/testdata/unions"
 	"go.uber.org/thriftrw/ptr"
+	"go.uber.org/thriftrw/uuid"
 )
 
 const Home enums.RecordType = enums.RecordTypeHomeAddress
@@ -50,10 +51,14 @@ const Lower enums.LowerCaseEnum = enums.LowerCaseEnumItems
 
 const MyEnum typedefs.MyEnum = typedefs.MyEnum(enums.EnumWithValuesY)
 
+var NilUUID uuid.UUID = uuid.UUID{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+
 var Node *structs.Node = &structs.Node{Tail: &structs.List{Tail: &structs.List{Value: 3}, Value: 2}, Value: 1}
 
 var PrimitiveContainers *containers.PrimitiveContainers = &containers.PrimitiveContainers{ListOfInts: []int64{1, 2, 3}, MapOfIntToString: map[int32]string{1: "1", 2: "2", 3: "3"}, MapOfStringToBool: map[string]bool{"1": false, "2": true, "3": true}, SetOfBytes: map[int8]struct{}{1: struct{}{}, 2: struct{}{}, 3: struct{}{}}, SetOfStrings: map[string]struct{}{"foo": struct{}{}, "bar": struct{}{}}}
 
+var RequestID typedefs.RequestID = typedefs.RequestID{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+
 func _EnumDefault_ptr(v enums.EnumDefault) *enums.EnumDefault {
 	return &v
 }
@@ -61,3 +66,12 @@ func _EnumDefault_ptr(v enums.EnumDefault) *enums.EnumDefault {
 var StructWithOptionalEnum *enums.StructWithOptionalEnum = &enums.StructWithOptionalEnum{E: _EnumDefault_ptr(enums.EnumDefaultBaz)}
 
 var UUID *typedefs.UUID = &typedefs.UUID{High: 1234, Low: 5678}
+
+// count is zero but set.
+var ValueFields *structs.ValueFields = func() *structs.ValueFields {
+	v := &structs.ValueFields{}
+	v.SetCount(0)
+	v.SetLimit(100)
+	v.SetName("foo")
+	return v
+}()
@@ -5,13 +5,14 @@ package containers
 
 import (
 	"bytes"
-	"errors"
+	"encoding/json"
 	"fmt"
 	"go.uber.org/thriftrw/gen/testdata/enum_conflict"
 	"go.uber.org/thriftrw/gen/testdata/enums"
 	"go.uber.org/thriftrw/gen/testdata/typedefs"
 	"go.uber.org/thriftrw/gen/testdata/uuid_conflict"
 	"go.uber.org/thriftrw/wire"
+	"strconv"
 	"strings"
 )
 
@@ -435,6 +436,7 @@ type _Map_Map_String_I32_I64_MapItemList []struct {
 }
 
 func (m _Map_Map_String_I32_I64_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	keys := wire.NewKeySet(len(m))
 	for _, i := range m {
 		k := i.Key
 		v := i.Value
@@ -445,6 +447,11 @@ func (m _Map_Map_String_I32_I64_MapItemList) ForEach(f func(wire.MapItem) error)
 		if err != nil {
 			return err
 		}
+		if _, dup, err := keys.Add(kw); err != nil {
+			return err
+		} else if dup {
+			return fmt.Errorf("invalid map key: duplicate key %v", k)
+		}
 		vw, err := wire.NewValueI64(v), error(nil)
 		if err != nil {
 			return err
@@ -505,6 +512,7 @@ type _Map_List_I32_Set_I64_MapItemList []struct {
 }
 
 func (m _Map_List_I32_Set_I64_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	keys := wire.NewKeySet(len(m))
 	for _, i := range m {
 		k := i.Key
 		v := i.Value
@@ -518,6 +526,11 @@ func (m _Map_List_I32_Set_I64_MapItemList) ForEach(f func(wire.MapItem) error) e
 		if err != nil {
 			return err
 		}
+		if _, dup, err := keys.Add(kw); err != nil {
+			return err
+		} else if dup {
+			return fmt.Errorf("invalid map key: duplicate key %v", k)
+		}
 		vw, err := wire.NewValueSet(_Set_I64_ValueList(v)), error(nil)
 		if err != nil {
 			return err
@@ -578,6 +591,7 @@ type _Map_Set_I32_List_Double_MapItemList []struct {
 }
 
 func (m _Map_Set_I32_List_Double_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	keys := wire.NewKeySet(len(m))
 	for _, i := range m {
 		k := i.Key
 		v := i.Value
@@ -591,6 +605,11 @@ func (m _Map_Set_I32_List_Double_MapItemList) ForEach(f func(wire.MapItem) error
 		if err != nil {
 			return err
 		}
+		if _, dup, err := keys.Add(kw); err != nil {
+			return err
+		} else if dup {
+			return fmt.Errorf("invalid map key: duplicate key %v", k)
+		}
 		vw, err := wire.NewValueList(_List_Double_ValueList(v)), error(nil)
 		if err != nil {
 			return err
@@ -956,7 +975,12 @@ func _Map_Map_String_I32_I64_Read(m wire.MapItemList) ([]struct {
 		Key   map[string]int32
 		Value int64
 	}, 0, m.Size())
+	keys := wire.NewKeySet(m.Size())
 	err := m.ForEach(func(x wire.MapItem) error {
+		i, dup, err := keys.Add(x.Key)
+		if err != nil {
+			return err
+		}
 		k, err := _Map_String_I32_Read(x.Key.GetMap())
 		if err != nil {
 			return err
@@ -965,6 +989,10 @@ func _Map_Map_String_I32_I64_Read(m wire.MapItemList) ([]struct {
 		if err != nil {
 			return err
 		}
+		if dup {
+			o[i].Value = v
+			return nil
+		}
 		o = append(o, struct {
 			Key   map[string]int32
 			Value int64
@@ -1006,7 +1034,12 @@ func _Map_List_I32_Set_I64_Read(m wire.MapItemList) ([]struct {
 		Key   []int32
 		Value map[int64]struct{}
 	}, 0, m.Size())
+	keys := wire.NewKeySet(m.Size())
 	err := m.ForEach(func(x wire.MapItem) error {
+		i, dup, err := keys.Add(x.Key)
+		if err != nil {
+			return err
+		}
 		k, err := _List_I32_Read(x.Key.GetList())
 		if err != nil {
 			return err
@@ -1015,6 +1048,10 @@ func _Map_List_I32_Set_I64_Read(m wire.MapItemList) ([]struct {
 		if err != nil {
 			return err
 		}
+		if dup {
+			o[i].Value = v
+			return nil
+		}
 		o = append(o, struct {
 			Key   []int32
 			Value map[int64]struct{}
@@ -1056,7 +1093,12 @@ func _Map_Set_I32_List_Double_Read(m wire.MapItemList) ([]struct {
 		Key   map[int32]struct{}
 		Value []float64
 	}, 0, m.Size())
+	keys := wire.NewKeySet(m.Size())
 	err := m.ForEach(func(x wire.MapItem) error {
+		i, dup, err := keys.Add(x.Key)
+		if err != nil {
+			return err
+		}
 		k, err := _Set_I32_Read(x.Key.GetSet())
 		if err != nil {
 			return err
@@ -1065,6 +1107,10 @@ func _Map_Set_I32_List_Double_Read(m wire.MapItemList) ([]struct {
 		if err != nil {
 			return err
 		}
+		if dup {
+			o[i].Value = v
+			return nil
+		}
 		o = append(o, struct {
 			Key   map[int32]struct{}
 			Value []float64
@@ -1083,6 +1129,7 @@ func (v *ContainersOfContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.ListOfLists, err = _List_List_I32_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("ContainersOfContainers", "ListOfLists", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1090,6 +1137,7 @@ func (v *ContainersOfContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.ListOfSets, err = _List_Set_I32_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("ContainersOfContainers", "ListOfSets", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1097,6 +1145,7 @@ func (v *ContainersOfContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.ListOfMaps, err = _List_Map_I32_I32_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("ContainersOfContainers", "ListOfMaps", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1104,6 +1153,7 @@ func (v *ContainersOfContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TSet {
 				v.SetOfSets, err = _Set_Set_String_Read(field.Value.GetSet())
 				if err != nil {
+					wire.ObserveDecodeError("ContainersOfContainers", "SetOfSets", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1111,6 +1161,7 @@ func (v *ContainersOfContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TSet {
 				v.SetOfLists, err = _Set_List_String_Read(field.Value.GetSet())
 				if err != nil {
+					wire.ObserveDecodeError("ContainersOfContainers", "SetOfLists", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1118,6 +1169,7 @@ func (v *ContainersOfContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TSet {
 				v.SetOfMaps, err = _Set_Map_String_String_Read(field.Value.GetSet())
 				if err != nil {
+					wire.ObserveDecodeError("ContainersOfContainers", "SetOfMaps", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1125,6 +1177,7 @@ func (v *ContainersOfContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TMap {
 				v.MapOfMapToInt, err = _Map_Map_String_I32_I64_Read(field.Value.GetMap())
 				if err != nil {
+					wire.ObserveDecodeError("ContainersOfContainers", "MapOfMapToInt", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1132,6 +1185,7 @@ func (v *ContainersOfContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TMap {
 				v.MapOfListToSet, err = _Map_List_I32_Set_I64_Read(field.Value.GetMap())
 				if err != nil {
+					wire.ObserveDecodeError("ContainersOfContainers", "MapOfListToSet", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1139,6 +1193,7 @@ func (v *ContainersOfContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TMap {
 				v.MapOfSetToListOfDouble, err = _Map_Set_I32_List_Double_Read(field.Value.GetMap())
 				if err != nil {
+					wire.ObserveDecodeError("ContainersOfContainers", "MapOfSetToListOfDouble", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1532,6 +1587,78 @@ func (v *ContainersOfContainers) Equals(rhs *ContainersOfContainers) bool {
 	return true
 }
 
+func (v *ContainersOfContainers) GetListOfLists() (o [][]int32) {
+	if v != nil && v.ListOfLists != nil {
+		return v.ListOfLists
+	}
+	return
+}
+
+func (v *ContainersOfContainers) GetListOfSets() (o []map[int32]struct{}) {
+	if v != nil && v.ListOfSets != nil {
+		return v.ListOfSets
+	}
+	return
+}
+
+func (v *ContainersOfContainers) GetListOfMaps() (o []map[int32]int32) {
+	if v != nil && v.ListOfMaps != nil {
+		return v.ListOfMaps
+	}
+	return
+}
+
+func (v *ContainersOfContainers) GetSetOfSets() (o []map[string]struct{}) {
+	if v != nil && v.SetOfSets != nil {
+		return v.SetOfSets
+	}
+	return
+}
+
+func (v *ContainersOfContainers) GetSetOfLists() (o [][]string) {
+	if v != nil && v.SetOfLists != nil {
+		return v.SetOfLists
+	}
+	return
+}
+
+func (v *ContainersOfContainers) GetSetOfMaps() (o []map[string]string) {
+	if v != nil && v.SetOfMaps != nil {
+		return v.SetOfMaps
+	}
+	return
+}
+
+func (v *ContainersOfContainers) GetMapOfMapToInt() (o []struct {
+	Key   map[string]int32
+	Value int64
+}) {
+	if v != nil && v.MapOfMapToInt != nil {
+		return v.MapOfMapToInt
+	}
+	return
+}
+
+func (v *ContainersOfContainers) GetMapOfListToSet() (o []struct {
+	Key   []int32
+	Value map[int64]struct{}
+}) {
+	if v != nil && v.MapOfListToSet != nil {
+		return v.MapOfListToSet
+	}
+	return
+}
+
+func (v *ContainersOfContainers) GetMapOfSetToListOfDouble() (o []struct {
+	Key   map[int32]struct{}
+	Value []float64
+}) {
+	if v != nil && v.MapOfSetToListOfDouble != nil {
+		return v.MapOfSetToListOfDouble
+	}
+	return
+}
+
 type EnumContainers struct {
 	ListOfEnums []enums.EnumDefault                     `json:"listOfEnums"`
 	SetOfEnums  map[enums.EnumWithValues]struct{}       `json:"setOfEnums"`
@@ -1745,6 +1872,7 @@ func (v *EnumContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.ListOfEnums, err = _List_EnumDefault_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("EnumContainers", "ListOfEnums", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1752,6 +1880,7 @@ func (v *EnumContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TSet {
 				v.SetOfEnums, err = _Set_EnumWithValues_Read(field.Value.GetSet())
 				if err != nil {
+					wire.ObserveDecodeError("EnumContainers", "SetOfEnums", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1759,6 +1888,7 @@ func (v *EnumContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TMap {
 				v.MapOfEnums, err = _Map_EnumWithDuplicateValues_I32_Read(field.Value.GetMap())
 				if err != nil {
+					wire.ObserveDecodeError("EnumContainers", "MapOfEnums", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1842,6 +1972,27 @@ func (v *EnumContainers) Equals(rhs *EnumContainers) bool {
 	return true
 }
 
+func (v *EnumContainers) GetListOfEnums() (o []enums.EnumDefault) {
+	if v != nil && v.ListOfEnums != nil {
+		return v.ListOfEnums
+	}
+	return
+}
+
+func (v *EnumContainers) GetSetOfEnums() (o map[enums.EnumWithValues]struct{}) {
+	if v != nil && v.SetOfEnums != nil {
+		return v.SetOfEnums
+	}
+	return
+}
+
+func (v *EnumContainers) GetMapOfEnums() (o map[enums.EnumWithDuplicateValues]int32) {
+	if v != nil && v.MapOfEnums != nil {
+		return v.MapOfEnums
+	}
+	return
+}
+
 type ListOfConflictingEnums struct {
 	Records      []enum_conflict.RecordType `json:"records"`
 	OtherRecords []enums.RecordType         `json:"otherRecords"`
@@ -1909,7 +2060,7 @@ func (v *ListOfConflictingEnums) ToWire() (wire.Value, error) {
 		err    error
 	)
 	if v.Records == nil {
-		return w, errors.New("field Records of ListOfConflictingEnums is required")
+		return w, wire.NilRequiredFieldError{Struct: "ListOfConflictingEnums", Field: "Records", ID: 1}
 	}
 	w, err = wire.NewValueList(_List_RecordType_ValueList(v.Records)), error(nil)
 	if err != nil {
@@ -1918,7 +2069,7 @@ func (v *ListOfConflictingEnums) ToWire() (wire.Value, error) {
 	fields[i] = wire.Field{ID: 1, Value: w}
 	i++
 	if v.OtherRecords == nil {
-		return w, errors.New("field OtherRecords of ListOfConflictingEnums is required")
+		return w, wire.NilRequiredFieldError{Struct: "ListOfConflictingEnums", Field: "OtherRecords", ID: 2}
 	}
 	w, err = wire.NewValueList(_List_RecordType_1_ValueList(v.OtherRecords)), error(nil)
 	if err != nil {
@@ -1985,6 +2136,7 @@ func (v *ListOfConflictingEnums) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.Records, err = _List_RecordType_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("ListOfConflictingEnums", "Records", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				recordsIsSet = true
@@ -1993,6 +2145,7 @@ func (v *ListOfConflictingEnums) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.OtherRecords, err = _List_RecordType_1_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("ListOfConflictingEnums", "OtherRecords", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				otherRecordsIsSet = true
@@ -2000,10 +2153,12 @@ func (v *ListOfConflictingEnums) FromWire(w wire.Value) error {
 		}
 	}
 	if !recordsIsSet {
-		return errors.New("field Records of ListOfConflictingEnums is required")
+		wire.ObserveDecodeError("ListOfConflictingEnums", "Records", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "ListOfConflictingEnums", Field: "Records", ID: 1}
 	}
 	if !otherRecordsIsSet {
-		return errors.New("field OtherRecords of ListOfConflictingEnums is required")
+		wire.ObserveDecodeError("ListOfConflictingEnums", "OtherRecords", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "ListOfConflictingEnums", Field: "OtherRecords", ID: 2}
 	}
 	return nil
 }
@@ -2057,6 +2212,20 @@ func (v *ListOfConflictingEnums) Equals(rhs *ListOfConflictingEnums) bool {
 	return true
 }
 
+func (v *ListOfConflictingEnums) GetRecords() (o []enum_conflict.RecordType) {
+	if v != nil {
+		o = v.Records
+	}
+	return
+}
+
+func (v *ListOfConflictingEnums) GetOtherRecords() (o []enums.RecordType) {
+	if v != nil {
+		o = v.OtherRecords
+	}
+	return
+}
+
 type ListOfConflictingUUIDs struct {
 	Uuids      []*typedefs.UUID     `json:"uuids"`
 	OtherUUIDs []uuid_conflict.UUID `json:"otherUUIDs"`
@@ -2127,7 +2296,7 @@ func (v *ListOfConflictingUUIDs) ToWire() (wire.Value, error) {
 		err    error
 	)
 	if v.Uuids == nil {
-		return w, errors.New("field Uuids of ListOfConflictingUUIDs is required")
+		return w, wire.NilRequiredFieldError{Struct: "ListOfConflictingUUIDs", Field: "Uuids", ID: 1}
 	}
 	w, err = wire.NewValueList(_List_UUID_ValueList(v.Uuids)), error(nil)
 	if err != nil {
@@ -2136,7 +2305,7 @@ func (v *ListOfConflictingUUIDs) ToWire() (wire.Value, error) {
 	fields[i] = wire.Field{ID: 1, Value: w}
 	i++
 	if v.OtherUUIDs == nil {
-		return w, errors.New("field OtherUUIDs of ListOfConflictingUUIDs is required")
+		return w, wire.NilRequiredFieldError{Struct: "ListOfConflictingUUIDs", Field: "OtherUUIDs", ID: 2}
 	}
 	w, err = wire.NewValueList(_List_UUID_1_ValueList(v.OtherUUIDs)), error(nil)
 	if err != nil {
@@ -2203,6 +2372,7 @@ func (v *ListOfConflictingUUIDs) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.Uuids, err = _List_UUID_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("ListOfConflictingUUIDs", "Uuids", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				uuidsIsSet = true
@@ -2211,6 +2381,7 @@ func (v *ListOfConflictingUUIDs) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.OtherUUIDs, err = _List_UUID_1_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("ListOfConflictingUUIDs", "OtherUUIDs", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				otherUUIDsIsSet = true
@@ -2218,10 +2389,12 @@ func (v *ListOfConflictingUUIDs) FromWire(w wire.Value) error {
 		}
 	}
 	if !uuidsIsSet {
-		return errors.New("field Uuids of ListOfConflictingUUIDs is required")
+		wire.ObserveDecodeError("ListOfConflictingUUIDs", "Uuids", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "ListOfConflictingUUIDs", Field: "Uuids", ID: 1}
 	}
 	if !otherUUIDsIsSet {
-		return errors.New("field OtherUUIDs of ListOfConflictingUUIDs is required")
+		wire.ObserveDecodeError("ListOfConflictingUUIDs", "OtherUUIDs", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "ListOfConflictingUUIDs", Field: "OtherUUIDs", ID: 2}
 	}
 	return nil
 }
@@ -2275,6 +2448,20 @@ func (v *ListOfConflictingUUIDs) Equals(rhs *ListOfConflictingUUIDs) bool {
 	return true
 }
 
+func (v *ListOfConflictingUUIDs) GetUuids() (o []*typedefs.UUID) {
+	if v != nil {
+		o = v.Uuids
+	}
+	return
+}
+
+func (v *ListOfConflictingUUIDs) GetOtherUUIDs() (o []uuid_conflict.UUID) {
+	if v != nil {
+		o = v.OtherUUIDs
+	}
+	return
+}
+
 type MapOfBinaryAndString struct {
 	BinaryToString []struct {
 		Key   []byte
@@ -2289,6 +2476,7 @@ type _Map_Binary_String_MapItemList []struct {
 }
 
 func (m _Map_Binary_String_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	keys := wire.NewKeySet(len(m))
 	for _, i := range m {
 		k := i.Key
 		v := i.Value
@@ -2299,6 +2487,11 @@ func (m _Map_Binary_String_MapItemList) ForEach(f func(wire.MapItem) error) erro
 		if err != nil {
 			return err
 		}
+		if _, dup, err := keys.Add(kw); err != nil {
+			return err
+		} else if dup {
+			return fmt.Errorf("invalid map key: duplicate key %v", k)
+		}
 		vw, err := wire.NewValueString(v), error(nil)
 		if err != nil {
 			return err
@@ -2404,7 +2597,12 @@ func _Map_Binary_String_Read(m wire.MapItemList) ([]struct {
 		Key   []byte
 		Value string
 	}, 0, m.Size())
+	keys := wire.NewKeySet(m.Size())
 	err := m.ForEach(func(x wire.MapItem) error {
+		i, dup, err := keys.Add(x.Key)
+		if err != nil {
+			return err
+		}
 		k, err := x.Key.GetBinary(), error(nil)
 		if err != nil {
 			return err
@@ -2413,6 +2611,10 @@ func _Map_Binary_String_Read(m wire.MapItemList) ([]struct {
 		if err != nil {
 			return err
 		}
+		if dup {
+			o[i].Value = v
+			return nil
+		}
 		o = append(o, struct {
 			Key   []byte
 			Value string
@@ -2455,6 +2657,7 @@ func (v *MapOfBinaryAndString) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TMap {
 				v.BinaryToString, err = _Map_Binary_String_Read(field.Value.GetMap())
 				if err != nil {
+					wire.ObserveDecodeError("MapOfBinaryAndString", "BinaryToString", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -2462,6 +2665,7 @@ func (v *MapOfBinaryAndString) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TMap {
 				v.StringToBinary, err = _Map_String_Binary_Read(field.Value.GetMap())
 				if err != nil {
+					wire.ObserveDecodeError("MapOfBinaryAndString", "StringToBinary", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -2543,6 +2747,332 @@ func (v *MapOfBinaryAndString) Equals(rhs *MapOfBinaryAndString) bool {
 	return true
 }
 
+func (v *MapOfBinaryAndString) GetBinaryToString() (o []struct {
+	Key   []byte
+	Value string
+}) {
+	if v != nil && v.BinaryToString != nil {
+		return v.BinaryToString
+	}
+	return
+}
+
+func (v *MapOfBinaryAndString) GetStringToBinary() (o map[string][]byte) {
+	if v != nil && v.StringToBinary != nil {
+		return v.StringToBinary
+	}
+	return
+}
+
+type MapsWithPrimitiveKeys struct {
+	BoolToString map[bool]string   `json:"boolToString"`
+	DoubleToInt  map[float64]int64 `json:"doubleToInt"`
+}
+
+type _Map_Bool_String_MapItemList map[bool]string
+
+func (m _Map_Bool_String_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		kw, err := wire.NewValueBool(k), error(nil)
+		if err != nil {
+			return err
+		}
+		vw, err := wire.NewValueString(v), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_Bool_String_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_Bool_String_MapItemList) KeyType() wire.Type {
+	return wire.TBool
+}
+
+func (_Map_Bool_String_MapItemList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_Bool_String_MapItemList) Close() {
+}
+
+type _Map_Double_I64_MapItemList map[float64]int64
+
+func (m _Map_Double_I64_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		kw, err := wire.NewValueDouble(k), error(nil)
+		if err != nil {
+			return err
+		}
+		vw, err := wire.NewValueI64(v), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_Double_I64_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_Double_I64_MapItemList) KeyType() wire.Type {
+	return wire.TDouble
+}
+
+func (_Map_Double_I64_MapItemList) ValueType() wire.Type {
+	return wire.TI64
+}
+
+func (_Map_Double_I64_MapItemList) Close() {
+}
+
+func (v *MapsWithPrimitiveKeys) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.BoolToString != nil {
+		w, err = wire.NewValueMap(_Map_Bool_String_MapItemList(v.BoolToString)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.DoubleToInt == nil {
+		return w, wire.NilRequiredFieldError{Struct: "MapsWithPrimitiveKeys", Field: "DoubleToInt", ID: 2}
+	}
+	w, err = wire.NewValueMap(_Map_Double_I64_MapItemList(v.DoubleToInt)), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 2, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Map_Bool_String_Read(m wire.MapItemList) (map[bool]string, error) {
+	if m.KeyType() != wire.TBool {
+		return nil, nil
+	}
+	if m.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+	o := make(map[bool]string, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetBool(), error(nil)
+		if err != nil {
+			return err
+		}
+		v, err := x.Value.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+func _Map_Double_I64_Read(m wire.MapItemList) (map[float64]int64, error) {
+	if m.KeyType() != wire.TDouble {
+		return nil, nil
+	}
+	if m.ValueType() != wire.TI64 {
+		return nil, nil
+	}
+	o := make(map[float64]int64, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetDouble(), error(nil)
+		if err != nil {
+			return err
+		}
+		v, err := x.Value.GetI64(), error(nil)
+		if err != nil {
+			return err
+		}
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+func (v *MapsWithPrimitiveKeys) FromWire(w wire.Value) error {
+	var err error
+	doubleToIntIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TMap {
+				v.BoolToString, err = _Map_Bool_String_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("MapsWithPrimitiveKeys", "BoolToString", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 2:
+			if field.Value.Type() == wire.TMap {
+				v.DoubleToInt, err = _Map_Double_I64_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("MapsWithPrimitiveKeys", "DoubleToInt", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				doubleToIntIsSet = true
+			}
+		}
+	}
+	if !doubleToIntIsSet {
+		wire.ObserveDecodeError("MapsWithPrimitiveKeys", "DoubleToInt", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "MapsWithPrimitiveKeys", Field: "DoubleToInt", ID: 2}
+	}
+	return nil
+}
+
+func (v *MapsWithPrimitiveKeys) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	if v.BoolToString != nil {
+		fields[i] = fmt.Sprintf("BoolToString: %v", v.BoolToString)
+		i++
+	}
+	fields[i] = fmt.Sprintf("DoubleToInt: %v", v.DoubleToInt)
+	i++
+	return fmt.Sprintf("MapsWithPrimitiveKeys{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Map_Bool_String_Equals(lhs, rhs map[bool]string) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func _Map_Double_I64_Equals(lhs, rhs map[float64]int64) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *MapsWithPrimitiveKeys) Equals(rhs *MapsWithPrimitiveKeys) bool {
+	if !((v.BoolToString == nil && rhs.BoolToString == nil) || (v.BoolToString != nil && rhs.BoolToString != nil && _Map_Bool_String_Equals(v.BoolToString, rhs.BoolToString))) {
+		return false
+	}
+	if !_Map_Double_I64_Equals(v.DoubleToInt, rhs.DoubleToInt) {
+		return false
+	}
+	return true
+}
+
+func (v *MapsWithPrimitiveKeys) MarshalJSON() ([]byte, error) {
+	type plain MapsWithPrimitiveKeys
+	x := struct {
+		*plain
+		BoolToString map[string]string `json:"boolToString"`
+		DoubleToInt  map[string]int64  `json:"doubleToInt"`
+	}{plain: (*plain)(v)}
+	if v.BoolToString != nil {
+		x.BoolToString = make(map[string]string, len(v.BoolToString))
+		for k, i := range v.BoolToString {
+			x.BoolToString[strconv.FormatBool(bool(k))] = i
+		}
+	}
+	if v.DoubleToInt != nil {
+		x.DoubleToInt = make(map[string]int64, len(v.DoubleToInt))
+		for k, i := range v.DoubleToInt {
+			x.DoubleToInt[strconv.FormatFloat(float64(k), 'g', -1, 64)] = i
+		}
+	}
+	return json.Marshal(x)
+}
+
+func (v *MapsWithPrimitiveKeys) UnmarshalJSON(text []byte) error {
+	type plain MapsWithPrimitiveKeys
+	x := struct {
+		*plain
+		BoolToString map[string]string `json:"boolToString"`
+		DoubleToInt  map[string]int64  `json:"doubleToInt"`
+	}{plain: (*plain)(v)}
+	if err := json.Unmarshal(text, &x); err != nil {
+		return err
+	}
+	v.BoolToString = nil
+	if x.BoolToString != nil {
+		v.BoolToString = make(map[bool]string, len(x.BoolToString))
+		for k, i := range x.BoolToString {
+			key, err := strconv.ParseBool(k)
+			if err != nil {
+				return fmt.Errorf("invalid key %q in field BoolToString of MapsWithPrimitiveKeys: %v", k, err)
+			}
+			v.BoolToString[bool(key)] = i
+		}
+	}
+	v.DoubleToInt = nil
+	if x.DoubleToInt != nil {
+		v.DoubleToInt = make(map[float64]int64, len(x.DoubleToInt))
+		for k, i := range x.DoubleToInt {
+			key, err := strconv.ParseFloat(k, 64)
+			if err != nil {
+				return fmt.Errorf("invalid key %q in field DoubleToInt of MapsWithPrimitiveKeys: %v", k, err)
+			}
+			v.DoubleToInt[float64(key)] = i
+		}
+	}
+	return nil
+}
+
+func (v *MapsWithPrimitiveKeys) GetBoolToString() (o map[bool]string) {
+	if v != nil && v.BoolToString != nil {
+		return v.BoolToString
+	}
+	return
+}
+
+func (v *MapsWithPrimitiveKeys) GetDoubleToInt() (o map[float64]int64) {
+	if v != nil {
+		o = v.DoubleToInt
+	}
+	return
+}
+
 type PrimitiveContainers struct {
 	ListOfBinary      [][]byte            `json:"listOfBinary"`
 	ListOfInts        []int64             `json:"listOfInts"`
@@ -2871,6 +3401,7 @@ func (v *PrimitiveContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.ListOfBinary, err = _List_Binary_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("PrimitiveContainers", "ListOfBinary", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -2878,6 +3409,7 @@ func (v *PrimitiveContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.ListOfInts, err = _List_I64_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("PrimitiveContainers", "ListOfInts", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -2885,6 +3417,7 @@ func (v *PrimitiveContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TSet {
 				v.SetOfStrings, err = _Set_String_Read(field.Value.GetSet())
 				if err != nil {
+					wire.ObserveDecodeError("PrimitiveContainers", "SetOfStrings", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -2892,6 +3425,7 @@ func (v *PrimitiveContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TSet {
 				v.SetOfBytes, err = _Set_Byte_Read(field.Value.GetSet())
 				if err != nil {
+					wire.ObserveDecodeError("PrimitiveContainers", "SetOfBytes", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -2899,6 +3433,7 @@ func (v *PrimitiveContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TMap {
 				v.MapOfIntToString, err = _Map_I32_String_Read(field.Value.GetMap())
 				if err != nil {
+					wire.ObserveDecodeError("PrimitiveContainers", "MapOfIntToString", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -2906,6 +3441,7 @@ func (v *PrimitiveContainers) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TMap {
 				v.MapOfStringToBool, err = _Map_String_Bool_Read(field.Value.GetMap())
 				if err != nil {
+					wire.ObserveDecodeError("PrimitiveContainers", "MapOfStringToBool", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -3039,6 +3575,48 @@ func (v *PrimitiveContainers) Equals(rhs *PrimitiveContainers) bool {
 	return true
 }
 
+func (v *PrimitiveContainers) GetListOfBinary() (o [][]byte) {
+	if v != nil && v.ListOfBinary != nil {
+		return v.ListOfBinary
+	}
+	return
+}
+
+func (v *PrimitiveContainers) GetListOfInts() (o []int64) {
+	if v != nil && v.ListOfInts != nil {
+		return v.ListOfInts
+	}
+	return
+}
+
+func (v *PrimitiveContainers) GetSetOfStrings() (o map[string]struct{}) {
+	if v != nil && v.SetOfStrings != nil {
+		return v.SetOfStrings
+	}
+	return
+}
+
+func (v *PrimitiveContainers) GetSetOfBytes() (o map[int8]struct{}) {
+	if v != nil && v.SetOfBytes != nil {
+		return v.SetOfBytes
+	}
+	return
+}
+
+func (v *PrimitiveContainers) GetMapOfIntToString() (o map[int32]string) {
+	if v != nil && v.MapOfIntToString != nil {
+		return v.MapOfIntToString
+	}
+	return
+}
+
+func (v *PrimitiveContainers) GetMapOfStringToBool() (o map[string]bool) {
+	if v != nil && v.MapOfStringToBool != nil {
+		return v.MapOfStringToBool
+	}
+	return
+}
+
 type PrimitiveContainersRequired struct {
 	ListOfStrings      []string           `json:"listOfStrings"`
 	SetOfInts          map[int32]struct{} `json:"setOfInts"`
@@ -3088,7 +3666,7 @@ func (v *PrimitiveContainersRequired) ToWire() (wire.Value, error) {
 		err    error
 	)
 	if v.ListOfStrings == nil {
-		return w, errors.New("field ListOfStrings of PrimitiveContainersRequired is required")
+		return w, wire.NilRequiredFieldError{Struct: "PrimitiveContainersRequired", Field: "ListOfStrings", ID: 1}
 	}
 	w, err = wire.NewValueList(_List_String_ValueList(v.ListOfStrings)), error(nil)
 	if err != nil {
@@ -3097,7 +3675,7 @@ func (v *PrimitiveContainersRequired) ToWire() (wire.Value, error) {
 	fields[i] = wire.Field{ID: 1, Value: w}
 	i++
 	if v.SetOfInts == nil {
-		return w, errors.New("field SetOfInts of PrimitiveContainersRequired is required")
+		return w, wire.NilRequiredFieldError{Struct: "PrimitiveContainersRequired", Field: "SetOfInts", ID: 2}
 	}
 	w, err = wire.NewValueSet(_Set_I32_ValueList(v.SetOfInts)), error(nil)
 	if err != nil {
@@ -3106,7 +3684,7 @@ func (v *PrimitiveContainersRequired) ToWire() (wire.Value, error) {
 	fields[i] = wire.Field{ID: 2, Value: w}
 	i++
 	if v.MapOfIntsToDoubles == nil {
-		return w, errors.New("field MapOfIntsToDoubles of PrimitiveContainersRequired is required")
+		return w, wire.NilRequiredFieldError{Struct: "PrimitiveContainersRequired", Field: "MapOfIntsToDoubles", ID: 3}
 	}
 	w, err = wire.NewValueMap(_Map_I64_Double_MapItemList(v.MapOfIntsToDoubles)), error(nil)
 	if err != nil {
@@ -3152,6 +3730,7 @@ func (v *PrimitiveContainersRequired) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.ListOfStrings, err = _List_String_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("PrimitiveContainersRequired", "ListOfStrings", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				listOfStringsIsSet = true
@@ -3160,6 +3739,7 @@ func (v *PrimitiveContainersRequired) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TSet {
 				v.SetOfInts, err = _Set_I32_Read(field.Value.GetSet())
 				if err != nil {
+					wire.ObserveDecodeError("PrimitiveContainersRequired", "SetOfInts", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				setOfIntsIsSet = true
@@ -3168,6 +3748,7 @@ func (v *PrimitiveContainersRequired) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TMap {
 				v.MapOfIntsToDoubles, err = _Map_I64_Double_Read(field.Value.GetMap())
 				if err != nil {
+					wire.ObserveDecodeError("PrimitiveContainersRequired", "MapOfIntsToDoubles", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				mapOfIntsToDoublesIsSet = true
@@ -3175,13 +3756,16 @@ func (v *PrimitiveContainersRequired) FromWire(w wire.Value) error {
 		}
 	}
 	if !listOfStringsIsSet {
-		return errors.New("field ListOfStrings of PrimitiveContainersRequired is required")
+		wire.ObserveDecodeError("PrimitiveContainersRequired", "ListOfStrings", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "PrimitiveContainersRequired", Field: "ListOfStrings", ID: 1}
 	}
 	if !setOfIntsIsSet {
-		return errors.New("field SetOfInts of PrimitiveContainersRequired is required")
+		wire.ObserveDecodeError("PrimitiveContainersRequired", "SetOfInts", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "PrimitiveContainersRequired", Field: "SetOfInts", ID: 2}
 	}
 	if !mapOfIntsToDoublesIsSet {
-		return errors.New("field MapOfIntsToDoubles of PrimitiveContainersRequired is required")
+		wire.ObserveDecodeError("PrimitiveContainersRequired", "MapOfIntsToDoubles", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "PrimitiveContainersRequired", Field: "MapOfIntsToDoubles", ID: 3}
 	}
 	return nil
 }
@@ -3229,3 +3813,24 @@ func (v *PrimitiveContainersRequired) Equals(rhs *PrimitiveContainersRequired) b
 	}
 	return true
 }
+
+func (v *PrimitiveContainersRequired) GetListOfStrings() (o []string) {
+	if v != nil {
+		o = v.ListOfStrings
+	}
+	return
+}
+
+func (v *PrimitiveContainersRequired) GetSetOfInts() (o map[int32]struct{}) {
+	if v != nil {
+		o = v.SetOfInts
+	}
+	return
+}
+
+func (v *PrimitiveContainersRequired) GetMapOfIntsToDoubles() (o map[int64]float64) {
+	if v != nil {
+		o = v.MapOfIntsToDoubles
+	}
+	return
+}
@@ -34,10 +34,25 @@ func (v *RecordType) UnmarshalText(value []byte) error {
 		*v = RecordTypeEmail
 		return nil
 	default:
-		return fmt.Errorf("unknown enum value %q for %q", value, "RecordType")
+		val, err := strconv.ParseInt(string(value), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", value, "RecordType", err)
+		}
+		*v = RecordType(val)
+		return nil
 	}
 }
 
+func (v RecordType) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("Name"), nil
+	case 1:
+		return []byte("Email"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
 func (v RecordType) ToWire() (wire.Value, error) {
 	return wire.NewValueI32(int32(v)), nil
 }
@@ -167,6 +182,7 @@ func (v *Records) FromWire(w wire.Value) error {
 				x, err = _RecordType_Read(field.Value)
 				v.RecordType = &x
 				if err != nil {
+					wire.ObserveDecodeError("Records", "RecordType", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -176,6 +192,7 @@ func (v *Records) FromWire(w wire.Value) error {
 				x, err = _RecordType_1_Read(field.Value)
 				v.OtherRecordType = &x
 				if err != nil {
+					wire.ObserveDecodeError("Records", "OtherRecordType", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -234,3 +251,19 @@ func (v *Records) Equals(rhs *Records) bool {
 	}
 	return true
 }
+
+func (v *Records) GetRecordType() (o RecordType) {
+	if v != nil && v.RecordType != nil {
+		return *v.RecordType
+	}
+	o = DefaultRecordType
+	return
+}
+
+func (v *Records) GetOtherRecordType() (o enums.RecordType) {
+	if v != nil && v.OtherRecordType != nil {
+		return *v.OtherRecordType
+	}
+	o = DefaultOtherRecordType
+	return
+}
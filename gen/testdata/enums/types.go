@@ -18,10 +18,19 @@ type EmptyEnum int32
 func (v *EmptyEnum) UnmarshalText(value []byte) error {
 	switch string(value) {
 	default:
-		return fmt.Errorf("unknown enum value %q for %q", value, "EmptyEnum")
+		val, err := strconv.ParseInt(string(value), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", value, "EmptyEnum", err)
+		}
+		*v = EmptyEnum(val)
+		return nil
 	}
 }
 
+func (v EmptyEnum) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
 func (v EmptyEnum) ToWire() (wire.Value, error) {
 	return wire.NewValueI32(int32(v)), nil
 }
@@ -96,10 +105,27 @@ func (v *EnumDefault) UnmarshalText(value []byte) error {
 		*v = EnumDefaultBaz
 		return nil
 	default:
-		return fmt.Errorf("unknown enum value %q for %q", value, "EnumDefault")
+		val, err := strconv.ParseInt(string(value), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", value, "EnumDefault", err)
+		}
+		*v = EnumDefault(val)
+		return nil
 	}
 }
 
+func (v EnumDefault) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("Foo"), nil
+	case 1:
+		return []byte("Bar"), nil
+	case 2:
+		return []byte("Baz"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
 func (v EnumDefault) ToWire() (wire.Value, error) {
 	return wire.NewValueI32(int32(v)), nil
 }
@@ -166,6 +192,7 @@ func (v *EnumDefault) UnmarshalJSON(text []byte) error {
 	}
 }
 
+// enum with item names conflicting with those of another enum
 type EnumWithDuplicateName int32
 
 const (
@@ -214,10 +241,39 @@ func (v *EnumWithDuplicateName) UnmarshalText(value []byte) error {
 		*v = EnumWithDuplicateNameZ
 		return nil
 	default:
-		return fmt.Errorf("unknown enum value %q for %q", value, "EnumWithDuplicateName")
+		val, err := strconv.ParseInt(string(value), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", value, "EnumWithDuplicateName", err)
+		}
+		*v = EnumWithDuplicateName(val)
+		return nil
 	}
 }
 
+func (v EnumWithDuplicateName) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("A"), nil
+	case 1:
+		return []byte("B"), nil
+	case 2:
+		return []byte("C"), nil
+	case 3:
+		return []byte("P"), nil
+	case 4:
+		return []byte("Q"), nil
+	case 5:
+		return []byte("R"), nil
+	case 6:
+		return []byte("X"), nil
+	case 7:
+		return []byte("Y"), nil
+	case 8:
+		return []byte("Z"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
 func (v EnumWithDuplicateName) ToWire() (wire.Value, error) {
 	return wire.NewValueI32(int32(v)), nil
 }
@@ -332,8 +388,23 @@ func (v *EnumWithDuplicateValues) UnmarshalText(value []byte) error {
 		*v = EnumWithDuplicateValuesR
 		return nil
 	default:
-		return fmt.Errorf("unknown enum value %q for %q", value, "EnumWithDuplicateValues")
+		val, err := strconv.ParseInt(string(value), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", value, "EnumWithDuplicateValues", err)
+		}
+		*v = EnumWithDuplicateValues(val)
+		return nil
+	}
+}
+
+func (v EnumWithDuplicateValues) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("P"), nil
+	case -1:
+		return []byte("Q"), nil
 	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
 
 func (v EnumWithDuplicateValues) ToWire() (wire.Value, error) {
@@ -422,10 +493,27 @@ func (v *EnumWithValues) UnmarshalText(value []byte) error {
 		*v = EnumWithValuesZ
 		return nil
 	default:
-		return fmt.Errorf("unknown enum value %q for %q", value, "EnumWithValues")
+		val, err := strconv.ParseInt(string(value), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", value, "EnumWithValues", err)
+		}
+		*v = EnumWithValues(val)
+		return nil
 	}
 }
 
+func (v EnumWithValues) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 123:
+		return []byte("X"), nil
+	case 456:
+		return []byte("Y"), nil
+	case 789:
+		return []byte("Z"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
 func (v EnumWithValues) ToWire() (wire.Value, error) {
 	return wire.NewValueI32(int32(v)), nil
 }
@@ -516,10 +604,27 @@ func (v *RecordType) UnmarshalText(value []byte) error {
 		*v = RecordTypeWorkAddress
 		return nil
 	default:
-		return fmt.Errorf("unknown enum value %q for %q", value, "RecordType")
+		val, err := strconv.ParseInt(string(value), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", value, "RecordType", err)
+		}
+		*v = RecordType(val)
+		return nil
 	}
 }
 
+func (v RecordType) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("NAME"), nil
+	case 1:
+		return []byte("HOME_ADDRESS"), nil
+	case 2:
+		return []byte("WORK_ADDRESS"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
 func (v RecordType) ToWire() (wire.Value, error) {
 	return wire.NewValueI32(int32(v)), nil
 }
@@ -586,6 +691,7 @@ func (v *RecordType) UnmarshalJSON(text []byte) error {
 	}
 }
 
+// collision with RecordType_Values() function.
 type RecordTypeValues int32
 
 const (
@@ -606,8 +712,23 @@ func (v *RecordTypeValues) UnmarshalText(value []byte) error {
 		*v = RecordTypeValuesBar
 		return nil
 	default:
-		return fmt.Errorf("unknown enum value %q for %q", value, "RecordTypeValues")
+		val, err := strconv.ParseInt(string(value), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", value, "RecordTypeValues", err)
+		}
+		*v = RecordTypeValues(val)
+		return nil
+	}
+}
+
+func (v RecordTypeValues) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("FOO"), nil
+	case 1:
+		return []byte("BAR"), nil
 	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
 
 func (v RecordTypeValues) ToWire() (wire.Value, error) {
@@ -672,6 +793,114 @@ func (v *RecordTypeValues) UnmarshalJSON(text []byte) error {
 	}
 }
 
+// Enum which rejects unrecognized values when decoded.
+type StrictEnum int32
+
+const (
+	StrictEnumActive   StrictEnum = 1
+	StrictEnumInactive StrictEnum = 2
+)
+
+func StrictEnum_Values() []StrictEnum {
+	return []StrictEnum{StrictEnumActive, StrictEnumInactive}
+}
+
+func (v *StrictEnum) UnmarshalText(value []byte) error {
+	switch string(value) {
+	case "ACTIVE":
+		*v = StrictEnumActive
+		return nil
+	case "INACTIVE":
+		*v = StrictEnumInactive
+		return nil
+	default:
+		val, err := strconv.ParseInt(string(value), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", value, "StrictEnum", err)
+		}
+		*v = StrictEnum(val)
+		return nil
+	}
+}
+
+func (v StrictEnum) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 1:
+		return []byte("ACTIVE"), nil
+	case 2:
+		return []byte("INACTIVE"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+func (v StrictEnum) ToWire() (wire.Value, error) {
+	return wire.NewValueI32(int32(v)), nil
+}
+
+func (v *StrictEnum) FromWire(w wire.Value) error {
+	x := w.GetI32()
+	switch x {
+	case 1, 2:
+		*v = (StrictEnum)(x)
+		return nil
+	}
+	return wire.UnknownEnumError{Enum: "StrictEnum", Value: x}
+}
+
+func (v StrictEnum) String() string {
+	w := int32(v)
+	switch w {
+	case 1:
+		return "ACTIVE"
+	case 2:
+		return "INACTIVE"
+	}
+	return fmt.Sprintf("StrictEnum(%d)", w)
+}
+
+func (v StrictEnum) Equals(rhs StrictEnum) bool {
+	return v == rhs
+}
+
+func (v StrictEnum) MarshalJSON() ([]byte, error) {
+	switch int32(v) {
+	case 1:
+		return ([]byte)("\"ACTIVE\""), nil
+	case 2:
+		return ([]byte)("\"INACTIVE\""), nil
+	}
+	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+}
+
+func (v *StrictEnum) UnmarshalJSON(text []byte) error {
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+	switch w := t.(type) {
+	case json.Number:
+		x2, err := w.Int64()
+		if err != nil {
+			return err
+		}
+		if x2 > math.MaxInt32 {
+			return fmt.Errorf("enum overflow from JSON %q for %q", text, "StrictEnum")
+		}
+		if x2 < math.MinInt32 {
+			return fmt.Errorf("enum underflow from JSON %q for %q", text, "StrictEnum")
+		}
+		*v = (StrictEnum)(x2)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(w))
+	default:
+		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "StrictEnum")
+	}
+}
+
+// Enum treated as optional inside a struct
 type StructWithOptionalEnum struct {
 	E *EnumDefault `json:"e,omitempty"`
 }
@@ -710,6 +939,7 @@ func (v *StructWithOptionalEnum) FromWire(w wire.Value) error {
 				x, err = _EnumDefault_Read(field.Value)
 				v.E = &x
 				if err != nil {
+					wire.ObserveDecodeError("StructWithOptionalEnum", "E", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -747,6 +977,13 @@ func (v *StructWithOptionalEnum) Equals(rhs *StructWithOptionalEnum) bool {
 	return true
 }
 
+func (v *StructWithOptionalEnum) GetE() (o EnumDefault) {
+	if v != nil && v.E != nil {
+		return *v.E
+	}
+	return
+}
+
 type LowerCaseEnum int32
 
 const (
@@ -771,8 +1008,25 @@ func (v *LowerCaseEnum) UnmarshalText(value []byte) error {
 		*v = LowerCaseEnumItems
 		return nil
 	default:
-		return fmt.Errorf("unknown enum value %q for %q", value, "LowerCaseEnum")
+		val, err := strconv.ParseInt(string(value), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", value, "LowerCaseEnum", err)
+		}
+		*v = LowerCaseEnum(val)
+		return nil
+	}
+}
+
+func (v LowerCaseEnum) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("containing"), nil
+	case 1:
+		return []byte("lower_case"), nil
+	case 2:
+		return []byte("items"), nil
 	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
 
 func (v LowerCaseEnum) ToWire() (wire.Value, error) {
@@ -5,6 +5,6 @@ package exceptions
 
 import "go.uber.org/thriftrw/thriftreflect"
 
-var ThriftModule = &thriftreflect.ThriftModule{Name: "exceptions", Package: "go.uber.org/thriftrw/gen/testdata/exceptions", FilePath: "exceptions.thrift", SHA1: "7515e1a7bcd9ef547ad37a0eaaeaf89df21897fa", Raw: rawIDL}
+var ThriftModule = &thriftreflect.ThriftModule{Name: "exceptions", Package: "go.uber.org/thriftrw/gen/testdata/exceptions", FilePath: "exceptions.thrift", SHA1: "8cc18d47b5c4d542da9472132ec701f2c69bc26c", Raw: rawIDL}
 
-const rawIDL = "exception EmptyException {}\n\nexception DoesNotExistException {\n    1: required string key\n    2: optional string Error (go.name=\"Error2\")\n}\n"
+const rawIDL = "exception EmptyException {}\n\nexception InvalidArgumentException {\n    1: optional string message\n} (rpc.code = \"INVALID_ARGUMENT\")\n\nexception DoesNotExistException {\n    1: required string key\n    2: optional string Error (go.name=\"Error2\")\n} (http.status = \"404\", grpc.code = \"NOT_FOUND\", safety = \"safe\")\n"
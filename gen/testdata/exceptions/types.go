@@ -4,7 +4,6 @@
 package exceptions
 
 import (
-	"errors"
 	"fmt"
 	"go.uber.org/thriftrw/wire"
 	"strings"
@@ -48,6 +47,7 @@ func (v *DoesNotExistException) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.Key, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("DoesNotExistException", "Key", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				keyIsSet = true
@@ -58,13 +58,15 @@ func (v *DoesNotExistException) FromWire(w wire.Value) error {
 				x, err = field.Value.GetString(), error(nil)
 				v.Error2 = &x
 				if err != nil {
+					wire.ObserveDecodeError("DoesNotExistException", "Error2", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
 		}
 	}
 	if !keyIsSet {
-		return errors.New("field Key of DoesNotExistException is required")
+		wire.ObserveDecodeError("DoesNotExistException", "Key", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "DoesNotExistException", Field: "Key", ID: 1}
 	}
 	return nil
 }
@@ -103,10 +105,36 @@ func (v *DoesNotExistException) Equals(rhs *DoesNotExistException) bool {
 	return true
 }
 
+func (v *DoesNotExistException) GetKey() (o string) {
+	if v != nil {
+		o = v.Key
+	}
+	return
+}
+
+func (v *DoesNotExistException) GetError2() (o string) {
+	if v != nil && v.Error2 != nil {
+		return *v.Error2
+	}
+	return
+}
+
 func (v *DoesNotExistException) Error() string {
 	return v.String()
 }
 
+func (v *DoesNotExistException) HTTPStatus() int {
+	return 404
+}
+
+func (v *DoesNotExistException) GRPCCode() string {
+	return "NOT_FOUND"
+}
+
+func (v *DoesNotExistException) Safe() bool {
+	return true
+}
+
 type EmptyException struct{}
 
 func (v *EmptyException) ToWire() (wire.Value, error) {
@@ -141,3 +169,79 @@ func (v *EmptyException) Equals(rhs *EmptyException) bool {
 func (v *EmptyException) Error() string {
 	return v.String()
 }
+
+type InvalidArgumentException struct {
+	Message *string `json:"message,omitempty"`
+}
+
+func (v *InvalidArgumentException) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Message != nil {
+		w, err = wire.NewValueString(*(v.Message)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *InvalidArgumentException) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Message = &x
+				if err != nil {
+					wire.ObserveDecodeError("InvalidArgumentException", "Message", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *InvalidArgumentException) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	if v.Message != nil {
+		fields[i] = fmt.Sprintf("Message: %v", *(v.Message))
+		i++
+	}
+	return fmt.Sprintf("InvalidArgumentException{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *InvalidArgumentException) Equals(rhs *InvalidArgumentException) bool {
+	if !_String_EqualsPtr(v.Message, rhs.Message) {
+		return false
+	}
+	return true
+}
+
+func (v *InvalidArgumentException) GetMessage() (o string) {
+	if v != nil && v.Message != nil {
+		return *v.Message
+	}
+	return
+}
+
+func (v *InvalidArgumentException) Error() string {
+	return v.String()
+}
+
+func (v *InvalidArgumentException) RPCCode() string {
+	return "INVALID_ARGUMENT"
+}
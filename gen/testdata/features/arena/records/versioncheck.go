@@ -0,0 +1,10 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+package records
+
+import "go.uber.org/thriftrw/version"
+
+func init() {
+	version.CheckCompatWithGeneratedCodeAt("1.4.0", "go.uber.org/thriftrw/gen/testdata/features/arena/records")
+}
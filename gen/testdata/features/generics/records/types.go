@@ -0,0 +1,2446 @@
+//go:build go1.18
+// +build go1.18
+
+// Code generated by thriftrw v1.4.0
+// @generated
+
+package records
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go.uber.org/thriftrw/ptr"
+	"go.uber.org/thriftrw/thriftlist"
+	"go.uber.org/thriftrw/thriftmap"
+	"go.uber.org/thriftrw/thriftset"
+	"go.uber.org/thriftrw/wire"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type Color int32
+
+const (
+	ColorRed   Color = 0
+	ColorGreen Color = 1
+	ColorBlue  Color = 2
+)
+
+func Color_Values() []Color {
+	return []Color{ColorRed, ColorGreen, ColorBlue}
+}
+
+func (v *Color) UnmarshalText(value []byte) error {
+	switch string(value) {
+	case "RED":
+		*v = ColorRed
+		return nil
+	case "GREEN":
+		*v = ColorGreen
+		return nil
+	case "BLUE":
+		*v = ColorBlue
+		return nil
+	default:
+		val, err := strconv.ParseInt(string(value), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", value, "Color", err)
+		}
+		*v = Color(val)
+		return nil
+	}
+}
+
+func (v Color) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("RED"), nil
+	case 1:
+		return []byte("GREEN"), nil
+	case 2:
+		return []byte("BLUE"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+func (v Color) ToWire() (wire.Value, error) {
+	return wire.NewValueI32(int32(v)), nil
+}
+
+func (v *Color) FromWire(w wire.Value) error {
+	*v = (Color)(w.GetI32())
+	return nil
+}
+
+func (v Color) String() string {
+	w := int32(v)
+	switch w {
+	case 0:
+		return "RED"
+	case 1:
+		return "GREEN"
+	case 2:
+		return "BLUE"
+	}
+	return fmt.Sprintf("Color(%d)", w)
+}
+
+func (v Color) Equals(rhs Color) bool {
+	return v == rhs
+}
+
+func (v Color) MarshalJSON() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return ([]byte)("\"RED\""), nil
+	case 1:
+		return ([]byte)("\"GREEN\""), nil
+	case 2:
+		return ([]byte)("\"BLUE\""), nil
+	}
+	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+}
+
+func (v *Color) UnmarshalJSON(text []byte) error {
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+	switch w := t.(type) {
+	case json.Number:
+		x, err := w.Int64()
+		if err != nil {
+			return err
+		}
+		if x > math.MaxInt32 {
+			return fmt.Errorf("enum overflow from JSON %q for %q", text, "Color")
+		}
+		if x < math.MinInt32 {
+			return fmt.Errorf("enum underflow from JSON %q for %q", text, "Color")
+		}
+		*v = (Color)(x)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(w))
+	default:
+		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "Color")
+	}
+}
+
+func _Map_String_I32_MapItemList(m map[string]int32) wire.MapItemList {
+	return thriftmap.Encode(m, wire.TBinary, wire.TI32, func(k string) (wire.Value, error) {
+		return wire.NewValueString(k), error(nil)
+	}, func(v int32) (wire.Value, error) {
+		return wire.NewValueI32(v), error(nil)
+	})
+}
+
+func _Map_String_I32_Read(m wire.MapItemList) (map[string]int32, error) {
+	return thriftmap.Decode(m, wire.TBinary, wire.TI32, func(x wire.Value) (string, error) {
+		return x.GetString(), error(nil)
+	}, func(x wire.Value) (int32, error) {
+		return x.GetI32(), error(nil)
+	})
+}
+
+func _Map_String_I32_Equals(lhs, rhs map[string]int32) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+type Counts map[string]int32
+
+func (v Counts) ToWire() (wire.Value, error) {
+	x := (map[string]int32)(v)
+	return wire.NewValueMap(_Map_String_I32_MapItemList(x)), error(nil)
+}
+
+func (v Counts) String() string {
+	x := (map[string]int32)(v)
+	return fmt.Sprint(x)
+}
+
+func (v *Counts) FromWire(w wire.Value) error {
+	x, err := _Map_String_I32_Read(w.GetMap())
+	*v = (Counts)(x)
+	return err
+}
+
+func (lhs Counts) Equals(rhs Counts) bool {
+	return _Map_String_I32_Equals(lhs, rhs)
+}
+
+type Empty struct{}
+
+func (v *Empty) ToWire() (wire.Value, error) {
+	var (
+		fields [0]wire.Field
+		i      int = 0
+	)
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *Empty) FromWire(w wire.Value) error {
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		}
+	}
+	return nil
+}
+
+func (v *Empty) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [0]string
+	i := 0
+	return fmt.Sprintf("Empty{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *Empty) Equals(rhs *Empty) bool {
+	return true
+}
+
+func _Map_Point_String_MapItemList(m []struct {
+	Key   *Point
+	Value string
+}) wire.MapItemList {
+	return thriftmap.EncodeSlice(m, wire.TStruct, wire.TBinary, func(k *Point) (wire.Value, error) {
+		if k == nil {
+			return wire.Value{}, thriftmap.ErrNil
+		}
+		return k.ToWire()
+	}, func(v string) (wire.Value, error) {
+		return wire.NewValueString(v), error(nil)
+	})
+}
+
+func _Point_Read(w wire.Value) (*Point, error) {
+	var v Point
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _Map_Point_String_Read(m wire.MapItemList) ([]struct {
+	Key   *Point
+	Value string
+}, error) {
+	return thriftmap.DecodeSlice(m, wire.TStruct, wire.TBinary, func(x wire.Value) (*Point, error) {
+		return _Point_Read(x)
+	}, func(x wire.Value) (string, error) {
+		return x.GetString(), error(nil)
+	})
+}
+
+func _Map_Point_String_Equals(lhs, rhs []struct {
+	Key   *Point
+	Value string
+}) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for _, i := range lhs {
+		lk := i.Key
+		lv := i.Value
+		ok := false
+		for _, j := range rhs {
+			rk := j.Key
+			rv := j.Value
+			if !lk.Equals(rk) {
+				continue
+			}
+			if !(lv == rv) {
+				return false
+			}
+			ok = true
+			break
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+type Labels []struct {
+	Key   *Point
+	Value string
+}
+
+func (v Labels) ToWire() (wire.Value, error) {
+	x := ([]struct {
+		Key   *Point
+		Value string
+	})(v)
+	return wire.NewValueMap(_Map_Point_String_MapItemList(x)), error(nil)
+}
+
+func (v Labels) String() string {
+	x := ([]struct {
+		Key   *Point
+		Value string
+	})(v)
+	return fmt.Sprint(x)
+}
+
+func (v *Labels) FromWire(w wire.Value) error {
+	x, err := _Map_Point_String_Read(w.GetMap())
+	*v = (Labels)(x)
+	return err
+}
+
+func (lhs Labels) Equals(rhs Labels) bool {
+	return _Map_Point_String_Equals(lhs, rhs)
+}
+
+func _List_String_ValueList(v []string) wire.ValueList {
+	return thriftlist.Encode(v, wire.TBinary, func(x string) (wire.Value, error) {
+		return wire.NewValueString(x), error(nil)
+	})
+}
+
+func _List_String_Read(l wire.ValueList) ([]string, error) {
+	return thriftlist.Decode(l, wire.TBinary, func(x wire.Value) (string, error) {
+		return x.GetString(), error(nil)
+	})
+}
+
+func _List_String_Equals(lhs, rhs []string) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+type Names []string
+
+func (v Names) ToWire() (wire.Value, error) {
+	x := ([]string)(v)
+	return wire.NewValueList(_List_String_ValueList(x)), error(nil)
+}
+
+func (v Names) String() string {
+	x := ([]string)(v)
+	return fmt.Sprint(x)
+}
+
+func (v *Names) FromWire(w wire.Value) error {
+	x, err := _List_String_Read(w.GetList())
+	*v = (Names)(x)
+	return err
+}
+
+func (lhs Names) Equals(rhs Names) bool {
+	return _List_String_Equals(lhs, rhs)
+}
+
+type NotFound struct {
+	Key string `json:"key"`
+}
+
+func (v *NotFound) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueString(v.Key), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *NotFound) FromWire(w wire.Value) error {
+	var err error
+	keyIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.Key, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("NotFound", "Key", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				keyIsSet = true
+			}
+		}
+	}
+	if !keyIsSet {
+		wire.ObserveDecodeError("NotFound", "Key", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "NotFound", Field: "Key", ID: 1}
+	}
+	return nil
+}
+
+func (v *NotFound) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	fields[i] = fmt.Sprintf("Key: %v", v.Key)
+	i++
+	return fmt.Sprintf("NotFound{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *NotFound) Equals(rhs *NotFound) bool {
+	if !(v.Key == rhs.Key) {
+		return false
+	}
+	return true
+}
+
+func (v *NotFound) GetKey() (o string) {
+	if v != nil {
+		o = v.Key
+	}
+	return
+}
+
+func (v *NotFound) Error() string {
+	return v.String()
+}
+
+func _Points_Read(w wire.Value) (Points, error) {
+	var x Points
+	err := x.FromWire(w)
+	return x, err
+}
+
+type Outline Points
+
+func (v Outline) ToWire() (wire.Value, error) {
+	x := (Points)(v)
+	return x.ToWire()
+}
+
+func (v Outline) String() string {
+	x := (Points)(v)
+	return fmt.Sprint(x)
+}
+
+func (v *Outline) FromWire(w wire.Value) error {
+	x, err := _Points_Read(w)
+	*v = (Outline)(x)
+	return err
+}
+
+func (lhs Outline) Equals(rhs Outline) bool {
+	return lhs.Equals(rhs)
+}
+
+type Point struct {
+	X int32 `json:"x"`
+	Y int32 `json:"y"`
+}
+
+func (v *Point) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueI32(v.X), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	w, err = wire.NewValueI32(v.Y), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 2, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *Point) FromWire(w wire.Value) error {
+	var err error
+	xIsSet := false
+	yIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TI32 {
+				v.X, err = field.Value.GetI32(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("Point", "X", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				xIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TI32 {
+				v.Y, err = field.Value.GetI32(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("Point", "Y", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				yIsSet = true
+			}
+		}
+	}
+	if !xIsSet {
+		wire.ObserveDecodeError("Point", "X", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Point", Field: "X", ID: 1}
+	}
+	if !yIsSet {
+		wire.ObserveDecodeError("Point", "Y", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Point", Field: "Y", ID: 2}
+	}
+	return nil
+}
+
+func (v *Point) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	fields[i] = fmt.Sprintf("X: %v", v.X)
+	i++
+	fields[i] = fmt.Sprintf("Y: %v", v.Y)
+	i++
+	return fmt.Sprintf("Point{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *Point) Equals(rhs *Point) bool {
+	if !(v.X == rhs.X) {
+		return false
+	}
+	if !(v.Y == rhs.Y) {
+		return false
+	}
+	return true
+}
+
+func (v *Point) GetX() (o int32) {
+	if v != nil {
+		o = v.X
+	}
+	return
+}
+
+func (v *Point) GetY() (o int32) {
+	if v != nil {
+		o = v.Y
+	}
+	return
+}
+
+func _Set_Point_ValueList(v []*Point) wire.ValueList {
+	return thriftset.EncodeSlice(v, wire.TStruct, func(x *Point) (wire.Value, error) {
+		if x == nil {
+			return wire.Value{}, thriftset.ErrNil
+		}
+		return x.ToWire()
+	})
+}
+
+func _Set_Point_Read(s wire.ValueList) ([]*Point, error) {
+	return thriftset.DecodeSlice(s, wire.TStruct, func(x wire.Value) (*Point, error) {
+		return _Point_Read(x)
+	})
+}
+
+func _Set_Point_Equals(lhs, rhs []*Point) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for _, x := range lhs {
+		ok := false
+		for _, y := range rhs {
+			if x.Equals(y) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+type Points []*Point
+
+func (v Points) ToWire() (wire.Value, error) {
+	x := ([]*Point)(v)
+	return wire.NewValueSet(_Set_Point_ValueList(x)), error(nil)
+}
+
+func (v Points) String() string {
+	x := ([]*Point)(v)
+	return fmt.Sprint(x)
+}
+
+func (v *Points) FromWire(w wire.Value) error {
+	x, err := _Set_Point_Read(w.GetSet())
+	*v = (Points)(x)
+	return err
+}
+
+func (lhs Points) Equals(rhs Points) bool {
+	return _Set_Point_Equals(lhs, rhs)
+}
+
+// Profile has a field named after the presence method of another field.
+type Profile struct {
+	Email    *string `json:"email,omitempty"`
+	HasEmail *bool   `json:"hasEmail,omitempty"`
+}
+
+func (v *Profile) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Email != nil {
+		w, err = wire.NewValueString(*(v.Email)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.HasEmail != nil {
+		w, err = wire.NewValueBool(*(v.HasEmail)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *Profile) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Email = &x
+				if err != nil {
+					wire.ObserveDecodeError("Profile", "Email", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 2:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.HasEmail = &x
+				if err != nil {
+					wire.ObserveDecodeError("Profile", "HasEmail", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *Profile) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	if v.Email != nil {
+		fields[i] = fmt.Sprintf("Email: %v", *(v.Email))
+		i++
+	}
+	if v.HasEmail != nil {
+		fields[i] = fmt.Sprintf("HasEmail: %v", *(v.HasEmail))
+		i++
+	}
+	return fmt.Sprintf("Profile{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _String_EqualsPtr(lhs, rhs *string) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _Bool_EqualsPtr(lhs, rhs *bool) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func (v *Profile) Equals(rhs *Profile) bool {
+	if !_String_EqualsPtr(v.Email, rhs.Email) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.HasEmail, rhs.HasEmail) {
+		return false
+	}
+	return true
+}
+
+func (v *Profile) GetEmail() (o string) {
+	if v != nil && v.Email != nil {
+		return *v.Email
+	}
+	return
+}
+
+func (v *Profile) GetHasEmail() (o bool) {
+	if v != nil && v.HasEmail != nil {
+		return *v.HasEmail
+	}
+	return
+}
+
+type Session struct {
+	ID       string `json:"id"`
+	LastSeen *int64 `json:"lastSeen,omitempty"`
+}
+
+func (v *Session) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueString(v.ID), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.LastSeen != nil {
+		w, err = wire.NewValueI64(*(v.LastSeen)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *Session) FromWire(w wire.Value) error {
+	var err error
+	idIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.ID, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("Session", "ID", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				idIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.LastSeen = &x
+				if err != nil {
+					wire.ObserveDecodeError("Session", "LastSeen", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	if !idIsSet {
+		wire.ObserveDecodeError("Session", "ID", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Session", Field: "ID", ID: 1}
+	}
+	return nil
+}
+
+func (v *Session) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	fields[i] = fmt.Sprintf("ID: %v", v.ID)
+	i++
+	if v.LastSeen != nil {
+		fields[i] = fmt.Sprintf("LastSeen: %v", *(v.LastSeen))
+		i++
+	}
+	return fmt.Sprintf("Session{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _I64_EqualsPtr(lhs, rhs *int64) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func (v *Session) Equals(rhs *Session) bool {
+	if !(v.ID == rhs.ID) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.LastSeen, rhs.LastSeen) {
+		return false
+	}
+	return true
+}
+
+func (v *Session) GetID() (o string) {
+	if v != nil {
+		o = v.ID
+	}
+	return
+}
+
+func (v *Session) GetLastSeen() (o int64) {
+	if v != nil && v.LastSeen != nil {
+		return *v.LastSeen
+	}
+	return
+}
+
+type Shape struct {
+	Point   *Point   `json:"point,omitempty"`
+	Polygon []*Point `json:"polygon"`
+}
+
+func _List_Point_ValueList(v []*Point) wire.ValueList {
+	return thriftlist.Encode(v, wire.TStruct, func(x *Point) (wire.Value, error) {
+		if x == nil {
+			return wire.Value{}, thriftlist.ErrNil
+		}
+		return x.ToWire()
+	})
+}
+
+func (v *Shape) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Point != nil {
+		w, err = v.Point.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.Polygon != nil {
+		w, err = wire.NewValueList(_List_Point_ValueList(v.Polygon)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("Shape should have exactly one field: got %v fields", i)
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _List_Point_Read(l wire.ValueList) ([]*Point, error) {
+	return thriftlist.Decode(l, wire.TStruct, func(x wire.Value) (*Point, error) {
+		return _Point_Read(x)
+	})
+}
+
+func (v *Shape) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.Point, err = _Point_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("Shape", "Point", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 2:
+			if field.Value.Type() == wire.TList {
+				v.Polygon, err = _List_Point_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("Shape", "Polygon", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	count := 0
+	if v.Point != nil {
+		count++
+	}
+	if v.Polygon != nil {
+		count++
+	}
+	if count != 1 {
+		wire.ObserveDecodeError("Shape", "", wire.DecodeErrorInvalidUnion)
+		return fmt.Errorf("Shape should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *Shape) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	if v.Point != nil {
+		fields[i] = fmt.Sprintf("Point: %v", v.Point)
+		i++
+	}
+	if v.Polygon != nil {
+		fields[i] = fmt.Sprintf("Polygon: %v", v.Polygon)
+		i++
+	}
+	return fmt.Sprintf("Shape{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_Point_Equals(lhs, rhs []*Point) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *Shape) Equals(rhs *Shape) bool {
+	if !((v.Point == nil && rhs.Point == nil) || (v.Point != nil && rhs.Point != nil && v.Point.Equals(rhs.Point))) {
+		return false
+	}
+	if !((v.Polygon == nil && rhs.Polygon == nil) || (v.Polygon != nil && rhs.Polygon != nil && _List_Point_Equals(v.Polygon, rhs.Polygon))) {
+		return false
+	}
+	return true
+}
+
+func (v *Shape) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.Point != nil {
+		count++
+	}
+	if v.Polygon != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("Shape should have exactly one field: got %v fields", count)
+	}
+	type plain Shape
+	return json.Marshal((*plain)(v))
+}
+
+func (v *Shape) UnmarshalJSON(text []byte) error {
+	type plain Shape
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.Point != nil {
+		count++
+	}
+	if v.Polygon != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("Shape should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *Shape) GetPoint() (o *Point) {
+	if v != nil && v.Point != nil {
+		return v.Point
+	}
+	return
+}
+
+func (v *Shape) GetPolygon() (o []*Point) {
+	if v != nil && v.Polygon != nil {
+		return v.Polygon
+	}
+	return
+}
+
+type Shapes struct {
+	Points []*Point            `json:"points"`
+	Names  map[string]struct{} `json:"names"`
+	Blobs  [][]byte            `json:"blobs"`
+	ByName map[string]*Point   `json:"byName"`
+	Counts []struct {
+		Key   *Point
+		Value int32
+	} `json:"counts"`
+	Grid [][]int32 `json:"grid"`
+}
+
+func _Set_String_ValueList(v map[string]struct{}) wire.ValueList {
+	return thriftset.Encode(v, wire.TBinary, func(x string) (wire.Value, error) {
+		return wire.NewValueString(x), error(nil)
+	})
+}
+
+func _Set_Binary_ValueList(v [][]byte) wire.ValueList {
+	return thriftset.EncodeSlice(v, wire.TBinary, func(x []byte) (wire.Value, error) {
+		if x == nil {
+			return wire.Value{}, thriftset.ErrNil
+		}
+		return wire.NewValueBinary(x), error(nil)
+	})
+}
+
+func _Map_String_Point_MapItemList(m map[string]*Point) wire.MapItemList {
+	return thriftmap.Encode(m, wire.TBinary, wire.TStruct, func(k string) (wire.Value, error) {
+		return wire.NewValueString(k), error(nil)
+	}, func(v *Point) (wire.Value, error) {
+		if v == nil {
+			return wire.Value{}, thriftmap.ErrNil
+		}
+		return v.ToWire()
+	})
+}
+
+func _Map_Point_I32_MapItemList(m []struct {
+	Key   *Point
+	Value int32
+}) wire.MapItemList {
+	return thriftmap.EncodeSlice(m, wire.TStruct, wire.TI32, func(k *Point) (wire.Value, error) {
+		if k == nil {
+			return wire.Value{}, thriftmap.ErrNil
+		}
+		return k.ToWire()
+	}, func(v int32) (wire.Value, error) {
+		return wire.NewValueI32(v), error(nil)
+	})
+}
+
+func _List_I32_ValueList(v []int32) wire.ValueList {
+	return thriftlist.Encode(v, wire.TI32, func(x int32) (wire.Value, error) {
+		return wire.NewValueI32(x), error(nil)
+	})
+}
+
+func _List_List_I32_ValueList(v [][]int32) wire.ValueList {
+	return thriftlist.Encode(v, wire.TList, func(x []int32) (wire.Value, error) {
+		if x == nil {
+			return wire.Value{}, thriftlist.ErrNil
+		}
+		return wire.NewValueList(_List_I32_ValueList(x)), error(nil)
+	})
+}
+
+func (v *Shapes) ToWire() (wire.Value, error) {
+	var (
+		fields [6]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Points != nil {
+		w, err = wire.NewValueList(_List_Point_ValueList(v.Points)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.Names != nil {
+		w, err = wire.NewValueSet(_Set_String_ValueList(v.Names)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.Blobs != nil {
+		w, err = wire.NewValueSet(_Set_Binary_ValueList(v.Blobs)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.ByName != nil {
+		w, err = wire.NewValueMap(_Map_String_Point_MapItemList(v.ByName)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.Counts != nil {
+		w, err = wire.NewValueMap(_Map_Point_I32_MapItemList(v.Counts)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+	if v.Grid != nil {
+		w, err = wire.NewValueList(_List_List_I32_ValueList(v.Grid)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 6, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Set_String_Read(s wire.ValueList) (map[string]struct{}, error) {
+	return thriftset.Decode(s, wire.TBinary, func(x wire.Value) (string, error) {
+		return x.GetString(), error(nil)
+	})
+}
+
+func _Set_Binary_Read(s wire.ValueList) ([][]byte, error) {
+	return thriftset.DecodeSlice(s, wire.TBinary, func(x wire.Value) ([]byte, error) {
+		return x.GetBinary(), error(nil)
+	})
+}
+
+func _Map_String_Point_Read(m wire.MapItemList) (map[string]*Point, error) {
+	return thriftmap.Decode(m, wire.TBinary, wire.TStruct, func(x wire.Value) (string, error) {
+		return x.GetString(), error(nil)
+	}, func(x wire.Value) (*Point, error) {
+		return _Point_Read(x)
+	})
+}
+
+func _Map_Point_I32_Read(m wire.MapItemList) ([]struct {
+	Key   *Point
+	Value int32
+}, error) {
+	return thriftmap.DecodeSlice(m, wire.TStruct, wire.TI32, func(x wire.Value) (*Point, error) {
+		return _Point_Read(x)
+	}, func(x wire.Value) (int32, error) {
+		return x.GetI32(), error(nil)
+	})
+}
+
+func _List_I32_Read(l wire.ValueList) ([]int32, error) {
+	return thriftlist.Decode(l, wire.TI32, func(x wire.Value) (int32, error) {
+		return x.GetI32(), error(nil)
+	})
+}
+
+func _List_List_I32_Read(l wire.ValueList) ([][]int32, error) {
+	return thriftlist.Decode(l, wire.TList, func(x wire.Value) ([]int32, error) {
+		return _List_I32_Read(x.GetList())
+	})
+}
+
+func (v *Shapes) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TList {
+				v.Points, err = _List_Point_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("Shapes", "Points", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 2:
+			if field.Value.Type() == wire.TSet {
+				v.Names, err = _Set_String_Read(field.Value.GetSet())
+				if err != nil {
+					wire.ObserveDecodeError("Shapes", "Names", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 3:
+			if field.Value.Type() == wire.TSet {
+				v.Blobs, err = _Set_Binary_Read(field.Value.GetSet())
+				if err != nil {
+					wire.ObserveDecodeError("Shapes", "Blobs", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 4:
+			if field.Value.Type() == wire.TMap {
+				v.ByName, err = _Map_String_Point_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("Shapes", "ByName", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 5:
+			if field.Value.Type() == wire.TMap {
+				v.Counts, err = _Map_Point_I32_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("Shapes", "Counts", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 6:
+			if field.Value.Type() == wire.TList {
+				v.Grid, err = _List_List_I32_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("Shapes", "Grid", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *Shapes) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [6]string
+	i := 0
+	if v.Points != nil {
+		fields[i] = fmt.Sprintf("Points: %v", v.Points)
+		i++
+	}
+	if v.Names != nil {
+		fields[i] = fmt.Sprintf("Names: %v", v.Names)
+		i++
+	}
+	if v.Blobs != nil {
+		fields[i] = fmt.Sprintf("Blobs: %v", v.Blobs)
+		i++
+	}
+	if v.ByName != nil {
+		fields[i] = fmt.Sprintf("ByName: %v", v.ByName)
+		i++
+	}
+	if v.Counts != nil {
+		fields[i] = fmt.Sprintf("Counts: %v", v.Counts)
+		i++
+	}
+	if v.Grid != nil {
+		fields[i] = fmt.Sprintf("Grid: %v", v.Grid)
+		i++
+	}
+	return fmt.Sprintf("Shapes{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Set_String_Equals(lhs, rhs map[string]struct{}) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for x := range rhs {
+		if _, ok := lhs[x]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func _Set_Binary_Equals(lhs, rhs [][]byte) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for _, x := range lhs {
+		ok := false
+		for _, y := range rhs {
+			if bytes.Equal(x, y) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func _Map_String_Point_Equals(lhs, rhs map[string]*Point) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func _Map_Point_I32_Equals(lhs, rhs []struct {
+	Key   *Point
+	Value int32
+}) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for _, i := range lhs {
+		lk := i.Key
+		lv := i.Value
+		ok := false
+		for _, j := range rhs {
+			rk := j.Key
+			rv := j.Value
+			if !lk.Equals(rk) {
+				continue
+			}
+			if !(lv == rv) {
+				return false
+			}
+			ok = true
+			break
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func _List_I32_Equals(lhs, rhs []int32) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func _List_List_I32_Equals(lhs, rhs [][]int32) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !_List_I32_Equals(lv, rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *Shapes) Equals(rhs *Shapes) bool {
+	if !((v.Points == nil && rhs.Points == nil) || (v.Points != nil && rhs.Points != nil && _List_Point_Equals(v.Points, rhs.Points))) {
+		return false
+	}
+	if !((v.Names == nil && rhs.Names == nil) || (v.Names != nil && rhs.Names != nil && _Set_String_Equals(v.Names, rhs.Names))) {
+		return false
+	}
+	if !((v.Blobs == nil && rhs.Blobs == nil) || (v.Blobs != nil && rhs.Blobs != nil && _Set_Binary_Equals(v.Blobs, rhs.Blobs))) {
+		return false
+	}
+	if !((v.ByName == nil && rhs.ByName == nil) || (v.ByName != nil && rhs.ByName != nil && _Map_String_Point_Equals(v.ByName, rhs.ByName))) {
+		return false
+	}
+	if !((v.Counts == nil && rhs.Counts == nil) || (v.Counts != nil && rhs.Counts != nil && _Map_Point_I32_Equals(v.Counts, rhs.Counts))) {
+		return false
+	}
+	if !((v.Grid == nil && rhs.Grid == nil) || (v.Grid != nil && rhs.Grid != nil && _List_List_I32_Equals(v.Grid, rhs.Grid))) {
+		return false
+	}
+	return true
+}
+
+func (v *Shapes) GetPoints() (o []*Point) {
+	if v != nil && v.Points != nil {
+		return v.Points
+	}
+	return
+}
+
+func (v *Shapes) GetNames() (o map[string]struct{}) {
+	if v != nil && v.Names != nil {
+		return v.Names
+	}
+	return
+}
+
+func (v *Shapes) GetBlobs() (o [][]byte) {
+	if v != nil && v.Blobs != nil {
+		return v.Blobs
+	}
+	return
+}
+
+func (v *Shapes) GetByName() (o map[string]*Point) {
+	if v != nil && v.ByName != nil {
+		return v.ByName
+	}
+	return
+}
+
+func (v *Shapes) GetCounts() (o []struct {
+	Key   *Point
+	Value int32
+}) {
+	if v != nil && v.Counts != nil {
+		return v.Counts
+	}
+	return
+}
+
+func (v *Shapes) GetGrid() (o [][]int32) {
+	if v != nil && v.Grid != nil {
+		return v.Grid
+	}
+	return
+}
+
+type Tags map[string]struct{}
+
+func (v Tags) ToWire() (wire.Value, error) {
+	x := (map[string]struct{})(v)
+	return wire.NewValueSet(_Set_String_ValueList(x)), error(nil)
+}
+
+func (v Tags) String() string {
+	x := (map[string]struct{})(v)
+	return fmt.Sprint(x)
+}
+
+func (v *Tags) FromWire(w wire.Value) error {
+	x, err := _Set_String_Read(w.GetSet())
+	*v = (Tags)(x)
+	return err
+}
+
+func (lhs Tags) Equals(rhs Tags) bool {
+	return _Set_String_Equals(lhs, rhs)
+}
+
+type User struct {
+	Name   string             `json:"name"`
+	Age    *int32             `json:"age,omitempty"`
+	Avatar []byte             `json:"avatar"`
+	Color  *Color             `json:"color,omitempty"`
+	Tags   []string           `json:"tags"`
+	Places map[string]*Point  `json:"places"`
+	Ids    map[int64]struct{} `json:"ids"`
+	Home   *Point             `json:"home,omitempty"`
+	Active *bool              `json:"active,omitempty"`
+	Score  *float64           `json:"score,omitempty"`
+}
+
+func _Set_I64_ValueList(v map[int64]struct{}) wire.ValueList {
+	return thriftset.Encode(v, wire.TI64, func(x int64) (wire.Value, error) {
+		return wire.NewValueI64(x), error(nil)
+	})
+}
+
+func (v *User) ToWire() (wire.Value, error) {
+	var (
+		fields [10]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueString(v.Name), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.Age != nil {
+		w, err = wire.NewValueI32(*(v.Age)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.Avatar != nil {
+		w, err = wire.NewValueBinary(v.Avatar), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.Color != nil {
+		w, err = v.Color.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.Tags != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.Tags)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+	if v.Places != nil {
+		w, err = wire.NewValueMap(_Map_String_Point_MapItemList(v.Places)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 6, Value: w}
+		i++
+	}
+	if v.Ids != nil {
+		w, err = wire.NewValueSet(_Set_I64_ValueList(v.Ids)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 7, Value: w}
+		i++
+	}
+	if v.Home != nil {
+		w, err = v.Home.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 8, Value: w}
+		i++
+	}
+	if v.Active == nil {
+		v.Active = ptr.Bool(true)
+	}
+	{
+		w, err = wire.NewValueBool(*(v.Active)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 9, Value: w}
+		i++
+	}
+	if v.Score != nil {
+		w, err = wire.NewValueDouble(*(v.Score)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Color_Read(w wire.Value) (Color, error) {
+	var v Color
+	err := v.FromWire(w)
+	return v, err
+}
+
+func _Set_I64_Read(s wire.ValueList) (map[int64]struct{}, error) {
+	return thriftset.Decode(s, wire.TI64, func(x wire.Value) (int64, error) {
+		return x.GetI64(), error(nil)
+	})
+}
+
+func (v *User) FromWire(w wire.Value) error {
+	var err error
+	nameIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.Name, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("User", "Name", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				nameIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Age = &x
+				if err != nil {
+					wire.ObserveDecodeError("User", "Age", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 3:
+			if field.Value.Type() == wire.TBinary {
+				v.Avatar, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("User", "Avatar", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 4:
+			if field.Value.Type() == wire.TI32 {
+				var x Color
+				x, err = _Color_Read(field.Value)
+				v.Color = &x
+				if err != nil {
+					wire.ObserveDecodeError("User", "Color", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 5:
+			if field.Value.Type() == wire.TList {
+				v.Tags, err = _List_String_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("User", "Tags", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 6:
+			if field.Value.Type() == wire.TMap {
+				v.Places, err = _Map_String_Point_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("User", "Places", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 7:
+			if field.Value.Type() == wire.TSet {
+				v.Ids, err = _Set_I64_Read(field.Value.GetSet())
+				if err != nil {
+					wire.ObserveDecodeError("User", "Ids", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 8:
+			if field.Value.Type() == wire.TStruct {
+				v.Home, err = _Point_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("User", "Home", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 9:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Active = &x
+				if err != nil {
+					wire.ObserveDecodeError("User", "Active", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 10:
+			if field.Value.Type() == wire.TDouble {
+				var x float64
+				x, err = field.Value.GetDouble(), error(nil)
+				v.Score = &x
+				if err != nil {
+					wire.ObserveDecodeError("User", "Score", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	if !nameIsSet {
+		wire.ObserveDecodeError("User", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "User", Field: "Name", ID: 1}
+	}
+	if v.Active == nil {
+		v.Active = ptr.Bool(true)
+	}
+	return nil
+}
+
+func (v *User) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [10]string
+	i := 0
+	fields[i] = fmt.Sprintf("Name: %v", v.Name)
+	i++
+	if v.Age != nil {
+		fields[i] = fmt.Sprintf("Age: %v", *(v.Age))
+		i++
+	}
+	if v.Avatar != nil {
+		fields[i] = fmt.Sprintf("Avatar: %v", v.Avatar)
+		i++
+	}
+	if v.Color != nil {
+		fields[i] = fmt.Sprintf("Color: %v", *(v.Color))
+		i++
+	}
+	if v.Tags != nil {
+		fields[i] = fmt.Sprintf("Tags: %v", v.Tags)
+		i++
+	}
+	if v.Places != nil {
+		fields[i] = fmt.Sprintf("Places: %v", v.Places)
+		i++
+	}
+	if v.Ids != nil {
+		fields[i] = fmt.Sprintf("Ids: %v", v.Ids)
+		i++
+	}
+	if v.Home != nil {
+		fields[i] = fmt.Sprintf("Home: %v", v.Home)
+		i++
+	}
+	if v.Active != nil {
+		fields[i] = fmt.Sprintf("Active: %v", *(v.Active))
+		i++
+	}
+	if v.Score != nil {
+		fields[i] = fmt.Sprintf("Score: %v", *(v.Score))
+		i++
+	}
+	return fmt.Sprintf("User{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _I32_EqualsPtr(lhs, rhs *int32) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _Color_EqualsPtr(lhs, rhs *Color) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _Set_I64_Equals(lhs, rhs map[int64]struct{}) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for x := range rhs {
+		if _, ok := lhs[x]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func _Double_EqualsPtr(lhs, rhs *float64) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func (v *User) Equals(rhs *User) bool {
+	if !(v.Name == rhs.Name) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.Age, rhs.Age) {
+		return false
+	}
+	if !((v.Avatar == nil && rhs.Avatar == nil) || (v.Avatar != nil && rhs.Avatar != nil && bytes.Equal(v.Avatar, rhs.Avatar))) {
+		return false
+	}
+	if !_Color_EqualsPtr(v.Color, rhs.Color) {
+		return false
+	}
+	if !((v.Tags == nil && rhs.Tags == nil) || (v.Tags != nil && rhs.Tags != nil && _List_String_Equals(v.Tags, rhs.Tags))) {
+		return false
+	}
+	if !((v.Places == nil && rhs.Places == nil) || (v.Places != nil && rhs.Places != nil && _Map_String_Point_Equals(v.Places, rhs.Places))) {
+		return false
+	}
+	if !((v.Ids == nil && rhs.Ids == nil) || (v.Ids != nil && rhs.Ids != nil && _Set_I64_Equals(v.Ids, rhs.Ids))) {
+		return false
+	}
+	if !((v.Home == nil && rhs.Home == nil) || (v.Home != nil && rhs.Home != nil && v.Home.Equals(rhs.Home))) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.Active, rhs.Active) {
+		return false
+	}
+	if !_Double_EqualsPtr(v.Score, rhs.Score) {
+		return false
+	}
+	return true
+}
+
+func (v *User) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
+}
+
+func (v *User) GetAge() (o int32) {
+	if v != nil && v.Age != nil {
+		return *v.Age
+	}
+	return
+}
+
+func (v *User) GetAvatar() (o []byte) {
+	if v != nil && v.Avatar != nil {
+		return v.Avatar
+	}
+	return
+}
+
+func (v *User) GetColor() (o Color) {
+	if v != nil && v.Color != nil {
+		return *v.Color
+	}
+	return
+}
+
+func (v *User) GetTags() (o []string) {
+	if v != nil && v.Tags != nil {
+		return v.Tags
+	}
+	return
+}
+
+func (v *User) GetPlaces() (o map[string]*Point) {
+	if v != nil && v.Places != nil {
+		return v.Places
+	}
+	return
+}
+
+func (v *User) GetIds() (o map[int64]struct{}) {
+	if v != nil && v.Ids != nil {
+		return v.Ids
+	}
+	return
+}
+
+func (v *User) GetHome() (o *Point) {
+	if v != nil && v.Home != nil {
+		return v.Home
+	}
+	return
+}
+
+func (v *User) GetActive() (o bool) {
+	if v != nil && v.Active != nil {
+		return *v.Active
+	}
+	o = true
+	return
+}
+
+func (v *User) GetScore() (o float64) {
+	if v != nil && v.Score != nil {
+		return *v.Score
+	}
+	return
+}
+
+// UserV2 is a newer version of User with more fields. Values encoded from it
+// have fields that User does not know about.
+type UserV2 struct {
+	Name     string              `json:"name"`
+	Age      *int32              `json:"age,omitempty"`
+	Avatar   []byte              `json:"avatar"`
+	Color    *Color              `json:"color,omitempty"`
+	Tags     []string            `json:"tags"`
+	Places   map[string]*Point   `json:"places"`
+	Ids      map[int64]struct{}  `json:"ids"`
+	Home     *Point              `json:"home,omitempty"`
+	Active   *bool               `json:"active,omitempty"`
+	Score    *float64            `json:"score,omitempty"`
+	History  []*Point            `json:"history"`
+	Scores   map[string][]int32  `json:"scores"`
+	Nickname *string             `json:"nickname,omitempty"`
+	Aliases  map[string]struct{} `json:"aliases"`
+	Referrer *UserV2             `json:"referrer,omitempty"`
+}
+
+func _Map_String_List_I32_MapItemList(m map[string][]int32) wire.MapItemList {
+	return thriftmap.Encode(m, wire.TBinary, wire.TList, func(k string) (wire.Value, error) {
+		return wire.NewValueString(k), error(nil)
+	}, func(v []int32) (wire.Value, error) {
+		if v == nil {
+			return wire.Value{}, thriftmap.ErrNil
+		}
+		return wire.NewValueList(_List_I32_ValueList(v)), error(nil)
+	})
+}
+
+func (v *UserV2) ToWire() (wire.Value, error) {
+	var (
+		fields [15]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueString(v.Name), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.Age != nil {
+		w, err = wire.NewValueI32(*(v.Age)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.Avatar != nil {
+		w, err = wire.NewValueBinary(v.Avatar), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.Color != nil {
+		w, err = v.Color.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.Tags != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.Tags)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+	if v.Places != nil {
+		w, err = wire.NewValueMap(_Map_String_Point_MapItemList(v.Places)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 6, Value: w}
+		i++
+	}
+	if v.Ids != nil {
+		w, err = wire.NewValueSet(_Set_I64_ValueList(v.Ids)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 7, Value: w}
+		i++
+	}
+	if v.Home != nil {
+		w, err = v.Home.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 8, Value: w}
+		i++
+	}
+	if v.Active == nil {
+		v.Active = ptr.Bool(true)
+	}
+	{
+		w, err = wire.NewValueBool(*(v.Active)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 9, Value: w}
+		i++
+	}
+	if v.Score != nil {
+		w, err = wire.NewValueDouble(*(v.Score)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.History != nil {
+		w, err = wire.NewValueList(_List_Point_ValueList(v.History)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 11, Value: w}
+		i++
+	}
+	if v.Scores != nil {
+		w, err = wire.NewValueMap(_Map_String_List_I32_MapItemList(v.Scores)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 12, Value: w}
+		i++
+	}
+	if v.Nickname != nil {
+		w, err = wire.NewValueString(*(v.Nickname)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 13, Value: w}
+		i++
+	}
+	if v.Aliases != nil {
+		w, err = wire.NewValueSet(_Set_String_ValueList(v.Aliases)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 14, Value: w}
+		i++
+	}
+	if v.Referrer != nil {
+		w, err = v.Referrer.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 15, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Map_String_List_I32_Read(m wire.MapItemList) (map[string][]int32, error) {
+	return thriftmap.Decode(m, wire.TBinary, wire.TList, func(x wire.Value) (string, error) {
+		return x.GetString(), error(nil)
+	}, func(x wire.Value) ([]int32, error) {
+		return _List_I32_Read(x.GetList())
+	})
+}
+
+func _UserV2_Read(w wire.Value) (*UserV2, error) {
+	var v UserV2
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func (v *UserV2) FromWire(w wire.Value) error {
+	var err error
+	nameIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.Name, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Name", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				nameIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Age = &x
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Age", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 3:
+			if field.Value.Type() == wire.TBinary {
+				v.Avatar, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Avatar", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 4:
+			if field.Value.Type() == wire.TI32 {
+				var x Color
+				x, err = _Color_Read(field.Value)
+				v.Color = &x
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Color", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 5:
+			if field.Value.Type() == wire.TList {
+				v.Tags, err = _List_String_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Tags", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 6:
+			if field.Value.Type() == wire.TMap {
+				v.Places, err = _Map_String_Point_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Places", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 7:
+			if field.Value.Type() == wire.TSet {
+				v.Ids, err = _Set_I64_Read(field.Value.GetSet())
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Ids", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 8:
+			if field.Value.Type() == wire.TStruct {
+				v.Home, err = _Point_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Home", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 9:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Active = &x
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Active", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 10:
+			if field.Value.Type() == wire.TDouble {
+				var x float64
+				x, err = field.Value.GetDouble(), error(nil)
+				v.Score = &x
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Score", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 11:
+			if field.Value.Type() == wire.TList {
+				v.History, err = _List_Point_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "History", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 12:
+			if field.Value.Type() == wire.TMap {
+				v.Scores, err = _Map_String_List_I32_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Scores", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 13:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Nickname = &x
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Nickname", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 14:
+			if field.Value.Type() == wire.TSet {
+				v.Aliases, err = _Set_String_Read(field.Value.GetSet())
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Aliases", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 15:
+			if field.Value.Type() == wire.TStruct {
+				v.Referrer, err = _UserV2_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Referrer", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	if !nameIsSet {
+		wire.ObserveDecodeError("UserV2", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "UserV2", Field: "Name", ID: 1}
+	}
+	if v.Active == nil {
+		v.Active = ptr.Bool(true)
+	}
+	return nil
+}
+
+func (v *UserV2) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [15]string
+	i := 0
+	fields[i] = fmt.Sprintf("Name: %v", v.Name)
+	i++
+	if v.Age != nil {
+		fields[i] = fmt.Sprintf("Age: %v", *(v.Age))
+		i++
+	}
+	if v.Avatar != nil {
+		fields[i] = fmt.Sprintf("Avatar: %v", v.Avatar)
+		i++
+	}
+	if v.Color != nil {
+		fields[i] = fmt.Sprintf("Color: %v", *(v.Color))
+		i++
+	}
+	if v.Tags != nil {
+		fields[i] = fmt.Sprintf("Tags: %v", v.Tags)
+		i++
+	}
+	if v.Places != nil {
+		fields[i] = fmt.Sprintf("Places: %v", v.Places)
+		i++
+	}
+	if v.Ids != nil {
+		fields[i] = fmt.Sprintf("Ids: %v", v.Ids)
+		i++
+	}
+	if v.Home != nil {
+		fields[i] = fmt.Sprintf("Home: %v", v.Home)
+		i++
+	}
+	if v.Active != nil {
+		fields[i] = fmt.Sprintf("Active: %v", *(v.Active))
+		i++
+	}
+	if v.Score != nil {
+		fields[i] = fmt.Sprintf("Score: %v", *(v.Score))
+		i++
+	}
+	if v.History != nil {
+		fields[i] = fmt.Sprintf("History: %v", v.History)
+		i++
+	}
+	if v.Scores != nil {
+		fields[i] = fmt.Sprintf("Scores: %v", v.Scores)
+		i++
+	}
+	if v.Nickname != nil {
+		fields[i] = fmt.Sprintf("Nickname: %v", *(v.Nickname))
+		i++
+	}
+	if v.Aliases != nil {
+		fields[i] = fmt.Sprintf("Aliases: %v", v.Aliases)
+		i++
+	}
+	if v.Referrer != nil {
+		fields[i] = fmt.Sprintf("Referrer: %v", v.Referrer)
+		i++
+	}
+	return fmt.Sprintf("UserV2{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Map_String_List_I32_Equals(lhs, rhs map[string][]int32) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !_List_I32_Equals(lv, rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *UserV2) Equals(rhs *UserV2) bool {
+	if !(v.Name == rhs.Name) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.Age, rhs.Age) {
+		return false
+	}
+	if !((v.Avatar == nil && rhs.Avatar == nil) || (v.Avatar != nil && rhs.Avatar != nil && bytes.Equal(v.Avatar, rhs.Avatar))) {
+		return false
+	}
+	if !_Color_EqualsPtr(v.Color, rhs.Color) {
+		return false
+	}
+	if !((v.Tags == nil && rhs.Tags == nil) || (v.Tags != nil && rhs.Tags != nil && _List_String_Equals(v.Tags, rhs.Tags))) {
+		return false
+	}
+	if !((v.Places == nil && rhs.Places == nil) || (v.Places != nil && rhs.Places != nil && _Map_String_Point_Equals(v.Places, rhs.Places))) {
+		return false
+	}
+	if !((v.Ids == nil && rhs.Ids == nil) || (v.Ids != nil && rhs.Ids != nil && _Set_I64_Equals(v.Ids, rhs.Ids))) {
+		return false
+	}
+	if !((v.Home == nil && rhs.Home == nil) || (v.Home != nil && rhs.Home != nil && v.Home.Equals(rhs.Home))) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.Active, rhs.Active) {
+		return false
+	}
+	if !_Double_EqualsPtr(v.Score, rhs.Score) {
+		return false
+	}
+	if !((v.History == nil && rhs.History == nil) || (v.History != nil && rhs.History != nil && _List_Point_Equals(v.History, rhs.History))) {
+		return false
+	}
+	if !((v.Scores == nil && rhs.Scores == nil) || (v.Scores != nil && rhs.Scores != nil && _Map_String_List_I32_Equals(v.Scores, rhs.Scores))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Nickname, rhs.Nickname) {
+		return false
+	}
+	if !((v.Aliases == nil && rhs.Aliases == nil) || (v.Aliases != nil && rhs.Aliases != nil && _Set_String_Equals(v.Aliases, rhs.Aliases))) {
+		return false
+	}
+	if !((v.Referrer == nil && rhs.Referrer == nil) || (v.Referrer != nil && rhs.Referrer != nil && v.Referrer.Equals(rhs.Referrer))) {
+		return false
+	}
+	return true
+}
+
+func (v *UserV2) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
+}
+
+func (v *UserV2) GetAge() (o int32) {
+	if v != nil && v.Age != nil {
+		return *v.Age
+	}
+	return
+}
+
+func (v *UserV2) GetAvatar() (o []byte) {
+	if v != nil && v.Avatar != nil {
+		return v.Avatar
+	}
+	return
+}
+
+func (v *UserV2) GetColor() (o Color) {
+	if v != nil && v.Color != nil {
+		return *v.Color
+	}
+	return
+}
+
+func (v *UserV2) GetTags() (o []string) {
+	if v != nil && v.Tags != nil {
+		return v.Tags
+	}
+	return
+}
+
+func (v *UserV2) GetPlaces() (o map[string]*Point) {
+	if v != nil && v.Places != nil {
+		return v.Places
+	}
+	return
+}
+
+func (v *UserV2) GetIds() (o map[int64]struct{}) {
+	if v != nil && v.Ids != nil {
+		return v.Ids
+	}
+	return
+}
+
+func (v *UserV2) GetHome() (o *Point) {
+	if v != nil && v.Home != nil {
+		return v.Home
+	}
+	return
+}
+
+func (v *UserV2) GetActive() (o bool) {
+	if v != nil && v.Active != nil {
+		return *v.Active
+	}
+	o = true
+	return
+}
+
+func (v *UserV2) GetScore() (o float64) {
+	if v != nil && v.Score != nil {
+		return *v.Score
+	}
+	return
+}
+
+func (v *UserV2) GetHistory() (o []*Point) {
+	if v != nil && v.History != nil {
+		return v.History
+	}
+	return
+}
+
+func (v *UserV2) GetScores() (o map[string][]int32) {
+	if v != nil && v.Scores != nil {
+		return v.Scores
+	}
+	return
+}
+
+func (v *UserV2) GetNickname() (o string) {
+	if v != nil && v.Nickname != nil {
+		return *v.Nickname
+	}
+	return
+}
+
+func (v *UserV2) GetAliases() (o map[string]struct{}) {
+	if v != nil && v.Aliases != nil {
+		return v.Aliases
+	}
+	return
+}
+
+func (v *UserV2) GetReferrer() (o *UserV2) {
+	if v != nil && v.Referrer != nil {
+		return v.Referrer
+	}
+	return
+}
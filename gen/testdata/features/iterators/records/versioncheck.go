@@ -0,0 +1,13 @@
+//go:build go1.23
+// +build go1.23
+
+// Code generated by thriftrw v1.4.0
+// @generated
+
+package records
+
+import "go.uber.org/thriftrw/version"
+
+func init() {
+	version.CheckCompatWithGeneratedCodeAt("1.4.0", "go.uber.org/thriftrw/gen/testdata/features/iterators/records")
+}
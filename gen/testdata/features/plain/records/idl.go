@@ -0,0 +1,10 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+package records
+
+import "go.uber.org/thriftrw/thriftreflect"
+
+var ThriftModule = &thriftreflect.ThriftModule{Name: "records", Package: "go.uber.org/thriftrw/gen/testdata/features/plain/records", FilePath: "records.thrift", SHA1: "2a3bbe9815a7735058f5214f1af2dd415e5b3e7a", Raw: rawIDL}
+
+const rawIDL = "// Types generated with different code generation options into the packages\n// under gen/testdata/features so that tests can verify the behavior of the\n// generated code, and compare it between options.\n\nenum Color {\n    RED\n    GREEN\n    BLUE\n}\n\nstruct Point {\n    1: required i32 x\n    2: required i32 y\n}\n\nstruct User {\n    1: required string name\n    2: optional i32 age\n    3: optional binary avatar\n    4: optional Color color\n    5: optional list<string> tags\n    6: optional map<string, Point> places\n    7: optional set<i64> ids\n    8: optional Point home\n    9: optional bool active = true\n    10: optional double score\n}\n\n/**\n * UserV2 is a newer version of User with more fields. Values encoded from it\n * have fields that User does not know about.\n */\nstruct UserV2 {\n    1: required string name\n    2: optional i32 age\n    3: optional binary avatar\n    4: optional Color color\n    5: optional list<string> tags\n    6: optional map<string, Point> places\n    7: optional set<i64> ids\n    8: optional Point home\n    9: optional bool active = true\n    10: optional double score\n    11: optional list<Point> history\n    12: optional map<string, list<i32>> scores\n    13: optional string nickname\n    14: optional set<string> aliases\n    15: optional UserV2 referrer\n}\n\nstruct Shapes {\n    1: optional list<Point> points\n    2: optional set<string> names\n    3: optional set<binary> blobs\n    4: optional map<string, Point> byName\n    5: optional map<Point, i32> counts\n    6: optional list<list<i32>> grid\n}\n\nstruct Session {\n    1: required string id\n    2: optional i64 lastSeen (go.hash = \"false\")\n}\n\nunion Shape {\n    1: Point point\n    2: list<Point> polygon\n}\n\nexception NotFound {\n    1: required string key\n}\n\nstruct Empty {}\n\n/**\n * Profile has a field named after the presence method of another field.\n */\nstruct Profile {\n    1: optional string email\n    2: optional bool hasEmail\n}\n\ntypedef set<string> Tags\ntypedef set<Point> Points\ntypedef Points Outline\ntypedef map<string, i32> Counts\ntypedef map<Point, string> Labels\ntypedef list<string> Names\n"
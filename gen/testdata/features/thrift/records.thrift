@@ -0,0 +1,89 @@
+// Types generated with different code generation options into the packages
+// under gen/testdata/features so that tests can verify the behavior of the
+// generated code, and compare it between options.
+
+enum Color {
+    RED
+    GREEN
+    BLUE
+}
+
+struct Point {
+    1: required i32 x
+    2: required i32 y
+}
+
+struct User {
+    1: required string name
+    2: optional i32 age
+    3: optional binary avatar
+    4: optional Color color
+    5: optional list<string> tags
+    6: optional map<string, Point> places
+    7: optional set<i64> ids
+    8: optional Point home
+    9: optional bool active = true
+    10: optional double score
+}
+
+/**
+ * UserV2 is a newer version of User with more fields. Values encoded from it
+ * have fields that User does not know about.
+ */
+struct UserV2 {
+    1: required string name
+    2: optional i32 age
+    3: optional binary avatar
+    4: optional Color color
+    5: optional list<string> tags
+    6: optional map<string, Point> places
+    7: optional set<i64> ids
+    8: optional Point home
+    9: optional bool active = true
+    10: optional double score
+    11: optional list<Point> history
+    12: optional map<string, list<i32>> scores
+    13: optional string nickname
+    14: optional set<string> aliases
+    15: optional UserV2 referrer
+}
+
+struct Shapes {
+    1: optional list<Point> points
+    2: optional set<string> names
+    3: optional set<binary> blobs
+    4: optional map<string, Point> byName
+    5: optional map<Point, i32> counts
+    6: optional list<list<i32>> grid
+}
+
+struct Session {
+    1: required string id
+    2: optional i64 lastSeen (go.hash = "false")
+}
+
+union Shape {
+    1: Point point
+    2: list<Point> polygon
+}
+
+exception NotFound {
+    1: required string key
+}
+
+struct Empty {}
+
+/**
+ * Profile has a field named after the presence method of another field.
+ */
+struct Profile {
+    1: optional string email
+    2: optional bool hasEmail
+}
+
+typedef set<string> Tags
+typedef set<Point> Points
+typedef Points Outline
+typedef map<string, i32> Counts
+typedef map<Point, string> Labels
+typedef list<string> Names
@@ -4,7 +4,9 @@
 package services
 
 import (
+	"bytes"
 	"fmt"
+	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/wire"
 	"strings"
 )
@@ -48,10 +50,24 @@ func (v *Cache_Clear_Args) EnvelopeType() wire.EnvelopeType {
 	return wire.OneWay
 }
 
-var Cache_Clear_Helper = struct{ Args func() *Cache_Clear_Args }{}
+var Cache_Clear_Helper = struct {
+	Args       func() *Cache_Clear_Args
+	DecodeArgs func(protocol.Protocol, []byte) (*Cache_Clear_Args, error)
+}{}
 
 func init() {
 	Cache_Clear_Helper.Args = func() *Cache_Clear_Args {
 		return &Cache_Clear_Args{}
 	}
+	Cache_Clear_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*Cache_Clear_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args Cache_Clear_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
 }
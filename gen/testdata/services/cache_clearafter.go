@@ -4,7 +4,9 @@
 package services
 
 import (
+	"bytes"
 	"fmt"
+	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/wire"
 	"strings"
 )
@@ -41,6 +43,7 @@ func (v *Cache_ClearAfter_Args) FromWire(w wire.Value) error {
 				x, err = field.Value.GetI64(), error(nil)
 				v.DurationMS = &x
 				if err != nil {
+					wire.ObserveDecodeError("Cache_ClearAfter_Args", "DurationMS", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -78,6 +81,13 @@ func (v *Cache_ClearAfter_Args) Equals(rhs *Cache_ClearAfter_Args) bool {
 	return true
 }
 
+func (v *Cache_ClearAfter_Args) GetDurationMS() (o int64) {
+	if v != nil && v.DurationMS != nil {
+		return *v.DurationMS
+	}
+	return
+}
+
 func (v *Cache_ClearAfter_Args) MethodName() string {
 	return "clearAfter"
 }
@@ -87,11 +97,39 @@ func (v *Cache_ClearAfter_Args) EnvelopeType() wire.EnvelopeType {
 }
 
 var Cache_ClearAfter_Helper = struct {
-	Args func(durationMS *int64) *Cache_ClearAfter_Args
+	Args       func(durationMS *int64) *Cache_ClearAfter_Args
+	DecodeArgs func(protocol.Protocol, []byte) (*Cache_ClearAfter_Args, error)
 }{}
 
 func init() {
 	Cache_ClearAfter_Helper.Args = func(durationMS *int64) *Cache_ClearAfter_Args {
 		return &Cache_ClearAfter_Args{DurationMS: durationMS}
 	}
+	Cache_ClearAfter_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*Cache_ClearAfter_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args Cache_ClearAfter_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
+}
+
+type Cache_ClearAfter_ArgsOption func(*Cache_ClearAfter_Args)
+
+func Cache_ClearAfter_WithDurationMS(v int64) Cache_ClearAfter_ArgsOption {
+	return func(args *Cache_ClearAfter_Args) {
+		args.DurationMS = &v
+	}
+}
+
+func Cache_ClearAfter_NewArgs(opts ...Cache_ClearAfter_ArgsOption) *Cache_ClearAfter_Args {
+	args := &Cache_ClearAfter_Args{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	return args
 }
@@ -0,0 +1,259 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+	"strings"
+)
+
+type Cache_ClearMatching_Args struct {
+	Prefix     string `json:"prefix"`
+	DurationMS *int64 `json:"durationMS,omitempty"`
+	Exclude    []Key  `json:"exclude"`
+}
+
+type _List_Key_ValueList []Key
+
+func (v _List_Key_ValueList) ForEach(f func(wire.Value) error) error {
+	for _, x := range v {
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_Key_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_Key_ValueList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_List_Key_ValueList) Close() {
+}
+
+func (v *Cache_ClearMatching_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueString(v.Prefix), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.DurationMS != nil {
+		w, err = wire.NewValueI64(*(v.DurationMS)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.Exclude != nil {
+		w, err = wire.NewValueList(_List_Key_ValueList(v.Exclude)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Key_Read(w wire.Value) (Key, error) {
+	var x Key
+	err := x.FromWire(w)
+	return x, err
+}
+
+func _List_Key_Read(l wire.ValueList) ([]Key, error) {
+	if l.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+	o := make([]Key, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _Key_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func (v *Cache_ClearMatching_Args) FromWire(w wire.Value) error {
+	var err error
+	prefixIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.Prefix, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("Cache_ClearMatching_Args", "Prefix", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				prefixIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.DurationMS = &x
+				if err != nil {
+					wire.ObserveDecodeError("Cache_ClearMatching_Args", "DurationMS", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 3:
+			if field.Value.Type() == wire.TList {
+				v.Exclude, err = _List_Key_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("Cache_ClearMatching_Args", "Exclude", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	if !prefixIsSet {
+		wire.ObserveDecodeError("Cache_ClearMatching_Args", "Prefix", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Cache_ClearMatching_Args", Field: "Prefix", ID: 1}
+	}
+	return nil
+}
+
+func (v *Cache_ClearMatching_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [3]string
+	i := 0
+	fields[i] = fmt.Sprintf("Prefix: %v", v.Prefix)
+	i++
+	if v.DurationMS != nil {
+		fields[i] = fmt.Sprintf("DurationMS: %v", *(v.DurationMS))
+		i++
+	}
+	if v.Exclude != nil {
+		fields[i] = fmt.Sprintf("Exclude: %v", v.Exclude)
+		i++
+	}
+	return fmt.Sprintf("Cache_ClearMatching_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_Key_Equals(lhs, rhs []Key) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *Cache_ClearMatching_Args) Equals(rhs *Cache_ClearMatching_Args) bool {
+	if !(v.Prefix == rhs.Prefix) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.DurationMS, rhs.DurationMS) {
+		return false
+	}
+	if !((v.Exclude == nil && rhs.Exclude == nil) || (v.Exclude != nil && rhs.Exclude != nil && _List_Key_Equals(v.Exclude, rhs.Exclude))) {
+		return false
+	}
+	return true
+}
+
+func (v *Cache_ClearMatching_Args) GetPrefix() (o string) {
+	if v != nil {
+		o = v.Prefix
+	}
+	return
+}
+
+func (v *Cache_ClearMatching_Args) GetDurationMS() (o int64) {
+	if v != nil && v.DurationMS != nil {
+		return *v.DurationMS
+	}
+	return
+}
+
+func (v *Cache_ClearMatching_Args) GetExclude() (o []Key) {
+	if v != nil && v.Exclude != nil {
+		return v.Exclude
+	}
+	return
+}
+
+func (v *Cache_ClearMatching_Args) MethodName() string {
+	return "clearMatching"
+}
+
+func (v *Cache_ClearMatching_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.OneWay
+}
+
+var Cache_ClearMatching_Helper = struct {
+	Args       func(prefix string, durationMS *int64, exclude []Key) *Cache_ClearMatching_Args
+	DecodeArgs func(protocol.Protocol, []byte) (*Cache_ClearMatching_Args, error)
+}{}
+
+func init() {
+	Cache_ClearMatching_Helper.Args = func(prefix string, durationMS *int64, exclude []Key) *Cache_ClearMatching_Args {
+		return &Cache_ClearMatching_Args{Prefix: prefix, DurationMS: durationMS, Exclude: exclude}
+	}
+	Cache_ClearMatching_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*Cache_ClearMatching_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args Cache_ClearMatching_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
+}
+
+type Cache_ClearMatching_ArgsOption func(*Cache_ClearMatching_Args)
+
+func Cache_ClearMatching_WithDurationMS(v int64) Cache_ClearMatching_ArgsOption {
+	return func(args *Cache_ClearMatching_Args) {
+		args.DurationMS = &v
+	}
+}
+
+func Cache_ClearMatching_WithExclude(v []Key) Cache_ClearMatching_ArgsOption {
+	return func(args *Cache_ClearMatching_Args) {
+		args.Exclude = v
+	}
+}
+
+func Cache_ClearMatching_NewArgs(prefix string, opts ...Cache_ClearMatching_ArgsOption) *Cache_ClearMatching_Args {
+	args := &Cache_ClearMatching_Args{Prefix: prefix}
+	for _, opt := range opts {
+		opt(args)
+	}
+	return args
+}
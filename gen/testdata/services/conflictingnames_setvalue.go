@@ -4,7 +4,10 @@
 package services
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/wire"
 	"strings"
 )
@@ -45,6 +48,7 @@ func (v *ConflictingNames_SetValue_Args) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.Request, err = _ConflictingNamesSetValueArgs_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("ConflictingNames_SetValue_Args", "Request", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -73,6 +77,13 @@ func (v *ConflictingNames_SetValue_Args) Equals(rhs *ConflictingNames_SetValue_A
 	return true
 }
 
+func (v *ConflictingNames_SetValue_Args) GetRequest() (o *ConflictingNamesSetValueArgs) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+	return
+}
+
 func (v *ConflictingNames_SetValue_Args) MethodName() string {
 	return "setValue"
 }
@@ -82,22 +93,38 @@ func (v *ConflictingNames_SetValue_Args) EnvelopeType() wire.EnvelopeType {
 }
 
 var ConflictingNames_SetValue_Helper = struct {
-	Args           func(request *ConflictingNamesSetValueArgs) *ConflictingNames_SetValue_Args
-	IsException    func(error) bool
-	WrapResponse   func(error) (*ConflictingNames_SetValue_Result, error)
-	UnwrapResponse func(*ConflictingNames_SetValue_Result) error
+	Args            func(request *ConflictingNamesSetValueArgs) *ConflictingNames_SetValue_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*ConflictingNames_SetValue_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func(error) (*ConflictingNames_SetValue_Result, error)
+	UnwrapResponse  func(*ConflictingNames_SetValue_Result) error
 }{}
 
 func init() {
 	ConflictingNames_SetValue_Helper.Args = func(request *ConflictingNamesSetValueArgs) *ConflictingNames_SetValue_Args {
 		return &ConflictingNames_SetValue_Args{Request: request}
 	}
+	ConflictingNames_SetValue_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*ConflictingNames_SetValue_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args ConflictingNames_SetValue_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
 	ConflictingNames_SetValue_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		default:
 			return false
 		}
 	}
+	ConflictingNames_SetValue_Helper.IsSafeException = func(error) bool {
+		return false
+	}
 	ConflictingNames_SetValue_Helper.WrapResponse = func(err error) (*ConflictingNames_SetValue_Result, error) {
 		if err == nil {
 			return &ConflictingNames_SetValue_Result{}, nil
@@ -109,6 +136,22 @@ func init() {
 	}
 }
 
+type ConflictingNames_SetValue_ArgsOption func(*ConflictingNames_SetValue_Args)
+
+func ConflictingNames_SetValue_WithRequest(v *ConflictingNamesSetValueArgs) ConflictingNames_SetValue_ArgsOption {
+	return func(args *ConflictingNames_SetValue_Args) {
+		args.Request = v
+	}
+}
+
+func ConflictingNames_SetValue_NewArgs(opts ...ConflictingNames_SetValue_ArgsOption) *ConflictingNames_SetValue_Args {
+	args := &ConflictingNames_SetValue_Args{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	return args
+}
+
 type ConflictingNames_SetValue_Result struct{}
 
 func (v *ConflictingNames_SetValue_Result) ToWire() (wire.Value, error) {
@@ -147,3 +190,14 @@ func (v *ConflictingNames_SetValue_Result) MethodName() string {
 func (v *ConflictingNames_SetValue_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
+
+func ConflictingNames_SetValue_NewResultSuccess() *ConflictingNames_SetValue_Result {
+	return &ConflictingNames_SetValue_Result{}
+}
+
+func ConflictingNames_SetValue_NewResultError(err error) (*ConflictingNames_SetValue_Result, error) {
+	if err == nil {
+		return nil, errors.New("ConflictingNames_SetValue_NewResultError received a nil error")
+	}
+	return nil, err
+}
@@ -4,13 +4,17 @@
 package services
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"go.uber.org/thriftrw/gen/testdata/exceptions"
+	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/wire"
 	"strings"
 )
 
+// void with exceptions
 type KeyValue_DeleteValue_Args struct {
 	Key *Key `json:"key,omitempty"`
 }
@@ -33,12 +37,6 @@ func (v *KeyValue_DeleteValue_Args) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _Key_Read(w wire.Value) (Key, error) {
-	var x Key
-	err := x.FromWire(w)
-	return x, err
-}
-
 func (v *KeyValue_DeleteValue_Args) FromWire(w wire.Value) error {
 	var err error
 	for _, field := range w.GetStruct().Fields {
@@ -49,6 +47,7 @@ func (v *KeyValue_DeleteValue_Args) FromWire(w wire.Value) error {
 				x, err = _Key_Read(field.Value)
 				v.Key = &x
 				if err != nil {
+					wire.ObserveDecodeError("KeyValue_DeleteValue_Args", "Key", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -86,6 +85,13 @@ func (v *KeyValue_DeleteValue_Args) Equals(rhs *KeyValue_DeleteValue_Args) bool
 	return true
 }
 
+func (v *KeyValue_DeleteValue_Args) GetKey() (o Key) {
+	if v != nil && v.Key != nil {
+		return *v.Key
+	}
+	return
+}
+
 func (v *KeyValue_DeleteValue_Args) MethodName() string {
 	return "deleteValue"
 }
@@ -95,16 +101,29 @@ func (v *KeyValue_DeleteValue_Args) EnvelopeType() wire.EnvelopeType {
 }
 
 var KeyValue_DeleteValue_Helper = struct {
-	Args           func(key *Key) *KeyValue_DeleteValue_Args
-	IsException    func(error) bool
-	WrapResponse   func(error) (*KeyValue_DeleteValue_Result, error)
-	UnwrapResponse func(*KeyValue_DeleteValue_Result) error
+	Args            func(key *Key) *KeyValue_DeleteValue_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*KeyValue_DeleteValue_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func(error) (*KeyValue_DeleteValue_Result, error)
+	UnwrapResponse  func(*KeyValue_DeleteValue_Result) error
 }{}
 
 func init() {
 	KeyValue_DeleteValue_Helper.Args = func(key *Key) *KeyValue_DeleteValue_Args {
 		return &KeyValue_DeleteValue_Args{Key: key}
 	}
+	KeyValue_DeleteValue_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*KeyValue_DeleteValue_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args KeyValue_DeleteValue_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
 	KeyValue_DeleteValue_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		case *exceptions.DoesNotExistException:
@@ -115,6 +134,14 @@ func init() {
 			return false
 		}
 	}
+	KeyValue_DeleteValue_Helper.IsSafeException = func(err error) bool {
+		switch err.(type) {
+		case *exceptions.DoesNotExistException:
+			return true
+		default:
+			return false
+		}
+	}
 	KeyValue_DeleteValue_Helper.WrapResponse = func(err error) (*KeyValue_DeleteValue_Result, error) {
 		if err == nil {
 			return &KeyValue_DeleteValue_Result{}, nil
@@ -146,6 +173,22 @@ func init() {
 	}
 }
 
+type KeyValue_DeleteValue_ArgsOption func(*KeyValue_DeleteValue_Args)
+
+func KeyValue_DeleteValue_WithKey(v Key) KeyValue_DeleteValue_ArgsOption {
+	return func(args *KeyValue_DeleteValue_Args) {
+		args.Key = &v
+	}
+}
+
+func KeyValue_DeleteValue_NewArgs(opts ...KeyValue_DeleteValue_ArgsOption) *KeyValue_DeleteValue_Args {
+	args := &KeyValue_DeleteValue_Args{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	return args
+}
+
 type KeyValue_DeleteValue_Result struct {
 	DoesNotExist  *exceptions.DoesNotExistException `json:"doesNotExist,omitempty"`
 	InternalError *InternalError                    `json:"internalError,omitempty"`
@@ -200,6 +243,7 @@ func (v *KeyValue_DeleteValue_Result) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.DoesNotExist, err = _DoesNotExistException_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("KeyValue_DeleteValue_Result", "DoesNotExist", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -207,6 +251,7 @@ func (v *KeyValue_DeleteValue_Result) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.InternalError, err = _InternalError_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("KeyValue_DeleteValue_Result", "InternalError", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -220,6 +265,7 @@ func (v *KeyValue_DeleteValue_Result) FromWire(w wire.Value) error {
 		count++
 	}
 	if count > 1 {
+		wire.ObserveDecodeError("KeyValue_DeleteValue_Result", "", wire.DecodeErrorInvalidUnion)
 		return fmt.Errorf("KeyValue_DeleteValue_Result should have at most one field: got %v fields", count)
 	}
 	return nil
@@ -252,6 +298,53 @@ func (v *KeyValue_DeleteValue_Result) Equals(rhs *KeyValue_DeleteValue_Result) b
 	return true
 }
 
+func (v *KeyValue_DeleteValue_Result) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.DoesNotExist != nil {
+		count++
+	}
+	if v.InternalError != nil {
+		count++
+	}
+	if count > 1 {
+		return nil, fmt.Errorf("KeyValue_DeleteValue_Result should have at most one field: got %v fields", count)
+	}
+	type plain KeyValue_DeleteValue_Result
+	return json.Marshal((*plain)(v))
+}
+
+func (v *KeyValue_DeleteValue_Result) UnmarshalJSON(text []byte) error {
+	type plain KeyValue_DeleteValue_Result
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.DoesNotExist != nil {
+		count++
+	}
+	if v.InternalError != nil {
+		count++
+	}
+	if count > 1 {
+		return fmt.Errorf("KeyValue_DeleteValue_Result should have at most one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *KeyValue_DeleteValue_Result) GetDoesNotExist() (o *exceptions.DoesNotExistException) {
+	if v != nil && v.DoesNotExist != nil {
+		return v.DoesNotExist
+	}
+	return
+}
+
+func (v *KeyValue_DeleteValue_Result) GetInternalError() (o *InternalError) {
+	if v != nil && v.InternalError != nil {
+		return v.InternalError
+	}
+	return
+}
+
 func (v *KeyValue_DeleteValue_Result) MethodName() string {
 	return "deleteValue"
 }
@@ -259,3 +352,26 @@ func (v *KeyValue_DeleteValue_Result) MethodName() string {
 func (v *KeyValue_DeleteValue_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
+
+func KeyValue_DeleteValue_NewResultSuccess() *KeyValue_DeleteValue_Result {
+	return &KeyValue_DeleteValue_Result{}
+}
+
+func KeyValue_DeleteValue_NewResultError(err error) (*KeyValue_DeleteValue_Result, error) {
+	if err == nil {
+		return nil, errors.New("KeyValue_DeleteValue_NewResultError received a nil error")
+	}
+	switch e := err.(type) {
+	case *exceptions.DoesNotExistException:
+		if e == nil {
+			return nil, errors.New("KeyValue_DeleteValue_NewResultError received non-nil error type with nil value for KeyValue_DeleteValue_Result.DoesNotExist")
+		}
+		return &KeyValue_DeleteValue_Result{DoesNotExist: e}, nil
+	case *InternalError:
+		if e == nil {
+			return nil, errors.New("KeyValue_DeleteValue_NewResultError received non-nil error type with nil value for KeyValue_DeleteValue_Result.InternalError")
+		}
+		return &KeyValue_DeleteValue_Result{InternalError: e}, nil
+	}
+	return nil, err
+}
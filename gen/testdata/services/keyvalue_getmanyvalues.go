@@ -4,10 +4,13 @@
 package services
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"go.uber.org/thriftrw/gen/testdata/exceptions"
 	"go.uber.org/thriftrw/gen/testdata/unions"
+	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/wire"
 	"strings"
 )
@@ -16,33 +19,6 @@ type KeyValue_GetManyValues_Args struct {
 	Range []Key `json:"range"`
 }
 
-type _List_Key_ValueList []Key
-
-func (v _List_Key_ValueList) ForEach(f func(wire.Value) error) error {
-	for _, x := range v {
-		w, err := x.ToWire()
-		if err != nil {
-			return err
-		}
-		err = f(w)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (v _List_Key_ValueList) Size() int {
-	return len(v)
-}
-
-func (_List_Key_ValueList) ValueType() wire.Type {
-	return wire.TBinary
-}
-
-func (_List_Key_ValueList) Close() {
-}
-
 func (v *KeyValue_GetManyValues_Args) ToWire() (wire.Value, error) {
 	var (
 		fields [1]wire.Field
@@ -61,23 +37,6 @@ func (v *KeyValue_GetManyValues_Args) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _List_Key_Read(l wire.ValueList) ([]Key, error) {
-	if l.ValueType() != wire.TBinary {
-		return nil, nil
-	}
-	o := make([]Key, 0, l.Size())
-	err := l.ForEach(func(x wire.Value) error {
-		i, err := _Key_Read(x)
-		if err != nil {
-			return err
-		}
-		o = append(o, i)
-		return nil
-	})
-	l.Close()
-	return o, err
-}
-
 func (v *KeyValue_GetManyValues_Args) FromWire(w wire.Value) error {
 	var err error
 	for _, field := range w.GetStruct().Fields {
@@ -86,6 +45,7 @@ func (v *KeyValue_GetManyValues_Args) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.Range, err = _List_Key_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("KeyValue_GetManyValues_Args", "Range", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -107,24 +67,18 @@ func (v *KeyValue_GetManyValues_Args) String() string {
 	return fmt.Sprintf("KeyValue_GetManyValues_Args{%v}", strings.Join(fields[:i], ", "))
 }
 
-func _List_Key_Equals(lhs, rhs []Key) bool {
-	if len(lhs) != len(rhs) {
+func (v *KeyValue_GetManyValues_Args) Equals(rhs *KeyValue_GetManyValues_Args) bool {
+	if !((v.Range == nil && rhs.Range == nil) || (v.Range != nil && rhs.Range != nil && _List_Key_Equals(v.Range, rhs.Range))) {
 		return false
 	}
-	for i, lv := range lhs {
-		rv := rhs[i]
-		if !(lv == rv) {
-			return false
-		}
-	}
 	return true
 }
 
-func (v *KeyValue_GetManyValues_Args) Equals(rhs *KeyValue_GetManyValues_Args) bool {
-	if !((v.Range == nil && rhs.Range == nil) || (v.Range != nil && rhs.Range != nil && _List_Key_Equals(v.Range, rhs.Range))) {
-		return false
+func (v *KeyValue_GetManyValues_Args) GetRange() (o []Key) {
+	if v != nil && v.Range != nil {
+		return v.Range
 	}
-	return true
+	return
 }
 
 func (v *KeyValue_GetManyValues_Args) MethodName() string {
@@ -136,16 +90,29 @@ func (v *KeyValue_GetManyValues_Args) EnvelopeType() wire.EnvelopeType {
 }
 
 var KeyValue_GetManyValues_Helper = struct {
-	Args           func(range2 []Key) *KeyValue_GetManyValues_Args
-	IsException    func(error) bool
-	WrapResponse   func([]*unions.ArbitraryValue, error) (*KeyValue_GetManyValues_Result, error)
-	UnwrapResponse func(*KeyValue_GetManyValues_Result) ([]*unions.ArbitraryValue, error)
+	Args            func(range2 []Key) *KeyValue_GetManyValues_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*KeyValue_GetManyValues_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func([]*unions.ArbitraryValue, error) (*KeyValue_GetManyValues_Result, error)
+	UnwrapResponse  func(*KeyValue_GetManyValues_Result) ([]*unions.ArbitraryValue, error)
 }{}
 
 func init() {
 	KeyValue_GetManyValues_Helper.Args = func(range2 []Key) *KeyValue_GetManyValues_Args {
 		return &KeyValue_GetManyValues_Args{Range: range2}
 	}
+	KeyValue_GetManyValues_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*KeyValue_GetManyValues_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args KeyValue_GetManyValues_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
 	KeyValue_GetManyValues_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		case *exceptions.DoesNotExistException:
@@ -154,6 +121,14 @@ func init() {
 			return false
 		}
 	}
+	KeyValue_GetManyValues_Helper.IsSafeException = func(err error) bool {
+		switch err.(type) {
+		case *exceptions.DoesNotExistException:
+			return true
+		default:
+			return false
+		}
+	}
 	KeyValue_GetManyValues_Helper.WrapResponse = func(success []*unions.ArbitraryValue, err error) (*KeyValue_GetManyValues_Result, error) {
 		if err == nil {
 			return &KeyValue_GetManyValues_Result{Success: success}, nil
@@ -181,6 +156,22 @@ func init() {
 	}
 }
 
+type KeyValue_GetManyValues_ArgsOption func(*KeyValue_GetManyValues_Args)
+
+func KeyValue_GetManyValues_WithRange(v []Key) KeyValue_GetManyValues_ArgsOption {
+	return func(args *KeyValue_GetManyValues_Args) {
+		args.Range = v
+	}
+}
+
+func KeyValue_GetManyValues_NewArgs(opts ...KeyValue_GetManyValues_ArgsOption) *KeyValue_GetManyValues_Args {
+	args := &KeyValue_GetManyValues_Args{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	return args
+}
+
 type KeyValue_GetManyValues_Result struct {
 	Success      []*unions.ArbitraryValue          `json:"success"`
 	DoesNotExist *exceptions.DoesNotExistException `json:"doesNotExist,omitempty"`
@@ -276,6 +267,7 @@ func (v *KeyValue_GetManyValues_Result) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.Success, err = _List_ArbitraryValue_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("KeyValue_GetManyValues_Result", "Success", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -283,6 +275,7 @@ func (v *KeyValue_GetManyValues_Result) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.DoesNotExist, err = _DoesNotExistException_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("KeyValue_GetManyValues_Result", "DoesNotExist", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -296,6 +289,7 @@ func (v *KeyValue_GetManyValues_Result) FromWire(w wire.Value) error {
 		count++
 	}
 	if count != 1 {
+		wire.ObserveDecodeError("KeyValue_GetManyValues_Result", "", wire.DecodeErrorInvalidUnion)
 		return fmt.Errorf("KeyValue_GetManyValues_Result should have exactly one field: got %v fields", count)
 	}
 	return nil
@@ -341,6 +335,53 @@ func (v *KeyValue_GetManyValues_Result) Equals(rhs *KeyValue_GetManyValues_Resul
 	return true
 }
 
+func (v *KeyValue_GetManyValues_Result) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.DoesNotExist != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("KeyValue_GetManyValues_Result should have exactly one field: got %v fields", count)
+	}
+	type plain KeyValue_GetManyValues_Result
+	return json.Marshal((*plain)(v))
+}
+
+func (v *KeyValue_GetManyValues_Result) UnmarshalJSON(text []byte) error {
+	type plain KeyValue_GetManyValues_Result
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.DoesNotExist != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("KeyValue_GetManyValues_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *KeyValue_GetManyValues_Result) GetSuccess() (o []*unions.ArbitraryValue) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+	return
+}
+
+func (v *KeyValue_GetManyValues_Result) GetDoesNotExist() (o *exceptions.DoesNotExistException) {
+	if v != nil && v.DoesNotExist != nil {
+		return v.DoesNotExist
+	}
+	return
+}
+
 func (v *KeyValue_GetManyValues_Result) MethodName() string {
 	return "getManyValues"
 }
@@ -348,3 +389,21 @@ func (v *KeyValue_GetManyValues_Result) MethodName() string {
 func (v *KeyValue_GetManyValues_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
+
+func KeyValue_GetManyValues_NewResultSuccess(success []*unions.ArbitraryValue) *KeyValue_GetManyValues_Result {
+	return &KeyValue_GetManyValues_Result{Success: success}
+}
+
+func KeyValue_GetManyValues_NewResultError(err error) (*KeyValue_GetManyValues_Result, error) {
+	if err == nil {
+		return nil, errors.New("KeyValue_GetManyValues_NewResultError received a nil error")
+	}
+	switch e := err.(type) {
+	case *exceptions.DoesNotExistException:
+		if e == nil {
+			return nil, errors.New("KeyValue_GetManyValues_NewResultError received non-nil error type with nil value for KeyValue_GetManyValues_Result.DoesNotExist")
+		}
+		return &KeyValue_GetManyValues_Result{DoesNotExist: e}, nil
+	}
+	return nil, err
+}
@@ -4,14 +4,18 @@
 package services
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"go.uber.org/thriftrw/gen/testdata/exceptions"
 	"go.uber.org/thriftrw/gen/testdata/unions"
+	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/wire"
 	"strings"
 )
 
+// Return with exceptions
 type KeyValue_GetValue_Args struct {
 	Key *Key `json:"key,omitempty"`
 }
@@ -44,6 +48,7 @@ func (v *KeyValue_GetValue_Args) FromWire(w wire.Value) error {
 				x, err = _Key_Read(field.Value)
 				v.Key = &x
 				if err != nil {
+					wire.ObserveDecodeError("KeyValue_GetValue_Args", "Key", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -72,6 +77,13 @@ func (v *KeyValue_GetValue_Args) Equals(rhs *KeyValue_GetValue_Args) bool {
 	return true
 }
 
+func (v *KeyValue_GetValue_Args) GetKey() (o Key) {
+	if v != nil && v.Key != nil {
+		return *v.Key
+	}
+	return
+}
+
 func (v *KeyValue_GetValue_Args) MethodName() string {
 	return "getValue"
 }
@@ -81,16 +93,29 @@ func (v *KeyValue_GetValue_Args) EnvelopeType() wire.EnvelopeType {
 }
 
 var KeyValue_GetValue_Helper = struct {
-	Args           func(key *Key) *KeyValue_GetValue_Args
-	IsException    func(error) bool
-	WrapResponse   func(*unions.ArbitraryValue, error) (*KeyValue_GetValue_Result, error)
-	UnwrapResponse func(*KeyValue_GetValue_Result) (*unions.ArbitraryValue, error)
+	Args            func(key *Key) *KeyValue_GetValue_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*KeyValue_GetValue_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func(*unions.ArbitraryValue, error) (*KeyValue_GetValue_Result, error)
+	UnwrapResponse  func(*KeyValue_GetValue_Result) (*unions.ArbitraryValue, error)
 }{}
 
 func init() {
 	KeyValue_GetValue_Helper.Args = func(key *Key) *KeyValue_GetValue_Args {
 		return &KeyValue_GetValue_Args{Key: key}
 	}
+	KeyValue_GetValue_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*KeyValue_GetValue_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args KeyValue_GetValue_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
 	KeyValue_GetValue_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		case *exceptions.DoesNotExistException:
@@ -99,6 +124,14 @@ func init() {
 			return false
 		}
 	}
+	KeyValue_GetValue_Helper.IsSafeException = func(err error) bool {
+		switch err.(type) {
+		case *exceptions.DoesNotExistException:
+			return true
+		default:
+			return false
+		}
+	}
 	KeyValue_GetValue_Helper.WrapResponse = func(success *unions.ArbitraryValue, err error) (*KeyValue_GetValue_Result, error) {
 		if err == nil {
 			return &KeyValue_GetValue_Result{Success: success}, nil
@@ -126,6 +159,22 @@ func init() {
 	}
 }
 
+type KeyValue_GetValue_ArgsOption func(*KeyValue_GetValue_Args)
+
+func KeyValue_GetValue_WithKey(v Key) KeyValue_GetValue_ArgsOption {
+	return func(args *KeyValue_GetValue_Args) {
+		args.Key = &v
+	}
+}
+
+func KeyValue_GetValue_NewArgs(opts ...KeyValue_GetValue_ArgsOption) *KeyValue_GetValue_Args {
+	args := &KeyValue_GetValue_Args{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	return args
+}
+
 type KeyValue_GetValue_Result struct {
 	Success      *unions.ArbitraryValue            `json:"success,omitempty"`
 	DoesNotExist *exceptions.DoesNotExistException `json:"doesNotExist,omitempty"`
@@ -168,6 +217,7 @@ func (v *KeyValue_GetValue_Result) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.Success, err = _ArbitraryValue_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("KeyValue_GetValue_Result", "Success", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -175,6 +225,7 @@ func (v *KeyValue_GetValue_Result) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.DoesNotExist, err = _DoesNotExistException_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("KeyValue_GetValue_Result", "DoesNotExist", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -188,6 +239,7 @@ func (v *KeyValue_GetValue_Result) FromWire(w wire.Value) error {
 		count++
 	}
 	if count != 1 {
+		wire.ObserveDecodeError("KeyValue_GetValue_Result", "", wire.DecodeErrorInvalidUnion)
 		return fmt.Errorf("KeyValue_GetValue_Result should have exactly one field: got %v fields", count)
 	}
 	return nil
@@ -220,6 +272,53 @@ func (v *KeyValue_GetValue_Result) Equals(rhs *KeyValue_GetValue_Result) bool {
 	return true
 }
 
+func (v *KeyValue_GetValue_Result) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.DoesNotExist != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("KeyValue_GetValue_Result should have exactly one field: got %v fields", count)
+	}
+	type plain KeyValue_GetValue_Result
+	return json.Marshal((*plain)(v))
+}
+
+func (v *KeyValue_GetValue_Result) UnmarshalJSON(text []byte) error {
+	type plain KeyValue_GetValue_Result
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.DoesNotExist != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("KeyValue_GetValue_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *KeyValue_GetValue_Result) GetSuccess() (o *unions.ArbitraryValue) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+	return
+}
+
+func (v *KeyValue_GetValue_Result) GetDoesNotExist() (o *exceptions.DoesNotExistException) {
+	if v != nil && v.DoesNotExist != nil {
+		return v.DoesNotExist
+	}
+	return
+}
+
 func (v *KeyValue_GetValue_Result) MethodName() string {
 	return "getValue"
 }
@@ -227,3 +326,21 @@ func (v *KeyValue_GetValue_Result) MethodName() string {
 func (v *KeyValue_GetValue_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
+
+func KeyValue_GetValue_NewResultSuccess(success *unions.ArbitraryValue) *KeyValue_GetValue_Result {
+	return &KeyValue_GetValue_Result{Success: success}
+}
+
+func KeyValue_GetValue_NewResultError(err error) (*KeyValue_GetValue_Result, error) {
+	if err == nil {
+		return nil, errors.New("KeyValue_GetValue_NewResultError received a nil error")
+	}
+	switch e := err.(type) {
+	case *exceptions.DoesNotExistException:
+		if e == nil {
+			return nil, errors.New("KeyValue_GetValue_NewResultError received non-nil error type with nil value for KeyValue_GetValue_Result.DoesNotExist")
+		}
+		return &KeyValue_GetValue_Result{DoesNotExist: e}, nil
+	}
+	return nil, err
+}
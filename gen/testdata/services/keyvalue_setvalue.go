@@ -4,12 +4,16 @@
 package services
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"go.uber.org/thriftrw/gen/testdata/unions"
+	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/wire"
 	"strings"
 )
 
+// void and no exceptions
 type KeyValue_SetValue_Args struct {
 	Key   *Key                   `json:"key,omitempty"`
 	Value *unions.ArbitraryValue `json:"value,omitempty"`
@@ -51,6 +55,7 @@ func (v *KeyValue_SetValue_Args) FromWire(w wire.Value) error {
 				x, err = _Key_Read(field.Value)
 				v.Key = &x
 				if err != nil {
+					wire.ObserveDecodeError("KeyValue_SetValue_Args", "Key", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -58,6 +63,7 @@ func (v *KeyValue_SetValue_Args) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.Value, err = _ArbitraryValue_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("KeyValue_SetValue_Args", "Value", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -93,6 +99,20 @@ func (v *KeyValue_SetValue_Args) Equals(rhs *KeyValue_SetValue_Args) bool {
 	return true
 }
 
+func (v *KeyValue_SetValue_Args) GetKey() (o Key) {
+	if v != nil && v.Key != nil {
+		return *v.Key
+	}
+	return
+}
+
+func (v *KeyValue_SetValue_Args) GetValue() (o *unions.ArbitraryValue) {
+	if v != nil && v.Value != nil {
+		return v.Value
+	}
+	return
+}
+
 func (v *KeyValue_SetValue_Args) MethodName() string {
 	return "setValue"
 }
@@ -102,22 +122,38 @@ func (v *KeyValue_SetValue_Args) EnvelopeType() wire.EnvelopeType {
 }
 
 var KeyValue_SetValue_Helper = struct {
-	Args           func(key *Key, value *unions.ArbitraryValue) *KeyValue_SetValue_Args
-	IsException    func(error) bool
-	WrapResponse   func(error) (*KeyValue_SetValue_Result, error)
-	UnwrapResponse func(*KeyValue_SetValue_Result) error
+	Args            func(key *Key, value *unions.ArbitraryValue) *KeyValue_SetValue_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*KeyValue_SetValue_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func(error) (*KeyValue_SetValue_Result, error)
+	UnwrapResponse  func(*KeyValue_SetValue_Result) error
 }{}
 
 func init() {
 	KeyValue_SetValue_Helper.Args = func(key *Key, value *unions.ArbitraryValue) *KeyValue_SetValue_Args {
 		return &KeyValue_SetValue_Args{Key: key, Value: value}
 	}
+	KeyValue_SetValue_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*KeyValue_SetValue_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args KeyValue_SetValue_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
 	KeyValue_SetValue_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		default:
 			return false
 		}
 	}
+	KeyValue_SetValue_Helper.IsSafeException = func(error) bool {
+		return false
+	}
 	KeyValue_SetValue_Helper.WrapResponse = func(err error) (*KeyValue_SetValue_Result, error) {
 		if err == nil {
 			return &KeyValue_SetValue_Result{}, nil
@@ -129,6 +165,28 @@ func init() {
 	}
 }
 
+type KeyValue_SetValue_ArgsOption func(*KeyValue_SetValue_Args)
+
+func KeyValue_SetValue_WithKey(v Key) KeyValue_SetValue_ArgsOption {
+	return func(args *KeyValue_SetValue_Args) {
+		args.Key = &v
+	}
+}
+
+func KeyValue_SetValue_WithValue(v *unions.ArbitraryValue) KeyValue_SetValue_ArgsOption {
+	return func(args *KeyValue_SetValue_Args) {
+		args.Value = v
+	}
+}
+
+func KeyValue_SetValue_NewArgs(opts ...KeyValue_SetValue_ArgsOption) *KeyValue_SetValue_Args {
+	args := &KeyValue_SetValue_Args{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	return args
+}
+
 type KeyValue_SetValue_Result struct{}
 
 func (v *KeyValue_SetValue_Result) ToWire() (wire.Value, error) {
@@ -167,3 +225,14 @@ func (v *KeyValue_SetValue_Result) MethodName() string {
 func (v *KeyValue_SetValue_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
+
+func KeyValue_SetValue_NewResultSuccess() *KeyValue_SetValue_Result {
+	return &KeyValue_SetValue_Result{}
+}
+
+func KeyValue_SetValue_NewResultError(err error) (*KeyValue_SetValue_Result, error) {
+	if err == nil {
+		return nil, errors.New("KeyValue_SetValue_NewResultError received a nil error")
+	}
+	return nil, err
+}
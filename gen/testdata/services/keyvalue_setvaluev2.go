@@ -4,9 +4,11 @@
 package services
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"go.uber.org/thriftrw/gen/testdata/unions"
+	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/wire"
 	"strings"
 )
@@ -30,7 +32,7 @@ func (v *KeyValue_SetValueV2_Args) ToWire() (wire.Value, error) {
 	fields[i] = wire.Field{ID: 1, Value: w}
 	i++
 	if v.Value == nil {
-		return w, errors.New("field Value of KeyValue_SetValueV2_Args is required")
+		return w, wire.NilRequiredFieldError{Struct: "KeyValue_SetValueV2_Args", Field: "Value", ID: 2}
 	}
 	w, err = v.Value.ToWire()
 	if err != nil {
@@ -51,6 +53,7 @@ func (v *KeyValue_SetValueV2_Args) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.Key, err = _Key_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("KeyValue_SetValueV2_Args", "Key", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				keyIsSet = true
@@ -59,6 +62,7 @@ func (v *KeyValue_SetValueV2_Args) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.Value, err = _ArbitraryValue_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("KeyValue_SetValueV2_Args", "Value", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				valueIsSet = true
@@ -66,10 +70,12 @@ func (v *KeyValue_SetValueV2_Args) FromWire(w wire.Value) error {
 		}
 	}
 	if !keyIsSet {
-		return errors.New("field Key of KeyValue_SetValueV2_Args is required")
+		wire.ObserveDecodeError("KeyValue_SetValueV2_Args", "Key", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "KeyValue_SetValueV2_Args", Field: "Key", ID: 1}
 	}
 	if !valueIsSet {
-		return errors.New("field Value of KeyValue_SetValueV2_Args is required")
+		wire.ObserveDecodeError("KeyValue_SetValueV2_Args", "Value", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "KeyValue_SetValueV2_Args", Field: "Value", ID: 2}
 	}
 	return nil
 }
@@ -97,6 +103,20 @@ func (v *KeyValue_SetValueV2_Args) Equals(rhs *KeyValue_SetValueV2_Args) bool {
 	return true
 }
 
+func (v *KeyValue_SetValueV2_Args) GetKey() (o Key) {
+	if v != nil {
+		o = v.Key
+	}
+	return
+}
+
+func (v *KeyValue_SetValueV2_Args) GetValue() (o *unions.ArbitraryValue) {
+	if v != nil {
+		o = v.Value
+	}
+	return
+}
+
 func (v *KeyValue_SetValueV2_Args) MethodName() string {
 	return "setValueV2"
 }
@@ -106,22 +126,41 @@ func (v *KeyValue_SetValueV2_Args) EnvelopeType() wire.EnvelopeType {
 }
 
 var KeyValue_SetValueV2_Helper = struct {
-	Args           func(key Key, value *unions.ArbitraryValue) *KeyValue_SetValueV2_Args
-	IsException    func(error) bool
-	WrapResponse   func(error) (*KeyValue_SetValueV2_Result, error)
-	UnwrapResponse func(*KeyValue_SetValueV2_Result) error
+	Args            func(key Key, value *unions.ArbitraryValue) *KeyValue_SetValueV2_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*KeyValue_SetValueV2_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func(error) (*KeyValue_SetValueV2_Result, error)
+	UnwrapResponse  func(*KeyValue_SetValueV2_Result) error
 }{}
 
 func init() {
 	KeyValue_SetValueV2_Helper.Args = func(key Key, value *unions.ArbitraryValue) *KeyValue_SetValueV2_Args {
 		return &KeyValue_SetValueV2_Args{Key: key, Value: value}
 	}
+	KeyValue_SetValueV2_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*KeyValue_SetValueV2_Args, error) {
+		if int64(len(body)) > 1048576 {
+			return nil, protocol.SizeLimitError{Method: "setValueV2", Size: int64(len(body)), Limit: 1048576}
+		}
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args KeyValue_SetValueV2_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
 	KeyValue_SetValueV2_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		default:
 			return false
 		}
 	}
+	KeyValue_SetValueV2_Helper.IsSafeException = func(error) bool {
+		return false
+	}
 	KeyValue_SetValueV2_Helper.WrapResponse = func(err error) (*KeyValue_SetValueV2_Result, error) {
 		if err == nil {
 			return &KeyValue_SetValueV2_Result{}, nil
@@ -171,3 +210,14 @@ func (v *KeyValue_SetValueV2_Result) MethodName() string {
 func (v *KeyValue_SetValueV2_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
+
+func KeyValue_SetValueV2_NewResultSuccess() *KeyValue_SetValueV2_Result {
+	return &KeyValue_SetValueV2_Result{}
+}
+
+func KeyValue_SetValueV2_NewResultError(err error) (*KeyValue_SetValueV2_Result, error) {
+	if err == nil {
+		return nil, errors.New("KeyValue_SetValueV2_NewResultError received a nil error")
+	}
+	return nil, err
+}
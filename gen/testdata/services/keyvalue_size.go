@@ -4,8 +4,11 @@
 package services
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/wire"
 	"strings"
 )
@@ -50,22 +53,38 @@ func (v *KeyValue_Size_Args) EnvelopeType() wire.EnvelopeType {
 }
 
 var KeyValue_Size_Helper = struct {
-	Args           func() *KeyValue_Size_Args
-	IsException    func(error) bool
-	WrapResponse   func(int64, error) (*KeyValue_Size_Result, error)
-	UnwrapResponse func(*KeyValue_Size_Result) (int64, error)
+	Args            func() *KeyValue_Size_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*KeyValue_Size_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func(int64, error) (*KeyValue_Size_Result, error)
+	UnwrapResponse  func(*KeyValue_Size_Result) (int64, error)
 }{}
 
 func init() {
 	KeyValue_Size_Helper.Args = func() *KeyValue_Size_Args {
 		return &KeyValue_Size_Args{}
 	}
+	KeyValue_Size_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*KeyValue_Size_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args KeyValue_Size_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
 	KeyValue_Size_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		default:
 			return false
 		}
 	}
+	KeyValue_Size_Helper.IsSafeException = func(error) bool {
+		return false
+	}
 	KeyValue_Size_Helper.WrapResponse = func(success int64, err error) (*KeyValue_Size_Result, error) {
 		if err == nil {
 			return &KeyValue_Size_Result{Success: &success}, nil
@@ -117,6 +136,7 @@ func (v *KeyValue_Size_Result) FromWire(w wire.Value) error {
 				x, err = field.Value.GetI64(), error(nil)
 				v.Success = &x
 				if err != nil {
+					wire.ObserveDecodeError("KeyValue_Size_Result", "Success", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -127,6 +147,7 @@ func (v *KeyValue_Size_Result) FromWire(w wire.Value) error {
 		count++
 	}
 	if count != 1 {
+		wire.ObserveDecodeError("KeyValue_Size_Result", "", wire.DecodeErrorInvalidUnion)
 		return fmt.Errorf("KeyValue_Size_Result should have exactly one field: got %v fields", count)
 	}
 	return nil
@@ -152,6 +173,40 @@ func (v *KeyValue_Size_Result) Equals(rhs *KeyValue_Size_Result) bool {
 	return true
 }
 
+func (v *KeyValue_Size_Result) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("KeyValue_Size_Result should have exactly one field: got %v fields", count)
+	}
+	type plain KeyValue_Size_Result
+	return json.Marshal((*plain)(v))
+}
+
+func (v *KeyValue_Size_Result) UnmarshalJSON(text []byte) error {
+	type plain KeyValue_Size_Result
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("KeyValue_Size_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *KeyValue_Size_Result) GetSuccess() (o int64) {
+	if v != nil && v.Success != nil {
+		return *v.Success
+	}
+	return
+}
+
 func (v *KeyValue_Size_Result) MethodName() string {
 	return "size"
 }
@@ -159,3 +214,14 @@ func (v *KeyValue_Size_Result) MethodName() string {
 func (v *KeyValue_Size_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
+
+func KeyValue_Size_NewResultSuccess(success int64) *KeyValue_Size_Result {
+	return &KeyValue_Size_Result{Success: &success}
+}
+
+func KeyValue_Size_NewResultError(err error) (*KeyValue_Size_Result, error) {
+	if err == nil {
+		return nil, errors.New("KeyValue_Size_NewResultError received a nil error")
+	}
+	return nil, err
+}
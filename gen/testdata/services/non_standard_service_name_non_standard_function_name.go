@@ -4,7 +4,10 @@
 package services
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/wire"
 	"strings"
 )
@@ -49,22 +52,38 @@ func (v *NonStandardServiceName_NonStandardFunctionName_Args) EnvelopeType() wir
 }
 
 var NonStandardServiceName_NonStandardFunctionName_Helper = struct {
-	Args           func() *NonStandardServiceName_NonStandardFunctionName_Args
-	IsException    func(error) bool
-	WrapResponse   func(error) (*NonStandardServiceName_NonStandardFunctionName_Result, error)
-	UnwrapResponse func(*NonStandardServiceName_NonStandardFunctionName_Result) error
+	Args            func() *NonStandardServiceName_NonStandardFunctionName_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*NonStandardServiceName_NonStandardFunctionName_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func(error) (*NonStandardServiceName_NonStandardFunctionName_Result, error)
+	UnwrapResponse  func(*NonStandardServiceName_NonStandardFunctionName_Result) error
 }{}
 
 func init() {
 	NonStandardServiceName_NonStandardFunctionName_Helper.Args = func() *NonStandardServiceName_NonStandardFunctionName_Args {
 		return &NonStandardServiceName_NonStandardFunctionName_Args{}
 	}
+	NonStandardServiceName_NonStandardFunctionName_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*NonStandardServiceName_NonStandardFunctionName_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args NonStandardServiceName_NonStandardFunctionName_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
 	NonStandardServiceName_NonStandardFunctionName_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		default:
 			return false
 		}
 	}
+	NonStandardServiceName_NonStandardFunctionName_Helper.IsSafeException = func(error) bool {
+		return false
+	}
 	NonStandardServiceName_NonStandardFunctionName_Helper.WrapResponse = func(err error) (*NonStandardServiceName_NonStandardFunctionName_Result, error) {
 		if err == nil {
 			return &NonStandardServiceName_NonStandardFunctionName_Result{}, nil
@@ -114,3 +133,14 @@ func (v *NonStandardServiceName_NonStandardFunctionName_Result) MethodName() str
 func (v *NonStandardServiceName_NonStandardFunctionName_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
+
+func NonStandardServiceName_NonStandardFunctionName_NewResultSuccess() *NonStandardServiceName_NonStandardFunctionName_Result {
+	return &NonStandardServiceName_NonStandardFunctionName_Result{}
+}
+
+func NonStandardServiceName_NonStandardFunctionName_NewResultError(err error) (*NonStandardServiceName_NonStandardFunctionName_Result, error) {
+	if err == nil {
+		return nil, errors.New("NonStandardServiceName_NonStandardFunctionName_NewResultError received a nil error")
+	}
+	return nil, err
+}
@@ -5,7 +5,6 @@ package services
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"go.uber.org/thriftrw/wire"
 	"strings"
@@ -30,7 +29,7 @@ func (v *ConflictingNamesSetValueArgs) ToWire() (wire.Value, error) {
 	fields[i] = wire.Field{ID: 1, Value: w}
 	i++
 	if v.Value == nil {
-		return w, errors.New("field Value of ConflictingNamesSetValueArgs is required")
+		return w, wire.NilRequiredFieldError{Struct: "ConflictingNamesSetValueArgs", Field: "Value", ID: 2}
 	}
 	w, err = wire.NewValueBinary(v.Value), error(nil)
 	if err != nil {
@@ -51,6 +50,7 @@ func (v *ConflictingNamesSetValueArgs) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.Key, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("ConflictingNamesSetValueArgs", "Key", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				keyIsSet = true
@@ -59,6 +59,7 @@ func (v *ConflictingNamesSetValueArgs) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.Value, err = field.Value.GetBinary(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("ConflictingNamesSetValueArgs", "Value", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				valueIsSet = true
@@ -66,10 +67,12 @@ func (v *ConflictingNamesSetValueArgs) FromWire(w wire.Value) error {
 		}
 	}
 	if !keyIsSet {
-		return errors.New("field Key of ConflictingNamesSetValueArgs is required")
+		wire.ObserveDecodeError("ConflictingNamesSetValueArgs", "Key", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "ConflictingNamesSetValueArgs", Field: "Key", ID: 1}
 	}
 	if !valueIsSet {
-		return errors.New("field Value of ConflictingNamesSetValueArgs is required")
+		wire.ObserveDecodeError("ConflictingNamesSetValueArgs", "Value", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "ConflictingNamesSetValueArgs", Field: "Value", ID: 2}
 	}
 	return nil
 }
@@ -97,6 +100,20 @@ func (v *ConflictingNamesSetValueArgs) Equals(rhs *ConflictingNamesSetValueArgs)
 	return true
 }
 
+func (v *ConflictingNamesSetValueArgs) GetKey() (o string) {
+	if v != nil {
+		o = v.Key
+	}
+	return
+}
+
+func (v *ConflictingNamesSetValueArgs) GetValue() (o []byte) {
+	if v != nil {
+		o = v.Value
+	}
+	return
+}
+
 type InternalError struct {
 	Message *string `json:"message,omitempty"`
 }
@@ -129,6 +146,7 @@ func (v *InternalError) FromWire(w wire.Value) error {
 				x, err = field.Value.GetString(), error(nil)
 				v.Message = &x
 				if err != nil {
+					wire.ObserveDecodeError("InternalError", "Message", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -166,10 +184,21 @@ func (v *InternalError) Equals(rhs *InternalError) bool {
 	return true
 }
 
+func (v *InternalError) GetMessage() (o string) {
+	if v != nil && v.Message != nil {
+		return *v.Message
+	}
+	return
+}
+
 func (v *InternalError) Error() string {
 	return v.String()
 }
 
+func (v *InternalError) Safe() bool {
+	return false
+}
+
 type Key string
 
 func (v Key) ToWire() (wire.Value, error) {
@@ -188,6 +217,15 @@ func (v *Key) FromWire(w wire.Value) error {
 	return err
 }
 
+func (v Key) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+func (v *Key) UnmarshalText(text []byte) error {
+	*v = Key(text)
+	return nil
+}
+
 func (lhs Key) Equals(rhs Key) bool {
 	return (lhs == rhs)
 }
@@ -8,6 +8,6 @@ import (
 	"go.uber.org/thriftrw/thriftreflect"
 )
 
-var ThriftModule = &thriftreflect.ThriftModule{Name: "structs", Package: "go.uber.org/thriftrw/gen/testdata/structs", FilePath: "structs.thrift", SHA1: "d019aed07c659d5f052a1a1992e658e0c6851597", Includes: []*thriftreflect.ThriftModule{enums.ThriftModule}, Raw: rawIDL}
+var ThriftModule = &thriftreflect.ThriftModule{Name: "structs", Package: "go.uber.org/thriftrw/gen/testdata/structs", FilePath: "structs.thrift", SHA1: "784152f5070a07820f3e48c027b8df9596b10b96", Includes: []*thriftreflect.ThriftModule{enums.ThriftModule}, Raw: rawIDL}
 
-const rawIDL = "include \"./enums.thrift\"\n\nstruct EmptyStruct {}\n\n//////////////////////////////////////////////////////////////////////////////\n// Structs with primitives\n\nstruct PrimitiveRequiredStruct {\n    1: required bool boolField\n    2: required byte byteField\n    3: required i16 int16Field\n    4: required i32 int32Field\n    5: required i64 int64Field\n    6: required double doubleField\n    7: required string stringField\n    8: required binary binaryField\n}\n\nstruct PrimitiveOptionalStruct {\n    1: optional bool boolField\n    2: optional byte byteField\n    3: optional i16 int16Field\n    4: optional i32 int32Field\n    5: optional i64 int64Field\n    6: optional double doubleField\n    7: optional string stringField\n    8: optional binary binaryField\n}\n\n//////////////////////////////////////////////////////////////////////////////\n// Nested structs (Required)\n\nstruct Point {\n    1: required double x\n    2: required double y\n}\n\nstruct Size {\n    1: required double width\n    2: required double height\n}\n\nstruct Frame {\n    1: required Point topLeft\n    2: required Size size\n}\n\nstruct Edge {\n    1: required Point startPoint\n    2: required Point endPoint\n}\n\nstruct Graph {\n    1: required list<Edge> edges\n}\n\n//////////////////////////////////////////////////////////////////////////////\n// Nested structs (Optional)\n\nstruct ContactInfo {\n    1: required string emailAddress\n}\n\nstruct User {\n    1: required string name\n    2: optional ContactInfo contact\n}\n\n//////////////////////////////////////////////////////////////////////////////\n// self-referential struct\n\ntypedef Node List\n\nstruct Node {\n    1: required i32 value\n    2: optional List tail\n}\n\n\n//////////////////////////////////////////////////////////////////////////////\n// Default values\n\nstruct DefaultsStruct {\n    1: required i32 requiredPrimitive = 100\n    2: optional i32 optionalPrimitive = 200\n\n    3: required enums.EnumDefault requiredEnum = enums.EnumDefault.Bar\n    4: optional enums.EnumDefault optionalEnum = 2\n\n    5: required list<string> requiredList = [\"hello\", \"world\"]\n    6: optional list<double> optionalList = [1, 2.0, 3]\n\n    7: required Frame requiredStruct = {\n        \"topLeft\": {\"x\": 1, \"y\": 2},\n        \"size\": {\"width\": 100, \"height\": 200},\n    }\n    8: optional Edge optionalStruct = {\n        \"startPoint\": {\"x\": 1, \"y\": 2},\n        \"endPoint\":   {\"x\": 3, \"y\": 4},\n    }\n}\n"
+const rawIDL = "include \"./enums.thrift\"\n\nstruct EmptyStruct {}\n\n//////////////////////////////////////////////////////////////////////////////\n// Structs with primitives\n\nstruct PrimitiveRequiredStruct {\n    1: required bool boolField\n    2: required byte byteField\n    3: required i16 int16Field\n    4: required i32 int32Field\n    5: required i64 int64Field\n    6: required double doubleField\n    7: required string stringField\n    8: required binary binaryField\n}\n\nstruct PrimitiveOptionalStruct {\n    1: optional bool boolField\n    2: optional byte byteField\n    3: optional i16 int16Field\n    4: optional i32 int32Field\n    5: optional i64 int64Field\n    6: optional double doubleField\n    7: optional string stringField\n    8: optional binary binaryField\n}\n\n//////////////////////////////////////////////////////////////////////////////\n// Nested structs (Required)\n\nstruct Point {\n    1: required double x\n    2: required double y\n}\n\nstruct Size {\n    1: required double width\n    2: required double height\n}\n\nstruct Frame {\n    1: required Point topLeft\n    2: required Size size\n}\n\nstruct Edge {\n    1: required Point startPoint\n    2: required Point endPoint\n}\n\nstruct Graph {\n    1: required list<Edge> edges\n}\n\n//////////////////////////////////////////////////////////////////////////////\n// Nested structs (Optional)\n\nstruct ContactInfo {\n    1: required string emailAddress\n}\n\nstruct User {\n    1: required string name\n    2: optional ContactInfo contact\n}\n\n//////////////////////////////////////////////////////////////////////////////\n// self-referential struct\n\ntypedef Node List\n\nstruct Node {\n    1: required i32 value\n    2: optional List tail\n}\n\n// self-referential through containers\nstruct Tree {\n    1: required string value\n    2: optional Tree left\n    3: optional Tree right\n    4: optional list<Tree> children\n    5: optional map<string, Tree> named\n}\n\n//////////////////////////////////////////////////////////////////////////////\n// go.implements\n\nstruct Failure {\n    1: required string reason\n} (go.implements = \"fmt.Stringer; error\")\n\n//////////////////////////////////////////////////////////////////////////////\n// normalize\n\nstruct NormalizedUser {\n    1: required string name (normalize = \"trim\")\n    2: optional string email (normalize = \"trim, lower\")\n    3: optional string countryCode (normalize = \"upper,trim\")\n    4: optional string bio\n}\n\n//////////////////////////////////////////////////////////////////////////////\n// validate\n\nstruct ValidatedUser {\n    1: required string name (validate.min = \"1\", validate.max = \"16\")\n    2: optional i32 age (validate.min = \"0\", validate.max = \"150\")\n    3: optional string email (validate.pattern = \"^[^@\\\\s]+@[^@\\\\s]+$\")\n    4: optional double score (validate.min = \"-1\", validate.max = \"1.5\")\n    5: optional list<string> tags (validate.max = \"2\")\n    6: optional ValidatedUser manager\n    7: optional list<ValidatedUser> reports\n    8: optional map<string, ValidatedUser> byName\n}\n\n//////////////////////////////////////////////////////////////////////////////\n// headers\n\nstruct Request {\n    1: optional map<string, binary> headers (\n        headers = \"true\",\n        headers.Deadline = \"x-deadline:i64\",\n        headers.Caller = \"x-caller:string\",\n        headers.Traced = \"x-traced:bool\",\n        headers.Token = \"x-token\",\n    )\n    2: optional string body\n}\n\nstruct TextHeaders {\n    1: required map<string, string> values (\n        headers = \"true\",\n        headers.Priority = \"priority:i32\",\n        headers.Weight = \"weight:double\",\n        headers.Raw = \"raw:binary\",\n    )\n}\n\n//////////////////////////////////////////////////////////////////////////////\n// streams\n\nstruct PointStream {\n    1: required list<Point> points (go.stream = \"true\")\n    2: optional map<string, i32> counts (go.stream = \"true\")\n    3: optional set<string> tags (go.stream = \"true\")\n    4: optional string name\n}\n\n//////////////////////////////////////////////////////////////////////////////\n// conversions\n\nstruct UserV1 {\n    1: required string name\n    2: optional i32 age\n    3: optional string email\n    4: required i64 id\n    5: optional list<string> nicknames\n    7: required bool active\n}\n\nstruct UserV2 {\n    1: required string name\n    2: required i32 age\n    3: optional binary email\n    4: optional i64 id\n    5: optional list<string> nicknames\n    6: optional list<string> tags\n    7: optional bool active\n} (go.convert_from = \"UserV1\")\n\nstruct UserV3 {\n    1: required string name\n    5: optional list<string> nicknames\n} (go.convert_from = \"UserV1, UserV2\")\n\n\n//////////////////////////////////////////////////////////////////////////////\n// Default values\n\nstruct DefaultsStruct {\n    1: required i32 requiredPrimitive = 100\n    2: optional i32 optionalPrimitive = 200\n\n    3: required enums.EnumDefault requiredEnum = enums.EnumDefault.Bar\n    4: optional enums.EnumDefault optionalEnum = 2\n\n    5: required list<string> requiredList = [\"hello\", \"world\"]\n    6: optional list<double> optionalList = [1, 2.0, 3]\n\n    7: required Frame requiredStruct = {\n        \"topLeft\": {\"x\": 1, \"y\": 2},\n        \"size\": {\"width\": 100, \"height\": 200},\n    }\n    8: optional Edge optionalStruct = {\n        \"startPoint\": {\"x\": 1, \"y\": 2},\n        \"endPoint\":   {\"x\": 3, \"y\": 4},\n    }\n}\n\nstruct UUIDStruct {\n    1: required uuid id\n    2: optional uuid parentID\n    3: optional list<uuid> children\n    4: optional set<uuid> tags\n    5: optional map<uuid, string> names\n    6: optional uuid defaultID = \"123e4567-e89b-12d3-a456-426614174000\"\n    // uuid is not a keyword so it may still be used as a field name.\n    7: optional string uuid\n}\n\n//////////////////////////////////////////////////////////////////////////////\n// value fields\n\nstruct ValueFields {\n    1: optional i32 count (go.pointer = \"false\")\n    2: optional string name (go.pointer = \"false\", normalize = \"trim\")\n    3: optional bool enabled (go.pointer = \"false\")\n    4: optional enums.EnumDefault kind (go.pointer = \"false\")\n    5: optional i32 limit = 100 (go.pointer = \"false\")\n    6: optional uuid id (go.pointer = \"false\")\n    7: optional i64 pointer\n}\n\n//////////////////////////////////////////////////////////////////////////////\n// builders\n\nstruct BuiltUser {\n    1: required string name (validate.min = \"1\")\n    2: optional string email\n    3: optional list<string> tags\n    4: optional i32 age (go.pointer = \"false\")\n    5: optional BuiltUser manager\n    6: required Point location\n    7: optional bool active = true\n} (go.builder = \"true\")\n"
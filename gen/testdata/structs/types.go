@@ -5,86 +5,26 @@ package structs
 
 import (
 	"bytes"
-	"errors"
+	"encoding/json"
 	"fmt"
 	"go.uber.org/thriftrw/gen/testdata/enums"
 	"go.uber.org/thriftrw/ptr"
+	"go.uber.org/thriftrw/uuid"
 	"go.uber.org/thriftrw/wire"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
-type ContactInfo struct {
-	EmailAddress string `json:"emailAddress"`
-}
-
-func (v *ContactInfo) ToWire() (wire.Value, error) {
-	var (
-		fields [1]wire.Field
-		i      int = 0
-		w      wire.Value
-		err    error
-	)
-	w, err = wire.NewValueString(v.EmailAddress), error(nil)
-	if err != nil {
-		return w, err
-	}
-	fields[i] = wire.Field{ID: 1, Value: w}
-	i++
-	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
-}
-
-func (v *ContactInfo) FromWire(w wire.Value) error {
-	var err error
-	emailAddressIsSet := false
-	for _, field := range w.GetStruct().Fields {
-		switch field.ID {
-		case 1:
-			if field.Value.Type() == wire.TBinary {
-				v.EmailAddress, err = field.Value.GetString(), error(nil)
-				if err != nil {
-					return err
-				}
-				emailAddressIsSet = true
-			}
-		}
-	}
-	if !emailAddressIsSet {
-		return errors.New("field EmailAddress of ContactInfo is required")
-	}
-	return nil
-}
-
-func (v *ContactInfo) String() string {
-	if v == nil {
-		return "<nil>"
-	}
-	var fields [1]string
-	i := 0
-	fields[i] = fmt.Sprintf("EmailAddress: %v", v.EmailAddress)
-	i++
-	return fmt.Sprintf("ContactInfo{%v}", strings.Join(fields[:i], ", "))
-}
-
-func (v *ContactInfo) Equals(rhs *ContactInfo) bool {
-	if !(v.EmailAddress == rhs.EmailAddress) {
-		return false
-	}
-	return true
-}
-
-type DefaultsStruct struct {
-	RequiredPrimitive *int32             `json:"requiredPrimitive,omitempty"`
-	OptionalPrimitive *int32             `json:"optionalPrimitive,omitempty"`
-	RequiredEnum      *enums.EnumDefault `json:"requiredEnum,omitempty"`
-	OptionalEnum      *enums.EnumDefault `json:"optionalEnum,omitempty"`
-	RequiredList      []string           `json:"requiredList"`
-	OptionalList      []float64          `json:"optionalList"`
-	RequiredStruct    *Frame             `json:"requiredStruct,omitempty"`
-	OptionalStruct    *Edge              `json:"optionalStruct,omitempty"`
-}
-
-func _EnumDefault_ptr(v enums.EnumDefault) *enums.EnumDefault {
-	return &v
+type BuiltUser struct {
+	Name     string     `json:"name"`
+	Email    *string    `json:"email,omitempty"`
+	Tags     []string   `json:"tags"`
+	Age      int32      `json:"age,omitempty"`
+	Manager  *BuiltUser `json:"manager,omitempty"`
+	Location *Point     `json:"location"`
+	Active   *bool      `json:"active,omitempty"`
+	isSetAge bool
 }
 
 type _List_String_ValueList []string
@@ -114,137 +54,74 @@ func (_List_String_ValueList) ValueType() wire.Type {
 func (_List_String_ValueList) Close() {
 }
 
-type _List_Double_ValueList []float64
-
-func (v _List_Double_ValueList) ForEach(f func(wire.Value) error) error {
-	for _, x := range v {
-		w, err := wire.NewValueDouble(x), error(nil)
-		if err != nil {
-			return err
-		}
-		err = f(w)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (v _List_Double_ValueList) Size() int {
-	return len(v)
-}
-
-func (_List_Double_ValueList) ValueType() wire.Type {
-	return wire.TDouble
-}
-
-func (_List_Double_ValueList) Close() {
-}
-
-func (v *DefaultsStruct) ToWire() (wire.Value, error) {
+func (v *BuiltUser) ToWire() (wire.Value, error) {
 	var (
-		fields [8]wire.Field
+		fields [7]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
-	if v.RequiredPrimitive == nil {
-		v.RequiredPrimitive = ptr.Int32(100)
-	}
-	{
-		w, err = wire.NewValueI32(*(v.RequiredPrimitive)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 1, Value: w}
-		i++
-	}
-	if v.OptionalPrimitive == nil {
-		v.OptionalPrimitive = ptr.Int32(200)
+	w, err = wire.NewValueString(v.Name), error(nil)
+	if err != nil {
+		return w, err
 	}
-	{
-		w, err = wire.NewValueI32(*(v.OptionalPrimitive)), error(nil)
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.Email != nil {
+		w, err = wire.NewValueString(*(v.Email)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 2, Value: w}
 		i++
 	}
-	if v.RequiredEnum == nil {
-		v.RequiredEnum = _EnumDefault_ptr(enums.EnumDefaultBar)
-	}
-	{
-		w, err = v.RequiredEnum.ToWire()
+	if v.Tags != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.Tags)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 3, Value: w}
 		i++
 	}
-	if v.OptionalEnum == nil {
-		v.OptionalEnum = _EnumDefault_ptr(enums.EnumDefaultBaz)
-	}
-	{
-		w, err = v.OptionalEnum.ToWire()
+	if v.isSetAge || v.Age != 0 {
+		w, err = wire.NewValueI32(v.Age), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 4, Value: w}
 		i++
 	}
-	if v.RequiredList == nil {
-		v.RequiredList = []string{"hello", "world"}
-	}
-	{
-		w, err = wire.NewValueList(_List_String_ValueList(v.RequiredList)), error(nil)
+	if v.Manager != nil {
+		w, err = v.Manager.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 5, Value: w}
 		i++
 	}
-	if v.OptionalList == nil {
-		v.OptionalList = []float64{1, 2, 3}
+	if v.Location == nil {
+		return w, wire.NilRequiredFieldError{Struct: "BuiltUser", Field: "Location", ID: 6}
 	}
-	{
-		w, err = wire.NewValueList(_List_Double_ValueList(v.OptionalList)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 6, Value: w}
-		i++
+	w, err = v.Location.ToWire()
+	if err != nil {
+		return w, err
 	}
-	if v.RequiredStruct == nil {
-		v.RequiredStruct = &Frame{Size: &Size{Height: 200, Width: 100}, TopLeft: &Point{X: 1, Y: 2}}
+	fields[i] = wire.Field{ID: 6, Value: w}
+	i++
+	if v.Active == nil {
+		v.Active = ptr.Bool(true)
 	}
 	{
-		w, err = v.RequiredStruct.ToWire()
+		w, err = wire.NewValueBool(*(v.Active)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 7, Value: w}
 		i++
 	}
-	if v.OptionalStruct == nil {
-		v.OptionalStruct = &Edge{EndPoint: &Point{X: 3, Y: 4}, StartPoint: &Point{X: 1, Y: 2}}
-	}
-	{
-		w, err = v.OptionalStruct.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 8, Value: w}
-		i++
-	}
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _EnumDefault_Read(w wire.Value) (enums.EnumDefault, error) {
-	var v enums.EnumDefault
-	err := v.FromWire(w)
-	return v, err
-}
-
 func _List_String_Read(l wire.ValueList) ([]string, error) {
 	if l.ValueType() != wire.TBinary {
 		return nil, nil
@@ -262,174 +139,137 @@ func _List_String_Read(l wire.ValueList) ([]string, error) {
 	return o, err
 }
 
-func _List_Double_Read(l wire.ValueList) ([]float64, error) {
-	if l.ValueType() != wire.TDouble {
-		return nil, nil
-	}
-	o := make([]float64, 0, l.Size())
-	err := l.ForEach(func(x wire.Value) error {
-		i, err := x.GetDouble(), error(nil)
-		if err != nil {
-			return err
-		}
-		o = append(o, i)
-		return nil
-	})
-	l.Close()
-	return o, err
-}
-
-func _Frame_Read(w wire.Value) (*Frame, error) {
-	var v Frame
+func _BuiltUser_Read(w wire.Value) (*BuiltUser, error) {
+	var v BuiltUser
 	err := v.FromWire(w)
 	return &v, err
 }
 
-func _Edge_Read(w wire.Value) (*Edge, error) {
-	var v Edge
+func _Point_Read(w wire.Value) (*Point, error) {
+	var v Point
 	err := v.FromWire(w)
 	return &v, err
 }
 
-func (v *DefaultsStruct) FromWire(w wire.Value) error {
+func (v *BuiltUser) FromWire(w wire.Value) error {
 	var err error
+	nameIsSet := false
+	locationIsSet := false
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 1:
-			if field.Value.Type() == wire.TI32 {
-				var x int32
-				x, err = field.Value.GetI32(), error(nil)
-				v.RequiredPrimitive = &x
+			if field.Value.Type() == wire.TBinary {
+				v.Name, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("BuiltUser", "Name", wire.DecodeErrorInvalidValue)
 					return err
 				}
+				nameIsSet = true
 			}
 		case 2:
-			if field.Value.Type() == wire.TI32 {
-				var x int32
-				x, err = field.Value.GetI32(), error(nil)
-				v.OptionalPrimitive = &x
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Email = &x
 				if err != nil {
+					wire.ObserveDecodeError("BuiltUser", "Email", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
 		case 3:
-			if field.Value.Type() == wire.TI32 {
-				var x enums.EnumDefault
-				x, err = _EnumDefault_Read(field.Value)
-				v.RequiredEnum = &x
+			if field.Value.Type() == wire.TList {
+				v.Tags, err = _List_String_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("BuiltUser", "Tags", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
 		case 4:
 			if field.Value.Type() == wire.TI32 {
-				var x enums.EnumDefault
-				x, err = _EnumDefault_Read(field.Value)
-				v.OptionalEnum = &x
+				v.Age, err = field.Value.GetI32(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("BuiltUser", "Age", wire.DecodeErrorInvalidValue)
 					return err
 				}
+				v.isSetAge = true
 			}
 		case 5:
-			if field.Value.Type() == wire.TList {
-				v.RequiredList, err = _List_String_Read(field.Value.GetList())
+			if field.Value.Type() == wire.TStruct {
+				v.Manager, err = _BuiltUser_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("BuiltUser", "Manager", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
 		case 6:
-			if field.Value.Type() == wire.TList {
-				v.OptionalList, err = _List_Double_Read(field.Value.GetList())
-				if err != nil {
-					return err
-				}
-			}
-		case 7:
 			if field.Value.Type() == wire.TStruct {
-				v.RequiredStruct, err = _Frame_Read(field.Value)
+				v.Location, err = _Point_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("BuiltUser", "Location", wire.DecodeErrorInvalidValue)
 					return err
 				}
+				locationIsSet = true
 			}
-		case 8:
-			if field.Value.Type() == wire.TStruct {
-				v.OptionalStruct, err = _Edge_Read(field.Value)
+		case 7:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Active = &x
 				if err != nil {
+					wire.ObserveDecodeError("BuiltUser", "Active", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
 		}
 	}
-	if v.RequiredPrimitive == nil {
-		v.RequiredPrimitive = ptr.Int32(100)
-	}
-	if v.OptionalPrimitive == nil {
-		v.OptionalPrimitive = ptr.Int32(200)
-	}
-	if v.RequiredEnum == nil {
-		v.RequiredEnum = _EnumDefault_ptr(enums.EnumDefaultBar)
-	}
-	if v.OptionalEnum == nil {
-		v.OptionalEnum = _EnumDefault_ptr(enums.EnumDefaultBaz)
-	}
-	if v.RequiredList == nil {
-		v.RequiredList = []string{"hello", "world"}
-	}
-	if v.OptionalList == nil {
-		v.OptionalList = []float64{1, 2, 3}
+	if !nameIsSet {
+		wire.ObserveDecodeError("BuiltUser", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "BuiltUser", Field: "Name", ID: 1}
 	}
-	if v.RequiredStruct == nil {
-		v.RequiredStruct = &Frame{Size: &Size{Height: 200, Width: 100}, TopLeft: &Point{X: 1, Y: 2}}
+	if !locationIsSet {
+		wire.ObserveDecodeError("BuiltUser", "Location", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "BuiltUser", Field: "Location", ID: 6}
 	}
-	if v.OptionalStruct == nil {
-		v.OptionalStruct = &Edge{EndPoint: &Point{X: 3, Y: 4}, StartPoint: &Point{X: 1, Y: 2}}
+	if v.Active == nil {
+		v.Active = ptr.Bool(true)
 	}
 	return nil
 }
 
-func (v *DefaultsStruct) String() string {
+func (v *BuiltUser) String() string {
 	if v == nil {
 		return "<nil>"
 	}
-	var fields [8]string
+	var fields [7]string
 	i := 0
-	if v.RequiredPrimitive != nil {
-		fields[i] = fmt.Sprintf("RequiredPrimitive: %v", *(v.RequiredPrimitive))
-		i++
-	}
-	if v.OptionalPrimitive != nil {
-		fields[i] = fmt.Sprintf("OptionalPrimitive: %v", *(v.OptionalPrimitive))
-		i++
-	}
-	if v.RequiredEnum != nil {
-		fields[i] = fmt.Sprintf("RequiredEnum: %v", *(v.RequiredEnum))
-		i++
-	}
-	if v.OptionalEnum != nil {
-		fields[i] = fmt.Sprintf("OptionalEnum: %v", *(v.OptionalEnum))
+	fields[i] = fmt.Sprintf("Name: %v", v.Name)
+	i++
+	if v.Email != nil {
+		fields[i] = fmt.Sprintf("Email: %v", *(v.Email))
 		i++
 	}
-	if v.RequiredList != nil {
-		fields[i] = fmt.Sprintf("RequiredList: %v", v.RequiredList)
+	if v.Tags != nil {
+		fields[i] = fmt.Sprintf("Tags: %v", v.Tags)
 		i++
 	}
-	if v.OptionalList != nil {
-		fields[i] = fmt.Sprintf("OptionalList: %v", v.OptionalList)
+	if v.isSetAge || v.Age != 0 {
+		fields[i] = fmt.Sprintf("Age: %v", v.Age)
 		i++
 	}
-	if v.RequiredStruct != nil {
-		fields[i] = fmt.Sprintf("RequiredStruct: %v", v.RequiredStruct)
+	if v.Manager != nil {
+		fields[i] = fmt.Sprintf("Manager: %v", v.Manager)
 		i++
 	}
-	if v.OptionalStruct != nil {
-		fields[i] = fmt.Sprintf("OptionalStruct: %v", v.OptionalStruct)
+	fields[i] = fmt.Sprintf("Location: %v", v.Location)
+	i++
+	if v.Active != nil {
+		fields[i] = fmt.Sprintf("Active: %v", *(v.Active))
 		i++
 	}
-	return fmt.Sprintf("DefaultsStruct{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("BuiltUser{%v}", strings.Join(fields[:i], ", "))
 }
 
-func _I32_EqualsPtr(lhs, rhs *int32) bool {
+func _String_EqualsPtr(lhs, rhs *string) bool {
 	if lhs != nil && rhs != nil {
 		x := *lhs
 		y := *rhs
@@ -438,15 +278,6 @@ func _I32_EqualsPtr(lhs, rhs *int32) bool {
 	return lhs == nil && rhs == nil
 }
 
-func _EnumDefault_EqualsPtr(lhs, rhs *enums.EnumDefault) bool {
-	if lhs != nil && rhs != nil {
-		x := *lhs
-		y := *rhs
-		return x.Equals(y)
-	}
-	return lhs == nil && rhs == nil
-}
-
 func _List_String_Equals(lhs, rhs []string) bool {
 	if len(lhs) != len(rhs) {
 		return false
@@ -460,280 +291,299 @@ func _List_String_Equals(lhs, rhs []string) bool {
 	return true
 }
 
-func _List_Double_Equals(lhs, rhs []float64) bool {
-	if len(lhs) != len(rhs) {
-		return false
-	}
-	for i, lv := range lhs {
-		rv := rhs[i]
-		if !(lv == rv) {
-			return false
-		}
+func _Bool_EqualsPtr(lhs, rhs *bool) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
 	}
-	return true
+	return lhs == nil && rhs == nil
 }
 
-func (v *DefaultsStruct) Equals(rhs *DefaultsStruct) bool {
-	if !_I32_EqualsPtr(v.RequiredPrimitive, rhs.RequiredPrimitive) {
-		return false
-	}
-	if !_I32_EqualsPtr(v.OptionalPrimitive, rhs.OptionalPrimitive) {
+func (v *BuiltUser) Equals(rhs *BuiltUser) bool {
+	if !(v.Name == rhs.Name) {
 		return false
 	}
-	if !_EnumDefault_EqualsPtr(v.RequiredEnum, rhs.RequiredEnum) {
+	if !_String_EqualsPtr(v.Email, rhs.Email) {
 		return false
 	}
-	if !_EnumDefault_EqualsPtr(v.OptionalEnum, rhs.OptionalEnum) {
+	if !((v.Tags == nil && rhs.Tags == nil) || (v.Tags != nil && rhs.Tags != nil && _List_String_Equals(v.Tags, rhs.Tags))) {
 		return false
 	}
-	if !((v.RequiredList == nil && rhs.RequiredList == nil) || (v.RequiredList != nil && rhs.RequiredList != nil && _List_String_Equals(v.RequiredList, rhs.RequiredList))) {
+	if (v.isSetAge || v.Age != 0) != (rhs.isSetAge || rhs.Age != 0) || !(v.Age == rhs.Age) {
 		return false
 	}
-	if !((v.OptionalList == nil && rhs.OptionalList == nil) || (v.OptionalList != nil && rhs.OptionalList != nil && _List_Double_Equals(v.OptionalList, rhs.OptionalList))) {
+	if !((v.Manager == nil && rhs.Manager == nil) || (v.Manager != nil && rhs.Manager != nil && v.Manager.Equals(rhs.Manager))) {
 		return false
 	}
-	if !((v.RequiredStruct == nil && rhs.RequiredStruct == nil) || (v.RequiredStruct != nil && rhs.RequiredStruct != nil && v.RequiredStruct.Equals(rhs.RequiredStruct))) {
+	if !v.Location.Equals(rhs.Location) {
 		return false
 	}
-	if !((v.OptionalStruct == nil && rhs.OptionalStruct == nil) || (v.OptionalStruct != nil && rhs.OptionalStruct != nil && v.OptionalStruct.Equals(rhs.OptionalStruct))) {
+	if !_Bool_EqualsPtr(v.Active, rhs.Active) {
 		return false
 	}
 	return true
 }
 
-type Edge struct {
-	StartPoint *Point `json:"startPoint"`
-	EndPoint   *Point `json:"endPoint"`
+func (v *BuiltUser) MarshalJSON() ([]byte, error) {
+	type plain BuiltUser
+	x := struct {
+		*plain
+		Age *int32 `json:"age,omitempty"`
+	}{plain: (*plain)(v)}
+	if v.isSetAge || v.Age != 0 {
+		x.Age = &v.Age
+	}
+	return json.Marshal(x)
 }
 
-func (v *Edge) ToWire() (wire.Value, error) {
-	var (
-		fields [2]wire.Field
-		i      int = 0
-		w      wire.Value
-		err    error
-	)
-	if v.StartPoint == nil {
-		return w, errors.New("field StartPoint of Edge is required")
-	}
-	w, err = v.StartPoint.ToWire()
-	if err != nil {
-		return w, err
-	}
-	fields[i] = wire.Field{ID: 1, Value: w}
-	i++
-	if v.EndPoint == nil {
-		return w, errors.New("field EndPoint of Edge is required")
-	}
-	w, err = v.EndPoint.ToWire()
-	if err != nil {
-		return w, err
+func (v *BuiltUser) UnmarshalJSON(text []byte) error {
+	type plain BuiltUser
+	x := struct {
+		*plain
+		Age *int32 `json:"age,omitempty"`
+	}{plain: (*plain)(v)}
+	if err := json.Unmarshal(text, &x); err != nil {
+		return err
+	}
+	if x.Age != nil {
+		v.Age = *x.Age
+		v.isSetAge = true
+	} else {
+		var zero int32
+		v.Age = zero
+		v.isSetAge = false
 	}
-	fields[i] = wire.Field{ID: 2, Value: w}
-	i++
-	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+	return nil
 }
 
-func _Point_Read(w wire.Value) (*Point, error) {
-	var v Point
-	err := v.FromWire(w)
-	return &v, err
+func (v *BuiltUser) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
 }
 
-func (v *Edge) FromWire(w wire.Value) error {
-	var err error
-	startPointIsSet := false
-	endPointIsSet := false
-	for _, field := range w.GetStruct().Fields {
-		switch field.ID {
-		case 1:
-			if field.Value.Type() == wire.TStruct {
-				v.StartPoint, err = _Point_Read(field.Value)
-				if err != nil {
-					return err
-				}
-				startPointIsSet = true
-			}
-		case 2:
-			if field.Value.Type() == wire.TStruct {
-				v.EndPoint, err = _Point_Read(field.Value)
-				if err != nil {
-					return err
-				}
-				endPointIsSet = true
-			}
-		}
+func (v *BuiltUser) GetEmail() (o string) {
+	if v != nil && v.Email != nil {
+		return *v.Email
 	}
-	if !startPointIsSet {
-		return errors.New("field StartPoint of Edge is required")
+	return
+}
+
+func (v *BuiltUser) GetTags() (o []string) {
+	if v != nil && v.Tags != nil {
+		return v.Tags
 	}
-	if !endPointIsSet {
-		return errors.New("field EndPoint of Edge is required")
+	return
+}
+
+func (v *BuiltUser) GetAge() (o int32) {
+	if v != nil && (v.isSetAge || v.Age != 0) {
+		return v.Age
 	}
-	return nil
+	return
 }
 
-func (v *Edge) String() string {
-	if v == nil {
-		return "<nil>"
+func (v *BuiltUser) GetManager() (o *BuiltUser) {
+	if v != nil && v.Manager != nil {
+		return v.Manager
 	}
-	var fields [2]string
-	i := 0
-	fields[i] = fmt.Sprintf("StartPoint: %v", v.StartPoint)
-	i++
-	fields[i] = fmt.Sprintf("EndPoint: %v", v.EndPoint)
-	i++
-	return fmt.Sprintf("Edge{%v}", strings.Join(fields[:i], ", "))
+	return
 }
 
-func (v *Edge) Equals(rhs *Edge) bool {
-	if !v.StartPoint.Equals(rhs.StartPoint) {
-		return false
+func (v *BuiltUser) GetLocation() (o *Point) {
+	if v != nil {
+		o = v.Location
 	}
-	if !v.EndPoint.Equals(rhs.EndPoint) {
-		return false
+	return
+}
+
+func (v *BuiltUser) GetActive() (o bool) {
+	if v != nil && v.Active != nil {
+		return *v.Active
 	}
-	return true
+	o = true
+	return
 }
 
-type EmptyStruct struct{}
+func (v *BuiltUser) IsSetAge() bool {
+	return v != nil && (v.isSetAge || v.Age != 0)
+}
 
-func (v *EmptyStruct) ToWire() (wire.Value, error) {
-	var (
-		fields [0]wire.Field
-		i      int = 0
-	)
-	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+func (v *BuiltUser) SetAge(x int32) {
+	v.Age = x
+	v.isSetAge = true
 }
 
-func (v *EmptyStruct) FromWire(w wire.Value) error {
-	for _, field := range w.GetStruct().Fields {
-		switch field.ID {
-		}
-	}
-	return nil
+func (v *BuiltUser) ClearAge() {
+	var x int32
+	v.Age = x
+	v.isSetAge = false
 }
 
-func (v *EmptyStruct) String() string {
+func (v *BuiltUser) Validate() error {
 	if v == nil {
-		return "<nil>"
+		return nil
 	}
-	var fields [0]string
-	i := 0
-	return fmt.Sprintf("EmptyStruct{%v}", strings.Join(fields[:i], ", "))
+	if len(v.Name) < 1 {
+		return wire.InvalidFieldError{Struct: "BuiltUser", Field: "Name", ID: 1, Reason: "length must be at least 1"}
+	}
+	if err := v.Manager.Validate(); err != nil {
+		return err
+	}
+	return nil
 }
 
-func (v *EmptyStruct) Equals(rhs *EmptyStruct) bool {
-	return true
+type BuiltUserBuilder struct {
+	v           BuiltUser
+	hasName     bool
+	hasLocation bool
 }
 
-type Frame struct {
-	TopLeft *Point `json:"topLeft"`
-	Size    *Size  `json:"size"`
+func NewBuiltUserBuilder() *BuiltUserBuilder {
+	return &BuiltUserBuilder{}
 }
 
-func (v *Frame) ToWire() (wire.Value, error) {
+func (b *BuiltUserBuilder) Name(x string) *BuiltUserBuilder {
+	b.v.Name = x
+	b.hasName = true
+	return b
+}
+
+func (b *BuiltUserBuilder) Email(x string) *BuiltUserBuilder {
+	b.v.Email = &x
+	return b
+}
+
+func (b *BuiltUserBuilder) Tags(x []string) *BuiltUserBuilder {
+	b.v.Tags = x
+	return b
+}
+
+func (b *BuiltUserBuilder) Age(x int32) *BuiltUserBuilder {
+	b.v.SetAge(x)
+	return b
+}
+
+func (b *BuiltUserBuilder) Manager(x *BuiltUser) *BuiltUserBuilder {
+	b.v.Manager = x
+	return b
+}
+
+func (b *BuiltUserBuilder) Location(x *Point) *BuiltUserBuilder {
+	b.v.Location = x
+	b.hasLocation = true
+	return b
+}
+
+func (b *BuiltUserBuilder) Active(x bool) *BuiltUserBuilder {
+	b.v.Active = &x
+	return b
+}
+
+func (b *BuiltUserBuilder) Build() (*BuiltUser, error) {
+	if !b.hasName {
+		return nil, wire.NilRequiredFieldError{Struct: "BuiltUser", Field: "Name", ID: 1}
+	}
+	if !b.hasLocation || b.v.Location == nil {
+		return nil, wire.NilRequiredFieldError{Struct: "BuiltUser", Field: "Location", ID: 6}
+	}
+	v := b.v
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+type ContactInfo struct {
+	EmailAddress string `json:"emailAddress"`
+}
+
+func (v *ContactInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [2]wire.Field
+		fields [1]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
-	if v.TopLeft == nil {
-		return w, errors.New("field TopLeft of Frame is required")
-	}
-	w, err = v.TopLeft.ToWire()
+	w, err = wire.NewValueString(v.EmailAddress), error(nil)
 	if err != nil {
 		return w, err
 	}
 	fields[i] = wire.Field{ID: 1, Value: w}
 	i++
-	if v.Size == nil {
-		return w, errors.New("field Size of Frame is required")
-	}
-	w, err = v.Size.ToWire()
-	if err != nil {
-		return w, err
-	}
-	fields[i] = wire.Field{ID: 2, Value: w}
-	i++
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _Size_Read(w wire.Value) (*Size, error) {
-	var v Size
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func (v *Frame) FromWire(w wire.Value) error {
+func (v *ContactInfo) FromWire(w wire.Value) error {
 	var err error
-	topLeftIsSet := false
-	sizeIsSet := false
+	emailAddressIsSet := false
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 1:
-			if field.Value.Type() == wire.TStruct {
-				v.TopLeft, err = _Point_Read(field.Value)
-				if err != nil {
-					return err
-				}
-				topLeftIsSet = true
-			}
-		case 2:
-			if field.Value.Type() == wire.TStruct {
-				v.Size, err = _Size_Read(field.Value)
+			if field.Value.Type() == wire.TBinary {
+				v.EmailAddress, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("ContactInfo", "EmailAddress", wire.DecodeErrorInvalidValue)
 					return err
 				}
-				sizeIsSet = true
+				emailAddressIsSet = true
 			}
 		}
 	}
-	if !topLeftIsSet {
-		return errors.New("field TopLeft of Frame is required")
-	}
-	if !sizeIsSet {
-		return errors.New("field Size of Frame is required")
+	if !emailAddressIsSet {
+		wire.ObserveDecodeError("ContactInfo", "EmailAddress", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "ContactInfo", Field: "EmailAddress", ID: 1}
 	}
 	return nil
 }
 
-func (v *Frame) String() string {
+func (v *ContactInfo) String() string {
 	if v == nil {
 		return "<nil>"
 	}
-	var fields [2]string
+	var fields [1]string
 	i := 0
-	fields[i] = fmt.Sprintf("TopLeft: %v", v.TopLeft)
-	i++
-	fields[i] = fmt.Sprintf("Size: %v", v.Size)
+	fields[i] = fmt.Sprintf("EmailAddress: %v", v.EmailAddress)
 	i++
-	return fmt.Sprintf("Frame{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("ContactInfo{%v}", strings.Join(fields[:i], ", "))
 }
 
-func (v *Frame) Equals(rhs *Frame) bool {
-	if !v.TopLeft.Equals(rhs.TopLeft) {
-		return false
-	}
-	if !v.Size.Equals(rhs.Size) {
+func (v *ContactInfo) Equals(rhs *ContactInfo) bool {
+	if !(v.EmailAddress == rhs.EmailAddress) {
 		return false
 	}
 	return true
 }
 
-type Graph struct {
-	Edges []*Edge `json:"edges"`
+func (v *ContactInfo) GetEmailAddress() (o string) {
+	if v != nil {
+		o = v.EmailAddress
+	}
+	return
 }
 
-type _List_Edge_ValueList []*Edge
+type DefaultsStruct struct {
+	RequiredPrimitive *int32             `json:"requiredPrimitive,omitempty"`
+	OptionalPrimitive *int32             `json:"optionalPrimitive,omitempty"`
+	RequiredEnum      *enums.EnumDefault `json:"requiredEnum,omitempty"`
+	OptionalEnum      *enums.EnumDefault `json:"optionalEnum,omitempty"`
+	RequiredList      []string           `json:"requiredList"`
+	OptionalList      []float64          `json:"optionalList"`
+	RequiredStruct    *Frame             `json:"requiredStruct,omitempty"`
+	OptionalStruct    *Edge              `json:"optionalStruct,omitempty"`
+}
 
-func (v _List_Edge_ValueList) ForEach(f func(wire.Value) error) error {
-	for i, x := range v {
-		if x == nil {
-			return fmt.Errorf("invalid [%v]: value is nil", i)
-		}
-		w, err := x.ToWire()
+func _EnumDefault_ptr(v enums.EnumDefault) *enums.EnumDefault {
+	return &v
+}
+
+type _List_Double_ValueList []float64
+
+func (v _List_Double_ValueList) ForEach(f func(wire.Value) error) error {
+	for _, x := range v {
+		w, err := wire.NewValueDouble(x), error(nil)
 		if err != nil {
 			return err
 		}
@@ -745,43 +595,128 @@ func (v _List_Edge_ValueList) ForEach(f func(wire.Value) error) error {
 	return nil
 }
 
-func (v _List_Edge_ValueList) Size() int {
+func (v _List_Double_ValueList) Size() int {
 	return len(v)
 }
 
-func (_List_Edge_ValueList) ValueType() wire.Type {
-	return wire.TStruct
+func (_List_Double_ValueList) ValueType() wire.Type {
+	return wire.TDouble
 }
 
-func (_List_Edge_ValueList) Close() {
+func (_List_Double_ValueList) Close() {
 }
 
-func (v *Graph) ToWire() (wire.Value, error) {
+func (v *DefaultsStruct) ToWire() (wire.Value, error) {
 	var (
-		fields [1]wire.Field
+		fields [8]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
-	if v.Edges == nil {
-		return w, errors.New("field Edges of Graph is required")
+	if v.RequiredPrimitive == nil {
+		v.RequiredPrimitive = ptr.Int32(100)
 	}
-	w, err = wire.NewValueList(_List_Edge_ValueList(v.Edges)), error(nil)
-	if err != nil {
-		return w, err
+	{
+		w, err = wire.NewValueI32(*(v.RequiredPrimitive)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.OptionalPrimitive == nil {
+		v.OptionalPrimitive = ptr.Int32(200)
+	}
+	{
+		w, err = wire.NewValueI32(*(v.OptionalPrimitive)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.RequiredEnum == nil {
+		v.RequiredEnum = _EnumDefault_ptr(enums.EnumDefaultBar)
+	}
+	{
+		w, err = v.RequiredEnum.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.OptionalEnum == nil {
+		v.OptionalEnum = _EnumDefault_ptr(enums.EnumDefaultBaz)
+	}
+	{
+		w, err = v.OptionalEnum.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.RequiredList == nil {
+		v.RequiredList = []string{"hello", "world"}
+	}
+	{
+		w, err = wire.NewValueList(_List_String_ValueList(v.RequiredList)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+	if v.OptionalList == nil {
+		v.OptionalList = []float64{1, 2, 3}
+	}
+	{
+		w, err = wire.NewValueList(_List_Double_ValueList(v.OptionalList)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 6, Value: w}
+		i++
+	}
+	if v.RequiredStruct == nil {
+		v.RequiredStruct = &Frame{Size: &Size{Height: 200, Width: 100}, TopLeft: &Point{X: 1, Y: 2}}
+	}
+	{
+		w, err = v.RequiredStruct.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 7, Value: w}
+		i++
+	}
+	if v.OptionalStruct == nil {
+		v.OptionalStruct = &Edge{EndPoint: &Point{X: 3, Y: 4}, StartPoint: &Point{X: 1, Y: 2}}
+	}
+	{
+		w, err = v.OptionalStruct.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 8, Value: w}
+		i++
 	}
-	fields[i] = wire.Field{ID: 1, Value: w}
-	i++
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _List_Edge_Read(l wire.ValueList) ([]*Edge, error) {
-	if l.ValueType() != wire.TStruct {
+func _EnumDefault_Read(w wire.Value) (enums.EnumDefault, error) {
+	var v enums.EnumDefault
+	err := v.FromWire(w)
+	return v, err
+}
+
+func _List_Double_Read(l wire.ValueList) ([]float64, error) {
+	if l.ValueType() != wire.TDouble {
 		return nil, nil
 	}
-	o := make([]*Edge, 0, l.Size())
+	o := make([]float64, 0, l.Size())
 	err := l.ForEach(func(x wire.Value) error {
-		i, err := _Edge_Read(x)
+		i, err := x.GetDouble(), error(nil)
 		if err != nil {
 			return err
 		}
@@ -792,325 +727,4398 @@ func _List_Edge_Read(l wire.ValueList) ([]*Edge, error) {
 	return o, err
 }
 
-func (v *Graph) FromWire(w wire.Value) error {
+func _Frame_Read(w wire.Value) (*Frame, error) {
+	var v Frame
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _Edge_Read(w wire.Value) (*Edge, error) {
+	var v Edge
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func (v *DefaultsStruct) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.RequiredPrimitive = &x
+				if err != nil {
+					wire.ObserveDecodeError("DefaultsStruct", "RequiredPrimitive", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 2:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.OptionalPrimitive = &x
+				if err != nil {
+					wire.ObserveDecodeError("DefaultsStruct", "OptionalPrimitive", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 3:
+			if field.Value.Type() == wire.TI32 {
+				var x enums.EnumDefault
+				x, err = _EnumDefault_Read(field.Value)
+				v.RequiredEnum = &x
+				if err != nil {
+					wire.ObserveDecodeError("DefaultsStruct", "RequiredEnum", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 4:
+			if field.Value.Type() == wire.TI32 {
+				var x enums.EnumDefault
+				x, err = _EnumDefault_Read(field.Value)
+				v.OptionalEnum = &x
+				if err != nil {
+					wire.ObserveDecodeError("DefaultsStruct", "OptionalEnum", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 5:
+			if field.Value.Type() == wire.TList {
+				v.RequiredList, err = _List_String_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("DefaultsStruct", "RequiredList", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 6:
+			if field.Value.Type() == wire.TList {
+				v.OptionalList, err = _List_Double_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("DefaultsStruct", "OptionalList", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 7:
+			if field.Value.Type() == wire.TStruct {
+				v.RequiredStruct, err = _Frame_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("DefaultsStruct", "RequiredStruct", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 8:
+			if field.Value.Type() == wire.TStruct {
+				v.OptionalStruct, err = _Edge_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("DefaultsStruct", "OptionalStruct", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	if v.RequiredPrimitive == nil {
+		v.RequiredPrimitive = ptr.Int32(100)
+	}
+	if v.OptionalPrimitive == nil {
+		v.OptionalPrimitive = ptr.Int32(200)
+	}
+	if v.RequiredEnum == nil {
+		v.RequiredEnum = _EnumDefault_ptr(enums.EnumDefaultBar)
+	}
+	if v.OptionalEnum == nil {
+		v.OptionalEnum = _EnumDefault_ptr(enums.EnumDefaultBaz)
+	}
+	if v.RequiredList == nil {
+		v.RequiredList = []string{"hello", "world"}
+	}
+	if v.OptionalList == nil {
+		v.OptionalList = []float64{1, 2, 3}
+	}
+	if v.RequiredStruct == nil {
+		v.RequiredStruct = &Frame{Size: &Size{Height: 200, Width: 100}, TopLeft: &Point{X: 1, Y: 2}}
+	}
+	if v.OptionalStruct == nil {
+		v.OptionalStruct = &Edge{EndPoint: &Point{X: 3, Y: 4}, StartPoint: &Point{X: 1, Y: 2}}
+	}
+	return nil
+}
+
+func (v *DefaultsStruct) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [8]string
+	i := 0
+	if v.RequiredPrimitive != nil {
+		fields[i] = fmt.Sprintf("RequiredPrimitive: %v", *(v.RequiredPrimitive))
+		i++
+	}
+	if v.OptionalPrimitive != nil {
+		fields[i] = fmt.Sprintf("OptionalPrimitive: %v", *(v.OptionalPrimitive))
+		i++
+	}
+	if v.RequiredEnum != nil {
+		fields[i] = fmt.Sprintf("RequiredEnum: %v", *(v.RequiredEnum))
+		i++
+	}
+	if v.OptionalEnum != nil {
+		fields[i] = fmt.Sprintf("OptionalEnum: %v", *(v.OptionalEnum))
+		i++
+	}
+	if v.RequiredList != nil {
+		fields[i] = fmt.Sprintf("RequiredList: %v", v.RequiredList)
+		i++
+	}
+	if v.OptionalList != nil {
+		fields[i] = fmt.Sprintf("OptionalList: %v", v.OptionalList)
+		i++
+	}
+	if v.RequiredStruct != nil {
+		fields[i] = fmt.Sprintf("RequiredStruct: %v", v.RequiredStruct)
+		i++
+	}
+	if v.OptionalStruct != nil {
+		fields[i] = fmt.Sprintf("OptionalStruct: %v", v.OptionalStruct)
+		i++
+	}
+	return fmt.Sprintf("DefaultsStruct{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _I32_EqualsPtr(lhs, rhs *int32) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _EnumDefault_EqualsPtr(lhs, rhs *enums.EnumDefault) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _List_Double_Equals(lhs, rhs []float64) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *DefaultsStruct) Equals(rhs *DefaultsStruct) bool {
+	if !_I32_EqualsPtr(v.RequiredPrimitive, rhs.RequiredPrimitive) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.OptionalPrimitive, rhs.OptionalPrimitive) {
+		return false
+	}
+	if !_EnumDefault_EqualsPtr(v.RequiredEnum, rhs.RequiredEnum) {
+		return false
+	}
+	if !_EnumDefault_EqualsPtr(v.OptionalEnum, rhs.OptionalEnum) {
+		return false
+	}
+	if !((v.RequiredList == nil && rhs.RequiredList == nil) || (v.RequiredList != nil && rhs.RequiredList != nil && _List_String_Equals(v.RequiredList, rhs.RequiredList))) {
+		return false
+	}
+	if !((v.OptionalList == nil && rhs.OptionalList == nil) || (v.OptionalList != nil && rhs.OptionalList != nil && _List_Double_Equals(v.OptionalList, rhs.OptionalList))) {
+		return false
+	}
+	if !((v.RequiredStruct == nil && rhs.RequiredStruct == nil) || (v.RequiredStruct != nil && rhs.RequiredStruct != nil && v.RequiredStruct.Equals(rhs.RequiredStruct))) {
+		return false
+	}
+	if !((v.OptionalStruct == nil && rhs.OptionalStruct == nil) || (v.OptionalStruct != nil && rhs.OptionalStruct != nil && v.OptionalStruct.Equals(rhs.OptionalStruct))) {
+		return false
+	}
+	return true
+}
+
+func (v *DefaultsStruct) GetRequiredPrimitive() (o int32) {
+	if v != nil && v.RequiredPrimitive != nil {
+		return *v.RequiredPrimitive
+	}
+	o = 100
+	return
+}
+
+func (v *DefaultsStruct) GetOptionalPrimitive() (o int32) {
+	if v != nil && v.OptionalPrimitive != nil {
+		return *v.OptionalPrimitive
+	}
+	o = 200
+	return
+}
+
+func (v *DefaultsStruct) GetRequiredEnum() (o enums.EnumDefault) {
+	if v != nil && v.RequiredEnum != nil {
+		return *v.RequiredEnum
+	}
+	o = enums.EnumDefaultBar
+	return
+}
+
+func (v *DefaultsStruct) GetOptionalEnum() (o enums.EnumDefault) {
+	if v != nil && v.OptionalEnum != nil {
+		return *v.OptionalEnum
+	}
+	o = enums.EnumDefaultBaz
+	return
+}
+
+func (v *DefaultsStruct) GetRequiredList() (o []string) {
+	if v != nil && v.RequiredList != nil {
+		return v.RequiredList
+	}
+	o = []string{"hello", "world"}
+	return
+}
+
+func (v *DefaultsStruct) GetOptionalList() (o []float64) {
+	if v != nil && v.OptionalList != nil {
+		return v.OptionalList
+	}
+	o = []float64{1, 2, 3}
+	return
+}
+
+func (v *DefaultsStruct) GetRequiredStruct() (o *Frame) {
+	if v != nil && v.RequiredStruct != nil {
+		return v.RequiredStruct
+	}
+	o = &Frame{Size: &Size{Height: 200, Width: 100}, TopLeft: &Point{X: 1, Y: 2}}
+	return
+}
+
+func (v *DefaultsStruct) GetOptionalStruct() (o *Edge) {
+	if v != nil && v.OptionalStruct != nil {
+		return v.OptionalStruct
+	}
+	o = &Edge{EndPoint: &Point{X: 3, Y: 4}, StartPoint: &Point{X: 1, Y: 2}}
+	return
+}
+
+type Edge struct {
+	StartPoint *Point `json:"startPoint"`
+	EndPoint   *Point `json:"endPoint"`
+}
+
+func (v *Edge) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.StartPoint == nil {
+		return w, wire.NilRequiredFieldError{Struct: "Edge", Field: "StartPoint", ID: 1}
+	}
+	w, err = v.StartPoint.ToWire()
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.EndPoint == nil {
+		return w, wire.NilRequiredFieldError{Struct: "Edge", Field: "EndPoint", ID: 2}
+	}
+	w, err = v.EndPoint.ToWire()
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 2, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *Edge) FromWire(w wire.Value) error {
+	var err error
+	startPointIsSet := false
+	endPointIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.StartPoint, err = _Point_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("Edge", "StartPoint", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				startPointIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.EndPoint, err = _Point_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("Edge", "EndPoint", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				endPointIsSet = true
+			}
+		}
+	}
+	if !startPointIsSet {
+		wire.ObserveDecodeError("Edge", "StartPoint", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Edge", Field: "StartPoint", ID: 1}
+	}
+	if !endPointIsSet {
+		wire.ObserveDecodeError("Edge", "EndPoint", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Edge", Field: "EndPoint", ID: 2}
+	}
+	return nil
+}
+
+func (v *Edge) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	fields[i] = fmt.Sprintf("StartPoint: %v", v.StartPoint)
+	i++
+	fields[i] = fmt.Sprintf("EndPoint: %v", v.EndPoint)
+	i++
+	return fmt.Sprintf("Edge{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *Edge) Equals(rhs *Edge) bool {
+	if !v.StartPoint.Equals(rhs.StartPoint) {
+		return false
+	}
+	if !v.EndPoint.Equals(rhs.EndPoint) {
+		return false
+	}
+	return true
+}
+
+func (v *Edge) GetStartPoint() (o *Point) {
+	if v != nil {
+		o = v.StartPoint
+	}
+	return
+}
+
+func (v *Edge) GetEndPoint() (o *Point) {
+	if v != nil {
+		o = v.EndPoint
+	}
+	return
+}
+
+type EmptyStruct struct{}
+
+func (v *EmptyStruct) ToWire() (wire.Value, error) {
+	var (
+		fields [0]wire.Field
+		i      int = 0
+	)
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *EmptyStruct) FromWire(w wire.Value) error {
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		}
+	}
+	return nil
+}
+
+func (v *EmptyStruct) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [0]string
+	i := 0
+	return fmt.Sprintf("EmptyStruct{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *EmptyStruct) Equals(rhs *EmptyStruct) bool {
+	return true
+}
+
+type Failure struct {
+	Reason string `json:"reason"`
+}
+
+func (v *Failure) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueString(v.Reason), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *Failure) FromWire(w wire.Value) error {
+	var err error
+	reasonIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.Reason, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("Failure", "Reason", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				reasonIsSet = true
+			}
+		}
+	}
+	if !reasonIsSet {
+		wire.ObserveDecodeError("Failure", "Reason", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Failure", Field: "Reason", ID: 1}
+	}
+	return nil
+}
+
+func (v *Failure) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	fields[i] = fmt.Sprintf("Reason: %v", v.Reason)
+	i++
+	return fmt.Sprintf("Failure{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *Failure) Equals(rhs *Failure) bool {
+	if !(v.Reason == rhs.Reason) {
+		return false
+	}
+	return true
+}
+
+func (v *Failure) GetReason() (o string) {
+	if v != nil {
+		o = v.Reason
+	}
+	return
+}
+
+func (v *Failure) Error() string {
+	return v.String()
+}
+
+var _ fmt.Stringer = (*Failure)(nil)
+
+var _ error = (*Failure)(nil)
+
+type Frame struct {
+	TopLeft *Point `json:"topLeft"`
+	Size    *Size  `json:"size"`
+}
+
+func (v *Frame) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.TopLeft == nil {
+		return w, wire.NilRequiredFieldError{Struct: "Frame", Field: "TopLeft", ID: 1}
+	}
+	w, err = v.TopLeft.ToWire()
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.Size == nil {
+		return w, wire.NilRequiredFieldError{Struct: "Frame", Field: "Size", ID: 2}
+	}
+	w, err = v.Size.ToWire()
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 2, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Size_Read(w wire.Value) (*Size, error) {
+	var v Size
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func (v *Frame) FromWire(w wire.Value) error {
+	var err error
+	topLeftIsSet := false
+	sizeIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.TopLeft, err = _Point_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("Frame", "TopLeft", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				topLeftIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.Size, err = _Size_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("Frame", "Size", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				sizeIsSet = true
+			}
+		}
+	}
+	if !topLeftIsSet {
+		wire.ObserveDecodeError("Frame", "TopLeft", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Frame", Field: "TopLeft", ID: 1}
+	}
+	if !sizeIsSet {
+		wire.ObserveDecodeError("Frame", "Size", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Frame", Field: "Size", ID: 2}
+	}
+	return nil
+}
+
+func (v *Frame) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	fields[i] = fmt.Sprintf("TopLeft: %v", v.TopLeft)
+	i++
+	fields[i] = fmt.Sprintf("Size: %v", v.Size)
+	i++
+	return fmt.Sprintf("Frame{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *Frame) Equals(rhs *Frame) bool {
+	if !v.TopLeft.Equals(rhs.TopLeft) {
+		return false
+	}
+	if !v.Size.Equals(rhs.Size) {
+		return false
+	}
+	return true
+}
+
+func (v *Frame) GetTopLeft() (o *Point) {
+	if v != nil {
+		o = v.TopLeft
+	}
+	return
+}
+
+func (v *Frame) GetSize() (o *Size) {
+	if v != nil {
+		o = v.Size
+	}
+	return
+}
+
+type Graph struct {
+	Edges []*Edge `json:"edges"`
+}
+
+type _List_Edge_ValueList []*Edge
+
+func (v _List_Edge_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_Edge_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_Edge_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_Edge_ValueList) Close() {
+}
+
+func (v *Graph) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Edges == nil {
+		return w, wire.NilRequiredFieldError{Struct: "Graph", Field: "Edges", ID: 1}
+	}
+	w, err = wire.NewValueList(_List_Edge_ValueList(v.Edges)), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _List_Edge_Read(l wire.ValueList) ([]*Edge, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+	o := make([]*Edge, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _Edge_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func (v *Graph) FromWire(w wire.Value) error {
+	var err error
+	edgesIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TList {
+				v.Edges, err = _List_Edge_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("Graph", "Edges", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				edgesIsSet = true
+			}
+		}
+	}
+	if !edgesIsSet {
+		wire.ObserveDecodeError("Graph", "Edges", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Graph", Field: "Edges", ID: 1}
+	}
+	return nil
+}
+
+func (v *Graph) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	fields[i] = fmt.Sprintf("Edges: %v", v.Edges)
+	i++
+	return fmt.Sprintf("Graph{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_Edge_Equals(lhs, rhs []*Edge) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *Graph) Equals(rhs *Graph) bool {
+	if !_List_Edge_Equals(v.Edges, rhs.Edges) {
+		return false
+	}
+	return true
+}
+
+func (v *Graph) GetEdges() (o []*Edge) {
+	if v != nil {
+		o = v.Edges
+	}
+	return
+}
+
+type List Node
+
+func (v *List) ToWire() (wire.Value, error) {
+	x := (*Node)(v)
+	return x.ToWire()
+}
+
+func (v *List) String() string {
+	x := (*Node)(v)
+	return fmt.Sprint(x)
+}
+
+func (v *List) FromWire(w wire.Value) error {
+	return (*Node)(v).FromWire(w)
+}
+
+func (lhs *List) Equals(rhs *List) bool {
+	return (*Node)(lhs).Equals((*Node)(rhs))
+}
+
+type Node struct {
+	Value int32 `json:"value"`
+	Tail  *List `json:"tail,omitempty"`
+}
+
+func (v *Node) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueI32(v.Value), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.Tail != nil {
+		w, err = v.Tail.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _List_Read(w wire.Value) (*List, error) {
+	var x List
+	err := x.FromWire(w)
+	return &x, err
+}
+
+func (v *Node) FromWire(w wire.Value) error {
+	var err error
+	valueIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TI32 {
+				v.Value, err = field.Value.GetI32(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("Node", "Value", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				valueIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.Tail, err = _List_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("Node", "Tail", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	if !valueIsSet {
+		wire.ObserveDecodeError("Node", "Value", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Node", Field: "Value", ID: 1}
+	}
+	return nil
+}
+
+func (v *Node) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	fields[i] = fmt.Sprintf("Value: %v", v.Value)
+	i++
+	if v.Tail != nil {
+		fields[i] = fmt.Sprintf("Tail: %v", v.Tail)
+		i++
+	}
+	return fmt.Sprintf("Node{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *Node) Equals(rhs *Node) bool {
+	if !(v.Value == rhs.Value) {
+		return false
+	}
+	if !((v.Tail == nil && rhs.Tail == nil) || (v.Tail != nil && rhs.Tail != nil && v.Tail.Equals(rhs.Tail))) {
+		return false
+	}
+	return true
+}
+
+func (v *Node) GetValue() (o int32) {
+	if v != nil {
+		o = v.Value
+	}
+	return
+}
+
+func (v *Node) GetTail() (o *List) {
+	if v != nil && v.Tail != nil {
+		return v.Tail
+	}
+	return
+}
+
+type NormalizedUser struct {
+	Name        string  `json:"name"`
+	Email       *string `json:"email,omitempty"`
+	CountryCode *string `json:"countryCode,omitempty"`
+	Bio         *string `json:"bio,omitempty"`
+}
+
+func (v *NormalizedUser) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueString(v.Name), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.Email != nil {
+		w, err = wire.NewValueString(*(v.Email)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.CountryCode != nil {
+		w, err = wire.NewValueString(*(v.CountryCode)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.Bio != nil {
+		w, err = wire.NewValueString(*(v.Bio)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *NormalizedUser) FromWire(w wire.Value) error {
+	var err error
+	nameIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.Name, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("NormalizedUser", "Name", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				nameIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Email = &x
+				if err != nil {
+					wire.ObserveDecodeError("NormalizedUser", "Email", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 3:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.CountryCode = &x
+				if err != nil {
+					wire.ObserveDecodeError("NormalizedUser", "CountryCode", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 4:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Bio = &x
+				if err != nil {
+					wire.ObserveDecodeError("NormalizedUser", "Bio", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	if !nameIsSet {
+		wire.ObserveDecodeError("NormalizedUser", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "NormalizedUser", Field: "Name", ID: 1}
+	}
+	return nil
+}
+
+func (v *NormalizedUser) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [4]string
+	i := 0
+	fields[i] = fmt.Sprintf("Name: %v", v.Name)
+	i++
+	if v.Email != nil {
+		fields[i] = fmt.Sprintf("Email: %v", *(v.Email))
+		i++
+	}
+	if v.CountryCode != nil {
+		fields[i] = fmt.Sprintf("CountryCode: %v", *(v.CountryCode))
+		i++
+	}
+	if v.Bio != nil {
+		fields[i] = fmt.Sprintf("Bio: %v", *(v.Bio))
+		i++
+	}
+	return fmt.Sprintf("NormalizedUser{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *NormalizedUser) Equals(rhs *NormalizedUser) bool {
+	if !(v.Name == rhs.Name) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Email, rhs.Email) {
+		return false
+	}
+	if !_String_EqualsPtr(v.CountryCode, rhs.CountryCode) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Bio, rhs.Bio) {
+		return false
+	}
+	return true
+}
+
+func (v *NormalizedUser) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
+}
+
+func (v *NormalizedUser) GetEmail() (o string) {
+	if v != nil && v.Email != nil {
+		return *v.Email
+	}
+	return
+}
+
+func (v *NormalizedUser) GetCountryCode() (o string) {
+	if v != nil && v.CountryCode != nil {
+		return *v.CountryCode
+	}
+	return
+}
+
+func (v *NormalizedUser) GetBio() (o string) {
+	if v != nil && v.Bio != nil {
+		return *v.Bio
+	}
+	return
+}
+
+func (v *NormalizedUser) Sanitize() {
+	v.Name = strings.TrimSpace(v.Name)
+	if v.Email != nil {
+		x := strings.ToLower(strings.TrimSpace(*v.Email))
+		v.Email = &x
+	}
+	if v.CountryCode != nil {
+		x := strings.TrimSpace(strings.ToUpper(*v.CountryCode))
+		v.CountryCode = &x
+	}
+}
+
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+func (v *Point) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueDouble(v.X), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	w, err = wire.NewValueDouble(v.Y), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 2, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *Point) FromWire(w wire.Value) error {
+	var err error
+	xIsSet := false
+	yIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TDouble {
+				v.X, err = field.Value.GetDouble(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("Point", "X", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				xIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TDouble {
+				v.Y, err = field.Value.GetDouble(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("Point", "Y", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				yIsSet = true
+			}
+		}
+	}
+	if !xIsSet {
+		wire.ObserveDecodeError("Point", "X", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Point", Field: "X", ID: 1}
+	}
+	if !yIsSet {
+		wire.ObserveDecodeError("Point", "Y", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Point", Field: "Y", ID: 2}
+	}
+	return nil
+}
+
+func (v *Point) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	fields[i] = fmt.Sprintf("X: %v", v.X)
+	i++
+	fields[i] = fmt.Sprintf("Y: %v", v.Y)
+	i++
+	return fmt.Sprintf("Point{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *Point) Equals(rhs *Point) bool {
+	if !(v.X == rhs.X) {
+		return false
+	}
+	if !(v.Y == rhs.Y) {
+		return false
+	}
+	return true
+}
+
+func (v *Point) GetX() (o float64) {
+	if v != nil {
+		o = v.X
+	}
+	return
+}
+
+func (v *Point) GetY() (o float64) {
+	if v != nil {
+		o = v.Y
+	}
+	return
+}
+
+type PointStream struct {
+	Points []*Point            `json:"points"`
+	Counts map[string]int32    `json:"counts"`
+	Tags   map[string]struct{} `json:"tags"`
+	Name   *string             `json:"name,omitempty"`
+}
+
+type _List_Point_ValueList []*Point
+
+func (v _List_Point_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_Point_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_Point_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_Point_ValueList) Close() {
+}
+
+type _Map_String_I32_MapItemList map[string]int32
+
+func (m _Map_String_I32_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
+		vw, err := wire.NewValueI32(v), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_String_I32_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_String_I32_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_I32_MapItemList) ValueType() wire.Type {
+	return wire.TI32
+}
+
+func (_Map_String_I32_MapItemList) Close() {
+}
+
+type _Set_String_ValueList map[string]struct{}
+
+func (v _Set_String_ValueList) ForEach(f func(wire.Value) error) error {
+	for x := range v {
+		w, err := wire.NewValueString(x), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _Set_String_ValueList) Size() int {
+	return len(v)
+}
+
+func (_Set_String_ValueList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Set_String_ValueList) Close() {
+}
+
+func (v *PointStream) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Points == nil {
+		return w, wire.NilRequiredFieldError{Struct: "PointStream", Field: "Points", ID: 1}
+	}
+	w, err = wire.NewValueList(_List_Point_ValueList(v.Points)), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.Counts != nil {
+		w, err = wire.NewValueMap(_Map_String_I32_MapItemList(v.Counts)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.Tags != nil {
+		w, err = wire.NewValueSet(_Set_String_ValueList(v.Tags)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.Name != nil {
+		w, err = wire.NewValueString(*(v.Name)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _List_Point_Read(l wire.ValueList) ([]*Point, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+	o := make([]*Point, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _Point_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _Map_String_I32_Read(m wire.MapItemList) (map[string]int32, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+	if m.ValueType() != wire.TI32 {
+		return nil, nil
+	}
+	o := make(map[string]int32, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		v, err := x.Value.GetI32(), error(nil)
+		if err != nil {
+			return err
+		}
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+func _Set_String_Read(s wire.ValueList) (map[string]struct{}, error) {
+	if s.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+	o := make(map[string]struct{}, s.Size())
+	err := s.ForEach(func(x wire.Value) error {
+		i, err := x.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		o[i] = struct{}{}
+		return nil
+	})
+	s.Close()
+	return o, err
+}
+
+func (v *PointStream) FromWire(w wire.Value) error {
+	var err error
+	pointsIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TList {
+				v.Points, err = _List_Point_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("PointStream", "Points", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				pointsIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TMap {
+				v.Counts, err = _Map_String_I32_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("PointStream", "Counts", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 3:
+			if field.Value.Type() == wire.TSet {
+				v.Tags, err = _Set_String_Read(field.Value.GetSet())
+				if err != nil {
+					wire.ObserveDecodeError("PointStream", "Tags", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 4:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Name = &x
+				if err != nil {
+					wire.ObserveDecodeError("PointStream", "Name", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	if !pointsIsSet {
+		wire.ObserveDecodeError("PointStream", "Points", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "PointStream", Field: "Points", ID: 1}
+	}
+	return nil
+}
+
+func (v *PointStream) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [4]string
+	i := 0
+	fields[i] = fmt.Sprintf("Points: %v", v.Points)
+	i++
+	if v.Counts != nil {
+		fields[i] = fmt.Sprintf("Counts: %v", v.Counts)
+		i++
+	}
+	if v.Tags != nil {
+		fields[i] = fmt.Sprintf("Tags: %v", v.Tags)
+		i++
+	}
+	if v.Name != nil {
+		fields[i] = fmt.Sprintf("Name: %v", *(v.Name))
+		i++
+	}
+	return fmt.Sprintf("PointStream{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_Point_Equals(lhs, rhs []*Point) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func _Map_String_I32_Equals(lhs, rhs map[string]int32) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func _Set_String_Equals(lhs, rhs map[string]struct{}) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for x := range rhs {
+		if _, ok := lhs[x]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *PointStream) Equals(rhs *PointStream) bool {
+	if !_List_Point_Equals(v.Points, rhs.Points) {
+		return false
+	}
+	if !((v.Counts == nil && rhs.Counts == nil) || (v.Counts != nil && rhs.Counts != nil && _Map_String_I32_Equals(v.Counts, rhs.Counts))) {
+		return false
+	}
+	if !((v.Tags == nil && rhs.Tags == nil) || (v.Tags != nil && rhs.Tags != nil && _Set_String_Equals(v.Tags, rhs.Tags))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Name, rhs.Name) {
+		return false
+	}
+	return true
+}
+
+func (v *PointStream) GetPoints() (o []*Point) {
+	if v != nil {
+		o = v.Points
+	}
+	return
+}
+
+func (v *PointStream) GetCounts() (o map[string]int32) {
+	if v != nil && v.Counts != nil {
+		return v.Counts
+	}
+	return
+}
+
+func (v *PointStream) GetTags() (o map[string]struct{}) {
+	if v != nil && v.Tags != nil {
+		return v.Tags
+	}
+	return
+}
+
+func (v *PointStream) GetName() (o string) {
+	if v != nil && v.Name != nil {
+		return *v.Name
+	}
+	return
+}
+
+type PointStream_Streams struct {
+	Points func(*Point) error
+	Counts func(string, int32) error
+	Tags   func(string) error
+}
+
+func (v *PointStream) FromWireStreams(w wire.Value, s PointStream_Streams) error {
+	fields := make([]wire.Field, 0, len(w.GetStruct().Fields))
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if s.Points != nil && field.Value.Type() == wire.TList {
+				l := field.Value.GetList()
+				if l.ValueType() == wire.TStruct {
+					err := l.ForEach(func(x wire.Value) error {
+						i, err := _Point_Read(x)
+						if err != nil {
+							return err
+						}
+						return s.Points(i)
+					})
+					l.Close()
+					if err != nil {
+						return err
+					}
+				}
+				field.Value = wire.NewValueList(wire.ValueListFromSlice(l.ValueType(), nil))
+			}
+		case 2:
+			if s.Counts != nil && field.Value.Type() == wire.TMap {
+				l := field.Value.GetMap()
+				if l.KeyType() == wire.TBinary && l.ValueType() == wire.TI32 {
+					err := l.ForEach(func(x wire.MapItem) error {
+						k, err := x.Key.GetString(), error(nil)
+						if err != nil {
+							return err
+						}
+						m, err := x.Value.GetI32(), error(nil)
+						if err != nil {
+							return err
+						}
+						return s.Counts(k, m)
+					})
+					l.Close()
+					if err != nil {
+						return err
+					}
+				}
+				field.Value = wire.NewValueMap(wire.MapItemListFromSlice(l.KeyType(), l.ValueType(), nil))
+			}
+		case 3:
+			if s.Tags != nil && field.Value.Type() == wire.TSet {
+				l := field.Value.GetSet()
+				if l.ValueType() == wire.TBinary {
+					err := l.ForEach(func(x wire.Value) error {
+						i, err := x.GetString(), error(nil)
+						if err != nil {
+							return err
+						}
+						return s.Tags(i)
+					})
+					l.Close()
+					if err != nil {
+						return err
+					}
+				}
+				field.Value = wire.NewValueSet(wire.ValueListFromSlice(l.ValueType(), nil))
+			}
+		}
+		fields = append(fields, field)
+	}
+	return v.FromWire(wire.NewValueStruct(wire.Struct{Fields: fields}))
+}
+
+type PrimitiveOptionalStruct struct {
+	BoolField   *bool    `json:"boolField,omitempty"`
+	ByteField   *int8    `json:"byteField,omitempty"`
+	Int16Field  *int16   `json:"int16Field,omitempty"`
+	Int32Field  *int32   `json:"int32Field,omitempty"`
+	Int64Field  *int64   `json:"int64Field,omitempty"`
+	DoubleField *float64 `json:"doubleField,omitempty"`
+	StringField *string  `json:"stringField,omitempty"`
+	BinaryField []byte   `json:"binaryField"`
+}
+
+func (v *PrimitiveOptionalStruct) ToWire() (wire.Value, error) {
+	var (
+		fields [8]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.BoolField != nil {
+		w, err = wire.NewValueBool(*(v.BoolField)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.ByteField != nil {
+		w, err = wire.NewValueI8(*(v.ByteField)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.Int16Field != nil {
+		w, err = wire.NewValueI16(*(v.Int16Field)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.Int32Field != nil {
+		w, err = wire.NewValueI32(*(v.Int32Field)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.Int64Field != nil {
+		w, err = wire.NewValueI64(*(v.Int64Field)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+	if v.DoubleField != nil {
+		w, err = wire.NewValueDouble(*(v.DoubleField)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 6, Value: w}
+		i++
+	}
+	if v.StringField != nil {
+		w, err = wire.NewValueString(*(v.StringField)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 7, Value: w}
+		i++
+	}
+	if v.BinaryField != nil {
+		w, err = wire.NewValueBinary(v.BinaryField), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 8, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *PrimitiveOptionalStruct) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.BoolField = &x
+				if err != nil {
+					wire.ObserveDecodeError("PrimitiveOptionalStruct", "BoolField", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 2:
+			if field.Value.Type() == wire.TI8 {
+				var x int8
+				x, err = field.Value.GetI8(), error(nil)
+				v.ByteField = &x
+				if err != nil {
+					wire.ObserveDecodeError("PrimitiveOptionalStruct", "ByteField", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 3:
+			if field.Value.Type() == wire.TI16 {
+				var x int16
+				x, err = field.Value.GetI16(), error(nil)
+				v.Int16Field = &x
+				if err != nil {
+					wire.ObserveDecodeError("PrimitiveOptionalStruct", "Int16Field", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 4:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Int32Field = &x
+				if err != nil {
+					wire.ObserveDecodeError("PrimitiveOptionalStruct", "Int32Field", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 5:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Int64Field = &x
+				if err != nil {
+					wire.ObserveDecodeError("PrimitiveOptionalStruct", "Int64Field", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 6:
+			if field.Value.Type() == wire.TDouble {
+				var x float64
+				x, err = field.Value.GetDouble(), error(nil)
+				v.DoubleField = &x
+				if err != nil {
+					wire.ObserveDecodeError("PrimitiveOptionalStruct", "DoubleField", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 7:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.StringField = &x
+				if err != nil {
+					wire.ObserveDecodeError("PrimitiveOptionalStruct", "StringField", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 8:
+			if field.Value.Type() == wire.TBinary {
+				v.BinaryField, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("PrimitiveOptionalStruct", "BinaryField", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *PrimitiveOptionalStruct) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [8]string
+	i := 0
+	if v.BoolField != nil {
+		fields[i] = fmt.Sprintf("BoolField: %v", *(v.BoolField))
+		i++
+	}
+	if v.ByteField != nil {
+		fields[i] = fmt.Sprintf("ByteField: %v", *(v.ByteField))
+		i++
+	}
+	if v.Int16Field != nil {
+		fields[i] = fmt.Sprintf("Int16Field: %v", *(v.Int16Field))
+		i++
+	}
+	if v.Int32Field != nil {
+		fields[i] = fmt.Sprintf("Int32Field: %v", *(v.Int32Field))
+		i++
+	}
+	if v.Int64Field != nil {
+		fields[i] = fmt.Sprintf("Int64Field: %v", *(v.Int64Field))
+		i++
+	}
+	if v.DoubleField != nil {
+		fields[i] = fmt.Sprintf("DoubleField: %v", *(v.DoubleField))
+		i++
+	}
+	if v.StringField != nil {
+		fields[i] = fmt.Sprintf("StringField: %v", *(v.StringField))
+		i++
+	}
+	if v.BinaryField != nil {
+		fields[i] = fmt.Sprintf("BinaryField: %v", v.BinaryField)
+		i++
+	}
+	return fmt.Sprintf("PrimitiveOptionalStruct{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Byte_EqualsPtr(lhs, rhs *int8) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _I16_EqualsPtr(lhs, rhs *int16) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _I64_EqualsPtr(lhs, rhs *int64) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _Double_EqualsPtr(lhs, rhs *float64) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func (v *PrimitiveOptionalStruct) Equals(rhs *PrimitiveOptionalStruct) bool {
+	if !_Bool_EqualsPtr(v.BoolField, rhs.BoolField) {
+		return false
+	}
+	if !_Byte_EqualsPtr(v.ByteField, rhs.ByteField) {
+		return false
+	}
+	if !_I16_EqualsPtr(v.Int16Field, rhs.Int16Field) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.Int32Field, rhs.Int32Field) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Int64Field, rhs.Int64Field) {
+		return false
+	}
+	if !_Double_EqualsPtr(v.DoubleField, rhs.DoubleField) {
+		return false
+	}
+	if !_String_EqualsPtr(v.StringField, rhs.StringField) {
+		return false
+	}
+	if !((v.BinaryField == nil && rhs.BinaryField == nil) || (v.BinaryField != nil && rhs.BinaryField != nil && bytes.Equal(v.BinaryField, rhs.BinaryField))) {
+		return false
+	}
+	return true
+}
+
+func (v *PrimitiveOptionalStruct) GetBoolField() (o bool) {
+	if v != nil && v.BoolField != nil {
+		return *v.BoolField
+	}
+	return
+}
+
+func (v *PrimitiveOptionalStruct) GetByteField() (o int8) {
+	if v != nil && v.ByteField != nil {
+		return *v.ByteField
+	}
+	return
+}
+
+func (v *PrimitiveOptionalStruct) GetInt16Field() (o int16) {
+	if v != nil && v.Int16Field != nil {
+		return *v.Int16Field
+	}
+	return
+}
+
+func (v *PrimitiveOptionalStruct) GetInt32Field() (o int32) {
+	if v != nil && v.Int32Field != nil {
+		return *v.Int32Field
+	}
+	return
+}
+
+func (v *PrimitiveOptionalStruct) GetInt64Field() (o int64) {
+	if v != nil && v.Int64Field != nil {
+		return *v.Int64Field
+	}
+	return
+}
+
+func (v *PrimitiveOptionalStruct) GetDoubleField() (o float64) {
+	if v != nil && v.DoubleField != nil {
+		return *v.DoubleField
+	}
+	return
+}
+
+func (v *PrimitiveOptionalStruct) GetStringField() (o string) {
+	if v != nil && v.StringField != nil {
+		return *v.StringField
+	}
+	return
+}
+
+func (v *PrimitiveOptionalStruct) GetBinaryField() (o []byte) {
+	if v != nil && v.BinaryField != nil {
+		return v.BinaryField
+	}
+	return
+}
+
+type PrimitiveRequiredStruct struct {
+	BoolField   bool    `json:"boolField"`
+	ByteField   int8    `json:"byteField"`
+	Int16Field  int16   `json:"int16Field"`
+	Int32Field  int32   `json:"int32Field"`
+	Int64Field  int64   `json:"int64Field"`
+	DoubleField float64 `json:"doubleField"`
+	StringField string  `json:"stringField"`
+	BinaryField []byte  `json:"binaryField"`
+}
+
+func (v *PrimitiveRequiredStruct) ToWire() (wire.Value, error) {
+	var (
+		fields [8]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueBool(v.BoolField), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	w, err = wire.NewValueI8(v.ByteField), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 2, Value: w}
+	i++
+	w, err = wire.NewValueI16(v.Int16Field), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 3, Value: w}
+	i++
+	w, err = wire.NewValueI32(v.Int32Field), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 4, Value: w}
+	i++
+	w, err = wire.NewValueI64(v.Int64Field), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 5, Value: w}
+	i++
+	w, err = wire.NewValueDouble(v.DoubleField), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 6, Value: w}
+	i++
+	w, err = wire.NewValueString(v.StringField), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 7, Value: w}
+	i++
+	if v.BinaryField == nil {
+		return w, wire.NilRequiredFieldError{Struct: "PrimitiveRequiredStruct", Field: "BinaryField", ID: 8}
+	}
+	w, err = wire.NewValueBinary(v.BinaryField), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 8, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *PrimitiveRequiredStruct) FromWire(w wire.Value) error {
+	var err error
+	boolFieldIsSet := false
+	byteFieldIsSet := false
+	int16FieldIsSet := false
+	int32FieldIsSet := false
+	int64FieldIsSet := false
+	doubleFieldIsSet := false
+	stringFieldIsSet := false
+	binaryFieldIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBool {
+				v.BoolField, err = field.Value.GetBool(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("PrimitiveRequiredStruct", "BoolField", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				boolFieldIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TI8 {
+				v.ByteField, err = field.Value.GetI8(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("PrimitiveRequiredStruct", "ByteField", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				byteFieldIsSet = true
+			}
+		case 3:
+			if field.Value.Type() == wire.TI16 {
+				v.Int16Field, err = field.Value.GetI16(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("PrimitiveRequiredStruct", "Int16Field", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				int16FieldIsSet = true
+			}
+		case 4:
+			if field.Value.Type() == wire.TI32 {
+				v.Int32Field, err = field.Value.GetI32(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("PrimitiveRequiredStruct", "Int32Field", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				int32FieldIsSet = true
+			}
+		case 5:
+			if field.Value.Type() == wire.TI64 {
+				v.Int64Field, err = field.Value.GetI64(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("PrimitiveRequiredStruct", "Int64Field", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				int64FieldIsSet = true
+			}
+		case 6:
+			if field.Value.Type() == wire.TDouble {
+				v.DoubleField, err = field.Value.GetDouble(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("PrimitiveRequiredStruct", "DoubleField", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				doubleFieldIsSet = true
+			}
+		case 7:
+			if field.Value.Type() == wire.TBinary {
+				v.StringField, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("PrimitiveRequiredStruct", "StringField", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				stringFieldIsSet = true
+			}
+		case 8:
+			if field.Value.Type() == wire.TBinary {
+				v.BinaryField, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("PrimitiveRequiredStruct", "BinaryField", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				binaryFieldIsSet = true
+			}
+		}
+	}
+	if !boolFieldIsSet {
+		wire.ObserveDecodeError("PrimitiveRequiredStruct", "BoolField", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "PrimitiveRequiredStruct", Field: "BoolField", ID: 1}
+	}
+	if !byteFieldIsSet {
+		wire.ObserveDecodeError("PrimitiveRequiredStruct", "ByteField", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "PrimitiveRequiredStruct", Field: "ByteField", ID: 2}
+	}
+	if !int16FieldIsSet {
+		wire.ObserveDecodeError("PrimitiveRequiredStruct", "Int16Field", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "PrimitiveRequiredStruct", Field: "Int16Field", ID: 3}
+	}
+	if !int32FieldIsSet {
+		wire.ObserveDecodeError("PrimitiveRequiredStruct", "Int32Field", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "PrimitiveRequiredStruct", Field: "Int32Field", ID: 4}
+	}
+	if !int64FieldIsSet {
+		wire.ObserveDecodeError("PrimitiveRequiredStruct", "Int64Field", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "PrimitiveRequiredStruct", Field: "Int64Field", ID: 5}
+	}
+	if !doubleFieldIsSet {
+		wire.ObserveDecodeError("PrimitiveRequiredStruct", "DoubleField", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "PrimitiveRequiredStruct", Field: "DoubleField", ID: 6}
+	}
+	if !stringFieldIsSet {
+		wire.ObserveDecodeError("PrimitiveRequiredStruct", "StringField", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "PrimitiveRequiredStruct", Field: "StringField", ID: 7}
+	}
+	if !binaryFieldIsSet {
+		wire.ObserveDecodeError("PrimitiveRequiredStruct", "BinaryField", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "PrimitiveRequiredStruct", Field: "BinaryField", ID: 8}
+	}
+	return nil
+}
+
+func (v *PrimitiveRequiredStruct) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [8]string
+	i := 0
+	fields[i] = fmt.Sprintf("BoolField: %v", v.BoolField)
+	i++
+	fields[i] = fmt.Sprintf("ByteField: %v", v.ByteField)
+	i++
+	fields[i] = fmt.Sprintf("Int16Field: %v", v.Int16Field)
+	i++
+	fields[i] = fmt.Sprintf("Int32Field: %v", v.Int32Field)
+	i++
+	fields[i] = fmt.Sprintf("Int64Field: %v", v.Int64Field)
+	i++
+	fields[i] = fmt.Sprintf("DoubleField: %v", v.DoubleField)
+	i++
+	fields[i] = fmt.Sprintf("StringField: %v", v.StringField)
+	i++
+	fields[i] = fmt.Sprintf("BinaryField: %v", v.BinaryField)
+	i++
+	return fmt.Sprintf("PrimitiveRequiredStruct{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *PrimitiveRequiredStruct) Equals(rhs *PrimitiveRequiredStruct) bool {
+	if !(v.BoolField == rhs.BoolField) {
+		return false
+	}
+	if !(v.ByteField == rhs.ByteField) {
+		return false
+	}
+	if !(v.Int16Field == rhs.Int16Field) {
+		return false
+	}
+	if !(v.Int32Field == rhs.Int32Field) {
+		return false
+	}
+	if !(v.Int64Field == rhs.Int64Field) {
+		return false
+	}
+	if !(v.DoubleField == rhs.DoubleField) {
+		return false
+	}
+	if !(v.StringField == rhs.StringField) {
+		return false
+	}
+	if !bytes.Equal(v.BinaryField, rhs.BinaryField) {
+		return false
+	}
+	return true
+}
+
+func (v *PrimitiveRequiredStruct) GetBoolField() (o bool) {
+	if v != nil {
+		o = v.BoolField
+	}
+	return
+}
+
+func (v *PrimitiveRequiredStruct) GetByteField() (o int8) {
+	if v != nil {
+		o = v.ByteField
+	}
+	return
+}
+
+func (v *PrimitiveRequiredStruct) GetInt16Field() (o int16) {
+	if v != nil {
+		o = v.Int16Field
+	}
+	return
+}
+
+func (v *PrimitiveRequiredStruct) GetInt32Field() (o int32) {
+	if v != nil {
+		o = v.Int32Field
+	}
+	return
+}
+
+func (v *PrimitiveRequiredStruct) GetInt64Field() (o int64) {
+	if v != nil {
+		o = v.Int64Field
+	}
+	return
+}
+
+func (v *PrimitiveRequiredStruct) GetDoubleField() (o float64) {
+	if v != nil {
+		o = v.DoubleField
+	}
+	return
+}
+
+func (v *PrimitiveRequiredStruct) GetStringField() (o string) {
+	if v != nil {
+		o = v.StringField
+	}
+	return
+}
+
+func (v *PrimitiveRequiredStruct) GetBinaryField() (o []byte) {
+	if v != nil {
+		o = v.BinaryField
+	}
+	return
+}
+
+type Request struct {
+	Headers map[string][]byte `json:"headers"`
+	Body    *string           `json:"body,omitempty"`
+}
+
+type _Map_String_Binary_MapItemList map[string][]byte
+
+func (m _Map_String_Binary_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		if v == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", k)
+		}
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
+		vw, err := wire.NewValueBinary(v), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_String_Binary_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_String_Binary_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_Binary_MapItemList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_Binary_MapItemList) Close() {
+}
+
+func (v *Request) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Headers != nil {
+		w, err = wire.NewValueMap(_Map_String_Binary_MapItemList(v.Headers)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.Body != nil {
+		w, err = wire.NewValueString(*(v.Body)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Map_String_Binary_Read(m wire.MapItemList) (map[string][]byte, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+	if m.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+	o := make(map[string][]byte, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		v, err := x.Value.GetBinary(), error(nil)
+		if err != nil {
+			return err
+		}
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+func (v *Request) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TMap {
+				v.Headers, err = _Map_String_Binary_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("Request", "Headers", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 2:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Body = &x
+				if err != nil {
+					wire.ObserveDecodeError("Request", "Body", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *Request) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	if v.Headers != nil {
+		fields[i] = fmt.Sprintf("Headers: %v", v.Headers)
+		i++
+	}
+	if v.Body != nil {
+		fields[i] = fmt.Sprintf("Body: %v", *(v.Body))
+		i++
+	}
+	return fmt.Sprintf("Request{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Map_String_Binary_Equals(lhs, rhs map[string][]byte) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !bytes.Equal(lv, rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *Request) Equals(rhs *Request) bool {
+	if !((v.Headers == nil && rhs.Headers == nil) || (v.Headers != nil && rhs.Headers != nil && _Map_String_Binary_Equals(v.Headers, rhs.Headers))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Body, rhs.Body) {
+		return false
+	}
+	return true
+}
+
+func (v *Request) GetHeaders() (o map[string][]byte) {
+	if v != nil && v.Headers != nil {
+		return v.Headers
+	}
+	return
+}
+
+func (v *Request) GetBody() (o string) {
+	if v != nil && v.Body != nil {
+		return *v.Body
+	}
+	return
+}
+
+func (v *Request) GetHeader(key string) (value []byte, ok bool) {
+	if v != nil {
+		value, ok = v.Headers[key]
+	}
+	return
+}
+
+func (v *Request) SetHeader(key string, value []byte) {
+	if v.Headers == nil {
+		v.Headers = make(map[string][]byte)
+	}
+	v.Headers[key] = value
+}
+
+func (v *Request) DeleteHeader(key string) {
+	delete(v.Headers, key)
+}
+
+func (v *Request) GetCaller() (string, bool) {
+	value, ok := v.GetHeader("x-caller")
+	return string(value), ok
+}
+
+func (v *Request) SetCaller(x string) {
+	v.SetHeader("x-caller", []byte(x))
+}
+
+func (v *Request) GetDeadline() (int64, bool, error) {
+	value, ok := v.GetHeader("x-deadline")
+	if !ok {
+		return 0, false, nil
+	}
+	x, err := strconv.ParseInt(string(value), 10, 64)
+	return x, true, err
+}
+
+func (v *Request) SetDeadline(x int64) {
+	v.SetHeader("x-deadline", []byte(strconv.FormatInt(x, 10)))
+}
+
+func (v *Request) GetToken() ([]byte, bool) {
+	value, ok := v.GetHeader("x-token")
+	return value, ok
+}
+
+func (v *Request) SetToken(x []byte) {
+	v.SetHeader("x-token", x)
+}
+
+func (v *Request) GetTraced() (bool, bool, error) {
+	value, ok := v.GetHeader("x-traced")
+	if !ok {
+		return false, false, nil
+	}
+	x, err := strconv.ParseBool(string(value))
+	return x, true, err
+}
+
+func (v *Request) SetTraced(x bool) {
+	v.SetHeader("x-traced", []byte(strconv.FormatBool(x)))
+}
+
+type Size struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+func (v *Size) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueDouble(v.Width), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	w, err = wire.NewValueDouble(v.Height), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 2, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *Size) FromWire(w wire.Value) error {
+	var err error
+	widthIsSet := false
+	heightIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TDouble {
+				v.Width, err = field.Value.GetDouble(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("Size", "Width", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				widthIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TDouble {
+				v.Height, err = field.Value.GetDouble(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("Size", "Height", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				heightIsSet = true
+			}
+		}
+	}
+	if !widthIsSet {
+		wire.ObserveDecodeError("Size", "Width", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Size", Field: "Width", ID: 1}
+	}
+	if !heightIsSet {
+		wire.ObserveDecodeError("Size", "Height", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Size", Field: "Height", ID: 2}
+	}
+	return nil
+}
+
+func (v *Size) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	fields[i] = fmt.Sprintf("Width: %v", v.Width)
+	i++
+	fields[i] = fmt.Sprintf("Height: %v", v.Height)
+	i++
+	return fmt.Sprintf("Size{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *Size) Equals(rhs *Size) bool {
+	if !(v.Width == rhs.Width) {
+		return false
+	}
+	if !(v.Height == rhs.Height) {
+		return false
+	}
+	return true
+}
+
+func (v *Size) GetWidth() (o float64) {
+	if v != nil {
+		o = v.Width
+	}
+	return
+}
+
+func (v *Size) GetHeight() (o float64) {
+	if v != nil {
+		o = v.Height
+	}
+	return
+}
+
+type TextHeaders struct {
+	Values map[string]string `json:"values"`
+}
+
+type _Map_String_String_MapItemList map[string]string
+
+func (m _Map_String_String_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
+		vw, err := wire.NewValueString(v), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_String_String_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_String_String_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_String_MapItemList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_String_MapItemList) Close() {
+}
+
+func (v *TextHeaders) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Values == nil {
+		return w, wire.NilRequiredFieldError{Struct: "TextHeaders", Field: "Values", ID: 1}
+	}
+	w, err = wire.NewValueMap(_Map_String_String_MapItemList(v.Values)), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Map_String_String_Read(m wire.MapItemList) (map[string]string, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+	if m.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+	o := make(map[string]string, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		v, err := x.Value.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+func (v *TextHeaders) FromWire(w wire.Value) error {
+	var err error
+	valuesIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TMap {
+				v.Values, err = _Map_String_String_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("TextHeaders", "Values", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				valuesIsSet = true
+			}
+		}
+	}
+	if !valuesIsSet {
+		wire.ObserveDecodeError("TextHeaders", "Values", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "TextHeaders", Field: "Values", ID: 1}
+	}
+	return nil
+}
+
+func (v *TextHeaders) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	fields[i] = fmt.Sprintf("Values: %v", v.Values)
+	i++
+	return fmt.Sprintf("TextHeaders{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Map_String_String_Equals(lhs, rhs map[string]string) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *TextHeaders) Equals(rhs *TextHeaders) bool {
+	if !_Map_String_String_Equals(v.Values, rhs.Values) {
+		return false
+	}
+	return true
+}
+
+func (v *TextHeaders) GetValues() (o map[string]string) {
+	if v != nil {
+		o = v.Values
+	}
+	return
+}
+
+func (v *TextHeaders) GetHeader(key string) (value string, ok bool) {
+	if v != nil {
+		value, ok = v.Values[key]
+	}
+	return
+}
+
+func (v *TextHeaders) SetHeader(key string, value string) {
+	if v.Values == nil {
+		v.Values = make(map[string]string)
+	}
+	v.Values[key] = value
+}
+
+func (v *TextHeaders) DeleteHeader(key string) {
+	delete(v.Values, key)
+}
+
+func (v *TextHeaders) GetPriority() (int32, bool, error) {
+	value, ok := v.GetHeader("priority")
+	if !ok {
+		return 0, false, nil
+	}
+	x, err := strconv.ParseInt(value, 10, 32)
+	return int32(x), true, err
+}
+
+func (v *TextHeaders) SetPriority(x int32) {
+	v.SetHeader("priority", strconv.FormatInt(int64(x), 10))
+}
+
+func (v *TextHeaders) GetRaw() ([]byte, bool) {
+	value, ok := v.GetHeader("raw")
+	return []byte(value), ok
+}
+
+func (v *TextHeaders) SetRaw(x []byte) {
+	v.SetHeader("raw", string(x))
+}
+
+func (v *TextHeaders) GetWeight() (float64, bool, error) {
+	value, ok := v.GetHeader("weight")
+	if !ok {
+		return 0, false, nil
+	}
+	x, err := strconv.ParseFloat(value, 64)
+	return x, true, err
+}
+
+func (v *TextHeaders) SetWeight(x float64) {
+	v.SetHeader("weight", strconv.FormatFloat(x, 'g', -1, 64))
+}
+
+// self-referential through containers
+type Tree struct {
+	Value    string           `json:"value"`
+	Left     *Tree            `json:"left,omitempty"`
+	Right    *Tree            `json:"right,omitempty"`
+	Children []*Tree          `json:"children"`
+	Named    map[string]*Tree `json:"named"`
+}
+
+type _List_Tree_ValueList []*Tree
+
+func (v _List_Tree_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_Tree_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_Tree_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_Tree_ValueList) Close() {
+}
+
+type _Map_String_Tree_MapItemList map[string]*Tree
+
+func (m _Map_String_Tree_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		if v == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", k)
+		}
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
+		vw, err := v.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_String_Tree_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_String_Tree_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_Tree_MapItemList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_Map_String_Tree_MapItemList) Close() {
+}
+
+func (v *Tree) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueString(v.Value), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.Left != nil {
+		w, err = v.Left.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.Right != nil {
+		w, err = v.Right.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.Children != nil {
+		w, err = wire.NewValueList(_List_Tree_ValueList(v.Children)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.Named != nil {
+		w, err = wire.NewValueMap(_Map_String_Tree_MapItemList(v.Named)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Tree_Read(w wire.Value) (*Tree, error) {
+	var v Tree
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_Tree_Read(l wire.ValueList) ([]*Tree, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+	o := make([]*Tree, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _Tree_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _Map_String_Tree_Read(m wire.MapItemList) (map[string]*Tree, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+	if m.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+	o := make(map[string]*Tree, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		v, err := _Tree_Read(x.Value)
+		if err != nil {
+			return err
+		}
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+func (v *Tree) FromWire(w wire.Value) error {
+	var err error
+	valueIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.Value, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("Tree", "Value", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				valueIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.Left, err = _Tree_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("Tree", "Left", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 3:
+			if field.Value.Type() == wire.TStruct {
+				v.Right, err = _Tree_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("Tree", "Right", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 4:
+			if field.Value.Type() == wire.TList {
+				v.Children, err = _List_Tree_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("Tree", "Children", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 5:
+			if field.Value.Type() == wire.TMap {
+				v.Named, err = _Map_String_Tree_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("Tree", "Named", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	if !valueIsSet {
+		wire.ObserveDecodeError("Tree", "Value", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Tree", Field: "Value", ID: 1}
+	}
+	return nil
+}
+
+func (v *Tree) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [5]string
+	i := 0
+	fields[i] = fmt.Sprintf("Value: %v", v.Value)
+	i++
+	if v.Left != nil {
+		fields[i] = fmt.Sprintf("Left: %v", v.Left)
+		i++
+	}
+	if v.Right != nil {
+		fields[i] = fmt.Sprintf("Right: %v", v.Right)
+		i++
+	}
+	if v.Children != nil {
+		fields[i] = fmt.Sprintf("Children: %v", v.Children)
+		i++
+	}
+	if v.Named != nil {
+		fields[i] = fmt.Sprintf("Named: %v", v.Named)
+		i++
+	}
+	return fmt.Sprintf("Tree{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_Tree_Equals(lhs, rhs []*Tree) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func _Map_String_Tree_Equals(lhs, rhs map[string]*Tree) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *Tree) Equals(rhs *Tree) bool {
+	if !(v.Value == rhs.Value) {
+		return false
+	}
+	if !((v.Left == nil && rhs.Left == nil) || (v.Left != nil && rhs.Left != nil && v.Left.Equals(rhs.Left))) {
+		return false
+	}
+	if !((v.Right == nil && rhs.Right == nil) || (v.Right != nil && rhs.Right != nil && v.Right.Equals(rhs.Right))) {
+		return false
+	}
+	if !((v.Children == nil && rhs.Children == nil) || (v.Children != nil && rhs.Children != nil && _List_Tree_Equals(v.Children, rhs.Children))) {
+		return false
+	}
+	if !((v.Named == nil && rhs.Named == nil) || (v.Named != nil && rhs.Named != nil && _Map_String_Tree_Equals(v.Named, rhs.Named))) {
+		return false
+	}
+	return true
+}
+
+func (v *Tree) GetValue() (o string) {
+	if v != nil {
+		o = v.Value
+	}
+	return
+}
+
+func (v *Tree) GetLeft() (o *Tree) {
+	if v != nil && v.Left != nil {
+		return v.Left
+	}
+	return
+}
+
+func (v *Tree) GetRight() (o *Tree) {
+	if v != nil && v.Right != nil {
+		return v.Right
+	}
+	return
+}
+
+func (v *Tree) GetChildren() (o []*Tree) {
+	if v != nil && v.Children != nil {
+		return v.Children
+	}
+	return
+}
+
+func (v *Tree) GetNamed() (o map[string]*Tree) {
+	if v != nil && v.Named != nil {
+		return v.Named
+	}
+	return
+}
+
+type UUIDStruct struct {
+	ID        uuid.UUID              `json:"id"`
+	ParentID  *uuid.UUID             `json:"parentID,omitempty"`
+	Children  []uuid.UUID            `json:"children"`
+	Tags      map[uuid.UUID]struct{} `json:"tags"`
+	Names     map[uuid.UUID]string   `json:"names"`
+	DefaultID *uuid.UUID             `json:"defaultID,omitempty"`
+	// uuid is not a keyword so it may still be used as a field name.
+	UUID *string `json:"uuid,omitempty"`
+}
+
+type _List_UUID_ValueList []uuid.UUID
+
+func (v _List_UUID_ValueList) ForEach(f func(wire.Value) error) error {
+	for _, x := range v {
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_UUID_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_UUID_ValueList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_List_UUID_ValueList) Close() {
+}
+
+type _Set_UUID_ValueList map[uuid.UUID]struct{}
+
+func (v _Set_UUID_ValueList) ForEach(f func(wire.Value) error) error {
+	for x := range v {
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _Set_UUID_ValueList) Size() int {
+	return len(v)
+}
+
+func (_Set_UUID_ValueList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Set_UUID_ValueList) Close() {
+}
+
+type _Map_UUID_String_MapItemList map[uuid.UUID]string
+
+func (m _Map_UUID_String_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		kw, err := k.ToWire()
+		if err != nil {
+			return err
+		}
+		vw, err := wire.NewValueString(v), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_UUID_String_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_UUID_String_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_UUID_String_MapItemList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_UUID_String_MapItemList) Close() {
+}
+
+func _UUID_ptr(v uuid.UUID) *uuid.UUID {
+	return &v
+}
+
+func (v *UUIDStruct) ToWire() (wire.Value, error) {
+	var (
+		fields [7]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = v.ID.ToWire()
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.ParentID != nil {
+		w, err = v.ParentID.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.Children != nil {
+		w, err = wire.NewValueList(_List_UUID_ValueList(v.Children)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.Tags != nil {
+		w, err = wire.NewValueSet(_Set_UUID_ValueList(v.Tags)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.Names != nil {
+		w, err = wire.NewValueMap(_Map_UUID_String_MapItemList(v.Names)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+	if v.DefaultID == nil {
+		v.DefaultID = _UUID_ptr(uuid.UUID{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00})
+	}
+	{
+		w, err = v.DefaultID.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 6, Value: w}
+		i++
+	}
+	if v.UUID != nil {
+		w, err = wire.NewValueString(*(v.UUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 7, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _List_UUID_Read(l wire.ValueList) ([]uuid.UUID, error) {
+	if l.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+	o := make([]uuid.UUID, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := uuid.FromBytes(x.GetBinary())
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _Set_UUID_Read(s wire.ValueList) (map[uuid.UUID]struct{}, error) {
+	if s.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+	o := make(map[uuid.UUID]struct{}, s.Size())
+	err := s.ForEach(func(x wire.Value) error {
+		i, err := uuid.FromBytes(x.GetBinary())
+		if err != nil {
+			return err
+		}
+		o[i] = struct{}{}
+		return nil
+	})
+	s.Close()
+	return o, err
+}
+
+func _Map_UUID_String_Read(m wire.MapItemList) (map[uuid.UUID]string, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+	if m.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+	o := make(map[uuid.UUID]string, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := uuid.FromBytes(x.Key.GetBinary())
+		if err != nil {
+			return err
+		}
+		v, err := x.Value.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+func (v *UUIDStruct) FromWire(w wire.Value) error {
+	var err error
+	idIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.ID, err = uuid.FromBytes(field.Value.GetBinary())
+				if err != nil {
+					wire.ObserveDecodeError("UUIDStruct", "ID", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				idIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TBinary {
+				var x uuid.UUID
+				x, err = uuid.FromBytes(field.Value.GetBinary())
+				v.ParentID = &x
+				if err != nil {
+					wire.ObserveDecodeError("UUIDStruct", "ParentID", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 3:
+			if field.Value.Type() == wire.TList {
+				v.Children, err = _List_UUID_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("UUIDStruct", "Children", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 4:
+			if field.Value.Type() == wire.TSet {
+				v.Tags, err = _Set_UUID_Read(field.Value.GetSet())
+				if err != nil {
+					wire.ObserveDecodeError("UUIDStruct", "Tags", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 5:
+			if field.Value.Type() == wire.TMap {
+				v.Names, err = _Map_UUID_String_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("UUIDStruct", "Names", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 6:
+			if field.Value.Type() == wire.TBinary {
+				var x uuid.UUID
+				x, err = uuid.FromBytes(field.Value.GetBinary())
+				v.DefaultID = &x
+				if err != nil {
+					wire.ObserveDecodeError("UUIDStruct", "DefaultID", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 7:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.UUID = &x
+				if err != nil {
+					wire.ObserveDecodeError("UUIDStruct", "UUID", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	if !idIsSet {
+		wire.ObserveDecodeError("UUIDStruct", "ID", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "UUIDStruct", Field: "ID", ID: 1}
+	}
+	if v.DefaultID == nil {
+		v.DefaultID = _UUID_ptr(uuid.UUID{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00})
+	}
+	return nil
+}
+
+func (v *UUIDStruct) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [7]string
+	i := 0
+	fields[i] = fmt.Sprintf("ID: %v", v.ID)
+	i++
+	if v.ParentID != nil {
+		fields[i] = fmt.Sprintf("ParentID: %v", *(v.ParentID))
+		i++
+	}
+	if v.Children != nil {
+		fields[i] = fmt.Sprintf("Children: %v", v.Children)
+		i++
+	}
+	if v.Tags != nil {
+		fields[i] = fmt.Sprintf("Tags: %v", v.Tags)
+		i++
+	}
+	if v.Names != nil {
+		fields[i] = fmt.Sprintf("Names: %v", v.Names)
+		i++
+	}
+	if v.DefaultID != nil {
+		fields[i] = fmt.Sprintf("DefaultID: %v", *(v.DefaultID))
+		i++
+	}
+	if v.UUID != nil {
+		fields[i] = fmt.Sprintf("UUID: %v", *(v.UUID))
+		i++
+	}
+	return fmt.Sprintf("UUIDStruct{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _UUID_EqualsPtr(lhs, rhs *uuid.UUID) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _List_UUID_Equals(lhs, rhs []uuid.UUID) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func _Set_UUID_Equals(lhs, rhs map[uuid.UUID]struct{}) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for x := range rhs {
+		if _, ok := lhs[x]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func _Map_UUID_String_Equals(lhs, rhs map[uuid.UUID]string) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *UUIDStruct) Equals(rhs *UUIDStruct) bool {
+	if !(v.ID == rhs.ID) {
+		return false
+	}
+	if !_UUID_EqualsPtr(v.ParentID, rhs.ParentID) {
+		return false
+	}
+	if !((v.Children == nil && rhs.Children == nil) || (v.Children != nil && rhs.Children != nil && _List_UUID_Equals(v.Children, rhs.Children))) {
+		return false
+	}
+	if !((v.Tags == nil && rhs.Tags == nil) || (v.Tags != nil && rhs.Tags != nil && _Set_UUID_Equals(v.Tags, rhs.Tags))) {
+		return false
+	}
+	if !((v.Names == nil && rhs.Names == nil) || (v.Names != nil && rhs.Names != nil && _Map_UUID_String_Equals(v.Names, rhs.Names))) {
+		return false
+	}
+	if !_UUID_EqualsPtr(v.DefaultID, rhs.DefaultID) {
+		return false
+	}
+	if !_String_EqualsPtr(v.UUID, rhs.UUID) {
+		return false
+	}
+	return true
+}
+
+func (v *UUIDStruct) GetID() (o uuid.UUID) {
+	if v != nil {
+		o = v.ID
+	}
+	return
+}
+
+func (v *UUIDStruct) GetParentID() (o uuid.UUID) {
+	if v != nil && v.ParentID != nil {
+		return *v.ParentID
+	}
+	return
+}
+
+func (v *UUIDStruct) GetChildren() (o []uuid.UUID) {
+	if v != nil && v.Children != nil {
+		return v.Children
+	}
+	return
+}
+
+func (v *UUIDStruct) GetTags() (o map[uuid.UUID]struct{}) {
+	if v != nil && v.Tags != nil {
+		return v.Tags
+	}
+	return
+}
+
+func (v *UUIDStruct) GetNames() (o map[uuid.UUID]string) {
+	if v != nil && v.Names != nil {
+		return v.Names
+	}
+	return
+}
+
+func (v *UUIDStruct) GetDefaultID() (o uuid.UUID) {
+	if v != nil && v.DefaultID != nil {
+		return *v.DefaultID
+	}
+	o = uuid.UUID{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	return
+}
+
+func (v *UUIDStruct) GetUUID() (o string) {
+	if v != nil && v.UUID != nil {
+		return *v.UUID
+	}
+	return
+}
+
+type User struct {
+	Name    string       `json:"name"`
+	Contact *ContactInfo `json:"contact,omitempty"`
+}
+
+func (v *User) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueString(v.Name), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.Contact != nil {
+		w, err = v.Contact.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ContactInfo_Read(w wire.Value) (*ContactInfo, error) {
+	var v ContactInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func (v *User) FromWire(w wire.Value) error {
+	var err error
+	nameIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.Name, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("User", "Name", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				nameIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.Contact, err = _ContactInfo_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("User", "Contact", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	if !nameIsSet {
+		wire.ObserveDecodeError("User", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "User", Field: "Name", ID: 1}
+	}
+	return nil
+}
+
+func (v *User) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	fields[i] = fmt.Sprintf("Name: %v", v.Name)
+	i++
+	if v.Contact != nil {
+		fields[i] = fmt.Sprintf("Contact: %v", v.Contact)
+		i++
+	}
+	return fmt.Sprintf("User{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *User) Equals(rhs *User) bool {
+	if !(v.Name == rhs.Name) {
+		return false
+	}
+	if !((v.Contact == nil && rhs.Contact == nil) || (v.Contact != nil && rhs.Contact != nil && v.Contact.Equals(rhs.Contact))) {
+		return false
+	}
+	return true
+}
+
+func (v *User) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
+}
+
+func (v *User) GetContact() (o *ContactInfo) {
+	if v != nil && v.Contact != nil {
+		return v.Contact
+	}
+	return
+}
+
+type UserV1 struct {
+	Name      string   `json:"name"`
+	Age       *int32   `json:"age,omitempty"`
+	Email     *string  `json:"email,omitempty"`
+	ID        int64    `json:"id"`
+	Nicknames []string `json:"nicknames"`
+	Active    bool     `json:"active"`
+}
+
+func (v *UserV1) ToWire() (wire.Value, error) {
+	var (
+		fields [6]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueString(v.Name), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.Age != nil {
+		w, err = wire.NewValueI32(*(v.Age)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.Email != nil {
+		w, err = wire.NewValueString(*(v.Email)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	w, err = wire.NewValueI64(v.ID), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 4, Value: w}
+	i++
+	if v.Nicknames != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.Nicknames)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+	w, err = wire.NewValueBool(v.Active), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 7, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *UserV1) FromWire(w wire.Value) error {
 	var err error
-	edgesIsSet := false
+	nameIsSet := false
+	idIsSet := false
+	activeIsSet := false
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.Name, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("UserV1", "Name", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				nameIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Age = &x
+				if err != nil {
+					wire.ObserveDecodeError("UserV1", "Age", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 3:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Email = &x
+				if err != nil {
+					wire.ObserveDecodeError("UserV1", "Email", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 4:
+			if field.Value.Type() == wire.TI64 {
+				v.ID, err = field.Value.GetI64(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("UserV1", "ID", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				idIsSet = true
+			}
+		case 5:
 			if field.Value.Type() == wire.TList {
-				v.Edges, err = _List_Edge_Read(field.Value.GetList())
+				v.Nicknames, err = _List_String_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("UserV1", "Nicknames", wire.DecodeErrorInvalidValue)
 					return err
 				}
-				edgesIsSet = true
+			}
+		case 7:
+			if field.Value.Type() == wire.TBool {
+				v.Active, err = field.Value.GetBool(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("UserV1", "Active", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				activeIsSet = true
 			}
 		}
 	}
-	if !edgesIsSet {
-		return errors.New("field Edges of Graph is required")
+	if !nameIsSet {
+		wire.ObserveDecodeError("UserV1", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "UserV1", Field: "Name", ID: 1}
+	}
+	if !idIsSet {
+		wire.ObserveDecodeError("UserV1", "ID", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "UserV1", Field: "ID", ID: 4}
+	}
+	if !activeIsSet {
+		wire.ObserveDecodeError("UserV1", "Active", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "UserV1", Field: "Active", ID: 7}
 	}
 	return nil
 }
 
-func (v *Graph) String() string {
+func (v *UserV1) String() string {
 	if v == nil {
 		return "<nil>"
 	}
-	var fields [1]string
+	var fields [6]string
 	i := 0
-	fields[i] = fmt.Sprintf("Edges: %v", v.Edges)
+	fields[i] = fmt.Sprintf("Name: %v", v.Name)
 	i++
-	return fmt.Sprintf("Graph{%v}", strings.Join(fields[:i], ", "))
+	if v.Age != nil {
+		fields[i] = fmt.Sprintf("Age: %v", *(v.Age))
+		i++
+	}
+	if v.Email != nil {
+		fields[i] = fmt.Sprintf("Email: %v", *(v.Email))
+		i++
+	}
+	fields[i] = fmt.Sprintf("ID: %v", v.ID)
+	i++
+	if v.Nicknames != nil {
+		fields[i] = fmt.Sprintf("Nicknames: %v", v.Nicknames)
+		i++
+	}
+	fields[i] = fmt.Sprintf("Active: %v", v.Active)
+	i++
+	return fmt.Sprintf("UserV1{%v}", strings.Join(fields[:i], ", "))
 }
 
-func _List_Edge_Equals(lhs, rhs []*Edge) bool {
-	if len(lhs) != len(rhs) {
+func (v *UserV1) Equals(rhs *UserV1) bool {
+	if !(v.Name == rhs.Name) {
 		return false
 	}
-	for i, lv := range lhs {
-		rv := rhs[i]
-		if !lv.Equals(rv) {
-			return false
-		}
+	if !_I32_EqualsPtr(v.Age, rhs.Age) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Email, rhs.Email) {
+		return false
+	}
+	if !(v.ID == rhs.ID) {
+		return false
+	}
+	if !((v.Nicknames == nil && rhs.Nicknames == nil) || (v.Nicknames != nil && rhs.Nicknames != nil && _List_String_Equals(v.Nicknames, rhs.Nicknames))) {
+		return false
+	}
+	if !(v.Active == rhs.Active) {
+		return false
 	}
 	return true
 }
 
-func (v *Graph) Equals(rhs *Graph) bool {
-	if !_List_Edge_Equals(v.Edges, rhs.Edges) {
-		return false
+func (v *UserV1) GetName() (o string) {
+	if v != nil {
+		o = v.Name
 	}
-	return true
+	return
 }
 
-type List Node
+func (v *UserV1) GetAge() (o int32) {
+	if v != nil && v.Age != nil {
+		return *v.Age
+	}
+	return
+}
 
-func (v *List) ToWire() (wire.Value, error) {
-	x := (*Node)(v)
-	return x.ToWire()
+func (v *UserV1) GetEmail() (o string) {
+	if v != nil && v.Email != nil {
+		return *v.Email
+	}
+	return
 }
 
-func (v *List) String() string {
-	x := (*Node)(v)
-	return fmt.Sprint(x)
+func (v *UserV1) GetID() (o int64) {
+	if v != nil {
+		o = v.ID
+	}
+	return
 }
 
-func (v *List) FromWire(w wire.Value) error {
-	return (*Node)(v).FromWire(w)
+func (v *UserV1) GetNicknames() (o []string) {
+	if v != nil && v.Nicknames != nil {
+		return v.Nicknames
+	}
+	return
 }
 
-func (lhs *List) Equals(rhs *List) bool {
-	return (*Node)(lhs).Equals((*Node)(rhs))
+func (v *UserV1) GetActive() (o bool) {
+	if v != nil {
+		o = v.Active
+	}
+	return
 }
 
-type Node struct {
-	Value int32 `json:"value"`
-	Tail  *List `json:"tail,omitempty"`
+type UserV2 struct {
+	Name      string   `json:"name"`
+	Age       int32    `json:"age"`
+	Email     []byte   `json:"email"`
+	ID        *int64   `json:"id,omitempty"`
+	Nicknames []string `json:"nicknames"`
+	Tags      []string `json:"tags"`
+	Active    *bool    `json:"active,omitempty"`
 }
 
-func (v *Node) ToWire() (wire.Value, error) {
+func (v *UserV2) ToWire() (wire.Value, error) {
 	var (
-		fields [2]wire.Field
+		fields [7]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
-	w, err = wire.NewValueI32(v.Value), error(nil)
+	w, err = wire.NewValueString(v.Name), error(nil)
 	if err != nil {
 		return w, err
 	}
 	fields[i] = wire.Field{ID: 1, Value: w}
 	i++
-	if v.Tail != nil {
-		w, err = v.Tail.ToWire()
+	w, err = wire.NewValueI32(v.Age), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 2, Value: w}
+	i++
+	if v.Email != nil {
+		w, err = wire.NewValueBinary(v.Email), error(nil)
 		if err != nil {
 			return w, err
 		}
-		fields[i] = wire.Field{ID: 2, Value: w}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.ID != nil {
+		w, err = wire.NewValueI64(*(v.ID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.Nicknames != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.Nicknames)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+	if v.Tags != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.Tags)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 6, Value: w}
+		i++
+	}
+	if v.Active != nil {
+		w, err = wire.NewValueBool(*(v.Active)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 7, Value: w}
 		i++
 	}
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _List_Read(w wire.Value) (*List, error) {
-	var x List
-	err := x.FromWire(w)
-	return &x, err
-}
-
-func (v *Node) FromWire(w wire.Value) error {
+func (v *UserV2) FromWire(w wire.Value) error {
 	var err error
-	valueIsSet := false
+	nameIsSet := false
+	ageIsSet := false
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 1:
-			if field.Value.Type() == wire.TI32 {
-				v.Value, err = field.Value.GetI32(), error(nil)
+			if field.Value.Type() == wire.TBinary {
+				v.Name, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Name", wire.DecodeErrorInvalidValue)
 					return err
 				}
-				valueIsSet = true
+				nameIsSet = true
 			}
 		case 2:
-			if field.Value.Type() == wire.TStruct {
-				v.Tail, err = _List_Read(field.Value)
+			if field.Value.Type() == wire.TI32 {
+				v.Age, err = field.Value.GetI32(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Age", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				ageIsSet = true
+			}
+		case 3:
+			if field.Value.Type() == wire.TBinary {
+				v.Email, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Email", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 4:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ID = &x
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "ID", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 5:
+			if field.Value.Type() == wire.TList {
+				v.Nicknames, err = _List_String_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Nicknames", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 6:
+			if field.Value.Type() == wire.TList {
+				v.Tags, err = _List_String_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Tags", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 7:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Active = &x
+				if err != nil {
+					wire.ObserveDecodeError("UserV2", "Active", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
 		}
 	}
-	if !valueIsSet {
-		return errors.New("field Value of Node is required")
+	if !nameIsSet {
+		wire.ObserveDecodeError("UserV2", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "UserV2", Field: "Name", ID: 1}
+	}
+	if !ageIsSet {
+		wire.ObserveDecodeError("UserV2", "Age", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "UserV2", Field: "Age", ID: 2}
 	}
 	return nil
 }
 
-func (v *Node) String() string {
+func (v *UserV2) String() string {
 	if v == nil {
 		return "<nil>"
 	}
-	var fields [2]string
+	var fields [7]string
 	i := 0
-	fields[i] = fmt.Sprintf("Value: %v", v.Value)
+	fields[i] = fmt.Sprintf("Name: %v", v.Name)
 	i++
-	if v.Tail != nil {
-		fields[i] = fmt.Sprintf("Tail: %v", v.Tail)
+	fields[i] = fmt.Sprintf("Age: %v", v.Age)
+	i++
+	if v.Email != nil {
+		fields[i] = fmt.Sprintf("Email: %v", v.Email)
 		i++
 	}
-	return fmt.Sprintf("Node{%v}", strings.Join(fields[:i], ", "))
+	if v.ID != nil {
+		fields[i] = fmt.Sprintf("ID: %v", *(v.ID))
+		i++
+	}
+	if v.Nicknames != nil {
+		fields[i] = fmt.Sprintf("Nicknames: %v", v.Nicknames)
+		i++
+	}
+	if v.Tags != nil {
+		fields[i] = fmt.Sprintf("Tags: %v", v.Tags)
+		i++
+	}
+	if v.Active != nil {
+		fields[i] = fmt.Sprintf("Active: %v", *(v.Active))
+		i++
+	}
+	return fmt.Sprintf("UserV2{%v}", strings.Join(fields[:i], ", "))
 }
 
-func (v *Node) Equals(rhs *Node) bool {
-	if !(v.Value == rhs.Value) {
+func (v *UserV2) Equals(rhs *UserV2) bool {
+	if !(v.Name == rhs.Name) {
 		return false
 	}
-	if !((v.Tail == nil && rhs.Tail == nil) || (v.Tail != nil && rhs.Tail != nil && v.Tail.Equals(rhs.Tail))) {
+	if !(v.Age == rhs.Age) {
+		return false
+	}
+	if !((v.Email == nil && rhs.Email == nil) || (v.Email != nil && rhs.Email != nil && bytes.Equal(v.Email, rhs.Email))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.ID, rhs.ID) {
+		return false
+	}
+	if !((v.Nicknames == nil && rhs.Nicknames == nil) || (v.Nicknames != nil && rhs.Nicknames != nil && _List_String_Equals(v.Nicknames, rhs.Nicknames))) {
+		return false
+	}
+	if !((v.Tags == nil && rhs.Tags == nil) || (v.Tags != nil && rhs.Tags != nil && _List_String_Equals(v.Tags, rhs.Tags))) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.Active, rhs.Active) {
 		return false
 	}
 	return true
 }
 
-type Point struct {
-	X float64 `json:"x"`
-	Y float64 `json:"y"`
+func (v *UserV2) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
 }
 
-func (v *Point) ToWire() (wire.Value, error) {
+func (v *UserV2) GetAge() (o int32) {
+	if v != nil {
+		o = v.Age
+	}
+	return
+}
+
+func (v *UserV2) GetEmail() (o []byte) {
+	if v != nil && v.Email != nil {
+		return v.Email
+	}
+	return
+}
+
+func (v *UserV2) GetID() (o int64) {
+	if v != nil && v.ID != nil {
+		return *v.ID
+	}
+	return
+}
+
+func (v *UserV2) GetNicknames() (o []string) {
+	if v != nil && v.Nicknames != nil {
+		return v.Nicknames
+	}
+	return
+}
+
+func (v *UserV2) GetTags() (o []string) {
+	if v != nil && v.Tags != nil {
+		return v.Tags
+	}
+	return
+}
+
+func (v *UserV2) GetActive() (o bool) {
+	if v != nil && v.Active != nil {
+		return *v.Active
+	}
+	return
+}
+
+type UserV3 struct {
+	Name      string   `json:"name"`
+	Nicknames []string `json:"nicknames"`
+}
+
+func (v *UserV3) ToWire() (wire.Value, error) {
 	var (
 		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
-	w, err = wire.NewValueDouble(v.X), error(nil)
+	w, err = wire.NewValueString(v.Name), error(nil)
 	if err != nil {
 		return w, err
 	}
 	fields[i] = wire.Field{ID: 1, Value: w}
 	i++
-	w, err = wire.NewValueDouble(v.Y), error(nil)
-	if err != nil {
-		return w, err
-	}
-	fields[i] = wire.Field{ID: 2, Value: w}
-	i++
+	if v.Nicknames != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.Nicknames)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func (v *Point) FromWire(w wire.Value) error {
+func (v *UserV3) FromWire(w wire.Value) error {
 	var err error
-	xIsSet := false
-	yIsSet := false
+	nameIsSet := false
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 1:
-			if field.Value.Type() == wire.TDouble {
-				v.X, err = field.Value.GetDouble(), error(nil)
+			if field.Value.Type() == wire.TBinary {
+				v.Name, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("UserV3", "Name", wire.DecodeErrorInvalidValue)
 					return err
 				}
-				xIsSet = true
+				nameIsSet = true
 			}
-		case 2:
-			if field.Value.Type() == wire.TDouble {
-				v.Y, err = field.Value.GetDouble(), error(nil)
+		case 5:
+			if field.Value.Type() == wire.TList {
+				v.Nicknames, err = _List_String_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("UserV3", "Nicknames", wire.DecodeErrorInvalidValue)
 					return err
 				}
-				yIsSet = true
 			}
 		}
 	}
-	if !xIsSet {
-		return errors.New("field X of Point is required")
-	}
-	if !yIsSet {
-		return errors.New("field Y of Point is required")
+	if !nameIsSet {
+		wire.ObserveDecodeError("UserV3", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "UserV3", Field: "Name", ID: 1}
 	}
 	return nil
 }
 
-func (v *Point) String() string {
+func (v *UserV3) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 	var fields [2]string
 	i := 0
-	fields[i] = fmt.Sprintf("X: %v", v.X)
-	i++
-	fields[i] = fmt.Sprintf("Y: %v", v.Y)
+	fields[i] = fmt.Sprintf("Name: %v", v.Name)
 	i++
-	return fmt.Sprintf("Point{%v}", strings.Join(fields[:i], ", "))
+	if v.Nicknames != nil {
+		fields[i] = fmt.Sprintf("Nicknames: %v", v.Nicknames)
+		i++
+	}
+	return fmt.Sprintf("UserV3{%v}", strings.Join(fields[:i], ", "))
 }
 
-func (v *Point) Equals(rhs *Point) bool {
-	if !(v.X == rhs.X) {
+func (v *UserV3) Equals(rhs *UserV3) bool {
+	if !(v.Name == rhs.Name) {
 		return false
 	}
-	if !(v.Y == rhs.Y) {
+	if !((v.Nicknames == nil && rhs.Nicknames == nil) || (v.Nicknames != nil && rhs.Nicknames != nil && _List_String_Equals(v.Nicknames, rhs.Nicknames))) {
 		return false
 	}
 	return true
 }
 
-type PrimitiveOptionalStruct struct {
-	BoolField   *bool    `json:"boolField,omitempty"`
-	ByteField   *int8    `json:"byteField,omitempty"`
-	Int16Field  *int16   `json:"int16Field,omitempty"`
-	Int32Field  *int32   `json:"int32Field,omitempty"`
-	Int64Field  *int64   `json:"int64Field,omitempty"`
-	DoubleField *float64 `json:"doubleField,omitempty"`
-	StringField *string  `json:"stringField,omitempty"`
-	BinaryField []byte   `json:"binaryField"`
+func (v *UserV3) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
 }
 
-func (v *PrimitiveOptionalStruct) ToWire() (wire.Value, error) {
+func (v *UserV3) GetNicknames() (o []string) {
+	if v != nil && v.Nicknames != nil {
+		return v.Nicknames
+	}
+	return
+}
+
+type ValidatedUser struct {
+	Name    string                    `json:"name"`
+	Age     *int32                    `json:"age,omitempty"`
+	Email   *string                   `json:"email,omitempty"`
+	Score   *float64                  `json:"score,omitempty"`
+	Tags    []string                  `json:"tags"`
+	Manager *ValidatedUser            `json:"manager,omitempty"`
+	Reports []*ValidatedUser          `json:"reports"`
+	ByName  map[string]*ValidatedUser `json:"byName"`
+}
+
+type _List_ValidatedUser_ValueList []*ValidatedUser
+
+func (v _List_ValidatedUser_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_ValidatedUser_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_ValidatedUser_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_ValidatedUser_ValueList) Close() {
+}
+
+type _Map_String_ValidatedUser_MapItemList map[string]*ValidatedUser
+
+func (m _Map_String_ValidatedUser_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		if v == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", k)
+		}
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
+		vw, err := v.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_String_ValidatedUser_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_String_ValidatedUser_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_ValidatedUser_MapItemList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_Map_String_ValidatedUser_MapItemList) Close() {
+}
+
+func (v *ValidatedUser) ToWire() (wire.Value, error) {
 	var (
 		fields [8]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
-	if v.BoolField != nil {
-		w, err = wire.NewValueBool(*(v.BoolField)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 1, Value: w}
-		i++
+	w, err = wire.NewValueString(v.Name), error(nil)
+	if err != nil {
+		return w, err
 	}
-	if v.ByteField != nil {
-		w, err = wire.NewValueI8(*(v.ByteField)), error(nil)
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.Age != nil {
+		w, err = wire.NewValueI32(*(v.Age)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 2, Value: w}
 		i++
 	}
-	if v.Int16Field != nil {
-		w, err = wire.NewValueI16(*(v.Int16Field)), error(nil)
+	if v.Email != nil {
+		w, err = wire.NewValueString(*(v.Email)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 3, Value: w}
 		i++
 	}
-	if v.Int32Field != nil {
-		w, err = wire.NewValueI32(*(v.Int32Field)), error(nil)
+	if v.Score != nil {
+		w, err = wire.NewValueDouble(*(v.Score)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 4, Value: w}
 		i++
 	}
-	if v.Int64Field != nil {
-		w, err = wire.NewValueI64(*(v.Int64Field)), error(nil)
+	if v.Tags != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.Tags)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 5, Value: w}
 		i++
 	}
-	if v.DoubleField != nil {
-		w, err = wire.NewValueDouble(*(v.DoubleField)), error(nil)
+	if v.Manager != nil {
+		w, err = v.Manager.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 6, Value: w}
 		i++
 	}
-	if v.StringField != nil {
-		w, err = wire.NewValueString(*(v.StringField)), error(nil)
+	if v.Reports != nil {
+		w, err = wire.NewValueList(_List_ValidatedUser_ValueList(v.Reports)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 7, Value: w}
 		i++
 	}
-	if v.BinaryField != nil {
-		w, err = wire.NewValueBinary(v.BinaryField), error(nil)
+	if v.ByName != nil {
+		w, err = wire.NewValueMap(_Map_String_ValidatedUser_MapItemList(v.ByName)), error(nil)
 		if err != nil {
 			return w, err
 		}
@@ -1120,605 +5128,871 @@ func (v *PrimitiveOptionalStruct) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func (v *PrimitiveOptionalStruct) FromWire(w wire.Value) error {
+func _ValidatedUser_Read(w wire.Value) (*ValidatedUser, error) {
+	var v ValidatedUser
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_ValidatedUser_Read(l wire.ValueList) ([]*ValidatedUser, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+	o := make([]*ValidatedUser, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _ValidatedUser_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _Map_String_ValidatedUser_Read(m wire.MapItemList) (map[string]*ValidatedUser, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+	if m.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+	o := make(map[string]*ValidatedUser, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		v, err := _ValidatedUser_Read(x.Value)
+		if err != nil {
+			return err
+		}
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+func (v *ValidatedUser) FromWire(w wire.Value) error {
 	var err error
+	nameIsSet := false
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 1:
-			if field.Value.Type() == wire.TBool {
-				var x bool
-				x, err = field.Value.GetBool(), error(nil)
-				v.BoolField = &x
+			if field.Value.Type() == wire.TBinary {
+				v.Name, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("ValidatedUser", "Name", wire.DecodeErrorInvalidValue)
 					return err
 				}
+				nameIsSet = true
 			}
 		case 2:
-			if field.Value.Type() == wire.TI8 {
-				var x int8
-				x, err = field.Value.GetI8(), error(nil)
-				v.ByteField = &x
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Age = &x
 				if err != nil {
+					wire.ObserveDecodeError("ValidatedUser", "Age", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
 		case 3:
-			if field.Value.Type() == wire.TI16 {
-				var x int16
-				x, err = field.Value.GetI16(), error(nil)
-				v.Int16Field = &x
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Email = &x
 				if err != nil {
+					wire.ObserveDecodeError("ValidatedUser", "Email", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
 		case 4:
-			if field.Value.Type() == wire.TI32 {
-				var x int32
-				x, err = field.Value.GetI32(), error(nil)
-				v.Int32Field = &x
+			if field.Value.Type() == wire.TDouble {
+				var x float64
+				x, err = field.Value.GetDouble(), error(nil)
+				v.Score = &x
 				if err != nil {
+					wire.ObserveDecodeError("ValidatedUser", "Score", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
 		case 5:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.Int64Field = &x
+			if field.Value.Type() == wire.TList {
+				v.Tags, err = _List_String_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("ValidatedUser", "Tags", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
 		case 6:
-			if field.Value.Type() == wire.TDouble {
-				var x float64
-				x, err = field.Value.GetDouble(), error(nil)
-				v.DoubleField = &x
+			if field.Value.Type() == wire.TStruct {
+				v.Manager, err = _ValidatedUser_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("ValidatedUser", "Manager", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
 		case 7:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.StringField = &x
+			if field.Value.Type() == wire.TList {
+				v.Reports, err = _List_ValidatedUser_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("ValidatedUser", "Reports", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
 		case 8:
-			if field.Value.Type() == wire.TBinary {
-				v.BinaryField, err = field.Value.GetBinary(), error(nil)
+			if field.Value.Type() == wire.TMap {
+				v.ByName, err = _Map_String_ValidatedUser_Read(field.Value.GetMap())
 				if err != nil {
+					wire.ObserveDecodeError("ValidatedUser", "ByName", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
 		}
 	}
-	return nil
+	if !nameIsSet {
+		wire.ObserveDecodeError("ValidatedUser", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "ValidatedUser", Field: "Name", ID: 1}
+	}
+	return nil
+}
+
+func (v *ValidatedUser) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [8]string
+	i := 0
+	fields[i] = fmt.Sprintf("Name: %v", v.Name)
+	i++
+	if v.Age != nil {
+		fields[i] = fmt.Sprintf("Age: %v", *(v.Age))
+		i++
+	}
+	if v.Email != nil {
+		fields[i] = fmt.Sprintf("Email: %v", *(v.Email))
+		i++
+	}
+	if v.Score != nil {
+		fields[i] = fmt.Sprintf("Score: %v", *(v.Score))
+		i++
+	}
+	if v.Tags != nil {
+		fields[i] = fmt.Sprintf("Tags: %v", v.Tags)
+		i++
+	}
+	if v.Manager != nil {
+		fields[i] = fmt.Sprintf("Manager: %v", v.Manager)
+		i++
+	}
+	if v.Reports != nil {
+		fields[i] = fmt.Sprintf("Reports: %v", v.Reports)
+		i++
+	}
+	if v.ByName != nil {
+		fields[i] = fmt.Sprintf("ByName: %v", v.ByName)
+		i++
+	}
+	return fmt.Sprintf("ValidatedUser{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_ValidatedUser_Equals(lhs, rhs []*ValidatedUser) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func _Map_String_ValidatedUser_Equals(lhs, rhs map[string]*ValidatedUser) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
 }
 
-func (v *PrimitiveOptionalStruct) String() string {
-	if v == nil {
-		return "<nil>"
+func (v *ValidatedUser) Equals(rhs *ValidatedUser) bool {
+	if !(v.Name == rhs.Name) {
+		return false
 	}
-	var fields [8]string
-	i := 0
-	if v.BoolField != nil {
-		fields[i] = fmt.Sprintf("BoolField: %v", *(v.BoolField))
-		i++
+	if !_I32_EqualsPtr(v.Age, rhs.Age) {
+		return false
 	}
-	if v.ByteField != nil {
-		fields[i] = fmt.Sprintf("ByteField: %v", *(v.ByteField))
-		i++
+	if !_String_EqualsPtr(v.Email, rhs.Email) {
+		return false
 	}
-	if v.Int16Field != nil {
-		fields[i] = fmt.Sprintf("Int16Field: %v", *(v.Int16Field))
-		i++
+	if !_Double_EqualsPtr(v.Score, rhs.Score) {
+		return false
 	}
-	if v.Int32Field != nil {
-		fields[i] = fmt.Sprintf("Int32Field: %v", *(v.Int32Field))
-		i++
+	if !((v.Tags == nil && rhs.Tags == nil) || (v.Tags != nil && rhs.Tags != nil && _List_String_Equals(v.Tags, rhs.Tags))) {
+		return false
 	}
-	if v.Int64Field != nil {
-		fields[i] = fmt.Sprintf("Int64Field: %v", *(v.Int64Field))
-		i++
+	if !((v.Manager == nil && rhs.Manager == nil) || (v.Manager != nil && rhs.Manager != nil && v.Manager.Equals(rhs.Manager))) {
+		return false
 	}
-	if v.DoubleField != nil {
-		fields[i] = fmt.Sprintf("DoubleField: %v", *(v.DoubleField))
-		i++
+	if !((v.Reports == nil && rhs.Reports == nil) || (v.Reports != nil && rhs.Reports != nil && _List_ValidatedUser_Equals(v.Reports, rhs.Reports))) {
+		return false
 	}
-	if v.StringField != nil {
-		fields[i] = fmt.Sprintf("StringField: %v", *(v.StringField))
-		i++
+	if !((v.ByName == nil && rhs.ByName == nil) || (v.ByName != nil && rhs.ByName != nil && _Map_String_ValidatedUser_Equals(v.ByName, rhs.ByName))) {
+		return false
 	}
-	if v.BinaryField != nil {
-		fields[i] = fmt.Sprintf("BinaryField: %v", v.BinaryField)
-		i++
+	return true
+}
+
+func (v *ValidatedUser) GetName() (o string) {
+	if v != nil {
+		o = v.Name
 	}
-	return fmt.Sprintf("PrimitiveOptionalStruct{%v}", strings.Join(fields[:i], ", "))
+	return
 }
 
-func _Bool_EqualsPtr(lhs, rhs *bool) bool {
-	if lhs != nil && rhs != nil {
-		x := *lhs
-		y := *rhs
-		return (x == y)
+func (v *ValidatedUser) GetAge() (o int32) {
+	if v != nil && v.Age != nil {
+		return *v.Age
 	}
-	return lhs == nil && rhs == nil
+	return
 }
 
-func _Byte_EqualsPtr(lhs, rhs *int8) bool {
-	if lhs != nil && rhs != nil {
-		x := *lhs
-		y := *rhs
-		return (x == y)
+func (v *ValidatedUser) GetEmail() (o string) {
+	if v != nil && v.Email != nil {
+		return *v.Email
 	}
-	return lhs == nil && rhs == nil
+	return
 }
 
-func _I16_EqualsPtr(lhs, rhs *int16) bool {
-	if lhs != nil && rhs != nil {
-		x := *lhs
-		y := *rhs
-		return (x == y)
+func (v *ValidatedUser) GetScore() (o float64) {
+	if v != nil && v.Score != nil {
+		return *v.Score
 	}
-	return lhs == nil && rhs == nil
+	return
 }
 
-func _I64_EqualsPtr(lhs, rhs *int64) bool {
-	if lhs != nil && rhs != nil {
-		x := *lhs
-		y := *rhs
-		return (x == y)
+func (v *ValidatedUser) GetTags() (o []string) {
+	if v != nil && v.Tags != nil {
+		return v.Tags
 	}
-	return lhs == nil && rhs == nil
+	return
 }
 
-func _Double_EqualsPtr(lhs, rhs *float64) bool {
-	if lhs != nil && rhs != nil {
-		x := *lhs
-		y := *rhs
-		return (x == y)
+func (v *ValidatedUser) GetManager() (o *ValidatedUser) {
+	if v != nil && v.Manager != nil {
+		return v.Manager
 	}
-	return lhs == nil && rhs == nil
+	return
 }
 
-func _String_EqualsPtr(lhs, rhs *string) bool {
-	if lhs != nil && rhs != nil {
-		x := *lhs
-		y := *rhs
-		return (x == y)
+func (v *ValidatedUser) GetReports() (o []*ValidatedUser) {
+	if v != nil && v.Reports != nil {
+		return v.Reports
 	}
-	return lhs == nil && rhs == nil
+	return
 }
 
-func (v *PrimitiveOptionalStruct) Equals(rhs *PrimitiveOptionalStruct) bool {
-	if !_Bool_EqualsPtr(v.BoolField, rhs.BoolField) {
-		return false
+func (v *ValidatedUser) GetByName() (o map[string]*ValidatedUser) {
+	if v != nil && v.ByName != nil {
+		return v.ByName
 	}
-	if !_Byte_EqualsPtr(v.ByteField, rhs.ByteField) {
-		return false
+	return
+}
+
+var _ValidatedUser_Email_Pattern = regexp.MustCompile("^[^@\\s]+@[^@\\s]+$")
+
+func _List_ValidatedUser_Validate(v []*ValidatedUser) error {
+	for _, x := range v {
+		if err := x.Validate(); err != nil {
+			return err
+		}
 	}
-	if !_I16_EqualsPtr(v.Int16Field, rhs.Int16Field) {
-		return false
+	return nil
+}
+
+func _Map_String_ValidatedUser_Validate(v map[string]*ValidatedUser) error {
+	for _, x := range v {
+		if err := x.Validate(); err != nil {
+			return err
+		}
 	}
-	if !_I32_EqualsPtr(v.Int32Field, rhs.Int32Field) {
-		return false
+	return nil
+}
+
+func (v *ValidatedUser) Validate() error {
+	if v == nil {
+		return nil
 	}
-	if !_I64_EqualsPtr(v.Int64Field, rhs.Int64Field) {
-		return false
+	if len(v.Name) < 1 {
+		return wire.InvalidFieldError{Struct: "ValidatedUser", Field: "Name", ID: 1, Reason: "length must be at least 1"}
 	}
-	if !_Double_EqualsPtr(v.DoubleField, rhs.DoubleField) {
-		return false
+	if len(v.Name) > 16 {
+		return wire.InvalidFieldError{Struct: "ValidatedUser", Field: "Name", ID: 1, Reason: "length must be at most 16"}
 	}
-	if !_String_EqualsPtr(v.StringField, rhs.StringField) {
-		return false
+	if v.Age != nil {
+		if *v.Age < 0 {
+			return wire.InvalidFieldError{Struct: "ValidatedUser", Field: "Age", ID: 2, Reason: "must be at least 0"}
+		}
+		if *v.Age > 150 {
+			return wire.InvalidFieldError{Struct: "ValidatedUser", Field: "Age", ID: 2, Reason: "must be at most 150"}
+		}
 	}
-	if !((v.BinaryField == nil && rhs.BinaryField == nil) || (v.BinaryField != nil && rhs.BinaryField != nil && bytes.Equal(v.BinaryField, rhs.BinaryField))) {
-		return false
+	if v.Email != nil {
+		if !_ValidatedUser_Email_Pattern.MatchString(*v.Email) {
+			return wire.InvalidFieldError{Struct: "ValidatedUser", Field: "Email", ID: 3, Reason: "must match pattern \"^[^@\\\\s]+@[^@\\\\s]+$\""}
+		}
 	}
-	return true
+	if v.Score != nil {
+		if *v.Score < -1 {
+			return wire.InvalidFieldError{Struct: "ValidatedUser", Field: "Score", ID: 4, Reason: "must be at least -1"}
+		}
+		if *v.Score > 1.5 {
+			return wire.InvalidFieldError{Struct: "ValidatedUser", Field: "Score", ID: 4, Reason: "must be at most 1.5"}
+		}
+	}
+	if v.Tags != nil {
+		if len(v.Tags) > 2 {
+			return wire.InvalidFieldError{Struct: "ValidatedUser", Field: "Tags", ID: 5, Reason: "length must be at most 2"}
+		}
+	}
+	if err := v.Manager.Validate(); err != nil {
+		return err
+	}
+	if err := _List_ValidatedUser_Validate(v.Reports); err != nil {
+		return err
+	}
+	if err := _Map_String_ValidatedUser_Validate(v.ByName); err != nil {
+		return err
+	}
+	return nil
 }
 
-type PrimitiveRequiredStruct struct {
-	BoolField   bool    `json:"boolField"`
-	ByteField   int8    `json:"byteField"`
-	Int16Field  int16   `json:"int16Field"`
-	Int32Field  int32   `json:"int32Field"`
-	Int64Field  int64   `json:"int64Field"`
-	DoubleField float64 `json:"doubleField"`
-	StringField string  `json:"stringField"`
-	BinaryField []byte  `json:"binaryField"`
+type ValueFields struct {
+	Count        int32             `json:"count,omitempty"`
+	Name         string            `json:"name,omitempty"`
+	Enabled      bool              `json:"enabled,omitempty"`
+	Kind         enums.EnumDefault `json:"kind,omitempty"`
+	Limit        int32             `json:"limit,omitempty"`
+	ID           uuid.UUID         `json:"id,omitempty"`
+	Pointer      *int64            `json:"pointer,omitempty"`
+	isSetCount   bool
+	isSetName    bool
+	isSetEnabled bool
+	isSetKind    bool
+	isSetLimit   bool
+	isSetID      bool
 }
 
-func (v *PrimitiveRequiredStruct) ToWire() (wire.Value, error) {
+func (v *ValueFields) ToWire() (wire.Value, error) {
 	var (
-		fields [8]wire.Field
+		fields [7]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
-	w, err = wire.NewValueBool(v.BoolField), error(nil)
-	if err != nil {
-		return w, err
-	}
-	fields[i] = wire.Field{ID: 1, Value: w}
-	i++
-	w, err = wire.NewValueI8(v.ByteField), error(nil)
-	if err != nil {
-		return w, err
+	if v.isSetCount || v.Count != 0 {
+		w, err = wire.NewValueI32(v.Count), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
 	}
-	fields[i] = wire.Field{ID: 2, Value: w}
-	i++
-	w, err = wire.NewValueI16(v.Int16Field), error(nil)
-	if err != nil {
-		return w, err
+	if v.isSetName || v.Name != "" {
+		w, err = wire.NewValueString(v.Name), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
 	}
-	fields[i] = wire.Field{ID: 3, Value: w}
-	i++
-	w, err = wire.NewValueI32(v.Int32Field), error(nil)
-	if err != nil {
-		return w, err
+	if v.isSetEnabled || v.Enabled {
+		w, err = wire.NewValueBool(v.Enabled), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
 	}
-	fields[i] = wire.Field{ID: 4, Value: w}
-	i++
-	w, err = wire.NewValueI64(v.Int64Field), error(nil)
-	if err != nil {
-		return w, err
+	if v.isSetKind || v.Kind != 0 {
+		w, err = v.Kind.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
 	}
-	fields[i] = wire.Field{ID: 5, Value: w}
-	i++
-	w, err = wire.NewValueDouble(v.DoubleField), error(nil)
-	if err != nil {
-		return w, err
+	if !(v.isSetLimit || v.Limit != 0) {
+		v.Limit = 100
+		v.isSetLimit = true
 	}
-	fields[i] = wire.Field{ID: 6, Value: w}
-	i++
-	w, err = wire.NewValueString(v.StringField), error(nil)
-	if err != nil {
-		return w, err
+	{
+		w, err = wire.NewValueI32(v.Limit), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
 	}
-	fields[i] = wire.Field{ID: 7, Value: w}
-	i++
-	if v.BinaryField == nil {
-		return w, errors.New("field BinaryField of PrimitiveRequiredStruct is required")
+	if v.isSetID || v.ID != (uuid.UUID{}) {
+		w, err = v.ID.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 6, Value: w}
+		i++
 	}
-	w, err = wire.NewValueBinary(v.BinaryField), error(nil)
-	if err != nil {
-		return w, err
+	if v.Pointer != nil {
+		w, err = wire.NewValueI64(*(v.Pointer)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 7, Value: w}
+		i++
 	}
-	fields[i] = wire.Field{ID: 8, Value: w}
-	i++
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func (v *PrimitiveRequiredStruct) FromWire(w wire.Value) error {
+func (v *ValueFields) FromWire(w wire.Value) error {
 	var err error
-	boolFieldIsSet := false
-	byteFieldIsSet := false
-	int16FieldIsSet := false
-	int32FieldIsSet := false
-	int64FieldIsSet := false
-	doubleFieldIsSet := false
-	stringFieldIsSet := false
-	binaryFieldIsSet := false
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 1:
-			if field.Value.Type() == wire.TBool {
-				v.BoolField, err = field.Value.GetBool(), error(nil)
+			if field.Value.Type() == wire.TI32 {
+				v.Count, err = field.Value.GetI32(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("ValueFields", "Count", wire.DecodeErrorInvalidValue)
 					return err
 				}
-				boolFieldIsSet = true
+				v.isSetCount = true
 			}
 		case 2:
-			if field.Value.Type() == wire.TI8 {
-				v.ByteField, err = field.Value.GetI8(), error(nil)
+			if field.Value.Type() == wire.TBinary {
+				v.Name, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("ValueFields", "Name", wire.DecodeErrorInvalidValue)
 					return err
 				}
-				byteFieldIsSet = true
+				v.isSetName = true
 			}
 		case 3:
-			if field.Value.Type() == wire.TI16 {
-				v.Int16Field, err = field.Value.GetI16(), error(nil)
+			if field.Value.Type() == wire.TBool {
+				v.Enabled, err = field.Value.GetBool(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("ValueFields", "Enabled", wire.DecodeErrorInvalidValue)
 					return err
 				}
-				int16FieldIsSet = true
+				v.isSetEnabled = true
 			}
 		case 4:
 			if field.Value.Type() == wire.TI32 {
-				v.Int32Field, err = field.Value.GetI32(), error(nil)
-				if err != nil {
-					return err
-				}
-				int32FieldIsSet = true
-			}
-		case 5:
-			if field.Value.Type() == wire.TI64 {
-				v.Int64Field, err = field.Value.GetI64(), error(nil)
+				v.Kind, err = _EnumDefault_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("ValueFields", "Kind", wire.DecodeErrorInvalidValue)
 					return err
-				}
-				int64FieldIsSet = true
-			}
-		case 6:
-			if field.Value.Type() == wire.TDouble {
-				v.DoubleField, err = field.Value.GetDouble(), error(nil)
+				}
+				v.isSetKind = true
+			}
+		case 5:
+			if field.Value.Type() == wire.TI32 {
+				v.Limit, err = field.Value.GetI32(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("ValueFields", "Limit", wire.DecodeErrorInvalidValue)
 					return err
 				}
-				doubleFieldIsSet = true
+				v.isSetLimit = true
 			}
-		case 7:
+		case 6:
 			if field.Value.Type() == wire.TBinary {
-				v.StringField, err = field.Value.GetString(), error(nil)
+				v.ID, err = uuid.FromBytes(field.Value.GetBinary())
 				if err != nil {
+					wire.ObserveDecodeError("ValueFields", "ID", wire.DecodeErrorInvalidValue)
 					return err
 				}
-				stringFieldIsSet = true
+				v.isSetID = true
 			}
-		case 8:
-			if field.Value.Type() == wire.TBinary {
-				v.BinaryField, err = field.Value.GetBinary(), error(nil)
+		case 7:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Pointer = &x
 				if err != nil {
+					wire.ObserveDecodeError("ValueFields", "Pointer", wire.DecodeErrorInvalidValue)
 					return err
 				}
-				binaryFieldIsSet = true
 			}
 		}
 	}
-	if !boolFieldIsSet {
-		return errors.New("field BoolField of PrimitiveRequiredStruct is required")
+	if !(v.isSetLimit || v.Limit != 0) {
+		v.Limit = 100
+		v.isSetLimit = true
 	}
-	if !byteFieldIsSet {
-		return errors.New("field ByteField of PrimitiveRequiredStruct is required")
+	return nil
+}
+
+func (v *ValueFields) String() string {
+	if v == nil {
+		return "<nil>"
 	}
-	if !int16FieldIsSet {
-		return errors.New("field Int16Field of PrimitiveRequiredStruct is required")
+	var fields [7]string
+	i := 0
+	if v.isSetCount || v.Count != 0 {
+		fields[i] = fmt.Sprintf("Count: %v", v.Count)
+		i++
 	}
-	if !int32FieldIsSet {
-		return errors.New("field Int32Field of PrimitiveRequiredStruct is required")
+	if v.isSetName || v.Name != "" {
+		fields[i] = fmt.Sprintf("Name: %v", v.Name)
+		i++
 	}
-	if !int64FieldIsSet {
-		return errors.New("field Int64Field of PrimitiveRequiredStruct is required")
+	if v.isSetEnabled || v.Enabled {
+		fields[i] = fmt.Sprintf("Enabled: %v", v.Enabled)
+		i++
 	}
-	if !doubleFieldIsSet {
-		return errors.New("field DoubleField of PrimitiveRequiredStruct is required")
+	if v.isSetKind || v.Kind != 0 {
+		fields[i] = fmt.Sprintf("Kind: %v", v.Kind)
+		i++
 	}
-	if !stringFieldIsSet {
-		return errors.New("field StringField of PrimitiveRequiredStruct is required")
+	if v.isSetLimit || v.Limit != 0 {
+		fields[i] = fmt.Sprintf("Limit: %v", v.Limit)
+		i++
 	}
-	if !binaryFieldIsSet {
-		return errors.New("field BinaryField of PrimitiveRequiredStruct is required")
+	if v.isSetID || v.ID != (uuid.UUID{}) {
+		fields[i] = fmt.Sprintf("ID: %v", v.ID)
+		i++
 	}
-	return nil
-}
-
-func (v *PrimitiveRequiredStruct) String() string {
-	if v == nil {
-		return "<nil>"
+	if v.Pointer != nil {
+		fields[i] = fmt.Sprintf("Pointer: %v", *(v.Pointer))
+		i++
 	}
-	var fields [8]string
-	i := 0
-	fields[i] = fmt.Sprintf("BoolField: %v", v.BoolField)
-	i++
-	fields[i] = fmt.Sprintf("ByteField: %v", v.ByteField)
-	i++
-	fields[i] = fmt.Sprintf("Int16Field: %v", v.Int16Field)
-	i++
-	fields[i] = fmt.Sprintf("Int32Field: %v", v.Int32Field)
-	i++
-	fields[i] = fmt.Sprintf("Int64Field: %v", v.Int64Field)
-	i++
-	fields[i] = fmt.Sprintf("DoubleField: %v", v.DoubleField)
-	i++
-	fields[i] = fmt.Sprintf("StringField: %v", v.StringField)
-	i++
-	fields[i] = fmt.Sprintf("BinaryField: %v", v.BinaryField)
-	i++
-	return fmt.Sprintf("PrimitiveRequiredStruct{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("ValueFields{%v}", strings.Join(fields[:i], ", "))
 }
 
-func (v *PrimitiveRequiredStruct) Equals(rhs *PrimitiveRequiredStruct) bool {
-	if !(v.BoolField == rhs.BoolField) {
-		return false
-	}
-	if !(v.ByteField == rhs.ByteField) {
+func (v *ValueFields) Equals(rhs *ValueFields) bool {
+	if (v.isSetCount || v.Count != 0) != (rhs.isSetCount || rhs.Count != 0) || !(v.Count == rhs.Count) {
 		return false
 	}
-	if !(v.Int16Field == rhs.Int16Field) {
+	if (v.isSetName || v.Name != "") != (rhs.isSetName || rhs.Name != "") || !(v.Name == rhs.Name) {
 		return false
 	}
-	if !(v.Int32Field == rhs.Int32Field) {
+	if (v.isSetEnabled || v.Enabled) != (rhs.isSetEnabled || rhs.Enabled) || !(v.Enabled == rhs.Enabled) {
 		return false
 	}
-	if !(v.Int64Field == rhs.Int64Field) {
+	if (v.isSetKind || v.Kind != 0) != (rhs.isSetKind || rhs.Kind != 0) || !v.Kind.Equals(rhs.Kind) {
 		return false
 	}
-	if !(v.DoubleField == rhs.DoubleField) {
+	if (v.isSetLimit || v.Limit != 0) != (rhs.isSetLimit || rhs.Limit != 0) || !(v.Limit == rhs.Limit) {
 		return false
 	}
-	if !(v.StringField == rhs.StringField) {
+	if (v.isSetID || v.ID != (uuid.UUID{})) != (rhs.isSetID || rhs.ID != (uuid.UUID{})) || !(v.ID == rhs.ID) {
 		return false
 	}
-	if !bytes.Equal(v.BinaryField, rhs.BinaryField) {
+	if !_I64_EqualsPtr(v.Pointer, rhs.Pointer) {
 		return false
 	}
 	return true
 }
 
-type Size struct {
-	Width  float64 `json:"width"`
-	Height float64 `json:"height"`
+func (v *ValueFields) MarshalJSON() ([]byte, error) {
+	type plain ValueFields
+	x := struct {
+		*plain
+		Count   *int32             `json:"count,omitempty"`
+		Name    *string            `json:"name,omitempty"`
+		Enabled *bool              `json:"enabled,omitempty"`
+		Kind    *enums.EnumDefault `json:"kind,omitempty"`
+		Limit   *int32             `json:"limit,omitempty"`
+		ID      *uuid.UUID         `json:"id,omitempty"`
+	}{plain: (*plain)(v)}
+	if v.isSetCount || v.Count != 0 {
+		x.Count = &v.Count
+	}
+	if v.isSetName || v.Name != "" {
+		x.Name = &v.Name
+	}
+	if v.isSetEnabled || v.Enabled {
+		x.Enabled = &v.Enabled
+	}
+	if v.isSetKind || v.Kind != 0 {
+		x.Kind = &v.Kind
+	}
+	if v.isSetLimit || v.Limit != 0 {
+		x.Limit = &v.Limit
+	}
+	if v.isSetID || v.ID != (uuid.UUID{}) {
+		x.ID = &v.ID
+	}
+	return json.Marshal(x)
 }
 
-func (v *Size) ToWire() (wire.Value, error) {
-	var (
-		fields [2]wire.Field
-		i      int = 0
-		w      wire.Value
-		err    error
-	)
-	w, err = wire.NewValueDouble(v.Width), error(nil)
-	if err != nil {
-		return w, err
+func (v *ValueFields) UnmarshalJSON(text []byte) error {
+	type plain ValueFields
+	x := struct {
+		*plain
+		Count   *int32             `json:"count,omitempty"`
+		Name    *string            `json:"name,omitempty"`
+		Enabled *bool              `json:"enabled,omitempty"`
+		Kind    *enums.EnumDefault `json:"kind,omitempty"`
+		Limit   *int32             `json:"limit,omitempty"`
+		ID      *uuid.UUID         `json:"id,omitempty"`
+	}{plain: (*plain)(v)}
+	if err := json.Unmarshal(text, &x); err != nil {
+		return err
+	}
+	if x.Count != nil {
+		v.Count = *x.Count
+		v.isSetCount = true
+	} else {
+		var zero int32
+		v.Count = zero
+		v.isSetCount = false
+	}
+	if x.Name != nil {
+		v.Name = *x.Name
+		v.isSetName = true
+	} else {
+		var zero string
+		v.Name = zero
+		v.isSetName = false
+	}
+	if x.Enabled != nil {
+		v.Enabled = *x.Enabled
+		v.isSetEnabled = true
+	} else {
+		var zero bool
+		v.Enabled = zero
+		v.isSetEnabled = false
+	}
+	if x.Kind != nil {
+		v.Kind = *x.Kind
+		v.isSetKind = true
+	} else {
+		var zero enums.EnumDefault
+		v.Kind = zero
+		v.isSetKind = false
+	}
+	if x.Limit != nil {
+		v.Limit = *x.Limit
+		v.isSetLimit = true
+	} else {
+		var zero int32
+		v.Limit = zero
+		v.isSetLimit = false
+	}
+	if x.ID != nil {
+		v.ID = *x.ID
+		v.isSetID = true
+	} else {
+		var zero uuid.UUID
+		v.ID = zero
+		v.isSetID = false
 	}
-	fields[i] = wire.Field{ID: 1, Value: w}
-	i++
-	w, err = wire.NewValueDouble(v.Height), error(nil)
-	if err != nil {
-		return w, err
+	return nil
+}
+
+func (v *ValueFields) GetCount() (o int32) {
+	if v != nil && (v.isSetCount || v.Count != 0) {
+		return v.Count
 	}
-	fields[i] = wire.Field{ID: 2, Value: w}
-	i++
-	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+	return
 }
 
-func (v *Size) FromWire(w wire.Value) error {
-	var err error
-	widthIsSet := false
-	heightIsSet := false
-	for _, field := range w.GetStruct().Fields {
-		switch field.ID {
-		case 1:
-			if field.Value.Type() == wire.TDouble {
-				v.Width, err = field.Value.GetDouble(), error(nil)
-				if err != nil {
-					return err
-				}
-				widthIsSet = true
-			}
-		case 2:
-			if field.Value.Type() == wire.TDouble {
-				v.Height, err = field.Value.GetDouble(), error(nil)
-				if err != nil {
-					return err
-				}
-				heightIsSet = true
-			}
-		}
+func (v *ValueFields) GetName() (o string) {
+	if v != nil && (v.isSetName || v.Name != "") {
+		return v.Name
 	}
-	if !widthIsSet {
-		return errors.New("field Width of Size is required")
+	return
+}
+
+func (v *ValueFields) GetEnabled() (o bool) {
+	if v != nil && (v.isSetEnabled || v.Enabled) {
+		return v.Enabled
 	}
-	if !heightIsSet {
-		return errors.New("field Height of Size is required")
+	return
+}
+
+func (v *ValueFields) GetKind() (o enums.EnumDefault) {
+	if v != nil && (v.isSetKind || v.Kind != 0) {
+		return v.Kind
 	}
-	return nil
+	return
 }
 
-func (v *Size) String() string {
-	if v == nil {
-		return "<nil>"
+func (v *ValueFields) GetLimit() (o int32) {
+	if v != nil && (v.isSetLimit || v.Limit != 0) {
+		return v.Limit
 	}
-	var fields [2]string
-	i := 0
-	fields[i] = fmt.Sprintf("Width: %v", v.Width)
-	i++
-	fields[i] = fmt.Sprintf("Height: %v", v.Height)
-	i++
-	return fmt.Sprintf("Size{%v}", strings.Join(fields[:i], ", "))
+	o = 100
+	return
 }
 
-func (v *Size) Equals(rhs *Size) bool {
-	if !(v.Width == rhs.Width) {
-		return false
+func (v *ValueFields) GetID() (o uuid.UUID) {
+	if v != nil && (v.isSetID || v.ID != (uuid.UUID{})) {
+		return v.ID
 	}
-	if !(v.Height == rhs.Height) {
-		return false
+	return
+}
+
+func (v *ValueFields) GetPointer() (o int64) {
+	if v != nil && v.Pointer != nil {
+		return *v.Pointer
 	}
-	return true
+	return
 }
 
-type User struct {
-	Name    string       `json:"name"`
-	Contact *ContactInfo `json:"contact,omitempty"`
+func (v *ValueFields) IsSetCount() bool {
+	return v != nil && (v.isSetCount || v.Count != 0)
 }
 
-func (v *User) ToWire() (wire.Value, error) {
-	var (
-		fields [2]wire.Field
-		i      int = 0
-		w      wire.Value
-		err    error
-	)
-	w, err = wire.NewValueString(v.Name), error(nil)
-	if err != nil {
-		return w, err
-	}
-	fields[i] = wire.Field{ID: 1, Value: w}
-	i++
-	if v.Contact != nil {
-		w, err = v.Contact.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 2, Value: w}
-		i++
-	}
-	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+func (v *ValueFields) SetCount(x int32) {
+	v.Count = x
+	v.isSetCount = true
 }
 
-func _ContactInfo_Read(w wire.Value) (*ContactInfo, error) {
-	var v ContactInfo
-	err := v.FromWire(w)
-	return &v, err
+func (v *ValueFields) ClearCount() {
+	var x int32
+	v.Count = x
+	v.isSetCount = false
 }
 
-func (v *User) FromWire(w wire.Value) error {
-	var err error
-	nameIsSet := false
-	for _, field := range w.GetStruct().Fields {
-		switch field.ID {
-		case 1:
-			if field.Value.Type() == wire.TBinary {
-				v.Name, err = field.Value.GetString(), error(nil)
-				if err != nil {
-					return err
-				}
-				nameIsSet = true
-			}
-		case 2:
-			if field.Value.Type() == wire.TStruct {
-				v.Contact, err = _ContactInfo_Read(field.Value)
-				if err != nil {
-					return err
-				}
-			}
-		}
-	}
-	if !nameIsSet {
-		return errors.New("field Name of User is required")
+func (v *ValueFields) IsSetName() bool {
+	return v != nil && (v.isSetName || v.Name != "")
+}
+
+func (v *ValueFields) SetName(x string) {
+	v.Name = x
+	v.isSetName = true
+}
+
+func (v *ValueFields) ClearName() {
+	var x string
+	v.Name = x
+	v.isSetName = false
+}
+
+func (v *ValueFields) IsSetEnabled() bool {
+	return v != nil && (v.isSetEnabled || v.Enabled)
+}
+
+func (v *ValueFields) SetEnabled(x bool) {
+	v.Enabled = x
+	v.isSetEnabled = true
+}
+
+func (v *ValueFields) ClearEnabled() {
+	var x bool
+	v.Enabled = x
+	v.isSetEnabled = false
+}
+
+func (v *ValueFields) IsSetKind() bool {
+	return v != nil && (v.isSetKind || v.Kind != 0)
+}
+
+func (v *ValueFields) SetKind(x enums.EnumDefault) {
+	v.Kind = x
+	v.isSetKind = true
+}
+
+func (v *ValueFields) ClearKind() {
+	var x enums.EnumDefault
+	v.Kind = x
+	v.isSetKind = false
+}
+
+func (v *ValueFields) IsSetLimit() bool {
+	return v != nil && (v.isSetLimit || v.Limit != 0)
+}
+
+func (v *ValueFields) SetLimit(x int32) {
+	v.Limit = x
+	v.isSetLimit = true
+}
+
+func (v *ValueFields) ClearLimit() {
+	var x int32
+	v.Limit = x
+	v.isSetLimit = false
+}
+
+func (v *ValueFields) IsSetID() bool {
+	return v != nil && (v.isSetID || v.ID != (uuid.UUID{}))
+}
+
+func (v *ValueFields) SetID(x uuid.UUID) {
+	v.ID = x
+	v.isSetID = true
+}
+
+func (v *ValueFields) ClearID() {
+	var x uuid.UUID
+	v.ID = x
+	v.isSetID = false
+}
+
+func (v *ValueFields) Sanitize() {
+	if v.isSetName || v.Name != "" {
+		v.Name = strings.TrimSpace(v.Name)
+		v.isSetName = true
 	}
-	return nil
 }
 
-func (v *User) String() string {
+type UserV1ToUserV2_Unmapped struct {
+	Email func(*UserV1) []byte
+	Tags  func(*UserV1) []string
+}
+
+func ConvertUserV1ToUserV2(v *UserV1, u UserV1ToUserV2_Unmapped) *UserV2 {
 	if v == nil {
-		return "<nil>"
+		return nil
 	}
-	var fields [2]string
-	i := 0
-	fields[i] = fmt.Sprintf("Name: %v", v.Name)
-	i++
-	if v.Contact != nil {
-		fields[i] = fmt.Sprintf("Contact: %v", v.Contact)
-		i++
+	var o UserV2
+	o.Name = v.Name
+	if v.Age != nil {
+		o.Age = *v.Age
 	}
-	return fmt.Sprintf("User{%v}", strings.Join(fields[:i], ", "))
+	if u.Email != nil {
+		o.Email = u.Email(v)
+	}
+	x := v.ID
+	o.ID = &x
+	o.Nicknames = v.Nicknames
+	if u.Tags != nil {
+		o.Tags = u.Tags(v)
+	}
+	x2 := v.Active
+	o.Active = &x2
+	return &o
 }
 
-func (v *User) Equals(rhs *User) bool {
-	if !(v.Name == rhs.Name) {
-		return false
+func ConvertUserV1ToUserV3(v *UserV1) *UserV3 {
+	if v == nil {
+		return nil
 	}
-	if !((v.Contact == nil && rhs.Contact == nil) || (v.Contact != nil && rhs.Contact != nil && v.Contact.Equals(rhs.Contact))) {
-		return false
+	var o UserV3
+	o.Name = v.Name
+	o.Nicknames = v.Nicknames
+	return &o
+}
+
+func ConvertUserV2ToUserV3(v *UserV2) *UserV3 {
+	if v == nil {
+		return nil
 	}
-	return true
+	var o UserV3
+	o.Name = v.Name
+	o.Nicknames = v.Nicknames
+	return &o
 }
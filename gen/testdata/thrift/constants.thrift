@@ -69,6 +69,9 @@ const structs.Graph graph = {
     ]
 }
 
+// count is zero but set.
+const structs.ValueFields valueFields = {"count": 0, "name": "foo"}
+
 const structs.Node lastNode = {"value": 3}
 const structs.Node node = {
     "value": 1,
@@ -107,3 +110,6 @@ const enums.RecordType HOME = enums.RecordType.HOME_ADDRESS
 const enums.RecordType WORK_ADDRESS = enums.RecordType.WORK_ADDRESS
 
 const enums.lowerCaseEnum lower = enums.lowerCaseEnum.items
+
+const uuid nilUUID = "00000000-0000-0000-0000-000000000000"
+const typedefs.RequestID requestID = "123E4567-E89B-12D3-A456-426614174000"
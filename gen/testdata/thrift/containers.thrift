@@ -52,3 +52,8 @@ struct ListOfConflictingUUIDs {
     1: required list<typedefs.UUID> uuids
     2: required list<uuid_conflict.UUID> otherUUIDs
 }
+
+struct MapsWithPrimitiveKeys {
+    1: optional map<bool, string> boolToString
+    2: required map<double, i64> doubleToInt
+}
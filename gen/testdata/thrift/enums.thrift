@@ -38,3 +38,9 @@ enum lowerCaseEnum {
 
 // collision with RecordType_Values() function.
 enum RecordType_Values { FOO, BAR }
+
+// Enum which rejects unrecognized values when decoded.
+enum StrictEnum {
+    ACTIVE = 1,
+    INACTIVE = 2,
+} (go.strict = "true")
@@ -1,6 +1,10 @@
 exception EmptyException {}
 
+exception InvalidArgumentException {
+    1: optional string message
+} (rpc.code = "INVALID_ARGUMENT")
+
 exception DoesNotExistException {
     1: required string key
     2: optional string Error (go.name="Error2")
-}
+} (http.status = "404", grpc.code = "NOT_FOUND", safety = "safe")
@@ -5,7 +5,7 @@ typedef string Key
 
 exception InternalError {
     1: optional string message
-}
+} (safety = "unsafe")
 
 service KeyValue {
     // void and no exceptions
@@ -14,7 +14,7 @@ service KeyValue {
     void setValueV2(
         1: required Key key,
         2: required unions.ArbitraryValue value,
-    )
+    ) (max_request_bytes = "1048576")
 
     // Return with exceptions
     unions.ArbitraryValue getValue(1: Key key)
@@ -39,6 +39,11 @@ service KeyValue {
 service Cache {
     oneway void clear()
     oneway void clearAfter(1: i64 durationMS)
+    oneway void clearMatching(
+        1: required string prefix
+        2: optional i64 durationMS
+        3: optional list<Key> exclude
+    )
 }
 
 struct ConflictingNames_SetValue_Args {
@@ -76,6 +76,106 @@ struct Node {
     2: optional List tail
 }
 
+// self-referential through containers
+struct Tree {
+    1: required string value
+    2: optional Tree left
+    3: optional Tree right
+    4: optional list<Tree> children
+    5: optional map<string, Tree> named
+}
+
+//////////////////////////////////////////////////////////////////////////////
+// go.implements
+
+struct Failure {
+    1: required string reason
+} (go.implements = "fmt.Stringer; error")
+
+//////////////////////////////////////////////////////////////////////////////
+// normalize
+
+struct NormalizedUser {
+    1: required string name (normalize = "trim")
+    2: optional string email (normalize = "trim, lower")
+    3: optional string countryCode (normalize = "upper,trim")
+    4: optional string bio
+}
+
+//////////////////////////////////////////////////////////////////////////////
+// validate
+
+struct ValidatedUser {
+    1: required string name (validate.min = "1", validate.max = "16")
+    2: optional i32 age (validate.min = "0", validate.max = "150")
+    3: optional string email (validate.pattern = "^[^@\\s]+@[^@\\s]+$")
+    4: optional double score (validate.min = "-1", validate.max = "1.5")
+    5: optional list<string> tags (validate.max = "2")
+    6: optional ValidatedUser manager
+    7: optional list<ValidatedUser> reports
+    8: optional map<string, ValidatedUser> byName
+}
+
+//////////////////////////////////////////////////////////////////////////////
+// headers
+
+struct Request {
+    1: optional map<string, binary> headers (
+        headers = "true",
+        headers.Deadline = "x-deadline:i64",
+        headers.Caller = "x-caller:string",
+        headers.Traced = "x-traced:bool",
+        headers.Token = "x-token",
+    )
+    2: optional string body
+}
+
+struct TextHeaders {
+    1: required map<string, string> values (
+        headers = "true",
+        headers.Priority = "priority:i32",
+        headers.Weight = "weight:double",
+        headers.Raw = "raw:binary",
+    )
+}
+
+//////////////////////////////////////////////////////////////////////////////
+// streams
+
+struct PointStream {
+    1: required list<Point> points (go.stream = "true")
+    2: optional map<string, i32> counts (go.stream = "true")
+    3: optional set<string> tags (go.stream = "true")
+    4: optional string name
+}
+
+//////////////////////////////////////////////////////////////////////////////
+// conversions
+
+struct UserV1 {
+    1: required string name
+    2: optional i32 age
+    3: optional string email
+    4: required i64 id
+    5: optional list<string> nicknames
+    7: required bool active
+}
+
+struct UserV2 {
+    1: required string name
+    2: required i32 age
+    3: optional binary email
+    4: optional i64 id
+    5: optional list<string> nicknames
+    6: optional list<string> tags
+    7: optional bool active
+} (go.convert_from = "UserV1")
+
+struct UserV3 {
+    1: required string name
+    5: optional list<string> nicknames
+} (go.convert_from = "UserV1, UserV2")
+
 
 //////////////////////////////////////////////////////////////////////////////
 // Default values
@@ -99,3 +199,40 @@ struct DefaultsStruct {
         "endPoint":   {"x": 3, "y": 4},
     }
 }
+
+struct UUIDStruct {
+    1: required uuid id
+    2: optional uuid parentID
+    3: optional list<uuid> children
+    4: optional set<uuid> tags
+    5: optional map<uuid, string> names
+    6: optional uuid defaultID = "123e4567-e89b-12d3-a456-426614174000"
+    // uuid is not a keyword so it may still be used as a field name.
+    7: optional string uuid
+}
+
+//////////////////////////////////////////////////////////////////////////////
+// value fields
+
+struct ValueFields {
+    1: optional i32 count (go.pointer = "false")
+    2: optional string name (go.pointer = "false", normalize = "trim")
+    3: optional bool enabled (go.pointer = "false")
+    4: optional enums.EnumDefault kind (go.pointer = "false")
+    5: optional i32 limit = 100 (go.pointer = "false")
+    6: optional uuid id (go.pointer = "false")
+    7: optional i64 pointer
+}
+
+//////////////////////////////////////////////////////////////////////////////
+// builders
+
+struct BuiltUser {
+    1: required string name (validate.min = "1")
+    2: optional string email
+    3: optional list<string> tags
+    4: optional i32 age (go.pointer = "false")
+    5: optional BuiltUser manager
+    6: required Point location
+    7: optional bool active = true
+} (go.builder = "true")
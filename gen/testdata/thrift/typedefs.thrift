@@ -24,6 +24,30 @@ struct Transition {
     3: optional EventGroup events
 }
 
+const State DefaultState = "idle"
+
+struct DefaultPrimitiveTypedef {
+    1: optional State state = "hello"  // typedef of primitive
+    2: optional Timestamp time = 42
+    3: optional State initial = DefaultState  // reference to a typed constant
+    4: optional MyEnum myEnum = enums.EnumWithValues.Y  // typedef in another module
+}
+
+struct NormalizedTransition {
+    1: required State fromState (normalize = "trim,lower")
+    2: optional State toState (normalize = "trim,lower")
+}
+
+typedef structs.ValidatedUser ValidatedEmployee
+
+struct ValidatedTeam {
+    1: required State state (validate.pattern = "^[a-z]+$", validate.max = "8")
+    2: optional ValidatedEmployee lead
+    3: optional set<structs.ValidatedUser> members
+    4: optional map<structs.ValidatedUser, i32> ranks
+    5: optional list<list<ValidatedEmployee>> rotations
+}
+
 typedef binary PDF  // alias of []byte
 
 typedef set<structs.Frame> FrameGroup
@@ -35,3 +59,5 @@ typedef set<binary> BinarySet
 typedef map<structs.Edge, structs.Edge> EdgeMap
 
 typedef enums.EnumWithValues MyEnum
+
+typedef uuid RequestID
@@ -0,0 +1,8 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+package typedefs
+
+
+
+const DefaultState State = "idle"
@@ -9,6 +9,6 @@ import (
 	"go.uber.org/thriftrw/thriftreflect"
 )
 
-var ThriftModule = &thriftreflect.ThriftModule{Name: "typedefs", Package: "go.uber.org/thriftrw/gen/testdata/typedefs", FilePath: "typedefs.thrift", SHA1: "6c080659c8c233951ce263aca6081a6fea54a386", Includes: []*thriftreflect.ThriftModule{enums.ThriftModule, structs.ThriftModule}, Raw: rawIDL}
+var ThriftModule = &thriftreflect.ThriftModule{Name: "typedefs", Package: "go.uber.org/thriftrw/gen/testdata/typedefs", FilePath: "typedefs.thrift", SHA1: "091f4d8e70bf1096f096b94006be728cb09369eb", Includes: []*thriftreflect.ThriftModule{enums.ThriftModule, structs.ThriftModule}, Raw: rawIDL}
 
-const rawIDL = "include \"./structs.thrift\"\ninclude \"./enums.thrift\"\n\ntypedef i64 Timestamp  // alias of primitive\ntypedef string State\n\ntypedef i128 UUID  // alias of struct\n\ntypedef list<Event> EventGroup  // alias fo collection\n\nstruct i128 {\n    1: required i64 high\n    2: required i64 low\n}\n\nstruct Event {\n    1: required UUID uuid  // required typedef\n    2: optional Timestamp time  // optional typedef\n}\n\nstruct Transition {\n    1: required State fromState\n    2: required State toState\n    3: optional EventGroup events\n}\n\ntypedef binary PDF  // alias of []byte\n\ntypedef set<structs.Frame> FrameGroup\n\ntypedef map<structs.Point, structs.Point> PointMap\n\ntypedef set<binary> BinarySet\n\ntypedef map<structs.Edge, structs.Edge> EdgeMap\n\ntypedef enums.EnumWithValues MyEnum\n"
+const rawIDL = "include \"./structs.thrift\"\ninclude \"./enums.thrift\"\n\ntypedef i64 Timestamp  // alias of primitive\ntypedef string State\n\ntypedef i128 UUID  // alias of struct\n\ntypedef list<Event> EventGroup  // alias fo collection\n\nstruct i128 {\n    1: required i64 high\n    2: required i64 low\n}\n\nstruct Event {\n    1: required UUID uuid  // required typedef\n    2: optional Timestamp time  // optional typedef\n}\n\nstruct Transition {\n    1: required State fromState\n    2: required State toState\n    3: optional EventGroup events\n}\n\nconst State DefaultState = \"idle\"\n\nstruct DefaultPrimitiveTypedef {\n    1: optional State state = \"hello\"  // typedef of primitive\n    2: optional Timestamp time = 42\n    3: optional State initial = DefaultState  // reference to a typed constant\n    4: optional MyEnum myEnum = enums.EnumWithValues.Y  // typedef in another module\n}\n\nstruct NormalizedTransition {\n    1: required State fromState (normalize = \"trim,lower\")\n    2: optional State toState (normalize = \"trim,lower\")\n}\n\ntypedef structs.ValidatedUser ValidatedEmployee\n\nstruct ValidatedTeam {\n    1: required State state (validate.pattern = \"^[a-z]+$\", validate.max = \"8\")\n    2: optional ValidatedEmployee lead\n    3: optional set<structs.ValidatedUser> members\n    4: optional map<structs.ValidatedUser, i32> ranks\n    5: optional list<list<ValidatedEmployee>> rotations\n}\n\ntypedef binary PDF  // alias of []byte\n\ntypedef set<structs.Frame> FrameGroup\n\ntypedef map<structs.Point, structs.Point> PointMap\n\ntypedef set<binary> BinarySet\n\ntypedef map<structs.Edge, structs.Edge> EdgeMap\n\ntypedef enums.EnumWithValues MyEnum\n\ntypedef uuid RequestID\n"
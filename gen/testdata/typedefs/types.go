@@ -5,11 +5,12 @@ package typedefs
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"go.uber.org/thriftrw/gen/testdata/enums"
 	"go.uber.org/thriftrw/gen/testdata/structs"
+	"go.uber.org/thriftrw/uuid"
 	"go.uber.org/thriftrw/wire"
+	"regexp"
 	"strings"
 )
 
@@ -101,12 +102,265 @@ func (lhs BinarySet) Equals(rhs BinarySet) bool {
 	return _Set_Binary_Equals(lhs, rhs)
 }
 
+type DefaultPrimitiveTypedef struct {
+	State   *State     `json:"state,omitempty"`
+	Time    *Timestamp `json:"time,omitempty"`
+	Initial *State     `json:"initial,omitempty"`
+	MyEnum  *MyEnum    `json:"myEnum,omitempty"`
+}
+
+func _State_ptr(v State) *State {
+	return &v
+}
+
+func _Timestamp_ptr(v Timestamp) *Timestamp {
+	return &v
+}
+
+func _MyEnum_ptr(v MyEnum) *MyEnum {
+	return &v
+}
+
+func (v *DefaultPrimitiveTypedef) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.State == nil {
+		v.State = _State_ptr("hello")
+	}
+	{
+		w, err = v.State.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.Time == nil {
+		v.Time = _Timestamp_ptr(Timestamp(42))
+	}
+	{
+		w, err = v.Time.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.Initial == nil {
+		v.Initial = _State_ptr(DefaultState)
+	}
+	{
+		w, err = v.Initial.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.MyEnum == nil {
+		v.MyEnum = _MyEnum_ptr(MyEnum(enums.EnumWithValuesY))
+	}
+	{
+		w, err = v.MyEnum.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _State_Read(w wire.Value) (State, error) {
+	var x State
+	err := x.FromWire(w)
+	return x, err
+}
+
+func _Timestamp_Read(w wire.Value) (Timestamp, error) {
+	var x Timestamp
+	err := x.FromWire(w)
+	return x, err
+}
+
+func _MyEnum_Read(w wire.Value) (MyEnum, error) {
+	var x MyEnum
+	err := x.FromWire(w)
+	return x, err
+}
+
+func (v *DefaultPrimitiveTypedef) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				var x State
+				x, err = _State_Read(field.Value)
+				v.State = &x
+				if err != nil {
+					wire.ObserveDecodeError("DefaultPrimitiveTypedef", "State", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 2:
+			if field.Value.Type() == wire.TI64 {
+				var x Timestamp
+				x, err = _Timestamp_Read(field.Value)
+				v.Time = &x
+				if err != nil {
+					wire.ObserveDecodeError("DefaultPrimitiveTypedef", "Time", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 3:
+			if field.Value.Type() == wire.TBinary {
+				var x State
+				x, err = _State_Read(field.Value)
+				v.Initial = &x
+				if err != nil {
+					wire.ObserveDecodeError("DefaultPrimitiveTypedef", "Initial", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 4:
+			if field.Value.Type() == wire.TI32 {
+				var x MyEnum
+				x, err = _MyEnum_Read(field.Value)
+				v.MyEnum = &x
+				if err != nil {
+					wire.ObserveDecodeError("DefaultPrimitiveTypedef", "MyEnum", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	if v.State == nil {
+		v.State = _State_ptr("hello")
+	}
+	if v.Time == nil {
+		v.Time = _Timestamp_ptr(Timestamp(42))
+	}
+	if v.Initial == nil {
+		v.Initial = _State_ptr(DefaultState)
+	}
+	if v.MyEnum == nil {
+		v.MyEnum = _MyEnum_ptr(MyEnum(enums.EnumWithValuesY))
+	}
+	return nil
+}
+
+func (v *DefaultPrimitiveTypedef) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [4]string
+	i := 0
+	if v.State != nil {
+		fields[i] = fmt.Sprintf("State: %v", *(v.State))
+		i++
+	}
+	if v.Time != nil {
+		fields[i] = fmt.Sprintf("Time: %v", *(v.Time))
+		i++
+	}
+	if v.Initial != nil {
+		fields[i] = fmt.Sprintf("Initial: %v", *(v.Initial))
+		i++
+	}
+	if v.MyEnum != nil {
+		fields[i] = fmt.Sprintf("MyEnum: %v", *(v.MyEnum))
+		i++
+	}
+	return fmt.Sprintf("DefaultPrimitiveTypedef{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _State_EqualsPtr(lhs, rhs *State) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _Timestamp_EqualsPtr(lhs, rhs *Timestamp) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _MyEnum_EqualsPtr(lhs, rhs *MyEnum) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func (v *DefaultPrimitiveTypedef) Equals(rhs *DefaultPrimitiveTypedef) bool {
+	if !_State_EqualsPtr(v.State, rhs.State) {
+		return false
+	}
+	if !_Timestamp_EqualsPtr(v.Time, rhs.Time) {
+		return false
+	}
+	if !_State_EqualsPtr(v.Initial, rhs.Initial) {
+		return false
+	}
+	if !_MyEnum_EqualsPtr(v.MyEnum, rhs.MyEnum) {
+		return false
+	}
+	return true
+}
+
+func (v *DefaultPrimitiveTypedef) GetState() (o State) {
+	if v != nil && v.State != nil {
+		return *v.State
+	}
+	o = "hello"
+	return
+}
+
+func (v *DefaultPrimitiveTypedef) GetTime() (o Timestamp) {
+	if v != nil && v.Time != nil {
+		return *v.Time
+	}
+	o = Timestamp(42)
+	return
+}
+
+func (v *DefaultPrimitiveTypedef) GetInitial() (o State) {
+	if v != nil && v.Initial != nil {
+		return *v.Initial
+	}
+	o = DefaultState
+	return
+}
+
+func (v *DefaultPrimitiveTypedef) GetMyEnum() (o MyEnum) {
+	if v != nil && v.MyEnum != nil {
+		return *v.MyEnum
+	}
+	o = MyEnum(enums.EnumWithValuesY)
+	return
+}
+
 type _Map_Edge_Edge_MapItemList []struct {
 	Key   *structs.Edge
 	Value *structs.Edge
 }
 
 func (m _Map_Edge_Edge_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	keys := wire.NewKeySet(len(m))
 	for _, i := range m {
 		k := i.Key
 		v := i.Value
@@ -120,6 +374,11 @@ func (m _Map_Edge_Edge_MapItemList) ForEach(f func(wire.MapItem) error) error {
 		if err != nil {
 			return err
 		}
+		if _, dup, err := keys.Add(kw); err != nil {
+			return err
+		} else if dup {
+			return fmt.Errorf("invalid map key: duplicate key %v", k)
+		}
 		vw, err := v.ToWire()
 		if err != nil {
 			return err
@@ -167,7 +426,12 @@ func _Map_Edge_Edge_Read(m wire.MapItemList) ([]struct {
 		Key   *structs.Edge
 		Value *structs.Edge
 	}, 0, m.Size())
+	keys := wire.NewKeySet(m.Size())
 	err := m.ForEach(func(x wire.MapItem) error {
+		i, dup, err := keys.Add(x.Key)
+		if err != nil {
+			return err
+		}
 		k, err := _Edge_Read(x.Key)
 		if err != nil {
 			return err
@@ -176,6 +440,10 @@ func _Map_Edge_Edge_Read(m wire.MapItemList) ([]struct {
 		if err != nil {
 			return err
 		}
+		if dup {
+			o[i].Value = v
+			return nil
+		}
 		o = append(o, struct {
 			Key   *structs.Edge
 			Value *structs.Edge
@@ -260,7 +528,7 @@ func (v *Event) ToWire() (wire.Value, error) {
 		err    error
 	)
 	if v.UUID == nil {
-		return w, errors.New("field UUID of Event is required")
+		return w, wire.NilRequiredFieldError{Struct: "Event", Field: "UUID", ID: 1}
 	}
 	w, err = v.UUID.ToWire()
 	if err != nil {
@@ -285,12 +553,6 @@ func _UUID_Read(w wire.Value) (*UUID, error) {
 	return &x, err
 }
 
-func _Timestamp_Read(w wire.Value) (Timestamp, error) {
-	var x Timestamp
-	err := x.FromWire(w)
-	return x, err
-}
-
 func (v *Event) FromWire(w wire.Value) error {
 	var err error
 	uuidIsSet := false
@@ -300,6 +562,7 @@ func (v *Event) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.UUID, err = _UUID_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("Event", "UUID", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				uuidIsSet = true
@@ -310,13 +573,15 @@ func (v *Event) FromWire(w wire.Value) error {
 				x, err = _Timestamp_Read(field.Value)
 				v.Time = &x
 				if err != nil {
+					wire.ObserveDecodeError("Event", "Time", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
 		}
 	}
 	if !uuidIsSet {
-		return errors.New("field UUID of Event is required")
+		wire.ObserveDecodeError("Event", "UUID", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Event", Field: "UUID", ID: 1}
 	}
 	return nil
 }
@@ -336,15 +601,6 @@ func (v *Event) String() string {
 	return fmt.Sprintf("Event{%v}", strings.Join(fields[:i], ", "))
 }
 
-func _Timestamp_EqualsPtr(lhs, rhs *Timestamp) bool {
-	if lhs != nil && rhs != nil {
-		x := *lhs
-		y := *rhs
-		return (x == y)
-	}
-	return lhs == nil && rhs == nil
-}
-
 func (v *Event) Equals(rhs *Event) bool {
 	if !v.UUID.Equals(rhs.UUID) {
 		return false
@@ -355,6 +611,20 @@ func (v *Event) Equals(rhs *Event) bool {
 	return true
 }
 
+func (v *Event) GetUUID() (o *UUID) {
+	if v != nil {
+		o = v.UUID
+	}
+	return
+}
+
+func (v *Event) GetTime() (o Timestamp) {
+	if v != nil && v.Time != nil {
+		return *v.Time
+	}
+	return
+}
+
 type _List_Event_ValueList []*Event
 
 func (v _List_Event_ValueList) ForEach(f func(wire.Value) error) error {
@@ -561,69 +831,192 @@ func (v *MyEnum) FromWire(w wire.Value) error {
 	return err
 }
 
-func (lhs MyEnum) Equals(rhs MyEnum) bool {
-	return lhs.Equals(rhs)
-}
-
-type PDF []byte
-
-func (v PDF) ToWire() (wire.Value, error) {
-	x := ([]byte)(v)
-	return wire.NewValueBinary(x), error(nil)
-}
-
-func (v PDF) String() string {
-	x := ([]byte)(v)
-	return fmt.Sprint(x)
+func (v MyEnum) MarshalText() ([]byte, error) {
+	return (enums.EnumWithValues)(v).MarshalText()
 }
 
-func (v *PDF) FromWire(w wire.Value) error {
-	x, err := w.GetBinary(), error(nil)
-	*v = (PDF)(x)
-	return err
+func (v *MyEnum) UnmarshalText(text []byte) error {
+	return (*enums.EnumWithValues)(v).UnmarshalText(text)
 }
 
-func (lhs PDF) Equals(rhs PDF) bool {
-	return bytes.Equal(lhs, rhs)
+func (lhs MyEnum) Equals(rhs MyEnum) bool {
+	return lhs.Equals(rhs)
 }
 
-type _Map_Point_Point_MapItemList []struct {
-	Key   *structs.Point
-	Value *structs.Point
+type NormalizedTransition struct {
+	FromState State  `json:"fromState"`
+	ToState   *State `json:"toState,omitempty"`
 }
 
-func (m _Map_Point_Point_MapItemList) ForEach(f func(wire.MapItem) error) error {
-	for _, i := range m {
-		k := i.Key
-		v := i.Value
-		if k == nil {
-			return fmt.Errorf("invalid map key: value is nil")
-		}
-		if v == nil {
-			return fmt.Errorf("invalid [%v]: value is nil", k)
-		}
-		kw, err := k.ToWire()
-		if err != nil {
-			return err
-		}
-		vw, err := v.ToWire()
-		if err != nil {
-			return err
-		}
-		err = f(wire.MapItem{Key: kw, Value: vw})
+func (v *NormalizedTransition) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = v.FromState.ToWire()
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.ToState != nil {
+		w, err = v.ToState.ToWire()
 		if err != nil {
-			return err
+			return w, err
 		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
 	}
-	return nil
-}
-
-func (m _Map_Point_Point_MapItemList) Size() int {
-	return len(m)
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func (_Map_Point_Point_MapItemList) KeyType() wire.Type {
-	return wire.TStruct
+func (v *NormalizedTransition) FromWire(w wire.Value) error {
+	var err error
+	fromStateIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.FromState, err = _State_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("NormalizedTransition", "FromState", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				fromStateIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TBinary {
+				var x State
+				x, err = _State_Read(field.Value)
+				v.ToState = &x
+				if err != nil {
+					wire.ObserveDecodeError("NormalizedTransition", "ToState", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	if !fromStateIsSet {
+		wire.ObserveDecodeError("NormalizedTransition", "FromState", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "NormalizedTransition", Field: "FromState", ID: 1}
+	}
+	return nil
+}
+
+func (v *NormalizedTransition) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	fields[i] = fmt.Sprintf("FromState: %v", v.FromState)
+	i++
+	if v.ToState != nil {
+		fields[i] = fmt.Sprintf("ToState: %v", *(v.ToState))
+		i++
+	}
+	return fmt.Sprintf("NormalizedTransition{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *NormalizedTransition) Equals(rhs *NormalizedTransition) bool {
+	if !(v.FromState == rhs.FromState) {
+		return false
+	}
+	if !_State_EqualsPtr(v.ToState, rhs.ToState) {
+		return false
+	}
+	return true
+}
+
+func (v *NormalizedTransition) GetFromState() (o State) {
+	if v != nil {
+		o = v.FromState
+	}
+	return
+}
+
+func (v *NormalizedTransition) GetToState() (o State) {
+	if v != nil && v.ToState != nil {
+		return *v.ToState
+	}
+	return
+}
+
+func (v *NormalizedTransition) Sanitize() {
+	v.FromState = (State)(strings.ToLower(strings.TrimSpace(string(v.FromState))))
+	if v.ToState != nil {
+		x := (State)(strings.ToLower(strings.TrimSpace(string(*v.ToState))))
+		v.ToState = &x
+	}
+}
+
+type PDF []byte
+
+func (v PDF) ToWire() (wire.Value, error) {
+	x := ([]byte)(v)
+	return wire.NewValueBinary(x), error(nil)
+}
+
+func (v PDF) String() string {
+	x := ([]byte)(v)
+	return fmt.Sprint(x)
+}
+
+func (v *PDF) FromWire(w wire.Value) error {
+	x, err := w.GetBinary(), error(nil)
+	*v = (PDF)(x)
+	return err
+}
+
+func (lhs PDF) Equals(rhs PDF) bool {
+	return bytes.Equal(lhs, rhs)
+}
+
+type _Map_Point_Point_MapItemList []struct {
+	Key   *structs.Point
+	Value *structs.Point
+}
+
+func (m _Map_Point_Point_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	keys := wire.NewKeySet(len(m))
+	for _, i := range m {
+		k := i.Key
+		v := i.Value
+		if k == nil {
+			return fmt.Errorf("invalid map key: value is nil")
+		}
+		if v == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", k)
+		}
+		kw, err := k.ToWire()
+		if err != nil {
+			return err
+		}
+		if _, dup, err := keys.Add(kw); err != nil {
+			return err
+		} else if dup {
+			return fmt.Errorf("invalid map key: duplicate key %v", k)
+		}
+		vw, err := v.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_Point_Point_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_Point_Point_MapItemList) KeyType() wire.Type {
+	return wire.TStruct
 }
 
 func (_Map_Point_Point_MapItemList) ValueType() wire.Type {
@@ -653,7 +1046,12 @@ func _Map_Point_Point_Read(m wire.MapItemList) ([]struct {
 		Key   *structs.Point
 		Value *structs.Point
 	}, 0, m.Size())
+	keys := wire.NewKeySet(m.Size())
 	err := m.ForEach(func(x wire.MapItem) error {
+		i, dup, err := keys.Add(x.Key)
+		if err != nil {
+			return err
+		}
 		k, err := _Point_Read(x.Key)
 		if err != nil {
 			return err
@@ -662,6 +1060,10 @@ func _Map_Point_Point_Read(m wire.MapItemList) ([]struct {
 		if err != nil {
 			return err
 		}
+		if dup {
+			o[i].Value = v
+			return nil
+		}
 		o = append(o, struct {
 			Key   *structs.Point
 			Value *structs.Point
@@ -733,6 +1135,36 @@ func (lhs PointMap) Equals(rhs PointMap) bool {
 	return _Map_Point_Point_Equals(lhs, rhs)
 }
 
+type RequestID uuid.UUID
+
+func (v RequestID) ToWire() (wire.Value, error) {
+	x := (uuid.UUID)(v)
+	return x.ToWire()
+}
+
+func (v RequestID) String() string {
+	x := (uuid.UUID)(v)
+	return fmt.Sprint(x)
+}
+
+func (v *RequestID) FromWire(w wire.Value) error {
+	x, err := uuid.FromBytes(w.GetBinary())
+	*v = (RequestID)(x)
+	return err
+}
+
+func (v RequestID) MarshalText() ([]byte, error) {
+	return (uuid.UUID)(v).MarshalText()
+}
+
+func (v *RequestID) UnmarshalText(text []byte) error {
+	return (*uuid.UUID)(v).UnmarshalText(text)
+}
+
+func (lhs RequestID) Equals(rhs RequestID) bool {
+	return (lhs == rhs)
+}
+
 type State string
 
 func (v State) ToWire() (wire.Value, error) {
@@ -751,6 +1183,15 @@ func (v *State) FromWire(w wire.Value) error {
 	return err
 }
 
+func (v State) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+func (v *State) UnmarshalText(text []byte) error {
+	*v = State(text)
+	return nil
+}
+
 func (lhs State) Equals(rhs State) bool {
 	return (lhs == rhs)
 }
@@ -813,12 +1254,6 @@ func (v *Transition) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _State_Read(w wire.Value) (State, error) {
-	var x State
-	err := x.FromWire(w)
-	return x, err
-}
-
 func _EventGroup_Read(w wire.Value) (EventGroup, error) {
 	var x EventGroup
 	err := x.FromWire(w)
@@ -835,6 +1270,7 @@ func (v *Transition) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.FromState, err = _State_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("Transition", "FromState", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				fromStateIsSet = true
@@ -843,6 +1279,7 @@ func (v *Transition) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.ToState, err = _State_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("Transition", "ToState", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				toStateIsSet = true
@@ -851,16 +1288,19 @@ func (v *Transition) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.Events, err = _EventGroup_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("Transition", "Events", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
 		}
 	}
 	if !fromStateIsSet {
-		return errors.New("field FromState of Transition is required")
+		wire.ObserveDecodeError("Transition", "FromState", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Transition", Field: "FromState", ID: 1}
 	}
 	if !toStateIsSet {
-		return errors.New("field ToState of Transition is required")
+		wire.ObserveDecodeError("Transition", "ToState", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Transition", Field: "ToState", ID: 2}
 	}
 	return nil
 }
@@ -895,6 +1335,27 @@ func (v *Transition) Equals(rhs *Transition) bool {
 	return true
 }
 
+func (v *Transition) GetFromState() (o State) {
+	if v != nil {
+		o = v.FromState
+	}
+	return
+}
+
+func (v *Transition) GetToState() (o State) {
+	if v != nil {
+		o = v.ToState
+	}
+	return
+}
+
+func (v *Transition) GetEvents() (o EventGroup) {
+	if v != nil && v.Events != nil {
+		return v.Events
+	}
+	return
+}
+
 type UUID I128
 
 func (v *UUID) ToWire() (wire.Value, error) {
@@ -915,85 +1376,706 @@ func (lhs *UUID) Equals(rhs *UUID) bool {
 	return (*I128)(lhs).Equals((*I128)(rhs))
 }
 
-type I128 struct {
-	High int64 `json:"high"`
-	Low  int64 `json:"low"`
+type ValidatedEmployee structs.ValidatedUser
+
+func (v *ValidatedEmployee) ToWire() (wire.Value, error) {
+	x := (*structs.ValidatedUser)(v)
+	return x.ToWire()
 }
 
-func (v *I128) ToWire() (wire.Value, error) {
-	var (
-		fields [2]wire.Field
-		i      int = 0
-		w      wire.Value
-		err    error
-	)
-	w, err = wire.NewValueI64(v.High), error(nil)
-	if err != nil {
-		return w, err
-	}
-	fields[i] = wire.Field{ID: 1, Value: w}
-	i++
-	w, err = wire.NewValueI64(v.Low), error(nil)
-	if err != nil {
-		return w, err
-	}
-	fields[i] = wire.Field{ID: 2, Value: w}
-	i++
-	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+func (v *ValidatedEmployee) String() string {
+	x := (*structs.ValidatedUser)(v)
+	return fmt.Sprint(x)
 }
 
-func (v *I128) FromWire(w wire.Value) error {
-	var err error
-	highIsSet := false
-	lowIsSet := false
-	for _, field := range w.GetStruct().Fields {
-		switch field.ID {
-		case 1:
-			if field.Value.Type() == wire.TI64 {
-				v.High, err = field.Value.GetI64(), error(nil)
-				if err != nil {
-					return err
-				}
-				highIsSet = true
-			}
-		case 2:
-			if field.Value.Type() == wire.TI64 {
-				v.Low, err = field.Value.GetI64(), error(nil)
-				if err != nil {
-					return err
-				}
-				lowIsSet = true
-			}
+func (v *ValidatedEmployee) FromWire(w wire.Value) error {
+	return (*structs.ValidatedUser)(v).FromWire(w)
+}
+
+func (lhs *ValidatedEmployee) Equals(rhs *ValidatedEmployee) bool {
+	return (*structs.ValidatedUser)(lhs).Equals((*structs.ValidatedUser)(rhs))
+}
+
+type ValidatedTeam struct {
+	State   State                    `json:"state"`
+	Lead    *ValidatedEmployee       `json:"lead,omitempty"`
+	Members []*structs.ValidatedUser `json:"members"`
+	Ranks   []struct {
+		Key   *structs.ValidatedUser
+		Value int32
+	} `json:"ranks"`
+	Rotations [][]*ValidatedEmployee `json:"rotations"`
+}
+
+type _Set_ValidatedUser_ValueList []*structs.ValidatedUser
+
+func (v _Set_ValidatedUser_ValueList) ForEach(f func(wire.Value) error) error {
+	for _, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid set item: value is nil")
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
 		}
-	}
-	if !highIsSet {
-		return errors.New("field High of I128 is required")
-	}
-	if !lowIsSet {
-		return errors.New("field Low of I128 is required")
 	}
 	return nil
 }
 
-func (v *I128) String() string {
-	if v == nil {
-		return "<nil>"
-	}
-	var fields [2]string
-	i := 0
-	fields[i] = fmt.Sprintf("High: %v", v.High)
-	i++
-	fields[i] = fmt.Sprintf("Low: %v", v.Low)
-	i++
-	return fmt.Sprintf("I128{%v}", strings.Join(fields[:i], ", "))
+func (v _Set_ValidatedUser_ValueList) Size() int {
+	return len(v)
 }
 
-func (v *I128) Equals(rhs *I128) bool {
-	if !(v.High == rhs.High) {
-		return false
-	}
-	if !(v.Low == rhs.Low) {
-		return false
-	}
-	return true
+func (_Set_ValidatedUser_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_Set_ValidatedUser_ValueList) Close() {
+}
+
+type _Map_ValidatedUser_I32_MapItemList []struct {
+	Key   *structs.ValidatedUser
+	Value int32
+}
+
+func (m _Map_ValidatedUser_I32_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	keys := wire.NewKeySet(len(m))
+	for _, i := range m {
+		k := i.Key
+		v := i.Value
+		if k == nil {
+			return fmt.Errorf("invalid map key: value is nil")
+		}
+		kw, err := k.ToWire()
+		if err != nil {
+			return err
+		}
+		if _, dup, err := keys.Add(kw); err != nil {
+			return err
+		} else if dup {
+			return fmt.Errorf("invalid map key: duplicate key %v", k)
+		}
+		vw, err := wire.NewValueI32(v), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_ValidatedUser_I32_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_ValidatedUser_I32_MapItemList) KeyType() wire.Type {
+	return wire.TStruct
+}
+
+func (_Map_ValidatedUser_I32_MapItemList) ValueType() wire.Type {
+	return wire.TI32
+}
+
+func (_Map_ValidatedUser_I32_MapItemList) Close() {
+}
+
+type _List_ValidatedEmployee_ValueList []*ValidatedEmployee
+
+func (v _List_ValidatedEmployee_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_ValidatedEmployee_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_ValidatedEmployee_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_ValidatedEmployee_ValueList) Close() {
+}
+
+type _List_List_ValidatedEmployee_ValueList [][]*ValidatedEmployee
+
+func (v _List_List_ValidatedEmployee_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := wire.NewValueList(_List_ValidatedEmployee_ValueList(x)), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_List_ValidatedEmployee_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_List_ValidatedEmployee_ValueList) ValueType() wire.Type {
+	return wire.TList
+}
+
+func (_List_List_ValidatedEmployee_ValueList) Close() {
+}
+
+func (v *ValidatedTeam) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = v.State.ToWire()
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.Lead != nil {
+		w, err = v.Lead.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.Members != nil {
+		w, err = wire.NewValueSet(_Set_ValidatedUser_ValueList(v.Members)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.Ranks != nil {
+		w, err = wire.NewValueMap(_Map_ValidatedUser_I32_MapItemList(v.Ranks)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.Rotations != nil {
+		w, err = wire.NewValueList(_List_List_ValidatedEmployee_ValueList(v.Rotations)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ValidatedEmployee_Read(w wire.Value) (*ValidatedEmployee, error) {
+	var x ValidatedEmployee
+	err := x.FromWire(w)
+	return &x, err
+}
+
+func _ValidatedUser_Read(w wire.Value) (*structs.ValidatedUser, error) {
+	var v structs.ValidatedUser
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _Set_ValidatedUser_Read(s wire.ValueList) ([]*structs.ValidatedUser, error) {
+	if s.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+	o := make([]*structs.ValidatedUser, 0, s.Size())
+	err := s.ForEach(func(x wire.Value) error {
+		i, err := _ValidatedUser_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	s.Close()
+	return o, err
+}
+
+func _Map_ValidatedUser_I32_Read(m wire.MapItemList) ([]struct {
+	Key   *structs.ValidatedUser
+	Value int32
+}, error) {
+	if m.KeyType() != wire.TStruct {
+		return nil, nil
+	}
+	if m.ValueType() != wire.TI32 {
+		return nil, nil
+	}
+	o := make([]struct {
+		Key   *structs.ValidatedUser
+		Value int32
+	}, 0, m.Size())
+	keys := wire.NewKeySet(m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		i, dup, err := keys.Add(x.Key)
+		if err != nil {
+			return err
+		}
+		k, err := _ValidatedUser_Read(x.Key)
+		if err != nil {
+			return err
+		}
+		v, err := x.Value.GetI32(), error(nil)
+		if err != nil {
+			return err
+		}
+		if dup {
+			o[i].Value = v
+			return nil
+		}
+		o = append(o, struct {
+			Key   *structs.ValidatedUser
+			Value int32
+		}{k, v})
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+func _List_ValidatedEmployee_Read(l wire.ValueList) ([]*ValidatedEmployee, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+	o := make([]*ValidatedEmployee, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _ValidatedEmployee_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _List_List_ValidatedEmployee_Read(l wire.ValueList) ([][]*ValidatedEmployee, error) {
+	if l.ValueType() != wire.TList {
+		return nil, nil
+	}
+	o := make([][]*ValidatedEmployee, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _List_ValidatedEmployee_Read(x.GetList())
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func (v *ValidatedTeam) FromWire(w wire.Value) error {
+	var err error
+	stateIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.State, err = _State_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("ValidatedTeam", "State", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				stateIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.Lead, err = _ValidatedEmployee_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("ValidatedTeam", "Lead", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 3:
+			if field.Value.Type() == wire.TSet {
+				v.Members, err = _Set_ValidatedUser_Read(field.Value.GetSet())
+				if err != nil {
+					wire.ObserveDecodeError("ValidatedTeam", "Members", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 4:
+			if field.Value.Type() == wire.TMap {
+				v.Ranks, err = _Map_ValidatedUser_I32_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("ValidatedTeam", "Ranks", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 5:
+			if field.Value.Type() == wire.TList {
+				v.Rotations, err = _List_List_ValidatedEmployee_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("ValidatedTeam", "Rotations", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	if !stateIsSet {
+		wire.ObserveDecodeError("ValidatedTeam", "State", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "ValidatedTeam", Field: "State", ID: 1}
+	}
+	return nil
+}
+
+func (v *ValidatedTeam) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [5]string
+	i := 0
+	fields[i] = fmt.Sprintf("State: %v", v.State)
+	i++
+	if v.Lead != nil {
+		fields[i] = fmt.Sprintf("Lead: %v", v.Lead)
+		i++
+	}
+	if v.Members != nil {
+		fields[i] = fmt.Sprintf("Members: %v", v.Members)
+		i++
+	}
+	if v.Ranks != nil {
+		fields[i] = fmt.Sprintf("Ranks: %v", v.Ranks)
+		i++
+	}
+	if v.Rotations != nil {
+		fields[i] = fmt.Sprintf("Rotations: %v", v.Rotations)
+		i++
+	}
+	return fmt.Sprintf("ValidatedTeam{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Set_ValidatedUser_Equals(lhs, rhs []*structs.ValidatedUser) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for _, x := range lhs {
+		ok := false
+		for _, y := range rhs {
+			if x.Equals(y) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func _Map_ValidatedUser_I32_Equals(lhs, rhs []struct {
+	Key   *structs.ValidatedUser
+	Value int32
+}) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for _, i := range lhs {
+		lk := i.Key
+		lv := i.Value
+		ok := false
+		for _, j := range rhs {
+			rk := j.Key
+			rv := j.Value
+			if !lk.Equals(rk) {
+				continue
+			}
+			if !(lv == rv) {
+				return false
+			}
+			ok = true
+			break
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func _List_ValidatedEmployee_Equals(lhs, rhs []*ValidatedEmployee) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func _List_List_ValidatedEmployee_Equals(lhs, rhs [][]*ValidatedEmployee) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !_List_ValidatedEmployee_Equals(lv, rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *ValidatedTeam) Equals(rhs *ValidatedTeam) bool {
+	if !(v.State == rhs.State) {
+		return false
+	}
+	if !((v.Lead == nil && rhs.Lead == nil) || (v.Lead != nil && rhs.Lead != nil && v.Lead.Equals(rhs.Lead))) {
+		return false
+	}
+	if !((v.Members == nil && rhs.Members == nil) || (v.Members != nil && rhs.Members != nil && _Set_ValidatedUser_Equals(v.Members, rhs.Members))) {
+		return false
+	}
+	if !((v.Ranks == nil && rhs.Ranks == nil) || (v.Ranks != nil && rhs.Ranks != nil && _Map_ValidatedUser_I32_Equals(v.Ranks, rhs.Ranks))) {
+		return false
+	}
+	if !((v.Rotations == nil && rhs.Rotations == nil) || (v.Rotations != nil && rhs.Rotations != nil && _List_List_ValidatedEmployee_Equals(v.Rotations, rhs.Rotations))) {
+		return false
+	}
+	return true
+}
+
+func (v *ValidatedTeam) GetState() (o State) {
+	if v != nil {
+		o = v.State
+	}
+	return
+}
+
+func (v *ValidatedTeam) GetLead() (o *ValidatedEmployee) {
+	if v != nil && v.Lead != nil {
+		return v.Lead
+	}
+	return
+}
+
+func (v *ValidatedTeam) GetMembers() (o []*structs.ValidatedUser) {
+	if v != nil && v.Members != nil {
+		return v.Members
+	}
+	return
+}
+
+func (v *ValidatedTeam) GetRanks() (o []struct {
+	Key   *structs.ValidatedUser
+	Value int32
+}) {
+	if v != nil && v.Ranks != nil {
+		return v.Ranks
+	}
+	return
+}
+
+func (v *ValidatedTeam) GetRotations() (o [][]*ValidatedEmployee) {
+	if v != nil && v.Rotations != nil {
+		return v.Rotations
+	}
+	return
+}
+
+var _ValidatedTeam_State_Pattern = regexp.MustCompile("^[a-z]+$")
+
+func _Set_ValidatedUser_Validate(v []*structs.ValidatedUser) error {
+	for _, x := range v {
+		if err := x.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _Map_ValidatedUser_I32_Validate(v []struct {
+	Key   *structs.ValidatedUser
+	Value int32
+}) error {
+	for _, x := range v {
+		if err := x.Key.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _List_ValidatedEmployee_Validate(v []*ValidatedEmployee) error {
+	for _, x := range v {
+		if err := (*structs.ValidatedUser)(x).Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _List_List_ValidatedEmployee_Validate(v [][]*ValidatedEmployee) error {
+	for _, x := range v {
+		if err := _List_ValidatedEmployee_Validate(x); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *ValidatedTeam) Validate() error {
+	if v == nil {
+		return nil
+	}
+	if len(v.State) > 8 {
+		return wire.InvalidFieldError{Struct: "ValidatedTeam", Field: "State", ID: 1, Reason: "length must be at most 8"}
+	}
+	if !_ValidatedTeam_State_Pattern.MatchString(string(v.State)) {
+		return wire.InvalidFieldError{Struct: "ValidatedTeam", Field: "State", ID: 1, Reason: "must match pattern \"^[a-z]+$\""}
+	}
+	if err := (*structs.ValidatedUser)(v.Lead).Validate(); err != nil {
+		return err
+	}
+	if err := _Set_ValidatedUser_Validate(v.Members); err != nil {
+		return err
+	}
+	if err := _Map_ValidatedUser_I32_Validate(v.Ranks); err != nil {
+		return err
+	}
+	if err := _List_List_ValidatedEmployee_Validate(v.Rotations); err != nil {
+		return err
+	}
+	return nil
+}
+
+type I128 struct {
+	High int64 `json:"high"`
+	Low  int64 `json:"low"`
+}
+
+func (v *I128) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueI64(v.High), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	w, err = wire.NewValueI64(v.Low), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 2, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *I128) FromWire(w wire.Value) error {
+	var err error
+	highIsSet := false
+	lowIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TI64 {
+				v.High, err = field.Value.GetI64(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("I128", "High", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				highIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TI64 {
+				v.Low, err = field.Value.GetI64(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("I128", "Low", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				lowIsSet = true
+			}
+		}
+	}
+	if !highIsSet {
+		wire.ObserveDecodeError("I128", "High", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "I128", Field: "High", ID: 1}
+	}
+	if !lowIsSet {
+		wire.ObserveDecodeError("I128", "Low", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "I128", Field: "Low", ID: 2}
+	}
+	return nil
+}
+
+func (v *I128) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	fields[i] = fmt.Sprintf("High: %v", v.High)
+	i++
+	fields[i] = fmt.Sprintf("Low: %v", v.Low)
+	i++
+	return fmt.Sprintf("I128{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *I128) Equals(rhs *I128) bool {
+	if !(v.High == rhs.High) {
+		return false
+	}
+	if !(v.Low == rhs.Low) {
+		return false
+	}
+	return true
+}
+
+func (v *I128) GetHigh() (o int64) {
+	if v != nil {
+		o = v.High
+	}
+	return
+}
+
+func (v *I128) GetLow() (o int64) {
+	if v != nil {
+		o = v.Low
+	}
+	return
 }
@@ -4,6 +4,7 @@
 package unions
 
 import (
+	"encoding/json"
 	"fmt"
 	"go.uber.org/thriftrw/gen/testdata/typedefs"
 	"go.uber.org/thriftrw/wire"
@@ -196,6 +197,7 @@ func (v *ArbitraryValue) FromWire(w wire.Value) error {
 				x, err = field.Value.GetBool(), error(nil)
 				v.BoolValue = &x
 				if err != nil {
+					wire.ObserveDecodeError("ArbitraryValue", "BoolValue", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -205,6 +207,7 @@ func (v *ArbitraryValue) FromWire(w wire.Value) error {
 				x, err = field.Value.GetI64(), error(nil)
 				v.Int64Value = &x
 				if err != nil {
+					wire.ObserveDecodeError("ArbitraryValue", "Int64Value", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -214,6 +217,7 @@ func (v *ArbitraryValue) FromWire(w wire.Value) error {
 				x, err = field.Value.GetString(), error(nil)
 				v.StringValue = &x
 				if err != nil {
+					wire.ObserveDecodeError("ArbitraryValue", "StringValue", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -221,6 +225,7 @@ func (v *ArbitraryValue) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.ListValue, err = _List_ArbitraryValue_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("ArbitraryValue", "ListValue", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -228,6 +233,7 @@ func (v *ArbitraryValue) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TMap {
 				v.MapValue, err = _Map_String_ArbitraryValue_Read(field.Value.GetMap())
 				if err != nil {
+					wire.ObserveDecodeError("ArbitraryValue", "MapValue", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -250,6 +256,7 @@ func (v *ArbitraryValue) FromWire(w wire.Value) error {
 		count++
 	}
 	if count != 1 {
+		wire.ObserveDecodeError("ArbitraryValue", "", wire.DecodeErrorInvalidUnion)
 		return fmt.Errorf("ArbitraryValue should have exactly one field: got %v fields", count)
 	}
 	return nil
@@ -359,6 +366,92 @@ func (v *ArbitraryValue) Equals(rhs *ArbitraryValue) bool {
 	return true
 }
 
+func (v *ArbitraryValue) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.BoolValue != nil {
+		count++
+	}
+	if v.Int64Value != nil {
+		count++
+	}
+	if v.StringValue != nil {
+		count++
+	}
+	if v.ListValue != nil {
+		count++
+	}
+	if v.MapValue != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("ArbitraryValue should have exactly one field: got %v fields", count)
+	}
+	type plain ArbitraryValue
+	return json.Marshal((*plain)(v))
+}
+
+func (v *ArbitraryValue) UnmarshalJSON(text []byte) error {
+	type plain ArbitraryValue
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.BoolValue != nil {
+		count++
+	}
+	if v.Int64Value != nil {
+		count++
+	}
+	if v.StringValue != nil {
+		count++
+	}
+	if v.ListValue != nil {
+		count++
+	}
+	if v.MapValue != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("ArbitraryValue should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *ArbitraryValue) GetBoolValue() (o bool) {
+	if v != nil && v.BoolValue != nil {
+		return *v.BoolValue
+	}
+	return
+}
+
+func (v *ArbitraryValue) GetInt64Value() (o int64) {
+	if v != nil && v.Int64Value != nil {
+		return *v.Int64Value
+	}
+	return
+}
+
+func (v *ArbitraryValue) GetStringValue() (o string) {
+	if v != nil && v.StringValue != nil {
+		return *v.StringValue
+	}
+	return
+}
+
+func (v *ArbitraryValue) GetListValue() (o []*ArbitraryValue) {
+	if v != nil && v.ListValue != nil {
+		return v.ListValue
+	}
+	return
+}
+
+func (v *ArbitraryValue) GetMapValue() (o map[string]*ArbitraryValue) {
+	if v != nil && v.MapValue != nil {
+		return v.MapValue
+	}
+	return
+}
+
 type Document struct {
 	Pdf       typedefs.PDF `json:"pdf"`
 	PlainText *string      `json:"plainText,omitempty"`
@@ -407,6 +500,7 @@ func (v *Document) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.Pdf, err = _PDF_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("Document", "Pdf", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -416,6 +510,7 @@ func (v *Document) FromWire(w wire.Value) error {
 				x, err = field.Value.GetString(), error(nil)
 				v.PlainText = &x
 				if err != nil {
+					wire.ObserveDecodeError("Document", "PlainText", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -429,6 +524,7 @@ func (v *Document) FromWire(w wire.Value) error {
 		count++
 	}
 	if count != 1 {
+		wire.ObserveDecodeError("Document", "", wire.DecodeErrorInvalidUnion)
 		return fmt.Errorf("Document should have exactly one field: got %v fields", count)
 	}
 	return nil
@@ -461,6 +557,53 @@ func (v *Document) Equals(rhs *Document) bool {
 	return true
 }
 
+func (v *Document) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.Pdf != nil {
+		count++
+	}
+	if v.PlainText != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("Document should have exactly one field: got %v fields", count)
+	}
+	type plain Document
+	return json.Marshal((*plain)(v))
+}
+
+func (v *Document) UnmarshalJSON(text []byte) error {
+	type plain Document
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.Pdf != nil {
+		count++
+	}
+	if v.PlainText != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("Document should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *Document) GetPdf() (o typedefs.PDF) {
+	if v != nil && v.Pdf != nil {
+		return v.Pdf
+	}
+	return
+}
+
+func (v *Document) GetPlainText() (o string) {
+	if v != nil && v.PlainText != nil {
+		return *v.PlainText
+	}
+	return
+}
+
 type EmptyUnion struct{}
 
 func (v *EmptyUnion) ToWire() (wire.Value, error) {
@@ -4,7 +4,6 @@
 package uuid_conflict
 
 import (
-	"errors"
 	"fmt"
 	"go.uber.org/thriftrw/gen/testdata/typedefs"
 	"go.uber.org/thriftrw/wire"
@@ -29,6 +28,15 @@ func (v *UUID) FromWire(w wire.Value) error {
 	return err
 }
 
+func (v UUID) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+func (v *UUID) UnmarshalText(text []byte) error {
+	*v = UUID(text)
+	return nil
+}
+
 func (lhs UUID) Equals(rhs UUID) bool {
 	return (lhs == rhs)
 }
@@ -52,7 +60,7 @@ func (v *UUIDConflict) ToWire() (wire.Value, error) {
 	fields[i] = wire.Field{ID: 1, Value: w}
 	i++
 	if v.ImportedUUID == nil {
-		return w, errors.New("field ImportedUUID of UUIDConflict is required")
+		return w, wire.NilRequiredFieldError{Struct: "UUIDConflict", Field: "ImportedUUID", ID: 2}
 	}
 	w, err = v.ImportedUUID.ToWire()
 	if err != nil {
@@ -85,6 +93,7 @@ func (v *UUIDConflict) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.LocalUUID, err = _UUID_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("UUIDConflict", "LocalUUID", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				localUUIDIsSet = true
@@ -93,6 +102,7 @@ func (v *UUIDConflict) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.ImportedUUID, err = _UUID_1_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("UUIDConflict", "ImportedUUID", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				importedUUIDIsSet = true
@@ -100,10 +110,12 @@ func (v *UUIDConflict) FromWire(w wire.Value) error {
 		}
 	}
 	if !localUUIDIsSet {
-		return errors.New("field LocalUUID of UUIDConflict is required")
+		wire.ObserveDecodeError("UUIDConflict", "LocalUUID", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "UUIDConflict", Field: "LocalUUID", ID: 1}
 	}
 	if !importedUUIDIsSet {
-		return errors.New("field ImportedUUID of UUIDConflict is required")
+		wire.ObserveDecodeError("UUIDConflict", "ImportedUUID", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "UUIDConflict", Field: "ImportedUUID", ID: 2}
 	}
 	return nil
 }
@@ -130,3 +142,17 @@ func (v *UUIDConflict) Equals(rhs *UUIDConflict) bool {
 	}
 	return true
 }
+
+func (v *UUIDConflict) GetLocalUUID() (o UUID) {
+	if v != nil {
+		o = v.LocalUUID
+	}
+	return
+}
+
+func (v *UUIDConflict) GetImportedUUID() (o *typedefs.UUID) {
+	if v != nil {
+		o = v.ImportedUUID
+	}
+	return
+}
@@ -54,12 +54,13 @@ func isHashable(t compile.TypeSpec) bool {
 // primitive.
 //
 // Note that binary is not considered a primitive type because it is
-// represented as []byte in Go.
+// represented as []byte in Go. uuid is considered primitive because it is
+// represented as a [16]byte.
 func isPrimitiveType(spec compile.TypeSpec) bool {
 	spec = compile.RootTypeSpec(spec)
 	switch spec.(type) {
 	case *compile.BoolSpec, *compile.I8Spec, *compile.I16Spec, *compile.I32Spec,
-		*compile.I64Spec, *compile.DoubleSpec, *compile.StringSpec:
+		*compile.I64Spec, *compile.DoubleSpec, *compile.StringSpec, *compile.UUIDSpec:
 		return true
 	}
 
@@ -140,6 +141,8 @@ func typeName(g Generator, spec compile.TypeSpec) (string, error) {
 		return "string", nil
 	case *compile.BinarySpec:
 		return "[]byte", nil
+	case *compile.UUIDSpec:
+		return g.Import("go.uber.org/thriftrw/uuid") + ".UUID", nil
 	case *compile.MapSpec:
 		k, err := typeReference(g, s.KeySpec)
 		if err != nil {
@@ -171,6 +174,9 @@ func typeName(g Generator, spec compile.TypeSpec) (string, error) {
 		}
 		return fmt.Sprintf("map[%s]struct{}", v), nil
 	case *compile.EnumSpec, *compile.StructSpec, *compile.TypedefSpec:
+		if sub, ok := lookupSubstitution(g, spec); ok {
+			return importQualified(g, sub.Type)
+		}
 		return g.LookupTypeName(spec)
 	default:
 		panic(fmt.Sprintf("Unknown type (%T) %v", spec, spec))
@@ -196,6 +202,9 @@ func valueListName(g Generator, spec compile.TypeSpec) string {
 // canBeConstant returns true if the given type can be a constant.
 func canBeConstant(t compile.TypeSpec) bool {
 	// Only primitives can use const declarations. Everything else has to be a
-	// `var` declaration.
+	// `var` declaration. UUIDs are arrays so they cannot be constants either.
+	if _, ok := compile.RootTypeSpec(t).(*compile.UUIDSpec); ok {
+		return false
+	}
 	return isPrimitiveType(t)
 }
@@ -26,6 +26,10 @@ import "go.uber.org/thriftrw/compile"
 type typedefGenerator struct{}
 
 func (t *typedefGenerator) Reader(g Generator, spec *compile.TypedefSpec) (string, error) {
+	if useArenaAllocation(g) {
+		return t.arenaReader(g, spec)
+	}
+
 	name := readerFuncName(g, spec)
 	err := g.EnsureDeclared(
 		`
@@ -52,50 +56,172 @@ func (t *typedefGenerator) Reader(g Generator, spec *compile.TypedefSpec) (strin
 	return name, wrapGenerateError(spec.ThriftName(), err)
 }
 
+// arenaReader is the same as Reader except the generated function accepts
+// an arena.Arena from which typedefs of structs are allocated.
+func (t *typedefGenerator) arenaReader(g Generator, spec *compile.TypedefSpec) (string, error) {
+	// Typedefs of structs have the same underlying type as the struct so
+	// they can be allocated from the slab of the struct.
+	var alloc string
+	if root, ok := compile.RootTypeSpec(spec).(*compile.StructSpec); ok {
+		var err error
+		alloc, err = (&structGenerator{}).Allocator(g, root)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	name := readerFuncName(g, spec)
+	err := g.EnsureDeclared(
+		`
+		<$wire := import "go.uber.org/thriftrw/wire">
+		<$arena := import "go.uber.org/thriftrw/arena">
+
+		<$x := newVar "x">
+		<$w := newVar "w">
+		func <.Name>(<$w> <$wire>.Value, <.Arena> *<$arena>.Arena) (<typeReference .Spec>, error) {
+			<if .Alloc>
+				<$x> := (<typeReference .Spec>)(<.Alloc>(<.Arena>))
+			<else>
+				var <$x> <typeName .Spec>
+			<end>
+			err := <$x>.FromWireArena(<$w>, <.Arena>)
+			return <$x>, err
+		}
+		`,
+		struct {
+			Name  string
+			Spec  *compile.TypedefSpec
+			Alloc string
+			Arena string
+		}{Name: name, Spec: spec, Alloc: alloc, Arena: arenaVarName},
+	)
+
+	return name, wrapGenerateError(spec.ThriftName(), err)
+}
+
 // typedef generates code for the given typedef.
 func typedef(g Generator, spec *compile.TypedefSpec) error {
-	err := g.DeclareFromTemplate(
+	// The typedef is referenced by name rather than with typeName and
+	// typeReference because those resolve to the substituted Go type if the
+	// typedef has a go.type annotation.
+	name, err := g.LookupTypeName(spec)
+	if err != nil {
+		return wrapGenerateError(spec.Name, err)
+	}
+	ref := name
+	if isStructType(spec) {
+		ref = "*" + name
+	}
+
+	// Typedefs of strings, enums, and uuids implement encoding.TextMarshaler
+	// and encoding.TextUnmarshaler so that they may be used with flag
+	// parsing, configuration files, and URL queries. Typedefs don't inherit
+	// the text encoding of the types they're defined as. Without it, typedefs
+	// of uuid would be encoded in JSON as arrays of bytes.
+	var stringText, forwardText bool
+	if _, ok := spec.Target.(*compile.StringSpec); ok {
+		stringText = true
+	} else {
+		switch compile.RootTypeSpec(spec).(type) {
+		case *compile.StringSpec, *compile.EnumSpec, *compile.UUIDSpec:
+			forwardText = true
+		}
+	}
+
+	err = g.DeclareFromTemplate(
 		`
 		<$fmt := import "fmt">
 		<$wire := import "go.uber.org/thriftrw/wire">
-		<$typedefType := typeReference .>
+		<$typedefType := .Ref>
 
-		type <typeName .> <typeName .Target>
+		<doc .Spec.Doc>
+		type <.Name> <typeName .Spec.Target>
 
 		<$v := newVar "v">
 		<$x := newVar "x">
 		func (<$v> <$typedefType>) ToWire() (<$wire>.Value, error) {
-			<$x> := (<typeReference .Target>)(<$v>)
-			return <toWire .Target $x>
+			<$x> := (<typeReference .Spec.Target>)(<$v>)
+			return <toWire .Spec.Target $x>
 		}
 
 		func (<$v> <$typedefType>) String() string {
-			<$x> := (<typeReference .Target>)(<$v>)
+			<$x> := (<typeReference .Spec.Target>)(<$v>)
 			return <$fmt>.Sprint(<$x>)
 		}
 
 		<$w := newVar "w">
-		func (<$v> *<typeName .>) FromWire(<$w> <$wire>.Value) error {
-			<if isStructType .>
-				return (<typeReference .Target>)(<$v>).FromWire(<$w>)
+		<if useArena>
+			<$arena := import "go.uber.org/thriftrw/arena">
+			func (<$v> *<.Name>) FromWire(<$w> <$wire>.Value) error {
+				return <$v>.FromWireArena(<$w>, nil)
+			}
+
+			// FromWireArena is the same as FromWire except it allocates
+			// decoded values from the given arena.
+			func (<$v> *<.Name>) FromWireArena(<$w> <$wire>.Value, <.Arena> *<$arena>.Arena) error {
+		<else>
+			func (<$v> *<.Name>) FromWire(<$w> <$wire>.Value) error {
+		<end>
+			<if and (isStructType .Spec) useArena>
+				return (<typeReference .Spec.Target>)(<$v>).FromWireArena(<$w>, <.Arena>)
+			<else if isStructType .Spec>
+				return (<typeReference .Spec.Target>)(<$v>).FromWire(<$w>)
 			<else>
-				<$x>, err := <fromWire .Target $w>
+				<$x>, err := <fromWire .Spec.Target $w>
 				*<$v> = (<$typedefType>)(<$x>)
 				return err
 			<end>
 		}
 
+		<$text := newVar "text">
+		<if .StringText>
+			func (<$v> <$typedefType>) MarshalText() ([]byte, error) {
+				return []byte(<$v>), nil
+			}
+
+			func (<$v> *<.Name>) UnmarshalText(<$text> []byte) error {
+				*<$v> = <.Name>(<$text>)
+				return nil
+			}
+		<else if .ForwardText>
+			func (<$v> <$typedefType>) MarshalText() ([]byte, error) {
+				return (<typeReference .Spec.Target>)(<$v>).MarshalText()
+			}
+
+			func (<$v> *<.Name>) UnmarshalText(<$text> []byte) error {
+				return (*<typeReference .Spec.Target>)(<$v>).UnmarshalText(<$text>)
+			}
+		<end>
+
 		<$lhs := newVar "lhs">
 		<$rhs := newVar "rhs">
 		func (<$lhs> <$typedefType>) Equals(<$rhs> <$typedefType>) bool {
-			<if isStructType .>
-				return (<typeReference .Target>)(<$lhs>).Equals((<typeReference .Target>)(<$rhs>))
+			<if isStructType .Spec>
+				return (<typeReference .Spec.Target>)(<$lhs>).Equals((<typeReference .Spec.Target>)(<$rhs>))
 			<else>
-				return <equals .Target $lhs $rhs>
+				return <equals .Spec.Target $lhs $rhs>
 			<end>
 		}
 		`,
-		spec,
+		struct {
+			Spec  *compile.TypedefSpec
+			Name  string
+			Ref   string
+			Arena string
+
+			StringText  bool
+			ForwardText bool
+		}{
+			Spec:        spec,
+			Name:        name,
+			Ref:         ref,
+			Arena:       arenaVarName,
+			StringText:  stringText,
+			ForwardText: forwardText,
+		},
 	)
+	if err == nil && useIterators(g) {
+		err = typedefIterator(g, spec, name)
+	}
 	return wrapGenerateError(spec.Name, err)
 }
@@ -21,13 +21,17 @@
 package gen
 
 import (
+	"encoding"
+	"reflect"
 	"testing"
 
+	te "go.uber.org/thriftrw/gen/testdata/enums"
 	ts "go.uber.org/thriftrw/gen/testdata/structs"
 	td "go.uber.org/thriftrw/gen/testdata/typedefs"
 	"go.uber.org/thriftrw/wire"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTypedefI64(t *testing.T) {
@@ -512,3 +516,60 @@ func TestBinarySetEquals(t *testing.T) {
 		assert.False(t, tt.x.Equals(tt.y), "BinarySet unequal")
 	}
 }
+
+func TestTypedefDefaults(t *testing.T) {
+	state := td.State("hello")
+	time := td.Timestamp(42)
+	initial := td.DefaultState
+	myEnum := td.MyEnum(te.EnumWithValuesY)
+	want := td.DefaultPrimitiveTypedef{
+		State:   &state,
+		Time:    &time,
+		Initial: &initial,
+		MyEnum:  &myEnum,
+	}
+
+	var got td.DefaultPrimitiveTypedef
+	require.NoError(t, got.FromWire(wire.NewValueStruct(wire.Struct{})))
+	assert.Equal(t, want, got)
+
+	w, err := (&td.DefaultPrimitiveTypedef{}).ToWire()
+	require.NoError(t, err)
+
+	var roundTrip td.DefaultPrimitiveTypedef
+	require.NoError(t, roundTrip.FromWire(w))
+	assert.Equal(t, want, roundTrip)
+}
+
+func TestTypedefText(t *testing.T) {
+	tests := []struct {
+		desc string
+		give encoding.TextMarshaler
+		want string
+		into encoding.TextUnmarshaler
+	}{
+		{
+			desc: "string",
+			give: td.State("running"),
+			want: "running",
+			into: new(td.State),
+		},
+		{
+			desc: "enum",
+			give: td.MyEnum(te.EnumWithValuesY),
+			want: "Y",
+			into: new(td.MyEnum),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			text, err := tt.give.MarshalText()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(text))
+
+			require.NoError(t, tt.into.UnmarshalText(text))
+			assert.Equal(t, tt.give, reflect.ValueOf(tt.into).Elem().Interface())
+		})
+	}
+}
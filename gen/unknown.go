@@ -0,0 +1,40 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+// unknownFieldsName is the name of the unexported field in which structs
+// retain the fields they did not recognize if unknown fields are kept.
+const unknownFieldsName = "unknownFields"
+
+// unknownFieldsGenerator is implemented by Generators which may generate
+// structs that retain unrecognized fields.
+type unknownFieldsGenerator interface {
+	keepUnknownFields() bool
+}
+
+// useKeepUnknownFields returns true if structs should retain the fields they
+// do not recognize in FromWire and encode them again in ToWire.
+func useKeepUnknownFields(g Generator) bool {
+	if o, ok := g.(unknownFieldsGenerator); ok {
+		return o.keepUnknownFields()
+	}
+	return false
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	tp "go.uber.org/thriftrw/gen/testdata/features/plain/records"
+	tk "go.uber.org/thriftrw/gen/testdata/features/unknown/records"
+	tkc "go.uber.org/thriftrw/gen/testdata/features/unknowncompact/records"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/ptr"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newerUser returns a UserV2 with fields that User does not know about,
+// including lists, sets, maps, and structs which are decoded lazily.
+//
+// Fields known to User hold at most one item so that the order in which
+// they are encoded does not depend on map iteration.
+func newerUser() *tp.UserV2 {
+	return &tp.UserV2{
+		Name:   "alice",
+		Age:    ptr.Int32(30),
+		Avatar: []byte{1, 2, 3},
+		Tags:   []string{"admin"},
+		Places: map[string]*tp.Point{"home": {X: 1, Y: 2}},
+		Ids:    map[int64]struct{}{42: {}},
+		History: []*tp.Point{
+			{X: 1, Y: 2},
+			{X: 3, Y: 4},
+		},
+		Scores: map[string][]int32{
+			"math":    {90, 80},
+			"science": {70},
+			"art":     {},
+		},
+		Nickname: ptr.String("al"),
+		Aliases:  map[string]struct{}{"a": {}, "b": {}, "c": {}},
+		Referrer: &tp.UserV2{
+			Name:    "bob",
+			History: []*tp.Point{{X: 5, Y: 6}},
+			Scores:  map[string][]int32{"math": {100}},
+		},
+	}
+}
+
+func encodeBinary(t *testing.T, x thriftType) []byte {
+	w, err := x.ToWire()
+	require.NoError(t, err, "failed to serialize %v", x)
+
+	var buff bytes.Buffer
+	require.NoError(t, protocol.Binary.Encode(w, &buff), "failed to encode %v", x)
+	return buff.Bytes()
+}
+
+func TestKeepUnknownFieldsRoundTrip(t *testing.T) {
+	want := encodeBinary(t, newerUser())
+
+	decoders := []struct {
+		desc   string
+		decode func([]byte) (wire.Value, error)
+
+		// Whether strings and binary values of known fields reference the
+		// input.
+		noCopy bool
+	}{
+		{
+			desc: "Decode",
+			decode: func(b []byte) (wire.Value, error) {
+				return protocol.Binary.Decode(bytes.NewReader(b), wire.TStruct)
+			},
+		},
+		{
+			desc: "DecodeNoCopy",
+			decode: func(b []byte) (wire.Value, error) {
+				return protocol.DecodeNoCopy(protocol.Binary, b, wire.TStruct)
+			},
+			noCopy: true,
+		},
+	}
+
+	users := []struct {
+		desc string
+		new  func() thriftType
+		name func(thriftType) string
+
+		// Sets the known fields which reference the input of a no-copy
+		// decoder to their original values.
+		restore func(thriftType, *tp.UserV2)
+	}{
+		{
+			desc: "keep unknown fields",
+			new:  func() thriftType { return new(tk.User) },
+			name: func(x thriftType) string { return x.(*tk.User).Name },
+			restore: func(x thriftType, v2 *tp.UserV2) {
+				u := x.(*tk.User)
+				u.Name, u.Avatar, u.Tags = v2.Name, v2.Avatar, v2.Tags
+				u.Places = map[string]*tk.Point{"home": {X: 1, Y: 2}}
+			},
+		},
+		{
+			desc: "keep unknown fields with compact codegen",
+			new:  func() thriftType { return new(tkc.User) },
+			name: func(x thriftType) string { return x.(*tkc.User).Name },
+			restore: func(x thriftType, v2 *tp.UserV2) {
+				u := x.(*tkc.User)
+				u.Name, u.Avatar, u.Tags = v2.Name, v2.Avatar, v2.Tags
+				u.Places = map[string]*tkc.Point{"home": {X: 1, Y: 2}}
+			},
+		},
+	}
+
+	for _, d := range decoders {
+		for _, u := range users {
+			t.Run(d.desc+"/"+u.desc, func(t *testing.T) {
+				input := append([]byte(nil), want...)
+				w, err := d.decode(input)
+				require.NoError(t, err, "failed to decode")
+
+				user := u.new()
+				require.NoError(t, user.FromWire(w), "FromWire failed")
+				assert.Equal(t, "alice", u.name(user))
+
+				// The retained fields must not reference the input.
+				for i := range input {
+					input[i] = 0xff
+				}
+				if d.noCopy {
+					u.restore(user, newerUser())
+				}
+
+				assert.Equal(t, want, encodeBinary(t, user),
+					"unknown fields must be encoded as they were decoded")
+			})
+		}
+	}
+}
+
+func TestUnknownFieldsDroppedByDefault(t *testing.T) {
+	w, err := protocol.Binary.Decode(bytes.NewReader(encodeBinary(t, newerUser())), wire.TStruct)
+	require.NoError(t, err)
+
+	var user tp.User
+	require.NoError(t, user.FromWire(w))
+
+	v2 := newerUser()
+	want := &tp.UserV2{
+		Name:   v2.Name,
+		Age:    v2.Age,
+		Avatar: v2.Avatar,
+		Tags:   v2.Tags,
+		Places: v2.Places,
+		Ids:    v2.Ids,
+	}
+	assert.Equal(t, encodeBinary(t, want), encodeBinary(t, &user))
+}
+
+// failingList is a ValueList which cannot be read, like a lazily decoded
+// list whose payload is truncated.
+type failingList struct{}
+
+func (failingList) Size() int                            { return 1 }
+func (failingList) ValueType() wire.Type                 { return wire.TI32 }
+func (failingList) ForEach(func(wire.Value) error) error { return errors.New("great sadness") }
+func (failingList) Close()                               {}
+
+func TestKeepUnknownFieldsUnreadable(t *testing.T) {
+	w := wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+		{ID: 1, Value: wire.NewValueString("alice")},
+		{ID: 11, Value: wire.NewValueList(failingList{})},
+	}})
+
+	assert.EqualError(t, new(tk.User).FromWire(w), "great sadness")
+	assert.EqualError(t, new(tkc.User).FromWire(w), "great sadness")
+}
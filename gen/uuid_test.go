@@ -0,0 +1,155 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"encoding/json"
+	"testing"
+
+	ts "go.uber.org/thriftrw/gen/testdata/structs"
+	td "go.uber.org/thriftrw/gen/testdata/typedefs"
+	"go.uber.org/thriftrw/uuid"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUUIDStructWire(t *testing.T) {
+	id := uuid.MustParse("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+	parent := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	defaultID := parent
+
+	tests := []struct {
+		desc string
+		x    *ts.UUIDStruct
+		v    wire.Value
+	}{
+		{
+			desc: "required only",
+			x: &ts.UUIDStruct{
+				ID:        id,
+				DefaultID: &defaultID,
+			},
+			v: wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+				{ID: 1, Value: wire.NewValueBinary(id[:])},
+				{ID: 6, Value: wire.NewValueBinary(parent[:])},
+			}}),
+		},
+		{
+			desc: "all fields",
+			x: &ts.UUIDStruct{
+				ID:        id,
+				ParentID:  &parent,
+				Children:  []uuid.UUID{parent, id},
+				Tags:      map[uuid.UUID]struct{}{id: {}},
+				Names:     map[uuid.UUID]string{id: "foo"},
+				DefaultID: &defaultID,
+			},
+			v: wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+				{ID: 1, Value: wire.NewValueBinary(id[:])},
+				{ID: 2, Value: wire.NewValueBinary(parent[:])},
+				{ID: 3, Value: wire.NewValueList(
+					wire.ValueListFromSlice(wire.TBinary, []wire.Value{
+						wire.NewValueBinary(parent[:]),
+						wire.NewValueBinary(id[:]),
+					}),
+				)},
+				{ID: 4, Value: wire.NewValueSet(
+					wire.ValueListFromSlice(wire.TBinary, []wire.Value{
+						wire.NewValueBinary(id[:]),
+					}),
+				)},
+				{ID: 5, Value: wire.NewValueMap(
+					wire.MapItemListFromSlice(wire.TBinary, wire.TBinary, []wire.MapItem{
+						{Key: wire.NewValueBinary(id[:]), Value: wire.NewValueString("foo")},
+					}),
+				)},
+				{ID: 6, Value: wire.NewValueBinary(parent[:])},
+			}}),
+		},
+	}
+
+	for _, tt := range tests {
+		assertRoundTrip(t, tt.x, tt.v, "%s", tt.desc)
+	}
+}
+
+func TestUUIDStructDefault(t *testing.T) {
+	var x ts.UUIDStruct
+	v := wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+		{ID: 1, Value: wire.NewValueBinary(make([]byte, 16))},
+	}})
+	require.NoError(t, x.FromWire(v))
+	require.NotNil(t, x.DefaultID)
+	assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", x.DefaultID.String())
+}
+
+func TestUUIDStructInvalidLength(t *testing.T) {
+	var x ts.UUIDStruct
+	err := x.FromWire(wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+		{ID: 1, Value: wire.NewValueBinary([]byte{1, 2, 3})},
+	}}))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "expected 16 bytes, got 3")
+	}
+}
+
+func TestUUIDJSON(t *testing.T) {
+	x := ts.UUIDStruct{
+		ID:       uuid.MustParse("f81d4fae-7dec-11d0-a765-00a0c91e6bf6"),
+		Children: []uuid.UUID{{}},
+	}
+	b, err := json.Marshal(x)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"children": ["00000000-0000-0000-0000-000000000000"],
+		"tags": null,
+		"names": null
+	}`, string(b))
+
+	var got ts.UUIDStruct
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, x, got)
+
+	rid := td.RequestID(x.ID)
+	b, err = json.Marshal(rid)
+	require.NoError(t, err)
+	assert.Equal(t, `"f81d4fae-7dec-11d0-a765-00a0c91e6bf6"`, string(b))
+
+	var gotRID td.RequestID
+	require.NoError(t, json.Unmarshal(b, &gotRID))
+	assert.Equal(t, rid, gotRID)
+}
+
+func TestEmptyBinaryRoundTrip(t *testing.T) {
+	x := &ts.PrimitiveRequiredStruct{BinaryField: []byte{}}
+	v, err := x.ToWire()
+	require.NoError(t, err)
+
+	var got ts.PrimitiveRequiredStruct
+	require.NoError(t, got.FromWire(v))
+	assert.NotNil(t, got.BinaryField, "empty binary must not decode to nil")
+
+	_, err = got.ToWire()
+	assert.NoError(t, err, "decoded struct must encode again")
+}
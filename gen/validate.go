@@ -0,0 +1,456 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+const _validateAnnotationPrefix = "validate."
+
+// fieldConstraints are the constraints listed in the validate annotations of
+// a field.
+//
+// 	1: required string name (validate.min = "1", validate.max = "64")
+// 	2: optional i32 age (validate.min = "0")
+// 	3: optional string email (validate.pattern = "^[^@]+@[^@]+$")
+//
+// For numeric fields, min and max bound the value of the field. For strings,
+// binary, and collections, they bound its length.
+type fieldConstraints struct {
+	// Go literals for the bounds, or empty if the bound wasn't specified.
+	Min, Max string
+
+	// Whether Min and Max apply to the length of the field.
+	Length bool
+
+	Pattern string
+}
+
+// hasValidateAnnotation returns true if the given field has any validate
+// annotations.
+func hasValidateAnnotation(f *compile.FieldSpec) bool {
+	for key := range f.Annotations {
+		if strings.HasPrefix(key, _validateAnnotationPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateAnnotation parses the validate annotations of the given field. It
+// returns nil if the field has none.
+func validateAnnotation(f *compile.FieldSpec) (*fieldConstraints, error) {
+	if !hasValidateAnnotation(f) {
+		return nil, nil
+	}
+
+	var (
+		c        fieldConstraints
+		min, max float64
+	)
+	for _, key := range sortStringKeys(f.Annotations) {
+		if !strings.HasPrefix(key, _validateAnnotationPrefix) {
+			continue
+		}
+
+		value := f.Annotations[key]
+		var err error
+		switch strings.TrimPrefix(key, _validateAnnotationPrefix) {
+		case "min":
+			c.Min, min, err = parseBound(f, value)
+		case "max":
+			c.Max, max, err = parseBound(f, value)
+		case "pattern":
+			if _, ok := compile.RootTypeSpec(f.Type).(*compile.StringSpec); !ok {
+				err = fmt.Errorf("field %q is not a string", f.Name)
+			} else if _, err = regexp.Compile(value); err == nil {
+				c.Pattern = value
+			}
+		default:
+			err = fmt.Errorf("unknown constraint (expected min, max, or pattern)")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v annotation %q: %v", key, value, err)
+		}
+	}
+
+	if c.Min != "" && c.Max != "" && min > max {
+		return nil, fmt.Errorf(
+			"invalid validate annotations on field %q: min %v is greater than max %v",
+			f.Name, c.Min, c.Max)
+	}
+
+	switch compile.RootTypeSpec(f.Type).(type) {
+	case *compile.I8Spec, *compile.I16Spec, *compile.I32Spec, *compile.I64Spec,
+		*compile.DoubleSpec:
+		c.Length = false
+	default:
+		c.Length = true
+	}
+	return &c, nil
+}
+
+// parseBound parses the value of a validate.min or validate.max annotation
+// on the given field, returning it as a Go literal and as a float64 for
+// comparison with the other bound.
+func parseBound(f *compile.FieldSpec, value string) (string, float64, error) {
+	value = strings.TrimSpace(value)
+
+	var bits int
+	switch compile.RootTypeSpec(f.Type).(type) {
+	case *compile.I8Spec:
+		bits = 8
+	case *compile.I16Spec:
+		bits = 16
+	case *compile.I32Spec:
+		bits = 32
+	case *compile.I64Spec:
+		bits = 64
+	case *compile.DoubleSpec:
+		d, err := strconv.ParseFloat(value, 64)
+		if err == nil && (math.IsInf(d, 0) || math.IsNaN(d)) {
+			err = fmt.Errorf("bound must be finite")
+		}
+		return strconv.FormatFloat(d, 'g', -1, 64), d, err
+	case *compile.StringSpec, *compile.BinarySpec, *compile.ListSpec,
+		*compile.SetSpec, *compile.MapSpec:
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err == nil && n < 0 {
+			err = fmt.Errorf("length must not be negative")
+		}
+		return strconv.FormatInt(n, 10), float64(n), err
+	default:
+		return "", 0, fmt.Errorf(
+			"field %q must be numeric, a string, binary, or a collection", f.Name)
+	}
+
+	n, err := strconv.ParseInt(value, 10, bits)
+	return strconv.FormatInt(n, 10), float64(n), err
+}
+
+// hasValidation returns true if values of the given type need to be
+// validated; that is, if it is a struct with validate annotations on any of
+// its fields, or it references such a struct directly or through
+// collections.
+func hasValidation(g Generator, spec compile.TypeSpec) bool {
+	return validationFinder{g: g, visiting: make(map[compile.TypeSpec]struct{})}.Visit(spec)
+}
+
+type validationFinder struct {
+	g Generator
+
+	// Structs being visited. Recursive references to these are ignored.
+	visiting map[compile.TypeSpec]struct{}
+}
+
+func (f validationFinder) Visit(spec compile.TypeSpec) bool {
+	if _, ok := lookupSubstitution(f.g, spec); ok {
+		// Substituted types are opaque to us.
+		return false
+	}
+
+	switch s := spec.(type) {
+	case *compile.TypedefSpec:
+		return f.Visit(s.Target)
+	case *compile.ListSpec:
+		return f.Visit(s.ValueSpec)
+	case *compile.SetSpec:
+		return f.Visit(s.ValueSpec)
+	case *compile.MapSpec:
+		return f.Visit(s.KeySpec) || f.Visit(s.ValueSpec)
+	case *compile.StructSpec:
+		if _, ok := f.visiting[s]; ok {
+			return false
+		}
+		f.visiting[s] = struct{}{}
+		defer delete(f.visiting, s)
+
+		for _, field := range s.Fields {
+			if hasValidateAnnotation(field) || f.Visit(field.Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateGenerator generates functions which validate collections.
+type validateGenerator struct{}
+
+// Validate generates an expression of type error which validates the value
+// of the given type in expr. It returns an empty string if values of the
+// type don't need validation.
+func (v *validateGenerator) Validate(g Generator, spec compile.TypeSpec, expr string) (string, error) {
+	if !hasValidation(g, spec) {
+		return "", nil
+	}
+
+	switch s := compile.RootTypeSpec(spec).(type) {
+	case *compile.StructSpec:
+		if s != spec {
+			// Typedefs of structs don't have a Validate method.
+			ref, err := typeReference(g, s)
+			if err != nil {
+				return "", err
+			}
+			expr = fmt.Sprintf("(%s)(%s)", ref, expr)
+		}
+		return expr + ".Validate()", nil
+	default:
+		name, err := v.collection(g, s)
+		return fmt.Sprintf("%s(%s)", name, expr), err
+	}
+}
+
+// collection declares a function which validates the items of a list, set,
+// or map and returns its name.
+func (v *validateGenerator) collection(g Generator, spec compile.TypeSpec) (string, error) {
+	name := fmt.Sprintf("_%s_Validate", g.MangleType(spec))
+	err := g.EnsureDeclared(
+		`
+		<$v := newVar "v">
+		<$x := newVar "x">
+		func <.Name>(<$v> <typeReference .Spec>) error {
+			<if isMap .Spec>
+				<if isHashable .Spec.KeySpec>
+					for _, <$x> := range <$v> {
+						if err := <validate .Spec.ValueSpec $x>; err != nil {
+							return err
+						}
+					}
+				<else>
+					for _, <$x> := range <$v> {
+						<with validate .Spec.KeySpec (printf "%v.Key" $x)>
+							if err := <.>; err != nil {
+								return err
+							}
+						<end>
+						<with validate .Spec.ValueSpec (printf "%v.Value" $x)>
+							if err := <.>; err != nil {
+								return err
+							}
+						<end>
+					}
+				<end>
+			<else>
+				for _, <$x> := range <$v> {
+					if err := <validate .Spec.ValueSpec $x>; err != nil {
+						return err
+					}
+				}
+			<end>
+			return nil
+		}
+		`,
+		struct {
+			Name string
+			Spec compile.TypeSpec
+		}{Name: name, Spec: spec},
+		TemplateFunc("isMap", func(s compile.TypeSpec) bool {
+			_, ok := s.(*compile.MapSpec)
+			return ok
+		}),
+		TemplateFunc("validate", func(s compile.TypeSpec, expr string) (string, error) {
+			return v.Validate(g, s, expr)
+		}),
+	)
+	return name, wrapGenerateError(spec.ThriftName(), err)
+}
+
+// validatedField is a field checked by a Validate method.
+type validatedField struct {
+	Name     string // Go name of the field
+	ID       int16
+	Required bool
+
+	// Whether the Go field is a pointer to the value.
+	Pointer bool
+
+	Constraints *fieldConstraints
+
+	// Name of the compiled regular expression for Constraints.Pattern.
+	PatternVar string
+
+	Field *compile.FieldSpec
+	Spec  compile.TypeSpec
+}
+
+// Validate generates a Validate method for the field group if any of its
+// fields have validate annotations or reference structs which do.
+//
+// 	func (v *User) Validate() error {
+// 		if v == nil {
+// 			return nil
+// 		}
+// 		if len(v.Name) < 1 {
+// 			return wire.InvalidFieldError{...}
+// 		}
+// 		...
+// 	}
+func (f fieldGroupGenerator) Validate(g Generator) error {
+	var fields []validatedField
+	for _, field := range f.Fields {
+		c, err := validateAnnotation(field)
+		if err != nil {
+			return err
+		}
+		if c == nil && !hasValidation(g, field.Type) {
+			continue
+		}
+
+		if _, ok := lookupSubstitution(g, field.Type); ok && c != nil {
+			return fmt.Errorf(
+				"invalid validate annotation: field %q has a substituted type", field.Name)
+		}
+
+		name, err := goNames(g).goName(field)
+		if err != nil {
+			return err
+		}
+
+		vf := validatedField{
+			Name:        name,
+			ID:          field.ID,
+			Required:    field.Required,
+			Pointer:     !field.Required && !isValueField(field) && !isReferenceType(field.Type) && !isStructType(field.Type),
+			Constraints: c,
+			Field:       field,
+			Spec:        field.Type,
+		}
+		if c != nil && c.Pattern != "" {
+			vf.PatternVar = fmt.Sprintf("_%v_%v_Pattern", f.Name, name)
+		}
+		fields = append(fields, vf)
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	for _, field := range fields {
+		if field.PatternVar == "" {
+			continue
+		}
+		err := g.DeclareFromTemplate(
+			`var <.PatternVar> = <import "regexp">.MustCompile(<printf "%q" .Constraints.Pattern>)`,
+			field)
+		if err != nil {
+			return err
+		}
+	}
+
+	var validateG validateGenerator
+	return g.DeclareFromTemplate(
+		`
+		<$wire := import "go.uber.org/thriftrw/wire">
+		<$v := newVar "v">
+
+		// Validate returns an error if a field of this <.Name> or of a value
+		// it references violates the constraints in its validate annotations.
+		//
+		// Validate returns nil for nil values.
+		func (<$v> *<.Name>) Validate() error {
+			if <$v> == nil {
+				return nil
+			}
+			<$structName := .Name>
+			<range .Fields>
+				<if .Constraints>
+					<$f := printf "%v.%v" $v .Name>
+					<if .Required>
+						<checks $structName . $f>
+					<else if isValueField .Field>
+						if <valueFieldIsSet $v .Field> {
+							<checks $structName . $f>
+						}
+					<else>
+						if <$f> != nil {
+							<if .Pointer>
+								<checks $structName . (printf "*%v" $f)>
+							<else>
+								<checks $structName . $f>
+							<end>
+						}
+					<end>
+				<end>
+				<with validate .Spec (printf "%v.%v" $v .Name)>
+					if err := <.>; err != nil {
+						return err
+					}
+				<end>
+			<end>
+			return nil
+		}
+		`,
+		struct {
+			Name   string
+			Fields []validatedField
+		}{Name: f.Name, Fields: fields},
+		TemplateFunc("checks", func(structName string, f validatedField, expr string) string {
+			return validateChecks(g, structName, f, expr)
+		}),
+		TemplateFunc("validate", func(s compile.TypeSpec, expr string) (string, error) {
+			return validateG.Validate(g, s, expr)
+		}),
+	)
+}
+
+// validateChecks generates the statements which check the constraints of
+// the given field against the value in expr.
+func validateChecks(g Generator, structName string, f validatedField, expr string) string {
+	c := f.Constraints
+	var (
+		lines  []string
+		value  = expr
+		prefix = "must be"
+	)
+	if c.Length {
+		value = fmt.Sprintf("len(%s)", expr)
+		prefix = "length must be"
+	}
+
+	check := func(cond, reason string) {
+		lines = append(lines, fmt.Sprintf(
+			"if %s {\nreturn %s.InvalidFieldError{Struct: %q, Field: %q, ID: %d, Reason: %q}\n}",
+			cond, g.Import("go.uber.org/thriftrw/wire"), structName, f.Name, f.ID, reason))
+	}
+	if c.Min != "" {
+		check(fmt.Sprintf("%s < %s", value, c.Min), fmt.Sprintf("%s at least %s", prefix, c.Min))
+	}
+	if c.Max != "" {
+		check(fmt.Sprintf("%s > %s", value, c.Max), fmt.Sprintf("%s at most %s", prefix, c.Max))
+	}
+	if c.Pattern != "" {
+		if _, ok := f.Spec.(*compile.StringSpec); !ok {
+			expr = fmt.Sprintf("string(%s)", expr)
+		}
+		check(fmt.Sprintf("!%s.MatchString(%s)", f.PatternVar, expr),
+			fmt.Sprintf("must match pattern %q", c.Pattern))
+	}
+	return strings.Join(lines, "\n")
+}
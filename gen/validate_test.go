@@ -0,0 +1,297 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+	ts "go.uber.org/thriftrw/gen/testdata/structs"
+	td "go.uber.org/thriftrw/gen/testdata/typedefs"
+	"go.uber.org/thriftrw/ptr"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAnnotation(t *testing.T) {
+	tests := []struct {
+		desc      string
+		give      compile.Annotations
+		typ       compile.TypeSpec
+		want      *fieldConstraints
+		wantError string
+	}{
+		{desc: "none", give: compile.Annotations{"normalize": "trim"}},
+		{
+			desc: "length",
+			give: compile.Annotations{"validate.min": "1", "validate.max": " 10 "},
+			want: &fieldConstraints{Min: "1", Max: "10", Length: true},
+		},
+		{
+			desc: "pattern",
+			give: compile.Annotations{"validate.pattern": "^a+$"},
+			want: &fieldConstraints{Pattern: "^a+$", Length: true},
+		},
+		{
+			desc: "integer",
+			give: compile.Annotations{"validate.min": "-128"},
+			typ:  &compile.I8Spec{},
+			want: &fieldConstraints{Min: "-128"},
+		},
+		{
+			desc: "double",
+			give: compile.Annotations{"validate.min": "0.25", "validate.max": "1e3"},
+			typ:  &compile.DoubleSpec{},
+			want: &fieldConstraints{Min: "0.25", Max: "1000"},
+		},
+		{
+			desc: "list",
+			give: compile.Annotations{"validate.max": "3"},
+			typ:  &compile.ListSpec{ValueSpec: &compile.I32Spec{}},
+			want: &fieldConstraints{Max: "3", Length: true},
+		},
+		{
+			desc:      "unknown",
+			give:      compile.Annotations{"validate.email": "true"},
+			wantError: `invalid validate.email annotation "true": unknown constraint`,
+		},
+		{
+			desc:      "out of range",
+			give:      compile.Annotations{"validate.max": "200"},
+			typ:       &compile.I8Spec{},
+			wantError: `invalid validate.max annotation "200"`,
+		},
+		{
+			desc:      "negative length",
+			give:      compile.Annotations{"validate.min": "-1"},
+			wantError: `invalid validate.min annotation "-1": length must not be negative`,
+		},
+		{
+			desc:      "infinite",
+			give:      compile.Annotations{"validate.max": "Inf"},
+			typ:       &compile.DoubleSpec{},
+			wantError: `invalid validate.max annotation "Inf": bound must be finite`,
+		},
+		{
+			desc:      "min greater than max",
+			give:      compile.Annotations{"validate.min": "5", "validate.max": "4"},
+			typ:       &compile.I32Spec{},
+			wantError: `invalid validate annotations on field "foo": min 5 is greater than max 4`,
+		},
+		{
+			desc:      "unsupported type",
+			give:      compile.Annotations{"validate.min": "1"},
+			typ:       &compile.BoolSpec{},
+			wantError: `field "foo" must be numeric, a string, binary, or a collection`,
+		},
+		{
+			desc:      "pattern on non-string",
+			give:      compile.Annotations{"validate.pattern": "a"},
+			typ:       &compile.BinarySpec{},
+			wantError: `invalid validate.pattern annotation "a": field "foo" is not a string`,
+		},
+		{
+			desc:      "invalid pattern",
+			give:      compile.Annotations{"validate.pattern": "a("},
+			wantError: `invalid validate.pattern annotation "a("`,
+		},
+	}
+
+	for _, tt := range tests {
+		typ := tt.typ
+		if typ == nil {
+			typ = &compile.StringSpec{}
+		}
+		spec := &compile.FieldSpec{Name: "foo", Type: typ, Annotations: tt.give}
+
+		got, err := validateAnnotation(spec)
+		if tt.wantError != "" {
+			if assert.Error(t, err, tt.desc) {
+				assert.Contains(t, err.Error(), tt.wantError, tt.desc)
+			}
+			continue
+		}
+
+		if assert.NoError(t, err, tt.desc) {
+			assert.Equal(t, tt.want, got, tt.desc)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *ts.ValidatedUser {
+		return &ts.ValidatedUser{
+			Name:  "jane",
+			Age:   ptr.Int32(30),
+			Email: ptr.String("jane@example.com"),
+			Score: ptr.Float64(1.5),
+			Tags:  []string{"a", "b"},
+		}
+	}
+
+	tests := []struct {
+		desc  string
+		give  func(*ts.ValidatedUser)
+		field string
+		want  string
+	}{
+		{desc: "valid", give: func(*ts.ValidatedUser) {}},
+		{
+			desc: "unset optional fields",
+			give: func(u *ts.ValidatedUser) {
+				u.Age, u.Email, u.Score, u.Tags = nil, nil, nil, nil
+			},
+		},
+		{
+			desc:  "empty name",
+			give:  func(u *ts.ValidatedUser) { u.Name = "" },
+			field: "Name",
+			want:  "length must be at least 1",
+		},
+		{
+			desc:  "long name",
+			give:  func(u *ts.ValidatedUser) { u.Name = "abcdefghijklmnopq" },
+			field: "Name",
+			want:  "length must be at most 16",
+		},
+		{
+			desc:  "negative age",
+			give:  func(u *ts.ValidatedUser) { u.Age = ptr.Int32(-1) },
+			field: "Age",
+			want:  "must be at least 0",
+		},
+		{
+			desc:  "email",
+			give:  func(u *ts.ValidatedUser) { u.Email = ptr.String("jane") },
+			field: "Email",
+			want:  `must match pattern "^[^@\\s]+@[^@\\s]+$"`,
+		},
+		{
+			desc:  "score",
+			give:  func(u *ts.ValidatedUser) { u.Score = ptr.Float64(1.75) },
+			field: "Score",
+			want:  "must be at most 1.5",
+		},
+		{
+			desc:  "tags",
+			give:  func(u *ts.ValidatedUser) { u.Tags = append(u.Tags, "c") },
+			field: "Tags",
+			want:  "length must be at most 2",
+		},
+		{
+			desc:  "manager",
+			give:  func(u *ts.ValidatedUser) { u.Manager = &ts.ValidatedUser{} },
+			field: "Name",
+			want:  "length must be at least 1",
+		},
+		{
+			desc: "report",
+			give: func(u *ts.ValidatedUser) {
+				u.Reports = []*ts.ValidatedUser{valid(), {Name: "joe", Age: ptr.Int32(151)}}
+			},
+			field: "Age",
+			want:  "must be at most 150",
+		},
+		{
+			desc: "map value",
+			give: func(u *ts.ValidatedUser) {
+				u.ByName = map[string]*ts.ValidatedUser{"joe": {Name: "joe", Score: ptr.Float64(-2)}}
+			},
+			field: "Score",
+			want:  "must be at least -1",
+		},
+	}
+
+	for _, tt := range tests {
+		u := valid()
+		tt.give(u)
+
+		err := u.Validate()
+		if tt.want == "" {
+			assert.NoError(t, err, tt.desc)
+			continue
+		}
+
+		if assert.Error(t, err, tt.desc) {
+			fe, ok := err.(wire.InvalidFieldError)
+			if assert.True(t, ok, "%v: expected InvalidFieldError, got %T", tt.desc, err) {
+				assert.Equal(t, "ValidatedUser", fe.Struct, tt.desc)
+				assert.Equal(t, tt.field, fe.Field, tt.desc)
+				assert.Equal(t, tt.want, fe.Reason, tt.desc)
+			}
+		}
+	}
+
+	var nilUser *ts.ValidatedUser
+	assert.NoError(t, nilUser.Validate(), "nil values are valid")
+}
+
+func TestValidateTypedefs(t *testing.T) {
+	invalid := &ts.ValidatedUser{}
+
+	tests := []struct {
+		desc  string
+		give  td.ValidatedTeam
+		field string
+	}{
+		{desc: "valid", give: td.ValidatedTeam{State: "ready"}},
+		{desc: "pattern", give: td.ValidatedTeam{State: "Ready"}, field: "State"},
+		{desc: "length", give: td.ValidatedTeam{State: "unstarted"}, field: "State"},
+		{
+			desc:  "typedef of struct",
+			give:  td.ValidatedTeam{State: "ready", Lead: (*td.ValidatedEmployee)(invalid)},
+			field: "Name",
+		},
+		{
+			desc:  "set",
+			give:  td.ValidatedTeam{State: "ready", Members: []*ts.ValidatedUser{invalid}},
+			field: "Name",
+		},
+		{
+			desc: "map key",
+			give: td.ValidatedTeam{State: "ready", Ranks: []struct {
+				Key   *ts.ValidatedUser
+				Value int32
+			}{{Key: invalid, Value: 1}}},
+			field: "Name",
+		},
+		{
+			desc: "nested lists",
+			give: td.ValidatedTeam{State: "ready", Rotations: [][]*td.ValidatedEmployee{
+				{}, {(*td.ValidatedEmployee)(invalid)},
+			}},
+			field: "Name",
+		},
+	}
+
+	for _, tt := range tests {
+		err := tt.give.Validate()
+		if tt.field == "" {
+			assert.NoError(t, err, tt.desc)
+			continue
+		}
+
+		if fe, ok := err.(wire.InvalidFieldError); assert.True(t, ok, "%v: got %v", tt.desc, err) {
+			assert.Equal(t, tt.field, fe.Field, tt.desc)
+		}
+	}
+}
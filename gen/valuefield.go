@@ -0,0 +1,181 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+const goPointerAnnotation = "go.pointer"
+
+// isValueField returns true if the given field is an optional field annotated
+// with (go.pointer = "false").
+//
+// 	1: optional i32 age (go.pointer = "false")
+//
+// Such fields are stored as values instead of pointers. Whether they are set
+// is tracked separately: a value field is set if it holds a value other than
+// the zero value of its type, or if it was explicitly set with the generated
+// SetX method or decoded from the wire.
+func isValueField(f *compile.FieldSpec) bool {
+	return !f.Required && f.Annotations[goPointerAnnotation] == "false"
+}
+
+// checkValueFields verifies that go.pointer annotations on the fields of the
+// field group are valid.
+func (f fieldGroupGenerator) checkValueFields(g Generator) error {
+	for _, field := range f.Fields {
+		value, ok := field.Annotations[goPointerAnnotation]
+		if !ok {
+			continue
+		}
+
+		switch {
+		case value != "true" && value != "false":
+			return fmt.Errorf(
+				"invalid go.pointer annotation %q on field %q: expected true or false", value, field.Name)
+		case value == "true":
+			continue
+		case field.Required:
+			return fmt.Errorf(
+				"invalid go.pointer annotation: field %q is required and is never a pointer", field.Name)
+		case f.IsUnion:
+			return fmt.Errorf(
+				"invalid go.pointer annotation: field %q of union %q must be a pointer", field.Name, f.Name)
+		case !isPrimitiveType(field.Type):
+			return fmt.Errorf(
+				"invalid go.pointer annotation: field %q is not of a primitive type", field.Name)
+		}
+
+		if _, ok := lookupSubstitution(g, field.Type); ok {
+			return fmt.Errorf(
+				"invalid go.pointer annotation: field %q has a substituted type", field.Name)
+		}
+	}
+	return nil
+}
+
+// valueFieldFlag returns the name of the unexported struct field which
+// records that the given value field was explicitly set.
+func valueFieldFlag(f *compile.FieldSpec) (string, error) {
+	name, err := goName(f)
+	if err != nil {
+		return "", err
+	}
+	return "isSet" + name, nil
+}
+
+// valueFieldIsSet generates an expression of type bool which checks whether
+// the given value field of the struct v is set.
+func valueFieldIsSet(g Generator, v string, f *compile.FieldSpec) (string, error) {
+	name, err := goNames(g).goName(f)
+	if err != nil {
+		return "", err
+	}
+	flag, err := valueFieldFlag(f)
+	if err != nil {
+		return "", err
+	}
+
+	var nonZero string
+	switch compile.RootTypeSpec(f.Type).(type) {
+	case *compile.BoolSpec:
+		nonZero = fmt.Sprintf("%v.%v", v, name)
+	case *compile.StringSpec:
+		nonZero = fmt.Sprintf("%v.%v != \"\"", v, name)
+	case *compile.UUIDSpec:
+		t, err := typeReference(g, f.Type)
+		if err != nil {
+			return "", err
+		}
+		nonZero = fmt.Sprintf("%v.%v != (%v{})", v, name, t)
+	default:
+		nonZero = fmt.Sprintf("%v.%v != 0", v, name)
+	}
+	return fmt.Sprintf("(%v.%v || %v)", v, flag, nonZero), nil
+}
+
+// ValueFields generates methods to check, set, and clear each value field of
+// the field group.
+//
+// 	func (v *User) IsSetAge() bool
+// 	func (v *User) SetAge(x int32)
+// 	func (v *User) ClearAge()
+//
+// SetAge marks the field as set even if it is given the zero value.
+func (f fieldGroupGenerator) ValueFields(g Generator) error {
+	for _, field := range f.Fields {
+		if !isValueField(field) {
+			continue
+		}
+
+		name, err := goNames(g).goName(field)
+		if err != nil {
+			return err
+		}
+		for _, m := range []string{"IsSet" + name, "Set" + name, "Clear" + name} {
+			if err := f.Reserve(m); err != nil {
+				return fmt.Errorf(
+					"cannot generate %v for field %q: the name is already used by the struct", m, field.Name)
+			}
+		}
+
+		err = g.DeclareFromTemplate(
+			`
+			<$fname := goName .Field>
+			<$flag := valueFieldFlag .Field>
+			<$v := newVar "v">
+			<$x := newVar "x">
+			// IsSet<$fname> returns true if <$fname> is set on this <.Name>.
+			//
+			// <$fname> is set if it holds a non-zero value, or if it was set
+			// with Set<$fname> or decoded from a value that included it.
+			func (<$v> *<.Name>) IsSet<$fname>() bool {
+				return <$v> != nil && <valueFieldIsSet $v .Field>
+			}
+
+			// Set<$fname> sets <$fname> on this <.Name> to the given value. The
+			// field is set even if the value is the zero value.
+			func (<$v> *<.Name>) Set<$fname>(<$x> <typeReference .Field.Type>) {
+				<$v>.<$fname> = <$x>
+				<$v>.<$flag> = true
+			}
+
+			// Clear<$fname> unsets <$fname> on this <.Name>.
+			func (<$v> *<.Name>) Clear<$fname>() {
+				var <$x> <typeReference .Field.Type>
+				<$v>.<$fname> = <$x>
+				<$v>.<$flag> = false
+			}
+			`,
+			struct {
+				Name  string
+				Field *compile.FieldSpec
+			}{Name: f.Name, Field: field},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+	tk "go.uber.org/thriftrw/gen/testdata/constants"
+	te "go.uber.org/thriftrw/gen/testdata/enums"
+	ts "go.uber.org/thriftrw/gen/testdata/structs"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueFieldsWire(t *testing.T) {
+	tests := []struct {
+		desc string
+		give func() *ts.ValueFields
+		want wire.Value
+	}{
+		{
+			desc: "empty",
+			give: func() *ts.ValueFields { return &ts.ValueFields{} },
+			want: singleFieldStruct(5, wire.NewValueI32(100)),
+		},
+		{
+			desc: "non-zero values",
+			give: func() *ts.ValueFields {
+				return &ts.ValueFields{Count: 1, Name: "foo", Enabled: true, Kind: te.EnumDefaultBar}
+			},
+			want: wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+				{ID: 1, Value: wire.NewValueI32(1)},
+				{ID: 2, Value: wire.NewValueString("foo")},
+				{ID: 3, Value: wire.NewValueBool(true)},
+				{ID: 4, Value: wire.NewValueI32(1)},
+				{ID: 5, Value: wire.NewValueI32(100)},
+			}}),
+		},
+		{
+			desc: "explicit zero values",
+			give: func() *ts.ValueFields {
+				v := &ts.ValueFields{}
+				v.SetCount(0)
+				v.SetEnabled(false)
+				v.SetLimit(0)
+				return v
+			},
+			want: wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+				{ID: 1, Value: wire.NewValueI32(0)},
+				{ID: 3, Value: wire.NewValueBool(false)},
+				{ID: 5, Value: wire.NewValueI32(0)},
+			}}),
+		},
+	}
+
+	for _, tt := range tests {
+		w, err := tt.give().ToWire()
+		require.NoError(t, err, tt.desc)
+		assert.True(t, wire.ValuesAreEqual(tt.want, w), "%v: expected %v, got %v", tt.desc, tt.want, w)
+
+		var got ts.ValueFields
+		require.NoError(t, got.FromWire(w), tt.desc)
+
+		// Fields that were decoded are set even if they hold zero values.
+		want := tt.give()
+		_, err = want.ToWire() // fills in defaults
+		require.NoError(t, err, tt.desc)
+		assert.True(t, want.Equals(&got), "%v: expected %v, got %v", tt.desc, want, &got)
+		assert.Equal(t, want.IsSetCount(), got.IsSetCount(), tt.desc)
+		assert.Equal(t, want.IsSetEnabled(), got.IsSetEnabled(), tt.desc)
+	}
+}
+
+func TestValueFieldsJSON(t *testing.T) {
+	tests := []struct {
+		desc string
+		give func() *ts.ValueFields
+		want string
+	}{
+		{
+			desc: "empty",
+			give: func() *ts.ValueFields { return &ts.ValueFields{} },
+			want: `{}`,
+		},
+		{
+			desc: "non-zero values",
+			give: func() *ts.ValueFields {
+				return &ts.ValueFields{Count: 1, Name: "foo", Enabled: true}
+			},
+			want: `{"count":1,"name":"foo","enabled":true}`,
+		},
+		{
+			desc: "explicit zero values",
+			give: func() *ts.ValueFields {
+				v := &ts.ValueFields{}
+				v.SetCount(0)
+				v.SetEnabled(false)
+				v.SetLimit(0)
+				return v
+			},
+			want: `{"count":0,"enabled":false,"limit":0}`,
+		},
+	}
+
+	for _, tt := range tests {
+		b, err := json.Marshal(tt.give())
+		require.NoError(t, err, tt.desc)
+		assert.JSONEq(t, tt.want, string(b), tt.desc)
+
+		got := &ts.ValueFields{Count: 42}
+		got.SetEnabled(true)
+		require.NoError(t, json.Unmarshal(b, got), tt.desc)
+
+		want := tt.give()
+		assert.True(t, want.Equals(got), "%v: expected %v, got %v", tt.desc, want, got)
+		assert.Equal(t, want.IsSetCount(), got.IsSetCount(), tt.desc)
+		assert.Equal(t, want.IsSetEnabled(), got.IsSetEnabled(), tt.desc)
+		assert.Equal(t, want.IsSetLimit(), got.IsSetLimit(), tt.desc)
+	}
+}
+
+func TestValueFieldsAccessors(t *testing.T) {
+	var v ts.ValueFields
+	assert.False(t, v.IsSetCount())
+	assert.Equal(t, int32(100), v.GetLimit(), "unset fields must default")
+
+	v.Count = 42
+	assert.True(t, v.IsSetCount(), "non-zero fields must be set")
+	assert.Equal(t, int32(42), v.GetCount())
+
+	v.ClearCount()
+	assert.False(t, v.IsSetCount())
+	assert.Equal(t, int32(0), v.Count)
+
+	v.SetCount(0)
+	assert.True(t, v.IsSetCount(), "fields set to zero with setters must be set")
+	assert.Equal(t, "ValueFields{Count: 0}", v.String())
+	assert.False(t, v.Equals(&ts.ValueFields{}), "set and unset fields must not be equal")
+
+	v.SetLimit(0)
+	assert.Equal(t, int32(0), v.GetLimit(), "set fields must not default")
+
+	var nilV *ts.ValueFields
+	assert.False(t, nilV.IsSetCount())
+
+	v.SetName("  foo ")
+	v.Sanitize()
+	assert.Equal(t, "foo", v.Name)
+
+	v.SetName("  ")
+	v.Sanitize()
+	assert.True(t, v.IsSetName(), "normalized fields must remain set")
+
+	assert.True(t, tk.ValueFields.IsSetCount(), "constants must set zero values")
+	assert.Equal(t, "foo", tk.ValueFields.Name)
+	assert.False(t, tk.ValueFields.IsSetEnabled())
+}
+
+func TestValueFieldErrors(t *testing.T) {
+	tests := []struct {
+		desc      string
+		idl       string
+		wantError string
+	}{
+		{
+			desc:      "invalid value",
+			idl:       `struct Foo { 1: optional i32 a (go.pointer = "no") }`,
+			wantError: `invalid go.pointer annotation "no" on field "a": expected true or false`,
+		},
+		{
+			desc:      "required",
+			idl:       `struct Foo { 1: required i32 a (go.pointer = "false") }`,
+			wantError: `invalid go.pointer annotation: field "a" is required and is never a pointer`,
+		},
+		{
+			desc:      "union",
+			idl:       `union Foo { 1: i32 a (go.pointer = "false") }`,
+			wantError: `invalid go.pointer annotation: field "a" of union "Foo" must be a pointer`,
+		},
+		{
+			desc:      "collection",
+			idl:       `struct Foo { 1: optional list<i32> a (go.pointer = "false") }`,
+			wantError: `invalid go.pointer annotation: field "a" is not of a primitive type`,
+		},
+		{
+			desc:      "argument",
+			idl:       `service Foo { void bar(1: i32 a (go.pointer = "false")) }`,
+			wantError: `invalid go.pointer annotation: argument "a" must be a pointer`,
+		},
+		{
+			desc: "method conflict",
+			idl: `struct Foo {
+				1: optional i32 a (go.pointer = "false")
+				2: optional i32 isSetA
+			}`,
+			wantError: `cannot generate IsSetA for field "a": the name is already used by the struct`,
+		},
+	}
+
+	for _, tt := range tests {
+		dir, err := ioutil.TempDir("", "thriftrw-value-fields-test")
+		require.NoError(t, err, tt.desc)
+		defer os.RemoveAll(dir)
+
+		thriftFile := filepath.Join(dir, "main.thrift")
+		require.NoError(t, ioutil.WriteFile(thriftFile, []byte(tt.idl), 0644), tt.desc)
+
+		module, err := compile.Compile(thriftFile)
+		require.NoError(t, err, tt.desc)
+
+		err = Generate(module, &Options{
+			OutputDir:      filepath.Join(dir, "out"),
+			PackagePrefix:  "example.com/out",
+			ThriftRoot:     dir,
+			NoVersionCheck: true,
+			NoEmbedIDL:     true,
+		})
+		if assert.Error(t, err, tt.desc) {
+			assert.Contains(t, err.Error(), tt.wantError, tt.desc)
+		}
+	}
+}
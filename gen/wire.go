@@ -36,11 +36,18 @@ type WireGenerator struct {
 	enumG    enumGenerator
 	structG  structGenerator
 	typedefG typedefGenerator
+
+	substitutionG substitutionGenerator
 }
 
 // ToWire generates an expression of type (Value, error) object containing the
 // wire representation of the variable $varName of type $spec or an error.
 func (w *WireGenerator) ToWire(g Generator, spec compile.TypeSpec, varName string) (string, error) {
+	if sub, ok := lookupSubstitution(g, spec); ok {
+		toWire, err := w.substitutionG.ToWire(g, spec, sub)
+		return fmt.Sprintf("%s(%s)", toWire, varName), err
+	}
+
 	wire := g.Import("go.uber.org/thriftrw/wire")
 	switch s := spec.(type) {
 	case *compile.BoolSpec:
@@ -113,6 +120,10 @@ func (w *WireGenerator) ToWire(g Generator, spec compile.TypeSpec, varName strin
 // ToWirePtr is the same as ToWire expect `varName` is expected to be a
 // reference to a value of the given type.
 func (w *WireGenerator) ToWirePtr(g Generator, spec compile.TypeSpec, varName string) (string, error) {
+	if _, ok := lookupSubstitution(g, spec); ok && isPrimitiveType(spec) {
+		return w.ToWire(g, spec, fmt.Sprintf("*(%s)", varName))
+	}
+
 	switch spec.(type) {
 	case *compile.BoolSpec, *compile.I8Spec, *compile.I16Spec, *compile.I32Spec,
 		*compile.I64Spec, *compile.DoubleSpec, *compile.StringSpec:
@@ -127,6 +138,11 @@ func (w *WireGenerator) ToWirePtr(g Generator, spec compile.TypeSpec, varName st
 // FromWire generates an expression of type ($spec, error) which reads the Value
 // at $value into a $spec.
 func (w *WireGenerator) FromWire(g Generator, spec compile.TypeSpec, value string) (string, error) {
+	if sub, ok := lookupSubstitution(g, spec); ok {
+		reader, err := w.substitutionG.Reader(g, spec, sub)
+		return fmt.Sprintf("%s(%s)", reader, value), err
+	}
+
 	switch s := spec.(type) {
 	case *compile.BoolSpec:
 		return fmt.Sprintf("%s.GetBool(), error(nil)", value), nil
@@ -144,30 +160,32 @@ func (w *WireGenerator) FromWire(g Generator, spec compile.TypeSpec, value strin
 		return fmt.Sprintf("%s.GetString(), error(nil)", value), nil
 	case *compile.BinarySpec:
 		return fmt.Sprintf("%s.GetBinary(), error(nil)", value), nil
+	case *compile.UUIDSpec:
+		return fmt.Sprintf("%s.FromBytes(%s.GetBinary())", g.Import("go.uber.org/thriftrw/uuid"), value), nil
 	case *compile.MapSpec:
 		reader, err := w.mapG.Reader(g, s)
 		if err != nil {
 			return "", err
 		}
-		return fmt.Sprintf("%s(%s.GetMap())", reader, value), nil
+		return fmt.Sprintf("%s(%s.GetMap()%s)", reader, value, arenaArg(g)), nil
 	case *compile.ListSpec:
 		reader, err := w.listG.Reader(g, s)
 		if err != nil {
 			return "", err
 		}
-		return fmt.Sprintf("%s(%s.GetList())", reader, value), nil
+		return fmt.Sprintf("%s(%s.GetList()%s)", reader, value, arenaArg(g)), nil
 	case *compile.SetSpec:
 		reader, err := w.setG.Reader(g, s)
 		if err != nil {
 			return "", err
 		}
-		return fmt.Sprintf("%s(%s.GetSet())", reader, value), nil
+		return fmt.Sprintf("%s(%s.GetSet()%s)", reader, value, arenaArg(g)), nil
 	case *compile.TypedefSpec:
 		reader, err := w.typedefG.Reader(g, s)
 		if err != nil {
 			return "", err
 		}
-		return fmt.Sprintf("%s(%s)", reader, value), nil
+		return fmt.Sprintf("%s(%s%s)", reader, value, arenaArg(g)), nil
 	case *compile.EnumSpec:
 		reader, err := w.enumG.Reader(g, s)
 		if err != nil {
@@ -179,7 +197,7 @@ func (w *WireGenerator) FromWire(g Generator, spec compile.TypeSpec, value strin
 		if err != nil {
 			return "", err
 		}
-		return fmt.Sprintf("%s(%s)", reader, value), nil
+		return fmt.Sprintf("%s(%s%s)", reader, value, arenaArg(g)), nil
 	default:
 		panic(fmt.Sprintf("Unknown TypeSpec (%T) %v", spec, spec))
 	}
@@ -199,21 +217,50 @@ func (w *WireGenerator) FromWirePtr(g Generator, spec compile.TypeSpec, lhs stri
 		}
 		return fmt.Sprintf("%s, err = %s", lhs, out), err
 	}
+	// Optional primitives are allocated from the arena unless they were
+	// substituted with a different Go type.
+	var arenaMethod, arenaType string
+	if _, ok := lookupSubstitution(g, spec); !ok && useArenaAllocation(g) {
+		arenaMethod, arenaType = arenaPrimitive(g, spec)
+	}
 	return g.TextTemplate(
 		`
 			<$x := newVar "x">
 			var <$x> <typeReference .Spec>
 			<$x>, err = <fromWire .Spec .Value>
-			<.LHS> = &<$x>
+			<if .ArenaMethod>
+				<.LHS> = (*<typeName .Spec>)(<.Arena>.<.ArenaMethod>(<.ArenaType>(<$x>)))
+			<else>
+				<.LHS> = &<$x>
+			<end>
 			`,
 		struct {
-			Spec  compile.TypeSpec
-			LHS   string
-			Value string
-		}{Spec: spec, LHS: lhs, Value: value},
+			Spec        compile.TypeSpec
+			LHS         string
+			Value       string
+			Arena       string
+			ArenaMethod string
+			ArenaType   string
+		}{
+			Spec:        spec,
+			LHS:         lhs,
+			Value:       value,
+			Arena:       arenaVarName,
+			ArenaMethod: arenaMethod,
+			ArenaType:   arenaType,
+		},
 	)
 }
 
+// arenaArg returns the additional argument passed to readers of structs,
+// typedefs, and containers when arena allocation is enabled.
+func arenaArg(g Generator) string {
+	if useArenaAllocation(g) {
+		return ", " + arenaVarName
+	}
+	return ""
+}
+
 // TypeCode gets an expression of type 'wire.Type' that represents the
 // over-the-wire type code for the given TypeSpec.
 func TypeCode(g Generator, spec compile.TypeSpec) string {
@@ -233,7 +280,7 @@ func TypeCode(g Generator, spec compile.TypeSpec) string {
 		return fmt.Sprintf("%s.TI64", wire)
 	case *compile.DoubleSpec:
 		return fmt.Sprintf("%s.TDouble", wire)
-	case *compile.StringSpec, *compile.BinarySpec:
+	case *compile.StringSpec, *compile.BinarySpec, *compile.UUIDSpec:
 		return fmt.Sprintf("%s.TBinary", wire)
 	case *compile.MapSpec:
 		return fmt.Sprintf("%s.TMap", wire)
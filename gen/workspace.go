@@ -0,0 +1,205 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// writeFiles writes the given files into outputDir. Keys of the map are paths
+// relative to outputDir.
+//
+// All files are first written to a temporary workspace inside outputDir. If
+// any of them cannot be written, outputDir is left untouched. Once every file
+// has been written, each package directory is swapped into place with
+// renames, so that a package is never left with only some of its files
+// updated. Files in the existing package directories that were not
+// generated, as well as nested packages, are carried over.
+func writeFiles(outputDir string, files map[string][]byte) error {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("could not create directory %q: %v", outputDir, err)
+	}
+
+	ws, err := ioutil.TempDir(outputDir, ".thriftrw-")
+	if err != nil {
+		return fmt.Errorf("could not create workspace in %q: %v", outputDir, err)
+	}
+
+	w := workspace{Dir: ws, OutputDir: outputDir}
+	if err := w.Stage(files); err != nil {
+		os.RemoveAll(ws)
+		return err
+	}
+
+	if err := w.Commit(); err != nil {
+		// The workspace may hold the previous contents of a package which
+		// could not be restored. Leave it for the user to recover.
+		return fmt.Errorf("%v: the workspace %q was left in place", err, ws)
+	}
+	return os.RemoveAll(ws)
+}
+
+// workspace is a temporary directory into which generated packages are
+// staged before being moved into OutputDir.
+type workspace struct {
+	Dir       string
+	OutputDir string
+
+	// Packages directories relative to OutputDir which were staged.
+	packages []string
+}
+
+func (w *workspace) stagedPath(rel string) string {
+	return filepath.Join(w.Dir, "new", rel)
+}
+
+// Stage writes the given files into the workspace.
+func (w *workspace) Stage(files map[string][]byte) error {
+	packages := make(map[string]struct{})
+	for relPath, contents := range files {
+		path := w.stagedPath(relPath)
+		directory := filepath.Dir(path)
+
+		if err := os.MkdirAll(directory, 0755); err != nil {
+			return fmt.Errorf("could not create directory %q: %v", directory, err)
+		}
+
+		if err := ioutil.WriteFile(path, contents, 0644); err != nil {
+			return fmt.Errorf("failed to write %q: %v", filepath.Join(w.OutputDir, relPath), err)
+		}
+
+		packages[filepath.Dir(relPath)] = struct{}{}
+	}
+
+	w.packages = sortStringKeys(packages)
+	return nil
+}
+
+// Commit moves all staged packages into OutputDir.
+func (w *workspace) Commit() error {
+	// Nested packages are swapped before their parents so that staging
+	// directories of parents contain only their own files by the time they
+	// are moved.
+	pkgs := append([]string(nil), w.packages...)
+	sort.Sort(sort.Reverse(sort.StringSlice(pkgs)))
+
+	for i, pkg := range pkgs {
+		var err error
+		if pkg == "." {
+			// Files at the top of OutputDir can't be swapped as a directory
+			// because the workspace itself lives there.
+			err = w.commitFiles(pkg)
+		} else {
+			err = w.swapPackage(pkg, filepath.Join(w.Dir, fmt.Sprintf("old%d", i)))
+		}
+		if err != nil {
+			return fmt.Errorf("could not move package %q into place: %v", pkg, err)
+		}
+	}
+	return nil
+}
+
+// swapPackage replaces the directory for the given package with its staged
+// copy. backup is a path inside the workspace where the previous directory
+// is held while it is being replaced.
+func (w *workspace) swapPackage(pkg, backup string) error {
+	src := w.stagedPath(pkg)
+	dst := filepath.Join(w.OutputDir, pkg)
+
+	if _, err := os.Lstat(dst); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+		return os.Rename(src, dst)
+	} else if err != nil {
+		return err
+	}
+
+	if err := os.Rename(dst, backup); err != nil {
+		return err
+	}
+	if err := os.Rename(src, dst); err != nil {
+		if rerr := os.Rename(backup, dst); rerr != nil {
+			return fmt.Errorf("%v (and failed to restore %q: %v)", err, dst, rerr)
+		}
+		return err
+	}
+
+	// Carry over anything in the old directory that wasn't regenerated.
+	return carryOver(backup, dst)
+}
+
+// carryOver moves entries of the directory old which don't exist in the
+// directory dst into dst. Directories which exist in both are merged
+// recursively.
+func carryOver(old, dst string) error {
+	entries, err := ioutil.ReadDir(old)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		oldPath := filepath.Join(old, e.Name())
+		newPath := filepath.Join(dst, e.Name())
+
+		info, err := os.Lstat(newPath)
+		switch {
+		case os.IsNotExist(err):
+			if err := os.Rename(oldPath, newPath); err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		case info.IsDir() && e.IsDir():
+			if err := carryOver(oldPath, newPath); err != nil {
+				return err
+			}
+		default:
+			// Replaced by a generated file.
+		}
+	}
+	return nil
+}
+
+// commitFiles moves the staged files of the given package into place one at
+// a time.
+func (w *workspace) commitFiles(pkg string) error {
+	entries, err := ioutil.ReadDir(w.stagedPath(pkg))
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue // nested packages were already moved
+		}
+
+		rel := filepath.Join(pkg, e.Name())
+		if err := os.Rename(w.stagedPath(rel), filepath.Join(w.OutputDir, rel)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
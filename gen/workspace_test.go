@@ -0,0 +1,166 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	for path, contents := range files {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+}
+
+func readTree(t *testing.T, dir string) map[string]string {
+	files := make(map[string]string)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		require.NoError(t, err)
+		contents, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		files[rel] = string(contents)
+		return nil
+	})
+	require.NoError(t, err)
+	return files
+}
+
+func TestWriteFiles(t *testing.T) {
+	tests := []struct {
+		desc     string
+		existing map[string]string
+		files    map[string]string
+		want     map[string]string
+	}{
+		{
+			desc: "empty output directory",
+			files: map[string]string{
+				"foo/types.go":          "foo",
+				"foo/keyvalue/types.go": "keyvalue",
+				"a/b/c/types.go":        "c",
+				"plugin.go":             "plugin",
+			},
+			want: map[string]string{
+				"foo/types.go":          "foo",
+				"foo/keyvalue/types.go": "keyvalue",
+				"a/b/c/types.go":        "c",
+				"plugin.go":             "plugin",
+			},
+		},
+		{
+			desc: "replace existing packages",
+			existing: map[string]string{
+				"foo/types.go":          "old foo",
+				"foo/doc.go":            "handwritten",
+				"foo/keyvalue/types.go": "old keyvalue",
+				"foo/x/y/types.go":      "old y",
+				"bar/types.go":          "bar",
+				"plugin.go":             "old plugin",
+			},
+			files: map[string]string{
+				"foo/types.go":          "foo",
+				"foo/keyvalue/types.go": "keyvalue",
+				"plugin.go":             "plugin",
+			},
+			want: map[string]string{
+				"foo/types.go":          "foo",
+				"foo/doc.go":            "handwritten",
+				"foo/keyvalue/types.go": "keyvalue",
+				"foo/x/y/types.go":      "old y",
+				"bar/types.go":          "bar",
+				"plugin.go":             "plugin",
+			},
+		},
+		{
+			desc: "nested package under intermediate directory",
+			existing: map[string]string{
+				"foo/types.go":      "old foo",
+				"foo/a/b/types.go":  "old b",
+				"foo/a/notes.txt":   "notes",
+				"foo/a/b/helper.go": "handwritten",
+			},
+			files: map[string]string{
+				"foo/types.go":     "foo",
+				"foo/a/b/types.go": "b",
+			},
+			want: map[string]string{
+				"foo/types.go":      "foo",
+				"foo/a/b/types.go":  "b",
+				"foo/a/notes.txt":   "notes",
+				"foo/a/b/helper.go": "handwritten",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "thriftrw-write-test")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			writeTree(t, dir, tt.existing)
+
+			files := make(map[string][]byte, len(tt.files))
+			for path, contents := range tt.files {
+				files[path] = []byte(contents)
+			}
+			require.NoError(t, writeFiles(dir, files))
+
+			// readTree would also report leftovers from the workspace.
+			assert.Equal(t, tt.want, readTree(t, dir))
+		})
+	}
+}
+
+func TestWriteFilesStageFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-write-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	existing := map[string]string{
+		"foo/types.go": "old foo",
+		"bar/types.go": "old bar",
+	}
+	writeTree(t, dir, existing)
+
+	// "bar/types.go" can't be both a file and a directory, so staging fails
+	// after some of the files may have been written.
+	err = writeFiles(dir, map[string][]byte{
+		"foo/types.go":          []byte("foo"),
+		"bar/types.go":          []byte("bar"),
+		"bar/types.go/types.go": []byte("conflict"),
+	})
+	require.Error(t, err)
+
+	assert.Equal(t, existing, readTree(t, dir), "output must be untouched")
+}
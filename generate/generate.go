@@ -0,0 +1,214 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package generate runs the ThriftRW code generator end-to-end: it compiles
+// a Thrift file and the files it includes, and writes the generated Go
+// code to disk.
+//
+// This is the same process as the one run by the thriftrw command, made
+// available to build tools which embed the generator rather than running
+// it as a separate program.
+//
+// 	err := generate.Generate(ctx, generate.Config{
+// 		ThriftFile: "idl/users.thrift",
+// 		Options: gen.Options{
+// 			OutputDir:     "gen-go",
+// 			PackagePrefix: "example.com/myservice/gen-go",
+// 		},
+// 	})
+package generate
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/gen"
+	"go.uber.org/thriftrw/internal/plugin"
+
+	"go.uber.org/multierr"
+)
+
+// Config configures a single run of the code generator.
+type Config struct {
+	// ThriftFile is the path to the Thrift file for which code is
+	// generated. This is required.
+	ThriftFile string
+
+	// Options controls the generated code.
+	//
+	// Unlike gen.Generate, relative paths are accepted for OutputDir and
+	// ThriftRoot, and are resolved against the working directory.
+	// OutputDir defaults to the working directory, and ThriftRoot defaults
+	// to the deepest common ancestor directory of the Thrift files.
+	// PackagePrefix is required.
+	//
+	// If Options.Plugin is set, it is used in addition to Plugins. The
+	// caller remains responsible for closing it.
+	Options gen.Options
+
+	// IncludePaths are directories searched, in order, for included Thrift
+	// files which are not found relative to the files including them.
+	IncludePaths []string
+
+	// StrictUnused fails compilation if an included Thrift file is never
+	// referenced, or if a type or constant declared in an included file is
+	// never used.
+	StrictUnused bool
+
+	// Pedantic fails compilation if a definition is likely to behave unlike
+	// what its author intended, like a required field with a default value.
+	// These are otherwise reported to Warnings.
+	Pedantic bool
+
+	// Warnings, if non-nil, is called with the warnings reported by the
+	// compiler, for example, for deprecated syntax.
+	Warnings func(compile.Warning)
+
+	// Plugins are the code generation plugins to run, specified in the same
+	// format as the --plugin option of the thriftrw command.
+	//
+	// 	Plugins: []string{"yarpc --sanitize-tchannel", "foo@unix:/tmp/foo.sock"}
+	Plugins []string
+
+	// PluginTLSConfig is used to connect to plugin daemons reached over
+	// TLS. By default, the system's root certificates are used.
+	PluginTLSConfig *tls.Config
+}
+
+// Generate compiles the Thrift file specified in the configuration and
+// generates code for it.
+//
+// The context is checked for cancellation between the steps of
+// generation. Once code has started being written to the output directory,
+// Generate runs to completion.
+func Generate(ctx context.Context, cfg Config) (err error) {
+	if cfg.ThriftFile == "" {
+		return fmt.Errorf("a Thrift file is required")
+	}
+
+	if _, err := os.Stat(cfg.ThriftFile); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("File %q does not exist: %v", cfg.ThriftFile, err)
+		}
+		return fmt.Errorf("Could not stat file %q: %v", cfg.ThriftFile, err)
+	}
+
+	opts := cfg.Options
+	if opts.PackagePrefix == "" {
+		return fmt.Errorf(
+			"A package prefix is required to use correct import paths in the generated code.")
+	}
+
+	if opts.OutputDir == "" {
+		opts.OutputDir = "."
+	}
+	if opts.OutputDir, err = filepath.Abs(opts.OutputDir); err != nil {
+		return fmt.Errorf("Unable to resolve absolute path for %q: %v", opts.OutputDir, err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var compileOpts []compile.Option
+	if cfg.Warnings != nil {
+		compileOpts = append(compileOpts, compile.Warnings(cfg.Warnings))
+	}
+	if len(cfg.IncludePaths) > 0 {
+		compileOpts = append(compileOpts, compile.IncludePaths(cfg.IncludePaths...))
+	}
+	if cfg.StrictUnused {
+		compileOpts = append(compileOpts, compile.StrictUnused())
+	}
+	if cfg.Pedantic {
+		compileOpts = append(compileOpts, compile.Pedantic())
+	}
+	module, err := compile.Compile(cfg.ThriftFile, compileOpts...)
+	if err != nil {
+		// TODO(abg): For nested compile errors, split causal chain across
+		// multiple lines.
+		return fmt.Errorf("Failed to compile %q: %+v", cfg.ThriftFile, err)
+	}
+
+	if opts.ThriftRoot == "" {
+		opts.ThriftRoot, err = FindThriftRoot(module)
+		if err != nil {
+			return fmt.Errorf(
+				"Could not find a common parent directory for %q and the Thrift files "+
+					"imported by it.\nThis directory is required to generate a consistent "+
+					"hierarchy for generated packages.\nUse the --thrift-root option to "+
+					"provide this path.\n\t%v", cfg.ThriftFile, err)
+		}
+	} else {
+		root, err := filepath.Abs(opts.ThriftRoot)
+		if err != nil {
+			return fmt.Errorf("Unable to resolve absolute path for %q: %v", opts.ThriftRoot, err)
+		}
+		opts.ThriftRoot = root
+		if err := VerifyThriftRoot(module, opts.ThriftRoot); err != nil {
+			return fmt.Errorf(
+				"An included Thrift file is not contained in the %q directory tree: %v",
+				opts.ThriftRoot, err)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if len(cfg.Plugins) > 0 {
+		flags := make(plugin.Flags, len(cfg.Plugins))
+		for i, spec := range cfg.Plugins {
+			if err := flags[i].UnmarshalFlag(spec); err != nil {
+				return err
+			}
+			flags[i].TLSConfig = cfg.PluginTLSConfig
+		}
+
+		handle, err := flags.Handle()
+		if err != nil {
+			return fmt.Errorf("Failed to initialize plugins: %+v", err)
+		}
+		defer func() {
+			err = multierr.Append(err, handle.Close())
+		}()
+
+		if opts.Plugin != nil {
+			opts.Plugin = append(plugin.MultiHandle{opts.Plugin}, handle...)
+		} else {
+			opts.Plugin = handle
+		}
+	}
+
+	opts.NoServiceHelpers = opts.NoServiceHelpers || opts.NoTypes
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := gen.Generate(module, &opts); err != nil {
+		return fmt.Errorf("Failed to generate code: %+v", err)
+	}
+	return nil
+}
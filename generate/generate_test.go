@@ -0,0 +1,177 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package generate
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/gen"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-generate-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	idl := filepath.Join(dir, "idl")
+	require.NoError(t, os.Mkdir(idl, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(idl, "shared.thrift"),
+		[]byte("struct Shared { 1: optional string name }\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(idl, "users.thrift"), []byte(
+		`include "./shared.thrift"
+		struct User { 1: required shared.Shared shared }
+		`), 0644))
+
+	out := filepath.Join(dir, "out")
+	err = Generate(context.Background(), Config{
+		ThriftFile: filepath.Join(idl, "users.thrift"),
+		Options: gen.Options{
+			OutputDir:     out,
+			PackagePrefix: "example.com/out",
+		},
+	})
+	require.NoError(t, err)
+
+	for _, f := range []string{"users/types.go", "shared/types.go"} {
+		_, err := os.Stat(filepath.Join(out, f))
+		assert.NoError(t, err, "expected %q to be generated", f)
+	}
+}
+
+func TestGenerateWarnings(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-generate-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "foo.thrift")
+	require.NoError(t, ioutil.WriteFile(file,
+		[]byte("php_namespace Foo\nstruct Foo { 1: optional string value }\n"), 0644))
+
+	var warnings []compile.Warning
+	err = Generate(context.Background(), Config{
+		ThriftFile: file,
+		Warnings: func(w compile.Warning) {
+			warnings = append(warnings, w)
+		},
+		Options: gen.Options{
+			OutputDir:     filepath.Join(dir, "out"),
+			PackagePrefix: "example.com/out",
+		},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, warnings)
+}
+
+func TestGenerateErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-generate-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "foo.thrift")
+	require.NoError(t, ioutil.WriteFile(file, []byte("struct Foo {}\n"), 0644))
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		desc   string
+		ctx    context.Context
+		cfg    Config
+		errMsg string
+	}{
+		{
+			desc:   "no file",
+			cfg:    Config{Options: gen.Options{PackagePrefix: "example.com/out"}},
+			errMsg: "a Thrift file is required",
+		},
+		{
+			desc: "missing file",
+			cfg: Config{
+				ThriftFile: filepath.Join(dir, "bar.thrift"),
+				Options:    gen.Options{PackagePrefix: "example.com/out"},
+			},
+			errMsg: "does not exist",
+		},
+		{
+			desc:   "no package prefix",
+			cfg:    Config{ThriftFile: file},
+			errMsg: "A package prefix is required",
+		},
+		{
+			desc: "file outside thrift root",
+			cfg: Config{
+				ThriftFile: file,
+				Options: gen.Options{
+					PackagePrefix: "example.com/out",
+					ThriftRoot:    filepath.Join(dir, "idl"),
+				},
+			},
+			errMsg: "is not contained in the",
+		},
+		{
+			desc: "invalid plugin",
+			cfg: Config{
+				ThriftFile: file,
+				Plugins:    []string{"thriftrw-generate-test-does-not-exist"},
+				Options: gen.Options{
+					OutputDir:     filepath.Join(dir, "out"),
+					PackagePrefix: "example.com/out",
+				},
+			},
+			errMsg: "could not find executable",
+		},
+		{
+			desc: "canceled",
+			ctx:  canceled,
+			cfg: Config{
+				ThriftFile: file,
+				Options: gen.Options{
+					OutputDir:     filepath.Join(dir, "out"),
+					PackagePrefix: "example.com/out",
+				},
+			},
+			errMsg: context.Canceled.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		ctx := tt.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		err := Generate(ctx, tt.cfg)
+		if assert.Error(t, err, tt.desc) {
+			assert.Contains(t, err.Error(), tt.errMsg, tt.desc)
+		}
+	}
+
+	_, err = os.Stat(filepath.Join(dir, "out"))
+	assert.True(t, os.IsNotExist(err), "nothing must be generated")
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package generate
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+// VerifyThriftRoot verifies that the Thrift file for the given module and
+// the Thrift files for all imported modules are contained within the
+// directory tree rooted at the given path.
+func VerifyThriftRoot(m *compile.Module, root string) error {
+	return m.Walk(func(m *compile.Module) error {
+		path, err := filepath.Rel(root, m.ThriftPath)
+		if err != nil {
+			return fmt.Errorf(
+				"could not resolve path for %q: %v", m.ThriftPath, err)
+		}
+
+		if strings.HasPrefix(path, "..") {
+			return fmt.Errorf(
+				"%q is not contained in the %q directory tree",
+				m.ThriftPath, root)
+		}
+
+		return nil
+	})
+}
+
+// FindThriftRoot finds the deepest common ancestor for the given module and
+// all modules imported by it.
+func FindThriftRoot(m *compile.Module) (string, error) {
+	var result []string
+	var lastString string
+
+	err := m.Walk(func(m *compile.Module) error {
+		thriftPath := m.ThriftPath
+		if !filepath.IsAbs(thriftPath) {
+			return fmt.Errorf(
+				"ThriftPath must be absolute: %q is not absolute", thriftPath)
+		}
+
+		thriftDir := filepath.Dir(thriftPath)
+
+		// Split("/foo/bar", "/") = ["", "foo", "bar"]
+		parts := strings.Split(thriftDir, string(filepath.Separator))
+		if result == nil {
+			result = parts
+			lastString = thriftPath
+			return nil
+		}
+
+		result = commonPrefix(result, parts)
+		if len(result) == 1 && result[0] == "" {
+			return fmt.Errorf(
+				"%q does not share an ancestor with %q",
+				thriftPath, lastString)
+		}
+
+		lastString = thriftPath
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(result, string(filepath.Separator)), nil
+}
+
+// commonPrefix finds the shortest common prefix for the two lists.
+//
+// An empty slice may be returned if the two lists don't have a common prefix.
+func commonPrefix(l, r []string) []string {
+	var i int
+	for i = 0; i < len(l) && i < len(r); i++ {
+		if l[i] != r[i] {
+			break
+		}
+	}
+	return l[:i]
+}
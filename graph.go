@@ -0,0 +1,109 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/internal/idlgraph"
+
+	"github.com/jessevdk/go-flags"
+)
+
+// Status code with which "thriftrw graph --fail-on-cycle" exits if it found
+// include cycles. Other failures exit with status 1.
+const graphCycleExitCode = 2
+
+type graphOptions struct {
+	Format      string `long:"format" value-name:"FORMAT" default:"dot" choice:"dot" choice:"json" description:"Format in which the graph is written."`
+	ThriftRoot  string `long:"thrift-root" value-name:"DIR" description:"Directory whose descendants contain all Thrift files. Files in the graph are identified by their paths relative to this directory. By default, this is the deepest common ancestor directory of the Thrift files."`
+	FailOnCycle bool   `long:"fail-on-cycle" description:"Exit with status 2 if the Thrift files include each other."`
+}
+
+// doGraph implements the "thriftrw graph" command.
+func doGraph(args []string) error {
+	var opts graphOptions
+
+	parser := flags.NewParser(&opts, flags.Default)
+	parser.Name = "thriftrw graph"
+	parser.Usage = "[OPTIONS] FILE\n\n" +
+		"Writes the include graph of FILE to stdout in the Graphviz DOT format or as JSON.\n" +
+		"Each node lists the number of types, constants, services, and lines in the file.\n" +
+		"Include cycles are highlighted."
+
+	files, err := parser.ParseArgs(args)
+	if err != nil {
+		return nil // message already printed by go-flags
+	}
+
+	if len(files) != 1 {
+		var buffer bytes.Buffer
+		parser.WriteHelp(&buffer)
+		return errors.New(buffer.String())
+	}
+
+	return writeGraph(files[0], &opts, os.Stdout)
+}
+
+// writeGraph writes the include graph of the given Thrift file to the given
+// writer.
+func writeGraph(file string, opts *graphOptions, out io.Writer) error {
+	module, err := compile.Compile(file)
+	if err != nil {
+		return fmt.Errorf("Failed to compile %q: %+v", file, err)
+	}
+
+	thriftRoot, err := resolveThriftRoot(file, module, opts.ThriftRoot)
+	if err != nil {
+		return err
+	}
+
+	graph, err := idlgraph.Build(module, thriftRoot)
+	if err != nil {
+		return fmt.Errorf("Failed to build the include graph: %v", err)
+	}
+
+	switch opts.Format {
+	case "json":
+		err = graph.WriteJSON(out)
+	default:
+		err = graph.WriteDOT(out)
+	}
+	if err != nil {
+		return err
+	}
+
+	if opts.FailOnCycle && len(graph.Cycles) > 0 {
+		var msg bytes.Buffer
+		fmt.Fprintf(&msg, "Found %d include cycles:", len(graph.Cycles))
+		for _, cycle := range graph.Cycles {
+			fmt.Fprintf(&msg, "\n\t%v", strings.Join(cycle, ", "))
+		}
+		return exitError{Code: graphCycleExitCode, Message: msg.String()}
+	}
+	return nil
+}
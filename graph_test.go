@@ -0,0 +1,85 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteGraph(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-graph-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"a.thrift": "include \"./b.thrift\"\n",
+		"b.thrift": "include \"./a.thrift\"\nstruct Foo {}\n",
+	}
+	for name, contents := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644))
+	}
+	file := filepath.Join(dir, "a.thrift")
+
+	var out bytes.Buffer
+	require.NoError(t, writeGraph(file, &graphOptions{Format: "dot"}, &out))
+	assert.Contains(t, out.String(), `"a.thrift" -> "b.thrift" [color=red];`)
+	assert.Contains(t, out.String(), `1 types, 0 constants, 0 services, 2 lines`)
+
+	out.Reset()
+	require.NoError(t, writeGraph(file, &graphOptions{Format: "json"}, &out))
+	assert.Contains(t, out.String(), `"path": "b.thrift"`)
+
+	out.Reset()
+	err = writeGraph(file, &graphOptions{Format: "dot", FailOnCycle: true}, &out)
+	if assert.Error(t, err) {
+		exitErr, ok := err.(exitError)
+		require.True(t, ok, "expected an exitError, got %T", err)
+		assert.Equal(t, graphCycleExitCode, exitErr.Code)
+		assert.Contains(t, exitErr.Message, "a.thrift, b.thrift")
+	}
+	assert.NotEmpty(t, out.String(), "graph must be written even if a cycle was found")
+}
+
+func TestWriteGraphThriftRoot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-graph-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "idl", "service.thrift")
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+	require.NoError(t, ioutil.WriteFile(file, []byte("service Foo {}\n"), 0644))
+
+	var out bytes.Buffer
+	require.NoError(t, writeGraph(file, &graphOptions{Format: "dot", ThriftRoot: dir}, &out))
+	assert.Contains(t, out.String(), `"idl/service.thrift"`)
+
+	err = writeGraph(file, &graphOptions{Format: "dot", ThriftRoot: filepath.Join(dir, "elsewhere")}, &out)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "is not contained in the")
+	}
+}
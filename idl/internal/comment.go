@@ -0,0 +1,174 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package internal
+
+import (
+	"bytes"
+	"strings"
+
+	"go.uber.org/thriftrw/ast"
+)
+
+// recordComments records comments found between the current position of the
+// lexer and the next token. If the comment immediately preceding the token is
+// a doc comment, and the token is the first one on its line, the doc comment
+// is recorded as well.
+//
+// Doc comments are either multi-line comments starting with "/**", or a run
+// of "//" comments on their own lines ending on the line right above the
+// token.
+//
+// 	// Maximum number of retries.
+// 	const i32 maxRetries = 3
+//
+// This must be called before the lexer advances to the next token. It does
+// not change the position of the lexer.
+func (lex *lexer) recordComments() {
+	data := lex.data[:lex.pe]
+	p, line := lex.p, lex.line
+	var (
+		doc string
+
+		lineDoc    []string // consecutive comments starting with "//"
+		lineDocEnd int      // line of the last comment in lineDoc
+	)
+	for p < len(data) {
+		switch c := data[p]; {
+		case c == '\n':
+			line++
+			p++
+		case c == ' ' || c == '\t' || c == '\r':
+			p++
+		case c == '#' || bytes.HasPrefix(data[p:], []byte("//")):
+			end := bytes.IndexByte(data[p:], '\n')
+			if end < 0 {
+				end = len(data)
+			} else {
+				end += p
+			}
+			text := string(data[p:end])
+			lex.comments = append(lex.comments, &ast.Comment{
+				Text: text,
+				Line: line,
+			})
+			doc = ""
+			switch {
+			case c == '#' || line == lex.lastTokenLine:
+				// Trailing comments and "#" comments are never doc
+				// comments.
+				lineDoc = nil
+			case len(lineDoc) > 0 && lineDocEnd == line-1:
+				lineDoc = append(lineDoc, text)
+			default:
+				lineDoc = []string{text}
+			}
+			lineDocEnd = line
+			p = end
+		case bytes.HasPrefix(data[p:], []byte("/*")):
+			end := bytes.Index(data[p+2:], []byte("*/"))
+			if end < 0 {
+				// Unterminated comment. The lexer will report this.
+				return
+			}
+			end += p + 4
+			text := data[p:end]
+			lex.comments = append(lex.comments, &ast.Comment{
+				Text: string(text),
+				Line: line,
+			})
+			line += bytes.Count(text, []byte{'\n'})
+			doc = ""
+			lineDoc = nil
+			if isDocComment(text) {
+				doc = string(text)
+			}
+			p = end
+		default:
+			if line != lex.lastTokenLine {
+				var text string
+				switch {
+				case doc != "":
+					text = docText(doc)
+				case len(lineDoc) > 0 && lineDocEnd == line-1:
+					text = lineDocText(lineDoc)
+				}
+				if text != "" {
+					if lex.docs == nil {
+						lex.docs = make(map[int]string)
+					}
+					lex.docs[line] = text
+				}
+			}
+			lex.lastTokenLine = line
+			return
+		}
+	}
+}
+
+// isDocComment returns true if the given multi-line comment is a doc
+// comment.
+//
+// 	/** Doc comment. */
+func isDocComment(text []byte) bool {
+	return bytes.HasPrefix(text, []byte("/**")) && !bytes.Equal(text, []byte("/**/"))
+}
+
+// docText returns the text of a doc comment without the comment markers and
+// the leading "*" on each line.
+//
+// 	/**
+// 	 * Foo bar.
+// 	 *
+// 	 * Baz.
+// 	 */
+//
+// The comment above has the text "Foo bar.\n\nBaz.".
+func docText(comment string) string {
+	comment = strings.TrimSuffix(strings.TrimPrefix(comment, "/**"), "*/")
+
+	lines := strings.Split(comment, "\n")
+	for i, l := range lines {
+		l = strings.TrimSpace(l)
+		if strings.HasPrefix(l, "*") {
+			l = strings.TrimPrefix(l[1:], " ")
+		}
+		lines[i] = strings.TrimRight(l, " \t\r")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// lineDocText returns the text of a run of "//" comments without the comment
+// markers.
+//
+// 	// Foo bar.
+// 	//
+// 	// Baz.
+//
+// The comments above have the text "Foo bar.\n\nBaz.".
+func lineDocText(comments []string) string {
+	lines := make([]string, len(comments))
+	for i, c := range comments {
+		l := strings.TrimPrefix(c, "//")
+		l = strings.TrimPrefix(l, " ")
+		lines[i] = strings.TrimRight(l, " \t\r")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
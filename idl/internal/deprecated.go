@@ -0,0 +1,77 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package internal
+
+import "fmt"
+
+// deprecatedKeywords maps identifiers which are deprecated keywords of the
+// Thrift IDL to their tokens.
+//
+// These are recognized by the lexer as identifiers and turned into keywords
+// afterwards so that each use may be reported.
+var deprecatedKeywords = map[string]int{
+	"senum":        SENUM,
+	"xsd_all":      XSD_ALL,
+	"xsd_optional": XSD_OPTIONAL,
+	"xsd_nillable": XSD_NILLABLE,
+	"xsd_attrs":    XSD_ATTRS,
+}
+
+// legacyNamespaceScopes maps the deprecated language-specific namespace
+// keywords to the scopes of the equivalent namespace statements.
+var legacyNamespaceScopes = map[string]string{
+	"cpp_namespace":    "cpp",
+	"csharp_namespace": "csharp",
+	"delphi_namespace": "delphi",
+	"java_package":     "java",
+	"perl_package":     "perl",
+	"php_namespace":    "php",
+	"py_module":        "py",
+	"ruby_namespace":   "rb",
+	"cocoa_prefix":     "cocoa",
+}
+
+// identifierToken returns the token for the given identifier, reporting
+// deprecated keywords.
+func (lex *lexer) identifierToken(s string) int {
+	if scope, ok := legacyNamespaceScopes[s]; ok {
+		lex.warnf("%q is deprecated: use \"namespace %v\" instead", s, scope)
+		return LEGACY_NAMESPACE
+	}
+
+	tok, ok := deprecatedKeywords[s]
+	if !ok {
+		return IDENTIFIER
+	}
+
+	if tok == SENUM {
+		lex.warnf("senum is deprecated: it is treated as a typedef of string")
+	} else {
+		lex.warnf("%q is deprecated and has no effect", s)
+	}
+	return tok
+}
+
+func (lex *lexer) warnf(format string, args ...interface{}) {
+	if lex.warn != nil {
+		lex.warn(lex.line, fmt.Sprintf(format, args...))
+	}
+}
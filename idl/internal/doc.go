@@ -0,0 +1,71 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package internal
+
+import "go.uber.org/thriftrw/ast"
+
+// attachDocs sets the Doc fields of nodes in the program from the given doc
+// comments, keyed by the line of the token following each comment.
+//
+// A doc comment is attached to the first node, in the order of a depth-first
+// walk, which starts on that line. Given,
+//
+// 	/** A point. */
+// 	struct Point { 1: required double x }
+//
+// the comment documents Point, not its field x.
+func attachDocs(prog *ast.Program, docs map[int]string) {
+	if len(docs) == 0 {
+		return
+	}
+
+	ast.Walk(ast.VisitorFunc(func(_ ast.Walker, n ast.Node) {
+		var (
+			doc  *string
+			line int
+		)
+		switch n := n.(type) {
+		case *ast.Constant:
+			doc, line = &n.Doc, n.Line
+		case *ast.Typedef:
+			doc, line = &n.Doc, n.Line
+		case *ast.Enum:
+			doc, line = &n.Doc, n.Line
+		case *ast.EnumItem:
+			doc, line = &n.Doc, n.Line
+		case *ast.Struct:
+			doc, line = &n.Doc, n.Line
+		case *ast.Service:
+			doc, line = &n.Doc, n.Line
+		case *ast.Function:
+			doc, line = &n.Doc, n.Line
+		case *ast.Field:
+			doc, line = &n.Doc, n.Line
+		default:
+			return
+		}
+
+		if text, ok := docs[line]; ok {
+			*doc = text
+			delete(docs, line)
+		}
+	}), prog)
+}
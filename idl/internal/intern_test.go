@@ -0,0 +1,49 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInternsWithinDocument(t *testing.T) {
+	var calls [][]byte
+	prog, err := Parse([]byte(`
+		typedef string UUID
+		struct User { 1: required UUID id (name = "id") }
+	`), Options{Intern: func(b []byte) string {
+		calls = append(calls, append([]byte(nil), b...))
+		return string(b)
+	}})
+	if assert.NoError(t, err) {
+		assert.Len(t, prog.Definitions, 2)
+	}
+	assert.Equal(t, [][]byte{
+		[]byte("UUID"),
+		[]byte("User"),
+		[]byte("UUID"),
+		[]byte("id"),
+		[]byte("name"),
+		[]byte("id"),
+	}, calls, "identifiers and literals must be interned in order")
+}
@@ -28,6 +28,22 @@ type lexer struct {
     err parseError
     parseFailed bool
 
+    // Comments are recorded only if keepComments is true.
+    keepComments bool
+    comments []*ast.Comment
+
+    // Doc comments keyed by the line of the token that follows them. Only
+    // doc comments followed by the first token on a line are recorded.
+    docs map[int]string
+    lastTokenLine int
+
+    // If non-nil, this is called with warnings about deprecated syntax.
+    warn func(line int, msg string)
+
+    // intern returns a string with the given contents. It is used for
+    // identifiers and literals so that repeated names share storage.
+    intern func([]byte) string
+
     // Ragel:
     p, pe, cs, ts, te, act int
     data []byte
@@ -53,6 +69,10 @@ func (lex *lexer) Lex(out *yySymType) int {
     eof := lex.pe
     tok := 0
 
+    if lex.keepComments {
+        lex.recordComments()
+    }
+
     %%{
         ws = [ \t\r];
 
@@ -293,7 +313,7 @@ func (lex *lexer) Lex(out *yySymType) int {
                 if err != nil {
                     lex.Error(err.Error())
                 } else {
-                    out.str = str
+                    out.str = lex.intern([]byte(str))
                     tok = LITERAL
                 }
 
@@ -301,13 +321,19 @@ func (lex *lexer) Lex(out *yySymType) int {
             };
 
             reservedKeyword __ => {
-                lex.Error(fmt.Sprintf("%q is a reserved keyword", reservedKeyword))
+                if reservedKeyword == "as" {
+                    // "as" is reserved everywhere except in include-as
+                    // headers.
+                    tok = AS
+                } else {
+                    lex.Error(fmt.Sprintf("%q is a reserved keyword", reservedKeyword))
+                }
                 fbreak;
             };
 
             identifier => {
-                out.str = string(lex.data[lex.ts:lex.te])
-                tok = IDENTIFIER
+                out.str = lex.intern(lex.data[lex.ts:lex.te])
+                tok = lex.identifierToken(out.str)
                 fbreak;
             };
         *|;
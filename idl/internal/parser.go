@@ -26,11 +26,37 @@ func init() {
 	yyErrorVerbose = true
 }
 
+// Options controls how documents are parsed.
+type Options struct {
+	// Retain comments in the Program.
+	Comments bool
+
+	// If non-nil, Warn is called with the line number and a description of
+	// each use of deprecated syntax.
+	Warn func(line int, msg string)
+
+	// If non-nil, Intern is used to build the strings for identifiers and
+	// literals. Otherwise, they are interned within the document.
+	Intern func([]byte) string
+}
+
 // Parse parses the given Thrift document.
-func Parse(s []byte) (*ast.Program, error) {
+func Parse(s []byte, opts Options) (*ast.Program, error) {
 	lex := newLexer(s)
+	// Comments are always recorded so that doc comments can be attached to
+	// the definitions that follow them.
+	lex.keepComments = true
+	lex.warn = opts.Warn
+	lex.intern = opts.Intern
+	if lex.intern == nil {
+		lex.intern = newInterner()
+	}
 	e := yyParse(lex)
 	if e == 0 && !lex.parseFailed {
+		if opts.Comments {
+			lex.program.Comments = lex.comments
+		}
+		attachDocs(lex.program, lex.docs)
 		return lex.program, nil
 	}
 	return nil, lex.err
@@ -16,6 +16,7 @@ import "go.uber.org/thriftrw/ast"
 
     bul bool
     str string
+    strs []string
     i64 int64
     dub float64
 
@@ -52,11 +53,15 @@ import "go.uber.org/thriftrw/ast"
 %token <dub> DUBCONSTANT
 
 // Reserved keywords
-%token NAMESPACE INCLUDE
+%token NAMESPACE INCLUDE AS
 %token VOID BOOL BYTE I8 I16 I32 I64 DOUBLE STRING BINARY MAP LIST SET
 %token ONEWAY TYPEDEF STRUCT UNION EXCEPTION EXTENDS THROWS SERVICE ENUM CONST
 %token REQUIRED OPTIONAL TRUE FALSE
 
+// Deprecated keywords
+%token <str> LEGACY_NAMESPACE
+%token SENUM XSD_ALL XSD_OPTIONAL XSD_NILLABLE XSD_ATTRS
+
 %type <line> lineno
 %type <prog> program
 %type <fieldType> type
@@ -79,6 +84,10 @@ import "go.uber.org/thriftrw/ast"
 %type <definition> definition
 %type <definitions> definitions
 
+%type <bul> xsd_all xsd_optional xsd_nillable
+%type <fields> xsd_attributes
+%type <strs> senum_values
+
 %type <constantValue> const_value
 %type <constantValues> const_list_items
 %type <constantMapItems> const_map_items
@@ -121,22 +130,41 @@ header
                 Line: $1,
             }
         }
-    | lineno NAMESPACE '*' IDENTIFIER
+    | lineno INCLUDE LITERAL AS IDENTIFIER
+        {
+            $$ = &ast.Include{
+                Name: $5,
+                Path: $3,
+                Line: $1,
+            }
+        }
+    | lineno NAMESPACE '*' IDENTIFIER type_annotations
         {
             $$ = &ast.Namespace{
                 Scope: "*",
                 Name: $4,
+                Annotations: $5,
                 Line: $1,
             }
         }
-    | lineno NAMESPACE IDENTIFIER IDENTIFIER
+    | lineno NAMESPACE IDENTIFIER IDENTIFIER type_annotations
         {
             $$ = &ast.Namespace{
                 Scope: $3,
                 Name: $4,
+                Annotations: $5,
                 Line: $1,
             }
         }
+    | lineno LEGACY_NAMESPACE IDENTIFIER
+        {
+            $$ = &ast.Namespace{
+                Scope: legacyNamespaceScopes[$2],
+                Name: $3,
+                Line: $1,
+                Keyword: $2,
+            }
+        }
     ;
 
 /***************************************************************************
@@ -179,14 +207,24 @@ definition
                 Line: $1,
             }
         }
-    | lineno struct_type IDENTIFIER '{' fields '}' type_annotations
+    | lineno SENUM IDENTIFIER '{' senum_values '}' type_annotations
+        {
+            $$ = &ast.Senum{
+                Name: $3,
+                Values: $5,
+                Annotations: $7,
+                Line: $1,
+            }
+        }
+    | lineno struct_type IDENTIFIER xsd_all '{' fields '}' type_annotations
         {
             $$ = &ast.Struct{
                 Name: $3,
                 Type: $2,
-                Fields: $5,
-                Annotations: $7,
+                Fields: $6,
+                Annotations: $8,
                 Line: $1,
+                XSDAll: $4,
             }
         }
     /* services */
@@ -223,6 +261,16 @@ struct_type
     | EXCEPTION { $$ = ast.ExceptionType }
     ;
 
+senum_values
+    : /* nothing */ { $$ = nil }
+    | senum_values LITERAL optional_sep { $$ = append($1, $2) }
+    ;
+
+xsd_all
+    : /* nothing */ { $$ = false }
+    | XSD_ALL       { $$ = true }
+    ;
+
 enum_items
     : /* nothing */ { $$ = nil }
     | enum_items enum_item optional_sep { $$ = append($1, $2) }
@@ -250,19 +298,23 @@ fields
 
 
 field
-    : lineno INTCONSTANT ':' field_required type IDENTIFIER type_annotations
+    : lineno INTCONSTANT ':' field_required type IDENTIFIER
+      xsd_optional xsd_nillable xsd_attributes type_annotations
         {
             $$ = &ast.Field{
                 ID: int($2),
                 Name: $6,
                 Type: $5,
                 Requiredness: $4,
-                Annotations: $7,
+                Annotations: $10,
                 Line: $1,
+                XSDOptional: $7,
+                XSDNillable: $8,
+                XSDAttributes: $9,
             }
         }
     | lineno INTCONSTANT ':' field_required type IDENTIFIER '=' const_value
-      type_annotations
+      xsd_optional xsd_nillable xsd_attributes type_annotations
         {
             $$ = &ast.Field{
                 ID: int($2),
@@ -270,12 +322,30 @@ field
                 Type: $5,
                 Requiredness: $4,
                 Default: $8,
-                Annotations: $9,
+                Annotations: $12,
                 Line: $1,
+                XSDOptional: $9,
+                XSDNillable: $10,
+                XSDAttributes: $11,
             }
         }
     ;
 
+xsd_optional
+    : /* nothing */ { $$ = false }
+    | XSD_OPTIONAL  { $$ = true }
+    ;
+
+xsd_nillable
+    : /* nothing */ { $$ = false }
+    | XSD_NILLABLE  { $$ = true }
+    ;
+
+xsd_attributes
+    : /* nothing */ { $$ = nil }
+    | XSD_ATTRS '{' fields '}' { $$ = $3 }
+    ;
+
 field_required
     : REQUIRED { $$ =    ast.Required }
     | OPTIONAL { $$ =    ast.Optional }
@@ -334,7 +404,15 @@ type
     | lineno SET '<' type '>' type_annotations
         { $$ = ast.SetType{ValueType: $4, Annotations: $6, Line: $1} }
     | lineno IDENTIFIER
-        { $$ = ast.TypeReference{Name: $2, Line: $1} }
+        {
+            // uuid is not a keyword so that existing fields and definitions
+            // named uuid continue to parse.
+            if $2 == "uuid" {
+                $$ = ast.BaseType{ID: ast.UUIDTypeID, Line: $1}
+            } else {
+                $$ = ast.TypeReference{Name: $2, Line: $1}
+            }
+        }
     ;
 
 base_type_name
@@ -41,10 +41,11 @@ type yySymType struct {
 
 	// Other intermediate variables:
 
-	bul bool
-	str string
-	i64 int64
-	dub float64
+	bul  bool
+	str  string
+	strs []string
+	i64  int64
+	dub  float64
 
 	fieldType     ast.Type
 	structType    ast.StructureType
@@ -79,33 +80,40 @@ const INTCONSTANT = 57348
 const DUBCONSTANT = 57349
 const NAMESPACE = 57350
 const INCLUDE = 57351
-const VOID = 57352
-const BOOL = 57353
-const BYTE = 57354
-const I8 = 57355
-const I16 = 57356
-const I32 = 57357
-const I64 = 57358
-const DOUBLE = 57359
-const STRING = 57360
-const BINARY = 57361
-const MAP = 57362
-const LIST = 57363
-const SET = 57364
-const ONEWAY = 57365
-const TYPEDEF = 57366
-const STRUCT = 57367
-const UNION = 57368
-const EXCEPTION = 57369
-const EXTENDS = 57370
-const THROWS = 57371
-const SERVICE = 57372
-const ENUM = 57373
-const CONST = 57374
-const REQUIRED = 57375
-const OPTIONAL = 57376
-const TRUE = 57377
-const FALSE = 57378
+const AS = 57352
+const VOID = 57353
+const BOOL = 57354
+const BYTE = 57355
+const I8 = 57356
+const I16 = 57357
+const I32 = 57358
+const I64 = 57359
+const DOUBLE = 57360
+const STRING = 57361
+const BINARY = 57362
+const MAP = 57363
+const LIST = 57364
+const SET = 57365
+const ONEWAY = 57366
+const TYPEDEF = 57367
+const STRUCT = 57368
+const UNION = 57369
+const EXCEPTION = 57370
+const EXTENDS = 57371
+const THROWS = 57372
+const SERVICE = 57373
+const ENUM = 57374
+const CONST = 57375
+const REQUIRED = 57376
+const OPTIONAL = 57377
+const TRUE = 57378
+const FALSE = 57379
+const LEGACY_NAMESPACE = 57380
+const SENUM = 57381
+const XSD_ALL = 57382
+const XSD_OPTIONAL = 57383
+const XSD_NILLABLE = 57384
+const XSD_ATTRS = 57385
 
 var yyToknames = [...]string{
 	"$end",
@@ -117,6 +125,7 @@ var yyToknames = [...]string{
 	"DUBCONSTANT",
 	"NAMESPACE",
 	"INCLUDE",
+	"AS",
 	"VOID",
 	"BOOL",
 	"BYTE",
@@ -144,6 +153,12 @@ var yyToknames = [...]string{
 	"OPTIONAL",
 	"TRUE",
 	"FALSE",
+	"LEGACY_NAMESPACE",
+	"SENUM",
+	"XSD_ALL",
+	"XSD_OPTIONAL",
+	"XSD_NILLABLE",
+	"XSD_ATTRS",
 	"'*'",
 	"'='",
 	"'{'",
@@ -170,123 +185,140 @@ var yyExca = [...]int{
 	1, -1,
 	-2, 0,
 	-1, 2,
-	8, 70,
-	9, 70,
-	-2, 8,
+	8, 83,
+	9, 83,
+	38, 83,
+	-2, 10,
 	-1, 3,
 	1, 1,
-	-2, 70,
+	-2, 83,
 }
 
 const yyPrivate = 57344
 
-const yyLast = 179
+const yyLast = 215
 
 var yyAct = [...]int{
 
-	26, 87, 55, 5, 7, 10, 63, 62, 65, 25,
-	70, 66, 67, 11, 122, 124, 94, 12, 93, 92,
-	155, 11, 59, 58, 27, 12, 57, 146, 145, 114,
-	90, 153, 56, 56, 56, 137, 89, 139, 120, 85,
-	68, 69, 115, 85, 88, 70, 66, 67, 79, 76,
-	53, 106, 60, 118, 64, 71, 130, 51, 50, 54,
-	82, 52, 78, 81, 150, 24, 104, 73, 74, 75,
-	127, 128, 125, 91, 100, 68, 69, 9, 8, 95,
-	22, 21, 98, 96, 133, 101, 99, 31, 141, 102,
-	129, 109, 97, 86, 105, 49, 111, 112, 23, 34,
-	113, 33, 110, 32, 116, 30, 29, 28, 71, 121,
-	149, 103, 84, 117, 72, 123, 119, 108, 107, 3,
-	6, 61, 71, 77, 83, 131, 2, 134, 135, 4,
-	132, 80, 16, 138, 126, 35, 136, 1, 0, 140,
-	71, 0, 0, 81, 144, 142, 71, 0, 143, 147,
-	0, 151, 152, 0, 148, 81, 14, 18, 19, 20,
-	39, 154, 17, 15, 13, 0, 0, 40, 41, 42,
-	43, 44, 45, 46, 47, 48, 36, 37, 38,
+	62, 167, 92, 29, 172, 162, 5, 7, 77, 74,
+	133, 82, 78, 79, 12, 28, 100, 12, 13, 139,
+	105, 13, 104, 103, 69, 68, 67, 179, 160, 127,
+	98, 30, 11, 63, 63, 171, 152, 155, 141, 64,
+	96, 66, 80, 81, 96, 91, 182, 137, 111, 87,
+	177, 60, 164, 119, 73, 70, 163, 26, 102, 56,
+	55, 135, 65, 143, 75, 168, 101, 93, 59, 83,
+	173, 164, 82, 78, 79, 89, 58, 9, 8, 166,
+	99, 150, 151, 84, 85, 86, 117, 90, 106, 35,
+	140, 109, 130, 144, 114, 36, 113, 25, 24, 23,
+	159, 142, 120, 80, 81, 124, 125, 10, 108, 126,
+	97, 118, 128, 61, 54, 39, 38, 37, 34, 123,
+	131, 107, 33, 32, 110, 83, 138, 115, 31, 132,
+	136, 27, 165, 116, 95, 76, 122, 121, 72, 57,
+	147, 148, 83, 3, 153, 129, 6, 146, 71, 88,
+	94, 2, 4, 134, 112, 157, 18, 149, 40, 83,
+	1, 113, 0, 0, 158, 156, 170, 83, 0, 145,
+	0, 0, 169, 176, 175, 174, 178, 154, 0, 113,
+	180, 0, 183, 181, 113, 0, 15, 20, 21, 22,
+	0, 161, 19, 16, 14, 44, 0, 0, 0, 0,
+	17, 0, 0, 45, 46, 47, 48, 49, 50, 51,
+	52, 53, 41, 42, 43,
 }
 var yyPact = [...]int{
 
-	-1000, -1000, -1000, -1000, -1000, 69, -32, 132, 76, 61,
-	-1000, -1000, -1000, -1000, -1000, 103, 102, 101, -1000, -1000,
-	-1000, -1000, 82, 99, 97, 95, 156, 91, 19, 18,
-	22, -1000, -1000, -1000, 21, -8, -18, -21, -22, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -8,
-	-1000, -1000, -1000, -1000, 40, -1000, -1000, -1000, -1000, -1000,
-	-1000, 9, 8, 20, 89, -1000, -1000, -1000, -1000, -1000,
-	-1000, -3, -13, -26, -28, -30, -8, -32, 88, -8,
-	-32, 68, -8, -32, 56, -1000, 12, -1000, -1000, -1000,
-	-1000, 87, -1000, -8, -8, -1000, -1000, -9, -1000, -1000,
-	1, -1000, -1000, -1000, -1000, -1000, -1000, 5, -2, -24,
-	-31, -1000, -1000, -1000, 66, 37, 86, 16, -1000, -32,
-	-1000, 40, 79, -1000, -8, -8, -1000, -1000, -1000, -7,
-	-8, -1000, -4, -32, -1000, -1000, 84, -1000, -1000, 40,
-	-1000, -10, -16, -32, -1000, 40, 35, -1000, -8, -8,
-	-11, -1000, -1000, -1000, -23, -1000,
+	-1000, -1000, -1000, -1000, -1000, 69, -38, 161, 94, 53,
+	127, -1000, -1000, -1000, -1000, -1000, 124, 119, 118, 114,
+	-1000, -1000, -1000, 79, 90, 113, 112, -1000, 111, 191,
+	110, 14, 13, 36, 22, 109, -1000, -15, -15, 17,
+	-15, -25, -26, -27, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -15, -1000, -1000, 8, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, 67, -1000, -1000, -1000, -1000,
+	-1000, 2, 40, -1000, 20, 106, -20, -1000, -1000, -1000,
+	-1000, -1000, -1000, 12, -29, -31, -33, -15, -38, 104,
+	-15, -38, 1, -15, -38, 75, -1000, 7, -1000, 98,
+	-1000, -1000, -1000, -1000, -15, -15, -1000, -1000, -16, -1000,
+	-1000, -15, -38, 86, -1000, -1000, -1000, -1000, -1000, -1000,
+	-35, 6, 0, -34, -1000, -1000, -1000, 84, -1000, -1000,
+	-10, 97, 16, 88, -1000, -1000, -38, -1000, 67, -15,
+	-15, 47, -13, -15, -38, -1000, -11, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, 67, 96, -22, -38, 11,
+	49, -1000, 23, 67, -1000, -15, -14, 27, -1000, 30,
+	-1000, -1000, -15, 4, 23, -23, -1000, -1000, 27, -1000,
+	-1, -15, -1000, -1000,
 }
 var yyPgo = [...]int{
 
-	0, 0, 137, 9, 135, 134, 132, 131, 7, 129,
-	126, 124, 6, 123, 121, 120, 119, 8, 118, 117,
-	114, 2, 5, 112, 111, 110,
+	0, 3, 160, 15, 158, 157, 156, 154, 2, 152,
+	151, 150, 9, 149, 148, 146, 143, 139, 5, 1,
+	4, 138, 8, 137, 136, 135, 0, 32, 134, 133,
+	132,
 }
 var yyR1 = [...]int{
 
-	0, 2, 10, 10, 9, 9, 9, 9, 16, 16,
-	15, 15, 15, 15, 15, 15, 6, 6, 6, 14,
-	14, 13, 13, 8, 8, 7, 7, 5, 5, 5,
-	12, 12, 11, 23, 23, 24, 24, 25, 25, 3,
-	3, 3, 3, 3, 4, 4, 4, 4, 4, 4,
-	4, 4, 4, 17, 17, 17, 17, 17, 17, 17,
-	17, 18, 18, 19, 19, 21, 21, 20, 20, 20,
-	1, 22, 22, 22,
+	0, 2, 10, 10, 9, 9, 9, 9, 9, 9,
+	16, 16, 15, 15, 15, 15, 15, 15, 15, 6,
+	6, 6, 21, 21, 17, 17, 14, 14, 13, 13,
+	8, 8, 7, 7, 18, 18, 19, 19, 20, 20,
+	5, 5, 5, 12, 12, 11, 28, 28, 29, 29,
+	30, 30, 3, 3, 3, 3, 3, 4, 4, 4,
+	4, 4, 4, 4, 4, 4, 22, 22, 22, 22,
+	22, 22, 22, 22, 23, 23, 24, 24, 26, 26,
+	25, 25, 25, 1, 27, 27, 27,
 }
 var yyR2 = [...]int{
 
-	0, 2, 0, 2, 3, 4, 4, 4, 0, 3,
-	6, 5, 7, 7, 7, 10, 1, 1, 1, 0,
-	3, 3, 5, 0, 3, 7, 9, 1, 1, 0,
-	0, 3, 9, 1, 0, 1, 1, 0, 4, 3,
-	8, 6, 6, 2, 1, 1, 1, 1, 1, 1,
-	1, 1, 1, 1, 1, 1, 1, 1, 2, 4,
-	4, 0, 3, 0, 6, 0, 3, 0, 6, 4,
-	0, 1, 1, 0,
+	0, 2, 0, 2, 3, 4, 5, 5, 5, 3,
+	0, 3, 6, 5, 7, 7, 8, 7, 10, 1,
+	1, 1, 0, 3, 0, 1, 0, 3, 3, 5,
+	0, 3, 10, 12, 0, 1, 0, 1, 0, 4,
+	1, 1, 0, 0, 3, 9, 1, 0, 1, 1,
+	0, 4, 3, 8, 6, 6, 2, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 2, 4, 4, 0, 3, 0, 6, 0, 3,
+	0, 6, 4, 0, 1, 1, 0,
 }
 var yyChk = [...]int{
 
 	-1000, -2, -10, -16, -9, -1, -15, -1, 9, 8,
-	-22, 45, 49, 32, 24, 31, -6, 30, 25, 26,
-	27, 5, 4, 37, 4, -3, -1, -3, 4, 4,
-	4, 5, 4, 4, 4, -4, 20, 21, 22, 4,
-	11, 12, 13, 14, 15, 16, 17, 18, 19, 4,
-	39, 39, 39, 28, 38, -21, 42, 44, 44, 44,
-	-21, -14, -8, -12, -1, -17, 6, 7, 35, 36,
-	5, -1, -20, -3, -3, -3, 40, -13, -1, 40,
-	-7, -1, 40, -11, -23, 23, 4, 4, 47, 39,
-	43, -1, 45, 46, 46, -21, -22, 4, -21, -22,
-	6, -21, -22, -24, 10, -3, 39, -18, -19, 4,
-	-3, -21, -21, -21, 38, 41, -1, -12, 48, -17,
-	40, -1, 38, -22, 46, 6, -5, 33, 34, 4,
-	40, -22, -17, 5, -21, -21, -3, 42, -21, 41,
-	-22, 4, -8, -17, -21, 38, 43, -22, -17, -25,
-	29, -21, -21, 42, -8, 43,
+	38, -27, 52, 56, 33, 25, 32, 39, -6, 31,
+	26, 27, 28, 5, 4, 44, 4, 4, -3, -1,
+	-3, 4, 4, 4, 4, 10, 5, 4, 4, 4,
+	-4, 21, 22, 23, 4, 12, 13, 14, 15, 16,
+	17, 18, 19, 20, 4, 46, 46, -17, 40, 46,
+	29, 4, -26, 49, -26, 45, -26, 51, 51, 51,
+	-26, -14, -21, 46, -12, -1, -25, -22, 6, 7,
+	36, 37, 5, -1, -3, -3, -3, 47, -13, -1,
+	47, 5, -8, 47, -11, -28, 24, 4, 50, -1,
+	4, 54, 46, 52, 53, 53, -26, -27, 4, -26,
+	-27, 47, -7, -1, -26, -27, -29, 11, -3, 46,
+	4, -23, -24, -3, -26, -26, -26, 45, -26, -27,
+	6, -1, -12, 45, -27, 55, -22, 47, -1, 53,
+	6, 48, 4, 47, 5, -27, -22, -26, -26, -5,
+	34, 35, 49, -26, -27, 48, -3, -8, -22, 4,
+	50, -27, -18, 45, 41, -30, 30, -19, 42, -22,
+	-26, 49, -20, 43, -18, -8, -26, 46, -19, 50,
+	-8, -20, 47, -26,
 }
 var yyDef = [...]int{
 
-	2, -2, -2, -2, 3, 0, 73, 0, 0, 0,
-	9, 71, 72, 70, 70, 0, 0, 0, 16, 17,
-	18, 4, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 5, 6, 7, 0, 65, 0, 0, 0, 43,
-	44, 45, 46, 47, 48, 49, 50, 51, 52, 65,
-	19, 23, 30, 70, 70, 39, 67, 70, 70, 70,
-	11, 70, 70, 34, 0, 10, 53, 54, 55, 56,
-	57, 0, 70, 0, 0, 0, 65, 73, 0, 65,
-	73, 0, 65, 73, 70, 33, 0, 58, 61, 63,
-	66, 0, 70, 65, 65, 12, 20, 65, 13, 24,
-	0, 14, 31, 70, 35, 36, 30, 70, 70, 73,
-	0, 41, 42, 21, 0, 29, 0, 34, 59, 73,
-	60, 70, 0, 69, 65, 65, 70, 27, 28, 0,
-	65, 62, 0, 73, 40, 22, 0, 23, 15, 70,
-	68, 65, 70, 73, 25, 70, 37, 64, 65, 65,
-	0, 26, 32, 23, 70, 38,
+	2, -2, -2, -2, 3, 0, 86, 0, 0, 0,
+	0, 11, 84, 85, 83, 83, 0, 0, 0, 0,
+	19, 20, 21, 4, 0, 0, 0, 9, 0, 0,
+	0, 0, 0, 24, 0, 0, 5, 78, 78, 0,
+	78, 0, 0, 0, 56, 57, 58, 59, 60, 61,
+	62, 63, 64, 65, 78, 26, 22, 0, 25, 43,
+	83, 6, 7, 80, 8, 83, 52, 83, 83, 83,
+	13, 83, 0, 30, 47, 0, 83, 12, 66, 67,
+	68, 69, 70, 0, 0, 0, 0, 78, 86, 0,
+	78, 86, 83, 78, 86, 83, 46, 0, 79, 0,
+	71, 74, 76, 83, 78, 78, 14, 27, 78, 15,
+	23, 78, 86, 0, 17, 44, 83, 48, 49, 43,
+	86, 83, 83, 0, 54, 55, 28, 0, 16, 31,
+	0, 0, 47, 0, 82, 72, 86, 73, 83, 78,
+	78, 42, 0, 78, 86, 75, 0, 53, 29, 83,
+	40, 41, 30, 18, 81, 83, 0, 83, 86, 34,
+	50, 77, 36, 83, 35, 78, 0, 38, 37, 34,
+	45, 30, 78, 0, 36, 83, 32, 30, 38, 51,
+	83, 78, 39, 33,
 }
 var yyTok1 = [...]int{
 
@@ -294,22 +326,23 @@ var yyTok1 = [...]int{
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	42, 43, 37, 3, 45, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 41, 49,
-	44, 38, 46, 3, 3, 3, 3, 3, 3, 3,
+	49, 50, 44, 3, 52, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 48, 56,
+	51, 45, 53, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 47, 3, 48, 3, 3, 3, 3, 3, 3,
+	3, 54, 3, 55, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 39, 3, 40,
+	3, 3, 3, 46, 3, 47,
 }
 var yyTok2 = [...]int{
 
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
 	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
-	32, 33, 34, 35, 36,
+	32, 33, 34, 35, 36, 37, 38, 39, 40, 41,
+	42, 43,
 }
 var yyTok3 = [...]int{
 	0,
@@ -654,7 +687,7 @@ yydefault:
 
 	case 1:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line thrift.y:92
+		//line thrift.y:101
 		{
 			yyVAL.prog = &ast.Program{Headers: yyDollar[1].headers, Definitions: yyDollar[2].definitions}
 			yylex.(*lexer).program = yyVAL.prog
@@ -662,19 +695,19 @@ yydefault:
 		}
 	case 2:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line thrift.y:104
+		//line thrift.y:113
 		{
 			yyVAL.headers = nil
 		}
 	case 3:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line thrift.y:105
+		//line thrift.y:114
 		{
 			yyVAL.headers = append(yyDollar[1].headers, yyDollar[2].header)
 		}
 	case 4:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line thrift.y:110
+		//line thrift.y:119
 		{
 			yyVAL.header = &ast.Include{
 				Path: yyDollar[3].str,
@@ -683,7 +716,7 @@ yydefault:
 		}
 	case 5:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line thrift.y:117
+		//line thrift.y:126
 		{
 			yyVAL.header = &ast.Include{
 				Name: yyDollar[3].str,
@@ -692,40 +725,63 @@ yydefault:
 			}
 		}
 	case 6:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line thrift.y:125
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line thrift.y:134
 		{
-			yyVAL.header = &ast.Namespace{
-				Scope: "*",
-				Name:  yyDollar[4].str,
-				Line:  yyDollar[1].line,
+			yyVAL.header = &ast.Include{
+				Name: yyDollar[5].str,
+				Path: yyDollar[3].str,
+				Line: yyDollar[1].line,
 			}
 		}
 	case 7:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line thrift.y:133
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line thrift.y:142
 		{
 			yyVAL.header = &ast.Namespace{
-				Scope: yyDollar[3].str,
-				Name:  yyDollar[4].str,
-				Line:  yyDollar[1].line,
+				Scope:       "*",
+				Name:        yyDollar[4].str,
+				Annotations: yyDollar[5].typeAnnotations,
+				Line:        yyDollar[1].line,
 			}
 		}
 	case 8:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		//line thrift.y:151
+		{
+			yyVAL.header = &ast.Namespace{
+				Scope:       yyDollar[3].str,
+				Name:        yyDollar[4].str,
+				Annotations: yyDollar[5].typeAnnotations,
+				Line:        yyDollar[1].line,
+			}
+		}
+	case 9:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line thrift.y:160
+		{
+			yyVAL.header = &ast.Namespace{
+				Scope:   legacyNamespaceScopes[yyDollar[2].str],
+				Name:    yyDollar[3].str,
+				Line:    yyDollar[1].line,
+				Keyword: yyDollar[2].str,
+			}
+		}
+	case 10:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line thrift.y:147
+		//line thrift.y:175
 		{
 			yyVAL.definitions = nil
 		}
-	case 9:
+	case 11:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line thrift.y:148
+		//line thrift.y:176
 		{
 			yyVAL.definitions = append(yyDollar[1].definitions, yyDollar[2].definition)
 		}
-	case 10:
+	case 12:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line thrift.y:155
+		//line thrift.y:183
 		{
 			yyVAL.definition = &ast.Constant{
 				Name:  yyDollar[4].str,
@@ -734,9 +790,9 @@ yydefault:
 				Line:  yyDollar[1].line,
 			}
 		}
-	case 11:
+	case 13:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line thrift.y:165
+		//line thrift.y:193
 		{
 			yyVAL.definition = &ast.Typedef{
 				Name:        yyDollar[4].str,
@@ -745,9 +801,9 @@ yydefault:
 				Line:        yyDollar[1].line,
 			}
 		}
-	case 12:
+	case 14:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line thrift.y:174
+		//line thrift.y:202
 		{
 			yyVAL.definition = &ast.Enum{
 				Name:        yyDollar[3].str,
@@ -756,21 +812,33 @@ yydefault:
 				Line:        yyDollar[1].line,
 			}
 		}
-	case 13:
+	case 15:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line thrift.y:183
+		//line thrift.y:211
+		{
+			yyVAL.definition = &ast.Senum{
+				Name:        yyDollar[3].str,
+				Values:      yyDollar[5].strs,
+				Annotations: yyDollar[7].typeAnnotations,
+				Line:        yyDollar[1].line,
+			}
+		}
+	case 16:
+		yyDollar = yyS[yypt-8 : yypt+1]
+		//line thrift.y:220
 		{
 			yyVAL.definition = &ast.Struct{
 				Name:        yyDollar[3].str,
 				Type:        yyDollar[2].structType,
-				Fields:      yyDollar[5].fields,
-				Annotations: yyDollar[7].typeAnnotations,
+				Fields:      yyDollar[6].fields,
+				Annotations: yyDollar[8].typeAnnotations,
 				Line:        yyDollar[1].line,
+				XSDAll:      yyDollar[4].bul,
 			}
 		}
-	case 14:
+	case 17:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line thrift.y:194
+		//line thrift.y:232
 		{
 			yyVAL.definition = &ast.Service{
 				Name:        yyDollar[3].str,
@@ -779,9 +847,9 @@ yydefault:
 				Line:        yyDollar[1].line,
 			}
 		}
-	case 15:
+	case 18:
 		yyDollar = yyS[yypt-10 : yypt+1]
-		//line thrift.y:204
+		//line thrift.y:242
 		{
 			parent := &ast.ServiceReference{
 				Name: yyDollar[6].str,
@@ -796,45 +864,69 @@ yydefault:
 				Line:        yyDollar[1].line,
 			}
 		}
-	case 16:
+	case 19:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:221
+		//line thrift.y:259
 		{
 			yyVAL.structType = ast.StructType
 		}
-	case 17:
+	case 20:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:222
+		//line thrift.y:260
 		{
 			yyVAL.structType = ast.UnionType
 		}
-	case 18:
+	case 21:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:223
+		//line thrift.y:261
 		{
 			yyVAL.structType = ast.ExceptionType
 		}
-	case 19:
+	case 22:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line thrift.y:265
+		{
+			yyVAL.strs = nil
+		}
+	case 23:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line thrift.y:266
+		{
+			yyVAL.strs = append(yyDollar[1].strs, yyDollar[2].str)
+		}
+	case 24:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line thrift.y:227
+		//line thrift.y:270
+		{
+			yyVAL.bul = false
+		}
+	case 25:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line thrift.y:271
+		{
+			yyVAL.bul = true
+		}
+	case 26:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line thrift.y:275
 		{
 			yyVAL.enumItems = nil
 		}
-	case 20:
+	case 27:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line thrift.y:228
+		//line thrift.y:276
 		{
 			yyVAL.enumItems = append(yyDollar[1].enumItems, yyDollar[2].enumItem)
 		}
-	case 21:
+	case 28:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line thrift.y:233
+		//line thrift.y:281
 		{
 			yyVAL.enumItem = &ast.EnumItem{Name: yyDollar[2].str, Annotations: yyDollar[3].typeAnnotations, Line: yyDollar[1].line}
 		}
-	case 22:
+	case 29:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line thrift.y:235
+		//line thrift.y:283
 		{
 			value := int(yyDollar[4].i64)
 			yyVAL.enumItem = &ast.EnumItem{
@@ -844,78 +936,120 @@ yydefault:
 				Line:        yyDollar[1].line,
 			}
 		}
-	case 23:
+	case 30:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line thrift.y:247
+		//line thrift.y:295
 		{
 			yyVAL.fields = nil
 		}
-	case 24:
+	case 31:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line thrift.y:248
+		//line thrift.y:296
 		{
 			yyVAL.fields = append(yyDollar[1].fields, yyDollar[2].field)
 		}
-	case 25:
-		yyDollar = yyS[yypt-7 : yypt+1]
-		//line thrift.y:254
+	case 32:
+		yyDollar = yyS[yypt-10 : yypt+1]
+		//line thrift.y:303
 		{
 			yyVAL.field = &ast.Field{
-				ID:           int(yyDollar[2].i64),
-				Name:         yyDollar[6].str,
-				Type:         yyDollar[5].fieldType,
-				Requiredness: yyDollar[4].fieldRequired,
-				Annotations:  yyDollar[7].typeAnnotations,
-				Line:         yyDollar[1].line,
+				ID:            int(yyDollar[2].i64),
+				Name:          yyDollar[6].str,
+				Type:          yyDollar[5].fieldType,
+				Requiredness:  yyDollar[4].fieldRequired,
+				Annotations:   yyDollar[10].typeAnnotations,
+				Line:          yyDollar[1].line,
+				XSDOptional:   yyDollar[7].bul,
+				XSDNillable:   yyDollar[8].bul,
+				XSDAttributes: yyDollar[9].fields,
 			}
 		}
-	case 26:
-		yyDollar = yyS[yypt-9 : yypt+1]
-		//line thrift.y:266
+	case 33:
+		yyDollar = yyS[yypt-12 : yypt+1]
+		//line thrift.y:318
 		{
 			yyVAL.field = &ast.Field{
-				ID:           int(yyDollar[2].i64),
-				Name:         yyDollar[6].str,
-				Type:         yyDollar[5].fieldType,
-				Requiredness: yyDollar[4].fieldRequired,
-				Default:      yyDollar[8].constantValue,
-				Annotations:  yyDollar[9].typeAnnotations,
-				Line:         yyDollar[1].line,
+				ID:            int(yyDollar[2].i64),
+				Name:          yyDollar[6].str,
+				Type:          yyDollar[5].fieldType,
+				Requiredness:  yyDollar[4].fieldRequired,
+				Default:       yyDollar[8].constantValue,
+				Annotations:   yyDollar[12].typeAnnotations,
+				Line:          yyDollar[1].line,
+				XSDOptional:   yyDollar[9].bul,
+				XSDNillable:   yyDollar[10].bul,
+				XSDAttributes: yyDollar[11].fields,
 			}
 		}
-	case 27:
+	case 34:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line thrift.y:335
+		{
+			yyVAL.bul = false
+		}
+	case 35:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:280
+		//line thrift.y:336
+		{
+			yyVAL.bul = true
+		}
+	case 36:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line thrift.y:340
+		{
+			yyVAL.bul = false
+		}
+	case 37:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line thrift.y:341
+		{
+			yyVAL.bul = true
+		}
+	case 38:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		//line thrift.y:345
+		{
+			yyVAL.fields = nil
+		}
+	case 39:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		//line thrift.y:346
+		{
+			yyVAL.fields = yyDollar[3].fields
+		}
+	case 40:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line thrift.y:350
 		{
 			yyVAL.fieldRequired = ast.Required
 		}
-	case 28:
+	case 41:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:281
+		//line thrift.y:351
 		{
 			yyVAL.fieldRequired = ast.Optional
 		}
-	case 29:
+	case 42:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line thrift.y:282
+		//line thrift.y:352
 		{
 			yyVAL.fieldRequired = ast.Unspecified
 		}
-	case 30:
+	case 43:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line thrift.y:286
+		//line thrift.y:356
 		{
 			yyVAL.functions = nil
 		}
-	case 31:
+	case 44:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line thrift.y:287
+		//line thrift.y:357
 		{
 			yyVAL.functions = append(yyDollar[1].functions, yyDollar[2].function)
 		}
-	case 32:
+	case 45:
 		yyDollar = yyS[yypt-9 : yypt+1]
-		//line thrift.y:293
+		//line thrift.y:363
 		{
 			yyVAL.function = &ast.Function{
 				Name:        yyDollar[4].str,
@@ -927,231 +1061,237 @@ yydefault:
 				Line:        yyDollar[3].line,
 			}
 		}
-	case 33:
+	case 46:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:307
+		//line thrift.y:377
 		{
 			yyVAL.bul = true
 		}
-	case 34:
+	case 47:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line thrift.y:308
+		//line thrift.y:378
 		{
 			yyVAL.bul = false
 		}
-	case 35:
+	case 48:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:312
+		//line thrift.y:382
 		{
 			yyVAL.fieldType = nil
 		}
-	case 36:
+	case 49:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:313
+		//line thrift.y:383
 		{
 			yyVAL.fieldType = yyDollar[1].fieldType
 		}
-	case 37:
+	case 50:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line thrift.y:317
+		//line thrift.y:387
 		{
 			yyVAL.fields = nil
 		}
-	case 38:
+	case 51:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line thrift.y:318
+		//line thrift.y:388
 		{
 			yyVAL.fields = yyDollar[3].fields
 		}
-	case 39:
+	case 52:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line thrift.y:327
+		//line thrift.y:397
 		{
 			yyVAL.fieldType = ast.BaseType{ID: yyDollar[2].baseTypeID, Annotations: yyDollar[3].typeAnnotations, Line: yyDollar[1].line}
 		}
-	case 40:
+	case 53:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line thrift.y:331
+		//line thrift.y:401
 		{
 			yyVAL.fieldType = ast.MapType{KeyType: yyDollar[4].fieldType, ValueType: yyDollar[6].fieldType, Annotations: yyDollar[8].typeAnnotations, Line: yyDollar[1].line}
 		}
-	case 41:
+	case 54:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line thrift.y:333
+		//line thrift.y:403
 		{
 			yyVAL.fieldType = ast.ListType{ValueType: yyDollar[4].fieldType, Annotations: yyDollar[6].typeAnnotations, Line: yyDollar[1].line}
 		}
-	case 42:
+	case 55:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line thrift.y:335
+		//line thrift.y:405
 		{
 			yyVAL.fieldType = ast.SetType{ValueType: yyDollar[4].fieldType, Annotations: yyDollar[6].typeAnnotations, Line: yyDollar[1].line}
 		}
-	case 43:
+	case 56:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line thrift.y:337
-		{
-			yyVAL.fieldType = ast.TypeReference{Name: yyDollar[2].str, Line: yyDollar[1].line}
+		//line thrift.y:407
+		{
+			// uuid is not a keyword so that existing fields and definitions
+			// named uuid continue to parse.
+			if yyDollar[2].str == "uuid" {
+				yyVAL.fieldType = ast.BaseType{ID: ast.UUIDTypeID, Line: yyDollar[1].line}
+			} else {
+				yyVAL.fieldType = ast.TypeReference{Name: yyDollar[2].str, Line: yyDollar[1].line}
+			}
 		}
-	case 44:
+	case 57:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:341
+		//line thrift.y:419
 		{
 			yyVAL.baseTypeID = ast.BoolTypeID
 		}
-	case 45:
+	case 58:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:342
+		//line thrift.y:420
 		{
 			yyVAL.baseTypeID = ast.I8TypeID
 		}
-	case 46:
+	case 59:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:343
+		//line thrift.y:421
 		{
 			yyVAL.baseTypeID = ast.I8TypeID
 		}
-	case 47:
+	case 60:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:344
+		//line thrift.y:422
 		{
 			yyVAL.baseTypeID = ast.I16TypeID
 		}
-	case 48:
+	case 61:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:345
+		//line thrift.y:423
 		{
 			yyVAL.baseTypeID = ast.I32TypeID
 		}
-	case 49:
+	case 62:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:346
+		//line thrift.y:424
 		{
 			yyVAL.baseTypeID = ast.I64TypeID
 		}
-	case 50:
+	case 63:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:347
+		//line thrift.y:425
 		{
 			yyVAL.baseTypeID = ast.DoubleTypeID
 		}
-	case 51:
+	case 64:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:348
+		//line thrift.y:426
 		{
 			yyVAL.baseTypeID = ast.StringTypeID
 		}
-	case 52:
+	case 65:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:349
+		//line thrift.y:427
 		{
 			yyVAL.baseTypeID = ast.BinaryTypeID
 		}
-	case 53:
+	case 66:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:357
+		//line thrift.y:435
 		{
 			yyVAL.constantValue = ast.ConstantInteger(yyDollar[1].i64)
 		}
-	case 54:
+	case 67:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:358
+		//line thrift.y:436
 		{
 			yyVAL.constantValue = ast.ConstantDouble(yyDollar[1].dub)
 		}
-	case 55:
+	case 68:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:359
+		//line thrift.y:437
 		{
 			yyVAL.constantValue = ast.ConstantBoolean(true)
 		}
-	case 56:
+	case 69:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:360
+		//line thrift.y:438
 		{
 			yyVAL.constantValue = ast.ConstantBoolean(false)
 		}
-	case 57:
+	case 70:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line thrift.y:361
+		//line thrift.y:439
 		{
 			yyVAL.constantValue = ast.ConstantString(yyDollar[1].str)
 		}
-	case 58:
+	case 71:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line thrift.y:363
+		//line thrift.y:441
 		{
 			yyVAL.constantValue = ast.ConstantReference{Name: yyDollar[2].str, Line: yyDollar[1].line}
 		}
-	case 59:
+	case 72:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line thrift.y:365
+		//line thrift.y:443
 		{
 			yyVAL.constantValue = ast.ConstantList{Items: yyDollar[3].constantValues, Line: yyDollar[1].line}
 		}
-	case 60:
+	case 73:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line thrift.y:366
+		//line thrift.y:444
 		{
 			yyVAL.constantValue = ast.ConstantMap{Items: yyDollar[3].constantMapItems, Line: yyDollar[1].line}
 		}
-	case 61:
+	case 74:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line thrift.y:370
+		//line thrift.y:448
 		{
 			yyVAL.constantValues = nil
 		}
-	case 62:
+	case 75:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line thrift.y:372
+		//line thrift.y:450
 		{
 			yyVAL.constantValues = append(yyDollar[1].constantValues, yyDollar[2].constantValue)
 		}
-	case 63:
+	case 76:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line thrift.y:376
+		//line thrift.y:454
 		{
 			yyVAL.constantMapItems = nil
 		}
-	case 64:
+	case 77:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line thrift.y:378
+		//line thrift.y:456
 		{
 			yyVAL.constantMapItems = append(yyDollar[1].constantMapItems, ast.ConstantMapItem{Key: yyDollar[3].constantValue, Value: yyDollar[5].constantValue, Line: yyDollar[2].line})
 		}
-	case 65:
+	case 78:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line thrift.y:386
+		//line thrift.y:464
 		{
 			yyVAL.typeAnnotations = nil
 		}
-	case 66:
+	case 79:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line thrift.y:387
+		//line thrift.y:465
 		{
 			yyVAL.typeAnnotations = yyDollar[2].typeAnnotations
 		}
-	case 67:
+	case 80:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line thrift.y:391
+		//line thrift.y:469
 		{
 			yyVAL.typeAnnotations = nil
 		}
-	case 68:
+	case 81:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line thrift.y:393
+		//line thrift.y:471
 		{
 			yyVAL.typeAnnotations = append(yyDollar[1].typeAnnotations, &ast.Annotation{Name: yyDollar[3].str, Value: yyDollar[5].str, Line: yyDollar[2].line})
 		}
-	case 69:
+	case 82:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line thrift.y:395
+		//line thrift.y:473
 		{
 			yyVAL.typeAnnotations = append(yyDollar[1].typeAnnotations, &ast.Annotation{Name: yyDollar[3].str, Line: yyDollar[2].line})
 		}
-	case 70:
+	case 83:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line thrift.y:412
+		//line thrift.y:490
 		{
 			yyVAL.line = yylex.(*lexer).line
 		}
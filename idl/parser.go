@@ -21,10 +21,93 @@
 // Package idl provides a parser for Thrift IDL files.
 package idl
 
-import "go.uber.org/thriftrw/ast"
-import "go.uber.org/thriftrw/idl/internal"
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/idl/internal"
+)
 
 // Parse parses a Thrift document.
 func Parse(s []byte) (*ast.Program, error) {
-	return internal.Parse(s)
+	return internal.Parse(s, internal.Options{})
+}
+
+// Config configures the Thrift parser.
+type Config struct {
+	// Comments specifies whether comments should be retained in the
+	// Comments field of the parsed Program.
+	Comments bool
+
+	// Warn, if non-nil, is called for each use of deprecated syntax such as
+	// senums, language-specific namespace statements like php_namespace,
+	// and XSD attributes. These are always accepted by the parser and
+	// retained in the Program.
+	Warn func(Warning)
+
+	// Interner, if non-nil, is shared between all documents parsed with
+	// it so that identifiers and string literals which appear in many
+	// documents, such as type names and annotation keys, are stored only
+	// once. Programs that hold many parsed documents in memory at the same
+	// time should share one Interner between them.
+	//
+	// Strings are always interned within a single document.
+	Interner *Interner
+}
+
+// Interner deduplicates the strings of parsed Thrift documents.
+//
+// An Interner is safe for concurrent use. The zero value is ready to use.
+// Strings are retained for as long as the Interner is reachable.
+type Interner struct {
+	mu      sync.Mutex
+	strings map[string]string
+}
+
+func (i *Interner) intern(b []byte) string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if s, ok := i.strings[string(b)]; ok {
+		return s
+	}
+	if i.strings == nil {
+		i.strings = make(map[string]string)
+	}
+	s := string(b)
+	i.strings[s] = s
+	return s
+}
+
+// Len returns the number of distinct strings held by the Interner.
+func (i *Interner) Len() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return len(i.strings)
+}
+
+// Warning describes a problem with a Thrift document that did not prevent it
+// from being parsed.
+type Warning struct {
+	Line    int
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("line %d: %v", w.Line, w.Message)
+}
+
+// Parse parses a Thrift document with this configuration.
+func (c *Config) Parse(s []byte) (*ast.Program, error) {
+	opts := internal.Options{Comments: c.Comments}
+	if c.Interner != nil {
+		opts.Intern = c.Interner.intern
+	}
+	if c.Warn != nil {
+		opts.Warn = func(line int, msg string) {
+			c.Warn(Warning{Line: line, Message: msg})
+		}
+	}
+	return internal.Parse(s, opts)
 }
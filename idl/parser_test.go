@@ -21,13 +21,16 @@
 package idl
 
 import (
+	"reflect"
 	"strings"
 	"testing"
+	"unsafe"
 
 	. "go.uber.org/thriftrw/ast"
 
 	"github.com/kr/pretty"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type parseCase struct {
@@ -64,6 +67,118 @@ func TestParseComments(t *testing.T) {
 	assert.NoError(t, err, "Failed to parse:\n%s", s)
 }
 
+func TestParseRetainComments(t *testing.T) {
+	s := `// license
+		include "foo.thrift" # trailing
+
+		/* multi
+		   line */
+		struct Foo {
+			1: required string bar // bar
+			// "not a string"
+		} // end
+		const string quoted = "// not a comment"
+		/* eof */`
+
+	program, err := (&Config{Comments: true}).Parse([]byte(s))
+	require.NoError(t, err, "Failed to parse:\n%s", s)
+	assert.Equal(t, []*Comment{
+		{Text: "// license", Line: 1},
+		{Text: "# trailing", Line: 2},
+		{Text: "/* multi\n\t\t   line */", Line: 4},
+		{Text: "// bar", Line: 7},
+		{Text: `// "not a string"`, Line: 8},
+		{Text: "// end", Line: 9},
+		{Text: "/* eof */", Line: 11},
+	}, program.Comments)
+	assert.Len(t, program.Definitions, 2)
+
+	program, err = Parse([]byte(s))
+	require.NoError(t, err, "Failed to parse:\n%s", s)
+	assert.Nil(t, program.Comments, "comments must not be retained by default")
+}
+
+func TestParseDocComments(t *testing.T) {
+	s := `/** Status of a user. */
+		enum Status {
+			/** Allowed to log in. */
+			Enabled,
+			Disabled /** Not followed by anything. */
+		}
+
+		/**
+		 * A user.
+		 *
+		 * Users are identified by name.
+		 */
+		struct User {
+			/** Name of the user. */
+			1: required string name
+			// Plain comments separate doc comments from fields.
+			/** Ignored. */
+			// Plain comment.
+			2: optional Status status
+		}
+
+		// Plain comments followed by a blank line are not doc comments.
+
+		typedef i64 Timestamp
+
+		/** Users. */ service Users {
+			/** Gets a user. */
+			User getUser(
+				/** Name of the user. */
+				1: string name
+			)
+		}
+
+		/** Unrelated. */
+
+		/** Default timeout. */
+		const i32 timeout = 10
+		/**/
+		typedef string UUID
+
+		// Line comments document
+		//
+		// the entity that follows them.
+		exception NotFound {
+			1: optional string key // Trailing comments are not doc comments.
+			# Neither are hash comments.
+			2: optional string message
+		}
+	`
+
+	program, err := Parse([]byte(s))
+	require.NoError(t, err, "Failed to parse:\n%s", s)
+	require.Len(t, program.Definitions, 7)
+
+	enum := program.Definitions[0].(*Enum)
+	assert.Equal(t, "Status of a user.", enum.Doc)
+	assert.Equal(t, "Allowed to log in.", enum.Items[0].Doc)
+	assert.Empty(t, enum.Items[1].Doc)
+
+	user := program.Definitions[1].(*Struct)
+	assert.Equal(t, "A user.\n\nUsers are identified by name.", user.Doc)
+	assert.Equal(t, "Name of the user.", user.Fields[0].Doc)
+	assert.Equal(t, "Plain comment.", user.Fields[1].Doc)
+
+	assert.Empty(t, program.Definitions[2].(*Typedef).Doc)
+
+	svc := program.Definitions[3].(*Service)
+	assert.Equal(t, "Users.", svc.Doc)
+	assert.Equal(t, "Gets a user.", svc.Functions[0].Doc)
+	assert.Equal(t, "Name of the user.", svc.Functions[0].Parameters[0].Doc)
+
+	assert.Equal(t, "Default timeout.", program.Definitions[4].(*Constant).Doc)
+	assert.Empty(t, program.Definitions[5].(*Typedef).Doc)
+
+	notFound := program.Definitions[6].(*Struct)
+	assert.Equal(t, "Line comments document\n\nthe entity that follows them.", notFound.Doc)
+	assert.Empty(t, notFound.Fields[0].Doc)
+	assert.Empty(t, notFound.Fields[1].Doc)
+}
+
 func TestParseErrors(t *testing.T) {
 	tests := []struct {
 		give       string
@@ -124,6 +239,14 @@ func TestParseErrors(t *testing.T) {
 			give:       `union Operation { 1: Insert insert; 2: Delete delete }`,
 			wantErrors: []string{"line 1:", `"delete" is a reserved keyword`},
 		},
+		{
+			give:       `include "foo.thrift" as`,
+			wantErrors: []string{"line 1:", "unexpected $end"},
+		},
+		{
+			give:       `struct as {}`,
+			wantErrors: []string{"line 1:", "unexpected AS"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -142,10 +265,12 @@ func TestParseHeaders(t *testing.T) {
 			`
 				include "foo.thrift"
 				include t "bar.thrift"
+				include "./shared/baz.thrift" as sharedbaz
 			`,
 			&Program{Headers: []Header{
 				&Include{Path: "foo.thrift", Line: 2},
 				&Include{Path: "bar.thrift", Name: "t", Line: 3},
+				&Include{Path: "./shared/baz.thrift", Name: "sharedbaz", Line: 4},
 			}},
 		},
 		{
@@ -181,6 +306,31 @@ func TestParseHeaders(t *testing.T) {
 				},
 			},
 		},
+		{
+			`
+				namespace go foo (go.package = "bar")
+				namespace * baz (a = "b", c = "d")
+			`,
+			&Program{Headers: []Header{
+				&Namespace{
+					Scope: "go",
+					Name:  "foo",
+					Annotations: []*Annotation{
+						{Name: "go.package", Value: "bar", Line: 2},
+					},
+					Line: 2,
+				},
+				&Namespace{
+					Scope: "*",
+					Name:  "baz",
+					Annotations: []*Annotation{
+						{Name: "a", Value: "b", Line: 3},
+						{Name: "c", Value: "d", Line: 3},
+					},
+					Line: 3,
+				},
+			}},
+		},
 	}
 	assertParseCases(t, tests)
 }
@@ -232,6 +382,7 @@ func TestParseConstants(t *testing.T) {
 					Type:  BaseType{ID: DoubleTypeID, Line: 10},
 					Value: ConstantDouble(1.23),
 					Line:  10,
+					Doc:   "def is reserved but def_ is not",
 				},
 			}},
 		},
@@ -396,6 +547,8 @@ func TestParseTypedef(t *testing.T) {
 
 				typedef i8 foo
 				typedef byte bar
+
+				typedef uuid RequestID
 			`,
 			&Program{Definitions: []Definition{
 				&Typedef{
@@ -435,6 +588,46 @@ func TestParseTypedef(t *testing.T) {
 					Type: BaseType{ID: I8TypeID, Line: 7},
 					Line: 7,
 				},
+				&Typedef{
+					Name: "RequestID",
+					Type: BaseType{ID: UUIDTypeID, Line: 9},
+					Line: 9,
+				},
+			}},
+		},
+		{
+			// uuid is not reserved and may still be used as a name.
+			`
+				struct Request {
+					1: required uuid uuid
+					2: optional list<uuid> parents
+				}
+			`,
+			&Program{Definitions: []Definition{
+				&Struct{
+					Name: "Request",
+					Type: StructType,
+					Fields: []*Field{
+						{
+							ID:           1,
+							Name:         "uuid",
+							Requiredness: Required,
+							Type:         BaseType{ID: UUIDTypeID, Line: 3},
+							Line:         3,
+						},
+						{
+							ID:           2,
+							Name:         "parents",
+							Requiredness: Optional,
+							Type: ListType{
+								ValueType: BaseType{ID: UUIDTypeID, Line: 4},
+								Line:      4,
+							},
+							Line: 4,
+						},
+					},
+					Line: 2,
+				},
 			}},
 		},
 	}
@@ -717,3 +910,132 @@ func TestParseServices(t *testing.T) {
 
 	assertParseCases(t, tests)
 }
+
+func TestParseDeprecated(t *testing.T) {
+	tests := []parseCase{
+		{
+			`php_namespace Foo.Bar`,
+			&Program{Headers: []Header{
+				&Namespace{
+					Scope:   "php",
+					Name:    "Foo.Bar",
+					Keyword: "php_namespace",
+					Line:    1,
+				},
+			}},
+		},
+		{
+			`senum Status { "a", "b"; "c" } (foo = "bar")`,
+			&Program{Definitions: []Definition{
+				&Senum{
+					Name:   "Status",
+					Values: []string{"a", "b", "c"},
+					Annotations: []*Annotation{
+						{Name: "foo", Value: "bar", Line: 1},
+					},
+					Line: 1,
+				},
+			}},
+		},
+		{
+			`struct User xsd_all {
+				1: string name xsd_optional xsd_nillable
+				2: string id xsd_attrs { 1: string lang }
+			}`,
+			&Program{Definitions: []Definition{
+				&Struct{
+					Name:   "User",
+					Type:   StructType,
+					XSDAll: true,
+					Fields: []*Field{
+						{
+							ID:          1,
+							Name:        "name",
+							Type:        BaseType{ID: StringTypeID, Line: 2},
+							XSDOptional: true,
+							XSDNillable: true,
+							Line:        2,
+						},
+						{
+							ID:   2,
+							Name: "id",
+							Type: BaseType{ID: StringTypeID, Line: 3},
+							XSDAttributes: []*Field{
+								{
+									ID:   1,
+									Name: "lang",
+									Type: BaseType{ID: StringTypeID, Line: 3},
+									Line: 3,
+								},
+							},
+							Line: 3,
+						},
+					},
+					Line: 1,
+				},
+			}},
+		},
+	}
+
+	assertParseCases(t, tests)
+}
+
+func TestParseDeprecatedWarnings(t *testing.T) {
+	src := `py_module foo
+		senum Status { "a" }
+		struct User xsd_all {
+			1: string name xsd_nillable
+		}`
+
+	var warnings []Warning
+	_, err := (&Config{Warn: func(w Warning) {
+		warnings = append(warnings, w)
+	}}).Parse([]byte(src))
+	require.NoError(t, err)
+
+	var got []string
+	for _, w := range warnings {
+		got = append(got, w.String())
+	}
+	assert.Equal(t, []string{
+		`line 1: "py_module" is deprecated: use "namespace py" instead`,
+		`line 2: senum is deprecated: it is treated as a typedef of string`,
+		`line 3: "xsd_all" is deprecated and has no effect`,
+		`line 4: "xsd_nillable" is deprecated and has no effect`,
+	}, got)
+}
+
+func TestParseInterner(t *testing.T) {
+	var interner Interner
+	cfg := Config{Interner: &interner}
+
+	a, err := cfg.Parse([]byte(`
+		typedef string UUID (format = "uuid")
+		struct User { 1: required UUID id }
+	`))
+	require.NoError(t, err)
+	b, err := cfg.Parse([]byte(`
+		typedef i64 Timestamp (format = "uuid")
+		struct Event { 1: optional UUID id }
+	`))
+	require.NoError(t, err)
+
+	// UUID, format, uuid, and id are shared between the two documents.
+	assert.Equal(t, 7, interner.Len())
+
+	aUUID := a.Definitions[0].(*Typedef).Name
+	bUUID := b.Definitions[1].(*Struct).Fields[0].Type.(TypeReference).Name
+	assert.Equal(t, "UUID", bUUID)
+	assert.True(t, sameString(aUUID, bUUID), "identifiers must share storage")
+
+	aFormat := a.Definitions[0].(*Typedef).Annotations[0].Value
+	bFormat := b.Definitions[0].(*Typedef).Annotations[0].Value
+	assert.True(t, sameString(aFormat, bFormat), "literals must share storage")
+}
+
+// sameString reports whether the two strings share the same storage.
+func sameString(a, b string) bool {
+	ah := (*reflect.StringHeader)(unsafe.Pointer(&a))
+	bh := (*reflect.StringHeader)(unsafe.Pointer(&b))
+	return a == b && ah.Data == bh.Data
+}
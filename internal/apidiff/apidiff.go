@@ -0,0 +1,340 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package apidiff lists changes to the Go API of generated packages.
+//
+// The API of a tree of Go packages is recorded as a mapping from exported
+// identifiers to their signatures. Two such mappings, produced from code
+// generated by different versions of ThriftRW or recorded in a manifest
+// earlier, may then be compared to find out how the generated code changed.
+package apidiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// API maps the exported identifiers of a tree of Go packages to their
+// signatures.
+//
+// Identifiers are qualified with the path of the package relative to the
+// root of the tree. Fields and methods are further qualified with the name
+// of their type.
+//
+// 	"foo/bar.User"            => "struct"
+// 	"foo/bar.User.Name"       => "*string"
+// 	"foo/bar.User.GetName"    => "(*User) func() string"
+// 	"foo/bar.NewUser"         => "func(string) *User"
+type API map[string]string
+
+// Manifest is a recorded API along with the version of ThriftRW that
+// generated the code.
+type Manifest struct {
+	Version string `json:"version"`
+	API     API    `json:"api"`
+}
+
+// ReadManifest reads a Manifest written by WriteManifest.
+func ReadManifest(r io.Reader) (*Manifest, error) {
+	var m Manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// WriteManifest writes the given Manifest to the given writer.
+//
+// Identifiers are written in sorted order so that manifests may be checked
+// into version control and reviewed.
+func WriteManifest(w io.Writer, m *Manifest) error {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// Load parses the Go files in the tree rooted at the given directory and
+// returns their API. Test files are ignored.
+func Load(root string) (API, error) {
+	api := make(API)
+	fset := token.NewFileSet()
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".go" || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		pkg, err := filepath.Rel(root, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return fmt.Errorf("could not parse %q: %v", path, err)
+		}
+		collector{api: api, pkg: filepath.ToSlash(pkg)}.File(f)
+		return nil
+	})
+	return api, err
+}
+
+type collector struct {
+	api API
+	pkg string
+}
+
+func (c collector) add(name, sig string) {
+	c.api[c.pkg+"."+name] = sig
+}
+
+func (c collector) File(f *ast.File) {
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			c.funcDecl(d)
+		case *ast.GenDecl:
+			c.genDecl(d)
+		}
+	}
+}
+
+func (c collector) funcDecl(d *ast.FuncDecl) {
+	if !d.Name.IsExported() {
+		return
+	}
+
+	if d.Recv == nil {
+		c.add(d.Name.Name, funcString(d.Type))
+		return
+	}
+
+	recv := d.Recv.List[0].Type
+	typeName := recv
+	if star, ok := recv.(*ast.StarExpr); ok {
+		typeName = star.X
+	}
+	ident, ok := typeName.(*ast.Ident)
+	if !ok || !ident.IsExported() {
+		return
+	}
+	c.add(ident.Name+"."+d.Name.Name,
+		"("+types.ExprString(recv)+") "+funcString(d.Type))
+}
+
+func (c collector) genDecl(d *ast.GenDecl) {
+	for _, spec := range d.Specs {
+		switch s := spec.(type) {
+		case *ast.TypeSpec:
+			c.typeSpec(s)
+		case *ast.ValueSpec:
+			kind := "var"
+			if d.Tok == token.CONST {
+				kind = "const"
+			}
+			sig := kind
+			if s.Type != nil {
+				sig += " " + types.ExprString(s.Type)
+			}
+			for _, name := range s.Names {
+				if name.IsExported() {
+					c.add(name.Name, sig)
+				}
+			}
+		}
+	}
+}
+
+func (c collector) typeSpec(s *ast.TypeSpec) {
+	if !s.Name.IsExported() {
+		return
+	}
+
+	name := s.Name.Name
+	switch t := s.Type.(type) {
+	case *ast.StructType:
+		c.add(name, "struct")
+		for _, f := range t.Fields.List {
+			typ := types.ExprString(f.Type)
+			if len(f.Names) == 0 {
+				// Embedded fields are named after their type.
+				embedded := strings.TrimPrefix(typ, "*")
+				if i := strings.LastIndex(embedded, "."); i >= 0 {
+					embedded = embedded[i+1:]
+				}
+				if ast.IsExported(embedded) {
+					c.add(name+"."+embedded, typ)
+				}
+				continue
+			}
+			for _, n := range f.Names {
+				if n.IsExported() {
+					c.add(name+"."+n.Name, typ)
+				}
+			}
+		}
+	case *ast.InterfaceType:
+		c.add(name, "interface")
+		for _, m := range t.Methods.List {
+			for _, n := range m.Names {
+				if n.IsExported() {
+					c.add(name+"."+n.Name, funcString(m.Type.(*ast.FuncType)))
+				}
+			}
+			if len(m.Names) == 0 {
+				c.add(name+"."+types.ExprString(m.Type), "embedded interface")
+			}
+		}
+	default:
+		c.add(name, types.ExprString(s.Type))
+	}
+}
+
+// funcString returns the signature of the given function type without the
+// names of its parameters and results.
+func funcString(t *ast.FuncType) string {
+	s := "func(" + fieldTypes(t.Params) + ")"
+	if t.Results == nil || len(t.Results.List) == 0 {
+		return s
+	}
+
+	results := fieldTypes(t.Results)
+	if len(t.Results.List) == 1 && len(t.Results.List[0].Names) <= 1 {
+		return s + " " + results
+	}
+	return s + " (" + results + ")"
+}
+
+func fieldTypes(fields *ast.FieldList) string {
+	if fields == nil {
+		return ""
+	}
+
+	var typs []string
+	for _, f := range fields.List {
+		typ := types.ExprString(f.Type)
+		n := len(f.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			typs = append(typs, typ)
+		}
+	}
+	return strings.Join(typs, ", ")
+}
+
+// ChangeKind specifies how an identifier changed.
+type ChangeKind int
+
+// Kinds of changes.
+const (
+	Removed ChangeKind = iota + 1
+	Changed
+	Added
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	case Added:
+		return "added"
+	default:
+		return fmt.Sprintf("ChangeKind(%d)", int(k))
+	}
+}
+
+// Change is a change to an identifier between two versions of an API.
+type Change struct {
+	Kind ChangeKind
+	Name string
+
+	// Signatures of the identifier in the old and new API. Old is empty if
+	// the identifier was added, and New if it was removed.
+	Old, New string
+}
+
+// Breaking returns true if the change may break code using the old API.
+func (c Change) Breaking() bool {
+	return c.Kind != Added
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case Removed:
+		return fmt.Sprintf("removed %v: %v", c.Name, c.Old)
+	case Added:
+		return fmt.Sprintf("added %v: %v", c.Name, c.New)
+	default:
+		return fmt.Sprintf("changed %v: %v => %v", c.Name, c.Old, c.New)
+	}
+}
+
+// Compare returns the changes between the given versions of an API, sorted
+// by kind and then by identifier.
+func Compare(old, new API) []Change {
+	var changes []Change
+	for name, oldSig := range old {
+		newSig, ok := new[name]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Kind: Removed, Name: name, Old: oldSig})
+		case oldSig != newSig:
+			changes = append(changes, Change{Kind: Changed, Name: name, Old: oldSig, New: newSig})
+		}
+	}
+	for name, newSig := range new {
+		if _, ok := old[name]; !ok {
+			changes = append(changes, Change{Kind: Added, Name: name, New: newSig})
+		}
+	}
+
+	sort.Sort(byKindAndName(changes))
+	return changes
+}
+
+type byKindAndName []Change
+
+func (cs byKindAndName) Len() int      { return len(cs) }
+func (cs byKindAndName) Swap(i, j int) { cs[i], cs[j] = cs[j], cs[i] }
+
+func (cs byKindAndName) Less(i, j int) bool {
+	if cs[i].Kind != cs[j].Kind {
+		return cs[i].Kind < cs[j].Kind
+	}
+	return cs[i].Name < cs[j].Name
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package apidiff
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-apidiff-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for path, contents := range map[string]string{
+		"foo/types.go": `package foo
+
+			import "go.uber.org/thriftrw/wire"
+
+			type Status int32
+
+			const (
+				StatusEnabled Status = 0
+				statusUnknown Status = 1
+			)
+
+			var Default = &User{}
+
+			type User struct {
+				Name  *string
+				Tags  []string
+				email string
+				*Base
+			}
+
+			type Base struct{}
+
+			func NewUser(name, email string) *User { return nil }
+
+			func (v *User) ToWire() (wire.Value, error) { return wire.Value{}, nil }
+
+			func (v User) String() (s string) { return "" }
+
+			func (v *User) hidden() {}
+
+			type hidden struct{ Visible string }
+
+			func (hidden) Exported() {}
+
+			type Service interface {
+				Get(key string, opts ...int) (*User, error)
+				private()
+			}
+		`,
+		"foo/bar/bar.go": `package bar
+
+			type Key string
+		`,
+		"foo/foo_test.go": `package foo
+
+			func TestIgnored() {}
+		`,
+	} {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+
+	api, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, API{
+		"foo.Status":        "int32",
+		"foo.StatusEnabled": "const Status",
+		"foo.Default":       "var",
+		"foo.User":          "struct",
+		"foo.User.Name":     "*string",
+		"foo.User.Tags":     "[]string",
+		"foo.User.Base":     "*Base",
+		"foo.Base":          "struct",
+		"foo.NewUser":       "func(string, string) *User",
+		"foo.User.ToWire":   "(*User) func() (wire.Value, error)",
+		"foo.User.String":   "(User) func() string",
+		"foo.Service":       "interface",
+		"foo.Service.Get":   "func(string, ...int) (*User, error)",
+		"foo/bar.Key":       "string",
+	}, api)
+}
+
+func TestLoadError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-apidiff-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "bad.go"), []byte("package"), 0644))
+
+	_, err = Load(dir)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "could not parse")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	old := API{
+		"foo.User":         "struct",
+		"foo.User.Name":    "*string",
+		"foo.User.Email":   "*string",
+		"foo.User.GetName": "(*User) func() string",
+	}
+	new := API{
+		"foo.User":         "struct",
+		"foo.User.Name":    "string",
+		"foo.User.GetName": "(*User) func() string",
+		"foo.User.IsSet":   "(*User) func() bool",
+	}
+
+	changes := Compare(old, new)
+	assert.Equal(t, []Change{
+		{Kind: Removed, Name: "foo.User.Email", Old: "*string"},
+		{Kind: Changed, Name: "foo.User.Name", Old: "*string", New: "string"},
+		{Kind: Added, Name: "foo.User.IsSet", New: "(*User) func() bool"},
+	}, changes)
+
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.String())
+	}
+	assert.Equal(t, []string{
+		"removed foo.User.Email: *string",
+		"changed foo.User.Name: *string => string",
+		"added foo.User.IsSet: (*User) func() bool",
+	}, got)
+
+	assert.True(t, changes[0].Breaking())
+	assert.True(t, changes[1].Breaking())
+	assert.False(t, changes[2].Breaking())
+
+	assert.Empty(t, Compare(old, old))
+}
+
+func TestManifestRoundTrip(t *testing.T) {
+	give := &Manifest{
+		Version: "1.2.3",
+		API: API{
+			"foo.User":      "struct",
+			"foo.User.Name": "*string",
+		},
+	}
+
+	var buff bytes.Buffer
+	require.NoError(t, WriteManifest(&buff, give))
+
+	got, err := ReadManifest(&buff)
+	require.NoError(t, err)
+	assert.Equal(t, give, got)
+
+	_, err = ReadManifest(bytes.NewReader([]byte("{")))
+	assert.Error(t, err)
+}
@@ -0,0 +1,385 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package compat detects changes between two versions of a Thrift file that
+// break wire compatibility.
+package compat
+
+import (
+	"fmt"
+	"sort"
+
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/compile"
+)
+
+// Break is a change which breaks wire compatibility between peers using the
+// old and new versions of a Thrift file.
+type Break struct {
+	// Definition that changed, for example "struct Foo" or "service Bar".
+	// Definitions from included files are qualified with the name of that
+	// file.
+	Definition string
+
+	// Description of the change.
+	Reason string
+}
+
+func (b Break) String() string {
+	return b.Definition + ": " + b.Reason
+}
+
+// Check compares two versions of a Thrift module and the modules included by
+// them and returns the changes which break wire compatibility.
+//
+// The following changes are reported:
+//
+// 	- fields, arguments, and exceptions which were removed or changed IDs
+// 	- fields and arguments which changed types or requiredness
+// 	- required fields and arguments which were added
+// 	- enum items which were removed or changed values
+// 	- types which changed kinds, for example from struct to union
+// 	- services and functions which were removed, changed parents, return
+// 	  types, or oneway-ness
+//
+// Types are compared by their representation on the wire so, for example,
+// switching between a typedef and its target or between string and binary
+// is not a breaking change. Types and constants which were removed are not
+// reported since peers never see them on the wire.
+func Check(old, new *compile.Module) []Break {
+	c := checker{visited: make(map[[2]string]struct{})}
+	c.module("", old, new)
+	return c.breaks
+}
+
+type checker struct {
+	breaks []Break
+
+	// Pairs of Thrift files that were already compared.
+	visited map[[2]string]struct{}
+}
+
+func (c *checker) add(definition, format string, args ...interface{}) {
+	c.breaks = append(c.breaks, Break{
+		Definition: definition,
+		Reason:     fmt.Sprintf(format, args...),
+	})
+}
+
+// module compares two versions of a module. prefix is prepended to the names
+// of definitions in it.
+func (c *checker) module(prefix string, old, new *compile.Module) {
+	key := [2]string{old.ThriftPath, new.ThriftPath}
+	if _, ok := c.visited[key]; ok {
+		return
+	}
+	c.visited[key] = struct{}{}
+
+	for _, name := range sortedTypeNames(old.Types) {
+		newSpec, ok := new.Types[name]
+		if !ok {
+			continue
+		}
+		c.typeSpec(prefix+name, old.Types[name], newSpec)
+	}
+
+	for _, name := range sortedServiceNames(old.Services) {
+		definition := "service " + prefix + name
+		newService, ok := new.Services[name]
+		if !ok {
+			c.add(definition, "service was removed")
+			continue
+		}
+		c.service(definition, old.Services[name], newService)
+	}
+
+	for _, name := range sortedIncludeNames(old.Includes) {
+		newInclude, ok := new.Includes[name]
+		if !ok {
+			continue
+		}
+		c.module(name+".", old.Includes[name].Module, newInclude.Module)
+	}
+}
+
+func (c *checker) typeSpec(name string, old, new compile.TypeSpec) {
+	oldKind, newKind := kindOf(old), kindOf(new)
+	definition := oldKind + " " + name
+	if oldKind != newKind {
+		c.add(definition, "changed from %v to %v", oldKind, newKind)
+		return
+	}
+
+	switch old := old.(type) {
+	case *compile.EnumSpec:
+		c.enum(definition, old, new.(*compile.EnumSpec))
+	case *compile.StructSpec:
+		c.fields(definition, "", "field", old.Fields, new.(*compile.StructSpec).Fields)
+	case *compile.TypedefSpec:
+		oldTarget, newTarget := old.Target, new.(*compile.TypedefSpec).Target
+		if wireName(oldTarget) != wireName(newTarget) {
+			c.add(definition, "changed from %v to %v", oldTarget.ThriftName(), newTarget.ThriftName())
+		}
+	}
+}
+
+func (c *checker) enum(definition string, old, new *compile.EnumSpec) {
+	newItems := make(map[string]compile.EnumItem, len(new.Items))
+	for _, item := range new.Items {
+		newItems[item.Name] = item
+	}
+
+	for _, item := range old.Items {
+		newItem, ok := newItems[item.Name]
+		switch {
+		case !ok:
+			c.add(definition, "item %v (%d) was removed", item.Name, item.Value)
+		case newItem.Value != item.Value:
+			c.add(definition, "item %v changed value from %d to %d",
+				item.Name, item.Value, newItem.Value)
+		}
+	}
+}
+
+// fields compares two versions of a group of fields. Reasons are prefixed
+// with the given prefix and refer to fields using the given noun, e.g.
+// "argument".
+func (c *checker) fields(definition, prefix, noun string, old, new compile.FieldGroup) {
+	newByID := make(map[int16]*compile.FieldSpec, len(new))
+	newByName := make(map[string]*compile.FieldSpec, len(new))
+	for _, f := range new {
+		newByID[f.ID] = f
+		newByName[f.Name] = f
+	}
+
+	oldIDs := make(map[int16]struct{}, len(old))
+	renumbered := make(map[int16]struct{})
+	for _, f := range sortedFields(old) {
+		oldIDs[f.ID] = struct{}{}
+
+		nf, ok := newByID[f.ID]
+		if !ok {
+			if nf, ok := newByName[f.Name]; ok {
+				c.add(definition, "%v%v %v changed ID from %d to %d",
+					prefix, noun, f.Name, f.ID, nf.ID)
+				renumbered[nf.ID] = struct{}{}
+			} else {
+				c.add(definition, "%v%v %d (%v) was removed", prefix, noun, f.ID, f.Name)
+			}
+			continue
+		}
+
+		if wireName(f.Type) != wireName(nf.Type) {
+			c.add(definition, "%v%v %d (%v) changed type from %v to %v",
+				prefix, noun, f.ID, f.Name, f.Type.ThriftName(), nf.Type.ThriftName())
+		}
+		if f.Required != nf.Required {
+			c.add(definition, "%v%v %d (%v) changed from %v to %v",
+				prefix, noun, f.ID, f.Name, requiredness(f), requiredness(nf))
+		}
+	}
+
+	for _, f := range sortedFields(new) {
+		if _, ok := oldIDs[f.ID]; ok {
+			continue
+		}
+		if _, ok := renumbered[f.ID]; ok {
+			continue
+		}
+		if f.Required {
+			c.add(definition, "%vrequired %v %d (%v) was added", prefix, noun, f.ID, f.Name)
+		}
+	}
+}
+
+func (c *checker) service(definition string, old, new *compile.ServiceSpec) {
+	if oldParent, newParent := parentName(old), parentName(new); oldParent != newParent {
+		c.add(definition, "changed parent from %v to %v", oldParent, newParent)
+	}
+
+	for _, name := range sortedFunctionNames(old.Functions) {
+		f := old.Functions[name]
+		nf, ok := new.Functions[name]
+		if !ok {
+			c.add(definition, "function %v was removed", name)
+			continue
+		}
+
+		prefix := "function " + name + ": "
+		if f.OneWay != nf.OneWay {
+			c.add(definition, "%vchanged from %v to %v", prefix, callKind(f), callKind(nf))
+			continue
+		}
+
+		c.fields(definition, prefix, "argument",
+			compile.FieldGroup(f.ArgsSpec), compile.FieldGroup(nf.ArgsSpec))
+
+		if f.ResultSpec == nil || nf.ResultSpec == nil {
+			continue
+		}
+
+		oldReturn, newReturn := f.ResultSpec.ReturnType, nf.ResultSpec.ReturnType
+		if returnName(oldReturn, wireName) != returnName(newReturn, wireName) {
+			c.add(definition, "%vchanged return type from %v to %v", prefix,
+				returnName(oldReturn, compile.TypeSpec.ThriftName),
+				returnName(newReturn, compile.TypeSpec.ThriftName))
+		}
+
+		c.exceptions(definition, prefix, f.ResultSpec.Exceptions, nf.ResultSpec.Exceptions)
+	}
+}
+
+// exceptions compares two versions of the exceptions raised by a function.
+//
+// Exceptions behave like optional fields of the result except that clients
+// can't decode results for exceptions they don't know about.
+func (c *checker) exceptions(definition, prefix string, old, new compile.FieldGroup) {
+	c.fields(definition, prefix, "exception", old, new)
+
+	oldIDs := make(map[int16]struct{}, len(old))
+	oldNames := make(map[string]struct{}, len(old))
+	for _, f := range old {
+		oldIDs[f.ID] = struct{}{}
+		oldNames[f.Name] = struct{}{}
+	}
+	for _, f := range sortedFields(new) {
+		_, knownID := oldIDs[f.ID]
+		_, knownName := oldNames[f.Name]
+		if !knownID && !knownName {
+			c.add(definition, "%vexception %d (%v) was added", prefix, f.ID, f.Name)
+		}
+	}
+}
+
+// wireName describes how values of the given type are represented on the
+// wire. Types with the same wire name are compatible.
+func wireName(spec compile.TypeSpec) string {
+	switch s := compile.RootTypeSpec(spec).(type) {
+	case *compile.MapSpec:
+		return fmt.Sprintf("map<%v, %v>", wireName(s.KeySpec), wireName(s.ValueSpec))
+	case *compile.ListSpec:
+		return fmt.Sprintf("list<%v>", wireName(s.ValueSpec))
+	case *compile.SetSpec:
+		return fmt.Sprintf("set<%v>", wireName(s.ValueSpec))
+	case *compile.EnumSpec:
+		return "i32"
+	case *compile.StringSpec:
+		return "binary"
+	default:
+		return s.ThriftName()
+	}
+}
+
+func returnName(spec compile.TypeSpec, name func(compile.TypeSpec) string) string {
+	if spec == nil {
+		return "void"
+	}
+	return name(spec)
+}
+
+func kindOf(spec compile.TypeSpec) string {
+	switch s := spec.(type) {
+	case *compile.EnumSpec:
+		return "enum"
+	case *compile.TypedefSpec:
+		return "typedef"
+	case *compile.StructSpec:
+		switch {
+		case s.IsExceptionType():
+			return "exception"
+		case s.Type == ast.UnionType:
+			return "union"
+		default:
+			return "struct"
+		}
+	default:
+		return "type"
+	}
+}
+
+func requiredness(f *compile.FieldSpec) string {
+	if f.Required {
+		return "required"
+	}
+	return "optional"
+}
+
+func callKind(f *compile.FunctionSpec) string {
+	if f.OneWay {
+		return "oneway"
+	}
+	return "request-response"
+}
+
+func parentName(s *compile.ServiceSpec) string {
+	if s.Parent == nil {
+		return "none"
+	}
+	return s.Parent.Name
+}
+
+func sortedTypeNames(m map[string]compile.TypeSpec) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedServiceNames(m map[string]*compile.ServiceSpec) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedFunctionNames(m map[string]*compile.FunctionSpec) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedIncludeNames(m map[string]*compile.IncludedModule) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedFields(fields compile.FieldGroup) compile.FieldGroup {
+	sorted := make(compile.FieldGroup, len(fields))
+	copy(sorted, fields)
+	sort.Sort(byID(sorted))
+	return sorted
+}
+
+type byID compile.FieldGroup
+
+func (fs byID) Len() int           { return len(fs) }
+func (fs byID) Less(i, j int) bool { return fs[i].ID < fs[j].ID }
+func (fs byID) Swap(i, j int)      { fs[i], fs[j] = fs[j], fs[i] }
@@ -0,0 +1,222 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compat
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// compileFiles writes the given files to a temporary directory and compiles
+// the one named main.thrift.
+func compileFiles(t *testing.T, files map[string]string) *compile.Module {
+	dir, err := ioutil.TempDir("", "thriftrw-compat-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+
+	m, err := compile.Compile(filepath.Join(dir, "main.thrift"))
+	require.NoError(t, err)
+	return m
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		desc string
+		old  map[string]string
+		new  map[string]string
+		want []string
+	}{
+		{
+			desc: "no changes",
+			old: map[string]string{"main.thrift": `
+				struct Foo { 1: required string bar }
+			`},
+			new: map[string]string{"main.thrift": `
+				typedef string Bar
+
+				struct Foo {
+					1: required Bar bar
+					2: optional i32 baz
+				}
+			`},
+		},
+		{
+			desc: "struct fields",
+			old: map[string]string{"main.thrift": `
+				struct Foo {
+					1: required string a
+					2: optional i32 b
+					3: optional i32 c
+					4: optional i64 d
+					5: optional list<string> e
+				}
+			`},
+			new: map[string]string{"main.thrift": `
+				struct Foo {
+					1: optional string a
+					2: optional i64 b
+					6: optional i32 c
+					5: optional list<i32> e
+					7: required string f
+					8: optional string g
+				}
+			`},
+			want: []string{
+				"struct Foo: field 1 (a) changed from required to optional",
+				"struct Foo: field 2 (b) changed type from i32 to i64",
+				"struct Foo: field c changed ID from 3 to 6",
+				"struct Foo: field 4 (d) was removed",
+				"struct Foo: field 5 (e) changed type from list<string> to list<i32>",
+				"struct Foo: required field 7 (f) was added",
+			},
+		},
+		{
+			desc: "enums and kinds",
+			old: map[string]string{"main.thrift": `
+				enum Role { User, Admin, Moderator }
+				struct Foo {}
+				union Bar {}
+				typedef i32 Baz
+			`},
+			new: map[string]string{"main.thrift": `
+				enum Role { User, Moderator, Owner }
+				union Foo {}
+				union Bar {}
+				typedef i64 Baz
+			`},
+			want: []string{
+				"typedef Baz: changed from i32 to i64",
+				"struct Foo: changed from struct to union",
+				"enum Role: item Admin (1) was removed",
+				"enum Role: item Moderator changed value from 2 to 1",
+			},
+		},
+		{
+			desc: "services",
+			old: map[string]string{"main.thrift": `
+				exception NotFound {}
+				exception Internal {}
+
+				service Base {}
+				service Foo extends Base {
+					void a(1: string x)
+					i32 b(1: string x, 2: optional string y)
+					void c() throws (1: NotFound notFound, 2: Internal internal)
+					oneway void d()
+					void e()
+				}
+				service Bar {}
+			`},
+			new: map[string]string{"main.thrift": `
+				exception NotFound {}
+				exception Internal {}
+
+				service Foo {
+					i32 a(1: string x, 2: required string z)
+					i64 b(2: optional string y)
+					void c() throws (1: NotFound notFound, 3: Internal other)
+					void d()
+				}
+			`},
+			want: []string{
+				"service Bar: service was removed",
+				"service Base: service was removed",
+				"service Foo: changed parent from Base to none",
+				"service Foo: function a: required argument 2 (z) was added",
+				"service Foo: function a: changed return type from void to i32",
+				"service Foo: function b: argument 1 (x) was removed",
+				"service Foo: function b: changed return type from i32 to i64",
+				"service Foo: function c: exception 2 (internal) was removed",
+				"service Foo: function c: exception 3 (other) was added",
+				"service Foo: function d: changed from oneway to request-response",
+				"service Foo: function e was removed",
+			},
+		},
+		{
+			desc: "includes",
+			old: map[string]string{
+				"main.thrift": `
+					include "./shared/common.thrift"
+					struct Foo { 1: optional common.Bar bar }
+				`,
+				"shared/common.thrift": `
+					struct Bar { 1: optional string baz }
+				`,
+			},
+			new: map[string]string{
+				"main.thrift": `
+					include "./shared/common.thrift"
+					struct Foo { 1: optional common.Bar bar }
+				`,
+				"shared/common.thrift": `
+					struct Bar { 1: optional binary baz, 2: optional i32 qux }
+				`,
+			},
+		},
+		{
+			desc: "include changes",
+			old: map[string]string{
+				"main.thrift": `
+					include "./common.thrift"
+					struct Foo { 1: optional common.Bar bar }
+				`,
+				"common.thrift": `
+					struct Bar { 1: optional string baz }
+				`,
+			},
+			new: map[string]string{
+				"main.thrift": `
+					include "./common.thrift"
+					struct Foo { 1: optional common.Bar bar }
+				`,
+				"common.thrift": `
+					struct Bar { 1: optional i32 baz }
+				`,
+			},
+			want: []string{
+				"struct common.Bar: field 1 (baz) changed type from string to i32",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		breaks := Check(compileFiles(t, tt.old), compileFiles(t, tt.new))
+
+		var got []string
+		for _, b := range breaks {
+			got = append(got, b.String())
+		}
+		assert.Equal(t, tt.want, got, tt.desc)
+	}
+}
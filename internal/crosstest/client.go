@@ -0,0 +1,410 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package crosstest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/thriftrw/envelope"
+	"go.uber.org/thriftrw/internal/crosstest/thrifttest"
+	"go.uber.org/thriftrw/internal/envelope/exception"
+	"go.uber.org/thriftrw/internal/frame"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/ptr"
+)
+
+// Client calls the ThriftTest service of a cross-test server.
+type Client struct {
+	r *frame.Reader
+	w *frame.Writer
+
+	seqID int32
+}
+
+// NewClient builds a Client which sends requests over the given connection.
+func NewClient(conn io.ReadWriter) *Client {
+	return &Client{r: frame.NewReader(conn), w: frame.NewWriter(conn)}
+}
+
+// call sends the given request and decodes its response into res. res must
+// be nil for oneway requests.
+func (c *Client) call(req envelope.Enveloper, res envelope.Unenveloper) error {
+	c.seqID++
+
+	var buff bytes.Buffer
+	if err := envelope.Write(protocol.Binary, &buff, c.seqID, req); err != nil {
+		return err
+	}
+	if err := c.w.Write(buff.Bytes()); err != nil {
+		return err
+	}
+	if res == nil {
+		return nil
+	}
+
+	body, err := c.r.Read()
+	if err != nil {
+		return err
+	}
+	env, err := envelope.Decode(protocol.Binary, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if env.Name != req.MethodName() {
+		return fmt.Errorf("expected a response to %q, got %q", req.MethodName(), env.Name)
+	}
+	if env.SeqID != c.seqID {
+		return fmt.Errorf("expected sequence ID %d, got %d", c.seqID, env.SeqID)
+	}
+	return env.Unwrap(res)
+}
+
+// Run calls every function of the ThriftTest service with the given Client
+// and reports the outcome of each call to w.
+//
+// An error is returned if any of the calls failed.
+func Run(w io.Writer, c *Client) (err error) {
+	for _, tt := range _tests {
+		if terr := tt.Run(c); terr != nil {
+			fmt.Fprintf(w, "FAIL %v: %v\n", tt.Name, terr)
+			err = multierr.Append(err, fmt.Errorf("%v failed: %v", tt.Name, terr))
+			continue
+		}
+		fmt.Fprintf(w, "ok   %v\n", tt.Name)
+	}
+	return err
+}
+
+type testCase struct {
+	Name string
+	Run  func(*Client) error
+}
+
+// mismatchError is returned when a server responds with an unexpected
+// value.
+type mismatchError struct {
+	Want, Got interface{}
+}
+
+func (e mismatchError) Error() string {
+	return fmt.Sprintf("expected %v, got %v", e.Want, e.Got)
+}
+
+func checkEqual(want, got interface{}, err error) error {
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(want, got) {
+		return mismatchError{Want: want, Got: got}
+	}
+	return nil
+}
+
+var _xtruct = &thrifttest.Xtruct{
+	StringThing: ptr.String("Zero"),
+	ByteThing:   ptr.Int8(1),
+	I32Thing:    ptr.Int32(-3),
+	I64Thing:    ptr.Int64(-5),
+}
+
+var _insanity = &thrifttest.Insanity{
+	UserMap: map[thrifttest.Numberz]thrifttest.UserId{
+		thrifttest.NumberzFive:  5,
+		thrifttest.NumberzEight: 8,
+	},
+	Xtructs: []*thrifttest.Xtruct{
+		{
+			StringThing: ptr.String("Goodbye4"),
+			ByteThing:   ptr.Int8(4),
+			I32Thing:    ptr.Int32(4),
+			I64Thing:    ptr.Int64(4),
+		},
+		{
+			StringThing: ptr.String("Hello2"),
+			ByteThing:   ptr.Int8(2),
+			I32Thing:    ptr.Int32(2),
+			I64Thing:    ptr.Int64(2),
+		},
+	},
+}
+
+var _tests = []testCase{
+	{"testVoid", func(c *Client) error {
+		var res thrifttest.ThriftTest_TestVoid_Result
+		if err := c.call(thrifttest.ThriftTest_TestVoid_Helper.Args(), &res); err != nil {
+			return err
+		}
+		return thrifttest.ThriftTest_TestVoid_Helper.UnwrapResponse(&res)
+	}},
+	{"testString", func(c *Client) error {
+		want := "Test"
+		var res thrifttest.ThriftTest_TestString_Result
+		if err := c.call(thrifttest.ThriftTest_TestString_Helper.Args(&want), &res); err != nil {
+			return err
+		}
+		got, err := thrifttest.ThriftTest_TestString_Helper.UnwrapResponse(&res)
+		return checkEqual(want, got, err)
+	}},
+	{"testBool", func(c *Client) error {
+		for _, want := range []bool{true, false} {
+			var res thrifttest.ThriftTest_TestBool_Result
+			if err := c.call(thrifttest.ThriftTest_TestBool_Helper.Args(&want), &res); err != nil {
+				return err
+			}
+			got, err := thrifttest.ThriftTest_TestBool_Helper.UnwrapResponse(&res)
+			if err := checkEqual(want, got, err); err != nil {
+				return err
+			}
+		}
+		return nil
+	}},
+	{"testByte", func(c *Client) error {
+		want := int8(-1)
+		var res thrifttest.ThriftTest_TestByte_Result
+		if err := c.call(thrifttest.ThriftTest_TestByte_Helper.Args(&want), &res); err != nil {
+			return err
+		}
+		got, err := thrifttest.ThriftTest_TestByte_Helper.UnwrapResponse(&res)
+		return checkEqual(want, got, err)
+	}},
+	{"testI32", func(c *Client) error {
+		want := int32(-1)
+		var res thrifttest.ThriftTest_TestI32_Result
+		if err := c.call(thrifttest.ThriftTest_TestI32_Helper.Args(&want), &res); err != nil {
+			return err
+		}
+		got, err := thrifttest.ThriftTest_TestI32_Helper.UnwrapResponse(&res)
+		return checkEqual(want, got, err)
+	}},
+	{"testI64", func(c *Client) error {
+		want := int64(-34359738368)
+		var res thrifttest.ThriftTest_TestI64_Result
+		if err := c.call(thrifttest.ThriftTest_TestI64_Helper.Args(&want), &res); err != nil {
+			return err
+		}
+		got, err := thrifttest.ThriftTest_TestI64_Helper.UnwrapResponse(&res)
+		return checkEqual(want, got, err)
+	}},
+	{"testDouble", func(c *Client) error {
+		want := -5.2098523
+		var res thrifttest.ThriftTest_TestDouble_Result
+		if err := c.call(thrifttest.ThriftTest_TestDouble_Helper.Args(&want), &res); err != nil {
+			return err
+		}
+		got, err := thrifttest.ThriftTest_TestDouble_Helper.UnwrapResponse(&res)
+		return checkEqual(want, got, err)
+	}},
+	{"testBinary", func(c *Client) error {
+		want := make([]byte, 256)
+		for i := range want {
+			want[i] = byte(i)
+		}
+		var res thrifttest.ThriftTest_TestBinary_Result
+		if err := c.call(thrifttest.ThriftTest_TestBinary_Helper.Args(want), &res); err != nil {
+			return err
+		}
+		got, err := thrifttest.ThriftTest_TestBinary_Helper.UnwrapResponse(&res)
+		return checkEqual(want, got, err)
+	}},
+	{"testStruct", func(c *Client) error {
+		var res thrifttest.ThriftTest_TestStruct_Result
+		if err := c.call(thrifttest.ThriftTest_TestStruct_Helper.Args(_xtruct), &res); err != nil {
+			return err
+		}
+		got, err := thrifttest.ThriftTest_TestStruct_Helper.UnwrapResponse(&res)
+		return checkEqual(_xtruct, got, err)
+	}},
+	{"testNest", func(c *Client) error {
+		want := &thrifttest.Xtruct2{
+			ByteThing:   ptr.Int8(1),
+			StructThing: _xtruct,
+			I32Thing:    ptr.Int32(5),
+		}
+		var res thrifttest.ThriftTest_TestNest_Result
+		if err := c.call(thrifttest.ThriftTest_TestNest_Helper.Args(want), &res); err != nil {
+			return err
+		}
+		got, err := thrifttest.ThriftTest_TestNest_Helper.UnwrapResponse(&res)
+		return checkEqual(want, got, err)
+	}},
+	{"testMap", func(c *Client) error {
+		want := map[int32]int32{0: -10, 1: -9, 2: -8, 3: -7, 4: -6}
+		var res thrifttest.ThriftTest_TestMap_Result
+		if err := c.call(thrifttest.ThriftTest_TestMap_Helper.Args(want), &res); err != nil {
+			return err
+		}
+		got, err := thrifttest.ThriftTest_TestMap_Helper.UnwrapResponse(&res)
+		return checkEqual(want, got, err)
+	}},
+	{"testStringMap", func(c *Client) error {
+		want := map[string]string{"a": "2", "b": "blah", "some": "thing"}
+		var res thrifttest.ThriftTest_TestStringMap_Result
+		if err := c.call(thrifttest.ThriftTest_TestStringMap_Helper.Args(want), &res); err != nil {
+			return err
+		}
+		got, err := thrifttest.ThriftTest_TestStringMap_Helper.UnwrapResponse(&res)
+		return checkEqual(want, got, err)
+	}},
+	{"testSet", func(c *Client) error {
+		want := map[int32]struct{}{-2: {}, -1: {}, 0: {}, 1: {}, 2: {}}
+		var res thrifttest.ThriftTest_TestSet_Result
+		if err := c.call(thrifttest.ThriftTest_TestSet_Helper.Args(want), &res); err != nil {
+			return err
+		}
+		got, err := thrifttest.ThriftTest_TestSet_Helper.UnwrapResponse(&res)
+		return checkEqual(want, got, err)
+	}},
+	{"testList", func(c *Client) error {
+		want := []int32{-2, -1, 0, 1, 2}
+		var res thrifttest.ThriftTest_TestList_Result
+		if err := c.call(thrifttest.ThriftTest_TestList_Helper.Args(want), &res); err != nil {
+			return err
+		}
+		got, err := thrifttest.ThriftTest_TestList_Helper.UnwrapResponse(&res)
+		return checkEqual(want, got, err)
+	}},
+	{"testEnum", func(c *Client) error {
+		for _, want := range thrifttest.Numberz_Values() {
+			var res thrifttest.ThriftTest_TestEnum_Result
+			if err := c.call(thrifttest.ThriftTest_TestEnum_Helper.Args(&want), &res); err != nil {
+				return err
+			}
+			got, err := thrifttest.ThriftTest_TestEnum_Helper.UnwrapResponse(&res)
+			if err := checkEqual(want, got, err); err != nil {
+				return err
+			}
+		}
+		return nil
+	}},
+	{"testTypedef", func(c *Client) error {
+		want := thrifttest.UserId(309858235082523)
+		var res thrifttest.ThriftTest_TestTypedef_Result
+		if err := c.call(thrifttest.ThriftTest_TestTypedef_Helper.Args(&want), &res); err != nil {
+			return err
+		}
+		got, err := thrifttest.ThriftTest_TestTypedef_Helper.UnwrapResponse(&res)
+		return checkEqual(want, got, err)
+	}},
+	{"testMapMap", func(c *Client) error {
+		var res thrifttest.ThriftTest_TestMapMap_Result
+		if err := c.call(thrifttest.ThriftTest_TestMapMap_Helper.Args(ptr.Int32(1)), &res); err != nil {
+			return err
+		}
+		got, err := thrifttest.ThriftTest_TestMapMap_Helper.UnwrapResponse(&res)
+		return checkEqual(mapMap(), got, err)
+	}},
+	{"testInsanity", func(c *Client) error {
+		var res thrifttest.ThriftTest_TestInsanity_Result
+		if err := c.call(thrifttest.ThriftTest_TestInsanity_Helper.Args(_insanity), &res); err != nil {
+			return err
+		}
+		got, err := thrifttest.ThriftTest_TestInsanity_Helper.UnwrapResponse(&res)
+		return checkEqual(insanity(_insanity), got, err)
+	}},
+	{"testMulti", func(c *Client) error {
+		var (
+			res  thrifttest.ThriftTest_TestMulti_Result
+			arg4 = thrifttest.NumberzFive
+			arg5 = thrifttest.UserId(6)
+		)
+		req := thrifttest.ThriftTest_TestMulti_Helper.Args(
+			ptr.Int8(1), ptr.Int32(2), ptr.Int64(3), map[int16]string{1: "test"}, &arg4, &arg5)
+		if err := c.call(req, &res); err != nil {
+			return err
+		}
+		want := &thrifttest.Xtruct{
+			StringThing: ptr.String("Hello2"),
+			ByteThing:   ptr.Int8(1),
+			I32Thing:    ptr.Int32(2),
+			I64Thing:    ptr.Int64(3),
+		}
+		got, err := thrifttest.ThriftTest_TestMulti_Helper.UnwrapResponse(&res)
+		return checkEqual(want, got, err)
+	}},
+	{"testException", func(c *Client) error {
+		call := func(arg string) error {
+			var res thrifttest.ThriftTest_TestException_Result
+			if err := c.call(thrifttest.ThriftTest_TestException_Helper.Args(&arg), &res); err != nil {
+				return err
+			}
+			return thrifttest.ThriftTest_TestException_Helper.UnwrapResponse(&res)
+		}
+
+		want := &thrifttest.Xception{ErrorCode: ptr.Int32(1001), Message: ptr.String("Xception")}
+		if err := checkEqual(want, call("Xception"), nil); err != nil {
+			return err
+		}
+		if err, ok := call("TException").(*exception.TApplicationException); !ok {
+			return fmt.Errorf("expected a TApplicationException, got %v", err)
+		}
+		return call("success")
+	}},
+	{"testMultiException", func(c *Client) error {
+		call := func(arg0 string) (*thrifttest.Xtruct, error) {
+			var res thrifttest.ThriftTest_TestMultiException_Result
+			req := thrifttest.ThriftTest_TestMultiException_Helper.Args(&arg0, ptr.String("test"))
+			if err := c.call(req, &res); err != nil {
+				return nil, err
+			}
+			return thrifttest.ThriftTest_TestMultiException_Helper.UnwrapResponse(&res)
+		}
+
+		_, err := call("Xception")
+		want := &thrifttest.Xception{ErrorCode: ptr.Int32(1001), Message: ptr.String("This is an Xception")}
+		if err := checkEqual(want, err, nil); err != nil {
+			return err
+		}
+
+		_, err = call("Xception2")
+		want2 := &thrifttest.Xception2{
+			ErrorCode:   ptr.Int32(2002),
+			StructThing: &thrifttest.Xtruct{StringThing: ptr.String("This is an Xception2")},
+		}
+		if err := checkEqual(want2, err, nil); err != nil {
+			return err
+		}
+
+		res, err := call("success")
+		return checkEqual(&thrifttest.Xtruct{StringThing: ptr.String("test")}, res, err)
+	}},
+	{"testOneway", func(c *Client) error {
+		start := time.Now()
+		if err := c.call(thrifttest.ThriftTest_TestOneway_Helper.Args(ptr.Int32(1)), nil); err != nil {
+			return err
+		}
+		if d := time.Since(start); d > 500*time.Millisecond {
+			return fmt.Errorf("oneway call took %v", d)
+		}
+
+		// The server must not send a response to the oneway call. Make sure
+		// that the next response is for the next call.
+		var res thrifttest.ThriftTest_TestVoid_Result
+		if err := c.call(thrifttest.ThriftTest_TestVoid_Helper.Args(), &res); err != nil {
+			return err
+		}
+		return thrifttest.ThriftTest_TestVoid_Helper.UnwrapResponse(&res)
+	}},
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package crosstest
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"go.uber.org/thriftrw/envelope"
+	"go.uber.org/thriftrw/internal/envelope/exception"
+	"go.uber.org/thriftrw/internal/frame"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientServer(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	go Serve(l)
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var out bytes.Buffer
+	assert.NoError(t, Run(&out, NewClient(conn)), "output:\n%s", out.String())
+	for _, tt := range _tests {
+		assert.Contains(t, out.String(), "ok   "+tt.Name+"\n")
+	}
+}
+
+func TestServeConnUnknownMethod(t *testing.T) {
+	client, server := net.Pipe()
+	done := make(chan error, 1)
+	go func() { done <- ServeConn(server) }()
+
+	var req bytes.Buffer
+	require.NoError(t, envelope.Envelope{
+		Name:  "testUnknown",
+		Type:  wire.Call,
+		SeqID: 42,
+		Value: wire.NewValueStruct(wire.Struct{}),
+	}.Encode(protocol.Binary, &req))
+
+	body, err := frame.NewClient(client, client).Send(req.Bytes())
+	require.NoError(t, err)
+
+	res, err := envelope.Decode(protocol.Binary, bytes.NewReader(body))
+	require.NoError(t, err)
+	assert.Equal(t, int32(42), res.SeqID)
+
+	_, err = res.Reply()
+	if assert.IsType(t, &exception.TApplicationException{}, err) {
+		assert.Equal(t, exception.ExceptionTypeUnknownMethod,
+			err.(*exception.TApplicationException).GetType())
+	}
+
+	require.NoError(t, client.Close())
+	assert.NoError(t, <-done)
+}
+
+func TestRunReportsFailures(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	// Respond to every request, including oneway requests, with an empty
+	// struct, which is not a valid response to any of them.
+	respond := frameHandler(func(b []byte) ([]byte, error) {
+		req, err := envelope.Decode(protocol.Binary, bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+
+		var res bytes.Buffer
+		err = envelope.Envelope{
+			Name:  req.Name,
+			Type:  wire.Reply,
+			SeqID: req.SeqID,
+			Value: wire.NewValueStruct(wire.Struct{}),
+		}.Encode(protocol.Binary, &res)
+		return res.Bytes(), err
+	})
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		frame.NewServer(conn, conn).Serve(respond)
+	}()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var out bytes.Buffer
+	err = Run(&out, NewClient(conn))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "testString failed")
+	assert.Contains(t, out.String(), "FAIL testString: ")
+	assert.Contains(t, out.String(), `FAIL testOneway: expected a response to "testVoid", got "testOneway"`)
+}
+
+type frameHandler func([]byte) ([]byte, error)
+
+func (f frameHandler) Handle(b []byte) ([]byte, error) { return f(b) }
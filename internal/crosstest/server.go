@@ -0,0 +1,328 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package crosstest
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"time"
+
+	"go.uber.org/thriftrw/envelope"
+	"go.uber.org/thriftrw/internal/crosstest/thrifttest"
+	intenvelope "go.uber.org/thriftrw/internal/envelope"
+	"go.uber.org/thriftrw/internal/frame"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/ptr"
+	"go.uber.org/thriftrw/wire"
+)
+
+// Serve accepts connections on the given listener and serves the ThriftTest
+// service on each of them until the listener is closed.
+func Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go ServeConn(conn)
+	}
+}
+
+// ServeConn serves the ThriftTest service on the given connection until the
+// client disconnects. The connection is closed when ServeConn returns.
+//
+// Requests are handled in the order in which they are received. Responses
+// are not sent for oneway requests.
+func ServeConn(conn io.ReadWriteCloser) error {
+	defer conn.Close()
+
+	r := frame.NewReader(conn)
+	w := frame.NewWriter(conn)
+	server := intenvelope.NewServer(protocol.Binary, handler{})
+	for {
+		req, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		env, err := envelope.Decode(protocol.Binary, bytes.NewReader(req))
+		if err != nil {
+			return err
+		}
+		if env.Type == wire.OneWay {
+			if _, err := (handler{}).Handle(env.Name, env.Value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		res, err := server.Handle(req)
+		if err != nil {
+			return err
+		}
+		if err := w.Write(res); err != nil {
+			return err
+		}
+	}
+}
+
+// handler implements the ThriftTest service as specified by the test suite.
+type handler struct{}
+
+func (handler) Handle(name string, body wire.Value) (wire.Value, error) {
+	m, ok := _methods[name]
+	if !ok {
+		return wire.Value{}, intenvelope.ErrUnknownMethod(name)
+	}
+	return m(body)
+}
+
+type method func(body wire.Value) (wire.Value, error)
+
+// toWirer is implemented by the results of all methods.
+type toWirer interface {
+	ToWire() (wire.Value, error)
+}
+
+// respond converts the result of WrapResponse into a wire.Value.
+func respond(res toWirer, err error) (wire.Value, error) {
+	if err != nil {
+		return wire.Value{}, err
+	}
+	return res.ToWire()
+}
+
+var _methods = map[string]method{
+	"testVoid": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestVoid_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestVoid_Helper.WrapResponse(nil))
+	},
+	"testString": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestString_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestString_Helper.WrapResponse(args.GetThing(), nil))
+	},
+	"testBool": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestBool_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestBool_Helper.WrapResponse(args.GetThing(), nil))
+	},
+	"testByte": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestByte_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestByte_Helper.WrapResponse(args.GetThing(), nil))
+	},
+	"testI32": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestI32_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestI32_Helper.WrapResponse(args.GetThing(), nil))
+	},
+	"testI64": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestI64_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestI64_Helper.WrapResponse(args.GetThing(), nil))
+	},
+	"testDouble": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestDouble_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestDouble_Helper.WrapResponse(args.GetThing(), nil))
+	},
+	"testBinary": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestBinary_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestBinary_Helper.WrapResponse(args.GetThing(), nil))
+	},
+	"testStruct": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestStruct_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestStruct_Helper.WrapResponse(args.GetThing(), nil))
+	},
+	"testNest": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestNest_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestNest_Helper.WrapResponse(args.GetThing(), nil))
+	},
+	"testMap": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestMap_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestMap_Helper.WrapResponse(args.GetThing(), nil))
+	},
+	"testStringMap": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestStringMap_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestStringMap_Helper.WrapResponse(args.GetThing(), nil))
+	},
+	"testSet": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestSet_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestSet_Helper.WrapResponse(args.GetThing(), nil))
+	},
+	"testList": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestList_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestList_Helper.WrapResponse(args.GetThing(), nil))
+	},
+	"testEnum": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestEnum_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestEnum_Helper.WrapResponse(args.GetThing(), nil))
+	},
+	"testTypedef": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestTypedef_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestTypedef_Helper.WrapResponse(args.GetThing(), nil))
+	},
+	"testMapMap": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestMapMap_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestMapMap_Helper.WrapResponse(mapMap(), nil))
+	},
+	"testInsanity": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestInsanity_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestInsanity_Helper.WrapResponse(insanity(args.Argument), nil))
+	},
+	"testMulti": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestMulti_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		return respond(thrifttest.ThriftTest_TestMulti_Helper.WrapResponse(&thrifttest.Xtruct{
+			StringThing: ptr.String("Hello2"),
+			ByteThing:   args.Arg0,
+			I32Thing:    args.Arg1,
+			I64Thing:    args.Arg2,
+		}, nil))
+	},
+	"testException": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestException_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+
+		var err error
+		switch args.GetArg() {
+		case "Xception":
+			err = &thrifttest.Xception{ErrorCode: ptr.Int32(1001), Message: args.Arg}
+		case "TException":
+			// Errors other than declared exceptions are sent to the client
+			// as TApplicationExceptions.
+			err = errors.New("This is a TException")
+		}
+		return respond(thrifttest.ThriftTest_TestException_Helper.WrapResponse(err))
+	},
+	"testMultiException": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestMultiException_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+
+		var err error
+		switch args.GetArg0() {
+		case "Xception":
+			err = &thrifttest.Xception{
+				ErrorCode: ptr.Int32(1001),
+				Message:   ptr.String("This is an Xception"),
+			}
+		case "Xception2":
+			err = &thrifttest.Xception2{
+				ErrorCode:   ptr.Int32(2002),
+				StructThing: &thrifttest.Xtruct{StringThing: ptr.String("This is an Xception2")},
+			}
+		default:
+			return respond(thrifttest.ThriftTest_TestMultiException_Helper.WrapResponse(
+				&thrifttest.Xtruct{StringThing: args.Arg1}, nil))
+		}
+		return respond(thrifttest.ThriftTest_TestMultiException_Helper.WrapResponse(nil, err))
+	},
+	"testOneway": func(body wire.Value) (wire.Value, error) {
+		var args thrifttest.ThriftTest_TestOneway_Args
+		if err := args.FromWire(body); err != nil {
+			return wire.Value{}, err
+		}
+		time.Sleep(time.Duration(args.GetSecondsToSleep()) * time.Second)
+		return wire.Value{}, nil
+	},
+}
+
+// mapMap returns the response expected from testMapMap.
+func mapMap() map[int32]map[int32]int32 {
+	return map[int32]map[int32]int32{
+		-4: {-4: -4, -3: -3, -2: -2, -1: -1},
+		4:  {1: 1, 2: 2, 3: 3, 4: 4},
+	}
+}
+
+// insanity returns the response expected from testInsanity with the given
+// argument.
+func insanity(argument *thrifttest.Insanity) map[thrifttest.UserId]map[thrifttest.Numberz]*thrifttest.Insanity {
+	return map[thrifttest.UserId]map[thrifttest.Numberz]*thrifttest.Insanity{
+		1: {
+			thrifttest.NumberzTwo:   argument,
+			thrifttest.NumberzThree: argument,
+		},
+		2: {
+			thrifttest.NumberzSix: &thrifttest.Insanity{},
+		},
+	}
+}
@@ -0,0 +1,30 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thrifttest
+
+import "go.uber.org/thriftrw/thriftreflect"
+
+var ThriftModule = &thriftreflect.ThriftModule{Name: "thrifttest", Package: "go.uber.org/thriftrw/internal/crosstest/thrifttest", FilePath: "thrifttest.thrift", SHA1: "351b3d539bf3d52a60c3c408e6a15d58df749a44", Raw: rawIDL}
+
+const rawIDL = "// The subset of Apache Thrift's test/ThriftTest.thrift exercised by the\n// cross-implementation test suite. Names, field IDs, and types must match the\n// upstream file so that thriftrw can talk to the test servers and clients of\n// other languages.\n//\n// Fields without a requiredness upstream are marked optional, which is how\n// thriftrw treats them when it compiles Thrift files in non-strict mode.\n\ntypedef i64 UserId\n\nenum Numberz {\n  ONE = 1\n  TWO\n  THREE\n  FIVE = 5\n  SIX\n  EIGHT = 8\n}\n\nstruct Xtruct {\n  1: optional string string_thing\n  4: optional i8 byte_thing\n  9: optional i32 i32_thing\n  11: optional i64 i64_thing\n}\n\nstruct Xtruct2 {\n  1: optional i8 byte_thing\n  2: optional Xtruct struct_thing\n  3: optional i32 i32_thing\n}\n\nstruct Insanity {\n  1: optional map<Numberz, UserId> userMap\n  2: optional list<Xtruct> xtructs\n}\n\nexception Xception {\n  1: optional i32 errorCode\n  2: optional string message\n}\n\nexception Xception2 {\n  1: optional i32 errorCode\n  2: optional Xtruct struct_thing\n}\n\nservice ThriftTest {\n  void testVoid()\n  string testString(1: string thing)\n  bool testBool(1: bool thing)\n  i8 testByte(1: i8 thing)\n  i32 testI32(1: i32 thing)\n  i64 testI64(1: i64 thing)\n  double testDouble(1: double thing)\n  binary testBinary(1: binary thing)\n  Xtruct testStruct(1: Xtruct thing)\n  Xtruct2 testNest(1: Xtruct2 thing)\n  map<i32, i32> testMap(1: map<i32, i32> thing)\n  map<string, string> testStringMap(1: map<string, string> thing)\n  set<i32> testSet(1: set<i32> thing)\n  list<i32> testList(1: list<i32> thing)\n  Numberz testEnum(1: Numberz thing)\n  UserId testTypedef(1: UserId thing)\n  map<i32, map<i32, i32>> testMapMap(1: i32 hello)\n  map<UserId, map<Numberz, Insanity>> testInsanity(1: Insanity argument)\n  Xtruct testMulti(\n    1: i8 arg0\n    2: i32 arg1\n    3: i64 arg2\n    4: map<i16, string> arg3\n    5: Numberz arg4\n    6: UserId arg5\n  )\n  void testException(1: string arg) throws (1: Xception err1)\n  Xtruct testMultiException(1: string arg0, 2: string arg1)\n    throws (1: Xception err1, 2: Xception2 err2)\n  oneway void testOneway(1: i32 secondsToSleep)\n}\n"
@@ -0,0 +1,309 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thrifttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+	"strings"
+)
+
+type ThriftTest_TestBool_Args struct {
+	Thing *bool `json:"thing,omitempty"`
+}
+
+func (v *ThriftTest_TestBool_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Thing != nil {
+		w, err = wire.NewValueBool(*(v.Thing)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *ThriftTest_TestBool_Args) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Thing = &x
+				if err != nil {
+					wire.ObserveDecodeError("ThriftTest_TestBool_Args", "Thing", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestBool_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	if v.Thing != nil {
+		fields[i] = fmt.Sprintf("Thing: %v", *(v.Thing))
+		i++
+	}
+	return fmt.Sprintf("ThriftTest_TestBool_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Bool_EqualsPtr(lhs, rhs *bool) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func (v *ThriftTest_TestBool_Args) Equals(rhs *ThriftTest_TestBool_Args) bool {
+	if !_Bool_EqualsPtr(v.Thing, rhs.Thing) {
+		return false
+	}
+	return true
+}
+
+func (v *ThriftTest_TestBool_Args) GetThing() (o bool) {
+	if v != nil && v.Thing != nil {
+		return *v.Thing
+	}
+	return
+}
+
+func (v *ThriftTest_TestBool_Args) MethodName() string {
+	return "testBool"
+}
+
+func (v *ThriftTest_TestBool_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+var ThriftTest_TestBool_Helper = struct {
+	Args            func(thing *bool) *ThriftTest_TestBool_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*ThriftTest_TestBool_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func(bool, error) (*ThriftTest_TestBool_Result, error)
+	UnwrapResponse  func(*ThriftTest_TestBool_Result) (bool, error)
+}{}
+
+func init() {
+	ThriftTest_TestBool_Helper.Args = func(thing *bool) *ThriftTest_TestBool_Args {
+		return &ThriftTest_TestBool_Args{Thing: thing}
+	}
+	ThriftTest_TestBool_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*ThriftTest_TestBool_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args ThriftTest_TestBool_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
+	ThriftTest_TestBool_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		default:
+			return false
+		}
+	}
+	ThriftTest_TestBool_Helper.IsSafeException = func(error) bool {
+		return false
+	}
+	ThriftTest_TestBool_Helper.WrapResponse = func(success bool, err error) (*ThriftTest_TestBool_Result, error) {
+		if err == nil {
+			return &ThriftTest_TestBool_Result{Success: &success}, nil
+		}
+		return nil, err
+	}
+	ThriftTest_TestBool_Helper.UnwrapResponse = func(result *ThriftTest_TestBool_Result) (success bool, err error) {
+		if result.Success != nil {
+			success = *result.Success
+			return
+		}
+		err = errors.New("expected a non-void result")
+		return
+	}
+}
+
+type ThriftTest_TestBool_ArgsOption func(*ThriftTest_TestBool_Args)
+
+func ThriftTest_TestBool_WithThing(v bool) ThriftTest_TestBool_ArgsOption {
+	return func(args *ThriftTest_TestBool_Args) {
+		args.Thing = &v
+	}
+}
+
+func ThriftTest_TestBool_NewArgs(opts ...ThriftTest_TestBool_ArgsOption) *ThriftTest_TestBool_Args {
+	args := &ThriftTest_TestBool_Args{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	return args
+}
+
+type ThriftTest_TestBool_Result struct {
+	Success *bool `json:"success,omitempty"`
+}
+
+func (v *ThriftTest_TestBool_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Success != nil {
+		w, err = wire.NewValueBool(*(v.Success)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("ThriftTest_TestBool_Result should have exactly one field: got %v fields", i)
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *ThriftTest_TestBool_Result) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Success = &x
+				if err != nil {
+					wire.ObserveDecodeError("ThriftTest_TestBool_Result", "Success", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		wire.ObserveDecodeError("ThriftTest_TestBool_Result", "", wire.DecodeErrorInvalidUnion)
+		return fmt.Errorf("ThriftTest_TestBool_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestBool_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", *(v.Success))
+		i++
+	}
+	return fmt.Sprintf("ThriftTest_TestBool_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *ThriftTest_TestBool_Result) Equals(rhs *ThriftTest_TestBool_Result) bool {
+	if !_Bool_EqualsPtr(v.Success, rhs.Success) {
+		return false
+	}
+	return true
+}
+
+func (v *ThriftTest_TestBool_Result) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("ThriftTest_TestBool_Result should have exactly one field: got %v fields", count)
+	}
+	type plain ThriftTest_TestBool_Result
+	return json.Marshal((*plain)(v))
+}
+
+func (v *ThriftTest_TestBool_Result) UnmarshalJSON(text []byte) error {
+	type plain ThriftTest_TestBool_Result
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("ThriftTest_TestBool_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestBool_Result) GetSuccess() (o bool) {
+	if v != nil && v.Success != nil {
+		return *v.Success
+	}
+	return
+}
+
+func (v *ThriftTest_TestBool_Result) MethodName() string {
+	return "testBool"
+}
+
+func (v *ThriftTest_TestBool_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+func ThriftTest_TestBool_NewResultSuccess(success bool) *ThriftTest_TestBool_Result {
+	return &ThriftTest_TestBool_Result{Success: &success}
+}
+
+func ThriftTest_TestBool_NewResultError(err error) (*ThriftTest_TestBool_Result, error) {
+	if err == nil {
+		return nil, errors.New("ThriftTest_TestBool_NewResultError received a nil error")
+	}
+	return nil, err
+}
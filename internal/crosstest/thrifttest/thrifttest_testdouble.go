@@ -0,0 +1,309 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thrifttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+	"strings"
+)
+
+type ThriftTest_TestDouble_Args struct {
+	Thing *float64 `json:"thing,omitempty"`
+}
+
+func (v *ThriftTest_TestDouble_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Thing != nil {
+		w, err = wire.NewValueDouble(*(v.Thing)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *ThriftTest_TestDouble_Args) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TDouble {
+				var x float64
+				x, err = field.Value.GetDouble(), error(nil)
+				v.Thing = &x
+				if err != nil {
+					wire.ObserveDecodeError("ThriftTest_TestDouble_Args", "Thing", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestDouble_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	if v.Thing != nil {
+		fields[i] = fmt.Sprintf("Thing: %v", *(v.Thing))
+		i++
+	}
+	return fmt.Sprintf("ThriftTest_TestDouble_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Double_EqualsPtr(lhs, rhs *float64) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func (v *ThriftTest_TestDouble_Args) Equals(rhs *ThriftTest_TestDouble_Args) bool {
+	if !_Double_EqualsPtr(v.Thing, rhs.Thing) {
+		return false
+	}
+	return true
+}
+
+func (v *ThriftTest_TestDouble_Args) GetThing() (o float64) {
+	if v != nil && v.Thing != nil {
+		return *v.Thing
+	}
+	return
+}
+
+func (v *ThriftTest_TestDouble_Args) MethodName() string {
+	return "testDouble"
+}
+
+func (v *ThriftTest_TestDouble_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+var ThriftTest_TestDouble_Helper = struct {
+	Args            func(thing *float64) *ThriftTest_TestDouble_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*ThriftTest_TestDouble_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func(float64, error) (*ThriftTest_TestDouble_Result, error)
+	UnwrapResponse  func(*ThriftTest_TestDouble_Result) (float64, error)
+}{}
+
+func init() {
+	ThriftTest_TestDouble_Helper.Args = func(thing *float64) *ThriftTest_TestDouble_Args {
+		return &ThriftTest_TestDouble_Args{Thing: thing}
+	}
+	ThriftTest_TestDouble_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*ThriftTest_TestDouble_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args ThriftTest_TestDouble_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
+	ThriftTest_TestDouble_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		default:
+			return false
+		}
+	}
+	ThriftTest_TestDouble_Helper.IsSafeException = func(error) bool {
+		return false
+	}
+	ThriftTest_TestDouble_Helper.WrapResponse = func(success float64, err error) (*ThriftTest_TestDouble_Result, error) {
+		if err == nil {
+			return &ThriftTest_TestDouble_Result{Success: &success}, nil
+		}
+		return nil, err
+	}
+	ThriftTest_TestDouble_Helper.UnwrapResponse = func(result *ThriftTest_TestDouble_Result) (success float64, err error) {
+		if result.Success != nil {
+			success = *result.Success
+			return
+		}
+		err = errors.New("expected a non-void result")
+		return
+	}
+}
+
+type ThriftTest_TestDouble_ArgsOption func(*ThriftTest_TestDouble_Args)
+
+func ThriftTest_TestDouble_WithThing(v float64) ThriftTest_TestDouble_ArgsOption {
+	return func(args *ThriftTest_TestDouble_Args) {
+		args.Thing = &v
+	}
+}
+
+func ThriftTest_TestDouble_NewArgs(opts ...ThriftTest_TestDouble_ArgsOption) *ThriftTest_TestDouble_Args {
+	args := &ThriftTest_TestDouble_Args{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	return args
+}
+
+type ThriftTest_TestDouble_Result struct {
+	Success *float64 `json:"success,omitempty"`
+}
+
+func (v *ThriftTest_TestDouble_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Success != nil {
+		w, err = wire.NewValueDouble(*(v.Success)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("ThriftTest_TestDouble_Result should have exactly one field: got %v fields", i)
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *ThriftTest_TestDouble_Result) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TDouble {
+				var x float64
+				x, err = field.Value.GetDouble(), error(nil)
+				v.Success = &x
+				if err != nil {
+					wire.ObserveDecodeError("ThriftTest_TestDouble_Result", "Success", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		wire.ObserveDecodeError("ThriftTest_TestDouble_Result", "", wire.DecodeErrorInvalidUnion)
+		return fmt.Errorf("ThriftTest_TestDouble_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestDouble_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", *(v.Success))
+		i++
+	}
+	return fmt.Sprintf("ThriftTest_TestDouble_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *ThriftTest_TestDouble_Result) Equals(rhs *ThriftTest_TestDouble_Result) bool {
+	if !_Double_EqualsPtr(v.Success, rhs.Success) {
+		return false
+	}
+	return true
+}
+
+func (v *ThriftTest_TestDouble_Result) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("ThriftTest_TestDouble_Result should have exactly one field: got %v fields", count)
+	}
+	type plain ThriftTest_TestDouble_Result
+	return json.Marshal((*plain)(v))
+}
+
+func (v *ThriftTest_TestDouble_Result) UnmarshalJSON(text []byte) error {
+	type plain ThriftTest_TestDouble_Result
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("ThriftTest_TestDouble_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestDouble_Result) GetSuccess() (o float64) {
+	if v != nil && v.Success != nil {
+		return *v.Success
+	}
+	return
+}
+
+func (v *ThriftTest_TestDouble_Result) MethodName() string {
+	return "testDouble"
+}
+
+func (v *ThriftTest_TestDouble_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+func ThriftTest_TestDouble_NewResultSuccess(success float64) *ThriftTest_TestDouble_Result {
+	return &ThriftTest_TestDouble_Result{Success: &success}
+}
+
+func ThriftTest_TestDouble_NewResultError(err error) (*ThriftTest_TestDouble_Result, error) {
+	if err == nil {
+		return nil, errors.New("ThriftTest_TestDouble_NewResultError received a nil error")
+	}
+	return nil, err
+}
@@ -0,0 +1,300 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thrifttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+	"strings"
+)
+
+type ThriftTest_TestI32_Args struct {
+	Thing *int32 `json:"thing,omitempty"`
+}
+
+func (v *ThriftTest_TestI32_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Thing != nil {
+		w, err = wire.NewValueI32(*(v.Thing)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *ThriftTest_TestI32_Args) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Thing = &x
+				if err != nil {
+					wire.ObserveDecodeError("ThriftTest_TestI32_Args", "Thing", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestI32_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	if v.Thing != nil {
+		fields[i] = fmt.Sprintf("Thing: %v", *(v.Thing))
+		i++
+	}
+	return fmt.Sprintf("ThriftTest_TestI32_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *ThriftTest_TestI32_Args) Equals(rhs *ThriftTest_TestI32_Args) bool {
+	if !_I32_EqualsPtr(v.Thing, rhs.Thing) {
+		return false
+	}
+	return true
+}
+
+func (v *ThriftTest_TestI32_Args) GetThing() (o int32) {
+	if v != nil && v.Thing != nil {
+		return *v.Thing
+	}
+	return
+}
+
+func (v *ThriftTest_TestI32_Args) MethodName() string {
+	return "testI32"
+}
+
+func (v *ThriftTest_TestI32_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+var ThriftTest_TestI32_Helper = struct {
+	Args            func(thing *int32) *ThriftTest_TestI32_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*ThriftTest_TestI32_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func(int32, error) (*ThriftTest_TestI32_Result, error)
+	UnwrapResponse  func(*ThriftTest_TestI32_Result) (int32, error)
+}{}
+
+func init() {
+	ThriftTest_TestI32_Helper.Args = func(thing *int32) *ThriftTest_TestI32_Args {
+		return &ThriftTest_TestI32_Args{Thing: thing}
+	}
+	ThriftTest_TestI32_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*ThriftTest_TestI32_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args ThriftTest_TestI32_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
+	ThriftTest_TestI32_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		default:
+			return false
+		}
+	}
+	ThriftTest_TestI32_Helper.IsSafeException = func(error) bool {
+		return false
+	}
+	ThriftTest_TestI32_Helper.WrapResponse = func(success int32, err error) (*ThriftTest_TestI32_Result, error) {
+		if err == nil {
+			return &ThriftTest_TestI32_Result{Success: &success}, nil
+		}
+		return nil, err
+	}
+	ThriftTest_TestI32_Helper.UnwrapResponse = func(result *ThriftTest_TestI32_Result) (success int32, err error) {
+		if result.Success != nil {
+			success = *result.Success
+			return
+		}
+		err = errors.New("expected a non-void result")
+		return
+	}
+}
+
+type ThriftTest_TestI32_ArgsOption func(*ThriftTest_TestI32_Args)
+
+func ThriftTest_TestI32_WithThing(v int32) ThriftTest_TestI32_ArgsOption {
+	return func(args *ThriftTest_TestI32_Args) {
+		args.Thing = &v
+	}
+}
+
+func ThriftTest_TestI32_NewArgs(opts ...ThriftTest_TestI32_ArgsOption) *ThriftTest_TestI32_Args {
+	args := &ThriftTest_TestI32_Args{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	return args
+}
+
+type ThriftTest_TestI32_Result struct {
+	Success *int32 `json:"success,omitempty"`
+}
+
+func (v *ThriftTest_TestI32_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Success != nil {
+		w, err = wire.NewValueI32(*(v.Success)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("ThriftTest_TestI32_Result should have exactly one field: got %v fields", i)
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *ThriftTest_TestI32_Result) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Success = &x
+				if err != nil {
+					wire.ObserveDecodeError("ThriftTest_TestI32_Result", "Success", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		wire.ObserveDecodeError("ThriftTest_TestI32_Result", "", wire.DecodeErrorInvalidUnion)
+		return fmt.Errorf("ThriftTest_TestI32_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestI32_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", *(v.Success))
+		i++
+	}
+	return fmt.Sprintf("ThriftTest_TestI32_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *ThriftTest_TestI32_Result) Equals(rhs *ThriftTest_TestI32_Result) bool {
+	if !_I32_EqualsPtr(v.Success, rhs.Success) {
+		return false
+	}
+	return true
+}
+
+func (v *ThriftTest_TestI32_Result) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("ThriftTest_TestI32_Result should have exactly one field: got %v fields", count)
+	}
+	type plain ThriftTest_TestI32_Result
+	return json.Marshal((*plain)(v))
+}
+
+func (v *ThriftTest_TestI32_Result) UnmarshalJSON(text []byte) error {
+	type plain ThriftTest_TestI32_Result
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("ThriftTest_TestI32_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestI32_Result) GetSuccess() (o int32) {
+	if v != nil && v.Success != nil {
+		return *v.Success
+	}
+	return
+}
+
+func (v *ThriftTest_TestI32_Result) MethodName() string {
+	return "testI32"
+}
+
+func (v *ThriftTest_TestI32_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+func ThriftTest_TestI32_NewResultSuccess(success int32) *ThriftTest_TestI32_Result {
+	return &ThriftTest_TestI32_Result{Success: &success}
+}
+
+func ThriftTest_TestI32_NewResultError(err error) (*ThriftTest_TestI32_Result, error) {
+	if err == nil {
+		return nil, errors.New("ThriftTest_TestI32_NewResultError received a nil error")
+	}
+	return nil, err
+}
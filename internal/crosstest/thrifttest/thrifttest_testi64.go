@@ -0,0 +1,300 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thrifttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+	"strings"
+)
+
+type ThriftTest_TestI64_Args struct {
+	Thing *int64 `json:"thing,omitempty"`
+}
+
+func (v *ThriftTest_TestI64_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Thing != nil {
+		w, err = wire.NewValueI64(*(v.Thing)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *ThriftTest_TestI64_Args) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Thing = &x
+				if err != nil {
+					wire.ObserveDecodeError("ThriftTest_TestI64_Args", "Thing", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestI64_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	if v.Thing != nil {
+		fields[i] = fmt.Sprintf("Thing: %v", *(v.Thing))
+		i++
+	}
+	return fmt.Sprintf("ThriftTest_TestI64_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *ThriftTest_TestI64_Args) Equals(rhs *ThriftTest_TestI64_Args) bool {
+	if !_I64_EqualsPtr(v.Thing, rhs.Thing) {
+		return false
+	}
+	return true
+}
+
+func (v *ThriftTest_TestI64_Args) GetThing() (o int64) {
+	if v != nil && v.Thing != nil {
+		return *v.Thing
+	}
+	return
+}
+
+func (v *ThriftTest_TestI64_Args) MethodName() string {
+	return "testI64"
+}
+
+func (v *ThriftTest_TestI64_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+var ThriftTest_TestI64_Helper = struct {
+	Args            func(thing *int64) *ThriftTest_TestI64_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*ThriftTest_TestI64_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func(int64, error) (*ThriftTest_TestI64_Result, error)
+	UnwrapResponse  func(*ThriftTest_TestI64_Result) (int64, error)
+}{}
+
+func init() {
+	ThriftTest_TestI64_Helper.Args = func(thing *int64) *ThriftTest_TestI64_Args {
+		return &ThriftTest_TestI64_Args{Thing: thing}
+	}
+	ThriftTest_TestI64_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*ThriftTest_TestI64_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args ThriftTest_TestI64_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
+	ThriftTest_TestI64_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		default:
+			return false
+		}
+	}
+	ThriftTest_TestI64_Helper.IsSafeException = func(error) bool {
+		return false
+	}
+	ThriftTest_TestI64_Helper.WrapResponse = func(success int64, err error) (*ThriftTest_TestI64_Result, error) {
+		if err == nil {
+			return &ThriftTest_TestI64_Result{Success: &success}, nil
+		}
+		return nil, err
+	}
+	ThriftTest_TestI64_Helper.UnwrapResponse = func(result *ThriftTest_TestI64_Result) (success int64, err error) {
+		if result.Success != nil {
+			success = *result.Success
+			return
+		}
+		err = errors.New("expected a non-void result")
+		return
+	}
+}
+
+type ThriftTest_TestI64_ArgsOption func(*ThriftTest_TestI64_Args)
+
+func ThriftTest_TestI64_WithThing(v int64) ThriftTest_TestI64_ArgsOption {
+	return func(args *ThriftTest_TestI64_Args) {
+		args.Thing = &v
+	}
+}
+
+func ThriftTest_TestI64_NewArgs(opts ...ThriftTest_TestI64_ArgsOption) *ThriftTest_TestI64_Args {
+	args := &ThriftTest_TestI64_Args{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	return args
+}
+
+type ThriftTest_TestI64_Result struct {
+	Success *int64 `json:"success,omitempty"`
+}
+
+func (v *ThriftTest_TestI64_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Success != nil {
+		w, err = wire.NewValueI64(*(v.Success)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("ThriftTest_TestI64_Result should have exactly one field: got %v fields", i)
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *ThriftTest_TestI64_Result) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Success = &x
+				if err != nil {
+					wire.ObserveDecodeError("ThriftTest_TestI64_Result", "Success", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		wire.ObserveDecodeError("ThriftTest_TestI64_Result", "", wire.DecodeErrorInvalidUnion)
+		return fmt.Errorf("ThriftTest_TestI64_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestI64_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", *(v.Success))
+		i++
+	}
+	return fmt.Sprintf("ThriftTest_TestI64_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *ThriftTest_TestI64_Result) Equals(rhs *ThriftTest_TestI64_Result) bool {
+	if !_I64_EqualsPtr(v.Success, rhs.Success) {
+		return false
+	}
+	return true
+}
+
+func (v *ThriftTest_TestI64_Result) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("ThriftTest_TestI64_Result should have exactly one field: got %v fields", count)
+	}
+	type plain ThriftTest_TestI64_Result
+	return json.Marshal((*plain)(v))
+}
+
+func (v *ThriftTest_TestI64_Result) UnmarshalJSON(text []byte) error {
+	type plain ThriftTest_TestI64_Result
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("ThriftTest_TestI64_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestI64_Result) GetSuccess() (o int64) {
+	if v != nil && v.Success != nil {
+		return *v.Success
+	}
+	return
+}
+
+func (v *ThriftTest_TestI64_Result) MethodName() string {
+	return "testI64"
+}
+
+func (v *ThriftTest_TestI64_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+func ThriftTest_TestI64_NewResultSuccess(success int64) *ThriftTest_TestI64_Result {
+	return &ThriftTest_TestI64_Result{Success: &success}
+}
+
+func ThriftTest_TestI64_NewResultError(err error) (*ThriftTest_TestI64_Result, error) {
+	if err == nil {
+		return nil, errors.New("ThriftTest_TestI64_NewResultError received a nil error")
+	}
+	return nil, err
+}
@@ -0,0 +1,371 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thrifttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+	"strings"
+)
+
+type ThriftTest_TestMap_Args struct {
+	Thing map[int32]int32 `json:"thing"`
+}
+
+type _Map_I32_I32_MapItemList map[int32]int32
+
+func (m _Map_I32_I32_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		kw, err := wire.NewValueI32(k), error(nil)
+		if err != nil {
+			return err
+		}
+		vw, err := wire.NewValueI32(v), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_I32_I32_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_I32_I32_MapItemList) KeyType() wire.Type {
+	return wire.TI32
+}
+
+func (_Map_I32_I32_MapItemList) ValueType() wire.Type {
+	return wire.TI32
+}
+
+func (_Map_I32_I32_MapItemList) Close() {
+}
+
+func (v *ThriftTest_TestMap_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Thing != nil {
+		w, err = wire.NewValueMap(_Map_I32_I32_MapItemList(v.Thing)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Map_I32_I32_Read(m wire.MapItemList) (map[int32]int32, error) {
+	if m.KeyType() != wire.TI32 {
+		return nil, nil
+	}
+	if m.ValueType() != wire.TI32 {
+		return nil, nil
+	}
+	o := make(map[int32]int32, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetI32(), error(nil)
+		if err != nil {
+			return err
+		}
+		v, err := x.Value.GetI32(), error(nil)
+		if err != nil {
+			return err
+		}
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+func (v *ThriftTest_TestMap_Args) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TMap {
+				v.Thing, err = _Map_I32_I32_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("ThriftTest_TestMap_Args", "Thing", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestMap_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	if v.Thing != nil {
+		fields[i] = fmt.Sprintf("Thing: %v", v.Thing)
+		i++
+	}
+	return fmt.Sprintf("ThriftTest_TestMap_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Map_I32_I32_Equals(lhs, rhs map[int32]int32) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *ThriftTest_TestMap_Args) Equals(rhs *ThriftTest_TestMap_Args) bool {
+	if !((v.Thing == nil && rhs.Thing == nil) || (v.Thing != nil && rhs.Thing != nil && _Map_I32_I32_Equals(v.Thing, rhs.Thing))) {
+		return false
+	}
+	return true
+}
+
+func (v *ThriftTest_TestMap_Args) GetThing() (o map[int32]int32) {
+	if v != nil && v.Thing != nil {
+		return v.Thing
+	}
+	return
+}
+
+func (v *ThriftTest_TestMap_Args) MethodName() string {
+	return "testMap"
+}
+
+func (v *ThriftTest_TestMap_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+var ThriftTest_TestMap_Helper = struct {
+	Args            func(thing map[int32]int32) *ThriftTest_TestMap_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*ThriftTest_TestMap_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func(map[int32]int32, error) (*ThriftTest_TestMap_Result, error)
+	UnwrapResponse  func(*ThriftTest_TestMap_Result) (map[int32]int32, error)
+}{}
+
+func init() {
+	ThriftTest_TestMap_Helper.Args = func(thing map[int32]int32) *ThriftTest_TestMap_Args {
+		return &ThriftTest_TestMap_Args{Thing: thing}
+	}
+	ThriftTest_TestMap_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*ThriftTest_TestMap_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args ThriftTest_TestMap_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
+	ThriftTest_TestMap_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		default:
+			return false
+		}
+	}
+	ThriftTest_TestMap_Helper.IsSafeException = func(error) bool {
+		return false
+	}
+	ThriftTest_TestMap_Helper.WrapResponse = func(success map[int32]int32, err error) (*ThriftTest_TestMap_Result, error) {
+		if err == nil {
+			return &ThriftTest_TestMap_Result{Success: success}, nil
+		}
+		return nil, err
+	}
+	ThriftTest_TestMap_Helper.UnwrapResponse = func(result *ThriftTest_TestMap_Result) (success map[int32]int32, err error) {
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+		err = errors.New("expected a non-void result")
+		return
+	}
+}
+
+type ThriftTest_TestMap_ArgsOption func(*ThriftTest_TestMap_Args)
+
+func ThriftTest_TestMap_WithThing(v map[int32]int32) ThriftTest_TestMap_ArgsOption {
+	return func(args *ThriftTest_TestMap_Args) {
+		args.Thing = v
+	}
+}
+
+func ThriftTest_TestMap_NewArgs(opts ...ThriftTest_TestMap_ArgsOption) *ThriftTest_TestMap_Args {
+	args := &ThriftTest_TestMap_Args{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	return args
+}
+
+type ThriftTest_TestMap_Result struct {
+	Success map[int32]int32 `json:"success"`
+}
+
+func (v *ThriftTest_TestMap_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Success != nil {
+		w, err = wire.NewValueMap(_Map_I32_I32_MapItemList(v.Success)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("ThriftTest_TestMap_Result should have exactly one field: got %v fields", i)
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *ThriftTest_TestMap_Result) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TMap {
+				v.Success, err = _Map_I32_I32_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("ThriftTest_TestMap_Result", "Success", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		wire.ObserveDecodeError("ThriftTest_TestMap_Result", "", wire.DecodeErrorInvalidUnion)
+		return fmt.Errorf("ThriftTest_TestMap_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestMap_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
+	return fmt.Sprintf("ThriftTest_TestMap_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *ThriftTest_TestMap_Result) Equals(rhs *ThriftTest_TestMap_Result) bool {
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && _Map_I32_I32_Equals(v.Success, rhs.Success))) {
+		return false
+	}
+	return true
+}
+
+func (v *ThriftTest_TestMap_Result) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("ThriftTest_TestMap_Result should have exactly one field: got %v fields", count)
+	}
+	type plain ThriftTest_TestMap_Result
+	return json.Marshal((*plain)(v))
+}
+
+func (v *ThriftTest_TestMap_Result) UnmarshalJSON(text []byte) error {
+	type plain ThriftTest_TestMap_Result
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("ThriftTest_TestMap_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestMap_Result) GetSuccess() (o map[int32]int32) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+	return
+}
+
+func (v *ThriftTest_TestMap_Result) MethodName() string {
+	return "testMap"
+}
+
+func (v *ThriftTest_TestMap_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+func ThriftTest_TestMap_NewResultSuccess(success map[int32]int32) *ThriftTest_TestMap_Result {
+	return &ThriftTest_TestMap_Result{Success: success}
+}
+
+func ThriftTest_TestMap_NewResultError(err error) (*ThriftTest_TestMap_Result, error) {
+	if err == nil {
+		return nil, errors.New("ThriftTest_TestMap_NewResultError received a nil error")
+	}
+	return nil, err
+}
@@ -0,0 +1,146 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thrifttest
+
+import (
+	"bytes"
+	"fmt"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+	"strings"
+)
+
+type ThriftTest_TestOneway_Args struct {
+	SecondsToSleep *int32 `json:"secondsToSleep,omitempty"`
+}
+
+func (v *ThriftTest_TestOneway_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.SecondsToSleep != nil {
+		w, err = wire.NewValueI32(*(v.SecondsToSleep)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *ThriftTest_TestOneway_Args) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.SecondsToSleep = &x
+				if err != nil {
+					wire.ObserveDecodeError("ThriftTest_TestOneway_Args", "SecondsToSleep", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestOneway_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	if v.SecondsToSleep != nil {
+		fields[i] = fmt.Sprintf("SecondsToSleep: %v", *(v.SecondsToSleep))
+		i++
+	}
+	return fmt.Sprintf("ThriftTest_TestOneway_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *ThriftTest_TestOneway_Args) Equals(rhs *ThriftTest_TestOneway_Args) bool {
+	if !_I32_EqualsPtr(v.SecondsToSleep, rhs.SecondsToSleep) {
+		return false
+	}
+	return true
+}
+
+func (v *ThriftTest_TestOneway_Args) GetSecondsToSleep() (o int32) {
+	if v != nil && v.SecondsToSleep != nil {
+		return *v.SecondsToSleep
+	}
+	return
+}
+
+func (v *ThriftTest_TestOneway_Args) MethodName() string {
+	return "testOneway"
+}
+
+func (v *ThriftTest_TestOneway_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.OneWay
+}
+
+var ThriftTest_TestOneway_Helper = struct {
+	Args       func(secondsToSleep *int32) *ThriftTest_TestOneway_Args
+	DecodeArgs func(protocol.Protocol, []byte) (*ThriftTest_TestOneway_Args, error)
+}{}
+
+func init() {
+	ThriftTest_TestOneway_Helper.Args = func(secondsToSleep *int32) *ThriftTest_TestOneway_Args {
+		return &ThriftTest_TestOneway_Args{SecondsToSleep: secondsToSleep}
+	}
+	ThriftTest_TestOneway_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*ThriftTest_TestOneway_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args ThriftTest_TestOneway_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
+}
+
+type ThriftTest_TestOneway_ArgsOption func(*ThriftTest_TestOneway_Args)
+
+func ThriftTest_TestOneway_WithSecondsToSleep(v int32) ThriftTest_TestOneway_ArgsOption {
+	return func(args *ThriftTest_TestOneway_Args) {
+		args.SecondsToSleep = &v
+	}
+}
+
+func ThriftTest_TestOneway_NewArgs(opts ...ThriftTest_TestOneway_ArgsOption) *ThriftTest_TestOneway_Args {
+	args := &ThriftTest_TestOneway_Args{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	return args
+}
@@ -0,0 +1,352 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thrifttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+	"strings"
+)
+
+type ThriftTest_TestSet_Args struct {
+	Thing map[int32]struct{} `json:"thing"`
+}
+
+type _Set_I32_ValueList map[int32]struct{}
+
+func (v _Set_I32_ValueList) ForEach(f func(wire.Value) error) error {
+	for x := range v {
+		w, err := wire.NewValueI32(x), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _Set_I32_ValueList) Size() int {
+	return len(v)
+}
+
+func (_Set_I32_ValueList) ValueType() wire.Type {
+	return wire.TI32
+}
+
+func (_Set_I32_ValueList) Close() {
+}
+
+func (v *ThriftTest_TestSet_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Thing != nil {
+		w, err = wire.NewValueSet(_Set_I32_ValueList(v.Thing)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Set_I32_Read(s wire.ValueList) (map[int32]struct{}, error) {
+	if s.ValueType() != wire.TI32 {
+		return nil, nil
+	}
+	o := make(map[int32]struct{}, s.Size())
+	err := s.ForEach(func(x wire.Value) error {
+		i, err := x.GetI32(), error(nil)
+		if err != nil {
+			return err
+		}
+		o[i] = struct{}{}
+		return nil
+	})
+	s.Close()
+	return o, err
+}
+
+func (v *ThriftTest_TestSet_Args) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TSet {
+				v.Thing, err = _Set_I32_Read(field.Value.GetSet())
+				if err != nil {
+					wire.ObserveDecodeError("ThriftTest_TestSet_Args", "Thing", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestSet_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	if v.Thing != nil {
+		fields[i] = fmt.Sprintf("Thing: %v", v.Thing)
+		i++
+	}
+	return fmt.Sprintf("ThriftTest_TestSet_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Set_I32_Equals(lhs, rhs map[int32]struct{}) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for x := range rhs {
+		if _, ok := lhs[x]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *ThriftTest_TestSet_Args) Equals(rhs *ThriftTest_TestSet_Args) bool {
+	if !((v.Thing == nil && rhs.Thing == nil) || (v.Thing != nil && rhs.Thing != nil && _Set_I32_Equals(v.Thing, rhs.Thing))) {
+		return false
+	}
+	return true
+}
+
+func (v *ThriftTest_TestSet_Args) GetThing() (o map[int32]struct{}) {
+	if v != nil && v.Thing != nil {
+		return v.Thing
+	}
+	return
+}
+
+func (v *ThriftTest_TestSet_Args) MethodName() string {
+	return "testSet"
+}
+
+func (v *ThriftTest_TestSet_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+var ThriftTest_TestSet_Helper = struct {
+	Args            func(thing map[int32]struct{}) *ThriftTest_TestSet_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*ThriftTest_TestSet_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func(map[int32]struct{}, error) (*ThriftTest_TestSet_Result, error)
+	UnwrapResponse  func(*ThriftTest_TestSet_Result) (map[int32]struct{}, error)
+}{}
+
+func init() {
+	ThriftTest_TestSet_Helper.Args = func(thing map[int32]struct{}) *ThriftTest_TestSet_Args {
+		return &ThriftTest_TestSet_Args{Thing: thing}
+	}
+	ThriftTest_TestSet_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*ThriftTest_TestSet_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args ThriftTest_TestSet_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
+	ThriftTest_TestSet_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		default:
+			return false
+		}
+	}
+	ThriftTest_TestSet_Helper.IsSafeException = func(error) bool {
+		return false
+	}
+	ThriftTest_TestSet_Helper.WrapResponse = func(success map[int32]struct{}, err error) (*ThriftTest_TestSet_Result, error) {
+		if err == nil {
+			return &ThriftTest_TestSet_Result{Success: success}, nil
+		}
+		return nil, err
+	}
+	ThriftTest_TestSet_Helper.UnwrapResponse = func(result *ThriftTest_TestSet_Result) (success map[int32]struct{}, err error) {
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+		err = errors.New("expected a non-void result")
+		return
+	}
+}
+
+type ThriftTest_TestSet_ArgsOption func(*ThriftTest_TestSet_Args)
+
+func ThriftTest_TestSet_WithThing(v map[int32]struct{}) ThriftTest_TestSet_ArgsOption {
+	return func(args *ThriftTest_TestSet_Args) {
+		args.Thing = v
+	}
+}
+
+func ThriftTest_TestSet_NewArgs(opts ...ThriftTest_TestSet_ArgsOption) *ThriftTest_TestSet_Args {
+	args := &ThriftTest_TestSet_Args{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	return args
+}
+
+type ThriftTest_TestSet_Result struct {
+	Success map[int32]struct{} `json:"success"`
+}
+
+func (v *ThriftTest_TestSet_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Success != nil {
+		w, err = wire.NewValueSet(_Set_I32_ValueList(v.Success)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("ThriftTest_TestSet_Result should have exactly one field: got %v fields", i)
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *ThriftTest_TestSet_Result) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TSet {
+				v.Success, err = _Set_I32_Read(field.Value.GetSet())
+				if err != nil {
+					wire.ObserveDecodeError("ThriftTest_TestSet_Result", "Success", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		wire.ObserveDecodeError("ThriftTest_TestSet_Result", "", wire.DecodeErrorInvalidUnion)
+		return fmt.Errorf("ThriftTest_TestSet_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestSet_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
+	return fmt.Sprintf("ThriftTest_TestSet_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *ThriftTest_TestSet_Result) Equals(rhs *ThriftTest_TestSet_Result) bool {
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && _Set_I32_Equals(v.Success, rhs.Success))) {
+		return false
+	}
+	return true
+}
+
+func (v *ThriftTest_TestSet_Result) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("ThriftTest_TestSet_Result should have exactly one field: got %v fields", count)
+	}
+	type plain ThriftTest_TestSet_Result
+	return json.Marshal((*plain)(v))
+}
+
+func (v *ThriftTest_TestSet_Result) UnmarshalJSON(text []byte) error {
+	type plain ThriftTest_TestSet_Result
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("ThriftTest_TestSet_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestSet_Result) GetSuccess() (o map[int32]struct{}) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+	return
+}
+
+func (v *ThriftTest_TestSet_Result) MethodName() string {
+	return "testSet"
+}
+
+func (v *ThriftTest_TestSet_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+func ThriftTest_TestSet_NewResultSuccess(success map[int32]struct{}) *ThriftTest_TestSet_Result {
+	return &ThriftTest_TestSet_Result{Success: success}
+}
+
+func ThriftTest_TestSet_NewResultError(err error) (*ThriftTest_TestSet_Result, error) {
+	if err == nil {
+		return nil, errors.New("ThriftTest_TestSet_NewResultError received a nil error")
+	}
+	return nil, err
+}
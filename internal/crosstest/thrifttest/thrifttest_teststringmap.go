@@ -0,0 +1,371 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thrifttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+	"strings"
+)
+
+type ThriftTest_TestStringMap_Args struct {
+	Thing map[string]string `json:"thing"`
+}
+
+type _Map_String_String_MapItemList map[string]string
+
+func (m _Map_String_String_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
+		vw, err := wire.NewValueString(v), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_String_String_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_String_String_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_String_MapItemList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_String_MapItemList) Close() {
+}
+
+func (v *ThriftTest_TestStringMap_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Thing != nil {
+		w, err = wire.NewValueMap(_Map_String_String_MapItemList(v.Thing)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Map_String_String_Read(m wire.MapItemList) (map[string]string, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+	if m.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+	o := make(map[string]string, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		v, err := x.Value.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+func (v *ThriftTest_TestStringMap_Args) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TMap {
+				v.Thing, err = _Map_String_String_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("ThriftTest_TestStringMap_Args", "Thing", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestStringMap_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	if v.Thing != nil {
+		fields[i] = fmt.Sprintf("Thing: %v", v.Thing)
+		i++
+	}
+	return fmt.Sprintf("ThriftTest_TestStringMap_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Map_String_String_Equals(lhs, rhs map[string]string) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *ThriftTest_TestStringMap_Args) Equals(rhs *ThriftTest_TestStringMap_Args) bool {
+	if !((v.Thing == nil && rhs.Thing == nil) || (v.Thing != nil && rhs.Thing != nil && _Map_String_String_Equals(v.Thing, rhs.Thing))) {
+		return false
+	}
+	return true
+}
+
+func (v *ThriftTest_TestStringMap_Args) GetThing() (o map[string]string) {
+	if v != nil && v.Thing != nil {
+		return v.Thing
+	}
+	return
+}
+
+func (v *ThriftTest_TestStringMap_Args) MethodName() string {
+	return "testStringMap"
+}
+
+func (v *ThriftTest_TestStringMap_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+var ThriftTest_TestStringMap_Helper = struct {
+	Args            func(thing map[string]string) *ThriftTest_TestStringMap_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*ThriftTest_TestStringMap_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func(map[string]string, error) (*ThriftTest_TestStringMap_Result, error)
+	UnwrapResponse  func(*ThriftTest_TestStringMap_Result) (map[string]string, error)
+}{}
+
+func init() {
+	ThriftTest_TestStringMap_Helper.Args = func(thing map[string]string) *ThriftTest_TestStringMap_Args {
+		return &ThriftTest_TestStringMap_Args{Thing: thing}
+	}
+	ThriftTest_TestStringMap_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*ThriftTest_TestStringMap_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args ThriftTest_TestStringMap_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
+	ThriftTest_TestStringMap_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		default:
+			return false
+		}
+	}
+	ThriftTest_TestStringMap_Helper.IsSafeException = func(error) bool {
+		return false
+	}
+	ThriftTest_TestStringMap_Helper.WrapResponse = func(success map[string]string, err error) (*ThriftTest_TestStringMap_Result, error) {
+		if err == nil {
+			return &ThriftTest_TestStringMap_Result{Success: success}, nil
+		}
+		return nil, err
+	}
+	ThriftTest_TestStringMap_Helper.UnwrapResponse = func(result *ThriftTest_TestStringMap_Result) (success map[string]string, err error) {
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+		err = errors.New("expected a non-void result")
+		return
+	}
+}
+
+type ThriftTest_TestStringMap_ArgsOption func(*ThriftTest_TestStringMap_Args)
+
+func ThriftTest_TestStringMap_WithThing(v map[string]string) ThriftTest_TestStringMap_ArgsOption {
+	return func(args *ThriftTest_TestStringMap_Args) {
+		args.Thing = v
+	}
+}
+
+func ThriftTest_TestStringMap_NewArgs(opts ...ThriftTest_TestStringMap_ArgsOption) *ThriftTest_TestStringMap_Args {
+	args := &ThriftTest_TestStringMap_Args{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	return args
+}
+
+type ThriftTest_TestStringMap_Result struct {
+	Success map[string]string `json:"success"`
+}
+
+func (v *ThriftTest_TestStringMap_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Success != nil {
+		w, err = wire.NewValueMap(_Map_String_String_MapItemList(v.Success)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("ThriftTest_TestStringMap_Result should have exactly one field: got %v fields", i)
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *ThriftTest_TestStringMap_Result) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TMap {
+				v.Success, err = _Map_String_String_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("ThriftTest_TestStringMap_Result", "Success", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		wire.ObserveDecodeError("ThriftTest_TestStringMap_Result", "", wire.DecodeErrorInvalidUnion)
+		return fmt.Errorf("ThriftTest_TestStringMap_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestStringMap_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
+	return fmt.Sprintf("ThriftTest_TestStringMap_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *ThriftTest_TestStringMap_Result) Equals(rhs *ThriftTest_TestStringMap_Result) bool {
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && _Map_String_String_Equals(v.Success, rhs.Success))) {
+		return false
+	}
+	return true
+}
+
+func (v *ThriftTest_TestStringMap_Result) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("ThriftTest_TestStringMap_Result should have exactly one field: got %v fields", count)
+	}
+	type plain ThriftTest_TestStringMap_Result
+	return json.Marshal((*plain)(v))
+}
+
+func (v *ThriftTest_TestStringMap_Result) UnmarshalJSON(text []byte) error {
+	type plain ThriftTest_TestStringMap_Result
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("ThriftTest_TestStringMap_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *ThriftTest_TestStringMap_Result) GetSuccess() (o map[string]string) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+	return
+}
+
+func (v *ThriftTest_TestStringMap_Result) MethodName() string {
+	return "testStringMap"
+}
+
+func (v *ThriftTest_TestStringMap_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+func ThriftTest_TestStringMap_NewResultSuccess(success map[string]string) *ThriftTest_TestStringMap_Result {
+	return &ThriftTest_TestStringMap_Result{Success: success}
+}
+
+func ThriftTest_TestStringMap_NewResultError(err error) (*ThriftTest_TestStringMap_Result, error) {
+	if err == nil {
+		return nil, errors.New("ThriftTest_TestStringMap_NewResultError received a nil error")
+	}
+	return nil, err
+}
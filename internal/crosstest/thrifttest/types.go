@@ -0,0 +1,990 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thrifttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go.uber.org/thriftrw/wire"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type Insanity struct {
+	UserMap map[Numberz]UserId `json:"userMap"`
+	Xtructs []*Xtruct          `json:"xtructs"`
+}
+
+type _Map_Numberz_UserId_MapItemList map[Numberz]UserId
+
+func (m _Map_Numberz_UserId_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		kw, err := k.ToWire()
+		if err != nil {
+			return err
+		}
+		vw, err := v.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_Numberz_UserId_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_Numberz_UserId_MapItemList) KeyType() wire.Type {
+	return wire.TI32
+}
+
+func (_Map_Numberz_UserId_MapItemList) ValueType() wire.Type {
+	return wire.TI64
+}
+
+func (_Map_Numberz_UserId_MapItemList) Close() {
+}
+
+type _List_Xtruct_ValueList []*Xtruct
+
+func (v _List_Xtruct_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_Xtruct_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_Xtruct_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_Xtruct_ValueList) Close() {
+}
+
+func (v *Insanity) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.UserMap != nil {
+		w, err = wire.NewValueMap(_Map_Numberz_UserId_MapItemList(v.UserMap)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.Xtructs != nil {
+		w, err = wire.NewValueList(_List_Xtruct_ValueList(v.Xtructs)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Numberz_Read(w wire.Value) (Numberz, error) {
+	var v Numberz
+	err := v.FromWire(w)
+	return v, err
+}
+
+func _UserId_Read(w wire.Value) (UserId, error) {
+	var x UserId
+	err := x.FromWire(w)
+	return x, err
+}
+
+func _Map_Numberz_UserId_Read(m wire.MapItemList) (map[Numberz]UserId, error) {
+	if m.KeyType() != wire.TI32 {
+		return nil, nil
+	}
+	if m.ValueType() != wire.TI64 {
+		return nil, nil
+	}
+	o := make(map[Numberz]UserId, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := _Numberz_Read(x.Key)
+		if err != nil {
+			return err
+		}
+		v, err := _UserId_Read(x.Value)
+		if err != nil {
+			return err
+		}
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+func _Xtruct_Read(w wire.Value) (*Xtruct, error) {
+	var v Xtruct
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_Xtruct_Read(l wire.ValueList) ([]*Xtruct, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+	o := make([]*Xtruct, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _Xtruct_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func (v *Insanity) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TMap {
+				v.UserMap, err = _Map_Numberz_UserId_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("Insanity", "UserMap", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 2:
+			if field.Value.Type() == wire.TList {
+				v.Xtructs, err = _List_Xtruct_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("Insanity", "Xtructs", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *Insanity) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	if v.UserMap != nil {
+		fields[i] = fmt.Sprintf("UserMap: %v", v.UserMap)
+		i++
+	}
+	if v.Xtructs != nil {
+		fields[i] = fmt.Sprintf("Xtructs: %v", v.Xtructs)
+		i++
+	}
+	return fmt.Sprintf("Insanity{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Map_Numberz_UserId_Equals(lhs, rhs map[Numberz]UserId) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func _List_Xtruct_Equals(lhs, rhs []*Xtruct) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *Insanity) Equals(rhs *Insanity) bool {
+	if !((v.UserMap == nil && rhs.UserMap == nil) || (v.UserMap != nil && rhs.UserMap != nil && _Map_Numberz_UserId_Equals(v.UserMap, rhs.UserMap))) {
+		return false
+	}
+	if !((v.Xtructs == nil && rhs.Xtructs == nil) || (v.Xtructs != nil && rhs.Xtructs != nil && _List_Xtruct_Equals(v.Xtructs, rhs.Xtructs))) {
+		return false
+	}
+	return true
+}
+
+func (v *Insanity) GetUserMap() (o map[Numberz]UserId) {
+	if v != nil && v.UserMap != nil {
+		return v.UserMap
+	}
+	return
+}
+
+func (v *Insanity) GetXtructs() (o []*Xtruct) {
+	if v != nil && v.Xtructs != nil {
+		return v.Xtructs
+	}
+	return
+}
+
+type Numberz int32
+
+const (
+	NumberzOne   Numberz = 1
+	NumberzTwo   Numberz = 2
+	NumberzThree Numberz = 3
+	NumberzFive  Numberz = 5
+	NumberzSix   Numberz = 6
+	NumberzEight Numberz = 8
+)
+
+func Numberz_Values() []Numberz {
+	return []Numberz{NumberzOne, NumberzTwo, NumberzThree, NumberzFive, NumberzSix, NumberzEight}
+}
+
+func (v *Numberz) UnmarshalText(value []byte) error {
+	switch string(value) {
+	case "ONE":
+		*v = NumberzOne
+		return nil
+	case "TWO":
+		*v = NumberzTwo
+		return nil
+	case "THREE":
+		*v = NumberzThree
+		return nil
+	case "FIVE":
+		*v = NumberzFive
+		return nil
+	case "SIX":
+		*v = NumberzSix
+		return nil
+	case "EIGHT":
+		*v = NumberzEight
+		return nil
+	default:
+		val, err := strconv.ParseInt(string(value), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", value, "Numberz", err)
+		}
+		*v = Numberz(val)
+		return nil
+	}
+}
+
+func (v Numberz) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 1:
+		return []byte("ONE"), nil
+	case 2:
+		return []byte("TWO"), nil
+	case 3:
+		return []byte("THREE"), nil
+	case 5:
+		return []byte("FIVE"), nil
+	case 6:
+		return []byte("SIX"), nil
+	case 8:
+		return []byte("EIGHT"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+func (v Numberz) ToWire() (wire.Value, error) {
+	return wire.NewValueI32(int32(v)), nil
+}
+
+func (v *Numberz) FromWire(w wire.Value) error {
+	*v = (Numberz)(w.GetI32())
+	return nil
+}
+
+func (v Numberz) String() string {
+	w := int32(v)
+	switch w {
+	case 1:
+		return "ONE"
+	case 2:
+		return "TWO"
+	case 3:
+		return "THREE"
+	case 5:
+		return "FIVE"
+	case 6:
+		return "SIX"
+	case 8:
+		return "EIGHT"
+	}
+	return fmt.Sprintf("Numberz(%d)", w)
+}
+
+func (v Numberz) Equals(rhs Numberz) bool {
+	return v == rhs
+}
+
+func (v Numberz) MarshalJSON() ([]byte, error) {
+	switch int32(v) {
+	case 1:
+		return ([]byte)("\"ONE\""), nil
+	case 2:
+		return ([]byte)("\"TWO\""), nil
+	case 3:
+		return ([]byte)("\"THREE\""), nil
+	case 5:
+		return ([]byte)("\"FIVE\""), nil
+	case 6:
+		return ([]byte)("\"SIX\""), nil
+	case 8:
+		return ([]byte)("\"EIGHT\""), nil
+	}
+	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+}
+
+func (v *Numberz) UnmarshalJSON(text []byte) error {
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+	switch w := t.(type) {
+	case json.Number:
+		x, err := w.Int64()
+		if err != nil {
+			return err
+		}
+		if x > math.MaxInt32 {
+			return fmt.Errorf("enum overflow from JSON %q for %q", text, "Numberz")
+		}
+		if x < math.MinInt32 {
+			return fmt.Errorf("enum underflow from JSON %q for %q", text, "Numberz")
+		}
+		*v = (Numberz)(x)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(w))
+	default:
+		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "Numberz")
+	}
+}
+
+type UserId int64
+
+func (v UserId) ToWire() (wire.Value, error) {
+	x := (int64)(v)
+	return wire.NewValueI64(x), error(nil)
+}
+
+func (v UserId) String() string {
+	x := (int64)(v)
+	return fmt.Sprint(x)
+}
+
+func (v *UserId) FromWire(w wire.Value) error {
+	x, err := w.GetI64(), error(nil)
+	*v = (UserId)(x)
+	return err
+}
+
+func (lhs UserId) Equals(rhs UserId) bool {
+	return (lhs == rhs)
+}
+
+type Xception struct {
+	ErrorCode *int32  `json:"errorCode,omitempty"`
+	Message   *string `json:"message,omitempty"`
+}
+
+func (v *Xception) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.ErrorCode != nil {
+		w, err = wire.NewValueI32(*(v.ErrorCode)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.Message != nil {
+		w, err = wire.NewValueString(*(v.Message)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *Xception) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.ErrorCode = &x
+				if err != nil {
+					wire.ObserveDecodeError("Xception", "ErrorCode", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 2:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Message = &x
+				if err != nil {
+					wire.ObserveDecodeError("Xception", "Message", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *Xception) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	if v.ErrorCode != nil {
+		fields[i] = fmt.Sprintf("ErrorCode: %v", *(v.ErrorCode))
+		i++
+	}
+	if v.Message != nil {
+		fields[i] = fmt.Sprintf("Message: %v", *(v.Message))
+		i++
+	}
+	return fmt.Sprintf("Xception{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _I32_EqualsPtr(lhs, rhs *int32) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _String_EqualsPtr(lhs, rhs *string) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func (v *Xception) Equals(rhs *Xception) bool {
+	if !_I32_EqualsPtr(v.ErrorCode, rhs.ErrorCode) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Message, rhs.Message) {
+		return false
+	}
+	return true
+}
+
+func (v *Xception) GetErrorCode() (o int32) {
+	if v != nil && v.ErrorCode != nil {
+		return *v.ErrorCode
+	}
+	return
+}
+
+func (v *Xception) GetMessage() (o string) {
+	if v != nil && v.Message != nil {
+		return *v.Message
+	}
+	return
+}
+
+func (v *Xception) Error() string {
+	return v.String()
+}
+
+type Xception2 struct {
+	ErrorCode   *int32  `json:"errorCode,omitempty"`
+	StructThing *Xtruct `json:"struct_thing,omitempty"`
+}
+
+func (v *Xception2) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.ErrorCode != nil {
+		w, err = wire.NewValueI32(*(v.ErrorCode)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.StructThing != nil {
+		w, err = v.StructThing.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *Xception2) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.ErrorCode = &x
+				if err != nil {
+					wire.ObserveDecodeError("Xception2", "ErrorCode", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.StructThing, err = _Xtruct_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("Xception2", "StructThing", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *Xception2) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	if v.ErrorCode != nil {
+		fields[i] = fmt.Sprintf("ErrorCode: %v", *(v.ErrorCode))
+		i++
+	}
+	if v.StructThing != nil {
+		fields[i] = fmt.Sprintf("StructThing: %v", v.StructThing)
+		i++
+	}
+	return fmt.Sprintf("Xception2{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *Xception2) Equals(rhs *Xception2) bool {
+	if !_I32_EqualsPtr(v.ErrorCode, rhs.ErrorCode) {
+		return false
+	}
+	if !((v.StructThing == nil && rhs.StructThing == nil) || (v.StructThing != nil && rhs.StructThing != nil && v.StructThing.Equals(rhs.StructThing))) {
+		return false
+	}
+	return true
+}
+
+func (v *Xception2) GetErrorCode() (o int32) {
+	if v != nil && v.ErrorCode != nil {
+		return *v.ErrorCode
+	}
+	return
+}
+
+func (v *Xception2) GetStructThing() (o *Xtruct) {
+	if v != nil && v.StructThing != nil {
+		return v.StructThing
+	}
+	return
+}
+
+func (v *Xception2) Error() string {
+	return v.String()
+}
+
+type Xtruct struct {
+	StringThing *string `json:"string_thing,omitempty"`
+	ByteThing   *int8   `json:"byte_thing,omitempty"`
+	I32Thing    *int32  `json:"i32_thing,omitempty"`
+	I64Thing    *int64  `json:"i64_thing,omitempty"`
+}
+
+func (v *Xtruct) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.StringThing != nil {
+		w, err = wire.NewValueString(*(v.StringThing)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.ByteThing != nil {
+		w, err = wire.NewValueI8(*(v.ByteThing)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.I32Thing != nil {
+		w, err = wire.NewValueI32(*(v.I32Thing)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 9, Value: w}
+		i++
+	}
+	if v.I64Thing != nil {
+		w, err = wire.NewValueI64(*(v.I64Thing)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 11, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *Xtruct) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.StringThing = &x
+				if err != nil {
+					wire.ObserveDecodeError("Xtruct", "StringThing", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 4:
+			if field.Value.Type() == wire.TI8 {
+				var x int8
+				x, err = field.Value.GetI8(), error(nil)
+				v.ByteThing = &x
+				if err != nil {
+					wire.ObserveDecodeError("Xtruct", "ByteThing", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 9:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.I32Thing = &x
+				if err != nil {
+					wire.ObserveDecodeError("Xtruct", "I32Thing", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 11:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.I64Thing = &x
+				if err != nil {
+					wire.ObserveDecodeError("Xtruct", "I64Thing", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *Xtruct) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [4]string
+	i := 0
+	if v.StringThing != nil {
+		fields[i] = fmt.Sprintf("StringThing: %v", *(v.StringThing))
+		i++
+	}
+	if v.ByteThing != nil {
+		fields[i] = fmt.Sprintf("ByteThing: %v", *(v.ByteThing))
+		i++
+	}
+	if v.I32Thing != nil {
+		fields[i] = fmt.Sprintf("I32Thing: %v", *(v.I32Thing))
+		i++
+	}
+	if v.I64Thing != nil {
+		fields[i] = fmt.Sprintf("I64Thing: %v", *(v.I64Thing))
+		i++
+	}
+	return fmt.Sprintf("Xtruct{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Byte_EqualsPtr(lhs, rhs *int8) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _I64_EqualsPtr(lhs, rhs *int64) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func (v *Xtruct) Equals(rhs *Xtruct) bool {
+	if !_String_EqualsPtr(v.StringThing, rhs.StringThing) {
+		return false
+	}
+	if !_Byte_EqualsPtr(v.ByteThing, rhs.ByteThing) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.I32Thing, rhs.I32Thing) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.I64Thing, rhs.I64Thing) {
+		return false
+	}
+	return true
+}
+
+func (v *Xtruct) GetStringThing() (o string) {
+	if v != nil && v.StringThing != nil {
+		return *v.StringThing
+	}
+	return
+}
+
+func (v *Xtruct) GetByteThing() (o int8) {
+	if v != nil && v.ByteThing != nil {
+		return *v.ByteThing
+	}
+	return
+}
+
+func (v *Xtruct) GetI32Thing() (o int32) {
+	if v != nil && v.I32Thing != nil {
+		return *v.I32Thing
+	}
+	return
+}
+
+func (v *Xtruct) GetI64Thing() (o int64) {
+	if v != nil && v.I64Thing != nil {
+		return *v.I64Thing
+	}
+	return
+}
+
+type Xtruct2 struct {
+	ByteThing   *int8   `json:"byte_thing,omitempty"`
+	StructThing *Xtruct `json:"struct_thing,omitempty"`
+	I32Thing    *int32  `json:"i32_thing,omitempty"`
+}
+
+func (v *Xtruct2) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.ByteThing != nil {
+		w, err = wire.NewValueI8(*(v.ByteThing)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if v.StructThing != nil {
+		w, err = v.StructThing.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.I32Thing != nil {
+		w, err = wire.NewValueI32(*(v.I32Thing)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *Xtruct2) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TI8 {
+				var x int8
+				x, err = field.Value.GetI8(), error(nil)
+				v.ByteThing = &x
+				if err != nil {
+					wire.ObserveDecodeError("Xtruct2", "ByteThing", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 2:
+			if field.Value.Type() == wire.TStruct {
+				v.StructThing, err = _Xtruct_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("Xtruct2", "StructThing", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 3:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.I32Thing = &x
+				if err != nil {
+					wire.ObserveDecodeError("Xtruct2", "I32Thing", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *Xtruct2) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [3]string
+	i := 0
+	if v.ByteThing != nil {
+		fields[i] = fmt.Sprintf("ByteThing: %v", *(v.ByteThing))
+		i++
+	}
+	if v.StructThing != nil {
+		fields[i] = fmt.Sprintf("StructThing: %v", v.StructThing)
+		i++
+	}
+	if v.I32Thing != nil {
+		fields[i] = fmt.Sprintf("I32Thing: %v", *(v.I32Thing))
+		i++
+	}
+	return fmt.Sprintf("Xtruct2{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *Xtruct2) Equals(rhs *Xtruct2) bool {
+	if !_Byte_EqualsPtr(v.ByteThing, rhs.ByteThing) {
+		return false
+	}
+	if !((v.StructThing == nil && rhs.StructThing == nil) || (v.StructThing != nil && rhs.StructThing != nil && v.StructThing.Equals(rhs.StructThing))) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.I32Thing, rhs.I32Thing) {
+		return false
+	}
+	return true
+}
+
+func (v *Xtruct2) GetByteThing() (o int8) {
+	if v != nil && v.ByteThing != nil {
+		return *v.ByteThing
+	}
+	return
+}
+
+func (v *Xtruct2) GetStructThing() (o *Xtruct) {
+	if v != nil && v.StructThing != nil {
+		return v.StructThing
+	}
+	return
+}
+
+func (v *Xtruct2) GetI32Thing() (o int32) {
+	if v != nil && v.I32Thing != nil {
+		return *v.I32Thing
+	}
+	return
+}
@@ -0,0 +1,544 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package docgen generates browsable documentation for compiled Thrift
+// modules.
+//
+// One page is generated for each Thrift file. Pages list the constants,
+// types, and services defined in the file along with their doc comments,
+// and link to the pages of included files wherever their types are
+// referenced.
+package docgen
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/compile"
+)
+
+// Format is the format of generated documentation.
+type Format int
+
+const (
+	// Markdown generates a Markdown file for each Thrift file.
+	Markdown Format = iota
+
+	// HTML generates a standalone HTML page for each Thrift file.
+	HTML
+)
+
+// ParseFormat parses the name of a Format.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "markdown", "md":
+		return Markdown, nil
+	case "html":
+		return HTML, nil
+	default:
+		return 0, fmt.Errorf(`unknown documentation format %q: must be "markdown" or "html"`, s)
+	}
+}
+
+// Extension returns the file extension used for pages in this format.
+func (f Format) Extension() string {
+	if f == HTML {
+		return ".html"
+	}
+	return ".md"
+}
+
+// Options controls how documentation is generated.
+type Options struct {
+	// Format of the generated pages.
+	Format Format
+
+	// ThriftRoot is the directory within whose tree all Thrift files are
+	// contained. Pages are laid out relative to it, mirroring the paths of
+	// the Thrift files.
+	//
+	// This must be an absolute path.
+	ThriftRoot string
+
+	// NoRecurse generates a page for only the given module. Links to types
+	// defined in included files still point to where their pages would be.
+	NoRecurse bool
+}
+
+// Generate generates documentation for the given module and, unless
+// NoRecurse is set, all modules included by it. The result maps paths
+// relative to the output directory to file contents.
+func Generate(m *compile.Module, o *Options) (map[string][]byte, error) {
+	if !filepath.IsAbs(o.ThriftRoot) {
+		return nil, fmt.Errorf(
+			"ThriftRoot must be an absolute path: %q is not absolute", o.ThriftRoot)
+	}
+
+	render := renderMarkdown
+	if o.Format == HTML {
+		render = renderHTML
+	}
+
+	files := make(map[string][]byte)
+	generate := func(m *compile.Module) error {
+		p, err := newPage(m, o)
+		if err != nil {
+			return err
+		}
+
+		var buff bytes.Buffer
+		if err := render(&buff, p); err != nil {
+			return fmt.Errorf("could not render documentation for %q: %v", m.ThriftPath, err)
+		}
+		files[p.Path] = buff.Bytes()
+		return nil
+	}
+
+	if o.NoRecurse {
+		return files, generate(m)
+	}
+	return files, m.Walk(generate)
+}
+
+// page is the documentation for a single Thrift file.
+type page struct {
+	Name string
+
+	// Path of the page and the Thrift file relative to the ThriftRoot.
+	Path, ThriftFile string
+
+	Includes  []link
+	Constants []constantDoc
+	Types     []typeDoc
+	Services  []serviceDoc
+}
+
+type link struct {
+	Text, Href string
+}
+
+// typeRef is a reference to a type, split into segments which may link to
+// the pages where the types were defined.
+//
+// 	map<string, [shared.UUID](shared.md#UUID)>
+type typeRef []link
+
+type constantDoc struct {
+	Name  string
+	Type  typeRef
+	Value string
+	Doc   string
+}
+
+type typeDoc struct {
+	Name string
+	Kind string // struct, union, exception, enum, or typedef
+	Doc  string
+
+	Target typeRef    // typedefs only
+	Fields []fieldDoc // structs only
+	Items  []itemDoc  // enums only
+}
+
+type fieldDoc struct {
+	ID       int16
+	Name     string
+	Type     typeRef
+	Required bool
+	Default  string
+	Doc      string
+
+	// Documentation hints specified with the doc.format and doc.example
+	// annotations.
+	Format, Example string
+}
+
+type itemDoc struct {
+	Name  string
+	Value int32
+	Doc   string
+}
+
+type serviceDoc struct {
+	Name      string
+	Parent    typeRef
+	Doc       string
+	Functions []functionDoc
+}
+
+type functionDoc struct {
+	Name       string
+	OneWay     bool
+	Returns    typeRef // nil for void
+	Args       []fieldDoc
+	Exceptions []fieldDoc
+	Doc        string
+}
+
+// pageBuilder builds the page for a module.
+type pageBuilder struct {
+	Module  *compile.Module
+	Options *Options
+
+	// Path of the page relative to ThriftRoot.
+	Path string
+}
+
+func newPage(m *compile.Module, o *Options) (*page, error) {
+	path, err := pagePath(m.ThriftPath, o)
+	if err != nil {
+		return nil, err
+	}
+	thriftFile, err := filepath.Rel(o.ThriftRoot, m.ThriftPath)
+	if err != nil {
+		return nil, err
+	}
+
+	b := pageBuilder{Module: m, Options: o, Path: path}
+	p := &page{Name: m.Name, Path: path, ThriftFile: thriftFile}
+
+	for _, name := range sortedKeys(m.Includes) {
+		inc := m.Includes[name]
+		href, err := b.href(inc.Module.ThriftPath, "")
+		if err != nil {
+			return nil, err
+		}
+		p.Includes = append(p.Includes, link{Text: name, Href: href})
+	}
+
+	for _, name := range sortedKeys(m.Constants) {
+		c := m.Constants[name]
+		typ, err := b.typeRef(c.Type)
+		if err != nil {
+			return nil, err
+		}
+		p.Constants = append(p.Constants, constantDoc{
+			Name:  name,
+			Type:  typ,
+			Value: valueString(c.Value),
+			Doc:   c.Doc,
+		})
+	}
+
+	for _, name := range sortedKeys(m.Types) {
+		t, err := b.typeDoc(m.Types[name])
+		if err != nil {
+			return nil, err
+		}
+		p.Types = append(p.Types, t)
+	}
+
+	for _, name := range sortedKeys(m.Services) {
+		s, err := b.serviceDoc(m.Services[name])
+		if err != nil {
+			return nil, err
+		}
+		p.Services = append(p.Services, s)
+	}
+
+	return p, nil
+}
+
+// pagePath returns the path of the page for the given Thrift file relative
+// to the ThriftRoot.
+func pagePath(thriftPath string, o *Options) (string, error) {
+	rel, err := filepath.Rel(o.ThriftRoot, thriftPath)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(rel, ".thrift") + o.Format.Extension(), nil
+}
+
+// href returns a link from the current page to the page for the given
+// Thrift file, pointing at the given anchor if non-empty.
+func (b *pageBuilder) href(thriftPath, anchor string) (string, error) {
+	var target string
+	if thriftPath != b.Module.ThriftPath {
+		path, err := pagePath(thriftPath, b.Options)
+		if err != nil {
+			return "", err
+		}
+
+		target, err = filepath.Rel(filepath.Dir(b.Path), path)
+		if err != nil {
+			return "", err
+		}
+		target = filepath.ToSlash(target)
+	}
+
+	if anchor != "" {
+		target += "#" + anchor
+	}
+	return target, nil
+}
+
+func (b *pageBuilder) typeRef(t compile.TypeSpec) (typeRef, error) {
+	var ref typeRef
+	text := func(s string) {
+		// Merge adjacent text-only segments.
+		if n := len(ref); n > 0 && ref[n-1].Href == "" {
+			ref[n-1].Text += s
+		} else {
+			ref = append(ref, link{Text: s})
+		}
+	}
+
+	var visit func(compile.TypeSpec) error
+	visit = func(t compile.TypeSpec) error {
+		switch t := t.(type) {
+		case *compile.MapSpec:
+			text("map<")
+			if err := visit(t.KeySpec); err != nil {
+				return err
+			}
+			text(", ")
+			if err := visit(t.ValueSpec); err != nil {
+				return err
+			}
+			text(">")
+		case *compile.ListSpec:
+			text("list<")
+			if err := visit(t.ValueSpec); err != nil {
+				return err
+			}
+			text(">")
+		case *compile.SetSpec:
+			text("set<")
+			if err := visit(t.ValueSpec); err != nil {
+				return err
+			}
+			text(">")
+		default:
+			if t.ThriftFile() == "" {
+				text(t.ThriftName())
+				return nil
+			}
+
+			href, err := b.href(t.ThriftFile(), t.ThriftName())
+			if err != nil {
+				return err
+			}
+			ref = append(ref, link{Text: b.qualifiedName(t), Href: href})
+		}
+		return nil
+	}
+
+	return ref, visit(t)
+}
+
+// qualifiedName returns the name by which the given user-defined type is
+// referenced from the current module.
+func (b *pageBuilder) qualifiedName(t compile.TypeSpec) string {
+	if t.ThriftFile() == b.Module.ThriftPath {
+		return t.ThriftName()
+	}
+	for _, name := range sortedKeys(b.Module.Includes) {
+		if b.Module.Includes[name].Module.ThriftPath == t.ThriftFile() {
+			return name + "." + t.ThriftName()
+		}
+	}
+
+	// Types of modules that were included transitively.
+	return strings.TrimSuffix(filepath.Base(t.ThriftFile()), ".thrift") + "." + t.ThriftName()
+}
+
+func (b *pageBuilder) typeDoc(t compile.TypeSpec) (typeDoc, error) {
+	doc := typeDoc{Name: t.ThriftName()}
+	switch t := t.(type) {
+	case *compile.StructSpec:
+		fields, err := b.fieldDocs(t.Fields)
+		if err != nil {
+			return doc, err
+		}
+		doc.Kind = structKind(t)
+		doc.Doc = t.Doc
+		doc.Fields = fields
+	case *compile.EnumSpec:
+		doc.Kind = "enum"
+		doc.Doc = t.Doc
+		for _, item := range t.Items {
+			doc.Items = append(doc.Items, itemDoc{
+				Name:  item.Name,
+				Value: item.Value,
+				Doc:   item.Doc,
+			})
+		}
+	case *compile.TypedefSpec:
+		target, err := b.typeRef(t.Target)
+		if err != nil {
+			return doc, err
+		}
+		doc.Kind = "typedef"
+		doc.Doc = t.Doc
+		doc.Target = target
+	default:
+		return doc, fmt.Errorf("unknown type %q of type %T", t.ThriftName(), t)
+	}
+	return doc, nil
+}
+
+func structKind(s *compile.StructSpec) string {
+	if s.IsExceptionType() {
+		return "exception"
+	}
+	if s.Type == ast.UnionType {
+		return "union"
+	}
+	return "struct"
+}
+
+func (b *pageBuilder) fieldDocs(fields compile.FieldGroup) ([]fieldDoc, error) {
+	docs := make([]fieldDoc, 0, len(fields))
+	for _, f := range fields {
+		typ, err := b.typeRef(f.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		hints := compile.LookupDoc(f)
+		docs = append(docs, fieldDoc{
+			ID:       f.ID,
+			Name:     f.Name,
+			Type:     typ,
+			Required: f.Required,
+			Default:  valueString(f.Default),
+			Doc:      f.Doc,
+			Format:   hints.Format,
+			Example:  hints.Example,
+		})
+	}
+	return docs, nil
+}
+
+func (b *pageBuilder) serviceDoc(s *compile.ServiceSpec) (serviceDoc, error) {
+	doc := serviceDoc{Name: s.Name, Doc: s.Doc}
+	if s.Parent != nil {
+		href, err := b.href(s.Parent.File, s.Parent.Name)
+		if err != nil {
+			return doc, err
+		}
+		doc.Parent = typeRef{{Text: b.qualifiedServiceName(s.Parent), Href: href}}
+	}
+
+	for _, name := range sortedKeys(s.Functions) {
+		f := s.Functions[name]
+		args, err := b.fieldDocs(compile.FieldGroup(f.ArgsSpec))
+		if err != nil {
+			return doc, err
+		}
+
+		fdoc := functionDoc{Name: name, OneWay: f.OneWay, Args: args, Doc: f.Doc}
+		if f.ResultSpec != nil {
+			if f.ResultSpec.ReturnType != nil {
+				fdoc.Returns, err = b.typeRef(f.ResultSpec.ReturnType)
+				if err != nil {
+					return doc, err
+				}
+			}
+			fdoc.Exceptions, err = b.fieldDocs(f.ResultSpec.Exceptions)
+			if err != nil {
+				return doc, err
+			}
+		}
+		doc.Functions = append(doc.Functions, fdoc)
+	}
+	return doc, nil
+}
+
+func (b *pageBuilder) qualifiedServiceName(s *compile.ServiceSpec) string {
+	if s.File == b.Module.ThriftPath {
+		return s.Name
+	}
+	for _, name := range sortedKeys(b.Module.Includes) {
+		if b.Module.Includes[name].Module.ThriftPath == s.File {
+			return name + "." + s.Name
+		}
+	}
+	return strings.TrimSuffix(filepath.Base(s.File), ".thrift") + "." + s.Name
+}
+
+// valueString returns a Thrift representation of the given constant value,
+// or an empty string if the value is nil.
+func valueString(v compile.ConstantValue) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case compile.ConstantBool:
+		return strconv.FormatBool(bool(v))
+	case compile.ConstantInt:
+		return strconv.FormatInt(int64(v), 10)
+	case compile.ConstantDouble:
+		return strconv.FormatFloat(float64(v), 'g', -1, 64)
+	case compile.ConstantString:
+		return strconv.Quote(string(v))
+	case compile.ConstReference:
+		return v.Target.Name
+	case compile.EnumItemReference:
+		return v.Enum.Name + "." + v.Item.Name
+	case compile.ConstantList:
+		return "[" + joinValues(v) + "]"
+	case compile.ConstantSet:
+		return "[" + joinValues(v) + "]"
+	case compile.ConstantMap:
+		items := make([]string, len(v))
+		for i, pair := range v {
+			items[i] = valueString(pair.Key) + ": " + valueString(pair.Value)
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	case *compile.ConstantStruct:
+		names := sortedKeys(v.Fields)
+		items := make([]string, len(names))
+		for i, name := range names {
+			items[i] = strconv.Quote(name) + ": " + valueString(v.Fields[name])
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func joinValues(vs []compile.ConstantValue) string {
+	items := make([]string, len(vs))
+	for i, v := range vs {
+		items[i] = valueString(v)
+	}
+	return strings.Join(items, ", ")
+}
+
+// sortedKeys returns the keys of the given map[string]* in sorted order.
+func sortedKeys(m interface{}) []string {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		panic(fmt.Sprintf("sortedKeys may be called with a map[string]* only: got %T", m))
+	}
+
+	keys := make([]string, 0, v.Len())
+	for _, k := range v.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package docgen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compileFiles(t *testing.T, files map[string]string, main string) (*compile.Module, string) {
+	dir, err := ioutil.TempDir("", "thriftrw-docgen-test")
+	require.NoError(t, err)
+
+	for path, contents := range files {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+
+	m, err := compile.Compile(filepath.Join(dir, main))
+	require.NoError(t, err)
+	return m, dir
+}
+
+var _testFiles = map[string]string{
+	"shared/types.thrift": `
+		/** A unique identifier. */
+		typedef string UUID (doc.format = "uuid")
+
+		service Base {}
+	`,
+	"users.thrift": `
+		include "./shared/types.thrift"
+
+		/** Default page size. */
+		const i32 pageSize = 20
+
+		/** A user. */
+		struct User {
+			/** ID of the user. */
+			1: required types.UUID id
+			2: optional string email (doc.example = "jane@example.com")
+			3: optional map<string, list<types.UUID>> groups
+		}
+
+		/** Manages users. */
+		service Users extends types.Base {
+			/** Gets a user. */
+			User getUser(1: types.UUID id)
+		}
+	`,
+}
+
+func TestGenerateMarkdown(t *testing.T) {
+	m, dir := compileFiles(t, _testFiles, "users.thrift")
+	defer os.RemoveAll(dir)
+
+	files, err := Generate(m, &Options{Format: Markdown, ThriftRoot: dir})
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	shared := string(files["shared/types.md"])
+	assert.Contains(t, shared, "# types\n")
+	assert.Contains(t, shared, "<a name=\"UUID\"></a>\n\n### UUID (typedef)\n\ntypedef string UUID\n\nA unique identifier.\n")
+
+	users := string(files["users.md"])
+	for _, want := range []string{
+		"Generated from `users.thrift`.",
+		"-   [types](shared/types.md)\n",
+		"const i32 pageSize = `20`\n\nDefault page size.\n",
+		"### User (struct)\n\nA user.\n",
+		"| 1 | id | [types.UUID](shared/types.md#UUID) | yes |  | ID of the user. |\n",
+		"| 2 | email | string | no |  | Example: `jane@example.com` |\n",
+		"| 3 | groups | map\\<string, list\\<[types.UUID](shared/types.md#UUID)\\>\\> | no |  |  |\n",
+		"Extends [types.Base](shared/types.md#Base).\n",
+		"<a name=\"Users.getUser\"></a>\n\n#### getUser\n\n[User](#User) getUser(1: [types.UUID](shared/types.md#UUID) id)\n\nGets a user.\n",
+	} {
+		assert.Contains(t, users, want)
+	}
+}
+
+func TestGenerateHTML(t *testing.T) {
+	m, dir := compileFiles(t, _testFiles, "users.thrift")
+	defer os.RemoveAll(dir)
+
+	files, err := Generate(m, &Options{Format: HTML, ThriftRoot: dir, NoRecurse: true})
+	require.NoError(t, err)
+	require.Len(t, files, 1, "only the root module must be documented")
+
+	users := string(files["users.html"])
+	for _, want := range []string{
+		"<title>users</title>",
+		`<li><a href="shared/types.html">types</a></li>`,
+		`<h3 id="User">User (struct)</h3>`,
+		`<td><a href="shared/types.html#UUID">types.UUID</a></td>`,
+		`<td>map&lt;string, list&lt;<a href="shared/types.html#UUID">types.UUID</a>&gt;&gt;</td>`,
+		`Example: <code>jane@example.com</code>`,
+		`<h4 id="Users.getUser">getUser</h4>`,
+		`<p class="signature"><a href="#User">User</a> getUser(1: <a href="shared/types.html#UUID">types.UUID</a> id)</p>`,
+	} {
+		assert.Contains(t, users, want)
+	}
+}
+
+func TestGenerateLinksFromNestedPages(t *testing.T) {
+	m, dir := compileFiles(t, map[string]string{
+		"a/common.thrift": `struct Thing {}`,
+		"b/c/main.thrift": `
+			include "../../a/common.thrift"
+			struct Wrapper { 1: optional common.Thing thing }
+		`,
+	}, "b/c/main.thrift")
+	defer os.RemoveAll(dir)
+
+	files, err := Generate(m, &Options{ThriftRoot: dir})
+	require.NoError(t, err)
+	assert.Contains(t, string(files["b/c/main.md"]), "[common.Thing](../../a/common.md#Thing)")
+}
+
+func TestGenerateRelativeThriftRoot(t *testing.T) {
+	m, dir := compileFiles(t, _testFiles, "users.thrift")
+	defer os.RemoveAll(dir)
+
+	_, err := Generate(m, &Options{ThriftRoot: "."})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "must be an absolute path")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		give      string
+		want      Format
+		wantError string
+	}{
+		{give: "markdown", want: Markdown},
+		{give: "md", want: Markdown},
+		{give: "HTML", want: HTML},
+		{give: "pdf", wantError: `unknown documentation format "pdf"`},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.give)
+		if tt.wantError != "" {
+			if assert.Error(t, err, tt.give) {
+				assert.Contains(t, err.Error(), tt.wantError, tt.give)
+			}
+			continue
+		}
+		if assert.NoError(t, err, tt.give) {
+			assert.Equal(t, tt.want, got, tt.give)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package docgen
+
+import (
+	"html/template"
+	"io"
+	"strings"
+)
+
+func renderHTML(w io.Writer, p *page) error {
+	return _htmlTemplate.Execute(w, p)
+}
+
+var _htmlTemplate = template.Must(template.New("page").Funcs(template.FuncMap{
+	"paragraphs": paragraphs,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Name}}</title>
+<style>
+body { font-family: sans-serif; max-width: 60em; margin: 2em auto; }
+code, .signature { font-family: monospace; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; vertical-align: top; }
+</style>
+</head>
+<body>
+<h1>{{.Name}}</h1>
+<p>Generated from <code>{{.ThriftFile}}</code>.</p>
+{{- if .Includes}}
+<h2>Includes</h2>
+<ul>
+{{- range .Includes}}
+<li><a href="{{.Href}}">{{.Text}}</a></li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Constants}}
+<h2>Constants</h2>
+{{- range .Constants}}
+<h3 id="{{.Name}}">{{.Name}}</h3>
+<p class="signature">const {{template "ref" .Type}} {{.Name}} = {{.Value}}</p>
+{{- template "doc" .Doc}}
+{{- end}}
+{{- end}}
+{{- if .Types}}
+<h2>Types</h2>
+{{- range .Types}}
+<h3 id="{{.Name}}">{{.Name}} ({{.Kind}})</h3>
+{{- if .Target}}
+<p class="signature">typedef {{template "ref" .Target}} {{.Name}}</p>
+{{- end}}
+{{- template "doc" .Doc}}
+{{- if .Fields}}
+{{- template "fields" .Fields}}
+{{- end}}
+{{- if .Items}}
+<table>
+<tr><th>Name</th><th>Value</th><th>Description</th></tr>
+{{- range .Items}}
+<tr><td>{{.Name}}</td><td>{{.Value}}</td><td>{{.Doc}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if .Services}}
+<h2>Services</h2>
+{{- range .Services}}
+{{- $service := .Name}}
+<h3 id="{{.Name}}">{{.Name}} (service)</h3>
+{{- if .Parent}}
+<p>Extends {{template "ref" .Parent}}.</p>
+{{- end}}
+{{- template "doc" .Doc}}
+{{- range .Functions}}
+<h4 id="{{$service}}.{{.Name}}">{{.Name}}</h4>
+<p class="signature">
+{{- if .OneWay}}oneway {{end -}}
+{{- if .Returns}}{{template "ref" .Returns}}{{else}}void{{end}} {{.Name}}({{template "params" .Args}})
+{{- if .Exceptions}} throws ({{template "params" .Exceptions}}){{end -}}
+</p>
+{{- template "doc" .Doc}}
+{{- if .Args}}
+<p>Arguments:</p>
+{{- template "fields" .Args}}
+{{- end}}
+{{- if .Exceptions}}
+<p>Exceptions:</p>
+{{- template "fields" .Exceptions}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+</body>
+</html>
+{{define "ref"}}{{range .}}{{if .Href}}<a href="{{.Href}}">{{.Text}}</a>{{else}}{{.Text}}{{end}}{{end}}{{end}}
+{{- define "params"}}{{range $i, $f := .}}{{if $i}}, {{end}}{{$f.ID}}: {{template "ref" $f.Type}} {{$f.Name}}{{end}}{{end}}
+{{- define "doc"}}{{range paragraphs .}}
+<p>{{.}}</p>
+{{- end}}{{end}}
+{{- define "fields"}}
+<table>
+<tr><th>ID</th><th>Name</th><th>Type</th><th>Required</th><th>Default</th><th>Description</th></tr>
+{{- range .}}
+<tr><td>{{.ID}}</td><td>{{.Name}}</td><td>{{template "ref" .Type}}</td><td>{{if .Required}}yes{{else}}no{{end}}</td><td>{{if .Default}}<code>{{.Default}}</code>{{end}}</td><td>
+{{- .Doc}}
+{{- if .Format}}<br>Format: <code>{{.Format}}</code>{{end}}
+{{- if .Example}}<br>Example: <code>{{.Example}}</code>{{end -}}
+</td></tr>
+{{- end}}
+</table>
+{{- end}}
+`))
+
+// paragraphs splits the text of a doc comment into paragraphs separated by
+// blank lines.
+func paragraphs(text string) []string {
+	var paras []string
+	for _, p := range strings.Split(text, "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			paras = append(paras, p)
+		}
+	}
+	return paras
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package docgen
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+func renderMarkdown(w io.Writer, p *page) error {
+	mw := markdownWriter{w: w}
+	mw.printf("# %s\n\n", mdEscape(p.Name))
+	mw.printf("Generated from `%s`.\n", p.ThriftFile)
+
+	if len(p.Includes) > 0 {
+		mw.printf("\n## Includes\n\n")
+		for _, inc := range p.Includes {
+			mw.printf("-   [%s](%s)\n", mdEscape(inc.Text), inc.Href)
+		}
+	}
+
+	if len(p.Constants) > 0 {
+		mw.printf("\n## Constants\n")
+		for _, c := range p.Constants {
+			mw.heading(3, c.Name, mdEscape(c.Name))
+			mw.printf("\nconst %s %s = %s\n", mdRef(c.Type), mdEscape(c.Name), mdCode(c.Value))
+			mw.doc(c.Doc)
+		}
+	}
+
+	if len(p.Types) > 0 {
+		mw.printf("\n## Types\n")
+		for _, t := range p.Types {
+			mw.heading(3, t.Name, fmt.Sprintf("%s (%s)", mdEscape(t.Name), t.Kind))
+			if t.Kind == "typedef" {
+				mw.printf("\ntypedef %s %s\n", mdRef(t.Target), mdEscape(t.Name))
+			}
+			mw.doc(t.Doc)
+
+			switch {
+			case len(t.Fields) > 0:
+				mw.fields(t.Fields)
+			case len(t.Items) > 0:
+				mw.printf("\n| Name | Value | Description |\n")
+				mw.printf("| ---- | ----- | ----------- |\n")
+				for _, item := range t.Items {
+					mw.printf("| %s | %d | %s |\n", mdEscape(item.Name), item.Value, mdCell(item.Doc))
+				}
+			}
+		}
+	}
+
+	if len(p.Services) > 0 {
+		mw.printf("\n## Services\n")
+		for _, s := range p.Services {
+			mw.heading(3, s.Name, mdEscape(s.Name)+" (service)")
+			if s.Parent != nil {
+				mw.printf("\nExtends %s.\n", mdRef(s.Parent))
+			}
+			mw.doc(s.Doc)
+
+			for _, f := range s.Functions {
+				mw.heading(4, s.Name+"."+f.Name, mdEscape(f.Name))
+				mw.printf("\n%s\n", mdSignature(f))
+				mw.doc(f.Doc)
+				if len(f.Args) > 0 {
+					mw.printf("\nArguments:\n")
+					mw.fields(f.Args)
+				}
+				if len(f.Exceptions) > 0 {
+					mw.printf("\nExceptions:\n")
+					mw.fields(f.Exceptions)
+				}
+			}
+		}
+	}
+
+	return mw.err
+}
+
+// markdownWriter writes Markdown to an io.Writer, retaining the first
+// error.
+type markdownWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (mw *markdownWriter) printf(format string, args ...interface{}) {
+	if mw.err == nil {
+		_, mw.err = fmt.Fprintf(mw.w, format, args...)
+	}
+}
+
+// heading writes a heading with an explicit anchor so that links don't
+// depend on how the renderer derives anchors from headings.
+func (mw *markdownWriter) heading(level int, anchor, text string) {
+	mw.printf("\n<a name=\"%s\"></a>\n\n%s %s\n", anchor, strings.Repeat("#", level), text)
+}
+
+// doc writes the text of a doc comment as a paragraph. Doc comments are
+// assumed to already be Markdown.
+func (mw *markdownWriter) doc(text string) {
+	if text != "" {
+		mw.printf("\n%s\n", text)
+	}
+}
+
+func (mw *markdownWriter) fields(fields []fieldDoc) {
+	mw.printf("\n| ID | Name | Type | Required | Default | Description |\n")
+	mw.printf("| -- | ---- | ---- | -------- | ------- | ----------- |\n")
+	for _, f := range fields {
+		required := "no"
+		if f.Required {
+			required = "yes"
+		}
+
+		desc := []string{mdCell(f.Doc)}
+		if f.Format != "" {
+			desc = append(desc, "Format: "+mdCode(f.Format))
+		}
+		if f.Example != "" {
+			desc = append(desc, "Example: "+mdCode(f.Example))
+		}
+
+		mw.printf("| %d | %s | %s | %s | %s | %s |\n",
+			f.ID, mdEscape(f.Name), mdRef(f.Type), required, mdCode(f.Default),
+			strings.TrimPrefix(strings.Join(desc, "<br>"), "<br>"))
+	}
+}
+
+// mdSignature renders the signature of a function.
+//
+// 	User getUser(1: string name) throws (1: NotFoundError notFound)
+func mdSignature(f functionDoc) string {
+	params := func(fields []fieldDoc) string {
+		items := make([]string, len(fields))
+		for i, field := range fields {
+			items[i] = fmt.Sprintf("%d: %s %s", field.ID, mdRef(field.Type), mdEscape(field.Name))
+		}
+		return "(" + strings.Join(items, ", ") + ")"
+	}
+
+	returns := "void"
+	if f.Returns != nil {
+		returns = mdRef(f.Returns)
+	}
+
+	s := returns + " " + mdEscape(f.Name) + params(f.Args)
+	if f.OneWay {
+		s = "oneway " + s
+	}
+	if len(f.Exceptions) > 0 {
+		s += " throws " + params(f.Exceptions)
+	}
+	return s
+}
+
+func mdRef(ref typeRef) string {
+	var s string
+	for _, part := range ref {
+		if part.Href == "" {
+			s += mdEscape(part.Text)
+		} else {
+			s += fmt.Sprintf("[%s](%s)", mdEscape(part.Text), part.Href)
+		}
+	}
+	return s
+}
+
+var _mdEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "|", `\|`,
+)
+
+func mdEscape(s string) string {
+	return _mdEscaper.Replace(s)
+}
+
+// mdCode renders the given text as inline code, or an empty string if the
+// text is empty.
+func mdCode(s string) string {
+	if s == "" {
+		return ""
+	}
+	s = strings.Replace(s, "|", `\|`, -1)
+	if strings.Contains(s, "`") {
+		return "`` " + s + " ``"
+	}
+	return "`" + s + "`"
+}
+
+// mdCell renders text for use inside a table cell.
+func mdCell(s string) string {
+	s = strings.Replace(s, "|", `\|`, -1)
+	return strings.Replace(s, "\n", "<br>", -1)
+}
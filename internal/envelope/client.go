@@ -22,11 +22,14 @@ package envelope
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 
-	"go.uber.org/thriftrw/internal/envelope/exception"
+	"go.uber.org/thriftrw/envelope"
 	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/wire"
+
+	"go.uber.org/atomic"
 )
 
 // Transport sends and receive binary payloads.
@@ -44,53 +47,63 @@ type Client interface {
 
 // NewClient builds a new client which sends requests over the given
 // transport, encoding them using the given protocol.
+//
+// Each request is sent with a new sequence ID so that transports which
+// have multiple requests in flight can match responses to their requests.
 func NewClient(p protocol.Protocol, t Transport) Client {
-	return client{p: p, t: t}
+	return client{p: p, t: t, seqID: atomic.NewInt32(0)}
 }
 
 type client struct {
 	p protocol.Protocol
 	t Transport
+
+	// Sequence ID of the last request sent by this client.
+	seqID *atomic.Int32
 }
 
 // Send sends the given request envelope over this transport.
 func (c client) Send(name string, reqValue wire.Value) (wire.Value, error) {
-	reqEnvelope := wire.Envelope{
+	return c.SendContext(context.Background(), name, reqValue)
+}
+
+// SendContext sends the given request envelope over this transport, checking
+// the given context between encoding the request, sending it, and decoding
+// the response.
+func (c client) SendContext(ctx context.Context, name string, reqValue wire.Value) (wire.Value, error) {
+	if err := ctx.Err(); err != nil {
+		return wire.Value{}, err
+	}
+
+	reqEnvelope := envelope.Envelope{
 		Name:  name,
 		Type:  wire.Call,
-		SeqID: 1, // don't care
+		SeqID: c.seqID.Inc(),
 		Value: reqValue,
 	}
 
-	// TODO(abg): We don't use or support out-of-order requests and responses
-	// for plugin communaction so this should be fine for now but we may
-	// eventually want to match responses to requests using seqID.
-
 	var buff bytes.Buffer
-	if err := c.p.EncodeEnveloped(reqEnvelope, &buff); err != nil {
+	if err := reqEnvelope.Encode(c.p, &buff); err != nil {
 		return wire.Value{}, err
 	}
 
-	resBody, err := c.t.Send(buff.Bytes())
+	resBody, err := sendContext(ctx, c.t, buff.Bytes())
 	if err != nil {
 		return wire.Value{}, err
 	}
 
-	resEnvelope, err := c.p.DecodeEnveloped(bytes.NewReader(resBody))
+	resEnvelope, err := envelope.DecodeContext(ctx, c.p, bytes.NewReader(resBody))
 	if err != nil {
 		return wire.Value{}, err
 	}
 
 	switch resEnvelope.Type {
-	case wire.Exception:
-		var exc exception.TApplicationException
-		if err := exc.FromWire(resEnvelope.Value); err != nil {
+	case wire.Exception, wire.Reply:
+		resValue, err := resEnvelope.Reply()
+		if err != nil {
 			return wire.Value{}, err
 		}
-		return wire.Value{}, &exc
-
-	case wire.Reply:
-		return resEnvelope.Value, nil
+		return resValue, nil
 
 	default:
 		return wire.Value{}, errUnknownEnvelopeType(resEnvelope.Type)
@@ -3,10 +3,14 @@ package envelope
 import (
 	"errors"
 	"io"
+	"sync"
 	"testing"
+	"time"
 
 	"go.uber.org/thriftrw/internal/envelope/envelopetest"
 	"go.uber.org/thriftrw/internal/envelope/exception"
+	"go.uber.org/thriftrw/internal/frame"
+	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/ptr"
 	"go.uber.org/thriftrw/wire"
 
@@ -127,3 +131,82 @@ func TestClient(t *testing.T) {
 		assert.Equal(t, tt.wantError, err)
 	}
 }
+
+type echoHandler struct{}
+
+func (echoHandler) Handle(name string, body wire.Value) (wire.Value, error) {
+	return body, nil
+}
+
+func TestClientPipelined(t *testing.T) {
+	const numRequests = 10
+
+	serverReader, clientWriter := io.Pipe()
+	clientReader, serverWriter := io.Pipe()
+
+	transport := frame.NewPipelinedClient(clientWriter, clientReader, frame.BinaryEnvelopeSeqID)
+	client := NewClient(protocol.Binary, transport)
+
+	// The server waits for all requests to be in flight and answers them in
+	// the reverse order.
+	serverDone := make(chan struct{})
+	go func() {
+		defer close(serverDone)
+
+		r, w := frame.NewReader(serverReader), frame.NewWriter(serverWriter)
+		defer w.Close()
+
+		var requests [][]byte
+		for len(requests) < numRequests {
+			req, err := r.Read()
+			if err != nil {
+				return // the client gave up
+			}
+			requests = append(requests, req)
+		}
+
+		server := NewServer(protocol.Binary, echoHandler{})
+		for i := len(requests) - 1; i >= 0; i-- {
+			res, err := server.Handle(requests[i])
+			if !assert.NoError(t, err, "failed to handle request") {
+				return
+			}
+			if !assert.NoError(t, w.Write(res), "failed to write response") {
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < numRequests; i++ {
+		wg.Add(1)
+		go func(i int32) {
+			defer wg.Done()
+
+			body := wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+				{ID: 1, Value: wire.NewValueI32(i)},
+			}})
+			res, err := client.Send("echo", body)
+			if assert.NoError(t, err, "request %d failed", i) {
+				assert.True(t, wire.ValuesAreEqual(body, res),
+					"response to request %d does not match: %v", i, res)
+			}
+		}(int32(i))
+	}
+
+	clientDone := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(clientDone)
+	}()
+
+	select {
+	case <-clientDone:
+	case <-time.After(5 * time.Second):
+		t.Error("timed out waiting for all requests to reach the server")
+	}
+
+	assert.NoError(t, transport.Close())
+	<-clientDone
+	<-serverDone
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package envelope
+
+import (
+	"context"
+
+	"go.uber.org/thriftrw/wire"
+)
+
+// ContextClient is a Client which stops sending a request once a context is
+// done.
+type ContextClient interface {
+	Client
+
+	// SendContext is like Send but returns ctx.Err() once the given context
+	// is done.
+	SendContext(ctx context.Context, name string, body wire.Value) (wire.Value, error)
+}
+
+// ContextTransport is a Transport which stops sending a payload once a
+// context is done.
+type ContextTransport interface {
+	Transport
+
+	// SendContext is like Send but returns ctx.Err() once the given context
+	// is done.
+	SendContext(ctx context.Context, body []byte) ([]byte, error)
+}
+
+// SendContext sends a request to the method with the given name and body
+// using the given client.
+//
+// If the client is not a ContextClient, the context is checked only before
+// the request is sent and after the response is received.
+func SendContext(ctx context.Context, c Client, name string, body wire.Value) (wire.Value, error) {
+	if cc, ok := c.(ContextClient); ok {
+		return cc.SendContext(ctx, name, body)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return wire.Value{}, err
+	}
+
+	res, err := c.Send(name, body)
+	if err != nil {
+		return wire.Value{}, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return wire.Value{}, err
+	}
+	return res, nil
+}
+
+// sendContext sends the given payload over the given transport. If the
+// transport is not a ContextTransport, the context is checked only before
+// the payload is sent and after the response is received.
+func sendContext(ctx context.Context, t Transport, body []byte) ([]byte, error) {
+	if ct, ok := t.(ContextTransport); ok {
+		return ct.SendContext(ctx, body)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	res, err := t.Send(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"go.uber.org/thriftrw/envelope"
+	"go.uber.org/thriftrw/internal/frame"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type transportFunc func([]byte) ([]byte, error)
+
+func (f transportFunc) Send(b []byte) ([]byte, error) { return f(b) }
+
+// echoTransport replies to every request with its own body.
+var echoTransport = transportFunc(func(req []byte) ([]byte, error) {
+	e, err := envelope.Decode(protocol.Binary, bytes.NewReader(req))
+	if err != nil {
+		return nil, err
+	}
+	e.Type = wire.Reply
+
+	var buff bytes.Buffer
+	err = e.Encode(protocol.Binary, &buff)
+	return buff.Bytes(), err
+})
+
+type contextTransport struct {
+	Transport
+	sent []context.Context
+}
+
+func (t *contextTransport) SendContext(ctx context.Context, b []byte) ([]byte, error) {
+	t.sent = append(t.sent, ctx)
+	return t.Send(b)
+}
+
+type sendFunc func(string, wire.Value) (wire.Value, error)
+
+func (f sendFunc) Send(name string, body wire.Value) (wire.Value, error) { return f(name, body) }
+
+func TestClientSendContext(t *testing.T) {
+	body := wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+		{ID: 1, Value: wire.NewValueString("hello")},
+	}})
+
+	t.Run("success", func(t *testing.T) {
+		transport := &contextTransport{Transport: echoTransport}
+		ctx := context.Background()
+
+		res, err := SendContext(ctx, NewClient(protocol.Binary, transport), "hello", body)
+		require.NoError(t, err)
+		assert.True(t, wire.ValuesAreEqual(body, res), "response must match request")
+		assert.Equal(t, []context.Context{ctx}, transport.sent)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		transport := transportFunc(func([]byte) ([]byte, error) {
+			t.Fatal("request must not be sent")
+			return nil, nil
+		})
+		_, err := SendContext(ctx, NewClient(protocol.Binary, transport), "hello", body)
+		assert.Equal(t, context.Canceled, err)
+	})
+
+	t.Run("canceled while sending", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		transport := transportFunc(func(req []byte) ([]byte, error) {
+			cancel()
+			return echoTransport(req)
+		})
+		_, err := SendContext(ctx, NewClient(protocol.Binary, transport), "hello", body)
+		assert.Equal(t, context.Canceled, err)
+	})
+}
+
+func TestSendContextFallback(t *testing.T) {
+	body := wire.NewValueStruct(wire.Struct{})
+	sendErr := errors.New("great sadness")
+
+	var calls int
+	client := sendFunc(func(name string, v wire.Value) (wire.Value, error) {
+		calls++
+		assert.Equal(t, "hello", name)
+		return v, nil
+	})
+
+	_, err := SendContext(context.Background(), client, "hello", body)
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = SendContext(ctx, client, "hello", body)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 1, calls, "canceled request must not be sent")
+
+	failing := sendFunc(func(string, wire.Value) (wire.Value, error) {
+		return wire.Value{}, sendErr
+	})
+	_, err = SendContext(context.Background(), failing, "hello", body)
+	assert.Equal(t, sendErr, err)
+}
+
+// The frame transports stop waiting for responses when the context is done.
+var (
+	_ ContextTransport = (*frame.Client)(nil)
+	_ ContextTransport = (*frame.Pool)(nil)
+	_ ContextTransport = (*frame.PipelinedClient)(nil)
+)
+
+func TestClientSendContextBlockingTransport(t *testing.T) {
+	body := wire.NewValueStruct(wire.Struct{})
+
+	transports := []struct {
+		desc string
+		new  func(io.Writer, io.Reader) ContextTransport
+	}{
+		{
+			desc: "Client",
+			new: func(w io.Writer, r io.Reader) ContextTransport {
+				return frame.NewClient(w, r)
+			},
+		},
+		{
+			desc: "PipelinedClient",
+			new: func(w io.Writer, r io.Reader) ContextTransport {
+				return frame.NewPipelinedClient(w, r, frame.BinaryEnvelopeSeqID)
+			},
+		},
+	}
+
+	for _, tt := range transports {
+		serverReader, clientWriter := io.Pipe()
+		clientReader, serverWriter := io.Pipe()
+
+		// The server receives the request but never responds. The request
+		// is canceled once it has been received.
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			if _, err := frame.NewReader(serverReader).Read(); err == nil {
+				cancel()
+			}
+		}()
+
+		client := NewClient(protocol.Binary, tt.new(clientWriter, clientReader))
+		_, err := SendContext(ctx, client, "hello", body)
+		assert.Equal(t, context.Canceled, err, tt.desc)
+
+		cancel()
+		serverWriter.Close()
+		serverReader.Close()
+	}
+}
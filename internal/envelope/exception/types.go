@@ -89,8 +89,41 @@ func (v *ExceptionType) UnmarshalText(value []byte) error {
 		*v = ExceptionTypeUnsupportedClientType
 		return nil
 	default:
-		return fmt.Errorf("unknown enum value %q for %q", value, "ExceptionType")
+		val, err := strconv.ParseInt(string(value), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", value, "ExceptionType", err)
+		}
+		*v = ExceptionType(val)
+		return nil
+	}
+}
+
+func (v ExceptionType) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("UNKNOWN"), nil
+	case 1:
+		return []byte("UNKNOWN_METHOD"), nil
+	case 2:
+		return []byte("INVALID_MESSAGE_TYPE"), nil
+	case 3:
+		return []byte("WRONG_METHOD_NAME"), nil
+	case 4:
+		return []byte("BAD_SEQUENCE_ID"), nil
+	case 5:
+		return []byte("MISSING_RESULT"), nil
+	case 6:
+		return []byte("INTERNAL_ERROR"), nil
+	case 7:
+		return []byte("PROTOCOL_ERROR"), nil
+	case 8:
+		return []byte("INVALID_TRANSFORM"), nil
+	case 9:
+		return []byte("INVALID_PROTOCOL"), nil
+	case 10:
+		return []byte("UNSUPPORTED_CLIENT_TYPE"), nil
 	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
 
 func (v ExceptionType) ToWire() (wire.Value, error) {
@@ -238,6 +271,7 @@ func (v *TApplicationException) FromWire(w wire.Value) error {
 				x, err = field.Value.GetString(), error(nil)
 				v.Message = &x
 				if err != nil {
+					wire.ObserveDecodeError("TApplicationException", "Message", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -247,6 +281,7 @@ func (v *TApplicationException) FromWire(w wire.Value) error {
 				x, err = _ExceptionType_Read(field.Value)
 				v.Type = &x
 				if err != nil {
+					wire.ObserveDecodeError("TApplicationException", "Type", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -300,6 +335,20 @@ func (v *TApplicationException) Equals(rhs *TApplicationException) bool {
 	return true
 }
 
+func (v *TApplicationException) GetMessage() (o string) {
+	if v != nil && v.Message != nil {
+		return *v.Message
+	}
+	return
+}
+
+func (v *TApplicationException) GetType() (o ExceptionType) {
+	if v != nil && v.Type != nil {
+		return *v.Type
+	}
+	return
+}
+
 func (v *TApplicationException) Error() string {
 	return v.String()
 }
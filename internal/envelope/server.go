@@ -24,6 +24,7 @@ import (
 	"bytes"
 	"fmt"
 
+	"go.uber.org/thriftrw/envelope"
 	"go.uber.org/thriftrw/internal/envelope/exception"
 	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/ptr"
@@ -60,12 +61,12 @@ func NewServer(p protocol.Protocol, h Handler) Server {
 
 // Handle handles the given binary payload.
 func (s Server) Handle(data []byte) ([]byte, error) {
-	request, err := s.p.DecodeEnveloped(bytes.NewReader(data))
+	request, err := envelope.Decode(s.p, bytes.NewReader(data))
 	if err != nil {
 		return nil, err
 	}
 
-	response := wire.Envelope{
+	response := envelope.Envelope{
 		Name:  request.Name,
 		SeqID: request.SeqID,
 		Type:  wire.Reply,
@@ -75,7 +76,7 @@ func (s Server) Handle(data []byte) ([]byte, error) {
 	if err != nil {
 		response.Type = wire.Exception
 		switch err.(type) {
-		case ErrUnknownMethod:
+		case ErrUnknownMethod, envelope.UnknownServiceError:
 			response.Value, err = tappExc(err, exception.ExceptionTypeUnknownMethod)
 		default:
 			response.Value, err = tappExc(err, exception.ExceptionTypeInternalError)
@@ -87,7 +88,7 @@ func (s Server) Handle(data []byte) ([]byte, error) {
 	}
 
 	var buff bytes.Buffer
-	if err := s.p.EncodeEnveloped(response, &buff); err != nil {
+	if err := response.Encode(s.p, &buff); err != nil {
 		return nil, err
 	}
 
@@ -5,6 +5,7 @@ import (
 	"io"
 	"testing"
 
+	"go.uber.org/thriftrw/envelope"
 	"go.uber.org/thriftrw/wire"
 
 	"github.com/golang/mock/gomock"
@@ -99,6 +100,27 @@ func TestServer(t *testing.T) {
 				}}),
 			},
 		},
+		{
+			desc: "unknown service",
+			giveEnvelope: wire.Envelope{
+				Name:  "Foo:hello",
+				Type:  wire.Call,
+				SeqID: 1,
+				Value: wire.NewValueStruct(wire.Struct{}),
+			},
+			handler: func(name string, _ wire.Value) (wire.Value, error) {
+				return wire.Value{}, envelope.UnknownServiceError{Name: name}
+			},
+			wantEnvelope: &wire.Envelope{
+				Name:  "Foo:hello",
+				Type:  wire.Exception,
+				SeqID: 1,
+				Value: wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+					{ID: 1, Value: wire.NewValueString(`unknown method "Foo:hello": unknown service "Foo"`)},
+					{ID: 2, Value: wire.NewValueI32(1)}, // Unknown method
+				}}),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,398 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package fixtures builds sample values for the types defined in a Thrift
+// module and encodes them with the Binary protocol.
+//
+// The fixtures are meant to be consumed by codecs written in other
+// languages: each fixture is accompanied by a JSON description of the
+// encoded value so that a test in that language can construct the same value
+// and compare its encoding with the fixture.
+package fixtures
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/gen"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/uuid"
+	"go.uber.org/thriftrw/wire"
+)
+
+// Languages for which fixture descriptions may include identifiers.
+var Languages = []string{"go", "java", "py"}
+
+// Fixture is an encoded sample value of a Thrift type.
+type Fixture struct {
+	// Module and name of the type, "$module.$type".
+	Name string
+
+	// Binary protocol encoding of the value.
+	Encoded []byte
+
+	// Description of the encoded value.
+	Description Description
+}
+
+// Description describes the value encoded in a Fixture.
+type Description struct {
+	Type     string `json:"type"`
+	Protocol string `json:"protocol"`
+
+	// Value in a language-neutral form:
+	//
+	// 	bool, integers, double      JSON booleans and numbers
+	// 	string                      JSON strings
+	// 	binary                      base64-encoded JSON strings
+	// 	enums                       the integer value of the item
+	// 	lists and sets              JSON arrays
+	// 	maps                        JSON arrays of {"key": ..., "value": ...}
+	// 	structs, unions, exceptions JSON objects keyed by Thrift field names
+	//
+	// Typedefs are described the same way as their targets.
+	Value interface{} `json:"value"`
+
+	// Identifiers used by the code generated for each requested language,
+	// keyed by the Thrift names of the types and their fields.
+	Identifiers map[string]map[string]Identifier `json:"identifiers,omitempty"`
+}
+
+// Identifier is the name of a type and its fields in generated code.
+type Identifier struct {
+	Name   string            `json:"name"`
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// Build builds fixtures for all types defined in the given module and the
+// modules it includes. Identifiers are included for the given languages.
+func Build(m *compile.Module, langs []string) ([]Fixture, error) {
+	for _, lang := range langs {
+		if !isLanguage(lang) {
+			return nil, fmt.Errorf("unsupported language %q: expected one of %s",
+				lang, strings.Join(Languages, ", "))
+		}
+	}
+
+	var fixtures []Fixture
+	err := m.Walk(func(m *compile.Module) error {
+		names := make([]string, 0, len(m.Types))
+		for name := range m.Types {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			f, err := build(m.Name+"."+name, m.Types[name], langs)
+			if err != nil {
+				return fmt.Errorf("could not build fixture for %q: %v", m.Name+"."+name, err)
+			}
+			fixtures = append(fixtures, f)
+		}
+		return nil
+	})
+	return fixtures, err
+}
+
+func build(name string, spec compile.TypeSpec, langs []string) (Fixture, error) {
+	s := sampler{visiting: make(map[compile.TypeSpec]struct{})}
+	w, desc, err := s.Sample(spec, 1, spec.ThriftName())
+	if err != nil {
+		return Fixture{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := protocol.Binary.Encode(w, &buf); err != nil {
+		return Fixture{}, err
+	}
+
+	f := Fixture{
+		Name:    name,
+		Encoded: buf.Bytes(),
+		Description: Description{
+			Type:     name,
+			Protocol: "binary",
+			Value:    desc,
+		},
+	}
+
+	if len(langs) > 0 {
+		f.Description.Identifiers = make(map[string]map[string]Identifier)
+		for _, lang := range langs {
+			ids := make(map[string]Identifier)
+			if err := identifiers(lang, spec, ids); err != nil {
+				return Fixture{}, err
+			}
+			f.Description.Identifiers[lang] = ids
+		}
+	}
+	return f, nil
+}
+
+func isLanguage(lang string) bool {
+	for _, l := range Languages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// identifiers records the identifiers for the given type and the types it
+// references in the given language.
+func identifiers(lang string, spec compile.TypeSpec, ids map[string]Identifier) error {
+	switch s := spec.(type) {
+	case *compile.ListSpec:
+		return identifiers(lang, s.ValueSpec, ids)
+	case *compile.SetSpec:
+		return identifiers(lang, s.ValueSpec, ids)
+	case *compile.MapSpec:
+		if err := identifiers(lang, s.KeySpec, ids); err != nil {
+			return err
+		}
+		return identifiers(lang, s.ValueSpec, ids)
+	case *compile.TypedefSpec:
+		if _, ok := ids[s.Name]; ok {
+			return nil
+		}
+		name, err := identifier(lang, s)
+		if err != nil {
+			return err
+		}
+		ids[s.Name] = Identifier{Name: name}
+		return identifiers(lang, s.Target, ids)
+	case *compile.EnumSpec:
+		name, err := identifier(lang, s)
+		if err != nil {
+			return err
+		}
+		ids[s.Name] = Identifier{Name: name}
+		return nil
+	case *compile.StructSpec:
+		if _, ok := ids[s.Name]; ok {
+			return nil
+		}
+		name, err := identifier(lang, s)
+		if err != nil {
+			return err
+		}
+
+		id := Identifier{Name: name, Fields: make(map[string]string, len(s.Fields))}
+		ids[s.Name] = id // recorded before recursing for self-referencing structs
+		for _, f := range s.Fields {
+			fname, err := identifier(lang, f)
+			if err != nil {
+				return err
+			}
+			id.Fields[f.Name] = fname
+			if err := identifiers(lang, f.Type, ids); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// identifier returns the name used for the given entity by the code
+// generated for the given language.
+func identifier(lang string, e compile.NamedEntity) (string, error) {
+	if lang == "go" {
+		return gen.GoName(e)
+	}
+	// The Apache Thrift generators for Java and Python use Thrift names
+	// as-is.
+	return e.ThriftName(), nil
+}
+
+// errRecursive is returned when a sample requires a value of a struct that
+// is already being sampled.
+var errRecursive = errors.New("type is recursive")
+
+// sampler builds deterministic sample values for Thrift types.
+//
+// Each value is derived from a seed, which is the ID of the field it is
+// being built for, so that fields of the same type get different values.
+type sampler struct {
+	// Structs that are currently being sampled.
+	visiting map[compile.TypeSpec]struct{}
+}
+
+// Sample returns a sample Value of the given type and its description. hint
+// is used to build string values.
+func (s sampler) Sample(spec compile.TypeSpec, seed int, hint string) (wire.Value, interface{}, error) {
+	switch t := spec.(type) {
+	case *compile.BoolSpec:
+		v := seed%2 == 1
+		return wire.NewValueBool(v), v, nil
+	case *compile.I8Spec:
+		v := int8(seed)
+		return wire.NewValueI8(v), v, nil
+	case *compile.I16Spec:
+		v := int16(seed) * 100
+		return wire.NewValueI16(v), v, nil
+	case *compile.I32Spec:
+		v := int32(seed) * 10000
+		return wire.NewValueI32(v), v, nil
+	case *compile.I64Spec:
+		// Exercise the upper half of 64-bit integers while remaining exactly
+		// representable as a double by JSON decoders.
+		v := int64(seed)<<33 | int64(seed)
+		return wire.NewValueI64(v), v, nil
+	case *compile.DoubleSpec:
+		v := float64(seed) + 0.5
+		return wire.NewValueDouble(v), v, nil
+	case *compile.StringSpec:
+		v := fmt.Sprintf("%s-%d", hint, seed)
+		return wire.NewValueString(v), v, nil
+	case *compile.BinarySpec:
+		v := []byte(fmt.Sprintf("%s-%d", hint, seed))
+		return wire.NewValueBinary(v), base64.StdEncoding.EncodeToString(v), nil
+	case *compile.UUIDSpec:
+		var v uuid.UUID
+		binary.BigEndian.PutUint64(v[8:], uint64(seed))
+		w, err := v.ToWire()
+		return w, v.String(), err
+	case *compile.EnumSpec:
+		var v int32
+		if n := len(t.Items); n > 0 {
+			v = t.Items[(seed%n+n)%n].Value // seeds may be negative
+		}
+		return wire.NewValueI32(v), v, nil
+	case *compile.TypedefSpec:
+		return s.Sample(t.Target, seed, hint)
+	case *compile.ListSpec:
+		return s.list(t, seed, hint)
+	case *compile.SetSpec:
+		return s.set(t, seed, hint)
+	case *compile.MapSpec:
+		return s.mapping(t, seed, hint)
+	case *compile.StructSpec:
+		return s.structure(t)
+	default:
+		return wire.Value{}, nil, fmt.Errorf("unsupported type %v", spec.ThriftName())
+	}
+}
+
+// list samples lists with two items unless the items are recursive, in which
+// case the list is empty.
+func (s sampler) list(t *compile.ListSpec, seed int, hint string) (wire.Value, interface{}, error) {
+	values := make([]wire.Value, 0, 2)
+	descs := make([]interface{}, 0, 2)
+	for i := 0; i < 2; i++ {
+		v, d, err := s.Sample(t.ValueSpec, seed+i, hint)
+		if err == errRecursive {
+			break
+		}
+		if err != nil {
+			return wire.Value{}, nil, err
+		}
+		values = append(values, v)
+		descs = append(descs, d)
+	}
+	typ := t.ValueSpec.TypeCode()
+	return wire.NewValueList(wire.ValueListFromSlice(typ, values)), descs, nil
+}
+
+// set samples sets with a single item so that the items are unique.
+func (s sampler) set(t *compile.SetSpec, seed int, hint string) (wire.Value, interface{}, error) {
+	var (
+		values []wire.Value
+		descs  = []interface{}{}
+	)
+	v, d, err := s.Sample(t.ValueSpec, seed, hint)
+	switch err {
+	case nil:
+		values = append(values, v)
+		descs = append(descs, d)
+	case errRecursive:
+		// leave the set empty
+	default:
+		return wire.Value{}, nil, err
+	}
+	typ := t.ValueSpec.TypeCode()
+	return wire.NewValueSet(wire.ValueListFromSlice(typ, values)), descs, nil
+}
+
+type mapItemDescription struct {
+	Key   interface{} `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// mapping samples maps with a single item.
+func (s sampler) mapping(t *compile.MapSpec, seed int, hint string) (wire.Value, interface{}, error) {
+	var (
+		items []wire.MapItem
+		descs = []mapItemDescription{}
+	)
+	k, kd, err := s.Sample(t.KeySpec, seed, hint+"-key")
+	if err == nil {
+		var v wire.Value
+		var vd interface{}
+		v, vd, err = s.Sample(t.ValueSpec, seed, hint+"-value")
+		if err == nil {
+			items = append(items, wire.MapItem{Key: k, Value: v})
+			descs = append(descs, mapItemDescription{Key: kd, Value: vd})
+		}
+	}
+	if err != nil && err != errRecursive {
+		return wire.Value{}, nil, err
+	}
+	kt, vt := t.KeySpec.TypeCode(), t.ValueSpec.TypeCode()
+	return wire.NewValueMap(wire.MapItemListFromSlice(kt, vt, items)), descs, nil
+}
+
+// structure samples structs with all their fields set. Optional fields which
+// would recurse into a struct being sampled are omitted. Only the first
+// field of unions is set.
+func (s sampler) structure(t *compile.StructSpec) (wire.Value, interface{}, error) {
+	if _, ok := s.visiting[t]; ok {
+		return wire.Value{}, nil, errRecursive
+	}
+	s.visiting[t] = struct{}{}
+	defer delete(s.visiting, t)
+
+	var fields []wire.Field
+	desc := make(map[string]interface{})
+	for _, f := range t.Fields {
+		v, d, err := s.Sample(f.Type, int(f.ID), f.Name)
+		if err == errRecursive && !f.Required {
+			continue
+		}
+		if err != nil {
+			return wire.Value{}, nil, err
+		}
+
+		fields = append(fields, wire.Field{ID: f.ID, Value: v})
+		desc[f.Name] = d
+		if t.Type == ast.UnionType {
+			break
+		}
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields}), desc, nil
+}
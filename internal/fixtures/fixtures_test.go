@@ -0,0 +1,163 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fixtures
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+	ts "go.uber.org/thriftrw/gen/testdata/structs"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compileString(t *testing.T, contents string) *compile.Module {
+	dir, err := ioutil.TempDir("", "thriftrw-fixtures-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "test.thrift")
+	require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+
+	m, err := compile.Compile(path)
+	require.NoError(t, err)
+	return m
+}
+
+func TestBuild(t *testing.T) {
+	m := compileString(t, `
+		enum Color { Red, Green }
+		typedef i64 Timestamp
+
+		struct user_info {
+			1: required string name
+			2: optional Color color
+			3: optional map<string, Timestamp> seen
+			4: optional user_info parent
+		}
+
+		union Value { 1: i32 intValue, 2: string stringValue }
+	`)
+
+	fixtures, err := Build(m, []string{"go", "py"})
+	require.NoError(t, err)
+
+	byName := make(map[string]Fixture)
+	for _, f := range fixtures {
+		byName[f.Name] = f
+	}
+	assert.Len(t, byName, 4)
+
+	user := byName["test.user_info"]
+	desc, err := json.Marshal(user.Description)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "test.user_info",
+		"protocol": "binary",
+		"value": {
+			"name": "name-1",
+			"color": 0,
+			"seen": [{"key": "seen-key-3", "value": 25769803779}]
+		},
+		"identifiers": {
+			"go": {
+				"user_info": {
+					"name": "UserInfo",
+					"fields": {"name": "Name", "color": "Color", "seen": "Seen", "parent": "Parent"}
+				},
+				"Color": {"name": "Color"},
+				"Timestamp": {"name": "Timestamp"}
+			},
+			"py": {
+				"user_info": {
+					"name": "user_info",
+					"fields": {"name": "name", "color": "color", "seen": "seen", "parent": "parent"}
+				},
+				"Color": {"name": "Color"},
+				"Timestamp": {"name": "Timestamp"}
+			}
+		}
+	}`, string(desc))
+
+	w, err := protocol.Binary.Decode(bytes.NewReader(user.Encoded), wire.TStruct)
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(w.GetStruct().Fields), "recursive optional field must be omitted")
+
+	union := byName["test.Value"]
+	w, err = protocol.Binary.Decode(bytes.NewReader(union.Encoded), wire.TStruct)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(w.GetStruct().Fields), "unions must have a single field set")
+	assert.Equal(t, map[string]interface{}{"intValue": int32(10000)}, union.Description.Value)
+}
+
+func TestBuildErrors(t *testing.T) {
+	_, err := Build(compileString(t, `struct Foo {}`), []string{"go", "rust"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `unsupported language "rust"`)
+	}
+
+	_, err = Build(compileString(t, `struct Node { 1: required Node child }`), nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `could not build fixture for "test.Node": type is recursive`)
+	}
+}
+
+func TestBuildDecodesWithGeneratedTypes(t *testing.T) {
+	m, err := compile.Compile("../../gen/testdata/thrift/structs.thrift")
+	require.NoError(t, err)
+
+	fixtures, err := Build(m, nil)
+	require.NoError(t, err)
+
+	decode := func(name string, v interface {
+		FromWire(wire.Value) error
+	}) {
+		for _, f := range fixtures {
+			if f.Name != name {
+				continue
+			}
+			w, err := protocol.Binary.Decode(bytes.NewReader(f.Encoded), wire.TStruct)
+			require.NoError(t, err, name)
+			require.NoError(t, v.FromWire(w), name)
+			return
+		}
+		t.Fatalf("fixture %q not found", name)
+	}
+
+	var point ts.Point
+	decode("structs.Point", &point)
+	assert.Equal(t, ts.Point{X: 1.5, Y: 2.5}, point)
+
+	var user ts.User
+	decode("structs.User", &user)
+	assert.Equal(t, "name-1", user.Name)
+	if assert.NotNil(t, user.Contact) {
+		assert.Equal(t, "emailAddress-1", user.Contact.EmailAddress)
+	}
+}
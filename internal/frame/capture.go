@@ -0,0 +1,229 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frame
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// _captureMagic starts every capture file. The last two bytes are the
+// version of the format.
+var _captureMagic = []byte("TRWCAP\x00\x01")
+
+// Direction is the direction in which a captured frame traveled, relative to
+// the side that captured it.
+type Direction uint8
+
+// Directions of captured frames.
+const (
+	// Sent frames were written by the side that captured them.
+	Sent Direction = iota + 1
+
+	// Received frames were read by the side that captured them.
+	Received
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Sent:
+		return "sent"
+	case Received:
+		return "received"
+	default:
+		return fmt.Sprintf("Direction(%d)", uint8(d))
+	}
+}
+
+// CaptureRecord is a single frame recorded in a capture file.
+type CaptureRecord struct {
+	// Time at which the frame was sent or received.
+	Time time.Time
+
+	// Name of the stream over which the frame was exchanged. For plugins,
+	// this is the name of the plugin.
+	Stream string
+
+	Direction Direction
+
+	// Contents of the frame, without the length prefix.
+	Frame []byte
+}
+
+// CaptureWriter records frames to a capture file.
+//
+// A capture file starts with a header identifying the format, followed by
+// records of the following form. All integers are big-endian.
+//
+// 	direction:1 timestamp:8 len(stream):2 stream len(frame):4 frame
+//
+// The timestamp is the number of nanoseconds since the Unix epoch.
+//
+// CaptureWriter is safe for concurrent use.
+type CaptureWriter struct {
+	mu  sync.Mutex
+	w   io.Writer
+	err error
+}
+
+// NewCaptureWriter builds a CaptureWriter which writes a capture file to the
+// given io.Writer.
+//
+// If the io.Writer is a WriteCloser, its Close method will be called when
+// the CaptureWriter is closed.
+func NewCaptureWriter(w io.Writer) (*CaptureWriter, error) {
+	if _, err := w.Write(_captureMagic); err != nil {
+		return nil, err
+	}
+	return &CaptureWriter{w: w}, nil
+}
+
+// Write records the given frame. Records without a Time are recorded with
+// the current time.
+//
+// Once a Write fails, all following calls fail with the same error because
+// the capture file may be corrupt.
+func (w *CaptureWriter) Write(r CaptureRecord) error {
+	if len(r.Stream) > math.MaxUint16 {
+		return fmt.Errorf("stream name is too long: %d bytes", len(r.Stream))
+	}
+	if uint64(len(r.Frame)) > math.MaxUint32 {
+		return fmt.Errorf("frame is too large: %d bytes", len(r.Frame))
+	}
+	if r.Time.IsZero() {
+		r.Time = time.Now()
+	}
+
+	buff := make([]byte, 11, 15+len(r.Stream)+len(r.Frame))
+	buff[0] = byte(r.Direction)
+	binary.BigEndian.PutUint64(buff[1:9], uint64(r.Time.UnixNano()))
+	binary.BigEndian.PutUint16(buff[9:11], uint16(len(r.Stream)))
+	buff = append(buff, r.Stream...)
+
+	var frameLen [4]byte
+	binary.BigEndian.PutUint32(frameLen[:], uint32(len(r.Frame)))
+	buff = append(buff, frameLen[:]...)
+	buff = append(buff, r.Frame...)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err != nil {
+		return w.err
+	}
+
+	// Records are written with a single call so that they do not interleave.
+	_, w.err = w.w.Write(buff)
+	return w.err
+}
+
+// Close closes the CaptureWriter.
+func (w *CaptureWriter) Close() error {
+	if c, ok := w.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// CaptureReader reads records from a capture file written by a
+// CaptureWriter.
+type CaptureReader struct {
+	r *bufio.Reader
+}
+
+// NewCaptureReader builds a CaptureReader which reads the capture file from
+// the given io.Reader.
+//
+// Fails if the file is not a capture file.
+func NewCaptureReader(r io.Reader) (*CaptureReader, error) {
+	br := bufio.NewReader(r)
+
+	magic := make([]byte, len(_captureMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || !bytes.Equal(magic, _captureMagic) {
+		return nil, errors.New("not a thriftrw capture file")
+	}
+
+	return &CaptureReader{r: br}, nil
+}
+
+// Read reads the next record from the capture file. Returns io.EOF if there
+// are no more records.
+func (r *CaptureReader) Read() (CaptureRecord, error) {
+	var (
+		rec       CaptureRecord
+		direction uint8
+		nanos     int64
+		streamLen uint16
+		frameLen  uint32
+	)
+
+	if err := binary.Read(r.r, binary.BigEndian, &direction); err != nil {
+		return rec, err // io.EOF if there are no more records
+	}
+
+	err := r.readFull(&nanos, &streamLen)
+	stream := make([]byte, streamLen)
+	if err == nil {
+		err = r.readFull(stream, &frameLen)
+	}
+	var frame []byte
+	if err == nil {
+		frame = make([]byte, frameLen)
+		err = r.readFull(frame)
+	}
+	if err != nil {
+		return rec, fmt.Errorf("truncated capture record: %v", err)
+	}
+
+	return CaptureRecord{
+		Time:      time.Unix(0, nanos),
+		Stream:    string(stream),
+		Direction: Direction(direction),
+		Frame:     frame,
+	}, nil
+}
+
+// readFull reads the given byte slices and integers in order.
+func (r *CaptureReader) readFull(values ...interface{}) error {
+	for _, v := range values {
+		var err error
+		if b, ok := v.([]byte); ok {
+			_, err = io.ReadFull(r.r, b)
+		} else {
+			err = binary.Read(r.r, binary.BigEndian, v)
+		}
+
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
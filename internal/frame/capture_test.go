@@ -0,0 +1,154 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frame
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureRoundTrip(t *testing.T) {
+	records := []CaptureRecord{
+		{
+			Time:      time.Unix(1494000000, 123456789),
+			Stream:    "foo",
+			Direction: Sent,
+			Frame:     []byte("hello"),
+		},
+		{
+			Time:      time.Unix(1494000001, 0),
+			Stream:    "foo",
+			Direction: Received,
+			Frame:     []byte("world"),
+		},
+		{
+			Time:      time.Unix(1494000002, 0),
+			Direction: Sent,
+			Frame:     []byte{},
+		},
+	}
+
+	var buff bytes.Buffer
+	w, err := NewCaptureWriter(&buff)
+	require.NoError(t, err)
+	for _, r := range records {
+		require.NoError(t, w.Write(r))
+	}
+	require.NoError(t, w.Close())
+
+	r, err := NewCaptureReader(&buff)
+	require.NoError(t, err)
+	for _, want := range records {
+		got, err := r.Read()
+		require.NoError(t, err)
+		assert.True(t, want.Time.Equal(got.Time), "time mismatch: %v != %v", want.Time, got.Time)
+		assert.Equal(t, want.Stream, got.Stream)
+		assert.Equal(t, want.Direction, got.Direction)
+		assert.Equal(t, want.Frame, got.Frame)
+	}
+
+	_, err = r.Read()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestCaptureWriterDefaultTime(t *testing.T) {
+	var buff bytes.Buffer
+	w, err := NewCaptureWriter(&buff)
+	require.NoError(t, err)
+
+	before := time.Now()
+	require.NoError(t, w.Write(CaptureRecord{Direction: Sent, Frame: []byte("x")}))
+
+	r, err := NewCaptureReader(&buff)
+	require.NoError(t, err)
+	rec, err := r.Read()
+	require.NoError(t, err)
+	assert.False(t, rec.Time.Before(before.Truncate(time.Second)), "time must be set")
+}
+
+func TestCaptureWriterConcurrent(t *testing.T) {
+	var buff bytes.Buffer
+	w, err := NewCaptureWriter(&buff)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, w.Write(CaptureRecord{Direction: Sent, Frame: bytes.Repeat([]byte("x"), 100)}))
+		}()
+	}
+	wg.Wait()
+
+	r, err := NewCaptureReader(&buff)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		rec, err := r.Read()
+		require.NoError(t, err)
+		assert.Len(t, rec.Frame, 100)
+	}
+}
+
+type failingWriter struct{ err error }
+
+func (w failingWriter) Write([]byte) (int, error) { return 0, w.err }
+
+func TestCaptureWriterFailure(t *testing.T) {
+	_, err := NewCaptureWriter(failingWriter{errors.New("great sadness")})
+	assert.EqualError(t, err, "great sadness")
+
+	w := &CaptureWriter{w: failingWriter{errors.New("great sadness")}}
+	assert.EqualError(t, w.Write(CaptureRecord{Frame: []byte("x")}), "great sadness")
+	assert.EqualError(t, w.Write(CaptureRecord{Frame: []byte("y")}), "great sadness",
+		"writes after a failure must fail")
+}
+
+func TestCaptureReaderErrors(t *testing.T) {
+	_, err := NewCaptureReader(bytes.NewReader([]byte("not a capture")))
+	assert.EqualError(t, err, "not a thriftrw capture file")
+
+	var buff bytes.Buffer
+	w, err := NewCaptureWriter(&buff)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(CaptureRecord{Direction: Sent, Frame: []byte("hello")}))
+
+	// Drop the last byte of the frame.
+	r, err := NewCaptureReader(bytes.NewReader(buff.Bytes()[:buff.Len()-1]))
+	require.NoError(t, err)
+	_, err = r.Read()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "truncated capture record")
+	}
+}
+
+func TestDirectionString(t *testing.T) {
+	assert.Equal(t, "sent", Sent.String())
+	assert.Equal(t, "received", Received.String())
+	assert.Equal(t, "Direction(42)", Direction(42).String())
+}
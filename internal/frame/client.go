@@ -21,38 +21,104 @@
 package frame
 
 import (
+	"context"
 	"io"
 	"sync"
+	"time"
 )
 
+// ClientOption customizes the behavior of a Client.
+type ClientOption func(*Client)
+
+// ClientObserver specifies an Observer which is notified of every request
+// sent and response received by the Client.
+func ClientObserver(o Observer) ClientOption {
+	return func(c *Client) {
+		c.observer = o
+	}
+}
+
 // Client provides bidirectional outgoing framed communication.
 //
 // It allows sending framed requests where each request has a corresponding
 // response. Only one active request is allowed at a time. Other requests are
-// blocked while a request is ongoing.
+// blocked while a request is ongoing. Use PipelinedClient to send multiple
+// requests at the same time.
 type Client struct {
 	sync.Mutex
 
 	r *Reader
 	w *Writer
+
+	observer Observer
 }
 
 // NewClient builds a new Client which uses the given writer to send requests
 // and the given reader to read their responses.
-func NewClient(w io.Writer, r io.Reader) *Client {
-	return &Client{
-		r: NewReader(r),
-		w: NewWriter(w),
+func NewClient(w io.Writer, r io.Reader, opts ...ClientOption) *Client {
+	c := &Client{
+		r:        NewReader(r),
+		w:        NewWriter(w),
+		observer: nopObserver{},
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 // Send sends the given frame and returns its response.
 func (c *Client) Send(b []byte) ([]byte, error) {
+	return c.send(context.Background(), b)
+}
+
+// SendContext sends the given frame and returns its response.
+//
+// If the context is done before the response is received, SendContext
+// returns the context's error. The request is not sent if the context is
+// done while it waits for an earlier request. Otherwise, its response is
+// read and discarded before the next request is sent.
+func (c *Client) SendContext(ctx context.Context, b []byte) ([]byte, error) {
+	if ctx.Done() == nil {
+		return c.send(ctx, b) // never canceled
+	}
+
+	ch := make(chan frameResult, 1)
+	go func() {
+		res, err := c.send(ctx, b)
+		ch <- frameResult{Body: res, Err: err}
+	}()
+
+	select {
+	case res := <-ch:
+		return res.Body, res.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *Client) send(ctx context.Context, b []byte) (res []byte, err error) {
 	c.Lock()
 	defer c.Unlock()
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	defer func() {
+		c.observer.OnHandle(time.Since(start), err)
+	}()
+
 	if err := c.w.Write(b); err != nil {
 		return nil, err
 	}
-	return c.r.Read()
+	c.observer.OnFrameWritten(len(b))
+
+	res, err = c.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	c.observer.OnFrameRead(len(res))
+	return res, nil
 }
@@ -1,10 +1,12 @@
 package frame
 
 import (
+	"context"
 	"errors"
 	"io"
 	"testing"
 	"testing/quick"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -75,8 +77,113 @@ func TestClientServerHandleError(t *testing.T) {
 	assert.Equal(t, io.EOF, err)
 }
 
+func TestClientServerObserver(t *testing.T) {
+	serverReader, clientWriter := io.Pipe()
+	clientReader, serverWriter := io.Pipe()
+
+	defer func() {
+		assert.NoError(t, serverWriter.Close())
+		assert.NoError(t, clientWriter.Close())
+		assert.NoError(t, clientReader.Close())
+		assert.NoError(t, serverReader.Close())
+	}()
+
+	var serverObserver, clientObserver recordingObserver
+	server := NewServer(serverReader, serverWriter, ServerObserver(&serverObserver))
+	client := NewClient(clientWriter, clientReader, ClientObserver(&clientObserver))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err := server.Serve(handlerFunc(
+			func(got []byte) ([]byte, error) {
+				if string(got) == "fail" {
+					return nil, errors.New("great sadness")
+				}
+				return []byte("world!"), nil
+			},
+		))
+		assert.Error(t, err)
+	}()
+
+	got, err := client.Send([]byte("hello"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("world!"), got)
+
+	_, err = client.Send([]byte("fail"))
+	assert.Equal(t, io.EOF, err)
+	<-done
+
+	assert.Equal(t, []int{5, 4}, serverObserver.read)
+	assert.Equal(t, []int{6}, serverObserver.written)
+	assert.Equal(t, []error{nil, errors.New("great sadness")}, serverObserver.handled)
+
+	assert.Equal(t, []int{6}, clientObserver.read)
+	assert.Equal(t, []int{5, 4}, clientObserver.written)
+	assert.Equal(t, []error{nil, io.EOF}, clientObserver.handled)
+}
+
+type recordingObserver struct {
+	read, written []int
+	handled       []error
+}
+
+func (o *recordingObserver) OnFrameRead(size int)    { o.read = append(o.read, size) }
+func (o *recordingObserver) OnFrameWritten(size int) { o.written = append(o.written, size) }
+
+func (o *recordingObserver) OnHandle(d time.Duration, err error) {
+	o.handled = append(o.handled, err)
+}
+
 type handlerFunc func([]byte) ([]byte, error)
 
 func (f handlerFunc) Handle(b []byte) ([]byte, error) {
 	return f(b)
 }
+
+func TestClientServerSendContext(t *testing.T) {
+	serverReader, clientWriter := io.Pipe()
+	clientReader, serverWriter := io.Pipe()
+
+	defer func() {
+		assert.NoError(t, serverWriter.Close())
+		assert.NoError(t, clientWriter.Close())
+		assert.NoError(t, clientReader.Close())
+		assert.NoError(t, serverReader.Close())
+	}()
+
+	server := NewServer(serverReader, serverWriter)
+	client := NewClient(clientWriter, clientReader)
+
+	// The server answers "slow" requests only once release is closed.
+	received := make(chan string, 10)
+	release := make(chan struct{})
+	go server.Serve(handlerFunc(func(b []byte) ([]byte, error) {
+		received <- string(b)
+		if string(b) == "slow" {
+			<-release
+		}
+		return b, nil
+	}))
+	defer server.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.SendContext(ctx, []byte("hello"))
+	assert.Equal(t, context.Canceled, err)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		assert.Equal(t, "slow", <-received, "canceled request must not be sent")
+		cancel()
+	}()
+	_, err = client.SendContext(ctx, []byte("slow"))
+	assert.Equal(t, context.Canceled, err)
+
+	// The response to the canceled request is discarded.
+	close(release)
+	res, err := client.SendContext(context.Background(), []byte("world"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "world", string(res))
+	}
+}
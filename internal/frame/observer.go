@@ -0,0 +1,54 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frame
+
+import "time"
+
+// Observer is notified of the frames exchanged by a Server or Client. It
+// may be used to instrument framed traffic, for example, with request size
+// and latency metrics, without wrapping the underlying reader and writer.
+//
+// Methods of an Observer are called synchronously from the goroutine
+// exchanging the frames and must not block.
+type Observer interface {
+	// OnFrameRead is called with the size in bytes of every frame read,
+	// not including its length prefix.
+	OnFrameRead(size int)
+
+	// OnFrameWritten is called with the size in bytes of every frame
+	// written, not including its length prefix.
+	OnFrameWritten(size int)
+
+	// OnHandle is called after every request with the time taken to
+	// respond to it and the error, if any.
+	//
+	// For a Server, this is the time taken by the Handler. For a Client,
+	// this is the time between sending the request and receiving its
+	// response.
+	OnHandle(d time.Duration, err error)
+}
+
+// nopObserver is the Observer used if none was provided.
+type nopObserver struct{}
+
+func (nopObserver) OnFrameRead(int)               {}
+func (nopObserver) OnFrameWritten(int)            {}
+func (nopObserver) OnHandle(time.Duration, error) {}
@@ -0,0 +1,250 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frame
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"go.uber.org/multierr"
+)
+
+// SeqIDFunc returns the sequence ID of a framed request or response.
+//
+// PipelinedClient uses it to match responses to the requests they answer.
+type SeqIDFunc func([]byte) (int32, error)
+
+// PipelinedClient provides bidirectional outgoing framed communication with
+// multiple requests in flight at the same time.
+//
+// Unlike Client, requests do not wait for earlier requests to be answered.
+// Responses are matched to their requests by sequence ID so they may arrive
+// in any order. Each in-flight request must have a distinct sequence ID.
+// The sequence ID of a cancelled request stays in use until its response
+// arrives or the client stops.
+type PipelinedClient struct {
+	r     *Reader
+	w     *Writer
+	seqID SeqIDFunc
+
+	mu sync.Mutex
+
+	// Requests waiting for responses, keyed by sequence ID. Cancelled
+	// requests keep their entries, with a nil channel, until their responses
+	// arrive so that their sequence IDs are not reused in the meantime.
+	pending map[int32]chan frameResult
+
+	// Non-nil once the client has stopped reading responses. All pending
+	// and future requests fail with this error.
+	err error
+}
+
+// NewPipelinedClient builds a new PipelinedClient which uses the given writer
+// to send requests and the given reader to read their responses. seqID
+// extracts sequence IDs from both.
+//
+// Responses are read in the background until Close is called or reading
+// fails.
+func NewPipelinedClient(w io.Writer, r io.Reader, seqID SeqIDFunc) *PipelinedClient {
+	c := &PipelinedClient{
+		r:       NewReader(r),
+		w:       NewWriter(w),
+		seqID:   seqID,
+		pending: make(map[int32]chan frameResult),
+	}
+	go c.readLoop()
+	return c
+}
+
+// Send sends the given frame and returns its response.
+func (c *PipelinedClient) Send(b []byte) ([]byte, error) {
+	return c.SendContext(context.Background(), b)
+}
+
+// SendContext sends the given frame and returns its response.
+//
+// If the context expires before the response is received, SendContext
+// returns the context's error. The response, if it arrives later, is
+// discarded. Until then, requests reusing the same sequence ID are rejected
+// so that the late response is not mistaken for theirs.
+func (c *PipelinedClient) SendContext(ctx context.Context, b []byte) ([]byte, error) {
+	id, err := c.seqID(b)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine sequence ID of request: %v", err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ch := make(chan frameResult, 1)
+	c.mu.Lock()
+	if c.err != nil {
+		err := c.err
+		c.mu.Unlock()
+		return nil, err
+	}
+	if _, ok := c.pending[id]; ok {
+		c.mu.Unlock()
+		return nil, duplicateSeqIDError{SeqID: id}
+	}
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	if err := c.w.Write(b); err != nil {
+		c.forget(id)
+		return nil, err
+	}
+
+	select {
+	case res := <-ch:
+		return res.Body, res.Err
+	case <-ctx.Done():
+		c.cancel(id, ch)
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops the client. Pending requests fail with an error and the Reader
+// and Writer are closed.
+func (c *PipelinedClient) Close() error {
+	c.stop(errClientClosed)
+	return multierr.Append(c.r.Close(), c.w.Close())
+}
+
+func (c *PipelinedClient) readLoop() {
+	for {
+		b, err := c.r.Read()
+		if err != nil {
+			c.stop(err)
+			return
+		}
+
+		id, err := c.seqID(b)
+		if err != nil {
+			c.stop(fmt.Errorf("could not determine sequence ID of response: %v", err))
+			return
+		}
+
+		c.mu.Lock()
+		ch, ok := c.pending[id]
+		delete(c.pending, id)
+		c.mu.Unlock()
+
+		// Responses to requests that were cancelled are dropped.
+		if ok && ch != nil {
+			ch <- frameResult{Body: b}
+		}
+	}
+}
+
+// forget stops waiting for the response to the given request.
+func (c *PipelinedClient) forget(id int32) {
+	c.mu.Lock()
+	delete(c.pending, id)
+	c.mu.Unlock()
+}
+
+// cancel stops waiting for the response to the given request but keeps its
+// sequence ID reserved until the response arrives.
+func (c *PipelinedClient) cancel(id int32, ch chan frameResult) {
+	c.mu.Lock()
+	// The response may have arrived, or the client stopped, while the
+	// context was being cancelled.
+	if c.pending[id] == ch {
+		c.pending[id] = nil
+	}
+	c.mu.Unlock()
+}
+
+// stop fails all pending and future requests with the given error. Only the
+// first call has an effect.
+func (c *PipelinedClient) stop(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return
+	}
+	c.err = err
+
+	for id, ch := range c.pending {
+		if ch != nil {
+			ch <- frameResult{Err: err}
+		}
+		delete(c.pending, id)
+	}
+}
+
+// Versioned envelopes start with this prefix. See protocol/binary.
+const (
+	envelopeVersionMask = 0xffff0000
+	envelopeVersion1    = 0x80010000
+)
+
+// BinaryEnvelopeSeqID is a SeqIDFunc for frames holding Thrift Binary
+// protocol envelopes. Both strict and non-strict envelopes are supported.
+func BinaryEnvelopeSeqID(b []byte) (int32, error) {
+	if len(b) < 4 {
+		return 0, errShortEnvelope
+	}
+
+	// Strict envelopes start with the version and type, followed by the
+	// name. Non-strict envelopes start with the name, followed by a 1-byte
+	// type.
+	var nameOffset, typeLen int
+	initial := binary.BigEndian.Uint32(b)
+	if int32(initial) < 0 {
+		if initial&envelopeVersionMask != envelopeVersion1 {
+			return 0, fmt.Errorf("unsupported envelope version %#x", initial&envelopeVersionMask)
+		}
+		if len(b) < 8 {
+			return 0, errShortEnvelope
+		}
+		nameOffset = 4
+	} else {
+		typeLen = 1
+	}
+
+	nameLen := int(binary.BigEndian.Uint32(b[nameOffset:]))
+	seqOffset := nameOffset + 4 + nameLen + typeLen
+	if nameLen < 0 || seqOffset < 0 || len(b) < seqOffset+4 {
+		return 0, errShortEnvelope
+	}
+	return int32(binary.BigEndian.Uint32(b[seqOffset:])), nil
+}
+
+var (
+	errClientClosed  = errors.New("client is closed")
+	errShortEnvelope = errors.New("frame is too short to hold an envelope header")
+)
+
+type duplicateSeqIDError struct {
+	SeqID int32
+}
+
+func (e duplicateSeqIDError) Error() string {
+	return fmt.Sprintf("a request with sequence ID %d is already in flight", e.SeqID)
+}
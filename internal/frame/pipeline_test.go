@@ -0,0 +1,330 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frame
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seqIDPrefix is a SeqIDFunc for frames which start with their sequence ID.
+func seqIDPrefix(b []byte) (int32, error) {
+	if len(b) < 4 {
+		return 0, errShortEnvelope
+	}
+	return int32(binary.BigEndian.Uint32(b)), nil
+}
+
+func seqIDFrame(id int32, body string) []byte {
+	b := make([]byte, 4, 4+len(body))
+	binary.BigEndian.PutUint32(b, uint32(id))
+	return append(b, body...)
+}
+
+// pipelineServer is the remote end of a PipelinedClient connection.
+type pipelineServer struct {
+	r *Reader
+	w *Writer
+}
+
+func newPipelinedClientPair() (*PipelinedClient, pipelineServer) {
+	serverReader, clientWriter := io.Pipe()
+	clientReader, serverWriter := io.Pipe()
+
+	client := NewPipelinedClient(clientWriter, clientReader, seqIDPrefix)
+	server := pipelineServer{r: NewReader(serverReader), w: NewWriter(serverWriter)}
+	return client, server
+}
+
+func (s pipelineServer) Close() error {
+	s.r.Close()
+	return s.w.Close()
+}
+
+type sendResult struct {
+	Body []byte
+	Err  error
+}
+
+func sendAsync(c *PipelinedClient, ctx context.Context, b []byte) <-chan sendResult {
+	ch := make(chan sendResult, 1)
+	go func() {
+		body, err := c.SendContext(ctx, b)
+		ch <- sendResult{Body: body, Err: err}
+	}()
+	return ch
+}
+
+func TestPipelinedClientOutOfOrder(t *testing.T) {
+	client, server := newPipelinedClientPair()
+	defer server.Close()
+	defer client.Close()
+
+	const n = 10
+	results := make([]<-chan sendResult, n)
+	for i := 0; i < n; i++ {
+		results[i] = sendAsync(client, context.Background(), seqIDFrame(int32(i), "request"))
+	}
+
+	// Collect all requests before responding to any of them to prove that
+	// they were in flight at the same time.
+	ids := make([]int32, 0, n)
+	for i := 0; i < n; i++ {
+		req, err := server.r.Read()
+		require.NoError(t, err)
+		id, err := seqIDPrefix(req)
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	for i := len(ids) - 1; i >= 0; i-- {
+		require.NoError(t, server.w.Write(seqIDFrame(ids[i], "response")))
+	}
+
+	for i, ch := range results {
+		res := <-ch
+		if assert.NoError(t, res.Err, "request %d failed", i) {
+			assert.Equal(t, seqIDFrame(int32(i), "response"), res.Body)
+		}
+	}
+}
+
+func TestPipelinedClientCancel(t *testing.T) {
+	client, server := newPipelinedClientPair()
+	defer server.Close()
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := sendAsync(client, ctx, seqIDFrame(1, "slow"))
+
+	_, err := server.r.Read()
+	require.NoError(t, err)
+	cancel()
+
+	res := <-cancelled
+	assert.Equal(t, context.Canceled, res.Err)
+
+	// The late response is discarded without affecting other requests.
+	require.NoError(t, server.w.Write(seqIDFrame(1, "late")))
+
+	next := sendAsync(client, context.Background(), seqIDFrame(2, "next"))
+	_, err = server.r.Read()
+	require.NoError(t, err)
+	require.NoError(t, server.w.Write(seqIDFrame(2, "next response")))
+
+	res = <-next
+	if assert.NoError(t, res.Err) {
+		assert.Equal(t, seqIDFrame(2, "next response"), res.Body)
+	}
+}
+
+func TestPipelinedClientCancelReusedSeqID(t *testing.T) {
+	client, server := newPipelinedClientPair()
+	defer server.Close()
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := sendAsync(client, ctx, seqIDFrame(1, "slow"))
+
+	_, err := server.r.Read()
+	require.NoError(t, err)
+	cancel()
+	assert.Equal(t, context.Canceled, (<-cancelled).Err)
+
+	// The sequence ID stays reserved until the late response arrives.
+	_, err = client.Send(seqIDFrame(1, "reused"))
+	assert.Equal(t, duplicateSeqIDError{SeqID: 1}, err)
+
+	// Responses are read in order so once the response to this request
+	// arrives, the late response has been dropped.
+	barrier := sendAsync(client, context.Background(), seqIDFrame(2, "sync"))
+	_, err = server.r.Read()
+	require.NoError(t, err)
+	require.NoError(t, server.w.Write(seqIDFrame(1, "late")))
+	require.NoError(t, server.w.Write(seqIDFrame(2, "sync response")))
+	require.NoError(t, (<-barrier).Err)
+
+	// The ID may now be reused and the new request receives its own
+	// response.
+	reused := sendAsync(client, context.Background(), seqIDFrame(1, "reused"))
+	req, err := server.r.Read()
+	require.NoError(t, err)
+	assert.Equal(t, seqIDFrame(1, "reused"), req)
+	require.NoError(t, server.w.Write(seqIDFrame(1, "reused response")))
+
+	res := <-reused
+	if assert.NoError(t, res.Err) {
+		assert.Equal(t, seqIDFrame(1, "reused response"), res.Body)
+	}
+}
+
+func TestPipelinedClientCancelClose(t *testing.T) {
+	client, server := newPipelinedClientPair()
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := sendAsync(client, ctx, seqIDFrame(1, "slow"))
+
+	_, err := server.r.Read()
+	require.NoError(t, err)
+	cancel()
+	assert.Equal(t, context.Canceled, (<-cancelled).Err)
+
+	// Closing the client releases reserved sequence IDs.
+	require.NoError(t, client.Close())
+	client.mu.Lock()
+	assert.Empty(t, client.pending)
+	client.mu.Unlock()
+}
+
+func TestPipelinedClientExpiredContext(t *testing.T) {
+	client, server := newPipelinedClientPair()
+	defer server.Close()
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+
+	_, err := client.SendContext(ctx, seqIDFrame(1, "request"))
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestPipelinedClientDuplicateSeqID(t *testing.T) {
+	client, server := newPipelinedClientPair()
+	defer server.Close()
+	defer client.Close()
+
+	first := sendAsync(client, context.Background(), seqIDFrame(1, "first"))
+	_, err := server.r.Read()
+	require.NoError(t, err)
+
+	_, err = client.Send(seqIDFrame(1, "second"))
+	assert.EqualError(t, err, "a request with sequence ID 1 is already in flight")
+
+	require.NoError(t, server.w.Write(seqIDFrame(1, "response")))
+	assert.NoError(t, (<-first).Err)
+}
+
+func TestPipelinedClientClose(t *testing.T) {
+	client, server := newPipelinedClientPair()
+	defer server.Close()
+
+	pending := sendAsync(client, context.Background(), seqIDFrame(1, "request"))
+	_, err := server.r.Read()
+	require.NoError(t, err)
+
+	require.NoError(t, client.Close())
+	assert.Equal(t, errClientClosed, (<-pending).Err)
+
+	_, err = client.Send(seqIDFrame(2, "request"))
+	assert.Equal(t, errClientClosed, err)
+}
+
+func TestPipelinedClientReadError(t *testing.T) {
+	client, server := newPipelinedClientPair()
+	defer client.Close()
+
+	pending := sendAsync(client, context.Background(), seqIDFrame(1, "request"))
+	_, err := server.r.Read()
+	require.NoError(t, err)
+
+	require.NoError(t, server.Close())
+	assert.Equal(t, io.EOF, (<-pending).Err)
+}
+
+func TestPipelinedClientInvalidRequest(t *testing.T) {
+	client, server := newPipelinedClientPair()
+	defer server.Close()
+	defer client.Close()
+
+	_, err := client.Send([]byte{1})
+	assert.EqualError(t, err, "could not determine sequence ID of request: "+errShortEnvelope.Error())
+}
+
+func TestBinaryEnvelopeSeqID(t *testing.T) {
+	var strict bytes.Buffer
+	require.NoError(t, protocol.Binary.EncodeEnveloped(wire.Envelope{
+		Name:  "hello",
+		Type:  wire.Reply,
+		SeqID: 42,
+		Value: wire.NewValueStruct(wire.Struct{}),
+	}, &strict))
+
+	nonStrict := []byte{
+		0x00, 0x00, 0x00, 0x05, // name length
+		'h', 'e', 'l', 'l', 'o',
+		0x01,                   // type
+		0xff, 0xff, 0xff, 0xfe, // seqID
+		0x00, // empty struct
+	}
+
+	tests := []struct {
+		desc    string
+		give    []byte
+		want    int32
+		wantErr string
+	}{
+		{desc: "strict", give: strict.Bytes(), want: 42},
+		{desc: "non-strict", give: nonStrict, want: -2},
+		{
+			desc:    "empty",
+			give:    []byte{},
+			wantErr: errShortEnvelope.Error(),
+		},
+		{
+			desc:    "truncated strict",
+			give:    strict.Bytes()[:10],
+			wantErr: errShortEnvelope.Error(),
+		},
+		{
+			desc:    "truncated non-strict",
+			give:    nonStrict[:11],
+			wantErr: errShortEnvelope.Error(),
+		},
+		{
+			desc:    "unknown version",
+			give:    []byte{0x80, 0x02, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00},
+			wantErr: "unsupported envelope version 0x80020000",
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := BinaryEnvelopeSeqID(tt.give)
+		if tt.wantErr != "" {
+			assert.EqualError(t, err, tt.wantErr, tt.desc)
+			continue
+		}
+		if assert.NoError(t, err, tt.desc) {
+			assert.Equal(t, tt.want, got, tt.desc)
+		}
+	}
+}
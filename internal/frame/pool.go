@@ -0,0 +1,222 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frame
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"go.uber.org/multierr"
+)
+
+var errPoolClosed = errors.New("pool is closed")
+
+// DefaultPoolSize is the maximum number of connections a Pool opens at a
+// time unless configured otherwise with PoolSize.
+const DefaultPoolSize = 8
+
+// DialFunc opens a new connection for a Pool.
+type DialFunc func() (io.ReadWriteCloser, error)
+
+// PoolOption customizes the behavior of a Pool.
+type PoolOption func(*Pool)
+
+// PoolSize specifies the maximum number of connections the Pool may have
+// open at a time. Requests sent while all connections are in use wait for
+// one of them to become available.
+//
+// Defaults to DefaultPoolSize.
+func PoolSize(n int) PoolOption {
+	return func(p *Pool) {
+		p.size = n
+	}
+}
+
+// MaxIdle specifies the maximum number of idle connections the Pool keeps
+// open for later requests. Connections beyond this are closed as soon as
+// their requests finish.
+//
+// Defaults to the size of the Pool.
+func MaxIdle(n int) PoolOption {
+	return func(p *Pool) {
+		p.maxIdle = n
+	}
+}
+
+// HealthCheck specifies a function which checks that an idle connection is
+// still usable before the Pool uses it for a request. Connections for which
+// the check fails are closed and replaced.
+//
+// Idle connections are not checked by default.
+func HealthCheck(f func(*Client) error) PoolOption {
+	return func(p *Pool) {
+		p.healthCheck = f
+	}
+}
+
+// Pool provides bidirectional outgoing framed communication over multiple
+// connections.
+//
+// Each connection serves one request at a time like Client, but requests
+// sent concurrently are spread over separate connections instead of waiting
+// for each other. Connections are opened on demand and reused for later
+// requests.
+//
+// Pool implements the ContextTransport interface of internal/envelope, so
+// it may back an envelope client used by many goroutines at once.
+type Pool struct {
+	dial        DialFunc
+	size        int
+	maxIdle     int
+	healthCheck func(*Client) error
+
+	// Holds a token for every connection that is in use.
+	tokens chan struct{}
+
+	mu     sync.Mutex
+	idle   []*poolConn
+	closed bool
+}
+
+type poolConn struct {
+	*Client
+
+	conn io.Closer
+}
+
+// NewPool builds a new Pool which opens connections with the given function.
+func NewPool(dial DialFunc, opts ...PoolOption) *Pool {
+	p := &Pool{dial: dial, size: DefaultPoolSize}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.size < 1 {
+		p.size = 1
+	}
+	if p.maxIdle <= 0 || p.maxIdle > p.size {
+		p.maxIdle = p.size
+	}
+	p.tokens = make(chan struct{}, p.size)
+	return p
+}
+
+// Send sends the given frame over an available connection and returns its
+// response.
+//
+// A connection which fails to send the request or read its response is
+// closed rather than reused.
+func (p *Pool) Send(b []byte) ([]byte, error) {
+	return p.SendContext(context.Background(), b)
+}
+
+// SendContext is like Send but returns the context's error if the context
+// is done before the response is received. The connection used for the
+// request is closed if the request was already sent.
+func (p *Pool) SendContext(ctx context.Context, b []byte) ([]byte, error) {
+	select {
+	case p.tokens <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-p.tokens }()
+
+	c, err := p.get()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.SendContext(ctx, b)
+	if err != nil {
+		// The connection may have been left in the middle of a frame.
+		return nil, multierr.Append(err, c.conn.Close())
+	}
+
+	p.put(c)
+	return res, nil
+}
+
+// get returns a healthy idle connection or opens a new one.
+func (p *Pool) get() (*poolConn, error) {
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return nil, errPoolClosed
+		}
+		var c *poolConn
+		if n := len(p.idle); n > 0 {
+			c = p.idle[n-1]
+			p.idle[n-1] = nil
+			p.idle = p.idle[:n-1]
+		}
+		p.mu.Unlock()
+
+		if c == nil {
+			break
+		}
+		if p.healthCheck == nil {
+			return c, nil
+		}
+		if err := p.healthCheck(c.Client); err == nil {
+			return c, nil
+		}
+		// Replace connections that fail the health check.
+		c.conn.Close()
+	}
+
+	conn, err := p.dial()
+	if err != nil {
+		return nil, err
+	}
+	return &poolConn{Client: NewClient(conn, conn), conn: conn}, nil
+}
+
+// put returns a connection to the pool after its request has finished.
+func (p *Pool) put(c *poolConn) {
+	p.mu.Lock()
+	if !p.closed && len(p.idle) < p.maxIdle {
+		p.idle = append(p.idle, c)
+		c = nil
+	}
+	p.mu.Unlock()
+
+	if c != nil {
+		c.conn.Close()
+	}
+}
+
+// Close closes the idle connections of the Pool. Connections in use are
+// closed when their requests finish. Requests sent after Close fail.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.closed = true
+	p.mu.Unlock()
+
+	var err error
+	for _, c := range idle {
+		err = multierr.Append(err, c.conn.Close())
+	}
+	return err
+}
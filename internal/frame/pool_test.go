@@ -0,0 +1,273 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frame
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+// pipeDialer opens in-memory connections served by frame Servers with the
+// given handler.
+type pipeDialer struct {
+	h Handler
+
+	dials  atomic.Int32
+	closed atomic.Int32
+	fail   atomic.Bool
+}
+
+func (d *pipeDialer) Dial() (io.ReadWriteCloser, error) {
+	if d.fail.Load() {
+		return nil, errors.New("dial failed")
+	}
+	d.dials.Inc()
+
+	client, server := net.Pipe()
+	go NewServer(server, server).Serve(d.h)
+	return &countingCloser{Conn: client, closed: &d.closed}, nil
+}
+
+type countingCloser struct {
+	net.Conn
+
+	closed *atomic.Int32
+	once   sync.Once
+}
+
+func (c *countingCloser) Close() error {
+	c.once.Do(func() { c.closed.Inc() })
+	return c.Conn.Close()
+}
+
+func echoHandler() Handler {
+	return handlerFunc(func(b []byte) ([]byte, error) { return b, nil })
+}
+
+func TestPoolReusesConnections(t *testing.T) {
+	d := &pipeDialer{h: echoHandler()}
+	p := NewPool(d.Dial)
+	defer p.Close()
+
+	for i := 0; i < 10; i++ {
+		res, err := p.Send([]byte("hello"))
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(res))
+	}
+	assert.Equal(t, int32(1), d.dials.Load(), "sequential requests must share a connection")
+}
+
+func TestPoolConcurrentRequests(t *testing.T) {
+	const size = 3
+
+	// The handler blocks until size requests are in flight at the same time,
+	// which is possible only if they use separate connections.
+	var (
+		wg      sync.WaitGroup
+		arrived sync.WaitGroup
+	)
+	arrived.Add(size)
+	d := &pipeDialer{h: handlerFunc(func(b []byte) ([]byte, error) {
+		arrived.Done()
+		arrived.Wait()
+		return b, nil
+	})}
+	p := NewPool(d.Dial, PoolSize(size))
+	defer p.Close()
+
+	for i := 0; i < size; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := p.Send([]byte("hello"))
+			assert.NoError(t, err)
+			assert.Equal(t, "hello", string(res))
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("requests were serialized")
+	}
+	assert.Equal(t, int32(size), d.dials.Load())
+}
+
+func TestPoolSizeLimit(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		inFlight int
+		maxSeen  int
+	)
+	d := &pipeDialer{h: handlerFunc(func(b []byte) ([]byte, error) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxSeen {
+			maxSeen = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return b, nil
+	})}
+	p := NewPool(d.Dial, PoolSize(2), MaxIdle(1))
+	defer p.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := p.Send([]byte("hello"))
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.True(t, maxSeen <= 2, "at most 2 requests may be in flight, saw %v", maxSeen)
+	assert.Equal(t, d.dials.Load()-1, d.closed.Load(),
+		"all connections but one idle connection must be closed")
+}
+
+func TestPoolHealthCheck(t *testing.T) {
+	d := &pipeDialer{h: echoHandler()}
+
+	var healthy atomic.Bool
+	p := NewPool(d.Dial, HealthCheck(func(c *Client) error {
+		if !healthy.Load() {
+			return errors.New("unhealthy")
+		}
+		res, err := c.Send([]byte("ping"))
+		if err == nil && string(res) != "ping" {
+			err = errors.New("unexpected response")
+		}
+		return err
+	}))
+	defer p.Close()
+
+	_, err := p.Send([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), d.dials.Load())
+
+	_, err = p.Send([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), d.dials.Load(), "unhealthy connection must be replaced")
+	assert.Equal(t, int32(1), d.closed.Load(), "unhealthy connection must be closed")
+
+	healthy.Store(true)
+	_, err = p.Send([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), d.dials.Load(), "healthy connection must be reused")
+}
+
+func TestPoolDropsBrokenConnections(t *testing.T) {
+	d := &pipeDialer{h: handlerFunc(func(b []byte) ([]byte, error) {
+		if string(b) == "fail" {
+			return nil, errors.New("great sadness")
+		}
+		return b, nil
+	})}
+	p := NewPool(d.Dial)
+	defer p.Close()
+
+	_, err := p.Send([]byte("fail"))
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), d.closed.Load())
+
+	res, err := p.Send([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(res))
+	assert.Equal(t, int32(2), d.dials.Load())
+}
+
+func TestPoolDialError(t *testing.T) {
+	d := &pipeDialer{h: echoHandler()}
+	d.fail.Store(true)
+	p := NewPool(d.Dial)
+	defer p.Close()
+
+	_, err := p.Send([]byte("hello"))
+	assert.EqualError(t, err, "dial failed")
+}
+
+func TestPoolClose(t *testing.T) {
+	d := &pipeDialer{h: echoHandler()}
+	p := NewPool(d.Dial)
+
+	_, err := p.Send([]byte("hello"))
+	require.NoError(t, err)
+
+	require.NoError(t, p.Close())
+	assert.Equal(t, int32(1), d.closed.Load(), "idle connections must be closed")
+
+	_, err = p.Send([]byte("hello"))
+	assert.Equal(t, errPoolClosed, err)
+}
+
+func TestPoolSendContext(t *testing.T) {
+	received := make(chan struct{})
+	done := make(chan struct{})
+	defer close(done)
+	d := &pipeDialer{h: handlerFunc(func(b []byte) ([]byte, error) {
+		received <- struct{}{}
+		<-done // never respond
+		return b, nil
+	})}
+	p := NewPool(d.Dial, PoolSize(1))
+	defer p.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-received
+		cancel()
+	}()
+	_, err := p.SendContext(ctx, []byte("hello"))
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, int32(1), d.closed.Load(), "connection of a canceled request must be closed")
+
+	// Requests canceled while waiting for a connection are not sent. Take
+	// the only connection so that the request has to wait.
+	p.tokens <- struct{}{}
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = p.SendContext(ctx, []byte("hello"))
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, int32(1), d.dials.Load())
+	<-p.tokens
+}
@@ -21,8 +21,11 @@
 package frame
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"sync"
+	"time"
 
 	"go.uber.org/atomic"
 	"go.uber.org/multierr"
@@ -34,6 +37,42 @@ type Handler interface {
 	Handle([]byte) ([]byte, error)
 }
 
+// ServerOption customizes the behavior of a Server.
+type ServerOption func(*Server)
+
+// HandlerTimeout specifies the maximum amount of time a Handler may take to
+// respond to a request. If a Handler does not respond in time, Serve stops
+// with an error.
+//
+// A Handler that times out continues to run in the background because
+// Handlers cannot be cancelled. Its response is discarded.
+//
+// Handlers may take an unlimited amount of time by default.
+func HandlerTimeout(d time.Duration) ServerOption {
+	return func(s *Server) {
+		s.handlerTimeout = d
+	}
+}
+
+// IdleTimeout specifies the maximum amount of time the Server will wait for
+// the next request. If no request is received in time, Serve stops with an
+// error and the Reader and Writer are closed.
+//
+// The Server waits for requests forever by default.
+func IdleTimeout(d time.Duration) ServerOption {
+	return func(s *Server) {
+		s.idleTimeout = d
+	}
+}
+
+// ServerObserver specifies an Observer which is notified of every request
+// read, handled, and responded to by the Server.
+func ServerObserver(o Observer) ServerOption {
+	return func(s *Server) {
+		s.observer = o
+	}
+}
+
 // Server provides bidirectional incoming framed communication.
 //
 // It allows receiving framed requests and responding to them.
@@ -41,25 +80,40 @@ type Server struct {
 	r *Reader
 	w *Writer
 
+	handlerTimeout time.Duration
+	idleTimeout    time.Duration
+	observer       Observer
+
 	running *atomic.Bool
+
+	// Closed when Serve returns.
+	stopped     chan struct{}
+	stoppedOnce sync.Once
 }
 
 // NewServer builds a new server which reads requests from the given Reader
 // and writes responses to the given Writer.
-func NewServer(r io.Reader, w io.Writer) *Server {
-	return &Server{
-		r:       NewReader(r),
-		w:       NewWriter(w),
-		running: atomic.NewBool(false),
+func NewServer(r io.Reader, w io.Writer, opts ...ServerOption) *Server {
+	s := &Server{
+		r:        NewReader(r),
+		w:        NewWriter(w),
+		observer: nopObserver{},
+		running:  atomic.NewBool(false),
+		stopped:  make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // Serve serves the given Handler with the Server.
 //
 // Only one request is served at a time. The server stops handling requests if
-// there is an IO error or an unhandled error is received from the Handler.
+// there is an IO error, an unhandled error is received from the Handler, or
+// one of the configured timeouts is exceeded.
 //
-// This blocks until the server is stopped using Stop.
+// This blocks until the server is stopped using Stop or Shutdown.
 func (s *Server) Serve(h Handler) (err error) {
 	if s.running.Swap(true) {
 		return fmt.Errorf("server is already running")
@@ -68,10 +122,11 @@ func (s *Server) Serve(h Handler) (err error) {
 	defer func() {
 		err = multierr.Append(err, s.r.Close())
 		err = multierr.Append(err, s.w.Close())
+		s.stoppedOnce.Do(func() { close(s.stopped) })
 	}()
 
 	for s.running.Load() {
-		req, err := s.r.Read()
+		req, err := s.read()
 		if err != nil {
 			// If the error occurred because the server was stopped, ignore it.
 			if !s.running.Load() {
@@ -80,8 +135,11 @@ func (s *Server) Serve(h Handler) (err error) {
 
 			return err
 		}
+		s.observer.OnFrameRead(len(req))
 
-		res, err := h.Handle(req)
+		start := time.Now()
+		res, err := s.handle(h, req)
+		s.observer.OnHandle(time.Since(start), err)
 		if err != nil {
 			return err
 		}
@@ -89,11 +147,61 @@ func (s *Server) Serve(h Handler) (err error) {
 		if err := s.w.Write(res); err != nil {
 			return err
 		}
+		s.observer.OnFrameWritten(len(res))
 	}
 
 	return nil
 }
 
+// read reads the next request, giving up if the idle timeout is exceeded.
+func (s *Server) read() ([]byte, error) {
+	if s.idleTimeout <= 0 {
+		return s.r.Read()
+	}
+
+	ch := make(chan frameResult, 1)
+	go func() {
+		b, err := s.r.Read()
+		ch <- frameResult{Body: b, Err: err}
+	}()
+
+	timer := time.NewTimer(s.idleTimeout)
+	defer timer.Stop()
+
+	select {
+	case res := <-ch:
+		return res.Body, res.Err
+	case <-timer.C:
+		// The pending Read will be interrupted when Serve closes the
+		// Reader.
+		return nil, idleTimeoutError{Timeout: s.idleTimeout}
+	}
+}
+
+// handle calls the handler with the given request, giving up if the handler
+// timeout is exceeded.
+func (s *Server) handle(h Handler, req []byte) ([]byte, error) {
+	if s.handlerTimeout <= 0 {
+		return h.Handle(req)
+	}
+
+	ch := make(chan frameResult, 1)
+	go func() {
+		b, err := h.Handle(req)
+		ch <- frameResult{Body: b, Err: err}
+	}()
+
+	timer := time.NewTimer(s.handlerTimeout)
+	defer timer.Stop()
+
+	select {
+	case res := <-ch:
+		return res.Body, res.Err
+	case <-timer.C:
+		return nil, handlerTimeoutError{Timeout: s.handlerTimeout}
+	}
+}
+
 // Stop tells the Server that it's okay to stop Serve.
 //
 // This is a no-op if the server wasn't already running.
@@ -107,3 +215,53 @@ func (s *Server) Stop() error {
 	}
 	return nil
 }
+
+// Shutdown gracefully stops the Server. No new requests are accepted, and
+// Shutdown blocks until the response to the in-flight request, if any, has
+// been written and the Reader and Writer have been closed.
+//
+// If the context expires before the Server has stopped, Shutdown returns the
+// context's error. Serve will still return once the in-flight request
+// completes.
+//
+// Shutdown must not be called from inside a Handler because the in-flight
+// request would never complete. Use Stop instead.
+//
+// This is a no-op if the server wasn't already running.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if !s.running.Swap(false) {
+		return nil
+	}
+
+	if err := s.r.Close(); err != nil {
+		return err
+	}
+
+	select {
+	case <-s.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type frameResult struct {
+	Body []byte
+	Err  error
+}
+
+type handlerTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e handlerTimeoutError) Error() string {
+	return fmt.Sprintf("handler did not respond within %v", e.Timeout)
+}
+
+type idleTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e idleTimeoutError) Error() string {
+	return fmt.Sprintf("no request received within %v", e.Timeout)
+}
@@ -2,13 +2,17 @@ package frame
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"io"
 	"testing"
+	"time"
 
 	"go.uber.org/thriftrw/internal/iotest"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestServeReadError(t *testing.T) {
@@ -69,3 +73,134 @@ func TestServeWriteError(t *testing.T) {
 
 	assert.Equal(t, errors.New("great sadness"), err)
 }
+
+func TestServeHandlerTimeout(t *testing.T) {
+	r := bytes.NewReader([]byte{0x00, 0x00, 0x00, 0x00})
+	var w bytes.Buffer
+
+	release := make(chan struct{})
+	defer close(release)
+
+	server := NewServer(r, &w, HandlerTimeout(10*time.Millisecond))
+	err := server.Serve(handlerFunc(
+		func([]byte) ([]byte, error) {
+			<-release
+			return []byte("hello"), nil
+		},
+	))
+
+	assert.Equal(t, handlerTimeoutError{Timeout: 10 * time.Millisecond}, err)
+	assert.Empty(t, w.Bytes(), "response must not be written")
+}
+
+func TestServeIdleTimeout(t *testing.T) {
+	r, _ := io.Pipe()
+	var w bytes.Buffer
+
+	server := NewServer(r, &w, IdleTimeout(10*time.Millisecond))
+	err := server.Serve(handlerFunc(
+		func([]byte) ([]byte, error) {
+			return nil, errors.New("unexpected call")
+		},
+	))
+
+	assert.Equal(t, idleTimeoutError{Timeout: 10 * time.Millisecond}, err)
+}
+
+func TestServeIdleTimeoutResetsPerRequest(t *testing.T) {
+	r, rw := io.Pipe()
+	var w bytes.Buffer
+
+	go func() {
+		for i := 0; i < 3; i++ {
+			time.Sleep(5 * time.Millisecond)
+			rw.Write([]byte{0x00, 0x00, 0x00, 0x00})
+		}
+	}()
+
+	var calls int
+	server := NewServer(r, &w, IdleTimeout(50*time.Millisecond))
+	err := server.Serve(handlerFunc(
+		func([]byte) ([]byte, error) {
+			calls++
+			return []byte{}, nil
+		},
+	))
+
+	assert.Equal(t, idleTimeoutError{Timeout: 50 * time.Millisecond}, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestServerShutdownDrains(t *testing.T) {
+	r, rw := io.Pipe()
+	var w bytes.Buffer
+
+	received := make(chan struct{})
+	release := make(chan struct{})
+	server := NewServer(r, &w)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(handlerFunc(
+			func([]byte) ([]byte, error) {
+				close(received)
+				<-release
+				return []byte("hello"), nil
+			},
+		))
+	}()
+
+	go rw.Write([]byte{0x00, 0x00, 0x00, 0x00})
+	<-received
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- server.Shutdown(context.Background())
+	}()
+
+	select {
+	case err := <-shutdown:
+		t.Fatalf("Shutdown returned before the request finished: %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(release)
+	require.NoError(t, <-shutdown)
+	assert.NoError(t, <-served)
+	assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x05, 'h', 'e', 'l', 'l', 'o'}, w.Bytes())
+}
+
+func TestServerShutdownContextExpired(t *testing.T) {
+	r, rw := io.Pipe()
+	var w bytes.Buffer
+
+	received := make(chan struct{})
+	release := make(chan struct{})
+	server := NewServer(r, &w)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(handlerFunc(
+			func([]byte) ([]byte, error) {
+				close(received)
+				<-release
+				return []byte{}, nil
+			},
+		))
+	}()
+
+	go rw.Write([]byte{0x00, 0x00, 0x00, 0x00})
+	<-received
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, server.Shutdown(ctx))
+
+	close(release)
+	assert.NoError(t, <-served)
+}
+
+func TestServerShutdownNotRunning(t *testing.T) {
+	server := NewServer(bytes.NewReader(nil), new(bytes.Buffer))
+	assert.NoError(t, server.Shutdown(context.Background()))
+}
@@ -21,6 +21,7 @@
 package frame
 
 import (
+	"bufio"
 	"encoding/binary"
 	"io"
 	"sync"
@@ -28,6 +29,20 @@ import (
 	"go.uber.org/atomic"
 )
 
+// WriterOption customizes the behavior of a Writer.
+type WriterOption func(*Writer)
+
+// WriterBufferSize buffers up to n bytes of frames in memory before they are
+// written to the underlying io.Writer. Buffered frames are written when the
+// buffer fills up, or when Flush or Close is called.
+//
+// Frames are written to the underlying io.Writer immediately by default.
+func WriterBufferSize(n int) WriterOption {
+	return func(w *Writer) {
+		w.bufferSize = n
+	}
+}
+
 // Writer is a writer for framed messages.
 type Writer struct {
 	sync.Mutex
@@ -35,14 +50,25 @@ type Writer struct {
 	closed atomic.Bool
 	w      io.Writer
 	buff   [4]byte
+
+	// bw buffers frames if the Writer was built with a WriterBufferSize.
+	bufferSize int
+	bw         *bufio.Writer
 }
 
 // NewWriter builds a new Writer which writes frames to the given io.Writer.
 //
 // If the io.Writer is a WriteCloser, its Close method will be called when the
 // frame.Writer is closed.
-func NewWriter(w io.Writer) *Writer {
-	return &Writer{w: w}
+func NewWriter(w io.Writer, opts ...WriterOption) *Writer {
+	writer := &Writer{w: w}
+	for _, opt := range opts {
+		opt(writer)
+	}
+	if writer.bufferSize > 0 {
+		writer.bw = bufio.NewWriterSize(w, writer.bufferSize)
+	}
+	return writer
 }
 
 // Write writes the given frame to the Writer.
@@ -50,9 +76,14 @@ func (w *Writer) Write(b []byte) error {
 	w.Lock()
 	defer w.Unlock()
 
+	var out io.Writer = w.w
+	if w.bw != nil {
+		out = w.bw
+	}
+
 	// TODO(abg): Bounds check?
 	binary.BigEndian.PutUint32(w.buff[:], uint32(len(b)))
-	if _, err := w.w.Write(w.buff[:]); err != nil {
+	if _, err := out.Write(w.buff[:]); err != nil {
 		return err
 	}
 
@@ -60,17 +91,77 @@ func (w *Writer) Write(b []byte) error {
 		return nil
 	}
 
-	_, err := w.w.Write(b)
+	_, err := out.Write(b)
 	return err
 }
 
+// WriteBuffers writes the given frames to the Writer.
+//
+// If the Writer is not buffered, all frames are written to the underlying
+// io.Writer at once. Connections which support vectored I/O, such as
+// *net.TCPConn and *net.UnixConn, receive them in a single system call.
+func (w *Writer) WriteBuffers(frames ...[]byte) error {
+	if len(frames) == 0 {
+		return nil
+	}
+
+	w.Lock()
+	defer w.Unlock()
+
+	headers := make([]byte, 4*len(frames))
+	bufs := make([][]byte, 0, 2*len(frames))
+	for i, b := range frames {
+		// TODO(abg): Bounds check?
+		header := headers[4*i : 4*i+4]
+		binary.BigEndian.PutUint32(header, uint32(len(b)))
+		bufs = append(bufs, header)
+		if len(b) > 0 {
+			bufs = append(bufs, b)
+		}
+	}
+
+	if w.bw != nil {
+		for _, b := range bufs {
+			if _, err := w.bw.Write(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return writeBuffers(w.w, bufs)
+}
+
+// Flush writes all buffered frames to the underlying io.Writer.
+//
+// Flush does nothing if the Writer is not buffered.
+func (w *Writer) Flush() error {
+	w.Lock()
+	defer w.Unlock()
+
+	if w.bw == nil {
+		return nil
+	}
+	return w.bw.Flush()
+}
+
 // Close closes the given Writeer.
+//
+// Buffered frames are flushed before the underlying io.Writer is closed.
 func (w *Writer) Close() error {
 	if w.closed.Swap(true) {
 		return nil // already closed
 	}
+
+	var err error
+	if w.bw != nil {
+		err = w.Flush()
+	}
+
 	if c, ok := w.w.(io.Closer); ok {
-		return c.Close()
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
 	}
-	return nil
+	return err
 }
@@ -115,3 +115,103 @@ func TestWriterClose(t *testing.T) {
 		assert.Equal(t, tt.want, err)
 	}
 }
+
+func TestWriterWriteBuffers(t *testing.T) {
+	tests := []struct {
+		desc       string
+		giveFrames [][]byte
+		wantBody   []byte
+	}{
+		{desc: "no frames"},
+		{
+			desc:       "empty frame",
+			giveFrames: [][]byte{{}},
+			wantBody:   []byte{0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			desc: "multiple frames",
+			giveFrames: [][]byte{
+				{0x01},
+				{},
+				{0x01, 0x02, 0x03},
+			},
+			wantBody: []byte{
+				0x00, 0x00, 0x00, 0x01, 0x01,
+				0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x03, 0x01, 0x02, 0x03,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		var unbuffered bytes.Buffer
+		assert.NoError(t, NewWriter(&unbuffered).WriteBuffers(tt.giveFrames...), tt.desc)
+		assert.Equal(t, tt.wantBody, unbuffered.Bytes(), "%v: unbuffered", tt.desc)
+
+		var buffered bytes.Buffer
+		w := NewWriter(&buffered, WriterBufferSize(1024))
+		assert.NoError(t, w.WriteBuffers(tt.giveFrames...), tt.desc)
+		assert.NoError(t, w.Flush(), tt.desc)
+		assert.Equal(t, tt.wantBody, buffered.Bytes(), "%v: buffered", tt.desc)
+	}
+}
+
+func TestWriterWriteBuffersError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	w := NewMockWriter(mockCtrl)
+	gomock.InOrder(
+		w.EXPECT().Write([]byte{0x00, 0x00, 0x00, 0x01}).Return(4, nil),
+		w.EXPECT().Write([]byte{0x00}).Return(0, errors.New("great sadness")),
+	)
+
+	err := NewWriter(w).WriteBuffers([]byte{0x00}, []byte{0x01})
+	assert.Equal(t, errors.New("great sadness"), err)
+}
+
+func TestWriterBuffered(t *testing.T) {
+	var buff bytes.Buffer
+	w := NewWriter(&buff, WriterBufferSize(8))
+
+	assert.NoError(t, w.Write([]byte{0x01}))
+	assert.Empty(t, buff.Bytes(), "frames must be buffered")
+
+	assert.NoError(t, w.Flush())
+	assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x01, 0x01}, buff.Bytes())
+
+	// Frames larger than the buffer are written without waiting for Flush.
+	assert.NoError(t, w.Write([]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09}))
+	assert.True(t, buff.Len() > 5, "large frames must not wait for Flush")
+	assert.NoError(t, w.Flush())
+	assert.Equal(t, 18, buff.Len())
+
+	assert.NoError(t, w.Write([]byte{0x02}))
+	assert.Equal(t, 18, buff.Len(), "frames must be buffered")
+	assert.NoError(t, w.Close(), "close must flush")
+	assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x01, 0x02}, buff.Bytes()[18:])
+}
+
+func TestWriterFlushUnbuffered(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	// No calls are expected on the mock.
+	assert.NoError(t, NewWriter(NewMockWriter(mockCtrl)).Flush())
+}
+
+func TestWriterCloseFlushError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	w := NewMockWriteCloser(mockCtrl)
+	gomock.InOrder(
+		w.EXPECT().Write([]byte{0x00, 0x00, 0x00, 0x01, 0x01}).
+			Return(0, errors.New("great sadness")),
+		w.EXPECT().Close().Return(nil),
+	)
+
+	writer := NewWriter(w, WriterBufferSize(16))
+	assert.NoError(t, writer.Write([]byte{0x01}))
+	assert.Equal(t, errors.New("great sadness"), writer.Close())
+}
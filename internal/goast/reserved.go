@@ -31,12 +31,29 @@ func init() {
 		"import", "return", "var", "error",
 	}
 
+	// from https://golang.org/ref/spec#Predeclared_identifiers
+	//
+	// These are not keywords but generated code which shadows them may
+	// not compile.
+	predeclaredNames := []string{
+		"any", "bool", "byte", "comparable", "complex64", "complex128",
+		"float32", "float64", "int", "int8", "int16", "int32", "int64",
+		"rune", "string", "uint", "uint8", "uint16", "uint32", "uint64",
+		"uintptr", "true", "false", "iota", "nil", "append", "cap", "clear",
+		"close", "complex", "copy", "delete", "imag", "len", "make", "max",
+		"min", "new", "panic", "print", "println", "real", "recover",
+	}
+
 	for _, n := range reservedNames {
 		_reservedNames[n] = struct{}{}
 	}
+	for _, n := range predeclaredNames {
+		_reservedNames[n] = struct{}{}
+	}
 }
 
-// IsReservedKeyword returns true if the given word is a reserved keyword.
+// IsReservedKeyword returns true if the given word is a reserved keyword or
+// a predeclared identifier.
 func IsReservedKeyword(n string) bool {
 	_, ok := _reservedNames[n]
 	return ok
@@ -0,0 +1,76 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package goast
+
+import (
+	"bytes"
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"strings"
+)
+
+// ErrorPosition returns the position of the first error in an error
+// returned by go/parser.
+func ErrorPosition(err error) (token.Position, bool) {
+	switch e := err.(type) {
+	case scanner.ErrorList:
+		if len(e) > 0 {
+			return e[0].Pos, true
+		}
+	case *scanner.Error:
+		return e.Pos, true
+	}
+	return token.Position{}, false
+}
+
+// Snippet returns the lines of src within context lines of the given line,
+// prefixed by their line numbers. The given line is marked with a ">".
+//
+// 	  2 | func foo() {
+// 	> 3 | 	return 42 +
+// 	  4 | }
+func Snippet(src []byte, line, context int) string {
+	lines := strings.Split(strings.TrimRight(string(src), "\n"), "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+
+	start := line - context
+	if start < 1 {
+		start = 1
+	}
+	end := line + context
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	width := len(fmt.Sprint(end))
+	var buff bytes.Buffer
+	for i := start; i <= end; i++ {
+		marker := " "
+		if i == line {
+			marker = ">"
+		}
+		fmt.Fprintf(&buff, "%s %*d | %s\n", marker, width, i, lines[i-1])
+	}
+	return buff.String()
+}
@@ -0,0 +1,307 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package idlgraph builds the include graph of compiled Thrift modules.
+//
+// Each Thrift file is a node of the graph, annotated with the number of
+// definitions and lines in it, and each include is an edge. Groups of files
+// which include each other, directly or indirectly, are reported as cycles.
+package idlgraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+// Graph is the include graph of a Thrift file.
+type Graph struct {
+	// Nodes of the graph, sorted by path.
+	Nodes []*Node `json:"nodes"`
+
+	// Edges of the graph, sorted by the paths of their endpoints.
+	Edges []*Edge `json:"edges"`
+
+	// Cycles lists groups of files which include each other. Each cycle
+	// is sorted by path, and cycles are sorted by their first path.
+	Cycles [][]string `json:"cycles"`
+}
+
+// Node is a Thrift file in the include graph.
+type Node struct {
+	// Path to the Thrift file relative to the Thrift root.
+	Path string `json:"path"`
+
+	Types     int `json:"types"`
+	Constants int `json:"constants"`
+	Services  int `json:"services"`
+	Lines     int `json:"lines"`
+
+	// Whether this file is part of an include cycle.
+	InCycle bool `json:"inCycle"`
+}
+
+// Edge is an include of one Thrift file by another.
+type Edge struct {
+	// Paths of the including and included files.
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	// Name under which the file is included.
+	Name string `json:"name"`
+
+	// Whether both files are part of the same include cycle.
+	InCycle bool `json:"inCycle"`
+}
+
+// Build builds the include graph of the given module and all modules
+// included by it.
+//
+// Paths in the graph are relative to thriftRoot, which must be an absolute
+// path containing all Thrift files.
+func Build(m *compile.Module, thriftRoot string) (*Graph, error) {
+	b := builder{
+		root:  thriftRoot,
+		nodes: make(map[string]*Node),
+	}
+	if err := b.visit(m); err != nil {
+		return nil, err
+	}
+
+	g := &Graph{Edges: b.edges, Cycles: b.cycles()}
+	for _, n := range b.nodes {
+		g.Nodes = append(g.Nodes, n)
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		return g.Nodes[i].Path < g.Nodes[j].Path
+	})
+	sort.Slice(g.Edges, func(i, j int) bool {
+		ei, ej := g.Edges[i], g.Edges[j]
+		if ei.From != ej.From {
+			return ei.From < ej.From
+		}
+		return ei.To < ej.To
+	})
+
+	component := make(map[string]int)
+	for i, cycle := range g.Cycles {
+		for _, path := range cycle {
+			component[path] = i
+			b.nodes[path].InCycle = true
+		}
+	}
+	for _, e := range g.Edges {
+		ci, okFrom := component[e.From]
+		cj, okTo := component[e.To]
+		e.InCycle = okFrom && okTo && ci == cj
+	}
+	return g, nil
+}
+
+type builder struct {
+	root  string
+	nodes map[string]*Node // keyed by relative path
+	edges []*Edge
+}
+
+func (b *builder) visit(m *compile.Module) error {
+	path, err := b.relPath(m)
+	if err != nil {
+		return err
+	}
+	if _, ok := b.nodes[path]; ok {
+		return nil
+	}
+
+	b.nodes[path] = &Node{
+		Path:      path,
+		Types:     len(m.Types),
+		Constants: len(m.Constants),
+		Services:  len(m.Services),
+		Lines:     countLines(m.Raw),
+	}
+
+	for _, name := range sortedIncludes(m) {
+		included := m.Includes[name].Module
+		to, err := b.relPath(included)
+		if err != nil {
+			return err
+		}
+		b.edges = append(b.edges, &Edge{From: path, To: to, Name: name})
+		if err := b.visit(included); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builder) relPath(m *compile.Module) (string, error) {
+	path, err := filepath.Rel(b.root, m.ThriftPath)
+	if err != nil {
+		return "", fmt.Errorf("could not find path to %q relative to %q: %v", m.ThriftPath, b.root, err)
+	}
+	return filepath.ToSlash(path), nil
+}
+
+// cycles finds the strongly connected components of the graph which
+// contain a cycle using Tarjan's algorithm.
+func (b *builder) cycles() [][]string {
+	adjacent := make(map[string][]string)
+	selfIncluded := make(map[string]bool)
+	for _, e := range b.edges {
+		adjacent[e.From] = append(adjacent[e.From], e.To)
+		if e.From == e.To {
+			selfIncluded[e.From] = true
+		}
+	}
+
+	paths := make([]string, 0, len(b.nodes))
+	for path := range b.nodes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var (
+		index   = make(map[string]int)
+		lowlink = make(map[string]int)
+		onStack = make(map[string]bool)
+		stack   []string
+		cycles  [][]string
+	)
+
+	var connect func(string)
+	connect = func(v string) {
+		index[v] = len(index)
+		lowlink[v] = index[v]
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range adjacent[v] {
+			if _, visited := index[w]; !visited {
+				connect(w)
+				if lowlink[w] < lowlink[v] {
+					lowlink[v] = lowlink[w]
+				}
+			} else if onStack[w] && index[w] < lowlink[v] {
+				lowlink[v] = index[w]
+			}
+		}
+
+		if lowlink[v] != index[v] {
+			return
+		}
+
+		var component []string
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			component = append(component, w)
+			if w == v {
+				break
+			}
+		}
+		if len(component) > 1 || selfIncluded[v] {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, path := range paths {
+		if _, visited := index[path]; !visited {
+			connect(path)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i][0] < cycles[j][0]
+	})
+	return cycles
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language. Files and
+// includes that are part of a cycle are highlighted in red.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var buff bytes.Buffer
+	buff.WriteString("digraph includes {\n")
+	buff.WriteString("\tnode [shape=box];\n")
+	for _, n := range g.Nodes {
+		label := fmt.Sprintf("%s\\n%d types, %d constants, %d services, %d lines",
+			n.Path, n.Types, n.Constants, n.Services, n.Lines)
+		fmt.Fprintf(&buff, "\t%q [label=\"%s\"", n.Path, label)
+		if n.InCycle {
+			buff.WriteString(", color=red")
+		}
+		buff.WriteString("];\n")
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&buff, "\t%q -> %q", e.From, e.To)
+		if e.InCycle {
+			buff.WriteString(" [color=red]")
+		}
+		buff.WriteString(";\n")
+	}
+	buff.WriteString("}\n")
+
+	_, err := w.Write(buff.Bytes())
+	return err
+}
+
+// WriteJSON writes the graph as a JSON object.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	out := *g
+	if out.Nodes == nil {
+		out.Nodes = []*Node{}
+	}
+	if out.Edges == nil {
+		out.Edges = []*Edge{}
+	}
+	if out.Cycles == nil {
+		out.Cycles = [][]string{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func sortedIncludes(m *compile.Module) []string {
+	names := make([]string, 0, len(m.Includes))
+	for name := range m.Includes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// countLines returns the number of lines in the given file contents,
+// counting a trailing line without a newline.
+func countLines(b []byte) int {
+	n := bytes.Count(b, []byte("\n"))
+	if len(b) > 0 && b[len(b)-1] != '\n' {
+		n++
+	}
+	return n
+}
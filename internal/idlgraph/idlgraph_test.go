@@ -0,0 +1,158 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package idlgraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compileFiles(t *testing.T, files map[string]string, main string) (*compile.Module, string) {
+	dir, err := ioutil.TempDir("", "thriftrw-idlgraph-test")
+	require.NoError(t, err)
+
+	for path, contents := range files {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+
+	m, err := compile.Compile(filepath.Join(dir, main))
+	require.NoError(t, err)
+	return m, dir
+}
+
+func TestBuild(t *testing.T) {
+	m, dir := compileFiles(t, map[string]string{
+		"shared/types.thrift": "typedef string UUID\nconst i32 limit = 10\n",
+		"users.thrift": "include \"./shared/types.thrift\"\n" +
+			"struct User { 1: required types.UUID id }\n" +
+			"service Users {}",
+		"main.thrift": "include \"./users.thrift\"\ninclude \"./shared/types.thrift\"\n",
+	}, "main.thrift")
+	defer os.RemoveAll(dir)
+
+	g, err := Build(m, dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*Node{
+		{Path: "main.thrift", Lines: 2},
+		{Path: "shared/types.thrift", Types: 1, Constants: 1, Lines: 2},
+		{Path: "users.thrift", Types: 1, Services: 1, Lines: 3},
+	}, g.Nodes)
+	assert.Equal(t, []*Edge{
+		{From: "main.thrift", To: "shared/types.thrift", Name: "types"},
+		{From: "main.thrift", To: "users.thrift", Name: "users"},
+		{From: "users.thrift", To: "shared/types.thrift", Name: "types"},
+	}, g.Edges)
+	assert.Empty(t, g.Cycles)
+}
+
+func TestBuildCycles(t *testing.T) {
+	m, dir := compileFiles(t, map[string]string{
+		"a.thrift": "include \"./b.thrift\"\ninclude \"./d.thrift\"\n",
+		"b.thrift": "include \"./c.thrift\"\n",
+		"c.thrift": "include \"./a.thrift\"\n",
+		"d.thrift": "include \"./e.thrift\"\n",
+		"e.thrift": "include \"./d.thrift\"\n",
+	}, "a.thrift")
+	defer os.RemoveAll(dir)
+
+	g, err := Build(m, dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{
+		{"a.thrift", "b.thrift", "c.thrift"},
+		{"d.thrift", "e.thrift"},
+	}, g.Cycles)
+	for _, n := range g.Nodes {
+		assert.True(t, n.InCycle, "%v must be part of a cycle", n.Path)
+	}
+	for _, e := range g.Edges {
+		if e.From == "a.thrift" && e.To == "d.thrift" {
+			assert.False(t, e.InCycle, "edge between cycles must not be part of a cycle")
+		} else {
+			assert.True(t, e.InCycle, "%v -> %v must be part of a cycle", e.From, e.To)
+		}
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	g := &Graph{
+		Nodes: []*Node{
+			{Path: "a.thrift", Types: 1, Lines: 3, InCycle: true},
+			{Path: "b.thrift", Services: 2, Lines: 5, InCycle: true},
+			{Path: "c.thrift", Constants: 4, Lines: 7},
+		},
+		Edges: []*Edge{
+			{From: "a.thrift", To: "b.thrift", Name: "b", InCycle: true},
+			{From: "b.thrift", To: "a.thrift", Name: "a", InCycle: true},
+			{From: "b.thrift", To: "c.thrift", Name: "c"},
+		},
+		Cycles: [][]string{{"a.thrift", "b.thrift"}},
+	}
+
+	var buff bytes.Buffer
+	require.NoError(t, g.WriteDOT(&buff))
+	assert.Equal(t, `digraph includes {
+	node [shape=box];
+	"a.thrift" [label="a.thrift\n1 types, 0 constants, 0 services, 3 lines", color=red];
+	"b.thrift" [label="b.thrift\n0 types, 0 constants, 2 services, 5 lines", color=red];
+	"c.thrift" [label="c.thrift\n0 types, 4 constants, 0 services, 7 lines"];
+	"a.thrift" -> "b.thrift" [color=red];
+	"b.thrift" -> "a.thrift" [color=red];
+	"b.thrift" -> "c.thrift";
+}
+`, buff.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buff bytes.Buffer
+	require.NoError(t, (&Graph{
+		Nodes: []*Node{{Path: "a.thrift", Types: 2, Lines: 4}},
+	}).WriteJSON(&buff))
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(buff.Bytes(), &got))
+	assert.Equal(t, map[string]interface{}{
+		"nodes": []interface{}{
+			map[string]interface{}{
+				"path":      "a.thrift",
+				"types":     2.0,
+				"constants": 0.0,
+				"services":  0.0,
+				"lines":     4.0,
+				"inCycle":   false,
+			},
+		},
+		"edges":  []interface{}{},
+		"cycles": []interface{}{},
+	}, got)
+}
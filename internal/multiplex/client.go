@@ -21,7 +21,10 @@
 package multiplex
 
 import (
-	"go.uber.org/thriftrw/internal/envelope"
+	"context"
+
+	"go.uber.org/thriftrw/envelope"
+	intenvelope "go.uber.org/thriftrw/internal/envelope"
 	"go.uber.org/thriftrw/wire"
 )
 
@@ -29,16 +32,20 @@ import (
 //
 // name is the name of the service for which requests are being sent through
 // this client.
-func NewClient(name string, c envelope.Client) envelope.Client {
+func NewClient(name string, c intenvelope.Client) intenvelope.Client {
 	return client{name: name, c: c}
 }
 
 // client is a multiplexing client.
 type client struct {
-	c    envelope.Client
+	c    intenvelope.Client
 	name string
 }
 
 func (c client) Send(name string, reqValue wire.Value) (wire.Value, error) {
-	return c.c.Send(c.name+":"+name, reqValue)
+	return c.c.Send(envelope.MultiplexedName(c.name, name), reqValue)
+}
+
+func (c client) SendContext(ctx context.Context, name string, reqValue wire.Value) (wire.Value, error) {
+	return intenvelope.SendContext(ctx, c.c, envelope.MultiplexedName(c.name, name), reqValue)
 }
@@ -21,40 +21,38 @@
 package multiplex
 
 import (
-	"strings"
-
-	"go.uber.org/thriftrw/internal/envelope"
+	"go.uber.org/thriftrw/envelope"
+	intenvelope "go.uber.org/thriftrw/internal/envelope"
 	"go.uber.org/thriftrw/wire"
 )
 
 // Handler implements a service multiplexer
 type Handler struct {
-	services map[string]envelope.Handler
+	services map[string]intenvelope.Handler
 }
 
 // NewHandler builds a new handler.
 func NewHandler() Handler {
-	return Handler{services: make(map[string]envelope.Handler)}
+	return Handler{services: make(map[string]intenvelope.Handler)}
 }
 
 // Put adds the given service to the multiplexer.
-func (h Handler) Put(name string, service envelope.Handler) {
+func (h Handler) Put(name string, service intenvelope.Handler) {
 	h.services[name] = service
 }
 
 // Handle handles the given request, dispatching to one of the
 // registered services.
 func (h Handler) Handle(name string, req wire.Value) (wire.Value, error) {
-	parts := strings.SplitN(name, ":", 2)
-	if len(parts) < 2 {
-		return wire.Value{}, envelope.ErrUnknownMethod(name)
+	serviceName, method := envelope.SplitMultiplexedName(name)
+	if serviceName == "" {
+		return wire.Value{}, intenvelope.ErrUnknownMethod(name)
 	}
 
-	service, ok := h.services[parts[0]]
+	service, ok := h.services[serviceName]
 	if !ok {
-		return wire.Value{}, envelope.ErrUnknownMethod(name)
+		return wire.Value{}, intenvelope.ErrUnknownMethod(name)
 	}
 
-	name = parts[1]
-	return service.Handle(name, req)
+	return service.Handle(method, req)
 }
@@ -25,6 +25,7 @@
 package pluginapigen
 
 import (
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -95,6 +96,15 @@ func (sgen) Generate(req *api.GenerateServiceRequest) (*api.GenerateServiceRespo
 		if err != nil {
 			return nil, err
 		}
+
+		mockImportPath, mockDir := mockPackage(module)
+		mockPath := filepath.Join(mockDir, strings.ToLower(service.Name)+".go")
+		files[mockPath], err = plugin.GoFileFromTemplate(
+			mockPath, mockTemplate, templateData,
+			append(templateOptions, plugin.GoFileImportPath(mockImportPath))...)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return &api.GenerateServiceResponse{Files: files}, nil
 }
@@ -105,9 +115,36 @@ func getService(req *api.GenerateServiceRequest, id api.ServiceID) *api.Service
 	return req.Services[id]
 }
 
+// allFunctions returns the functions of the given service, including those
+// inherited from its parents.
+func allFunctions(req *api.GenerateServiceRequest, service *api.Service) []*api.Function {
+	var functions []*api.Function
+	if service.ParentID != nil {
+		functions = allFunctions(req, req.Services[*service.ParentID])
+	}
+	return append(functions, service.Functions...)
+}
+
+// mockPackage returns the import path and directory of the package holding
+// mocks for the services of the given module.
+//
+// Mocks are placed in a sibling package named after the parent directory, so
+// mocks for go.uber.org/thriftrw/plugin/api are placed in
+// go.uber.org/thriftrw/plugin/plugintest.
+func mockPackage(m *api.Module) (importPath, dir string) {
+	parent := path.Dir(m.ImportPath)
+	name := path.Base(parent) + "test"
+	return path.Join(parent, name), filepath.Join(filepath.Dir(m.Directory), name)
+}
+
 var templateOptions = []plugin.TemplateOption{
 	plugin.TemplateFunc("basename", filepath.Base),
 	plugin.TemplateFunc("getService", getService),
+	plugin.TemplateFunc("allFunctions", allFunctions),
+	plugin.TemplateFunc("mockPackageName", func(m *api.Module) string {
+		importPath, _ := mockPackage(m)
+		return path.Base(importPath)
+	}),
 }
 
 const interfaceTemplate = `
@@ -134,6 +171,43 @@ type <.Service.Name> interface {
 		) <if .ReturnType>(<formatType .ReturnType>, error)<else>error<end>
 	<end>
 }
+
+<$context := import "context">
+
+// <.Service.Name>Context is the <.Service.Name> service with the context of the
+// request passed to every function.
+type <.Service.Name>Context interface {
+	<if .Service.ParentID>
+		<$parent := getService .Request .Service.ParentID>
+		<if eq $parent.ModuleID .Service.ModuleID>
+			<$parent.Name>Context
+		<else>
+			<$parentModule := index .Request.Modules $parent.ModuleID>
+			<import $parentModule.ImportPath>.<$parent.Name>Context
+		<end>
+	<end>
+
+	<range .Service.Functions>
+		<.Name>(
+			ctx <$context>.Context,<range .Arguments>
+			<.Name> <formatType .Type>,<end>
+		) <if .ReturnType>(<formatType .ReturnType>, error)<else>error<end>
+	<end>
+}
+
+<$adapter := printf "_%s_withoutContext" .Service.Name>
+
+// <$adapter> calls an implementation of <.Service.Name> without the context.
+type <$adapter> struct{ impl <.Service.Name> }
+
+<range allFunctions .Request .Service>
+func (s <$adapter>) <.Name>(
+	ctx <$context>.Context,<range .Arguments>
+	_<.Name> <formatType .Type>,<end>
+) <if .ReturnType>(<formatType .ReturnType>, error)<else>error<end> {
+	return s.impl.<.Name>(<range .Arguments>_<.Name>, <end>)
+}
+<end>
 `
 
 const clientTemplate = `
@@ -169,10 +243,10 @@ func New<.Service.Name>Client(c <$envelope>.Client) <.Service.Name> {
 		<if .Service.ParentID>
 			<$parent := getService .Request .Service.ParentID>
 			<if eq $parent.ModuleID .Service.ModuleID>
-				<$parent.Name>: New<$parent.Name>Client(t),
+				<$parent.Name>: New<$parent.Name>Client(c),
 			<else>
 				<$parentModule := index .Request.Modules $parent.ModuleID>
-				<$parent.Name>: <import $parentModule>.New<$parent.Name>Client(t),
+				<$parent.Name>: <import $parentModule.ImportPath>.New<$parent.Name>Client(c),
 			<end>
 		<end>
 	}
@@ -208,6 +282,73 @@ func (c *<$client>) <.Name>(<range .Arguments>
 	return
 }
 <end>
+
+<$context := import "context">
+<$contextClient := printf "_%s_contextClient" .Service.Name>
+
+// <$contextClient> implements a <.Service.Name>Context client.
+type <$contextClient> struct {
+	<if .Service.ParentID>
+		<$parent := getService .Request .Service.ParentID>
+		<if eq $parent.ModuleID .Service.ModuleID>
+			<$parent.Name>Context
+		<else>
+			<$parentModule := index .Request.Modules $parent.ModuleID>
+			<import $parentModule.ImportPath>.<$parent.Name>Context
+		<end>
+	<end>
+
+	client <$envelope>.Client
+}
+
+// New<.Service.Name>ContextClient builds a new <.Service.Name> client which
+// stops waiting for a response and returns the context's error once the
+// context passed to a function is done.
+func New<.Service.Name>ContextClient(c <$envelope>.Client) <.Service.Name>Context {
+	return &<$contextClient>{
+		client: c,
+		<if .Service.ParentID>
+			<$parent := getService .Request .Service.ParentID>
+			<if eq $parent.ModuleID .Service.ModuleID>
+				<$parent.Name>Context: New<$parent.Name>ContextClient(c),
+			<else>
+				<$parentModule := index .Request.Modules $parent.ModuleID>
+				<$parent.Name>Context: <import $parentModule.ImportPath>.New<$parent.Name>ContextClient(c),
+			<end>
+		<end>
+	}
+}
+
+<range .Service.Functions>
+<$wire := import "go.uber.org/thriftrw/wire">
+<$prefix := printf "%s_%s_" $serviceName .Name>
+
+func (c *<$contextClient>) <.Name>(
+	ctx <$context>.Context,<range .Arguments>
+	_<.Name> <formatType .Type>,<end>
+) (<if .ReturnType>success <formatType .ReturnType>,<end> err error) {
+	args := <$prefix>Helper.Args(<range .Arguments>_<.Name>, <end>)
+
+	var body <$wire>.Value
+	body, err = args.ToWire()
+	if err != nil {
+		return
+	}
+
+	body, err = <$envelope>.SendContext(ctx, c.client, "<.ThriftName>", body)
+	if err != nil {
+		return
+	}
+
+	var result <$prefix>Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	<if .ReturnType>success, <end>err = <$prefix>Helper.UnwrapResponse(&result)
+	return
+}
+<end>
 `
 
 const handlerTemplate = `
@@ -217,14 +358,19 @@ const handlerTemplate = `
 <$module := index .Request.Modules .Service.ModuleID>
 package <basename $module.ImportPath>
 
+<$context  := import "context">
 <$envelope := import "go.uber.org/thriftrw/internal/envelope">
+<$mw       := import "go.uber.org/thriftrw/envelope">
 <$wire     := import "go.uber.org/thriftrw/wire">
 
 <$Handler := printf "%sHandler" .Service.Name>
 
 // <$Handler> serves an implementation of the <.Service.Name> service.
 type <$Handler> struct {
-	impl <.Service.Name>
+	impl <.Service.Name>Context
+
+	// dispatch with the middleware applied
+	handle <$mw>.HandlerFunc
 
 	<if .Service.ParentID>
 		<$parent := getService .Request .Service.ParentID>
@@ -238,23 +384,62 @@ type <$Handler> struct {
 }
 
 // New<$Handler> builds a new <.Service.Name> handler.
-func New<$Handler>(service <.Service.Name>) <$Handler> {
-	return <$Handler>{
+//
+// The given middleware is invoked around every function of the service, in
+// order.
+func New<$Handler>(service <.Service.Name>, middleware ...<$mw>.Middleware) <$Handler> {
+	return New<.Service.Name>ContextHandler(_<.Service.Name>_withoutContext{service}, middleware...)
+}
+
+// New<.Service.Name>ContextHandler builds a new <.Service.Name> handler which
+// passes the context of every request to the given implementation.
+//
+// Requests are rejected with the context's error if it is done before the
+// implementation is called.
+func New<.Service.Name>ContextHandler(service <.Service.Name>Context, middleware ...<$mw>.Middleware) <$Handler> {
+	h := <$Handler>{
 		impl: service,
 		<if .Service.ParentID>
 			<$parent := getService .Request .Service.ParentID>
 			<if eq $parent.ModuleID .Service.ModuleID>
-				parent: New<$parent.Name>Handler(service),
+				parent: New<$parent.Name>ContextHandler(service, middleware...),
 			<else>
 				<$parentModule := index .Request.Modules $parent.ModuleID>
-				parent: <import $parentModule.ImportPath>.New<$parent.Name>Handler(service),
+				parent: <import $parentModule.ImportPath>.New<$parent.Name>ContextHandler(service, middleware...),
 			<end>
 		<end>
 	}
+	h.handle = <$mw>.ApplyMiddleware(h.dispatch, middleware...)
+	return h
 }
 
 // Handle receives and handles a request for the <.Service.Name> service.
 func (h <$Handler>) Handle(name string, reqValue <$wire>.Value) (<$wire>.Value, error) {
+	return h.HandleContext(<$context>.Background(), name, reqValue)
+}
+
+// HandleContext receives and handles a request for the <.Service.Name>
+// service, passing the given context to the middleware.
+//
+// Requests multiplexed for the <.Service.Name> service ("<.Service.Name>:method")
+// are accepted as well.
+func (h <$Handler>) HandleContext(ctx <$context>.Context, name string, reqValue <$wire>.Value) (<$wire>.Value, error) {
+	name = <$mw>.TrimServiceName("<.Service.Name>", name)
+	switch name {
+		<range .Service.Functions>
+			case "<.ThriftName>":
+				return h.handle(ctx, name, reqValue)
+		<end>
+		default:
+			<if .Service.ParentID>
+				return h.parent.HandleContext(ctx, name, reqValue)
+			<else>
+				return <$wire>.Value{}, <$envelope>.ErrUnknownMethod(name)
+			<end>
+	}
+}
+
+func (h <$Handler>) dispatch(ctx <$context>.Context, name string, reqValue <$wire>.Value) (<$wire>.Value, error) {
 	switch name {
 		<$serviceName := .Service.Name>
 		<range .Service.Functions>
@@ -265,8 +450,12 @@ func (h <$Handler>) Handle(name string, reqValue <$wire>.Value) (<$wire>.Value,
 					return <$wire>.Value{}, err
 				}
 
+				if err := ctx.Err(); err != nil {
+					return <$wire>.Value{}, err
+				}
+
 				result, err := <$prefix>Helper.WrapResponse(
-					h.impl.<.Name>(<range .Arguments>args.<.Name>, <end>),
+					h.impl.<.Name>(ctx, <range .Arguments>args.<.Name>, <end>),
 				)
 				if err != nil {
 					return <$wire>.Value{}, err
@@ -275,11 +464,66 @@ func (h <$Handler>) Handle(name string, reqValue <$wire>.Value) (<$wire>.Value,
 				return result.ToWire()
 		<end>
 		default:
-			<if .Service.ParentID>
-				return h.parent.Handle(name, reqValue)
-			<else>
-				return <$wire>.Value{}, <$envelope>.ErrUnknownMethod(name)
-			<end>
+			return <$wire>.Value{}, <$envelope>.ErrUnknownMethod(name)
 	}
 }
 `
+
+const mockTemplate = `
+// Code generated by thriftrw --generate-plugin-api
+// @generated
+
+<$module := index .Request.Modules .Service.ModuleID>
+package <mockPackageName $module>
+
+<$gomock := import "github.com/golang/mock/gomock">
+<$service := printf "%s.%s" (import $module.ImportPath) .Service.Name>
+
+<$mock := printf "Mock%s" .Service.Name>
+<$recorder := printf "%sMockRecorder" $mock>
+
+// <$mock> is a mock of the <.Service.Name> interface.
+type <$mock> struct {
+	ctrl     *<$gomock>.Controller
+	recorder *<$recorder>
+}
+
+var _ <$service> = (*<$mock>)(nil)
+
+// <$recorder> records expected calls to a <$mock>.
+type <$recorder> struct {
+	mock *<$mock>
+}
+
+// New<$mock> builds a new mock of the <.Service.Name> interface.
+func New<$mock>(ctrl *<$gomock>.Controller) *<$mock> {
+	mock := &<$mock>{ctrl: ctrl}
+	mock.recorder = &<$recorder>{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *<$mock>) EXPECT() *<$recorder> {
+	return m.recorder
+}
+
+<range allFunctions .Request .Service>
+// <.Name> mocks the <.Name> method.
+func (m *<$mock>) <.Name>(<range .Arguments>
+	_<.Name> <formatType .Type>,<end>
+) <if .ReturnType>(<formatType .ReturnType>, error)<else>error<end> {
+	ret := m.ctrl.Call(m, "<.Name>", <range .Arguments>_<.Name>, <end>)
+	<if .ReturnType>ret0, _ := ret[0].(<formatType .ReturnType>)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1<else>ret0, _ := ret[0].(error)
+	return ret0<end>
+}
+
+// <.Name> indicates an expected call of <.Name>.
+func (mr *<$recorder>) <.Name>(<range .Arguments>
+	_<.Name> interface{},<end>
+) *<$gomock>.Call {
+	return mr.mock.ctrl.RecordCall(mr.mock, "<.Name>", <range .Arguments>_<.Name>, <end>)
+}
+<end>
+`
@@ -0,0 +1,157 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package pluginapigen
+
+import (
+	"testing"
+
+	"go.uber.org/thriftrw/plugin/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockPackage(t *testing.T) {
+	importPath, dir := mockPackage(&api.Module{
+		ImportPath: "go.uber.org/thriftrw/plugin/api",
+		Directory:  "api",
+	})
+	assert.Equal(t, "go.uber.org/thriftrw/plugin/plugintest", importPath)
+	assert.Equal(t, "plugintest", dir)
+
+	importPath, dir = mockPackage(&api.Module{
+		ImportPath: "example.com/foo/bar/kv",
+		Directory:  "bar/kv",
+	})
+	assert.Equal(t, "example.com/foo/bar/bartest", importPath)
+	assert.Equal(t, "bar/bartest", dir)
+}
+
+func TestAllFunctions(t *testing.T) {
+	parentID := api.ServiceID(1)
+	req := &api.GenerateServiceRequest{
+		Services: map[api.ServiceID]*api.Service{
+			1: {Name: "Base", Functions: []*api.Function{{Name: "Health"}}},
+			2: {
+				Name:      "KeyValue",
+				ParentID:  &parentID,
+				Functions: []*api.Function{{Name: "Get"}, {Name: "Set"}},
+			},
+		},
+	}
+
+	var names []string
+	for _, f := range allFunctions(req, req.Services[2]) {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"Health", "Get", "Set"}, names)
+}
+
+func TestGenerateInheritedServices(t *testing.T) {
+	baseID, readerID := api.ServiceID(1), api.ServiceID(2)
+	req := &api.GenerateServiceRequest{
+		RootServices: []api.ServiceID{1, 2, 3},
+		Modules: map[api.ModuleID]*api.Module{
+			1: {ImportPath: "example.com/idl/base", Directory: "base"},
+			2: {ImportPath: "example.com/idl/store", Directory: "store"},
+		},
+		Services: map[api.ServiceID]*api.Service{
+			1: {
+				Name:       "Base",
+				ThriftName: "Base",
+				ModuleID:   1,
+				Functions:  []*api.Function{{Name: "Health", ThriftName: "health"}},
+			},
+			2: {
+				Name:       "Reader",
+				ThriftName: "Reader",
+				ModuleID:   2,
+				ParentID:   &baseID,
+				Functions:  []*api.Function{{Name: "Get", ThriftName: "get"}},
+			},
+			3: {
+				Name:       "Store",
+				ThriftName: "Store",
+				ModuleID:   2,
+				ParentID:   &readerID,
+				Functions:  []*api.Function{{Name: "Put", ThriftName: "put"}},
+			},
+		},
+	}
+
+	res, err := sgen{}.Generate(req)
+	require.NoError(t, err)
+
+	tests := []struct {
+		file string
+		want []string
+	}{
+		{
+			file: "store/reader_client.go",
+			want: []string{
+				"base.Base\n",
+				"Base: base.NewBaseClient(c),",
+			},
+		},
+		{
+			file: "store/store_client.go",
+			want: []string{
+				"Reader\n",
+				"Reader: NewReaderClient(c),",
+				"ReaderContext: NewReaderContextClient(c),",
+			},
+		},
+		{
+			file: "store/reader_handler.go",
+			want: []string{
+				"parent base.BaseHandler",
+				"parent: base.NewBaseContextHandler(service, middleware...),",
+				"return h.parent.HandleContext(ctx, name, reqValue)",
+			},
+		},
+		{
+			file: "store/store.go",
+			want: []string{
+				"type Store interface {\n\tReader\n",
+				"type StoreContext interface {\n\tReaderContext\n",
+				"func (s _Store_withoutContext) Health(\n\tctx context.Context,\n) error {",
+			},
+		},
+		{
+			file: "idltest/store.go",
+			want: []string{
+				"func (m *MockStore) Health() error {",
+				"func (m *MockStore) Get() error {",
+				"func (m *MockStore) Put() error {",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		contents, ok := res.Files[tt.file]
+		if !assert.True(t, ok, "file %q was not generated", tt.file) {
+			continue
+		}
+		for _, want := range tt.want {
+			assert.Contains(t, string(contents), want, tt.file)
+		}
+	}
+}
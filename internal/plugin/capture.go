@@ -0,0 +1,86 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package plugin
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"go.uber.org/thriftrw/internal/envelope"
+	"go.uber.org/thriftrw/internal/frame"
+
+	"go.uber.org/multierr"
+)
+
+// captureTransport is an envelope.Transport which records the requests and
+// responses exchanged with the underlying transport.
+type captureTransport struct {
+	envelope.Transport
+
+	name string
+	w    *frame.CaptureWriter
+}
+
+// newCaptureTransport wraps the given transport so that its traffic is
+// recorded to w as the stream with the given name.
+func newCaptureTransport(name string, t envelope.Transport, w *frame.CaptureWriter) *captureTransport {
+	return &captureTransport{Transport: t, name: name, w: w}
+}
+
+func (t *captureTransport) Send(req []byte) ([]byte, error) {
+	if err := t.record(frame.Sent, req); err != nil {
+		return nil, err
+	}
+
+	res, err := t.Transport.Send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.record(frame.Received, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (t *captureTransport) record(d frame.Direction, b []byte) error {
+	err := t.w.Write(frame.CaptureRecord{
+		Time:      time.Now(),
+		Stream:    t.name,
+		Direction: d,
+		Frame:     b,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to capture traffic for plugin %q: %v", t.name, err)
+	}
+	return nil
+}
+
+// Close closes the underlying transport if it is an io.Closer. The
+// CaptureWriter is not closed because it may be shared between transports.
+func (t *captureTransport) Close() error {
+	var err error
+	if c, ok := t.Transport.(io.Closer); ok {
+		err = multierr.Append(err, c.Close())
+	}
+	return err
+}
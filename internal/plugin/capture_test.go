@@ -0,0 +1,93 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package plugin
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"go.uber.org/thriftrw/internal/frame"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type transportFunc func([]byte) ([]byte, error)
+
+func (f transportFunc) Send(b []byte) ([]byte, error) { return f(b) }
+
+func TestCaptureTransport(t *testing.T) {
+	var buff bytes.Buffer
+	w, err := frame.NewCaptureWriter(&buff)
+	require.NoError(t, err)
+
+	echo := transportFunc(func(b []byte) ([]byte, error) {
+		return append([]byte("re: "), b...), nil
+	})
+	transport := newCaptureTransport("foo", echo, w)
+
+	res, err := transport.Send([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, "re: hello", string(res))
+	assert.NoError(t, transport.Close())
+
+	r, err := frame.NewCaptureReader(&buff)
+	require.NoError(t, err)
+
+	req, err := r.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "foo", req.Stream)
+	assert.Equal(t, frame.Sent, req.Direction)
+	assert.Equal(t, "hello", string(req.Frame))
+
+	rep, err := r.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "foo", rep.Stream)
+	assert.Equal(t, frame.Received, rep.Direction)
+	assert.Equal(t, "re: hello", string(rep.Frame))
+
+	_, err = r.Read()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestCaptureTransportSendError(t *testing.T) {
+	var buff bytes.Buffer
+	w, err := frame.NewCaptureWriter(&buff)
+	require.NoError(t, err)
+
+	failing := transportFunc(func([]byte) ([]byte, error) {
+		return nil, errors.New("great sadness")
+	})
+	_, err = newCaptureTransport("foo", failing, w).Send([]byte("hello"))
+	assert.EqualError(t, err, "great sadness")
+
+	// The request is recorded even though it failed.
+	r, err := frame.NewCaptureReader(&buff)
+	require.NoError(t, err)
+	req, err := r.Read()
+	require.NoError(t, err)
+	assert.Equal(t, frame.Sent, req.Direction)
+
+	_, err = r.Read()
+	assert.Equal(t, io.EOF, err)
+}
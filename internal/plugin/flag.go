@@ -21,13 +21,19 @@
 package plugin
 
 import (
+	"crypto/tls"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"strings"
 	"sync"
 
 	"go.uber.org/thriftrw/internal/concurrent"
+	"go.uber.org/thriftrw/internal/envelope"
+	"go.uber.org/thriftrw/internal/frame"
 	"go.uber.org/thriftrw/internal/process"
+	"go.uber.org/thriftrw/internal/socket"
 
 	"github.com/anmitsu/go-shlex"
 	"go.uber.org/multierr"
@@ -46,21 +52,44 @@ const _pluginExecPrefix = "thriftrw-plugin-"
 // 	-p "foo -a --bc"
 //
 // Will pass the arguments "-a --bc" to the executable "thriftrw-plugin-foo".
+//
+// Plugins may also be reached over a socket if they are running as daemons.
+// These are specified as the plugin name followed by "@" and the address of
+// the daemon. For example,
+//
+// 	-p "foo@unix:/tmp/foo.sock"
+// 	-p "foo@tls:plugins.example.com:4242"
 type Flag struct {
 	Name    string    // Name of the plugin
 	Command *exec.Cmd // Command specification
+
+	// Address of the plugin daemon. If non-nil, Command is nil.
+	Address *socket.Address
+
+	// TLS configuration used to connect to the plugin daemon if its address
+	// uses TLS.
+	TLSConfig *tls.Config
+
+	// If non-nil, all frames exchanged with the plugin are recorded to this
+	// CaptureWriter.
+	Capture *frame.CaptureWriter
 }
 
 // Handle gets a Handle to this plugin specification.
 //
 // The returned handle MUST be closed by the caller if error was nil.
 func (f *Flag) Handle() (Handle, error) {
-	transport, err := process.NewClient(f.Command)
+	transport, err := f.transport()
 	if err != nil {
 		return nil, fmt.Errorf("failed to open plugin %q: %v", f.Name, err)
 	}
 
-	handle, err := NewTransportHandle(f.Name, transport)
+	var t envelope.Transport = transport
+	if f.Capture != nil {
+		t = newCaptureTransport(f.Name, transport, f.Capture)
+	}
+
+	handle, err := NewTransportHandle(f.Name, t)
 	if err != nil {
 		return nil, multierr.Combine(
 			fmt.Errorf("failed to open plugin %q: %v", f.Name, err),
@@ -71,6 +100,25 @@ func (f *Flag) Handle() (Handle, error) {
 	return handle, nil
 }
 
+// closingTransport is an envelope.Transport which can be closed.
+type closingTransport interface {
+	envelope.Transport
+	io.Closer
+}
+
+// transport starts the plugin or connects to its daemon.
+func (f *Flag) transport() (closingTransport, error) {
+	if f.Address == nil {
+		return process.NewClient(f.Command, process.ClientSeqID(frame.BinaryEnvelopeSeqID))
+	}
+
+	conn, err := socket.Dial(*f.Address, f.TLSConfig)
+	if err != nil {
+		return nil, err
+	}
+	return socket.NewClient(conn, socket.ClientSeqID(frame.BinaryEnvelopeSeqID)), nil
+}
+
 // UnmarshalFlag parses a string specification of a plugin.
 func (f *Flag) UnmarshalFlag(value string) error {
 	tokens, err := shlex.Split(value, true /* posix */)
@@ -82,6 +130,22 @@ func (f *Flag) UnmarshalFlag(value string) error {
 		return fmt.Errorf("invalid plugin %q: please provide a name", value)
 	}
 
+	if i := strings.Index(tokens[0], "@"); i >= 0 {
+		if len(tokens) > 1 {
+			return fmt.Errorf(
+				"invalid plugin %q: arguments cannot be passed to a plugin daemon", value)
+		}
+
+		addr, err := socket.ParseAddress(tokens[0][i+1:])
+		if err != nil {
+			return fmt.Errorf("invalid plugin %q: %v", value, err)
+		}
+
+		f.Name = tokens[0][:i]
+		f.Address = &addr
+		return nil
+	}
+
 	f.Name = tokens[0]
 	exe := _pluginExecPrefix + f.Name
 	path, err := exec.LookPath(exe)
@@ -1,12 +1,16 @@
 package plugin
 
 import (
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"go.uber.org/thriftrw/internal/socket"
+	"go.uber.org/thriftrw/plugin"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -264,3 +268,86 @@ func TestFlagsHandle(t *testing.T) {
 		}
 	}
 }
+
+func TestUnmarshalFlagDaemon(t *testing.T) {
+	tests := []struct {
+		giveValue string
+
+		wantName    string
+		wantAddress socket.Address
+
+		wantError string
+	}{
+		{
+			giveValue:   "foo@unix:/tmp/foo.sock",
+			wantName:    "foo",
+			wantAddress: socket.Address{Network: "unix", Address: "/tmp/foo.sock"},
+		},
+		{
+			giveValue:   "foo@tls:localhost:4242",
+			wantName:    "foo",
+			wantAddress: socket.Address{Network: "tcp", Address: "localhost:4242", TLS: true},
+		},
+		{
+			giveValue: "foo@udp:localhost:53",
+			wantError: `invalid plugin "foo@udp:localhost:53": invalid address "udp:localhost:53": unknown scheme "udp"`,
+		},
+		{
+			giveValue: "foo@unix:/tmp/foo.sock --bar",
+			wantError: `invalid plugin "foo@unix:/tmp/foo.sock --bar": arguments cannot be passed to a plugin daemon`,
+		},
+	}
+
+	for _, tt := range tests {
+		var f Flag
+		err := f.UnmarshalFlag(tt.giveValue)
+		if tt.wantError != "" {
+			if assert.Error(t, err, tt.giveValue) {
+				assert.Contains(t, err.Error(), tt.wantError, tt.giveValue)
+			}
+			continue
+		}
+
+		if assert.NoError(t, err, tt.giveValue) {
+			assert.Equal(t, tt.wantName, f.Name, tt.giveValue)
+			assert.Nil(t, f.Command, tt.giveValue)
+			if assert.NotNil(t, f.Address, tt.giveValue) {
+				assert.Equal(t, tt.wantAddress, *f.Address, tt.giveValue)
+			}
+		}
+	}
+}
+
+func TestFlagHandleDaemon(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-plugin-daemon-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	addr := socket.Address{Network: "unix", Address: filepath.Join(dir, "foo.sock")}
+	l, err := socket.Listen(addr, nil)
+	require.NoError(t, err)
+
+	// The daemon keeps running until the test process exits.
+	go plugin.Main(&plugin.Plugin{Name: "foo", Listener: l})
+
+	// A single daemon serves multiple invocations.
+	for i := 0; i < 3; i++ {
+		f := Flag{Name: "foo", Address: &addr}
+		h, err := f.Handle()
+		require.NoError(t, err)
+		assert.Equal(t, "foo", h.Name())
+		assert.NoError(t, h.Close())
+	}
+
+	f := Flag{Name: "bar", Address: &addr}
+	_, err = f.Handle()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `failed to open plugin "bar"`)
+	}
+
+	f = Flag{Name: "foo", Address: &socket.Address{Network: "unix", Address: filepath.Join(dir, "bar.sock")}}
+	_, err = f.Handle()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `failed to open plugin "foo"`)
+	}
+}
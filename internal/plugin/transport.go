@@ -56,6 +56,11 @@ type transportHandle struct {
 
 	Transport envelope.Transport
 	Client    api.Plugin
+
+	// Envelope client shared by the clients of all services of the plugin
+	// so that requests sent to them concurrently have distinct sequence IDs.
+	Envelope envelope.Client
+
 	Running   *atomic.Bool
 	Features  map[api.Feature]struct{}
 }
@@ -64,10 +69,8 @@ type transportHandle struct {
 //
 // If the transport is an io.Closer, it will be closed when the handle is closed.
 func NewTransportHandle(name string, t envelope.Transport) (Handle, error) {
-	client := api.NewPluginClient(multiplex.NewClient(
-		"Plugin",
-		envelope.NewClient(_proto, t),
-	))
+	envelopeClient := envelope.NewClient(_proto, t)
+	client := api.NewPluginClient(multiplex.NewClient("Plugin", envelopeClient))
 
 	handshake, err := client.Handshake(&api.HandshakeRequest{})
 	if err != nil {
@@ -121,6 +124,7 @@ func NewTransportHandle(name string, t envelope.Transport) (Handle, error) {
 		name:      name,
 		Transport: t,
 		Client:    client,
+		Envelope:  envelopeClient,
 		Running:   atomic.NewBool(true),
 		Features:  features,
 	}, nil
@@ -156,7 +160,7 @@ func (h *transportHandle) ServiceGenerator() ServiceGenerator {
 		Running: h.Running,
 		ServiceGenerator: api.NewServiceGeneratorClient(multiplex.NewClient(
 			"ServiceGenerator",
-			envelope.NewClient(_proto, h.Transport),
+			h.Envelope,
 		)),
 	}
 }
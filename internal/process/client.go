@@ -21,6 +21,7 @@
 package process
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os/exec"
@@ -31,6 +32,24 @@ import (
 	"go.uber.org/multierr"
 )
 
+// ClientOption customizes the behavior of a Client.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	seqID frame.SeqIDFunc
+}
+
+// ClientSeqID specifies that requests are sent with a frame.PipelinedClient
+// so that multiple requests may be in flight at the same time. The given
+// function extracts the sequence IDs which match responses to requests.
+//
+// By default, requests are sent one at a time.
+func ClientSeqID(f frame.SeqIDFunc) ClientOption {
+	return func(o *clientOptions) {
+		o.seqID = f
+	}
+}
+
 // Client sends framed requests and receives framed responses from an external
 // process.
 type Client struct {
@@ -38,14 +57,22 @@ type Client struct {
 	cmd     *exec.Cmd
 	stdout  io.ReadCloser
 	stdin   io.WriteCloser
-	client  *frame.Client
+	client  interface {
+		Send([]byte) ([]byte, error)
+		SendContext(context.Context, []byte) ([]byte, error)
+	}
 }
 
 // NewClient starts up the given external process and communicates with it over
 // stdin and stdout using framed requests and responses.
 //
 // The Cmd MUST NOT have Stdout or Stdin set.
-func NewClient(cmd *exec.Cmd) (*Client, error) {
+func NewClient(cmd *exec.Cmd, opts ...ClientOption) (*Client, error) {
+	var options clientOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stdout pipe to %q: %v", cmd.Path, err)
@@ -60,13 +87,17 @@ func NewClient(cmd *exec.Cmd) (*Client, error) {
 		return nil, fmt.Errorf("failed to start %q: %v", cmd.Path, err)
 	}
 
-	return &Client{
+	c := &Client{
 		stdout:  stdout,
 		stdin:   stdin,
 		running: atomic.NewBool(true),
 		client:  frame.NewClient(stdin, stdout),
 		cmd:     cmd,
-	}, nil
+	}
+	if options.seqID != nil {
+		c.client = frame.NewPipelinedClient(stdin, stdout, options.seqID)
+	}
+	return c, nil
 }
 
 // Send sends the given frame to the external process and returns the response.
@@ -80,6 +111,18 @@ func (c *Client) Send(data []byte) ([]byte, error) {
 	return c.client.Send(data)
 }
 
+// SendContext is like Send but returns the context's error if the context
+// is done before the response is received.
+//
+// Panics if Close was already called.
+func (c *Client) SendContext(ctx context.Context, data []byte) ([]byte, error) {
+	if !c.running.Load() {
+		panic(fmt.Sprintf("process.Client for %q has been closed", c.cmd.Path))
+	}
+
+	return c.client.SendContext(ctx, data)
+}
+
 // Close detaches from the external process and waits for it to exit.
 func (c *Client) Close() error {
 	if !c.running.Swap(false) {
@@ -2,7 +2,10 @@ package process
 
 import (
 	"bytes"
+	"encoding/binary"
+	"errors"
 	"os/exec"
+	"sync"
 	"testing"
 	"testing/quick"
 
@@ -22,6 +25,38 @@ func TestCat(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCatPipelined(t *testing.T) {
+	// Frames start with their sequence ID.
+	seqID := func(b []byte) (int32, error) {
+		if len(b) < 4 {
+			return 0, errors.New("frame is too short")
+		}
+		return int32(binary.BigEndian.Uint32(b)), nil
+	}
+
+	client, err := NewClient(exec.Command("cat"), ClientSeqID(seqID))
+	require.NoError(t, err)
+	defer client.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i uint32) {
+			defer wg.Done()
+
+			give := make([]byte, 8)
+			binary.BigEndian.PutUint32(give, i)
+			binary.BigEndian.PutUint32(give[4:], i*i)
+
+			got, err := client.Send(give)
+			if assert.NoError(t, err, "request %d failed", i) {
+				assert.Equal(t, give, got, "response to request %d does not match", i)
+			}
+		}(uint32(i))
+	}
+	wg.Wait()
+}
+
 func TestSendAfterStop(t *testing.T) {
 	client, err := NewClient(exec.Command("cat"))
 	require.NoError(t, err)
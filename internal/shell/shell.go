@@ -0,0 +1,276 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package shell implements an interactive shell in which values of the types
+// defined in a Thrift module may be encoded to and decoded from the Binary
+// protocol.
+//
+// 	> encode User {"name": "foo", "age": 42}
+// 	0b000100000003666f6f0800020000002a00
+// 	> decode User 0b000100000003666f6f0800020000002a00
+// 	{"name":"foo","age":42}
+//
+// Values are written as JSON. See the documentation of the "help" command
+// for how each Thrift type is represented.
+package shell
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+)
+
+// Prompt is printed before each command is read.
+const Prompt = "> "
+
+const help = `Commands:
+  types                  List the types which may be encoded and decoded.
+  encode TYPE VALUE      Encode a JSON value of TYPE and print it as hex.
+  decode TYPE DATA       Decode hex or base64 DATA as TYPE and print it as JSON.
+  help                   Print this message.
+  exit                   Leave the shell.
+
+Types of included files are prefixed with the name of the file, as in
+Thrift: shared.Timestamp.
+
+Values are written as JSON. Structs are objects keyed by the Thrift names
+of their fields. Enums are the names of their items; integers may be used
+for unknown items. Binary values are base64-encoded strings. Maps are arrays
+of {"key": ..., "value": ...} objects, or objects if their keys are strings.
+`
+
+// errExit is returned by Exec for the "exit" command.
+var errExit = errors.New("exit")
+
+// Shell encodes and decodes values of the types of a Thrift module.
+type Shell struct {
+	types map[string]compile.TypeSpec
+	names []string // sorted names of types
+	out   io.Writer
+}
+
+// New builds a Shell for the types of the given module and the modules it
+// includes. Results of commands are written to the given Writer.
+func New(m *compile.Module, out io.Writer) *Shell {
+	types := make(map[string]compile.TypeSpec)
+	for name, t := range m.Types {
+		types[name] = t
+	}
+	for _, inc := range m.Includes {
+		for name, t := range inc.Module.Types {
+			types[inc.Name+"."+name] = t
+		}
+	}
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return &Shell{types: types, names: names, out: out}
+}
+
+// Run reads commands from the given Reader until it is exhausted or the
+// "exit" command is run. prompt is printed before each command.
+//
+// Errors from individual commands are printed and do not stop the shell.
+func (s *Shell) Run(r io.Reader, prompt string) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16*1024*1024) // allow large pasted values
+	for {
+		io.WriteString(s.out, prompt)
+		if !scanner.Scan() {
+			break
+		}
+
+		err := s.Exec(scanner.Text())
+		if err == errExit {
+			return nil
+		}
+		if err != nil {
+			fmt.Fprintf(s.out, "error: %v\n", err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if prompt != "" {
+		// End the line with the last prompt.
+		io.WriteString(s.out, "\n")
+	}
+	return nil
+}
+
+// Exec runs a single command.
+func (s *Shell) Exec(line string) error {
+	cmd, rest := splitWord(line)
+	switch cmd {
+	case "":
+		return nil
+	case "exit", "quit":
+		return errExit
+	case "help":
+		_, err := io.WriteString(s.out, help)
+		return err
+	case "types":
+		for _, name := range s.names {
+			if _, err := fmt.Fprintf(s.out, "%v\n", name); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "encode":
+		spec, value, err := s.lookup(rest, "encode TYPE VALUE")
+		if err != nil {
+			return err
+		}
+		b, err := s.encode(spec, value)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(s.out, "%x\n", b)
+		return err
+	case "decode":
+		spec, data, err := s.lookup(rest, "decode TYPE DATA")
+		if err != nil {
+			return err
+		}
+		out, err := s.decode(spec, data)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(s.out, "%s\n", out)
+		return err
+	default:
+		return fmt.Errorf("unknown command %q: run \"help\" for a list of commands", cmd)
+	}
+}
+
+// lookup splits the arguments of a command into the type they refer to and
+// the rest of the line. usage is reported if either is missing.
+func (s *Shell) lookup(args, usage string) (compile.TypeSpec, string, error) {
+	name, rest := splitWord(args)
+	if name == "" || rest == "" {
+		return nil, "", fmt.Errorf("usage: %v", usage)
+	}
+
+	spec, ok := s.types[name]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown type %q: run \"types\" for a list of types", name)
+	}
+	return spec, rest, nil
+}
+
+// encode encodes the given JSON value of the given type with the Binary
+// protocol.
+func (s *Shell) encode(spec compile.TypeSpec, value string) ([]byte, error) {
+	w, err := EncodeJSON(spec, value)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := protocol.Binary.Encode(w, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decode decodes the given hex or base64 encoded bytes as a value of the
+// given type and returns its JSON representation.
+func (s *Shell) decode(spec compile.TypeSpec, data string) ([]byte, error) {
+	b, err := parseBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := protocol.Binary.Decode(bytes.NewReader(b), spec.TypeCode())
+	if err != nil {
+		return nil, fmt.Errorf("could not decode %v: %v", spec.ThriftName(), err)
+	}
+	return DecodeJSON(spec, w)
+}
+
+// EncodeJSON converts the given JSON value of the given type to its wire
+// representation. Values are written in the same form as in the shell.
+func EncodeJSON(spec compile.TypeSpec, value string) (wire.Value, error) {
+	dec := json.NewDecoder(strings.NewReader(value))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return wire.Value{}, fmt.Errorf("invalid JSON: %v", err)
+	}
+	if dec.More() {
+		return wire.Value{}, errors.New("invalid JSON: unexpected data after the value")
+	}
+	return encodeValue(spec, v)
+}
+
+// DecodeJSON returns the JSON representation of the given wire value of the
+// given type.
+func DecodeJSON(spec compile.TypeSpec, w wire.Value) ([]byte, error) {
+	// Lists, sets, and maps are decoded lazily so errors in their items
+	// are reported by decodeValue.
+	v, err := decodeValue(spec, w)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode %v: %v", spec.ThriftName(), err)
+	}
+	return json.Marshal(v)
+}
+
+// parseBytes parses hex, optionally prefixed with 0x, or base64. Whitespace
+// is ignored. Input which is valid as both is treated as hex.
+func parseBytes(s string) ([]byte, error) {
+	s = strings.Join(strings.Fields(s), "")
+	if b, err := hex.DecodeString(strings.TrimPrefix(s, "0x")); err == nil {
+		return b, nil
+	}
+	for _, enc := range []*base64.Encoding{
+		base64.StdEncoding,
+		base64.RawStdEncoding,
+		base64.URLEncoding,
+		base64.RawURLEncoding,
+	} {
+		if b, err := enc.DecodeString(s); err == nil {
+			return b, nil
+		}
+	}
+	return nil, errors.New("data must be hex or base64-encoded")
+}
+
+// splitWord splits the first whitespace-separated word off the given string.
+func splitWord(s string) (word, rest string) {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexAny(s, " \t"); i >= 0 {
+		return s[:i], strings.TrimSpace(s[i:])
+	}
+	return s, ""
+}
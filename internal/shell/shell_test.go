@@ -0,0 +1,229 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shell
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/thriftrw/compile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestShell(t *testing.T) (*Shell, *bytes.Buffer) {
+	dir, err := ioutil.TempDir("", "thriftrw-shell-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "shared.thrift"), []byte(`
+		typedef i64 Timestamp
+	`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.thrift"), []byte(`
+		include "./shared.thrift"
+
+		enum Role { ADMIN = 1, GUEST = 2 }
+
+		struct User {
+			1: required string name
+			2: optional i32 age
+			3: optional Role role
+			4: optional binary avatar
+			5: optional shared.Timestamp created
+			6: optional map<string, list<i16>> scores
+			7: optional map<i32, string> labels
+		}
+
+		union Contact {
+			1: string email
+			2: string phone
+		}
+	`), 0644))
+
+	module, err := compile.Compile(filepath.Join(dir, "main.thrift"))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	return New(module, &out), &out
+}
+
+func TestShellRoundTrip(t *testing.T) {
+	tests := []struct {
+		desc  string
+		typ   string
+		value string
+		want  string // defaults to value
+	}{
+		{
+			desc:  "required field",
+			typ:   "User",
+			value: `{"name":"foo"}`,
+		},
+		{
+			desc:  "all fields",
+			typ:   "User",
+			value: `{"name":"foo","age":42,"role":"ADMIN","avatar":"AQID","created":1500000000000,"scores":{"a":[1,2]},"labels":[{"key":1,"value":"one"}]}`,
+		},
+		{
+			desc:  "fields are printed in the order in which they were encoded",
+			typ:   "User",
+			value: `{"age":42, "name":"foo"}`,
+			want:  `{"name":"foo","age":42}`,
+		},
+		{
+			desc:  "unknown enum value",
+			typ:   "Role",
+			value: `3`,
+		},
+		{
+			desc:  "included type",
+			typ:   "shared.Timestamp",
+			value: `1500000000000`,
+		},
+		{
+			desc:  "union",
+			typ:   "Contact",
+			value: `{"phone":"555-0100"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, out := newTestShell(t)
+			require.NoError(t, s.Exec("encode "+tt.typ+" "+tt.value))
+			encoded := strings.TrimSpace(out.String())
+
+			out.Reset()
+			require.NoError(t, s.Exec("decode "+tt.typ+" "+encoded))
+
+			want := tt.want
+			if want == "" {
+				want = tt.value
+			}
+			assert.Equal(t, want+"\n", out.String())
+		})
+	}
+}
+
+func TestShellEncode(t *testing.T) {
+	s, out := newTestShell(t)
+	require.NoError(t, s.Exec(`encode User {"name": "foo", "age": 42}`))
+	assert.Equal(t, "0b000100000003666f6f0800020000002a00\n", out.String())
+}
+
+func TestShellDecode(t *testing.T) {
+	tests := []struct {
+		desc string
+		data string
+		want string
+	}{
+		{
+			desc: "hex",
+			data: "0b000100000003666f6f0800020000002a00",
+			want: `{"name":"foo","age":42}`,
+		},
+		{
+			desc: "hex with prefix and spaces",
+			data: "0x0b0001 00000003 666f6f 00",
+			want: `{"name":"foo"}`,
+		},
+		{
+			desc: "base64",
+			data: "CwABAAAAA2ZvbwgAAgAAACoA",
+			want: `{"name":"foo","age":42}`,
+		},
+		{
+			desc: "unknown field",
+			data: "0b000100000003666f6f02006301" + "00",
+			want: `{"name":"foo","#99":"TBool(true)"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, out := newTestShell(t)
+			require.NoError(t, s.Exec("decode User "+tt.data))
+			assert.Equal(t, tt.want+"\n", out.String())
+		})
+	}
+}
+
+func TestShellErrors(t *testing.T) {
+	tests := []struct {
+		line    string
+		wantErr string
+	}{
+		{"frobnicate", `unknown command "frobnicate"`},
+		{"encode User", "usage: encode TYPE VALUE"},
+		{"decode", "usage: decode TYPE DATA"},
+		{`encode Foo {}`, `unknown type "Foo"`},
+		{`encode User {"name": "foo"`, "invalid JSON"},
+		{`encode User {"name": "foo"} 1`, "unexpected data after the value"},
+		{`encode User {}`, `missing required field "name" of User`},
+		{`encode User {"name": "foo", "nickname": "bar"}`, `User does not have a field named "nickname"`},
+		{`encode User {"name": 42}`, `field "name": cannot use 42 as string`},
+		{`encode User {"name": "foo", "age": 1.5}`, `field "age": invalid i32 1.5`},
+		{`encode User {"name": "foo", "role": "OWNER"}`, `unknown item "OWNER" of enum Role`},
+		{`encode User {"name": "foo", "avatar": "!"}`, "must be base64-encoded"},
+		{`encode User {"name": "foo", "labels": {"1": "one"}}`, "must be given as an array"},
+		{`encode Contact {"email": "a", "phone": "b"}`, "exactly one field of union Contact must be set, got 2"},
+		{"decode User !!", "data must be hex or base64-encoded"},
+		{"decode User 0b0001", "could not decode User"},
+		{"decode User 0b000100000003666f6f0b000200000000" + "00", `field "age": expected i32, got TBinary`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			s, _ := newTestShell(t)
+			err := s.Exec(tt.line)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestShellRun(t *testing.T) {
+	s, out := newTestShell(t)
+	require.NoError(t, s.Run(strings.NewReader(
+		"types\n"+
+			"\n"+
+			"encode Role \"GUEST\"\n"+
+			"encode Foo 1\n"+
+			"exit\n"+
+			"types\n",
+	), ""))
+	assert.Equal(t,
+		"Contact\nRole\nUser\nshared.Timestamp\n"+
+			"00000002\n"+
+			"error: unknown type \"Foo\": run \"types\" for a list of types\n",
+		out.String())
+
+	out.Reset()
+	require.NoError(t, s.Run(strings.NewReader("help\n"), Prompt))
+	assert.True(t, strings.HasPrefix(out.String(), Prompt+"Commands:"), "output: %q", out.String())
+	assert.True(t, strings.HasSuffix(out.String(), Prompt+"\n"), "output: %q", out.String())
+}
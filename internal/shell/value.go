@@ -0,0 +1,420 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shell
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/uuid"
+	"go.uber.org/thriftrw/wire"
+)
+
+// Values are represented with the types produced by decoding JSON with
+// json.Decoder.UseNumber:
+//
+// 	bool              bool
+// 	byte, i16,        json.Number
+// 	i32, i64, double
+// 	string            string
+// 	binary            base64-encoded string
+// 	uuid              string in the canonical form
+// 	enum              name of an item as a string, or its value as a number
+// 	list, set         array of values
+// 	map               array of {"key": ..., "value": ...} objects, or an
+// 	                  object if the keys are strings
+// 	struct            object keyed by the Thrift names of its fields
+
+// encodeValue converts the given value to a wire.Value of the given type.
+func encodeValue(spec compile.TypeSpec, v interface{}) (wire.Value, error) {
+	switch t := compile.RootTypeSpec(spec).(type) {
+	case *compile.BoolSpec:
+		b, ok := v.(bool)
+		if !ok {
+			return wire.Value{}, typeError(spec, v)
+		}
+		return wire.NewValueBool(b), nil
+	case *compile.I8Spec:
+		i, err := parseInt(spec, v, 8)
+		return wire.NewValueI8(int8(i)), err
+	case *compile.I16Spec:
+		i, err := parseInt(spec, v, 16)
+		return wire.NewValueI16(int16(i)), err
+	case *compile.I32Spec:
+		i, err := parseInt(spec, v, 32)
+		return wire.NewValueI32(int32(i)), err
+	case *compile.I64Spec:
+		i, err := parseInt(spec, v, 64)
+		return wire.NewValueI64(i), err
+	case *compile.DoubleSpec:
+		n, ok := v.(json.Number)
+		if !ok {
+			return wire.Value{}, typeError(spec, v)
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return wire.Value{}, fmt.Errorf("invalid %v %v: %v", spec.ThriftName(), n, err)
+		}
+		return wire.NewValueDouble(f), nil
+	case *compile.StringSpec:
+		s, ok := v.(string)
+		if !ok {
+			return wire.Value{}, typeError(spec, v)
+		}
+		return wire.NewValueString(s), nil
+	case *compile.BinarySpec:
+		s, ok := v.(string)
+		if !ok {
+			return wire.Value{}, typeError(spec, v)
+		}
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return wire.Value{}, fmt.Errorf("invalid %v %q: must be base64-encoded: %v", spec.ThriftName(), s, err)
+		}
+		return wire.NewValueBinary(b), nil
+	case *compile.UUIDSpec:
+		s, ok := v.(string)
+		if !ok {
+			return wire.Value{}, typeError(spec, v)
+		}
+		u, err := uuid.Parse(s)
+		if err != nil {
+			return wire.Value{}, fmt.Errorf("invalid %v %q: %v", spec.ThriftName(), s, err)
+		}
+		return u.ToWire()
+	case *compile.EnumSpec:
+		return encodeEnum(t, v)
+	case *compile.ListSpec:
+		items, err := encodeItems(t.ValueSpec, v)
+		if err != nil {
+			return wire.Value{}, err
+		}
+		return wire.NewValueList(wire.ValueListFromSlice(t.ValueSpec.TypeCode(), items)), nil
+	case *compile.SetSpec:
+		items, err := encodeItems(t.ValueSpec, v)
+		if err != nil {
+			return wire.Value{}, err
+		}
+		return wire.NewValueSet(wire.ValueListFromSlice(t.ValueSpec.TypeCode(), items)), nil
+	case *compile.MapSpec:
+		return encodeMap(t, v)
+	case *compile.StructSpec:
+		return encodeStruct(t, v)
+	default:
+		return wire.Value{}, fmt.Errorf("unsupported type %v", spec.ThriftName())
+	}
+}
+
+func typeError(spec compile.TypeSpec, v interface{}) error {
+	b, _ := json.Marshal(v)
+	return fmt.Errorf("cannot use %s as %v", b, spec.ThriftName())
+}
+
+func parseInt(spec compile.TypeSpec, v interface{}, bits int) (int64, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, typeError(spec, v)
+	}
+	i, err := strconv.ParseInt(n.String(), 10, bits)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %v %v: must be an integer that fits in %d bits", spec.ThriftName(), n, bits)
+	}
+	return i, nil
+}
+
+func encodeEnum(spec *compile.EnumSpec, v interface{}) (wire.Value, error) {
+	switch v := v.(type) {
+	case string:
+		for _, item := range spec.Items {
+			if item.Name == v {
+				return wire.NewValueI32(item.Value), nil
+			}
+		}
+		return wire.Value{}, fmt.Errorf("unknown item %q of enum %v", v, spec.ThriftName())
+	case json.Number:
+		// Unknown values are allowed so that they may be sent to peers
+		// with newer versions of the enum.
+		i, err := parseInt(spec, v, 32)
+		return wire.NewValueI32(int32(i)), err
+	default:
+		return wire.Value{}, typeError(spec, v)
+	}
+}
+
+func encodeItems(spec compile.TypeSpec, v interface{}) ([]wire.Value, error) {
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil, typeError(spec, v)
+	}
+	values := make([]wire.Value, len(items))
+	for i, item := range items {
+		w, err := encodeValue(spec, item)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %v", i, err)
+		}
+		values[i] = w
+	}
+	return values, nil
+}
+
+func encodeMap(spec *compile.MapSpec, v interface{}) (wire.Value, error) {
+	var items []wire.MapItem
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if _, ok := compile.RootTypeSpec(spec.KeySpec).(*compile.StringSpec); !ok {
+			return wire.Value{}, fmt.Errorf(
+				"keys of %v must be given as an array of {\"key\": ..., \"value\": ...} objects", spec.ThriftName())
+		}
+		for _, k := range sortedKeys(v) {
+			w, err := encodeValue(spec.ValueSpec, v[k])
+			if err != nil {
+				return wire.Value{}, fmt.Errorf("key %q: %v", k, err)
+			}
+			items = append(items, wire.MapItem{Key: wire.NewValueString(k), Value: w})
+		}
+	case []interface{}:
+		for i, item := range v {
+			obj, ok := item.(map[string]interface{})
+			if !ok || len(obj) != 2 || obj["key"] == nil || obj["value"] == nil {
+				return wire.Value{}, fmt.Errorf("item %d: must be a {\"key\": ..., \"value\": ...} object", i)
+			}
+			k, err := encodeValue(spec.KeySpec, obj["key"])
+			if err != nil {
+				return wire.Value{}, fmt.Errorf("item %d: key: %v", i, err)
+			}
+			w, err := encodeValue(spec.ValueSpec, obj["value"])
+			if err != nil {
+				return wire.Value{}, fmt.Errorf("item %d: value: %v", i, err)
+			}
+			items = append(items, wire.MapItem{Key: k, Value: w})
+		}
+	default:
+		return wire.Value{}, typeError(spec, v)
+	}
+	kt, vt := spec.KeySpec.TypeCode(), spec.ValueSpec.TypeCode()
+	return wire.NewValueMap(wire.MapItemListFromSlice(kt, vt, items)), nil
+}
+
+func encodeStruct(spec *compile.StructSpec, v interface{}) (wire.Value, error) {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return wire.Value{}, typeError(spec, v)
+	}
+
+	known := make(map[string]struct{}, len(spec.Fields))
+	var fields []wire.Field
+	for _, f := range spec.Fields {
+		known[f.Name] = struct{}{}
+		fv, ok := obj[f.Name]
+		if !ok || fv == nil {
+			if f.Required && f.Default == nil {
+				return wire.Value{}, fmt.Errorf("missing required field %q of %v", f.Name, spec.Name)
+			}
+			continue
+		}
+
+		w, err := encodeValue(f.Type, fv)
+		if err != nil {
+			return wire.Value{}, fmt.Errorf("field %q: %v", f.Name, err)
+		}
+		fields = append(fields, wire.Field{ID: f.ID, Value: w})
+	}
+
+	for _, name := range sortedKeys(obj) {
+		if _, ok := known[name]; !ok {
+			return wire.Value{}, fmt.Errorf("%v does not have a field named %q", spec.Name, name)
+		}
+	}
+	if spec.Type == ast.UnionType && len(fields) != 1 {
+		return wire.Value{}, fmt.Errorf("exactly one field of union %v must be set, got %d", spec.Name, len(fields))
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields}), nil
+}
+
+// decodeValue converts the given wire.Value of the given type to its
+// representation, which may be marshaled to JSON.
+func decodeValue(spec compile.TypeSpec, w wire.Value) (interface{}, error) {
+	if w.Type() != spec.TypeCode() {
+		return nil, fmt.Errorf("expected %v, got %v", spec.ThriftName(), w.Type())
+	}
+
+	switch t := compile.RootTypeSpec(spec).(type) {
+	case *compile.BoolSpec:
+		return w.GetBool(), nil
+	case *compile.I8Spec:
+		return w.GetI8(), nil
+	case *compile.I16Spec:
+		return w.GetI16(), nil
+	case *compile.I32Spec:
+		return w.GetI32(), nil
+	case *compile.I64Spec:
+		return w.GetI64(), nil
+	case *compile.DoubleSpec:
+		return w.GetDouble(), nil
+	case *compile.StringSpec:
+		return w.GetString(), nil
+	case *compile.BinarySpec:
+		return base64.StdEncoding.EncodeToString(w.GetBinary()), nil
+	case *compile.UUIDSpec:
+		var u uuid.UUID
+		if err := u.FromWire(w); err != nil {
+			return nil, err
+		}
+		return u.String(), nil
+	case *compile.EnumSpec:
+		i := w.GetI32()
+		for _, item := range t.Items {
+			if item.Value == i {
+				return item.Name, nil
+			}
+		}
+		return i, nil
+	case *compile.ListSpec:
+		return decodeItems(t.ValueSpec, w.GetList())
+	case *compile.SetSpec:
+		return decodeItems(t.ValueSpec, w.GetSet())
+	case *compile.MapSpec:
+		return decodeMap(t, w.GetMap())
+	case *compile.StructSpec:
+		return decodeStruct(t, w.GetStruct())
+	default:
+		return nil, fmt.Errorf("unsupported type %v", spec.ThriftName())
+	}
+}
+
+func decodeItems(spec compile.TypeSpec, l wire.ValueList) (interface{}, error) {
+	items := make([]interface{}, 0, l.Size())
+	err := l.ForEach(func(w wire.Value) error {
+		v, err := decodeValue(spec, w)
+		if err != nil {
+			return fmt.Errorf("item %d: %v", len(items), err)
+		}
+		items = append(items, v)
+		return nil
+	})
+	return items, err
+}
+
+func decodeMap(spec *compile.MapSpec, m wire.MapItemList) (interface{}, error) {
+	_, stringKeys := compile.RootTypeSpec(spec.KeySpec).(*compile.StringSpec)
+
+	var (
+		obj   object
+		items = make([]interface{}, 0, m.Size())
+	)
+	err := m.ForEach(func(item wire.MapItem) error {
+		k, err := decodeValue(spec.KeySpec, item.Key)
+		if err != nil {
+			return fmt.Errorf("item %d: key: %v", len(items), err)
+		}
+		v, err := decodeValue(spec.ValueSpec, item.Value)
+		if err != nil {
+			return fmt.Errorf("item %d: value: %v", len(items), err)
+		}
+		if stringKeys {
+			obj = append(obj, member{Name: k.(string), Value: v})
+		}
+		items = append(items, object{{Name: "key", Value: k}, {Name: "value", Value: v}})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if stringKeys {
+		return obj, nil
+	}
+	return items, nil
+}
+
+func decodeStruct(spec *compile.StructSpec, s wire.Struct) (interface{}, error) {
+	obj := make(object, 0, len(s.Fields))
+	for _, fw := range s.Fields {
+		f, ok := findField(spec, fw.ID)
+		if !ok {
+			// Fields unknown to the IDL are still shown, with their
+			// wire representation, to help debug mismatched versions.
+			obj = append(obj, member{Name: fmt.Sprintf("#%d", fw.ID), Value: fw.Value.String()})
+			continue
+		}
+
+		v, err := decodeValue(f.Type, fw.Value)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %v", f.Name, err)
+		}
+		obj = append(obj, member{Name: f.Name, Value: v})
+	}
+	return obj, nil
+}
+
+func findField(spec *compile.StructSpec, id int16) (*compile.FieldSpec, bool) {
+	for _, f := range spec.Fields {
+		if f.ID == id {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// object is a JSON object which retains the order of its members.
+type object []member
+
+type member struct {
+	Name  string
+	Value interface{}
+}
+
+func (o object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(m.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
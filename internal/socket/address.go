@@ -0,0 +1,114 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package socket
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const _dialTimeout = 10 * time.Second
+
+// Address is the address of a plugin daemon.
+//
+// Addresses are specified in one of the following forms.
+//
+// 	unix:/path/to/socket
+// 	tcp:host:port
+// 	tls:host:port
+type Address struct {
+	// Network is "unix" or "tcp".
+	Network string
+
+	// Address of the socket on that network.
+	Address string
+
+	// Whether connections are secured with TLS. This is supported for TCP
+	// only.
+	TLS bool
+}
+
+// ParseAddress parses an address in one of the forms accepted by Address.
+func ParseAddress(s string) (Address, error) {
+	i := strings.Index(s, ":")
+	if i < 0 {
+		return Address{}, fmt.Errorf(
+			"invalid address %q: expected unix:PATH, tcp:HOST:PORT, or tls:HOST:PORT", s)
+	}
+
+	scheme, addr := s[:i], s[i+1:]
+	if addr == "" {
+		return Address{}, fmt.Errorf("invalid address %q: address is empty", s)
+	}
+
+	switch scheme {
+	case "unix":
+		return Address{Network: "unix", Address: addr}, nil
+	case "tcp", "tls":
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return Address{}, fmt.Errorf("invalid address %q: %v", s, err)
+		}
+		return Address{Network: "tcp", Address: addr, TLS: scheme == "tls"}, nil
+	default:
+		return Address{}, fmt.Errorf(
+			"invalid address %q: unknown scheme %q (expected unix, tcp, or tls)", s, scheme)
+	}
+}
+
+func (a Address) String() string {
+	scheme := a.Network
+	if a.TLS {
+		scheme = "tls"
+	}
+	return scheme + ":" + a.Address
+}
+
+// Dial connects to the given address. The TLS configuration is used only if
+// the address uses TLS. If it is nil, the system's root certificates are
+// used to verify the server.
+func Dial(a Address, config *tls.Config) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: _dialTimeout}
+	if a.TLS {
+		return tls.DialWithDialer(dialer, a.Network, a.Address, config)
+	}
+	return dialer.Dial(a.Network, a.Address)
+}
+
+// Listen listens on the given address. The TLS configuration must specify a
+// certificate if the address uses TLS.
+func Listen(a Address, config *tls.Config) (net.Listener, error) {
+	if a.TLS && (config == nil || len(config.Certificates) == 0) {
+		return nil, errors.New("a certificate is required to listen for TLS connections")
+	}
+
+	l, err := net.Listen(a.Network, a.Address)
+	if err != nil {
+		return nil, err
+	}
+	if a.TLS {
+		l = tls.NewListener(l, config)
+	}
+	return l, nil
+}
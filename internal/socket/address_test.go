@@ -0,0 +1,182 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package socket
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		give      string
+		want      Address
+		wantError string
+	}{
+		{give: "unix:/tmp/foo.sock", want: Address{Network: "unix", Address: "/tmp/foo.sock"}},
+		{give: "tcp:localhost:4242", want: Address{Network: "tcp", Address: "localhost:4242"}},
+		{give: "tls:example.com:443", want: Address{Network: "tcp", Address: "example.com:443", TLS: true}},
+		{give: "tcp:[::1]:4242", want: Address{Network: "tcp", Address: "[::1]:4242"}},
+		{
+			give:      "/tmp/foo.sock",
+			wantError: `invalid address "/tmp/foo.sock": expected unix:PATH, tcp:HOST:PORT, or tls:HOST:PORT`,
+		},
+		{give: "unix:", wantError: `invalid address "unix:": address is empty`},
+		{give: "tcp:localhost", wantError: `invalid address "tcp:localhost": `},
+		{
+			give:      "udp:localhost:53",
+			wantError: `invalid address "udp:localhost:53": unknown scheme "udp" (expected unix, tcp, or tls)`,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAddress(tt.give)
+		if tt.wantError != "" {
+			if assert.Error(t, err, tt.give) {
+				assert.Contains(t, err.Error(), tt.wantError, tt.give)
+			}
+			continue
+		}
+
+		if assert.NoError(t, err, tt.give) {
+			assert.Equal(t, tt.want, got, tt.give)
+			assert.Equal(t, tt.give, got.String(), "String must round trip")
+		}
+	}
+}
+
+// serveEcho serves a framed echo server on the given listener until it is
+// closed.
+func serveEcho(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			buff := make([]byte, 1024)
+			for {
+				n, err := conn.Read(buff)
+				if err != nil {
+					return
+				}
+				if _, err := conn.Write(buff[:n]); err != nil {
+					return
+				}
+			}
+		}()
+	}
+}
+
+func TestUnixSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-socket-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	addr := Address{Network: "unix", Address: filepath.Join(dir, "plugin.sock")}
+	l, err := Listen(addr, nil)
+	require.NoError(t, err)
+	defer l.Close()
+	go serveEcho(l)
+
+	conn, err := Dial(addr, nil)
+	require.NoError(t, err)
+
+	client := NewClient(conn)
+	res, err := client.Send([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(res))
+	assert.NoError(t, client.Close())
+}
+
+// selfSignedCert generates a certificate for localhost.
+func selfSignedCert(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{Organization: []string{"thriftrw"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pool
+}
+
+func TestTLSSocket(t *testing.T) {
+	cert, pool := selfSignedCert(t)
+
+	l, err := Listen(
+		Address{Network: "tcp", Address: "127.0.0.1:0", TLS: true},
+		&tls.Config{Certificates: []tls.Certificate{cert}},
+	)
+	require.NoError(t, err)
+	defer l.Close()
+	go serveEcho(l)
+
+	addr := Address{Network: "tcp", Address: l.Addr().String(), TLS: true}
+
+	_, err = Dial(addr, nil)
+	assert.Error(t, err, "untrusted certificates must be rejected")
+
+	conn, err := Dial(addr, &tls.Config{RootCAs: pool})
+	require.NoError(t, err)
+
+	client := NewClient(conn)
+	res, err := client.Send([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(res))
+	assert.NoError(t, client.Close())
+}
+
+func TestListenTLSWithoutCertificate(t *testing.T) {
+	_, err := Listen(Address{Network: "tcp", Address: "127.0.0.1:0", TLS: true}, nil)
+	assert.EqualError(t, err, "a certificate is required to listen for TLS connections")
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package socket
+
+import (
+	"context"
+	"net"
+
+	"go.uber.org/thriftrw/internal/frame"
+)
+
+// ClientOption customizes the behavior of a Client.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	seqID frame.SeqIDFunc
+}
+
+// ClientSeqID specifies that requests are sent with a frame.PipelinedClient
+// so that multiple requests may be in flight at the same time. The given
+// function extracts the sequence IDs which match responses to requests.
+//
+// By default, requests are sent one at a time.
+func ClientSeqID(f frame.SeqIDFunc) ClientOption {
+	return func(o *clientOptions) {
+		o.seqID = f
+	}
+}
+
+// Client sends framed requests and receives framed responses over a
+// connection to a plugin daemon.
+type Client struct {
+	client interface {
+		Send([]byte) ([]byte, error)
+		SendContext(context.Context, []byte) ([]byte, error)
+	}
+
+	conn net.Conn
+}
+
+// NewClient builds a Client which speaks over the given connection. The
+// connection is closed when the Client is closed.
+func NewClient(conn net.Conn, opts ...ClientOption) *Client {
+	var options clientOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	c := &Client{client: frame.NewClient(conn, conn), conn: conn}
+	if options.seqID != nil {
+		c.client = frame.NewPipelinedClient(conn, conn, options.seqID)
+	}
+	return c
+}
+
+// Send sends the given frame and returns its response.
+func (c *Client) Send(b []byte) ([]byte, error) {
+	return c.client.Send(b)
+}
+
+// SendContext is like Send but returns the context's error if the context
+// is done before the response is received.
+func (c *Client) SendContext(ctx context.Context, b []byte) ([]byte, error) {
+	return c.client.SendContext(ctx, b)
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,705 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package thriftfmt formats Thrift files.
+package thriftfmt
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/idl"
+)
+
+const _indent = "    "
+
+// noLine is used as the line of the next item when there is no such item.
+const noLine = math.MaxInt32
+
+// Format parses the given Thrift file and returns it in the canonical format.
+//
+// The formatted file has the same syntax tree as the source and retains all
+// comments. Everything else is normalized: definitions are indented with
+// four spaces, fields are printed one per line with their IDs aligned, runs
+// of blank lines are collapsed, and blocks are always separated by a blank
+// line.
+//
+// Deprecated syntax like senum and xsd_optional is printed as-is; use
+// Modernize to rewrite it.
+func Format(src []byte) ([]byte, error) {
+	return format(src, false)
+}
+
+// Modernize is like Format but it also rewrites deprecated syntax to its
+// supported equivalent.
+//
+// 	php_namespace Foo          =>  namespace php Foo
+// 	senum Foo { "a", "b" }     =>  typedef string Foo
+//
+// The values of an senum are retained in a comment above the typedef. The
+// xsd_all, xsd_optional, xsd_nillable, and xsd_attrs keywords are dropped.
+func Modernize(src []byte) ([]byte, error) {
+	return format(src, true)
+}
+
+func format(src []byte, modernize bool) ([]byte, error) {
+	prog, err := (&idl.Config{Comments: true}).Parse(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := printer{
+		lines:      strings.Split(string(src), "\n"),
+		comments:   prog.Comments,
+		modernize:  modernize,
+		blockStart: true,
+		lineStart:  true,
+	}
+	p.program(prog)
+	return p.buf.Bytes(), nil
+}
+
+// printer writes out a program, interleaving comments from the source at the
+// positions closest to where they were originally.
+//
+// Items being printed are told the line of the source on which the next item
+// at the same level starts. Comments before that line may belong to the
+// current item.
+type printer struct {
+	buf    bytes.Buffer
+	indent int
+
+	// Lines of the source.
+	lines []string
+
+	// Comments that haven't been printed yet.
+	comments []*ast.Comment
+
+	// Whether deprecated syntax should be rewritten.
+	modernize bool
+
+	// Whether we just opened a block or the file. Blank lines are not
+	// preserved at the start of blocks.
+	blockStart bool
+
+	// Whether the last line written was blank.
+	lastBlank bool
+
+	// Whether we're at the start of a line in the output.
+	lineStart bool
+}
+
+// text writes the given string on the current line, indenting it if this is
+// the start of the line.
+func (p *printer) text(s string) {
+	if p.lineStart {
+		p.buf.WriteString(strings.Repeat(_indent, p.indent))
+		p.lineStart = false
+	}
+	p.buf.WriteString(s)
+}
+
+// textf is like text but formats the string first.
+func (p *printer) textf(format string, args ...interface{}) {
+	p.text(fmt.Sprintf(format, args...))
+}
+
+// newline ends the current line, appending any comments that were on the
+// given line of the source.
+func (p *printer) newline(line int) {
+	for c := p.pending(); c != nil && line > 0 && c.Line == line; c = p.pending() {
+		p.text(" " + commentText(c))
+		p.comments = p.comments[1:]
+	}
+	p.buf.WriteByte('\n')
+	p.lineStart = true
+	p.blockStart = false
+	p.lastBlank = false
+}
+
+// blank writes a blank line unless one was just written or a block was just
+// opened.
+func (p *printer) blank() {
+	if p.lastBlank || p.blockStart {
+		return
+	}
+	p.buf.WriteByte('\n')
+	p.lastBlank = true
+}
+
+// blankBefore writes a blank line if the source has a blank line before the
+// given line.
+func (p *printer) blankBefore(line int) {
+	if line > 1 && line <= len(p.lines) && strings.TrimSpace(p.lines[line-2]) == "" {
+		p.blank()
+	}
+}
+
+// pending returns the next comment to be printed or nil.
+func (p *printer) pending() *ast.Comment {
+	if len(p.comments) == 0 {
+		return nil
+	}
+	return p.comments[0]
+}
+
+// item prepares for writing an item which starts on the given line of the
+// source by writing out the comments before it.
+func (p *printer) item(line int) {
+	for c := p.pending(); c != nil && c.Line < line; c = p.pending() {
+		p.blankBefore(c.Line)
+		p.text(commentText(c))
+		p.comments = p.comments[1:]
+		p.newline(0)
+	}
+	if line != noLine {
+		p.blankBefore(line)
+	}
+}
+
+// openBlock writes the given delimiter and starts a new indented block.
+func (p *printer) openBlock(open string, line int) {
+	p.text(open)
+	p.newline(line)
+	p.indent++
+	p.blockStart = true
+}
+
+// closeBlock writes out comments at the end of the block and the given
+// closing delimiter. It returns the line of the source on which the
+// delimiter was found if a comment follows it on the same line, or 0.
+func (p *printer) closeBlock(close string, next int) int {
+	for c := p.pending(); c != nil && p.insideBlock(c, close, next); c = p.pending() {
+		p.blankBefore(c.Line)
+		p.text(commentText(c))
+		p.comments = p.comments[1:]
+		p.newline(0)
+	}
+
+	p.indent--
+	p.text(close)
+
+	if c := p.pending(); c != nil && c.Line < next && p.startsWith(c.Line, close) {
+		return c.Line
+	}
+	return 0
+}
+
+// block writes a block which holds the given number of items.
+//
+// Empty blocks without comments inside them are written on a single line.
+func (p *printer) block(open, close string, line, next, n int, write func(next int)) int {
+	if n == 0 {
+		if c := p.pending(); c == nil || !p.insideBlock(c, close, next) {
+			p.text(open + close)
+			return line
+		}
+	}
+	p.openBlock(open, line)
+	write(next)
+	return p.closeBlock(close, next)
+}
+
+// insideBlock checks if the given comment is inside a block that ends with
+// the given delimiter, before the line that has the delimiter.
+func (p *printer) insideBlock(c *ast.Comment, close string, next int) bool {
+	if c.Line >= next || p.startsWith(c.Line, close) {
+		return false
+	}
+	l := p.nextCodeLine(c)
+	return l > 0 && p.startsWith(l, close)
+}
+
+// startsWith checks if the given line of the source starts with the given
+// string, ignoring leading whitespace.
+func (p *printer) startsWith(line int, s string) bool {
+	if line < 1 || line > len(p.lines) {
+		return false
+	}
+	return strings.HasPrefix(strings.TrimSpace(p.lines[line-1]), s)
+}
+
+// nextCodeLine returns the first line after the given comment which is not
+// blank and does not start with a comment, or 0 if there is no such line.
+func (p *printer) nextCodeLine(c *ast.Comment) int {
+	comments := p.comments
+	for len(comments) > 0 && comments[0] != c {
+		comments = comments[1:]
+	}
+
+	for len(comments) > 0 {
+		c := comments[0]
+		comments = comments[1:]
+
+		line := c.Line + strings.Count(c.Text, "\n") + 1
+		for ; line <= len(p.lines); line++ {
+			if strings.TrimSpace(p.lines[line-1]) != "" {
+				break
+			}
+		}
+		if line > len(p.lines) {
+			return 0
+		}
+		if len(comments) == 0 || comments[0].Line != line || !p.startsWithComment(line) {
+			return line
+		}
+	}
+	return 0
+}
+
+func (p *printer) startsWithComment(line int) bool {
+	for _, prefix := range []string{"#", "//", "/*"} {
+		if p.startsWith(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func commentText(c *ast.Comment) string {
+	if strings.HasPrefix(c.Text, "/*") {
+		return c.Text
+	}
+	return strings.TrimRight(c.Text, " \t\r")
+}
+
+type topLevelItem struct {
+	Line  int
+	Block bool // whether this item must be separated by blank lines
+	Write func(p *printer, next int)
+}
+
+func (p *printer) program(prog *ast.Program) {
+	var items []topLevelItem
+	for _, h := range prog.Headers {
+		items = append(items, headerItem(h))
+	}
+	headers := len(items)
+	for _, d := range prog.Definitions {
+		items = append(items, definitionItem(d))
+	}
+
+	for i, item := range items {
+		next := noLine
+		if i+1 < len(items) {
+			next = items[i+1].Line
+		}
+
+		if i > 0 && (i == headers || item.Block || items[i-1].Block) {
+			p.blank()
+		}
+		p.item(item.Line)
+		item.Write(p, next)
+	}
+
+	// Comments at the end of the file.
+	p.item(noLine)
+}
+
+func headerItem(h ast.Header) topLevelItem {
+	item := topLevelItem{Line: h.Info().Line}
+	switch h := h.(type) {
+	case *ast.Include:
+		item.Write = func(p *printer, next int) {
+			p.text("include " + strconv.Quote(h.Path))
+			if h.Name != "" {
+				p.text(" as " + h.Name)
+			}
+			p.newline(h.Line)
+		}
+	case *ast.Namespace:
+		item.Write = func(p *printer, next int) {
+			if h.Keyword != "" && !p.modernize {
+				p.textf("%v %v", h.Keyword, h.Name)
+			} else {
+				p.textf("namespace %v %v", h.Scope, h.Name)
+			}
+			p.annotations(h.Annotations)
+			p.newline(h.Line)
+		}
+	default:
+		panic(fmt.Sprintf("unknown header %T", h))
+	}
+	return item
+}
+
+func definitionItem(d ast.Definition) topLevelItem {
+	item := topLevelItem{Line: d.Info().Line}
+	switch d := d.(type) {
+	case *ast.Constant:
+		item.Write = func(p *printer, next int) {
+			p.textf("const %v %v = ", d.Type, d.Name)
+			line := p.constantValue(d.Value, d.Line, next)
+			p.newline(line)
+		}
+	case *ast.Typedef:
+		item.Write = func(p *printer, next int) {
+			p.textf("typedef %v %v", d.Type, d.Name)
+			p.annotations(d.Annotations)
+			p.newline(d.Line)
+		}
+	case *ast.Senum:
+		item.Block = true
+		item.Write = func(p *printer, next int) {
+			if p.modernize {
+				p.senumTypedef(d)
+				return
+			}
+			p.textf("senum %v ", d.Name)
+			line := p.block("{", "}", d.Line, next, len(d.Values), func(next int) {
+				for _, v := range d.Values {
+					p.text(strconv.Quote(v) + ",")
+					p.newline(noLine)
+				}
+			})
+			p.annotations(d.Annotations)
+			p.newline(line)
+		}
+	case *ast.Enum:
+		item.Block = true
+		item.Write = func(p *printer, next int) {
+			p.textf("enum %v ", d.Name)
+			line := p.block("{", "}", d.Line, next, len(d.Items), func(next int) {
+				p.enumItems(d.Items)
+			})
+			p.annotations(d.Annotations)
+			p.newline(line)
+		}
+	case *ast.Struct:
+		item.Block = true
+		item.Write = func(p *printer, next int) {
+			p.textf("%v %v ", structureKeyword(d.Type), d.Name)
+			if d.XSDAll && !p.modernize {
+				p.text("xsd_all ")
+			}
+			line := p.block("{", "}", d.Line, next, len(d.Fields), func(next int) {
+				p.fields(d.Fields, "", next)
+			})
+			p.annotations(d.Annotations)
+			p.newline(line)
+		}
+	case *ast.Service:
+		item.Block = true
+		item.Write = func(p *printer, next int) {
+			p.textf("service %v ", d.Name)
+			if d.Parent != nil {
+				p.textf("extends %v ", d.Parent.Name)
+			}
+			line := p.block("{", "}", d.Line, next, len(d.Functions), func(next int) {
+				p.functions(d.Functions, next)
+			})
+			p.annotations(d.Annotations)
+			p.newline(line)
+		}
+	default:
+		panic(fmt.Sprintf("unknown definition %T", d))
+	}
+	return item
+}
+
+// senumTypedef writes the given senum as a typedef of string, listing its
+// values in a comment.
+func (p *printer) senumTypedef(d *ast.Senum) {
+	if len(d.Values) > 0 {
+		values := make([]string, len(d.Values))
+		for i, v := range d.Values {
+			values[i] = strconv.Quote(v)
+		}
+		p.text("// senum values: " + strings.Join(values, ", "))
+		p.newline(noLine)
+	}
+	p.textf("typedef string %v", d.Name)
+	p.annotations(d.Annotations)
+	p.newline(d.Line)
+}
+
+func structureKeyword(t ast.StructureType) string {
+	switch t {
+	case ast.UnionType:
+		return "union"
+	case ast.ExceptionType:
+		return "exception"
+	default:
+		return "struct"
+	}
+}
+
+func (p *printer) annotations(anns []*ast.Annotation) {
+	if len(anns) > 0 {
+		p.text(" " + ast.FormatAnnotations(anns))
+	}
+}
+
+func (p *printer) enumItems(items []*ast.EnumItem) {
+	for _, item := range items {
+		p.item(item.Line)
+		p.text(item.Name)
+		if item.Value != nil {
+			p.textf(" = %d", *item.Value)
+		}
+		p.annotations(item.Annotations)
+		p.text(",")
+		p.newline(item.Line)
+	}
+}
+
+// fields writes the given fields one per line with their IDs aligned. sep is
+// written after each field.
+func (p *printer) fields(fields []*ast.Field, sep string, next int) {
+	width := 0
+	for _, f := range fields {
+		if w := len(strconv.Itoa(f.ID)); w > width {
+			width = w
+		}
+	}
+
+	for i, f := range fields {
+		fieldNext := next
+		if i+1 < len(fields) {
+			fieldNext = fields[i+1].Line
+		}
+
+		p.item(f.Line)
+		p.textf("%*d: ", width, f.ID)
+		line := p.field(f, fieldNext)
+		p.text(sep)
+		p.newline(line)
+	}
+}
+
+// inlineFields writes the given fields on the current line.
+func (p *printer) inlineFields(fields []*ast.Field, next int) {
+	for i, f := range fields {
+		if i > 0 {
+			p.text(", ")
+		}
+		p.textf("%d: ", f.ID)
+		p.field(f, next)
+	}
+}
+
+// field writes the given field without its ID. It returns the line of the
+// source on which the field ends.
+func (p *printer) field(f *ast.Field, next int) int {
+	switch f.Requiredness {
+	case ast.Required:
+		p.text("required ")
+	case ast.Optional:
+		p.text("optional ")
+	}
+	p.textf("%v %v", f.Type, f.Name)
+
+	line := f.Line
+	if f.Default != nil {
+		p.text(" = ")
+		line = p.constantValue(f.Default, f.Line, next)
+	}
+	if !p.modernize {
+		if f.XSDOptional {
+			p.text(" xsd_optional")
+		}
+		if f.XSDNillable {
+			p.text(" xsd_nillable")
+		}
+		if len(f.XSDAttributes) > 0 {
+			p.text(" xsd_attrs { ")
+			p.inlineFields(f.XSDAttributes, next)
+			p.text(" }")
+		}
+	}
+	p.annotations(f.Annotations)
+	return line
+}
+
+func (p *printer) functions(functions []*ast.Function, next int) {
+	for i, f := range functions {
+		fnNext := next
+		if i+1 < len(functions) {
+			fnNext = functions[i+1].Line
+		}
+
+		p.item(f.Line)
+		if f.OneWay {
+			p.text("oneway ")
+		}
+		if f.ReturnType == nil {
+			p.text("void ")
+		} else {
+			p.textf("%v ", f.ReturnType)
+		}
+		p.text(f.Name)
+
+		line := f.Line
+		if spansLines(f.Line, f.Parameters) {
+			p.openBlock("(", f.Line)
+			p.fields(f.Parameters, ",", fnNext)
+			line = p.closeBlock(")", fnNext)
+		} else {
+			p.text("(")
+			p.inlineFields(f.Parameters, fnNext)
+			p.text(")")
+		}
+
+		if len(f.Exceptions) > 0 {
+			p.text(" throws ")
+			if spansLines(f.Exceptions[0].Line, f.Exceptions) {
+				p.openBlock("(", line)
+				p.fields(f.Exceptions, ",", fnNext)
+				line = p.closeBlock(")", fnNext)
+			} else {
+				p.text("(")
+				p.inlineFields(f.Exceptions, fnNext)
+				p.text(")")
+			}
+		}
+
+		p.annotations(f.Annotations)
+		p.newline(line)
+	}
+}
+
+// spansLines checks if any of the given fields is on a line other than the
+// given line.
+func spansLines(line int, fields []*ast.Field) bool {
+	for _, f := range fields {
+		if f.Line != line {
+			return true
+		}
+	}
+	return false
+}
+
+// constantValue writes the given constant value. It returns the line of the
+// source on which the value ends if known, or the given line otherwise.
+func (p *printer) constantValue(v ast.ConstantValue, line, next int) int {
+	switch v := v.(type) {
+	case ast.ConstantBoolean:
+		p.text(strconv.FormatBool(bool(v)))
+	case ast.ConstantInteger:
+		p.text(strconv.FormatInt(int64(v), 10))
+	case ast.ConstantString:
+		p.text(strconv.Quote(string(v)))
+	case ast.ConstantDouble:
+		p.text(formatDouble(float64(v)))
+	case ast.ConstantReference:
+		p.text(v.Name)
+	case ast.ConstantList:
+		if !isMultiline(v) {
+			p.text("[")
+			for i, item := range v.Items {
+				if i > 0 {
+					p.text(", ")
+				}
+				p.constantValue(item, line, next)
+			}
+			p.text("]")
+			return line
+		}
+
+		p.openBlock("[", line)
+		for i, item := range v.Items {
+			itemNext := next
+			if i+1 < len(v.Items) {
+				if l := ast.LineNumber(v.Items[i+1]); l > 0 {
+					itemNext = l
+				}
+			}
+
+			itemLine := ast.LineNumber(item)
+			if itemLine > 0 {
+				p.item(itemLine)
+			}
+			end := p.constantValue(item, itemLine, itemNext)
+			p.text(",")
+			p.newline(end)
+		}
+		return p.closeBlock("]", next)
+	case ast.ConstantMap:
+		if !isMultiline(v) {
+			p.text("{")
+			for i, item := range v.Items {
+				if i > 0 {
+					p.text(", ")
+				}
+				p.constantValue(item.Key, line, next)
+				p.text(": ")
+				p.constantValue(item.Value, line, next)
+			}
+			p.text("}")
+			return line
+		}
+
+		p.openBlock("{", line)
+		for i, item := range v.Items {
+			itemNext := next
+			if i+1 < len(v.Items) {
+				itemNext = v.Items[i+1].Line
+			}
+
+			p.item(item.Line)
+			p.constantValue(item.Key, item.Line, itemNext)
+			p.text(": ")
+			end := p.constantValue(item.Value, item.Line, itemNext)
+			p.text(",")
+			p.newline(end)
+		}
+		return p.closeBlock("}", next)
+	default:
+		panic(fmt.Sprintf("unknown constant value %T", v))
+	}
+	return line
+}
+
+// isMultiline checks if the given constant value is a collection with items
+// on lines other than the one it starts on.
+func isMultiline(v ast.ConstantValue) bool {
+	switch v := v.(type) {
+	case ast.ConstantList:
+		for _, item := range v.Items {
+			if l := ast.LineNumber(item); l > 0 && l != v.Line {
+				return true
+			}
+		}
+	case ast.ConstantMap:
+		for _, item := range v.Items {
+			if item.Line != v.Line {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// formatDouble formats a floating point number so that it will be parsed as
+// a double and not an integer.
+func formatDouble(f float64) string {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if strings.ContainsRune(s, '.') {
+		return s
+	}
+	if i := strings.IndexByte(s, 'e'); i >= 0 {
+		return s[:i] + ".0" + s[i:]
+	}
+	return s + ".0"
+}
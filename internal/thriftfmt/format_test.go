@@ -0,0 +1,341 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thriftfmt
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/idl"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		desc string
+		give string
+		want string
+	}{
+		{
+			desc: "empty",
+			give: "",
+			want: "",
+		},
+		{
+			desc: "headers",
+			give: `namespace   java   com.example
+include "shared.thrift"
+
+include './other.thrift'   as  o
+typedef   i64  Timestamp`,
+			want: `namespace java com.example
+include "shared.thrift"
+
+include "./other.thrift" as o
+
+typedef i64 Timestamp
+`,
+		},
+		{
+			desc: "namespace annotations",
+			give: `namespace  go   foo (  go.package =  "bar" )`,
+			want: `namespace go foo (go.package = "bar")
+`,
+		},
+		{
+			desc: "struct",
+			give: `
+# A point.
+struct Point {
+  1: required double x; // x coordinate
+  10: optional double y = 1,
+  // deprecated
+  100: string z (go.tag = 'json:"z"')
+} (foo = "bar")
+struct Empty {}
+`,
+			want: `# A point.
+struct Point {
+      1: required double x // x coordinate
+     10: optional double y = 1
+    // deprecated
+    100: string z (go.tag = "json:\"z\"")
+} (foo = "bar")
+
+struct Empty {}
+`,
+		},
+		{
+			desc: "enum",
+			give: `enum Role { User, Admin = 10 (go.name = "Admin"),
+
+	Moderator; # comment
+	// at the end
+}
+`,
+			want: `enum Role {
+    User,
+    Admin = 10 (go.name = "Admin"),
+
+    Moderator, # comment
+    // at the end
+}
+`,
+		},
+		{
+			desc: "service",
+			give: `service Foo extends Bar {
+	void ping()
+	oneway void fire(1: string a, 2: i32 b)
+	string get(
+		1: string key // key
+		2: i32 version,
+	) throws (1: KeyDoesNotExist e)
+	i32 put(1: string key) throws (
+		1: KeyExists exists,
+		2: Internal internal,
+	) (ttl = "100")
+}`,
+			want: `service Foo extends Bar {
+    void ping()
+    oneway void fire(1: string a, 2: i32 b)
+    string get(
+        1: string key, // key
+        2: i32 version,
+    ) throws (1: KeyDoesNotExist e)
+    i32 put(1: string key) throws (
+        1: KeyExists exists,
+        2: Internal internal,
+    ) (ttl = "100")
+}
+`,
+		},
+		{
+			desc: "constants",
+			give: `const i32 a = 42
+const double b = 1.0e10
+const double c = 2.0
+const list<string> d = ["x", 'y']
+const map<string, i32> e = {
+	"a": 1, // one
+	"b": 2
+}
+const f g = h.i
+`,
+			want: `const i32 a = 42
+const double b = 1.0e+10
+const double c = 2.0
+const list<string> d = ["x", "y"]
+const map<string, i32> e = {
+    "a": 1, // one
+    "b": 2,
+}
+const f g = h.i
+`,
+		},
+		{
+			desc: "block comments",
+			give: `/**
+ * Foo does things.
+ */
+struct Foo {
+	1: string bar /* trailing */
+}
+
+/* end */
+`,
+			want: `/**
+ * Foo does things.
+ */
+struct Foo {
+    1: string bar /* trailing */
+}
+
+/* end */
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := Format([]byte(tt.give))
+		if assert.NoError(t, err, tt.desc) {
+			assert.Equal(t, tt.want, string(got), tt.desc)
+		}
+	}
+}
+
+func TestFormatDeprecated(t *testing.T) {
+	src := `php_namespace   Foo
+senum Status { "a" "b" } (x = "y")
+struct User   xsd_all {
+  1: optional string name xsd_optional xsd_nillable
+  2: string id xsd_attrs { 1: string lang }
+}
+`
+	want := `php_namespace Foo
+
+senum Status {
+    "a",
+    "b",
+} (x = "y")
+
+struct User xsd_all {
+    1: optional string name xsd_optional xsd_nillable
+    2: string id xsd_attrs { 1: string lang }
+}
+`
+	got, err := Format([]byte(src))
+	require.NoError(t, err)
+	assert.Equal(t, want, string(got))
+
+	wantProg, err := idl.Parse([]byte(src))
+	require.NoError(t, err)
+	gotProg, err := idl.Parse(got)
+	require.NoError(t, err)
+	clearLines(reflect.ValueOf(wantProg))
+	clearLines(reflect.ValueOf(gotProg))
+	assert.Equal(t, wantProg, gotProg, "syntax tree changed")
+}
+
+func TestModernize(t *testing.T) {
+	src := `php_namespace Foo
+cocoa_prefix   FOO
+
+// Status of a user.
+senum Status { "a", "b" } (x = "y")
+senum Empty {}
+
+struct User xsd_all {
+  1: optional string name xsd_optional xsd_nillable
+  2: string id xsd_attrs { 1: string lang }
+}
+`
+	want := `namespace php Foo
+namespace cocoa FOO
+
+// Status of a user.
+// senum values: "a", "b"
+typedef string Status (x = "y")
+
+typedef string Empty
+
+struct User {
+    1: optional string name
+    2: string id
+}
+`
+	got, err := Modernize([]byte(src))
+	require.NoError(t, err)
+	assert.Equal(t, want, string(got))
+
+	var warnings []idl.Warning
+	_, err = (&idl.Config{Warn: func(w idl.Warning) {
+		warnings = append(warnings, w)
+	}}).Parse(got)
+	require.NoError(t, err)
+	assert.Empty(t, warnings, "modernized file must not use deprecated syntax")
+}
+
+func TestFormatError(t *testing.T) {
+	_, err := Format([]byte("struct Foo {"))
+	assert.Error(t, err)
+}
+
+func TestFormatTestdata(t *testing.T) {
+	files, err := filepath.Glob("../../gen/testdata/thrift/*.thrift")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		src, err := ioutil.ReadFile(file)
+		require.NoError(t, err, file)
+
+		formatted, err := Format(src)
+		require.NoError(t, err, "failed to format %v", file)
+
+		want, err := idl.Parse(src)
+		require.NoError(t, err, file)
+		got, err := idl.Parse(formatted)
+		require.NoError(t, err, "failed to parse formatted %v:\n%s", file, formatted)
+
+		clearLines(reflect.ValueOf(want))
+		clearLines(reflect.ValueOf(got))
+		assert.Equal(t, want, got, "syntax tree of %v changed", file)
+
+		again, err := Format(formatted)
+		require.NoError(t, err, file)
+		assert.Equal(t, string(formatted), string(again), "formatting %v is not idempotent", file)
+
+		wantComments, err := (&idl.Config{Comments: true}).Parse(src)
+		require.NoError(t, err, file)
+		gotComments, err := (&idl.Config{Comments: true}).Parse(formatted)
+		require.NoError(t, err, file)
+		assert.Equal(t, commentTexts(wantComments), commentTexts(gotComments),
+			"comments of %v changed", file)
+	}
+}
+
+// clearLines zeroes out all Line fields in the given syntax tree.
+func clearLines(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			clearLines(v.Elem())
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		// Values stored in interfaces can't be modified in place.
+		e := reflect.New(v.Elem().Type()).Elem()
+		e.Set(v.Elem())
+		clearLines(e)
+		if v.CanSet() {
+			v.Set(e)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			clearLines(v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Field(i)
+			if v.Type().Field(i).Name == "Line" && f.CanSet() {
+				f.SetInt(0)
+				continue
+			}
+			clearLines(f)
+		}
+	}
+}
+
+func commentTexts(p *ast.Program) []string {
+	texts := make([]string, len(p.Comments))
+	for i, c := range p.Comments {
+		texts[i] = c.Text
+	}
+	return texts
+}
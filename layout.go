@@ -0,0 +1,168 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Layouts of the output directory supported by --out-layout.
+const (
+	// The output directory is inside $GOPATH/src and its import path is its
+	// location relative to that.
+	gopathLayout = "gopath"
+
+	// The output directory is inside a Go module and its import path is
+	// the module path followed by its location relative to the go.mod file.
+	moduleLayout = "module"
+)
+
+// resolvePackagePrefix determines the import path of the Go package
+// corresponding to the output directory, which must be an absolute path.
+//
+// An explicit --pkg-prefix or --module is used as-is. Otherwise, the prefix
+// is determined based on the --out-layout. If no layout was specified, the
+// GOPATH layout is attempted first, followed by the module layout.
+func resolvePackagePrefix(gopts genOptions) (string, error) {
+	switch gopts.OutputLayout {
+	case "", gopathLayout, moduleLayout:
+	default:
+		return "", fmt.Errorf(
+			"Unknown output layout %q: must be %q or %q", gopts.OutputLayout, gopathLayout, moduleLayout)
+	}
+
+	switch {
+	case gopts.PackagePrefix != "" && gopts.Module != "":
+		return "", errors.New("The --pkg-prefix and --module options cannot be used together")
+	case gopts.PackagePrefix != "":
+		return gopts.PackagePrefix, nil
+	case gopts.Module != "":
+		if gopts.OutputLayout == gopathLayout {
+			return "", fmt.Errorf("The --module option cannot be used with --out-layout=%v", gopathLayout)
+		}
+		return strings.TrimSuffix(gopts.Module, "/"), nil
+	}
+
+	var (
+		prefix string
+		err    error
+	)
+	switch gopts.OutputLayout {
+	case gopathLayout:
+		prefix, err = determinePackagePrefix(gopts.OutputDirectory)
+	case moduleLayout:
+		prefix, err = determineModulePrefix(gopts.OutputDirectory)
+	default:
+		prefix, err = determinePackagePrefix(gopts.OutputDirectory)
+		if err != nil {
+			var modErr error
+			prefix, modErr = determineModulePrefix(gopts.OutputDirectory)
+			if modErr != nil {
+				err = fmt.Errorf("%v, and %v", err, modErr)
+			} else {
+				err = nil
+			}
+		}
+	}
+
+	if err != nil {
+		return "", fmt.Errorf(
+			"Could not determine a package prefix automatically: %v\n"+
+				"A package prefix is required to use correct import paths in the generated code.\n"+
+				"Use the --pkg-prefix option or the --module option to provide a package prefix manually.", err)
+	}
+	return prefix, nil
+}
+
+// determineModulePrefix determines the package prefix for Go packages
+// generated in the given directory based on the go.mod file of the Go module
+// containing it.
+//
+// dir must be an absolute path. The directory need not exist yet.
+func determineModulePrefix(dir string) (string, error) {
+	for moduleDir := dir; ; {
+		goMod := filepath.Join(moduleDir, "go.mod")
+		if _, err := os.Stat(goMod); err == nil {
+			contents, err := ioutil.ReadFile(goMod)
+			if err != nil {
+				return "", err
+			}
+
+			modulePath, err := parseModulePath(contents)
+			if err != nil {
+				return "", fmt.Errorf("could not read %v: %v", goMod, err)
+			}
+
+			rel, err := filepath.Rel(moduleDir, dir)
+			if err != nil {
+				return "", err
+			}
+			return path.Join(modulePath, filepath.ToSlash(rel)), nil
+		}
+
+		parent := filepath.Dir(moduleDir)
+		if parent == moduleDir {
+			break
+		}
+		moduleDir = parent
+	}
+
+	return "", fmt.Errorf("directory %q is not inside a Go module", dir)
+}
+
+// parseModulePath returns the module path from the module directive of the
+// given go.mod file.
+func parseModulePath(goMod []byte) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(goMod))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "module" {
+			continue
+		}
+
+		modulePath := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "module"))
+		if strings.HasPrefix(modulePath, `"`) || strings.HasPrefix(modulePath, "`") {
+			unquoted, err := strconv.Unquote(modulePath)
+			if err != nil {
+				return "", fmt.Errorf("invalid module path %v: %v", modulePath, err)
+			}
+			modulePath = unquoted
+		}
+		return modulePath, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", errors.New("no module directive found")
+}
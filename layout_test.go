@@ -0,0 +1,213 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseModulePath(t *testing.T) {
+	tests := []struct {
+		desc    string
+		give    string
+		want    string
+		wantErr string
+	}{
+		{
+			desc: "simple",
+			give: "module github.com/me/svc\n\ngo 1.21\n",
+			want: "github.com/me/svc",
+		},
+		{
+			desc: "comments",
+			give: "// module example.com/wrong\nmodule example.com/right // trailing\n",
+			want: "example.com/right",
+		},
+		{
+			desc: "quoted",
+			give: "module \"example.com/quoted\"\n",
+			want: "example.com/quoted",
+		},
+		{
+			desc: "not a module directive",
+			give: "modules example.com/wrong\nmodule example.com/right\n",
+			want: "example.com/right",
+		},
+		{
+			desc:    "missing",
+			give:    "go 1.21\n",
+			wantErr: "no module directive found",
+		},
+		{
+			desc:    "bad quotes",
+			give:    "module \"example.com/foo\n",
+			wantErr: "invalid module path",
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := parseModulePath([]byte(tt.give))
+		if tt.wantErr != "" {
+			if assert.Error(t, err, tt.desc) {
+				assert.Contains(t, err.Error(), tt.wantErr, tt.desc)
+			}
+			continue
+		}
+		if assert.NoError(t, err, tt.desc) {
+			assert.Equal(t, tt.want, got, tt.desc)
+		}
+	}
+}
+
+func TestResolvePackagePrefix(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "thriftrw-layout-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	moduleDir := filepath.Join(tmpDir, "svc")
+	require.NoError(t, os.MkdirAll(moduleDir, 0755))
+	require.NoError(t, ioutil.WriteFile(
+		filepath.Join(moduleDir, "go.mod"), []byte("module github.com/me/svc\n"), 0644))
+
+	realGoPath := os.Getenv("GOPATH")
+	defer os.Setenv("GOPATH", realGoPath)
+	require.NoError(t, os.Setenv("GOPATH", filepath.Join(tmpDir, "gopath")))
+
+	tests := []struct {
+		desc    string
+		give    genOptions
+		want    string
+		wantErr string
+	}{
+		{
+			desc: "package prefix",
+			give: genOptions{
+				OutputDirectory: filepath.Join(moduleDir, "gen"),
+				PackagePrefix:   "example.com/gen",
+			},
+			want: "example.com/gen",
+		},
+		{
+			desc: "module",
+			give: genOptions{
+				OutputDirectory: filepath.Join(moduleDir, "gen"),
+				Module:          "github.com/me/svc/gen/",
+			},
+			want: "github.com/me/svc/gen",
+		},
+		{
+			desc: "module layout",
+			give: genOptions{
+				OutputDirectory: filepath.Join(moduleDir, "internal", "gen"),
+				OutputLayout:    "module",
+			},
+			want: "github.com/me/svc/internal/gen",
+		},
+		{
+			desc: "module layout at the root",
+			give: genOptions{
+				OutputDirectory: moduleDir,
+				OutputLayout:    "module",
+			},
+			want: "github.com/me/svc",
+		},
+		{
+			desc: "default layout falls back to module",
+			give: genOptions{OutputDirectory: filepath.Join(moduleDir, "gen")},
+			want: "github.com/me/svc/gen",
+		},
+		{
+			desc: "gopath layout",
+			give: genOptions{
+				OutputDirectory: filepath.Join(tmpDir, "gopath", "src", "example.com", "gen"),
+				OutputLayout:    "gopath",
+			},
+			want: "example.com/gen",
+		},
+		{
+			desc: "gopath layout outside GOPATH",
+			give: genOptions{
+				OutputDirectory: filepath.Join(moduleDir, "gen"),
+				OutputLayout:    "gopath",
+			},
+			wantErr: "is not inside $GOPATH/src",
+		},
+		{
+			desc: "not in a module",
+			give: genOptions{
+				OutputDirectory: filepath.Join(tmpDir, "gen"),
+				OutputLayout:    "module",
+			},
+			wantErr: "is not inside a Go module",
+		},
+		{
+			desc: "neither",
+			give: genOptions{OutputDirectory: filepath.Join(tmpDir, "gen")},
+			wantErr: "is not inside $GOPATH/src, and directory " +
+				`"` + filepath.Join(tmpDir, "gen") + `" is not inside a Go module`,
+		},
+		{
+			desc: "pkg-prefix and module",
+			give: genOptions{
+				OutputDirectory: filepath.Join(moduleDir, "gen"),
+				PackagePrefix:   "example.com/gen",
+				Module:          "github.com/me/svc/gen",
+			},
+			wantErr: "--pkg-prefix and --module options cannot be used together",
+		},
+		{
+			desc: "module with gopath layout",
+			give: genOptions{
+				OutputDirectory: filepath.Join(moduleDir, "gen"),
+				OutputLayout:    "gopath",
+				Module:          "github.com/me/svc/gen",
+			},
+			wantErr: "--module option cannot be used with --out-layout=gopath",
+		},
+		{
+			desc: "unknown layout",
+			give: genOptions{
+				OutputDirectory: filepath.Join(moduleDir, "gen"),
+				OutputLayout:    "bazel",
+			},
+			wantErr: `Unknown output layout "bazel"`,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := resolvePackagePrefix(tt.give)
+		if tt.wantErr != "" {
+			if assert.Error(t, err, tt.desc) {
+				assert.Contains(t, err.Error(), tt.wantErr, tt.desc)
+			}
+			continue
+		}
+		if assert.NoError(t, err, tt.desc) {
+			assert.Equal(t, tt.want, got, tt.desc)
+		}
+	}
+}
@@ -22,15 +22,21 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 
 	"go.uber.org/thriftrw/compile"
 	"go.uber.org/thriftrw/gen"
+	"go.uber.org/thriftrw/generate"
 	"go.uber.org/thriftrw/internal/plugin"
 	"go.uber.org/thriftrw/internal/plugin/builtin/pluginapigen"
 	"go.uber.org/thriftrw/version"
@@ -41,23 +47,78 @@ import (
 
 type options struct {
 	DisplayVersion bool       `long:"version" short:"v" description:"Show the ThriftRW version number"`
+	Watch          string     `long:"watch" value-name:"DIR" description:"Watch the Thrift files in DIR and regenerate code for the affected files when they change. If no FILE is given, code is generated for all Thrift files in DIR."`
 	GOpts          genOptions `group:"Generator Options"`
 }
 
 type genOptions struct {
+	ConfigFile string `long:"config" value-name:"FILE" description:"JSON file with generator options. Options provided on the command line take precedence over those in the file. Relative paths in the file are resolved against the directory containing it."`
+
 	OutputDirectory string `long:"out" short:"o" value-name:"DIR" description:"Directory to which the generated files will be written."`
-	PackagePrefix   string `long:"pkg-prefix" value-name:"PREFIX" description:"Prefix for import paths of generated module. By default, this is based on the output directory's location relative to $GOPATH."`
+	PackagePrefix   string `long:"pkg-prefix" value-name:"PREFIX" description:"Prefix for import paths of generated module. By default, this is based on the output directory's location relative to $GOPATH or to the go.mod file of the Go module containing it."`
+	OutputLayout    string `long:"out-layout" value-name:"LAYOUT" description:"How the import path of the output directory is determined if --pkg-prefix and --module are not provided: gopath, based on its location relative to $GOPATH, or module, based on the module path in the nearest go.mod file and the location of the output directory relative to it. By default, gopath is attempted first, followed by module."`
+	Module          string `long:"module" value-name:"PATH" description:"Import path of the Go package corresponding to the output directory, for example, github.com/me/svc/gen for --out ./gen in the github.com/me/svc module. Generated packages import each other using paths inside this one. Cannot be used with --pkg-prefix."`
 	ThriftRoot      string `long:"thrift-root" value-name:"DIR" description:"Directory whose descendants contain all Thrift files. The structure of the generated Go packages mirrors the paths to the Thrift files relative to this directory. By default, this is the deepest common ancestor directory of the Thrift files."`
 
+	PackageName string `long:"package-name" value-name:"NAME" description:"Name of the Go package generated for the root Thrift file. By default, packages are named after their Thrift files. Included files may specify their package names with a go.package annotation on a namespace statement."`
+
 	NoRecurse bool         `long:"no-recurse" description:"Don't generate code for included Thrift files."`
+	Flatten   bool         `long:"flatten" description:"Generate code for the Thrift file and all files it includes into a single Go package. Types and constants of included files are prefixed with the names of their files."`
 	Plugins   plugin.Flags `long:"plugin" short:"p" value-name:"PLUGIN" description:"Code generation plugin for ThriftRW. This option may be provided multiple times to apply multiple plugins."`
 
+	PluginTLSCA string `long:"plugin-tls-ca" value-name:"FILE" description:"PEM file with the certificate authorities used to verify plugin daemons reached over TLS. By default, the system's root certificates are used."`
+
+	PluginCapture string `long:"plugin-capture" value-name:"FILE" description:"Record all frames exchanged with plugins to FILE. Use \"thriftrw replaycap FILE\" to inspect the recording."`
+
 	GeneratePluginAPI bool `long:"generate-plugin-api" hidden:"true" description:"Generates code for the plugin API"`
 	NoVersionCheck    bool `long:"no-version-check" hidden:"true" description:"Does not add library version checks to generated code."`
 	NoTypes           bool `long:"no-types" description:"Do not generate code for types, implies --no-service-helpers."`
 	NoConstants       bool `long:"no-constants" description:"Do not generate code for const declarations."`
 	NoServiceHelpers  bool `long:"no-service-helpers" description:"Do not generate service helpers."`
 	NoEmbedIDL        bool `long:"no-embed-idl" description:"Do not embed IDLs into the generated code."`
+	Reflection        bool `long:"reflection" description:"Register descriptors of the generated types and services with thriftreflect so that they may be served by reflection services. Cannot be used with --no-embed-idl."`
+
+	TypeSubstitutions string `long:"type-substitutions" value-name:"FILE" description:"JSON file mapping Thrift types (module.Type) to existing Go types and the functions used to convert between them."`
+	TypeConverters    string `long:"type-converters" value-name:"FILE" description:"JSON file mapping Go types named in go.type annotations to the functions used to convert between them and their Thrift representations."`
+	HeaderFile        string `long:"header-file" value-name:"FILE" description:"File whose contents are prepended to every generated Go file. The file is a Go template with access to {{.Year}}, {{.ThriftFile}}, and {{.Version}}."`
+
+	IncludeTypes []string `long:"include-types" value-name:"GLOB" description:"Generate only types whose names (Type or module.Type) match this pattern. This option may be provided multiple times."`
+	ExcludeTypes []string `long:"exclude-types" value-name:"GLOB" description:"Do not generate types whose names (Type or module.Type) match this pattern. This option may be provided multiple times. Note that the embedded IDL still contains these types unless --no-embed-idl is used."`
+
+	OnlyTypes      bool     `long:"only-types" description:"Generate only the types of the Thrift files, along with everything they reference. Services are not generated, and constants only if a type references them."`
+	OnlyServices   bool     `long:"only-services" description:"Generate only the services of the Thrift files, along with the types and constants they reference."`
+	IncludeSymbols []string `long:"include-symbols" value-name:"GLOB,..." description:"Generate only the types, constants, and services whose names (Name or module.Name) match one of these comma-separated patterns, along with everything they reference. This option may be provided multiple times."`
+
+	Initialisms   []string `long:"initialism" value-name:"WORD" description:"Write this word in all caps when it appears in the names of generated identifiers, in addition to common initialisms like ID and HTTP. This option may be provided multiple times."`
+	PreserveNames bool     `long:"preserve-names" description:"Keep the words of Thrift names unchanged in generated identifiers rather than converting them to PascalCase. Only the first letter of each name is capitalized."`
+
+	JSONInt64AsString bool `long:"json-i64-as-string" description:"Encode i64 fields as strings in JSON so that JavaScript clients do not lose precision."`
+
+	ConstantAccessors bool `long:"const-accessors" description:"Generate constants of struct, container, and binary types as functions which return a new copy of the value on every call instead of as mutable package-level variables."`
+
+	Allocator bool `long:"allocator" description:"Generate FromWireArena methods which allocate decoded structs and optional fields from a caller-provided arena.Arena. All included Thrift files must be generated with this option."`
+
+	StrictEnums bool `long:"strict-enums" description:"Generate enums which fail to decode values they do not define instead of preserving them. Enums may override this with the go.strict annotation."`
+
+	IncludePaths []string `long:"include-path" short:"I" value-name:"DIR" description:"Directory in which included Thrift files are searched for if they are not found relative to the file including them. This option may be provided multiple times, and directories are searched in the order provided."`
+
+	StrictUnused bool `long:"strict-unused" description:"Fail if an included Thrift file is never referenced, or if a type or constant declared in an included file is never used."`
+
+	Pedantic bool `long:"pedantic" description:"Fail if a definition is likely to behave unlike what its author intended, like a required field with a default value, instead of printing a warning."`
+
+	PresenceMethods bool `long:"presence-methods" description:"Generate Has, Clear, and Set methods for every optional field, similar to the presence API of protobuf."`
+
+	HashMethods bool `long:"hash-methods" description:"Generate a Hash method for every struct which returns a stable 64-bit hash of its value. Fields annotated with go.hash = \"false\" do not contribute to the hash. Structs may not have a field named Hash."`
+
+	CompactCodegen bool `long:"compact-codegen" description:"Decode structs with a table describing their fields instead of code unrolled for every field. This shrinks the generated code for Thrift files with many structs at a small cost in decoding performance."`
+
+	KeepUnknownFields bool `long:"keep-unknown-fields" description:"Retain fields which structs do not recognize when decoding them and encode them again when the structs are serialized. Retained fields are compared by Equals."`
+
+	BuilderThreshold int `long:"builder-threshold" value-name:"N" description:"Generate builders for structs with more than N fields. Structs may opt in or out with the go.builder annotation."`
+
+	GoVersion string `long:"go-version" value-name:"VERSION" description:"Oldest version of Go, for example 1.23, that the generated code must build with. Features which require newer versions of Go, such as generic container helpers (Go 1.18) and iterators over typedefs of sets and maps (Go 1.23), are generated only if this version supports them."`
+
+	Jobs int `long:"jobs" short:"j" value-name:"N" description:"Maximum number of Thrift files to generate code for concurrently. Defaults to the number of CPUs."`
 
 	// TODO(abg): Detailed help with examples of --thrift-root, --pkg-prefix,
 	// and --plugin
@@ -66,19 +127,75 @@ type genOptions struct {
 
 func main() {
 	if err := do(); err != nil {
+		if e, ok := err.(exitError); ok {
+			log.Print(e.Message)
+			os.Exit(e.Code)
+		}
 		log.Fatalf("%+v", err)
 		os.Exit(1)
 	}
 	os.Exit(0)
 }
 
+// exitError may be returned by commands to exit with a specific status code.
+type exitError struct {
+	Code    int
+	Message string
+}
+
+func (e exitError) Error() string {
+	return e.Message
+}
+
 func do() (err error) {
 	log.SetFlags(0) // don't include timestamps, etc. in the output
 
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "fmt":
+			return doFmt(os.Args[2:])
+		case "modernize":
+			return doModernize(os.Args[2:])
+		case "compat":
+			return doCompat(os.Args[2:])
+		case "fixtures":
+			return doFixtures(os.Args[2:])
+		case "apidiff":
+			return doAPIDiff(os.Args[2:])
+		case "doc":
+			return doDoc(os.Args[2:])
+		case "graph":
+			return doGraph(os.Args[2:])
+		case "replaycap":
+			return doReplayCap(os.Args[2:])
+		case "serve-ui":
+			return doServeUI(os.Args[2:])
+		case "crosstest":
+			return doCrossTest(os.Args[2:])
+		case "shell":
+			return doShell(os.Args[2:])
+		case "call":
+			return doCall(os.Args[2:])
+		}
+	}
+
 	var opts options
 
 	parser := flags.NewParser(&opts, flags.Default)
-	parser.Usage = "[OPTIONS] FILE"
+	parser.Usage = "[OPTIONS] FILE\n\n" +
+		"  thriftrw fmt [OPTIONS] FILE...\n" +
+		"  thriftrw modernize [OPTIONS] FILE...\n" +
+		"  thriftrw compat OLD NEW\n" +
+		"  thriftrw fixtures [OPTIONS] FILE\n" +
+		"  thriftrw apidiff [OPTIONS] FILE\n" +
+		"  thriftrw doc [OPTIONS] FILE\n" +
+		"  thriftrw graph [OPTIONS] FILE\n" +
+		"  thriftrw replaycap [OPTIONS] FILE\n" +
+		"  thriftrw serve-ui [OPTIONS]\n" +
+		"  thriftrw crosstest [OPTIONS] server|client\n" +
+		"  thriftrw shell FILE\n" +
+		"  thriftrw call [OPTIONS] ADDRESS [SERVICE::METHOD [ARGS]]\n" +
+		"  thriftrw --watch DIR [OPTIONS] [FILE...]"
 
 	args, err := parser.Parse()
 	if err != nil {
@@ -90,20 +207,38 @@ func do() (err error) {
 		return nil
 	}
 
+	if opts.Watch != "" {
+		return doWatch(opts.Watch, args, opts.GOpts)
+	}
+
 	if len(args) != 1 {
 		var buffer bytes.Buffer
 		parser.WriteHelp(&buffer)
 		return errors.New(buffer.String())
 	}
 
-	inputFile := args[0]
-	if _, err := os.Stat(inputFile); err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("File %q does not exist: %v", inputFile, err)
+	return generateFile(args[0], opts.GOpts)
+}
+
+// generate generates code for the given Thrift file with the given options.
+func generateFile(inputFile string, gopts genOptions) (err error) {
+	var fileOptions *gen.Options
+	if gopts.ConfigFile != "" {
+		fileOptions, err = readConfigFile(gopts.ConfigFile)
+		if err != nil {
+			return err
+		}
+
+		if gopts.OutputDirectory == "" {
+			gopts.OutputDirectory = fileOptions.OutputDir
+		}
+		if gopts.PackagePrefix == "" && gopts.Module == "" {
+			gopts.PackagePrefix = fileOptions.PackagePrefix
+		}
+		if gopts.ThriftRoot == "" {
+			gopts.ThriftRoot = fileOptions.ThriftRoot
 		}
-		return fmt.Errorf("Could not stat file %q: %v", inputFile, err)
 	}
-	gopts := opts.GOpts
 
 	if len(gopts.OutputDirectory) == 0 {
 		gopts.OutputDirectory = "."
@@ -113,41 +248,34 @@ func do() (err error) {
 		return fmt.Errorf("Unable to resolve absolute path for %q: %v", gopts.OutputDirectory, err)
 	}
 
-	if gopts.PackagePrefix == "" {
-		gopts.PackagePrefix, err = determinePackagePrefix(gopts.OutputDirectory)
-		if err != nil {
-			return fmt.Errorf(
-				"Could not determine a package prefix automatically: %v\n"+
-					"A package prefix is required to use correct import paths in the generated code.\n"+
-					"Use the --pkg-prefix option to provide a package prefix manually.", err)
-		}
-	}
-
-	module, err := compile.Compile(inputFile)
+	gopts.PackagePrefix, err = resolvePackagePrefix(gopts)
 	if err != nil {
-		// TODO(abg): For nested compile errors, split causal chain across
-		// multiple lines.
-		return fmt.Errorf("Failed to compile %q: %+v", inputFile, err)
+		return err
 	}
 
-	if gopts.ThriftRoot == "" {
-		gopts.ThriftRoot, err = findCommonAncestor(module)
+	if gopts.PluginTLSCA != "" {
+		config, err := readTLSConfig(gopts.PluginTLSCA)
 		if err != nil {
-			return fmt.Errorf(
-				"Could not find a common parent directory for %q and the Thrift files "+
-					"imported by it.\nThis directory is required to generate a consistent "+
-					"hierarchy for generated packages.\nUse the --thrift-root option to "+
-					"provide this path.\n\t%v", inputFile, err)
+			return fmt.Errorf("Failed to read plugin TLS certificate authorities: %v", err)
 		}
-	} else {
-		gopts.ThriftRoot, err = filepath.Abs(gopts.ThriftRoot)
-		if err != nil {
-			return fmt.Errorf("Unable to resolve absolute path for %q: %v", gopts.ThriftRoot, err)
+
+		for i := range gopts.Plugins {
+			gopts.Plugins[i].TLSConfig = config
+		}
+	}
+
+	if gopts.PluginCapture != "" {
+		// Don't shadow err: the deferred Close reports into it.
+		capture, openErr := openCapture(gopts.PluginCapture)
+		if openErr != nil {
+			return fmt.Errorf("Failed to open plugin capture file %q: %v", gopts.PluginCapture, openErr)
 		}
-		if err := verifyAncestry(module, gopts.ThriftRoot); err != nil {
-			return fmt.Errorf(
-				"An included Thrift file is not contained in the %q directory tree: %v",
-				gopts.ThriftRoot, err)
+		defer func() {
+			err = multierr.Append(err, capture.Close())
+		}()
+
+		for i := range gopts.Plugins {
+			gopts.Plugins[i].Capture = capture
 		}
 	}
 
@@ -164,96 +292,145 @@ func do() (err error) {
 		err = multierr.Append(err, pluginHandle.Close())
 	}()
 
-	generatorOptions := gen.Options{
-		OutputDir:        gopts.OutputDirectory,
-		PackagePrefix:    gopts.PackagePrefix,
-		ThriftRoot:       gopts.ThriftRoot,
-		NoRecurse:        gopts.NoRecurse,
-		NoVersionCheck:   gopts.NoVersionCheck,
-		Plugin:           pluginHandle,
-		NoTypes:          gopts.NoTypes,
-		NoConstants:      gopts.NoConstants,
-		NoServiceHelpers: gopts.NoServiceHelpers || gopts.NoTypes,
-		NoEmbedIDL:       gopts.NoEmbedIDL,
-	}
-	if err := gen.Generate(module, &generatorOptions); err != nil {
-		return fmt.Errorf("Failed to generate code: %+v", err)
-	}
-	return nil
-}
-
-// verifyAncestry verifies that the Thrift file for the given module and the
-// Thrift files for all imported modules are contained within the directory
-// tree rooted at the given path.
-func verifyAncestry(m *compile.Module, root string) error {
-	return m.Walk(func(m *compile.Module) error {
-		path, err := filepath.Rel(root, m.ThriftPath)
+	var typeSubstitutions map[string]gen.TypeSubstitution
+	if gopts.TypeSubstitutions != "" {
+		typeSubstitutions, err = readTypeSubstitutions(gopts.TypeSubstitutions)
 		if err != nil {
-			return fmt.Errorf(
-				"could not resolve path for %q: %v", m.ThriftPath, err)
+			return err
 		}
+	}
 
-		if strings.HasPrefix(path, "..") {
-			return fmt.Errorf(
-				"%q is not contained in the %q directory tree",
-				m.ThriftPath, root)
+	var typeConverters map[string]gen.TypeConverter
+	if gopts.TypeConverters != "" {
+		typeConverters, err = readTypeConverters(gopts.TypeConverters)
+		if err != nil {
+			return err
 		}
+	}
 
-		return nil
-	})
-}
-
-// findCommonAncestor finds the deepest common ancestor for the given module
-// and all modules imported by it.
-func findCommonAncestor(m *compile.Module) (string, error) {
-	var result []string
-	var lastString string
-
-	err := m.Walk(func(m *compile.Module) error {
-		thriftPath := m.ThriftPath
-		if !filepath.IsAbs(thriftPath) {
-			return fmt.Errorf(
-				"ThriftPath must be absolute: %q is not absolute", thriftPath)
+	var header string
+	if gopts.HeaderFile != "" {
+		contents, err := ioutil.ReadFile(gopts.HeaderFile)
+		if err != nil {
+			return fmt.Errorf("Could not read header file %q: %v", gopts.HeaderFile, err)
 		}
+		header = string(contents)
+	}
 
-		thriftDir := filepath.Dir(thriftPath)
+	generatorOptions := gen.Options{
+		OutputDir:         gopts.OutputDirectory,
+		PackagePrefix:     gopts.PackagePrefix,
+		ThriftRoot:        gopts.ThriftRoot,
+		NoRecurse:         gopts.NoRecurse,
+		Flatten:           gopts.Flatten,
+		NoVersionCheck:    gopts.NoVersionCheck,
+		Plugin:            pluginHandle,
+		NoTypes:           gopts.NoTypes,
+		NoConstants:       gopts.NoConstants,
+		NoServiceHelpers:  gopts.NoServiceHelpers,
+		NoEmbedIDL:        gopts.NoEmbedIDL,
+		Reflection:        gopts.Reflection,
+		TypeSubstitutions: typeSubstitutions,
+		TypeConverters:    typeConverters,
+		Header:            header,
+		IncludeTypes:      gopts.IncludeTypes,
+		ExcludeTypes:      gopts.ExcludeTypes,
+		OnlyTypes:         gopts.OnlyTypes,
+		OnlyServices:      gopts.OnlyServices,
+		IncludeSymbols:    splitPatterns(gopts.IncludeSymbols),
+		Initialisms:       gopts.Initialisms,
+		PreserveNames:     gopts.PreserveNames,
+		JSONInt64AsString: gopts.JSONInt64AsString,
+		ConstantAccessors: gopts.ConstantAccessors,
+		PackageName:       gopts.PackageName,
+		Allocator:         gopts.Allocator,
+		StrictEnums:       gopts.StrictEnums,
+		PresenceMethods:   gopts.PresenceMethods,
+		HashMethods:       gopts.HashMethods,
+		CompactCodegen:    gopts.CompactCodegen,
+		KeepUnknownFields: gopts.KeepUnknownFields,
+		BuilderThreshold:  gopts.BuilderThreshold,
+		Jobs:              gopts.Jobs,
+		GoVersion:         gopts.GoVersion,
+	}
+	if fileOptions != nil {
+		generatorOptions = mergeOptions(*fileOptions, generatorOptions)
+	}
 
-		// Split("/foo/bar", "/") = ["", "foo", "bar"]
-		parts := strings.Split(thriftDir, string(filepath.Separator))
-		if result == nil {
-			result = parts
-			lastString = thriftPath
-			return nil
-		}
+	var warned bool
+	err = generate.Generate(context.Background(), generate.Config{
+		ThriftFile:   inputFile,
+		IncludePaths: gopts.IncludePaths,
+		StrictUnused: gopts.StrictUnused,
+		Pedantic:     gopts.Pedantic,
+		Warnings: func(w compile.Warning) {
+			log.Printf("warning: %v", w)
+			if !w.Semantic {
+				warned = true
+			}
+		},
+		Options: generatorOptions,
+	})
+	if warned {
+		log.Print(`Use "thriftrw modernize -w FILE" to rewrite deprecated syntax.`)
+	}
+	return err
+}
 
-		result = commonPrefix(result, parts)
-		if len(result) == 1 && result[0] == "" {
-			return fmt.Errorf(
-				"%q does not share an ancestor with %q",
-				thriftPath, lastString)
-		}
+// readConfigFile reads generator options from the given JSON file.
+//
+// Relative paths in the file are resolved against the directory containing
+// it.
+func readConfigFile(path string) (*gen.Options, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Could not open config file %q: %v", path, err)
+	}
+	defer f.Close()
 
-		lastString = thriftPath
-		return nil
+	opts, err := gen.ReadOptions(f, func(option string) {
+		log.Printf("warning: %v: unknown option %q", path, option)
 	})
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("Could not read options from %q: %v", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	if opts.OutputDir != "" && !filepath.IsAbs(opts.OutputDir) {
+		opts.OutputDir = filepath.Join(dir, opts.OutputDir)
 	}
+	if opts.ThriftRoot != "" && !filepath.IsAbs(opts.ThriftRoot) {
+		opts.ThriftRoot = filepath.Join(dir, opts.ThriftRoot)
+	}
+	return opts, nil
+}
 
-	return strings.Join(result, string(filepath.Separator)), nil
+// mergeOptions returns the given base options with all options that are set
+// in overrides replaced.
+func mergeOptions(base, overrides gen.Options) gen.Options {
+	b := reflect.ValueOf(&base).Elem()
+	o := reflect.ValueOf(overrides)
+	for i := 0; i < o.NumField(); i++ {
+		f := o.Field(i)
+		if !reflect.DeepEqual(f.Interface(), reflect.Zero(f.Type()).Interface()) {
+			b.Field(i).Set(f)
+		}
+	}
+	return base
 }
 
-// commonPrefix finds the shortest common prefix for the two lists.
-//
-// An empty slice may be returned if the two lists don't have a common prefix.
-func commonPrefix(l, r []string) []string {
-	var i int
-	for i = 0; i < len(l) && i < len(r); i++ {
-		if l[i] != r[i] {
-			break
+// splitPatterns splits comma-separated patterns provided to a repeatable
+// option into a single list.
+func splitPatterns(values []string) []string {
+	var patterns []string
+	for _, v := range values {
+		for _, p := range strings.Split(v, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				patterns = append(patterns, p)
+			}
 		}
 	}
-	return l[:i]
+	return patterns
 }
 
 // determinePackagePrefix determines the package prefix for Go packages
@@ -280,3 +457,48 @@ func determinePackagePrefix(dir string) (string, error) {
 
 	return "", fmt.Errorf("directory %q is not inside $GOPATH/src", dir)
 }
+
+// readTypeSubstitutions reads the type substitutions from the given JSON file.
+// readTLSConfig builds a TLS configuration which trusts the certificate
+// authorities in the given PEM file.
+func readTLSConfig(path string) (*tls.Config, error) {
+	pem, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %q", path)
+	}
+	return &tls.Config{RootCAs: pool}, nil
+}
+
+func readTypeSubstitutions(path string) (map[string]gen.TypeSubstitution, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Could not open type substitutions file %q: %v", path, err)
+	}
+	defer f.Close()
+
+	subs, err := gen.ReadTypeSubstitutions(f)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read type substitutions from %q: %v", path, err)
+	}
+	return subs, nil
+}
+
+// readTypeConverters reads the type converters from the given JSON file.
+func readTypeConverters(path string) (map[string]gen.TypeConverter, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Could not open type converters file %q: %v", path, err)
+	}
+	defer f.Close()
+
+	convs, err := gen.ReadTypeConverters(f)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read type converters from %q: %v", path, err)
+	}
+	return convs, nil
+}
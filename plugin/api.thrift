@@ -108,6 +108,19 @@ struct Argument {
      * Argument type.
      */
     2: required Type type
+    /**
+     * ID of the field for this argument inside the args/result struct for
+     * that function.
+     */
+    3: optional i16 fieldID (go.name = "FieldID")
+    /**
+     * Name of the argument as defined in the Thrift file.
+     */
+    4: optional string thriftName
+    /**
+     * Annotations defined on this argument in the Thrift file.
+     */
+    5: optional map<string, string> annotations
 }
 
 /**
@@ -145,6 +158,23 @@ struct Function {
      * returnType and exceptions will be null or empty.
      */
     6: optional bool oneWay
+    /**
+     * Annotations defined on this function in the Thrift file.
+     */
+    7: optional map<string, string> annotations
+    /**
+     * Go type generated for the arguments of this function.
+     *
+     *   type $Service_$Function_Args struct { ... }
+     */
+    8: optional TypeReference argsType
+    /**
+     * Go type generated for the result of this function. This is not set
+     * for oneway functions.
+     *
+     *   type $Service_$Function_Result struct { ... }
+     */
+    9: optional TypeReference resultType
 }
 
 /**
@@ -171,6 +201,10 @@ struct Service {
      * ID of the module where this service was declared.
      */
     6: required ModuleID moduleID
+    /**
+     * Annotations defined on this service in the Thrift file.
+     */
+    8: optional map<string, string> annotations
 }
 
 /**
@@ -191,6 +225,29 @@ struct Module {
      * absolute location of the directory.
      */
     2: required string directory
+    /**
+     * Namespaces declared in the Thrift file, keyed by scope. The scope is
+     * the language for which the namespace applies, or "*" for namespaces
+     * which apply to all languages.
+     *
+     *   namespace java com.example.users
+     *
+     * If a Thrift file declares multiple namespaces for the same scope, the
+     * last one is used.
+     */
+    3: optional map<string, string> namespaces
+    /**
+     * Name of the Go package defining the types for this module.
+     *
+     * This is usually the last component of the import path but it differs
+     * if the package was renamed with the go.package annotation or if the
+     * default name is a Go keyword or predeclared identifier, in which case
+     * it is escaped with a trailing underscore: the package for
+     * string.thrift is named string_.
+     *
+     * This is not set by older versions of ThriftRW.
+     */
+    4: optional string packageName
 }
 
 //////////////////////////////////////////////////////////////////////////////
@@ -23,6 +23,8 @@
 
 package api
 
+import "context"
+
 type Plugin interface {
 	Goodbye() error
 
@@ -30,3 +32,32 @@ type Plugin interface {
 		Request *HandshakeRequest,
 	) (*HandshakeResponse, error)
 }
+
+// PluginContext is the Plugin service with the context of the
+// request passed to every function.
+type PluginContext interface {
+	Goodbye(
+		ctx context.Context,
+	) error
+
+	Handshake(
+		ctx context.Context,
+		Request *HandshakeRequest,
+	) (*HandshakeResponse, error)
+}
+
+// _Plugin_withoutContext calls an implementation of Plugin without the context.
+type _Plugin_withoutContext struct{ impl Plugin }
+
+func (s _Plugin_withoutContext) Goodbye(
+	ctx context.Context,
+) error {
+	return s.impl.Goodbye()
+}
+
+func (s _Plugin_withoutContext) Handshake(
+	ctx context.Context,
+	_Request *HandshakeRequest,
+) (*HandshakeResponse, error) {
+	return s.impl.Handshake(_Request)
+}
@@ -24,6 +24,7 @@
 package api
 
 import (
+	"context"
 	"go.uber.org/thriftrw/internal/envelope"
 	"go.uber.org/thriftrw/wire"
 )
@@ -87,3 +88,68 @@ func (c *_Plugin_client) Handshake(
 	success, err = Plugin_Handshake_Helper.UnwrapResponse(&result)
 	return
 }
+
+// _Plugin_contextClient implements a PluginContext client.
+type _Plugin_contextClient struct {
+	client envelope.Client
+}
+
+// NewPluginContextClient builds a new Plugin client which
+// stops waiting for a response and returns the context's error once the
+// context passed to a function is done.
+func NewPluginContextClient(c envelope.Client) PluginContext {
+	return &_Plugin_contextClient{
+		client: c,
+	}
+}
+
+func (c *_Plugin_contextClient) Goodbye(
+	ctx context.Context,
+) (err error) {
+	args := Plugin_Goodbye_Helper.Args()
+
+	var body wire.Value
+	body, err = args.ToWire()
+	if err != nil {
+		return
+	}
+
+	body, err = envelope.SendContext(ctx, c.client, "goodbye", body)
+	if err != nil {
+		return
+	}
+
+	var result Plugin_Goodbye_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = Plugin_Goodbye_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c *_Plugin_contextClient) Handshake(
+	ctx context.Context,
+	_Request *HandshakeRequest,
+) (success *HandshakeResponse, err error) {
+	args := Plugin_Handshake_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = args.ToWire()
+	if err != nil {
+		return
+	}
+
+	body, err = envelope.SendContext(ctx, c.client, "handshake", body)
+	if err != nil {
+		return
+	}
+
+	var result Plugin_Handshake_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = Plugin_Handshake_Helper.UnwrapResponse(&result)
+	return
+}
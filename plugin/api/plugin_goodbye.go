@@ -24,11 +24,16 @@
 package api
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/wire"
 	"strings"
 )
 
+// Informs the plugin process that it will not receive any more requests
+// and it is safe for it to exit.
 type Plugin_Goodbye_Args struct{}
 
 func (v *Plugin_Goodbye_Args) ToWire() (wire.Value, error) {
@@ -69,22 +74,38 @@ func (v *Plugin_Goodbye_Args) EnvelopeType() wire.EnvelopeType {
 }
 
 var Plugin_Goodbye_Helper = struct {
-	Args           func() *Plugin_Goodbye_Args
-	IsException    func(error) bool
-	WrapResponse   func(error) (*Plugin_Goodbye_Result, error)
-	UnwrapResponse func(*Plugin_Goodbye_Result) error
+	Args            func() *Plugin_Goodbye_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*Plugin_Goodbye_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func(error) (*Plugin_Goodbye_Result, error)
+	UnwrapResponse  func(*Plugin_Goodbye_Result) error
 }{}
 
 func init() {
 	Plugin_Goodbye_Helper.Args = func() *Plugin_Goodbye_Args {
 		return &Plugin_Goodbye_Args{}
 	}
+	Plugin_Goodbye_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*Plugin_Goodbye_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args Plugin_Goodbye_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
 	Plugin_Goodbye_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		default:
 			return false
 		}
 	}
+	Plugin_Goodbye_Helper.IsSafeException = func(error) bool {
+		return false
+	}
 	Plugin_Goodbye_Helper.WrapResponse = func(err error) (*Plugin_Goodbye_Result, error) {
 		if err == nil {
 			return &Plugin_Goodbye_Result{}, nil
@@ -134,3 +155,14 @@ func (v *Plugin_Goodbye_Result) MethodName() string {
 func (v *Plugin_Goodbye_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
+
+func Plugin_Goodbye_NewResultSuccess() *Plugin_Goodbye_Result {
+	return &Plugin_Goodbye_Result{}
+}
+
+func Plugin_Goodbye_NewResultError(err error) (*Plugin_Goodbye_Result, error) {
+	if err == nil {
+		return nil, errors.New("Plugin_Goodbye_NewResultError received a nil error")
+	}
+	return nil, err
+}
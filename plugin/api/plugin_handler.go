@@ -24,24 +24,69 @@
 package api
 
 import (
+	"context"
+	envelope2 "go.uber.org/thriftrw/envelope"
 	"go.uber.org/thriftrw/internal/envelope"
 	"go.uber.org/thriftrw/wire"
 )
 
 // PluginHandler serves an implementation of the Plugin service.
 type PluginHandler struct {
-	impl Plugin
+	impl PluginContext
+
+	// dispatch with the middleware applied
+	handle envelope2.HandlerFunc
 }
 
 // NewPluginHandler builds a new Plugin handler.
-func NewPluginHandler(service Plugin) PluginHandler {
-	return PluginHandler{
+//
+// The given middleware is invoked around every function of the service, in
+// order.
+func NewPluginHandler(service Plugin, middleware ...envelope2.Middleware) PluginHandler {
+	return NewPluginContextHandler(_Plugin_withoutContext{service}, middleware...)
+}
+
+// NewPluginContextHandler builds a new Plugin handler which
+// passes the context of every request to the given implementation.
+//
+// Requests are rejected with the context's error if it is done before the
+// implementation is called.
+func NewPluginContextHandler(service PluginContext, middleware ...envelope2.Middleware) PluginHandler {
+	h := PluginHandler{
 		impl: service,
 	}
+	h.handle = envelope2.ApplyMiddleware(h.dispatch, middleware...)
+	return h
 }
 
 // Handle receives and handles a request for the Plugin service.
 func (h PluginHandler) Handle(name string, reqValue wire.Value) (wire.Value, error) {
+	return h.HandleContext(context.Background(), name, reqValue)
+}
+
+// HandleContext receives and handles a request for the Plugin
+// service, passing the given context to the middleware.
+//
+// Requests multiplexed for the Plugin service ("Plugin:method")
+// are accepted as well.
+func (h PluginHandler) HandleContext(ctx context.Context, name string, reqValue wire.Value) (wire.Value, error) {
+	name = envelope2.TrimServiceName("Plugin", name)
+	switch name {
+
+	case "goodbye":
+		return h.handle(ctx, name, reqValue)
+
+	case "handshake":
+		return h.handle(ctx, name, reqValue)
+
+	default:
+
+		return wire.Value{}, envelope.ErrUnknownMethod(name)
+
+	}
+}
+
+func (h PluginHandler) dispatch(ctx context.Context, name string, reqValue wire.Value) (wire.Value, error) {
 	switch name {
 
 	case "goodbye":
@@ -51,8 +96,12 @@ func (h PluginHandler) Handle(name string, reqValue wire.Value) (wire.Value, err
 			return wire.Value{}, err
 		}
 
+		if err := ctx.Err(); err != nil {
+			return wire.Value{}, err
+		}
+
 		result, err := Plugin_Goodbye_Helper.WrapResponse(
-			h.impl.Goodbye(),
+			h.impl.Goodbye(ctx),
 		)
 		if err != nil {
 			return wire.Value{}, err
@@ -67,8 +116,12 @@ func (h PluginHandler) Handle(name string, reqValue wire.Value) (wire.Value, err
 			return wire.Value{}, err
 		}
 
+		if err := ctx.Err(); err != nil {
+			return wire.Value{}, err
+		}
+
 		result, err := Plugin_Handshake_Helper.WrapResponse(
-			h.impl.Handshake(args.Request),
+			h.impl.Handshake(ctx, args.Request),
 		)
 		if err != nil {
 			return wire.Value{}, err
@@ -77,8 +130,6 @@ func (h PluginHandler) Handle(name string, reqValue wire.Value) (wire.Value, err
 		return result.ToWire()
 
 	default:
-
 		return wire.Value{}, envelope.ErrUnknownMethod(name)
-
 	}
 }
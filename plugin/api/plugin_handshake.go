@@ -24,12 +24,17 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/wire"
 	"strings"
 )
 
+// handshake performs a handshake with the plugin to negotiate the
+// features provided by it and the version of the plugin API it expects.
 type Plugin_Handshake_Args struct {
 	Request *HandshakeRequest `json:"request,omitempty"`
 }
@@ -66,6 +71,7 @@ func (v *Plugin_Handshake_Args) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.Request, err = _HandshakeRequest_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("Plugin_Handshake_Args", "Request", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -94,6 +100,13 @@ func (v *Plugin_Handshake_Args) Equals(rhs *Plugin_Handshake_Args) bool {
 	return true
 }
 
+func (v *Plugin_Handshake_Args) GetRequest() (o *HandshakeRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+	return
+}
+
 func (v *Plugin_Handshake_Args) MethodName() string {
 	return "handshake"
 }
@@ -103,22 +116,38 @@ func (v *Plugin_Handshake_Args) EnvelopeType() wire.EnvelopeType {
 }
 
 var Plugin_Handshake_Helper = struct {
-	Args           func(request *HandshakeRequest) *Plugin_Handshake_Args
-	IsException    func(error) bool
-	WrapResponse   func(*HandshakeResponse, error) (*Plugin_Handshake_Result, error)
-	UnwrapResponse func(*Plugin_Handshake_Result) (*HandshakeResponse, error)
+	Args            func(request *HandshakeRequest) *Plugin_Handshake_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*Plugin_Handshake_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func(*HandshakeResponse, error) (*Plugin_Handshake_Result, error)
+	UnwrapResponse  func(*Plugin_Handshake_Result) (*HandshakeResponse, error)
 }{}
 
 func init() {
 	Plugin_Handshake_Helper.Args = func(request *HandshakeRequest) *Plugin_Handshake_Args {
 		return &Plugin_Handshake_Args{Request: request}
 	}
+	Plugin_Handshake_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*Plugin_Handshake_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args Plugin_Handshake_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
 	Plugin_Handshake_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		default:
 			return false
 		}
 	}
+	Plugin_Handshake_Helper.IsSafeException = func(error) bool {
+		return false
+	}
 	Plugin_Handshake_Helper.WrapResponse = func(success *HandshakeResponse, err error) (*Plugin_Handshake_Result, error) {
 		if err == nil {
 			return &Plugin_Handshake_Result{Success: success}, nil
@@ -135,6 +164,22 @@ func init() {
 	}
 }
 
+type Plugin_Handshake_ArgsOption func(*Plugin_Handshake_Args)
+
+func Plugin_Handshake_WithRequest(v *HandshakeRequest) Plugin_Handshake_ArgsOption {
+	return func(args *Plugin_Handshake_Args) {
+		args.Request = v
+	}
+}
+
+func Plugin_Handshake_NewArgs(opts ...Plugin_Handshake_ArgsOption) *Plugin_Handshake_Args {
+	args := &Plugin_Handshake_Args{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	return args
+}
+
 type Plugin_Handshake_Result struct {
 	Success *HandshakeResponse `json:"success,omitempty"`
 }
@@ -174,6 +219,7 @@ func (v *Plugin_Handshake_Result) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.Success, err = _HandshakeResponse_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("Plugin_Handshake_Result", "Success", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -184,6 +230,7 @@ func (v *Plugin_Handshake_Result) FromWire(w wire.Value) error {
 		count++
 	}
 	if count != 1 {
+		wire.ObserveDecodeError("Plugin_Handshake_Result", "", wire.DecodeErrorInvalidUnion)
 		return fmt.Errorf("Plugin_Handshake_Result should have exactly one field: got %v fields", count)
 	}
 	return nil
@@ -209,6 +256,40 @@ func (v *Plugin_Handshake_Result) Equals(rhs *Plugin_Handshake_Result) bool {
 	return true
 }
 
+func (v *Plugin_Handshake_Result) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("Plugin_Handshake_Result should have exactly one field: got %v fields", count)
+	}
+	type plain Plugin_Handshake_Result
+	return json.Marshal((*plain)(v))
+}
+
+func (v *Plugin_Handshake_Result) UnmarshalJSON(text []byte) error {
+	type plain Plugin_Handshake_Result
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("Plugin_Handshake_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *Plugin_Handshake_Result) GetSuccess() (o *HandshakeResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+	return
+}
+
 func (v *Plugin_Handshake_Result) MethodName() string {
 	return "handshake"
 }
@@ -216,3 +297,14 @@ func (v *Plugin_Handshake_Result) MethodName() string {
 func (v *Plugin_Handshake_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
+
+func Plugin_Handshake_NewResultSuccess(success *HandshakeResponse) *Plugin_Handshake_Result {
+	return &Plugin_Handshake_Result{Success: success}
+}
+
+func Plugin_Handshake_NewResultError(err error) (*Plugin_Handshake_Result, error) {
+	if err == nil {
+		return nil, errors.New("Plugin_Handshake_NewResultError received a nil error")
+	}
+	return nil, err
+}
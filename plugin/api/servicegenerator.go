@@ -23,8 +23,29 @@
 
 package api
 
+import "context"
+
 type ServiceGenerator interface {
 	Generate(
 		Request *GenerateServiceRequest,
 	) (*GenerateServiceResponse, error)
 }
+
+// ServiceGeneratorContext is the ServiceGenerator service with the context of the
+// request passed to every function.
+type ServiceGeneratorContext interface {
+	Generate(
+		ctx context.Context,
+		Request *GenerateServiceRequest,
+	) (*GenerateServiceResponse, error)
+}
+
+// _ServiceGenerator_withoutContext calls an implementation of ServiceGenerator without the context.
+type _ServiceGenerator_withoutContext struct{ impl ServiceGenerator }
+
+func (s _ServiceGenerator_withoutContext) Generate(
+	ctx context.Context,
+	_Request *GenerateServiceRequest,
+) (*GenerateServiceResponse, error) {
+	return s.impl.Generate(_Request)
+}
@@ -24,6 +24,7 @@
 package api
 
 import (
+	"context"
 	"go.uber.org/thriftrw/internal/envelope"
 	"go.uber.org/thriftrw/wire"
 )
@@ -64,3 +65,43 @@ func (c *_ServiceGenerator_client) Generate(
 	success, err = ServiceGenerator_Generate_Helper.UnwrapResponse(&result)
 	return
 }
+
+// _ServiceGenerator_contextClient implements a ServiceGeneratorContext client.
+type _ServiceGenerator_contextClient struct {
+	client envelope.Client
+}
+
+// NewServiceGeneratorContextClient builds a new ServiceGenerator client which
+// stops waiting for a response and returns the context's error once the
+// context passed to a function is done.
+func NewServiceGeneratorContextClient(c envelope.Client) ServiceGeneratorContext {
+	return &_ServiceGenerator_contextClient{
+		client: c,
+	}
+}
+
+func (c *_ServiceGenerator_contextClient) Generate(
+	ctx context.Context,
+	_Request *GenerateServiceRequest,
+) (success *GenerateServiceResponse, err error) {
+	args := ServiceGenerator_Generate_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = args.ToWire()
+	if err != nil {
+		return
+	}
+
+	body, err = envelope.SendContext(ctx, c.client, "generate", body)
+	if err != nil {
+		return
+	}
+
+	var result ServiceGenerator_Generate_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = ServiceGenerator_Generate_Helper.UnwrapResponse(&result)
+	return
+}
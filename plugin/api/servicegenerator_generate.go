@@ -24,12 +24,16 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/wire"
 	"strings"
 )
 
+// Generates code for requested services.
 type ServiceGenerator_Generate_Args struct {
 	Request *GenerateServiceRequest `json:"request,omitempty"`
 }
@@ -66,6 +70,7 @@ func (v *ServiceGenerator_Generate_Args) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.Request, err = _GenerateServiceRequest_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("ServiceGenerator_Generate_Args", "Request", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -94,6 +99,13 @@ func (v *ServiceGenerator_Generate_Args) Equals(rhs *ServiceGenerator_Generate_A
 	return true
 }
 
+func (v *ServiceGenerator_Generate_Args) GetRequest() (o *GenerateServiceRequest) {
+	if v != nil && v.Request != nil {
+		return v.Request
+	}
+	return
+}
+
 func (v *ServiceGenerator_Generate_Args) MethodName() string {
 	return "generate"
 }
@@ -103,22 +115,38 @@ func (v *ServiceGenerator_Generate_Args) EnvelopeType() wire.EnvelopeType {
 }
 
 var ServiceGenerator_Generate_Helper = struct {
-	Args           func(request *GenerateServiceRequest) *ServiceGenerator_Generate_Args
-	IsException    func(error) bool
-	WrapResponse   func(*GenerateServiceResponse, error) (*ServiceGenerator_Generate_Result, error)
-	UnwrapResponse func(*ServiceGenerator_Generate_Result) (*GenerateServiceResponse, error)
+	Args            func(request *GenerateServiceRequest) *ServiceGenerator_Generate_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*ServiceGenerator_Generate_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func(*GenerateServiceResponse, error) (*ServiceGenerator_Generate_Result, error)
+	UnwrapResponse  func(*ServiceGenerator_Generate_Result) (*GenerateServiceResponse, error)
 }{}
 
 func init() {
 	ServiceGenerator_Generate_Helper.Args = func(request *GenerateServiceRequest) *ServiceGenerator_Generate_Args {
 		return &ServiceGenerator_Generate_Args{Request: request}
 	}
+	ServiceGenerator_Generate_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*ServiceGenerator_Generate_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args ServiceGenerator_Generate_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
 	ServiceGenerator_Generate_Helper.IsException = func(err error) bool {
 		switch err.(type) {
 		default:
 			return false
 		}
 	}
+	ServiceGenerator_Generate_Helper.IsSafeException = func(error) bool {
+		return false
+	}
 	ServiceGenerator_Generate_Helper.WrapResponse = func(success *GenerateServiceResponse, err error) (*ServiceGenerator_Generate_Result, error) {
 		if err == nil {
 			return &ServiceGenerator_Generate_Result{Success: success}, nil
@@ -135,6 +163,22 @@ func init() {
 	}
 }
 
+type ServiceGenerator_Generate_ArgsOption func(*ServiceGenerator_Generate_Args)
+
+func ServiceGenerator_Generate_WithRequest(v *GenerateServiceRequest) ServiceGenerator_Generate_ArgsOption {
+	return func(args *ServiceGenerator_Generate_Args) {
+		args.Request = v
+	}
+}
+
+func ServiceGenerator_Generate_NewArgs(opts ...ServiceGenerator_Generate_ArgsOption) *ServiceGenerator_Generate_Args {
+	args := &ServiceGenerator_Generate_Args{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	return args
+}
+
 type ServiceGenerator_Generate_Result struct {
 	Success *GenerateServiceResponse `json:"success,omitempty"`
 }
@@ -174,6 +218,7 @@ func (v *ServiceGenerator_Generate_Result) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.Success, err = _GenerateServiceResponse_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("ServiceGenerator_Generate_Result", "Success", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -184,6 +229,7 @@ func (v *ServiceGenerator_Generate_Result) FromWire(w wire.Value) error {
 		count++
 	}
 	if count != 1 {
+		wire.ObserveDecodeError("ServiceGenerator_Generate_Result", "", wire.DecodeErrorInvalidUnion)
 		return fmt.Errorf("ServiceGenerator_Generate_Result should have exactly one field: got %v fields", count)
 	}
 	return nil
@@ -209,6 +255,40 @@ func (v *ServiceGenerator_Generate_Result) Equals(rhs *ServiceGenerator_Generate
 	return true
 }
 
+func (v *ServiceGenerator_Generate_Result) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("ServiceGenerator_Generate_Result should have exactly one field: got %v fields", count)
+	}
+	type plain ServiceGenerator_Generate_Result
+	return json.Marshal((*plain)(v))
+}
+
+func (v *ServiceGenerator_Generate_Result) UnmarshalJSON(text []byte) error {
+	type plain ServiceGenerator_Generate_Result
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("ServiceGenerator_Generate_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *ServiceGenerator_Generate_Result) GetSuccess() (o *GenerateServiceResponse) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+	return
+}
+
 func (v *ServiceGenerator_Generate_Result) MethodName() string {
 	return "generate"
 }
@@ -216,3 +296,14 @@ func (v *ServiceGenerator_Generate_Result) MethodName() string {
 func (v *ServiceGenerator_Generate_Result) EnvelopeType() wire.EnvelopeType {
 	return wire.Reply
 }
+
+func ServiceGenerator_Generate_NewResultSuccess(success *GenerateServiceResponse) *ServiceGenerator_Generate_Result {
+	return &ServiceGenerator_Generate_Result{Success: success}
+}
+
+func ServiceGenerator_Generate_NewResultError(err error) (*ServiceGenerator_Generate_Result, error) {
+	if err == nil {
+		return nil, errors.New("ServiceGenerator_Generate_NewResultError received a nil error")
+	}
+	return nil, err
+}
@@ -24,24 +24,66 @@
 package api
 
 import (
+	"context"
+	envelope2 "go.uber.org/thriftrw/envelope"
 	"go.uber.org/thriftrw/internal/envelope"
 	"go.uber.org/thriftrw/wire"
 )
 
 // ServiceGeneratorHandler serves an implementation of the ServiceGenerator service.
 type ServiceGeneratorHandler struct {
-	impl ServiceGenerator
+	impl ServiceGeneratorContext
+
+	// dispatch with the middleware applied
+	handle envelope2.HandlerFunc
 }
 
 // NewServiceGeneratorHandler builds a new ServiceGenerator handler.
-func NewServiceGeneratorHandler(service ServiceGenerator) ServiceGeneratorHandler {
-	return ServiceGeneratorHandler{
+//
+// The given middleware is invoked around every function of the service, in
+// order.
+func NewServiceGeneratorHandler(service ServiceGenerator, middleware ...envelope2.Middleware) ServiceGeneratorHandler {
+	return NewServiceGeneratorContextHandler(_ServiceGenerator_withoutContext{service}, middleware...)
+}
+
+// NewServiceGeneratorContextHandler builds a new ServiceGenerator handler which
+// passes the context of every request to the given implementation.
+//
+// Requests are rejected with the context's error if it is done before the
+// implementation is called.
+func NewServiceGeneratorContextHandler(service ServiceGeneratorContext, middleware ...envelope2.Middleware) ServiceGeneratorHandler {
+	h := ServiceGeneratorHandler{
 		impl: service,
 	}
+	h.handle = envelope2.ApplyMiddleware(h.dispatch, middleware...)
+	return h
 }
 
 // Handle receives and handles a request for the ServiceGenerator service.
 func (h ServiceGeneratorHandler) Handle(name string, reqValue wire.Value) (wire.Value, error) {
+	return h.HandleContext(context.Background(), name, reqValue)
+}
+
+// HandleContext receives and handles a request for the ServiceGenerator
+// service, passing the given context to the middleware.
+//
+// Requests multiplexed for the ServiceGenerator service ("ServiceGenerator:method")
+// are accepted as well.
+func (h ServiceGeneratorHandler) HandleContext(ctx context.Context, name string, reqValue wire.Value) (wire.Value, error) {
+	name = envelope2.TrimServiceName("ServiceGenerator", name)
+	switch name {
+
+	case "generate":
+		return h.handle(ctx, name, reqValue)
+
+	default:
+
+		return wire.Value{}, envelope.ErrUnknownMethod(name)
+
+	}
+}
+
+func (h ServiceGeneratorHandler) dispatch(ctx context.Context, name string, reqValue wire.Value) (wire.Value, error) {
 	switch name {
 
 	case "generate":
@@ -51,8 +93,12 @@ func (h ServiceGeneratorHandler) Handle(name string, reqValue wire.Value) (wire.
 			return wire.Value{}, err
 		}
 
+		if err := ctx.Err(); err != nil {
+			return wire.Value{}, err
+		}
+
 		result, err := ServiceGenerator_Generate_Helper.WrapResponse(
-			h.impl.Generate(args.Request),
+			h.impl.Generate(ctx, args.Request),
 		)
 		if err != nil {
 			return wire.Value{}, err
@@ -61,8 +107,6 @@ func (h ServiceGeneratorHandler) Handle(name string, reqValue wire.Value) (wire.
 		return result.ToWire()
 
 	default:
-
 		return wire.Value{}, envelope.ErrUnknownMethod(name)
-
 	}
 }
@@ -26,7 +26,6 @@ package api
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"go.uber.org/thriftrw/wire"
 	"math"
@@ -34,14 +33,69 @@ import (
 	"strings"
 )
 
+// Argument is a single Argument inside a Function.
+// For,
+//
+//	void setValue(1: string key, 2: string value)
+//
+// You get the arguments,
+//
+//	Argument{Name: "Key", Type: Type{SimpleType: SimpleTypeString}}
+//
+//	Argument{Name: "Value", Type: Type{SimpleType: SimpleTypeString}}
 type Argument struct {
+	// Name of the argument. This is also the name of the argument field
+	// inside the args/result struct for that function.
 	Name string `json:"name"`
-	Type *Type  `json:"type"`
+	// Argument type.
+	Type *Type `json:"type"`
+	// ID of the field for this argument inside the args/result struct for
+	// that function.
+	FieldID *int16 `json:"fieldID,omitempty"`
+	// Name of the argument as defined in the Thrift file.
+	ThriftName *string `json:"thriftName,omitempty"`
+	// Annotations defined on this argument in the Thrift file.
+	Annotations map[string]string `json:"annotations"`
+}
+
+type _Map_String_String_MapItemList map[string]string
+
+func (m _Map_String_String_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
+		vw, err := wire.NewValueString(v), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_String_String_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_String_String_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_String_MapItemList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_String_MapItemList) Close() {
 }
 
 func (v *Argument) ToWire() (wire.Value, error) {
 	var (
-		fields [2]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -53,7 +107,7 @@ func (v *Argument) ToWire() (wire.Value, error) {
 	fields[i] = wire.Field{ID: 1, Value: w}
 	i++
 	if v.Type == nil {
-		return w, errors.New("field Type of Argument is required")
+		return w, wire.NilRequiredFieldError{Struct: "Argument", Field: "Type", ID: 2}
 	}
 	w, err = v.Type.ToWire()
 	if err != nil {
@@ -61,6 +115,30 @@ func (v *Argument) ToWire() (wire.Value, error) {
 	}
 	fields[i] = wire.Field{ID: 2, Value: w}
 	i++
+	if v.FieldID != nil {
+		w, err = wire.NewValueI16(*(v.FieldID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.ThriftName != nil {
+		w, err = wire.NewValueString(*(v.ThriftName)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.Annotations != nil {
+		w, err = wire.NewValueMap(_Map_String_String_MapItemList(v.Annotations)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
@@ -70,6 +148,30 @@ func _Type_Read(w wire.Value) (*Type, error) {
 	return &v, err
 }
 
+func _Map_String_String_Read(m wire.MapItemList) (map[string]string, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+	if m.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+	o := make(map[string]string, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		v, err := x.Value.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
 func (v *Argument) FromWire(w wire.Value) error {
 	var err error
 	nameIsSet := false
@@ -80,6 +182,7 @@ func (v *Argument) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.Name, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("Argument", "Name", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				nameIsSet = true
@@ -88,17 +191,48 @@ func (v *Argument) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.Type, err = _Type_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("Argument", "Type", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				typeIsSet = true
 			}
+		case 3:
+			if field.Value.Type() == wire.TI16 {
+				var x int16
+				x, err = field.Value.GetI16(), error(nil)
+				v.FieldID = &x
+				if err != nil {
+					wire.ObserveDecodeError("Argument", "FieldID", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 4:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ThriftName = &x
+				if err != nil {
+					wire.ObserveDecodeError("Argument", "ThriftName", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 5:
+			if field.Value.Type() == wire.TMap {
+				v.Annotations, err = _Map_String_String_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("Argument", "Annotations", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
 		}
 	}
 	if !nameIsSet {
-		return errors.New("field Name of Argument is required")
+		wire.ObserveDecodeError("Argument", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Argument", Field: "Name", ID: 1}
 	}
 	if !typeIsSet {
-		return errors.New("field Type of Argument is required")
+		wire.ObserveDecodeError("Argument", "Type", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Argument", Field: "Type", ID: 2}
 	}
 	return nil
 }
@@ -107,15 +241,61 @@ func (v *Argument) String() string {
 	if v == nil {
 		return "<nil>"
 	}
-	var fields [2]string
+	var fields [5]string
 	i := 0
 	fields[i] = fmt.Sprintf("Name: %v", v.Name)
 	i++
 	fields[i] = fmt.Sprintf("Type: %v", v.Type)
 	i++
+	if v.FieldID != nil {
+		fields[i] = fmt.Sprintf("FieldID: %v", *(v.FieldID))
+		i++
+	}
+	if v.ThriftName != nil {
+		fields[i] = fmt.Sprintf("ThriftName: %v", *(v.ThriftName))
+		i++
+	}
+	if v.Annotations != nil {
+		fields[i] = fmt.Sprintf("Annotations: %v", v.Annotations)
+		i++
+	}
 	return fmt.Sprintf("Argument{%v}", strings.Join(fields[:i], ", "))
 }
 
+func _I16_EqualsPtr(lhs, rhs *int16) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _String_EqualsPtr(lhs, rhs *string) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _Map_String_String_Equals(lhs, rhs map[string]string) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
 func (v *Argument) Equals(rhs *Argument) bool {
 	if !(v.Name == rhs.Name) {
 		return false
@@ -123,12 +303,62 @@ func (v *Argument) Equals(rhs *Argument) bool {
 	if !v.Type.Equals(rhs.Type) {
 		return false
 	}
+	if !_I16_EqualsPtr(v.FieldID, rhs.FieldID) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ThriftName, rhs.ThriftName) {
+		return false
+	}
+	if !((v.Annotations == nil && rhs.Annotations == nil) || (v.Annotations != nil && rhs.Annotations != nil && _Map_String_String_Equals(v.Annotations, rhs.Annotations))) {
+		return false
+	}
 	return true
 }
 
+func (v *Argument) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
+}
+
+func (v *Argument) GetType() (o *Type) {
+	if v != nil {
+		o = v.Type
+	}
+	return
+}
+
+func (v *Argument) GetFieldID() (o int16) {
+	if v != nil && v.FieldID != nil {
+		return *v.FieldID
+	}
+	return
+}
+
+func (v *Argument) GetThriftName() (o string) {
+	if v != nil && v.ThriftName != nil {
+		return *v.ThriftName
+	}
+	return
+}
+
+func (v *Argument) GetAnnotations() (o map[string]string) {
+	if v != nil && v.Annotations != nil {
+		return v.Annotations
+	}
+	return
+}
+
+// Feature is a functionality offered by a ThriftRW plugin.
 type Feature int32
 
 const (
+	// SERVICE_GENERATOR specifies that the plugin may generate arbitrary code
+	// for services defined in the Thrift file.
+	//
+	// If a plugin provides this, it MUST implement the ServiceGenerator
+	// service.
 	FeatureServiceGenerator Feature = 1
 )
 
@@ -142,10 +372,23 @@ func (v *Feature) UnmarshalText(value []byte) error {
 		*v = FeatureServiceGenerator
 		return nil
 	default:
-		return fmt.Errorf("unknown enum value %q for %q", value, "Feature")
+		val, err := strconv.ParseInt(string(value), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", value, "Feature", err)
+		}
+		*v = Feature(val)
+		return nil
 	}
 }
 
+func (v Feature) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 1:
+		return []byte("SERVICE_GENERATOR"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
 func (v Feature) ToWire() (wire.Value, error) {
 	return wire.NewValueI32(int32(v)), nil
 }
@@ -204,13 +447,38 @@ func (v *Feature) UnmarshalJSON(text []byte) error {
 	}
 }
 
+// Function is a single function on a Thrift service.
 type Function struct {
-	Name       string      `json:"name"`
-	ThriftName string      `json:"thriftName"`
-	Arguments  []*Argument `json:"arguments"`
-	ReturnType *Type       `json:"returnType,omitempty"`
+	// Name of the Go function.
+	Name string `json:"name"`
+	// Name of the function as defined in the Thrift file.
+	ThriftName string `json:"thriftName"`
+	// List of arguments accepted by the function.
+	//
+	// This list is in the order specified by the user in the Thrift file.
+	Arguments []*Argument `json:"arguments"`
+	// Return type of the function, if any. If this is not set, the function
+	// is a void function.
+	ReturnType *Type `json:"returnType,omitempty"`
+	// List of exceptions raised by the function.
+	//
+	// This list is in the order specified by the user in the Thrift file.
 	Exceptions []*Argument `json:"exceptions"`
-	OneWay     *bool       `json:"oneWay,omitempty"`
+	// Whether this function is oneway or not. This should be assumed to be
+	// false unless explicitly stated otherwise. If this is true, the
+	// returnType and exceptions will be null or empty.
+	OneWay *bool `json:"oneWay,omitempty"`
+	// Annotations defined on this function in the Thrift file.
+	Annotations map[string]string `json:"annotations"`
+	// Go type generated for the arguments of this function.
+	//
+	//   type $Service_$Function_Args struct { ... }
+	ArgsType *TypeReference `json:"argsType,omitempty"`
+	// Go type generated for the result of this function. This is not set
+	// for oneway functions.
+	//
+	//   type $Service_$Function_Result struct { ... }
+	ResultType *TypeReference `json:"resultType,omitempty"`
 }
 
 type _List_Argument_ValueList []*Argument
@@ -245,7 +513,7 @@ func (_List_Argument_ValueList) Close() {
 
 func (v *Function) ToWire() (wire.Value, error) {
 	var (
-		fields [6]wire.Field
+		fields [9]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -263,7 +531,7 @@ func (v *Function) ToWire() (wire.Value, error) {
 	fields[i] = wire.Field{ID: 2, Value: w}
 	i++
 	if v.Arguments == nil {
-		return w, errors.New("field Arguments of Function is required")
+		return w, wire.NilRequiredFieldError{Struct: "Function", Field: "Arguments", ID: 3}
 	}
 	w, err = wire.NewValueList(_List_Argument_ValueList(v.Arguments)), error(nil)
 	if err != nil {
@@ -295,6 +563,30 @@ func (v *Function) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 6, Value: w}
 		i++
 	}
+	if v.Annotations != nil {
+		w, err = wire.NewValueMap(_Map_String_String_MapItemList(v.Annotations)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 7, Value: w}
+		i++
+	}
+	if v.ArgsType != nil {
+		w, err = v.ArgsType.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 8, Value: w}
+		i++
+	}
+	if v.ResultType != nil {
+		w, err = v.ResultType.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 9, Value: w}
+		i++
+	}
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
@@ -321,6 +613,12 @@ func _List_Argument_Read(l wire.ValueList) ([]*Argument, error) {
 	return o, err
 }
 
+func _TypeReference_Read(w wire.Value) (*TypeReference, error) {
+	var v TypeReference
+	err := v.FromWire(w)
+	return &v, err
+}
+
 func (v *Function) FromWire(w wire.Value) error {
 	var err error
 	nameIsSet := false
@@ -332,6 +630,7 @@ func (v *Function) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.Name, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("Function", "Name", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				nameIsSet = true
@@ -340,6 +639,7 @@ func (v *Function) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.ThriftName, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("Function", "ThriftName", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				thriftNameIsSet = true
@@ -348,6 +648,7 @@ func (v *Function) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.Arguments, err = _List_Argument_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("Function", "Arguments", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				argumentsIsSet = true
@@ -356,6 +657,7 @@ func (v *Function) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.ReturnType, err = _Type_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("Function", "ReturnType", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -363,6 +665,7 @@ func (v *Function) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.Exceptions, err = _List_Argument_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("Function", "Exceptions", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -372,19 +675,47 @@ func (v *Function) FromWire(w wire.Value) error {
 				x, err = field.Value.GetBool(), error(nil)
 				v.OneWay = &x
 				if err != nil {
+					wire.ObserveDecodeError("Function", "OneWay", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 7:
+			if field.Value.Type() == wire.TMap {
+				v.Annotations, err = _Map_String_String_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("Function", "Annotations", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 8:
+			if field.Value.Type() == wire.TStruct {
+				v.ArgsType, err = _TypeReference_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("Function", "ArgsType", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 9:
+			if field.Value.Type() == wire.TStruct {
+				v.ResultType, err = _TypeReference_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("Function", "ResultType", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
 		}
 	}
 	if !nameIsSet {
-		return errors.New("field Name of Function is required")
+		wire.ObserveDecodeError("Function", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Function", Field: "Name", ID: 1}
 	}
 	if !thriftNameIsSet {
-		return errors.New("field ThriftName of Function is required")
+		wire.ObserveDecodeError("Function", "ThriftName", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Function", Field: "ThriftName", ID: 2}
 	}
 	if !argumentsIsSet {
-		return errors.New("field Arguments of Function is required")
+		wire.ObserveDecodeError("Function", "Arguments", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Function", Field: "Arguments", ID: 3}
 	}
 	return nil
 }
@@ -393,7 +724,7 @@ func (v *Function) String() string {
 	if v == nil {
 		return "<nil>"
 	}
-	var fields [6]string
+	var fields [9]string
 	i := 0
 	fields[i] = fmt.Sprintf("Name: %v", v.Name)
 	i++
@@ -413,6 +744,18 @@ func (v *Function) String() string {
 		fields[i] = fmt.Sprintf("OneWay: %v", *(v.OneWay))
 		i++
 	}
+	if v.Annotations != nil {
+		fields[i] = fmt.Sprintf("Annotations: %v", v.Annotations)
+		i++
+	}
+	if v.ArgsType != nil {
+		fields[i] = fmt.Sprintf("ArgsType: %v", v.ArgsType)
+		i++
+	}
+	if v.ResultType != nil {
+		fields[i] = fmt.Sprintf("ResultType: %v", v.ResultType)
+		i++
+	}
 	return fmt.Sprintf("Function{%v}", strings.Join(fields[:i], ", "))
 }
 
@@ -457,13 +800,101 @@ func (v *Function) Equals(rhs *Function) bool {
 	if !_Bool_EqualsPtr(v.OneWay, rhs.OneWay) {
 		return false
 	}
+	if !((v.Annotations == nil && rhs.Annotations == nil) || (v.Annotations != nil && rhs.Annotations != nil && _Map_String_String_Equals(v.Annotations, rhs.Annotations))) {
+		return false
+	}
+	if !((v.ArgsType == nil && rhs.ArgsType == nil) || (v.ArgsType != nil && rhs.ArgsType != nil && v.ArgsType.Equals(rhs.ArgsType))) {
+		return false
+	}
+	if !((v.ResultType == nil && rhs.ResultType == nil) || (v.ResultType != nil && rhs.ResultType != nil && v.ResultType.Equals(rhs.ResultType))) {
+		return false
+	}
 	return true
 }
 
+func (v *Function) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
+}
+
+func (v *Function) GetThriftName() (o string) {
+	if v != nil {
+		o = v.ThriftName
+	}
+	return
+}
+
+func (v *Function) GetArguments() (o []*Argument) {
+	if v != nil {
+		o = v.Arguments
+	}
+	return
+}
+
+func (v *Function) GetReturnType() (o *Type) {
+	if v != nil && v.ReturnType != nil {
+		return v.ReturnType
+	}
+	return
+}
+
+func (v *Function) GetExceptions() (o []*Argument) {
+	if v != nil && v.Exceptions != nil {
+		return v.Exceptions
+	}
+	return
+}
+
+func (v *Function) GetOneWay() (o bool) {
+	if v != nil && v.OneWay != nil {
+		return *v.OneWay
+	}
+	return
+}
+
+func (v *Function) GetAnnotations() (o map[string]string) {
+	if v != nil && v.Annotations != nil {
+		return v.Annotations
+	}
+	return
+}
+
+func (v *Function) GetArgsType() (o *TypeReference) {
+	if v != nil && v.ArgsType != nil {
+		return v.ArgsType
+	}
+	return
+}
+
+func (v *Function) GetResultType() (o *TypeReference) {
+	if v != nil && v.ResultType != nil {
+		return v.ResultType
+	}
+	return
+}
+
+// GenerateServiceRequest is a request to generate code for zero or more
+// Thrift services.
 type GenerateServiceRequest struct {
-	RootServices []ServiceID            `json:"rootServices"`
-	Services     map[ServiceID]*Service `json:"services"`
-	Modules      map[ModuleID]*Module   `json:"modules"`
+	// IDs of services for which code should be generated.
+	//
+	// Note that the services map contains information about both, the
+	// services being generated and their transitive dependencies. Code should
+	// only be generated for service IDs listed here.
+	RootServices []ServiceID `json:"rootServices"`
+	// Map of service ID to service.
+	//
+	// Any service IDs present in this request will have a corresponding
+	// service definition in this map, including services for which code does
+	// not need to be generated.
+	Services map[ServiceID]*Service `json:"services"`
+	// Map of module ID to module.
+	//
+	// Any module IDs present in the request will have a corresponding module
+	// definition in this map.
+	Modules map[ModuleID]*Module `json:"modules"`
 }
 
 type _List_ServiceID_ValueList []ServiceID
@@ -577,7 +1008,7 @@ func (v *GenerateServiceRequest) ToWire() (wire.Value, error) {
 		err    error
 	)
 	if v.RootServices == nil {
-		return w, errors.New("field RootServices of GenerateServiceRequest is required")
+		return w, wire.NilRequiredFieldError{Struct: "GenerateServiceRequest", Field: "RootServices", ID: 1}
 	}
 	w, err = wire.NewValueList(_List_ServiceID_ValueList(v.RootServices)), error(nil)
 	if err != nil {
@@ -586,7 +1017,7 @@ func (v *GenerateServiceRequest) ToWire() (wire.Value, error) {
 	fields[i] = wire.Field{ID: 1, Value: w}
 	i++
 	if v.Services == nil {
-		return w, errors.New("field Services of GenerateServiceRequest is required")
+		return w, wire.NilRequiredFieldError{Struct: "GenerateServiceRequest", Field: "Services", ID: 2}
 	}
 	w, err = wire.NewValueMap(_Map_ServiceID_Service_MapItemList(v.Services)), error(nil)
 	if err != nil {
@@ -595,7 +1026,7 @@ func (v *GenerateServiceRequest) ToWire() (wire.Value, error) {
 	fields[i] = wire.Field{ID: 2, Value: w}
 	i++
 	if v.Modules == nil {
-		return w, errors.New("field Modules of GenerateServiceRequest is required")
+		return w, wire.NilRequiredFieldError{Struct: "GenerateServiceRequest", Field: "Modules", ID: 3}
 	}
 	w, err = wire.NewValueMap(_Map_ModuleID_Module_MapItemList(v.Modules)), error(nil)
 	if err != nil {
@@ -706,6 +1137,7 @@ func (v *GenerateServiceRequest) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.RootServices, err = _List_ServiceID_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("GenerateServiceRequest", "RootServices", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				rootServicesIsSet = true
@@ -714,6 +1146,7 @@ func (v *GenerateServiceRequest) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TMap {
 				v.Services, err = _Map_ServiceID_Service_Read(field.Value.GetMap())
 				if err != nil {
+					wire.ObserveDecodeError("GenerateServiceRequest", "Services", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				servicesIsSet = true
@@ -722,6 +1155,7 @@ func (v *GenerateServiceRequest) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TMap {
 				v.Modules, err = _Map_ModuleID_Module_Read(field.Value.GetMap())
 				if err != nil {
+					wire.ObserveDecodeError("GenerateServiceRequest", "Modules", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				modulesIsSet = true
@@ -729,13 +1163,16 @@ func (v *GenerateServiceRequest) FromWire(w wire.Value) error {
 		}
 	}
 	if !rootServicesIsSet {
-		return errors.New("field RootServices of GenerateServiceRequest is required")
+		wire.ObserveDecodeError("GenerateServiceRequest", "RootServices", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "GenerateServiceRequest", Field: "RootServices", ID: 1}
 	}
 	if !servicesIsSet {
-		return errors.New("field Services of GenerateServiceRequest is required")
+		wire.ObserveDecodeError("GenerateServiceRequest", "Services", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "GenerateServiceRequest", Field: "Services", ID: 2}
 	}
 	if !modulesIsSet {
-		return errors.New("field Modules of GenerateServiceRequest is required")
+		wire.ObserveDecodeError("GenerateServiceRequest", "Modules", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "GenerateServiceRequest", Field: "Modules", ID: 3}
 	}
 	return nil
 }
@@ -813,7 +1250,36 @@ func (v *GenerateServiceRequest) Equals(rhs *GenerateServiceRequest) bool {
 	return true
 }
 
+func (v *GenerateServiceRequest) GetRootServices() (o []ServiceID) {
+	if v != nil {
+		o = v.RootServices
+	}
+	return
+}
+
+func (v *GenerateServiceRequest) GetServices() (o map[ServiceID]*Service) {
+	if v != nil {
+		o = v.Services
+	}
+	return
+}
+
+func (v *GenerateServiceRequest) GetModules() (o map[ModuleID]*Module) {
+	if v != nil {
+		o = v.Modules
+	}
+	return
+}
+
+// GenerateServiceResponse is response to a GenerateServiceRequest.
 type GenerateServiceResponse struct {
+	// Map of file path to file contents.
+	//
+	// All paths MUST be relative to the output directory into which ThriftRW
+	// is generating code. Plugins SHOULD NOT make any assumptions about the
+	// absolute location of the directory.
+	//
+	// The paths MUST NOT contain the string ".." or the request will fail.
 	Files map[string][]byte `json:"files"`
 }
 
@@ -905,6 +1371,7 @@ func (v *GenerateServiceResponse) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TMap {
 				v.Files, err = _Map_String_Binary_Read(field.Value.GetMap())
 				if err != nil {
+					wire.ObserveDecodeError("GenerateServiceResponse", "Files", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -949,6 +1416,15 @@ func (v *GenerateServiceResponse) Equals(rhs *GenerateServiceResponse) bool {
 	return true
 }
 
+func (v *GenerateServiceResponse) GetFiles() (o map[string][]byte) {
+	if v != nil && v.Files != nil {
+		return v.Files
+	}
+	return
+}
+
+// HandshakeRequest is the initial request sent to the plugin as part of
+// establishing communication and feature negotiation.
 type HandshakeRequest struct{}
 
 func (v *HandshakeRequest) ToWire() (wire.Value, error) {
@@ -980,11 +1456,22 @@ func (v *HandshakeRequest) Equals(rhs *HandshakeRequest) bool {
 	return true
 }
 
+// HandshakeResponse is the response from the plugin for a HandshakeRequest.
 type HandshakeResponse struct {
-	Name           string    `json:"name"`
-	APIVersion     int32     `json:"apiVersion"`
-	Features       []Feature `json:"features"`
-	LibraryVersion *string   `json:"libraryVersion,omitempty"`
+	// Name of the plugin. This MUST match the name of the plugin specified
+	// over the command line or the program will fail.
+	Name string `json:"name"`
+	// Version of the plugin API.
+	//
+	// This MUST be set to API_VERSION by the plugin.
+	APIVersion int32 `json:"apiVersion"`
+	// List of features the plugin provides.
+	Features []Feature `json:"features"`
+	// Version of ThriftRW with which the plugin was built.
+	//
+	// This MUST be set to go.uber.org/thriftrw/version.Version by the plugin
+	// explicitly.
+	LibraryVersion *string `json:"libraryVersion,omitempty"`
 }
 
 type _List_Feature_ValueList []Feature
@@ -1034,7 +1521,7 @@ func (v *HandshakeResponse) ToWire() (wire.Value, error) {
 	fields[i] = wire.Field{ID: 2, Value: w}
 	i++
 	if v.Features == nil {
-		return w, errors.New("field Features of HandshakeResponse is required")
+		return w, wire.NilRequiredFieldError{Struct: "HandshakeResponse", Field: "Features", ID: 3}
 	}
 	w, err = wire.NewValueList(_List_Feature_ValueList(v.Features)), error(nil)
 	if err != nil {
@@ -1087,6 +1574,7 @@ func (v *HandshakeResponse) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.Name, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("HandshakeResponse", "Name", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				nameIsSet = true
@@ -1095,6 +1583,7 @@ func (v *HandshakeResponse) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TI32 {
 				v.APIVersion, err = field.Value.GetI32(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("HandshakeResponse", "APIVersion", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				apiVersionIsSet = true
@@ -1103,6 +1592,7 @@ func (v *HandshakeResponse) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.Features, err = _List_Feature_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("HandshakeResponse", "Features", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				featuresIsSet = true
@@ -1113,19 +1603,23 @@ func (v *HandshakeResponse) FromWire(w wire.Value) error {
 				x, err = field.Value.GetString(), error(nil)
 				v.LibraryVersion = &x
 				if err != nil {
+					wire.ObserveDecodeError("HandshakeResponse", "LibraryVersion", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
 		}
 	}
 	if !nameIsSet {
-		return errors.New("field Name of HandshakeResponse is required")
+		wire.ObserveDecodeError("HandshakeResponse", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "HandshakeResponse", Field: "Name", ID: 1}
 	}
 	if !apiVersionIsSet {
-		return errors.New("field APIVersion of HandshakeResponse is required")
+		wire.ObserveDecodeError("HandshakeResponse", "APIVersion", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "HandshakeResponse", Field: "APIVersion", ID: 2}
 	}
 	if !featuresIsSet {
-		return errors.New("field Features of HandshakeResponse is required")
+		wire.ObserveDecodeError("HandshakeResponse", "Features", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "HandshakeResponse", Field: "Features", ID: 3}
 	}
 	return nil
 }
@@ -1162,15 +1656,6 @@ func _List_Feature_Equals(lhs, rhs []Feature) bool {
 	return true
 }
 
-func _String_EqualsPtr(lhs, rhs *string) bool {
-	if lhs != nil && rhs != nil {
-		x := *lhs
-		y := *rhs
-		return (x == y)
-	}
-	return lhs == nil && rhs == nil
-}
-
 func (v *HandshakeResponse) Equals(rhs *HandshakeResponse) bool {
 	if !(v.Name == rhs.Name) {
 		return false
@@ -1187,14 +1672,70 @@ func (v *HandshakeResponse) Equals(rhs *HandshakeResponse) bool {
 	return true
 }
 
+func (v *HandshakeResponse) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
+}
+
+func (v *HandshakeResponse) GetAPIVersion() (o int32) {
+	if v != nil {
+		o = v.APIVersion
+	}
+	return
+}
+
+func (v *HandshakeResponse) GetFeatures() (o []Feature) {
+	if v != nil {
+		o = v.Features
+	}
+	return
+}
+
+func (v *HandshakeResponse) GetLibraryVersion() (o string) {
+	if v != nil && v.LibraryVersion != nil {
+		return *v.LibraryVersion
+	}
+	return
+}
+
+// Module is a module generated from a single Thrift file. Each module
+// corresponds to exactly one Thrift file and contains all the types and
+// constants defined in that Thrift file.
 type Module struct {
+	// Import path for the package defining the types for this module.
 	ImportPath string `json:"importPath"`
-	Directory  string `json:"directory"`
+	// Path to the directory containing the code for this module.
+	//
+	// The path is relative to the output directory into which ThriftRW is
+	// generating code. Plugins SHOULD NOT make any assumptions about the
+	// absolute location of the directory.
+	Directory string `json:"directory"`
+	// Namespaces declared in the Thrift file, keyed by scope. The scope is
+	// the language for which the namespace applies, or "*" for namespaces
+	// which apply to all languages.
+	//
+	//   namespace java com.example.users
+	//
+	// If a Thrift file declares multiple namespaces for the same scope, the
+	// last one is used.
+	Namespaces map[string]string `json:"namespaces"`
+	// Name of the Go package defining the types for this module.
+	//
+	// This is usually the last component of the import path but it differs
+	// if the package was renamed with the go.package annotation or if the
+	// default name is a Go keyword or predeclared identifier, in which case
+	// it is escaped with a trailing underscore: the package for
+	// string.thrift is named string_.
+	//
+	// This is not set by older versions of ThriftRW.
+	PackageName *string `json:"packageName,omitempty"`
 }
 
 func (v *Module) ToWire() (wire.Value, error) {
 	var (
-		fields [2]wire.Field
+		fields [4]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -1211,6 +1752,22 @@ func (v *Module) ToWire() (wire.Value, error) {
 	}
 	fields[i] = wire.Field{ID: 2, Value: w}
 	i++
+	if v.Namespaces != nil {
+		w, err = wire.NewValueMap(_Map_String_String_MapItemList(v.Namespaces)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.PackageName != nil {
+		w, err = wire.NewValueString(*(v.PackageName)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
@@ -1224,6 +1781,7 @@ func (v *Module) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.ImportPath, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("Module", "ImportPath", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				importPathIsSet = true
@@ -1232,17 +1790,38 @@ func (v *Module) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.Directory, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("Module", "Directory", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				directoryIsSet = true
 			}
+		case 3:
+			if field.Value.Type() == wire.TMap {
+				v.Namespaces, err = _Map_String_String_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("Module", "Namespaces", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 4:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.PackageName = &x
+				if err != nil {
+					wire.ObserveDecodeError("Module", "PackageName", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
 		}
 	}
 	if !importPathIsSet {
-		return errors.New("field ImportPath of Module is required")
+		wire.ObserveDecodeError("Module", "ImportPath", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Module", Field: "ImportPath", ID: 1}
 	}
 	if !directoryIsSet {
-		return errors.New("field Directory of Module is required")
+		wire.ObserveDecodeError("Module", "Directory", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Module", Field: "Directory", ID: 2}
 	}
 	return nil
 }
@@ -1251,12 +1830,20 @@ func (v *Module) String() string {
 	if v == nil {
 		return "<nil>"
 	}
-	var fields [2]string
+	var fields [4]string
 	i := 0
 	fields[i] = fmt.Sprintf("ImportPath: %v", v.ImportPath)
 	i++
 	fields[i] = fmt.Sprintf("Directory: %v", v.Directory)
 	i++
+	if v.Namespaces != nil {
+		fields[i] = fmt.Sprintf("Namespaces: %v", v.Namespaces)
+		i++
+	}
+	if v.PackageName != nil {
+		fields[i] = fmt.Sprintf("PackageName: %v", *(v.PackageName))
+		i++
+	}
 	return fmt.Sprintf("Module{%v}", strings.Join(fields[:i], ", "))
 }
 
@@ -1267,9 +1854,45 @@ func (v *Module) Equals(rhs *Module) bool {
 	if !(v.Directory == rhs.Directory) {
 		return false
 	}
+	if !((v.Namespaces == nil && rhs.Namespaces == nil) || (v.Namespaces != nil && rhs.Namespaces != nil && _Map_String_String_Equals(v.Namespaces, rhs.Namespaces))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.PackageName, rhs.PackageName) {
+		return false
+	}
 	return true
 }
 
+func (v *Module) GetImportPath() (o string) {
+	if v != nil {
+		o = v.ImportPath
+	}
+	return
+}
+
+func (v *Module) GetDirectory() (o string) {
+	if v != nil {
+		o = v.Directory
+	}
+	return
+}
+
+func (v *Module) GetNamespaces() (o map[string]string) {
+	if v != nil && v.Namespaces != nil {
+		return v.Namespaces
+	}
+	return
+}
+
+func (v *Module) GetPackageName() (o string) {
+	if v != nil && v.PackageName != nil {
+		return *v.PackageName
+	}
+	return
+}
+
+// ModuleID is an arbitrary unique identifier to reference the different
+// modules in this request.
 type ModuleID int32
 
 func (v ModuleID) ToWire() (wire.Value, error) {
@@ -1292,12 +1915,20 @@ func (lhs ModuleID) Equals(rhs ModuleID) bool {
 	return (lhs == rhs)
 }
 
+// Service is a service defined by the user in the Thrift file.
 type Service struct {
-	Name       string      `json:"name"`
-	ThriftName string      `json:"thriftName"`
-	ParentID   *ServiceID  `json:"parentID,omitempty"`
-	Functions  []*Function `json:"functions"`
-	ModuleID   ModuleID    `json:"moduleID"`
+	// Name of the Thrift service in Go code.
+	Name string `json:"name"`
+	// Name of the service as defined in the Thrift file.
+	ThriftName string `json:"thriftName"`
+	// ID of the parent service.
+	ParentID *ServiceID `json:"parentID,omitempty"`
+	// List of functions defined for this service.
+	Functions []*Function `json:"functions"`
+	// ID of the module where this service was declared.
+	ModuleID ModuleID `json:"moduleID"`
+	// Annotations defined on this service in the Thrift file.
+	Annotations map[string]string `json:"annotations"`
 }
 
 type _List_Function_ValueList []*Function
@@ -1332,7 +1963,7 @@ func (_List_Function_ValueList) Close() {
 
 func (v *Service) ToWire() (wire.Value, error) {
 	var (
-		fields [5]wire.Field
+		fields [6]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -1358,7 +1989,7 @@ func (v *Service) ToWire() (wire.Value, error) {
 		i++
 	}
 	if v.Functions == nil {
-		return w, errors.New("field Functions of Service is required")
+		return w, wire.NilRequiredFieldError{Struct: "Service", Field: "Functions", ID: 5}
 	}
 	w, err = wire.NewValueList(_List_Function_ValueList(v.Functions)), error(nil)
 	if err != nil {
@@ -1372,6 +2003,14 @@ func (v *Service) ToWire() (wire.Value, error) {
 	}
 	fields[i] = wire.Field{ID: 6, Value: w}
 	i++
+	if v.Annotations != nil {
+		w, err = wire.NewValueMap(_Map_String_String_MapItemList(v.Annotations)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 8, Value: w}
+		i++
+	}
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
@@ -1410,6 +2049,7 @@ func (v *Service) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.Name, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("Service", "Name", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				nameIsSet = true
@@ -1418,6 +2058,7 @@ func (v *Service) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.ThriftName, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("Service", "ThriftName", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				thriftNameIsSet = true
@@ -1428,6 +2069,7 @@ func (v *Service) FromWire(w wire.Value) error {
 				x, err = _ServiceID_Read(field.Value)
 				v.ParentID = &x
 				if err != nil {
+					wire.ObserveDecodeError("Service", "ParentID", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1435,6 +2077,7 @@ func (v *Service) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TList {
 				v.Functions, err = _List_Function_Read(field.Value.GetList())
 				if err != nil {
+					wire.ObserveDecodeError("Service", "Functions", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				functionsIsSet = true
@@ -1443,23 +2086,36 @@ func (v *Service) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TI32 {
 				v.ModuleID, err = _ModuleID_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("Service", "ModuleID", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				moduleIDIsSet = true
 			}
+		case 8:
+			if field.Value.Type() == wire.TMap {
+				v.Annotations, err = _Map_String_String_Read(field.Value.GetMap())
+				if err != nil {
+					wire.ObserveDecodeError("Service", "Annotations", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
 		}
 	}
 	if !nameIsSet {
-		return errors.New("field Name of Service is required")
+		wire.ObserveDecodeError("Service", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Service", Field: "Name", ID: 7}
 	}
 	if !thriftNameIsSet {
-		return errors.New("field ThriftName of Service is required")
+		wire.ObserveDecodeError("Service", "ThriftName", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Service", Field: "ThriftName", ID: 1}
 	}
 	if !functionsIsSet {
-		return errors.New("field Functions of Service is required")
+		wire.ObserveDecodeError("Service", "Functions", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Service", Field: "Functions", ID: 5}
 	}
 	if !moduleIDIsSet {
-		return errors.New("field ModuleID of Service is required")
+		wire.ObserveDecodeError("Service", "ModuleID", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Service", Field: "ModuleID", ID: 6}
 	}
 	return nil
 }
@@ -1468,7 +2124,7 @@ func (v *Service) String() string {
 	if v == nil {
 		return "<nil>"
 	}
-	var fields [5]string
+	var fields [6]string
 	i := 0
 	fields[i] = fmt.Sprintf("Name: %v", v.Name)
 	i++
@@ -1482,6 +2138,10 @@ func (v *Service) String() string {
 	i++
 	fields[i] = fmt.Sprintf("ModuleID: %v", v.ModuleID)
 	i++
+	if v.Annotations != nil {
+		fields[i] = fmt.Sprintf("Annotations: %v", v.Annotations)
+		i++
+	}
 	return fmt.Sprintf("Service{%v}", strings.Join(fields[:i], ", "))
 }
 
@@ -1523,9 +2183,56 @@ func (v *Service) Equals(rhs *Service) bool {
 	if !(v.ModuleID == rhs.ModuleID) {
 		return false
 	}
+	if !((v.Annotations == nil && rhs.Annotations == nil) || (v.Annotations != nil && rhs.Annotations != nil && _Map_String_String_Equals(v.Annotations, rhs.Annotations))) {
+		return false
+	}
 	return true
 }
 
+func (v *Service) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
+}
+
+func (v *Service) GetThriftName() (o string) {
+	if v != nil {
+		o = v.ThriftName
+	}
+	return
+}
+
+func (v *Service) GetParentID() (o ServiceID) {
+	if v != nil && v.ParentID != nil {
+		return *v.ParentID
+	}
+	return
+}
+
+func (v *Service) GetFunctions() (o []*Function) {
+	if v != nil {
+		o = v.Functions
+	}
+	return
+}
+
+func (v *Service) GetModuleID() (o ModuleID) {
+	if v != nil {
+		o = v.ModuleID
+	}
+	return
+}
+
+func (v *Service) GetAnnotations() (o map[string]string) {
+	if v != nil && v.Annotations != nil {
+		return v.Annotations
+	}
+	return
+}
+
+// ServiceID is an arbitrary unique identifier to reference the different
+// services in this request.
 type ServiceID int32
 
 func (v ServiceID) ToWire() (wire.Value, error) {
@@ -1548,6 +2255,7 @@ func (lhs ServiceID) Equals(rhs ServiceID) bool {
 	return (lhs == rhs)
 }
 
+// SimpleType is a standalone native Go type.
 type SimpleType int32
 
 const (
@@ -1596,8 +2304,37 @@ func (v *SimpleType) UnmarshalText(value []byte) error {
 		*v = SimpleTypeStructEmpty
 		return nil
 	default:
-		return fmt.Errorf("unknown enum value %q for %q", value, "SimpleType")
+		val, err := strconv.ParseInt(string(value), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", value, "SimpleType", err)
+		}
+		*v = SimpleType(val)
+		return nil
+	}
+}
+
+func (v SimpleType) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 1:
+		return []byte("BOOL"), nil
+	case 2:
+		return []byte("BYTE"), nil
+	case 3:
+		return []byte("INT8"), nil
+	case 4:
+		return []byte("INT16"), nil
+	case 5:
+		return []byte("INT32"), nil
+	case 6:
+		return []byte("INT64"), nil
+	case 7:
+		return []byte("FLOAT64"), nil
+	case 8:
+		return []byte("STRING"), nil
+	case 9:
+		return []byte("STRUCT_EMPTY"), nil
 	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
 
 func (v SimpleType) ToWire() (wire.Value, error) {
@@ -1690,13 +2427,25 @@ func (v *SimpleType) UnmarshalJSON(text []byte) error {
 	}
 }
 
+// Type is a reference to a Go type which may be native or user defined.
 type Type struct {
-	SimpleType        *SimpleType    `json:"simpleType,omitempty"`
-	SliceType         *Type          `json:"sliceType,omitempty"`
-	KeyValueSliceType *TypePair      `json:"keyValueSliceType,omitempty"`
-	MapType           *TypePair      `json:"mapType,omitempty"`
-	ReferenceType     *TypeReference `json:"referenceType,omitempty"`
-	PointerType       *Type          `json:"pointerType,omitempty"`
+	SimpleType *SimpleType `json:"simpleType,omitempty"`
+	// Slice of a type
+	//
+	// []$sliceType
+	SliceType *Type `json:"sliceType,omitempty"`
+	// Slice of key-value pairs of a pair of types.
+	//
+	// []struct{Key $left, Value $right}
+	KeyValueSliceType *TypePair `json:"keyValueSliceType,omitempty"`
+	// Map of a pair of types.
+	//
+	// map[$left]$right
+	MapType *TypePair `json:"mapType,omitempty"`
+	// Reference to a user-defined type.
+	ReferenceType *TypeReference `json:"referenceType,omitempty"`
+	// Pointer to a type.
+	PointerType *Type `json:"pointerType,omitempty"`
 }
 
 func (v *Type) ToWire() (wire.Value, error) {
@@ -1772,12 +2521,6 @@ func _TypePair_Read(w wire.Value) (*TypePair, error) {
 	return &v, err
 }
 
-func _TypeReference_Read(w wire.Value) (*TypeReference, error) {
-	var v TypeReference
-	err := v.FromWire(w)
-	return &v, err
-}
-
 func (v *Type) FromWire(w wire.Value) error {
 	var err error
 	for _, field := range w.GetStruct().Fields {
@@ -1788,6 +2531,7 @@ func (v *Type) FromWire(w wire.Value) error {
 				x, err = _SimpleType_Read(field.Value)
 				v.SimpleType = &x
 				if err != nil {
+					wire.ObserveDecodeError("Type", "SimpleType", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1795,6 +2539,7 @@ func (v *Type) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.SliceType, err = _Type_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("Type", "SliceType", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1802,6 +2547,7 @@ func (v *Type) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.KeyValueSliceType, err = _TypePair_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("Type", "KeyValueSliceType", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1809,6 +2555,7 @@ func (v *Type) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.MapType, err = _TypePair_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("Type", "MapType", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1816,6 +2563,7 @@ func (v *Type) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.ReferenceType, err = _TypeReference_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("Type", "ReferenceType", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1823,6 +2571,7 @@ func (v *Type) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.PointerType, err = _Type_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("Type", "PointerType", wire.DecodeErrorInvalidValue)
 					return err
 				}
 			}
@@ -1848,6 +2597,7 @@ func (v *Type) FromWire(w wire.Value) error {
 		count++
 	}
 	if count != 1 {
+		wire.ObserveDecodeError("Type", "", wire.DecodeErrorInvalidUnion)
 		return fmt.Errorf("Type should have exactly one field: got %v fields", count)
 	}
 	return nil
@@ -1917,6 +2667,106 @@ func (v *Type) Equals(rhs *Type) bool {
 	return true
 }
 
+func (v *Type) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.SimpleType != nil {
+		count++
+	}
+	if v.SliceType != nil {
+		count++
+	}
+	if v.KeyValueSliceType != nil {
+		count++
+	}
+	if v.MapType != nil {
+		count++
+	}
+	if v.ReferenceType != nil {
+		count++
+	}
+	if v.PointerType != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("Type should have exactly one field: got %v fields", count)
+	}
+	type plain Type
+	return json.Marshal((*plain)(v))
+}
+
+func (v *Type) UnmarshalJSON(text []byte) error {
+	type plain Type
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.SimpleType != nil {
+		count++
+	}
+	if v.SliceType != nil {
+		count++
+	}
+	if v.KeyValueSliceType != nil {
+		count++
+	}
+	if v.MapType != nil {
+		count++
+	}
+	if v.ReferenceType != nil {
+		count++
+	}
+	if v.PointerType != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("Type should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *Type) GetSimpleType() (o SimpleType) {
+	if v != nil && v.SimpleType != nil {
+		return *v.SimpleType
+	}
+	return
+}
+
+func (v *Type) GetSliceType() (o *Type) {
+	if v != nil && v.SliceType != nil {
+		return v.SliceType
+	}
+	return
+}
+
+func (v *Type) GetKeyValueSliceType() (o *TypePair) {
+	if v != nil && v.KeyValueSliceType != nil {
+		return v.KeyValueSliceType
+	}
+	return
+}
+
+func (v *Type) GetMapType() (o *TypePair) {
+	if v != nil && v.MapType != nil {
+		return v.MapType
+	}
+	return
+}
+
+func (v *Type) GetReferenceType() (o *TypeReference) {
+	if v != nil && v.ReferenceType != nil {
+		return v.ReferenceType
+	}
+	return
+}
+
+func (v *Type) GetPointerType() (o *Type) {
+	if v != nil && v.PointerType != nil {
+		return v.PointerType
+	}
+	return
+}
+
+// TypePair is a pair of two types.
 type TypePair struct {
 	Left  *Type `json:"left"`
 	Right *Type `json:"right"`
@@ -1930,7 +2780,7 @@ func (v *TypePair) ToWire() (wire.Value, error) {
 		err    error
 	)
 	if v.Left == nil {
-		return w, errors.New("field Left of TypePair is required")
+		return w, wire.NilRequiredFieldError{Struct: "TypePair", Field: "Left", ID: 1}
 	}
 	w, err = v.Left.ToWire()
 	if err != nil {
@@ -1939,7 +2789,7 @@ func (v *TypePair) ToWire() (wire.Value, error) {
 	fields[i] = wire.Field{ID: 1, Value: w}
 	i++
 	if v.Right == nil {
-		return w, errors.New("field Right of TypePair is required")
+		return w, wire.NilRequiredFieldError{Struct: "TypePair", Field: "Right", ID: 2}
 	}
 	w, err = v.Right.ToWire()
 	if err != nil {
@@ -1960,6 +2810,7 @@ func (v *TypePair) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.Left, err = _Type_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("TypePair", "Left", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				leftIsSet = true
@@ -1968,6 +2819,7 @@ func (v *TypePair) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TStruct {
 				v.Right, err = _Type_Read(field.Value)
 				if err != nil {
+					wire.ObserveDecodeError("TypePair", "Right", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				rightIsSet = true
@@ -1975,10 +2827,12 @@ func (v *TypePair) FromWire(w wire.Value) error {
 		}
 	}
 	if !leftIsSet {
-		return errors.New("field Left of TypePair is required")
+		wire.ObserveDecodeError("TypePair", "Left", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "TypePair", Field: "Left", ID: 1}
 	}
 	if !rightIsSet {
-		return errors.New("field Right of TypePair is required")
+		wire.ObserveDecodeError("TypePair", "Right", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "TypePair", Field: "Right", ID: 2}
 	}
 	return nil
 }
@@ -2006,8 +2860,24 @@ func (v *TypePair) Equals(rhs *TypePair) bool {
 	return true
 }
 
+func (v *TypePair) GetLeft() (o *Type) {
+	if v != nil {
+		o = v.Left
+	}
+	return
+}
+
+func (v *TypePair) GetRight() (o *Type) {
+	if v != nil {
+		o = v.Right
+	}
+	return
+}
+
+// TypeReference is a reference to a user-defined type.
 type TypeReference struct {
-	Name       string `json:"name"`
+	Name string `json:"name"`
+	// Import path for the package defining this type.
 	ImportPath string `json:"importPath"`
 }
 
@@ -2043,6 +2913,7 @@ func (v *TypeReference) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.Name, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("TypeReference", "Name", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				nameIsSet = true
@@ -2051,6 +2922,7 @@ func (v *TypeReference) FromWire(w wire.Value) error {
 			if field.Value.Type() == wire.TBinary {
 				v.ImportPath, err = field.Value.GetString(), error(nil)
 				if err != nil {
+					wire.ObserveDecodeError("TypeReference", "ImportPath", wire.DecodeErrorInvalidValue)
 					return err
 				}
 				importPathIsSet = true
@@ -2058,10 +2930,12 @@ func (v *TypeReference) FromWire(w wire.Value) error {
 		}
 	}
 	if !nameIsSet {
-		return errors.New("field Name of TypeReference is required")
+		wire.ObserveDecodeError("TypeReference", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "TypeReference", Field: "Name", ID: 1}
 	}
 	if !importPathIsSet {
-		return errors.New("field ImportPath of TypeReference is required")
+		wire.ObserveDecodeError("TypeReference", "ImportPath", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "TypeReference", Field: "ImportPath", ID: 2}
 	}
 	return nil
 }
@@ -2088,3 +2962,17 @@ func (v *TypeReference) Equals(rhs *TypeReference) bool {
 	}
 	return true
 }
+
+func (v *TypeReference) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
+}
+
+func (v *TypeReference) GetImportPath() (o string) {
+	if v != nil {
+		o = v.ImportPath
+	}
+	return
+}
@@ -53,4 +53,14 @@
 // 	thriftrw --plugin='myfancyplugin --useContext'
 //
 // Will pass `--useContext` to `thriftrw-plugin-myfancyplugin`.
+//
+// Plugins which are expensive to start may run as daemons that serve many
+// ThriftRW invocations. Start the plugin with THRIFTRW_PLUGIN_LISTEN set to
+// the address it should listen on and append that address to the plugin
+// name.
+//
+// 	THRIFTRW_PLUGIN_LISTEN=unix:/tmp/myfancyplugin.sock thriftrw-plugin-myfancyplugin &
+// 	thriftrw --plugin=myfancyplugin@unix:/tmp/myfancyplugin.sock foo.thrift
+//
+// See Main for the supported addresses.
 package plugin
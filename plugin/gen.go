@@ -21,5 +21,4 @@
 package plugin
 
 //go:generate thriftrw --generate-plugin-api api.thrift
-//go:generate mockgen -destination plugintest/api.go -package plugintest go.uber.org/thriftrw/plugin/api Plugin,ServiceGenerator
 //go:generate ../scripts/updateLicenses.sh
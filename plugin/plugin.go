@@ -21,13 +21,17 @@
 package plugin
 
 import (
+	"crypto/tls"
+	"fmt"
 	"io"
 	"log"
+	"net"
 	"os"
 
 	"go.uber.org/thriftrw/internal/envelope"
 	"go.uber.org/thriftrw/internal/frame"
 	"go.uber.org/thriftrw/internal/multiplex"
+	"go.uber.org/thriftrw/internal/socket"
 	"go.uber.org/thriftrw/plugin/api"
 	"go.uber.org/thriftrw/protocol"
 	"go.uber.org/thriftrw/ptr"
@@ -36,6 +40,13 @@ import (
 
 const _fastPathFrameSize = 10 * 1024 * 1024 // 10 MB
 
+// Environment variables used to run plugins as daemons.
+const (
+	_listenEnv  = "THRIFTRW_PLUGIN_LISTEN"
+	_tlsCertEnv = "THRIFTRW_PLUGIN_TLS_CERT"
+	_tlsKeyEnv  = "THRIFTRW_PLUGIN_TLS_KEY"
+)
+
 var _proto = protocol.Binary
 
 // Plugin defines a ThriftRW plugin.
@@ -57,6 +68,12 @@ type Plugin struct {
 	// stdout.
 	Reader io.Reader
 	Writer io.Writer
+
+	// If non-nil, the plugin runs as a daemon and serves every connection
+	// accepted by this Listener instead of using the Reader and Writer. The
+	// ServiceGenerator may be called concurrently for different
+	// connections.
+	Listener net.Listener
 }
 
 // Main serves the given plugin. It is the entry point to the plugin system.
@@ -65,26 +82,40 @@ type Plugin struct {
 // 	func main() {
 // 		plugin.Main(myPlugin)
 // 	}
+//
+// By default, plugins are started by ThriftRW and communicate with it over
+// stdin and stdout. Plugins may instead run as long-lived daemons which serve
+// many ThriftRW invocations if a Listener is provided or the
+// THRIFTRW_PLUGIN_LISTEN environment variable is set to an address in one of
+// the following forms.
+//
+// 	unix:/path/to/socket
+// 	tcp:host:port
+// 	tls:host:port
+//
+// TLS daemons read their certificate and private key from the PEM files
+// named by THRIFTRW_PLUGIN_TLS_CERT and THRIFTRW_PLUGIN_TLS_KEY.
 func Main(p *Plugin) {
 	if p.Name == "" {
 		panic("a plugin name must be provided")
 	}
 
-	// The plugin communicates with the ThriftRW process over stdout and stdin
-	// of this process. Requests and responses are Thrift envelopes with a
-	// 4-byte big-endian encoded length prefix. Envelope names contain method
-	// names prefixed with the service name and a ":".
-
-	mainHandler := multiplex.NewHandler()
-
-	features := []api.Feature{}
+	listener := p.Listener
+	if listener == nil && p.Reader == nil && p.Writer == nil {
+		var err error
+		listener, err = listenFromEnv()
+		if err != nil {
+			log.Fatalf("plugin failed to listen: %v", err)
+		}
+	}
 
-	if p.ServiceGenerator != nil {
-		features = append(features, api.FeatureServiceGenerator)
-		mainHandler.Put("ServiceGenerator", api.NewServiceGeneratorHandler(p.ServiceGenerator))
+	if listener != nil {
+		if err := serveListener(p, listener); err != nil {
+			log.Fatalf("plugin server failed with error: %v", err)
+		}
+		return
 	}
 
-	// TODO(abg): Check for other features and register handlers here.
 	reader := p.Reader
 	if reader == nil {
 		reader = os.Stdin
@@ -94,6 +125,79 @@ func Main(p *Plugin) {
 		writer = os.Stdout
 	}
 
+	if err := serve(p, reader, writer); err != nil {
+		log.Fatalf("plugin server failed with error: %v", err)
+	}
+}
+
+// listenFromEnv returns the listener specified by THRIFTRW_PLUGIN_LISTEN, or
+// nil if the variable is not set.
+func listenFromEnv() (net.Listener, error) {
+	value := os.Getenv(_listenEnv)
+	if value == "" {
+		return nil, nil
+	}
+
+	addr, err := socket.ParseAddress(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var config *tls.Config
+	if addr.TLS {
+		cert, err := tls.LoadX509KeyPair(os.Getenv(_tlsCertEnv), os.Getenv(_tlsKeyEnv))
+		if err != nil {
+			return nil, fmt.Errorf("could not load TLS certificate: %v", err)
+		}
+		config = &tls.Config{Certificates: []tls.Certificate{cert}}
+	}
+
+	return socket.Listen(addr, config)
+}
+
+// serveListener serves the plugin on every connection accepted by the
+// listener until the listener fails.
+func serveListener(p *Plugin, l net.Listener) error {
+	defer l.Close()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			// Hide the Close method of the connection from the reading side.
+			// The server closes its reader when the Goodbye request is
+			// received and the connection must remain open for the response.
+			// The connection is closed with the writer when the server stops.
+			reader := struct{ io.Reader }{conn}
+			if err := serve(p, reader, conn); err != nil && err != io.EOF {
+				log.Printf("plugin connection from %v failed: %v", conn.RemoteAddr(), err)
+			}
+		}()
+	}
+}
+
+// serve serves the plugin over the given reader and writer until ThriftRW
+// says goodbye.
+func serve(p *Plugin, reader io.Reader, writer io.Writer) error {
+	// The plugin communicates with the ThriftRW process over the reader and
+	// writer. Requests and responses are Thrift envelopes with a 4-byte
+	// big-endian encoded length prefix. Envelope names contain method names
+	// prefixed with the service name and a ":".
+
+	mainHandler := multiplex.NewHandler()
+
+	features := []api.Feature{}
+
+	if p.ServiceGenerator != nil {
+		features = append(features, api.FeatureServiceGenerator)
+		mainHandler.Put("ServiceGenerator", api.NewServiceGeneratorHandler(p.ServiceGenerator))
+	}
+
+	// TODO(abg): Check for other features and register handlers here.
+
 	server := frame.NewServer(reader, writer)
 	mainHandler.Put("Plugin", api.NewPluginHandler(pluginHandler{
 		server:   server,
@@ -101,9 +205,7 @@ func Main(p *Plugin) {
 		features: features,
 	}))
 
-	if err := server.Serve(envelope.NewServer(_proto, mainHandler)); err != nil {
-		log.Fatalf("plugin server failed with error: %v", err)
-	}
+	return server.Serve(envelope.NewServer(_proto, mainHandler))
 }
 
 // pluginHandler implements the Plugin service.
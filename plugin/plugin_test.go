@@ -2,6 +2,9 @@ package plugin
 
 import (
 	"io"
+	"net"
+	"os"
+	"sync"
 	"testing"
 
 	"go.uber.org/thriftrw/internal/envelope"
@@ -121,3 +124,57 @@ func TestServiceGenerator(t *testing.T) {
 		assert.Equal(t, res, gotRes)
 	}
 }
+
+func TestPluginListener(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	// The daemon keeps running until the test process exits.
+	go Main(&Plugin{Name: "hello", Listener: l})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			conn, err := net.Dial("tcp", l.Addr().String())
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer conn.Close()
+
+			client := api.NewPluginClient(multiplex.NewClient("Plugin", fakeEnvelopeClient(conn, conn)))
+			response, err := client.Handshake(&api.HandshakeRequest{})
+			if assert.NoError(t, err) {
+				assert.Equal(t, "hello", response.Name)
+			}
+			assert.NoError(t, client.Goodbye())
+		}()
+	}
+	wg.Wait()
+}
+
+func TestListenFromEnv(t *testing.T) {
+	defer os.Setenv(_listenEnv, os.Getenv(_listenEnv))
+
+	os.Setenv(_listenEnv, "")
+	l, err := listenFromEnv()
+	assert.NoError(t, err)
+	assert.Nil(t, l)
+
+	os.Setenv(_listenEnv, "tcp:127.0.0.1:0")
+	l, err = listenFromEnv()
+	require.NoError(t, err)
+	assert.NoError(t, l.Close())
+
+	os.Setenv(_listenEnv, "foo")
+	_, err = listenFromEnv()
+	assert.Error(t, err)
+
+	os.Setenv(_listenEnv, "tls:127.0.0.1:0")
+	_, err = listenFromEnv()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "could not load TLS certificate")
+	}
+}
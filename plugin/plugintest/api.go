@@ -1,113 +0,0 @@
-// Code generated by MockGen. DO NOT EDIT.
-
-// Copyright (c) 2017 Uber Technologies, Inc.
-//
-// Permission is hereby granted, free of charge, to any person obtaining a copy
-// of this software and associated documentation files (the "Software"), to deal
-// in the Software without restriction, including without limitation the rights
-// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
-// copies of the Software, and to permit persons to whom the Software is
-// furnished to do so, subject to the following conditions:
-//
-// The above copyright notice and this permission notice shall be included in
-// all copies or substantial portions of the Software.
-//
-// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
-// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
-// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
-// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
-// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
-// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
-// THE SOFTWARE.
-// Source: go.uber.org/thriftrw/plugin/api (interfaces: Plugin,ServiceGenerator)
-
-package plugintest
-
-import (
-	gomock "github.com/golang/mock/gomock"
-	api "go.uber.org/thriftrw/plugin/api"
-)
-
-// MockPlugin is a mock of Plugin interface
-type MockPlugin struct {
-	ctrl     *gomock.Controller
-	recorder *MockPluginMockRecorder
-}
-
-// MockPluginMockRecorder is the mock recorder for MockPlugin
-type MockPluginMockRecorder struct {
-	mock *MockPlugin
-}
-
-// NewMockPlugin creates a new mock instance
-func NewMockPlugin(ctrl *gomock.Controller) *MockPlugin {
-	mock := &MockPlugin{ctrl: ctrl}
-	mock.recorder = &MockPluginMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use
-func (_m *MockPlugin) EXPECT() *MockPluginMockRecorder {
-	return _m.recorder
-}
-
-// Goodbye mocks base method
-func (_m *MockPlugin) Goodbye() error {
-	ret := _m.ctrl.Call(_m, "Goodbye")
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// Goodbye indicates an expected call of Goodbye
-func (_mr *MockPluginMockRecorder) Goodbye() *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "Goodbye")
-}
-
-// Handshake mocks base method
-func (_m *MockPlugin) Handshake(_param0 *api.HandshakeRequest) (*api.HandshakeResponse, error) {
-	ret := _m.ctrl.Call(_m, "Handshake", _param0)
-	ret0, _ := ret[0].(*api.HandshakeResponse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// Handshake indicates an expected call of Handshake
-func (_mr *MockPluginMockRecorder) Handshake(arg0 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "Handshake", arg0)
-}
-
-// MockServiceGenerator is a mock of ServiceGenerator interface
-type MockServiceGenerator struct {
-	ctrl     *gomock.Controller
-	recorder *MockServiceGeneratorMockRecorder
-}
-
-// MockServiceGeneratorMockRecorder is the mock recorder for MockServiceGenerator
-type MockServiceGeneratorMockRecorder struct {
-	mock *MockServiceGenerator
-}
-
-// NewMockServiceGenerator creates a new mock instance
-func NewMockServiceGenerator(ctrl *gomock.Controller) *MockServiceGenerator {
-	mock := &MockServiceGenerator{ctrl: ctrl}
-	mock.recorder = &MockServiceGeneratorMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use
-func (_m *MockServiceGenerator) EXPECT() *MockServiceGeneratorMockRecorder {
-	return _m.recorder
-}
-
-// Generate mocks base method
-func (_m *MockServiceGenerator) Generate(_param0 *api.GenerateServiceRequest) (*api.GenerateServiceResponse, error) {
-	ret := _m.ctrl.Call(_m, "Generate", _param0)
-	ret0, _ := ret[0].(*api.GenerateServiceResponse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// Generate indicates an expected call of Generate
-func (_mr *MockServiceGeneratorMockRecorder) Generate(arg0 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "Generate", arg0)
-}
@@ -0,0 +1,83 @@
+// Code generated by thriftrw --generate-plugin-api
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package plugintest
+
+import (
+	"github.com/golang/mock/gomock"
+	"go.uber.org/thriftrw/plugin/api"
+)
+
+// MockPlugin is a mock of the Plugin interface.
+type MockPlugin struct {
+	ctrl     *gomock.Controller
+	recorder *MockPluginMockRecorder
+}
+
+var _ api.Plugin = (*MockPlugin)(nil)
+
+// MockPluginMockRecorder records expected calls to a MockPlugin.
+type MockPluginMockRecorder struct {
+	mock *MockPlugin
+}
+
+// NewMockPlugin builds a new mock of the Plugin interface.
+func NewMockPlugin(ctrl *gomock.Controller) *MockPlugin {
+	mock := &MockPlugin{ctrl: ctrl}
+	mock.recorder = &MockPluginMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlugin) EXPECT() *MockPluginMockRecorder {
+	return m.recorder
+}
+
+// Goodbye mocks the Goodbye method.
+func (m *MockPlugin) Goodbye() error {
+	ret := m.ctrl.Call(m, "Goodbye")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Goodbye indicates an expected call of Goodbye.
+func (mr *MockPluginMockRecorder) Goodbye() *gomock.Call {
+	return mr.mock.ctrl.RecordCall(mr.mock, "Goodbye")
+}
+
+// Handshake mocks the Handshake method.
+func (m *MockPlugin) Handshake(
+	_Request *api.HandshakeRequest,
+) (*api.HandshakeResponse, error) {
+	ret := m.ctrl.Call(m, "Handshake", _Request)
+	ret0, _ := ret[0].(*api.HandshakeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Handshake indicates an expected call of Handshake.
+func (mr *MockPluginMockRecorder) Handshake(
+	_Request interface{},
+) *gomock.Call {
+	return mr.mock.ctrl.RecordCall(mr.mock, "Handshake", _Request)
+}
@@ -0,0 +1,71 @@
+// Code generated by thriftrw --generate-plugin-api
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package plugintest
+
+import (
+	"github.com/golang/mock/gomock"
+	"go.uber.org/thriftrw/plugin/api"
+)
+
+// MockServiceGenerator is a mock of the ServiceGenerator interface.
+type MockServiceGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceGeneratorMockRecorder
+}
+
+var _ api.ServiceGenerator = (*MockServiceGenerator)(nil)
+
+// MockServiceGeneratorMockRecorder records expected calls to a MockServiceGenerator.
+type MockServiceGeneratorMockRecorder struct {
+	mock *MockServiceGenerator
+}
+
+// NewMockServiceGenerator builds a new mock of the ServiceGenerator interface.
+func NewMockServiceGenerator(ctrl *gomock.Controller) *MockServiceGenerator {
+	mock := &MockServiceGenerator{ctrl: ctrl}
+	mock.recorder = &MockServiceGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceGenerator) EXPECT() *MockServiceGeneratorMockRecorder {
+	return m.recorder
+}
+
+// Generate mocks the Generate method.
+func (m *MockServiceGenerator) Generate(
+	_Request *api.GenerateServiceRequest,
+) (*api.GenerateServiceResponse, error) {
+	ret := m.ctrl.Call(m, "Generate", _Request)
+	ret0, _ := ret[0].(*api.GenerateServiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockServiceGeneratorMockRecorder) Generate(
+	_Request interface{},
+) *gomock.Call {
+	return mr.mock.ctrl.RecordCall(mr.mock, "Generate", _Request)
+}
@@ -28,6 +28,8 @@ import (
 	"go/token"
 	"path/filepath"
 	"sort"
+	"strconv"
+	"strings"
 	"text/template"
 
 	"go.uber.org/thriftrw/internal/goast"
@@ -199,9 +201,8 @@ func (g *goFileGenerator) FormatType(t *api.Type) (string, error) {
 	}
 }
 
-// Generates a Go file with the given name using the provided template and
-// template data.
-func (g *goFileGenerator) Generate(filename, tmpl string, data interface{}) ([]byte, error) {
+// funcs returns the functions available to templates.
+func (g *goFileGenerator) funcs() template.FuncMap {
 	funcs := template.FuncMap{
 		"import":     g.Import,
 		"formatType": g.FormatType,
@@ -209,21 +210,32 @@ func (g *goFileGenerator) Generate(filename, tmpl string, data interface{}) ([]b
 	for k, v := range g.templateFuncs {
 		funcs[k] = v
 	}
+	return funcs
+}
 
-	t, err := template.New(filename).Delims("<", ">").Funcs(funcs).Parse(tmpl)
+// Generates a Go file with the given name using the provided template and
+// template data.
+func (g *goFileGenerator) Generate(filename, tmpl string, data interface{}) ([]byte, error) {
+	t, err := template.New(filename).Delims("<", ">").Funcs(g.funcs()).Parse(tmpl)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse template %q: %v", filename, err)
+		return nil, newTemplateError(
+			fmt.Sprintf("failed to parse template %q", filename), filename, tmpl, err)
 	}
 
 	var buff bytes.Buffer
 	if err := t.Execute(&buff, data); err != nil {
-		return nil, err
+		return nil, newTemplateError(
+			fmt.Sprintf("failed to render template %q", filename), filename, tmpl, err)
 	}
 
 	fset := token.NewFileSet()
 	f, err := parser.ParseFile(fset, filename, buff.Bytes(), parser.ParseComments)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse generated code: %v:\n%s", err, buff.String())
+		e := templateError{Message: "failed to parse generated code", Reason: err}
+		if pos, ok := goast.ErrorPosition(err); ok {
+			e.Snippet = goast.Snippet(buff.Bytes(), pos.Line, _snippetContext)
+		}
+		return nil, e
 	}
 
 	if len(f.Imports) > 0 {
@@ -248,6 +260,22 @@ func (g *goFileGenerator) Generate(filename, tmpl string, data interface{}) ([]b
 	return buff.Bytes(), nil
 }
 
+// ValidateTemplate parses the given template with the functions that would be
+// available to it in GoFileFromTemplate and reports the problems with it, if
+// any. The template is not rendered.
+//
+// This may be used to verify templates supplied by users before they are
+// used to generate code.
+func ValidateTemplate(filename, tmpl string, opts ...TemplateOption) error {
+	g := newGoFileGenerator(opts)
+	_, err := template.New(filename).Delims("<", ">").Funcs(g.funcs()).Parse(tmpl)
+	if err != nil {
+		return newTemplateError(
+			fmt.Sprintf("failed to parse template %q", filename), filename, tmpl, err)
+	}
+	return nil
+}
+
 // GoFileFromTemplate generates a Go file from the given template and template
 // data.
 //
@@ -282,3 +310,61 @@ func (g *goFileGenerator) Generate(filename, tmpl string, data interface{}) ([]b
 func GoFileFromTemplate(filename, tmpl string, data interface{}, opts ...TemplateOption) ([]byte, error) {
 	return newGoFileGenerator(opts).Generate(filename, tmpl, data)
 }
+
+// _snippetContext is the number of lines shown on either side of the line at
+// which a template failed.
+const _snippetContext = 3
+
+// templateError is returned if a template fails to parse or render, or if
+// the code it generates is not valid Go.
+type templateError struct {
+	Message string
+	Reason  error
+
+	// Lines of the template or the generated code around the failure.
+	Snippet string
+}
+
+// newTemplateError builds a templateError for an error returned by
+// text/template for the template with the given name and source. text/template
+// reports the line of the template at which it failed but not its contents.
+func newTemplateError(msg, name, tmpl string, err error) templateError {
+	e := templateError{Message: msg, Reason: err}
+	if line := templateErrorLine(name, err); line > 0 {
+		e.Snippet = goast.Snippet([]byte(tmpl), line, _snippetContext)
+	}
+	return e
+}
+
+// templateErrorLine extracts the line number from errors of the form,
+//
+// 	template: $name:$line: $message
+// 	template: $name:$line:$column: $message
+//
+// Returns 0 if the line number is unknown.
+func templateErrorLine(name string, err error) int {
+	prefix := "template: " + name + ":"
+	msg := err.Error()
+	if !strings.HasPrefix(msg, prefix) {
+		return 0
+	}
+	msg = msg[len(prefix):]
+
+	end := strings.IndexFunc(msg, func(r rune) bool { return r < '0' || r > '9' })
+	if end < 0 {
+		end = len(msg)
+	}
+	line, err := strconv.Atoi(msg[:end])
+	if err != nil {
+		return 0
+	}
+	return line
+}
+
+func (e templateError) Error() string {
+	msg := fmt.Sprintf("%v: %v", e.Message, e.Reason)
+	if e.Snippet != "" {
+		msg += ":\n" + e.Snippet
+	}
+	return msg
+}
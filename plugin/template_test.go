@@ -151,6 +151,48 @@ func TestGoFileFromTemplate(t *testing.T) {
 			template:  `func main() {}`,
 			wantError: `failed to parse generated code: test.go:`,
 		},
+		{
+			desc: "invalid Go code snippet",
+			template: unlines(
+				`package main`,
+				``,
+				`func <.Name>() {`,
+				`	return 42 +`,
+				`}`,
+			),
+			data: struct{ Name string }{Name: "foo"},
+			wantError: unlines(
+				`failed to parse generated code: test.go:5:1: expected operand, found '}':`,
+				`  2 | `,
+				`  3 | func foo() {`,
+				`  4 | 	return 42 +`,
+				`> 5 | }`,
+			),
+		},
+		{
+			desc: "invalid template snippet",
+			template: unlines(
+				`package main`,
+				``,
+				`var x = <foo>`,
+			),
+			wantError: unlines(
+				`failed to parse template "test.go": template: test.go:3: function "foo" not defined:`,
+				`  1 | package main`,
+				`  2 | `,
+				`> 3 | var x = <foo>`,
+			),
+		},
+		{
+			desc: "template execution error",
+			template: unlines(
+				`package main`,
+				``,
+				`var x = <.Missing>`,
+			),
+			data:      struct{ Name string }{Name: "foo"},
+			wantError: `failed to render template "test.go": template: test.go:3:`,
+		},
 		{
 			desc: "explicit import",
 			template: `
@@ -241,3 +283,41 @@ func TestGoFileFromTemplate(t *testing.T) {
 func unlines(lines ...string) string {
 	return strings.Join(lines, "\n") + "\n"
 }
+
+func TestValidateTemplate(t *testing.T) {
+	tests := []struct {
+		desc      string
+		template  string
+		options   []TemplateOption
+		wantError string
+	}{
+		{
+			desc:     "valid",
+			template: `package <.Name>; var x <formatType .Type>`,
+		},
+		{
+			desc:     "custom function",
+			template: `package <lower "FOO">`,
+			options:  []TemplateOption{TemplateFunc("lower", strings.ToLower)},
+		},
+		{
+			desc:     "unknown function",
+			template: "package foo\n\n<lower \"FOO\">",
+			wantError: unlines(
+				`failed to parse template "foo.go": template: foo.go:3: function "lower" not defined:`,
+				`  1 | package foo`,
+				`  2 | `,
+				`> 3 | <lower "FOO">`,
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		err := ValidateTemplate("foo.go", tt.template, tt.options...)
+		if tt.wantError == "" {
+			assert.NoError(t, err, tt.desc)
+		} else if assert.Error(t, err, tt.desc) {
+			assert.Equal(t, tt.wantError, err.Error(), tt.desc)
+		}
+	}
+}
@@ -21,6 +21,7 @@
 package protocol
 
 import (
+	"context"
 	"io"
 
 	"go.uber.org/thriftrw/protocol/binary"
@@ -34,7 +35,20 @@ func init() {
 	Binary = binaryProtocol{}
 }
 
-type binaryProtocol struct{}
+// BinaryWithLimits returns an implementation of the Thrift Binary Protocol
+// which fails to decode values that exceed the given limits. Use this to
+// decode payloads from untrusted sources, since a few bytes declaring a
+// large container are otherwise enough to make decoders allocate gigabytes
+// of memory.
+//
+// Values are encoded exactly like with Binary.
+func BinaryWithLimits(l binary.Limits) Protocol {
+	return binaryProtocol{limits: l}
+}
+
+type binaryProtocol struct {
+	limits binary.Limits
+}
 
 func (binaryProtocol) Encode(v wire.Value, w io.Writer) error {
 	writer := binary.BorrowWriter(w)
@@ -43,8 +57,9 @@ func (binaryProtocol) Encode(v wire.Value, w io.Writer) error {
 	return err
 }
 
-func (binaryProtocol) Decode(r io.ReaderAt, t wire.Type) (wire.Value, error) {
+func (p binaryProtocol) Decode(r io.ReaderAt, t wire.Type) (wire.Value, error) {
 	reader := binary.NewReader(r)
+	reader.SetLimits(p.limits)
 	value, _, err := reader.ReadValue(t, 0)
 	return value, err
 }
@@ -56,8 +71,41 @@ func (binaryProtocol) EncodeEnveloped(e wire.Envelope, w io.Writer) error {
 	return err
 }
 
-func (binaryProtocol) DecodeEnveloped(r io.ReaderAt) (wire.Envelope, error) {
+func (p binaryProtocol) DecodeEnveloped(r io.ReaderAt) (wire.Envelope, error) {
 	reader := binary.NewReader(r)
+	reader.SetLimits(p.limits)
 	e, err := reader.ReadEnveloped()
 	return e, err
 }
+
+func (p binaryProtocol) DecodeContext(ctx context.Context, r io.ReaderAt, t wire.Type) (wire.Value, error) {
+	reader := binary.NewReaderContext(ctx, r)
+	reader.SetLimits(p.limits)
+	value, _, err := reader.ReadValue(t, 0)
+	return value, err
+}
+
+func (p binaryProtocol) DecodeEnvelopedContext(ctx context.Context, r io.ReaderAt) (wire.Envelope, error) {
+	reader := binary.NewReaderContext(ctx, r)
+	reader.SetLimits(p.limits)
+	return reader.ReadEnveloped()
+}
+
+func (p binaryProtocol) DecodeNoCopy(b []byte, t wire.Type) (wire.Value, error) {
+	reader := binary.NewNoCopyReader(b)
+	reader.SetLimits(p.limits)
+	value, _, err := reader.ReadValue(t, 0)
+	return value, err
+}
+
+func (p binaryProtocol) DecodeEnvelopedNoCopy(b []byte) (wire.Envelope, error) {
+	reader := binary.NewNoCopyReader(b)
+	reader.SetLimits(p.limits)
+	return reader.ReadEnveloped()
+}
+
+func (p binaryProtocol) Extract(r io.ReaderAt, path ...int16) (wire.Value, bool, error) {
+	reader := binary.NewReader(r)
+	reader.SetLimits(p.limits)
+	return reader.Extract(0, path...)
+}
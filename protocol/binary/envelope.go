@@ -35,7 +35,9 @@ const (
 // WriteEnveloped writes enveloped value using the strict envelope.
 // TODO: Add an option when creating the writer to choose non-strict writes.
 func (bw *Writer) WriteEnveloped(e wire.Envelope) error {
-	version := uint32(version1) | uint32(e.Type)
+	// Convert through uint8 so that negative types don't overwrite the
+	// version bits.
+	version := uint32(version1) | uint32(uint8(e.Type))
 
 	if err := bw.writeInt32(int32(version)); err != nil {
 		return err
@@ -34,10 +34,15 @@ func decodeErrorf(f string, args ...interface{}) decodeError {
 	return decodeError{message: fmt.Sprintf(f, args...)}
 }
 
-// IsDecodeError checks if an error is a protocol decode error.
+// IsDecodeError checks if an error is a protocol decode error. Errors for
+// values which exceed the Limits of a Reader are decode errors.
 func IsDecodeError(e error) bool {
 	// TODO(abg): decode error can probably be shared across protocols. move
 	// to protocol/
-	_, isDecodeError := e.(decodeError)
-	return isDecodeError
+	switch e.(type) {
+	case decodeError, LimitError:
+		return true
+	default:
+		return false
+	}
 }
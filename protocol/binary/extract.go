@@ -0,0 +1,91 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package binary
+
+import "go.uber.org/thriftrw/wire"
+
+// Extract reads only the field at the given path of the struct that starts
+// at the given offset. Each element of the path is the ID of a field of the
+// struct selected by the path so far.
+//
+// Fields not on the path are skipped without being decoded. If a struct
+// along the path does not have the requested field, the returned boolean
+// is false. If a struct has multiple fields with the same ID, the first one
+// is used.
+//
+// With an empty path, the whole struct is read.
+func (br *Reader) Extract(off int64, path ...int16) (wire.Value, bool, error) {
+	t := wire.TStruct
+	for _, id := range path {
+		if t != wire.TStruct {
+			return wire.Value{}, false, decodeErrorf(
+				"cannot extract field %d from a value of type %v", id, t)
+		}
+
+		var (
+			found bool
+			err   error
+		)
+		t, off, found, err = br.findField(off, id)
+		if err != nil || !found {
+			return wire.Value{}, false, err
+		}
+	}
+
+	v, _, err := br.ReadValue(t, off)
+	if err != nil {
+		return wire.Value{}, false, err
+	}
+	return v, true, nil
+}
+
+// findField skips over the fields of the struct that starts at the given
+// offset until it finds the field with the given ID. It returns the type of
+// the field and the offset at which its value starts.
+func (br *Reader) findField(off int64, id int16) (wire.Type, int64, bool, error) {
+	typ, off, err := br.readByte(off)
+	if err != nil {
+		return 0, off, false, err
+	}
+
+	for typ != 0 {
+		var fid int16
+		fid, off, err = br.readInt16(off)
+		if err != nil {
+			return 0, off, false, err
+		}
+
+		if fid == id {
+			return wire.Type(typ), off, true, nil
+		}
+
+		off, err = br.skipValue(wire.Type(typ), off)
+		if err != nil {
+			return 0, off, false, err
+		}
+
+		typ, off, err = br.readByte(off)
+		if err != nil {
+			return 0, off, false, err
+		}
+	}
+	return 0, off, false, nil
+}
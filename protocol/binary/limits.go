@@ -0,0 +1,99 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package binary
+
+import "fmt"
+
+// Limits restricts what a Reader is willing to decode so that small,
+// maliciously crafted payloads cannot make it allocate large amounts of
+// memory or recurse without bound. Zero values mean no limit.
+//
+// Reading a value which exceeds a limit fails with a LimitError.
+type Limits struct {
+	// MaxContainerLength is the maximum number of items in a list, set, or
+	// map. It limits how much memory is allocated up front for decoded
+	// containers based on the lengths they declare.
+	MaxContainerLength int32
+
+	// MaxDepth is the maximum number of structs, lists, sets, and maps
+	// nested within each other. A top-level struct has a depth of 1.
+	MaxDepth int
+
+	// MaxBytes is the maximum number of bytes read from the input,
+	// including those of lazily decoded containers and of values which are
+	// skipped.
+	MaxBytes int64
+}
+
+// LimitError is returned when a Reader reads a value which exceeds one of
+// its Limits.
+type LimitError struct {
+	// Limit is the name of the exceeded field of Limits, for example,
+	// "MaxDepth".
+	Limit string
+
+	// Value is the length, depth, or number of bytes which exceeded Max.
+	Value, Max int64
+}
+
+func (e LimitError) Error() string {
+	return fmt.Sprintf("%v exceeded: %d is larger than the limit of %d", e.Limit, e.Value, e.Max)
+}
+
+// SetLimits restricts the values decoded by this Reader to the given
+// Limits.
+func (br *Reader) SetLimits(l Limits) {
+	br.limits = l
+}
+
+// checkLength verifies that a container with the given number of items is
+// within the limits.
+func (br *Reader) checkLength(count int32) error {
+	if max := br.limits.MaxContainerLength; max > 0 && count > max {
+		return LimitError{Limit: "MaxContainerLength", Value: int64(count), Max: int64(max)}
+	}
+	return nil
+}
+
+// checkOffset verifies that reading up to the given offset stays within the
+// limits.
+func (br *Reader) checkOffset(off int64) error {
+	if max := br.limits.MaxBytes; max > 0 && off > max {
+		return LimitError{Limit: "MaxBytes", Value: off, Max: max}
+	}
+	return nil
+}
+
+// enter records that a struct or container is being read, failing if it is
+// nested too deeply. If enter succeeds, callers must call leave once the
+// value has been read.
+func (br *Reader) enter() error {
+	if max := br.limits.MaxDepth; max > 0 && br.depth >= max {
+		return LimitError{Limit: "MaxDepth", Value: int64(br.depth + 1), Max: int64(max)}
+	}
+	br.depth++
+	return nil
+}
+
+// leave records that the struct or container entered last was read.
+func (br *Reader) leave() {
+	br.depth--
+}
@@ -22,6 +22,7 @@ package binary
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"math"
 
@@ -32,6 +33,10 @@ import (
 // buffer.
 const bytesAllocThreshold = 1048576 // 1 MB
 
+// Readers with a context check whether it is done after reading or skipping
+// this many values.
+const contextCheckInterval = 1024
+
 // Reader implements a parser for the Thrift Binary Protocol based on an
 // io.ReaderAt.
 type Reader struct {
@@ -39,6 +44,21 @@ type Reader struct {
 
 	// This buffer is re-used every time we need a slice of up to 8 bytes.
 	buffer [8]byte
+
+	// If non-nil, reading fails with ctx.Err() once this context is done.
+	ctx context.Context
+
+	// Number of values visited since the context was last checked.
+	visited int
+
+	// Limits on the values read, and the nesting depth of the struct or
+	// container currently being read.
+	limits Limits
+	depth  int
+
+	// If non-nil, this is the input being read. Strings and binary values
+	// are sub-slices of it rather than copies.
+	buf []byte
 }
 
 // NewReader builds a new Reader based on the given io.ReaderAt.
@@ -46,6 +66,38 @@ func NewReader(r io.ReaderAt) Reader {
 	return Reader{reader: r}
 }
 
+// NewReaderContext builds a new Reader based on the given io.ReaderAt which
+// stops reading and returns ctx.Err() once the given context is done.
+//
+// The context is checked periodically rather than for every value so that
+// the check does not dominate the cost of decoding. This includes values of
+// lazily decoded lists, sets, and maps read after ReadValue has returned.
+func NewReaderContext(ctx context.Context, r io.ReaderAt) Reader {
+	return Reader{reader: r, ctx: ctx}
+}
+
+// NewNoCopyReader builds a new Reader for the given byte slice. Strings and
+// binary values read by it reference the byte slice instead of being copied
+// out of it, so the byte slice must not be modified while they are in use.
+func NewNoCopyReader(b []byte) Reader {
+	return Reader{reader: bytes.NewReader(b), buf: b}
+}
+
+// checkContext returns the context's error if it is done. The context is
+// only consulted after every contextCheckInterval calls.
+func (br *Reader) checkContext() error {
+	if br.ctx == nil {
+		return nil
+	}
+
+	br.visited++
+	if br.visited < contextCheckInterval {
+		return nil
+	}
+	br.visited = 0
+	return br.ctx.Err()
+}
+
 // For the reader, we keep track of the read offset manually everywhere so
 // that we can implement lazy collections without extra allocations
 
@@ -112,13 +164,16 @@ func (br *Reader) skipMap(off int64) (int64, error) {
 	if count < 0 {
 		return off, decodeErrorf("negative length %d requested for map", count)
 	}
+	if err := br.checkLength(count); err != nil {
+		return off, err
+	}
 
 	kw := fixedWidth(kt)
 	vw := fixedWidth(vt)
 	if kw > 0 && vw > 0 {
 		// key and value are fixed width. calculate exact offset increase.
 		off += int64(count) * (kw + vw)
-		return off, err
+		return off, br.checkOffset(off)
 	}
 
 	for i := int32(0); i < count; i++ {
@@ -149,12 +204,15 @@ func (br *Reader) skipList(off int64) (int64, error) {
 	if count < 0 {
 		return off, decodeErrorf("negative length %d requested for collection", count)
 	}
+	if err := br.checkLength(count); err != nil {
+		return off, err
+	}
 
 	vw := fixedWidth(vt)
 	if vw > 0 {
 		// value is fixed width. can calculate new offset right away.
 		off += int64(count) * vw
-		return off, err
+		return off, br.checkOffset(off)
 	}
 
 	for i := int32(0); i < count; i++ {
@@ -167,6 +225,10 @@ func (br *Reader) skipList(off int64) (int64, error) {
 }
 
 func (br *Reader) skipValue(t wire.Type, off int64) (int64, error) {
+	if err := br.checkContext(); err != nil {
+		return off, err
+	}
+
 	if w := fixedWidth(t); w > 0 {
 		return off + w, nil
 	}
@@ -183,21 +245,35 @@ func (br *Reader) skipValue(t wire.Type, off int64) (int64, error) {
 			)
 		}
 		off += int64(length)
+		return off, br.checkOffset(off)
+	case wire.TStruct, wire.TMap, wire.TSet, wire.TList:
+		if err := br.enter(); err != nil {
+			return off, err
+		}
+		off, err := br.skipNested(t, off)
+		br.leave()
 		return off, err
+	default:
+		return off, decodeErrorf("unknown ttype %v", t)
+	}
+}
+
+// skipNested skips past a struct or container of the given type.
+func (br *Reader) skipNested(t wire.Type, off int64) (int64, error) {
+	switch t {
 	case wire.TStruct:
 		return br.skipStruct(off)
 	case wire.TMap:
 		return br.skipMap(off)
-	case wire.TSet:
-		return br.skipList(off)
-	case wire.TList:
-		return br.skipList(off)
 	default:
-		return off, decodeErrorf("unknown ttype %v", t)
+		return br.skipList(off)
 	}
 }
 
 func (br *Reader) read(bs []byte, off int64) (int64, error) {
+	if err := br.checkOffset(off + int64(len(bs))); err != nil {
+		return off, err
+	}
 	n, err := br.reader.ReadAt(bs, off)
 	off += int64(n)
 	if err == io.EOF {
@@ -209,6 +285,9 @@ func (br *Reader) read(bs []byte, off int64) (int64, error) {
 
 // copyN copies n bytes starting at offset off into the given Writer.
 func (br *Reader) copyN(w io.Writer, off int64, n int64) (int64, error) {
+	if err := br.checkOffset(off + n); err != nil {
+		return off, err
+	}
 	src := io.NewSectionReader(br.reader, off, n)
 	copied, err := io.CopyN(w, src, n)
 	off += copied
@@ -254,7 +333,23 @@ func (br *Reader) readBytes(off int64) ([]byte, int64, error) {
 		)
 	}
 	if length == 0 {
-		return nil, off, nil
+		// Return an empty slice rather than nil so that empty binary values
+		// survive a round trip. Generated code treats nil []byte fields as
+		// unset.
+		return []byte{}, off, nil
+	}
+	if err := br.checkOffset(off + int64(length)); err != nil {
+		return nil, off, err
+	}
+
+	if br.buf != nil {
+		end := off + int64(length)
+		if off > int64(len(br.buf)) || end > int64(len(br.buf)) {
+			return nil, int64(len(br.buf)), io.ErrUnexpectedEOF
+		}
+		// Limit the capacity so that appending to the value doesn't
+		// overwrite the rest of the input.
+		return br.buf[off:end:end], end, nil
 	}
 
 	// Use a dynamically resizing buffer for requests larger than
@@ -329,20 +424,31 @@ func (br *Reader) readMap(off int64) (wire.MapItemList, int64, error) {
 	if count < 0 {
 		return nil, off, decodeErrorf("negative length %d requested for map", count)
 	}
+	if err := br.checkLength(count); err != nil {
+		return nil, off, err
+	}
 
 	kt := wire.Type(ktByte)
 	vt := wire.Type(vtByte)
 
 	start := off
-	for i := int32(0); i < count; i++ {
-		off, err = br.skipValue(kt, off)
-		if err != nil {
+	if kw, vw := fixedWidth(kt), fixedWidth(vt); kw > 0 && vw > 0 {
+		// Don't visit each item if we can calculate the offset right away.
+		off += int64(count) * (kw + vw)
+		if err := br.checkOffset(off); err != nil {
 			return nil, off, err
 		}
-
-		off, err = br.skipValue(vt, off)
-		if err != nil {
-			return nil, off, err
+	} else {
+		for i := int32(0); i < count; i++ {
+			off, err = br.skipValue(kt, off)
+			if err != nil {
+				return nil, off, err
+			}
+
+			off, err = br.skipValue(vt, off)
+			if err != nil {
+				return nil, off, err
+			}
 		}
 	}
 
@@ -369,13 +475,24 @@ func (br *Reader) readSet(off int64) (wire.ValueList, int64, error) {
 	if count < 0 {
 		return nil, off, decodeErrorf("negative length %d requested for set", count)
 	}
+	if err := br.checkLength(count); err != nil {
+		return nil, off, err
+	}
 
 	start := off
-	for i := int32(0); i < count; i++ {
-		off, err = br.skipValue(wire.Type(typ), off)
-		if err != nil {
+	if w := fixedWidth(wire.Type(typ)); w > 0 {
+		// Don't visit each item if we can calculate the offset right away.
+		off += int64(count) * w
+		if err := br.checkOffset(off); err != nil {
 			return nil, off, err
 		}
+	} else {
+		for i := int32(0); i < count; i++ {
+			off, err = br.skipValue(wire.Type(typ), off)
+			if err != nil {
+				return nil, off, err
+			}
+		}
 	}
 
 	items := borrowLazyValueList()
@@ -400,13 +517,24 @@ func (br *Reader) readList(off int64) (wire.ValueList, int64, error) {
 	if count < 0 {
 		return nil, off, decodeErrorf("negative length %d requested for list", count)
 	}
+	if err := br.checkLength(count); err != nil {
+		return nil, off, err
+	}
 
 	start := off
-	for i := int32(0); i < count; i++ {
-		off, err = br.skipValue(wire.Type(typ), off)
-		if err != nil {
+	if w := fixedWidth(wire.Type(typ)); w > 0 {
+		// Don't visit each item if we can calculate the offset right away.
+		off += int64(count) * w
+		if err := br.checkOffset(off); err != nil {
 			return nil, off, err
 		}
+	} else {
+		for i := int32(0); i < count; i++ {
+			off, err = br.skipValue(wire.Type(typ), off)
+			if err != nil {
+				return nil, off, err
+			}
+		}
 	}
 
 	items := borrowLazyValueList()
@@ -423,6 +551,10 @@ func (br *Reader) readList(off int64) (wire.ValueList, int64, error) {
 //
 // Returns the Value, the new offset, and an error if there was a decode error.
 func (br *Reader) ReadValue(t wire.Type, off int64) (wire.Value, int64, error) {
+	if err := br.checkContext(); err != nil {
+		return wire.Value{}, off, err
+	}
+
 	switch t {
 	case wire.TBool:
 		b, off, err := br.readByte(off)
@@ -464,19 +596,35 @@ func (br *Reader) ReadValue(t wire.Type, off int64) (wire.Value, int64, error) {
 		return wire.NewValueBinary(v), off, err
 
 	case wire.TStruct:
+		if err := br.enter(); err != nil {
+			return wire.Value{}, off, err
+		}
 		s, off, err := br.readStruct(off)
+		br.leave()
 		return wire.NewValueStruct(s), off, err
 
 	case wire.TMap:
+		if err := br.enter(); err != nil {
+			return wire.Value{}, off, err
+		}
 		m, off, err := br.readMap(off)
+		br.leave()
 		return wire.NewValueMap(m), off, err
 
 	case wire.TSet:
+		if err := br.enter(); err != nil {
+			return wire.Value{}, off, err
+		}
 		s, off, err := br.readSet(off)
+		br.leave()
 		return wire.NewValueSet(s), off, err
 
 	case wire.TList:
+		if err := br.enter(); err != nil {
+			return wire.Value{}, off, err
+		}
 		l, off, err := br.readList(off)
+		br.leave()
 		return wire.NewValueList(l), off, err
 
 	default:
@@ -21,6 +21,7 @@
 package binary
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"math"
@@ -41,6 +42,10 @@ var writerPool = sync.Pool{New: func() interface{} {
 type Writer struct {
 	writer io.Writer
 
+	// Set if writer is a *bufio.Writer so that single bytes may be written
+	// to it without going through a slice.
+	buffered *bufio.Writer
+
 	// This buffer is re-used every time we need a slice of up to 8 bytes.
 	buffer [8]byte
 
@@ -57,25 +62,39 @@ type Writer struct {
 // BorrowWriter fetches a Writer from the system that will write its output to
 // the given io.Writer.
 //
+// The Writer makes a Write call for every part of a value it writes, so w
+// should be buffered, preferably with a *bufio.Writer, which is written to
+// more efficiently.
+//
 // This Writer must be returned back using ReturnWriter.
 func BorrowWriter(w io.Writer) *Writer {
 	writer := writerPool.Get().(*Writer)
 	writer.writer = w
+	writer.buffered, _ = w.(*bufio.Writer)
 	return writer
 }
 
 // ReturnWriter returns a previously borrowed Writer back to the system.
 func ReturnWriter(w *Writer) {
 	w.writer = nil
+	w.buffered = nil
 	writerPool.Put(w)
 }
 
 func (bw *Writer) write(bs []byte) error {
+	if bw.buffered != nil {
+		_, err := bw.buffered.Write(bs)
+		return err
+	}
 	_, err := bw.writer.Write(bs)
 	return err
 }
 
 func (bw *Writer) writeByte(b byte) error {
+	if bw.buffered != nil {
+		return bw.buffered.WriteByte(b)
+	}
+
 	bs := bw.buffer[0:1]
 	bs[0] = b
 	return bw.write(bs)
@@ -104,6 +123,10 @@ func (bw *Writer) writeString(s string) error {
 		return err
 	}
 
+	if bw.buffered != nil {
+		_, err := bw.buffered.WriteString(s)
+		return err
+	}
 	_, err := io.WriteString(bw.writer, s)
 	return err
 }
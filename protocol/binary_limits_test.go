@@ -0,0 +1,187 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protocol
+
+import (
+	"bytes"
+	"testing"
+
+	"go.uber.org/thriftrw/protocol/binary"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nestedLists returns a list of i32s nested in the given number of lists,
+// including the outermost one.
+func nestedLists(depth int) wire.Value {
+	v := vlist(wire.TI32, vi32(42))
+	for i := 1; i < depth; i++ {
+		v = vlist(wire.TList, v)
+	}
+	return v
+}
+
+func TestBinaryWithLimits(t *testing.T) {
+	encode := func(v wire.Value) []byte {
+		var buff bytes.Buffer
+		require.NoError(t, Binary.Encode(v, &buff))
+		return buff.Bytes()
+	}
+
+	tests := []struct {
+		desc   string
+		limits binary.Limits
+		data   []byte
+		typ    wire.Type
+
+		// Name of the exceeded limit, if decoding should fail.
+		wantLimit string
+	}{
+		{
+			desc:   "within limits",
+			limits: binary.Limits{MaxContainerLength: 5000, MaxDepth: 2, MaxBytes: 1 << 20},
+			data:   bigStruct(t, 5000),
+			typ:    wire.TStruct,
+		},
+		{
+			desc:   "declared list length",
+			limits: binary.Limits{MaxContainerLength: 1000},
+			// list<i64> claiming 2^31-1 items without any of them
+			data:      []byte{0x0a, 0x7f, 0xff, 0xff, 0xff},
+			typ:       wire.TList,
+			wantLimit: "MaxContainerLength",
+		},
+		{
+			desc:      "declared list size",
+			limits:    binary.Limits{MaxBytes: 1024},
+			data:      []byte{0x0a, 0x7f, 0xff, 0xff, 0xff},
+			typ:       wire.TList,
+			wantLimit: "MaxBytes",
+		},
+		{
+			desc:   "declared map length",
+			limits: binary.Limits{MaxContainerLength: 1000},
+			// map<i32, i32> claiming 2^31-1 items
+			data:      []byte{0x08, 0x08, 0x7f, 0xff, 0xff, 0xff},
+			typ:       wire.TMap,
+			wantLimit: "MaxContainerLength",
+		},
+		{
+			desc:   "declared length of nested list",
+			limits: binary.Limits{MaxContainerLength: 1000},
+			// list<list<i32>> with one list claiming 2^31-1 items
+			data:      []byte{0x0f, 0x00, 0x00, 0x00, 0x01, 0x08, 0x7f, 0xff, 0xff, 0xff},
+			typ:       wire.TList,
+			wantLimit: "MaxContainerLength",
+		},
+		{
+			desc:   "declared binary length",
+			limits: binary.Limits{MaxBytes: 1024},
+			// string claiming 2^31-1 bytes
+			data:      []byte{0x7f, 0xff, 0xff, 0xff},
+			typ:       wire.TBinary,
+			wantLimit: "MaxBytes",
+		},
+		{
+			desc:      "total size",
+			limits:    binary.Limits{MaxBytes: 1024},
+			data:      bigStruct(t, 1000),
+			typ:       wire.TStruct,
+			wantLimit: "MaxBytes",
+		},
+		{
+			desc:   "nesting depth",
+			limits: binary.Limits{MaxDepth: 10},
+			data:   encode(nestedLists(10)),
+			typ:    wire.TList,
+		},
+		{
+			desc:      "nesting depth exceeded",
+			limits:    binary.Limits{MaxDepth: 10},
+			data:      encode(nestedLists(11)),
+			typ:       wire.TList,
+			wantLimit: "MaxDepth",
+		},
+		{
+			desc:      "nested structs",
+			limits:    binary.Limits{MaxDepth: 2},
+			data:      encode(vstruct(vfield(1, vstruct(vfield(1, vstruct()))))),
+			typ:       wire.TStruct,
+			wantLimit: "MaxDepth",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			p := BinaryWithLimits(tt.limits)
+			decoders := map[string]func() (wire.Value, error){
+				"Decode": func() (wire.Value, error) {
+					return p.Decode(bytes.NewReader(tt.data), tt.typ)
+				},
+				"DecodeNoCopy": func() (wire.Value, error) {
+					return p.(NoCopyDecoder).DecodeNoCopy(tt.data, tt.typ)
+				},
+			}
+
+			for name, decode := range decoders {
+				v, err := decode()
+				if tt.wantLimit == "" {
+					require.NoError(t, err, name)
+					want, err := Binary.Decode(bytes.NewReader(tt.data), tt.typ)
+					require.NoError(t, err, name)
+					assert.True(t, wire.ValuesAreEqual(want, v), "%v: values must match", name)
+					continue
+				}
+
+				require.Error(t, err, name)
+				assert.True(t, binary.IsDecodeError(err), "%v: must be a decode error", name)
+				if limitErr, ok := err.(binary.LimitError); assert.True(t, ok, "%v: unexpected error %v", name, err) {
+					assert.Equal(t, tt.wantLimit, limitErr.Limit, name)
+				}
+			}
+		})
+	}
+}
+
+func TestBinaryWithoutLimits(t *testing.T) {
+	// Without limits, the declared length of a list is trusted.
+	v, err := Binary.Decode(bytes.NewReader([]byte{0x0a, 0x7f, 0xff, 0xff, 0xff}), wire.TList)
+	require.NoError(t, err)
+	assert.Equal(t, 2147483647, v.GetList().Size())
+}
+
+func TestBinaryWithLimitsEnveloped(t *testing.T) {
+	var buff bytes.Buffer
+	require.NoError(t, Binary.EncodeEnveloped(wire.Envelope{
+		Name:  "foo",
+		Type:  wire.Call,
+		Value: vstruct(vfield(1, nestedLists(3))),
+	}, &buff))
+
+	_, err := BinaryWithLimits(binary.Limits{MaxDepth: 4}).DecodeEnveloped(bytes.NewReader(buff.Bytes()))
+	assert.NoError(t, err)
+
+	_, err = BinaryWithLimits(binary.Limits{MaxDepth: 3}).DecodeEnveloped(bytes.NewReader(buff.Bytes()))
+	assert.Equal(t, binary.LimitError{Limit: "MaxDepth", Value: 4, Max: 3}, err)
+	assert.EqualError(t, err, "MaxDepth exceeded: 4 is larger than the limit of 3")
+}
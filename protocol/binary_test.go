@@ -285,6 +285,13 @@ func TestBinary(t *testing.T) {
 	checkEncodeDecode(t, wire.TBinary, tests)
 }
 
+func TestBinaryEmptyIsNotNil(t *testing.T) {
+	value, err := Binary.Decode(bytes.NewReader([]byte{0x00, 0x00, 0x00, 0x00}), wire.TBinary)
+	require.NoError(t, err, "failed to decode value")
+	assert.NotNil(t, value.GetBinary(), "empty binary must decode to a non-nil slice")
+	assert.Empty(t, value.GetBinary())
+}
+
 func TestBinaryLargeLength(t *testing.T) {
 	// 5 MB + 4 bytes for length
 	data := make([]byte, 5242880+4)
@@ -763,6 +770,24 @@ func TestBinaryEnvelopeSuccessful(t *testing.T) {
 				),
 			},
 		},
+		{
+			msg: "strict envelope, unknown type",
+			encoded: []byte{
+				0x80, 0x01, 0x00, 0x80, // version|type:4 = 1 | -128
+				0x00, 0x00, 0x00, 0x03, 'a', 'b', 'c', // name~4 = "abc"
+				0x00, 0x00, 0x15, 0x3c, // seqID:4 = 5436
+
+				// <struct>
+				0x00, // stop
+			},
+			want: wire.Envelope{
+				Name:  "abc",
+				Type:  wire.EnvelopeType(-128),
+				SeqID: 5436,
+				Value: vstruct(),
+			},
+			reencode: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,72 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protocol
+
+import (
+	"context"
+	"io"
+
+	"go.uber.org/thriftrw/wire"
+)
+
+// ContextDecoder is implemented by Protocols which can stop decoding a value
+// once a context is done.
+type ContextDecoder interface {
+	// DecodeContext is like Decode but fails with ctx.Err() if the context
+	// is done before the value has been decoded.
+	DecodeContext(ctx context.Context, r io.ReaderAt, t wire.Type) (wire.Value, error)
+
+	// DecodeEnvelopedContext is like DecodeEnveloped but fails with
+	// ctx.Err() if the context is done before the value has been decoded.
+	DecodeEnvelopedContext(ctx context.Context, r io.ReaderAt) (wire.Envelope, error)
+}
+
+// DecodeContext reads a Value of the given type from the given Reader using
+// the given Protocol. Decoding is aborted with ctx.Err() if the context is
+// done, so that a large payload does not keep consuming CPU after the caller
+// has given up on it.
+//
+// Lists, sets, and maps may be decoded lazily by the Protocol; reading them
+// after DecodeContext has returned continues to respect the context. If the
+// Protocol does not implement ContextDecoder, the context is checked only
+// before decoding.
+func DecodeContext(ctx context.Context, p Protocol, r io.ReaderAt, t wire.Type) (wire.Value, error) {
+	if d, ok := p.(ContextDecoder); ok {
+		return d.DecodeContext(ctx, r, t)
+	}
+	if err := ctx.Err(); err != nil {
+		return wire.Value{}, err
+	}
+	return p.Decode(r, t)
+}
+
+// DecodeEnvelopedContext reads an enveloped value from the given Reader
+// using the given Protocol, aborting with ctx.Err() if the context is done.
+// See DecodeContext for details.
+func DecodeEnvelopedContext(ctx context.Context, p Protocol, r io.ReaderAt) (wire.Envelope, error) {
+	if d, ok := p.(ContextDecoder); ok {
+		return d.DecodeEnvelopedContext(ctx, r)
+	}
+	if err := ctx.Err(); err != nil {
+		return wire.Envelope{}, err
+	}
+	return p.DecodeEnveloped(r)
+}
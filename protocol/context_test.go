@@ -0,0 +1,133 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protocol
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bigList(n int) []wire.Value {
+	items := make([]wire.Value, n)
+	for i := range items {
+		items[i] = wire.NewValueString("hello")
+	}
+	return items
+}
+
+// bigStruct returns an encoded struct with a list holding the given number
+// of strings.
+func bigStruct(t *testing.T, n int) []byte {
+	var buff bytes.Buffer
+	require.NoError(t, Binary.Encode(vstruct(wire.Field{ID: 1, Value: vlist(wire.TBinary, bigList(n)...)}), &buff))
+	return buff.Bytes()
+}
+
+func TestDecodeContext(t *testing.T) {
+	data := bigStruct(t, 5000)
+
+	v, err := DecodeContext(context.Background(), Binary, bytes.NewReader(data), wire.TStruct)
+	require.NoError(t, err)
+	want, err := Binary.Decode(bytes.NewReader(data), wire.TStruct)
+	require.NoError(t, err)
+	assert.True(t, wire.ValuesAreEqual(want, v), "values must match")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = DecodeContext(ctx, Binary, bytes.NewReader(data), wire.TStruct)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestDecodeContextLazyList(t *testing.T) {
+	data := bigStruct(t, 5000)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	v, err := DecodeContext(ctx, Binary, bytes.NewReader(data), wire.TStruct)
+	require.NoError(t, err)
+
+	cancel()
+	list := v.GetStruct().Fields[0].Value.GetList()
+	err = list.ForEach(func(wire.Value) error { return nil })
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestDecodeContextSmallValues(t *testing.T) {
+	// Values smaller than the check interval decode even if the context is
+	// already done.
+	data := bigStruct(t, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := DecodeContext(ctx, Binary, bytes.NewReader(data), wire.TStruct)
+	assert.NoError(t, err)
+}
+
+func TestDecodeEnvelopedContext(t *testing.T) {
+	var buff bytes.Buffer
+	require.NoError(t, Binary.EncodeEnveloped(wire.Envelope{
+		Name:  "foo",
+		Type:  wire.Call,
+		SeqID: 42,
+		Value: wire.NewValueStruct(wire.Struct{}),
+	}, &buff))
+	envelope := buff.Bytes()
+
+	e, err := DecodeEnvelopedContext(context.Background(), Binary, bytes.NewReader(envelope))
+	require.NoError(t, err)
+	assert.Equal(t, "foo", e.Name)
+	assert.Equal(t, int32(42), e.SeqID)
+
+	buff.Reset()
+	require.NoError(t, Binary.EncodeEnveloped(wire.Envelope{
+		Name:  "foo",
+		Type:  wire.Call,
+		Value: vstruct(wire.Field{ID: 1, Value: vlist(wire.TBinary, bigList(5000)...)}),
+	}, &buff))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = DecodeEnvelopedContext(ctx, Binary, bytes.NewReader(buff.Bytes()))
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestDecodeContextFallback(t *testing.T) {
+	// Protocols that don't implement ContextDecoder.
+	p := struct{ Protocol }{Binary}
+	data := bigStruct(t, 10)
+
+	_, err := DecodeContext(context.Background(), p, bytes.NewReader(data), wire.TStruct)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = DecodeContext(ctx, p, bytes.NewReader(data), wire.TStruct)
+	assert.Equal(t, context.Canceled, err)
+
+	_, err = DecodeEnvelopedContext(ctx, p, bytes.NewReader(data))
+	assert.Equal(t, context.Canceled, err)
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protocol
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Encoding identifies a Thrift protocol.
+type Encoding int
+
+// Encodings recognized by Detect.
+const (
+	// EncodingBinary is the Thrift Binary protocol with strict or
+	// non-strict envelopes.
+	EncodingBinary Encoding = iota + 1
+
+	// EncodingCompact is the Thrift Compact protocol.
+	EncodingCompact
+
+	// EncodingJSON is the Thrift JSON protocol.
+	EncodingJSON
+)
+
+func (e Encoding) String() string {
+	switch e {
+	case EncodingBinary:
+		return "binary"
+	case EncodingCompact:
+		return "compact"
+	case EncodingJSON:
+		return "json"
+	default:
+		return fmt.Sprintf("Encoding(%d)", int(e))
+	}
+}
+
+const (
+	_binaryVersion1 = 0x80 // first byte of a strict Binary envelope
+	_compactID      = 0x82 // first byte of a Compact envelope
+	_jsonStart      = '['  // first byte of a JSON envelope
+
+	// THeader frames start with their length and this magic number,
+	// followed by the flags, the sequence number, the size of the header,
+	// and the ID of the protocol of the payload.
+	_headerMagic0, _headerMagic1 = 0x0f, 0xff
+	_headerProtocolOffset        = 14
+	_headerProtocolBinary        = 0
+	_headerProtocolCompact       = 2
+
+	_binaryMinType = 1 // wire.Call
+	_binaryMaxType = 4 // wire.OneWay
+
+	// Non-strict Binary envelopes start with the length of the method
+	// name. Their names must be shorter than this to be detected.
+	_maxDetectNameLength = 4096
+
+	// Buffer large enough to inspect non-strict envelopes with the longest
+	// method names.
+	_detectBufferSize = _maxDetectNameLength + 16
+)
+
+// Detected describes the format of an enveloped message inspected by
+// Detect.
+type Detected struct {
+	// Framed is true if the message is preceded by its length as a 4-byte
+	// big-endian integer.
+	Framed bool
+
+	// Header is true if the message is a THeader frame. Such frames are
+	// always framed. Their contents should be decoded with theader.Decode
+	// and their payloads with Protocol.
+	Header bool
+
+	// Encoding of the message.
+	Encoding Encoding
+
+	// Protocol decodes messages of this Encoding.
+	Protocol Protocol
+}
+
+// UnsupportedEncodingError is returned by Detect if it recognizes the
+// encoding of a message but ThriftRW does not implement it.
+type UnsupportedEncodingError struct {
+	Encoding Encoding
+}
+
+func (e UnsupportedEncodingError) Error() string {
+	return fmt.Sprintf("messages encoded with the %v protocol are not supported", e.Encoding)
+}
+
+// Detect inspects the first bytes of the enveloped message in r to determine
+// whether it is framed and which Thrift protocol it is encoded with, so that
+// servers may accept clients with different protocol configurations.
+//
+// Detect returns a Reader which yields the full message, including the bytes
+// inspected by Detect, followed by the rest of r. If r is a *bufio.Reader
+// whose buffer is large enough to inspect messages with long names, it is
+// returned as-is.
+//
+// 	d, r, err := protocol.Detect(conn)
+// 	if err != nil {
+// 		return err
+// 	}
+// 	body, err := readMessage(r, d.Framed)
+// 	...
+// 	req, err := d.Protocol.DecodeEnveloped(bytes.NewReader(body))
+//
+// If the message uses a protocol that ThriftRW does not implement, Detect
+// returns an UnsupportedEncodingError along with a Detected that describes
+// the message.
+func Detect(r io.Reader) (Detected, io.Reader, error) {
+	// NewReaderSize returns r itself if it is a *bufio.Reader of at least
+	// this size. Smaller ones are wrapped since peeking past the end of
+	// their buffer returns bufio.ErrBufferFull.
+	br := bufio.NewReaderSize(r, _detectBufferSize)
+
+	d, err := detect(br)
+	if err != nil {
+		return d, br, err
+	}
+
+	if d.Encoding != EncodingBinary {
+		return d, br, UnsupportedEncodingError{Encoding: d.Encoding}
+	}
+	d.Protocol = Binary
+	return d, br, nil
+}
+
+func detect(r *bufio.Reader) (Detected, error) {
+	// Bytes are inspected a few at a time so that Detect does not block
+	// waiting for bytes beyond the end of a short message on connections
+	// where the client waits for a response.
+	b, err := r.Peek(2)
+	if len(b) < 2 {
+		return Detected{}, fmt.Errorf("could not read the start of the message: %v", err)
+	}
+	if e, ok := detectMagic(b); ok {
+		return Detected{Encoding: e}, nil
+	}
+
+	// The first four bytes may be the length of the frame.
+	b, err = r.Peek(6)
+	if len(b) < 6 {
+		return Detected{}, fmt.Errorf("could not read the start of the message: %v", err)
+	}
+	if e, ok := detectMagic(b[4:]); ok {
+		return Detected{Framed: true, Encoding: e}, nil
+	}
+	if b[4] == _headerMagic0 && b[5] == _headerMagic1 {
+		return detectHeader(r)
+	}
+
+	// All non-strict Binary envelopes are at least 10 bytes long.
+	b, err = r.Peek(8)
+	if len(b) < 8 {
+		return Detected{}, fmt.Errorf("could not read the start of the message: %v", err)
+	}
+
+	// Non-strict Binary envelopes start with the length of the method name,
+	// followed by the name and the message type. A framed envelope is at
+	// least 10 bytes longer than its method name, while the first bytes of
+	// the name of an unframed envelope are usually printable characters
+	// which make for a very long "name length".
+	size := int64(binary.BigEndian.Uint32(b))
+	nameLen := int64(binary.BigEndian.Uint32(b[4:]))
+	if nameLen <= _maxDetectNameLength && nameLen+10 <= size {
+		if isMessageType(r, 8+nameLen) {
+			return Detected{Framed: true, Encoding: EncodingBinary}, nil
+		}
+	} else if size <= _maxDetectNameLength && isMessageType(r, 4+size) {
+		return Detected{Encoding: EncodingBinary}, nil
+	}
+
+	return Detected{}, fmt.Errorf("unrecognized Thrift message starting with % x", b)
+}
+
+// detectHeader determines the encoding of the payload of the THeader frame
+// at the start of the buffered reader.
+func detectHeader(r *bufio.Reader) (Detected, error) {
+	b, err := r.Peek(_headerProtocolOffset + 1)
+	if len(b) <= _headerProtocolOffset {
+		return Detected{}, fmt.Errorf("could not read the THeader of the message: %v", err)
+	}
+
+	d := Detected{Framed: true, Header: true}
+	switch id := b[_headerProtocolOffset]; id {
+	case _headerProtocolBinary:
+		d.Encoding = EncodingBinary
+	case _headerProtocolCompact:
+		d.Encoding = EncodingCompact
+	default:
+		return Detected{}, fmt.Errorf("unrecognized THeader protocol ID %d", id)
+	}
+	return d, nil
+}
+
+// detectMagic checks whether the given bytes start with the version or
+// protocol identifier of an envelope.
+func detectMagic(b []byte) (Encoding, bool) {
+	switch {
+	case b[0] == _binaryVersion1 && b[1] == 0x01:
+		return EncodingBinary, true
+	case b[0] == _compactID && b[1]&0x1f == 0x01:
+		return EncodingCompact, true
+	case b[0] == _jsonStart:
+		return EncodingJSON, true
+	default:
+		return 0, false
+	}
+}
+
+// isMessageType checks whether the byte at the given offset of the buffered
+// reader is a valid message type of a non-strict Binary envelope.
+func isMessageType(r *bufio.Reader, off int64) bool {
+	b, _ := r.Peek(int(off) + 1)
+	if int64(len(b)) <= off {
+		return false
+	}
+	t := b[off]
+	return t >= _binaryMinType && t <= _binaryMaxType
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protocol
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func framed(b []byte) []byte {
+	out := make([]byte, 4, 4+len(b))
+	binary.BigEndian.PutUint32(out, uint32(len(b)))
+	return append(out, b...)
+}
+
+func TestDetect(t *testing.T) {
+	var strict bytes.Buffer
+	require.NoError(t, Binary.EncodeEnveloped(wire.Envelope{
+		Name:  "getUser",
+		Type:  wire.Call,
+		SeqID: 42,
+		Value: vstruct(vfield(1, vbinary("hello"))),
+	}, &strict))
+
+	nonStrict := []byte{
+		0x00, 0x00, 0x00, 0x05, 'w', 'r', 'i', 't', 'e', // name
+		0x04,                   // type = OneWay
+		0x00, 0x00, 0x00, 0x2a, // seqid = 42
+		0x00, // stop
+	}
+
+	nonStrictNoName := []byte{
+		0x00, 0x00, 0x00, 0x00, // name = ""
+		0x01,                   // type = Call
+		0x00, 0x00, 0x00, 0x01, // seqid = 1
+		0x00, // stop
+	}
+
+	compact := []byte{
+		0x82,      // protocol ID
+		0x21,      // type = Call, version = 1
+		0x01,      // seqid
+		0x01, 'a', // name
+		0x00, // stop
+	}
+
+	json := []byte(`[1,"getUser",1,42,{}]`)
+
+	header := func(protocolID byte, payload []byte) []byte {
+		return framed(append([]byte{
+			0x0f, 0xff, 0x00, 0x00, // magic, flags
+			0x00, 0x00, 0x00, 0x2a, // seqid = 42
+			0x00, 0x01, // header size
+			protocolID, 0x00, 0x00, 0x00, // protocol, transforms, padding
+		}, payload...))
+	}
+
+	tests := []struct {
+		desc       string
+		give       []byte
+		wantFramed bool
+		wantHeader bool
+		wantEnc    Encoding
+		wantErr    string
+	}{
+		{desc: "strict binary", give: strict.Bytes(), wantEnc: EncodingBinary},
+		{desc: "framed strict binary", give: framed(strict.Bytes()), wantFramed: true, wantEnc: EncodingBinary},
+		{desc: "non-strict binary", give: nonStrict, wantEnc: EncodingBinary},
+		{desc: "framed non-strict binary", give: framed(nonStrict), wantFramed: true, wantEnc: EncodingBinary},
+		{desc: "non-strict binary without name", give: nonStrictNoName, wantEnc: EncodingBinary},
+		{
+			desc:       "framed non-strict binary without name",
+			give:       framed(nonStrictNoName),
+			wantFramed: true,
+			wantEnc:    EncodingBinary,
+		},
+		{
+			desc:    "compact",
+			give:    compact,
+			wantEnc: EncodingCompact,
+			wantErr: "messages encoded with the compact protocol are not supported",
+		},
+		{
+			desc:       "framed compact",
+			give:       framed(compact),
+			wantFramed: true,
+			wantEnc:    EncodingCompact,
+			wantErr:    "messages encoded with the compact protocol are not supported",
+		},
+		{
+			desc:    "json",
+			give:    json,
+			wantEnc: EncodingJSON,
+			wantErr: "messages encoded with the json protocol are not supported",
+		},
+		{
+			desc:       "framed json",
+			give:       framed(json),
+			wantFramed: true,
+			wantEnc:    EncodingJSON,
+			wantErr:    "messages encoded with the json protocol are not supported",
+		},
+		{
+			desc:       "THeader binary",
+			give:       header(0x00, strict.Bytes()),
+			wantFramed: true,
+			wantHeader: true,
+			wantEnc:    EncodingBinary,
+		},
+		{
+			desc:       "THeader compact",
+			give:       header(0x02, compact),
+			wantFramed: true,
+			wantHeader: true,
+			wantEnc:    EncodingCompact,
+			wantErr:    "messages encoded with the compact protocol are not supported",
+		},
+		{
+			desc:    "THeader unknown protocol",
+			give:    header(0x05, nil),
+			wantErr: "unrecognized THeader protocol ID 5",
+		},
+		{
+			desc:    "THeader too short",
+			give:    []byte{0x00, 0x00, 0x00, 0x0a, 0x0f, 0xff, 0x00},
+			wantErr: "could not read the THeader of the message: EOF",
+		},
+		{
+			desc:    "HTTP",
+			give:    []byte("GET / HTTP/1.1\r\n\r\n"),
+			wantErr: "unrecognized Thrift message starting with 47 45 54 20 2f 20 48 54",
+		},
+		{
+			desc:    "empty",
+			give:    []byte{},
+			wantErr: "could not read the start of the message: EOF",
+		},
+		{
+			desc:    "too short",
+			give:    []byte{0x00, 0x00, 0x00},
+			wantErr: "could not read the start of the message: EOF",
+		},
+	}
+
+	for _, tt := range tests {
+		d, r, err := Detect(bytes.NewReader(tt.give))
+		if tt.wantErr != "" {
+			if assert.Error(t, err, tt.desc) {
+				assert.Contains(t, err.Error(), tt.wantErr, tt.desc)
+			}
+		} else {
+			assert.NoError(t, err, tt.desc)
+			assert.Equal(t, Binary, d.Protocol, tt.desc)
+		}
+		assert.Equal(t, tt.wantFramed, d.Framed, tt.desc)
+		assert.Equal(t, tt.wantHeader, d.Header, tt.desc)
+		assert.Equal(t, tt.wantEnc, d.Encoding, tt.desc)
+
+		// The returned reader replays the inspected bytes.
+		got, err := ioutil.ReadAll(r)
+		require.NoError(t, err, tt.desc)
+		assert.Equal(t, tt.give, got, tt.desc)
+	}
+}
+
+func TestDetectDecode(t *testing.T) {
+	want := wire.Envelope{
+		Name:  "getUser",
+		Type:  wire.Reply,
+		SeqID: 1,
+		Value: vstruct(vfield(0, vi16(100))),
+	}
+
+	var buff bytes.Buffer
+	require.NoError(t, Binary.EncodeEnveloped(want, &buff))
+
+	d, r, err := Detect(bytes.NewReader(framed(buff.Bytes())))
+	require.NoError(t, err)
+	require.True(t, d.Framed)
+
+	body, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+
+	got, err := d.Protocol.DecodeEnveloped(bytes.NewReader(body[4:]))
+	require.NoError(t, err)
+	assert.Equal(t, want.Name, got.Name)
+	assert.True(t, wire.ValuesAreEqual(want.Value, got.Value))
+}
+
+func TestDetectBufferedReader(t *testing.T) {
+	br := bufio.NewReaderSize(bytes.NewReader([]byte(`[1,"foo",1,1,{}]`)), _detectBufferSize)
+	_, r, _ := Detect(br)
+	assert.True(t, br == r, "large enough bufio.Readers must be used as-is")
+}
+
+func TestDetectSmallBufferedReader(t *testing.T) {
+	// A non-strict envelope whose name is too long to be peeked with the
+	// default buffer size of bufio.
+	name := bytes.Repeat([]byte("a"), _maxDetectNameLength)
+	msg := make([]byte, 4, 4+len(name)+6)
+	binary.BigEndian.PutUint32(msg, uint32(len(name)))
+	msg = append(msg, name...)
+	msg = append(msg,
+		0x01,                   // type = Call
+		0x00, 0x00, 0x00, 0x2a, // seqid = 42
+		0x00, // stop
+	)
+
+	for _, give := range [][]byte{msg, framed(msg)} {
+		br := bufio.NewReader(bytes.NewReader(give))
+		d, r, err := Detect(br)
+		require.NoError(t, err)
+		assert.Equal(t, EncodingBinary, d.Encoding)
+		assert.Equal(t, len(give) != len(msg), d.Framed)
+
+		got, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, give, got, "the returned reader must replay the inspected bytes")
+	}
+}
+
+func TestDetectDoesNotBlock(t *testing.T) {
+	// The client keeps the connection open after sending a short message.
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	go pw.Write([]byte{0x82, 0x21, 0x01, 0x00, 0x00})
+
+	done := make(chan Detected)
+	go func() {
+		d, _, _ := Detect(pr)
+		done <- d
+	}()
+
+	select {
+	case d := <-done:
+		assert.Equal(t, EncodingCompact, d.Encoding)
+	case <-time.After(time.Second):
+		t.Fatal("Detect blocked")
+	}
+}
+
+func TestEncodingString(t *testing.T) {
+	assert.Equal(t, "binary", EncodingBinary.String())
+	assert.Equal(t, "Encoding(42)", Encoding(42).String())
+}
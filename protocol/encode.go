@@ -0,0 +1,82 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protocol
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"sync"
+
+	"go.uber.org/thriftrw/wire"
+)
+
+// Buffers larger than this are not returned to the pool so that a single
+// large message doesn't pin a large amount of memory for the lifetime of the
+// process.
+const maxPooledBufferSize = 64 * 1024
+
+var bufferPool = sync.Pool{New: func() interface{} {
+	return new(bytes.Buffer)
+}}
+
+// EncodeTo encodes the given Value using the Thrift Binary Protocol and
+// writes the result to the given Writer with a single Write call.
+//
+// The Value is encoded into a pooled scratch buffer before being written, so
+// encoding many small messages does not allocate a new buffer for each
+// message and the Writer does not receive a Write call for every field.
+func EncodeTo(w io.Writer, v wire.Value) error {
+	buff := bufferPool.Get().(*bytes.Buffer)
+	defer returnBuffer(buff)
+
+	if err := Binary.Encode(v, buff); err != nil {
+		return err
+	}
+
+	_, err := w.Write(buff.Bytes())
+	return err
+}
+
+// EncodeStream encodes the given Value using the Thrift Binary Protocol and
+// writes it to the given bufio.Writer as it is encoded.
+//
+// Unlike EncodeTo, the encoded value is never held in memory in its entirety:
+// only the contents of the bufio.Writer's buffer are, which are flushed to
+// the underlying writer as it fills up. This keeps the peak memory of
+// encoding multi-megabyte values low. The caller must call Flush after the
+// value has been written.
+func EncodeStream(w *bufio.Writer, v wire.Value) error {
+	return Binary.Encode(v, w)
+}
+
+// EncodeEnvelopedStream is like EncodeStream for enveloped values.
+func EncodeEnvelopedStream(w *bufio.Writer, e wire.Envelope) error {
+	return Binary.EncodeEnveloped(e, w)
+}
+
+func returnBuffer(buff *bytes.Buffer) {
+	if buff.Cap() > maxPooledBufferSize {
+		return
+	}
+	buff.Reset()
+	bufferPool.Put(buff)
+}
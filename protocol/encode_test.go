@@ -0,0 +1,210 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protocol
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingWriter counts the number of Write calls made to it.
+type countingWriter struct {
+	bytes.Buffer
+
+	Writes int
+}
+
+func (w *countingWriter) Write(b []byte) (int, error) {
+	w.Writes++
+	return w.Buffer.Write(b)
+}
+
+type failingWriter struct{ err error }
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
+
+func smallStruct() wire.Value {
+	return wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+		{ID: 1, Value: wire.NewValueString("hello")},
+		{ID: 2, Value: wire.NewValueI32(42)},
+		{ID: 3, Value: wire.NewValueList(
+			wire.ValueListFromSlice(wire.TI64, []wire.Value{
+				wire.NewValueI64(1),
+				wire.NewValueI64(2),
+			}),
+		)},
+	}})
+}
+
+func TestEncodeTo(t *testing.T) {
+	v := smallStruct()
+
+	var want bytes.Buffer
+	require.NoError(t, Binary.Encode(v, &want))
+
+	// Run a few times to exercise reuse of pooled buffers.
+	for i := 0; i < 3; i++ {
+		var w countingWriter
+		require.NoError(t, EncodeTo(&w, v))
+		assert.Equal(t, want.Bytes(), w.Bytes())
+		assert.Equal(t, 1, w.Writes, "expected a single Write call")
+	}
+}
+
+func TestEncodeToWriteError(t *testing.T) {
+	err := EncodeTo(failingWriter{errors.New("great sadness")}, smallStruct())
+	assert.EqualError(t, err, "great sadness")
+}
+
+// largeStruct returns a struct which encodes to about n bytes.
+func largeStruct(n int) wire.Value {
+	chunk := make([]byte, 1024)
+	values := make([]wire.Value, n/len(chunk))
+	for i := range values {
+		values[i] = wire.NewValueBinary(chunk)
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+		{ID: 1, Value: wire.NewValueList(wire.ValueListFromSlice(wire.TBinary, values))},
+	}})
+}
+
+func TestEncodeStream(t *testing.T) {
+	tests := []struct {
+		desc string
+		give wire.Value
+	}{
+		{desc: "small", give: smallStruct()},
+		{desc: "large", give: largeStruct(64 * 1024)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var want bytes.Buffer
+			require.NoError(t, Binary.Encode(tt.give, &want))
+
+			var w countingWriter
+			bw := bufio.NewWriterSize(&w, 4096)
+			require.NoError(t, EncodeStream(bw, tt.give))
+			require.NoError(t, bw.Flush())
+			assert.Equal(t, want.Bytes(), w.Bytes())
+
+			// The value is written in chunks as it is encoded rather than
+			// all at once.
+			assert.True(t, w.Writes >= want.Len()/4096, "expected at least %d writes, got %d", want.Len()/4096, w.Writes)
+		})
+	}
+}
+
+func TestEncodeEnvelopedStream(t *testing.T) {
+	e := wire.Envelope{Name: "hello", Type: wire.Reply, SeqID: 42, Value: smallStruct()}
+
+	var want bytes.Buffer
+	require.NoError(t, Binary.EncodeEnveloped(e, &want))
+
+	var got bytes.Buffer
+	bw := bufio.NewWriter(&got)
+	require.NoError(t, EncodeEnvelopedStream(bw, e))
+	require.NoError(t, bw.Flush())
+	assert.Equal(t, want.Bytes(), got.Bytes())
+}
+
+func TestEncodeStreamWriteError(t *testing.T) {
+	bw := bufio.NewWriterSize(failingWriter{errors.New("great sadness")}, 16)
+	err := EncodeStream(bw, largeStruct(1024))
+	assert.EqualError(t, err, "failed to write field 1 (TList): great sadness")
+}
+
+func BenchmarkEncodeSmallStruct(b *testing.B) {
+	v := smallStruct()
+
+	b.Run("Encode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var buff bytes.Buffer
+			if err := Binary.Encode(v, &buff); err != nil {
+				b.Fatal(err)
+			}
+			if _, err := ioutil.Discard.Write(buff.Bytes()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("EncodeTo", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := EncodeTo(ioutil.Discard, v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("EncodeStream", func(b *testing.B) {
+		b.ReportAllocs()
+		bw := bufio.NewWriter(ioutil.Discard)
+		for i := 0; i < b.N; i++ {
+			if err := EncodeStream(bw, v); err != nil {
+				b.Fatal(err)
+			}
+			if err := bw.Flush(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkEncodeLargeStruct(b *testing.B) {
+	v := largeStruct(4 * 1024 * 1024)
+
+	// EncodeTo holds the entire encoded value in memory before writing it,
+	// while EncodeStream only holds the contents of the bufio.Writer.
+	b.Run("EncodeTo", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := EncodeTo(ioutil.Discard, v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("EncodeStream", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bw := bufio.NewWriterSize(ioutil.Discard, 32*1024)
+			if err := EncodeStream(bw, v); err != nil {
+				b.Fatal(err)
+			}
+			if err := bw.Flush(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
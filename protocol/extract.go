@@ -0,0 +1,79 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protocol
+
+import (
+	"fmt"
+	"io"
+
+	"go.uber.org/thriftrw/wire"
+)
+
+// FieldExtractor is implemented by Protocols which can decode a single
+// field of an encoded struct without decoding the rest of it.
+type FieldExtractor interface {
+	// Extract reads only the field at the given path of the struct
+	// encoded in the given reader. See Extract for details.
+	Extract(r io.ReaderAt, path ...int16) (wire.Value, bool, error)
+}
+
+// Extract decodes the field at the given path of a struct encoded with the
+// given Protocol. Each element of the path is the ID of a field of the
+// struct selected by the path so far. For example, the path (1, 3) selects
+// field 3 of the struct in field 1 of the encoded struct.
+//
+// This is useful for routers and proxies that need a single field, like a
+// routing key, out of a large payload. Protocols which implement
+// FieldExtractor skip over the other fields using the type and length
+// information of the encoding rather than decoding them. For other
+// Protocols, the whole struct is decoded.
+//
+// If a struct along the path does not have the requested field, the
+// returned boolean is false.
+func Extract(p Protocol, r io.ReaderAt, path ...int16) (wire.Value, bool, error) {
+	if e, ok := p.(FieldExtractor); ok {
+		return e.Extract(r, path...)
+	}
+
+	v, err := p.Decode(r, wire.TStruct)
+	if err != nil {
+		return wire.Value{}, false, err
+	}
+
+	for _, id := range path {
+		if v.Type() != wire.TStruct {
+			return wire.Value{}, false, fmt.Errorf(
+				"cannot extract field %d from a value of type %v", id, v.Type())
+		}
+
+		found := false
+		for _, f := range v.GetStruct().Fields {
+			if f.ID == id {
+				v, found = f.Value, true
+				break
+			}
+		}
+		if !found {
+			return wire.Value{}, false, nil
+		}
+	}
+	return v, true, nil
+}
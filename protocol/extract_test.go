@@ -0,0 +1,104 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protocol
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeOnly hides the FieldExtractor implementation of a Protocol.
+type decodeOnly struct{ Protocol }
+
+func TestExtract(t *testing.T) {
+	value := vstruct(
+		vfield(1, vlist(wire.TBinary, vbinary("foo"), vbinary("bar"))),
+		vfield(2, vmap(wire.TI32, wire.TBinary, vitem(vi32(1), vbinary("one")))),
+		vfield(3, vstruct(
+			vfield(1, vi64(42)),
+			vfield(2, vstruct(vfield(7, vbinary("routing-key")))),
+		)),
+		vfield(4, vi32(10)),
+		vfield(4, vi32(20)),
+	)
+	data := encodeValue(t, value)
+
+	tests := []struct {
+		desc string
+		path []int16
+
+		want      wire.Value
+		wantFound bool
+		wantErr   string
+	}{
+		{desc: "whole struct", want: value, wantFound: true},
+		{desc: "field after skipped fields", path: []int16{4}, want: vi32(10), wantFound: true},
+		{desc: "nested field", path: []int16{3, 2, 7}, want: vbinary("routing-key"), wantFound: true},
+		{desc: "nested struct", path: []int16{3, 1}, want: vi64(42), wantFound: true},
+		{desc: "missing field", path: []int16{5}},
+		{desc: "missing nested field", path: []int16{3, 2, 8}},
+		{
+			desc:    "field of non-struct",
+			path:    []int16{4, 1},
+			wantErr: "cannot extract field 1 from a value of type TI32",
+		},
+	}
+
+	protocols := map[string]Protocol{
+		"binary":  Binary,
+		"decoder": decodeOnly{Binary},
+	}
+
+	for name, p := range protocols {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.desc, func(t *testing.T) {
+				v, found, err := Extract(p, bytes.NewReader(data), tt.path...)
+				if tt.wantErr != "" {
+					require.Error(t, err)
+					assert.Contains(t, err.Error(), tt.wantErr)
+					return
+				}
+
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantFound, found)
+				if tt.wantFound {
+					assert.True(t, wire.ValuesAreEqual(tt.want, v), "expected %v, got %v", tt.want, v)
+				}
+			})
+		}
+	}
+}
+
+func TestExtractTruncated(t *testing.T) {
+	data := encodeValue(t, vstruct(
+		vfield(1, vbinary("hello")),
+		vfield(2, vi32(1)),
+	))
+
+	_, _, err := Extract(Binary, bytes.NewReader(data[:5]), 2)
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package protocol
+
+import (
+	"bytes"
+	"testing"
+
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run these with "go test -fuzz FuzzBinaryDecode ./protocol" to search for
+// inputs beyond the seed corpus. Fuzz targets for other protocols should be
+// added alongside these by calling fuzzDecode and fuzzDecodeEnveloped.
+
+// fuzzSeeds builds the seed corpus for the fuzz targets by encoding a few
+// values of every type with the given protocol.
+func fuzzSeeds(f *testing.F, p Protocol) [][]byte {
+	values := []wire.Value{
+		wire.NewValueStruct(wire.Struct{}),
+		wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+			{ID: 1, Value: wire.NewValueBool(true)},
+			{ID: 2, Value: wire.NewValueI8(-1)},
+			{ID: 3, Value: wire.NewValueI16(42)},
+			{ID: 4, Value: wire.NewValueI32(-1234)},
+			{ID: 5, Value: wire.NewValueI64(1 << 40)},
+			{ID: 6, Value: wire.NewValueDouble(3.14)},
+			{ID: 7, Value: wire.NewValueBinary([]byte("hello"))},
+		}}),
+		wire.NewValueStruct(wire.Struct{Fields: []wire.Field{
+			{ID: 1, Value: wire.NewValueList(wire.ValueListFromSlice(wire.TI32, []wire.Value{
+				wire.NewValueI32(1), wire.NewValueI32(2),
+			}))},
+			{ID: 2, Value: wire.NewValueSet(wire.ValueListFromSlice(wire.TBinary, []wire.Value{
+				wire.NewValueBinary([]byte("a")),
+			}))},
+			{ID: 3, Value: wire.NewValueMap(wire.MapItemListFromSlice(wire.TBinary, wire.TStruct, []wire.MapItem{
+				{
+					Key:   wire.NewValueBinary([]byte("k")),
+					Value: wire.NewValueStruct(wire.Struct{}),
+				},
+			}))},
+		}}),
+	}
+
+	seeds := make([][]byte, len(values))
+	for i, v := range values {
+		var buff bytes.Buffer
+		require.NoError(f, p.Encode(v, &buff))
+		seeds[i] = buff.Bytes()
+	}
+	return seeds
+}
+
+func FuzzBinaryDecode(f *testing.F) {
+	fuzzDecode(f, Binary)
+}
+
+func FuzzBinaryDecodeEnveloped(f *testing.F) {
+	fuzzDecodeEnveloped(f, Binary)
+}
+
+// fuzzDecode verifies that decoding arbitrary bytes with the given protocol
+// never panics, and that values which decode successfully are encoded
+// stably: encode(decode(encode(decode(x)))) == encode(decode(x)).
+func fuzzDecode(f *testing.F, p Protocol) {
+	for _, seed := range fuzzSeeds(f, p) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		v, err := p.Decode(bytes.NewReader(data), wire.TStruct)
+		if err != nil {
+			return
+		}
+
+		// Lists, sets, and maps may be decoded lazily. Evaluating them
+		// releases them so we decode the value again to use it.
+		if err := wire.EvaluateValue(v); err != nil {
+			return
+		}
+		v, err = p.Decode(bytes.NewReader(data), wire.TStruct)
+		require.NoError(t, err)
+
+		var first bytes.Buffer
+		require.NoError(t, p.Encode(v, &first), "failed to encode %v", v)
+
+		again, err := p.Decode(bytes.NewReader(first.Bytes()), wire.TStruct)
+		require.NoError(t, err, "failed to decode encoded %v", v)
+
+		var second bytes.Buffer
+		require.NoError(t, p.Encode(again, &second), "failed to encode %v", again)
+		assert.Equal(t, first.Bytes(), second.Bytes(), "encoding of %v is unstable", v)
+	})
+}
+
+// fuzzDecodeEnveloped is like fuzzDecode but for enveloped values.
+func fuzzDecodeEnveloped(f *testing.F, p Protocol) {
+	for i, seed := range fuzzSeeds(f, p) {
+		var buff bytes.Buffer
+		body, err := p.Decode(bytes.NewReader(seed), wire.TStruct)
+		require.NoError(f, err)
+		require.NoError(f, p.EncodeEnveloped(wire.Envelope{
+			Name:  "hello",
+			Type:  wire.Call,
+			SeqID: int32(i),
+			Value: body,
+		}, &buff))
+		f.Add(buff.Bytes())
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		e, err := p.DecodeEnveloped(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		if err := wire.EvaluateValue(e.Value); err != nil {
+			return
+		}
+		e, err = p.DecodeEnveloped(bytes.NewReader(data))
+		require.NoError(t, err)
+
+		var first bytes.Buffer
+		require.NoError(t, p.EncodeEnveloped(e, &first), "failed to encode %v", e)
+
+		again, err := p.DecodeEnveloped(bytes.NewReader(first.Bytes()))
+		require.NoError(t, err, "failed to decode encoded %v", e)
+		assert.Equal(t, e.Name, again.Name)
+		assert.Equal(t, e.Type, again.Type)
+		assert.Equal(t, e.SeqID, again.SeqID)
+
+		var second bytes.Buffer
+		require.NoError(t, p.EncodeEnveloped(again, &second), "failed to encode %v", again)
+		assert.Equal(t, first.Bytes(), second.Bytes(), "encoding of %v is unstable", e)
+	})
+}
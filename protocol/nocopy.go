@@ -0,0 +1,71 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protocol
+
+import (
+	"bytes"
+
+	"go.uber.org/thriftrw/wire"
+)
+
+// NoCopyDecoder is implemented by Protocols which can decode values without
+// copying strings and binary values out of the input.
+type NoCopyDecoder interface {
+	// DecodeNoCopy is like Decode but strings and binary values in the
+	// result reference the given byte slice.
+	DecodeNoCopy(b []byte, t wire.Type) (wire.Value, error)
+
+	// DecodeEnvelopedNoCopy is like DecodeEnveloped but strings and binary
+	// values in the result reference the given byte slice.
+	DecodeEnvelopedNoCopy(b []byte) (wire.Envelope, error)
+}
+
+// DecodeNoCopy reads a Value of the given type from the given byte slice
+// using the given Protocol without copying strings and binary values.
+//
+// This avoids an allocation and copy for every string and binary value,
+// which is useful for proxies that inspect a few fields of a request and
+// forward the rest.
+//
+// The byte slice is owned by the returned Value: strings and byte slices
+// read from the Value, including those held by types decoded from it with
+// FromWire, share memory with it. The caller MUST NOT modify or reuse the
+// byte slice for as long as any of them are in use. Copy values which need
+// to outlive the byte slice.
+//
+// If the Protocol does not implement NoCopyDecoder, the values are copied
+// as with Decode.
+func DecodeNoCopy(p Protocol, b []byte, t wire.Type) (wire.Value, error) {
+	if d, ok := p.(NoCopyDecoder); ok {
+		return d.DecodeNoCopy(b, t)
+	}
+	return p.Decode(bytes.NewReader(b), t)
+}
+
+// DecodeEnvelopedNoCopy reads an enveloped value from the given byte slice
+// using the given Protocol without copying strings and binary values. See
+// DecodeNoCopy for the ownership rules of the byte slice.
+func DecodeEnvelopedNoCopy(p Protocol, b []byte) (wire.Envelope, error) {
+	if d, ok := p.(NoCopyDecoder); ok {
+		return d.DecodeEnvelopedNoCopy(b)
+	}
+	return p.DecodeEnveloped(bytes.NewReader(b))
+}
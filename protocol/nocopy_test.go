@@ -0,0 +1,114 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protocol
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeValue(t *testing.T, v wire.Value) []byte {
+	var buff bytes.Buffer
+	require.NoError(t, Binary.Encode(v, &buff))
+	return buff.Bytes()
+}
+
+func TestDecodeNoCopy(t *testing.T) {
+	value := vstruct(
+		vfield(1, vbinary("hello")),
+		vfield(2, vlist(wire.TBinary, vbinary("foo"), vbinary("bar"))),
+		vfield(3, vbinary("")),
+	)
+	data := encodeValue(t, value)
+
+	v, err := DecodeNoCopy(Binary, data, wire.TStruct)
+	require.NoError(t, err)
+	assert.True(t, wire.ValuesAreEqual(value, v), "values must match")
+
+	fields := v.GetStruct().Fields
+	hello := fields[0].Value.GetBinary()
+	assert.Equal(t, len(hello), cap(hello), "capacity must be limited to the value")
+
+	var items []string
+	require.NoError(t, fields[1].Value.GetList().ForEach(func(v wire.Value) error {
+		items = append(items, v.GetString())
+		return nil
+	}))
+	assert.Equal(t, []string{"foo", "bar"}, items)
+
+	// The decoded values share memory with the input.
+	i := bytes.Index(data, []byte("hello"))
+	require.True(t, i >= 0)
+	data[i] = 'j'
+	str := fields[0].Value.GetString()
+	assert.Equal(t, "jello", str)
+}
+
+func TestDecodeNoCopyTruncated(t *testing.T) {
+	data := encodeValue(t, vstruct(vfield(1, vbinary("hello"))))
+
+	_, err := DecodeNoCopy(Binary, data[:len(data)-3], wire.TStruct)
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
+
+func TestDecodeEnvelopedNoCopy(t *testing.T) {
+	var buff bytes.Buffer
+	require.NoError(t, Binary.EncodeEnveloped(wire.Envelope{
+		Name:  "foo",
+		Type:  wire.Call,
+		SeqID: 42,
+		Value: vstruct(vfield(1, vbinary("hello"))),
+	}, &buff))
+
+	e, err := DecodeEnvelopedNoCopy(Binary, buff.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "foo", e.Name)
+	assert.Equal(t, int32(42), e.SeqID)
+	assert.Equal(t, "hello", e.Value.GetStruct().Fields[0].Value.GetString())
+}
+
+func TestDecodeNoCopyFallback(t *testing.T) {
+	// Protocols that don't implement NoCopyDecoder copy the values.
+	p := struct{ Protocol }{Binary}
+	data := encodeValue(t, vstruct(vfield(1, vbinary("hello"))))
+
+	v, err := DecodeNoCopy(p, data, wire.TStruct)
+	require.NoError(t, err)
+
+	data[bytes.Index(data, []byte("hello"))] = 'j'
+	assert.Equal(t, "hello", v.GetStruct().Fields[0].Value.GetString())
+
+	var buff bytes.Buffer
+	require.NoError(t, Binary.EncodeEnveloped(wire.Envelope{
+		Name:  "foo",
+		Type:  wire.Call,
+		Value: vstruct(),
+	}, &buff))
+	e, err := DecodeEnvelopedNoCopy(p, buff.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "foo", e.Name)
+}
@@ -0,0 +1,297 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protocol
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+
+	"go.uber.org/thriftrw/wire"
+)
+
+// Transform is a reversible transformation of encoded payloads, usually a
+// compression scheme.
+type Transform interface {
+	// Name of the transform for error messages, for example "zlib".
+	Name() string
+
+	// Apply transforms the given encoded payload.
+	Apply(b []byte) ([]byte, error)
+
+	// Reverse reverses Apply, returning the original payload. Use
+	// ReverseLimit for payloads from untrusted sources.
+	Reverse(b []byte) ([]byte, error)
+
+	// Detect returns true if the given bytes appear to be the output of
+	// Apply. It must return false for all payloads encoded with the
+	// Binary protocol.
+	Detect(b []byte) bool
+}
+
+// Zlib is a Transform which compresses payloads with zlib. It produces the
+// same output as the zlib transform of THeader.
+var Zlib Transform = zlibTransform{}
+
+// Gzip is a Transform which compresses payloads with gzip.
+var Gzip Transform = gzipTransform{}
+
+// Transforms which WithTransform protocols detect and reverse on decode, in
+// addition to the configured one.
+var _knownTransforms = []Transform{Zlib, Gzip}
+
+// DefaultMaxReversedSize is the maximum size in bytes of the payloads that
+// protocols returned by WithTransform produce when reversing a transform,
+// unless the wrapped Protocol limits the number of bytes it reads.
+//
+// A few kilobytes of compressed data may otherwise decompress to gigabytes
+// before any limit of the wrapped Protocol applies.
+const DefaultMaxReversedSize = 64 << 20
+
+// ReversedSizeError is returned when reversing a transform would produce a
+// payload larger than the allowed size.
+type ReversedSizeError struct {
+	// Transform is the name of the transform.
+	Transform string
+
+	// Max is the maximum size of the reversed payload in bytes.
+	Max int64
+}
+
+func (e ReversedSizeError) Error() string {
+	return fmt.Sprintf("%v payload is larger than the limit of %d bytes when reversed", e.Transform, e.Max)
+}
+
+// limitedReverser is implemented by Transforms which can stop reversing a
+// payload once it exceeds a given size.
+type limitedReverser interface {
+	reverseLimit(b []byte, max int64) ([]byte, error)
+}
+
+// ReverseLimit reverses the given Transform like Reverse but fails with a
+// ReversedSizeError if the result would be larger than max bytes.
+//
+// Zlib and Gzip stop decompressing as soon as the limit is exceeded. Other
+// Transforms are reversed fully before the size of the result is checked.
+func ReverseLimit(t Transform, b []byte, max int64) ([]byte, error) {
+	if lr, ok := t.(limitedReverser); ok {
+		return lr.reverseLimit(b, max)
+	}
+
+	out, err := t.Reverse(b)
+	if err == nil && int64(len(out)) > max {
+		return nil, ReversedSizeError{Transform: t.Name(), Max: max}
+	}
+	return out, err
+}
+
+// readLimit reads up to max bytes from the given reader, failing with a
+// ReversedSizeError if it has more.
+func readLimit(name string, r io.Reader, max int64) ([]byte, error) {
+	n := max
+	if n < math.MaxInt64 {
+		n++
+	}
+
+	b, err := ioutil.ReadAll(io.LimitReader(r, n))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) > max {
+		return nil, ReversedSizeError{Transform: name, Max: max}
+	}
+	return b, nil
+}
+
+// WithTransform returns a Protocol which encodes payloads with the given
+// Protocol and applies the Transform to them. This is helpful for large
+// payloads whose transfer time dominates their encoding time.
+//
+// 	p := protocol.WithTransform(protocol.Binary, protocol.Zlib)
+//
+// When decoding, payloads produced by the Transform, or by Zlib or Gzip, are
+// detected and reversed before being decoded. Other payloads are decoded
+// as-is so that peers which do not compress their payloads are understood.
+//
+// Reversed payloads may be at most as large as the MaxBytes limit of a
+// Protocol returned by BinaryWithLimits, or DefaultMaxReversedSize for
+// other Protocols. Use WithTransformLimit to change this.
+func WithTransform(p Protocol, t Transform) Protocol {
+	return WithTransformLimit(p, t, 0)
+}
+
+// WithTransformLimit is like WithTransform but fails to decode payloads
+// which are larger than max bytes once their transform is reversed. The
+// default limit of WithTransform is used if max is not positive.
+func WithTransformLimit(p Protocol, t Transform, max int64) Protocol {
+	if max <= 0 {
+		max = DefaultMaxReversedSize
+		if bp, ok := p.(binaryProtocol); ok && bp.limits.MaxBytes > 0 {
+			max = bp.limits.MaxBytes
+		}
+	}
+	return transformProtocol{p: p, t: t, max: max}
+}
+
+type transformProtocol struct {
+	p   Protocol
+	t   Transform
+	max int64
+}
+
+func (tp transformProtocol) Encode(v wire.Value, w io.Writer) error {
+	var buff bytes.Buffer
+	if err := tp.p.Encode(v, &buff); err != nil {
+		return err
+	}
+	return tp.write(buff.Bytes(), w)
+}
+
+func (tp transformProtocol) EncodeEnveloped(e wire.Envelope, w io.Writer) error {
+	var buff bytes.Buffer
+	if err := tp.p.EncodeEnveloped(e, &buff); err != nil {
+		return err
+	}
+	return tp.write(buff.Bytes(), w)
+}
+
+func (tp transformProtocol) Decode(r io.ReaderAt, t wire.Type) (wire.Value, error) {
+	b, err := tp.read(r)
+	if err != nil {
+		return wire.Value{}, err
+	}
+	return tp.p.Decode(bytes.NewReader(b), t)
+}
+
+func (tp transformProtocol) DecodeEnveloped(r io.ReaderAt) (wire.Envelope, error) {
+	b, err := tp.read(r)
+	if err != nil {
+		return wire.Envelope{}, err
+	}
+	return tp.p.DecodeEnveloped(bytes.NewReader(b))
+}
+
+func (tp transformProtocol) write(b []byte, w io.Writer) error {
+	b, err := tp.t.Apply(b)
+	if err != nil {
+		return fmt.Errorf("could not apply %v transform: %v", tp.t.Name(), err)
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// read reads the full payload from the given reader and reverses the
+// transform that was applied to it, if any.
+func (tp transformProtocol) read(r io.ReaderAt) ([]byte, error) {
+	b, err := ioutil.ReadAll(io.NewSectionReader(r, 0, math.MaxInt64))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range append([]Transform{tp.t}, _knownTransforms...) {
+		if !t.Detect(b) {
+			continue
+		}
+		b, err = ReverseLimit(t, b, tp.max)
+		if err != nil {
+			if _, ok := err.(ReversedSizeError); ok {
+				return nil, err
+			}
+			return nil, fmt.Errorf("could not reverse %v transform: %v", t.Name(), err)
+		}
+		return b, nil
+	}
+	return b, nil
+}
+
+type zlibTransform struct{}
+
+func (zlibTransform) Name() string { return "zlib" }
+
+func (zlibTransform) Apply(b []byte) ([]byte, error) {
+	var buff bytes.Buffer
+	w := zlib.NewWriter(&buff)
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
+
+func (t zlibTransform) Reverse(b []byte) ([]byte, error) {
+	return t.reverseLimit(b, math.MaxInt64)
+}
+
+func (t zlibTransform) reverseLimit(b []byte, max int64) ([]byte, error) {
+	r, err := zlib.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return readLimit(t.Name(), r, max)
+}
+
+// Detect checks for a zlib header with a 32K window, which is what zlib
+// writers produce by default. 0x78 is neither a valid type of the first
+// field of a struct nor the start of an envelope in the Binary protocol.
+func (zlibTransform) Detect(b []byte) bool {
+	return len(b) >= 2 && b[0] == 0x78 && (uint16(b[0])<<8|uint16(b[1]))%31 == 0
+}
+
+type gzipTransform struct{}
+
+func (gzipTransform) Name() string { return "gzip" }
+
+func (gzipTransform) Apply(b []byte) ([]byte, error) {
+	var buff bytes.Buffer
+	w := gzip.NewWriter(&buff)
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
+
+func (t gzipTransform) Reverse(b []byte) ([]byte, error) {
+	return t.reverseLimit(b, math.MaxInt64)
+}
+
+func (t gzipTransform) reverseLimit(b []byte, max int64) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return readLimit(t.Name(), r, max)
+}
+
+// Detect checks for the gzip magic number. 0x1f is not a valid Thrift type.
+func (gzipTransform) Detect(b []byte) bool {
+	return len(b) >= 2 && b[0] == 0x1f && b[1] == 0x8b
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package protocol
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"testing"
+
+	"go.uber.org/thriftrw/protocol/binary"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTransform(t *testing.T) {
+	value := vstruct(vfield(1, vbinary(string(bytes.Repeat([]byte("hello "), 100)))))
+	envelope := wire.Envelope{Name: "getUser", Type: wire.Call, SeqID: 1, Value: value}
+
+	var plain bytes.Buffer
+	require.NoError(t, Binary.Encode(value, &plain))
+
+	for _, tr := range []Transform{Zlib, Gzip} {
+		p := WithTransform(Binary, tr)
+
+		var buff bytes.Buffer
+		require.NoError(t, p.Encode(value, &buff), tr.Name())
+		assert.True(t, buff.Len() < plain.Len(), "%v: payload must be compressed", tr.Name())
+		assert.True(t, tr.Detect(buff.Bytes()), tr.Name())
+
+		got, err := p.Decode(bytes.NewReader(buff.Bytes()), wire.TStruct)
+		require.NoError(t, err, tr.Name())
+		assert.True(t, wire.ValuesAreEqual(value, got), tr.Name())
+
+		buff.Reset()
+		require.NoError(t, p.EncodeEnveloped(envelope, &buff), tr.Name())
+		env, err := p.DecodeEnveloped(bytes.NewReader(buff.Bytes()))
+		require.NoError(t, err, tr.Name())
+		assert.Equal(t, envelope.Name, env.Name, tr.Name())
+		assert.True(t, wire.ValuesAreEqual(value, env.Value), tr.Name())
+	}
+}
+
+func TestWithTransformDetects(t *testing.T) {
+	value := vstruct(vfield(1, vi32(42)), vfield(2, vbinary("foo")))
+
+	var plain bytes.Buffer
+	require.NoError(t, Binary.Encode(value, &plain))
+	gzipped, err := Gzip.Apply(plain.Bytes())
+	require.NoError(t, err)
+
+	assert.False(t, Zlib.Detect(plain.Bytes()))
+	assert.False(t, Gzip.Detect(plain.Bytes()))
+
+	// Payloads compressed with other known transforms and uncompressed
+	// payloads are decoded too.
+	p := WithTransform(Binary, Zlib)
+	for _, give := range [][]byte{plain.Bytes(), gzipped} {
+		got, err := p.Decode(bytes.NewReader(give), wire.TStruct)
+		require.NoError(t, err)
+		assert.True(t, wire.ValuesAreEqual(value, got))
+	}
+}
+
+func TestWithTransformErrors(t *testing.T) {
+	p := WithTransform(Binary, Zlib)
+
+	_, err := p.Decode(bytes.NewReader([]byte{0x78, 0x9c, 0x00}), wire.TStruct)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "could not reverse zlib transform")
+	}
+
+	p = WithTransform(Binary, failingTransform{})
+	err = p.Encode(vstruct(), new(bytes.Buffer))
+	assert.EqualError(t, err, "could not apply failing transform: great sadness")
+}
+
+type failingTransform struct{}
+
+func (failingTransform) Name() string                     { return "failing" }
+func (failingTransform) Apply(b []byte) ([]byte, error)   { return nil, errors.New("great sadness") }
+func (failingTransform) Reverse(b []byte) ([]byte, error) { return b, nil }
+func (failingTransform) Detect(b []byte) bool             { return false }
+
+// compressionBomb returns the given Transform applied to size zero bytes
+// without holding all of them in memory.
+func compressionBomb(t *testing.T, tr Transform, size int64) []byte {
+	var buff bytes.Buffer
+	var w io.WriteCloser
+	switch tr {
+	case Zlib:
+		w = zlib.NewWriter(&buff)
+	case Gzip:
+		w = gzip.NewWriter(&buff)
+	default:
+		t.Fatalf("unknown transform %v", tr.Name())
+	}
+
+	_, err := io.CopyN(w, zeros{}, size)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buff.Bytes()
+}
+
+type zeros struct{}
+
+func (zeros) Read(b []byte) (int, error) {
+	for i := range b {
+		b[i] = 0
+	}
+	return len(b), nil
+}
+
+func TestWithTransformLimit(t *testing.T) {
+	for _, tr := range []Transform{Zlib, Gzip} {
+		bomb := compressionBomb(t, tr, 1<<20)
+		assert.True(t, len(bomb) < 1<<12, "%v: bomb must be small", tr.Name())
+
+		for _, p := range []Protocol{
+			WithTransformLimit(Binary, tr, 1024),
+			WithTransform(BinaryWithLimits(binary.Limits{MaxBytes: 1024}), tr),
+		} {
+			_, err := p.Decode(bytes.NewReader(bomb), wire.TStruct)
+			assert.Equal(t, ReversedSizeError{Transform: tr.Name(), Max: 1024}, err, tr.Name())
+
+			_, err = p.DecodeEnveloped(bytes.NewReader(bomb))
+			assert.Equal(t, ReversedSizeError{Transform: tr.Name(), Max: 1024}, err, tr.Name())
+		}
+
+		// Payloads of exactly the limit are reversed.
+		b, err := ReverseLimit(tr, compressionBomb(t, tr, 1024), 1024)
+		require.NoError(t, err, tr.Name())
+		assert.Len(t, b, 1024, tr.Name())
+	}
+}
+
+func TestWithTransformDefaultLimit(t *testing.T) {
+	bomb := compressionBomb(t, Zlib, DefaultMaxReversedSize+1)
+	_, err := WithTransform(Binary, Zlib).Decode(bytes.NewReader(bomb), wire.TStruct)
+	assert.Equal(t, ReversedSizeError{Transform: "zlib", Max: DefaultMaxReversedSize}, err)
+	assert.EqualError(t, err, "zlib payload is larger than the limit of 67108864 bytes when reversed")
+}
+
+func TestReverseLimitCustomTransform(t *testing.T) {
+	_, err := ReverseLimit(failingTransform{}, []byte("hello"), 4)
+	assert.Equal(t, ReversedSizeError{Transform: "failing", Max: 4}, err)
+
+	b, err := ReverseLimit(failingTransform{}, []byte("hello"), 5)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello"), b)
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"go.uber.org/thriftrw/internal/frame"
+	"go.uber.org/thriftrw/protocol"
+
+	"github.com/jessevdk/go-flags"
+)
+
+type replayCapOptions struct {
+	Dump bool `long:"dump" description:"Print a hex dump of every frame."`
+}
+
+// doReplayCap implements the "thriftrw replaycap" command.
+func doReplayCap(args []string) error {
+	var opts replayCapOptions
+
+	parser := flags.NewParser(&opts, flags.Default)
+	parser.Name = "thriftrw replaycap"
+	parser.Usage = "[OPTIONS] FILE\n\n" +
+		"Prints the frames recorded in a capture file written with --plugin-capture,\n" +
+		"one per line, along with the Thrift envelope each frame contains."
+
+	files, err := parser.ParseArgs(args)
+	if err != nil {
+		return nil // message already printed by go-flags
+	}
+
+	if len(files) != 1 {
+		var buffer bytes.Buffer
+		parser.WriteHelp(&buffer)
+		return errors.New(buffer.String())
+	}
+
+	f, err := os.Open(files[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := replayCapture(os.Stdout, f, &opts); err != nil {
+		return fmt.Errorf("Failed to read capture file %q: %v", files[0], err)
+	}
+	return nil
+}
+
+// openCapture creates a capture file at the given path. The file is closed
+// when the returned CaptureWriter is closed.
+func openCapture(path string) (*frame.CaptureWriter, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := frame.NewCaptureWriter(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// replayCapture writes a description of every record in the capture file to
+// w.
+func replayCapture(w io.Writer, r io.Reader, opts *replayCapOptions) error {
+	cr, err := frame.NewCaptureReader(r)
+	if err != nil {
+		return err
+	}
+
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "%v %v %v %d bytes: %v\n",
+			rec.Time.UTC().Format(time.RFC3339Nano), rec.Stream, rec.Direction,
+			len(rec.Frame), describeEnvelope(rec.Frame))
+		if opts.Dump {
+			fmt.Fprint(w, hex.Dump(rec.Frame))
+		}
+	}
+}
+
+// describeEnvelope describes the Thrift envelope in the given frame.
+func describeEnvelope(b []byte) string {
+	e, err := protocol.Binary.DecodeEnveloped(bytes.NewReader(b))
+	if err != nil {
+		return fmt.Sprintf("not an envelope: %v", err)
+	}
+	return fmt.Sprintf("%v %q (seqID %d)", e.Type, e.Name, e.SeqID)
+}
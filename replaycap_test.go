@@ -0,0 +1,99 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/thriftrw/internal/frame"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayCapture(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thriftrw-replaycap-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var envelope bytes.Buffer
+	require.NoError(t, protocol.Binary.EncodeEnveloped(wire.Envelope{
+		Name:  "hello",
+		Type:  wire.Call,
+		SeqID: 42,
+		Value: wire.NewValueStruct(wire.Struct{}),
+	}, &envelope))
+
+	path := filepath.Join(dir, "plugin.cap")
+	w, err := openCapture(path)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(frame.CaptureRecord{
+		Time:      time.Date(2017, 5, 1, 12, 0, 0, 500, time.UTC),
+		Stream:    "foo",
+		Direction: frame.Sent,
+		Frame:     envelope.Bytes(),
+	}))
+	require.NoError(t, w.Write(frame.CaptureRecord{
+		Time:      time.Date(2017, 5, 1, 12, 0, 1, 0, time.UTC),
+		Stream:    "foo",
+		Direction: frame.Received,
+		Frame:     []byte{0x01},
+	}))
+	require.NoError(t, w.Close())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var out bytes.Buffer
+	require.NoError(t, replayCapture(&out, f, &replayCapOptions{}))
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	assert.Equal(t,
+		`2017-05-01T12:00:00.0000005Z foo sent 18 bytes: Call "hello" (seqID 42)`,
+		string(lines[0]))
+	assert.Contains(t, string(lines[1]),
+		"2017-05-01T12:00:01Z foo received 1 bytes: not an envelope")
+}
+
+func TestReplayCaptureDump(t *testing.T) {
+	var buff bytes.Buffer
+	w, err := frame.NewCaptureWriter(&buff)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(frame.CaptureRecord{Direction: frame.Sent, Frame: []byte("hello")}))
+
+	var out bytes.Buffer
+	require.NoError(t, replayCapture(&out, &buff, &replayCapOptions{Dump: true}))
+	assert.Contains(t, out.String(), "68 65 6c 6c 6f")
+}
+
+func TestReplayCaptureNotACapture(t *testing.T) {
+	err := replayCapture(ioutil.Discard, bytes.NewReader([]byte("foo")), &replayCapOptions{})
+	assert.EqualError(t, err, "not a thriftrw capture file")
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package retry reports whether requests that failed with Thrift exceptions
+// may be retried safely.
+//
+// Exceptions annotated with safety implement Safer.
+//
+// 	exception UserNotFound {
+// 		1: required string userID
+// 	} (safety = "safe")
+//
+// 	exception WriteConflict {
+// 		1: required string key
+// 	} (safety = "unsafe")
+//
+// A safe exception indicates that the request failed without side effects
+// and may be retried. An unsafe exception indicates that the request may
+// have been partially applied. Client middleware may use this to decide
+// whether to retry a request without knowing about specific exception
+// types.
+//
+// 	if retry.IsSafe(err) {
+// 		// retry the request
+// 	}
+//
+// Generated service helpers also provide an IsSafeException function which
+// reports whether an error is one of the safe exceptions declared by that
+// function.
+package retry
+
+// Safer is implemented by exceptions annotated with safety.
+type Safer interface {
+	error
+
+	// Safe returns true if the exception was annotated as safe and false if
+	// it was annotated as unsafe.
+	Safe() bool
+}
+
+// Safety returns whether the given error is safe to retry. False is returned
+// for ok if the error does not specify its safety.
+func Safety(err error) (safe bool, ok bool) {
+	if e, ok := err.(Safer); ok {
+		return e.Safe(), true
+	}
+	return false, false
+}
+
+// IsSafe returns true if the given error is an exception annotated as safe.
+// Errors that do not specify their safety are not considered safe.
+func IsSafe(err error) bool {
+	safe, _ := Safety(err)
+	return safe
+}
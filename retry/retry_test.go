@@ -0,0 +1,54 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package retry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type safeError bool
+
+func (e safeError) Error() string { return "great sadness" }
+func (e safeError) Safe() bool    { return bool(e) }
+
+func TestSafety(t *testing.T) {
+	tests := []struct {
+		desc     string
+		err      error
+		wantSafe bool
+		wantOK   bool
+	}{
+		{desc: "nil", err: nil},
+		{desc: "plain error", err: errors.New("great sadness")},
+		{desc: "safe", err: safeError(true), wantSafe: true, wantOK: true},
+		{desc: "unsafe", err: safeError(false), wantOK: true},
+	}
+
+	for _, tt := range tests {
+		safe, ok := Safety(tt.err)
+		assert.Equal(t, tt.wantSafe, safe, tt.desc)
+		assert.Equal(t, tt.wantOK, ok, tt.desc)
+		assert.Equal(t, tt.wantSafe, IsSafe(tt.err), tt.desc)
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/gen"
+	"go.uber.org/thriftrw/internal/apidiff"
+	"go.uber.org/thriftrw/internal/thriftfmt"
+
+	"github.com/jessevdk/go-flags"
+)
+
+// Maximum size of the IDL accepted by "thriftrw serve-ui".
+const serveUIMaxIDLBytes = 1 << 20
+
+// Name of the Thrift file that pasted IDL is validated as. It is also used to
+// refer to the IDL in messages reported back to the user.
+const serveUIThriftFile = "main.thrift"
+
+// Import path prefix for code generated by "thriftrw serve-ui". The code is
+// never compiled.
+const serveUIPackagePrefix = "thriftrw.serveui"
+
+type serveUIOptions struct {
+	Addr string `long:"addr" value-name:"ADDR" default:"localhost:8080" description:"Address on which the web UI is served."`
+}
+
+// doServeUI implements the "thriftrw serve-ui" command.
+func doServeUI(args []string) error {
+	var opts serveUIOptions
+
+	parser := flags.NewParser(&opts, flags.Default)
+	parser.Name = "thriftrw serve-ui"
+	parser.Usage = "[OPTIONS]\n\n" +
+		"Serves a local web page on which Thrift IDL may be pasted to see compile\n" +
+		"errors, lint results, and a summary of the Go API that would be generated\n" +
+		"for it."
+
+	rest, err := parser.ParseArgs(args)
+	if err != nil {
+		return nil // message already printed by go-flags
+	}
+
+	if len(rest) != 0 {
+		var buffer bytes.Buffer
+		parser.WriteHelp(&buffer)
+		return errors.New(buffer.String())
+	}
+
+	log.Printf("Serving on http://%v", opts.Addr)
+	return http.ListenAndServe(opts.Addr, newServeUIHandler())
+}
+
+// newServeUIHandler builds the http.Handler for "thriftrw serve-ui".
+//
+// 	GET /          the web page
+// 	POST /validate validates the IDL in the request body and responds with
+// 	               a JSON-encoded idlValidation
+func newServeUIHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, serveUIPage)
+	})
+	mux.HandleFunc("/validate", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			w.Header().Set("Allow", "POST")
+			http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+
+		src, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, serveUIMaxIDLBytes))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("could not read IDL: %v", err), http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		result, err := validateIDL(src)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	})
+	return mux
+}
+
+// idlValidation is the result of validating a Thrift file.
+type idlValidation struct {
+	// Problems that prevent code from being generated for the file.
+	Errors []string `json:"errors"`
+
+	// Problems that do not prevent code from being generated, such as
+	// deprecated syntax or formatting differences.
+	Lint []string `json:"lint"`
+
+	// Exported identifiers of the generated Go code along with their
+	// signatures, in sorted order. This is empty if there were errors.
+	API []string `json:"api"`
+}
+
+// validateIDL compiles the given Thrift IDL and generates code for it,
+// reporting any problems along with a summary of the generated API.
+//
+// An error is returned only if the IDL could not be validated at all.
+// Problems with the IDL itself are reported in the idlValidation.
+func validateIDL(src []byte) (*idlValidation, error) {
+	result := idlValidation{Errors: []string{}, Lint: []string{}, API: []string{}}
+
+	dir, err := ioutil.TempDir("", "thriftrw-serve-ui")
+	if err != nil {
+		return nil, fmt.Errorf("could not create a temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Messages refer to the Thrift file by its absolute path. Strip the
+	// temporary directory from them.
+	clean := func(msg string) string {
+		return strings.Replace(msg, dir+string(filepath.Separator), "", -1)
+	}
+
+	file := filepath.Join(dir, serveUIThriftFile)
+	if err := ioutil.WriteFile(file, src, 0644); err != nil {
+		return nil, fmt.Errorf("could not write IDL: %v", err)
+	}
+
+	if formatted, err := thriftfmt.Format(src); err == nil && !bytes.Equal(formatted, src) {
+		result.Lint = append(result.Lint,
+			serveUIThriftFile+" is not formatted; use \"thriftrw fmt\" to format it")
+	}
+
+	module, err := compile.Compile(file, compile.Warnings(func(w compile.Warning) {
+		result.Lint = append(result.Lint, clean(w.String()))
+	}))
+	if err != nil {
+		result.Errors = append(result.Errors, clean(fmt.Sprintf("%+v", err)))
+		return &result, nil
+	}
+
+	outputDir := filepath.Join(dir, "out")
+	err = gen.Generate(module, &gen.Options{
+		OutputDir:      outputDir,
+		PackagePrefix:  serveUIPackagePrefix,
+		ThriftRoot:     dir,
+		NoRecurse:      true,
+		NoVersionCheck: true,
+		NoEmbedIDL:     true,
+	})
+	if err != nil {
+		result.Errors = append(result.Errors, clean(fmt.Sprintf("%+v", err)))
+		return &result, nil
+	}
+
+	api, err := apidiff.Load(outputDir)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the API of the generated code: %v", err)
+	}
+	for name, sig := range api {
+		result.API = append(result.API, name+" "+sig)
+	}
+	sort.Strings(result.API)
+
+	return &result, nil
+}
+
+// serveUIPage is the web page served by "thriftrw serve-ui". It validates the
+// IDL as it is edited.
+const serveUIPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>thriftrw</title>
+<style>
+body { font-family: sans-serif; margin: 1em; }
+main { display: flex; gap: 1em; }
+textarea { flex: 1; height: 85vh; font-family: monospace; }
+#results { flex: 1; height: 85vh; overflow: auto; }
+pre { margin: 0 0 1em 0; white-space: pre-wrap; }
+.errors pre { color: #b00; }
+.lint pre { color: #a60; }
+</style>
+</head>
+<body>
+<main>
+<textarea id="idl" spellcheck="false" placeholder="Paste Thrift IDL here"></textarea>
+<div id="results">
+<h3>Errors</h3><div class="errors" id="errors"></div>
+<h3>Lint</h3><div class="lint" id="lint"></div>
+<h3>Go API</h3><div id="api"></div>
+</div>
+</main>
+<script>
+var idl = document.getElementById("idl");
+var timer = null;
+
+function show(id, lines) {
+	var el = document.getElementById(id);
+	el.innerHTML = "";
+	var pre = document.createElement("pre");
+	pre.textContent = lines.length ? lines.join("\n") : "None";
+	el.appendChild(pre);
+}
+
+function validate() {
+	fetch("/validate", {method: "POST", body: idl.value})
+		.then(function(res) {
+			if (!res.ok) {
+				return res.text().then(function(msg) { throw new Error(msg); });
+			}
+			return res.json();
+		})
+		.then(function(result) {
+			show("errors", result.errors);
+			show("lint", result.lint);
+			show("api", result.api);
+		})
+		.catch(function(err) {
+			show("errors", [err.message]);
+		});
+}
+
+idl.addEventListener("input", function() {
+	clearTimeout(timer);
+	timer = setTimeout(validate, 300);
+});
+validate();
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,163 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateIDL(t *testing.T) {
+	tests := []struct {
+		desc        string
+		idl         string
+		wantErrors  []string
+		wantLint    []string
+		wantAPI     []string
+		wantNoLint  bool
+		wantNoAPI   bool
+		wantNoError bool
+	}{
+		{
+			desc:        "valid",
+			idl:         "struct User {\n    1: required string name\n}\n",
+			wantAPI:     []string{"main.User struct", "main.User.Name string"},
+			wantNoLint:  true,
+			wantNoError: true,
+		},
+		{
+			desc:        "deprecated syntax",
+			idl:         "java_package com.example\n\nstruct User {\n    1: required string name\n}\n",
+			wantLint:    []string{`main.thrift:1: "java_package" is deprecated`},
+			wantAPI:     []string{"main.User struct"},
+			wantNoError: true,
+		},
+		{
+			desc:        "unformatted",
+			idl:         "struct User {1: required string name}",
+			wantLint:    []string{"main.thrift is not formatted"},
+			wantAPI:     []string{"main.User struct"},
+			wantNoError: true,
+		},
+		{
+			desc:       "parse error",
+			idl:        "struct User {",
+			wantErrors: []string{"main.thrift"},
+			wantNoAPI:  true,
+		},
+		{
+			desc:       "compile error",
+			idl:        "struct User {\n    1: required Name name\n}\n",
+			wantErrors: []string{`could not resolve reference "Name"`},
+			wantNoAPI:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := validateIDL([]byte(tt.idl))
+		require.NoError(t, err, tt.desc)
+
+		assertContainsAll(t, got.Errors, tt.wantErrors, tt.desc)
+		assertContainsAll(t, got.Lint, tt.wantLint, tt.desc)
+		assertContainsAll(t, got.API, tt.wantAPI, tt.desc)
+		if tt.wantNoError {
+			assert.Empty(t, got.Errors, tt.desc)
+		}
+		if tt.wantNoLint {
+			assert.Empty(t, got.Lint, tt.desc)
+		}
+		if tt.wantNoAPI {
+			assert.Empty(t, got.API, tt.desc)
+		}
+		for _, msg := range append(got.Errors, got.Lint...) {
+			assert.NotContains(t, msg, "thriftrw-serve-ui", "%v: temporary directory must be hidden", tt.desc)
+		}
+	}
+}
+
+// assertContainsAll asserts that every one of the wanted substrings is
+// contained in at least one of the given lines.
+func assertContainsAll(t *testing.T, lines, want []string, desc string) {
+	for _, w := range want {
+		found := false
+		for _, line := range lines {
+			if strings.Contains(line, w) {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "%v: expected %q in %q", desc, w, lines)
+	}
+}
+
+func TestServeUIHandler(t *testing.T) {
+	server := httptest.NewServer(newServeUIHandler())
+	defer server.Close()
+
+	t.Run("page", func(t *testing.T) {
+		res, err := http.Get(server.URL)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Contains(t, res.Header.Get("Content-Type"), "text/html")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		res, err := http.Get(server.URL + "/foo")
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		res, err := http.Post(server.URL+"/validate", "text/plain",
+			strings.NewReader("enum Color { RED, GREEN }\n"))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var result idlValidation
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&result))
+		assert.Empty(t, result.Errors)
+		assert.Contains(t, result.API, "main.ColorRed const Color")
+	})
+
+	t.Run("validate GET", func(t *testing.T) {
+		res, err := http.Get(server.URL + "/validate")
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		res, err := http.Post(server.URL+"/validate", "text/plain",
+			strings.NewReader(strings.Repeat(" ", serveUIMaxIDLBytes+1)))
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode)
+	})
+}
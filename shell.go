@@ -0,0 +1,66 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/internal/shell"
+
+	"github.com/jessevdk/go-flags"
+)
+
+// doShell implements the "thriftrw shell" command.
+func doShell(args []string) error {
+	parser := flags.NewParser(nil, flags.Default)
+	parser.Name = "thriftrw shell"
+	parser.Usage = "FILE\n\n" +
+		"Starts an interactive shell in which values of the types defined in FILE\n" +
+		"and the files it includes may be encoded to and decoded from the Binary\n" +
+		"protocol. Run \"help\" in the shell for a list of commands."
+
+	files, err := parser.ParseArgs(args)
+	if err != nil {
+		return nil // message already printed by go-flags
+	}
+
+	if len(files) != 1 {
+		var buffer bytes.Buffer
+		parser.WriteHelp(&buffer)
+		return errors.New(buffer.String())
+	}
+
+	module, err := compile.Compile(files[0])
+	if err != nil {
+		return fmt.Errorf("Failed to compile %q: %+v", files[0], err)
+	}
+
+	// Don't prompt if commands are being piped in.
+	var prompt string
+	if fi, err := os.Stdin.Stat(); err == nil && fi.Mode()&os.ModeCharDevice != 0 {
+		prompt = shell.Prompt
+	}
+	return shell.New(module, os.Stdout).Run(os.Stdin, prompt)
+}
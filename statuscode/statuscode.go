@@ -0,0 +1,140 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package statuscode translates Thrift exceptions into HTTP status codes,
+// gRPC status codes, and RPC error codes.
+//
+// Exceptions annotated with http.status, grpc.code, or rpc.code implement
+// HTTPStatuser, GRPCCoder, or RPCCoder respectively.
+//
+// 	exception UserNotFound {
+// 		1: required string userID
+// 	} (http.status = "404", grpc.code = "NOT_FOUND")
+//
+// Gateways may then translate errors returned by handlers consistently
+// without knowing about specific exception types.
+//
+// 	status, ok := statuscode.HTTPStatus(err)
+// 	if !ok {
+// 		status = http.StatusInternalServerError
+// 	}
+//
+// YARPC and gRPC use the same error codes with the same numeric values, so
+// transports may convert the names returned by RPCCode to either.
+//
+// 	if name, ok := statuscode.RPCCode(err); ok {
+// 		code, _ := statuscode.GRPCCodeValue(name)
+// 		return yarpcerrors.Newf(yarpcerrors.Code(code), err.Error())
+// 	}
+package statuscode
+
+// HTTPStatuser is implemented by exceptions annotated with http.status.
+type HTTPStatuser interface {
+	error
+
+	// HTTPStatus returns the HTTP status code for this exception.
+	HTTPStatus() int
+}
+
+// GRPCCoder is implemented by exceptions annotated with grpc.code.
+type GRPCCoder interface {
+	error
+
+	// GRPCCode returns the name of the gRPC status code for this exception,
+	// for example, "NOT_FOUND".
+	GRPCCode() string
+}
+
+// RPCCoder is implemented by exceptions annotated with rpc.code.
+type RPCCoder interface {
+	error
+
+	// RPCCode returns the name of the RPC error code for this exception, for
+	// example, "INVALID_ARGUMENT".
+	RPCCode() string
+}
+
+// HTTPStatus returns the HTTP status code for the given error. False is
+// returned if the error does not specify a status code.
+func HTTPStatus(err error) (int, bool) {
+	if e, ok := err.(HTTPStatuser); ok {
+		return e.HTTPStatus(), true
+	}
+	return 0, false
+}
+
+// GRPCCode returns the name of the gRPC status code for the given error,
+// falling back to its RPC error code. False is returned if the error does not
+// specify either.
+func GRPCCode(err error) (string, bool) {
+	if e, ok := err.(GRPCCoder); ok {
+		return e.GRPCCode(), true
+	}
+	if e, ok := err.(RPCCoder); ok {
+		return e.RPCCode(), true
+	}
+	return "", false
+}
+
+// RPCCode returns the name of the RPC error code for the given error,
+// falling back to its gRPC status code. False is returned if the error does
+// not specify either.
+func RPCCode(err error) (string, bool) {
+	if e, ok := err.(RPCCoder); ok {
+		return e.RPCCode(), true
+	}
+	if e, ok := err.(GRPCCoder); ok {
+		return e.GRPCCode(), true
+	}
+	return "", false
+}
+
+// Names of the canonical gRPC status codes, indexed by their numeric value.
+var grpcCodes = []string{
+	"OK",
+	"CANCELLED",
+	"UNKNOWN",
+	"INVALID_ARGUMENT",
+	"DEADLINE_EXCEEDED",
+	"NOT_FOUND",
+	"ALREADY_EXISTS",
+	"PERMISSION_DENIED",
+	"RESOURCE_EXHAUSTED",
+	"FAILED_PRECONDITION",
+	"ABORTED",
+	"OUT_OF_RANGE",
+	"UNIMPLEMENTED",
+	"INTERNAL",
+	"UNAVAILABLE",
+	"DATA_LOSS",
+	"UNAUTHENTICATED",
+}
+
+// GRPCCodeValue returns the numeric value of the gRPC status code with the
+// given name. This is also the value of the YARPC error code of that name. False is returned if the name is not a canonical gRPC status
+// code.
+func GRPCCodeValue(name string) (int, bool) {
+	for i, code := range grpcCodes {
+		if code == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
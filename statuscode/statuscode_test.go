@@ -0,0 +1,99 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package statuscode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type notFound struct{}
+
+func (notFound) Error() string    { return "not found" }
+func (notFound) HTTPStatus() int  { return 404 }
+func (notFound) GRPCCode() string { return "NOT_FOUND" }
+
+func TestHTTPStatus(t *testing.T) {
+	status, ok := HTTPStatus(notFound{})
+	assert.True(t, ok)
+	assert.Equal(t, 404, status)
+
+	_, ok = HTTPStatus(errors.New("great sadness"))
+	assert.False(t, ok)
+
+	_, ok = HTTPStatus(nil)
+	assert.False(t, ok)
+}
+
+func TestGRPCCode(t *testing.T) {
+	code, ok := GRPCCode(notFound{})
+	assert.True(t, ok)
+	assert.Equal(t, "NOT_FOUND", code)
+
+	_, ok = GRPCCode(errors.New("great sadness"))
+	assert.False(t, ok)
+}
+
+type invalidUser struct{}
+
+func (invalidUser) Error() string   { return "invalid user" }
+func (invalidUser) RPCCode() string { return "INVALID_ARGUMENT" }
+
+func TestGRPCCodeFromRPCCode(t *testing.T) {
+	code, ok := GRPCCode(invalidUser{})
+	assert.True(t, ok)
+	assert.Equal(t, "INVALID_ARGUMENT", code)
+}
+
+func TestRPCCode(t *testing.T) {
+	code, ok := RPCCode(invalidUser{})
+	assert.True(t, ok)
+	assert.Equal(t, "INVALID_ARGUMENT", code)
+
+	code, ok = RPCCode(notFound{})
+	assert.True(t, ok)
+	assert.Equal(t, "NOT_FOUND", code)
+
+	_, ok = RPCCode(errors.New("great sadness"))
+	assert.False(t, ok)
+}
+
+func TestGRPCCodeValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   int
+		wantOK bool
+	}{
+		{"OK", 0, true},
+		{"NOT_FOUND", 5, true},
+		{"UNAUTHENTICATED", 16, true},
+		{"NotFound", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := GRPCCodeValue(tt.name)
+		assert.Equal(t, tt.wantOK, ok, tt.name)
+		assert.Equal(t, tt.want, got, tt.name)
+	}
+}
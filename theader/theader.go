@@ -0,0 +1,417 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package theader implements the THeader transport used by Apache and
+// Facebook Thrift services to send key/value headers, such as tracing
+// baggage, alongside Thrift messages.
+//
+// A THeader frame holds a message encoded with one of the Thrift
+// protocols, the identifier of that protocol, the transforms that were
+// applied to the message, and the headers.
+//
+// 	body, err := theader.Frame{
+// 		SeqID:    seqID,
+// 		Protocol: theader.ProtocolBinary,
+// 		Headers:  map[string]string{"trace-id": traceID},
+// 		Payload:  req,
+// 	}.Encode()
+//
+// Frames are sent with their length as a 4-byte big-endian integer, which
+// Read and Write take care of. Decode and Encode work on the contents of a
+// frame without its length for use with other framing code.
+package theader
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"go.uber.org/thriftrw/protocol"
+)
+
+// Magic identifies THeader frames. The contents of every frame start with
+// it.
+const Magic = 0x0FFF
+
+const (
+	// Size of the fixed part of the frame before the variable-size header:
+	// magic, flags, sequence number, and header size.
+	_fixedSize = 10
+
+	// Headers are padded to a multiple of this many bytes. Their size is
+	// recorded in units of this size.
+	_headerWordSize = 4
+
+	_maxHeaderSize = math.MaxUint16 * _headerWordSize
+	_maxFrameSize  = math.MaxInt32
+
+	// Identifiers of the info blocks in the header.
+	_infoPadding  = 0
+	_infoKeyValue = 1
+)
+
+// ProtocolID identifies the Thrift protocol that the payload of a frame is
+// encoded with.
+type ProtocolID uint32
+
+// Protocols recognized by this package. ThriftRW implements only the
+// Binary protocol.
+const (
+	ProtocolBinary  ProtocolID = 0
+	ProtocolCompact ProtocolID = 2
+)
+
+func (p ProtocolID) String() string {
+	switch p {
+	case ProtocolBinary:
+		return "binary"
+	case ProtocolCompact:
+		return "compact"
+	default:
+		return fmt.Sprintf("ProtocolID(%d)", uint32(p))
+	}
+}
+
+// TransformID identifies a transform applied to the payload of a frame.
+type TransformID uint32
+
+// Transforms supported by this package.
+const (
+	// TransformZlib compresses the payload with zlib, like protocol.Zlib.
+	TransformZlib TransformID = 1
+)
+
+func (t TransformID) String() string {
+	switch t {
+	case TransformZlib:
+		return "zlib"
+	default:
+		return fmt.Sprintf("TransformID(%d)", uint32(t))
+	}
+}
+
+// UnsupportedTransformError is returned when a frame uses a transform that
+// this package does not implement.
+type UnsupportedTransformError struct {
+	Transform TransformID
+}
+
+func (e UnsupportedTransformError) Error() string {
+	return fmt.Sprintf("unsupported THeader transform %v", e.Transform)
+}
+
+// Frame is a THeader frame.
+type Frame struct {
+	// Flags of the frame. These are not interpreted by this package.
+	Flags uint16
+
+	// SeqID is the sequence number of the frame. It usually matches the
+	// sequence ID of the enveloped message in the payload.
+	SeqID int32
+
+	// Protocol the payload is encoded with.
+	Protocol ProtocolID
+
+	// Transforms applied to the payload, in the order in which they were
+	// applied. Encode applies them and Decode reverses them, so Payload
+	// always holds the untransformed message.
+	Transforms []TransformID
+
+	// Headers sent with the message.
+	Headers map[string]string
+
+	// Payload is the encoded message.
+	Payload []byte
+}
+
+// IsFrame returns true if the given contents of a frame, without its
+// length, form a THeader frame.
+func IsFrame(b []byte) bool {
+	return len(b) >= 2 && binary.BigEndian.Uint16(b) == Magic
+}
+
+// Read reads a length-prefixed THeader frame from the given reader. Its
+// payload may be at most protocol.DefaultMaxReversedSize bytes once its
+// transforms are reversed.
+func Read(r io.Reader) (Frame, error) {
+	return ReadLimit(r, protocol.DefaultMaxReversedSize)
+}
+
+// ReadLimit is like Read but allows payloads of up to maxPayloadSize bytes
+// once their transforms are reversed.
+func ReadLimit(r io.Reader, maxPayloadSize int64) (Frame, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return Frame{}, err
+	}
+
+	n := binary.BigEndian.Uint32(size[:])
+	if n > _maxFrameSize {
+		return Frame{}, fmt.Errorf("THeader frame size %d is too large", n)
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, r, int64(n)); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return Frame{}, err
+	}
+	return DecodeLimit(buf.Bytes(), maxPayloadSize)
+}
+
+// Write writes the frame to the given writer, preceded by its length.
+func Write(w io.Writer, f Frame) error {
+	b, err := f.Encode()
+	if err != nil {
+		return err
+	}
+
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(b)))
+	if _, err := w.Write(size[:]); err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// Decode decodes the contents of a THeader frame, without its length.
+//
+// Info blocks which this package does not recognize end the header; the
+// rest of it is ignored.
+//
+// Decompressing the payload fails with a protocol.ReversedSizeError if it
+// would produce more than protocol.DefaultMaxReversedSize bytes, so that a
+// small frame cannot make Decode allocate gigabytes of memory.
+func Decode(b []byte) (Frame, error) {
+	return DecodeLimit(b, protocol.DefaultMaxReversedSize)
+}
+
+// DecodeLimit is like Decode but allows payloads of up to maxPayloadSize
+// bytes once their transforms are reversed.
+func DecodeLimit(b []byte, maxPayloadSize int64) (Frame, error) {
+	if !IsFrame(b) {
+		return Frame{}, fmt.Errorf("not a THeader frame: starts with % x", head(b))
+	}
+	if len(b) < _fixedSize {
+		return Frame{}, fmt.Errorf("THeader frame is too short: got %d bytes", len(b))
+	}
+
+	f := Frame{
+		Flags: binary.BigEndian.Uint16(b[2:]),
+		SeqID: int32(binary.BigEndian.Uint32(b[4:])),
+	}
+
+	headerSize := int(binary.BigEndian.Uint16(b[8:])) * _headerWordSize
+	if headerSize > len(b)-_fixedSize {
+		return Frame{}, fmt.Errorf(
+			"THeader header size %d exceeds the %d bytes left in the frame",
+			headerSize, len(b)-_fixedSize)
+	}
+
+	d := decoder{b: b[_fixedSize : _fixedSize+headerSize]}
+	f.Protocol = ProtocolID(d.uvarint())
+	numTransforms := d.uvarint()
+	for i := uint32(0); i < numTransforms && d.err == nil; i++ {
+		f.Transforms = append(f.Transforms, TransformID(d.uvarint()))
+	}
+
+infos:
+	for len(d.b) > 0 && d.err == nil {
+		switch d.uvarint() {
+		case _infoKeyValue:
+			n := d.uvarint()
+			for i := uint32(0); i < n && d.err == nil; i++ {
+				k, v := d.string(), d.string()
+				if d.err != nil {
+					break
+				}
+				if f.Headers == nil {
+					f.Headers = make(map[string]string)
+				}
+				f.Headers[k] = v
+			}
+		default:
+			// Padding or an unknown info block whose size we cannot tell.
+			break infos
+		}
+	}
+	if d.err != nil {
+		return Frame{}, d.err
+	}
+
+	payload := b[_fixedSize+headerSize:]
+	for i := len(f.Transforms) - 1; i >= 0; i-- {
+		var err error
+		payload, err = untransform(f.Transforms[i], payload, maxPayloadSize)
+		if err != nil {
+			return Frame{}, err
+		}
+	}
+	f.Payload = payload
+	return f, nil
+}
+
+// Encode encodes the contents of the frame, without its length.
+func (f Frame) Encode() ([]byte, error) {
+	var header []byte
+	header = appendUvarint(header, uint32(f.Protocol))
+	header = appendUvarint(header, uint32(len(f.Transforms)))
+	for _, t := range f.Transforms {
+		header = appendUvarint(header, uint32(t))
+	}
+
+	if len(f.Headers) > 0 {
+		keys := make([]string, 0, len(f.Headers))
+		for k := range f.Headers {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		header = appendUvarint(header, _infoKeyValue)
+		header = appendUvarint(header, uint32(len(keys)))
+		for _, k := range keys {
+			header = appendString(header, k)
+			header = appendString(header, f.Headers[k])
+		}
+	}
+
+	for len(header)%_headerWordSize != 0 {
+		header = append(header, _infoPadding)
+	}
+	if len(header) > _maxHeaderSize {
+		return nil, fmt.Errorf(
+			"THeader headers are too large: %d bytes exceeds the limit of %d bytes",
+			len(header), _maxHeaderSize)
+	}
+
+	payload := f.Payload
+	for _, t := range f.Transforms {
+		var err error
+		payload, err = transform(t, payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	size := _fixedSize + len(header) + len(payload)
+	if size > _maxFrameSize {
+		return nil, fmt.Errorf("THeader frame size %d is too large", size)
+	}
+
+	b := make([]byte, _fixedSize, size)
+	binary.BigEndian.PutUint16(b, Magic)
+	binary.BigEndian.PutUint16(b[2:], f.Flags)
+	binary.BigEndian.PutUint32(b[4:], uint32(f.SeqID))
+	binary.BigEndian.PutUint16(b[8:], uint16(len(header)/_headerWordSize))
+	b = append(b, header...)
+	return append(b, payload...), nil
+}
+
+func transform(t TransformID, b []byte) ([]byte, error) {
+	switch t {
+	case TransformZlib:
+		return protocol.Zlib.Apply(b)
+	default:
+		return nil, UnsupportedTransformError{Transform: t}
+	}
+}
+
+func untransform(t TransformID, b []byte, max int64) ([]byte, error) {
+	switch t {
+	case TransformZlib:
+		out, err := protocol.ReverseLimit(protocol.Zlib, b, max)
+		if _, ok := err.(protocol.ReversedSizeError); ok {
+			return nil, err
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not decompress THeader payload: %v", err)
+		}
+		return out, nil
+	default:
+		return nil, UnsupportedTransformError{Transform: t}
+	}
+}
+
+// decoder reads the variable-size header of a frame. The first error
+// encountered is recorded and later reads return zero values.
+type decoder struct {
+	b   []byte
+	err error
+}
+
+var errTruncatedHeader = errors.New("THeader header is truncated")
+
+func (d *decoder) uvarint() uint32 {
+	if d.err != nil {
+		return 0
+	}
+
+	v, n := binary.Uvarint(d.b)
+	switch {
+	case n == 0:
+		d.err = errTruncatedHeader
+		return 0
+	case n < 0 || v > math.MaxUint32:
+		d.err = errors.New("THeader header has a varint that overflows 32 bits")
+		return 0
+	}
+	d.b = d.b[n:]
+	return uint32(v)
+}
+
+func (d *decoder) string() string {
+	n := d.uvarint()
+	if d.err != nil {
+		return ""
+	}
+	if uint64(n) > uint64(len(d.b)) {
+		d.err = errTruncatedHeader
+		return ""
+	}
+
+	s := string(d.b[:n])
+	d.b = d.b[n:]
+	return s
+}
+
+func appendUvarint(b []byte, v uint32) []byte {
+	var buf [binary.MaxVarintLen32]byte
+	n := binary.PutUvarint(buf[:], uint64(v))
+	return append(b, buf[:n]...)
+}
+
+func appendString(b []byte, s string) []byte {
+	b = appendUvarint(b, uint32(len(s)))
+	return append(b, s...)
+}
+
+// head returns up to the first four bytes of b for error messages.
+func head(b []byte) []byte {
+	if len(b) > 4 {
+		return b[:4]
+	}
+	return b
+}
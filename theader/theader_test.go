@@ -0,0 +1,271 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package theader
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"go.uber.org/thriftrw/protocol"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	tests := []struct {
+		desc  string
+		frame Frame
+		want  []byte
+	}{
+		{
+			desc:  "empty",
+			frame: Frame{},
+			want: []byte{
+				0x0f, 0xff, 0x00, 0x00, // magic, flags
+				0x00, 0x00, 0x00, 0x00, // seqID
+				0x00, 0x01, // header size
+				0x00, 0x00, 0x00, 0x00, // protocol, transforms, padding
+			},
+		},
+		{
+			desc: "headers",
+			frame: Frame{
+				Flags:   0x0001,
+				SeqID:   1,
+				Headers: map[string]string{"k": "v"},
+				Payload: []byte("ab"),
+			},
+			want: []byte{
+				0x0f, 0xff, 0x00, 0x01, // magic, flags
+				0x00, 0x00, 0x00, 0x01, // seqID
+				0x00, 0x02, // header size
+				0x00,      // protocol
+				0x00,      // transforms
+				0x01,      // key/value info
+				0x01,      // count
+				0x01, 'k', // key
+				0x01, 'v', // value
+				'a', 'b',
+			},
+		},
+		{
+			desc: "sorted headers with padding",
+			frame: Frame{
+				SeqID:    -1,
+				Protocol: ProtocolCompact,
+				Headers:  map[string]string{"b": "", "a": "x"},
+			},
+			want: []byte{
+				0x0f, 0xff, 0x00, 0x00, // magic, flags
+				0xff, 0xff, 0xff, 0xff, // seqID
+				0x00, 0x03, // header size
+				0x02, // protocol
+				0x00, // transforms
+				0x01, // key/value info
+				0x02, // count
+				0x01, 'a', 0x01, 'x',
+				0x01, 'b', 0x00,
+				0x00, // padding
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.frame.Encode()
+		require.NoError(t, err, tt.desc)
+		assert.Equal(t, tt.want, got, tt.desc)
+		assert.True(t, IsFrame(got), tt.desc)
+
+		decoded, err := Decode(got)
+		require.NoError(t, err, tt.desc)
+		if tt.frame.Payload == nil {
+			tt.frame.Payload = []byte{}
+		}
+		assert.Equal(t, tt.frame, decoded, tt.desc)
+	}
+}
+
+func TestZlibTransform(t *testing.T) {
+	payload := bytes.Repeat([]byte("hello world "), 100)
+	f := Frame{
+		SeqID:      42,
+		Transforms: []TransformID{TransformZlib},
+		Headers:    map[string]string{"trace-id": "abc"},
+		Payload:    payload,
+	}
+
+	b, err := f.Encode()
+	require.NoError(t, err)
+	assert.True(t, len(b) < len(payload), "payload must be compressed")
+
+	got, err := Decode(b)
+	require.NoError(t, err)
+	assert.Equal(t, f, got)
+}
+
+func TestReadWrite(t *testing.T) {
+	var buf bytes.Buffer
+	frames := []Frame{
+		{SeqID: 1, Headers: map[string]string{"a": "b"}, Payload: []byte("foo")},
+		{SeqID: 2, Payload: []byte("bar")},
+	}
+	for _, f := range frames {
+		require.NoError(t, Write(&buf, f))
+	}
+
+	for _, want := range frames {
+		got, err := Read(&buf)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := Read(&buf)
+	assert.Equal(t, io.EOF, err)
+
+	_, err = Read(bytes.NewReader([]byte{0, 0, 0, 20, 0x0f, 0xff}))
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
+
+func TestDecodeIgnoresUnknownInfo(t *testing.T) {
+	got, err := Decode([]byte{
+		0x0f, 0xff, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x07,
+		0x00, 0x02,
+		0x00,                   // protocol
+		0x00,                   // transforms
+		0x01, 0x01, 0x00, 0x00, // key/value info with an empty header
+		0x09, 0x42, // unknown info
+		'x',
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Frame{
+		SeqID:   7,
+		Headers: map[string]string{"": ""},
+		Payload: []byte("x"),
+	}, got)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		give    []byte
+		wantErr string
+	}{
+		{
+			desc:    "not a frame",
+			give:    []byte{0x80, 0x01, 0x00, 0x01, 0x00},
+			wantErr: "not a THeader frame: starts with 80 01 00 01",
+		},
+		{
+			desc:    "too short",
+			give:    []byte{0x0f, 0xff, 0x00, 0x00},
+			wantErr: "THeader frame is too short: got 4 bytes",
+		},
+		{
+			desc:    "header size",
+			give:    []byte{0x0f, 0xff, 0, 0, 0, 0, 0, 0, 0x00, 0x02, 0, 0, 0, 0},
+			wantErr: "THeader header size 8 exceeds the 4 bytes left in the frame",
+		},
+		{
+			desc:    "truncated header",
+			give:    []byte{0x0f, 0xff, 0, 0, 0, 0, 0, 0, 0x00, 0x01, 0x00, 0x00, 0x01, 0x01},
+			wantErr: "THeader header is truncated",
+		},
+		{
+			desc:    "truncated string",
+			give:    []byte{0x0f, 0xff, 0, 0, 0, 0, 0, 0, 0x00, 0x02, 0x00, 0x00, 0x01, 0x01, 0x05, 'a', 'b', 'c'},
+			wantErr: "THeader header is truncated",
+		},
+		{
+			desc:    "unsupported transform",
+			give:    []byte{0x0f, 0xff, 0, 0, 0, 0, 0, 0, 0x00, 0x01, 0x00, 0x01, 0x03, 0x00},
+			wantErr: "unsupported THeader transform TransformID(3)",
+		},
+		{
+			desc:    "corrupt zlib payload",
+			give:    []byte{0x0f, 0xff, 0, 0, 0, 0, 0, 0, 0x00, 0x01, 0x00, 0x01, 0x01, 0x00, 'x'},
+			wantErr: "could not decompress THeader payload",
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := Decode(tt.give)
+		if assert.Error(t, err, tt.desc) {
+			assert.Contains(t, err.Error(), tt.wantErr, tt.desc)
+		}
+	}
+}
+
+func TestEncodeUnsupportedTransform(t *testing.T) {
+	_, err := Frame{Transforms: []TransformID{3}}.Encode()
+	assert.Equal(t, UnsupportedTransformError{Transform: 3}, err)
+}
+
+func TestDecodeCompressionBomb(t *testing.T) {
+	var payload bytes.Buffer
+	w := zlib.NewWriter(&payload)
+	_, err := io.CopyN(w, zeros{}, protocol.DefaultMaxReversedSize+1)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	// A frame with a zlib transform and no headers.
+	frame := append([]byte{0x0f, 0xff, 0, 0, 0, 0, 0, 0, 0x00, 0x01, 0x00, 0x01, 0x01, 0x00}, payload.Bytes()...)
+	assert.True(t, int64(len(frame))*256 < protocol.DefaultMaxReversedSize,
+		"frame must be much smaller than its payload: got %d bytes", len(frame))
+
+	_, err = Decode(frame)
+	assert.Equal(t, protocol.ReversedSizeError{Transform: "zlib", Max: protocol.DefaultMaxReversedSize}, err)
+
+	_, err = DecodeLimit(frame, 1024)
+	assert.Equal(t, protocol.ReversedSizeError{Transform: "zlib", Max: 1024}, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, binary.Write(&buf, binary.BigEndian, uint32(len(frame))))
+	buf.Write(frame)
+	_, err = ReadLimit(&buf, 1024)
+	assert.Equal(t, protocol.ReversedSizeError{Transform: "zlib", Max: 1024}, err)
+}
+
+func TestDecodeLimit(t *testing.T) {
+	f := Frame{Transforms: []TransformID{TransformZlib}, Payload: bytes.Repeat([]byte("a"), 1024)}
+	b, err := f.Encode()
+	require.NoError(t, err)
+
+	got, err := DecodeLimit(b, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, f, got)
+
+	_, err = DecodeLimit(b, 1023)
+	assert.Equal(t, protocol.ReversedSizeError{Transform: "zlib", Max: 1023}, err)
+}
+
+type zeros struct{}
+
+func (zeros) Read(b []byte) (int, error) {
+	for i := range b {
+		b[i] = 0
+	}
+	return len(b), nil
+}
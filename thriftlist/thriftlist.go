@@ -0,0 +1,109 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+// Package thriftlist provides generic helpers which code generated by
+// ThriftRW for Go 1.18 and newer uses to convert slices to and from
+// wire.ValueLists, in place of conversion code generated for every list
+// type.
+//
+// 	func _List_User_ValueList(v []*User) wire.ValueList {
+// 		return thriftlist.Encode(v, wire.TStruct, func(x *User) (wire.Value, error) {
+// 			if x == nil {
+// 				return wire.Value{}, thriftlist.ErrNil
+// 			}
+// 			return x.ToWire()
+// 		})
+// 	}
+package thriftlist
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/thriftrw/wire"
+)
+
+// ErrNil may be returned by the functions given to Encode to reject nil
+// items. Encode reports it along with the position of the item.
+var ErrNil = errors.New("value is nil")
+
+// Encode returns a wire.ValueList of the given items, each of which is
+// converted to a wire.Value of type t with toWire when the list is
+// iterated.
+func Encode[T any](items []T, t wire.Type, toWire func(T) (wire.Value, error)) wire.ValueList {
+	return valueList[T]{items: items, t: t, toWire: toWire}
+}
+
+type valueList[T any] struct {
+	items  []T
+	t      wire.Type
+	toWire func(T) (wire.Value, error)
+}
+
+func (l valueList[T]) ForEach(f func(wire.Value) error) error {
+	for i, x := range l.items {
+		w, err := l.toWire(x)
+		if err == ErrNil {
+			return fmt.Errorf("invalid [%v]: %v", i, err)
+		}
+		if err != nil {
+			return err
+		}
+		if err := f(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l valueList[T]) Size() int {
+	return len(l.items)
+}
+
+func (l valueList[T]) ValueType() wire.Type {
+	return l.t
+}
+
+func (valueList[T]) Close() {}
+
+// Decode reads the items of the given wire.ValueList into a slice,
+// converting each with fromWire. The ValueList is closed afterwards.
+//
+// A nil slice is returned if the items of the list are not of type t.
+func Decode[T any](l wire.ValueList, t wire.Type, fromWire func(wire.Value) (T, error)) ([]T, error) {
+	if l.ValueType() != t {
+		return nil, nil
+	}
+
+	o := make([]T, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := fromWire(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package thriftlist
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func i32ToWire(x int32) (wire.Value, error) { return wire.NewValueI32(x), nil }
+
+func i32FromWire(w wire.Value) (int32, error) { return w.GetI32(), nil }
+
+func TestEncodeDecode(t *testing.T) {
+	l := Encode([]int32{1, 2, 3}, wire.TI32, i32ToWire)
+	assert.Equal(t, 3, l.Size())
+	assert.Equal(t, wire.TI32, l.ValueType())
+
+	var got []wire.Value
+	require.NoError(t, l.ForEach(func(w wire.Value) error {
+		got = append(got, w)
+		return nil
+	}))
+	assert.Equal(t, []wire.Value{wire.NewValueI32(1), wire.NewValueI32(2), wire.NewValueI32(3)}, got)
+
+	items, err := Decode(l, wire.TI32, i32FromWire)
+	require.NoError(t, err)
+	assert.Equal(t, []int32{1, 2, 3}, items)
+}
+
+func TestDecodeMismatchedType(t *testing.T) {
+	items, err := Decode(wire.ValueListFromSlice(wire.TBinary, nil), wire.TI32, i32FromWire)
+	require.NoError(t, err)
+	assert.Nil(t, items)
+}
+
+func TestEncodeErrors(t *testing.T) {
+	l := Encode([][]byte{[]byte("a"), nil}, wire.TBinary, func(b []byte) (wire.Value, error) {
+		if b == nil {
+			return wire.Value{}, ErrNil
+		}
+		return wire.NewValueBinary(b), nil
+	})
+	err := l.ForEach(func(wire.Value) error { return nil })
+	assert.EqualError(t, err, "invalid [1]: value is nil")
+
+	l = Encode([]int32{1}, wire.TI32, i32ToWire)
+	err = l.ForEach(func(wire.Value) error { return errors.New("great sadness") })
+	assert.EqualError(t, err, "great sadness")
+}
+
+func TestDecodeErrors(t *testing.T) {
+	l := Encode([]int32{1, 2}, wire.TI32, i32ToWire)
+	_, err := Decode(l, wire.TI32, func(wire.Value) (int32, error) {
+		return 0, errors.New("great sadness")
+	})
+	assert.EqualError(t, err, "great sadness")
+}
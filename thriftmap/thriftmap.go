@@ -0,0 +1,265 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+// Package thriftmap provides generic helpers which code generated by
+// ThriftRW for Go 1.18 and newer uses to convert maps to and from
+// wire.MapItemLists, in place of conversion code generated for every map
+// type.
+//
+// Maps with hashable keys are represented as Go maps and other maps as
+// slices of key-value pairs.
+//
+// 	func _Map_String_I32_MapItemList(v map[string]int32) wire.MapItemList {
+// 		return thriftmap.Encode(v, wire.TBinary, wire.TI32,
+// 			func(k string) (wire.Value, error) { return wire.NewValueString(k), error(nil) },
+// 			func(v int32) (wire.Value, error) { return wire.NewValueI32(v), error(nil) })
+// 	}
+package thriftmap
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/thriftrw/wire"
+)
+
+// ErrNil may be returned by the functions given to Encode and EncodeSlice
+// to reject nil keys or values.
+var ErrNil = errors.New("value is nil")
+
+// encoder converts the keys and values of maps to wire.Values.
+type encoder[K, V any] struct {
+	kt, vt      wire.Type
+	keyToWire   func(K) (wire.Value, error)
+	valueToWire func(V) (wire.Value, error)
+}
+
+// decoder converts wire.Values into the keys and values of maps.
+type decoder[K, V any] struct {
+	keyFromWire   func(wire.Value) (K, error)
+	valueFromWire func(wire.Value) (V, error)
+}
+
+// Encode returns a wire.MapItemList of the items of the given map. Keys and
+// values are converted to wire.Values of types kt and vt with keyToWire and
+// valueToWire when the list is iterated.
+func Encode[K comparable, V any](
+	m map[K]V,
+	kt, vt wire.Type,
+	keyToWire func(K) (wire.Value, error),
+	valueToWire func(V) (wire.Value, error),
+) wire.MapItemList {
+	return mapItemList[K, V]{m: m, e: encoder[K, V]{kt, vt, keyToWire, valueToWire}}
+}
+
+// EncodeSlice is like Encode for maps with keys which are not hashable.
+// Encoding the returned MapItemList fails if two items have equal keys.
+func EncodeSlice[K, V any](
+	items []struct {
+		Key   K
+		Value V
+	},
+	kt, vt wire.Type,
+	keyToWire func(K) (wire.Value, error),
+	valueToWire func(V) (wire.Value, error),
+) wire.MapItemList {
+	return sliceItemList[K, V]{items: items, e: encoder[K, V]{kt, vt, keyToWire, valueToWire}}
+}
+
+type mapItemList[K comparable, V any] struct {
+	m map[K]V
+	e encoder[K, V]
+}
+
+func (l mapItemList[K, V]) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range l.m {
+		if err := l.e.forItem(k, v, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l mapItemList[K, V]) Size() int {
+	return len(l.m)
+}
+
+func (l mapItemList[K, V]) KeyType() wire.Type {
+	return l.e.kt
+}
+
+func (l mapItemList[K, V]) ValueType() wire.Type {
+	return l.e.vt
+}
+
+func (mapItemList[K, V]) Close() {}
+
+type sliceItemList[K, V any] struct {
+	items []struct {
+		Key   K
+		Value V
+	}
+	e encoder[K, V]
+}
+
+func (l sliceItemList[K, V]) ForEach(f func(wire.MapItem) error) error {
+	keys := wire.NewKeySet(len(l.items))
+	for _, i := range l.items {
+		err := l.e.forItem(i.Key, i.Value, func(item wire.MapItem) error {
+			if _, dup, err := keys.Add(item.Key); err != nil {
+				return err
+			} else if dup {
+				return fmt.Errorf("invalid map key: duplicate key %v", i.Key)
+			}
+			return f(item)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l sliceItemList[K, V]) Size() int {
+	return len(l.items)
+}
+
+func (l sliceItemList[K, V]) KeyType() wire.Type {
+	return l.e.kt
+}
+
+func (l sliceItemList[K, V]) ValueType() wire.Type {
+	return l.e.vt
+}
+
+func (sliceItemList[K, V]) Close() {}
+
+func (e encoder[K, V]) forItem(k K, v V, f func(wire.MapItem) error) error {
+	kw, err := e.keyToWire(k)
+	if err == ErrNil {
+		return fmt.Errorf("invalid map key: %v", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	vw, err := e.valueToWire(v)
+	if err == ErrNil {
+		return fmt.Errorf("invalid [%v]: %v", k, err)
+	}
+	if err != nil {
+		return err
+	}
+	return f(wire.MapItem{Key: kw, Value: vw})
+}
+
+// Decode reads the items of the given wire.MapItemList into a map,
+// converting keys and values with keyFromWire and valueFromWire. The
+// MapItemList is closed afterwards.
+//
+// A nil map is returned if the keys and values of the list are not of types
+// kt and vt.
+func Decode[K comparable, V any](
+	l wire.MapItemList,
+	kt, vt wire.Type,
+	keyFromWire func(wire.Value) (K, error),
+	valueFromWire func(wire.Value) (V, error),
+) (map[K]V, error) {
+	if l.KeyType() != kt || l.ValueType() != vt {
+		return nil, nil
+	}
+
+	d := decoder[K, V]{keyFromWire, valueFromWire}
+
+	o := make(map[K]V, l.Size())
+	err := l.ForEach(func(x wire.MapItem) error {
+		k, v, err := d.fromWire(x)
+		if err != nil {
+			return err
+		}
+		o[k] = v
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// DecodeSlice is like Decode for maps with keys which are not hashable.
+// If a key occurs more than once, its last value is kept in the position of
+// its first occurrence.
+func DecodeSlice[K, V any](
+	l wire.MapItemList,
+	kt, vt wire.Type,
+	keyFromWire func(wire.Value) (K, error),
+	valueFromWire func(wire.Value) (V, error),
+) ([]struct {
+	Key   K
+	Value V
+}, error) {
+	if l.KeyType() != kt || l.ValueType() != vt {
+		return nil, nil
+	}
+
+	d := decoder[K, V]{keyFromWire, valueFromWire}
+
+	o := make([]struct {
+		Key   K
+		Value V
+	}, 0, l.Size())
+	keys := wire.NewKeySet(l.Size())
+	err := l.ForEach(func(x wire.MapItem) error {
+		// The key is recorded before it is decoded because decoding it
+		// closes the lists it contains.
+		i, dup, err := keys.Add(x.Key)
+		if err != nil {
+			return err
+		}
+
+		k, v, err := d.fromWire(x)
+		if err != nil {
+			return err
+		}
+		// Later values replace earlier ones for duplicate keys, as they
+		// would in a Go map.
+		if dup {
+			o[i].Value = v
+			return nil
+		}
+		o = append(o, struct {
+			Key   K
+			Value V
+		}{k, v})
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func (d decoder[K, V]) fromWire(x wire.MapItem) (k K, v V, err error) {
+	k, err = d.keyFromWire(x.Key)
+	if err != nil {
+		return k, v, err
+	}
+	v, err = d.valueFromWire(x.Value)
+	return k, v, err
+}
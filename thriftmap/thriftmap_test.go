@@ -0,0 +1,135 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package thriftmap
+
+import (
+	"testing"
+
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stringToWire(s string) (wire.Value, error) { return wire.NewValueString(s), nil }
+
+func stringFromWire(w wire.Value) (string, error) { return w.GetString(), nil }
+
+func i32ToWire(x int32) (wire.Value, error) { return wire.NewValueI32(x), nil }
+
+func i32FromWire(w wire.Value) (int32, error) { return w.GetI32(), nil }
+
+func TestEncodeDecode(t *testing.T) {
+	m := map[string]int32{"a": 1, "b": 2}
+	l := Encode(m, wire.TBinary, wire.TI32, stringToWire, i32ToWire)
+	assert.Equal(t, 2, l.Size())
+	assert.Equal(t, wire.TBinary, l.KeyType())
+	assert.Equal(t, wire.TI32, l.ValueType())
+
+	got, err := Decode(l, wire.TBinary, wire.TI32, stringFromWire, i32FromWire)
+	require.NoError(t, err)
+	assert.Equal(t, m, got)
+
+	got, err = Decode(l, wire.TBinary, wire.TI64, stringFromWire, i32FromWire)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestEncodeDecodeSlice(t *testing.T) {
+	items := []struct {
+		Key   []byte
+		Value int32
+	}{
+		{Key: []byte("a"), Value: 1},
+		{Key: []byte("b"), Value: 2},
+	}
+	binaryToWire := func(b []byte) (wire.Value, error) {
+		if b == nil {
+			return wire.Value{}, ErrNil
+		}
+		return wire.NewValueBinary(b), nil
+	}
+
+	l := EncodeSlice(items, wire.TBinary, wire.TI32, binaryToWire, i32ToWire)
+	assert.Equal(t, 2, l.Size())
+
+	got, err := DecodeSlice(l, wire.TBinary, wire.TI32, func(w wire.Value) ([]byte, error) {
+		return w.GetBinary(), nil
+	}, i32FromWire)
+	require.NoError(t, err)
+	assert.Equal(t, items, got)
+}
+
+func TestEncodeDecodeSliceDuplicateKeys(t *testing.T) {
+	items := []struct {
+		Key   []byte
+		Value int32
+	}{
+		{Key: []byte("a"), Value: 1},
+		{Key: []byte("b"), Value: 2},
+		{Key: []byte("a"), Value: 3},
+	}
+	binaryToWire := func(b []byte) (wire.Value, error) { return wire.NewValueBinary(b), nil }
+	binaryFromWire := func(w wire.Value) ([]byte, error) { return w.GetBinary(), nil }
+
+	l := EncodeSlice(items, wire.TBinary, wire.TI32, binaryToWire, i32ToWire)
+	err := l.ForEach(func(wire.MapItem) error { return nil })
+	assert.EqualError(t, err, "invalid map key: duplicate key [97]")
+
+	l = wire.MapItemListFromSlice(wire.TBinary, wire.TI32, []wire.MapItem{
+		{Key: wire.NewValueBinary([]byte("a")), Value: wire.NewValueI32(1)},
+		{Key: wire.NewValueBinary([]byte("b")), Value: wire.NewValueI32(2)},
+		{Key: wire.NewValueBinary([]byte("a")), Value: wire.NewValueI32(3)},
+	})
+	got, err := DecodeSlice(l, wire.TBinary, wire.TI32, binaryFromWire, i32FromWire)
+	require.NoError(t, err)
+	assert.Equal(t, []struct {
+		Key   []byte
+		Value int32
+	}{
+		{Key: []byte("a"), Value: 3},
+		{Key: []byte("b"), Value: 2},
+	}, got)
+}
+
+func TestEncodeNil(t *testing.T) {
+	l := Encode(map[string][]byte{"a": nil}, wire.TBinary, wire.TBinary, stringToWire,
+		func(b []byte) (wire.Value, error) {
+			if b == nil {
+				return wire.Value{}, ErrNil
+			}
+			return wire.NewValueBinary(b), nil
+		})
+	err := l.ForEach(func(wire.MapItem) error { return nil })
+	assert.EqualError(t, err, "invalid [a]: value is nil")
+
+	l = EncodeSlice([]struct {
+		Key   []byte
+		Value int32
+	}{{Value: 1}}, wire.TBinary, wire.TI32, func([]byte) (wire.Value, error) {
+		return wire.Value{}, ErrNil
+	}, i32ToWire)
+	err = l.ForEach(func(wire.MapItem) error { return nil })
+	assert.EqualError(t, err, "invalid map key: value is nil")
+}
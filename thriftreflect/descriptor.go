@@ -0,0 +1,55 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thriftreflect
+
+// Descriptor describes the contents of a Thrift file.
+type Descriptor struct {
+	Types    []TypeDescriptor
+	Services []ServiceDescriptor
+}
+
+// TypeDescriptor describes a user-defined type.
+type TypeDescriptor struct {
+	Name   string // Name of the type in the Thrift file.
+	GoName string // Name of the generated Go type.
+
+	// Kind of type: "enum", "struct", "union", "exception", or "typedef".
+	Kind string
+}
+
+// ServiceDescriptor describes a service.
+type ServiceDescriptor struct {
+	Name string
+
+	// Name of the service this service inherits from, if any. Parents
+	// defined in other Thrift files are qualified with the name of that
+	// file, e.g. "base.BaseService".
+	Parent string
+
+	// Functions defined in this service, excluding inherited functions.
+	Methods []MethodDescriptor
+}
+
+// MethodDescriptor describes a function of a service.
+type MethodDescriptor struct {
+	Name   string
+	OneWay bool
+}
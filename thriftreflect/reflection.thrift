@@ -0,0 +1,92 @@
+/**
+ * TypeInfo describes a user-defined type.
+ */
+struct TypeInfo {
+    /**
+     * Name of the type in the Thrift file.
+     */
+    1: required string name
+    /**
+     * Name of the generated Go type.
+     */
+    2: required string goName
+    /**
+     * One of "enum", "struct", "union", "exception", or "typedef".
+     */
+    3: required string kind
+}
+
+/**
+ * MethodInfo describes a function of a service.
+ */
+struct MethodInfo {
+    1: required string name
+    2: required bool oneWay
+}
+
+/**
+ * ServiceInfo describes a service.
+ */
+struct ServiceInfo {
+    1: required string name
+    /**
+     * Name of the service this service inherits from, if any.
+     */
+    2: optional string parent
+    /**
+     * Functions defined in this service, excluding inherited functions.
+     */
+    3: required list<MethodInfo> methods
+}
+
+/**
+ * ModuleInfo describes a Thrift file linked into the server.
+ */
+struct ModuleInfo {
+    1: required string name
+    /**
+     * Import path of the Go package generated for this file.
+     */
+    2: required string importPath
+    /**
+     * Path to the Thrift file relative to the Thrift root.
+     */
+    3: required string filePath
+    4: required string sha1
+    /**
+     * Import paths of the modules included by this file.
+     */
+    5: required list<string> includes
+    /**
+     * Types and services defined in this file. These are present only if
+     * the code was generated with --reflection.
+     */
+    6: optional list<TypeInfo> types
+    7: optional list<ServiceInfo> services
+}
+
+/**
+ * ModuleNotFoundError is raised if a module with the requested import path
+ * was not registered.
+ */
+exception ModuleNotFoundError {
+    1: required string importPath
+}
+
+/**
+ * Reflection exposes the Thrift files a server was built with so that
+ * clients may discover its services without access to the IDL.
+ */
+service Reflection {
+    /**
+     * Lists all registered modules, sorted by import path.
+     */
+    list<ModuleInfo> listModules()
+
+    /**
+     * Returns the source of the Thrift file for the module with the given
+     * import path.
+     */
+    string getIDL(1: required string importPath)
+        throws (1: ModuleNotFoundError notFound)
+}
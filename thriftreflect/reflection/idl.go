@@ -0,0 +1,30 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package reflection
+
+import "go.uber.org/thriftrw/thriftreflect"
+
+var ThriftModule = &thriftreflect.ThriftModule{Name: "reflection", Package: "go.uber.org/thriftrw/thriftreflect/reflection", FilePath: "reflection.thrift", SHA1: "6759504a6fa8c244be3962aa869099ee974d614e", Raw: rawIDL}
+
+const rawIDL = "/**\n * TypeInfo describes a user-defined type.\n */\nstruct TypeInfo {\n    /**\n     * Name of the type in the Thrift file.\n     */\n    1: required string name\n    /**\n     * Name of the generated Go type.\n     */\n    2: required string goName\n    /**\n     * One of \"enum\", \"struct\", \"union\", \"exception\", or \"typedef\".\n     */\n    3: required string kind\n}\n\n/**\n * MethodInfo describes a function of a service.\n */\nstruct MethodInfo {\n    1: required string name\n    2: required bool oneWay\n}\n\n/**\n * ServiceInfo describes a service.\n */\nstruct ServiceInfo {\n    1: required string name\n    /**\n     * Name of the service this service inherits from, if any.\n     */\n    2: optional string parent\n    /**\n     * Functions defined in this service, excluding inherited functions.\n     */\n    3: required list<MethodInfo> methods\n}\n\n/**\n * ModuleInfo describes a Thrift file linked into the server.\n */\nstruct ModuleInfo {\n    1: required string name\n    /**\n     * Import path of the Go package generated for this file.\n     */\n    2: required string importPath\n    /**\n     * Path to the Thrift file relative to the Thrift root.\n     */\n    3: required string filePath\n    4: required string sha1\n    /**\n     * Import paths of the modules included by this file.\n     */\n    5: required list<string> includes\n    /**\n     * Types and services defined in this file. These are present only if\n     * the code was generated with --reflection.\n     */\n    6: optional list<TypeInfo> types\n    7: optional list<ServiceInfo> services\n}\n\n/**\n * ModuleNotFoundError is raised if a module with the requested import path\n * was not registered.\n */\nexception ModuleNotFoundError {\n    1: required string importPath\n}\n\n/**\n * Reflection exposes the Thrift files a server was built with so that\n * clients may discover its services without access to the IDL.\n */\nservice Reflection {\n    /**\n     * Lists all registered modules, sorted by import path.\n     */\n    list<ModuleInfo> listModules()\n\n    /**\n     * Returns the source of the Thrift file for the module with the given\n     * import path.\n     */\n    string getIDL(1: required string importPath)\n        throws (1: ModuleNotFoundError notFound)\n}\n"
@@ -0,0 +1,63 @@
+// Code generated by thriftrw --generate-plugin-api
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package reflection
+
+import "context"
+
+type Reflection interface {
+	GetIDL(
+		ImportPath string,
+	) (string, error)
+
+	ListModules() ([]*ModuleInfo, error)
+}
+
+// ReflectionContext is the Reflection service with the context of the
+// request passed to every function.
+type ReflectionContext interface {
+	GetIDL(
+		ctx context.Context,
+		ImportPath string,
+	) (string, error)
+
+	ListModules(
+		ctx context.Context,
+	) ([]*ModuleInfo, error)
+}
+
+// _Reflection_withoutContext calls an implementation of Reflection without the context.
+type _Reflection_withoutContext struct{ impl Reflection }
+
+func (s _Reflection_withoutContext) GetIDL(
+	ctx context.Context,
+	_ImportPath string,
+) (string, error) {
+	return s.impl.GetIDL(_ImportPath)
+}
+
+func (s _Reflection_withoutContext) ListModules(
+	ctx context.Context,
+) ([]*ModuleInfo, error) {
+	return s.impl.ListModules()
+}
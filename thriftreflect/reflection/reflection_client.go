@@ -0,0 +1,155 @@
+// Code generated by thriftrw --generate-plugin-api
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package reflection
+
+import (
+	"context"
+	"go.uber.org/thriftrw/internal/envelope"
+	"go.uber.org/thriftrw/wire"
+)
+
+// Client implements a Reflection client.
+type _Reflection_client struct {
+	client envelope.Client
+}
+
+// NewReflectionClient builds a new Reflection client.
+func NewReflectionClient(c envelope.Client) Reflection {
+	return &_Reflection_client{
+		client: c,
+	}
+}
+
+func (c *_Reflection_client) GetIDL(
+	_ImportPath string,
+) (success string, err error) {
+	args := Reflection_GetIDL_Helper.Args(_ImportPath)
+
+	var body wire.Value
+	body, err = args.ToWire()
+	if err != nil {
+		return
+	}
+
+	body, err = c.client.Send("getIDL", body)
+	if err != nil {
+		return
+	}
+
+	var result Reflection_GetIDL_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = Reflection_GetIDL_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c *_Reflection_client) ListModules() (success []*ModuleInfo, err error) {
+	args := Reflection_ListModules_Helper.Args()
+
+	var body wire.Value
+	body, err = args.ToWire()
+	if err != nil {
+		return
+	}
+
+	body, err = c.client.Send("listModules", body)
+	if err != nil {
+		return
+	}
+
+	var result Reflection_ListModules_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = Reflection_ListModules_Helper.UnwrapResponse(&result)
+	return
+}
+
+// _Reflection_contextClient implements a ReflectionContext client.
+type _Reflection_contextClient struct {
+	client envelope.Client
+}
+
+// NewReflectionContextClient builds a new Reflection client which
+// stops waiting for a response and returns the context's error once the
+// context passed to a function is done.
+func NewReflectionContextClient(c envelope.Client) ReflectionContext {
+	return &_Reflection_contextClient{
+		client: c,
+	}
+}
+
+func (c *_Reflection_contextClient) GetIDL(
+	ctx context.Context,
+	_ImportPath string,
+) (success string, err error) {
+	args := Reflection_GetIDL_Helper.Args(_ImportPath)
+
+	var body wire.Value
+	body, err = args.ToWire()
+	if err != nil {
+		return
+	}
+
+	body, err = envelope.SendContext(ctx, c.client, "getIDL", body)
+	if err != nil {
+		return
+	}
+
+	var result Reflection_GetIDL_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = Reflection_GetIDL_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c *_Reflection_contextClient) ListModules(
+	ctx context.Context,
+) (success []*ModuleInfo, err error) {
+	args := Reflection_ListModules_Helper.Args()
+
+	var body wire.Value
+	body, err = args.ToWire()
+	if err != nil {
+		return
+	}
+
+	body, err = envelope.SendContext(ctx, c.client, "listModules", body)
+	if err != nil {
+		return
+	}
+
+	var result Reflection_ListModules_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = Reflection_ListModules_Helper.UnwrapResponse(&result)
+	return
+}
@@ -0,0 +1,352 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package reflection
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+	"strings"
+)
+
+// Returns the source of the Thrift file for the module with the given
+// import path.
+type Reflection_GetIDL_Args struct {
+	ImportPath string `json:"importPath"`
+}
+
+func (v *Reflection_GetIDL_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueString(v.ImportPath), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *Reflection_GetIDL_Args) FromWire(w wire.Value) error {
+	var err error
+	importPathIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.ImportPath, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("Reflection_GetIDL_Args", "ImportPath", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				importPathIsSet = true
+			}
+		}
+	}
+	if !importPathIsSet {
+		wire.ObserveDecodeError("Reflection_GetIDL_Args", "ImportPath", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "Reflection_GetIDL_Args", Field: "ImportPath", ID: 1}
+	}
+	return nil
+}
+
+func (v *Reflection_GetIDL_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	fields[i] = fmt.Sprintf("ImportPath: %v", v.ImportPath)
+	i++
+	return fmt.Sprintf("Reflection_GetIDL_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *Reflection_GetIDL_Args) Equals(rhs *Reflection_GetIDL_Args) bool {
+	if !(v.ImportPath == rhs.ImportPath) {
+		return false
+	}
+	return true
+}
+
+func (v *Reflection_GetIDL_Args) GetImportPath() (o string) {
+	if v != nil {
+		o = v.ImportPath
+	}
+	return
+}
+
+func (v *Reflection_GetIDL_Args) MethodName() string {
+	return "getIDL"
+}
+
+func (v *Reflection_GetIDL_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+var Reflection_GetIDL_Helper = struct {
+	Args            func(importPath string) *Reflection_GetIDL_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*Reflection_GetIDL_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func(string, error) (*Reflection_GetIDL_Result, error)
+	UnwrapResponse  func(*Reflection_GetIDL_Result) (string, error)
+}{}
+
+func init() {
+	Reflection_GetIDL_Helper.Args = func(importPath string) *Reflection_GetIDL_Args {
+		return &Reflection_GetIDL_Args{ImportPath: importPath}
+	}
+	Reflection_GetIDL_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*Reflection_GetIDL_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args Reflection_GetIDL_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
+	Reflection_GetIDL_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		case *ModuleNotFoundError:
+			return true
+		default:
+			return false
+		}
+	}
+	Reflection_GetIDL_Helper.IsSafeException = func(error) bool {
+		return false
+	}
+	Reflection_GetIDL_Helper.WrapResponse = func(success string, err error) (*Reflection_GetIDL_Result, error) {
+		if err == nil {
+			return &Reflection_GetIDL_Result{Success: &success}, nil
+		}
+		switch e := err.(type) {
+		case *ModuleNotFoundError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for Reflection_GetIDL_Result.NotFound")
+			}
+			return &Reflection_GetIDL_Result{NotFound: e}, nil
+		}
+		return nil, err
+	}
+	Reflection_GetIDL_Helper.UnwrapResponse = func(result *Reflection_GetIDL_Result) (success string, err error) {
+		if result.NotFound != nil {
+			err = result.NotFound
+			return
+		}
+		if result.Success != nil {
+			success = *result.Success
+			return
+		}
+		err = errors.New("expected a non-void result")
+		return
+	}
+}
+
+type Reflection_GetIDL_Result struct {
+	Success  *string              `json:"success,omitempty"`
+	NotFound *ModuleNotFoundError `json:"notFound,omitempty"`
+}
+
+func (v *Reflection_GetIDL_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Success != nil {
+		w, err = wire.NewValueString(*(v.Success)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if v.NotFound != nil {
+		w, err = v.NotFound.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 1, Value: w}
+		i++
+	}
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("Reflection_GetIDL_Result should have exactly one field: got %v fields", i)
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ModuleNotFoundError_Read(w wire.Value) (*ModuleNotFoundError, error) {
+	var v ModuleNotFoundError
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func (v *Reflection_GetIDL_Result) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Success = &x
+				if err != nil {
+					wire.ObserveDecodeError("Reflection_GetIDL_Result", "Success", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 1:
+			if field.Value.Type() == wire.TStruct {
+				v.NotFound, err = _ModuleNotFoundError_Read(field.Value)
+				if err != nil {
+					wire.ObserveDecodeError("Reflection_GetIDL_Result", "NotFound", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.NotFound != nil {
+		count++
+	}
+	if count != 1 {
+		wire.ObserveDecodeError("Reflection_GetIDL_Result", "", wire.DecodeErrorInvalidUnion)
+		return fmt.Errorf("Reflection_GetIDL_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *Reflection_GetIDL_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", *(v.Success))
+		i++
+	}
+	if v.NotFound != nil {
+		fields[i] = fmt.Sprintf("NotFound: %v", v.NotFound)
+		i++
+	}
+	return fmt.Sprintf("Reflection_GetIDL_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *Reflection_GetIDL_Result) Equals(rhs *Reflection_GetIDL_Result) bool {
+	if !_String_EqualsPtr(v.Success, rhs.Success) {
+		return false
+	}
+	if !((v.NotFound == nil && rhs.NotFound == nil) || (v.NotFound != nil && rhs.NotFound != nil && v.NotFound.Equals(rhs.NotFound))) {
+		return false
+	}
+	return true
+}
+
+func (v *Reflection_GetIDL_Result) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.NotFound != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("Reflection_GetIDL_Result should have exactly one field: got %v fields", count)
+	}
+	type plain Reflection_GetIDL_Result
+	return json.Marshal((*plain)(v))
+}
+
+func (v *Reflection_GetIDL_Result) UnmarshalJSON(text []byte) error {
+	type plain Reflection_GetIDL_Result
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if v.NotFound != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("Reflection_GetIDL_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *Reflection_GetIDL_Result) GetSuccess() (o string) {
+	if v != nil && v.Success != nil {
+		return *v.Success
+	}
+	return
+}
+
+func (v *Reflection_GetIDL_Result) GetNotFound() (o *ModuleNotFoundError) {
+	if v != nil && v.NotFound != nil {
+		return v.NotFound
+	}
+	return
+}
+
+func (v *Reflection_GetIDL_Result) MethodName() string {
+	return "getIDL"
+}
+
+func (v *Reflection_GetIDL_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+func Reflection_GetIDL_NewResultSuccess(success string) *Reflection_GetIDL_Result {
+	return &Reflection_GetIDL_Result{Success: &success}
+}
+
+func Reflection_GetIDL_NewResultError(err error) (*Reflection_GetIDL_Result, error) {
+	if err == nil {
+		return nil, errors.New("Reflection_GetIDL_NewResultError received a nil error")
+	}
+	switch e := err.(type) {
+	case *ModuleNotFoundError:
+		if e == nil {
+			return nil, errors.New("Reflection_GetIDL_NewResultError received non-nil error type with nil value for Reflection_GetIDL_Result.NotFound")
+		}
+		return &Reflection_GetIDL_Result{NotFound: e}, nil
+	}
+	return nil, err
+}
@@ -0,0 +1,135 @@
+// Code generated by thriftrw --generate-plugin-api
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package reflection
+
+import (
+	"context"
+	envelope2 "go.uber.org/thriftrw/envelope"
+	"go.uber.org/thriftrw/internal/envelope"
+	"go.uber.org/thriftrw/wire"
+)
+
+// ReflectionHandler serves an implementation of the Reflection service.
+type ReflectionHandler struct {
+	impl ReflectionContext
+
+	// dispatch with the middleware applied
+	handle envelope2.HandlerFunc
+}
+
+// NewReflectionHandler builds a new Reflection handler.
+//
+// The given middleware is invoked around every function of the service, in
+// order.
+func NewReflectionHandler(service Reflection, middleware ...envelope2.Middleware) ReflectionHandler {
+	return NewReflectionContextHandler(_Reflection_withoutContext{service}, middleware...)
+}
+
+// NewReflectionContextHandler builds a new Reflection handler which
+// passes the context of every request to the given implementation.
+//
+// Requests are rejected with the context's error if it is done before the
+// implementation is called.
+func NewReflectionContextHandler(service ReflectionContext, middleware ...envelope2.Middleware) ReflectionHandler {
+	h := ReflectionHandler{
+		impl: service,
+	}
+	h.handle = envelope2.ApplyMiddleware(h.dispatch, middleware...)
+	return h
+}
+
+// Handle receives and handles a request for the Reflection service.
+func (h ReflectionHandler) Handle(name string, reqValue wire.Value) (wire.Value, error) {
+	return h.HandleContext(context.Background(), name, reqValue)
+}
+
+// HandleContext receives and handles a request for the Reflection
+// service, passing the given context to the middleware.
+//
+// Requests multiplexed for the Reflection service ("Reflection:method")
+// are accepted as well.
+func (h ReflectionHandler) HandleContext(ctx context.Context, name string, reqValue wire.Value) (wire.Value, error) {
+	name = envelope2.TrimServiceName("Reflection", name)
+	switch name {
+
+	case "getIDL":
+		return h.handle(ctx, name, reqValue)
+
+	case "listModules":
+		return h.handle(ctx, name, reqValue)
+
+	default:
+
+		return wire.Value{}, envelope.ErrUnknownMethod(name)
+
+	}
+}
+
+func (h ReflectionHandler) dispatch(ctx context.Context, name string, reqValue wire.Value) (wire.Value, error) {
+	switch name {
+
+	case "getIDL":
+
+		var args Reflection_GetIDL_Args
+		if err := args.FromWire(reqValue); err != nil {
+			return wire.Value{}, err
+		}
+
+		if err := ctx.Err(); err != nil {
+			return wire.Value{}, err
+		}
+
+		result, err := Reflection_GetIDL_Helper.WrapResponse(
+			h.impl.GetIDL(ctx, args.ImportPath),
+		)
+		if err != nil {
+			return wire.Value{}, err
+		}
+
+		return result.ToWire()
+
+	case "listModules":
+
+		var args Reflection_ListModules_Args
+		if err := args.FromWire(reqValue); err != nil {
+			return wire.Value{}, err
+		}
+
+		if err := ctx.Err(); err != nil {
+			return wire.Value{}, err
+		}
+
+		result, err := Reflection_ListModules_Helper.WrapResponse(
+			h.impl.ListModules(ctx),
+		)
+		if err != nil {
+			return wire.Value{}, err
+		}
+
+		return result.ToWire()
+
+	default:
+		return wire.Value{}, envelope.ErrUnknownMethod(name)
+	}
+}
@@ -0,0 +1,312 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package reflection
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+	"strings"
+)
+
+// Lists all registered modules, sorted by import path.
+type Reflection_ListModules_Args struct{}
+
+func (v *Reflection_ListModules_Args) ToWire() (wire.Value, error) {
+	var (
+		fields [0]wire.Field
+		i      int = 0
+	)
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *Reflection_ListModules_Args) FromWire(w wire.Value) error {
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		}
+	}
+	return nil
+}
+
+func (v *Reflection_ListModules_Args) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [0]string
+	i := 0
+	return fmt.Sprintf("Reflection_ListModules_Args{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *Reflection_ListModules_Args) Equals(rhs *Reflection_ListModules_Args) bool {
+	return true
+}
+
+func (v *Reflection_ListModules_Args) MethodName() string {
+	return "listModules"
+}
+
+func (v *Reflection_ListModules_Args) EnvelopeType() wire.EnvelopeType {
+	return wire.Call
+}
+
+var Reflection_ListModules_Helper = struct {
+	Args            func() *Reflection_ListModules_Args
+	DecodeArgs      func(protocol.Protocol, []byte) (*Reflection_ListModules_Args, error)
+	IsException     func(error) bool
+	IsSafeException func(error) bool
+	WrapResponse    func([]*ModuleInfo, error) (*Reflection_ListModules_Result, error)
+	UnwrapResponse  func(*Reflection_ListModules_Result) ([]*ModuleInfo, error)
+}{}
+
+func init() {
+	Reflection_ListModules_Helper.Args = func() *Reflection_ListModules_Args {
+		return &Reflection_ListModules_Args{}
+	}
+	Reflection_ListModules_Helper.DecodeArgs = func(p protocol.Protocol, body []byte) (*Reflection_ListModules_Args, error) {
+		w, err := p.Decode(bytes.NewReader(body), wire.TStruct)
+		if err != nil {
+			return nil, err
+		}
+		var args Reflection_ListModules_Args
+		if err := args.FromWire(w); err != nil {
+			return nil, err
+		}
+		return &args, nil
+	}
+	Reflection_ListModules_Helper.IsException = func(err error) bool {
+		switch err.(type) {
+		default:
+			return false
+		}
+	}
+	Reflection_ListModules_Helper.IsSafeException = func(error) bool {
+		return false
+	}
+	Reflection_ListModules_Helper.WrapResponse = func(success []*ModuleInfo, err error) (*Reflection_ListModules_Result, error) {
+		if err == nil {
+			return &Reflection_ListModules_Result{Success: success}, nil
+		}
+		return nil, err
+	}
+	Reflection_ListModules_Helper.UnwrapResponse = func(result *Reflection_ListModules_Result) (success []*ModuleInfo, err error) {
+		if result.Success != nil {
+			success = result.Success
+			return
+		}
+		err = errors.New("expected a non-void result")
+		return
+	}
+}
+
+type Reflection_ListModules_Result struct {
+	Success []*ModuleInfo `json:"success"`
+}
+
+type _List_ModuleInfo_ValueList []*ModuleInfo
+
+func (v _List_ModuleInfo_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_ModuleInfo_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_ModuleInfo_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_ModuleInfo_ValueList) Close() {
+}
+
+func (v *Reflection_ListModules_Result) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	if v.Success != nil {
+		w, err = wire.NewValueList(_List_ModuleInfo_ValueList(v.Success)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 0, Value: w}
+		i++
+	}
+	if i != 1 {
+		return wire.Value{}, fmt.Errorf("Reflection_ListModules_Result should have exactly one field: got %v fields", i)
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ModuleInfo_Read(w wire.Value) (*ModuleInfo, error) {
+	var v ModuleInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_ModuleInfo_Read(l wire.ValueList) ([]*ModuleInfo, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+	o := make([]*ModuleInfo, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _ModuleInfo_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func (v *Reflection_ListModules_Result) FromWire(w wire.Value) error {
+	var err error
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 0:
+			if field.Value.Type() == wire.TList {
+				v.Success, err = _List_ModuleInfo_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("Reflection_ListModules_Result", "Success", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		wire.ObserveDecodeError("Reflection_ListModules_Result", "", wire.DecodeErrorInvalidUnion)
+		return fmt.Errorf("Reflection_ListModules_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *Reflection_ListModules_Result) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	if v.Success != nil {
+		fields[i] = fmt.Sprintf("Success: %v", v.Success)
+		i++
+	}
+	return fmt.Sprintf("Reflection_ListModules_Result{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_ModuleInfo_Equals(lhs, rhs []*ModuleInfo) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *Reflection_ListModules_Result) Equals(rhs *Reflection_ListModules_Result) bool {
+	if !((v.Success == nil && rhs.Success == nil) || (v.Success != nil && rhs.Success != nil && _List_ModuleInfo_Equals(v.Success, rhs.Success))) {
+		return false
+	}
+	return true
+}
+
+func (v *Reflection_ListModules_Result) MarshalJSON() ([]byte, error) {
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return nil, fmt.Errorf("Reflection_ListModules_Result should have exactly one field: got %v fields", count)
+	}
+	type plain Reflection_ListModules_Result
+	return json.Marshal((*plain)(v))
+}
+
+func (v *Reflection_ListModules_Result) UnmarshalJSON(text []byte) error {
+	type plain Reflection_ListModules_Result
+	if err := json.Unmarshal(text, (*plain)(v)); err != nil {
+		return err
+	}
+	count := 0
+	if v.Success != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("Reflection_ListModules_Result should have exactly one field: got %v fields", count)
+	}
+	return nil
+}
+
+func (v *Reflection_ListModules_Result) GetSuccess() (o []*ModuleInfo) {
+	if v != nil && v.Success != nil {
+		return v.Success
+	}
+	return
+}
+
+func (v *Reflection_ListModules_Result) MethodName() string {
+	return "listModules"
+}
+
+func (v *Reflection_ListModules_Result) EnvelopeType() wire.EnvelopeType {
+	return wire.Reply
+}
+
+func Reflection_ListModules_NewResultSuccess(success []*ModuleInfo) *Reflection_ListModules_Result {
+	return &Reflection_ListModules_Result{Success: success}
+}
+
+func Reflection_ListModules_NewResultError(err error) (*Reflection_ListModules_Result, error) {
+	if err == nil {
+		return nil, errors.New("Reflection_ListModules_NewResultError received a nil error")
+	}
+	return nil, err
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package reflection implements a service which exposes the Thrift modules
+// registered with thriftreflect to clients.
+//
+// Servers serve the Reflection service alongside their own services so that
+// tools may discover the methods and types a server supports without having
+// the IDL at hand. Only modules generated with embedded IDLs are registered;
+// descriptors of the types and services in each module are available only if
+// the code was generated with --reflection.
+package reflection
+
+import (
+	"go.uber.org/thriftrw/envelope"
+	intenvelope "go.uber.org/thriftrw/internal/envelope"
+	"go.uber.org/thriftrw/internal/multiplex"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/thriftreflect"
+)
+
+// ServiceName is the name of the Reflection service. Requests multiplexed
+// for this name may be dispatched to it with envelope.Mux.
+const ServiceName = "Reflection"
+
+// NewService returns an implementation of the Reflection service backed by
+// the modules registered with thriftreflect.
+func NewService() Reflection {
+	return registryService{}
+}
+
+type registryService struct{}
+
+func (registryService) ListModules() ([]*ModuleInfo, error) {
+	modules := thriftreflect.Modules()
+	infos := make([]*ModuleInfo, len(modules))
+	for i, m := range modules {
+		infos[i] = moduleInfo(m)
+	}
+	return infos, nil
+}
+
+func (registryService) GetIDL(importPath string) (string, error) {
+	m, ok := thriftreflect.LookupModule(importPath)
+	if !ok {
+		return "", &ModuleNotFoundError{ImportPath: importPath}
+	}
+	return m.Raw, nil
+}
+
+func moduleInfo(m *thriftreflect.ThriftModule) *ModuleInfo {
+	info := &ModuleInfo{
+		Name:       m.Name,
+		ImportPath: m.Package,
+		FilePath:   m.FilePath,
+		Sha1:       m.SHA1,
+		Includes:   make([]string, len(m.Includes)),
+	}
+	for i, inc := range m.Includes {
+		info.Includes[i] = inc.Package
+	}
+
+	d := m.Descriptor
+	if d == nil {
+		return info
+	}
+
+	info.Types = make([]*TypeInfo, len(d.Types))
+	for i, t := range d.Types {
+		info.Types[i] = &TypeInfo{Name: t.Name, GoName: t.GoName, Kind: t.Kind}
+	}
+
+	info.Services = make([]*ServiceInfo, len(d.Services))
+	for i, s := range d.Services {
+		service := &ServiceInfo{
+			Name:    s.Name,
+			Methods: make([]*MethodInfo, len(s.Methods)),
+		}
+		if s.Parent != "" {
+			parent := s.Parent
+			service.Parent = &parent
+		}
+		for j, method := range s.Methods {
+			service.Methods[j] = &MethodInfo{Name: method.Name, OneWay: method.OneWay}
+		}
+		info.Services[i] = service
+	}
+	return info
+}
+
+// Server handles enveloped requests to a Reflection service.
+type Server struct {
+	s intenvelope.Server
+}
+
+// NewServer builds a Server which decodes requests to and encodes responses
+// from the given Reflection service with the given protocol. The given
+// middleware is invoked around every request.
+func NewServer(p protocol.Protocol, service Reflection, middleware ...envelope.Middleware) Server {
+	return Server{s: intenvelope.NewServer(p, NewReflectionHandler(service, middleware...))}
+}
+
+// Handle handles a single enveloped request and returns the enveloped
+// response.
+func (s Server) Handle(request []byte) ([]byte, error) {
+	return s.s.Handle(request)
+}
+
+// Transport sends enveloped requests to a server and returns the enveloped
+// responses.
+type Transport interface {
+	Send([]byte) ([]byte, error)
+}
+
+// NewClient builds a Reflection client which sends requests over the given
+// transport, encoding them with the given protocol.
+func NewClient(p protocol.Protocol, t Transport) Reflection {
+	return NewReflectionClient(intenvelope.NewClient(p, t))
+}
+
+// NewMultiplexedClient is like NewClient but it addresses requests to the
+// Reflection service by name so that they may be sent to a server that
+// multiplexes multiple services.
+//
+// Servers built with NewServer accept both multiplexed and regular requests.
+func NewMultiplexedClient(p protocol.Protocol, t Transport) Reflection {
+	return NewReflectionClient(multiplex.NewClient(ServiceName, intenvelope.NewClient(p, t)))
+}
+
+// FetchIDLs retrieves the sources of all Thrift files known to the server
+// behind the given client, keyed by their paths relative to the Thrift root.
+//
+// Writing these files out under a common directory reproduces the layout the
+// server was generated from so that they may be compiled.
+func FetchIDLs(c Reflection) (map[string]string, error) {
+	modules, err := c.ListModules()
+	if err != nil {
+		return nil, err
+	}
+
+	idls := make(map[string]string, len(modules))
+	for _, m := range modules {
+		idls[m.FilePath], err = c.GetIDL(m.ImportPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return idls, nil
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package reflection
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/thriftrw/envelope"
+	intenvelope "go.uber.org/thriftrw/internal/envelope"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/ptr"
+	"go.uber.org/thriftrw/thriftreflect"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type transportFunc func([]byte) ([]byte, error)
+
+func (f transportFunc) Send(b []byte) ([]byte, error) { return f(b) }
+
+func TestReflection(t *testing.T) {
+	base := &thriftreflect.ThriftModule{
+		Name:     "base",
+		Package:  "example.com/reflection/base",
+		FilePath: "base.thrift",
+		SHA1:     "1",
+		Raw:      "service Base {}",
+		Descriptor: &thriftreflect.Descriptor{
+			Services: []thriftreflect.ServiceDescriptor{{Name: "Base"}},
+		},
+	}
+	foo := &thriftreflect.ThriftModule{
+		Name:     "foo",
+		Package:  "example.com/reflection/foo",
+		FilePath: "foo/foo.thrift",
+		SHA1:     "2",
+		Raw:      `include "../base.thrift"`,
+		Includes: []*thriftreflect.ThriftModule{base},
+		Descriptor: &thriftreflect.Descriptor{
+			Types: []thriftreflect.TypeDescriptor{
+				{Name: "Bar", GoName: "Bar", Kind: "struct"},
+			},
+			Services: []thriftreflect.ServiceDescriptor{
+				{
+					Name:   "Foo",
+					Parent: "base.Base",
+					Methods: []thriftreflect.MethodDescriptor{
+						{Name: "ping"},
+						{Name: "fire", OneWay: true},
+					},
+				},
+			},
+		},
+	}
+	thriftreflect.Register(foo)
+
+	server := NewServer(protocol.Binary, NewService())
+	client := NewClient(protocol.Binary, transportFunc(server.Handle))
+
+	modules, err := client.ListModules()
+	require.NoError(t, err)
+
+	byPath := make(map[string]*ModuleInfo)
+	for _, m := range modules {
+		byPath[m.ImportPath] = m
+	}
+
+	assert.Equal(t, &ModuleInfo{
+		Name:       "foo",
+		ImportPath: "example.com/reflection/foo",
+		FilePath:   "foo/foo.thrift",
+		Sha1:       "2",
+		Includes:   []string{"example.com/reflection/base"},
+		Types: []*TypeInfo{
+			{Name: "Bar", GoName: "Bar", Kind: "struct"},
+		},
+		Services: []*ServiceInfo{
+			{
+				Name:   "Foo",
+				Parent: ptr.String("base.Base"),
+				Methods: []*MethodInfo{
+					{Name: "ping"},
+					{Name: "fire", OneWay: true},
+				},
+			},
+		},
+	}, byPath["example.com/reflection/foo"])
+
+	if assert.Contains(t, byPath, "example.com/reflection/base") {
+		services := byPath["example.com/reflection/base"].Services
+		if assert.Len(t, services, 1) {
+			assert.Nil(t, services[0].Parent, "parent must be unset")
+		}
+	}
+
+	idl, err := client.GetIDL("example.com/reflection/base")
+	require.NoError(t, err)
+	assert.Equal(t, "service Base {}", idl)
+
+	_, err = client.GetIDL("example.com/reflection/bar")
+	assert.Equal(t, &ModuleNotFoundError{ImportPath: "example.com/reflection/bar"}, err)
+
+	idls, err := FetchIDLs(client)
+	require.NoError(t, err)
+	assert.Equal(t, "service Base {}", idls["base.thrift"])
+	assert.Equal(t, `include "../base.thrift"`, idls["foo/foo.thrift"])
+}
+
+func TestReflectionWithoutDescriptors(t *testing.T) {
+	info := moduleInfo(&thriftreflect.ThriftModule{
+		Name:    "plain",
+		Package: "example.com/reflection/plain",
+	})
+	assert.Nil(t, info.Types)
+	assert.Nil(t, info.Services)
+	assert.Empty(t, info.Includes)
+}
+
+func TestReflectionMiddleware(t *testing.T) {
+	var methods []string
+	middleware := func(ctx context.Context, method string, body wire.Value, next envelope.HandlerFunc) (wire.Value, error) {
+		methods = append(methods, method)
+		if method == "getIDL" {
+			return wire.Value{}, errors.New("permission denied")
+		}
+		return next(ctx, method, body)
+	}
+
+	server := NewServer(protocol.Binary, NewService(), middleware)
+	client := NewClient(protocol.Binary, transportFunc(server.Handle))
+
+	_, err := client.ListModules()
+	assert.NoError(t, err)
+
+	_, err = client.GetIDL("example.com/reflection/foo")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "permission denied")
+	}
+
+	assert.Equal(t, []string{"listModules", "getIDL"}, methods)
+}
+
+func TestReflectionMultiplexed(t *testing.T) {
+	thriftreflect.Register(&thriftreflect.ThriftModule{
+		Name:    "multiplexed",
+		Package: "example.com/reflection/multiplexed",
+		Raw:     "service Multiplexed {}",
+	})
+
+	var names []string
+	server := NewServer(protocol.Binary, NewService())
+	client := NewMultiplexedClient(protocol.Binary, transportFunc(func(req []byte) ([]byte, error) {
+		env, err := envelope.Decode(protocol.Binary, bytes.NewReader(req))
+		require.NoError(t, err)
+		names = append(names, env.Name)
+		return server.Handle(req)
+	}))
+
+	idl, err := client.GetIDL("example.com/reflection/multiplexed")
+	require.NoError(t, err)
+	assert.Equal(t, "service Multiplexed {}", idl)
+	assert.Equal(t, []string{"Reflection:getIDL"}, names)
+
+	mux := envelope.NewMux()
+	mux.Register(ServiceName, NewReflectionHandler(NewService()).HandleContext)
+
+	args, err := Reflection_GetIDL_Helper.Args("example.com/reflection/multiplexed").ToWire()
+	require.NoError(t, err)
+
+	body, err := mux.Handle(context.Background(), "Reflection:getIDL", args)
+	require.NoError(t, err)
+
+	var result Reflection_GetIDL_Result
+	require.NoError(t, result.FromWire(body))
+	idl, err = Reflection_GetIDL_Helper.UnwrapResponse(&result)
+	require.NoError(t, err)
+	assert.Equal(t, "service Multiplexed {}", idl)
+}
+
+func TestReflectionContext(t *testing.T) {
+	h := NewReflectionHandler(NewService())
+	args, err := Reflection_ListModules_Helper.Args().ToWire()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = h.HandleContext(ctx, "listModules", args)
+	assert.Equal(t, context.Canceled, err, "canceled requests must be rejected")
+
+	server := NewServer(protocol.Binary, NewService())
+	client := NewReflectionContextClient(intenvelope.NewClient(protocol.Binary, transportFunc(server.Handle)))
+
+	_, err = client.ListModules(context.Background())
+	require.NoError(t, err)
+
+	_, err = client.ListModules(ctx)
+	assert.Equal(t, context.Canceled, err, "canceled requests must not be sent")
+}
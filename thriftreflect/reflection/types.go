@@ -0,0 +1,1019 @@
+// Code generated by thriftrw v1.4.0
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package reflection
+
+import (
+	"fmt"
+	"go.uber.org/thriftrw/wire"
+	"strings"
+)
+
+// MethodInfo describes a function of a service.
+type MethodInfo struct {
+	Name   string `json:"name"`
+	OneWay bool   `json:"oneWay"`
+}
+
+func (v *MethodInfo) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueString(v.Name), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	w, err = wire.NewValueBool(v.OneWay), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 2, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *MethodInfo) FromWire(w wire.Value) error {
+	var err error
+	nameIsSet := false
+	oneWayIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.Name, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("MethodInfo", "Name", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				nameIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TBool {
+				v.OneWay, err = field.Value.GetBool(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("MethodInfo", "OneWay", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				oneWayIsSet = true
+			}
+		}
+	}
+	if !nameIsSet {
+		wire.ObserveDecodeError("MethodInfo", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "MethodInfo", Field: "Name", ID: 1}
+	}
+	if !oneWayIsSet {
+		wire.ObserveDecodeError("MethodInfo", "OneWay", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "MethodInfo", Field: "OneWay", ID: 2}
+	}
+	return nil
+}
+
+func (v *MethodInfo) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [2]string
+	i := 0
+	fields[i] = fmt.Sprintf("Name: %v", v.Name)
+	i++
+	fields[i] = fmt.Sprintf("OneWay: %v", v.OneWay)
+	i++
+	return fmt.Sprintf("MethodInfo{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *MethodInfo) Equals(rhs *MethodInfo) bool {
+	if !(v.Name == rhs.Name) {
+		return false
+	}
+	if !(v.OneWay == rhs.OneWay) {
+		return false
+	}
+	return true
+}
+
+func (v *MethodInfo) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
+}
+
+func (v *MethodInfo) GetOneWay() (o bool) {
+	if v != nil {
+		o = v.OneWay
+	}
+	return
+}
+
+// ModuleInfo describes a Thrift file linked into the server.
+type ModuleInfo struct {
+	Name string `json:"name"`
+	// Import path of the Go package generated for this file.
+	ImportPath string `json:"importPath"`
+	// Path to the Thrift file relative to the Thrift root.
+	FilePath string `json:"filePath"`
+	Sha1     string `json:"sha1"`
+	// Import paths of the modules included by this file.
+	Includes []string `json:"includes"`
+	// Types and services defined in this file. These are present only if
+	// the code was generated with --reflection.
+	Types    []*TypeInfo    `json:"types"`
+	Services []*ServiceInfo `json:"services"`
+}
+
+type _List_String_ValueList []string
+
+func (v _List_String_ValueList) ForEach(f func(wire.Value) error) error {
+	for _, x := range v {
+		w, err := wire.NewValueString(x), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_String_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_String_ValueList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_List_String_ValueList) Close() {
+}
+
+type _List_TypeInfo_ValueList []*TypeInfo
+
+func (v _List_TypeInfo_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_TypeInfo_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_TypeInfo_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_TypeInfo_ValueList) Close() {
+}
+
+type _List_ServiceInfo_ValueList []*ServiceInfo
+
+func (v _List_ServiceInfo_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_ServiceInfo_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_ServiceInfo_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_ServiceInfo_ValueList) Close() {
+}
+
+func (v *ModuleInfo) ToWire() (wire.Value, error) {
+	var (
+		fields [7]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueString(v.Name), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	w, err = wire.NewValueString(v.ImportPath), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 2, Value: w}
+	i++
+	w, err = wire.NewValueString(v.FilePath), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 3, Value: w}
+	i++
+	w, err = wire.NewValueString(v.Sha1), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 4, Value: w}
+	i++
+	if v.Includes == nil {
+		return w, wire.NilRequiredFieldError{Struct: "ModuleInfo", Field: "Includes", ID: 5}
+	}
+	w, err = wire.NewValueList(_List_String_ValueList(v.Includes)), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 5, Value: w}
+	i++
+	if v.Types != nil {
+		w, err = wire.NewValueList(_List_TypeInfo_ValueList(v.Types)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 6, Value: w}
+		i++
+	}
+	if v.Services != nil {
+		w, err = wire.NewValueList(_List_ServiceInfo_ValueList(v.Services)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 7, Value: w}
+		i++
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _List_String_Read(l wire.ValueList) ([]string, error) {
+	if l.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+	o := make([]string, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := x.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _TypeInfo_Read(w wire.Value) (*TypeInfo, error) {
+	var v TypeInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_TypeInfo_Read(l wire.ValueList) ([]*TypeInfo, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+	o := make([]*TypeInfo, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _TypeInfo_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _ServiceInfo_Read(w wire.Value) (*ServiceInfo, error) {
+	var v ServiceInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_ServiceInfo_Read(l wire.ValueList) ([]*ServiceInfo, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+	o := make([]*ServiceInfo, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _ServiceInfo_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func (v *ModuleInfo) FromWire(w wire.Value) error {
+	var err error
+	nameIsSet := false
+	importPathIsSet := false
+	filePathIsSet := false
+	sha1IsSet := false
+	includesIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.Name, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("ModuleInfo", "Name", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				nameIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TBinary {
+				v.ImportPath, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("ModuleInfo", "ImportPath", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				importPathIsSet = true
+			}
+		case 3:
+			if field.Value.Type() == wire.TBinary {
+				v.FilePath, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("ModuleInfo", "FilePath", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				filePathIsSet = true
+			}
+		case 4:
+			if field.Value.Type() == wire.TBinary {
+				v.Sha1, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("ModuleInfo", "Sha1", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				sha1IsSet = true
+			}
+		case 5:
+			if field.Value.Type() == wire.TList {
+				v.Includes, err = _List_String_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("ModuleInfo", "Includes", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				includesIsSet = true
+			}
+		case 6:
+			if field.Value.Type() == wire.TList {
+				v.Types, err = _List_TypeInfo_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("ModuleInfo", "Types", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 7:
+			if field.Value.Type() == wire.TList {
+				v.Services, err = _List_ServiceInfo_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("ModuleInfo", "Services", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		}
+	}
+	if !nameIsSet {
+		wire.ObserveDecodeError("ModuleInfo", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "ModuleInfo", Field: "Name", ID: 1}
+	}
+	if !importPathIsSet {
+		wire.ObserveDecodeError("ModuleInfo", "ImportPath", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "ModuleInfo", Field: "ImportPath", ID: 2}
+	}
+	if !filePathIsSet {
+		wire.ObserveDecodeError("ModuleInfo", "FilePath", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "ModuleInfo", Field: "FilePath", ID: 3}
+	}
+	if !sha1IsSet {
+		wire.ObserveDecodeError("ModuleInfo", "Sha1", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "ModuleInfo", Field: "Sha1", ID: 4}
+	}
+	if !includesIsSet {
+		wire.ObserveDecodeError("ModuleInfo", "Includes", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "ModuleInfo", Field: "Includes", ID: 5}
+	}
+	return nil
+}
+
+func (v *ModuleInfo) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [7]string
+	i := 0
+	fields[i] = fmt.Sprintf("Name: %v", v.Name)
+	i++
+	fields[i] = fmt.Sprintf("ImportPath: %v", v.ImportPath)
+	i++
+	fields[i] = fmt.Sprintf("FilePath: %v", v.FilePath)
+	i++
+	fields[i] = fmt.Sprintf("Sha1: %v", v.Sha1)
+	i++
+	fields[i] = fmt.Sprintf("Includes: %v", v.Includes)
+	i++
+	if v.Types != nil {
+		fields[i] = fmt.Sprintf("Types: %v", v.Types)
+		i++
+	}
+	if v.Services != nil {
+		fields[i] = fmt.Sprintf("Services: %v", v.Services)
+		i++
+	}
+	return fmt.Sprintf("ModuleInfo{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_String_Equals(lhs, rhs []string) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func _List_TypeInfo_Equals(lhs, rhs []*TypeInfo) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func _List_ServiceInfo_Equals(lhs, rhs []*ServiceInfo) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *ModuleInfo) Equals(rhs *ModuleInfo) bool {
+	if !(v.Name == rhs.Name) {
+		return false
+	}
+	if !(v.ImportPath == rhs.ImportPath) {
+		return false
+	}
+	if !(v.FilePath == rhs.FilePath) {
+		return false
+	}
+	if !(v.Sha1 == rhs.Sha1) {
+		return false
+	}
+	if !_List_String_Equals(v.Includes, rhs.Includes) {
+		return false
+	}
+	if !((v.Types == nil && rhs.Types == nil) || (v.Types != nil && rhs.Types != nil && _List_TypeInfo_Equals(v.Types, rhs.Types))) {
+		return false
+	}
+	if !((v.Services == nil && rhs.Services == nil) || (v.Services != nil && rhs.Services != nil && _List_ServiceInfo_Equals(v.Services, rhs.Services))) {
+		return false
+	}
+	return true
+}
+
+func (v *ModuleInfo) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
+}
+
+func (v *ModuleInfo) GetImportPath() (o string) {
+	if v != nil {
+		o = v.ImportPath
+	}
+	return
+}
+
+func (v *ModuleInfo) GetFilePath() (o string) {
+	if v != nil {
+		o = v.FilePath
+	}
+	return
+}
+
+func (v *ModuleInfo) GetSha1() (o string) {
+	if v != nil {
+		o = v.Sha1
+	}
+	return
+}
+
+func (v *ModuleInfo) GetIncludes() (o []string) {
+	if v != nil {
+		o = v.Includes
+	}
+	return
+}
+
+func (v *ModuleInfo) GetTypes() (o []*TypeInfo) {
+	if v != nil && v.Types != nil {
+		return v.Types
+	}
+	return
+}
+
+func (v *ModuleInfo) GetServices() (o []*ServiceInfo) {
+	if v != nil && v.Services != nil {
+		return v.Services
+	}
+	return
+}
+
+// ModuleNotFoundError is raised if a module with the requested import path
+// was not registered.
+type ModuleNotFoundError struct {
+	ImportPath string `json:"importPath"`
+}
+
+func (v *ModuleNotFoundError) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueString(v.ImportPath), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *ModuleNotFoundError) FromWire(w wire.Value) error {
+	var err error
+	importPathIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.ImportPath, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("ModuleNotFoundError", "ImportPath", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				importPathIsSet = true
+			}
+		}
+	}
+	if !importPathIsSet {
+		wire.ObserveDecodeError("ModuleNotFoundError", "ImportPath", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "ModuleNotFoundError", Field: "ImportPath", ID: 1}
+	}
+	return nil
+}
+
+func (v *ModuleNotFoundError) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [1]string
+	i := 0
+	fields[i] = fmt.Sprintf("ImportPath: %v", v.ImportPath)
+	i++
+	return fmt.Sprintf("ModuleNotFoundError{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *ModuleNotFoundError) Equals(rhs *ModuleNotFoundError) bool {
+	if !(v.ImportPath == rhs.ImportPath) {
+		return false
+	}
+	return true
+}
+
+func (v *ModuleNotFoundError) GetImportPath() (o string) {
+	if v != nil {
+		o = v.ImportPath
+	}
+	return
+}
+
+func (v *ModuleNotFoundError) Error() string {
+	return v.String()
+}
+
+// ServiceInfo describes a service.
+type ServiceInfo struct {
+	Name string `json:"name"`
+	// Name of the service this service inherits from, if any.
+	Parent *string `json:"parent,omitempty"`
+	// Functions defined in this service, excluding inherited functions.
+	Methods []*MethodInfo `json:"methods"`
+}
+
+type _List_MethodInfo_ValueList []*MethodInfo
+
+func (v _List_MethodInfo_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_MethodInfo_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_MethodInfo_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_MethodInfo_ValueList) Close() {
+}
+
+func (v *ServiceInfo) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueString(v.Name), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	if v.Parent != nil {
+		w, err = wire.NewValueString(*(v.Parent)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.Methods == nil {
+		return w, wire.NilRequiredFieldError{Struct: "ServiceInfo", Field: "Methods", ID: 3}
+	}
+	w, err = wire.NewValueList(_List_MethodInfo_ValueList(v.Methods)), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 3, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _MethodInfo_Read(w wire.Value) (*MethodInfo, error) {
+	var v MethodInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_MethodInfo_Read(l wire.ValueList) ([]*MethodInfo, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+	o := make([]*MethodInfo, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _MethodInfo_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func (v *ServiceInfo) FromWire(w wire.Value) error {
+	var err error
+	nameIsSet := false
+	methodsIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.Name, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("ServiceInfo", "Name", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				nameIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Parent = &x
+				if err != nil {
+					wire.ObserveDecodeError("ServiceInfo", "Parent", wire.DecodeErrorInvalidValue)
+					return err
+				}
+			}
+		case 3:
+			if field.Value.Type() == wire.TList {
+				v.Methods, err = _List_MethodInfo_Read(field.Value.GetList())
+				if err != nil {
+					wire.ObserveDecodeError("ServiceInfo", "Methods", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				methodsIsSet = true
+			}
+		}
+	}
+	if !nameIsSet {
+		wire.ObserveDecodeError("ServiceInfo", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "ServiceInfo", Field: "Name", ID: 1}
+	}
+	if !methodsIsSet {
+		wire.ObserveDecodeError("ServiceInfo", "Methods", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "ServiceInfo", Field: "Methods", ID: 3}
+	}
+	return nil
+}
+
+func (v *ServiceInfo) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [3]string
+	i := 0
+	fields[i] = fmt.Sprintf("Name: %v", v.Name)
+	i++
+	if v.Parent != nil {
+		fields[i] = fmt.Sprintf("Parent: %v", *(v.Parent))
+		i++
+	}
+	fields[i] = fmt.Sprintf("Methods: %v", v.Methods)
+	i++
+	return fmt.Sprintf("ServiceInfo{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _String_EqualsPtr(lhs, rhs *string) bool {
+	if lhs != nil && rhs != nil {
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _List_MethodInfo_Equals(lhs, rhs []*MethodInfo) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *ServiceInfo) Equals(rhs *ServiceInfo) bool {
+	if !(v.Name == rhs.Name) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Parent, rhs.Parent) {
+		return false
+	}
+	if !_List_MethodInfo_Equals(v.Methods, rhs.Methods) {
+		return false
+	}
+	return true
+}
+
+func (v *ServiceInfo) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
+}
+
+func (v *ServiceInfo) GetParent() (o string) {
+	if v != nil && v.Parent != nil {
+		return *v.Parent
+	}
+	return
+}
+
+func (v *ServiceInfo) GetMethods() (o []*MethodInfo) {
+	if v != nil {
+		o = v.Methods
+	}
+	return
+}
+
+// TypeInfo describes a user-defined type.
+type TypeInfo struct {
+	// Name of the type in the Thrift file.
+	Name string `json:"name"`
+	// Name of the generated Go type.
+	GoName string `json:"goName"`
+	// One of "enum", "struct", "union", "exception", or "typedef".
+	Kind string `json:"kind"`
+}
+
+func (v *TypeInfo) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+	w, err = wire.NewValueString(v.Name), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 1, Value: w}
+	i++
+	w, err = wire.NewValueString(v.GoName), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 2, Value: w}
+	i++
+	w, err = wire.NewValueString(v.Kind), error(nil)
+	if err != nil {
+		return w, err
+	}
+	fields[i] = wire.Field{ID: 3, Value: w}
+	i++
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func (v *TypeInfo) FromWire(w wire.Value) error {
+	var err error
+	nameIsSet := false
+	goNameIsSet := false
+	kindIsSet := false
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 1:
+			if field.Value.Type() == wire.TBinary {
+				v.Name, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("TypeInfo", "Name", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				nameIsSet = true
+			}
+		case 2:
+			if field.Value.Type() == wire.TBinary {
+				v.GoName, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("TypeInfo", "GoName", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				goNameIsSet = true
+			}
+		case 3:
+			if field.Value.Type() == wire.TBinary {
+				v.Kind, err = field.Value.GetString(), error(nil)
+				if err != nil {
+					wire.ObserveDecodeError("TypeInfo", "Kind", wire.DecodeErrorInvalidValue)
+					return err
+				}
+				kindIsSet = true
+			}
+		}
+	}
+	if !nameIsSet {
+		wire.ObserveDecodeError("TypeInfo", "Name", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "TypeInfo", Field: "Name", ID: 1}
+	}
+	if !goNameIsSet {
+		wire.ObserveDecodeError("TypeInfo", "GoName", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "TypeInfo", Field: "GoName", ID: 2}
+	}
+	if !kindIsSet {
+		wire.ObserveDecodeError("TypeInfo", "Kind", wire.DecodeErrorMissingRequiredField)
+		return wire.MissingRequiredFieldError{Struct: "TypeInfo", Field: "Kind", ID: 3}
+	}
+	return nil
+}
+
+func (v *TypeInfo) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+	var fields [3]string
+	i := 0
+	fields[i] = fmt.Sprintf("Name: %v", v.Name)
+	i++
+	fields[i] = fmt.Sprintf("GoName: %v", v.GoName)
+	i++
+	fields[i] = fmt.Sprintf("Kind: %v", v.Kind)
+	i++
+	return fmt.Sprintf("TypeInfo{%v}", strings.Join(fields[:i], ", "))
+}
+
+func (v *TypeInfo) Equals(rhs *TypeInfo) bool {
+	if !(v.Name == rhs.Name) {
+		return false
+	}
+	if !(v.GoName == rhs.GoName) {
+		return false
+	}
+	if !(v.Kind == rhs.Kind) {
+		return false
+	}
+	return true
+}
+
+func (v *TypeInfo) GetName() (o string) {
+	if v != nil {
+		o = v.Name
+	}
+	return
+}
+
+func (v *TypeInfo) GetGoName() (o string) {
+	if v != nil {
+		o = v.GoName
+	}
+	return
+}
+
+func (v *TypeInfo) GetKind() (o string) {
+	if v != nil {
+		o = v.Kind
+	}
+	return
+}
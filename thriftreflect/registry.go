@@ -0,0 +1,95 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thriftreflect
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var registry = struct {
+	sync.RWMutex
+
+	// Package import path to module
+	modules map[string]*ThriftModule
+}{modules: make(map[string]*ThriftModule)}
+
+// Register adds the given module and all modules included by it to the
+// process-wide registry.
+//
+// Code generated with --reflection calls this automatically from an init
+// function so that reflection services can list every Thrift module linked
+// into a binary. Registering the same module again is a no-op. Register
+// panics if a different version of a module was already registered for the
+// same package.
+func Register(m *ThriftModule) {
+	registry.Lock()
+	defer registry.Unlock()
+	register(m)
+}
+
+func register(m *ThriftModule) {
+	if old, ok := registry.modules[m.Package]; ok {
+		if old != m && old.SHA1 != m.SHA1 {
+			panic(fmt.Sprintf(
+				"thriftreflect: conflicting Thrift modules registered for package %q: "+
+					"%v (SHA1 %v) and %v (SHA1 %v)",
+				m.Package, old.FilePath, old.SHA1, m.FilePath, m.SHA1))
+		}
+		return
+	}
+
+	registry.modules[m.Package] = m
+	for _, inc := range m.Includes {
+		register(inc)
+	}
+}
+
+// Modules returns all registered modules, sorted by their package import
+// paths.
+func Modules() []*ThriftModule {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	modules := make([]*ThriftModule, 0, len(registry.modules))
+	for _, m := range registry.modules {
+		modules = append(modules, m)
+	}
+	sort.Sort(byPackage(modules))
+	return modules
+}
+
+// LookupModule returns the registered module generated into the package
+// with the given import path.
+func LookupModule(importPath string) (*ThriftModule, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	m, ok := registry.modules[importPath]
+	return m, ok
+}
+
+type byPackage []*ThriftModule
+
+func (ms byPackage) Len() int           { return len(ms) }
+func (ms byPackage) Less(i, j int) bool { return ms[i].Package < ms[j].Package }
+func (ms byPackage) Swap(i, j int)      { ms[i], ms[j] = ms[j], ms[i] }
@@ -0,0 +1,67 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thriftreflect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	base := &ThriftModule{Name: "base", Package: "example.com/reflect/base", SHA1: "1"}
+	foo := &ThriftModule{
+		Name:     "foo",
+		Package:  "example.com/reflect/foo",
+		SHA1:     "2",
+		Includes: []*ThriftModule{base},
+	}
+
+	Register(foo)
+	Register(foo) // no-op
+
+	got, ok := LookupModule("example.com/reflect/foo")
+	if assert.True(t, ok, "foo must be registered") {
+		assert.Equal(t, foo, got)
+	}
+
+	got, ok = LookupModule("example.com/reflect/base")
+	if assert.True(t, ok, "included module must be registered") {
+		assert.Equal(t, base, got)
+	}
+
+	_, ok = LookupModule("example.com/reflect/bar")
+	assert.False(t, ok, "bar must not be registered")
+
+	var packages []string
+	for _, m := range Modules() {
+		packages = append(packages, m.Package)
+	}
+	assert.Equal(t, []string{"example.com/reflect/base", "example.com/reflect/foo"}, packages)
+
+	// Copies of the same module are allowed.
+	copied := *base
+	assert.NotPanics(t, func() { Register(&copied) })
+
+	assert.Panics(t, func() {
+		Register(&ThriftModule{Name: "base", Package: "example.com/reflect/base", SHA1: "3"})
+	})
+}
@@ -29,4 +29,8 @@ type ThriftModule struct {
 	Includes []*ThriftModule // A reference to every included thrift modules.
 	SHA1     string          // The SHA1 of the thrift content.
 	Raw      string          // The full content of the thrift file.
+
+	// Types and services defined in the thrift file. This is set only if
+	// the code was generated with --reflection.
+	Descriptor *Descriptor
 }
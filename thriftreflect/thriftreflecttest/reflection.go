@@ -0,0 +1,84 @@
+// Code generated by thriftrw --generate-plugin-api
+// @generated
+
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thriftreflecttest
+
+import (
+	"github.com/golang/mock/gomock"
+	"go.uber.org/thriftrw/thriftreflect/reflection"
+)
+
+// MockReflection is a mock of the Reflection interface.
+type MockReflection struct {
+	ctrl     *gomock.Controller
+	recorder *MockReflectionMockRecorder
+}
+
+var _ reflection.Reflection = (*MockReflection)(nil)
+
+// MockReflectionMockRecorder records expected calls to a MockReflection.
+type MockReflectionMockRecorder struct {
+	mock *MockReflection
+}
+
+// NewMockReflection builds a new mock of the Reflection interface.
+func NewMockReflection(ctrl *gomock.Controller) *MockReflection {
+	mock := &MockReflection{ctrl: ctrl}
+	mock.recorder = &MockReflectionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReflection) EXPECT() *MockReflectionMockRecorder {
+	return m.recorder
+}
+
+// GetIDL mocks the GetIDL method.
+func (m *MockReflection) GetIDL(
+	_ImportPath string,
+) (string, error) {
+	ret := m.ctrl.Call(m, "GetIDL", _ImportPath)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIDL indicates an expected call of GetIDL.
+func (mr *MockReflectionMockRecorder) GetIDL(
+	_ImportPath interface{},
+) *gomock.Call {
+	return mr.mock.ctrl.RecordCall(mr.mock, "GetIDL", _ImportPath)
+}
+
+// ListModules mocks the ListModules method.
+func (m *MockReflection) ListModules() ([]*reflection.ModuleInfo, error) {
+	ret := m.ctrl.Call(m, "ListModules")
+	ret0, _ := ret[0].([]*reflection.ModuleInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModules indicates an expected call of ListModules.
+func (mr *MockReflectionMockRecorder) ListModules() *gomock.Call {
+	return mr.mock.ctrl.RecordCall(mr.mock, "ListModules")
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+// Package thriftset provides generic helpers which code generated by
+// ThriftRW for Go 1.18 and newer uses to convert sets to and from
+// wire.ValueLists, in place of conversion code generated for every set
+// type.
+//
+// Sets of hashable items are represented as maps to empty structs and sets
+// of other items as slices.
+//
+// 	func _Set_String_ValueList(v map[string]struct{}) wire.ValueList {
+// 		return thriftset.Encode(v, wire.TBinary, func(x string) (wire.Value, error) {
+// 			return wire.NewValueString(x), error(nil)
+// 		})
+// 	}
+package thriftset
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/thriftrw/wire"
+)
+
+// ErrNil may be returned by the functions given to Encode and EncodeSlice
+// to reject nil items.
+var ErrNil = errors.New("value is nil")
+
+// Encode returns a wire.ValueList of the items of the given set, each of
+// which is converted to a wire.Value of type t with toWire when the list is
+// iterated.
+func Encode[T comparable](items map[T]struct{}, t wire.Type, toWire func(T) (wire.Value, error)) wire.ValueList {
+	return mapValueList[T]{items: items, t: t, toWire: toWire}
+}
+
+// EncodeSlice is like Encode for sets of items which are not hashable.
+func EncodeSlice[T any](items []T, t wire.Type, toWire func(T) (wire.Value, error)) wire.ValueList {
+	return sliceValueList[T]{items: items, t: t, toWire: toWire}
+}
+
+type mapValueList[T comparable] struct {
+	items  map[T]struct{}
+	t      wire.Type
+	toWire func(T) (wire.Value, error)
+}
+
+func (l mapValueList[T]) ForEach(f func(wire.Value) error) error {
+	for x := range l.items {
+		if err := forItem(x, l.toWire, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l mapValueList[T]) Size() int {
+	return len(l.items)
+}
+
+func (l mapValueList[T]) ValueType() wire.Type {
+	return l.t
+}
+
+func (mapValueList[T]) Close() {}
+
+type sliceValueList[T any] struct {
+	items  []T
+	t      wire.Type
+	toWire func(T) (wire.Value, error)
+}
+
+func (l sliceValueList[T]) ForEach(f func(wire.Value) error) error {
+	for _, x := range l.items {
+		if err := forItem(x, l.toWire, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l sliceValueList[T]) Size() int {
+	return len(l.items)
+}
+
+func (l sliceValueList[T]) ValueType() wire.Type {
+	return l.t
+}
+
+func (sliceValueList[T]) Close() {}
+
+func forItem[T any](x T, toWire func(T) (wire.Value, error), f func(wire.Value) error) error {
+	w, err := toWire(x)
+	if err == ErrNil {
+		return fmt.Errorf("invalid set item: %v", err)
+	}
+	if err != nil {
+		return err
+	}
+	return f(w)
+}
+
+// Decode reads the items of the given wire.ValueList into a set,
+// converting each with fromWire. The ValueList is closed afterwards.
+//
+// A nil set is returned if the items of the list are not of type t.
+func Decode[T comparable](l wire.ValueList, t wire.Type, fromWire func(wire.Value) (T, error)) (map[T]struct{}, error) {
+	if l.ValueType() != t {
+		return nil, nil
+	}
+
+	o := make(map[T]struct{}, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := fromWire(x)
+		if err != nil {
+			return err
+		}
+		o[i] = struct{}{}
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// DecodeSlice is like Decode for sets of items which are not hashable.
+func DecodeSlice[T any](l wire.ValueList, t wire.Type, fromWire func(wire.Value) (T, error)) ([]T, error) {
+	if l.ValueType() != t {
+		return nil, nil
+	}
+
+	o := make([]T, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := fromWire(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package thriftset
+
+import (
+	"testing"
+
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stringToWire(s string) (wire.Value, error) { return wire.NewValueString(s), nil }
+
+func stringFromWire(w wire.Value) (string, error) { return w.GetString(), nil }
+
+func TestEncodeDecode(t *testing.T) {
+	set := map[string]struct{}{"a": {}, "b": {}}
+	l := Encode(set, wire.TBinary, stringToWire)
+	assert.Equal(t, 2, l.Size())
+	assert.Equal(t, wire.TBinary, l.ValueType())
+
+	got, err := Decode(l, wire.TBinary, stringFromWire)
+	require.NoError(t, err)
+	assert.Equal(t, set, got)
+
+	got, err = Decode(l, wire.TI32, stringFromWire)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestEncodeDecodeSlice(t *testing.T) {
+	items := [][]byte{[]byte("a"), []byte("b")}
+	l := EncodeSlice(items, wire.TBinary, func(b []byte) (wire.Value, error) {
+		if b == nil {
+			return wire.Value{}, ErrNil
+		}
+		return wire.NewValueBinary(b), nil
+	})
+	assert.Equal(t, 2, l.Size())
+
+	got, err := DecodeSlice(l, wire.TBinary, func(w wire.Value) ([]byte, error) {
+		return w.GetBinary(), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, items, got)
+
+	l = EncodeSlice([][]byte{nil}, wire.TBinary, func(b []byte) (wire.Value, error) {
+		return wire.Value{}, ErrNil
+	})
+	err = l.ForEach(func(wire.Value) error { return nil })
+	assert.EqualError(t, err, "invalid set item: value is nil")
+}
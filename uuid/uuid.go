@@ -0,0 +1,141 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package uuid provides the Go representation of the Thrift uuid type.
+//
+// Fields of type uuid are generated as UUID values.
+//
+// 	struct User {
+// 		1: required uuid id
+// 	}
+//
+// UUIDs are encoded on the wire as 16-byte binary values and in JSON and
+// text as strings in the canonical "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+// form.
+package uuid
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"go.uber.org/thriftrw/wire"
+)
+
+// Size is the number of bytes in a UUID.
+const Size = 16
+
+// Length of the canonical string representation of a UUID.
+const stringLength = 36
+
+// UUID is a universally unique identifier.
+//
+// The zero value is the nil UUID, 00000000-0000-0000-0000-000000000000.
+type UUID [Size]byte
+
+// Parse parses a UUID in the canonical "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+// form. Hexadecimal digits may be upper or lower case.
+func Parse(s string) (UUID, error) {
+	var u UUID
+	if len(s) != stringLength || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, fmt.Errorf("invalid UUID %q: must be in the form xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx", s)
+	}
+
+	b := u[:0]
+	for _, group := range []string{s[0:8], s[9:13], s[14:18], s[19:23], s[24:36]} {
+		decoded, err := hex.DecodeString(group)
+		if err != nil {
+			return UUID{}, fmt.Errorf("invalid UUID %q: %v", s, err)
+		}
+		b = append(b, decoded...)
+	}
+	return u, nil
+}
+
+// MustParse is like Parse but panics if the string is not a valid UUID.
+func MustParse(s string) UUID {
+	u, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// FromBytes builds a UUID from its 16-byte binary representation.
+func FromBytes(b []byte) (UUID, error) {
+	var u UUID
+	if len(b) != Size {
+		return u, fmt.Errorf("invalid UUID: expected %d bytes, got %d", Size, len(b))
+	}
+	copy(u[:], b)
+	return u, nil
+}
+
+// String returns the canonical string representation of the UUID.
+func (u UUID) String() string {
+	var buf [stringLength]byte
+	u.encode(buf[:])
+	return string(buf[:])
+}
+
+func (u UUID) encode(dst []byte) {
+	hex.Encode(dst[0:8], u[0:4])
+	dst[8] = '-'
+	hex.Encode(dst[9:13], u[4:6])
+	dst[13] = '-'
+	hex.Encode(dst[14:18], u[6:8])
+	dst[18] = '-'
+	hex.Encode(dst[19:23], u[8:10])
+	dst[23] = '-'
+	hex.Encode(dst[24:], u[10:])
+}
+
+// MarshalText encodes the UUID in its canonical string representation.
+func (u UUID) MarshalText() ([]byte, error) {
+	buf := make([]byte, stringLength)
+	u.encode(buf)
+	return buf, nil
+}
+
+// UnmarshalText decodes a UUID from its canonical string representation.
+func (u *UUID) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// ToWire encodes the UUID as a 16-byte binary Value.
+func (u UUID) ToWire() (wire.Value, error) {
+	b := make([]byte, Size)
+	copy(b, u[:])
+	return wire.NewValueBinary(b), nil
+}
+
+// FromWire decodes the UUID from a 16-byte binary Value.
+func (u *UUID) FromWire(w wire.Value) error {
+	parsed, err := FromBytes(w.GetBinary())
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sample = UUID{
+	0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3,
+	0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00,
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		give      string
+		want      UUID
+		wantError string
+	}{
+		{give: "123e4567-e89b-12d3-a456-426614174000", want: sample},
+		{give: "123E4567-E89B-12D3-A456-426614174000", want: sample},
+		{give: "00000000-0000-0000-0000-000000000000", want: UUID{}},
+		{
+			give:      "123e4567e89b12d3a456426614174000",
+			wantError: `invalid UUID "123e4567e89b12d3a456426614174000": must be in the form`,
+		},
+		{
+			give:      "123e4567-e89b-12d3-a456-42661417400g",
+			wantError: `invalid UUID "123e4567-e89b-12d3-a456-42661417400g"`,
+		},
+		{
+			give:      "123e4567-e89b-12d3-a456_426614174000",
+			wantError: "must be in the form",
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.give)
+		if tt.wantError != "" {
+			if assert.Error(t, err, tt.give) {
+				assert.Contains(t, err.Error(), tt.wantError, tt.give)
+			}
+			continue
+		}
+		if assert.NoError(t, err, tt.give) {
+			assert.Equal(t, tt.want, got, tt.give)
+		}
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	assert.Equal(t, sample, MustParse("123e4567-e89b-12d3-a456-426614174000"))
+	assert.Panics(t, func() { MustParse("foo") })
+}
+
+func TestString(t *testing.T) {
+	assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", sample.String())
+	assert.Equal(t, "00000000-0000-0000-0000-000000000000", UUID{}.String())
+}
+
+func TestFromBytes(t *testing.T) {
+	got, err := FromBytes(sample[:])
+	require.NoError(t, err)
+	assert.Equal(t, sample, got)
+
+	_, err = FromBytes(sample[:15])
+	assert.EqualError(t, err, "invalid UUID: expected 16 bytes, got 15")
+
+	_, err = FromBytes(nil)
+	assert.EqualError(t, err, "invalid UUID: expected 16 bytes, got 0")
+}
+
+func TestJSON(t *testing.T) {
+	type user struct {
+		ID     UUID          `json:"id"`
+		Parent *UUID         `json:"parent,omitempty"`
+		Names  map[UUID]bool `json:"names"`
+	}
+
+	give := user{ID: sample, Names: map[UUID]bool{sample: true}}
+	b, err := json.Marshal(give)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": "123e4567-e89b-12d3-a456-426614174000",
+		"names": {"123e4567-e89b-12d3-a456-426614174000": true}
+	}`, string(b))
+
+	var got user
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, give, got)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"id": "foo"}`), &got))
+}
+
+func TestWire(t *testing.T) {
+	w, err := sample.ToWire()
+	require.NoError(t, err)
+	assert.Equal(t, wire.TBinary, w.Type())
+	assert.Equal(t, sample[:], w.GetBinary())
+
+	var got UUID
+	require.NoError(t, got.FromWire(w))
+	assert.Equal(t, sample, got)
+
+	assert.Error(t, got.FromWire(wire.NewValueBinary([]byte("foo"))))
+	assert.Equal(t, sample, got, "value must not change on failure")
+}
@@ -22,26 +22,11 @@
 
 package version
 
-import (
-	"log"
-
-	"go.uber.org/thriftrw/internal/semver"
-)
-
-var compatRange = computeCompatibleRange()
-
-func computeCompatibleRange() semver.Range {
-	v, err := semver.Parse(Version)
-	if err != nil {
-		panic(err)
-	}
-
-	return semver.CompatibleRange(v)
-}
+import "log"
 
 // CheckCompatWithGeneratedCodeAt will panic if the ThriftRW version used to
 // generated code (given by `genCodeVersion`) is not compatible with the
-// current version of ThriftRW.
+// current version of ThriftRW. See CheckVersion for details.
 // This function is designed to be called during initialization of the
 // generated code.
 //
@@ -50,13 +35,7 @@ func computeCompatibleRange() semver.Range {
 // This function will ensure that the version mismatch is detected and help
 // avoid bugs that could be caused by this discrepancy.
 func CheckCompatWithGeneratedCodeAt(genCodeVersion string, fromPkg string) {
-	v, err := semver.Parse(genCodeVersion)
-	if err != nil {
-		panic(err)
-	}
-
-	if !compatRange.Contains(v) {
-		log.Panicf(`incompatible version from generated package %q, expected >=%s and <%s, got %s`,
-			fromPkg, &compatRange.Begin, &compatRange.End, &v)
+	if err := CheckVersion(genCodeVersion, fromPkg); err != nil {
+		log.Panic(err)
 	}
 }
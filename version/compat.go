@@ -0,0 +1,70 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package version
+
+import (
+	"fmt"
+
+	"go.uber.org/thriftrw/internal/semver"
+)
+
+var compatRange = computeCompatibleRange()
+
+func computeCompatibleRange() semver.Range {
+	v, err := semver.Parse(Version)
+	if err != nil {
+		panic(err)
+	}
+
+	return semver.CompatibleRange(v)
+}
+
+// CheckVersion returns an error if code generated by the given version of
+// ThriftRW cannot be used with this version of the ThriftRW library.
+//
+// Code generated by a newer minor version of ThriftRW may use APIs which do
+// not exist in this version of the library, and code generated by a
+// different major version is incompatible with it. The error states which
+// of the two needs to be upgraded.
+func CheckVersion(genCodeVersion string, fromPkg string) error {
+	v, err := semver.Parse(genCodeVersion)
+	if err != nil {
+		return fmt.Errorf("package %q was generated by an unknown version of thriftrw %q: %v",
+			fromPkg, genCodeVersion, err)
+	}
+
+	if compatRange.Contains(v) {
+		return nil
+	}
+
+	if v.Compare(&compatRange.End) >= 0 {
+		return fmt.Errorf(
+			"package %q was generated by thriftrw v%s which is newer than "+
+				"the go.uber.org/thriftrw v%s library it is built with: "+
+				"upgrade go.uber.org/thriftrw to v%s or newer",
+			fromPkg, &v, Version, &v)
+	}
+	return fmt.Errorf(
+		"package %q was generated by thriftrw v%s which is not compatible with "+
+			"the go.uber.org/thriftrw v%s library it is built with: "+
+			"regenerate it with thriftrw v%s",
+		fromPkg, &v, Version, Version)
+}
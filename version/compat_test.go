@@ -0,0 +1,70 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		wantErr string
+	}{
+		{version: Version},
+		{version: "1.0.0"},
+		{
+			version: "1.5.0",
+			wantErr: `package "foo" was generated by thriftrw v1.5.0 which is newer than ` +
+				`the go.uber.org/thriftrw v` + Version + ` library it is built with: ` +
+				`upgrade go.uber.org/thriftrw to v1.5.0 or newer`,
+		},
+		{
+			version: "2.0.0",
+			wantErr: "upgrade go.uber.org/thriftrw to v2.0.0 or newer",
+		},
+		{
+			version: "0.9.0",
+			wantErr: `package "foo" was generated by thriftrw v0.9.0 which is not compatible with ` +
+				`the go.uber.org/thriftrw v` + Version + ` library it is built with: ` +
+				`regenerate it with thriftrw v` + Version,
+		},
+		{
+			version: "not-a-version",
+			wantErr: `package "foo" was generated by an unknown version of thriftrw "not-a-version"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			err := CheckVersion(tt.version, "foo")
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// +build js,wasm
+
+// Command wasm exposes the ThriftRW parser and compiler to JavaScript when
+// built for WebAssembly.
+//
+// 	GOOS=js GOARCH=wasm go build -o thriftrw.wasm go.uber.org/thriftrw/wasm
+//
+// Once loaded, the module registers a global thriftrw object with the
+// following functions. Use thriftrw.js in this directory to load it.
+//
+// 	thriftrw.parse(source)
+//
+// Parses a single Thrift document and returns an object with the lists
+// "errors", "warnings", and "definitions". Definitions are objects with a
+// "kind", "name", and "line".
+//
+// 	thriftrw.compile(files, path)
+//
+// Compiles the Thrift file at path and its includes. files is an object
+// mapping paths of Thrift files to their contents; includes are resolved
+// only against it. Returns an object with the lists "errors", "warnings",
+// "constants", "types", and "services".
+package main
+
+import (
+	"sort"
+	"syscall/js"
+
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/idl"
+	"go.uber.org/thriftrw/version"
+)
+
+func main() {
+	js.Global().Set("thriftrw", js.ValueOf(map[string]interface{}{
+		"version": version.Version,
+		"parse":   js.FuncOf(parse),
+		"compile": js.FuncOf(compileFiles),
+	}))
+
+	// Block forever so that the registered functions remain callable.
+	select {}
+}
+
+func parse(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return result("parse(source) expects one argument")
+	}
+
+	var warnings []interface{}
+	cfg := idl.Config{Warn: func(w idl.Warning) {
+		warnings = append(warnings, map[string]interface{}{
+			"line":    w.Line,
+			"message": w.Message,
+		})
+	}}
+
+	prog, err := cfg.Parse([]byte(args[0].String()))
+	if err != nil {
+		return result(err.Error())
+	}
+
+	defs := make([]interface{}, 0, len(prog.Definitions))
+	for _, d := range prog.Definitions {
+		info := d.Info()
+		defs = append(defs, map[string]interface{}{
+			"kind": definitionKind(d),
+			"name": info.Name,
+			"line": info.Line,
+		})
+	}
+
+	r := result()
+	r["warnings"] = toArray(warnings)
+	r["definitions"] = defs
+	return r
+}
+
+func compileFiles(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return result("compile(files, path) expects two arguments")
+	}
+
+	fs := make(compile.MapFS)
+	files := args[0]
+	keys := js.Global().Get("Object").Call("keys", files)
+	for i := 0; i < keys.Length(); i++ {
+		name := keys.Index(i).String()
+		fs[name] = files.Get(name).String()
+	}
+
+	var warnings []interface{}
+	m, err := compile.Compile(args[1].String(),
+		compile.Filesystem(fs),
+		compile.Warnings(func(w compile.Warning) {
+			warnings = append(warnings, map[string]interface{}{
+				"path":    w.Path,
+				"line":    w.Line,
+				"message": w.Message,
+			})
+		}),
+	)
+	if err != nil {
+		return result(err.Error())
+	}
+
+	var constants, types, services []string
+	for name := range m.Constants {
+		constants = append(constants, name)
+	}
+	for name := range m.Types {
+		types = append(types, name)
+	}
+	for name := range m.Services {
+		services = append(services, name)
+	}
+
+	r := result()
+	r["warnings"] = toArray(warnings)
+	r["constants"] = sortedArray(constants)
+	r["types"] = sortedArray(types)
+	r["services"] = sortedArray(services)
+	return r
+}
+
+// result builds a result object with the given errors.
+func result(errors ...string) map[string]interface{} {
+	errs := make([]interface{}, len(errors))
+	for i, e := range errors {
+		errs[i] = e
+	}
+	return map[string]interface{}{
+		"errors":   errs,
+		"warnings": []interface{}{},
+	}
+}
+
+func toArray(items []interface{}) []interface{} {
+	if items == nil {
+		return []interface{}{}
+	}
+	return items
+}
+
+func sortedArray(names []string) []interface{} {
+	sort.Strings(names)
+	items := make([]interface{}, len(names))
+	for i, name := range names {
+		items[i] = name
+	}
+	return items
+}
+
+func definitionKind(d ast.Definition) string {
+	switch d := d.(type) {
+	case *ast.Constant:
+		return "constant"
+	case *ast.Typedef:
+		return "typedef"
+	case *ast.Enum:
+		return "enum"
+	case *ast.Senum:
+		return "senum"
+	case *ast.Struct:
+		switch d.Type {
+		case ast.UnionType:
+			return "union"
+		case ast.ExceptionType:
+			return "exception"
+		default:
+			return "struct"
+		}
+	case *ast.Service:
+		return "service"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// thriftrw.js loads the ThriftRW parser and compiler built for WebAssembly
+// (see main.go) and exposes them as a small promise-based API.
+//
+// wasm_exec.js from the Go distribution must be loaded first so that the
+// global Go class is available.
+//
+//   thriftrw.load('thriftrw.wasm').then(function (t) {
+//     var r = t.compile({'api.thrift': source}, 'api.thrift');
+//     r.errors.forEach(function (e) { console.error(e); });
+//   });
+//
+// load accepts a URL, a fetch Response (or a promise of one), or the module
+// bytes as an ArrayBuffer or typed array. The module is loaded at most once;
+// later calls return the same promise.
+(function (root, factory) {
+  'use strict';
+  if (typeof module === 'object' && module.exports) {
+    module.exports = factory(root);
+  } else {
+    root.thriftrw = factory(root);
+  }
+}(typeof globalThis !== 'undefined' ? globalThis : this, function (root) {
+  'use strict';
+
+  var loaded = null;
+
+  function instantiate(source, imports) {
+    if (typeof source === 'string') {
+      if (typeof fetch !== 'function') {
+        return Promise.reject(new Error(
+          'thriftrw: fetch is unavailable; pass the module bytes instead'));
+      }
+      source = fetch(source);
+    }
+
+    return Promise.resolve(source).then(function (src) {
+      if (typeof src.arrayBuffer === 'function') {
+        return src.arrayBuffer();
+      }
+      return src;
+    }).then(function (bytes) {
+      return WebAssembly.instantiate(bytes, imports);
+    }).then(function (result) {
+      return result.instance;
+    });
+  }
+
+  function load(source) {
+    if (loaded) {
+      return loaded;
+    }
+    if (typeof root.Go !== 'function') {
+      return Promise.reject(new Error(
+        'thriftrw: wasm_exec.js must be loaded before thriftrw.js'));
+    }
+
+    var go = new root.Go();
+    loaded = instantiate(source, go.importObject).then(function (instance) {
+      // The Go program registers its functions as the thriftrw global
+      // before blocking, so they are available as soon as run yields.
+      // Restore whatever it replaced, which may be this wrapper.
+      var previous = root.thriftrw;
+      go.run(instance);
+      var api = root.thriftrw;
+      if (previous === undefined) {
+        delete root.thriftrw;
+      } else {
+        root.thriftrw = previous;
+      }
+      return {
+        version: api.version,
+        parse: function (src) {
+          return api.parse(String(src));
+        },
+        compile: function (files, path) {
+          return api.compile(files, String(path));
+        },
+      };
+    });
+    return loaded;
+  }
+
+  return {load: load};
+}));
@@ -0,0 +1,299 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/thriftrw/compile"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Duration for which changes are collected before code is regenerated.
+// Editors often write files in multiple steps, and a single save may touch
+// many files.
+const watchDebounce = 200 * time.Millisecond
+
+// doWatch generates code for the given Thrift files (or all Thrift files in
+// dir if no files were given), and regenerates it whenever Thrift files in
+// dir change.
+//
+// doWatch does not return unless the file system watcher fails.
+func doWatch(dir string, files []string, gopts genOptions) error {
+	w, err := newWatcher(dir, files, gopts, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("Could not start watching %q: %v", dir, err)
+	}
+	defer fw.Close()
+
+	w.AddDir = fw.Add
+	if err := w.addDirs(w.Dir); err != nil {
+		return err
+	}
+
+	w.Build(nil)
+	return w.Run(fw.Events, fw.Errors, nil)
+}
+
+// watcher tracks the dependencies of Thrift files and regenerates code for
+// them when files change.
+type watcher struct {
+	// Absolute path to the watched directory.
+	Dir string
+
+	// Thrift files specified by the user. If empty, all Thrift files in Dir
+	// are generated.
+	Roots []string
+
+	GOpts    genOptions
+	Out      io.Writer
+	Debounce time.Duration
+
+	// Generate generates code for a single Thrift file.
+	Generate func(file string, gopts genOptions) error
+
+	// AddDir starts watching the given directory.
+	AddDir func(dir string) error
+
+	// Thrift files that each generated Thrift file depends on, including the
+	// file itself.
+	deps map[string]map[string]struct{}
+}
+
+func newWatcher(dir string, files []string, gopts genOptions, out io.Writer) (*watcher, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to resolve absolute path for %q: %v", dir, err)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%q is not a directory", dir)
+	}
+
+	roots := make([]string, len(files))
+	for i, f := range files {
+		roots[i], err = filepath.Abs(f)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to resolve absolute path for %q: %v", f, err)
+		}
+	}
+
+	// All Thrift files are generated relative to the same root so that
+	// regenerating a subset of them produces the same package hierarchy.
+	if gopts.ThriftRoot == "" {
+		gopts.ThriftRoot = dir
+	}
+
+	return &watcher{
+		Dir:      dir,
+		Roots:    roots,
+		GOpts:    gopts,
+		Out:      out,
+		Debounce: watchDebounce,
+		Generate: generateFile,
+		AddDir:   func(string) error { return nil },
+		deps:     make(map[string]map[string]struct{}),
+	}, nil
+}
+
+// Run regenerates code as changes are received on the given channel until
+// either channel is closed or stop is closed.
+func (w *watcher) Run(events <-chan fsnotify.Event, errors <-chan error, stop <-chan struct{}) error {
+	changed := make(map[string]struct{})
+	var timer <-chan time.Time
+	for {
+		select {
+		case <-stop:
+			return nil
+
+		case err, ok := <-errors:
+			if !ok {
+				return nil
+			}
+			return fmt.Errorf("Failed to watch %q: %v", w.Dir, err)
+
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if e.Op&fsnotify.Create != 0 {
+				if info, err := os.Stat(e.Name); err == nil && info.IsDir() {
+					if err := w.addDirs(e.Name); err != nil {
+						fmt.Fprintf(w.Out, "%v\n", err)
+					}
+					// Files may have been added to the directory before it
+					// was being watched.
+					w.Build(nil)
+					continue
+				}
+			}
+			if filepath.Ext(e.Name) != ".thrift" || e.Op == fsnotify.Chmod {
+				continue
+			}
+			changed[filepath.Clean(e.Name)] = struct{}{}
+			timer = time.After(w.Debounce)
+
+		case <-timer:
+			timer = nil
+			files := make([]string, 0, len(changed))
+			for f := range changed {
+				files = append(files, f)
+			}
+			changed = make(map[string]struct{})
+			w.Build(files)
+		}
+	}
+}
+
+// Build regenerates code for Thrift files affected by changes to the given
+// files and prints a summary. If changed is nil, code is generated for all
+// Thrift files.
+func (w *watcher) Build(changed []string) {
+	roots := w.affected(changed)
+	if len(roots) == 0 {
+		return
+	}
+
+	start := time.Now()
+	var (
+		lines    []string
+		failures int
+	)
+	for _, root := range roots {
+		name, err := filepath.Rel(w.Dir, root)
+		if err != nil {
+			name = root
+		}
+
+		if err := w.Generate(root, w.GOpts); err != nil {
+			failures++
+			lines = append(lines, fmt.Sprintf("  FAIL %v: %v", name, err))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  ok   %v", name))
+
+		if deps, err := thriftDependencies(root, w.GOpts.IncludePaths); err == nil {
+			w.deps[root] = deps
+		}
+	}
+
+	fmt.Fprintf(w.Out, "Regenerated %d of %d Thrift files in %v:\n%v\n",
+		len(roots)-failures, len(roots),
+		time.Since(start)/time.Millisecond*time.Millisecond, strings.Join(lines, "\n"))
+}
+
+// affected returns the Thrift files which need to be regenerated after the
+// given files changed, or all Thrift files if changed is nil.
+func (w *watcher) affected(changed []string) []string {
+	roots := w.Roots
+	if len(roots) == 0 {
+		roots = findThriftFiles(w.Dir)
+	}
+
+	isRoot := make(map[string]struct{}, len(roots))
+	for _, r := range roots {
+		isRoot[r] = struct{}{}
+	}
+	for r := range w.deps {
+		if _, ok := isRoot[r]; !ok {
+			delete(w.deps, r) // removed
+		}
+	}
+
+	if changed == nil {
+		return roots
+	}
+
+	var affected []string
+	for _, root := range roots {
+		deps, ok := w.deps[root]
+		if !ok {
+			// New files or files that never compiled.
+			affected = append(affected, root)
+			continue
+		}
+		for _, f := range changed {
+			if _, ok := deps[f]; ok {
+				affected = append(affected, root)
+				break
+			}
+		}
+	}
+	return affected
+}
+
+// addDirs watches the given directory and all directories inside it.
+func (w *watcher) addDirs(dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if err := w.AddDir(path); err != nil {
+			return fmt.Errorf("Could not watch %q: %v", path, err)
+		}
+		return nil
+	})
+}
+
+// findThriftFiles returns a sorted list of all Thrift files in the given
+// directory tree.
+func findThriftFiles(dir string) []string {
+	var files []string
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && filepath.Ext(path) == ".thrift" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files
+}
+
+// thriftDependencies returns the paths of the given Thrift file and all
+// Thrift files included by it, directly or transitively. Included files are
+// searched for in the given include paths.
+func thriftDependencies(file string, includePaths []string) (map[string]struct{}, error) {
+	module, err := compile.Compile(file, compile.IncludePaths(includePaths...))
+	if err != nil {
+		return nil, err
+	}
+
+	deps := make(map[string]struct{})
+	err = module.Walk(func(m *compile.Module) error {
+		deps[m.ThriftPath] = struct{}{}
+		return nil
+	})
+	return deps, err
+}
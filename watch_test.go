@@ -0,0 +1,193 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGenerate records the files for which code was generated.
+type fakeGenerate struct {
+	files []string
+	fail  map[string]error
+}
+
+func (f *fakeGenerate) Generate(file string, _ genOptions) error {
+	f.files = append(f.files, file)
+	return f.fail[file]
+}
+
+// Generated returns the names of the generated files relative to dir and
+// resets the list.
+func (f *fakeGenerate) Generated(t *testing.T, dir string) []string {
+	var names []string
+	for _, file := range f.files {
+		name, err := filepath.Rel(dir, file)
+		require.NoError(t, err)
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	f.files = nil
+	return names
+}
+
+func writeWatchTestFiles(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "thriftrw-watch-test")
+	require.NoError(t, err)
+
+	for path, contents := range map[string]string{
+		"shared/shared.thrift": `typedef string UUID`,
+		"users.thrift": `
+			include "./shared/shared.thrift"
+
+			struct User { 1: required shared.UUID id }
+		`,
+		"status.thrift": `enum Status { ENABLED }`,
+		"README.md":     `Not a Thrift file.`,
+	} {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+	return dir
+}
+
+func TestWatcherBuild(t *testing.T) {
+	dir := writeWatchTestFiles(t)
+	defer os.RemoveAll(dir)
+
+	var out bytes.Buffer
+	w, err := newWatcher(dir, nil, genOptions{}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, dir, w.GOpts.ThriftRoot, "Thrift root must default to the watched directory")
+
+	var gen fakeGenerate
+	w.Generate = gen.Generate
+
+	w.Build(nil)
+	assert.Equal(t, []string{"shared/shared.thrift", "status.thrift", "users.thrift"}, gen.Generated(t, dir))
+	assert.Contains(t, out.String(), "Regenerated 3 of 3 Thrift files")
+	assert.Contains(t, out.String(), "  ok   users.thrift\n")
+
+	w.Build([]string{filepath.Join(dir, "shared/shared.thrift")})
+	assert.Equal(t, []string{"shared/shared.thrift", "users.thrift"}, gen.Generated(t, dir))
+
+	w.Build([]string{filepath.Join(dir, "status.thrift")})
+	assert.Equal(t, []string{"status.thrift"}, gen.Generated(t, dir))
+
+	w.Build([]string{filepath.Join(dir, "README.md")})
+	assert.Empty(t, gen.Generated(t, dir))
+
+	// New files are always generated.
+	newFile := filepath.Join(dir, "new.thrift")
+	require.NoError(t, ioutil.WriteFile(newFile, []byte(`const i32 x = 42`), 0644))
+	w.Build([]string{filepath.Join(dir, "status.thrift")})
+	assert.Equal(t, []string{"new.thrift", "status.thrift"}, gen.Generated(t, dir))
+
+	out.Reset()
+	gen.fail = map[string]error{newFile: errors.New("great sadness")}
+	w.Build([]string{newFile})
+	assert.Equal(t, []string{"new.thrift"}, gen.Generated(t, dir))
+	assert.Contains(t, out.String(), "Regenerated 0 of 1 Thrift files")
+	assert.Contains(t, out.String(), "  FAIL new.thrift: great sadness\n")
+}
+
+func TestWatcherBuildRoots(t *testing.T) {
+	dir := writeWatchTestFiles(t)
+	defer os.RemoveAll(dir)
+
+	w, err := newWatcher(dir, []string{filepath.Join(dir, "users.thrift")}, genOptions{}, ioutil.Discard)
+	require.NoError(t, err)
+
+	var gen fakeGenerate
+	w.Generate = gen.Generate
+
+	w.Build(nil)
+	assert.Equal(t, []string{"users.thrift"}, gen.Generated(t, dir))
+
+	w.Build([]string{filepath.Join(dir, "status.thrift")})
+	assert.Empty(t, gen.Generated(t, dir))
+
+	w.Build([]string{filepath.Join(dir, "shared/shared.thrift")})
+	assert.Equal(t, []string{"users.thrift"}, gen.Generated(t, dir))
+}
+
+func TestWatcherRun(t *testing.T) {
+	dir := writeWatchTestFiles(t)
+	defer os.RemoveAll(dir)
+
+	w, err := newWatcher(dir, nil, genOptions{}, ioutil.Discard)
+	require.NoError(t, err)
+	w.Debounce = 10 * time.Millisecond
+
+	var gen fakeGenerate
+	w.Generate = gen.Generate
+	w.Build(nil)
+	gen.Generated(t, dir)
+
+	events := make(chan fsnotify.Event)
+	errs := make(chan error)
+	stop := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- w.Run(events, errs, stop) }()
+
+	// Multiple events for the same file are combined.
+	shared := filepath.Join(dir, "shared/shared.thrift")
+	events <- fsnotify.Event{Name: shared, Op: fsnotify.Write}
+	events <- fsnotify.Event{Name: shared, Op: fsnotify.Write}
+	events <- fsnotify.Event{Name: shared, Op: fsnotify.Chmod}
+	events <- fsnotify.Event{Name: filepath.Join(dir, "README.md"), Op: fsnotify.Write}
+
+	time.Sleep(100 * time.Millisecond)
+	close(stop)
+	require.NoError(t, <-done)
+	assert.Equal(t, []string{"shared/shared.thrift", "users.thrift"}, gen.Generated(t, dir))
+
+	t.Run("watch error", func(t *testing.T) {
+		go func() { done <- w.Run(events, errs, nil) }()
+		errs <- errors.New("great sadness")
+		err := <-done
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "great sadness")
+		}
+	})
+}
+
+func TestNewWatcherNotADirectory(t *testing.T) {
+	dir := writeWatchTestFiles(t)
+	defer os.RemoveAll(dir)
+
+	_, err := newWatcher(filepath.Join(dir, "users.thrift"), nil, genOptions{}, ioutil.Discard)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "is not a directory")
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package wire
+
+// Detach returns a copy of the given value which does not reference the
+// input it was decoded from. Lists, sets, and maps which were decoded lazily
+// are read into slices, and binary values are copied.
+//
+// Values which are retained after the buffer they were decoded from is
+// reused or released must be detached first.
+//
+// An error is returned if a lazily decoded list could not be read.
+func Detach(v Value) (Value, error) {
+	switch v.Type() {
+	case TBinary:
+		b := v.GetBinary()
+		return NewValueBinary(append(make([]byte, 0, len(b)), b...)), nil
+	case TStruct:
+		s := v.GetStruct()
+		if len(s.Fields) == 0 {
+			return v, nil
+		}
+		fields := make([]Field, len(s.Fields))
+		for i, f := range s.Fields {
+			fv, err := Detach(f.Value)
+			if err != nil {
+				return v, err
+			}
+			fields[i] = Field{ID: f.ID, Value: fv}
+		}
+		return NewValueStruct(Struct{Fields: fields}), nil
+	case TMap:
+		m := v.GetMap()
+		items := make([]MapItem, 0, m.Size())
+		err := m.ForEach(func(item MapItem) error {
+			k, err := Detach(item.Key)
+			if err != nil {
+				return err
+			}
+			iv, err := Detach(item.Value)
+			if err != nil {
+				return err
+			}
+			items = append(items, MapItem{Key: k, Value: iv})
+			return nil
+		})
+		return NewValueMap(MapItemListFromSlice(m.KeyType(), m.ValueType(), items)), err
+	case TSet:
+		l, err := detachList(v.GetSet())
+		return NewValueSet(l), err
+	case TList:
+		l, err := detachList(v.GetList())
+		return NewValueList(l), err
+	default:
+		return v, nil
+	}
+}
+
+func detachList(l ValueList) (ValueList, error) {
+	values := make([]Value, 0, l.Size())
+	err := l.ForEach(func(item Value) error {
+		iv, err := Detach(item)
+		if err != nil {
+			return err
+		}
+		values = append(values, iv)
+		return nil
+	})
+	return ValueListFromSlice(l.ValueType(), values), err
+}
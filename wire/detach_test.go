@@ -0,0 +1,101 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package wire
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bufferList is a lazy list of bytes which reads its items from a shared
+// buffer each time it is iterated.
+type bufferList struct {
+	buf []byte
+	err error
+}
+
+func (l bufferList) Size() int     { return len(l.buf) }
+func (bufferList) ValueType() Type { return TI8 }
+func (bufferList) Close()          {}
+func (l bufferList) ForEach(f func(Value) error) error {
+	if l.err != nil {
+		return l.err
+	}
+	for _, b := range l.buf {
+		if err := f(NewValueI8(int8(b))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestDetach(t *testing.T) {
+	buf := []byte{1, 2, 3}
+	v := NewValueStruct(Struct{Fields: []Field{
+		{ID: 1, Value: NewValueBinary(buf[:2])},
+		{ID: 2, Value: NewValueList(bufferList{buf: buf})},
+		{ID: 3, Value: NewValueSet(bufferList{buf: buf})},
+		{ID: 4, Value: NewValueMap(MapItemListFromSlice(TBinary, TList, []MapItem{
+			{Key: NewValueBinary(buf[2:]), Value: NewValueList(bufferList{buf: buf})},
+		}))},
+	}})
+
+	want := NewValueStruct(Struct{Fields: []Field{
+		{ID: 1, Value: NewValueBinary([]byte{1, 2})},
+		{ID: 2, Value: NewValueList(ValueListFromSlice(TI8, []Value{
+			NewValueI8(1), NewValueI8(2), NewValueI8(3),
+		}))},
+		{ID: 3, Value: NewValueSet(ValueListFromSlice(TI8, []Value{
+			NewValueI8(1), NewValueI8(2), NewValueI8(3),
+		}))},
+		{ID: 4, Value: NewValueMap(MapItemListFromSlice(TBinary, TList, []MapItem{
+			{
+				Key: NewValueBinary([]byte{3}),
+				Value: NewValueList(ValueListFromSlice(TI8, []Value{
+					NewValueI8(1), NewValueI8(2), NewValueI8(3),
+				})),
+			},
+		}))},
+	}})
+
+	got, err := Detach(v)
+	require.NoError(t, err)
+
+	// Reuse the buffer the original value was decoded from.
+	for i := range buf {
+		buf[i] = 0xff
+	}
+
+	assert.True(t, ValuesAreEqual(want, got), "detached value changed with its input")
+	assert.False(t, ValuesAreEqual(want, v), "original value should still reference its input")
+}
+
+func TestDetachError(t *testing.T) {
+	v := NewValueStruct(Struct{Fields: []Field{
+		{ID: 1, Value: NewValueList(bufferList{err: errors.New("great sadness")})},
+	}})
+
+	_, err := Detach(v)
+	assert.EqualError(t, err, "great sadness")
+}
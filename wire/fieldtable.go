@@ -0,0 +1,184 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package wire
+
+import "sort"
+
+// FieldInfo describes a field of a generated struct in a FieldTable.
+type FieldInfo struct {
+	// ID and type of the field.
+	ID   int16
+	Type Type
+
+	// Name of the Go field.
+	Name string
+
+	// Whether decoding fails if the field is absent.
+	Required bool
+}
+
+// FieldTable describes the fields of a generated struct. Types generated
+// with compact code generation decode their fields with it rather than with
+// code specialized to every field.
+//
+// FieldTables are created by generated code and should not be used
+// directly.
+type FieldTable struct {
+	name   string
+	fields []FieldInfo
+
+	// Indexes of fields in the table, sorted by their IDs.
+	byID []int
+
+	// Indexes of required fields in the table.
+	required []int
+}
+
+// linearFieldLookup is the number of fields up to which fields are looked up
+// by scanning the table rather than by binary search.
+const linearFieldLookup = 8
+
+// NewFieldTable builds a FieldTable for the generated struct with the given
+// name. This is called by generated code and should not be called directly.
+func NewFieldTable(structName string, fields ...FieldInfo) *FieldTable {
+	t := &FieldTable{name: structName, fields: fields}
+	for i, f := range fields {
+		if f.Required {
+			t.required = append(t.required, i)
+		}
+	}
+
+	if len(fields) > linearFieldLookup {
+		t.byID = make([]int, len(fields))
+		for i := range t.byID {
+			t.byID[i] = i
+		}
+		sort.SliceStable(t.byID, func(i, j int) bool {
+			return fields[t.byID[i]].ID < fields[t.byID[j]].ID
+		})
+	}
+	return t
+}
+
+// index returns the index of the field with the given ID in the table, or -1
+// if the table does not have such a field.
+func (t *FieldTable) index(id int16) int {
+	if t.byID == nil {
+		for i, f := range t.fields {
+			if f.ID == id {
+				return i
+			}
+		}
+		return -1
+	}
+
+	lo, hi := 0, len(t.byID)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if t.fields[t.byID[mid]].ID < id {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	if lo < len(t.byID) && t.fields[t.byID[lo]].ID == id {
+		return t.byID[lo]
+	}
+	return -1
+}
+
+// Decode calls set for every field of the given struct value that is listed
+// in the table with the same type. set receives the index of the field in
+// the table and the value of the field. Other fields are skipped.
+//
+// Errors returned by set are reported with ObserveDecodeError and returned
+// as-is. If a required field is absent, a MissingRequiredFieldError is
+// returned for the first such field in the table.
+func (t *FieldTable) Decode(w Value, set func(i int, v Value) error) error {
+	_, err := t.decode(w, set, false)
+	return err
+}
+
+// DecodeUnknown is like Decode but it also returns the fields of the struct
+// whose IDs are not listed in the table, in the order in which they
+// appeared. The returned slice is nil if there were no such fields.
+//
+// The values of the returned fields are detached from the input with Detach
+// so that they may be retained after it is reused.
+func (t *FieldTable) DecodeUnknown(w Value, set func(i int, v Value) error) ([]Field, error) {
+	return t.decode(w, set, true)
+}
+
+func (t *FieldTable) decode(w Value, set func(i int, v Value) error, keepUnknown bool) ([]Field, error) {
+	var (
+		unknown  []Field
+		seen     uint64 // fields found, if there are at most 64 fields
+		seenMany []bool // fields found, otherwise
+	)
+	if len(t.required) > 0 && len(t.fields) > 64 {
+		seenMany = make([]bool, len(t.fields))
+	}
+
+	for _, field := range w.GetStruct().Fields {
+		i := t.index(field.ID)
+		if i < 0 {
+			if keepUnknown {
+				v, err := Detach(field.Value)
+				if err != nil {
+					return unknown, err
+				}
+				unknown = append(unknown, Field{ID: field.ID, Value: v})
+			}
+			continue
+		}
+		if field.Value.Type() != t.fields[i].Type {
+			continue
+		}
+
+		if err := set(i, field.Value); err != nil {
+			ObserveDecodeError(t.name, t.fields[i].Name, DecodeErrorInvalidValue)
+			return unknown, err
+		}
+
+		if seenMany != nil {
+			seenMany[i] = true
+		} else {
+			seen |= 1 << uint(i)
+		}
+	}
+
+	for _, i := range t.required {
+		var ok bool
+		if seenMany != nil {
+			ok = seenMany[i]
+		} else {
+			ok = seen&(1<<uint(i)) != 0
+		}
+		if ok {
+			continue
+		}
+
+		f := t.fields[i]
+		ObserveDecodeError(t.name, f.Name, DecodeErrorMissingRequiredField)
+		return unknown, MissingRequiredFieldError{Struct: t.name, Field: f.Name, ID: f.ID}
+	}
+	return unknown, nil
+}
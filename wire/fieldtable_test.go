@@ -0,0 +1,170 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package wire
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldTableDecode(t *testing.T) {
+	table := NewFieldTable("User",
+		FieldInfo{ID: 3, Type: TBinary, Name: "Name", Required: true},
+		FieldInfo{ID: 1, Type: TI32, Name: "Age"},
+		FieldInfo{ID: 2, Type: TBinary, Name: "Email", Required: true},
+	)
+
+	decode := func(fields ...Field) (map[int]Value, error) {
+		got := make(map[int]Value)
+		err := table.Decode(NewValueStruct(Struct{Fields: fields}), func(i int, v Value) error {
+			got[i] = v
+			return nil
+		})
+		return got, err
+	}
+
+	t.Run("all fields", func(t *testing.T) {
+		got, err := decode(
+			Field{ID: 1, Value: NewValueI32(42)},
+			Field{ID: 2, Value: NewValueString("foo@example.com")},
+			Field{ID: 3, Value: NewValueString("foo")},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, map[int]Value{
+			0: NewValueString("foo"),
+			1: NewValueI32(42),
+			2: NewValueString("foo@example.com"),
+		}, got)
+	})
+
+	t.Run("unknown fields and mismatched types", func(t *testing.T) {
+		got, err := decode(
+			Field{ID: 1, Value: NewValueString("42")},
+			Field{ID: 2, Value: NewValueString("foo@example.com")},
+			Field{ID: 3, Value: NewValueString("foo")},
+			Field{ID: 4, Value: NewValueBool(true)},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, map[int]Value{
+			0: NewValueString("foo"),
+			2: NewValueString("foo@example.com"),
+		}, got)
+	})
+
+	t.Run("missing required fields", func(t *testing.T) {
+		var observed []string
+		defer SetErrorObserver(func(typeName, field string, kind DecodeErrorKind) {
+			observed = append(observed, typeName+"."+field+": "+kind.String())
+		})()
+
+		_, err := decode(Field{ID: 1, Value: NewValueI32(42)})
+		assert.Equal(t, MissingRequiredFieldError{Struct: "User", Field: "Name", ID: 3}, err)
+		assert.Equal(t, []string{"User.Name: missing_required_field"}, observed)
+	})
+
+	t.Run("set fails", func(t *testing.T) {
+		var observed []string
+		defer SetErrorObserver(func(typeName, field string, kind DecodeErrorKind) {
+			observed = append(observed, typeName+"."+field+": "+kind.String())
+		})()
+
+		giveErr := errors.New("great sadness")
+		err := table.Decode(
+			NewValueStruct(Struct{Fields: []Field{{ID: 2, Value: NewValueString("foo")}}}),
+			func(int, Value) error { return giveErr },
+		)
+		assert.Equal(t, giveErr, err)
+		assert.Equal(t, []string{"User.Email: invalid_value"}, observed)
+	})
+}
+
+func TestFieldTableManyFields(t *testing.T) {
+	// Enough fields for lookups to use binary search and for required
+	// fields to be tracked without a bit mask.
+	var fields []FieldInfo
+	for i := 0; i < 100; i++ {
+		fields = append(fields, FieldInfo{
+			ID:       int16(200 - 2*i),
+			Type:     TI32,
+			Name:     fmt.Sprintf("Field%d", i),
+			Required: i%10 == 0,
+		})
+	}
+	table := NewFieldTable("Big", fields...)
+
+	var all []Field
+	for _, f := range fields {
+		all = append(all, Field{ID: f.ID, Value: NewValueI32(int32(f.ID))})
+	}
+	// Fields that are not in the table.
+	all = append(all, Field{ID: 1, Value: NewValueI32(1)}, Field{ID: 201, Value: NewValueI32(201)})
+
+	got := make(map[int]int32)
+	err := table.Decode(NewValueStruct(Struct{Fields: all}), func(i int, v Value) error {
+		got[i] = v.GetI32()
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, got, len(fields))
+	for i, f := range fields {
+		assert.Equal(t, int32(f.ID), got[i], "field %d", i)
+	}
+
+	err = table.Decode(NewValueStruct(Struct{Fields: all[:55]}), func(int, Value) error {
+		return nil
+	})
+	assert.Equal(t, MissingRequiredFieldError{Struct: "Big", Field: "Field60", ID: 80}, err)
+}
+
+func TestFieldTableDecodeUnknown(t *testing.T) {
+	table := NewFieldTable("User",
+		FieldInfo{ID: 1, Type: TBinary, Name: "Name", Required: true},
+		FieldInfo{ID: 2, Type: TI32, Name: "Age"},
+	)
+
+	got := make(map[int]Value)
+	unknown, err := table.DecodeUnknown(NewValueStruct(Struct{Fields: []Field{
+		{ID: 4, Value: NewValueBool(true)},
+		{ID: 1, Value: NewValueString("foo")},
+		{ID: 2, Value: NewValueString("42")},
+		{ID: 3, Value: NewValueI64(42)},
+	}}), func(i int, v Value) error {
+		got[i] = v
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[int]Value{0: NewValueString("foo")}, got)
+	// Known fields with mismatched types are skipped rather than retained.
+	assert.Equal(t, []Field{
+		{ID: 4, Value: NewValueBool(true)},
+		{ID: 3, Value: NewValueI64(42)},
+	}, unknown)
+
+	unknown, err = table.DecodeUnknown(NewValueStruct(Struct{Fields: []Field{
+		{ID: 2, Value: NewValueI32(42)},
+	}}), func(int, Value) error { return nil })
+	assert.Equal(t, MissingRequiredFieldError{Struct: "User", Field: "Name", ID: 1}, err)
+	assert.Nil(t, unknown)
+}
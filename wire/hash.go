@@ -0,0 +1,154 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package wire
+
+import "math"
+
+const (
+	_fnvOffset64 = 14695981039346656037
+	_fnvPrime64  = 1099511628211
+)
+
+// fnv64 is an FNV-1a hash that is updated in place.
+type fnv64 uint64
+
+func newFNV64() fnv64 { return fnv64(_fnvOffset64) }
+
+func (h *fnv64) writeByte(b byte) {
+	*h = (*h ^ fnv64(b)) * _fnvPrime64
+}
+
+func (h *fnv64) writeUint64(v uint64) {
+	for i := uint(0); i < 64; i += 8 {
+		h.writeByte(byte(v >> (56 - i)))
+	}
+}
+
+// Hash returns a 64-bit hash of the given Value.
+//
+// Values which are equal according to ValuesAreEqual have the same hash. The
+// hash does not depend on the order in which the fields of structs or the
+// items of maps and sets are stored, and it does not change between
+// processes, so it may be persisted.
+func Hash(v Value) uint64 {
+	return hashValue(v)
+}
+
+// HashStruct returns a 64-bit hash of the given Struct, ignoring the fields
+// with the given IDs.
+//
+// HashStruct(s) is the same as Hash(NewValueStruct(s)).
+func HashStruct(s Struct, excludeIDs ...int16) uint64 {
+	h := newFNV64()
+	h.writeByte(byte(TStruct))
+	h.writeUint64(hashFields(s, excludeIDs))
+	return uint64(h)
+}
+
+func hashValue(v Value) uint64 {
+	h := newFNV64()
+	h.writeByte(byte(v.typ))
+
+	switch v.typ {
+	case TBool:
+		if v.GetBool() {
+			h.writeByte(1)
+		} else {
+			h.writeByte(0)
+		}
+	case TI8:
+		h.writeUint64(uint64(v.GetI8()))
+	case TI16:
+		h.writeUint64(uint64(v.GetI16()))
+	case TI32:
+		h.writeUint64(uint64(v.GetI32()))
+	case TI64:
+		h.writeUint64(uint64(v.GetI64()))
+	case TDouble:
+		f := v.GetDouble()
+		if f == 0 {
+			f = 0 // -0 == 0
+		}
+		h.writeUint64(math.Float64bits(f))
+	case TBinary:
+		h.writeUint64(uint64(len(v.tbinary)))
+		for _, b := range v.tbinary {
+			h.writeByte(b)
+		}
+	case TStruct:
+		h.writeUint64(hashFields(v.tstruct, nil))
+	case TMap:
+		m := v.GetMap()
+		h.writeByte(byte(m.KeyType()))
+		h.writeByte(byte(m.ValueType()))
+
+		// Items are combined with a commutative operation so that the hash
+		// does not depend on their order.
+		var sum uint64
+		_ = m.ForEach(func(item MapItem) error {
+			ih := newFNV64()
+			ih.writeUint64(hashValue(item.Key))
+			ih.writeUint64(hashValue(item.Value))
+			sum += uint64(ih)
+			return nil
+		})
+		h.writeUint64(sum)
+	case TSet:
+		s := v.GetSet()
+		h.writeByte(byte(s.ValueType()))
+
+		var sum uint64
+		_ = s.ForEach(func(item Value) error {
+			sum += hashValue(item)
+			return nil
+		})
+		h.writeUint64(sum)
+	case TList:
+		l := v.GetList()
+		h.writeByte(byte(l.ValueType()))
+		_ = l.ForEach(func(item Value) error {
+			h.writeUint64(hashValue(item))
+			return nil
+		})
+	}
+
+	return uint64(h)
+}
+
+// hashFields combines the hashes of the fields of the given struct, except
+// those with the given IDs, independent of their order.
+func hashFields(s Struct, excludeIDs []int16) uint64 {
+	var sum uint64
+fields:
+	for _, f := range s.Fields {
+		for _, id := range excludeIDs {
+			if f.ID == id {
+				continue fields
+			}
+		}
+
+		fh := newFNV64()
+		fh.writeUint64(uint64(uint16(f.ID)))
+		fh.writeUint64(hashValue(f.Value))
+		sum += uint64(fh)
+	}
+	return sum
+}
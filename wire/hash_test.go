@@ -0,0 +1,140 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package wire
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func vstruct(fields ...Field) Value {
+	return NewValueStruct(Struct{Fields: fields})
+}
+
+func TestHashEqualValues(t *testing.T) {
+	tests := []struct {
+		desc string
+		l, r Value
+	}{
+		{
+			desc: "struct fields in different orders",
+			l:    vstruct(Field{ID: 1, Value: vi32(1)}, Field{ID: 2, Value: vbinary("a")}),
+			r:    vstruct(Field{ID: 2, Value: vbinary("a")}, Field{ID: 1, Value: vi32(1)}),
+		},
+		{
+			desc: "set items in different orders",
+			l:    vset(TBinary, vbinary("1"), vbinary("2"), vbinary("3")),
+			r:    vset(TBinary, vbinary("3"), vbinary("1"), vbinary("2")),
+		},
+		{
+			desc: "map items in different orders",
+			l: vmap(
+				TI32, TSet,
+				vitem(vi32(1), vset(TI32, vi32(2), vi32(3))),
+				vitem(vi32(4), vset(TI32, vi32(5), vi32(6))),
+			),
+			r: vmap(
+				TI32, TSet,
+				vitem(vi32(4), vset(TI32, vi32(6), vi32(5))),
+				vitem(vi32(1), vset(TI32, vi32(3), vi32(2))),
+			),
+		},
+		{
+			desc: "string and binary",
+			l:    NewValueString("foo"),
+			r:    vbinary("foo"),
+		},
+		{
+			desc: "negative zero",
+			l:    NewValueDouble(0),
+			r:    NewValueDouble(math.Copysign(0, -1)),
+		},
+	}
+
+	for _, tt := range tests {
+		assert.True(t, ValuesAreEqual(tt.l, tt.r), tt.desc)
+		assert.Equal(t, Hash(tt.l), Hash(tt.r), tt.desc)
+	}
+}
+
+func TestHashDifferentValues(t *testing.T) {
+	values := []Value{
+		NewValueBool(false),
+		NewValueBool(true),
+		NewValueI8(1),
+		NewValueI16(1),
+		vi32(1),
+		vi32(-1),
+		NewValueI64(1),
+		NewValueDouble(1),
+		vbinary(""),
+		vbinary("a"),
+		vbinary("ab"),
+		vstruct(),
+		vstruct(Field{ID: 1, Value: vi32(1)}),
+		vstruct(Field{ID: 2, Value: vi32(1)}),
+		vlist(TI32),
+		vlist(TI32, vi32(1), vi32(2)),
+		vlist(TI32, vi32(2), vi32(1)),
+		vset(TI32),
+		vset(TI32, vi32(1)),
+		vset(TI64, NewValueI64(1)),
+		vmap(TI32, TI32),
+		vmap(TI32, TI32, vitem(vi32(1), vi32(2))),
+		vmap(TI32, TI32, vitem(vi32(2), vi32(1))),
+	}
+
+	seen := make(map[uint64]Value)
+	for _, v := range values {
+		h := Hash(v)
+		if other, ok := seen[h]; ok {
+			t.Errorf("%v and %v have the same hash %v", v, other, h)
+		}
+		seen[h] = v
+	}
+}
+
+func TestHashStruct(t *testing.T) {
+	s := Struct{Fields: []Field{
+		{ID: 1, Value: vi32(1)},
+		{ID: 2, Value: vbinary("hello")},
+		{ID: 3, Value: vlist(TI32, vi32(3))},
+	}}
+
+	assert.Equal(t, Hash(NewValueStruct(s)), HashStruct(s))
+	assert.NotEqual(t, HashStruct(s), HashStruct(s, 2))
+	assert.Equal(t,
+		HashStruct(Struct{Fields: []Field{s.Fields[0], s.Fields[2]}}),
+		HashStruct(s, 2),
+		"excluded fields must not affect the hash")
+	assert.Equal(t, Hash(vstruct()), HashStruct(s, 1, 2, 3))
+}
+
+func TestHashStable(t *testing.T) {
+	// Hashes may be persisted so they must not change between releases.
+	v := vstruct(
+		Field{ID: 1, Value: NewValueString("hello")},
+		Field{ID: 2, Value: vmap(TI32, TList, vitem(vi32(1), vlist(TBool, NewValueBool(true))))},
+	)
+	assert.Equal(t, uint64(0x65718583e2cb0f84), Hash(v))
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.23
+// +build go1.23
+
+package wire
+
+import (
+	"errors"
+	"iter"
+)
+
+// errStop aborts ForEach when the loop over an iterator ends early. It is
+// never surfaced to callers.
+var errStop = errors.New("stop iteration")
+
+// ValueListAll returns an iterator over the values of the given ValueList.
+// Lazily decoded lists are decoded as they are iterated.
+//
+// 	for v := range wire.ValueListAll(w.GetList()) {
+// 		...
+// 	}
+func ValueListAll(l ValueList) iter.Seq[Value] {
+	return func(yield func(Value) bool) {
+		_ = l.ForEach(func(v Value) error {
+			if !yield(v) {
+				return errStop
+			}
+			return nil
+		})
+	}
+}
+
+// MapItemListAll returns an iterator over the keys and values of the given
+// MapItemList. Lazily decoded maps are decoded as they are iterated.
+//
+// 	for k, v := range wire.MapItemListAll(w.GetMap()) {
+// 		...
+// 	}
+func MapItemListAll(l MapItemList) iter.Seq2[Value, Value] {
+	return func(yield func(Value, Value) bool) {
+		_ = l.ForEach(func(item MapItem) error {
+			if !yield(item.Key, item.Value) {
+				return errStop
+			}
+			return nil
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.23
+// +build go1.23
+
+package wire
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueListAll(t *testing.T) {
+	slice := []Value{
+		NewValueI32(1),
+		NewValueI32(2),
+		NewValueI32(3),
+	}
+	l := ValueListFromSlice(TI32, slice)
+
+	var got []Value
+	for v := range ValueListAll(l) {
+		got = append(got, v)
+	}
+	assert.Equal(t, slice, got)
+
+	got = nil
+	for v := range ValueListAll(l) {
+		got = append(got, v)
+		if len(got) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, slice[:2], got)
+}
+
+func TestMapItemListAll(t *testing.T) {
+	items := []MapItem{
+		{Key: NewValueString("foo"), Value: NewValueI32(1)},
+		{Key: NewValueString("bar"), Value: NewValueI32(2)},
+	}
+	l := MapItemListFromSlice(TBinary, TI32, items)
+
+	var got []MapItem
+	for k, v := range MapItemListAll(l) {
+		got = append(got, MapItem{Key: k, Value: v})
+	}
+	assert.Equal(t, items, got)
+
+	got = nil
+	for k, v := range MapItemListAll(l) {
+		got = append(got, MapItem{Key: k, Value: v})
+		break
+	}
+	assert.Equal(t, items[:1], got)
+}
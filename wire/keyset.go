@@ -0,0 +1,68 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package wire
+
+// KeySet detects duplicate keys in maps whose keys are not hashable in Go.
+// Generated code represents such maps as slices of key-value pairs and uses
+// a KeySet to give them the semantics of a map when they are encoded and
+// decoded.
+//
+// Keys are compared with ValuesAreEqual. The KeySet keeps copies of the
+// keys so lists that were decoded lazily may be closed after their keys are
+// added.
+type KeySet struct {
+	byHash map[uint64][]int
+	keys   []Value
+}
+
+// NewKeySet builds a KeySet with room for the given number of keys.
+func NewKeySet(size int) *KeySet {
+	return &KeySet{
+		byHash: make(map[uint64][]int, size),
+		keys:   make([]Value, 0, size),
+	}
+}
+
+// Add records the given key. It returns the position of the key among the
+// distinct keys added to the set so far, and whether an equal key had
+// already been added, in which case the position is that of the earlier
+// key.
+//
+// An error is returned if the key contains lazily decoded lists which could
+// not be read.
+func (s *KeySet) Add(key Value) (index int, duplicate bool, err error) {
+	key, err = Detach(key)
+	if err != nil {
+		return 0, false, err
+	}
+
+	h := Hash(key)
+	for _, i := range s.byHash[h] {
+		if ValuesAreEqual(s.keys[i], key) {
+			return i, true, nil
+		}
+	}
+
+	index = len(s.keys)
+	s.keys = append(s.keys, key)
+	s.byHash[h] = append(s.byHash[h], index)
+	return index, false, nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package wire
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySet(t *testing.T) {
+	set := func(xs ...int32) Value {
+		var values []Value
+		for _, x := range xs {
+			values = append(values, NewValueI32(x))
+		}
+		return NewValueSet(ValueListFromSlice(TI32, values))
+	}
+
+	keys := NewKeySet(0)
+	add := func(v Value) (int, bool) {
+		i, dup, err := keys.Add(v)
+		require.NoError(t, err)
+		return i, dup
+	}
+
+	i, dup := add(set(1, 2))
+	assert.Equal(t, 0, i)
+	assert.False(t, dup)
+
+	i, dup = add(set(3))
+	assert.Equal(t, 1, i)
+	assert.False(t, dup)
+
+	// Sets are equal regardless of the order of their items.
+	i, dup = add(set(2, 1))
+	assert.Equal(t, 0, i)
+	assert.True(t, dup)
+
+	i, dup = add(NewValueStruct(Struct{Fields: []Field{{ID: 1, Value: set(3)}}}))
+	assert.Equal(t, 2, i)
+	assert.False(t, dup)
+
+	i, dup = add(NewValueStruct(Struct{Fields: []Field{{ID: 1, Value: set(3)}}}))
+	assert.Equal(t, 2, i)
+	assert.True(t, dup)
+}
+
+// closingValueList is a ValueList which may not be read after it has been
+// closed, like lists that were decoded lazily.
+type closingValueList struct {
+	ValueList
+
+	closed bool
+}
+
+func (l *closingValueList) ForEach(f func(Value) error) error {
+	if l.closed {
+		return errors.New("list is closed")
+	}
+	return l.ValueList.ForEach(f)
+}
+
+func (l *closingValueList) Close() { l.closed = true }
+
+func TestKeySetClosedKeys(t *testing.T) {
+	newKey := func() (Value, *closingValueList) {
+		l := &closingValueList{ValueList: ValueListFromSlice(TI32, []Value{NewValueI32(1)})}
+		return NewValueList(l), l
+	}
+
+	keys := NewKeySet(2)
+	k1, l1 := newKey()
+	_, dup, err := keys.Add(k1)
+	require.NoError(t, err)
+	assert.False(t, dup)
+	l1.Close()
+
+	k2, _ := newKey()
+	i, dup, err := keys.Add(k2)
+	require.NoError(t, err)
+	assert.True(t, dup)
+	assert.Equal(t, 0, i)
+
+	_, _, err = keys.Add(k1)
+	assert.EqualError(t, err, "list is closed")
+}
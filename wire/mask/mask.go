@@ -0,0 +1,255 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package mask strips or retains fields of Thrift structs at the wire level,
+// without decoding them into generated types.
+//
+// Fields are identified by paths of field IDs, starting at the outermost
+// struct. Paths pass through lists, sets, and the values of maps, applying to
+// every struct in the collection.
+//
+// 	// struct User {
+// 	// 	1: required string name
+// 	// 	2: optional string email
+// 	// 	3: optional list<Address> addresses
+// 	// }
+// 	//
+// 	// struct Address {
+// 	// 	1: optional string street
+// 	// 	2: optional string city
+// 	// }
+// 	m := mask.New(mask.Path{2}, mask.Path{3, 1})
+//
+// 	// Drops email and the street of every address, leaving everything else.
+// 	redacted, err := m.Strip(v)
+//
+// 	// Keeps only email and the street of every address.
+// 	partial, err := m.Retain(v)
+//
+// StripEncoded and RetainEncoded do the same for structs encoded with the
+// Binary protocol, which is useful for logging middleware that must redact
+// sensitive fields of requests it cannot decode.
+package mask
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+)
+
+// Path identifies a field by the IDs of the fields leading to it from the
+// outermost struct.
+type Path []int16
+
+// ParsePath parses a path of dot-separated field IDs, for example "3.1".
+func ParsePath(s string) (Path, error) {
+	parts := strings.Split(s, ".")
+	p := make(Path, len(parts))
+	for i, part := range parts {
+		id, err := strconv.ParseInt(part, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field path %q: %q is not a field ID", s, part)
+		}
+		p[i] = int16(id)
+	}
+	return p, nil
+}
+
+func (p Path) String() string {
+	parts := make([]string, len(p))
+	for i, id := range p {
+		parts[i] = strconv.Itoa(int(id))
+	}
+	return strings.Join(parts, ".")
+}
+
+// Mask is a set of field paths. Masks are immutable and safe for concurrent
+// use.
+type Mask struct {
+	root *node
+}
+
+// node is a field in a Mask.
+type node struct {
+	// Whether a path ends at this field. If so, the field is stripped or
+	// retained in its entirety.
+	leaf bool
+
+	children map[int16]*node
+}
+
+// New builds a Mask from the given paths. A path that is a prefix of another
+// path takes precedence over it.
+func New(paths ...Path) Mask {
+	root := &node{}
+	for _, p := range paths {
+		if len(p) == 0 {
+			continue
+		}
+
+		n := root
+		for _, id := range p {
+			if n.leaf {
+				break
+			}
+			if n.children == nil {
+				n.children = make(map[int16]*node)
+			}
+			child, ok := n.children[id]
+			if !ok {
+				child = &node{}
+				n.children[id] = child
+			}
+			n = child
+		}
+		n.leaf = true
+		n.children = nil
+	}
+	return Mask{root: root}
+}
+
+// Strip returns a copy of the given struct without the fields in the mask.
+func (m Mask) Strip(v wire.Value) (wire.Value, error) {
+	return m.apply(v, false)
+}
+
+// Retain returns a copy of the given struct with only the fields in the
+// mask.
+func (m Mask) Retain(v wire.Value) (wire.Value, error) {
+	return m.apply(v, true)
+}
+
+// StripEncoded is the same as Strip for a struct encoded with the Binary
+// protocol.
+func (m Mask) StripEncoded(b []byte) ([]byte, error) {
+	return m.applyEncoded(b, false)
+}
+
+// RetainEncoded is the same as Retain for a struct encoded with the Binary
+// protocol.
+func (m Mask) RetainEncoded(b []byte) ([]byte, error) {
+	return m.applyEncoded(b, true)
+}
+
+func (m Mask) apply(v wire.Value, retain bool) (wire.Value, error) {
+	if v.Type() != wire.TStruct {
+		return v, fmt.Errorf("mask: cannot apply to a %v, expected a struct", v.Type())
+	}
+
+	root := m.root
+	if root == nil {
+		root = &node{}
+	}
+	return root.apply(v, retain), nil
+}
+
+func (m Mask) applyEncoded(b []byte, retain bool) ([]byte, error) {
+	v, err := protocol.Binary.Decode(bytes.NewReader(b), wire.TStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err = m.apply(v, retain)
+	if err != nil {
+		return nil, err
+	}
+
+	var buff bytes.Buffer
+	if err := protocol.Binary.Encode(v, &buff); err != nil {
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
+
+// apply strips or retains the children of this node from the given value.
+// Values other than structs and collections of structs are returned as-is.
+func (n *node) apply(v wire.Value, retain bool) wire.Value {
+	switch v.Type() {
+	case wire.TStruct:
+		return wire.NewValueStruct(n.applyStruct(v.GetStruct(), retain))
+	case wire.TList:
+		return wire.NewValueList(n.applyList(v.GetList(), retain))
+	case wire.TSet:
+		return wire.NewValueSet(n.applyList(v.GetSet(), retain))
+	case wire.TMap:
+		return wire.NewValueMap(n.applyMap(v.GetMap(), retain))
+	default:
+		return v
+	}
+}
+
+func (n *node) applyStruct(s wire.Struct, retain bool) wire.Struct {
+	fields := make([]wire.Field, 0, len(s.Fields))
+	for _, f := range s.Fields {
+		child, ok := n.children[f.ID]
+		switch {
+		case !ok:
+			if retain {
+				continue
+			}
+		case child.leaf:
+			if !retain {
+				continue
+			}
+		default:
+			f.Value = child.apply(f.Value, retain)
+		}
+		fields = append(fields, f)
+	}
+	return wire.Struct{Fields: fields}
+}
+
+func (n *node) applyList(l wire.ValueList, retain bool) wire.ValueList {
+	if !isContainer(l.ValueType()) {
+		return l
+	}
+
+	items := wire.ValueListToSlice(l)
+	for i, item := range items {
+		items[i] = n.apply(item, retain)
+	}
+	return wire.ValueListFromSlice(l.ValueType(), items)
+}
+
+func (n *node) applyMap(m wire.MapItemList, retain bool) wire.MapItemList {
+	if !isContainer(m.ValueType()) {
+		return m
+	}
+
+	items := wire.MapItemListToSlice(m)
+	for i, item := range items {
+		items[i].Value = n.apply(item.Value, retain)
+	}
+	return wire.MapItemListFromSlice(m.KeyType(), m.ValueType(), items)
+}
+
+// isContainer returns true if values of the given type may contain structs.
+func isContainer(t wire.Type) bool {
+	switch t {
+	case wire.TStruct, wire.TList, wire.TSet, wire.TMap:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mask
+
+import (
+	"bytes"
+	"testing"
+
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func vstruct(fs ...wire.Field) wire.Value {
+	return wire.NewValueStruct(wire.Struct{Fields: fs})
+}
+
+func vfield(id int16, v wire.Value) wire.Field {
+	return wire.Field{ID: id, Value: v}
+}
+
+func vstring(s string) wire.Value {
+	return wire.NewValueString(s)
+}
+
+// address builds an Address{1: street, 2: city}.
+func address(street, city string) wire.Value {
+	return vstruct(vfield(1, vstring(street)), vfield(2, vstring(city)))
+}
+
+// user builds a User{1: name, 2: email, 3: list<Address>, 4: map<string, Address>}.
+func user() wire.Value {
+	return vstruct(
+		vfield(1, vstring("jane")),
+		vfield(2, vstring("jane@example.com")),
+		vfield(3, wire.NewValueList(wire.ValueListFromSlice(wire.TStruct, []wire.Value{
+			address("1 Main St", "Springfield"),
+			address("2 Elm St", "Shelbyville"),
+		}))),
+		vfield(4, wire.NewValueMap(wire.MapItemListFromSlice(wire.TBinary, wire.TStruct, []wire.MapItem{
+			{Key: vstring("home"), Value: address("1 Main St", "Springfield")},
+		}))),
+	)
+}
+
+// userField returns the field of user() at the given index.
+func userField(i int) wire.Field {
+	u := user()
+	return u.GetStruct().Fields[i]
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		give      string
+		want      Path
+		wantError string
+	}{
+		{give: "1", want: Path{1}},
+		{give: "3.1", want: Path{3, 1}},
+		{give: "-1.2", want: Path{-1, 2}},
+		{give: "", wantError: `invalid field path "": "" is not a field ID`},
+		{give: "1.email", wantError: `invalid field path "1.email": "email" is not a field ID`},
+		{give: "40000", wantError: `"40000" is not a field ID`},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePath(tt.give)
+		if tt.wantError != "" {
+			if assert.Error(t, err, tt.give) {
+				assert.Contains(t, err.Error(), tt.wantError, tt.give)
+			}
+			continue
+		}
+		if assert.NoError(t, err, tt.give) {
+			assert.Equal(t, tt.want, got, tt.give)
+			assert.Equal(t, tt.give, got.String())
+		}
+	}
+}
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		desc       string
+		paths      []Path
+		wantStrip  wire.Value
+		wantRetain wire.Value
+	}{
+		{
+			desc:       "empty",
+			wantStrip:  user(),
+			wantRetain: vstruct(),
+		},
+		{
+			desc:      "top-level field",
+			paths:     []Path{{2}},
+			wantStrip: vstruct(userField(0), userField(2), userField(3)),
+			wantRetain: vstruct(
+				vfield(2, vstring("jane@example.com")),
+			),
+		},
+		{
+			desc:  "through list",
+			paths: []Path{{3, 1}},
+			wantStrip: vstruct(
+				vfield(1, vstring("jane")),
+				vfield(2, vstring("jane@example.com")),
+				vfield(3, wire.NewValueList(wire.ValueListFromSlice(wire.TStruct, []wire.Value{
+					vstruct(vfield(2, vstring("Springfield"))),
+					vstruct(vfield(2, vstring("Shelbyville"))),
+				}))),
+				userField(3),
+			),
+			wantRetain: vstruct(
+				vfield(3, wire.NewValueList(wire.ValueListFromSlice(wire.TStruct, []wire.Value{
+					vstruct(vfield(1, vstring("1 Main St"))),
+					vstruct(vfield(1, vstring("2 Elm St"))),
+				}))),
+			),
+		},
+		{
+			desc:  "through map values",
+			paths: []Path{{4, 2}, {1}},
+			wantStrip: vstruct(
+				userField(1),
+				userField(2),
+				vfield(4, wire.NewValueMap(wire.MapItemListFromSlice(wire.TBinary, wire.TStruct, []wire.MapItem{
+					{Key: vstring("home"), Value: vstruct(vfield(1, vstring("1 Main St")))},
+				}))),
+			),
+			wantRetain: vstruct(
+				vfield(1, vstring("jane")),
+				vfield(4, wire.NewValueMap(wire.MapItemListFromSlice(wire.TBinary, wire.TStruct, []wire.MapItem{
+					{Key: vstring("home"), Value: vstruct(vfield(2, vstring("Springfield")))},
+				}))),
+			),
+		},
+		{
+			desc:       "prefix takes precedence",
+			paths:      []Path{{3, 1}, {3}, {3, 2}},
+			wantStrip:  vstruct(userField(0), userField(1), userField(3)),
+			wantRetain: vstruct(userField(2)),
+		},
+		{
+			desc:       "path into a primitive",
+			paths:      []Path{{1, 5}},
+			wantStrip:  user(),
+			wantRetain: vstruct(vfield(1, vstring("jane"))),
+		},
+		{
+			desc:       "missing field",
+			paths:      []Path{{10}},
+			wantStrip:  user(),
+			wantRetain: vstruct(),
+		},
+	}
+
+	for _, tt := range tests {
+		m := New(tt.paths...)
+
+		got, err := m.Strip(user())
+		if assert.NoError(t, err, tt.desc) {
+			assert.True(t, wire.ValuesAreEqual(tt.wantStrip, got),
+				"%v: Strip: expected %v, got %v", tt.desc, tt.wantStrip, got)
+		}
+
+		got, err = m.Retain(user())
+		if assert.NoError(t, err, tt.desc) {
+			assert.True(t, wire.ValuesAreEqual(tt.wantRetain, got),
+				"%v: Retain: expected %v, got %v", tt.desc, tt.wantRetain, got)
+		}
+	}
+}
+
+func TestMaskNotStruct(t *testing.T) {
+	_, err := New(Path{1}).Strip(vstring("foo"))
+	if assert.Error(t, err) {
+		assert.Equal(t, "mask: cannot apply to a TBinary, expected a struct", err.Error())
+	}
+
+	var m Mask
+	got, err := m.Retain(user())
+	require.NoError(t, err)
+	assert.True(t, wire.ValuesAreEqual(vstruct(), got), "zero Mask retains nothing")
+}
+
+func TestMaskEncoded(t *testing.T) {
+	var buff bytes.Buffer
+	require.NoError(t, protocol.Binary.Encode(user(), &buff))
+
+	m := New(Path{2}, Path{3, 1})
+
+	stripped, err := m.StripEncoded(buff.Bytes())
+	require.NoError(t, err)
+	v, err := protocol.Binary.Decode(bytes.NewReader(stripped), wire.TStruct)
+	require.NoError(t, err)
+	want := vstruct(
+		vfield(1, vstring("jane")),
+		vfield(3, wire.NewValueList(wire.ValueListFromSlice(wire.TStruct, []wire.Value{
+			vstruct(vfield(2, vstring("Springfield"))),
+			vstruct(vfield(2, vstring("Shelbyville"))),
+		}))),
+		userField(3),
+	)
+	assert.True(t, wire.ValuesAreEqual(want, v), "expected %v, got %v", want, v)
+
+	retained, err := m.RetainEncoded(buff.Bytes())
+	require.NoError(t, err)
+	v, err = protocol.Binary.Decode(bytes.NewReader(retained), wire.TStruct)
+	require.NoError(t, err)
+	want = vstruct(
+		vfield(2, vstring("jane@example.com")),
+		vfield(3, wire.NewValueList(wire.ValueListFromSlice(wire.TStruct, []wire.Value{
+			vstruct(vfield(1, vstring("1 Main St"))),
+			vstruct(vfield(1, vstring("2 Elm St"))),
+		}))),
+	)
+	assert.True(t, wire.ValuesAreEqual(want, v), "expected %v, got %v", want, v)
+
+	_, err = m.StripEncoded([]byte{0xff})
+	assert.Error(t, err, "invalid payloads must fail")
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package wire
+
+import "sync/atomic"
+
+// DecodeErrorKind classifies the errors reported to an ErrorObserver.
+type DecodeErrorKind int
+
+const (
+	// DecodeErrorInvalidValue indicates that the value of a field could not
+	// be decoded. This includes failures to decode nested structs, so a
+	// malformed nested struct is reported once for every struct enclosing
+	// it.
+	DecodeErrorInvalidValue DecodeErrorKind = iota + 1
+
+	// DecodeErrorMissingRequiredField indicates that a required field was
+	// absent.
+	DecodeErrorMissingRequiredField
+
+	// DecodeErrorInvalidUnion indicates that a union did not have exactly
+	// one field set. The field name is empty for this kind.
+	DecodeErrorInvalidUnion
+)
+
+func (k DecodeErrorKind) String() string {
+	switch k {
+	case DecodeErrorInvalidValue:
+		return "invalid_value"
+	case DecodeErrorMissingRequiredField:
+		return "missing_required_field"
+	case DecodeErrorInvalidUnion:
+		return "invalid_union"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrorObserver is notified when the FromWire method of a generated type
+// fails. It receives the name of the generated Go type, the name of the
+// offending field, and the kind of failure.
+//
+// Observers are called synchronously by FromWire and must be safe for
+// concurrent use.
+type ErrorObserver func(typeName, field string, kind DecodeErrorKind)
+
+type errorObserverHolder struct{ Observe ErrorObserver }
+
+var _errorObserver atomic.Value // errorObserverHolder
+
+// SetErrorObserver sets the ErrorObserver notified by all generated types
+// and returns a function which restores the previous observer. Passing nil
+// disables observation.
+//
+// 	restore := wire.SetErrorObserver(func(typeName, field string, kind wire.DecodeErrorKind) {
+// 		decodeErrors.Tagged(map[string]string{
+// 			"type": typeName, "field": field, "kind": kind.String(),
+// 		}).Inc(1)
+// 	})
+// 	defer restore()
+func SetErrorObserver(o ErrorObserver) (restore func()) {
+	prev := loadErrorObserver()
+	_errorObserver.Store(errorObserverHolder{Observe: o})
+	return func() { _errorObserver.Store(errorObserverHolder{Observe: prev}) }
+}
+
+func loadErrorObserver() ErrorObserver {
+	h, _ := _errorObserver.Load().(errorObserverHolder)
+	return h.Observe
+}
+
+// ObserveDecodeError notifies the ErrorObserver, if any, of a decoding
+// failure. This is called by generated code and should not be called
+// directly.
+func ObserveDecodeError(typeName, field string, kind DecodeErrorKind) {
+	if o := loadErrorObserver(); o != nil {
+		o(typeName, field, kind)
+	}
+}
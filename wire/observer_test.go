@@ -0,0 +1,61 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package wire
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetErrorObserver(t *testing.T) {
+	// No observer is set by default.
+	ObserveDecodeError("Foo", "Bar", DecodeErrorInvalidValue)
+
+	var outer, inner []string
+	restoreOuter := SetErrorObserver(func(typeName, field string, kind DecodeErrorKind) {
+		outer = append(outer, typeName+"."+field+": "+kind.String())
+	})
+
+	ObserveDecodeError("Foo", "Bar", DecodeErrorInvalidValue)
+
+	restoreInner := SetErrorObserver(func(typeName, field string, kind DecodeErrorKind) {
+		inner = append(inner, typeName+"."+field+": "+kind.String())
+	})
+	ObserveDecodeError("Foo", "Baz", DecodeErrorMissingRequiredField)
+	restoreInner()
+
+	ObserveDecodeError("Qux", "", DecodeErrorInvalidUnion)
+	restoreOuter()
+
+	ObserveDecodeError("Foo", "Bar", DecodeErrorInvalidValue)
+
+	assert.Equal(t, []string{
+		"Foo.Bar: invalid_value",
+		"Qux.: invalid_union",
+	}, outer)
+	assert.Equal(t, []string{"Foo.Baz: missing_required_field"}, inner)
+}
+
+func TestDecodeErrorKindString(t *testing.T) {
+	assert.Equal(t, "unknown", DecodeErrorKind(0).String())
+	assert.Equal(t, "invalid_value", DecodeErrorInvalidValue.String())
+}
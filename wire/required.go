@@ -0,0 +1,54 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package wire
+
+import "fmt"
+
+// MissingRequiredFieldError is returned by the FromWire method of generated
+// types when a required field is absent from the decoded struct. This
+// usually indicates that the peer sent a malformed payload.
+type MissingRequiredFieldError struct {
+	// Names of the generated Go struct and of the missing field.
+	Struct, Field string
+
+	// ID of the missing field.
+	ID int16
+}
+
+func (e MissingRequiredFieldError) Error() string {
+	return fmt.Sprintf("field %s of %s is required", e.Field, e.Struct)
+}
+
+// NilRequiredFieldError is returned by the ToWire method of generated types
+// when a required field that is a reference type has not been set, and by
+// the Build method of generated builders when a required field has not been
+// set. This indicates a bug in the code which built the value.
+type NilRequiredFieldError struct {
+	// Names of the generated Go struct and of the unset field.
+	Struct, Field string
+
+	// ID of the unset field.
+	ID int16
+}
+
+func (e NilRequiredFieldError) Error() string {
+	return fmt.Sprintf("field %s of %s is required but was not set", e.Field, e.Struct)
+}